@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/audit"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+// auditFollowPollInterval controls how often 'agenc audit tail -f' checks
+// the audit log for new entries.
+const auditFollowPollInterval = 500 * time.Millisecond
+
+var auditTailLinesFlag int
+var auditTailFollowFlag bool
+var auditSearchSinceFlag string
+var auditSearchSourceFlag string
+var auditSearchActorFlag string
+var auditSearchLimitFlag int
+
+var auditCmd = &cobra.Command{
+	Use:   auditCmdStr,
+	Short: "Inspect the audit log of state-changing commands and API calls",
+	Long: fmt.Sprintf(`Inspect the audit log: an append-only record of every state-changing
+'agenc' command and server API call, so you can see what agents with 'agenc'
+in their PATH have done.
+
+Each entry records when it happened, its source (cli or api), the actor, the
+command or API route, and its parameters. Actors are:
+
+  %-8s an agent: the command ran inside a mission (AGENC_MISSION_UUID set)
+  %-8s a person at a terminal
+  %-8s a non-interactive process such as a cron job or shell script
+  %-8s an API client using a bearer token ('agenc config token create')
+  %-8s an API call over the server socket from outside any mission
+
+Read-only commands (ls, inspect, logs, ...) and the wrapper's own
+bookkeeping calls (heartbeats, prompt and tool-run events) are not recorded.
+Parameters that look like secrets (password, token, secret, ...) are
+redacted. A CLI command and the API calls it made share an invocation ID.
+
+The log is JSONL, one file per day, in $AGENC_DIRPATH/%s/.`,
+		audit.ActorMission, audit.ActorTTY, audit.ActorScript, audit.ActorToken, audit.ActorUser,
+		config.AuditDirname,
+	),
+}
+
+var auditTailCmd = &cobra.Command{
+	Use:   tailCmdStr,
+	Short: "Print the most recent audit entries",
+	Long: `Print the most recent audit entries, oldest first. Use -f/--follow to keep
+printing new entries as they are written (Ctrl-C to stop). With --output
+json, follow mode prints one JSON object per line.
+
+Examples:
+  agenc audit tail
+  agenc audit tail -n 100
+  agenc audit tail -f -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAuditTail,
+}
+
+var auditSearchCmd = &cobra.Command{
+	Use:   searchCmdStr + " [query]",
+	Short: "Search the audit log",
+	Long: `Search the audit log, oldest first. query matches case-insensitively
+anywhere in an entry: the command or route, parameters, actor, and error.
+
+Examples:
+  agenc audit search "mission rm"
+  agenc audit search --actor 2b4c8f1a --since 2026-10-01
+  agenc audit search DELETE --source api`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAuditSearch,
+}
+
+func init() {
+	auditTailCmd.Flags().IntVarP(&auditTailLinesFlag, auditLinesFlagName, "n", 20, "number of entries to print")
+	auditTailCmd.Flags().BoolVarP(&auditTailFollowFlag, followFlagName, "f", false, "keep printing new entries as they are written")
+	auditCmd.AddCommand(auditTailCmd)
+
+	auditSearchCmd.Flags().StringVar(&auditSearchSinceFlag, auditSinceFlagName, "", "only search entries on or after this time (YYYY-MM-DD or RFC3339)")
+	auditSearchCmd.Flags().StringVar(&auditSearchSourceFlag, auditSourceFlagName, "", "only search entries from this source (cli or api)")
+	auditSearchCmd.Flags().StringVar(&auditSearchActorFlag, auditActorFlagName, "", "only search entries by this actor: a kind (mission, tty, ...), user, token name, or mission ID prefix")
+	auditSearchCmd.Flags().IntVar(&auditSearchLimitFlag, timelineLimitFlagName, 200, "show only the most recent N matches (0 for all)")
+	auditCmd.AddCommand(auditSearchCmd)
+
+	rootCmd.AddCommand(auditCmd)
+}
+
+func runAuditTail(cmd *cobra.Command, args []string) error {
+	if auditTailLinesFlag < 0 {
+		return stacktrace.NewError("--%s must be zero or greater", auditLinesFlagName)
+	}
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get agenc directory path")
+	}
+
+	entries, err := readRecentAuditEntries(agencDirpath, auditTailLinesFlag)
+	if err != nil {
+		return err
+	}
+	if !auditTailFollowFlag {
+		return printAuditEntries(entries)
+	}
+
+	for _, entry := range entries {
+		printAuditEntryLine(entry)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := audit.Follow(ctx, agencDirpath, auditFollowPollInterval, printAuditEntryLine); err != nil {
+		return stacktrace.Propagate(err, "failed to follow the audit log")
+	}
+	return nil
+}
+
+func runAuditSearch(cmd *cobra.Command, args []string) error {
+	if auditSearchLimitFlag < 0 {
+		return stacktrace.NewError("--%s must be zero or greater", timelineLimitFlagName)
+	}
+	if auditSearchSourceFlag != "" && auditSearchSourceFlag != audit.SourceCLI && auditSearchSourceFlag != audit.SourceAPI {
+		return stacktrace.NewError("invalid --%s value %q: must be %q or %q", auditSourceFlagName, auditSearchSourceFlag, audit.SourceCLI, audit.SourceAPI)
+	}
+	var since time.Time
+	if auditSearchSinceFlag != "" {
+		var err error
+		if since, err = parseTimeFlag(auditSearchSinceFlag, true); err != nil {
+			return stacktrace.NewError("invalid --%s value %q: %s", auditSinceFlagName, auditSearchSinceFlag, err)
+		}
+	}
+	query := ""
+	if len(args) == 1 {
+		query = args[0]
+	}
+
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get agenc directory path")
+	}
+	entries, err := audit.Read(agencDirpath, since)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read the audit log")
+	}
+
+	var matches []audit.Entry
+	for _, entry := range entries {
+		if auditEntryMatches(entry, query, auditSearchSourceFlag, auditSearchActorFlag) {
+			matches = append(matches, entry)
+		}
+	}
+	if auditSearchLimitFlag > 0 && len(matches) > auditSearchLimitFlag {
+		matches = matches[len(matches)-auditSearchLimitFlag:]
+	}
+	return printAuditEntries(matches)
+}
+
+// readRecentAuditEntries returns the last n audit entries, reading day files
+// from the newest back until it has enough.
+func readRecentAuditEntries(agencDirpath string, n int) ([]audit.Entry, error) {
+	filepaths, err := audit.ListFiles(agencDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read the audit log")
+	}
+	var entries []audit.Entry
+	for i := len(filepaths) - 1; i >= 0 && len(entries) < n; i-- {
+		fileEntries, err := audit.ReadFile(filepaths[i])
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to read the audit log")
+		}
+		entries = append(fileEntries, entries...)
+	}
+	if len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries, nil
+}
+
+// auditEntryMatches applies the search filters to an entry. actor matches an
+// actor kind, user, or token name exactly, or a prefix of a mission ID.
+func auditEntryMatches(entry audit.Entry, query string, source string, actor string) bool {
+	if source != "" && entry.Source != source {
+		return false
+	}
+	if actor != "" {
+		a := entry.Actor
+		if a.Kind != actor && a.User != actor && a.Token != actor && (a.MissionID == "" || !strings.HasPrefix(a.MissionID, actor)) {
+			return false
+		}
+	}
+	return query == "" || entry.Matches(query)
+}
+
+// printAuditEntries prints entries as a table, or structured with --output.
+func printAuditEntries(entries []audit.Entry) error {
+	if isStructuredOutput() {
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+		return printStructured(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No audit entries.")
+		return nil
+	}
+	tbl := tableprinter.NewTable("TIME", "SOURCE", "ACTOR", "ACTION", "DETAILS")
+	for _, entry := range entries {
+		tbl.AddRow(
+			entry.Time.Local().Format("2006-01-02 15:04:05"),
+			entry.Source,
+			entry.Actor.String(),
+			entry.Action,
+			formatAuditDetails(entry),
+		)
+	}
+	tbl.Print()
+	return nil
+}
+
+// printAuditEntryLine prints one entry as a line, or as one JSON object per
+// line with --output json, for follow mode.
+func printAuditEntryLine(entry audit.Entry) {
+	if isStructuredOutput() {
+		if data, err := json.Marshal(entry); err == nil {
+			fmt.Println(string(data))
+		}
+		return
+	}
+	parts := []string{entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Source, entry.Actor.String(), entry.Action}
+	if details := formatAuditDetails(entry); details != "" {
+		parts = append(parts, details)
+	}
+	fmt.Println(strings.Join(parts, "  "))
+}
+
+// formatAuditDetails summarizes an entry on one line: a command's arguments
+// and flags, or an API call's path, status, and error.
+func formatAuditDetails(entry audit.Entry) string {
+	var parts []string
+	if entry.Source == audit.SourceAPI {
+		parts = append(parts, entry.Path, fmt.Sprintf("→ %d", entry.Status))
+		if entry.Error != "" {
+			parts = append(parts, entry.Error)
+		}
+		return strings.Join(parts, " ")
+	}
+
+	if args, ok := entry.Params["args"].([]any); ok {
+		for _, arg := range args {
+			parts = append(parts, fmt.Sprint(arg))
+		}
+	}
+	if flags, ok := entry.Params["flags"].(map[string]any); ok {
+		names := make([]string, 0, len(flags))
+		for name := range flags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("--%s=%v", name, flags[name]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"os"
+	"os/user"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/odyssey/agenc/internal/audit"
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// auditInvocationID identifies this CLI invocation in the audit log. It is
+// set once the invocation's entry is written and sent with every server
+// request (see serverClient) so the server's entries can be tied to it.
+var auditInvocationID string
+
+// auditReadOnlyCmdNames are commands left out of the audit log: those that
+// only read state, and hidden helpers that fzf pickers and Claude hooks run on
+// every keystroke or event. Anything else that runs is recorded.
+var auditReadOnlyCmdNames = map[string]bool{
+	lsCmdStr:             true,
+	getCmdStr:            true,
+	showCmdStr:           true,
+	inspectCmdStr:        true,
+	printCmdStr:          true,
+	statusCmdStr:         true,
+	statsCmdStr:          true,
+	historyCmdStr:        true,
+	diffCmdStr:           true,
+	browseCmdStr:         true,
+	searchCmdStr:         true,
+	conflictsCmdStr:      true,
+	promptsCmdStr:        true,
+	timelineCmdStr:       true,
+	transcriptCmdStr:     true,
+	queueCmdStr:          true,
+	logsCmdStr:           true,
+	tailCmdStr:           true,
+	openCmdStr:           true,
+	validateCmdStr:       true,
+	lintClaudeCmdStr:     true,
+	resolveMissionCmdStr: true,
+	eventsCmdStr:         true,
+	reportCmdStr:         true,
+	digestCmdStr:         true,
+	summaryCmdStr:        true,
+	dashboardCmdStr:      true,
+	paletteCmdStr:        true,
+	doctorCmdStr:         true,
+	versionCmdStr:        true,
+	completionCmdStr:     true,
+	primeCmdStr:          true,
+	discordCmdStr:        true,
+	starCmdStr:           true,
+	feedbackCmdStr:       true,
+	"help":               true,
+
+	"search-fzf":       true,
+	previewFzfCmdStr:   true,
+	"manage-fzf-input": true,
+	claudeUpdateCmdStr: true,
+	networkJailCmdStr:  true,
+}
+
+// recordCLIAudit appends an audit entry for a state-changing command before
+// it runs: the command path, its arguments, the flags it was given, and who
+// ran it. Failures to write are ignored so the audit log can never block a
+// command.
+func recordCLIAudit(cmd *cobra.Command, args []string) {
+	if !isAuditedCommand(cmd) {
+		return
+	}
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return
+	}
+
+	invocationID := uuid.New().String()
+	entry := audit.Entry{
+		Source:       audit.SourceCLI,
+		InvocationID: invocationID,
+		Actor:        cliAuditActor(),
+		Action:       cmd.CommandPath(),
+		Params:       cliAuditParams(cmd.Flags(), args),
+	}
+	if err := audit.Append(agencDirpath, entry); err != nil {
+		return
+	}
+	auditInvocationID = invocationID
+}
+
+// isAuditedCommand reports whether cmd changes state and so belongs in the
+// audit log. cobra's hidden completion commands are never recorded.
+func isAuditedCommand(cmd *cobra.Command) bool {
+	name := cmd.Name()
+	return !auditReadOnlyCmdNames[name] && name != cobra.ShellCompRequestCmd && name != cobra.ShellCompNoDescRequestCmd
+}
+
+// cliAuditActor attributes the invocation: the mission it runs in, a person
+// at a terminal, or a script.
+func cliAuditActor() audit.Actor {
+	actor := audit.Actor{Kind: audit.ActorScript}
+	if u, err := user.Current(); err == nil {
+		actor.User = u.Username
+	}
+	switch {
+	case os.Getenv(config.MissionUUIDEnvVar) != "":
+		actor.Kind = audit.ActorMission
+		actor.MissionID = os.Getenv(config.MissionUUIDEnvVar)
+	case isatty.IsTerminal(os.Stdin.Fd()):
+		actor.Kind = audit.ActorTTY
+	}
+	return actor
+}
+
+// cliAuditParams collects a command's positional arguments and the flags that
+// were set on the command line.
+func cliAuditParams(flags *pflag.FlagSet, args []string) map[string]any {
+	params := map[string]any{}
+	if len(args) > 0 {
+		params["args"] = args
+	}
+	setFlags := map[string]any{}
+	flags.Visit(func(flag *pflag.Flag) {
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			setFlags[flag.Name] = sliceValue.GetSlice()
+			return
+		}
+		setFlags[flag.Name] = flag.Value.String()
+	})
+	if len(setFlags) > 0 {
+		params["flags"] = setFlags
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
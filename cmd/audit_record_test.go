@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/odyssey/agenc/internal/audit"
+)
+
+func TestIsAuditedCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"new", true},
+		{"rm", true},
+		{"add", true},
+		{"ls", false},
+		{"inspect", false},
+		{"tail", false},
+		{cobra.ShellCompRequestCmd, false},
+	}
+	for _, tt := range tests {
+		if got := isAuditedCommand(&cobra.Command{Use: tt.name}); got != tt.expected {
+			t.Errorf("isAuditedCommand(%q) = %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestCLIAuditParams(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("prompt", "", "")
+	flags.Bool("force", false, "")
+	flags.StringSlice("tag", nil, "")
+	flags.Int("unset", 0, "")
+	if err := flags.Parse([]string{"--prompt", "fix it", "--force", "--tag", "a", "--tag", "b"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	params := cliAuditParams(flags, []string{"github.com/owner/repo"})
+
+	expected := map[string]any{
+		"args": []string{"github.com/owner/repo"},
+		"flags": map[string]any{
+			"prompt": "fix it",
+			"force":  "true",
+			"tag":    []string{"a", "b"},
+		},
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("cliAuditParams = %+v, expected %+v", params, expected)
+	}
+
+	if params := cliAuditParams(pflag.NewFlagSet("empty", pflag.ContinueOnError), nil); params != nil {
+		t.Errorf("expected nil params with no args or flags, got %+v", params)
+	}
+}
+
+func TestCLIAuditParams_RedactsEnvValues(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringArray(missionEnvFlagName, nil, "")
+	if err := flags.Parse([]string{"--env", "GITHUB_TOKEN=ghp_abc123", "--env", "DEBUG=1"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+
+	params := audit.Redact(cliAuditParams(flags, nil))
+
+	env := params["flags"].(map[string]any)[missionEnvFlagName]
+	expected := []string{"GITHUB_TOKEN=[redacted]", "DEBUG=[redacted]"}
+	if !reflect.DeepEqual(env, expected) {
+		t.Errorf("expected env values redacted, got %v", env)
+	}
+}
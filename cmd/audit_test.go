@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/odyssey/agenc/internal/audit"
+)
+
+func TestAuditEntryMatches(t *testing.T) {
+	entry := audit.Entry{
+		Source: audit.SourceCLI,
+		Actor:  audit.Actor{Kind: audit.ActorMission, MissionID: "2b4c8f1a-0000", User: "alice"},
+		Action: "agenc mission rm",
+	}
+	tests := []struct {
+		name     string
+		query    string
+		source   string
+		actor    string
+		expected bool
+	}{
+		{"no filters", "", "", "", true},
+		{"query", "mission rm", "", "", true},
+		{"query miss", "cron", "", "", false},
+		{"source", "", audit.SourceCLI, "", true},
+		{"source miss", "", audit.SourceAPI, "", false},
+		{"actor kind", "", "", audit.ActorMission, true},
+		{"actor user", "", "", "alice", true},
+		{"actor mission prefix", "", "", "2b4c", true},
+		{"actor miss", "", "", "bob", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auditEntryMatches(entry, tt.query, tt.source, tt.actor); got != tt.expected {
+				t.Errorf("auditEntryMatches = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatAuditDetails(t *testing.T) {
+	cliEntry := audit.Entry{
+		Source: audit.SourceCLI,
+		Params: map[string]any{
+			"args":  []any{"abc"},
+			"flags": map[string]any{"yes": "true", "force": "true"},
+		},
+	}
+	if got, expected := formatAuditDetails(cliEntry), "abc --force=true --yes=true"; got != expected {
+		t.Errorf("formatAuditDetails(cli) = %q, expected %q", got, expected)
+	}
+
+	apiEntry := audit.Entry{Source: audit.SourceAPI, Path: "/missions/abc", Status: 404, Error: "mission not found"}
+	if got, expected := formatAuditDetails(apiEntry), "/missions/abc → 404 mission not found"; got != expected {
+		t.Errorf("formatAuditDetails(api) = %q, expected %q", got, expected)
+	}
+}
@@ -10,22 +10,32 @@ const (
 	agencCmdStr = "agenc"
 
 	// Top-level commands
-	configCmdStr   = "config"
-	missionCmdStr  = "mission"
-	repoCmdStr     = "repo"
-	serverCmdStr   = "server"
-	discordCmdStr  = "discord"
-	tmuxCmdStr     = "tmux"
-	versionCmdStr  = "version"
-	loginCmdStr    = "login"
-	cronCmdStr     = "cron"
-	doctorCmdStr   = "doctor"
-	primeCmdStr    = "prime"
-	summaryCmdStr  = "summary"
-	starCmdStr     = "star"
-	feedbackCmdStr = "feedback"
-	sessionCmdStr  = "session"
-	stashCmdStr    = "stash"
+	configCmdStr     = "config"
+	missionCmdStr    = "mission"
+	repoCmdStr       = "repo"
+	serverCmdStr     = "server"
+	discordCmdStr    = "discord"
+	tmuxCmdStr       = "tmux"
+	versionCmdStr    = "version"
+	loginCmdStr      = "login"
+	cronCmdStr       = "cron"
+	doctorCmdStr     = "doctor"
+	primeCmdStr      = "prime"
+	summaryCmdStr    = "summary"
+	starCmdStr       = "star"
+	feedbackCmdStr   = "feedback"
+	sessionCmdStr    = "session"
+	stashCmdStr      = "stash"
+	dashboardCmdStr  = "dashboard"
+	completionCmdStr = "completion"
+	secretCmdStr     = "secret"
+	eventsCmdStr     = "events"
+	profileCmdStr    = "profile"
+	reportCmdStr     = "report"
+	projectCmdStr    = "project"
+	skillsCmdStr     = "skills"
+	auditCmdStr      = "audit"
+	digestCmdStr     = "digest"
 
 	notificationCmdStr = "notification"
 	showCmdStr         = "show"
@@ -52,10 +62,11 @@ const (
 	searchCmdStr = "search"
 
 	// Tmux subcommands
-	injectCmdStr         = "inject"
-	uninjectCmdStr       = "uninject"
-	paletteCmdStr        = "palette"
-	resolveMissionCmdStr = "resolve-mission"
+	injectCmdStr          = "inject"
+	uninjectCmdStr        = "uninject"
+	tmuxStatusSetupCmdStr = "setup"
+	paletteCmdStr         = "palette"
+	resolveMissionCmdStr  = "resolve-mission"
 
 	// Mission subcommands
 	newCmdStr          = "new"
@@ -70,8 +81,36 @@ const (
 	sendKeysCmdStr     = "send-keys"
 	draftCmdStr        = "draft"
 	rebuildCmdStr      = "rebuild"
+	tagCmdStr          = "tag"
+	statsCmdStr        = "stats"
+	exportCmdStr       = "export"
+	importCmdStr       = "import"
+	gcCmdStr           = "gc"
+	branchCmdStr       = "branch"
+	prCmdStr           = "pr"
+	queueCmdStr        = "queue"
+	repointCmdStr      = "repoint"
+	shareCmdStr        = "share"
+	summarizeCmdStr    = "summarize"
+	timelineCmdStr     = "timeline"
+	promptsCmdStr      = "prompts"
+	replayCmdStr       = "replay"
+	fromIssueCmdStr    = "from-issue"
+	transcriptCmdStr   = "transcript"
+	approveCmdStr      = "approve"
+	conflictsCmdStr    = "conflicts"
+	openCmdStr         = "open"
+	browseCmdStr       = "browse"
+	previewFzfCmdStr   = "preview-fzf"
+	networkJailCmdStr  = "net-jail"
+
+	// Audit subcommands
+	tailCmdStr = "tail"
 
 	// Config subcommands
+	tokenCmdStr          = "token"
+	createCmdStr         = "create"
+	revokeCmdStr         = "revoke"
 	initCmdStr           = "init"
 	getCmdStr            = "get"
 	setCmdStr            = "set"
@@ -80,11 +119,28 @@ const (
 	repoConfigCmdStr     = "repoConfig"
 	claudeMdCmdStr       = "claude-md"
 	settingsJsonCmdStr   = "settings-json"
+	validateCmdStr       = "validate"
+	lintClaudeCmdStr     = "lint-claude"
+	diffCmdStr           = "diff"
+	rollbackCmdStr       = "rollback"
+	shadowCmdStr         = "shadow"
+	remoteCmdStr         = "remote"
+	syncCmdStr           = "sync"
+
+	// Repo subcommands
+	syncGithubCmdStr = "sync-github"
+	forkCmdStr       = "fork"
+
+	// Profile subcommands
+	switchCmdStr = "switch"
 
 	// Server subcommands
-	startCmdStr   = "start"
-	restartCmdStr = "restart"
-	statusCmdStr  = "status"
+	startCmdStr     = "start"
+	restartCmdStr   = "restart"
+	statusCmdStr    = "status"
+	upgradeCmdStr   = "upgrade"
+	installCmdStr   = "install"
+	uninstallCmdStr = "uninstall"
 
 	// Cron subcommands
 	enableCmdStr  = "enable"
@@ -92,6 +148,7 @@ const (
 	runCmdStr     = "run"
 	logsCmdStr    = "logs"
 	historyCmdStr = "history"
+	atCmdStr      = "at"
 )
 
 // Centralized flag name strings for CLI flags. Use these constants in flag
@@ -100,25 +157,84 @@ const (
 
 const (
 	// mission new flags
-	cloneFlagName    = "clone"
-	promptFlagName   = "prompt"
-	blankFlagName    = "blank"
-	adjutantFlagName = "adjutant"
-	noFocusFlagName  = "no-focus"
+	cloneFlagName       = "clone"
+	promptFlagName      = "prompt"
+	blankFlagName       = "blank"
+	adjutantFlagName    = "adjutant"
+	noFocusFlagName     = "no-focus"
+	maxPromptsFlagName  = "max-prompts"
+	budgetUSDFlagName   = "budget-usd"
+	missionEnvFlagName  = "env"
+	sandboxFlagName     = "sandbox"
+	cloneModeFlagName   = "clone-mode"
+	missionPathFlagName = "path"
+	backendFlagName     = "backend"
+	historyFlagName     = "history"
+	missionGitFlagName  = "git"
+
+	// mission attach flags
+	splitFlagName = "split"
 
 	// mission reload flags
-	asyncFlagName = "async"
+	asyncFlagName    = "async"
+	staleFlagName    = "stale"
+	gracefulFlagName = "graceful"
 
 	// mission ls flags
 	allFlagName = "all"
+	tagFlagName = "tag"
+
+	// project flags, also used to scope mission new/ls, the dashboard, and
+	// cron config to a project
+	projectFlagName      = "project"
+	projectRepoFlagName  = "repo"
+	projectNotesFlagName = "notes"
+
+	// mission tag flags
+	removeFlagName = "remove"
 
-	// mission inspect flags
+	// mission logs flags
+	wrapperFlagName = "wrapper"
+
+	// mission inspect flags, also used by mission open
 	dirFlagName = "dir"
 
+	// mission open flags
+	missionOpenRepoFlagName = "repo"
+	missionOpenPRFlagName   = "pr"
+	missionOpenLogsFlagName = "logs"
+
+	// mission browse flags
+	recursiveFlagName = "recursive"
+
+	// audit flags
+	auditLinesFlagName  = "lines"
+	auditSinceFlagName  = "since"
+	auditSourceFlagName = "source"
+	auditActorFlagName  = "actor"
+
+	// report flags
+	reportTodayFlagName = "today"
+	reportWeekFlagName  = "week"
+
+	// mission diff flags
+	missionDiffStatFlagName  = "stat"
+	missionDiffPatchFlagName = "patch"
+
+	// mission timeline flags
+	timelineSinceFlagName = "since"
+	timelineLimitFlagName = "limit"
+
+	// mission replay flags
+	replayIntoFlagName = "into"
+
 	// session/mission print flags
 	tailFlagName   = "tail"
 	formatFlagName = "format"
 
+	// mission transcript flags
+	transcriptSessionFlagName = "session"
+
 	// mission nuke flags
 	forceFlagName = "force"
 
@@ -130,24 +246,119 @@ const (
 	paletteCommandDisabledFlagName    = "disabled"
 
 	// repoConfig flags
-	repoConfigAlwaysSyncedFlagName      = "always-synced"
-	repoConfigEmojiFlagName             = "emoji"
-	repoConfigTitleFlagName             = "title"
-	repoConfigDescriptionFlagName       = "description"
-	repoConfigTrustedMcpServersFlagName = "trusted-mcp-servers"
-	repoConfigDefaultModelFlagName      = "default-model"
-	repoConfigPostUpdateHookFlagName    = "post-update-hook"
-	repoConfigClaudeArgsFlagName        = "claude-args"
+	repoConfigAlwaysSyncedFlagName        = "always-synced"
+	repoConfigEmojiFlagName               = "emoji"
+	repoConfigTitleFlagName               = "title"
+	repoConfigDescriptionFlagName         = "description"
+	repoConfigTrustedMcpServersFlagName   = "trusted-mcp-servers"
+	repoConfigDefaultModelFlagName        = "default-model"
+	repoConfigPostUpdateHookFlagName      = "post-update-hook"
+	repoConfigPostUpdateHookCacheFlagName = "post-update-hook-cache"
+	repoConfigClaudeArgsFlagName          = "claude-args"
+	repoConfigWorkspaceModeFlagName       = "workspace-mode"
+	repoConfigAutoBranchFlagName          = "auto-branch"
+	repoConfigAutoBranchTemplateFlagName  = "auto-branch-template"
+	repoConfigIsolationFlagName           = "isolation"
+	repoConfigUpstreamFlagName            = "upstream"
+	repoConfigBackendFlagName             = "backend"
+	repoConfigClaudeMdAppendFlagName      = "claude-md-append"
+	repoConfigAllowedHostsFlagName        = "allowed-hosts"
+	repoConfigReviewRequiredFlagName      = "review-required"
+	repoConfigWindowTitleTemplateFlagName = "window-title-template"
 
 	// mission resume flags (internal)
 	runWrapperFlagName = "run-wrapper"
 
+	// run flags
+	runRepoFlagName    = "repo"
+	runTimeoutFlagName = "timeout"
+	runJSONFlagName    = "json"
+
+	// mission export flags; also the global output-format flag
+	outputFlagName = "output"
+
+	// global profile flag
+	profileFlagName = "profile"
+
+	// mission gc flags; also mission stop/archive/rm batch flags
+	dryRunFlagName = "dry-run"
+
+	// mission stop/archive/rm batch flags
+	batchRepoFlagName = "repo"
+	olderThanFlagName = "older-than"
+
+	// mission rm flags
+	cleanupRemoteFlagName = "cleanup-remote"
+
+	// mission branch flags
+	createFlagName = "create"
+
+	// repo create flags
+	repoCreateTemplateFlagName  = "template"
+	repoCreatePrivateFlagName   = "private"
+	repoCreateLicenseFlagName   = "license"
+	repoCreateGitignoreFlagName = "gitignore"
+	repoCreateMissionFlagName   = "mission"
+
+	// repo fork flags
+	repoForkOrgFlagName  = "org"
+	repoForkNameFlagName = "name"
+
+	// repo sync-github flags
+	repoSyncGithubOrgFlagName             = "org"
+	repoSyncGithubIncludeArchivedFlagName = "include-archived"
+
+	// mission pr flags
+	missionPRTitleFlagName   = "title"
+	missionPRBodyFlagName    = "body"
+	missionPRBaseFlagName    = "base"
+	missionPRDraftFlagName   = "draft"
+	missionPRMessageFlagName = "message"
+
+	// mission approve flags
+	missionApproveBranchFlagName = "branch"
+
+	// mission conflicts flags
+	missionConflictsRepoFlagName = "repo"
+
+	// mission from-issue flags
+	fromIssueLabelFlagName = "label"
+
+	// mission archive flags
+	archiveNoteFlagName = "note"
+
+	// server start/run flags
+	listenFlagName = "listen"
+
+	// mission repoint flags
+	rebaseFlagName = "rebase"
+
+	// tmux palette flags
+	paletteRunFlagName = "run"
+
+	// server upgrade flags
+	skipInstallFlagName = "skip-install"
+
+	// upgrade flags
+	releaseChannelFlagName     = "channel"
+	upgradeCheckFlagName       = "check"
+	skipSignatureCheckFlagName = "skip-signature-check"
+
+	// server status flags
+	verboseFlagName = "verbose"
+
+	// status flags
+	connectivityFlagName = "connectivity"
+
 	// cron flags
 	headlessFlagName = "headless"
 	followFlagName   = "follow"
 	cronFlagName     = "cron"
 	dateFlagName     = "date"
 
+	// config edit flags
+	formFlagName = "form"
+
 	// config claude-md/settings-json flags
 	contentHashFlagName = "content-hash"
 
@@ -156,8 +367,20 @@ const (
 	cronConfigPromptFlagName               = "prompt"
 	cronConfigDescriptionFlagName          = "description"
 	cronConfigRepoFlagName                 = "repo"
+	cronConfigRefFlagName                  = "ref"
 	cronConfigEnabledFlagName              = "enabled"
 	cronConfigNotificationsEnabledFlagName = "notifications-enabled"
+	cronConfigAfterFlagName                = "after"
+	cronConfigMaxPromptsFlagName           = "max-prompts"
+	cronConfigBudgetUSDFlagName            = "budget-usd"
+	cronConfigEnvFlagName                  = "env"
+	cronConfigNotifyFlagName               = "notify"
+	cronConfigIgnoreQuietHoursFlagName     = "ignore-quiet-hours"
+	cronConfigResumePolicyFlagName         = "resume-policy"
+	cronConfigAlertOnFailuresFlagName      = "alert-on-consecutive-failures"
+
+	// cron at flags
+	cronAtNameFlagName = "name"
 
 	// notifications flags
 	notificationsKindFlagName       = "kind"
@@ -167,4 +390,17 @@ const (
 	notificationsSourceRepoFlagName = "source-repo"
 	notificationsRepoFilterFlagName = "repo"
 	notificationsMissionIDFlagName  = "mission-id"
+
+	// events flags
+	eventsTypeFlagName    = "type"
+	eventsMissionFlagName = "mission"
+
+	// mission summarize flags
+	nowFlagName = "now"
+
+	// config shadow sync flags
+	shadowPreferFlagName = "prefer"
+
+	// skills add flags
+	skillsAddNameFlagName = "name"
 )
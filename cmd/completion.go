@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/repo"
+	"github.com/odyssey/agenc/internal/secrets"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+// completionDescriptionMaxLen caps the description shown next to each
+// completion candidate in zsh and fish.
+const completionDescriptionMaxLen = 50
+
+var completionCmd = &cobra.Command{
+	Use:   completionCmdStr + " <bash|zsh|fish>",
+	Short: "Generate a shell completion script",
+	Long: `Generate a shell completion script for bash, zsh, or fish.
+
+Besides subcommands and flags, the script completes live values: mission short
+IDs, repo names from the repo library, cron names, and palette command names.
+Mission IDs are only completed while the AgenC server is running; completion
+never starts it.
+
+Load completions for the current shell session:
+
+  source <(agenc completion bash)
+  source <(agenc completion zsh)
+  agenc completion fish | source
+
+Load them for every session by adding the matching line to ~/.bashrc or
+~/.zshrc, or for fish:
+
+  agenc completion fish > ~/.config/fish/completions/agenc.fish`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	RunE:      runCompletion,
+}
+
+func init() {
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(os.Stdout, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		return rootCmd.GenFishCompletion(os.Stdout, true)
+	default:
+		return stacktrace.NewError("unsupported shell '%s'; must be bash, zsh, or fish", args[0])
+	}
+}
+
+// ============================================================================
+// ValidArgsFunction implementations
+// ============================================================================
+
+// completeMissionID completes the first argument with mission short IDs.
+func completeMissionID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return missionIDCompletions(args), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeMissionIDs completes every argument with mission short IDs, for
+// commands that accept several missions. IDs already given are skipped.
+func completeMissionIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return missionIDCompletions(args), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepoName completes the first argument with repo library names.
+func completeRepoName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return repoNameCompletions(args), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepoNames completes every argument with repo library names.
+func completeRepoNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return repoNameCompletions(args), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCronName completes the first argument with configured cron names.
+func completeCronName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg := completionConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]string, 0, len(cfg.Crons))
+	for name, cronCfg := range cfg.Crons {
+		schedule := cronCfg.Schedule
+		if cronCfg.IsOneShot() {
+			schedule = "at " + cronCfg.RunAt
+		}
+		completions = append(completions, completionCandidate(name, schedule))
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSecretName completes the first argument with the names of secrets
+// set with 'agenc secret set'.
+func completeSecretName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	store, err := secrets.Open(agencDirpath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	list, err := store.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	completions := make([]string, 0, len(list))
+	for _, secret := range list {
+		completions = append(completions, secret.Name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeManagedSkillName completes the first argument with the names of
+// skills installed by 'agenc skills add'. Reads ~/.claude/skills directly so
+// completion works while the server is down.
+func completeManagedSkillName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg := completionConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	userClaudeDirpath, err := cfg.UserClaudeDirpath()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	skills, err := claudeconfig.ListSkills(userClaudeDirpath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, skill := range skills {
+		if skill.Source != nil {
+			completions = append(completions, completionCandidate(skill.Name, skill.Source.Repo))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePaletteCommandName completes the first argument with palette
+// command names, both builtin and custom.
+func completePaletteCommandName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg := completionConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, paletteCmd := range cfg.GetResolvedPaletteCommands() {
+		completions = append(completions, completionCandidate(paletteCmd.Name, paletteCmd.Title))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjectName completes the first argument with project names.
+func completeProjectName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return projectNameCompletions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjectFlag completes a flag value with project names.
+func completeProjectFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return projectNameCompletions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepoFlag completes a flag value with repo library names.
+func completeRepoFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return repoNameCompletions(nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCronFlag completes a flag value with configured cron names.
+func completeCronFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeCronName(cmd, nil, toComplete)
+}
+
+// completeBackendFlag completes a flag value with agent backend names.
+func completeBackendFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg := completionConfig()
+	if cfg == nil {
+		return []string{config.AgentBackendClaude}, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cfg.AgentBackendNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeMissionFlag completes a flag value with mission short IDs.
+func completeMissionFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return missionIDCompletions(nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+// ============================================================================
+// Completion sources
+// ============================================================================
+
+// missionIDCompletions returns the short IDs of non-archived missions, each
+// described by its session name, excluding IDs in exclude. Returns nothing
+// when the server is not running: a tab press should never start it.
+func missionIDCompletions(exclude []string) []string {
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil || !server.IsRunning(config.GetServerPIDFilepath(agencDirpath)) {
+		return nil
+	}
+	client := server.NewClient(config.GetServerSocketFilepath(agencDirpath))
+	missions, err := client.ListMissions(server.ListMissionsRequest{})
+	if err != nil {
+		return nil
+	}
+	return formatMissionCompletions(missions, exclude)
+}
+
+// formatMissionCompletions renders missions as "<short-id>\t<description>"
+// candidates, skipping those already named in exclude.
+func formatMissionCompletions(missions []*database.Mission, exclude []string) []string {
+	completions := make([]string, 0, len(missions))
+	for _, m := range missions {
+		if slices.Contains(exclude, m.ShortID) || slices.Contains(exclude, m.ID) {
+			continue
+		}
+		completions = append(completions, completionCandidate(m.ShortID, resolveSessionName(m)))
+	}
+	return completions
+}
+
+// projectNameCompletions returns project names, each described by its repo.
+// Returns nothing when the server is not running.
+func projectNameCompletions() []string {
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil || !server.IsRunning(config.GetServerPIDFilepath(agencDirpath)) {
+		return nil
+	}
+	client := server.NewClient(config.GetServerSocketFilepath(agencDirpath))
+	projects, err := client.ListProjects()
+	if err != nil {
+		return nil
+	}
+	completions := make([]string, 0, len(projects))
+	for _, p := range projects {
+		completions = append(completions, completionCandidate(p.Name, plainGitRepoName(p.GitRepo)))
+	}
+	return completions
+}
+
+// repoNameCompletions returns the canonical names of repos in the repo
+// library, excluding names in exclude. Reads the library directory directly
+// so it works without the server.
+func repoNameCompletions(exclude []string) []string {
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return nil
+	}
+	repoNames, err := repo.FindReposOnDisk(config.GetReposDirpath(agencDirpath))
+	if err != nil {
+		return nil
+	}
+	completions := make([]string, 0, len(repoNames))
+	for _, name := range repoNames {
+		if !slices.Contains(exclude, name) {
+			completions = append(completions, name)
+		}
+	}
+	return completions
+}
+
+// completionConfig reads config.yml for completions, returning nil on any
+// error. Unlike readConfig it never runs interactive first-time setup.
+func completionConfig() *config.AgencConfig {
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return nil
+	}
+	cfg, _, err := config.ReadAgencConfig(agencDirpath)
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// completionCandidate formats a completion value with an optional
+// description, which zsh and fish display next to the value. truncatePrompt
+// collapses whitespace, since tabs or newlines would break the completion
+// protocol.
+func completionCandidate(value string, description string) string {
+	if strings.TrimSpace(description) == "" {
+		return value
+	}
+	return value + "\t" + truncatePrompt(description, completionDescriptionMaxLen)
+}
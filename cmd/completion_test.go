@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestFormatMissionCompletions(t *testing.T) {
+	missions := []*database.Mission{
+		{ID: "aaaaaaaa-1111", ShortID: "aaaaaaaa", Prompt: "Fix the\tlogin\nredirect"},
+		{ID: "bbbbbbbb-2222", ShortID: "bbbbbbbb", ResolvedSessionTitle: "Refactor parser"},
+		{ID: "cccccccc-3333", ShortID: "cccccccc"},
+	}
+
+	got := formatMissionCompletions(missions, []string{"bbbbbbbb"})
+	want := []string{"aaaaaaaa\tFix the login redirect", "cccccccc"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCompletionCandidate(t *testing.T) {
+	long := "one two three four five six seven eight nine ten eleven twelve"
+	tests := []struct {
+		value       string
+		description string
+		want        string
+	}{
+		{"nightly", "0 3 * * *", "nightly\t0 3 * * *"},
+		{"nightly", "  ", "nightly"},
+		{"quick", long, "quick\t" + long[:completionDescriptionMaxLen] + "…"},
+	}
+	for _, tt := range tests {
+		if got := completionCandidate(tt.value, tt.description); got != tt.want {
+			t.Errorf("completionCandidate(%q, %q) = %q, want %q", tt.value, tt.description, got, tt.want)
+		}
+	}
+}
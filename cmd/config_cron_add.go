@@ -22,6 +22,12 @@ Only simple integer values and '*' (any) are supported. Ranges (1-5),
 lists (1,3,5), step values (*/15), and named days (SUN) are not supported
 because macOS launchd cannot represent them.
 
+--schedule also accepts a phrase such as "every day at 9am", "fridays at 5pm",
+or "every month on the 15th at noon". The computed cron expression and its
+next three run times are printed, and confirmed when run from a terminal.
+Phrases spanning several days ("every weekday") are rejected for the same
+launchd reason; add one cron per day instead.
+
 Examples:
   agenc config cron add daily-report \
     --schedule="0 9 * * *" \
@@ -31,6 +37,97 @@ Examples:
   agenc config cron add weekly-cleanup \
     --schedule="0 0 * * 0" \
     --prompt="Clean up old temporary files"
+
+  agenc config cron add monday-standup \
+    --schedule="every monday at 9am" \
+    --prompt="Summarize last week's merged PRs"
+
+The prompt may reference variables as {{name}}, filled in when each run
+starts: {{date}}, {{time}}, {{weekday}}, {{cronName}}, {{repo}},
+{{lastRun}} and {{lastSuccess}} (RFC3339, or "never"), {{lastRunStatus}},
+and {{repoDefaultBranchHead}} (the commit SHA at the head of the repo's
+default branch). Unknown placeholders are left as written:
+
+  agenc config cron add commit-digest \
+    --schedule="0 9 * * *" \
+    --prompt="Summarize commits since {{lastSuccess}} up to {{repoDefaultBranchHead}}" \
+    --repo=github.com/owner/my-repo
+
+With --after, the cron only starts once the named upstream cron's latest run
+succeeded that day. A scheduled firing that comes too early is skipped, and
+the cron starts as soon as the upstream succeeds later the same day:
+
+  agenc config cron add summarize \
+    --schedule="0 7 * * *" \
+    --prompt="Summarize the data fetched this morning" \
+    --after=fetch
+
+With --max-prompts and --budget-usd, each run's Claude is gracefully stopped
+once it has answered that many prompts or its estimated spend reaches the
+budget:
+
+  agenc config cron add triage \
+    --schedule="0 8 * * *" \
+    --prompt="Triage new issues" \
+    --budget-usd=2.50
+
+With --env (repeatable), each run's Claude gets extra environment variables.
+Values may reference secrets set with 'agenc secret set' as secret://NAME;
+they are resolved when the run starts and never written to config.yml:
+
+  agenc config cron add release-notes \
+    --schedule="0 17 * * 5" \
+    --prompt="Draft release notes from this week's merged PRs" \
+    --env=GITHUB_TOKEN=secret://GITHUB_TOKEN
+
+With --notify (repeatable), each run's start, success, and failure are sent to
+a Slack channel or email address. Delivery is configured under notifications
+in config.yml:
+
+  agenc config cron add nightly-report \
+    --schedule="0 3 * * *" \
+    --prompt="Write the nightly report" \
+    --notify=slack:#reports --notify=email:me@example.com
+
+When quietHours is set in config.yml, scheduled runs that fall inside it are
+deferred until it ends and their notifications are held back. Pass
+--ignore-quiet-hours for crons that must run on time:
+
+  agenc config cron add oncall-digest \
+    --schedule="0 2 * * *" \
+    --prompt="Summarize overnight alerts" \
+    --ignore-quiet-hours
+
+Each run starts a fresh mission by default. --resume-policy=continue sends the
+prompt to the previous run's mission instead, resuming its conversation.
+--resume-policy="forkIfBehind(N)" does the same unless that mission's checkout
+has fallen more than N commits behind the repo's default branch, in which
+case the conversation is forked into a new mission on an up-to-date checkout:
+
+  agenc config cron add dependency-watch \
+    --schedule="0 10 * * *" \
+    --prompt="Check for new dependency releases and update your notes" \
+    --repo=github.com/owner/my-repo \
+    --resume-policy="forkIfBehind(20)"
+
+With --ref, each run checks out a branch, tag, or commit instead of the
+repo's default branch. The mission records the ref and the commit it
+resolved to, so a scheduled job's run can be reproduced later:
+
+  agenc config cron add release-smoke-test \
+    --schedule="0 6 * * *" \
+    --prompt="Run the smoke tests and report failures" \
+    --repo=github.com/owner/my-repo \
+    --ref=release/2.4
+
+With --alert-on-consecutive-failures, an alert is raised once that many runs
+in a row have failed: a cron.failing event, a notification, and a message to
+the --notify targets. See success rates and durations with 'agenc cron stats':
+
+  agenc config cron add nightly-backup \
+    --schedule="0 1 * * *" \
+    --prompt="Back up the research notes" \
+    --alert-on-consecutive-failures=3 --notify=slack:#alerts
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigCronAdd,
@@ -38,11 +135,24 @@ Examples:
 
 func init() {
 	configCronCmd.AddCommand(configCronAddCmd)
-	configCronAddCmd.Flags().String(cronConfigScheduleFlagName, "", "cron schedule expression (e.g., '0 9 * * *') (required)")
+	configCronAddCmd.Flags().String(cronConfigScheduleFlagName, "", "cron schedule expression or phrase (e.g., '0 9 * * *' or 'every day at 9am') (required)")
 	configCronAddCmd.Flags().String(cronConfigPromptFlagName, "", "initial prompt for the Claude mission (required)")
 	configCronAddCmd.Flags().String(cronConfigDescriptionFlagName, "", "human-readable description (optional)")
 	configCronAddCmd.Flags().String(cronConfigRepoFlagName, "", "repository to clone (e.g., github.com/owner/repo) (optional)")
+	_ = configCronAddCmd.RegisterFlagCompletionFunc(cronConfigRepoFlagName, completeRepoFlag)
+	configCronAddCmd.Flags().String(cronConfigRefFlagName, "", "branch, tag, or commit each run checks out instead of the repo's default branch (requires --repo)")
 	configCronAddCmd.Flags().Bool(cronConfigNotificationsEnabledFlagName, true, "whether triggers of this cron create a cron.triggered notification")
+	configCronAddCmd.Flags().String(cronConfigAfterFlagName, "", "upstream cron that must have succeeded today before this one starts (optional)")
+	_ = configCronAddCmd.RegisterFlagCompletionFunc(cronConfigAfterFlagName, completeCronFlag)
+	configCronAddCmd.Flags().Int(cronConfigMaxPromptsFlagName, 0, "stop each run's Claude after this many prompts (0 = no limit)")
+	configCronAddCmd.Flags().Float64(cronConfigBudgetUSDFlagName, 0, "stop each run's Claude once estimated spend reaches this many USD (0 = no limit)")
+	configCronAddCmd.Flags().StringArray(cronConfigEnvFlagName, nil, "KEY=VALUE environment variable for each run's Claude; values may be secret://NAME (repeatable)")
+	configCronAddCmd.Flags().StringArray(cronConfigNotifyFlagName, nil, "target told when each run starts, succeeds, or fails: slack:#channel, email:address, or webhook:URL (repeatable)")
+	configCronAddCmd.Flags().String(projectFlagName, "", "project each run's mission joins (optional)")
+	configCronAddCmd.Flags().Bool(cronConfigIgnoreQuietHoursFlagName, false, "run on schedule and send notifications during quietHours instead of deferring")
+	configCronAddCmd.Flags().String(cronConfigResumePolicyFlagName, "", "how each run treats the previous run's mission: fresh, continue, or forkIfBehind(N) (default fresh)")
+	configCronAddCmd.Flags().Int(cronConfigAlertOnFailuresFlagName, 0, "alert once this many runs in a row have failed (0 = never)")
+	_ = configCronAddCmd.RegisterFlagCompletionFunc(projectFlagName, completeProjectFlag)
 	_ = configCronAddCmd.MarkFlagRequired(cronConfigScheduleFlagName)
 	_ = configCronAddCmd.MarkFlagRequired(cronConfigPromptFlagName)
 }
@@ -50,10 +160,14 @@ func init() {
 func runConfigCronAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	schedule, err := cmd.Flags().GetString(cronConfigScheduleFlagName)
+	scheduleInput, err := cmd.Flags().GetString(cronConfigScheduleFlagName)
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to read --%s flag", cronConfigScheduleFlagName)
 	}
+	schedule, err := resolveCronScheduleFlag(scheduleInput)
+	if err != nil {
+		return err
+	}
 
 	prompt, err := cmd.Flags().GetString(cronConfigPromptFlagName)
 	if err != nil {
@@ -61,8 +175,39 @@ func runConfigCronAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	description, _ := cmd.Flags().GetString(cronConfigDescriptionFlagName)
+	after, _ := cmd.Flags().GetString(cronConfigAfterFlagName)
+
+	maxPrompts, err := cmd.Flags().GetInt(cronConfigMaxPromptsFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", cronConfigMaxPromptsFlagName)
+	}
+	budgetUSD, err := cmd.Flags().GetFloat64(cronConfigBudgetUSDFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", cronConfigBudgetUSDFlagName)
+	}
+	envEntries, err := cmd.Flags().GetStringArray(cronConfigEnvFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", cronConfigEnvFlagName)
+	}
+	env, err := parseEnvFlag(cronConfigEnvFlagName, envEntries)
+	if err != nil {
+		return err
+	}
+	notifyTargets, err := cmd.Flags().GetStringArray(cronConfigNotifyFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", cronConfigNotifyFlagName)
+	}
+
+	project, _ := cmd.Flags().GetString(projectFlagName)
+	ignoreQuietHours, _ := cmd.Flags().GetBool(cronConfigIgnoreQuietHoursFlagName)
+	resumePolicy, _ := cmd.Flags().GetString(cronConfigResumePolicyFlagName)
+	alertOnConsecutiveFailures, _ := cmd.Flags().GetInt(cronConfigAlertOnFailuresFlagName)
 
+	ref, _ := cmd.Flags().GetString(cronConfigRefFlagName)
 	repo, _ := cmd.Flags().GetString(cronConfigRepoFlagName)
+	if ref != "" && repo == "" {
+		return stacktrace.NewError("--%s requires --%s", cronConfigRefFlagName, cronConfigRepoFlagName)
+	}
 	if repo != "" {
 		result, err := ResolveRepoInput(repo, "Select repo: ")
 		if err != nil {
@@ -77,11 +222,21 @@ func runConfigCronAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	createReq := server.CreateCronRequest{
-		Name:        name,
-		Schedule:    schedule,
-		Prompt:      prompt,
-		Description: description,
-		Repo:        repo,
+		Name:                       name,
+		Schedule:                   schedule,
+		Prompt:                     prompt,
+		Description:                description,
+		Repo:                       repo,
+		Ref:                        ref,
+		After:                      after,
+		MaxPrompts:                 maxPrompts,
+		BudgetUSD:                  budgetUSD,
+		Env:                        env,
+		Notify:                     notifyTargets,
+		Project:                    project,
+		IgnoreQuietHours:           ignoreQuietHours,
+		ResumePolicy:               resumePolicy,
+		AlertOnConsecutiveFailures: alertOnConsecutiveFailures,
 	}
 	if cmd.Flags().Changed(cronConfigNotificationsEnabledFlagName) {
 		notificationsEnabled, _ := cmd.Flags().GetBool(cronConfigNotificationsEnabledFlagName)
@@ -33,6 +33,10 @@ func runConfigCronLs(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to list crons")
 	}
 
+	if isStructuredOutput() {
+		return printStructured(crons)
+	}
+
 	if len(crons) == 0 {
 		fmt.Println("No cron jobs configured.")
 		return nil
@@ -50,7 +54,7 @@ func runConfigCronLs(cmd *cobra.Command, args []string) error {
 			prompt = prompt[:57] + "..."
 		}
 
-		tbl.AddRow(c.Name, c.Schedule, enabled, prompt)
+		tbl.AddRow(c.Name, formatCronSchedule(c), enabled, prompt)
 	}
 
 	tbl.Print()
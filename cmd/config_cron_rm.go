@@ -17,8 +17,9 @@ The cron job entry is completely removed from the configuration.
 Example:
   agenc config cron rm daily-report
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: runConfigCronRm,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigCronRm,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
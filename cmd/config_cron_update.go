@@ -20,6 +20,7 @@ will be updated; other fields remain unchanged.
 Examples:
   # Update the schedule
   agenc config cron update daily-report --schedule="0 10 * * *"
+  agenc config cron update daily-report --schedule="every day at 10am"
 
   # Disable a cron job
   agenc config cron update daily-report --enabled=false
@@ -34,19 +35,51 @@ Examples:
 
   # Clear the repository
   agenc config cron update daily-report --repo=""
+
+  # Run from the release branch; --ref="" goes back to the default branch
+  agenc config cron update smoke-test --ref=release/2.4
+
+  # Only run once the 'fetch' cron has succeeded today; --after="" clears it
+  agenc config cron update summarize --after=fetch
+
+  # Cap each run at $5 of estimated spend; --budget-usd=0 removes the cap
+  agenc config cron update daily-report --budget-usd=5
+
+  # Replace the run environment; --env="" clears it
+  agenc config cron update daily-report --env=GITHUB_TOKEN=secret://GITHUB_TOKEN
+
+  # Run on schedule even during quietHours
+  agenc config cron update oncall-digest --ignore-quiet-hours
+
+  # Alert after 3 failed runs in a row; 0 turns alerting off
+  agenc config cron update nightly-report --alert-on-consecutive-failures=3
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: runConfigCronUpdate,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigCronUpdate,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
 	configCronCmd.AddCommand(configCronUpdateCmd)
-	configCronUpdateCmd.Flags().String(cronConfigScheduleFlagName, "", "cron schedule expression (e.g., '0 9 * * *')")
+	configCronUpdateCmd.Flags().String(cronConfigScheduleFlagName, "", "cron schedule expression or phrase (e.g., '0 9 * * *' or 'every day at 9am')")
 	configCronUpdateCmd.Flags().String(cronConfigPromptFlagName, "", "initial prompt for the Claude mission")
 	configCronUpdateCmd.Flags().String(cronConfigDescriptionFlagName, "", "human-readable description")
 	configCronUpdateCmd.Flags().String(cronConfigRepoFlagName, "", "repository to clone (e.g., github.com/owner/repo)")
+	_ = configCronUpdateCmd.RegisterFlagCompletionFunc(cronConfigRepoFlagName, completeRepoFlag)
+	configCronUpdateCmd.Flags().String(cronConfigRefFlagName, "", "branch, tag, or commit each run checks out; --ref=\"\" goes back to the default branch")
 	configCronUpdateCmd.Flags().Bool(cronConfigEnabledFlagName, true, "whether the cron job is enabled")
 	configCronUpdateCmd.Flags().Bool(cronConfigNotificationsEnabledFlagName, true, "whether triggers of this cron create a cron.triggered notification")
+	configCronUpdateCmd.Flags().String(cronConfigAfterFlagName, "", "upstream cron that must have succeeded today before this one starts")
+	_ = configCronUpdateCmd.RegisterFlagCompletionFunc(cronConfigAfterFlagName, completeCronFlag)
+	configCronUpdateCmd.Flags().Int(cronConfigMaxPromptsFlagName, 0, "stop each run's Claude after this many prompts (0 = no limit)")
+	configCronUpdateCmd.Flags().Float64(cronConfigBudgetUSDFlagName, 0, "stop each run's Claude once estimated spend reaches this many USD (0 = no limit)")
+	configCronUpdateCmd.Flags().StringArray(cronConfigEnvFlagName, nil, "KEY=VALUE environment variable for each run's Claude, replacing the existing ones; --env=\"\" clears them (repeatable)")
+	configCronUpdateCmd.Flags().StringArray(cronConfigNotifyFlagName, nil, "slack:#channel, email:address, or webhook:URL target for run notifications, replacing the existing ones; --notify=\"\" clears them (repeatable)")
+	configCronUpdateCmd.Flags().String(projectFlagName, "", "project each run's mission joins; --project=\"\" clears it")
+	_ = configCronUpdateCmd.RegisterFlagCompletionFunc(projectFlagName, completeProjectFlag)
+	configCronUpdateCmd.Flags().Bool(cronConfigIgnoreQuietHoursFlagName, false, "run on schedule and send notifications during quietHours instead of deferring")
+	configCronUpdateCmd.Flags().String(cronConfigResumePolicyFlagName, "", "how each run treats the previous run's mission: fresh, continue, or forkIfBehind(N); --resume-policy=\"\" resets it to fresh")
+	configCronUpdateCmd.Flags().Int(cronConfigAlertOnFailuresFlagName, 0, "alert once this many runs in a row have failed (0 = never)")
 }
 
 func runConfigCronUpdate(cmd *cobra.Command, args []string) error {
@@ -54,8 +87,13 @@ func runConfigCronUpdate(cmd *cobra.Command, args []string) error {
 
 	allFlags := []string{
 		cronConfigScheduleFlagName, cronConfigPromptFlagName,
-		cronConfigDescriptionFlagName, cronConfigRepoFlagName,
+		cronConfigDescriptionFlagName, cronConfigRepoFlagName, cronConfigRefFlagName,
 		cronConfigEnabledFlagName, cronConfigNotificationsEnabledFlagName,
+		cronConfigAfterFlagName, cronConfigMaxPromptsFlagName,
+		cronConfigBudgetUSDFlagName, cronConfigEnvFlagName,
+		cronConfigNotifyFlagName, projectFlagName,
+		cronConfigIgnoreQuietHoursFlagName, cronConfigResumePolicyFlagName,
+		cronConfigAlertOnFailuresFlagName,
 	}
 	if !anyFlagChanged(cmd, allFlags) {
 		return stacktrace.NewError("at least one configuration flag must be provided")
@@ -64,7 +102,11 @@ func runConfigCronUpdate(cmd *cobra.Command, args []string) error {
 	var req server.UpdateCronRequest
 
 	if cmd.Flags().Changed(cronConfigScheduleFlagName) {
-		schedule, _ := cmd.Flags().GetString(cronConfigScheduleFlagName)
+		scheduleInput, _ := cmd.Flags().GetString(cronConfigScheduleFlagName)
+		schedule, err := resolveCronScheduleFlag(scheduleInput)
+		if err != nil {
+			return err
+		}
 		req.Schedule = &schedule
 	}
 	if cmd.Flags().Changed(cronConfigPromptFlagName) {
@@ -86,6 +128,10 @@ func runConfigCronUpdate(cmd *cobra.Command, args []string) error {
 		}
 		req.Repo = &repo
 	}
+	if cmd.Flags().Changed(cronConfigRefFlagName) {
+		ref, _ := cmd.Flags().GetString(cronConfigRefFlagName)
+		req.Ref = &ref
+	}
 	if cmd.Flags().Changed(cronConfigEnabledFlagName) {
 		enabled, _ := cmd.Flags().GetBool(cronConfigEnabledFlagName)
 		req.Enabled = &enabled
@@ -95,6 +141,54 @@ func runConfigCronUpdate(cmd *cobra.Command, args []string) error {
 		req.NotificationsEnabled = &notificationsEnabled
 	}
 
+	if cmd.Flags().Changed(cronConfigAfterFlagName) {
+		after, _ := cmd.Flags().GetString(cronConfigAfterFlagName)
+		req.After = &after
+	}
+	if cmd.Flags().Changed(cronConfigMaxPromptsFlagName) {
+		maxPrompts, _ := cmd.Flags().GetInt(cronConfigMaxPromptsFlagName)
+		req.MaxPrompts = &maxPrompts
+	}
+	if cmd.Flags().Changed(cronConfigBudgetUSDFlagName) {
+		budgetUSD, _ := cmd.Flags().GetFloat64(cronConfigBudgetUSDFlagName)
+		req.BudgetUSD = &budgetUSD
+	}
+	if cmd.Flags().Changed(cronConfigEnvFlagName) {
+		envEntries, _ := cmd.Flags().GetStringArray(cronConfigEnvFlagName)
+		env, err := parseEnvFlag(cronConfigEnvFlagName, envEntries)
+		if err != nil {
+			return err
+		}
+		req.Env = &env
+	}
+	if cmd.Flags().Changed(cronConfigNotifyFlagName) {
+		notifyTargets, _ := cmd.Flags().GetStringArray(cronConfigNotifyFlagName)
+		// --notify="" clears the targets
+		nonEmpty := []string{}
+		for _, target := range notifyTargets {
+			if target != "" {
+				nonEmpty = append(nonEmpty, target)
+			}
+		}
+		req.Notify = &nonEmpty
+	}
+	if cmd.Flags().Changed(projectFlagName) {
+		project, _ := cmd.Flags().GetString(projectFlagName)
+		req.Project = &project
+	}
+	if cmd.Flags().Changed(cronConfigIgnoreQuietHoursFlagName) {
+		ignoreQuietHours, _ := cmd.Flags().GetBool(cronConfigIgnoreQuietHoursFlagName)
+		req.IgnoreQuietHours = &ignoreQuietHours
+	}
+	if cmd.Flags().Changed(cronConfigResumePolicyFlagName) {
+		resumePolicy, _ := cmd.Flags().GetString(cronConfigResumePolicyFlagName)
+		req.ResumePolicy = &resumePolicy
+	}
+	if cmd.Flags().Changed(cronConfigAlertOnFailuresFlagName) {
+		alertOnConsecutiveFailures, _ := cmd.Flags().GetInt(cronConfigAlertOnFailuresFlagName)
+		req.AlertOnConsecutiveFailures = &alertOnConsecutiveFailures
+	}
+
 	client, err := serverClient()
 	if err != nil {
 		return err
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var configDiffCmd = &cobra.Command{
+	Use:   diffCmdStr + " [commit]",
+	Short: "Show config changes since a recorded snapshot",
+	Long: `Show how config.yml and claude-modifications/ differ from a recorded snapshot.
+
+With no argument, compares against the most recent snapshot, showing edits
+the server has not recorded yet. Pass a commit from 'agenc config history' to
+see everything that changed since then.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigDiff,
+}
+
+func init() {
+	configCmd.AddCommand(configDiffCmd)
+}
+
+func runConfigDiff(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+
+	commit := ""
+	if len(args) == 1 {
+		commit = args[0]
+	}
+
+	// Diffing stages the live config in the history repo, so it must not
+	// race a server snapshot.
+	release, err := config.AcquireConfigLock(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to acquire config lock")
+	}
+	defer release()
+
+	return config.DiffConfig(agencDirpath, commit, os.Stdout, isatty.IsTerminal(os.Stdout.Fd()))
+}
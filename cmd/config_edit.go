@@ -1,28 +1,62 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 	"syscall"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
 	"github.com/mattn/go-isatty"
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
 
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 var configEditCmd = &cobra.Command{
-	Use:   editCmdStr,
-	Short: "Open config.yml in your editor ($EDITOR)",
-	RunE:  runConfigEdit,
+	Use:   editCmdStr + " [section]",
+	Short: "Open config.yml, or one section of it, in your editor ($EDITOR)",
+	Long: fmt.Sprintf(`Open config.yml in your editor ($EDITOR).
+
+With a section name (a top-level key such as crons or repoConfig), only that
+section opens, with its comments. When the editor exits the section is
+validated together with the rest of the config before anything is written;
+if it is invalid the errors are shown and you can re-open the editor to fix
+them or abort, leaving config.yml unchanged. Comments elsewhere in the file
+are preserved. Crons added this way get an id automatically.
+
+--form edits the %s sections in a form instead of an editor: pick an entry
+(or add one), then fill in its fields. Each field is checked as you type, and
+the whole config is checked again before the entry is saved.`, strings.Join(configFormSectionNames(), ", ")),
+	Example: `  agenc config edit
+  agenc config edit crons
+  agenc config edit repoConfig --form`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeConfigSections,
+	RunE:              runConfigEdit,
 }
 
+var configEditFormFlag bool
+
 func init() {
+	configEditCmd.Flags().BoolVar(&configEditFormFlag, formFlagName, false,
+		fmt.Sprintf("edit the section in a form instead of $EDITOR (%s)", strings.Join(configFormSectionNames(), ", ")))
 	configCmd.AddCommand(configEditCmd)
 }
 
+func completeConfigSections(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return config.ConfigSectionNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
 func runConfigEdit(cmd *cobra.Command, args []string) error {
 	if !isatty.IsTerminal(os.Stdin.Fd()) {
 		return stacktrace.NewError("'%s %s %s' requires a terminal; use '%s %s %s'/'%s %s %s' instead",
@@ -35,17 +69,50 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+
+	if len(args) == 0 {
+		if configEditFormFlag {
+			return stacktrace.NewError("--%s needs a section; one of: %s", formFlagName, strings.Join(configFormSectionNames(), ", "))
+		}
+		return execEditorOnConfig(agencDirpath)
+	}
+
+	section := args[0]
+	if !config.IsConfigSection(section) {
+		return stacktrace.NewError("unknown config section '%s'; valid sections: %s", section, strings.Join(config.ConfigSectionNames(), ", "))
+	}
+	if configEditFormFlag {
+		spec, ok := configFormSpecs[section]
+		if !ok {
+			return stacktrace.NewError("--%s supports only %s; use '%s %s %s %s' to edit it in $EDITOR",
+				formFlagName, strings.Join(configFormSectionNames(), ", "), agencCmdStr, configCmdStr, editCmdStr, section)
+		}
+		return runConfigEditForm(agencDirpath, spec)
+	}
+	return runConfigEditSection(agencDirpath, section)
+}
+
+// resolveEditor splits $EDITOR on whitespace, so values like "code --wait"
+// work, and resolves the binary on PATH.
+func resolveEditor() (string, []string, error) {
 	editorEnv := os.Getenv("EDITOR")
 	if editorEnv == "" {
-		return stacktrace.NewError("$EDITOR is not set; set it to your preferred editor (e.g. export EDITOR=vim)")
+		return "", nil, stacktrace.NewError("$EDITOR is not set; set it to your preferred editor (e.g. export EDITOR=vim)")
 	}
-
-	// Split $EDITOR on whitespace so values like "code --wait" work correctly.
 	editorParts := strings.Fields(editorEnv)
-
 	editorBinary, err := exec.LookPath(editorParts[0])
 	if err != nil {
-		return stacktrace.Propagate(err, "editor '%s' not found in PATH", editorParts[0])
+		return "", nil, stacktrace.Propagate(err, "editor '%s' not found in PATH", editorParts[0])
+	}
+	return editorBinary, editorParts, nil
+}
+
+// execEditorOnConfig replaces this process with $EDITOR on the whole
+// config.yml.
+func execEditorOnConfig(agencDirpath string) error {
+	editorBinary, editorParts, err := resolveEditor()
+	if err != nil {
+		return err
 	}
 
 	configFilepath := config.GetConfigFilepath(agencDirpath)
@@ -55,3 +122,153 @@ func runConfigEdit(cmd *cobra.Command, args []string) error {
 
 	return syscall.Exec(editorBinary, argv, os.Environ())
 }
+
+// runConfigEditSection opens one section of config.yml in $EDITOR via a
+// temp file and writes it back once it validates. The config lock is only
+// held while writing, so a long editing session doesn't block other config
+// writers; the section replaces whatever is current at that point.
+func runConfigEditSection(agencDirpath string, section string) error {
+	editorBinary, editorParts, err := resolveEditor()
+	if err != nil {
+		return err
+	}
+
+	cfg, cm, err := config.ReadAgencConfig(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read config")
+	}
+	original, err := config.MarshalConfigSection(cfg, cm, section)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp("", "agenc-config-"+section+"-*.yml")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create temp file")
+	}
+	tmpFilepath := tmpFile.Name()
+	tmpFile.Close()
+	defer func() { _ = os.Remove(tmpFilepath) }()
+	if err := os.WriteFile(tmpFilepath, original, 0600); err != nil {
+		return stacktrace.Propagate(err, "failed to write temp file")
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		editorCmd := exec.Command(editorBinary, append(editorParts[1:], tmpFilepath)...)
+		editorCmd.Stdin = os.Stdin
+		editorCmd.Stdout = os.Stdout
+		editorCmd.Stderr = os.Stderr
+		if err := editorCmd.Run(); err != nil {
+			return stacktrace.Propagate(err, "editor exited with error")
+		}
+
+		edited, err := os.ReadFile(tmpFilepath)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read temp file")
+		}
+		if bytes.Equal(edited, original) {
+			fmt.Println("No changes; config.yml left unchanged")
+			return nil
+		}
+
+		applyErr := writeConfigSection(agencDirpath, section, edited)
+		if applyErr == nil {
+			fmt.Printf("Updated '%s' in config.yml\n", section)
+			refreshKeybindingsAfterEdit(agencDirpath, section)
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "\n%#s\n\n", applyErr)
+		reopen, err := promptYesNo(reader, "Re-open the editor to fix it? [y/N] ")
+		if err != nil {
+			return err
+		}
+		if !reopen {
+			return stacktrace.NewError("aborted; config.yml left unchanged")
+		}
+	}
+}
+
+// writeConfigSection applies an edited section to the current config.yml
+// under the config lock, validating the result before it is written.
+func writeConfigSection(agencDirpath string, section string, data []byte) error {
+	release, err := config.AcquireConfigLock(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to acquire config lock")
+	}
+	defer release()
+
+	cfg, cm, err := config.ReadAgencConfig(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read config")
+	}
+	newCfg, newCM, err := config.ApplyConfigSection(cfg, cm, section, data)
+	if err != nil {
+		return err
+	}
+	assignMissingCronIDs(newCfg)
+	if err := config.WriteAgencConfig(agencDirpath, newCfg, newCM); err != nil {
+		return stacktrace.Propagate(err, "failed to write config")
+	}
+	return nil
+}
+
+// assignMissingCronIDs gives every cron without an id a fresh one, as
+// 'agenc cron new' does, so crons added by hand get scheduled.
+func assignMissingCronIDs(cfg *config.AgencConfig) {
+	for name, cronCfg := range cfg.Crons {
+		if cronCfg.ID == "" {
+			cronCfg.ID = uuid.New().String()
+			cfg.Crons[name] = cronCfg
+		}
+	}
+}
+
+// runConfigEditForm runs the form editor for one section and writes the
+// saved entry into the current config.yml.
+func runConfigEditForm(agencDirpath string, spec *configFormSpec) error {
+	cfg, _, err := config.ReadAgencConfig(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read config")
+	}
+
+	model := newConfigFormModel(spec, cfg)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return stacktrace.Propagate(err, "config form exited with an error")
+	}
+	if model.saved == nil {
+		fmt.Println("No changes; config.yml left unchanged")
+		return nil
+	}
+
+	cfg, cm, release, err := readConfigWithComments()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	spec.apply(cfg, model.saved.name, model.saved.values)
+	assignMissingCronIDs(cfg)
+	if _, err := config.RevalidateAgencConfig(cfg); err != nil {
+		return stacktrace.Propagate(err, "config changed while the form was open; '%s' was not saved", model.saved.name)
+	}
+	if err := config.WriteAgencConfig(agencDirpath, cfg, cm); err != nil {
+		return stacktrace.Propagate(err, "failed to write config")
+	}
+
+	fmt.Printf("Saved %s entry '%s'\n", spec.section, model.saved.name)
+	refreshKeybindingsAfterEdit(agencDirpath, spec.section)
+	return nil
+}
+
+// refreshKeybindingsAfterEdit reloads the tmux keybindings when the edited
+// section feeds them.
+func refreshKeybindingsAfterEdit(agencDirpath string, section string) {
+	if section != "paletteCommands" && !isTmuxKeybindingKey(section) {
+		return
+	}
+	if err := tmux.RefreshKeybindings(agencDirpath); err != nil {
+		fmt.Printf("Warning: failed to reload tmux keybindings: %v\n", err)
+	}
+}
@@ -0,0 +1,591 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var (
+	configFormFocusStyle = lipgloss.NewStyle().Bold(true)
+	configFormLabelStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// configFormField is one editable line of the config form. Values are edited
+// as text and parsed by the section's setter when the entry is saved.
+type configFormField struct {
+	key      string
+	help     string
+	value    string
+	validate func(value string) error
+	err      error
+}
+
+// configFormFieldDef describes how one field of a T entry maps to form text.
+type configFormFieldDef[T any] struct {
+	key      string
+	help     string
+	validate func(value string) error
+	get      func(entry *T) string
+	set      func(entry *T, value string)
+}
+
+// configFormSpec is the form editor for one map-valued config section, such
+// as crons, whose entries share a shape.
+type configFormSpec struct {
+	section      string
+	validateName func(name string) error
+	names        func(cfg *config.AgencConfig) []string
+	fields       func(cfg *config.AgencConfig, name string) []configFormField
+	// apply stores values, in field order, as entry name of cfg. It replaces
+	// the section's map rather than writing into it, so configs that share the
+	// map are left untouched.
+	apply func(cfg *config.AgencConfig, name string, values []string)
+}
+
+// newConfigFormSpec builds a configFormSpec for a section stored as a
+// map[string]T on AgencConfig. Fields a form doesn't list keep their values.
+func newConfigFormSpec[T any](
+	section string,
+	entries func(cfg *config.AgencConfig) *map[string]T,
+	validateName func(name string) error,
+	defs []configFormFieldDef[T],
+) *configFormSpec {
+	return &configFormSpec{
+		section:      section,
+		validateName: validateName,
+		names: func(cfg *config.AgencConfig) []string {
+			names := make([]string, 0, len(*entries(cfg)))
+			for name := range *entries(cfg) {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return names
+		},
+		fields: func(cfg *config.AgencConfig, name string) []configFormField {
+			entry := (*entries(cfg))[name]
+			fields := make([]configFormField, 0, len(defs))
+			for _, def := range defs {
+				fields = append(fields, configFormField{key: def.key, help: def.help, value: def.get(&entry), validate: def.validate})
+			}
+			return fields
+		},
+		apply: func(cfg *config.AgencConfig, name string, values []string) {
+			current := *entries(cfg)
+			updated := make(map[string]T, len(current)+1)
+			for k, v := range current {
+				updated[k] = v
+			}
+			entry := updated[name]
+			for i, def := range defs {
+				def.set(&entry, values[i])
+			}
+			updated[name] = entry
+			*entries(cfg) = updated
+		},
+	}
+}
+
+// configFormSpecs are the sections 'agenc config edit <section> --form'
+// supports.
+var configFormSpecs = map[string]*configFormSpec{
+	"repoConfig":      repoConfigFormSpec,
+	"crons":           cronsFormSpec,
+	"paletteCommands": paletteCommandsFormSpec,
+}
+
+// configFormSectionNames returns the sections with a form editor, sorted.
+func configFormSectionNames() []string {
+	names := make([]string, 0, len(configFormSpecs))
+	for name := range configFormSpecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var repoConfigFormSpec = newConfigFormSpec("repoConfig",
+	func(cfg *config.AgencConfig) *map[string]config.RepoConfig { return &cfg.RepoConfigs },
+	validateFormRepoName,
+	[]configFormFieldDef[config.RepoConfig]{
+		{key: "emoji", help: "shown next to the repo in pickers and window titles",
+			get: func(rc *config.RepoConfig) string { return rc.Emoji },
+			set: func(rc *config.RepoConfig, v string) { rc.Emoji = v }},
+		{key: "title", help: "window title for the repo's missions",
+			get: func(rc *config.RepoConfig) string { return rc.Title },
+			set: func(rc *config.RepoConfig, v string) { rc.Title = v }},
+		{key: "description", help: "shown in the repo picker",
+			get: func(rc *config.RepoConfig) string { return rc.Description },
+			set: func(rc *config.RepoConfig, v string) { rc.Description = v }},
+		{key: "defaultModel", help: "Claude model for new missions",
+			get: func(rc *config.RepoConfig) string { return rc.DefaultModel },
+			set: func(rc *config.RepoConfig, v string) { rc.DefaultModel = v }},
+		{key: "workspaceMode", help: "copy or worktree", validate: optionalFormValue(config.ValidateWorkspaceMode),
+			get: func(rc *config.RepoConfig) string { return rc.WorkspaceMode },
+			set: func(rc *config.RepoConfig, v string) { rc.WorkspaceMode = v }},
+		{key: "isolation", help: "none or container", validate: optionalFormValue(config.ValidateIsolation),
+			get: func(rc *config.RepoConfig) string { return rc.Isolation },
+			set: func(rc *config.RepoConfig, v string) { rc.Isolation = v }},
+		{key: "autoBranch", help: "true to start each mission on its own branch", validate: validateFormBool,
+			get: func(rc *config.RepoConfig) string { return formatFormBool(rc.AutoBranch) },
+			set: func(rc *config.RepoConfig, v string) { rc.AutoBranch = parseFormBool(v) }},
+		{key: "autoBranchTemplate", help: "branch name template; must include {shortID} or {missionID}", validate: optionalFormValue(config.ValidateAutoBranchTemplate),
+			get: func(rc *config.RepoConfig) string { return rc.AutoBranchTemplate },
+			set: func(rc *config.RepoConfig, v string) { rc.AutoBranchTemplate = v }},
+		{key: "upstream", help: "canonical name of the repo this one forks", validate: optionalFormValue(validateFormRepoName),
+			get: func(rc *config.RepoConfig) string { return rc.Upstream },
+			set: func(rc *config.RepoConfig, v string) { rc.Upstream = v }},
+		{key: "backend", help: "agent backend for the repo's missions",
+			get: func(rc *config.RepoConfig) string { return rc.Backend },
+			set: func(rc *config.RepoConfig, v string) { rc.Backend = v }},
+		{key: "windowTitleTemplate", help: "tmux window title template", validate: config.ValidateWindowTitleTemplate,
+			get: func(rc *config.RepoConfig) string { return rc.WindowTitleTemplate },
+			set: func(rc *config.RepoConfig, v string) { rc.WindowTitleTemplate = v }},
+		{key: "reviewRequired", help: "true to hold pushes for 'agenc mission approve'", validate: validateFormBool,
+			get: func(rc *config.RepoConfig) string { return formatFormBool(rc.ReviewRequired) },
+			set: func(rc *config.RepoConfig, v string) { rc.ReviewRequired = parseFormBool(v) }},
+		{key: "alwaysSynced", help: "true to keep the repo synced in the background", validate: validateFormBool,
+			get: func(rc *config.RepoConfig) string { return formatFormBool(rc.AlwaysSynced) },
+			set: func(rc *config.RepoConfig, v string) { rc.AlwaysSynced = parseFormBool(v) }},
+	},
+)
+
+var cronsFormSpec = newConfigFormSpec("crons",
+	func(cfg *config.AgencConfig) *map[string]config.CronConfig { return &cfg.Crons },
+	config.ValidateCronName,
+	[]configFormFieldDef[config.CronConfig]{
+		{key: "schedule", help: "cron expression, e.g. 0 9 * * 1-5; leave empty with runAt", validate: optionalFormValue(config.ValidateCronSchedule),
+			get: func(c *config.CronConfig) string { return c.Schedule },
+			set: func(c *config.CronConfig, v string) { c.Schedule = v }},
+		{key: "runAt", help: "one-shot fire time, e.g. 2026-06-01 09:00", validate: optionalFormValue(validateFormRunAt),
+			get: func(c *config.CronConfig) string { return c.RunAt },
+			set: func(c *config.CronConfig, v string) { c.RunAt = v }},
+		{key: "prompt", help: "initial prompt for each run's mission", validate: validateFormRequired,
+			get: func(c *config.CronConfig) string { return c.Prompt },
+			set: func(c *config.CronConfig, v string) { c.Prompt = v }},
+		{key: "description", help: "human-readable description",
+			get: func(c *config.CronConfig) string { return c.Description },
+			set: func(c *config.CronConfig, v string) { c.Description = v }},
+		{key: "repo", help: "canonical repo to clone into each run", validate: optionalFormValue(validateFormRepoName),
+			get: func(c *config.CronConfig) string { return c.Repo },
+			set: func(c *config.CronConfig, v string) { c.Repo = v }},
+		{key: "ref", help: "branch, tag, or commit each run checks out", validate: optionalFormValue(config.ValidateCronRef),
+			get: func(c *config.CronConfig) string { return c.Ref },
+			set: func(c *config.CronConfig, v string) { c.Ref = v }},
+		{key: "enabled", help: "true or false; empty means enabled", validate: validateFormBool,
+			get: func(c *config.CronConfig) string { return formatFormBoolPtr(c.Enabled) },
+			set: func(c *config.CronConfig, v string) { c.Enabled = parseFormBoolPtr(v) }},
+		{key: "after", help: "cron whose run must succeed first today", validate: optionalFormValue(config.ValidateCronName),
+			get: func(c *config.CronConfig) string { return c.After },
+			set: func(c *config.CronConfig, v string) { c.After = v }},
+		{key: "maxPrompts", help: "stop each run after this many prompts; 0 for no limit", validate: validateFormNonNegativeInt,
+			get: func(c *config.CronConfig) string { return formatFormInt(c.MaxPrompts) },
+			set: func(c *config.CronConfig, v string) { c.MaxPrompts, _ = strconv.Atoi(v) }},
+		{key: "budgetUsd", help: "stop each run at this estimated spend; 0 for no limit", validate: validateFormNonNegativeFloat,
+			get: func(c *config.CronConfig) string { return formatFormFloat(c.BudgetUSD) },
+			set: func(c *config.CronConfig, v string) { c.BudgetUSD, _ = strconv.ParseFloat(v, 64) }},
+		{key: "project", help: "project each run's mission joins", validate: optionalFormValue(config.ValidateProjectName),
+			get: func(c *config.CronConfig) string { return c.Project },
+			set: func(c *config.CronConfig, v string) { c.Project = v }},
+		{key: "ignoreQuietHours", help: "true to run during quietHours", validate: validateFormBool,
+			get: func(c *config.CronConfig) string { return formatFormBool(c.IgnoreQuietHours) },
+			set: func(c *config.CronConfig, v string) { c.IgnoreQuietHours = parseFormBool(v) }},
+		{key: "resumePolicy", help: "fresh, continue, or forkIfBehind(N)", validate: validateFormCronResumePolicy,
+			get: func(c *config.CronConfig) string { return c.ResumePolicy },
+			set: func(c *config.CronConfig, v string) { c.ResumePolicy = v }},
+		{key: "alertOnConsecutiveFailures", help: "alert after this many failed runs in a row; 0 for never", validate: validateFormNonNegativeInt,
+			get: func(c *config.CronConfig) string { return formatFormInt(c.AlertOnConsecutiveFailures) },
+			set: func(c *config.CronConfig, v string) { c.AlertOnConsecutiveFailures, _ = strconv.Atoi(v) }},
+	},
+)
+
+var paletteCommandsFormSpec = newConfigFormSpec("paletteCommands",
+	func(cfg *config.AgencConfig) *map[string]config.PaletteCommandConfig { return &cfg.PaletteCommands },
+	config.ValidatePaletteCommandName,
+	[]configFormFieldDef[config.PaletteCommandConfig]{
+		{key: "title", help: "title shown in the palette picker",
+			get: func(c *config.PaletteCommandConfig) string { return formatFormStringPtr(c.Title) },
+			set: func(c *config.PaletteCommandConfig, v string) { c.Title = parseFormStringPtr(v) }},
+		{key: "description", help: "shown alongside the title",
+			get: func(c *config.PaletteCommandConfig) string { return formatFormStringPtr(c.Description) },
+			set: func(c *config.PaletteCommandConfig, v string) { c.Description = parseFormStringPtr(v) }},
+		{key: "command", help: "command to run; may use {{input:Label}}", validate: config.ValidatePaletteCommandInputs,
+			get: func(c *config.PaletteCommandConfig) string { return formatFormStringPtr(c.Command) },
+			set: func(c *config.PaletteCommandConfig, v string) { c.Command = parseFormStringPtr(v) }},
+		{key: "tmuxKeybinding", help: `tmux key, e.g. "f" or "-n C-s" for global`,
+			get: func(c *config.PaletteCommandConfig) string { return formatFormStringPtr(c.TmuxKeybinding) },
+			set: func(c *config.PaletteCommandConfig, v string) { c.TmuxKeybinding = parseFormStringPtr(v) }},
+		{key: "disabled", help: "true to hide the command", validate: validateFormBool,
+			get: func(c *config.PaletteCommandConfig) string { return formatFormBool(c.Disabled) },
+			set: func(c *config.PaletteCommandConfig, v string) { c.Disabled = parseFormBool(v) }},
+	},
+)
+
+// optionalFormValue wraps validate so an empty value, meaning "unset",
+// always passes.
+func optionalFormValue(validate func(string) error) func(string) error {
+	return func(value string) error {
+		if value == "" {
+			return nil
+		}
+		return validate(value)
+	}
+}
+
+func validateFormRequired(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return stacktrace.NewError("required")
+	}
+	return nil
+}
+
+func validateFormRepoName(name string) error {
+	if !config.IsCanonicalRepoName(name) {
+		return stacktrace.NewError("must be in canonical format 'host/owner/repo'")
+	}
+	return nil
+}
+
+func validateFormRunAt(value string) error {
+	_, err := config.ParseCronRunAt(value)
+	return err
+}
+
+func validateFormCronResumePolicy(value string) error {
+	_, err := config.ParseCronResumePolicy(value)
+	return err
+}
+
+func validateFormBool(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return stacktrace.NewError("must be true or false")
+	}
+	return nil
+}
+
+func validateFormNonNegativeInt(value string) error {
+	if value == "" {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return stacktrace.NewError("must be a whole number of at least 0")
+	}
+	return nil
+}
+
+func validateFormNonNegativeFloat(value string) error {
+	if value == "" {
+		return nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err != nil || f < 0 {
+		return stacktrace.NewError("must be a number of at least 0")
+	}
+	return nil
+}
+
+func formatFormBool(b bool) string {
+	if b {
+		return "true"
+	}
+	return ""
+}
+
+func parseFormBool(value string) bool {
+	b, _ := strconv.ParseBool(value)
+	return b
+}
+
+func formatFormBoolPtr(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func parseFormBoolPtr(value string) *bool {
+	if value == "" {
+		return nil
+	}
+	b := parseFormBool(value)
+	return &b
+}
+
+func formatFormInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func formatFormFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatFormStringPtr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func parseFormStringPtr(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return config.StringPtr(value)
+}
+
+// configFormSave is the entry the user saved from the form.
+type configFormSave struct {
+	name   string
+	values []string
+}
+
+// configFormModel is the bubbletea model behind 'agenc config edit <section>
+// --form'. It lists the section's entries, then edits one entry's fields with
+// inline validation. Saving checks the whole config with the entry applied
+// and quits; the caller writes the saved entry.
+type configFormModel struct {
+	spec *configFormSpec
+	cfg  *config.AgencConfig // as loaded; never modified
+
+	names  []string
+	cursor int // len(names) selects the "new entry" row
+
+	// Form state. fields is nil while the entry list is showing. For a new
+	// entry, fields[0] is the entry name.
+	entryName string
+	isNew     bool
+	fields    []configFormField
+	focus     int
+	formErr   string
+
+	saved *configFormSave
+
+	width int
+}
+
+func newConfigFormModel(spec *configFormSpec, cfg *config.AgencConfig) *configFormModel {
+	return &configFormModel{
+		spec:  spec,
+		cfg:   cfg,
+		names: spec.names(cfg),
+		width: 100,
+	}
+}
+
+func (m *configFormModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *configFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.fields != nil {
+			return m.updateForm(msg)
+		}
+		return m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m *configFormModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.names) {
+			m.cursor++
+		}
+	case "enter":
+		m.openEntry()
+	}
+	return m, nil
+}
+
+// openEntry switches to the form for the entry under the cursor, or for a
+// new entry when the cursor is on the last row.
+func (m *configFormModel) openEntry() {
+	m.isNew = m.cursor == len(m.names)
+	m.focus = 0
+	m.formErr = ""
+	if m.isNew {
+		m.entryName = ""
+		nameField := configFormField{key: "name", help: "name of the new " + m.spec.section + " entry", validate: m.validateNewName}
+		m.fields = append([]configFormField{nameField}, m.spec.fields(m.cfg, "")...)
+		return
+	}
+	m.entryName = m.names[m.cursor]
+	m.fields = m.spec.fields(m.cfg, m.entryName)
+	for i := range m.fields {
+		m.validateField(i)
+	}
+}
+
+func (m *configFormModel) validateNewName(name string) error {
+	if err := m.spec.validateName(name); err != nil {
+		return err
+	}
+	for _, existing := range m.names {
+		if existing == name {
+			return stacktrace.NewError("'%s' already exists", name)
+		}
+	}
+	return nil
+}
+
+func (m *configFormModel) validateField(i int) {
+	field := &m.fields[i]
+	field.err = nil
+	if field.validate != nil {
+		field.err = field.validate(field.value)
+	}
+}
+
+func (m *configFormModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.fields = nil
+		return m, nil
+	case tea.KeyUp, tea.KeyShiftTab:
+		if m.focus > 0 {
+			m.focus--
+		}
+	case tea.KeyDown, tea.KeyTab, tea.KeyEnter:
+		if m.focus < len(m.fields)-1 {
+			m.focus++
+		}
+	case tea.KeyCtrlS:
+		return m.save()
+	case tea.KeyBackspace:
+		field := &m.fields[m.focus]
+		if runes := []rune(field.value); len(runes) > 0 {
+			field.value = string(runes[:len(runes)-1])
+		}
+		m.validateField(m.focus)
+	case tea.KeyCtrlU:
+		m.fields[m.focus].value = ""
+		m.validateField(m.focus)
+	case tea.KeyRunes, tea.KeySpace:
+		m.fields[m.focus].value += string(msg.Runes)
+		m.validateField(m.focus)
+	}
+	return m, nil
+}
+
+// save validates every field, then the whole config with the entry applied.
+// Any problem stays on screen; a clean entry is recorded and the form quits.
+func (m *configFormModel) save() (tea.Model, tea.Cmd) {
+	invalid := 0
+	for i := range m.fields {
+		m.validateField(i)
+		if m.fields[i].err != nil {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		m.formErr = fmt.Sprintf("%d field(s) need fixing before saving", invalid)
+		return m, nil
+	}
+
+	name, values := m.entryName, m.fieldValues()
+	if m.isNew {
+		name, values = values[0], values[1:]
+	}
+	candidate := *m.cfg
+	m.spec.apply(&candidate, name, values)
+	if _, err := config.RevalidateAgencConfig(&candidate); err != nil {
+		m.formErr = strings.TrimSpace(fmt.Sprintf("%#s", err))
+		return m, nil
+	}
+
+	m.saved = &configFormSave{name: name, values: values}
+	return m, tea.Quit
+}
+
+func (m *configFormModel) fieldValues() []string {
+	values := make([]string, len(m.fields))
+	for i, field := range m.fields {
+		values[i] = strings.TrimSpace(field.value)
+	}
+	return values
+}
+
+func (m *configFormModel) View() string {
+	if m.fields != nil {
+		return m.viewForm()
+	}
+	return m.viewList()
+}
+
+func (m *configFormModel) viewList() string {
+	var b strings.Builder
+	b.WriteString(dashboardTitleStyle.Render("Edit "+m.spec.section) + "\n\n")
+	rows := append(append([]string{}, m.names...), "+ new entry")
+	for i, row := range rows {
+		if i == m.cursor {
+			b.WriteString(dashboardSelectedStyle.Render("> "+row) + "\n")
+		} else {
+			b.WriteString("  " + row + "\n")
+		}
+	}
+	b.WriteString("\n" + dashboardHelpStyle.Render("↑/↓ move · enter edit · q quit without saving"))
+	return b.String()
+}
+
+func (m *configFormModel) viewForm() string {
+	var b strings.Builder
+	title := "Edit " + m.spec.section + " › " + m.entryName
+	if m.isNew {
+		title = "New " + m.spec.section + " entry"
+	}
+	b.WriteString(dashboardTitleStyle.Render(title) + "\n\n")
+
+	labelWidth := 0
+	for _, field := range m.fields {
+		labelWidth = max(labelWidth, len(field.key))
+	}
+	for i, field := range m.fields {
+		label := padDisplay(field.key, labelWidth)
+		value := field.value
+		if i == m.focus {
+			b.WriteString(configFormFocusStyle.Render("> "+label+"  "+value) + "█\n")
+			b.WriteString(configFormLabelStyle.Render("  "+padDisplay("", labelWidth)+"  "+truncateDisplay(field.help, m.width-labelWidth-4)) + "\n")
+		} else {
+			b.WriteString("  " + configFormLabelStyle.Render(label) + "  " + value + "\n")
+		}
+		if field.err != nil {
+			message := strings.TrimSpace(fmt.Sprintf("%#s", field.err))
+			b.WriteString(dashboardErrorStyle.Render("  "+padDisplay("", labelWidth)+"  ✗ "+message) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.formErr != "" {
+		b.WriteString(dashboardErrorStyle.Render(m.formErr) + "\n")
+	}
+	b.WriteString(dashboardHelpStyle.Render("↑/↓ or tab move · type to edit · ctrl+u clear · ctrl+s save · esc back"))
+	return b.String()
+}
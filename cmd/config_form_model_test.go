@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func typeIntoConfigForm(m *configFormModel, text string) {
+	for _, r := range text {
+		if r == ' ' {
+			m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}})
+			continue
+		}
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func focusConfigFormField(t *testing.T, m *configFormModel, key string) {
+	t.Helper()
+	for i, field := range m.fields {
+		if field.key == key {
+			m.focus = i
+			return
+		}
+	}
+	t.Fatalf("form has no field '%s'", key)
+}
+
+func newTestConfigForm(t *testing.T, spec *configFormSpec) *configFormModel {
+	t.Helper()
+	enabled := false
+	cfg := &config.AgencConfig{
+		Crons: map[string]config.CronConfig{
+			"nightly": {ID: "abc", Schedule: "0 6 * * *", Prompt: "Summarize", Enabled: &enabled, Env: map[string]string{"FOO": "bar"}},
+		},
+		RepoConfigs: map[string]config.RepoConfig{},
+	}
+	return newConfigFormModel(spec, cfg)
+}
+
+func TestConfigForm_EditExistingEntryKeepsUnlistedFields(t *testing.T) {
+	m := newTestConfigForm(t, cronsFormSpec)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.fields == nil || m.entryName != "nightly" {
+		t.Fatalf("expected the form for 'nightly', got entry %q", m.entryName)
+	}
+
+	focusConfigFormField(t, m, "enabled")
+	if got := m.fields[m.focus].value; got != "false" {
+		t.Errorf("expected enabled prefilled as false, got %q", got)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+
+	focusConfigFormField(t, m, "prompt")
+	typeIntoConfigForm(m, " daily")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.saved == nil {
+		t.Fatalf("expected the entry saved, got error %q", m.formErr)
+	}
+
+	cfg := *m.cfg
+	cronsFormSpec.apply(&cfg, m.saved.name, m.saved.values)
+	got := cfg.Crons["nightly"]
+	if got.Prompt != "Summarize daily" || got.Enabled != nil {
+		t.Errorf("expected the edited prompt and enabled cleared, got %+v", got)
+	}
+	if got.ID != "abc" || got.Env["FOO"] != "bar" {
+		t.Errorf("expected fields outside the form kept, got %+v", got)
+	}
+	if m.cfg.Crons["nightly"].Prompt != "Summarize" {
+		t.Error("expected the loaded config untouched")
+	}
+}
+
+func TestConfigForm_InlineValidationBlocksSave(t *testing.T) {
+	m := newTestConfigForm(t, cronsFormSpec)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	focusConfigFormField(t, m, "maxPrompts")
+	typeIntoConfigForm(m, "lots")
+	if m.fields[m.focus].err == nil {
+		t.Fatal("expected an inline error for a non-numeric maxPrompts")
+	}
+	if !strings.Contains(m.View(), "must be a whole number") {
+		t.Errorf("expected the inline error rendered, got:\n%s", m.View())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.saved != nil || !strings.Contains(m.formErr, "1 field(s)") {
+		t.Fatalf("expected saving blocked, got saved=%v formErr=%q", m.saved, m.formErr)
+	}
+
+	for range "lots" {
+		m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	typeIntoConfigForm(m, "5")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.saved == nil {
+		t.Fatalf("expected the entry saved after fixing it, got error %q", m.formErr)
+	}
+}
+
+func TestConfigForm_NewEntry(t *testing.T) {
+	m := newTestConfigForm(t, cronsFormSpec)
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.isNew || m.fields[0].key != "name" {
+		t.Fatalf("expected a new-entry form with a name field, got %+v", m.fields)
+	}
+
+	typeIntoConfigForm(m, "nightly")
+	if m.fields[0].err == nil {
+		t.Error("expected an inline error for a duplicate name")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	typeIntoConfigForm(m, "weekly")
+
+	// Whole-config validation catches what no single field can: a cron
+	// needs a schedule or runAt
+	focusConfigFormField(t, m, "prompt")
+	typeIntoConfigForm(m, "Plan the week")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.saved != nil || m.formErr == "" {
+		t.Fatalf("expected whole-config validation to block saving, got saved=%v", m.saved)
+	}
+
+	focusConfigFormField(t, m, "schedule")
+	typeIntoConfigForm(m, "0 9 * * 1")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.saved == nil {
+		t.Fatalf("expected the entry saved, got error %q", m.formErr)
+	}
+	if m.saved.name != "weekly" || len(m.saved.values) != len(m.fields)-1 {
+		t.Errorf("expected the name split from the field values, got %+v", m.saved)
+	}
+}
+
+func TestConfigForm_EscReturnsToList(t *testing.T) {
+	m := newTestConfigForm(t, repoConfigFormSpec)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.isNew {
+		t.Fatal("expected an empty section to open a new entry")
+	}
+	typeIntoConfigForm(m, "owner/repo")
+	if m.fields[0].err == nil {
+		t.Error("expected a non-canonical repo name rejected inline")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.fields != nil || m.saved != nil {
+		t.Error("expected esc to discard the form")
+	}
+}
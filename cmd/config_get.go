@@ -14,15 +14,33 @@ import (
 // supportedConfigKeys lists all keys accepted by 'config get' and 'config set'.
 var supportedConfigKeys = []string{
 	"attachedMissionLimit",
+	"autoRestartCrashed",
 	"claudeArgs",
 	"claudeCodeOAuthToken",
+	"cleanupRemoteOnDelete",
 	"defaultModel",
+	"lifecycleHooks.onMissionStart",
+	"lifecycleHooks.onClaudeIdle",
+	"lifecycleHooks.onClaudeBusy",
+	"lifecycleHooks.onMissionEnd",
+	"missionAutoArchiveAfter",
+	"missionAutoDeleteAfter",
+	"missionsMaxConcurrent",
+	"notifications.desktop",
 	"paletteTmuxKeybinding",
+	"releaseChannel",
+	"serverListen",
 	"sessionTitleMaxWords",
+	"statusline.segments",
+	"suspendAfterIdle",
+	"terminalBackend",
+	"tmux.layout",
 	"tmuxWindowTitle.busyBackgroundColor",
 	"tmuxWindowTitle.busyForegroundColor",
 	"tmuxWindowTitle.attentionBackgroundColor",
 	"tmuxWindowTitle.attentionForegroundColor",
+	"windowTitleTemplate",
+	"wsl.windowsClaude",
 }
 
 var configGetCmd = &cobra.Command{
@@ -34,15 +52,32 @@ Prints "unset" if the key has not been explicitly set.
 
 Supported keys:
   attachedMissionLimit                       Max missions attachable to non-pool tmux sessions concurrently (positive integer; unset = no cap)
+  autoRestartCrashed                         Respawn the wrapper of a mission the server marks crashed (default: false)
   claudeArgs                                   Extra CLI flags passed to Claude Code (comma-separated, e.g., "--chrome,--verbose")
   claudeCodeOAuthToken                       Claude Code OAuth token (stored in secure token file, not config.yml)
+  cleanupRemoteOnDelete                      Delete a removed mission's pushed auto-branch and close its draft PR: "always", "never", or "ask" (default: "never")
   defaultModel                                 Default Claude model for missions (e.g., "opus", "sonnet", "claude-opus-4-6")
+  lifecycleHooks.onMissionStart              Shell command run after a mission's wrapper starts Claude (AGENC_* env vars describe the mission)
+  lifecycleHooks.onClaudeIdle                Shell command run when Claude finishes a turn and waits for input
+  lifecycleHooks.onClaudeBusy                Shell command run when Claude starts working on a prompt
+  lifecycleHooks.onMissionEnd                Shell command run after Claude exits (exit code in AGENC_EXIT_CODE)
+  missionAutoArchiveAfter                    Archive missions with no heartbeat for this long, e.g. "30d" (unset = never)
+  missionAutoDeleteAfter                     Delete missions archived for longer than this, e.g. "90d" (unset = never)
+  missionsMaxConcurrent                      Max interactive missions running at once; extra new missions are queued (positive integer; unset = no cap)
   paletteTmuxKeybinding                      Raw bind-key args for the command palette (default: "-T agenc k")
+  releaseChannel                             GitHub releases 'agenc upgrade' installs: "stable" or "beta" (default: "stable")
+  serverListen                               Loopback TCP address for the API, e.g. "tcp:127.0.0.1:7777" (requires API tokens; restart server to apply)
   sessionTitleMaxWords                       Max words in auto-generated session titles (default: 15; range: 3-50)
+  statusline.segments                        Statusline segments under each mission's Claude prompt, comma-separated: mission, branch, configBehind, budget, cron (default: "budget")
+  suspendAfterIdle                           Suspend missions idle this long, even ones open in tmux, e.g. "4h"; attaching resumes them (unset = never)
+  terminalBackend                            Terminal that opens mission windows outside tmux: "tmux", "wezterm", or "zellij" (default: "tmux")
+  tmux.layout                                tmux session mission windows are linked into: "pool", "perRepo", or "perProject" (default: "pool")
   tmuxWindowTitle.busyBackgroundColor        Background color for window tab when Claude is working (default: "colour018", empty = disable)
   tmuxWindowTitle.busyForegroundColor        Foreground color for window tab when Claude is working (default: "", empty = disable)
   tmuxWindowTitle.attentionBackgroundColor   Background color for window tab when Claude needs attention (default: "colour136", empty = disable)
-  tmuxWindowTitle.attentionForegroundColor   Foreground color for window tab when Claude needs attention (default: "", empty = disable)`,
+  tmuxWindowTitle.attentionForegroundColor   Foreground color for window tab when Claude needs attention (default: "", empty = disable)
+  windowTitleTemplate                        Tmux window title template; placeholders: {title}, {repo}, {shortID}, {branch}, {emoji}, {status-emoji} (unset = plain title)
+  wsl.windowsClaude                          Under WSL, run the Windows build of Claude Code (claude.exe) and ingest the Windows profile's .claude (default: false)`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigGet,
 }
@@ -68,10 +103,20 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if isStructuredOutput() {
+		return printStructured(configValueOutput{Key: key, Value: value})
+	}
+
 	fmt.Println(value)
 	return nil
 }
 
+// configValueOutput is the --output json|yaml form of `config get`.
+type configValueOutput struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 // getConfigValue returns the string representation of a config key's current
 // value, or "unset" if the key has not been explicitly set.
 func getConfigValue(agencDirpath string, cfg *config.AgencConfig, key string) (string, error) {
@@ -81,6 +126,13 @@ func getConfigValue(agencDirpath string, cfg *config.AgencConfig, key string) (s
 			return "unset", nil
 		}
 		return strconv.Itoa(*cfg.AttachedMissionLimit), nil
+	case "autoRestartCrashed":
+		return strconv.FormatBool(cfg.AutoRestartCrashed), nil
+	case "missionsMaxConcurrent":
+		if cfg.MissionsMaxConcurrent == 0 {
+			return "unset", nil
+		}
+		return strconv.Itoa(cfg.MissionsMaxConcurrent), nil
 	case "claudeArgs":
 		if len(cfg.ClaudeArgs) == 0 {
 			return "unset", nil
@@ -95,18 +147,59 @@ func getConfigValue(agencDirpath string, cfg *config.AgencConfig, key string) (s
 			return "unset", nil
 		}
 		return token, nil
+	case "cleanupRemoteOnDelete":
+		return cfg.GetCleanupRemoteOnDelete(), nil
 	case "defaultModel":
 		if cfg.DefaultModel == "" {
 			return "unset", nil
 		}
 		return cfg.DefaultModel, nil
+	case "lifecycleHooks.onMissionStart",
+		"lifecycleHooks.onClaudeIdle",
+		"lifecycleHooks.onClaudeBusy",
+		"lifecycleHooks.onMissionEnd":
+		command := *lifecycleHookField(cfg.GetLifecycleHooks(), key)
+		if command == "" {
+			return "unset", nil
+		}
+		return command, nil
+	case "missionAutoArchiveAfter":
+		if cfg.MissionAutoArchiveAfter == "" {
+			return "unset", nil
+		}
+		return cfg.MissionAutoArchiveAfter, nil
+	case "missionAutoDeleteAfter":
+		if cfg.MissionAutoDeleteAfter == "" {
+			return "unset", nil
+		}
+		return cfg.MissionAutoDeleteAfter, nil
+	case "notifications.desktop":
+		return strconv.FormatBool(cfg.IsDesktopNotificationsEnabled()), nil
 	case "paletteTmuxKeybinding":
 		if cfg.PaletteTmuxKeybinding == "" {
 			return "unset", nil
 		}
 		return cfg.PaletteTmuxKeybinding, nil
+	case "releaseChannel":
+		return cfg.GetReleaseChannel(), nil
+	case "serverListen":
+		if cfg.ServerListen == "" {
+			return "unset", nil
+		}
+		return cfg.ServerListen, nil
 	case "sessionTitleMaxWords":
 		return strconv.Itoa(cfg.GetSessionTitleMaxWords()), nil
+	case "statusline.segments":
+		return strings.Join(cfg.GetStatuslineSegments(), ","), nil
+	case "suspendAfterIdle":
+		if cfg.SuspendAfterIdle == "" {
+			return "unset", nil
+		}
+		return cfg.SuspendAfterIdle, nil
+	case "terminalBackend":
+		return cfg.GetTerminalBackend(), nil
+	case "tmux.layout":
+		return cfg.GetTmuxLayout(), nil
 	case "tmuxWindowTitle.busyBackgroundColor":
 		return formatOptionalColor(getTmuxWindowTitleField(cfg, key)), nil
 	case "tmuxWindowTitle.busyForegroundColor":
@@ -115,6 +208,13 @@ func getConfigValue(agencDirpath string, cfg *config.AgencConfig, key string) (s
 		return formatOptionalColor(getTmuxWindowTitleField(cfg, key)), nil
 	case "tmuxWindowTitle.attentionForegroundColor":
 		return formatOptionalColor(getTmuxWindowTitleField(cfg, key)), nil
+	case "windowTitleTemplate":
+		if cfg.WindowTitleTemplate == "" {
+			return "unset", nil
+		}
+		return cfg.WindowTitleTemplate, nil
+	case "wsl.windowsClaude":
+		return strconv.FormatBool(cfg.WSL != nil && cfg.WSL.WindowsClaude), nil
 	default:
 		return "", stacktrace.NewError(
 			"unknown config key '%s'; supported keys: %s",
@@ -143,6 +243,21 @@ func getTmuxWindowTitleField(cfg *config.AgencConfig, key string) *string {
 	}
 }
 
+// lifecycleHookField returns a pointer to the command for a lifecycleHooks
+// sub-key. hooks must be non-nil.
+func lifecycleHookField(hooks *config.LifecycleHooksConfig, key string) *string {
+	switch key {
+	case "lifecycleHooks.onMissionStart":
+		return &hooks.OnMissionStart
+	case "lifecycleHooks.onClaudeIdle":
+		return &hooks.OnClaudeIdle
+	case "lifecycleHooks.onClaudeBusy":
+		return &hooks.OnClaudeBusy
+	default:
+		return &hooks.OnMissionEnd
+	}
+}
+
 // formatOptionalColor formats a *string color value for display:
 // nil → "unset", empty → "disabled", otherwise the value itself.
 func formatOptionalColor(v *string) string {
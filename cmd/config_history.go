@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var configHistoryLimitFlag int
+
+var configHistoryCmd = &cobra.Command{
+	Use:   historyCmdStr,
+	Short: "Show recorded snapshots of the AgenC config",
+	Long: fmt.Sprintf(`Show recorded snapshots of config.yml and claude-modifications/, newest first.
+
+The server records a snapshot in a local git repo ($AGENC_DIRPATH/%s)
+every time the config changes, whether through 'agenc config' commands or a
+hand edit. This is separate from any git repo you keep in the config directory
+yourself.
+
+Use 'agenc config diff <commit>' to see what changed since a snapshot and
+'agenc config rollback <commit>' to restore one.`, config.ConfigHistoryDirname),
+	Args: cobra.NoArgs,
+	RunE: runConfigHistory,
+}
+
+func init() {
+	configHistoryCmd.Flags().IntVar(&configHistoryLimitFlag, "limit", 20, "maximum number of entries to show (0 for all)")
+	configCmd.AddCommand(configHistoryCmd)
+}
+
+func runConfigHistory(cmd *cobra.Command, args []string) error {
+	if configHistoryLimitFlag < 0 {
+		return stacktrace.NewError("--limit must be zero or greater")
+	}
+
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+
+	entries, err := config.ListConfigHistory(agencDirpath, configHistoryLimitFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read config history")
+	}
+
+	if isStructuredOutput() {
+		return printStructured(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No config history recorded yet.")
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("COMMIT", "WHEN", "MESSAGE")
+	for _, entry := range entries {
+		tbl.AddRow(
+			entry.ShortCommit,
+			entry.Time.Local().Format("2006-01-02 15:04:05"),
+			entry.Message,
+		)
+	}
+	tbl.Print()
+	return nil
+}
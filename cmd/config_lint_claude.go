@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var configLintClaudeCmd = &cobra.Command{
+	Use:   lintClaudeCmdStr + " [file]",
+	Short: "Check the Claude settings.json missions are built from",
+	Long: `Check the Claude settings.json missions are built from.
+
+Lints the hooks, permissions, and statusLine sections of your Claude
+settings.json (as mirrored into AgenC's shadow repo) and of the AgenC-specific
+settings.json ('agenc config settings-json'). Each problem is reported with
+its file and JSON path:
+
+  settings.json: error: hooks.PreToolUse[0].hooks[0].command: command hooks need a non-empty 'command' string
+
+Errors (malformed hooks, permission rules that aren't strings, a statusLine
+without a command) stop missions from being created until fixed. Warnings,
+such as hooks under an event Claude never fires, are only reported here.
+
+Pass a file path to lint a candidate settings.json before copying it into
+place. Exits non-zero if any errors are found.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigLintClaude,
+}
+
+func init() {
+	configCmd.AddCommand(configLintClaudeCmd)
+}
+
+func runConfigLintClaude(cmd *cobra.Command, args []string) error {
+	var results []claudeconfig.SettingsLintResult
+	if len(args) == 1 {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read '%s'", args[0])
+		}
+		results = []claudeconfig.SettingsLintResult{{Filepath: args[0], Issues: claudeconfig.LintSettings(data)}}
+	} else {
+		// Deliberately skip ensureConfigured: linting must not trigger setup
+		agencDirpath, err := config.GetAgencDirpath()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to get agenc directory path")
+		}
+		results, err = claudeconfig.LintMissionSettingsSources(agencDirpath)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("No Claude settings.json found")
+			return nil
+		}
+	}
+
+	numErrors := 0
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			severity := ansiYellow + issue.Severity + ansiReset
+			if issue.Severity == config.ConfigIssueSeverityError {
+				severity = ansiRed + issue.Severity + ansiReset
+				numErrors++
+			}
+			fmt.Printf("%s: %s: %s\n", result.Filepath, severity, issue)
+		}
+	}
+
+	if numErrors > 0 {
+		return stacktrace.NewError("Claude settings have %d error(s)", numErrors)
+	}
+	for _, result := range results {
+		fmt.Printf("%s is valid\n", result.Filepath)
+	}
+	return nil
+}
@@ -29,6 +29,11 @@ binding that works anywhere without the AgenC leader, prefix with "-n":
   --keybinding="f"       → prefix + a, f  (AgenC table)
   --keybinding="-n C-s"  → Ctrl-s globally (root table, no prefix needed)
 
+Commands may contain {{input:Label}} placeholders. Before the command runs,
+the palette asks for each value in a small prompt (submit an empty value to
+cancel) and substitutes it single-quoted, so write placeholders as bare words
+rather than inside quotes. A label used twice is asked for once.
+
 Examples:
   agenc config paletteCommand add dotfiles \
     --title="📁 Open dotfiles" \
@@ -40,6 +45,10 @@ Examples:
     --command="agenc mission stop \$AGENC_CALLING_MISSION_UUID" \
     --keybinding="-n C-s"
 
+  agenc config paletteCommand add askRepo \
+    --title="🎯 Mission with prompt" \
+    --command="agenc mission new {{input:Repo}} --prompt {{input:Prompt}}"
+
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigPaletteCommandAdd,
@@ -78,6 +87,10 @@ func runConfigPaletteCommandAdd(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to read --%s flag", paletteCommandCommandFlagName)
 	}
 
+	if err := config.ValidatePaletteCommandInputs(command); err != nil {
+		return err
+	}
+
 	keybinding, _ := cmd.Flags().GetString(paletteCommandKeybindingFlagName)
 	description, _ := cmd.Flags().GetString(paletteCommandDescriptionFlagName)
 
@@ -33,11 +33,11 @@ func runConfigPaletteCommandLs(cmd *cobra.Command, args []string) error {
 
 	// Also check for disabled builtins to show them
 	type displayEntry struct {
-		Name       string
-		Title      string
-		Keybinding string
-		Command    string
-		Source     string
+		Name       string `json:"name"`
+		Title      string `json:"title"`
+		Keybinding string `json:"keybinding"`
+		Command    string `json:"command"`
+		Source     string `json:"source"`
 	}
 
 	var entries []displayEntry
@@ -69,6 +69,10 @@ func runConfigPaletteCommandLs(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if isStructuredOutput() {
+		return printStructured(entries)
+	}
+
 	if len(entries) == 0 {
 		fmt.Println("No palette commands configured.")
 		return nil
@@ -17,8 +17,9 @@ var configPaletteCommandRmCmd = &cobra.Command{
 
 For custom commands, removes the entry entirely.
 For built-in commands, removes the config override and restores defaults.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runConfigPaletteCommandRm,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigPaletteCommandRm,
+	ValidArgsFunction: completePaletteCommandName,
 }
 
 func init() {
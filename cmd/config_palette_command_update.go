@@ -33,8 +33,9 @@ Example:
   agenc config paletteCommand update stopMission --keybinding="-n C-s"
   agenc config paletteCommand update nukeMissions --disabled
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: runConfigPaletteCommandUpdate,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigPaletteCommandUpdate,
+	ValidArgsFunction: completePaletteCommandName,
 }
 
 func init() {
@@ -99,6 +100,12 @@ func runConfigPaletteCommandUpdate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if existing.Command != nil {
+		if err := config.ValidatePaletteCommandInputs(*existing.Command); err != nil {
+			return err
+		}
+	}
+
 	if err := applyBoolFlag(cmd, paletteCommandDisabledFlagName, func(disabled bool) error {
 		existing.Disabled = disabled
 		return nil
@@ -10,13 +10,14 @@ var configRepoConfigCmd = &cobra.Command{
 	Long: `Manage per-repo configuration in config.yml.
 
 Each repo is identified by its canonical name (github.com/owner/repo) and
-supports five optional settings:
+supports these optional settings, among others:
 
   alwaysSynced       - server keeps the repo continuously fetched (every 60s)
   emoji              - emoji to display for missions using this repo
   description        - human/agent-readable description of what the repo is for
   defaultModel       - default Claude model for missions using this repo
   trustedMcpServers  - pre-approve MCP servers to skip the consent prompt
+  mcpServers         - MCP servers every mission for the repo gets (edit config.yml)
 
 Example config.yml:
 
@@ -27,6 +28,10 @@ Example config.yml:
       description: "The AgenC orchestration system"
       defaultModel: opus
       trustedMcpServers: all
+      mcpServers:
+        sentry:
+          command: npx
+          args: ["-y", "@sentry/mcp-server"]
     github.com/owner/other:
       alwaysSynced: true
 `,
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 
+	"github.com/goccy/go-yaml"
+	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
 
 	"github.com/odyssey/agenc/internal/config"
@@ -28,6 +31,10 @@ func runConfigRepoConfigLs(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if isStructuredOutput() {
+		return printRepoConfigsStructured(cfg.RepoConfigs)
+	}
+
 	if len(cfg.RepoConfigs) == 0 {
 		fmt.Println("No per-repo configuration.")
 		return nil
@@ -67,6 +74,24 @@ func runConfigRepoConfigLs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printRepoConfigsStructured prints repo configs keyed by repo name, using
+// the same camelCase keys as the repoConfig section of config.yml. RepoConfig
+// only carries yaml tags, so it is round-tripped through YAML first.
+func printRepoConfigsStructured(repoConfigs map[string]config.RepoConfig) error {
+	if repoConfigs == nil {
+		repoConfigs = map[string]config.RepoConfig{}
+	}
+	yamlData, err := yaml.Marshal(repoConfigs)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to encode repo configs")
+	}
+	jsonData, err := yaml.YAMLToJSON(yamlData)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to encode repo configs")
+	}
+	return printStructured(json.RawMessage(jsonData))
+}
+
 func formatTrustedMcpServers(t *config.TrustedMcpServers) string {
 	if t == nil {
 		return "--"
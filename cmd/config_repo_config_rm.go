@@ -18,8 +18,9 @@ This removes the entire repoConfig entry for the repo, including alwaysSynced
 and emoji settings. The cloned repo itself is not deleted (use 'agenc repo rm'
 for that).
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: runConfigRepoConfigRm,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigRepoConfigRm,
+	ValidArgsFunction: completeRepoName,
 }
 
 func init() {
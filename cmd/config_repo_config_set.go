@@ -15,7 +15,7 @@ var configRepoConfigSetCmd = &cobra.Command{
 	Short: "Set per-repo configuration",
 	Long: `Set or update configuration for a repository.
 
-The repo must be specified in canonical format (github.com/owner/repo).
+The repo must be specified in canonical format (host/owner/repo, e.g. github.com/owner/repo).
 At least one flag must be provided.
 
 Examples:
@@ -24,9 +24,20 @@ Examples:
   agenc config repoConfig set github.com/owner/repo --always-synced=true --emoji="🔥"
   agenc config repoConfig set github.com/owner/repo --description="The AgenC orchestration system"
   agenc config repoConfig set github.com/owner/repo --post-update-hook="make setup"
+  agenc config repoConfig set github.com/owner/repo --post-update-hook="npm ci" --post-update-hook-cache="node_modules"
+  agenc config repoConfig set github.com/owner/repo --workspace-mode=worktree
+  agenc config repoConfig set github.com/owner/repo --auto-branch=true --auto-branch-template="feature/{slug}-{shortID}"
+  agenc config repoConfig set github.com/owner/repo --isolation=container
+  agenc config repoConfig set github.com/owner/repo --backend=codex
+  agenc config repoConfig set github.com/owner/repo --claude-md-append=~/notes/owner-repo.md
+  agenc config repoConfig set github.com/owner/repo --claude-md-append="Run 'make lint' before committing."
+  agenc config repoConfig set github.com/owner/repo --allowed-hosts="github.com,registry.npmjs.org"
+  agenc config repoConfig set github.com/owner/repo --review-required=true
+  agenc config repoConfig set github.com/owner/repo --window-title-template="{status-emoji} {repo}:{branch}"
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: runConfigRepoConfigSet,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runConfigRepoConfigSet,
+	ValidArgsFunction: completeRepoName,
 }
 
 func init() {
@@ -38,7 +49,19 @@ func init() {
 	configRepoConfigSetCmd.Flags().String(repoConfigTrustedMcpServersFlagName, "", `MCP server trust: "all", comma-separated server names, or "" to clear`)
 	configRepoConfigSetCmd.Flags().String(repoConfigDefaultModelFlagName, "", `default Claude model for missions using this repo (e.g., "opus", "sonnet")`)
 	configRepoConfigSetCmd.Flags().String(repoConfigPostUpdateHookFlagName, "", `shell command to run after repo updates (e.g., "make setup"); empty to clear`)
+	configRepoConfigSetCmd.Flags().String(repoConfigPostUpdateHookCacheFlagName, "", `paths the hook populates, shared between missions via a per-repo cache: comma-separated (e.g., "node_modules,.venv"); empty to clear`)
 	configRepoConfigSetCmd.Flags().String(repoConfigClaudeArgsFlagName, "", `extra Claude CLI args: comma-separated (e.g., "--chrome,--verbose"); empty to clear`)
+	configRepoConfigSetCmd.Flags().String(repoConfigWorkspaceModeFlagName, "", `how new missions get the repo: "copy" (full clone) or "worktree" (git worktree of the library clone); empty to clear`)
+	configRepoConfigSetCmd.Flags().Bool(repoConfigAutoBranchFlagName, false, "start each new mission on a fresh branch instead of the default branch")
+	configRepoConfigSetCmd.Flags().String(repoConfigAutoBranchTemplateFlagName, "", `branch name template for --auto-branch; supports {shortID}, {missionID}, {slug} (default "`+config.DefaultAutoBranchTemplate+`"); empty to clear`)
+	configRepoConfigSetCmd.Flags().String(repoConfigIsolationFlagName, "", `where missions run Claude: "host" or "container" (a Docker/Podman sandbox); empty to clear`)
+	configRepoConfigSetCmd.Flags().String(repoConfigUpstreamFlagName, "", `repo this one is a fork of, in canonical format (host/owner/repo); new missions get an "upstream" remote; empty to clear`)
+	configRepoConfigSetCmd.Flags().String(repoConfigBackendFlagName, "", `agent backend new missions run: "claude", "codex", or an agentBackends entry; empty to clear`)
+	configRepoConfigSetCmd.Flags().String(repoConfigClaudeMdAppendFlagName, "", `extra CLAUDE.md instructions for missions using this repo: a markdown file path (absolute, ~/, or relative to the config dir) or inline text; empty to clear`)
+	configRepoConfigSetCmd.Flags().String(repoConfigAllowedHostsFlagName, "", `restrict missions' network access to these hosts and their subdomains: comma-separated (e.g., "github.com,pypi.org"); empty to lift the restriction`)
+	configRepoConfigSetCmd.Flags().String(repoConfigWindowTitleTemplateFlagName, "", "tmux window title template for the repo's missions, overriding the global windowTitleTemplate; supports {title}, {repo}, {shortID}, {branch}, {emoji}, {status-emoji}; empty to clear")
+	configRepoConfigSetCmd.Flags().Bool(repoConfigReviewRequiredFlagName, false, "send missions' pushes to agenc/review/<branch> holding branches until 'agenc mission approve'")
+	_ = configRepoConfigSetCmd.RegisterFlagCompletionFunc(repoConfigBackendFlagName, completeBackendFlag)
 }
 
 // applyAlwaysSyncedFlag enforces the invariant that a repo with a configured
@@ -61,18 +84,24 @@ func runConfigRepoConfigSet(cmd *cobra.Command, args []string) error {
 	repoName := args[0]
 
 	if !config.IsCanonicalRepoName(repoName) {
-		return stacktrace.NewError("repo must be in canonical format 'github.com/owner/repo'; got '%s'", repoName)
+		return stacktrace.NewError("repo must be in canonical format 'host/owner/repo'; got '%s'", repoName)
 	}
 
 	allFlags := []string{
 		repoConfigAlwaysSyncedFlagName, repoConfigEmojiFlagName,
 		repoConfigTitleFlagName, repoConfigDescriptionFlagName,
 		repoConfigTrustedMcpServersFlagName, repoConfigDefaultModelFlagName,
-		repoConfigPostUpdateHookFlagName, repoConfigClaudeArgsFlagName,
+		repoConfigPostUpdateHookFlagName, repoConfigPostUpdateHookCacheFlagName,
+		repoConfigClaudeArgsFlagName,
+		repoConfigWorkspaceModeFlagName, repoConfigAutoBranchFlagName,
+		repoConfigAutoBranchTemplateFlagName, repoConfigIsolationFlagName,
+		repoConfigUpstreamFlagName, repoConfigBackendFlagName,
+		repoConfigClaudeMdAppendFlagName, repoConfigAllowedHostsFlagName,
+		repoConfigReviewRequiredFlagName, repoConfigWindowTitleTemplateFlagName,
 	}
 	if !anyFlagChanged(cmd, allFlags) {
-		return stacktrace.NewError("at least one of --%s, --%s, --%s, --%s, --%s, --%s, --%s, or --%s must be provided",
-			repoConfigAlwaysSyncedFlagName, repoConfigEmojiFlagName, repoConfigTitleFlagName, repoConfigDescriptionFlagName, repoConfigTrustedMcpServersFlagName, repoConfigDefaultModelFlagName, repoConfigPostUpdateHookFlagName, repoConfigClaudeArgsFlagName)
+		return stacktrace.NewError("at least one of --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, --%s, or --%s must be provided",
+			repoConfigAlwaysSyncedFlagName, repoConfigEmojiFlagName, repoConfigTitleFlagName, repoConfigDescriptionFlagName, repoConfigTrustedMcpServersFlagName, repoConfigDefaultModelFlagName, repoConfigPostUpdateHookFlagName, repoConfigPostUpdateHookCacheFlagName, repoConfigClaudeArgsFlagName, repoConfigWorkspaceModeFlagName, repoConfigAutoBranchFlagName, repoConfigAutoBranchTemplateFlagName, repoConfigIsolationFlagName, repoConfigUpstreamFlagName, repoConfigBackendFlagName, repoConfigClaudeMdAppendFlagName, repoConfigAllowedHostsFlagName, repoConfigReviewRequiredFlagName, repoConfigWindowTitleTemplateFlagName)
 	}
 
 	cfg, cm, release, err := readConfigWithComments()
@@ -157,6 +186,156 @@ func runConfigRepoConfigSet(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to apply claude-args flag")
 	}
 
+	if err := applyStringFlag(cmd, repoConfigPostUpdateHookCacheFlagName, func(raw string) error {
+		if raw == "" {
+			rc.PostUpdateHookCache = nil
+			return nil
+		}
+		parts := strings.Split(raw, ",")
+		paths := make([]string, 0, len(parts))
+		for _, p := range parts {
+			p = strings.TrimSpace(p)
+			if p == "" {
+				continue
+			}
+			if err := config.ValidateDependencyCachePath(p); err != nil {
+				return err
+			}
+			paths = append(paths, p)
+		}
+		if len(paths) == 0 {
+			return stacktrace.NewError("--%s: no valid paths found in %q", repoConfigPostUpdateHookCacheFlagName, raw)
+		}
+		rc.PostUpdateHookCache = paths
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply post-update-hook-cache flag")
+	}
+
+	if err := applyStringFlag(cmd, repoConfigWorkspaceModeFlagName, func(mode string) error {
+		if mode != "" {
+			if err := config.ValidateWorkspaceMode(mode); err != nil {
+				return err
+			}
+		}
+		rc.WorkspaceMode = mode
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply workspace-mode flag")
+	}
+
+	if err := applyBoolFlag(cmd, repoConfigAutoBranchFlagName, func(enabled bool) error {
+		rc.AutoBranch = enabled
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply auto-branch flag")
+	}
+
+	if err := applyBoolFlag(cmd, repoConfigReviewRequiredFlagName, func(enabled bool) error {
+		rc.ReviewRequired = enabled
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply review-required flag")
+	}
+
+	if err := applyStringFlag(cmd, repoConfigWindowTitleTemplateFlagName, func(template string) error {
+		if err := config.ValidateWindowTitleTemplate(template); err != nil {
+			return err
+		}
+		rc.WindowTitleTemplate = template
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply window-title-template flag")
+	}
+
+	if err := applyStringFlag(cmd, repoConfigAutoBranchTemplateFlagName, func(template string) error {
+		if template != "" {
+			if err := config.ValidateAutoBranchTemplate(template); err != nil {
+				return err
+			}
+		}
+		rc.AutoBranchTemplate = template
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply auto-branch-template flag")
+	}
+
+	if err := applyStringFlag(cmd, repoConfigIsolationFlagName, func(isolation string) error {
+		if isolation != "" {
+			if err := config.ValidateIsolation(isolation); err != nil {
+				return err
+			}
+		}
+		rc.Isolation = isolation
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply isolation flag")
+	}
+
+	if err := applyStringFlag(cmd, repoConfigUpstreamFlagName, func(upstream string) error {
+		if upstream != "" && !config.IsCanonicalRepoName(upstream) {
+			return stacktrace.NewError("--%s must be in canonical format 'host/owner/repo'; got '%s'", repoConfigUpstreamFlagName, upstream)
+		}
+		rc.Upstream = upstream
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply upstream flag")
+	}
+
+	if err := applyStringFlag(cmd, repoConfigBackendFlagName, func(backend string) error {
+		if backend != "" {
+			if err := cfg.ValidateAgentBackend(backend); err != nil {
+				return err
+			}
+		}
+		rc.Backend = backend
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply backend flag")
+	}
+	if rc.Backend != "" && rc.Backend != config.AgentBackendClaude && rc.Isolation == config.IsolationContainer {
+		return stacktrace.NewError("isolation '%s' is only supported with the '%s' backend", config.IsolationContainer, config.AgentBackendClaude)
+	}
+
+	if err := applyStringFlag(cmd, repoConfigClaudeMdAppendFlagName, func(value string) error {
+		// Catch a mistyped path now rather than at the next mission spawn
+		if _, err := config.ResolveClaudeMdAppend(agencDirpath, value); err != nil {
+			return err
+		}
+		rc.ClaudeMdAppend = value
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply claude-md-append flag")
+	}
+
+	if err := applyStringFlag(cmd, repoConfigAllowedHostsFlagName, func(raw string) error {
+		if raw == "" {
+			rc.NetworkPolicy = nil
+			return nil
+		}
+		var hosts []string
+		for _, host := range strings.Split(raw, ",") {
+			host = strings.TrimSpace(host)
+			if host == "" {
+				continue
+			}
+			if err := config.ValidateNetworkPolicyHost(host); err != nil {
+				return err
+			}
+			hosts = append(hosts, host)
+		}
+		if len(hosts) == 0 {
+			return stacktrace.NewError("--%s: no valid hosts found in %q", repoConfigAllowedHostsFlagName, raw)
+		}
+		rc.NetworkPolicy = &config.NetworkPolicyConfig{AllowedHosts: hosts}
+		return nil
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to apply allowed-hosts flag")
+	}
+	if rc.NetworkPolicy != nil && rc.Isolation == config.IsolationContainer {
+		return stacktrace.NewError("--%s is not supported with isolation '%s'", repoConfigAllowedHostsFlagName, config.IsolationContainer)
+	}
+
 	cfg.SetRepoConfig(repoName, rc)
 
 	if err := config.WriteAgencConfig(agencDirpath, cfg, cm); err != nil {
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var configRollbackCmd = &cobra.Command{
+	Use:   rollbackCmdStr + " <commit>",
+	Short: "Restore the config to a recorded snapshot",
+	Long: `Restore config.yml and claude-modifications/ to a snapshot from 'agenc config history'.
+
+The current config is recorded first, so a rollback can itself be undone by
+rolling back to the snapshot taken just before it. The snapshot's config.yml
+is validated before anything is changed.
+
+The server picks up the restored config.yml automatically; running missions
+see restored claude-modifications after a reload.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigRollback,
+}
+
+func init() {
+	configCmd.AddCommand(configRollbackCmd)
+}
+
+func runConfigRollback(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+
+	release, err := config.AcquireConfigLock(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to acquire config lock")
+	}
+	defer release()
+
+	shortCommit, err := config.RollbackConfig(agencDirpath, args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to roll back config")
+	}
+
+	fmt.Printf("Rolled back config to %s\n", shortCommit)
+	return nil
+}
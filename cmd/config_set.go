@@ -19,15 +19,33 @@ var configSetCmd = &cobra.Command{
 
 Supported keys:
   attachedMissionLimit                       Max missions attachable to non-pool tmux sessions concurrently (positive integer; unset = no cap)
+  autoRestartCrashed                         Respawn the wrapper of a mission the server marks crashed (default: false)
   claudeArgs                                   Extra CLI flags passed to Claude Code (comma-separated, e.g., "--chrome,--verbose"; empty to clear)
   claudeCodeOAuthToken                       Claude Code OAuth token (stored in secure token file, not config.yml)
+  cleanupRemoteOnDelete                      Delete a removed mission's pushed auto-branch and close its draft PR: "always", "never", or "ask" (default: "never")
   defaultModel                                 Default Claude model for missions (e.g., "opus", "sonnet", "claude-opus-4-6")
+  lifecycleHooks.onMissionStart              Shell command run after a mission's wrapper starts Claude (AGENC_* env vars describe the mission)
+  lifecycleHooks.onClaudeIdle                Shell command run when Claude finishes a turn and waits for input
+  lifecycleHooks.onClaudeBusy                Shell command run when Claude starts working on a prompt
+  lifecycleHooks.onMissionEnd                Shell command run after Claude exits (exit code in AGENC_EXIT_CODE)
+  missionAutoArchiveAfter                    Archive missions with no heartbeat for this long, e.g. "30d" (unset = never)
+  missionAutoDeleteAfter                     Delete missions archived for longer than this, e.g. "90d" (unset = never)
+  missionsMaxConcurrent                      Max interactive missions running at once; extra new missions are queued (positive integer; unset = no cap)
+  notifications.desktop                      Desktop notification when an unfocused mission goes idle or needs permission (default: false; applies to newly started wrappers)
   paletteTmuxKeybinding                      Raw bind-key args for the command palette (default: "-T agenc k")
+  releaseChannel                             GitHub releases 'agenc upgrade' installs: "stable" or "beta" (default: "stable")
+  serverListen                               Loopback TCP address for the API, e.g. "tcp:127.0.0.1:7777" (requires API tokens; restart server to apply)
   sessionTitleMaxWords                       Max words in auto-generated session titles (default: 15; range: 3-50)
+  statusline.segments                        Statusline segments under each mission's Claude prompt, comma-separated: mission, branch, configBehind, budget, cron (default: "budget")
+  suspendAfterIdle                           Suspend missions idle this long, even ones open in tmux, e.g. "4h"; attaching resumes them (unset = never)
+  terminalBackend                            Terminal that opens mission windows outside tmux: "tmux", "wezterm", or "zellij" (default: "tmux")
+  tmux.layout                                tmux session mission windows are linked into: "pool", "perRepo", or "perProject" (default: "pool")
   tmuxWindowTitle.busyBackgroundColor        Background color for window tab when Claude is working (default: "colour018", empty = disable)
   tmuxWindowTitle.busyForegroundColor        Foreground color for window tab when Claude is working (default: "", empty = disable)
   tmuxWindowTitle.attentionBackgroundColor   Background color for window tab when Claude needs attention (default: "colour136", empty = disable)
   tmuxWindowTitle.attentionForegroundColor   Foreground color for window tab when Claude needs attention (default: "", empty = disable)
+  windowTitleTemplate                        Tmux window title template; placeholders: {title}, {repo}, {shortID}, {branch}, {emoji}, {status-emoji} (unset = plain title)
+  wsl.windowsClaude                          Under WSL, run the Windows build of Claude Code (claude.exe) and ingest the Windows profile's .claude (default: false)
 
 The paletteTmuxKeybinding value is inserted verbatim after "bind-key" in the
 tmux config. By default ("-T agenc k") it lives in the agenc key table, reached
@@ -136,6 +154,27 @@ func setConfigValue(cfg *config.AgencConfig, key, value string) error {
 		}
 		cfg.AttachedMissionLimit = &n
 		return nil
+	case "missionsMaxConcurrent":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return stacktrace.NewError(
+				"missionsMaxConcurrent must be an integer, got %q", value,
+			)
+		}
+		if err := config.ValidateMissionsMaxConcurrent(n); err != nil {
+			return err
+		}
+		cfg.MissionsMaxConcurrent = n
+		return nil
+	case "autoRestartCrashed":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return stacktrace.NewError(
+				"autoRestartCrashed must be true or false, got %q", value,
+			)
+		}
+		cfg.AutoRestartCrashed = enabled
+		return nil
 	case "claudeArgs":
 		if value == "" {
 			cfg.ClaudeArgs = nil
@@ -150,12 +189,63 @@ func setConfigValue(cfg *config.AgencConfig, key, value string) error {
 			cfg.ClaudeArgs = args
 		}
 		return nil
+	case "cleanupRemoteOnDelete":
+		if err := config.ValidateCleanupRemoteOnDelete(value); err != nil {
+			return err
+		}
+		cfg.CleanupRemoteOnDelete = value
+		return nil
 	case "defaultModel":
 		cfg.DefaultModel = value
 		return nil
+	case "lifecycleHooks.onMissionStart",
+		"lifecycleHooks.onClaudeIdle",
+		"lifecycleHooks.onClaudeBusy",
+		"lifecycleHooks.onMissionEnd":
+		if cfg.LifecycleHooks == nil {
+			cfg.LifecycleHooks = &config.LifecycleHooksConfig{}
+		}
+		*lifecycleHookField(cfg.LifecycleHooks, key) = value
+		return nil
+	case "missionAutoArchiveAfter":
+		if _, err := config.ParseRetentionDays(value); err != nil {
+			return err
+		}
+		cfg.MissionAutoArchiveAfter = value
+		return nil
+	case "missionAutoDeleteAfter":
+		if _, err := config.ParseRetentionDays(value); err != nil {
+			return err
+		}
+		cfg.MissionAutoDeleteAfter = value
+		return nil
+	case "notifications.desktop":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return stacktrace.NewError(
+				"notifications.desktop must be true or false, got %q", value,
+			)
+		}
+		if cfg.Notifications == nil {
+			cfg.Notifications = &config.NotificationsConfig{}
+		}
+		cfg.Notifications.Desktop = enabled
+		return nil
 	case "paletteTmuxKeybinding":
 		cfg.PaletteTmuxKeybinding = value
 		return nil
+	case "releaseChannel":
+		if err := config.ValidateReleaseChannel(value); err != nil {
+			return err
+		}
+		cfg.ReleaseChannel = value
+		return nil
+	case "serverListen":
+		if _, err := config.ParseServerListenAddr(value); err != nil {
+			return err
+		}
+		cfg.ServerListen = value
+		return nil
 	case "sessionTitleMaxWords":
 		n, err := strconv.Atoi(value)
 		if err != nil {
@@ -168,12 +258,66 @@ func setConfigValue(cfg *config.AgencConfig, key, value string) error {
 		}
 		cfg.SessionTitleMaxWords = n
 		return nil
+	case "statusline.segments":
+		var segments []string
+		for _, p := range strings.Split(value, ",") {
+			segment := strings.TrimSpace(p)
+			if segment == "" {
+				continue
+			}
+			if err := config.ValidateStatuslineSegment(segment); err != nil {
+				return err
+			}
+			segments = append(segments, segment)
+		}
+		if len(segments) == 0 {
+			cfg.Statusline = nil
+		} else {
+			cfg.Statusline = &config.StatuslineConfig{Segments: segments}
+		}
+		return nil
+	case "suspendAfterIdle":
+		if _, err := config.ParseSuspendAfterIdle(value); err != nil {
+			return err
+		}
+		cfg.SuspendAfterIdle = value
+		return nil
+	case "terminalBackend":
+		if err := config.ValidateTerminalBackend(value); err != nil {
+			return err
+		}
+		cfg.TerminalBackend = value
+		return nil
+	case "tmux.layout":
+		if err := config.ValidateTmuxLayout(value); err != nil {
+			return err
+		}
+		cfg.Tmux = &config.TmuxConfig{Layout: value}
+		return nil
 	case "tmuxWindowTitle.busyBackgroundColor",
 		"tmuxWindowTitle.busyForegroundColor",
 		"tmuxWindowTitle.attentionBackgroundColor",
 		"tmuxWindowTitle.attentionForegroundColor":
 		setTmuxWindowTitleField(cfg, key, &value)
 		return nil
+	case "windowTitleTemplate":
+		if err := config.ValidateWindowTitleTemplate(value); err != nil {
+			return err
+		}
+		cfg.WindowTitleTemplate = value
+		return nil
+	case "wsl.windowsClaude":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return stacktrace.NewError(
+				"wsl.windowsClaude must be true or false, got %q", value,
+			)
+		}
+		if cfg.WSL == nil {
+			cfg.WSL = &config.WSLConfig{}
+		}
+		cfg.WSL.WindowsClaude = enabled
+		return nil
 	default:
 		return stacktrace.NewError(
 			"unknown config key '%s'; supported keys: %s",
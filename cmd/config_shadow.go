@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configShadowCmd = &cobra.Command{
+	Use:   shadowCmdStr,
+	Short: "Back up and sync the shadow copy of your Claude config",
+	Long: `Back up and sync AgenC's shadow repo: the git-tracked copy of your ~/.claude
+config (CLAUDE.md, settings.json, skills/, hooks/, commands/, agents/) that
+missions are built from.
+
+The shadow repo is local-only until you give it a remote with
+'agenc config shadow remote set'. From then on the server syncs with the
+remote every few minutes: your Claude config edits are committed and pushed,
+and edits pushed from your other machines are pulled and written back to
+~/.claude. Use a private repository — your config may contain secrets.`,
+}
+
+func init() {
+	configCmd.AddCommand(configShadowCmd)
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configShadowRemoteCmd = &cobra.Command{
+	Use:   remoteCmdStr,
+	Short: "Manage the remote the shadow repo syncs with",
+}
+
+func init() {
+	configShadowCmd.AddCommand(configShadowRemoteCmd)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+)
+
+var configShadowRemoteRmCmd = &cobra.Command{
+	Use:   rmCmdStr,
+	Short: "Stop syncing the shadow repo with its remote",
+	Long: `Remove the shadow repo's remote. The shadow repo goes back to being
+local-only; nothing is deleted from the remote.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShadowRemoteRm,
+}
+
+func init() {
+	configShadowRemoteCmd.AddCommand(configShadowRemoteRmCmd)
+}
+
+func runConfigShadowRemoteRm(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	if err := claudeconfig.RemoveShadowRemote(agencDirpath); err != nil {
+		return err
+	}
+	fmt.Println("Shadow repo remote removed; the shadow repo is local-only.")
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+)
+
+var configShadowRemoteSetCmd = &cobra.Command{
+	Use:   setCmdStr + " <url>",
+	Short: "Back up and sync the shadow repo with a git remote",
+	Long: `Set the git remote the shadow repo backs up to and syncs with, then sync once.
+
+Any URL git accepts works; git's own credentials (SSH keys, credential
+helpers) are used, and the server never prompts for them. Point every machine
+at the same remote to keep their Claude config in step. The first sync on a
+machine merges its config with what other machines pushed; if the same file
+was edited differently on both, sync stops and reports the conflict.
+
+Examples:
+  agenc config shadow remote set git@github.com:me/claude-config.git`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigShadowRemoteSet,
+}
+
+func init() {
+	configShadowRemoteCmd.AddCommand(configShadowRemoteSetCmd)
+}
+
+func runConfigShadowRemoteSet(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	if err := claudeconfig.SetShadowRemote(agencDirpath, args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("Shadow repo remote set to %s\n", args[0])
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.SyncShadowRemote("")
+	if err != nil {
+		return stacktrace.Propagate(err, "remote set, but the first sync failed; fix the problem and run 'agenc %s %s %s' (use --%s to resolve conflicts)", configCmdStr, shadowCmdStr, syncCmdStr, shadowPreferFlagName)
+	}
+	printShadowSyncResult(result)
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+)
+
+var configShadowRemoteShowCmd = &cobra.Command{
+	Use:   showCmdStr,
+	Short: "Show the remote the shadow repo syncs with",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigShadowRemoteShow,
+}
+
+func init() {
+	configShadowRemoteCmd.AddCommand(configShadowRemoteShowCmd)
+}
+
+func runConfigShadowRemoteShow(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	remoteURL, err := claudeconfig.GetShadowRemoteURL(agencDirpath)
+	if err != nil {
+		return err
+	}
+	if remoteURL == "" {
+		fmt.Println("No remote set; the shadow repo is local-only.")
+		return nil
+	}
+	fmt.Println(remoteURL)
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var configShadowSyncCmd = &cobra.Command{
+	Use:   syncCmdStr,
+	Short: "Sync the shadow repo with its remote now",
+	Long: `Pull Claude config changes from the shadow repo's remote (writing them to
+~/.claude) and push local ones, without waiting for the server's periodic sync.
+
+Edits made on both sides are merged. When they touch the same lines the sync
+stops and changes nothing; rerun with --prefer local or --prefer remote to
+choose which side wins those lines.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShadowSync,
+}
+
+func init() {
+	configShadowSyncCmd.Flags().String(shadowPreferFlagName, "", "side that wins conflicting edits: 'local' or 'remote'")
+	configShadowCmd.AddCommand(configShadowSyncCmd)
+}
+
+func runConfigShadowSync(cmd *cobra.Command, args []string) error {
+	prefer, err := cmd.Flags().GetString(shadowPreferFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", shadowPreferFlagName)
+	}
+	if prefer != "" && prefer != claudeconfig.ShadowPreferLocal && prefer != claudeconfig.ShadowPreferRemote {
+		return stacktrace.NewError("--%s must be '%s' or '%s'", shadowPreferFlagName, claudeconfig.ShadowPreferLocal, claudeconfig.ShadowPreferRemote)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.SyncShadowRemote(prefer)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to sync the shadow repo")
+	}
+	printShadowSyncResult(result)
+	return nil
+}
+
+// printShadowSyncResult reports what a shadow repo sync changed.
+func printShadowSyncResult(result *server.ShadowSyncResponse) {
+	switch {
+	case result.Pulled && result.Pushed:
+		fmt.Printf("Pulled Claude config changes into ~/.claude and pushed local changes to %s\n", result.RemoteURL)
+	case result.Pulled:
+		fmt.Printf("Pulled Claude config changes from %s into ~/.claude\n", result.RemoteURL)
+	case result.Pushed:
+		fmt.Printf("Pushed Claude config changes to %s\n", result.RemoteURL)
+	default:
+		fmt.Printf("Claude config is in sync with %s\n", result.RemoteURL)
+	}
+}
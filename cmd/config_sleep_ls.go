@@ -27,6 +27,9 @@ func runConfigSleepLs(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to list sleep windows")
 	}
+	if isStructuredOutput() {
+		return printStructured(windows)
+	}
 	if len(windows) == 0 {
 		fmt.Println("No sleep windows configured")
 		return nil
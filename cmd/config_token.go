@@ -0,0 +1,22 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var configTokenCmd = &cobra.Command{
+	Use:   tokenCmdStr,
+	Short: "Manage API tokens for the server's TCP listener",
+	Long: `Manage bearer tokens for the server's optional TCP listener.
+
+The unix socket is always available to the current user without a token.
+When the server also listens on TCP (see 'agenc server start --listen' and the
+serverListen config key), every TCP request must send a token:
+
+  curl -H "Authorization: Bearer agenc_..." http://127.0.0.1:7777/health
+
+Only a hash of each token is stored, in $AGENC_DIRPATH/server/api-tokens.json.
+Revoked tokens are rejected immediately; no server restart is needed.`,
+}
+
+func init() {
+	configCmd.AddCommand(configTokenCmd)
+}
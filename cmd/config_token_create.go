@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var configTokenCreateCmd = &cobra.Command{
+	Use:   createCmdStr + " <name>",
+	Short: "Create an API token",
+	Long: `Create a new API token and print it.
+
+The token is shown only once; store it in the tool that will use it. The name
+identifies the token in 'agenc config token ls' and 'agenc config token revoke'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigTokenCreate,
+}
+
+func init() {
+	configTokenCmd.AddCommand(configTokenCreateCmd)
+}
+
+func runConfigTokenCreate(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+
+	plaintext, token, err := config.CreateAPIToken(agencDirpath, args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create API token")
+	}
+
+	fmt.Printf("Created API token '%s' (ID %s). It will not be shown again:\n\n", token.Name, token.ID)
+	fmt.Println(plaintext)
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var configTokenLsCmd = &cobra.Command{
+	Use:   lsCmdStr,
+	Short: "List API tokens",
+	Long: `List API tokens by ID, name, and creation time.
+
+Token values are never shown after creation; only their hashes are stored.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigTokenLs,
+}
+
+func init() {
+	configTokenCmd.AddCommand(configTokenLsCmd)
+}
+
+// apiTokenOutput is the --output json|yaml form of an API token.
+type apiTokenOutput struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func runConfigTokenLs(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+
+	tokens, err := config.ReadAPITokens(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read API tokens")
+	}
+	if isStructuredOutput() {
+		// Omit the token hashes; only the metadata is useful to scripts
+		outputs := make([]apiTokenOutput, 0, len(tokens))
+		for _, t := range tokens {
+			outputs = append(outputs, apiTokenOutput{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt})
+		}
+		return printStructured(outputs)
+	}
+	if len(tokens) == 0 {
+		fmt.Println("No API tokens.")
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("ID", "NAME", "CREATED")
+	for _, t := range tokens {
+		tbl.AddRow(t.ID, t.Name, t.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	tbl.Print()
+	return nil
+}
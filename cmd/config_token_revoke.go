@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var configTokenRevokeCmd = &cobra.Command{
+	Use:   revokeCmdStr + " <name|id>",
+	Short: "Revoke an API token",
+	Long: `Revoke an API token by name or ID (shown in 'agenc config token ls').
+
+The server re-reads the token store on every TCP request, so the token stops
+working immediately.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigTokenRevoke,
+}
+
+func init() {
+	configTokenCmd.AddCommand(configTokenRevokeCmd)
+}
+
+func runConfigTokenRevoke(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+
+	token, err := config.RevokeAPIToken(agencDirpath, args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to revoke API token")
+	}
+
+	fmt.Printf("Revoked API token '%s' (ID %s)\n", token.Name, token.ID)
+	return nil
+}
@@ -17,12 +17,30 @@ var configUnsetCmd = &cobra.Command{
 
 Supported keys:
   attachedMissionLimit                       Max missions attachable to non-pool tmux sessions concurrently (unset removes the cap)
+  autoRestartCrashed                         Respawn the wrapper of a mission the server marks crashed (unset = off)
+  cleanupRemoteOnDelete                      Remote cleanup when removing missions (unset = "never")
   defaultModel                                 Default Claude model for missions (e.g., "opus", "sonnet", "claude-opus-4-6")
+  lifecycleHooks.onMissionStart              Shell command run after a mission's wrapper starts Claude (AGENC_* env vars describe the mission)
+  lifecycleHooks.onClaudeIdle                Shell command run when Claude finishes a turn and waits for input
+  lifecycleHooks.onClaudeBusy                Shell command run when Claude starts working on a prompt
+  lifecycleHooks.onMissionEnd                Shell command run after Claude exits (exit code in AGENC_EXIT_CODE)
+  missionAutoArchiveAfter                    Archive missions with no heartbeat for this long, e.g. "30d" (unset = never)
+  missionAutoDeleteAfter                     Delete missions archived for longer than this, e.g. "90d" (unset = never)
+  missionsMaxConcurrent                      Max interactive missions running at once (unset removes the cap)
+  notifications.desktop                      Desktop notification when an unfocused mission goes idle or needs permission (unset = off)
   paletteTmuxKeybinding                      Raw bind-key args for the command palette (default: "-T agenc k")
+  releaseChannel                             GitHub releases 'agenc upgrade' installs (unset = "stable")
+  serverListen                               Loopback TCP address for the API, e.g. "tcp:127.0.0.1:7777" (requires API tokens; restart server to apply)
+  statusline.segments                        Statusline segments under each mission's Claude prompt (unset = "budget")
+  suspendAfterIdle                           Suspend missions idle this long, even ones open in tmux, e.g. "4h"; attaching resumes them (unset = never)
+  terminalBackend                            Terminal that opens mission windows outside tmux (unset = "tmux")
+  tmux.layout                                tmux session mission windows are linked into (unset = "pool")
   tmuxWindowTitle.busyBackgroundColor        Background color for window tab when Claude is working (default: "colour018", empty = disable)
   tmuxWindowTitle.busyForegroundColor        Foreground color for window tab when Claude is working (default: "", empty = disable)
   tmuxWindowTitle.attentionBackgroundColor   Background color for window tab when Claude needs attention (default: "colour136", empty = disable)
-  tmuxWindowTitle.attentionForegroundColor   Foreground color for window tab when Claude needs attention (default: "", empty = disable)`,
+  tmuxWindowTitle.attentionForegroundColor   Foreground color for window tab when Claude needs attention (default: "", empty = disable)
+  windowTitleTemplate                        Tmux window title template; placeholders: {title}, {repo}, {shortID}, {branch}, {emoji}, {status-emoji} (unset = plain title)
+  wsl.windowsClaude                          Under WSL, run the Windows build of Claude Code (unset = off)`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigUnset,
 }
@@ -75,18 +93,72 @@ func unsetConfigValue(cfg *config.AgencConfig, key string) error {
 	case "attachedMissionLimit":
 		cfg.AttachedMissionLimit = nil
 		return nil
+	case "autoRestartCrashed":
+		cfg.AutoRestartCrashed = false
+		return nil
+	case "cleanupRemoteOnDelete":
+		cfg.CleanupRemoteOnDelete = ""
+		return nil
 	case "defaultModel":
 		cfg.DefaultModel = ""
 		return nil
+	case "missionsMaxConcurrent":
+		cfg.MissionsMaxConcurrent = 0
+		return nil
+	case "lifecycleHooks.onMissionStart",
+		"lifecycleHooks.onClaudeIdle",
+		"lifecycleHooks.onClaudeBusy",
+		"lifecycleHooks.onMissionEnd":
+		if cfg.LifecycleHooks == nil {
+			return nil
+		}
+		*lifecycleHookField(cfg.LifecycleHooks, key) = ""
+		if *cfg.LifecycleHooks == (config.LifecycleHooksConfig{}) {
+			cfg.LifecycleHooks = nil
+		}
+		return nil
+	case "missionAutoArchiveAfter":
+		cfg.MissionAutoArchiveAfter = ""
+		return nil
+	case "missionAutoDeleteAfter":
+		cfg.MissionAutoDeleteAfter = ""
+		return nil
+	case "notifications.desktop":
+		cfg.Notifications = nil
+		return nil
 	case "paletteTmuxKeybinding":
 		cfg.PaletteTmuxKeybinding = ""
 		return nil
+	case "releaseChannel":
+		cfg.ReleaseChannel = ""
+		return nil
+	case "serverListen":
+		cfg.ServerListen = ""
+		return nil
+	case "statusline.segments":
+		cfg.Statusline = nil
+		return nil
+	case "suspendAfterIdle":
+		cfg.SuspendAfterIdle = ""
+		return nil
+	case "terminalBackend":
+		cfg.TerminalBackend = ""
+		return nil
+	case "tmux.layout":
+		cfg.Tmux = nil
+		return nil
 	case "tmuxWindowTitle.busyBackgroundColor",
 		"tmuxWindowTitle.busyForegroundColor",
 		"tmuxWindowTitle.attentionBackgroundColor",
 		"tmuxWindowTitle.attentionForegroundColor":
 		setTmuxWindowTitleField(cfg, key, nil)
 		return nil
+	case "windowTitleTemplate":
+		cfg.WindowTitleTemplate = ""
+		return nil
+	case "wsl.windowsClaude":
+		cfg.WSL = nil
+		return nil
 	default:
 		return stacktrace.NewError(
 			"unknown config key '%s'; supported keys: %s",
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:   validateCmdStr + " [file]",
+	Short: "Check config.yml for errors without applying it",
+	Long: `Check config.yml for errors without applying it.
+
+Validates YAML syntax, field names and types, required fields, and allowed
+values (cron schedules, repo names, sleep windows, palette commands, etc.).
+Each problem is reported with its line, column, and field path:
+
+  config.yml:12:15: error: crons.daily.schedule: invalid cron schedule ...
+
+Unknown fields are reported as warnings; AgenC ignores them when loading.
+
+Pass a file path to lint a candidate config before copying it into place.
+Defaults to $AGENC_DIRPATH/config/config.yml. Exits non-zero if any errors
+are found.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigValidate,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	var configFilepath string
+	if len(args) == 1 {
+		configFilepath = args[0]
+	} else {
+		// Deliberately skip ensureConfigured: linting must not trigger setup
+		agencDirpath, err := config.GetAgencDirpath()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to get agenc directory path")
+		}
+		configFilepath = config.GetConfigFilepath(agencDirpath)
+	}
+
+	issues, err := config.ValidateConfigFile(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	numErrors := 0
+	for _, issue := range issues {
+		severity := ansiYellow + issue.Severity + ansiReset
+		if issue.Severity == config.ConfigIssueSeverityError {
+			severity = ansiRed + issue.Severity + ansiReset
+			numErrors++
+		}
+		fmt.Println(formatConfigIssue(configFilepath, severity, issue))
+	}
+
+	if numErrors > 0 {
+		return stacktrace.NewError("%s has %d error(s)", configFilepath, numErrors)
+	}
+	fmt.Printf("%s is valid\n", configFilepath)
+	return nil
+}
+
+// formatConfigIssue renders an issue compiler-style, as
+// "file:line:col: severity: path: message".
+func formatConfigIssue(configFilepath string, severity string, issue config.ConfigIssue) string {
+	location := configFilepath
+	if issue.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", configFilepath, issue.Line, issue.Column)
+	}
+	message := issue.Message
+	if issue.Path != "" {
+		message = issue.Path + ": " + message
+	}
+	return fmt.Sprintf("%s: %s: %s", location, severity, message)
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var cronAtCmd = &cobra.Command{
+	Use:   atCmdStr + " <time>",
+	Short: "Schedule a one-shot headless mission",
+	Long: `Schedule a headless mission to run once at the given time.
+
+One-shot jobs are stored in the AgenC database rather than config.yml, show up
+in 'agenc cron ls', and are removed automatically once they fire. A job whose
+time passes while the server is down runs when the server next starts. Remove
+a pending job with 'agenc cron rm <name>'.
+
+The time may be:
+  in 2h, in 30m, in 3 days         relative to now
+  9am, 21:30, noon                 the next time the clock reads that
+  today 5pm, tomorrow 9am          on a given day
+  friday 9:30am                    on the next such weekday
+  2026-11-02 09:00                 an absolute local time
+  2026-11-02T09:00:00-08:00        RFC3339
+
+Examples:
+  agenc cron at "tomorrow 9am" --repo github.com/owner/my-repo \
+    --prompt="Check whether last night's deploy finished cleanly"
+
+  agenc cron at "in 2h" --name=follow-up --prompt="Summarize new issues"
+
+For a one-shot job kept in config.yml, add a cron with 'runAt' instead of
+'schedule'.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCronAt,
+}
+
+func init() {
+	cronCmd.AddCommand(cronAtCmd)
+	cronAtCmd.Flags().String(cronConfigPromptFlagName, "", "initial prompt for the Claude mission (required)")
+	cronAtCmd.Flags().String(cronConfigRepoFlagName, "", "repository to clone (e.g., github.com/owner/repo) (optional)")
+	_ = cronAtCmd.RegisterFlagCompletionFunc(cronConfigRepoFlagName, completeRepoFlag)
+	cronAtCmd.Flags().String(cronAtNameFlagName, "", "job name (default: 'at-' plus a random suffix)")
+	cronAtCmd.Flags().String(cronConfigDescriptionFlagName, "", "human-readable description (optional)")
+	_ = cronAtCmd.MarkFlagRequired(cronConfigPromptFlagName)
+}
+
+func runCronAt(cmd *cobra.Command, args []string) error {
+	runAt, err := parseAtTime(args[0], time.Now())
+	if err != nil {
+		return err
+	}
+
+	prompt, _ := cmd.Flags().GetString(cronConfigPromptFlagName)
+	name, _ := cmd.Flags().GetString(cronAtNameFlagName)
+	description, _ := cmd.Flags().GetString(cronConfigDescriptionFlagName)
+	repo, _ := cmd.Flags().GetString(cronConfigRepoFlagName)
+	if repo != "" {
+		result, err := ResolveRepoInput(repo, "Select repo: ")
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to resolve repo")
+		}
+		repo = result.RepoName
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	info, err := client.CreateCronAt(server.CreateCronAtRequest{
+		Name:        name,
+		RunAt:       runAt.Format(time.RFC3339),
+		Prompt:      prompt,
+		Description: description,
+		Repo:        repo,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to schedule one-shot job")
+	}
+
+	if isStructuredOutput() {
+		return printStructured(info)
+	}
+	fmt.Printf("Scheduled '%s' for %s\n", info.Name, runAt.Local().Format("Mon 2006-01-02 15:04"))
+	fmt.Printf("To cancel: %s %s %s %s\n", agencCmdStr, cronCmdStr, rmCmdStr, info.Name)
+	return nil
+}
+
+var (
+	atRelativeRegex = regexp.MustCompile(`^in\s+(\d+)\s*(m|mins?|minutes?|h|hrs?|hours?|d|days?)$`)
+	atClockRegex    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
+// parseAtTime parses the time argument of 'agenc cron at' relative to now.
+// Times without a day are the next time the clock reads that (today or
+// tomorrow); the result is truncated to the minute and must be in the future.
+func parseAtTime(input string, now time.Time) (time.Time, error) {
+	value := strings.ToLower(strings.Join(strings.Fields(input), " "))
+	result, err := parseAtTimeValue(value, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	result = result.Truncate(time.Minute)
+	if !result.After(now) {
+		return time.Time{}, stacktrace.NewError("'%s' (%s) is not in the future", input, result.Format("2006-01-02 15:04"))
+	}
+	return result, nil
+}
+
+func parseAtTimeValue(value string, now time.Time) (time.Time, error) {
+	if t, err := config.ParseCronRunAt(value); err == nil {
+		return t, nil
+	}
+	if t, err := config.ParseCronRunAt(strings.ToUpper(value)); err == nil {
+		// RFC3339 wants an upper-case 'T' and 'Z'
+		return t, nil
+	}
+
+	if match := atRelativeRegex.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		switch match[2][0] {
+		case 'm':
+			return now.Add(time.Duration(n) * time.Minute), nil
+		case 'h':
+			return now.Add(time.Duration(n) * time.Hour), nil
+		default:
+			return now.AddDate(0, 0, n), nil
+		}
+	}
+	if rest, ok := strings.CutPrefix(value, "in "); ok {
+		if d, err := time.ParseDuration(strings.ReplaceAll(rest, " ", "")); err == nil && d > 0 {
+			return now.Add(d), nil
+		}
+	}
+
+	// [day] <clock>, where day is today, tomorrow, or a weekday
+	day, clock := "", value
+	if first, rest, ok := strings.Cut(value, " "); ok && isAtDayWord(first) {
+		day, clock = first, rest
+	}
+	hour, minute, err := parseAtClock(clock)
+	if err != nil {
+		return time.Time{}, stacktrace.NewError(
+			"can't parse time '%s'; use e.g. 'in 2h', '9am', 'tomorrow 9:30am', 'friday 17:00', or '2026-11-02 09:00'", value,
+		)
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+
+	switch day {
+	case "":
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+	case "today":
+	case "tomorrow":
+		at = at.AddDate(0, 0, 1)
+	default:
+		weekday := atWeekdays[day]
+		offset := (int(weekday) - int(now.Weekday()) + 7) % 7
+		at = at.AddDate(0, 0, offset)
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 7)
+		}
+	}
+	return at, nil
+}
+
+var atWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+func isAtDayWord(word string) bool {
+	_, isWeekday := atWeekdays[word]
+	return word == "today" || word == "tomorrow" || isWeekday
+}
+
+// parseAtClock parses a time of day: "noon", "midnight", "9am", "9:30pm",
+// or 24-hour "21:30".
+func parseAtClock(clock string) (int, int, error) {
+	switch clock {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+	match := atClockRegex.FindStringSubmatch(clock)
+	if match == nil {
+		return 0, 0, stacktrace.NewError("invalid time of day '%s'", clock)
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	switch match[3] {
+	case "":
+		// A bare hour like "9" is ambiguous; require am/pm or HH:MM
+		if match[2] == "" {
+			return 0, 0, stacktrace.NewError("time of day '%s' needs am/pm or HH:MM", clock)
+		}
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, stacktrace.NewError("invalid hour in '%s'", clock)
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, stacktrace.NewError("invalid time of day '%s'", clock)
+	}
+	return hour, minute, nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAtTime(t *testing.T) {
+	// Wednesday 2026-03-04 10:30:45
+	now := time.Date(2026, 3, 4, 10, 30, 45, 0, time.Local)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"in 2h", time.Date(2026, 3, 4, 12, 30, 0, 0, time.Local)},
+		{"in 30 minutes", time.Date(2026, 3, 4, 11, 0, 0, 0, time.Local)},
+		{"in 3 days", time.Date(2026, 3, 7, 10, 30, 0, 0, time.Local)},
+		{"in 1h30m", time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local)},
+		{"5pm", time.Date(2026, 3, 4, 17, 0, 0, 0, time.Local)},
+		{"9am", time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local)},
+		{"21:15", time.Date(2026, 3, 4, 21, 15, 0, 0, time.Local)},
+		{"noon", time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local)},
+		{"12am", time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local)},
+		{"Tomorrow 9:30AM", time.Date(2026, 3, 5, 9, 30, 0, 0, time.Local)},
+		{"today 11pm", time.Date(2026, 3, 4, 23, 0, 0, 0, time.Local)},
+		{"friday 9am", time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local)},
+		{"wed 9am", time.Date(2026, 3, 11, 9, 0, 0, 0, time.Local)},
+		{"wednesday 11:00", time.Date(2026, 3, 4, 11, 0, 0, 0, time.Local)},
+		{"2026-04-01 08:00", time.Date(2026, 4, 1, 8, 0, 0, 0, time.Local)},
+		{"2026-04-01T08:00:00Z", time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseAtTime(tt.input, now)
+			if err != nil {
+				t.Fatalf("parseAtTime(%q) failed: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseAtTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	for _, input := range []string{"today 9am", "2026-01-01 08:00", "9", "13pm", "25:00", "someday", "in -2h"} {
+		if got, err := parseAtTime(input, now); err == nil {
+			t.Errorf("parseAtTime(%q) = %v, want an error", input, got)
+		}
+	}
+}
@@ -5,10 +5,11 @@ import (
 )
 
 var cronDisableCmd = &cobra.Command{
-	Use:   disableCmdStr + " <name>",
-	Short: "Disable a cron job",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runCronDisable,
+	Use:               disableCmdStr + " <name>",
+	Short:             "Disable a cron job",
+	Args:              cobra.ExactArgs(1),
+	RunE:              runCronDisable,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
@@ -10,10 +10,11 @@ import (
 )
 
 var cronEnableCmd = &cobra.Command{
-	Use:   enableCmdStr + " <name>",
-	Short: "Enable a cron job",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runCronEnable,
+	Use:               enableCmdStr + " <name>",
+	Short:             "Enable a cron job",
+	Args:              cobra.ExactArgs(1),
+	RunE:              runCronEnable,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
@@ -7,6 +7,7 @@ import (
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
 
+	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/server"
 	"github.com/odyssey/agenc/internal/tableprinter"
 )
@@ -20,80 +21,121 @@ var cronHistoryCmd = &cobra.Command{
 
 The argument can be a cron name (as defined in config.yml) or a cron UUID.
 
-Lists missions spawned by the cron scheduler, including their status and duration.
+Every firing of the cron is recorded: when it started and ended, whether it
+was scheduled or triggered manually via 'agenc cron run', the mission it
+spawned, and how it finished. A scheduled firing that arrives while the
+previous run is still going is recorded as skipped instead of spawning an
+overlapping mission.
+
+A run succeeds when Claude finishes its first turn, and fails when Claude
+exits with a non-zero code or the wrapper dies before the turn completes.
 
 Examples:
   agenc cron history daily-report
   agenc cron history daily-report --limit 50
   agenc cron history abc-123
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: runCronHistory,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runCronHistory,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
-	cronHistoryCmd.Flags().IntVar(&cronHistoryLimitFlag, "limit", 20, "maximum number of entries to show")
+	cronHistoryCmd.Flags().IntVar(&cronHistoryLimitFlag, "limit", 20, "maximum number of entries to show (0 for all)")
 	cronCmd.AddCommand(cronHistoryCmd)
 }
 
 func runCronHistory(cmd *cobra.Command, args []string) error {
 	nameOrID := args[0]
 
+	if cronHistoryLimitFlag < 0 {
+		return stacktrace.NewError("--limit must be zero or greater")
+	}
+
 	client, err := serverClient()
 	if err != nil {
 		return err
 	}
 
-	cronID, err := resolveCronID(client, nameOrID)
+	// Fetch one extra run so we can tell the user when output was truncated
+	fetchLimit := cronHistoryLimitFlag
+	if fetchLimit > 0 {
+		fetchLimit++
+	}
+	runs, err := client.ListCronRuns(nameOrID, fetchLimit)
 	if err != nil {
-		return err
+		return stacktrace.Propagate(err, "failed to fetch run history")
 	}
 
-	missions, err := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true, Source: "cron", SourceID: cronID})
-	if err != nil {
-		return stacktrace.Propagate(err, "failed to list missions")
+	truncated := cronHistoryLimitFlag > 0 && len(runs) > cronHistoryLimitFlag
+	if truncated {
+		runs = runs[:cronHistoryLimitFlag]
 	}
 
-	if len(missions) == 0 {
-		fmt.Printf("No runs found for cron job '%s'\n", nameOrID)
-		return nil
+	if isStructuredOutput() {
+		return printStructured(runs)
 	}
 
-	// Limit the number of entries
-	displayMissions := missions
-	if len(missions) > cronHistoryLimitFlag {
-		displayMissions = missions[:cronHistoryLimitFlag]
+	if len(runs) == 0 {
+		fmt.Printf("No runs found for cron job '%s'\n", nameOrID)
+		return nil
 	}
 
 	fmt.Printf("Run history for cron job '%s':\n\n", nameOrID)
 
-	tbl := tableprinter.NewTable("STARTED", "ID", "STATUS", "DURATION")
-
-	for _, m := range displayMissions {
-		status := getMissionStatus(m.ID, m.Status, m.ClaudeState)
-		coloredStatus := colorizeStatus(status)
+	tbl := tableprinter.NewTable("STARTED", "TRIGGER", "STATUS", "DURATION", "MISSION", "DETAIL")
+	for _, run := range runs {
+		tbl.AddRow(
+			run.StartedAt.Local().Format("2006-01-02 15:04:05"),
+			run.Trigger,
+			colorizeCronRunStatus(run.Status),
+			formatCronRunDuration(run),
+			formatCronRunMission(run),
+			run.Detail,
+		)
+	}
+	tbl.Print()
 
-		started := m.CreatedAt.Local().Format("2006-01-02 15:04:05")
+	if truncated {
+		fmt.Printf("\n...showing the %d most recent runs. Use --limit to see more.\n", cronHistoryLimitFlag)
+	}
 
-		// Calculate duration based on last heartbeat or current time
-		var duration string
-		if m.LastHeartbeat != nil {
-			d := m.LastHeartbeat.Sub(m.CreatedAt)
-			duration = formatMissionDuration(d)
-		} else {
-			duration = "--"
-		}
+	return nil
+}
 
-		tbl.AddRow(started, m.ShortID, coloredStatus, duration)
+// colorizeCronRunStatus wraps a cron run status in its display color.
+func colorizeCronRunStatus(status string) string {
+	switch status {
+	case database.CronRunStatusRunning:
+		return ansiGreen + status + ansiReset
+	case database.CronRunStatusFailed:
+		return ansiRed + status + ansiReset
+	case database.CronRunStatusSkipped:
+		return ansiYellow + status + ansiReset
+	default:
+		return status
 	}
+}
 
-	tbl.Print()
-
-	if len(missions) > cronHistoryLimitFlag {
-		fmt.Printf("\n...showing %d of %d runs. Use --limit to see more.\n", cronHistoryLimitFlag, len(missions))
+// formatCronRunDuration returns how long a run took, or how long it has been
+// going if it is still running. Skipped runs have no duration.
+func formatCronRunDuration(run server.CronRunResponse) string {
+	if run.Status == database.CronRunStatusSkipped {
+		return "--"
+	}
+	end := time.Now()
+	if run.EndedAt != nil {
+		end = *run.EndedAt
 	}
+	return formatMissionDuration(end.Sub(run.StartedAt))
+}
 
-	return nil
+// formatCronRunMission returns the short ID of the mission a run spawned.
+func formatCronRunMission(run server.CronRunResponse) string {
+	if run.MissionID == nil {
+		return "--"
+	}
+	return database.ShortID(*run.MissionID)
 }
 
 // formatMissionDuration formats a duration for display in the history table.
@@ -23,8 +23,9 @@ Examples:
   agenc cron logs print daily-report
   agenc cron logs print daily-report --all
   agenc cron logs print abc-123`,
-	Args: cobra.ExactArgs(1),
-	RunE: runCronLogsPrint,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runCronLogsPrint,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
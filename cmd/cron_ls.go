@@ -6,6 +6,7 @@ import (
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
 
+	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/server"
 	"github.com/odyssey/agenc/internal/tableprinter"
 )
@@ -31,6 +32,10 @@ func runCronLs(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to list crons")
 	}
 
+	if isStructuredOutput() {
+		return printStructured(crons)
+	}
+
 	if len(crons) == 0 {
 		fmt.Println("No cron jobs defined.")
 		fmt.Println("\nTo create a cron job, use 'agenc cron new' or ask the Adjutant.")
@@ -47,9 +52,14 @@ func runCronLs(cmd *cobra.Command, args []string) error {
 
 		lastRun, status := getCronLastRunStatus(client, c)
 
+		schedule := formatCronSchedule(c)
+		if c.After != "" {
+			schedule += " (after " + c.After + ")"
+		}
+
 		tbl.AddRow(
 			c.Name,
-			c.Schedule,
+			schedule,
 			enabled,
 			lastRun,
 			status,
@@ -60,21 +70,31 @@ func runCronLs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatCronSchedule returns a cron's schedule expression, or "at <time>" in
+// local time for one-shot jobs.
+func formatCronSchedule(cronInfo server.CronInfo) string {
+	if cronInfo.RunAt == "" {
+		return cronInfo.Schedule
+	}
+	runAt, err := config.ParseCronRunAt(cronInfo.RunAt)
+	if err != nil {
+		return "at " + cronInfo.RunAt
+	}
+	return "at " + runAt.Local().Format("2006-01-02 15:04")
+}
+
+// getCronLastRunStatus returns the start time and outcome of a cron's most
+// recent recorded run.
 func getCronLastRunStatus(client *server.Client, cronInfo server.CronInfo) (string, string) {
 	if cronInfo.ID == "" {
 		return "--", "--"
 	}
 
-	missions, err := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true, Source: "cron", SourceID: cronInfo.ID})
-	if err != nil || len(missions) == 0 {
+	runs, err := client.ListCronRuns(cronInfo.ID, 1)
+	if err != nil || len(runs) == 0 {
 		return "--", "--"
 	}
 
-	mission := missions[0]
-	lastRun := mission.CreatedAt.Local().Format("2006-01-02 15:04")
-
-	status := getMissionStatus(mission.ID, mission.Status, mission.ClaudeState)
-	coloredStatus := colorizeStatus(status)
-
-	return lastRun, coloredStatus
+	lastRun := runs[0].StartedAt.Local().Format("2006-01-02 15:04")
+	return lastRun, colorizeCronRunStatus(runs[0].Status)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mattn/go-isatty"
 	"github.com/mieubrisse/stacktrace"
@@ -22,6 +23,10 @@ var cronNewCmd = &cobra.Command{
 If a name is provided as an argument, the wizard will use it. Otherwise,
 you'll be prompted to enter a name.
 
+The schedule may be a cron expression or a phrase such as "every day at 9am",
+"every monday at 8:30am", or "every month on the 1st at noon". The computed
+cron expression and its next three run times are shown for confirmation.
+
 Example:
   agenc cron new daily-report
   agenc cron new
@@ -52,24 +57,78 @@ func promptCronName(reader *bufio.Reader, args []string) (string, error) {
 	return readPromptLine(reader, "Cron job name: ")
 }
 
-// promptCronSchedule displays schedule instructions and reads the schedule from stdin.
+// promptCronSchedule displays schedule instructions and reads the schedule
+// from stdin. Phrases like "every monday at 9am" are translated to cron
+// syntax, and the result is confirmed before use.
 func promptCronSchedule(reader *bufio.Reader) (string, error) {
-	fmt.Println("\nEnter cron schedule (e.g., '0 9 * * *' for 9am daily):")
+	fmt.Println("\nEnter a cron schedule or a phrase (e.g., '0 9 * * *' or 'every day at 9am'):")
 	fmt.Println("  Format: minute hour day-of-month month day-of-week")
 	fmt.Println("  Only simple integers and '*' are supported (no ranges, lists, or step values).")
 	fmt.Println("  Common examples:")
-	fmt.Println("    0 9 * * *     - 9am every day")
-	fmt.Println("    0 9 * * 1     - 9am every Monday")
-	fmt.Println("    0 0 * * 0     - midnight on Sundays")
-	fmt.Println("    0 0 1 * *     - midnight on the 1st of each month")
+	fmt.Println("    0 9 * * *     - every day at 9am")
+	fmt.Println("    0 9 * * 1     - every monday at 9am")
+	fmt.Println("    0 0 * * 0     - every sunday at midnight")
+	fmt.Println("    0 0 1 * *     - every month on the 1st at midnight")
+
+	for {
+		input, err := readPromptLine(reader, "\nSchedule: ")
+		if err != nil {
+			return "", stacktrace.Propagate(err, "")
+		}
+		schedule, err := config.ResolveCronSchedule(input)
+		if err != nil {
+			return "", stacktrace.Propagate(err, "")
+		}
+
+		printCronSchedulePreview(schedule)
+		confirmed, err := promptYesNo(reader, "Use this schedule? [y/N] ")
+		if err != nil {
+			return "", stacktrace.Propagate(err, "")
+		}
+		if confirmed {
+			return schedule, nil
+		}
+	}
+}
+
+// printCronSchedulePreview prints a cron expression and its next three run
+// times, so a translated schedule phrase can be checked before it is saved.
+func printCronSchedulePreview(schedule string) {
+	fmt.Printf("  Cron expression: %s\n", schedule)
+	runs, err := config.NextCronRuns(schedule, time.Now(), 3)
+	if err != nil || len(runs) == 0 {
+		return
+	}
+	fmt.Println("  Next runs:")
+	for _, run := range runs {
+		fmt.Printf("    %s\n", run.Format("Mon 2006-01-02 15:04"))
+	}
+}
 
-	schedule, err := readPromptLine(reader, "\nSchedule: ")
+// resolveCronScheduleFlag translates a --schedule value into a cron
+// expression. Cron expressions pass through unchanged; a phrase's translation
+// is shown and, when stdin is a terminal, confirmed.
+func resolveCronScheduleFlag(input string) (string, error) {
+	schedule, err := config.ResolveCronSchedule(input)
 	if err != nil {
-		return "", stacktrace.Propagate(err, "")
+		return "", stacktrace.Propagate(err, "invalid --%s", cronConfigScheduleFlagName)
+	}
+	if schedule == input {
+		return schedule, nil
 	}
-	if err := config.ValidateCronSchedule(schedule); err != nil {
+
+	fmt.Printf("Schedule '%s':\n", input)
+	printCronSchedulePreview(schedule)
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return schedule, nil
+	}
+	confirmed, err := promptYesNo(bufio.NewReader(os.Stdin), "Use this schedule? [y/N] ")
+	if err != nil {
 		return "", stacktrace.Propagate(err, "")
 	}
+	if !confirmed {
+		return "", stacktrace.NewError("schedule not confirmed")
+	}
 	return schedule, nil
 }
 
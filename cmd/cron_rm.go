@@ -10,10 +10,11 @@ import (
 )
 
 var cronRmCmd = &cobra.Command{
-	Use:   rmCmdStr + " <name>",
-	Short: "Remove a cron job from config",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runCronRm,
+	Use:               rmCmdStr + " <name>",
+	Short:             "Remove a cron job from config, or a pending one-shot job",
+	Args:              cobra.ExactArgs(1),
+	RunE:              runCronRm,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
@@ -27,16 +28,18 @@ func runCronRm(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if _, exists := cfg.Crons[name]; !exists {
+		// One-shot jobs from 'agenc cron at' live in the database. The server
+		// takes the config lock itself, so release ours first.
+		release()
+		return removeCronAtJob(name)
+	}
 	defer release()
 	agencDirpath, err := config.GetAgencDirpath()
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to get agenc directory path")
 	}
 
-	if _, exists := cfg.Crons[name]; !exists {
-		return stacktrace.NewError("cron job '%s' not found", name)
-	}
-
 	delete(cfg.Crons, name)
 
 	if err := config.WriteAgencConfig(agencDirpath, cfg, cm); err != nil {
@@ -46,3 +49,16 @@ func runCronRm(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Removed cron job '%s'\n", name)
 	return nil
 }
+
+// removeCronAtJob removes a pending one-shot job via the server.
+func removeCronAtJob(name string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	if err := client.DeleteCron(name); err != nil {
+		return stacktrace.Propagate(err, "failed to remove cron job '%s'", name)
+	}
+	fmt.Printf("Removed cron job '%s'\n", name)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"time"
 
 	"github.com/mieubrisse/stacktrace"
@@ -21,8 +22,9 @@ The mission will be tracked with source flags so it appears in 'cron history'.
 Example:
   agenc cron run daily-report
 `,
-	Args: cobra.ExactArgs(1),
-	RunE: runCronRun,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runCronRun,
+	ValidArgsFunction: completeCronName,
 }
 
 func init() {
@@ -61,6 +63,12 @@ func runCronRun(cmd *cobra.Command, args []string) error {
 		"--source-metadata", string(sourceMetadata),
 		"--prompt", cronCfg.Prompt,
 	}
+	if cronCfg.MaxPrompts > 0 {
+		cmdArgs = append(cmdArgs, "--"+maxPromptsFlagName, strconv.Itoa(cronCfg.MaxPrompts))
+	}
+	if cronCfg.BudgetUSD > 0 {
+		cmdArgs = append(cmdArgs, "--"+budgetUSDFlagName, strconv.FormatFloat(cronCfg.BudgetUSD, 'f', -1, 64))
+	}
 
 	if cronCfg.Repo != "" {
 		cmdArgs = append(cmdArgs, cronCfg.Repo)
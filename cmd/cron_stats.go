@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var cronStatsCmd = &cobra.Command{
+	Use:   statsCmdStr + " [name-or-id]",
+	Short: "Show success rates and run durations of cron jobs",
+	Long: `Show how reliably and how quickly cron jobs run.
+
+For each cron, the stats cover its last 100 runs: how many succeeded, failed,
+or were skipped, the success rate of the finished runs, the p50/p90/p99 run
+duration, and how many of the newest runs have failed in a row. Without an
+argument every configured cron is shown; with one, only that cron (by name or
+UUID).
+
+A cron with alertOnConsecutiveFailures set in config.yml raises an alert once
+its failures in a row reach that count: a cron.failing event, a notification
+('agenc notifications ls'), and a message to the cron's notify targets. The
+ALERT column shows the threshold.
+
+The same stats are served to Prometheus in its text format at GET /metrics
+when the server listens on TCP (serverListen), authenticated with a token
+from 'agenc config token create'.
+
+Examples:
+  agenc cron stats
+  agenc cron stats nightly-report
+  agenc cron stats -o json
+`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runCronStats,
+	ValidArgsFunction: completeCronName,
+}
+
+func init() {
+	cronCmd.AddCommand(cronStatsCmd)
+}
+
+func runCronStats(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	var allStats []server.CronStats
+	if len(args) == 1 {
+		stats, err := client.GetCronStats(args[0])
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to fetch cron stats")
+		}
+		allStats = []server.CronStats{*stats}
+	} else {
+		allStats, err = client.ListCronStats()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to fetch cron stats")
+		}
+	}
+
+	if isStructuredOutput() {
+		return printStructured(allStats)
+	}
+
+	if len(allStats) == 0 {
+		fmt.Println("No cron jobs configured.")
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("NAME", "OK", "FAILED", "SKIPPED", "SUCCESS", "P50", "P90", "P99", "FAILING", "ALERT")
+	for _, stats := range allStats {
+		tbl.AddRow(
+			stats.CronName,
+			strconv.Itoa(stats.Succeeded),
+			strconv.Itoa(stats.Failed),
+			strconv.Itoa(stats.Skipped),
+			formatCronSuccessRate(stats),
+			formatCronDurationPercentile(stats, "p50"),
+			formatCronDurationPercentile(stats, "p90"),
+			formatCronDurationPercentile(stats, "p99"),
+			formatCronFailureStreak(stats),
+			formatCronAlertThreshold(stats),
+		)
+	}
+	tbl.Print()
+	return nil
+}
+
+// formatCronSuccessRate returns a cron's success rate as a percentage, or
+// "--" before any run has finished.
+func formatCronSuccessRate(stats server.CronStats) string {
+	if stats.SuccessRate == nil {
+		return "--"
+	}
+	return fmt.Sprintf("%.0f%%", *stats.SuccessRate*100)
+}
+
+// formatCronDurationPercentile returns one of a cron's run duration
+// percentiles, or "--" before any run has finished.
+func formatCronDurationPercentile(stats server.CronStats, percentile string) string {
+	seconds, ok := stats.DurationSeconds[percentile]
+	if !ok {
+		return "--"
+	}
+	return formatMissionDuration(time.Duration(seconds * float64(time.Second)))
+}
+
+// formatCronFailureStreak returns how many runs in a row a cron has failed,
+// in red once it has reached its alert threshold.
+func formatCronFailureStreak(stats server.CronStats) string {
+	streak := strconv.Itoa(stats.ConsecutiveFailures)
+	if stats.AlertOnConsecutiveFailures > 0 && stats.ConsecutiveFailures >= stats.AlertOnConsecutiveFailures {
+		return ansiRed + streak + ansiReset
+	}
+	return streak
+}
+
+// formatCronAlertThreshold returns a cron's alertOnConsecutiveFailures, or
+// "--" when alerting is off.
+func formatCronAlertThreshold(stats server.CronStats) string {
+	if stats.AlertOnConsecutiveFailures <= 0 {
+		return "--"
+	}
+	return strconv.Itoa(stats.AlertOnConsecutiveFailures)
+}
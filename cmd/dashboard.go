@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   dashboardCmdStr,
+	Short: "Interactive dashboard of all missions",
+	Long: `Open a full-screen dashboard listing missions with their status, repo, last
+activity, and a busy/idle indicator from the wrapper. The list refreshes every
+two seconds.
+
+Keybindings:
+  ↑/↓, j/k    move the selection
+  enter, a    attach the selected mission to the current tmux session
+  s           stop the selected mission's wrapper
+  x           archive the selected mission (asks for confirmation)
+  l           follow the selected mission's Claude output log (esc to go back)
+  A           show or hide archived missions
+  p           cycle the project filter: all missions, then each project
+  r           refresh now
+  q, esc      quit
+
+--project starts the dashboard filtered to one project's missions.
+
+Attaching requires running the dashboard inside tmux.`,
+	Args: cobra.NoArgs,
+	RunE: runDashboard,
+}
+
+var dashboardProjectFlag string
+
+func init() {
+	dashboardCmd.Flags().StringVar(&dashboardProjectFlag, projectFlagName, "", "show only missions in this project")
+	_ = dashboardCmd.RegisterFlagCompletionFunc(projectFlagName, completeProjectFlag)
+	rootCmd.AddCommand(dashboardCmd)
+}
+
+func runDashboard(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	model := newDashboardModel(client, getCallingSessionName())
+	model.project = dashboardProjectFlag
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return stacktrace.Propagate(err, "dashboard exited with an error")
+	}
+	return nil
+}
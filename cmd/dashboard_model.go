@@ -0,0 +1,560 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+const (
+	// dashboardRefreshInterval is how often the dashboard re-fetches missions.
+	dashboardRefreshInterval = 2 * time.Second
+
+	// dashboardMaxLogLines caps the log view's scrollback.
+	dashboardMaxLogLines = 1000
+)
+
+// dashboardBackend is the subset of server.Client the dashboard uses, so the
+// model can be driven by a fake in tests.
+type dashboardBackend interface {
+	ListMissions(req server.ListMissionsRequest) ([]*database.Mission, error)
+	ListProjects() ([]server.ProjectResponse, error)
+	StopMission(id string) error
+	ArchiveMission(id string) error
+	AttachMission(id string, tmuxSession string, noFocus bool) error
+	StreamMissionOutput(ctx context.Context, id string, source string, all bool, onLine func(string)) error
+}
+
+var (
+	dashboardTitleStyle    = lipgloss.NewStyle().Bold(true)
+	dashboardHeaderStyle   = lipgloss.NewStyle().Bold(true).Underline(true)
+	dashboardSelectedStyle = lipgloss.NewStyle().Bold(true)
+	dashboardHelpStyle     = lipgloss.NewStyle().Faint(true)
+	dashboardErrorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+// Messages flowing through the dashboard's update loop.
+type (
+	dashboardTickMsg     struct{}
+	dashboardMissionsMsg struct {
+		missions []*database.Mission
+		err      error
+	}
+	dashboardActionMsg struct {
+		message string
+		err     error
+	}
+	dashboardLogLineMsg struct {
+		line string
+	}
+	dashboardLogClosedMsg struct{}
+	dashboardProjectsMsg  struct {
+		names []string
+		err   error
+	}
+)
+
+// dashboardModel is the bubbletea model behind `agenc dashboard`. It has two
+// views: the mission table and a live log tail for one mission.
+type dashboardModel struct {
+	backend     dashboardBackend
+	tmuxSession string
+
+	missions     []*database.Mission
+	cursor       int
+	showArchived bool
+	loadErr      error
+
+	// project, when set, limits the table to that project's missions.
+	project string
+
+	// status is a one-line message about the last action, shown in the footer.
+	status      string
+	statusIsErr bool
+
+	// pendingArchiveID is set while waiting for y/n confirmation of an archive.
+	pendingArchiveID string
+
+	width  int
+	height int
+
+	// Log view state. logLines is nil when the mission table is showing.
+	logMissionID string
+	logLines     []string
+	logCh        chan string
+	logCancel    context.CancelFunc
+}
+
+func newDashboardModel(backend dashboardBackend, tmuxSession string) *dashboardModel {
+	return &dashboardModel{
+		backend:     backend,
+		tmuxSession: tmuxSession,
+		width:       120,
+		height:      30,
+	}
+}
+
+func (m *dashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.loadMissions(), dashboardTick())
+}
+
+func dashboardTick() tea.Cmd {
+	return tea.Tick(dashboardRefreshInterval, func(time.Time) tea.Msg { return dashboardTickMsg{} })
+}
+
+func (m *dashboardModel) loadMissions() tea.Cmd {
+	backend := m.backend
+	req := server.ListMissionsRequest{IncludeArchived: m.showArchived, Project: m.project}
+	return func() tea.Msg {
+		missions, err := backend.ListMissions(req)
+		if err == nil {
+			sortMissionsForPicker(missions)
+		}
+		return dashboardMissionsMsg{missions: missions, err: err}
+	}
+}
+
+// loadProjects fetches the project names for cycling the project filter.
+func (m *dashboardModel) loadProjects() tea.Cmd {
+	backend := m.backend
+	return func() tea.Msg {
+		projects, err := backend.ListProjects()
+		if err != nil {
+			return dashboardProjectsMsg{err: err}
+		}
+		names := make([]string, 0, len(projects))
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		return dashboardProjectsMsg{names: names}
+	}
+}
+
+// nextDashboardProject returns the project filter after current when cycling
+// through names: no filter, then each project in order, then no filter again.
+func nextDashboardProject(names []string, current string) string {
+	if current == "" {
+		if len(names) == 0 {
+			return ""
+		}
+		return names[0]
+	}
+	for i, name := range names {
+		if name == current && i+1 < len(names) {
+			return names[i+1]
+		}
+	}
+	return ""
+}
+
+// runAction performs a backend call off the update loop and reports the
+// outcome as a status message.
+func (m *dashboardModel) runAction(fn func() error, successMessage string) tea.Cmd {
+	return func() tea.Msg {
+		if err := fn(); err != nil {
+			return dashboardActionMsg{err: err}
+		}
+		return dashboardActionMsg{message: successMessage}
+	}
+}
+
+func (m *dashboardModel) selected() *database.Mission {
+	if m.cursor < 0 || m.cursor >= len(m.missions) {
+		return nil
+	}
+	return m.missions[m.cursor]
+}
+
+func (m *dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case dashboardTickMsg:
+		return m, tea.Batch(m.loadMissions(), dashboardTick())
+
+	case dashboardMissionsMsg:
+		m.loadErr = msg.err
+		if msg.err == nil {
+			m.setMissions(msg.missions)
+		}
+		return m, nil
+
+	case dashboardActionMsg:
+		if msg.err != nil {
+			m.setStatus(msg.err.Error(), true)
+		} else {
+			m.setStatus(msg.message, false)
+		}
+		return m, m.loadMissions()
+
+	case dashboardProjectsMsg:
+		if msg.err != nil {
+			m.setStatus("Failed to list projects: "+msg.err.Error(), true)
+			return m, nil
+		}
+		if len(msg.names) == 0 {
+			m.setStatus("No projects; create one with 'agenc project new'", false)
+			return m, nil
+		}
+		m.project = nextDashboardProject(msg.names, m.project)
+		if m.project == "" {
+			m.setStatus("Showing all projects", false)
+		} else {
+			m.setStatus("Showing project "+m.project, false)
+		}
+		return m, m.loadMissions()
+
+	case dashboardLogLineMsg:
+		if m.logLines == nil {
+			return m, nil
+		}
+		m.logLines = append(m.logLines, msg.line)
+		if len(m.logLines) > dashboardMaxLogLines {
+			m.logLines = m.logLines[len(m.logLines)-dashboardMaxLogLines:]
+		}
+		return m, waitForDashboardLogLine(m.logCh)
+
+	case dashboardLogClosedMsg:
+		if m.logLines != nil {
+			m.logLines = append(m.logLines, dashboardHelpStyle.Render("-- log stream ended --"))
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.closeLogs()
+			return m, tea.Quit
+		}
+		if m.logLines != nil {
+			return m.updateLogView(msg)
+		}
+		return m.updateMissionView(msg)
+	}
+	return m, nil
+}
+
+// setMissions replaces the mission list, keeping the cursor on the same
+// mission when it is still present.
+func (m *dashboardModel) setMissions(missions []*database.Mission) {
+	var selectedID string
+	if sel := m.selected(); sel != nil {
+		selectedID = sel.ID
+	}
+	m.missions = missions
+	m.cursor = 0
+	for i, mission := range missions {
+		if mission.ID == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+func (m *dashboardModel) setStatus(status string, isErr bool) {
+	m.status = status
+	m.statusIsErr = isErr
+}
+
+func (m *dashboardModel) updateMissionView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if m.pendingArchiveID != "" {
+		missionID := m.pendingArchiveID
+		m.pendingArchiveID = ""
+		if key != "y" && key != "Y" {
+			m.setStatus("Archive cancelled", false)
+			return m, nil
+		}
+		shortID := database.ShortID(missionID)
+		m.setStatus("Archiving "+shortID+"...", false)
+		return m, m.runAction(func() error { return m.backend.ArchiveMission(missionID) }, "Archived "+shortID)
+	}
+
+	switch key {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.missions)-1 {
+			m.cursor++
+		}
+	case "g", "home":
+		m.cursor = 0
+	case "G", "end":
+		if len(m.missions) > 0 {
+			m.cursor = len(m.missions) - 1
+		}
+	case "r":
+		return m, m.loadMissions()
+	case "A":
+		m.showArchived = !m.showArchived
+		return m, m.loadMissions()
+	case "p":
+		return m, m.loadProjects()
+	case "enter", "a":
+		sel := m.selected()
+		if sel == nil {
+			return m, nil
+		}
+		if m.tmuxSession == "" {
+			m.setStatus("Attaching requires running the dashboard inside tmux", true)
+			return m, nil
+		}
+		missionID, tmuxSession := sel.ID, m.tmuxSession
+		m.setStatus("Attaching "+sel.ShortID+"...", false)
+		return m, m.runAction(func() error { return m.backend.AttachMission(missionID, tmuxSession, false) }, "Attached "+sel.ShortID)
+	case "s":
+		sel := m.selected()
+		if sel == nil {
+			return m, nil
+		}
+		missionID := sel.ID
+		m.setStatus("Stopping "+sel.ShortID+"...", false)
+		return m, m.runAction(func() error { return m.backend.StopMission(missionID) }, "Stopped "+sel.ShortID)
+	case "x":
+		sel := m.selected()
+		if sel == nil || sel.Status == "archived" {
+			return m, nil
+		}
+		m.pendingArchiveID = sel.ID
+		m.setStatus(fmt.Sprintf("Archive mission %s? (y/n)", sel.ShortID), false)
+	case "l":
+		sel := m.selected()
+		if sel == nil {
+			return m, nil
+		}
+		return m, m.openLogs(sel.ID)
+	}
+	return m, nil
+}
+
+func (m *dashboardModel) updateLogView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "l":
+		m.closeLogs()
+		return m, m.loadMissions()
+	}
+	return m, nil
+}
+
+// openLogs switches to the log view and starts following the mission's
+// Claude output. Lines arrive through logCh so the stream never blocks the
+// update loop.
+func (m *dashboardModel) openLogs(missionID string) tea.Cmd {
+	m.closeLogs()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan string, 256)
+	m.logMissionID = missionID
+	m.logLines = []string{}
+	m.logCh = ch
+	m.logCancel = cancel
+
+	backend := m.backend
+	go func() {
+		defer close(ch)
+		err := backend.StreamMissionOutput(ctx, missionID, "claude", false, func(line string) {
+			select {
+			case ch <- line:
+			case <-ctx.Done():
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			select {
+			case ch <- dashboardErrorStyle.Render("error: " + err.Error()):
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return waitForDashboardLogLine(ch)
+}
+
+func (m *dashboardModel) closeLogs() {
+	if m.logCancel != nil {
+		m.logCancel()
+	}
+	m.logMissionID = ""
+	m.logLines = nil
+	m.logCh = nil
+	m.logCancel = nil
+}
+
+func waitForDashboardLogLine(ch chan string) tea.Cmd {
+	if ch == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		line, ok := <-ch
+		if !ok {
+			return dashboardLogClosedMsg{}
+		}
+		return dashboardLogLineMsg{line: line}
+	}
+}
+
+func (m *dashboardModel) View() string {
+	if m.logLines != nil {
+		return m.viewLogs()
+	}
+	return m.viewMissions()
+}
+
+func (m *dashboardModel) viewMissions() string {
+	var b strings.Builder
+
+	numRunning := 0
+	for _, mission := range m.missions {
+		if mission.ClaudeState != nil {
+			numRunning++
+		}
+	}
+	title := fmt.Sprintf("AgenC missions — %d shown, %d running", len(m.missions), numRunning)
+	if m.project != "" {
+		title = fmt.Sprintf("AgenC missions in project %s — %d shown, %d running", m.project, len(m.missions), numRunning)
+	}
+	if m.showArchived {
+		title += " (including archived)"
+	}
+	b.WriteString(dashboardTitleStyle.Render(title) + "\n\n")
+
+	if m.loadErr != nil {
+		b.WriteString(dashboardErrorStyle.Render("Failed to load missions: "+m.loadErr.Error()) + "\n")
+	}
+
+	// Columns: cursor, indicator, ID, STATUS, LAST ACTIVITY, REPO, SESSION
+	const idWidth, statusWidth, activityWidth, repoWidth = 8, 8, 14, 28
+	header := fmt.Sprintf("    %-*s  %-*s  %-*s  %-*s  %s",
+		idWidth, "ID", statusWidth, "STATUS", activityWidth, "LAST ACTIVITY", repoWidth, "REPO", "SESSION")
+	b.WriteString(dashboardHeaderStyle.Render(header) + "\n")
+
+	// Leave room for title, header, summary and last error lines, and footer
+	visibleRows := max(m.height-9, 1)
+	start := 0
+	if m.cursor >= visibleRows {
+		start = m.cursor - visibleRows + 1
+	}
+	end := min(start+visibleRows, len(m.missions))
+
+	sessionWidth := max(m.width-(4+idWidth+2+statusWidth+2+activityWidth+2+repoWidth+2), 10)
+	now := time.Now()
+	for i := start; i < end; i++ {
+		mission := m.missions[i]
+		status := getMissionStatus(mission.ID, mission.Status, mission.ClaudeState)
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		indicator := dashboardIndicator(status)
+		if indicator == " " && mission.LastError != "" {
+			indicator = ansiRed + "✗" + ansiReset
+		}
+		row := cursor + indicator + " " +
+			padDisplay(mission.ShortID, idWidth) + "  " +
+			padDisplay(colorizeStatus(status), statusWidth) + "  " +
+			padDisplay(formatTimeAgo(missionLastActivity(mission), now), activityWidth) + "  " +
+			padDisplay(truncateDisplay(plainMissionRepoNames(mission), repoWidth), repoWidth) + "  " +
+			truncateDisplay(strings.Join(strings.Fields(resolveSessionName(mission)), " "), sessionWidth)
+		if i == m.cursor {
+			row = dashboardSelectedStyle.Render(row)
+		}
+		b.WriteString(row + "\n")
+	}
+	if len(m.missions) == 0 && m.loadErr == nil {
+		b.WriteString("  No missions.\n")
+	}
+
+	b.WriteString("\n")
+	if m.cursor < len(m.missions) && m.missions[m.cursor].AISummary != "" {
+		summary := strings.Join(strings.Fields(m.missions[m.cursor].AISummary), " ")
+		b.WriteString(truncateDisplay("Summary: "+summary, m.width) + "\n")
+	}
+	if m.cursor < len(m.missions) && m.missions[m.cursor].LastError != "" {
+		lastError := strings.Join(strings.Fields(m.missions[m.cursor].LastError), " ")
+		b.WriteString(dashboardErrorStyle.Render(truncateDisplay("Last error: "+lastError, m.width)) + "\n")
+	}
+	if m.status != "" {
+		if m.statusIsErr {
+			b.WriteString(dashboardErrorStyle.Render(m.status) + "\n")
+		} else {
+			b.WriteString(m.status + "\n")
+		}
+	}
+	b.WriteString(dashboardHelpStyle.Render("↑/↓ move · enter attach · s stop · x archive · l logs · A toggle archived · p cycle project · r refresh · q quit"))
+	return b.String()
+}
+
+func (m *dashboardModel) viewLogs() string {
+	var b strings.Builder
+	b.WriteString(dashboardTitleStyle.Render("Logs — mission "+database.ShortID(m.logMissionID)) + "\n\n")
+
+	visibleRows := max(m.height-4, 1)
+	lines := m.logLines
+	if len(lines) > visibleRows {
+		lines = lines[len(lines)-visibleRows:]
+	}
+	for _, line := range lines {
+		b.WriteString(truncateDisplay(line, m.width) + "\n")
+	}
+	for i := len(lines); i < visibleRows; i++ {
+		b.WriteString("\n")
+	}
+	b.WriteString(dashboardHelpStyle.Render("following claude-output.log · esc back · ctrl+c quit"))
+	return b.String()
+}
+
+// dashboardIndicator returns a one-cell activity marker for a mission status.
+func dashboardIndicator(status MissionDisplayStatus) string {
+	switch status {
+	case StatusBusy:
+		return ansiGreen + "●" + ansiReset
+	case StatusWaiting:
+		return ansiYellow + "●" + ansiReset
+	case StatusIdle, StatusRunning:
+		return ansiLightBlue + "○" + ansiReset
+	default:
+		return " "
+	}
+}
+
+// missionLastActivity returns the most recent user activity for a mission:
+// its last prompt, or its creation time if it has never been prompted.
+func missionLastActivity(mission *database.Mission) time.Time {
+	if mission.LastUserPromptAt != nil {
+		return *mission.LastUserPromptAt
+	}
+	return mission.CreatedAt
+}
+
+// padDisplay right-pads s with spaces to width terminal cells, ignoring ANSI
+// escape sequences when measuring.
+func padDisplay(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// truncateDisplay shortens plain text to at most width terminal cells,
+// marking the cut with an ellipsis.
+func truncateDisplay(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
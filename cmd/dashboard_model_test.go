@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+type fakeDashboardBackend struct {
+	missions []*database.Mission
+	projects []string
+	stopped  []string
+	archived []string
+	attached []string
+	logLines []string
+}
+
+func (f *fakeDashboardBackend) ListMissions(req server.ListMissionsRequest) ([]*database.Mission, error) {
+	if req.Project == "" {
+		return f.missions, nil
+	}
+	var filtered []*database.Mission
+	for _, mission := range f.missions {
+		if mission.Project == req.Project {
+			filtered = append(filtered, mission)
+		}
+	}
+	return filtered, nil
+}
+
+func (f *fakeDashboardBackend) ListProjects() ([]server.ProjectResponse, error) {
+	projects := make([]server.ProjectResponse, 0, len(f.projects))
+	for _, name := range f.projects {
+		projects = append(projects, server.ProjectResponse{Name: name})
+	}
+	return projects, nil
+}
+
+func (f *fakeDashboardBackend) StopMission(id string) error {
+	f.stopped = append(f.stopped, id)
+	return nil
+}
+
+func (f *fakeDashboardBackend) ArchiveMission(id string) error {
+	f.archived = append(f.archived, id)
+	return nil
+}
+
+func (f *fakeDashboardBackend) AttachMission(id string, tmuxSession string, noFocus bool) error {
+	f.attached = append(f.attached, id+"@"+tmuxSession)
+	return nil
+}
+
+func (f *fakeDashboardBackend) StreamMissionOutput(ctx context.Context, id string, source string, all bool, onLine func(string)) error {
+	for _, line := range f.logLines {
+		onLine(line)
+	}
+	return nil
+}
+
+func newTestDashboard(t *testing.T, tmuxSession string) (*dashboardModel, *fakeDashboardBackend) {
+	t.Helper()
+	t.Setenv("AGENC_DIRPATH", t.TempDir())
+	now := time.Now()
+	busy := "busy"
+	backend := &fakeDashboardBackend{missions: []*database.Mission{
+		{ID: "aaaaaaaa-0000-4000-8000-000000000001", ShortID: "aaaaaaaa", Status: "active", GitRepo: "github.com/o/one", Prompt: "first", CreatedAt: now.Add(-time.Hour), ClaudeState: &busy},
+		{ID: "bbbbbbbb-0000-4000-8000-000000000002", ShortID: "bbbbbbbb", Status: "active", GitRepo: "github.com/o/two", Prompt: "second", CreatedAt: now.Add(-2 * time.Hour)},
+	}}
+	m := newDashboardModel(backend, tmuxSession)
+	m.Update(m.loadMissions()())
+	return m, backend
+}
+
+// press sends a key to the model and runs any resulting command once,
+// feeding its message back in (enough for the dashboard's one-shot actions).
+func press(m *dashboardModel, key string) {
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	_, cmd := m.Update(msg)
+	if cmd != nil {
+		if result := cmd(); result != nil {
+			if _, isBatch := result.(tea.BatchMsg); !isBatch {
+				m.Update(result)
+			}
+		}
+	}
+}
+
+func TestDashboard_ActionsTargetSelectedMission(t *testing.T) {
+	m, backend := newTestDashboard(t, "work")
+
+	if !strings.Contains(m.View(), "aaaaaaaa") || !strings.Contains(m.View(), "o/two") {
+		t.Fatalf("missions not rendered:\n%s", m.View())
+	}
+
+	press(m, "down")
+	press(m, "s")
+	if len(backend.stopped) != 1 || backend.stopped[0] != backend.missions[1].ID {
+		t.Errorf("expected stop of second mission, got %v", backend.stopped)
+	}
+
+	press(m, "enter")
+	if len(backend.attached) != 1 || backend.attached[0] != backend.missions[1].ID+"@work" {
+		t.Errorf("expected attach of second mission into 'work', got %v", backend.attached)
+	}
+}
+
+func TestDashboard_ArchiveRequiresConfirmation(t *testing.T) {
+	m, backend := newTestDashboard(t, "work")
+
+	press(m, "x")
+	press(m, "n")
+	if len(backend.archived) != 0 {
+		t.Fatalf("archive should be cancelled, got %v", backend.archived)
+	}
+
+	press(m, "x")
+	press(m, "y")
+	if len(backend.archived) != 1 || backend.archived[0] != backend.missions[0].ID {
+		t.Errorf("expected archive of first mission, got %v", backend.archived)
+	}
+}
+
+func TestDashboard_AttachOutsideTmux(t *testing.T) {
+	m, backend := newTestDashboard(t, "")
+
+	press(m, "enter")
+	if len(backend.attached) != 0 {
+		t.Errorf("attach should not be attempted outside tmux, got %v", backend.attached)
+	}
+	if !m.statusIsErr || !strings.Contains(m.status, "tmux") {
+		t.Errorf("expected tmux error status, got %q", m.status)
+	}
+}
+
+func TestDashboard_LogView(t *testing.T) {
+	m, backend := newTestDashboard(t, "work")
+	backend.logLines = []string{"hello from claude"}
+
+	press(m, "l")
+	if m.logLines == nil {
+		t.Fatal("expected log view to open")
+	}
+	if !strings.Contains(m.View(), "hello from claude") {
+		t.Errorf("log line not rendered:\n%s", m.View())
+	}
+
+	press(m, "esc")
+	if m.logLines != nil {
+		t.Error("expected esc to return to the mission list")
+	}
+}
+
+func TestDashboard_CycleProjectFilter(t *testing.T) {
+	m, backend := newTestDashboard(t, "work")
+	backend.projects = []string{"billing", "launch"}
+	backend.missions[1].Project = "billing"
+
+	press(m, "p")
+	if m.project != "billing" {
+		t.Fatalf("expected filter on 'billing', got %q", m.project)
+	}
+	m.Update(m.loadMissions()())
+	if len(m.missions) != 1 || m.missions[0].ShortID != "bbbbbbbb" {
+		t.Errorf("expected only the billing mission, got %d missions", len(m.missions))
+	}
+	if !strings.Contains(m.View(), "project billing") {
+		t.Errorf("project not shown in title:\n%s", m.View())
+	}
+
+	press(m, "p")
+	if m.project != "launch" {
+		t.Errorf("expected filter on 'launch', got %q", m.project)
+	}
+	press(m, "p")
+	if m.project != "" {
+		t.Errorf("expected filter cleared after the last project, got %q", m.project)
+	}
+}
+
+func TestDashboard_ShowsSelectedMissionLastError(t *testing.T) {
+	m, backend := newTestDashboard(t, "work")
+	backend.missions[1].LastError = "claude exited with code 1: Error: boom"
+	m.Update(m.loadMissions()())
+
+	if strings.Contains(m.View(), "Last error:") {
+		t.Fatalf("last error shown for a mission without one:\n%s", m.View())
+	}
+	press(m, "down")
+	if !strings.Contains(m.View(), "Last error: claude exited with code 1: Error: boom") {
+		t.Errorf("expected the selected mission's last error:\n%s", m.View())
+	}
+}
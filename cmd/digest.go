@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/report"
+)
+
+var digestDateFlag string
+
+var digestCmd = &cobra.Command{
+	Use:   digestCmdStr,
+	Short: "Preview the daily digest of missions, cron runs, prompts, and failures",
+	Long: fmt.Sprintf(`Print the daily digest for a day: missions created and archived, prompts
+per repo, each cron's run outcomes, and failures (failed cron runs and
+mission crashes).
+
+With dailyDigest set in config.yml, the server writes yesterday's digest
+every morning at dailyDigest.time (default %s) to $AGENC_DIRPATH/%s/, as
+<date>.json (machine-readable) and <date>.txt (rendered with
+dailyDigest.template, a Go text/template), and sends the text to the
+dailyDigest.notify targets. Webhook targets also receive the JSON digest.
+
+This command builds the digest on the fly without storing or sending it, so
+it is handy for trying out a template. --output json prints the digest
+itself.
+
+Examples:
+  agenc digest
+  agenc digest --date 2026-10-01
+  agenc digest --output json`,
+		config.DefaultDailyDigestTime, config.DigestsDirname,
+	),
+	Args: cobra.NoArgs,
+	RunE: runDigest,
+}
+
+func init() {
+	digestCmd.Flags().StringVarP(&digestDateFlag, dateFlagName, "d", "", "day to summarize (YYYY-MM-DD, defaults to yesterday)")
+	rootCmd.AddCommand(digestCmd)
+}
+
+func runDigest(cmd *cobra.Command, args []string) error {
+	if digestDateFlag != "" {
+		if _, err := time.ParseInLocation(report.DateLayout, digestDateFlag, time.Local); err != nil {
+			return stacktrace.NewError("invalid --%s value %q: expected YYYY-MM-DD", dateFlagName, digestDateFlag)
+		}
+	}
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetDailyDigest(digestDateFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to build the daily digest")
+	}
+	if isStructuredOutput() {
+		return printStructured(resp.Digest)
+	}
+	fmt.Print(resp.Text)
+	return nil
+}
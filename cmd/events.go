@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var eventsFollowFlag bool
+var eventsTypeFlag []string
+var eventsMissionFlag string
+
+var eventsCmd = &cobra.Command{
+	Use:   eventsCmdStr,
+	Short: "Print or follow the server's event stream",
+	Long: fmt.Sprintf(`Print or follow the server's event stream.
+
+The server publishes an event whenever something integrations may want to
+react to happens, so scripts can subscribe instead of polling:
+
+  %-21s a mission was created (also on import and clone)
+  %-21s a mission's Claude received a prompt
+  %-21s a mission's Claude ran a tool
+  %-21s a mission's Claude finished responding and is waiting for input
+  %-21s a mission's wrapper crashed (see autoRestartCrashed)
+  %-21s a mission's running wrapper stopped answering health pings
+  %-21s a mission was reloaded, or restarted after a crash
+  %-21s a mission's Claude exited
+  %-21s a mission was archived
+  %-21s a mission pushed to its repo's default branch
+  %-21s a cron launched its mission
+  %-21s a cron's failed runs in a row reached alertOnConsecutiveFailures
+  %-21s a mission refreshed the shared Claude credentials
+
+By default, prints the recent events the server remembers (up to 200, since
+the server started). Use -f/--follow to keep streaming new events as they
+happen (Ctrl-C to stop). With --output json, follow mode prints one JSON
+object per line.
+
+The same stream is available to other programs as Server-Sent Events from
+GET /events?follow=true on the server socket. Mission events are also kept
+for the life of the mission; see 'agenc mission timeline'.
+
+Examples:
+  agenc events
+  agenc events -f --type mission.idle
+  agenc events -f --mission abc12345 -o json`,
+		server.EventMissionCreated, server.EventPromptSubmitted, server.EventToolRun, server.EventMissionIdle,
+		server.EventMissionCrashed, server.EventMissionUnresponsive, server.EventMissionRestarted, server.EventMissionExited, server.EventMissionArchived,
+		server.EventRefUpdated, server.EventCronFired, server.EventCronFailing, server.EventCredentialRefreshed),
+	Args: cobra.NoArgs,
+	RunE: runEvents,
+}
+
+func init() {
+	eventsCmd.Flags().BoolVarP(&eventsFollowFlag, followFlagName, "f", false, "stream new events as they happen")
+	eventsCmd.Flags().StringSliceVar(&eventsTypeFlag, eventsTypeFlagName, nil, "only show events of this type (repeatable or comma-separated)")
+	eventsCmd.Flags().StringVar(&eventsMissionFlag, eventsMissionFlagName, "", "only show events for this mission ID or short ID")
+	_ = eventsCmd.RegisterFlagCompletionFunc(eventsMissionFlagName, completeMissionFlag)
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	if !eventsFollowFlag {
+		events, err := client.ListEvents(eventsTypeFlag, eventsMissionFlag)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to list events")
+		}
+		if isStructuredOutput() {
+			return printStructured(events)
+		}
+		if len(events) == 0 {
+			fmt.Println("No events.")
+			return nil
+		}
+		tbl := tableprinter.NewTable("TIME", "TYPE", "MISSION", "DETAILS")
+		for _, event := range events {
+			tbl.AddRow(
+				event.Time.Local().Format("2006-01-02 15:04:05"),
+				event.Type,
+				database.ShortID(event.MissionID),
+				formatEventData(event.Data),
+			)
+		}
+		tbl.Print()
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err = client.StreamEvents(ctx, eventsTypeFlag, eventsMissionFlag, func(event server.Event) {
+		if isStructuredOutput() {
+			// One object per line, so consumers can process events as they arrive
+			data, err := json.Marshal(event)
+			if err == nil {
+				fmt.Println(string(data))
+			}
+			return
+		}
+		fmt.Println(formatEventLine(event))
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to follow events")
+	}
+	return nil
+}
+
+// formatEventLine renders an event as a single human-readable line.
+func formatEventLine(event server.Event) string {
+	parts := []string{event.Time.Local().Format("2006-01-02 15:04:05"), event.Type}
+	if event.MissionID != "" {
+		parts = append(parts, database.ShortID(event.MissionID))
+	}
+	if details := formatEventData(event.Data); details != "" {
+		parts = append(parts, details)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// formatEventData renders an event's data as space-separated key=value pairs
+// sorted by key, skipping empty values.
+func formatEventData(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key, value := range data {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+data[key])
+	}
+	return strings.Join(pairs, " ")
+}
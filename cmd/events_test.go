@@ -0,0 +1,13 @@
+package cmd
+
+import "testing"
+
+func TestFormatEventData(t *testing.T) {
+	got := formatEventData(map[string]string{"repo": "github.com/owner/repo", "empty": "", "cron": "nightly"})
+	if want := "cron=nightly repo=github.com/owner/repo"; got != want {
+		t.Errorf("formatEventData() = %q, want %q", got, want)
+	}
+	if got := formatEventData(nil); got != "" {
+		t.Errorf("formatEventData(nil) = %q, want empty", got)
+	}
+}
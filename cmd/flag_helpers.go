@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
 )
@@ -41,3 +43,20 @@ func applyBoolFlag(cmd *cobra.Command, flagName string, apply func(bool) error)
 	}
 	return apply(value)
 }
+
+// parseEnvFlag turns repeated KEY=VALUE flag values into a map. Empty values
+// are skipped, so a lone --env="" yields an empty map.
+func parseEnvFlag(flagName string, entries []string) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, entry := range entries {
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			return nil, stacktrace.NewError("invalid --%s value '%s'; expected KEY=VALUE", flagName, entry)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
@@ -154,6 +154,10 @@ type FzfSearchPickerConfig struct {
 	Headers       []string // Column headers
 	ReloadCommand string   // Command to run on each keystroke (receives {q} as query)
 	InitialInput  string   // Initial stdin content (pre-formatted with index columns)
+	// PreviewCommand, if set, fills a preview pane for the highlighted row
+	// ({1} is its index column); PreviewWindow is fzf's --preview-window
+	PreviewCommand string
+	PreviewWindow  string
 }
 
 // runFzfSearchPicker runs fzf in search mode with dynamic reloading.
@@ -179,6 +183,12 @@ func runFzfSearchPicker(cfg FzfSearchPickerConfig) (string, error) {
 	if len(cfg.Headers) > 0 {
 		args = append(args, "--header", strings.Join(cfg.Headers, "  "))
 	}
+	if cfg.PreviewCommand != "" {
+		args = append(args, "--preview", cfg.PreviewCommand)
+		if cfg.PreviewWindow != "" {
+			args = append(args, "--preview-window", cfg.PreviewWindow)
+		}
+	}
 
 	fzfCmd := exec.Command(fzfBinary, args...)
 	fzfCmd.Stdin = strings.NewReader(cfg.InitialInput)
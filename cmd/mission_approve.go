@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+var missionApproveBranchFlag string
+
+var missionApproveCmd = &cobra.Command{
+	Use:   approveCmdStr + " <mission-id>",
+	Short: "Fast-forward a reviewed mission's branch to what its agent pushed",
+	Long: fmt.Sprintf(`Fast-forward a reviewed mission's branch to what its agent pushed.
+
+For repos with reviewRequired set in their repoConfig, a mission's pushes land
+on an agenc/review/<branch> holding branch instead of <branch> itself. Once
+you've reviewed the holding branch, approving pushes it to <branch> on origin
+and deletes the holding branch. The push is never forced: if <branch> moved in
+the meantime, approval fails and the agent needs to rebase and push again.
+
+The branch defaults to the one checked out in the mission's workspace.
+
+Examples:
+  %s %s %s 2b4c8f1a
+  %s %s %s 2b4c8f1a --%s agenc/2b4c8f1a-fix-login`,
+		agencCmdStr, missionCmdStr, approveCmdStr,
+		agencCmdStr, missionCmdStr, approveCmdStr, missionApproveBranchFlagName,
+	),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionApprove,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionApproveCmd.Flags().StringVar(&missionApproveBranchFlag, missionApproveBranchFlagName, "", "branch to approve (default: the mission's checked-out branch)")
+	missionCmd.AddCommand(missionApproveCmd)
+}
+
+func runMissionApprove(cmd *cobra.Command, args []string) error {
+	if !looksLikeMissionID(args[0]) {
+		return stacktrace.NewError("not a valid mission ID: %s", args[0])
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	resp, err := client.ApproveMission(missionID, missionApproveBranchFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to approve mission %s", database.ShortID(missionID))
+	}
+
+	fmt.Printf("Approved '%s': fast-forwarded to %s and deleted '%s'\n", resp.Branch, shortCommit(resp.Commit), resp.ReviewBranch)
+	return nil
+}
@@ -1,30 +1,61 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/mattn/go-isatty"
 	"github.com/mieubrisse/stacktrace"
 	"github.com/odyssey/agenc/internal/server"
 	"github.com/spf13/cobra"
 )
 
+var archiveNoteFlag string
+
 var missionArchiveCmd = &cobra.Command{
 	Use:   archiveCmdStr + " [mission-id...]",
 	Short: "Stop and archive one or more missions",
-	Long: `Stop and archive one or more missions.
+	Long: fmt.Sprintf(`Stop and archive one or more missions.
 
 Without arguments, opens an interactive fzf picker showing active missions.
-With arguments, accepts a mission ID (short 8-char hex or full UUID).`,
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionArchive,
+With arguments, accepts a mission ID (short 8-char hex or full UUID).
+
+Before archiving, you're asked for an optional handoff note summarizing where
+the work stands (or pass it with --%s). The note is saved with the mission and
+written into its workspace as HANDOFF.md. When the mission is resumed, Claude
+is given the note as context until you next prompt it, so the work picks up
+where it left off.
+
+With --all, --repo, or --older-than, stops and archives every matching
+mission at once instead; --repo and --older-than can be combined.
+--older-than counts from a mission's last user prompt (or its creation, if
+never prompted) and takes a number of days like "7d". Use --dry-run to list
+the matches first.`, archiveNoteFlagName),
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionArchive,
+	ValidArgsFunction: completeMissionIDs,
 }
 
 func init() {
+	addMissionBatchFlags(missionArchiveCmd)
+	missionArchiveCmd.Flags().StringVar(&archiveNoteFlag, archiveNoteFlagName, "", "handoff note to leave for when the mission is resumed (skips the prompt)")
 	missionCmd.AddCommand(missionArchiveCmd)
 }
 
 func runMissionArchive(cmd *cobra.Command, args []string) error {
+	filter, isBatch, err := missionBatchFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if isBatch {
+		if archiveNoteFlag != "" {
+			return stacktrace.NewError("--%s applies to missions archived by ID or picker, not batch archives", archiveNoteFlagName)
+		}
+		return runMissionBatch(cmd, args, server.MissionBatchActionArchive, "Archived", filter, false)
+	}
+
 	client, err := serverClient()
 	if err != nil {
 		return err
@@ -77,8 +108,25 @@ func runMissionArchive(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	promptForNote := !cmd.Flags().Changed(archiveNoteFlagName) && isatty.IsTerminal(os.Stdin.Fd())
+	reader := bufio.NewReader(os.Stdin)
 	for _, entry := range result.Items {
-		if err := client.ArchiveMission(entry.MissionID); err != nil {
+		note := archiveNoteFlag
+		if promptForNote {
+			fmt.Printf("Handoff note for %s (Enter to skip): ", entry.ShortID)
+			input, err := reader.ReadString('\n')
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to read handoff note")
+			}
+			note = strings.TrimSpace(input)
+		}
+
+		if note != "" {
+			err = client.ArchiveMissionWithHandoff(entry.MissionID, note)
+		} else {
+			err = client.ArchiveMission(entry.MissionID)
+		}
+		if err != nil {
 			return stacktrace.Propagate(err, "failed to archive mission %s", entry.ShortID)
 		}
 		fmt.Printf("Archived mission: %s\n", entry.ShortID)
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"strings"
@@ -12,10 +11,14 @@ import (
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/server"
-	"github.com/odyssey/agenc/internal/tableprinter"
+	"github.com/odyssey/agenc/internal/terminal"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
-var attachNoFocusFlag bool
+var (
+	attachNoFocusFlag bool
+	attachSplitFlag   bool
+)
 
 var missionAttachCmd = &cobra.Command{
 	Use:   attachCmdStr + " [mission-id]",
@@ -26,16 +29,30 @@ Links the mission's tmux window into your session and focuses it.
 If the mission is already linked, just focuses the window.
 Stopped missions are automatically resumed; archived missions are unarchived first.
 
-Without arguments, opens an interactive search picker showing all missions.
-Type to search by conversation content; results update live.
+With --split, the mission's pane joins your current tmux window as a
+side-by-side split instead, so it sits next to your editor without switching
+windows. 'agenc mission detach' moves it back into its own window in the pool.
+
+Outside tmux, with terminalBackend set to "wezterm" or "zellij", the mission
+opens in a new tab of that terminal instead. The tab runs a tmux client on a
+session holding just the mission's window; closing the tab leaves the mission
+running in the pool. These backends are compiled in with the build tags of the
+same name.
+
+Without arguments, opens an interactive fzf picker listing missions with their
+short ID, idle/busy status, window title, repo, and AI summary, and a preview
+pane tailing the highlighted mission's recent output. Type to search by
+conversation content, title, or repo; results update live.
 With arguments, accepts a mission ID (short 8-char hex or full UUID).`,
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionAttach,
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionAttach,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
 	missionCmd.AddCommand(missionAttachCmd)
 	missionAttachCmd.Flags().BoolVar(&attachNoFocusFlag, noFocusFlagName, false, "don't focus the mission's tmux window after attaching")
+	missionAttachCmd.Flags().BoolVar(&attachSplitFlag, splitFlagName, false, "join the mission's pane into the current tmux window as a split")
 }
 
 func runMissionAttach(cmd *cobra.Command, args []string) error {
@@ -45,8 +62,24 @@ func runMissionAttach(cmd *cobra.Command, args []string) error {
 	}
 
 	tmuxSession := getCallingSessionName()
+	var terminalBackend string
 	if tmuxSession == "" {
-		return stacktrace.NewError("mission attach requires tmux; run inside a tmux session")
+		cfg, err := readConfig()
+		if err != nil {
+			return err
+		}
+		terminalBackend = cfg.GetTerminalBackend()
+		if terminalBackend == config.TerminalBackendTmux {
+			return stacktrace.NewError("mission attach requires tmux; run inside a tmux session, or set terminalBackend to open missions in WezTerm or Zellij")
+		}
+	}
+
+	var callerPane string
+	if attachSplitFlag {
+		callerPane = getCallingPaneID()
+		if tmuxSession == "" || callerPane == "" {
+			return stacktrace.NewError("--%s requires running inside a tmux pane", splitFlagName)
+		}
 	}
 
 	input := strings.Join(args, " ")
@@ -87,6 +120,17 @@ func runMissionAttach(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Attaching mission: %s\n", database.ShortID(missionID))
 
+	if tmuxSession == "" {
+		return attachMissionInTerminal(client, terminalBackend, missionID)
+	}
+
+	if attachSplitFlag {
+		if err := client.AttachMissionSplit(missionID, tmuxSession, callerPane, attachNoFocusFlag); err != nil {
+			return stacktrace.Propagate(err, "failed to attach mission")
+		}
+		return nil
+	}
+
 	if err := client.AttachMission(missionID, tmuxSession, attachNoFocusFlag); err != nil {
 		return stacktrace.Propagate(err, "failed to attach mission")
 	}
@@ -94,56 +138,74 @@ func runMissionAttach(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// runMissionSearchPicker opens the search-mode fzf picker for missions.
+// terminalViewSessionPrefix prefixes the tmux session that shows a single
+// mission in a terminal backend's tab.
+const terminalViewSessionPrefix = "agenc-view-"
+
+// attachMissionInTerminal opens a mission in a new tab of a non-tmux terminal
+// backend. The mission's pool window is linked into a dedicated tmux session
+// that is destroyed when its last client (the tab) goes away.
+func attachMissionInTerminal(client *server.Client, backendName string, missionID string) error {
+	backend, err := terminal.Get(backendName)
+	if err != nil {
+		return err
+	}
+	if !backend.IsInside() {
+		return stacktrace.NewError("terminalBackend is '%s' but this shell isn't running inside %s", backendName, backendName)
+	}
+
+	sessionName := terminalViewSessionPrefix + database.ShortID(missionID)
+	if !tmuxSessionExists(sessionName) {
+		// tmux sessions need a window; the placeholder is closed once the
+		// mission's window is linked in
+		output, err := tmux.Command("new-session", "-d", "-s", sessionName, "-P", "-F", "#{window_id}").Output()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to create tmux session '%s'", sessionName)
+		}
+		placeholderWindowID := strings.TrimSpace(string(output))
+		if err := client.AttachMissionToSession(missionID, sessionName); err != nil {
+			_ = tmux.Command("kill-session", "-t", "="+sessionName).Run()
+			return stacktrace.Propagate(err, "failed to attach mission")
+		}
+		_ = tmux.Command("kill-window", "-t", placeholderWindowID).Run()
+		_ = tmux.Command("set-option", "-t", "="+sessionName, "destroy-unattached", "on").Run()
+	}
+
+	title := "agenc " + database.ShortID(missionID)
+	if err := backend.OpenTab(title, tmux.Argv("attach-session", "-t", "="+sessionName)); err != nil {
+		return stacktrace.Propagate(err, "failed to open mission in %s", backendName)
+	}
+	return nil
+}
+
+// runMissionSearchPicker opens the search-mode fzf picker for missions, with
+// a preview of the highlighted mission's recent output.
 // Returns the selected mission's short ID, or empty string if cancelled.
 func runMissionSearchPicker(client *server.Client) (string, error) {
-	// Find our binary path for the reload command
+	// Find our binary path for the reload and preview commands
 	agencBinary, err := os.Executable()
 	if err != nil {
 		agencBinary = "agenc"
 	}
 	reloadCmd := fmt.Sprintf("%s mission search-fzf {q}", agencBinary)
+	previewCmd := fmt.Sprintf("%s mission %s {1}", agencBinary, previewFzfCmdStr)
 
-	// Build initial rows (recent missions for empty query)
-	missions, err := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true})
+	// Initial rows are the recent missions shown for an empty query
+	rows, err := recentMissionSearchFzfRows(client)
 	if err != nil {
 		return "", stacktrace.Propagate(err, "failed to list missions")
 	}
 
-	if len(missions) == 0 {
+	if len(rows) == 0 {
 		return "", stacktrace.NewError("no missions to attach")
 	}
 
-	sortMissionsForPicker(missions)
-	entries := buildMissionPickerEntries(missions, 30)
-
-	// Format initial input matching the search-fzf output format
-	var buf bytes.Buffer
-	tbl := tableprinter.NewTable("ID", "●", "LAST PROMPT", "SESSION", "REPO", "MATCH").WithWriter(&buf)
-	for _, e := range entries {
-		tbl.AddRow(e.ShortID, attachedDot(e.IsAttached), e.LastPrompt, e.Session, e.Repo, "")
-	}
-	tbl.Print()
-
-	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
-	var initialInput strings.Builder
-	for i, line := range lines {
-		if i == 0 {
-			continue // skip header
-		}
-		idx := i - 1
-		if idx < len(entries) {
-			initialInput.WriteString(entries[idx].ShortID)
-			initialInput.WriteString("\t")
-			initialInput.WriteString(line)
-			initialInput.WriteString("\n")
-		}
-	}
-
 	return runFzfSearchPicker(FzfSearchPickerConfig{
-		Prompt:        "Search missions: ",
-		Headers:       []string{"ID", "●", "LAST PROMPT", "SESSION", "REPO", "MATCH"},
-		ReloadCommand: reloadCmd,
-		InitialInput:  initialInput.String(),
+		Prompt:         "Search missions: ",
+		Headers:        missionSearchFzfHeaders,
+		ReloadCommand:  reloadCmd,
+		InitialInput:   formatSearchFzfInput(rows),
+		PreviewCommand: previewCmd,
+		PreviewWindow:  missionPreviewWindow,
 	})
 }
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+// addMissionBatchFlags registers the batch filter flags shared by mission
+// stop, archive, and rm.
+func addMissionBatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(allFlagName, false, "select every mission")
+	cmd.Flags().String(batchRepoFlagName, "", "select missions for this repo (owner/repo, canonical name, or URL)")
+	cmd.Flags().String(olderThanFlagName, "", "select missions last prompted more than this many days ago (e.g. 7d)")
+	cmd.Flags().Bool(dryRunFlagName, false, "list the selected missions without acting on them")
+	_ = cmd.RegisterFlagCompletionFunc(batchRepoFlagName, completeRepoFlag)
+}
+
+// missionBatchFilterFromFlags reads the batch filter flags. The returned bool
+// is false when none were given, meaning the command should fall back to its
+// per-mission behavior.
+func missionBatchFilterFromFlags(cmd *cobra.Command) (server.MissionBatchFilter, bool, error) {
+	var filter server.MissionBatchFilter
+	var err error
+	if filter.All, err = cmd.Flags().GetBool(allFlagName); err != nil {
+		return filter, false, stacktrace.Propagate(err, "failed to read --%s flag", allFlagName)
+	}
+	if filter.Repo, err = cmd.Flags().GetString(batchRepoFlagName); err != nil {
+		return filter, false, stacktrace.Propagate(err, "failed to read --%s flag", batchRepoFlagName)
+	}
+	if filter.OlderThan, err = cmd.Flags().GetString(olderThanFlagName); err != nil {
+		return filter, false, stacktrace.Propagate(err, "failed to read --%s flag", olderThanFlagName)
+	}
+	return filter, filter.All || filter.Repo != "" || filter.OlderThan != "", nil
+}
+
+// runMissionBatch applies action to the missions selected by filter and
+// prints what happened. pastTense describes the action in the summary line
+// (e.g. "Stopped"). When confirm is set and this is not a dry run, the
+// matches are listed first and the user must confirm unless --force is set.
+func runMissionBatch(cmd *cobra.Command, args []string, action string, pastTense string, filter server.MissionBatchFilter, confirm bool) error {
+	if len(args) > 0 {
+		return stacktrace.NewError("mission IDs cannot be combined with --%s, --%s, or --%s", allFlagName, batchRepoFlagName, olderThanFlagName)
+	}
+	dryRun, err := cmd.Flags().GetBool(dryRunFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", dryRunFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	if confirm && !dryRun {
+		force, err := cmd.Flags().GetBool(forceFlagName)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read --%s flag", forceFlagName)
+		}
+		if !force {
+			proceed, err := confirmMissionBatch(client, cmd, action, filter)
+			if err != nil || !proceed {
+				return err
+			}
+		}
+	}
+
+	resp, err := client.BatchMissions(server.MissionBatchRequest{Action: action, Filter: filter, DryRun: dryRun})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to %s missions", action)
+	}
+
+	if isStructuredOutput() {
+		return printStructured(resp)
+	}
+	if len(resp.Entries) == 0 {
+		fmt.Println("No matching missions.")
+		return nil
+	}
+
+	printMissionBatchEntries(resp.Entries)
+	fmt.Println()
+	numFailed, numForbidden := countMissionBatchFailures(resp.Entries)
+	if dryRun {
+		fmt.Printf("Dry run: would %s %d mission(s).\n", action, len(resp.Entries)-numForbidden)
+		printMissionBatchForbidden(numForbidden)
+		return nil
+	}
+	fmt.Printf("%s %d mission(s).\n", pastTense, len(resp.Entries)-numFailed-numForbidden)
+	printMissionBatchForbidden(numForbidden)
+	if numFailed > 0 {
+		return stacktrace.NewError("%d mission(s) could not be processed; see the server log", numFailed)
+	}
+	return nil
+}
+
+// countMissionBatchFailures counts the entries whose action failed and,
+// separately, those skipped because they belong to another user.
+func countMissionBatchFailures(entries []server.MissionBatchEntry) (numFailed int, numForbidden int) {
+	for _, e := range entries {
+		switch {
+		case e.Forbidden:
+			numForbidden++
+		case e.Error != "":
+			numFailed++
+		}
+	}
+	return numFailed, numForbidden
+}
+
+func printMissionBatchForbidden(numForbidden int) {
+	if numForbidden > 0 {
+		fmt.Printf("Skipped %d mission(s) that belong to other users.\n", numForbidden)
+	}
+}
+
+// confirmMissionBatch lists the missions a batch action would touch and asks
+// the user to confirm. Returns false without error when nothing matches or the
+// user declines.
+func confirmMissionBatch(client *server.Client, cmd *cobra.Command, action string, filter server.MissionBatchFilter) (bool, error) {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return false, stacktrace.NewError("'%s' requires a terminal for confirmation; use --%s to skip", cmd.CommandPath(), forceFlagName)
+	}
+
+	preview, err := client.BatchMissions(server.MissionBatchRequest{Action: action, Filter: filter, DryRun: true})
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to list matching missions")
+	}
+	_, numForbidden := countMissionBatchFailures(preview.Entries)
+	if len(preview.Entries) == numForbidden {
+		fmt.Println("No matching missions.")
+		printMissionBatchForbidden(numForbidden)
+		return false, nil
+	}
+
+	printMissionBatchEntries(preview.Entries)
+	fmt.Println()
+	printMissionBatchForbidden(numForbidden)
+	fmt.Printf("This will %s %d mission(s). Continue? [y/N] ", action, len(preview.Entries)-numForbidden)
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to read confirmation")
+	}
+	answer := strings.ToLower(strings.TrimSpace(input))
+	if answer != "y" && answer != "yes" {
+		fmt.Println("Aborted.")
+		return false, nil
+	}
+	return true, nil
+}
+
+func printMissionBatchEntries(entries []server.MissionBatchEntry) {
+	now := time.Now()
+	tbl := tableprinter.NewTable("ID", "LAST ACTIVE", "SESSION", "REPO")
+	for _, e := range entries {
+		shortID := e.ShortID
+		switch {
+		case e.Forbidden:
+			shortID = ansiRed + e.ShortID + " (forbidden)" + ansiReset
+		case e.Error != "":
+			shortID = ansiRed + e.ShortID + " (failed)" + ansiReset
+		}
+		tbl.AddRow(
+			shortID,
+			formatTimeAgo(e.LastActiveAt, now),
+			truncatePrompt(e.SessionName, defaultPromptMaxLen),
+			displayGitRepo(e.GitRepo),
+		)
+	}
+	tbl.Print()
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+var missionBranchCreateFlag bool
+
+var missionBranchCmd = &cobra.Command{
+	Use:   branchCmdStr + " <mission-id> [branch]",
+	Short: "Show or change the git branch a mission works on",
+	Long: fmt.Sprintf(`Show or change the git branch a mission works on.
+
+With only a mission ID, prints the branch checked out in the mission's
+workspace. With a branch name, switches the workspace to that branch; pass
+--%s to create it from the workspace's current HEAD. Uncommitted changes carry
+over, and the switch is refused if they would be overwritten.
+
+Repos with autoBranch enabled start every mission on a fresh branch; see
+'%s %s %s %s --help'.
+
+Examples:
+  %s %s %s 2b4c8f1a
+  %s %s %s 2b4c8f1a agenc/2b4c8f1a-fix-login --%s`,
+		createFlagName,
+		agencCmdStr, configCmdStr, repoConfigCmdStr, setCmdStr,
+		agencCmdStr, missionCmdStr, branchCmdStr,
+		agencCmdStr, missionCmdStr, branchCmdStr, createFlagName,
+	),
+	Args:              cobra.RangeArgs(1, 2),
+	RunE:              runMissionBranch,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionBranchCmd.Flags().BoolVar(&missionBranchCreateFlag, createFlagName, false, "create the branch from the workspace's current HEAD")
+	missionCmd.AddCommand(missionBranchCmd)
+}
+
+func runMissionBranch(cmd *cobra.Command, args []string) error {
+	if !looksLikeMissionID(args[0]) {
+		return stacktrace.NewError("not a valid mission ID: %s", args[0])
+	}
+	if len(args) < 2 && missionBranchCreateFlag {
+		return stacktrace.NewError("--%s requires a branch name", createFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	if len(args) < 2 {
+		resp, err := client.GetMissionBranch(missionID)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to get branch for mission %s", database.ShortID(missionID))
+		}
+		if resp.Branch == "" {
+			fmt.Println("(detached HEAD)")
+			return nil
+		}
+		fmt.Println(resp.Branch)
+		return nil
+	}
+
+	resp, err := client.SetMissionBranch(missionID, args[1], missionBranchCreateFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to switch branch for mission %s", database.ShortID(missionID))
+	}
+	fmt.Printf("Mission '%s' is now on branch '%s'\n", resp.ShortID, resp.Branch)
+	return nil
+}
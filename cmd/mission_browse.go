@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var missionBrowseRecursiveFlag bool
+
+var missionBrowseCmd = &cobra.Command{
+	Use:   browseCmdStr + " <mission-id> [path]",
+	Short: "Browse a mission's files without spawning it",
+	Long: fmt.Sprintf(`Browse a mission's agent directory read-only, without spawning the mission
+or unarchiving it — handy for pulling a file out of an archived mission.
+
+path is relative to the mission's agent directory (the repo checkout for repo
+missions) and defaults to the directory itself. A directory is listed; a file
+is printed to stdout as-is, so it can be piped or redirected.
+
+  --%s  list everything below the directory (.git is not expanded)
+
+Examples:
+  %s %s %s 2b4c8f1a
+  %s %s %s 2b4c8f1a docs --%s
+  %s %s %s 2b4c8f1a notes/plan.md > plan.md`,
+		recursiveFlagName,
+		agencCmdStr, missionCmdStr, browseCmdStr,
+		agencCmdStr, missionCmdStr, browseCmdStr, recursiveFlagName,
+		agencCmdStr, missionCmdStr, browseCmdStr,
+	),
+	Args:              cobra.RangeArgs(1, 2),
+	RunE:              runMissionBrowse,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionBrowseCmd.Flags().BoolVarP(&missionBrowseRecursiveFlag, recursiveFlagName, "r", false, "list everything below the directory")
+	missionCmd.AddCommand(missionBrowseCmd)
+}
+
+func runMissionBrowse(cmd *cobra.Command, args []string) error {
+	if !looksLikeMissionID(args[0]) {
+		return stacktrace.NewError("not a valid mission ID: %s", args[0])
+	}
+	relpath := ""
+	if len(args) == 2 {
+		relpath = args[1]
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	listing, err := client.ListMissionFiles(missionID, relpath, missionBrowseRecursiveFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to browse mission %s", database.ShortID(missionID))
+	}
+
+	if listing.Type != mission.SnapshotEntryDir {
+		content, err := client.GetMissionFileContent(missionID, listing.Path)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read '%s' from mission %s", listing.Path, listing.ShortID)
+		}
+		if _, err := os.Stdout.Write(content); err != nil {
+			return stacktrace.Propagate(err, "failed to write '%s' to stdout", listing.Path)
+		}
+		return nil
+	}
+
+	if isStructuredOutput() {
+		return printStructured(listing)
+	}
+	if len(listing.Entries) == 0 {
+		fmt.Printf("%s is empty.\n", formatBrowsePath(listing.Path))
+		return nil
+	}
+
+	now := time.Now()
+	tbl := tableprinter.NewTable("PATH", "SIZE", "MODIFIED")
+	for _, entry := range listing.Entries {
+		tbl.AddRow(formatBrowseEntryPath(entry), formatBrowseEntrySize(entry), formatTimeAgo(entry.ModTime, now))
+	}
+	tbl.Print()
+	return nil
+}
+
+// formatBrowsePath renders a listed path for messages, with "." shown as the
+// agent directory.
+func formatBrowsePath(relpath string) string {
+	if relpath == "." {
+		return "The agent directory"
+	}
+	return relpath
+}
+
+// formatBrowseEntryPath renders an entry's path with a trailing slash for
+// directories and an @ for symlinks, like 'ls -F'.
+func formatBrowseEntryPath(entry mission.SnapshotEntry) string {
+	switch entry.Type {
+	case mission.SnapshotEntryDir:
+		return entry.Path + "/"
+	case mission.SnapshotEntrySymlink:
+		return entry.Path + "@"
+	default:
+		return entry.Path
+	}
+}
+
+// formatBrowseEntrySize renders a file's size, or "--" for directories and
+// symlinks, whose sizes say nothing about their contents.
+func formatBrowseEntrySize(entry mission.SnapshotEntry) string {
+	if entry.Type != mission.SnapshotEntryFile {
+		return "--"
+	}
+	return config.FormatByteSize(entry.Size)
+}
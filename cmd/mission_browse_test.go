@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+func TestFormatBrowseEntry(t *testing.T) {
+	tests := []struct {
+		entry    mission.SnapshotEntry
+		wantPath string
+		wantSize string
+	}{
+		{mission.SnapshotEntry{Path: "docs/plan.md", Type: mission.SnapshotEntryFile, Size: 2048}, "docs/plan.md", "2.0 KiB"},
+		{mission.SnapshotEntry{Path: "docs", Type: mission.SnapshotEntryDir, Size: 4096}, "docs/", "--"},
+		{mission.SnapshotEntry{Path: "latest", Type: mission.SnapshotEntrySymlink, Size: 12}, "latest@", "--"},
+	}
+	for _, tt := range tests {
+		if got := formatBrowseEntryPath(tt.entry); got != tt.wantPath {
+			t.Errorf("formatBrowseEntryPath(%+v) = %q, want %q", tt.entry, got, tt.wantPath)
+		}
+		if got := formatBrowseEntrySize(tt.entry); got != tt.wantSize {
+			t.Errorf("formatBrowseEntrySize(%+v) = %q, want %q", tt.entry, got, tt.wantSize)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var missionConflictsCmd = &cobra.Command{
+	Use:   conflictsCmdStr,
+	Short: "Show active missions on the same branch and the files they both modify",
+	Long: `Show active missions working on the same branch of the same repo, and the
+files more than one of them is modifying.
+
+Each mission works in its own clone, but missions on the same branch end up
+pushing to it: overlapping changes there mean merge conflicts or one mission
+undoing another's work. A file counts as modified by a mission when its
+commits, uncommitted edits, or untracked files touch it.
+
+'agenc mission new' warns when a new mission starts on a branch other active
+missions are already using. Give each mission its own branch with the repo's
+autoBranch setting, or a git worktree workspace, to avoid this.
+
+Examples:
+  agenc mission conflicts
+  agenc mission conflicts --repo owner/repo
+`,
+	Args: cobra.NoArgs,
+	RunE: runMissionConflicts,
+}
+
+func init() {
+	missionCmd.AddCommand(missionConflictsCmd)
+	missionConflictsCmd.Flags().String(missionConflictsRepoFlagName, "", "only check missions for this repo (owner/repo, canonical name, or URL)")
+	_ = missionConflictsCmd.RegisterFlagCompletionFunc(missionConflictsRepoFlagName, completeRepoFlag)
+}
+
+func runMissionConflicts(cmd *cobra.Command, args []string) error {
+	repo, err := cmd.Flags().GetString(missionConflictsRepoFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", missionConflictsRepoFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	groups, err := client.ListMissionConflicts(repo)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check missions for conflicts")
+	}
+
+	if isStructuredOutput() {
+		return printStructured(groups)
+	}
+	if len(groups) == 0 {
+		fmt.Println("No active missions share a branch.")
+		return nil
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Println()
+		}
+		printMissionConflictGroup(group)
+	}
+	return nil
+}
+
+// printMissionConflictGroup prints the missions sharing one branch and the
+// files they overlap on.
+func printMissionConflictGroup(group server.MissionConflictGroup) {
+	fmt.Printf("%s (%s)\n", displayGitRepo(group.Repo), group.Branch)
+
+	tbl := tableprinter.NewTable("ID", "MODIFIED", "TITLE")
+	for _, m := range group.Missions {
+		modified := fmt.Sprintf("%d", m.ModifiedFiles)
+		if m.Error != "" {
+			modified = "error: " + m.Error
+		}
+		tbl.AddRow(m.ShortID, modified, truncatePrompt(m.Title, summaryColumnMaxLen))
+	}
+	tbl.Print()
+
+	if len(group.Files) == 0 {
+		fmt.Println("No files modified by more than one mission.")
+		return
+	}
+	fmt.Printf("%s modified by more than one mission:\n", formatConflictFileCount(len(group.Files)))
+	files := tableprinter.NewTable("FILE", "MISSIONS")
+	for _, file := range group.Files {
+		files.AddRow(file.Path, strings.Join(file.Missions, ", "))
+	}
+	files.Print()
+}
+
+// formatConflictFileCount renders a count of overlapping files.
+func formatConflictFileCount(count int) string {
+	if count == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", count)
+}
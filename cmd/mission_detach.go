@@ -17,14 +17,16 @@ var missionDetachCmd = &cobra.Command{
 	Short: "Detach a mission from the current tmux session",
 	Long: `Detach a mission from the current tmux session.
 
-Unlinks the mission's tmux window from your session. The mission keeps
+Unlinks the mission's tmux window from your session. A mission attached with
+--split is moved out of your window back into its own. The mission keeps
 running in the pool and can be re-attached later with 'agenc mission attach'.
 
 Without arguments, opens an interactive fzf picker showing missions
 linked to the current session.
 With arguments, accepts a mission ID (short 8-char hex or full UUID).`,
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionDetach,
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionDetach,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
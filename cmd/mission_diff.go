@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var missionDiffStatFlag bool
+var missionDiffPatchFlag bool
+
+var missionDiffCmd = &cobra.Command{
+	Use:   diffCmdStr + " <mission-id>",
+	Short: "Show a mission's changes relative to where it started",
+	Long: fmt.Sprintf(`Show what a mission has changed in its repo, so you can review an agent's
+work before attaching.
+
+Changes are measured against the commit where the mission's workspace
+diverged from the repo's default branch (origin/main, say), so they cover
+both the mission's commits and its uncommitted edits. Workspaces without an
+origin are compared against their HEAD.
+
+By default, prints the workspace's 'git status' followed by a diffstat.
+Untracked files only appear in the status.
+
+  --%-6s print only the diffstat
+  --%-6s print only the full patch, e.g. to pipe into a pager or 'git apply'
+
+Examples:
+  %s %s %s 2b4c8f1a
+  %s %s %s 2b4c8f1a --%s | less -R`,
+		missionDiffStatFlagName, missionDiffPatchFlagName,
+		agencCmdStr, missionCmdStr, diffCmdStr,
+		agencCmdStr, missionCmdStr, diffCmdStr, missionDiffPatchFlagName,
+	),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionDiff,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionDiffCmd.Flags().BoolVar(&missionDiffStatFlag, missionDiffStatFlagName, false, "print only the diffstat")
+	missionDiffCmd.Flags().BoolVar(&missionDiffPatchFlag, missionDiffPatchFlagName, false, "print only the full patch")
+	missionDiffCmd.MarkFlagsMutuallyExclusive(missionDiffStatFlagName, missionDiffPatchFlagName)
+	missionCmd.AddCommand(missionDiffCmd)
+}
+
+func runMissionDiff(cmd *cobra.Command, args []string) error {
+	if !looksLikeMissionID(args[0]) {
+		return stacktrace.NewError("not a valid mission ID: %s", args[0])
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	includePatch := missionDiffPatchFlag || isStructuredOutput()
+	diff, err := client.GetMissionDiff(missionID, includePatch)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to diff mission %s", database.ShortID(missionID))
+	}
+
+	if isStructuredOutput() {
+		return printStructured(diff)
+	}
+
+	switch {
+	case missionDiffPatchFlag:
+		fmt.Print(diff.Patch)
+	case missionDiffStatFlag:
+		fmt.Print(diff.Stat)
+	default:
+		printMissionDiffSummary(diff)
+	}
+	return nil
+}
+
+// printMissionDiffSummary prints the default view: what the diff is relative
+// to, the workspace status, and the diffstat.
+func printMissionDiffSummary(diff *server.MissionDiffResponse) {
+	switch {
+	case diff.BaseCommit == "":
+		fmt.Println("No commits yet in the mission workspace")
+	case diff.BaseRef != "":
+		fmt.Printf("Changes since %s (%s)\n", shortCommit(diff.BaseCommit), diff.BaseRef)
+	default:
+		fmt.Printf("Changes since HEAD (%s); the workspace has no origin\n", shortCommit(diff.BaseCommit))
+	}
+
+	if strings.TrimSpace(diff.Status) == "" && strings.TrimSpace(diff.Stat) == "" {
+		fmt.Println("\nNo changes.")
+		return
+	}
+	if strings.TrimSpace(diff.Status) != "" {
+		fmt.Println("\nUncommitted:")
+		fmt.Print(diff.Status)
+	}
+	if strings.TrimSpace(diff.Stat) != "" {
+		fmt.Println()
+		fmt.Print(diff.Stat)
+	}
+}
+
+// shortCommit abbreviates a commit SHA for display.
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
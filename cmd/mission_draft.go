@@ -8,15 +8,17 @@ import (
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
 	"github.com/spf13/cobra"
 )
 
 var missionDraftCmd = &cobra.Command{
-	Use:    draftCmdStr + " <mission-id>",
-	Short:  "Open an editor to draft text and paste it into the mission's pane",
-	Hidden: true,
-	Args:   cobra.ExactArgs(1),
-	RunE:   runMissionDraft,
+	Use:               draftCmdStr + " <mission-id>",
+	Short:             "Open an editor to draft text and paste it into the mission's pane",
+	Hidden:            true,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionDraft,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
@@ -80,7 +82,7 @@ func runMissionDraft(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	loadCmd := exec.Command("tmux", "load-buffer", tmpFilepath)
+	loadCmd := tmux.Command("load-buffer", tmpFilepath)
 	if output, err := loadCmd.CombinedOutput(); err != nil {
 		return stacktrace.Propagate(err, "failed to load buffer into tmux: %s", string(output))
 	}
@@ -88,12 +90,12 @@ func runMissionDraft(cmd *cobra.Command, args []string) error {
 	// Drop the target pane out of copy mode if it's in it, so the paste lands in the prompt.
 	// Gated on pane_in_mode — send-keys -X against a pane not in any mode can deliver a stray
 	// key to the running program (e.g. accepting Claude's default-suggestion prompt).
-	modeOut, modeErr := exec.Command("tmux", "display-message", "-p", "-t", targetPane, "#{pane_in_mode}").Output()
+	modeOut, modeErr := tmux.Command("display-message", "-p", "-t", targetPane, "#{pane_in_mode}").Output()
 	if modeErr == nil && strings.TrimSpace(string(modeOut)) == "1" {
-		_ = exec.Command("tmux", "send-keys", "-t", targetPane, "-X", "cancel").Run()
+		_ = tmux.Command("send-keys", "-t", targetPane, "-X", "cancel").Run()
 	}
 
-	pasteCmd := exec.Command("tmux", "paste-buffer", "-t", targetPane)
+	pasteCmd := tmux.Command("paste-buffer", "-t", targetPane)
 	if output, err := pasteCmd.CombinedOutput(); err != nil {
 		return stacktrace.Propagate(err, "failed to paste buffer into pane: %s", string(output))
 	}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+var missionExportOutputFlag string
+
+var missionExportCmd = &cobra.Command{
+	Use:   exportCmdStr + " <mission-id>",
+	Short: "Export a stopped mission to a portable bundle",
+	Long: fmt.Sprintf(`Export a stopped mission to a portable bundle.
+
+The bundle contains the mission's workspace (agent/, including git history),
+its Claude config snapshot, its conversation transcripts, and its database
+record, so a teammate or another machine can pick the mission up exactly where
+it left off with '%s %s %s'. Credentials are never included.
+
+The mission must be stopped first ('%s %s %s'). Missions using
+workspaceMode 'worktree' cannot be exported; push their branch instead.
+
+The output extension selects the compression: .tar.zst (default; requires the
+zstd binary), .tar.gz, or .tar.
+
+Example:
+  %s %s %s 2b4c8f1a -o handoff.tar.zst`,
+		agencCmdStr, missionCmdStr, importCmdStr,
+		agencCmdStr, missionCmdStr, stopCmdStr,
+		agencCmdStr, missionCmdStr, exportCmdStr,
+	),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionExport,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionExportCmd.Flags().StringVarP(&missionExportOutputFlag, outputFlagName, "o", "", "bundle path to write (default: <short-id>.tar.zst in the current directory)")
+	missionCmd.AddCommand(missionExportCmd)
+}
+
+func runMissionExport(cmd *cobra.Command, args []string) error {
+	if !looksLikeMissionID(args[0]) {
+		return stacktrace.NewError("not a valid mission ID: %s", args[0])
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	outputPath := missionExportOutputFlag
+	if outputPath == "" {
+		outputPath = database.ShortID(missionID) + ".tar.zst"
+	}
+	// The server writes the file, so it needs a path independent of our cwd
+	outputPath, err = filepath.Abs(outputPath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve output path")
+	}
+
+	resp, err := client.ExportMission(missionID, outputPath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to export mission %s", database.ShortID(missionID))
+	}
+
+	fmt.Printf("Exported mission '%s' to %s (%s)\n", database.ShortID(missionID), resp.OutputPath, config.FormatByteSize(resp.SizeBytes))
+	return nil
+}
@@ -20,3 +20,20 @@ func TestFormatLastPrompt_NonNilReturnsLocalFormatted(t *testing.T) {
 		t.Fatalf("formatLastPrompt(&t, ...) = %q, want %q", got, expected)
 	}
 }
+
+func TestFormatPRDisplay(t *testing.T) {
+	tests := []struct {
+		prURL string
+		want  string
+	}{
+		{"", "--"},
+		{"https://github.com/owner/repo/pull/42", "#42"},
+		{"https://github.com/owner/repo/pull/42/", "#42"},
+		{"https://example.com/review/7", "https://example.com/review/7"},
+	}
+	for _, tt := range tests {
+		if got := formatPRDisplay(tt.prURL); got != tt.want {
+			t.Errorf("formatPRDisplay(%q) = %q, want %q", tt.prURL, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/repo"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+// githubIssueMissionSource is the mission source recorded for missions
+// created from GitHub issues; the source ID is the owner/repo#123 reference.
+const githubIssueMissionSource = "github-issue"
+
+var fromIssueLabelFlag string
+
+var missionFromIssueCmd = &cobra.Command{
+	Use:   fromIssueCmdStr + " <owner/repo#123 | owner/repo --" + fromIssueLabelFlagName + " LABEL>",
+	Short: "Create missions from GitHub issues",
+	Long: fmt.Sprintf(`Create missions from GitHub issues.
+
+Fetches the issue's title, body, and comments with the GitHub CLI (gh),
+creates a mission on the issue's repo with the issue as its initial prompt,
+and comments on the issue with the new mission's ID.
+
+With --%s, takes owner/repo instead and creates a mission for every open issue
+carrying that label. Issues that already have a mission are skipped, so the
+command is safe to run on a schedule (e.g. from cron) as an intake poller.
+Polled missions run in the background pool.
+
+Examples:
+  %s %s %s acme/web#123
+  %s %s %s acme/web --%s agent`,
+		fromIssueLabelFlagName,
+		agencCmdStr, missionCmdStr, fromIssueCmdStr,
+		agencCmdStr, missionCmdStr, fromIssueCmdStr, fromIssueLabelFlagName,
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runMissionFromIssue,
+}
+
+func init() {
+	missionFromIssueCmd.Flags().StringVar(&fromIssueLabelFlag, fromIssueLabelFlagName, "", "create a mission for every open issue with this label")
+	missionCmd.AddCommand(missionFromIssueCmd)
+}
+
+func runMissionFromIssue(cmd *cobra.Command, args []string) error {
+	if _, err := ensureConfigured(); err != nil {
+		return err
+	}
+	ensureServerRunning()
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	if fromIssueLabelFlag == "" {
+		ownerRepo, number, err := repo.ParseIssueRef(args[0])
+		if err != nil {
+			return err
+		}
+		repoName, err := resolveIssueRepo(ownerRepo)
+		if err != nil {
+			return err
+		}
+		existing, err := findIssueMission(client, ownerRepo, number)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return stacktrace.NewError("issue %s already has mission %s", repo.FormatIssueRef(ownerRepo, number), existing.ShortID)
+		}
+		missionRecord, err := createMissionFromIssue(client, repoName, ownerRepo, number, getCallingSessionName())
+		if err != nil {
+			return err
+		}
+		printMissionLaunchStatus(missionRecord, getCallingSessionName())
+		return nil
+	}
+
+	ownerRepo := args[0]
+	if !repo.IsOwnerRepo(ownerRepo) {
+		return stacktrace.NewError("with --%s, expected owner/repo; got '%s'", fromIssueLabelFlagName, ownerRepo)
+	}
+	repoName, err := resolveIssueRepo(ownerRepo)
+	if err != nil {
+		return err
+	}
+	numbers, err := repo.ListLabeledGitHubIssues(ownerRepo, fromIssueLabelFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list issues labeled '%s' in %s", fromIssueLabelFlag, ownerRepo)
+	}
+
+	var created int
+	var errs []error
+	for _, number := range numbers {
+		existing, err := findIssueMission(client, ownerRepo, number)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+		if _, err := createMissionFromIssue(client, repoName, ownerRepo, number, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create a mission for %s: %v\n", repo.FormatIssueRef(ownerRepo, number), err)
+			errs = append(errs, err)
+			continue
+		}
+		created++
+	}
+	fmt.Printf("Created %d mission(s) from %d open issue(s) labeled '%s'\n", created, len(numbers), fromIssueLabelFlag)
+	if len(errs) > 0 {
+		return stacktrace.Propagate(errors.Join(errs...), "failed to create missions for %d issue(s)", len(errs))
+	}
+	return nil
+}
+
+// resolveIssueRepo resolves an issue's owner/repo to the canonical repo name
+// missions are created on.
+func resolveIssueRepo(ownerRepo string) (string, error) {
+	result, err := ResolveRepoInput(ownerRepo, "Select repo: ")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to resolve repo '%s'", ownerRepo)
+	}
+	return result.RepoName, nil
+}
+
+// findIssueMission returns the mission already created for an issue,
+// including archived ones, or nil if there is none.
+func findIssueMission(client *server.Client, ownerRepo string, number int) (*database.Mission, error) {
+	missions, err := client.ListMissions(server.ListMissionsRequest{
+		IncludeArchived: true,
+		Source:          githubIssueMissionSource,
+		SourceID:        repo.FormatIssueRef(ownerRepo, number),
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to look up missions for issue %s", repo.FormatIssueRef(ownerRepo, number))
+	}
+	if len(missions) == 0 {
+		return nil, nil
+	}
+	return missions[0], nil
+}
+
+// createMissionFromIssue fetches an issue, creates a mission on repoName with
+// the issue as its prompt, and comments the mission ID back on the issue. A
+// failed comment is reported but does not fail the mission.
+func createMissionFromIssue(client *server.Client, repoName string, ownerRepo string, number int, tmuxSession string) (*database.Mission, error) {
+	issueRef := repo.FormatIssueRef(ownerRepo, number)
+	issue, err := repo.FetchGitHubIssue(ownerRepo, number)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to fetch issue %s", issueRef)
+	}
+
+	missionRecord, err := client.CreateMission(server.CreateMissionRequest{
+		Repo:        repoName,
+		Prompt:      repo.BuildIssuePrompt(ownerRepo, issue),
+		TmuxSession: tmuxSession,
+		Source:      githubIssueMissionSource,
+		SourceID:    issueRef,
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to create mission for issue %s", issueRef)
+	}
+	fmt.Printf("Created mission %s for %s: %s\n", missionRecord.ShortID, issueRef, issue.Title)
+
+	comment := fmt.Sprintf("AgenC mission `%s` (`%s`) is working on this issue.", missionRecord.ShortID, missionRecord.ID)
+	if err := repo.CommentOnGitHubIssue(ownerRepo, number, comment); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to comment on %s: %v\n", issueRef, err)
+	}
+	return missionRecord, nil
+}
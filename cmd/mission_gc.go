@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var missionGCCmd = &cobra.Command{
+	Use:   gcCmdStr,
+	Short: "Apply the mission retention policy now",
+	Long: `Apply the mission retention policy now instead of waiting for the server's
+hourly pass.
+
+The policy is configured in config.yml:
+
+  missionAutoArchiveAfter: 30d   # archive missions with no heartbeat for 30 days
+  missionAutoDeleteAfter: 90d    # delete missions archived for more than 90 days
+
+Missions that have never run are measured from their creation time. Running
+missions are never touched. Use --dry-run to list what would be archived or
+deleted without changing anything.`,
+	Args: cobra.NoArgs,
+	RunE: runMissionGC,
+}
+
+func init() {
+	missionGCCmd.Flags().Bool(dryRunFlagName, false, "show what would be archived or deleted without doing it")
+	missionCmd.AddCommand(missionGCCmd)
+}
+
+func runMissionGC(cmd *cobra.Command, args []string) error {
+	dryRun, err := cmd.Flags().GetBool(dryRunFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", dryRunFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GCMissions(dryRun)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to run mission GC")
+	}
+
+	if resp.ArchiveAfter == "" && resp.DeleteAfter == "" {
+		fmt.Println("No retention policy configured; set missionAutoArchiveAfter and/or missionAutoDeleteAfter in config.yml.")
+		return nil
+	}
+	if len(resp.Entries) == 0 {
+		fmt.Println("No missions to archive or delete.")
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("ACTION", "ID", "INACTIVE SINCE", "SESSION", "REPO")
+	numArchived, numDeleted, numFailed := 0, 0, 0
+	for _, e := range resp.Entries {
+		action := e.Action
+		switch {
+		case e.Error != "":
+			action = ansiRed + e.Action + " (failed)" + ansiReset
+			numFailed++
+		case e.Action == "delete":
+			numDeleted++
+		default:
+			numArchived++
+		}
+		tbl.AddRow(
+			action,
+			e.ShortID,
+			e.InactiveSince.Local().Format("2006-01-02 15:04"),
+			truncatePrompt(e.SessionName, defaultPromptMaxLen),
+			displayGitRepo(e.GitRepo),
+		)
+	}
+	tbl.Print()
+
+	fmt.Println()
+	if dryRun {
+		fmt.Printf("Dry run: would archive %d and delete %d mission(s).\n", numArchived, numDeleted)
+		return nil
+	}
+	fmt.Printf("Archived %d and deleted %d mission(s).\n", numArchived, numDeleted)
+	if numFailed > 0 {
+		return stacktrace.NewError("%d mission(s) could not be processed; see the server log", numFailed)
+	}
+	return nil
+}
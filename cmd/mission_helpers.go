@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"os"
-	"os/exec"
 	"regexp"
 	"strings"
 
@@ -12,6 +11,7 @@ import (
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 // ============================================================================
@@ -101,7 +101,7 @@ func filterLinkedMissions(missions []*database.Mission, tmuxSession string) []*d
 // present in the given tmux session. Returns an empty map if the session doesn't
 // exist or tmux is not running.
 func getSessionPaneIDs(tmuxSession string) map[string]bool {
-	out, err := exec.Command("tmux", "list-panes", "-s", "-t", tmuxSession, "-F", "#{pane_id}").Output()
+	out, err := tmux.Command("list-panes", "-s", "-t", tmuxSession, "-F", "#{pane_id}").Output()
 	if err != nil {
 		return map[string]bool{}
 	}
@@ -143,7 +143,11 @@ func serverClient() (*server.Client, error) {
 	}
 	ensureServerRunning()
 	socketFilepath := config.GetServerSocketFilepath(dirpath)
-	return server.NewClient(socketFilepath), nil
+	client := server.NewClient(socketFilepath)
+	if auditInvocationID != "" {
+		client.SetAuditInvocationID(auditInvocationID)
+	}
+	return client, nil
 }
 
 // ============================================================================
@@ -161,7 +165,7 @@ func getCurrentTmuxSessionName() string {
 	if os.Getenv("TMUX") == "" {
 		return ""
 	}
-	out, err := exec.Command("tmux", "display-message", "-p", "#{session_name}").Output()
+	out, err := tmux.Command("display-message", "-p", "#{session_name}").Output()
 	if err != nil {
 		return ""
 	}
@@ -186,6 +190,18 @@ func getCallingSessionName() string {
 	return getCurrentTmuxSessionName()
 }
 
+// getCallingPaneID returns the tmux pane (without "%" prefix) the user is
+// working in. Prefers $AGENC_CALLING_PANE_ID for the same reason
+// getCallingSessionName prefers its env var: inside a popup, $TMUX_PANE is
+// the popup's pane, not the user's.
+func getCallingPaneID() string {
+	paneID := os.Getenv(config.CallingPaneIDEnvVar)
+	if paneID == "" {
+		paneID = os.Getenv("TMUX_PANE")
+	}
+	return strings.TrimPrefix(paneID, "%")
+}
+
 // readConfig centralizes the config reading boilerplate. It returns the config
 // only; use readConfigWithComments when the comment map is needed for write-back.
 func readConfig() (*config.AgencConfig, error) {
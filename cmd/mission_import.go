@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var missionImportCmd = &cobra.Command{
+	Use:   importCmdStr + " <bundle>",
+	Short: "Import a mission from a bundle created by 'mission export'",
+	Long: fmt.Sprintf(`Import a mission from a bundle created by '%s %s %s'.
+
+Recreates the mission with its original ID: the workspace, Claude config
+snapshot, conversation transcripts, and database record. Transcript paths are
+rewritten for this machine, so '%s %s %s' continues the conversation where it
+left off. The imported mission is not started.
+
+Importing fails if a mission with the same ID already exists here.`,
+		agencCmdStr, missionCmdStr, exportCmdStr,
+		agencCmdStr, missionCmdStr, resumeCmdStr,
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runMissionImport,
+}
+
+func init() {
+	missionCmd.AddCommand(missionImportCmd)
+}
+
+func runMissionImport(cmd *cobra.Command, args []string) error {
+	bundlePath, err := filepath.Abs(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve bundle path")
+	}
+	if _, err := os.Stat(bundlePath); err != nil {
+		return stacktrace.Propagate(err, "cannot read bundle '%s'", args[0])
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	missionRecord, err := client.ImportMission(bundlePath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to import mission")
+	}
+
+	fmt.Printf("Imported mission '%s'", missionRecord.ShortID)
+	if missionRecord.GitRepo != "" {
+		fmt.Printf(" (%s)", displayGitRepo(missionRecord.GitRepo))
+	}
+	fmt.Printf(". Resume it with '%s %s %s %s'.\n", agencCmdStr, missionCmdStr, resumeCmdStr, missionRecord.ShortID)
+	return nil
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/mieubrisse/stacktrace"
@@ -23,8 +25,9 @@ var missionInspectCmd = &cobra.Command{
 
 Without arguments, opens an interactive fzf picker to select a mission.
 With arguments, accepts a mission ID (short 8-char hex or full UUID).`,
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionInspect,
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionInspect,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
@@ -92,6 +95,16 @@ func runMissionInspect(cmd *cobra.Command, args []string) error {
 	return inspectMission(agencDirpath, result.Items[0].MissionID)
 }
 
+// missionInspectOutput is the --output json|yaml form of `mission inspect`.
+type missionInspectOutput struct {
+	missionOutput
+	Directory        string   `json:"directory"`
+	Path             string   `json:"path,omitempty"`
+	Env              []string `json:"env,omitempty"`
+	SessionIDs       []string `json:"session_ids"`
+	CurrentSessionID string   `json:"current_session_id"`
+}
+
 func inspectMission(agencDirpath string, missionID string) error {
 	client, err := serverClient()
 	if err != nil {
@@ -103,25 +116,56 @@ func inspectMission(agencDirpath string, missionID string) error {
 	}
 
 	missionDirpath := config.GetMissionDirpath(agencDirpath, missionID)
+	subpath := config.ReadMissionSubpath(agencDirpath, missionID)
 
 	if inspectDirFlag {
 		fmt.Println(missionDirpath)
 		return nil
 	}
 
+	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(agencDirpath, missionID)
+	sessionIDs := session.ListSessionIDs(claudeConfigDirpath, missionID)
+	currentSessionID := claudeconfig.GetLastSessionID(agencDirpath, missionID)
+
+	if isStructuredOutput() {
+		if sessionIDs == nil {
+			sessionIDs = []string{}
+		}
+		return printStructured(missionInspectOutput{
+			missionOutput:    newMissionOutput(mission),
+			Directory:        missionDirpath,
+			Path:             subpath,
+			Env:              sortedEnvKeys(mission.Env),
+			SessionIDs:       sessionIDs,
+			CurrentSessionID: currentSessionID,
+		})
+	}
+
 	fmt.Printf("ID:          %s\n", mission.ShortID)
 	fmt.Printf("Full ID:     %s\n", mission.ID)
 	fmt.Printf("Status:      %s\n", getMissionStatus(missionID, mission.Status, mission.ClaudeState))
+	if mission.WrapperHealth != "" && mission.WrapperHealth != server.WrapperHealthHealthy {
+		fmt.Printf("Wrapper:     %s (failing health pings)\n", mission.WrapperHealth)
+	}
 	cfg, _, _ := config.ReadAgencConfig(agencDirpath)
 	isAdjutant := config.IsMissionAdjutant(agencDirpath, missionID)
 	if isAdjutant {
 		fmt.Printf("Type:        🤖  Adjutant\n")
+	} else if len(mission.GitRepos) > 0 {
+		repoDisplays := make([]string, len(mission.GitRepos))
+		for i, repoName := range mission.GitRepos {
+			repoDisplays[i] = displayGitRepo(repoName)
+		}
+		fmt.Printf("Git repos:   %s\n", strings.Join(repoDisplays, ", "))
 	} else if mission.GitRepo != "" {
 		fmt.Printf("Git repo:    %s\n", displayGitRepo(mission.GitRepo))
 		repoDisplay := formatRepoDisplay(mission.GitRepo, false, cfg)
 		if repoDisplay != displayGitRepo(mission.GitRepo) {
 			fmt.Printf("Title:       %s\n", repoDisplay)
 		}
+		if mission.GitRef != "" {
+			fmt.Printf("Git ref:     %s (%s)\n", mission.GitRef, shortCommit(mission.GitRefCommit))
+		}
 	}
 	sessionName := resolveSessionName(mission)
 	if sessionName == "" {
@@ -133,15 +177,41 @@ func inspectMission(agencDirpath string, missionID string) error {
 		prompt = "--"
 	}
 	fmt.Printf("Prompt:      %s\n", prompt)
+	if mission.Owner != "" {
+		owner := mission.Owner
+		if len(mission.SharedWith) > 0 {
+			owner += " (shared with " + strings.Join(mission.SharedWith, ", ") + ")"
+		}
+		fmt.Printf("Owner:       %s\n", owner)
+	}
+	if len(mission.Tags) > 0 {
+		fmt.Printf("Tags:        %s\n", strings.Join(mission.Tags, ", "))
+	}
+	if mission.PRURL != "" {
+		fmt.Printf("PR:          %s\n", mission.PRURL)
+	}
+	if mission.LastError != "" {
+		fmt.Printf("Last error:  %s\n", mission.LastError)
+		fmt.Printf("             crash report: %s\n", config.GetMissionCrashReportFilepath(agencDirpath, mission.ID))
+	}
+	if mission.AgentDirBytes > 0 {
+		size := config.FormatByteSize(mission.AgentDirBytes)
+		if mission.IsOversized {
+			size += " (over the missionSizeLimit)"
+		}
+		fmt.Printf("Size:        %s\n", size)
+	}
 	fmt.Printf("Directory:   %s\n", missionDirpath)
+	if subpath != "" {
+		fmt.Printf("Path:        %s\n", subpath)
+	}
+	if len(mission.Env) > 0 {
+		fmt.Printf("Env:         %s\n", strings.Join(sortedEnvKeys(mission.Env), ", "))
+	}
 	fmt.Printf("Created:     %s\n", mission.CreatedAt.Format("2006-01-02 15:04:05"))
 	fmt.Printf("Updated:     %s\n", mission.UpdatedAt.Format("2006-01-02 15:04:05"))
 
 	// List session UUIDs
-	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(agencDirpath, missionID)
-	sessionIDs := session.ListSessionIDs(claudeConfigDirpath, missionID)
-	currentSessionID := claudeconfig.GetLastSessionID(agencDirpath, missionID)
-
 	if len(sessionIDs) == 0 {
 		fmt.Printf("Sessions:    --\n")
 	} else {
@@ -159,3 +229,12 @@ func inspectMission(agencDirpath string, missionID string) error {
 
 	return nil
 }
+
+// sortedEnvKeys returns the names of a mission's env overrides, sorted. Only
+// names are shown since values may hold secrets.
+func sortedEnvKeys(env map[string]string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(env))
+}
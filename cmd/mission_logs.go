@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var missionLogsFollowFlag bool
+var missionLogsWrapperFlag bool
+var missionLogsAllFlag bool
+
+var missionLogsCmd = &cobra.Command{
+	Use:   logsCmdStr + " <mission-id>",
+	Short: "Print or follow a mission's Claude output log",
+	Long: `Print or follow a mission's Claude output log.
+
+By default, prints the last 200 lines of claude-output.log. Use --wrapper to
+read wrapper.log instead, --all for the full file, and -f/--follow to keep
+streaming new lines as they are written (Ctrl-C to stop). Works for both
+interactive and headless missions.
+
+Examples:
+  agenc mission logs abc12345
+  agenc mission logs abc12345 -f
+  agenc mission logs abc12345 --wrapper --all`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionLogs,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionLogsCmd.Flags().BoolVarP(&missionLogsFollowFlag, followFlagName, "f", false, "stream new log lines as they are written")
+	missionLogsCmd.Flags().BoolVar(&missionLogsWrapperFlag, wrapperFlagName, false, "show wrapper.log instead of claude-output.log")
+	missionLogsCmd.Flags().BoolVar(&missionLogsAllFlag, allFlagName, false, "print entire log file instead of last 200 lines")
+	missionCmd.AddCommand(missionLogsCmd)
+}
+
+func runMissionLogs(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	source := "claude"
+	if missionLogsWrapperFlag {
+		source = "wrapper"
+	}
+
+	if !missionLogsFollowFlag {
+		body, err := client.GetMissionOutput(args[0], source, missionLogsAllFlag)
+		if err != nil {
+			return fmt.Errorf("failed to fetch mission logs: %w", err)
+		}
+		_, _ = os.Stdout.Write(body) // stdout write failure is unrecoverable
+		return nil
+	}
+
+	return followMissionLogs(client, args[0], source, missionLogsAllFlag)
+}
+
+// followMissionLogs streams a mission's log (source "claude" or "wrapper")
+// to stdout until interrupted, starting from its last 200 lines or, with
+// all, the whole file.
+func followMissionLogs(client *server.Client, missionID string, source string, all bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := client.StreamMissionOutput(ctx, missionID, source, all, func(line string) {
+		fmt.Println(line)
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to follow mission logs")
+	}
+	return nil
+}
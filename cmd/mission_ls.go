@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/mieubrisse/stacktrace"
-	"github.com/rodaine/table"
 	"github.com/spf13/cobra"
 
 	"github.com/odyssey/agenc/internal/config"
@@ -17,22 +16,33 @@ import (
 
 const defaultMissionLsLimit = 20
 
+// summaryColumnMaxLen is the width of the SUMMARY column in mission ls.
+const summaryColumnMaxLen = 60
+
+// lastErrorColumnMaxLen is the width of the LAST ERROR column in mission ls.
+const lastErrorColumnMaxLen = 60
+
 // MissionDisplayStatus represents the display status of a mission.
 type MissionDisplayStatus string
 
 const (
-	StatusIdle     MissionDisplayStatus = "IDLE"
-	StatusBusy     MissionDisplayStatus = "BUSY"
-	StatusWaiting  MissionDisplayStatus = "WAITING"
-	StatusRunning  MissionDisplayStatus = "RUNNING"
-	StatusStopped  MissionDisplayStatus = "STOPPED"
-	StatusArchived MissionDisplayStatus = "ARCHIVED"
+	StatusIdle      MissionDisplayStatus = "IDLE"
+	StatusBusy      MissionDisplayStatus = "BUSY"
+	StatusWaiting   MissionDisplayStatus = "WAITING"
+	StatusRunning   MissionDisplayStatus = "RUNNING"
+	StatusStopped   MissionDisplayStatus = "STOPPED"
+	StatusSuspended MissionDisplayStatus = "SUSPENDED"
+	StatusCrashed   MissionDisplayStatus = "CRASHED"
+	StatusQueued    MissionDisplayStatus = "QUEUED"
+	StatusArchived  MissionDisplayStatus = "ARCHIVED"
 )
 
 var lsAllFlag bool
 var lsCronFlag string
 var lsSinceFlag string
 var lsUntilFlag string
+var lsTagFlags []string
+var lsProjectFlag string
 
 var missionLsCmd = &cobra.Command{
 	Use:   lsCmdStr,
@@ -45,6 +55,9 @@ func init() {
 	missionLsCmd.Flags().StringVar(&lsCronFlag, cronFlagName, "", "filter to missions from a specific cron job")
 	missionLsCmd.Flags().StringVar(&lsSinceFlag, "since", "", "show missions created on or after this date (YYYY-MM-DD or RFC3339)")
 	missionLsCmd.Flags().StringVar(&lsUntilFlag, "until", "", "show missions created on or before this date (YYYY-MM-DD or RFC3339)")
+	missionLsCmd.Flags().StringSliceVar(&lsTagFlags, tagFlagName, nil, "show only missions carrying this tag (repeatable; all given tags must match)")
+	missionLsCmd.Flags().StringVar(&lsProjectFlag, projectFlagName, "", "show only missions in this project")
+	_ = missionLsCmd.RegisterFlagCompletionFunc(projectFlagName, completeProjectFlag)
 	missionCmd.AddCommand(missionLsCmd)
 }
 
@@ -54,6 +67,14 @@ func runMissionLs(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if isStructuredOutput() {
+		outputs := make([]missionOutput, 0, len(missions))
+		for _, m := range missions {
+			outputs = append(outputs, newMissionOutput(m))
+		}
+		return printStructured(outputs)
+	}
+
 	if len(missions) == 0 {
 		if hasTimeFilter() {
 			if lsSinceFlag != "" && lsUntilFlag != "" {
@@ -63,6 +84,10 @@ func runMissionLs(cmd *cobra.Command, args []string) error {
 			} else {
 				fmt.Printf("No missions found until %s.\n", lsUntilFlag)
 			}
+		} else if lsProjectFlag != "" {
+			fmt.Printf("No missions in project %s.\n", lsProjectFlag)
+		} else if len(lsTagFlags) > 0 {
+			fmt.Printf("No missions tagged %s.\n", strings.Join(lsTagFlags, ", "))
 		} else if lsAllFlag {
 			fmt.Println("No missions.")
 		} else {
@@ -79,39 +104,74 @@ func runMissionLs(cmd *cobra.Command, args []string) error {
 
 	cfg, _ := readConfig()
 
-	var tbl table.Table
+	// The SUMMARY column is left out when AI mission summaries are off
+	showSummary := cfg == nil || cfg.GetMissionSummaryTrigger() != config.MissionSummaryTriggerNever
+
+	headers := []interface{}{"ID", "LAST PROMPT", "STATUS"}
 	if lsAllFlag {
-		tbl = tableprinter.NewTable("ID", "LAST PROMPT", "STATUS", "PANE", "SESSION", "REPO")
-	} else {
-		tbl = tableprinter.NewTable("ID", "LAST PROMPT", "STATUS", "SESSION", "REPO")
+		headers = append(headers, "PANE")
+	}
+	headers = append(headers, "SESSION")
+	if showSummary {
+		headers = append(headers, "SUMMARY")
+	}
+	headers = append(headers, "REPO", "PR")
+
+	// The LAST ERROR column only appears when a listed mission has crashed
+	showLastError := false
+	for _, m := range displayMissions {
+		if m.LastError != "" {
+			showLastError = true
+			break
+		}
+	}
+	if showLastError {
+		headers = append(headers, "LAST ERROR")
+	}
+
+	// The SIZE column only appears when a listed mission is over the
+	// missionSizeLimit
+	showSize := false
+	for _, m := range displayMissions {
+		if m.IsOversized {
+			showSize = true
+			break
+		}
+	}
+	if showSize {
+		headers = append(headers, "SIZE")
 	}
+	tbl := tableprinter.NewTable(headers...)
+
 	for _, m := range displayMissions {
-		status := getMissionStatus(m.ID, m.Status, m.ClaudeState)
+		status := getMissionDisplayStatus(m)
 		sessionName := resolveSessionName(m)
-		repo := formatRepoDisplay(m.GitRepo, m.IsAdjutant, cfg)
+		repo := formatMissionRepoDisplay(m, cfg)
 
+		row := []interface{}{
+			m.ShortID,
+			formatLastPrompt(m.LastUserPromptAt, m.CreatedAt),
+			colorizeStatus(status),
+		}
 		if lsAllFlag {
 			pane := "--"
 			if m.TmuxPane != nil {
 				pane = *m.TmuxPane
 			}
-			tbl.AddRow(
-				m.ShortID,
-				formatLastPrompt(m.LastUserPromptAt, m.CreatedAt),
-				colorizeStatus(status),
-				pane,
-				truncatePrompt(sessionName, defaultPromptMaxLen),
-				repo,
-			)
-		} else {
-			tbl.AddRow(
-				m.ShortID,
-				formatLastPrompt(m.LastUserPromptAt, m.CreatedAt),
-				colorizeStatus(status),
-				truncatePrompt(sessionName, defaultPromptMaxLen),
-				repo,
-			)
+			row = append(row, pane)
+		}
+		row = append(row, truncatePrompt(sessionName, defaultPromptMaxLen))
+		if showSummary {
+			row = append(row, formatSummaryDisplay(m.AISummary))
+		}
+		row = append(row, repo, formatPRDisplay(m.PRURL))
+		if showLastError {
+			row = append(row, formatLastErrorDisplay(m.LastError))
+		}
+		if showSize {
+			row = append(row, formatAgentDirSizeDisplay(m.AgentDirBytes, m.IsOversized))
 		}
+		tbl.AddRow(row...)
 	}
 	if hasTimeFilter() {
 		fmt.Println(formatTimeFilterMessage(len(displayMissions), lsSinceFlag, lsUntilFlag))
@@ -127,6 +187,98 @@ func runMissionLs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// missionOutput is the --output json|yaml form of a mission, shared by
+// `mission ls` and `mission inspect`.
+type missionOutput struct {
+	ID               string     `json:"id"`
+	ShortID          string     `json:"short_id"`
+	Status           string     `json:"status"`
+	Session          string     `json:"session"`
+	Prompt           string     `json:"prompt"`
+	GitRepo          string     `json:"git_repo"`
+	GitRepos         []string   `json:"git_repos,omitempty"`
+	GitRef           string     `json:"git_ref,omitempty"`
+	GitRefCommit     string     `json:"git_ref_commit,omitempty"`
+	IsAdjutant       bool       `json:"is_adjutant"`
+	Tags             []string   `json:"tags"`
+	Project          string     `json:"project"`
+	PRURL            string     `json:"pr_url"`
+	AISummary        string     `json:"ai_summary"`
+	LastError        string     `json:"last_error"`
+	AgentDirBytes    int64      `json:"agent_dir_bytes"`
+	IsOversized      bool       `json:"is_oversized"`
+	WrapperHealth    string     `json:"wrapper_health,omitempty"`
+	TmuxPane         *string    `json:"tmux_pane"`
+	LastUserPromptAt *time.Time `json:"last_user_prompt_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// newMissionOutput converts a mission to its structured output form. Status
+// is the lowercased display status (e.g. "idle", "stopped", "crashed",
+// "archived").
+func newMissionOutput(m *database.Mission) missionOutput {
+	tags := m.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return missionOutput{
+		ID:               m.ID,
+		ShortID:          m.ShortID,
+		Status:           strings.ToLower(string(getMissionDisplayStatus(m))),
+		Session:          resolveSessionName(m),
+		Prompt:           m.Prompt,
+		GitRepo:          m.GitRepo,
+		GitRepos:         m.GitRepos,
+		GitRef:           m.GitRef,
+		GitRefCommit:     m.GitRefCommit,
+		IsAdjutant:       m.IsAdjutant,
+		Tags:             tags,
+		Project:          m.Project,
+		PRURL:            m.PRURL,
+		AISummary:        m.AISummary,
+		LastError:        m.LastError,
+		AgentDirBytes:    m.AgentDirBytes,
+		IsOversized:      m.IsOversized,
+		WrapperHealth:    m.WrapperHealth,
+		TmuxPane:         m.TmuxPane,
+		LastUserPromptAt: m.LastUserPromptAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// formatSummaryDisplay truncates a mission's AI summary for the table,
+// returning "--" when there is none.
+func formatSummaryDisplay(summary string) string {
+	if summary == "" {
+		return "--"
+	}
+	return truncatePrompt(summary, summaryColumnMaxLen)
+}
+
+// formatLastErrorDisplay truncates a mission's last error for the table and
+// colors it red, returning "--" when there is none.
+func formatLastErrorDisplay(lastError string) string {
+	if lastError == "" {
+		return "--"
+	}
+	return ansiRed + truncatePrompt(lastError, lastErrorColumnMaxLen) + ansiReset
+}
+
+// formatAgentDirSizeDisplay renders a mission's measured agent directory
+// size, in red with a warning marker when it is over the size limit. Returns
+// "--" when the size has not been measured.
+func formatAgentDirSizeDisplay(sizeBytes int64, oversized bool) string {
+	if sizeBytes == 0 {
+		return "--"
+	}
+	if oversized {
+		return ansiRed + "⚠ " + config.FormatByteSize(sizeBytes) + ansiReset
+	}
+	return config.FormatByteSize(sizeBytes)
+}
+
 // displayGitRepo formats a canonical repo name for user-facing display.
 // GitHub repos have their "github.com/" prefix stripped; non-GitHub repos are
 // shown in full. The repo name (final path segment) is colored light blue.
@@ -152,6 +304,19 @@ func plainGitRepoName(gitRepo string) string {
 	return strings.TrimPrefix(gitRepo, "github.com/")
 }
 
+// plainMissionRepoNames returns plainGitRepoName of a mission's repo, or of
+// every repo of a multi-repo mission joined by " + ".
+func plainMissionRepoNames(m *database.Mission) string {
+	if len(m.GitRepos) == 0 {
+		return plainGitRepoName(m.GitRepo)
+	}
+	names := make([]string, len(m.GitRepos))
+	for i, repoName := range m.GitRepos {
+		names[i] = plainGitRepoName(repoName)
+	}
+	return strings.Join(names, " + ")
+}
+
 // formatRepoDisplay returns a user-facing display string for a repo, combining
 // emoji prefix (if configured), title (if configured), or colored canonical name.
 // For adjutant missions, returns "🤖  Adjutant" regardless of other parameters.
@@ -177,6 +342,33 @@ func formatRepoDisplay(repoName string, isAdjutant bool, cfg *config.AgencConfig
 	return displayName
 }
 
+// formatMissionRepoDisplay returns the repo display for a mission: its repo
+// (see formatRepoDisplay), or every repo of a multi-repo mission joined by
+// " + ".
+func formatMissionRepoDisplay(m *database.Mission, cfg *config.AgencConfig) string {
+	if len(m.GitRepos) == 0 || m.IsAdjutant {
+		return formatRepoDisplay(m.GitRepo, m.IsAdjutant, cfg)
+	}
+	displays := make([]string, len(m.GitRepos))
+	for i, repoName := range m.GitRepos {
+		displays[i] = formatRepoDisplay(repoName, false, cfg)
+	}
+	return strings.Join(displays, " + ")
+}
+
+// formatPRDisplay returns a compact form of a mission's pull request URL:
+// "#<number>" for GitHub PR URLs, the URL itself otherwise, and "--" when the
+// mission has no PR.
+func formatPRDisplay(prURL string) string {
+	if prURL == "" {
+		return "--"
+	}
+	if _, number, ok := strings.Cut(prURL, "/pull/"); ok && number != "" {
+		return "#" + strings.TrimSuffix(number, "/")
+	}
+	return prURL
+}
+
 // formatLastPrompt returns a human-readable timestamp of the user's last
 // prompt for this mission. Returns "--" when no prompt has been recorded.
 // The createdAt parameter is unused for display; it exists for symmetry with
@@ -202,6 +394,10 @@ func colorizeStatus(status MissionDisplayStatus) string {
 		return ansiGreen + s + ansiReset
 	case StatusArchived:
 		return ansiYellow + s + ansiReset
+	case StatusSuspended:
+		return ansiLightBlue + s + ansiReset
+	case StatusCrashed:
+		return ansiRed + s + ansiReset
 	default:
 		return s
 	}
@@ -232,6 +428,15 @@ func truncatePrompt(prompt string, maxLen int) string {
 	return collapsed[:maxLen] + "…"
 }
 
+// getMissionDisplayStatus returns the display status for a mission fetched
+// from the server, reporting missions waiting in the start queue as QUEUED.
+func getMissionDisplayStatus(m *database.Mission) MissionDisplayStatus {
+	if m.QueuePosition > 0 {
+		return StatusQueued
+	}
+	return getMissionStatus(m.ID, m.Status, m.ClaudeState)
+}
+
 // getMissionStatus returns the display status for a mission.
 func getMissionStatus(missionID string, dbStatus string, claudeState *string) MissionDisplayStatus {
 	if dbStatus == "archived" {
@@ -258,6 +463,13 @@ func getMissionStatus(missionID string, dbStatus string, claudeState *string) Mi
 			return StatusRunning
 		}
 	}
+	switch dbStatus {
+	case "crashed":
+		return StatusCrashed
+	case "suspended":
+		// Stopped by the server for idleness; attaching resumes it
+		return StatusSuspended
+	}
 	return StatusStopped
 }
 
@@ -319,6 +531,8 @@ func fetchMissions() ([]*database.Mission, error) {
 	req := server.ListMissionsRequest{
 		IncludeArchived: lsAllFlag,
 		SourceID:        lsCronFlag,
+		Tags:            lsTagFlags,
+		Project:         lsProjectFlag,
 	}
 
 	if lsSinceFlag != "" {
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/wrapper"
+)
+
+var missionNetworkJailCmd = &cobra.Command{
+	Use:   networkJailCmdStr + " <proxy-socket> <command> [args...]",
+	Short: "Run an agent confined to its mission's network policy proxy",
+	// Internal: the wrapper runs agents through this when a repo has a
+	// networkPolicy, already inside a fresh user and network namespace
+	Hidden: true,
+	Long: `Run a command inside the network namespace this process was started in,
+with loopback as its only interface and a loopback port relayed to the network
+policy proxy's unix socket. The command's HTTPS_PROXY and friends point at that
+port, and the proxy is the only way out of the namespace.
+
+Exits with the command's exit code.`,
+	Args:               cobra.MinimumNArgs(2),
+	DisableFlagParsing: true,
+	RunE:               runMissionNetworkJail,
+}
+
+func init() {
+	missionCmd.AddCommand(missionNetworkJailCmd)
+}
+
+func runMissionNetworkJail(cmd *cobra.Command, args []string) error {
+	exitCode, err := wrapper.RunNetworkJail(args[0], args[1:])
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: exitCode}
+	}
+	return nil
+}
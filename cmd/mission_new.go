@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/repo"
 	"github.com/odyssey/agenc/internal/server"
 )
@@ -25,6 +26,23 @@ var headlessFlag bool
 var sourceFlag string
 var sourceIDFlag string
 var sourceMetadataFlag string
+var maxPromptsFlag int
+var budgetUSDFlag float64
+var missionEnvFlag []string
+
+// missionNewEnv is missionEnvFlag parsed by runMissionNew.
+var missionNewEnv map[string]string
+var missionGitFlag []string
+
+// missionNewRepos is missionGitFlag resolved to canonical repo names by
+// runMissionNew when it names two or more repos.
+var missionNewRepos []string
+var sandboxFlag bool
+var cloneModeFlag string
+var missionPathFlag string
+var backendFlag string
+var missionNewProjectFlag string
+var historyFlag bool
 
 var missionNewCmd = &cobra.Command{
 	Use:   newCmdStr + " [repo]",
@@ -35,20 +53,85 @@ Without arguments, opens an interactive fzf picker showing your repo library.
 With arguments, accepts a git reference (URL, shorthand like owner/repo, or
 local path).
 
-Use --%s <mission-uuid> to create a new mission with a full copy of an
-existing mission's agent directory.`,
-		cloneFlagName),
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionNew,
+Use --%s <mission-uuid> to create a new mission from an existing one. --%s
+picks what the clone takes:
+  %-13s copy the agent directory, start a fresh conversation (default)
+  %-13s fork the latest conversation into a clean checkout of the repo
+  %-13s copy the agent directory and fork the conversation
+
+Use --%s and --%s to cap the mission: once Claude has answered that many
+prompts, or its estimated spend (from token usage at list prices) reaches the
+budget, the wrapper gracefully stops Claude. The running totals are shown in
+Claude's statusline.
+
+Use --%s KEY=VALUE (repeatable) to set environment variables for the
+mission's Claude, on top of the repo's repoConfig env. Values may be
+secret://NAME references (see 'agenc secret'). They are recorded with the
+mission, so reloads, resumes, and clones get the same environment.
+
+Use --%s <repo> two or more times to check out several repos side by side
+under the mission's workspace, e.g. an API and its client, for changes that
+span them. Each repo gets its own directory, and a generated README at the
+root lists them. Each repo is a full copy with its own branches; the mission
+itself has no single repo, so per-repo commands like 'mission pr' don't apply.
+With a single --%s it is the same as passing the repo as an argument.
+
+Use --%s to run Claude in a Docker or Podman container that only sees the
+mission's workspace and Claude config, for repos you don't trust on your host
+filesystem. Repos with 'isolation: container' in their repoConfig are always
+sandboxed.
+
+Use --%s to run Claude in a subdirectory of the repo, e.g. --%s services/api
+in a monorepo. That directory becomes Claude's project root: it starts there,
+picks up the CLAUDE.md and .claude/ settings found there, and only gets file
+permissions for that subtree. The whole repo is still checked out, so git works
+as usual. Clones keep their source mission's path.
+
+Use --%s to run another agent CLI instead of Claude: "codex" (built in) or
+any backend defined under agentBackends in config.yml. Without it, the repo's
+'backend' setting applies. Other backends run on the host, without Claude's
+hooks, so AgenC doesn't track their idle state, prompts, or spend. Clones keep
+their source mission's backend.
+
+Use --%s to start the mission in a project (see 'agenc project'). Without a
+repo argument, the mission uses the project's repo. Missions started from
+inside another mission, and clones, join their source mission's project.
+
+Use --%s to rerun something you've asked for before: an fzf picker lists the
+initial prompts of past missions (archived ones included), most recent first,
+and the chosen one becomes the new mission's prompt. The repo is picked as
+usual, so the same prompt can be run against a different repo, e.g.
+'agenc mission new --%s owner/other-repo'.`,
+		cloneFlagName, cloneModeFlagName, server.CloneModeWorkspace, server.CloneModeConversation, server.CloneModeBoth,
+		maxPromptsFlagName, budgetUSDFlagName, missionEnvFlagName,
+		missionGitFlagName, missionGitFlagName, sandboxFlagName,
+		missionPathFlagName, missionPathFlagName, backendFlagName, projectFlagName,
+		historyFlagName, historyFlagName),
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionNew,
+	ValidArgsFunction: completeRepoName,
 }
 
 func init() {
 	missionNewCmd.Flags().StringVar(&cloneFlag, cloneFlagName, "", "mission UUID to clone agent directory from")
+	missionNewCmd.Flags().StringVar(&cloneModeFlag, cloneModeFlagName, server.CloneModeWorkspace, `what --clone copies: "workspace", "conversation", or "both"`)
 	missionNewCmd.Flags().StringVar(&promptFlag, promptFlagName, "", "initial prompt to start Claude with")
 	missionNewCmd.Flags().BoolVar(&blankFlag, blankFlagName, false, "create a blank mission with no repo (skip picker)")
 	missionNewCmd.Flags().BoolVar(&adjutantFlag, adjutantFlagName, false, "create an Adjutant mission")
 	missionNewCmd.Flags().BoolVar(&noFocusFlag, noFocusFlagName, false, "don't focus the new mission's tmux window after creation")
 	missionNewCmd.Flags().BoolVar(&headlessFlag, headlessFlagName, false, "run in headless mode (no terminal, outputs to log)")
+	missionNewCmd.Flags().IntVar(&maxPromptsFlag, maxPromptsFlagName, 0, "stop Claude after this many prompts (0 = no limit)")
+	missionNewCmd.Flags().Float64Var(&budgetUSDFlag, budgetUSDFlagName, 0, "stop Claude once estimated spend reaches this many USD (0 = no limit)")
+	missionNewCmd.Flags().StringArrayVar(&missionEnvFlag, missionEnvFlagName, nil, "KEY=VALUE environment variable for the mission's Claude; values may be secret://NAME (repeatable)")
+	missionNewCmd.Flags().StringArrayVar(&missionGitFlag, missionGitFlagName, nil, "repo to check out; repeat for a multi-repo mission")
+	_ = missionNewCmd.RegisterFlagCompletionFunc(missionGitFlagName, completeRepoName)
+	missionNewCmd.Flags().BoolVar(&sandboxFlag, sandboxFlagName, false, "run Claude in a sandbox container (see sandbox in config.yml)")
+	missionNewCmd.Flags().StringVar(&missionPathFlag, missionPathFlagName, "", "directory within the repo to run Claude in (its project root)")
+	missionNewCmd.Flags().StringVar(&backendFlag, backendFlagName, "", `agent backend to run (default: the repo's backend, else "claude")`)
+	missionNewCmd.Flags().BoolVar(&historyFlag, historyFlagName, false, "pick the initial prompt from past missions' prompts with fzf")
+	_ = missionNewCmd.RegisterFlagCompletionFunc(backendFlagName, completeBackendFlag)
+	missionNewCmd.Flags().StringVar(&missionNewProjectFlag, projectFlagName, "", "project the mission joins")
+	_ = missionNewCmd.RegisterFlagCompletionFunc(projectFlagName, completeProjectFlag)
 	missionNewCmd.Flags().StringVar(&sourceFlag, "source", "", "mission source type (internal use)")
 	missionNewCmd.Flags().StringVar(&sourceIDFlag, "source-id", "", "mission source identifier (internal use)")
 	missionNewCmd.Flags().StringVar(&sourceMetadataFlag, "source-metadata", "", "mission source metadata JSON (internal use)")
@@ -89,6 +172,55 @@ func runMissionNew(cmd *cobra.Command, args []string) error {
 	if len(sourceIDFlag) > 256 {
 		return stacktrace.NewError("--source-id exceeds 256 characters")
 	}
+	if maxPromptsFlag < 0 {
+		return stacktrace.NewError("--%s cannot be negative", maxPromptsFlagName)
+	}
+	if budgetUSDFlag < 0 {
+		return stacktrace.NewError("--%s cannot be negative", budgetUSDFlagName)
+	}
+	env, err := parseEnvFlag(missionEnvFlagName, missionEnvFlag)
+	if err != nil {
+		return err
+	}
+	for key := range env {
+		if err := config.ValidateEnvVarName(key); err != nil {
+			return stacktrace.Propagate(err, "invalid --%s", missionEnvFlagName)
+		}
+	}
+	missionNewEnv = env
+
+	if cmd.Flags().Changed(cloneModeFlagName) && cloneFlag == "" {
+		return stacktrace.NewError("--%s requires --%s", cloneModeFlagName, cloneFlagName)
+	}
+
+	if backendFlag != "" && backendFlag != config.AgentBackendClaude && adjutantFlag {
+		return stacktrace.NewError("--%s: adjutant missions can only use the '%s' backend", backendFlagName, config.AgentBackendClaude)
+	}
+
+	if missionPathFlag != "" && (adjutantFlag || blankFlag) {
+		return stacktrace.NewError("--%s requires a mission with a repo", missionPathFlagName)
+	}
+
+	if historyFlag {
+		if promptFlag != "" || cloneFlag != "" {
+			return stacktrace.NewError("--%s cannot be combined with --%s or --%s", historyFlagName, promptFlagName, cloneFlagName)
+		}
+		prompt, err := pickPromptFromHistory()
+		if err != nil {
+			return err
+		}
+		if prompt == "" {
+			return nil // user cancelled fzf
+		}
+		promptFlag = prompt
+	}
+
+	if len(missionGitFlag) > 0 {
+		if len(args) > 0 || cloneFlag != "" || adjutantFlag || blankFlag {
+			return stacktrace.NewError("--%s cannot be combined with a repo argument, --%s, --%s, or --%s", missionGitFlagName, cloneFlagName, adjutantFlagName, blankFlagName)
+		}
+		return runMissionNewWithGitFlags()
+	}
 
 	if cloneFlag != "" {
 		return runMissionNewWithClone()
@@ -102,12 +234,45 @@ func runMissionNew(cmd *cobra.Command, args []string) error {
 		return createAndLaunchMission("", promptFlag)
 	}
 
+	if len(args) == 0 && missionNewProjectFlag != "" {
+		projectRepo, err := getProjectRepo(missionNewProjectFlag)
+		if err != nil {
+			return err
+		}
+		if projectRepo != "" {
+			return createAndLaunchMission(projectRepo, promptFlag)
+		}
+	}
+
 	return runMissionNewWithPicker(args)
 }
 
-// runMissionNewWithClone creates a new mission by cloning the agent directory
-// of an existing mission. The source mission's git_repo carries over to the
-// new mission.
+// runMissionNewWithGitFlags resolves each --git repo (cloning it into the
+// library if needed) and creates the mission. Two or more repos make a
+// multi-repo mission.
+func runMissionNewWithGitFlags() error {
+	repoNames := make([]string, 0, len(missionGitFlag))
+	for _, input := range missionGitFlag {
+		result, err := ResolveRepoInput(input, "Select repo: ")
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to resolve repo '%s'", input)
+		}
+		repoNames = append(repoNames, result.RepoName)
+	}
+
+	if len(repoNames) == 1 {
+		return createAndLaunchMission(repoNames[0], promptFlag)
+	}
+	if missionPathFlag != "" {
+		return stacktrace.NewError("--%s is not supported for multi-repo missions", missionPathFlagName)
+	}
+	missionNewRepos = repoNames
+	return createAndLaunchMission("", promptFlag)
+}
+
+// runMissionNewWithClone creates a new mission by cloning the agent directory,
+// the conversation, or both of an existing mission, per --clone-mode. The
+// source mission's git_repo carries over to the new mission.
 func runMissionNewWithClone() error {
 	client, err := serverClient()
 	if err != nil {
@@ -129,8 +294,16 @@ func runMissionNewWithClone() error {
 		Repo:        sourceMission.GitRepo,
 		Prompt:      promptFlag,
 		CloneFrom:   sourceMission.ID,
+		CloneMode:   cloneModeFlag,
 		TmuxSession: tmuxSession,
 		NoFocus:     noFocusFlag,
+		MaxPrompts:  maxPromptsFlag,
+		BudgetUSD:   budgetUSDFlag,
+		Env:         missionNewEnv,
+		Sandbox:     sandboxFlag,
+		Path:        missionPathFlag,
+		Backend:     backendFlag,
+		Project:     missionNewProjectFlag,
 	})
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to create mission")
@@ -141,11 +314,7 @@ func runMissionNewWithClone() error {
 		fmt.Printf("Mission directory: %s\n", config.GetMissionDirpath(agencDirpath, missionRecord.ID))
 	}
 
-	if tmuxSession != "" || sourceFlag == "mission" {
-		fmt.Println("Launched in tmux pool")
-	} else {
-		fmt.Println("Running in background (pool window)")
-	}
+	printMissionLaunchStatus(missionRecord, tmuxSession)
 
 	return nil
 }
@@ -241,6 +410,10 @@ func createAndLaunchAdjutantMission(initialPrompt string) error {
 		Prompt:      initialPrompt,
 		TmuxSession: tmuxSession,
 		NoFocus:     noFocusFlag,
+		MaxPrompts:  maxPromptsFlag,
+		BudgetUSD:   budgetUSDFlag,
+		Env:         missionNewEnv,
+		Project:     missionNewProjectFlag,
 	})
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to create adjutant mission")
@@ -248,11 +421,7 @@ func createAndLaunchAdjutantMission(initialPrompt string) error {
 
 	fmt.Printf("Created Adjutant mission: %s\n", missionRecord.ShortID)
 
-	if tmuxSession != "" || sourceFlag == "mission" {
-		fmt.Println("Launched in tmux pool")
-	} else {
-		fmt.Println("Running in background (pool window)")
-	}
+	printMissionLaunchStatus(missionRecord, tmuxSession)
 
 	return nil
 }
@@ -356,7 +525,8 @@ func selectFromRepoLibrary(agencDirpath string, entries []repoLibraryEntry, init
 // createAndLaunchMission creates the mission record and directory via the
 // server, which spawns a wrapper in a tmux pool window.
 // gitRepoName is the canonical repo name stored in the DB (e.g.
-// "github.com/owner/repo"); empty when no git repo is involved.
+// "github.com/owner/repo"); empty when no git repo is involved, or for a
+// multi-repo mission, whose repos are in missionNewRepos.
 // initialPrompt is optional; if non-empty, it will be sent to Claude.
 func createAndLaunchMission(
 	gitRepoName string,
@@ -381,6 +551,14 @@ func createAndLaunchMission(
 		SourceID:       sourceIDFlag,
 		SourceMetadata: sourceMetadataFlag,
 		NoFocus:        noFocusFlag,
+		MaxPrompts:     maxPromptsFlag,
+		BudgetUSD:      budgetUSDFlag,
+		Env:            missionNewEnv,
+		Repos:          missionNewRepos,
+		Sandbox:        sandboxFlag,
+		Path:           missionPathFlag,
+		Backend:        backendFlag,
+		Project:        missionNewProjectFlag,
 	})
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to create mission")
@@ -388,13 +566,42 @@ func createAndLaunchMission(
 
 	fmt.Printf("Created mission: %s\n", missionRecord.ShortID)
 
-	if tmuxSession != "" || sourceFlag == "mission" {
+	printMissionLaunchStatus(missionRecord, tmuxSession)
+
+	return nil
+}
+
+// printMissionLaunchStatus reports where a newly created mission is running,
+// or its place in the start queue when missionsMaxConcurrent held it back,
+// and warns when other active missions are on the same branch of its repo or
+// when it started from a cached clone because the repo's remote was
+// unreachable.
+func printMissionLaunchStatus(missionRecord *database.Mission, tmuxSession string) {
+	switch {
+	case missionRecord.QueuePosition > 0:
+		fmt.Printf("Queued at position %d: missionsMaxConcurrent missions are already running. It will start when one stops (see 'agenc mission queue').\n", missionRecord.QueuePosition)
+	case tmuxSession != "" || sourceFlag == "mission":
 		fmt.Println("Launched in tmux pool")
-	} else {
+	default:
 		fmt.Println("Running in background (pool window)")
 	}
+	if len(missionRecord.BranchConflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %s also working on this branch of %s; run '%s %s %s' to see files they both modify\n",
+			formatBranchConflictMissions(missionRecord.BranchConflicts), displayGitRepo(missionRecord.GitRepo), agencCmdStr, missionCmdStr, conflictsCmdStr)
+	}
+	if missionRecord.UsedCachedClone {
+		fmt.Fprintf(os.Stderr, "Note: couldn't reach the remote of %s, so the mission started from the cached clone, which may be behind; the repo syncs when connectivity returns (see '%s %s --%s')\n",
+			displayGitRepo(missionRecord.GitRepo), agencCmdStr, statusCmdStr, connectivityFlagName)
+	}
+}
 
-	return nil
+// formatBranchConflictMissions renders the missions sharing a new mission's
+// branch as the subject of a sentence.
+func formatBranchConflictMissions(shortIDs []string) string {
+	if len(shortIDs) == 1 {
+		return "mission " + shortIDs[0] + " is"
+	}
+	return "missions " + strings.Join(shortIDs, ", ") + " are"
 }
 
 // promptForRepoLocator interactively prompts the user for a repo locator,
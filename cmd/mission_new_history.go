@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+// promptHistoryPickerMaxLen caps how much of each prompt the history picker
+// shows; fzf still matches against the shown text only.
+const promptHistoryPickerMaxLen = 120
+
+// promptHistoryEntry is one distinct initial prompt from past missions.
+type promptHistoryEntry struct {
+	Prompt   string
+	GitRepo  string // repo of the most recent mission started with the prompt
+	LastUsed time.Time
+	Uses     int
+}
+
+// buildPromptHistory collapses the initial prompts of missions into distinct
+// entries, most recently used first. Cron missions are skipped since their
+// prompts already live in config.yml.
+func buildPromptHistory(missions []*database.Mission) []promptHistoryEntry {
+	byPrompt := make(map[string]*promptHistoryEntry)
+	for _, m := range missions {
+		prompt := strings.TrimSpace(m.Prompt)
+		if prompt == "" || m.CronID != nil || (m.Source != nil && *m.Source == "cron") {
+			continue
+		}
+		entry, ok := byPrompt[prompt]
+		if !ok {
+			entry = &promptHistoryEntry{Prompt: prompt}
+			byPrompt[prompt] = entry
+		}
+		entry.Uses++
+		if m.CreatedAt.After(entry.LastUsed) {
+			entry.LastUsed = m.CreatedAt
+			entry.GitRepo = m.GitRepo
+		}
+	}
+
+	history := make([]promptHistoryEntry, 0, len(byPrompt))
+	for _, entry := range byPrompt {
+		history = append(history, *entry)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].LastUsed.After(history[j].LastUsed)
+	})
+	return history
+}
+
+// pickPromptFromHistory shows an fzf picker over the initial prompts of past
+// missions, archived ones included, and returns the chosen prompt. Returns ""
+// if the user cancels.
+func pickPromptFromHistory() (string, error) {
+	client, err := serverClient()
+	if err != nil {
+		return "", err
+	}
+	missions, err := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true})
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to list missions")
+	}
+
+	history := buildPromptHistory(missions)
+	if len(history) == 0 {
+		return "", stacktrace.NewError("no past mission prompts to choose from; start a mission with --%s first", promptFlagName)
+	}
+
+	now := time.Now()
+	rows := make([][]string, 0, len(history))
+	for _, entry := range history {
+		rows = append(rows, []string{
+			formatTimeAgo(entry.LastUsed, now),
+			fmt.Sprintf("%d", entry.Uses),
+			displayGitRepo(entry.GitRepo),
+			truncatePrompt(entry.Prompt, promptHistoryPickerMaxLen),
+		})
+	}
+
+	indices, err := runFzfPicker(FzfPickerConfig{
+		Prompt:  "Select prompt: ",
+		Headers: []string{"LAST USED", "USES", "LAST REPO", "PROMPT"},
+		Rows:    rows,
+	})
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to run prompt history picker")
+	}
+	if len(indices) == 0 {
+		return "", nil
+	}
+	return history[indices[0]].Prompt, nil
+}
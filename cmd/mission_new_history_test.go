@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestBuildPromptHistory(t *testing.T) {
+	now := time.Now().UTC()
+	cronID := "cron-id"
+
+	missions := []*database.Mission{
+		{Prompt: "Fix the flaky tests", GitRepo: "github.com/owner/api", CreatedAt: now.Add(-3 * time.Hour)},
+		{Prompt: "Bump dependencies", GitRepo: "github.com/owner/web", CreatedAt: now.Add(-2 * time.Hour)},
+		{Prompt: "  Fix the flaky tests\n", GitRepo: "github.com/owner/web", CreatedAt: now.Add(-1 * time.Hour)},
+		{Prompt: "", GitRepo: "github.com/owner/api", CreatedAt: now},
+		{Prompt: "Nightly report", CronID: &cronID, CreatedAt: now},
+		{Prompt: "Weekly digest", Source: strPtr("cron"), CreatedAt: now},
+	}
+
+	history := buildPromptHistory(missions)
+	if len(history) != 2 {
+		t.Fatalf("expected 2 distinct prompts, got %+v", history)
+	}
+
+	first := history[0]
+	if first.Prompt != "Fix the flaky tests" || first.Uses != 2 || first.GitRepo != "github.com/owner/web" {
+		t.Errorf("expected the repeated prompt first with its latest repo, got %+v", first)
+	}
+	if !first.LastUsed.Equal(now.Add(-1 * time.Hour)) {
+		t.Errorf("expected LastUsed from the latest mission, got %v", first.LastUsed)
+	}
+	if history[1].Prompt != "Bump dependencies" || history[1].Uses != 1 {
+		t.Errorf("unexpected second entry %+v", history[1])
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+var missionOpenDirFlag bool
+var missionOpenRepoFlag bool
+var missionOpenPRFlag bool
+var missionOpenLogsFlag bool
+
+var missionOpenCmd = &cobra.Command{
+	Use:   openCmdStr + " [mission-id]",
+	Short: "Open a mission's agent directory, repo, pull request, or logs",
+	Long: `Open one of a mission's artifacts.
+
+  --dir   (default) the mission's agent directory: in $EDITOR when run from a
+          terminal with $EDITOR set, otherwise in Finder (or the desktop's
+          file manager via xdg-open on Linux)
+  --repo  the mission's repo on its host in the browser; every repo for a
+          multi-repo mission
+  --pr    the mission's pull request in the browser (see 'agenc mission pr')
+  --logs  follow the mission's Claude output log, like
+          'agenc mission logs -f' (Ctrl-C to stop)
+
+If no mission-id is provided, uses $AGENC_CALLING_MISSION_UUID, so palette
+commands can open the focused mission's artifacts. The builtin palette
+commands openMissionDir, openMissionPR, and followMissionLogs do this.
+
+Examples:
+  agenc mission open abc12345
+  agenc mission open abc12345 --pr
+  agenc mission open --logs          # from a palette command`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runMissionOpen,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionOpenCmd.Flags().BoolVar(&missionOpenDirFlag, dirFlagName, false, "open the agent directory in $EDITOR or the file manager (default)")
+	missionOpenCmd.Flags().BoolVar(&missionOpenRepoFlag, missionOpenRepoFlagName, false, "open the mission's repo in the browser")
+	missionOpenCmd.Flags().BoolVar(&missionOpenPRFlag, missionOpenPRFlagName, false, "open the mission's pull request in the browser")
+	missionOpenCmd.Flags().BoolVar(&missionOpenLogsFlag, missionOpenLogsFlagName, false, "follow the mission's Claude output log")
+	missionOpenCmd.MarkFlagsMutuallyExclusive(dirFlagName, missionOpenRepoFlagName, missionOpenPRFlagName, missionOpenLogsFlagName)
+	missionCmd.AddCommand(missionOpenCmd)
+}
+
+func runMissionOpen(cmd *cobra.Command, args []string) error {
+	missionIDInput := os.Getenv(config.CallingMissionUUIDEnvVar)
+	if len(args) == 1 {
+		missionIDInput = args[0]
+	}
+	if missionIDInput == "" {
+		return stacktrace.NewError("no mission ID provided; pass a mission ID or set $%s", config.CallingMissionUUIDEnvVar)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionRecord, err := client.GetMission(missionIDInput)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission %s", missionIDInput)
+	}
+
+	switch {
+	case missionOpenLogsFlag:
+		return followMissionLogs(client, missionRecord.ID, "claude", false)
+	case missionOpenPRFlag:
+		if missionRecord.PRURL == "" {
+			return stacktrace.NewError("mission %s has no pull request; open one with 'agenc mission %s %s'", missionRecord.ShortID, prCmdStr, missionRecord.ShortID)
+		}
+		return openExternally(missionRecord.PRURL)
+	case missionOpenRepoFlag:
+		repoURLs := missionRepoWebURLs(missionRecord)
+		if len(repoURLs) == 0 {
+			return stacktrace.NewError("mission %s has no repo", missionRecord.ShortID)
+		}
+		for _, repoURL := range repoURLs {
+			if err := openExternally(repoURL); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		agencDirpath, err := config.GetAgencDirpath()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to get agenc directory path")
+		}
+		return openMissionDir(config.GetMissionAgentDirpath(agencDirpath, missionRecord.ID))
+	}
+}
+
+// missionRepoWebURLs returns the web pages of a mission's repos: its repo, or
+// each repo of a multi-repo mission. Canonical repo names ("host/owner/repo")
+// are served over HTTPS at the same path.
+func missionRepoWebURLs(missionRecord *database.Mission) []string {
+	repos := missionRecord.GitRepos
+	if missionRecord.GitRepo != "" {
+		repos = []string{missionRecord.GitRepo}
+	}
+	repoURLs := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		repoURLs = append(repoURLs, "https://"+strings.TrimSuffix(repo, ".git"))
+	}
+	return repoURLs
+}
+
+// openMissionDir opens dirpath in $EDITOR when run from a terminal with
+// $EDITOR set, since a terminal editor can't run without one (e.g. from the
+// palette), and in the file manager otherwise.
+func openMissionDir(dirpath string) error {
+	if _, err := os.Stat(dirpath); err != nil {
+		return stacktrace.Propagate(err, "mission directory '%s' is not available", dirpath)
+	}
+	if os.Getenv("EDITOR") == "" || !isatty.IsTerminal(os.Stdin.Fd()) {
+		return openExternally(dirpath)
+	}
+
+	editorBinary, editorParts, err := resolveEditor()
+	if err != nil {
+		return err
+	}
+	editorCmd := exec.Command(editorBinary, append(editorParts[1:], dirpath)...)
+	editorCmd.Dir = dirpath
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return stacktrace.Propagate(err, "editor exited with error")
+	}
+	return nil
+}
+
+// openExternally opens a URL or path with the desktop's default handler:
+// `open` on macOS, `xdg-open` elsewhere.
+func openExternally(target string) error {
+	opener := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		opener = "open"
+	}
+	output, err := exec.Command(opener, target).CombinedOutput()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open '%s' with %s: %s", target, opener, strings.TrimSpace(string(output)))
+	}
+	fmt.Printf("Opened %s\n", target)
+	return nil
+}
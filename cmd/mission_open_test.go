@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestMissionRepoWebURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		mission *database.Mission
+		want    []string
+	}{
+		{"single repo", &database.Mission{GitRepo: "github.com/owner/app"}, []string{"https://github.com/owner/app"}},
+		{"multi repo", &database.Mission{GitRepos: []string{"github.com/owner/api", "gitlab.com/owner/web"}},
+			[]string{"https://github.com/owner/api", "https://gitlab.com/owner/web"}},
+		{"blank mission", &database.Mission{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missionRepoWebURLs(tt.mission); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
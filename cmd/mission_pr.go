@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var (
+	missionPRTitleFlag   string
+	missionPRBodyFlag    string
+	missionPRBaseFlag    string
+	missionPRDraftFlag   bool
+	missionPRMessageFlag string
+)
+
+var missionPRCmd = &cobra.Command{
+	Use:   prCmdStr + " <mission-id>",
+	Short: "Open a GitHub pull request from a mission's work",
+	Long: fmt.Sprintf(`Open a GitHub pull request from a mission's work.
+
+Commits any outstanding changes in the mission's workspace, pushes its branch
+to origin, and opens a pull request with the GitHub CLI (gh). A mission still
+on the repo's default branch is first moved to a fresh branch named by the
+repo's autoBranchTemplate. If the branch already has an open PR, the new
+commits are pushed to it and its URL is reported instead.
+
+The PR title defaults to the mission's session title (or the first line of its
+prompt); the commit message defaults to the title. The PR URL is recorded on
+the mission and shown in '%s %s %s'.
+
+Examples:
+  %s %s %s 2b4c8f1a
+  %s %s %s 2b4c8f1a --%s "Fix login redirect" --%s`,
+		agencCmdStr, missionCmdStr, lsCmdStr,
+		agencCmdStr, missionCmdStr, prCmdStr,
+		agencCmdStr, missionCmdStr, prCmdStr, missionPRTitleFlagName, missionPRDraftFlagName,
+	),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionPR,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionPRCmd.Flags().StringVar(&missionPRTitleFlag, missionPRTitleFlagName, "", "PR title (default: the mission's session title)")
+	missionPRCmd.Flags().StringVar(&missionPRBodyFlag, missionPRBodyFlagName, "", "PR body")
+	missionPRCmd.Flags().StringVar(&missionPRBaseFlag, missionPRBaseFlagName, "", "branch to merge into (default: the repo's default branch)")
+	missionPRCmd.Flags().BoolVar(&missionPRDraftFlag, missionPRDraftFlagName, false, "open the PR as a draft")
+	missionPRCmd.Flags().StringVarP(&missionPRMessageFlag, missionPRMessageFlagName, "m", "", "commit message for outstanding changes (default: the PR title)")
+	missionCmd.AddCommand(missionPRCmd)
+}
+
+func runMissionPR(cmd *cobra.Command, args []string) error {
+	if !looksLikeMissionID(args[0]) {
+		return stacktrace.NewError("not a valid mission ID: %s", args[0])
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	resp, err := client.OpenMissionPR(missionID, server.MissionPRRequest{
+		Title:         missionPRTitleFlag,
+		Body:          missionPRBodyFlag,
+		Base:          missionPRBaseFlag,
+		Draft:         missionPRDraftFlag,
+		CommitMessage: missionPRMessageFlag,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open pull request for mission %s", database.ShortID(missionID))
+	}
+
+	if resp.Committed {
+		fmt.Printf("Committed outstanding changes on branch '%s'\n", resp.Branch)
+	}
+	if resp.Created {
+		fmt.Printf("Opened pull request: %s\n", resp.PRURL)
+	} else {
+		fmt.Printf("Pushed to existing pull request: %s\n", resp.PRURL)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+// missionPreviewWindow is fzf's --preview-window for the mission picker:
+// below the table so its columns keep their width.
+const missionPreviewWindow = "down:50%"
+
+// missionPreviewDefaultLines is how many output lines the preview shows when
+// fzf doesn't report the pane height.
+const missionPreviewDefaultLines = 40
+
+// missionPreviewFzfCmd is a hidden command used by the mission picker's fzf
+// --preview. It prints the mission's title, status, repo, and AI summary,
+// followed by the tail of its recent output.
+var missionPreviewFzfCmd = &cobra.Command{
+	Use:    previewFzfCmdStr + " <mission-id>",
+	Short:  "Preview a mission (fzf helper)",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE:   runMissionPreviewFzf,
+}
+
+func init() {
+	missionCmd.AddCommand(missionPreviewFzfCmd)
+}
+
+func runMissionPreviewFzf(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	m, err := client.GetMission(args[0])
+	if err != nil {
+		fmt.Printf("Mission %s not found\n", args[0])
+		return nil
+	}
+
+	cfg, _ := readConfig()
+	header := formatMissionPreviewHeader(m, formatRepoDisplay(m.GitRepo, m.IsAdjutant, cfg))
+
+	// fzf sets FZF_PREVIEW_LINES to the preview pane's height
+	lines := missionPreviewDefaultLines
+	if n, err := strconv.Atoi(os.Getenv("FZF_PREVIEW_LINES")); err == nil && n > 0 {
+		lines = n
+	}
+	lines = max(lines-strings.Count(header, "\n"), 1)
+
+	fmt.Print(header)
+	output := captureMissionPane(m, lines)
+	if output == "" {
+		if body, err := client.GetMissionOutput(m.ID, "claude", false); err == nil {
+			output = lastLines(strings.TrimRight(string(body), "\n "), lines)
+		}
+	}
+	if output == "" {
+		fmt.Println("(no recent output)")
+		return nil
+	}
+	fmt.Println(output)
+	return nil
+}
+
+// formatMissionPreviewHeader renders the top of a mission's preview: its
+// window title, status, repo, AI summary, and a separator.
+func formatMissionPreviewHeader(m *database.Mission, repo string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s\n", m.ShortID, resolveSessionName(m))
+	fmt.Fprintf(&b, "Status:  %s\n", colorizeStatus(getMissionStatus(m.ID, m.Status, m.ClaudeState)))
+	fmt.Fprintf(&b, "Repo:    %s\n", repo)
+	if m.AISummary != "" {
+		fmt.Fprintf(&b, "Summary: %s\n", strings.Join(strings.Fields(m.AISummary), " "))
+	}
+	b.WriteString(strings.Repeat("─", 40) + "\n")
+	return b.String()
+}
+
+// captureMissionPane returns the last lines shown in a running mission's tmux
+// pane, with colors, or "" when the mission has no live pane.
+func captureMissionPane(m *database.Mission, lines int) string {
+	if m.TmuxPane == nil || !isMissionRunning(getMissionStatus(m.ID, m.Status, m.ClaudeState)) {
+		return ""
+	}
+	out, err := tmux.Command("capture-pane", "-p", "-e", "-J", "-t", "%"+*m.TmuxPane, "-S", fmt.Sprintf("-%d", lines)).Output()
+	if err != nil {
+		return ""
+	}
+	return lastLines(strings.TrimRight(string(out), "\n "), lines)
+}
+
+// lastLines returns the last n lines of s.
+func lastLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[len(lines)-n:], "\n")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestLastLines(t *testing.T) {
+	tests := []struct {
+		input    string
+		n        int
+		expected string
+	}{
+		{"a\nb\nc", 2, "b\nc"},
+		{"a\nb", 5, "a\nb"},
+		{"", 3, ""},
+	}
+	for _, tt := range tests {
+		if got := lastLines(tt.input, tt.n); got != tt.expected {
+			t.Errorf("lastLines(%q, %d) = %q, expected %q", tt.input, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatMissionPreviewHeader(t *testing.T) {
+	m := &database.Mission{
+		ID:        "2b4c8f1a-0000-0000-0000-000000000000",
+		ShortID:   "2b4c8f1a",
+		Status:    "archived",
+		Prompt:    "fix the login flow",
+		AISummary: "Fixing the OAuth\nredirect",
+	}
+
+	header := formatMissionPreviewHeader(m, "owner/repo")
+
+	for _, want := range []string{"2b4c8f1a  fix the login flow\n", "Repo:    owner/repo\n", "Summary: Fixing the OAuth redirect\n", colorizeStatus(StatusArchived)} {
+		if !strings.Contains(header, want) {
+			t.Errorf("expected header to contain %q, got:\n%s", want, header)
+		}
+	}
+
+	m.AISummary = ""
+	if strings.Contains(formatMissionPreviewHeader(m, "owner/repo"), "Summary:") {
+		t.Error("expected no summary line without an AI summary")
+	}
+}
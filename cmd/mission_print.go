@@ -32,8 +32,9 @@ Example:
   agenc mission print 2571d5d8
   agenc mission print 2571d5d8 --format=jsonl
   agenc mission print 2571d5d8 --tail 50`,
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionPrint,
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionPrint,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var missionPromptsCmd = &cobra.Command{
+	Use:   promptsCmdStr + " <mission-id>",
+	Short: "List the prompts submitted to a mission",
+	Long: `List every prompt submitted to a mission, oldest first.
+
+Prompts are recorded as Claude receives them, whether typed by you, sent with
+'agenc mission send-keys', or given as the mission's initial prompt. Feed the
+same sequence into a fresh mission with 'agenc mission replay'.
+
+Prompt text is not recorded for devcontainer missions.
+
+Examples:
+  agenc mission prompts abc12345
+  agenc mission prompts abc12345 -o json`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionPrompts,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionCmd.AddCommand(missionPromptsCmd)
+}
+
+func runMissionPrompts(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	prompts, err := client.ListMissionPrompts(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get prompts for mission %s", args[0])
+	}
+
+	if isStructuredOutput() {
+		return printStructured(prompts)
+	}
+
+	if len(prompts) == 0 {
+		fmt.Println("No prompts recorded for this mission.")
+		return nil
+	}
+
+	for i, prompt := range prompts {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("#%d  %s\n", i+1, prompt.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+		fmt.Println(prompt.Prompt)
+	}
+	return nil
+}
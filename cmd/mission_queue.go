@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var missionQueueCmd = &cobra.Command{
+	Use:   queueCmdStr,
+	Short: "Show missions waiting to start",
+	Long: `Show missions waiting for a slot under missionsMaxConcurrent.
+
+When missionsMaxConcurrent is set in config.yml, new interactive missions
+beyond the limit are created but not started. The server queues them and
+starts them in order as running missions stop, are archived, or are deleted.
+Cron missions and headless runs are never queued.
+
+Stopping, archiving, or removing a queued mission takes it out of the queue;
+attaching to it starts it immediately.
+
+The queue lives in the server's memory: restarting the server drops it, and
+the affected missions stay stopped until resumed.
+
+Examples:
+  agenc config set missionsMaxConcurrent 3
+  agenc mission queue
+`,
+	Args: cobra.NoArgs,
+	RunE: runMissionQueue,
+}
+
+func init() {
+	missionCmd.AddCommand(missionQueueCmd)
+}
+
+func runMissionQueue(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	entries, err := client.ListMissionQueue()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to fetch mission queue")
+	}
+
+	if isStructuredOutput() {
+		return printStructured(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No missions queued.")
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("POS", "ID", "REPO", "WAITING", "PROMPT")
+	for _, entry := range entries {
+		tbl.AddRow(
+			fmt.Sprintf("%d", entry.Position),
+			entry.ShortID,
+			displayGitRepo(entry.GitRepo),
+			formatMissionDuration(time.Since(entry.QueuedAt)),
+			truncatePrompt(entry.Prompt, 60),
+		)
+	}
+	tbl.Print()
+	return nil
+}
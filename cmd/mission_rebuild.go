@@ -27,8 +27,9 @@ the latest devcontainer.json, then restarts Claude. Only works for missions
 whose repository has a devcontainer.json.
 
 Accepts a mission ID (short 8-char hex or full UUID).`,
-	Args: cobra.ExactArgs(1),
-	RunE: runMissionRebuild,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionRebuild,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
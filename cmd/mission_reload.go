@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
@@ -11,8 +12,10 @@ import (
 )
 
 var (
-	reloadPromptFlag string
-	reloadAsyncFlag  bool
+	reloadPromptFlag   string
+	reloadAsyncFlag    bool
+	reloadStaleFlag    bool
+	reloadGracefulFlag bool
 )
 
 var missionReloadCmd = &cobra.Command{
@@ -37,15 +40,29 @@ THEMSELVES SHOULD ALWAYS USE --async: a synchronous self-reload kills
 Claude mid-tool-call, which discards the bash tool result from Claude's
 conversation history. Async preserves the tool call and the prompt arrives
 cleanly on the next turn. Returns 202 Accepted; if Claude is already idle,
-the reload fires immediately.`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runMissionReload,
+the reload fires immediately.
+
+The --stale flag rolls a Claude config change out to every running mission
+whose config was built from an older commit of the shadow repo, instead of
+reloading them one by one. Combine it with --graceful (the same as --async)
+so each mission reloads only once Claude is idle, never mid-turn. Headless
+missions are skipped; they pick up the new config on their next run.
+
+Examples:
+  agenc mission reload 2571d5d8
+  agenc mission reload 2571d5d8 --async --prompt "Config reloaded; carry on"
+  agenc mission reload --stale --graceful`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runMissionReload,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
 	missionCmd.AddCommand(missionReloadCmd)
 	missionReloadCmd.Flags().StringVar(&reloadPromptFlag, promptFlagName, "", "follow-up prompt to send after reload (requires a mission with a live tmux pane)")
 	missionReloadCmd.Flags().BoolVar(&reloadAsyncFlag, asyncFlagName, false, "queue the reload for Claude's next idle (REQUIRED when an agent reloads itself, to preserve the calling tool result)")
+	missionReloadCmd.Flags().BoolVar(&reloadStaleFlag, staleFlagName, false, "reload every running mission whose Claude config is behind the latest config")
+	missionReloadCmd.Flags().BoolVar(&reloadGracefulFlag, gracefulFlagName, false, "reload only once Claude is idle (same as --async)")
 }
 
 func runMissionReload(cmd *cobra.Command, args []string) error {
@@ -54,6 +71,14 @@ func runMissionReload(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	async := reloadAsyncFlag || reloadGracefulFlag
+	if reloadStaleFlag {
+		if len(args) > 0 {
+			return stacktrace.NewError("--%s reloads every mission on an older config; it can't be combined with a mission ID", staleFlagName)
+		}
+		return runStaleMissionReload(client, async)
+	}
+
 	if len(args) == 1 {
 		input := args[0]
 		if !looksLikeMissionID(input) {
@@ -63,10 +88,10 @@ func runMissionReload(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return stacktrace.Propagate(err, "failed to resolve mission ID")
 		}
-		if err := client.ReloadMission(missionID, reloadPromptFlag, reloadAsyncFlag); err != nil {
+		if err := client.ReloadMission(missionID, reloadPromptFlag, async); err != nil {
 			return stacktrace.Propagate(err, "failed to reload mission %s", database.ShortID(missionID))
 		}
-		fmt.Println(reloadResultMessage(missionID, reloadAsyncFlag))
+		fmt.Println(reloadResultMessage(missionID, async))
 		return nil
 	}
 
@@ -101,13 +126,60 @@ func runMissionReload(cmd *cobra.Command, args []string) error {
 	}
 
 	entry := result.Items[0]
-	if err := client.ReloadMission(entry.MissionID, reloadPromptFlag, reloadAsyncFlag); err != nil {
+	if err := client.ReloadMission(entry.MissionID, reloadPromptFlag, async); err != nil {
 		return stacktrace.Propagate(err, "failed to reload mission %s", entry.ShortID)
 	}
-	fmt.Println(reloadResultMessage(entry.MissionID, reloadAsyncFlag))
+	fmt.Println(reloadResultMessage(entry.MissionID, async))
+	return nil
+}
+
+// runStaleMissionReload reloads every running mission whose Claude config is
+// behind the shadow repo HEAD. A failed reload doesn't stop the rest; the
+// command fails at the end if any did.
+func runStaleMissionReload(client *server.Client, async bool) error {
+	staleConfig, err := client.ListStaleConfigMissions()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to find missions with stale config")
+	}
+
+	reloadable, headless := partitionStaleMissions(staleConfig.Missions)
+	for _, m := range headless {
+		fmt.Printf("Skipping headless mission '%s'; it picks up the new config on its next run\n", m.ShortID)
+	}
+	if len(reloadable) == 0 {
+		fmt.Println("No running missions to reload: all are on the latest config.")
+		return nil
+	}
+
+	var failed []string
+	for _, m := range reloadable {
+		if err := client.ReloadMission(m.MissionID, reloadPromptFlag, async); err != nil {
+			fmt.Printf("Failed to reload mission '%s': %v\n", m.ShortID, err)
+			failed = append(failed, m.ShortID)
+			continue
+		}
+		fmt.Printf("%s [%s]\n", reloadResultMessage(m.MissionID, async), server.FormatCommitsBehind(m.CommitsBehind))
+	}
+	if len(failed) > 0 {
+		return stacktrace.NewError("failed to reload %d of %d stale missions: %s", len(failed), len(reloadable), strings.Join(failed, ", "))
+	}
 	return nil
 }
 
+// partitionStaleMissions splits stale missions into those that can be
+// reloaded in place and headless ones, which can't.
+func partitionStaleMissions(missions []server.StaleMissionResponse) ([]server.StaleMissionResponse, []server.StaleMissionResponse) {
+	var reloadable, headless []server.StaleMissionResponse
+	for _, m := range missions {
+		if m.Headless {
+			headless = append(headless, m)
+		} else {
+			reloadable = append(reloadable, m)
+		}
+	}
+	return reloadable, headless
+}
+
 func reloadResultMessage(missionID string, async bool) string {
 	if async {
 		return fmt.Sprintf("Reload queued for mission '%s' (will fire on Claude's next idle)", database.ShortID(missionID))
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"testing"
+
+	"github.com/odyssey/agenc/internal/server"
 )
 
 func TestLooksLikeMissionID(t *testing.T) {
@@ -123,3 +125,20 @@ func TestAllLookLikeMissionIDs(t *testing.T) {
 // Note: Integration tests that actually interact with tmux, the database, and
 // running wrappers should be added in a separate integration test suite.
 // These unit tests focus on the helper functions and input validation logic.
+
+func TestPartitionStaleMissions(t *testing.T) {
+	missions := []server.StaleMissionResponse{
+		{ShortID: "aaaa1111"},
+		{ShortID: "bbbb2222", Headless: true},
+		{ShortID: "cccc3333"},
+	}
+
+	reloadable, headless := partitionStaleMissions(missions)
+
+	if len(reloadable) != 2 || reloadable[0].ShortID != "aaaa1111" || reloadable[1].ShortID != "cccc3333" {
+		t.Errorf("reloadable = %+v, want aaaa1111 and cccc3333", reloadable)
+	}
+	if len(headless) != 1 || headless[0].ShortID != "bbbb2222" {
+		t.Errorf("headless = %+v, want bbbb2222", headless)
+	}
+}
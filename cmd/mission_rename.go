@@ -13,18 +13,25 @@ import (
 
 var missionRenameCmd = &cobra.Command{
 	Use:   renameCmdStr + " [mission-id] [title]",
-	Short: "Rename the active session's window title for a mission",
-	Long: `Rename the active session's window title for a mission.
+	Short: "Set a mission's display name",
+	Long: `Set a mission's display name.
 
-This is a convenience command that resolves the mission's active session and
-renames it. If no mission-id is provided, uses $AGENC_CALLING_MISSION_UUID.
-If no title is provided, prompts for input interactively.
+The display name is stored on the mission itself, so it survives reloads and
+new sessions. It takes precedence over session titles everywhere a mission is
+shown: 'agenc mission ls', the palette, the dashboard, search results, and the
+tmux window name. Pass an empty title to clear it and fall back to the session
+title.
+
+If no mission-id is provided, uses $AGENC_CALLING_MISSION_UUID. If no title is
+provided, prompts for input interactively.
 
 Example:
   agenc mission rename                          # uses env var, prompts for title
-  agenc mission rename abc12345 "My Feature"    # explicit mission and title`,
-	Args: cobra.RangeArgs(0, 2),
-	RunE: runMissionRename,
+  agenc mission rename abc12345 "My Feature"    # explicit mission and title
+  agenc mission rename abc12345 ""              # clear the display name`,
+	Args:              cobra.RangeArgs(0, 2),
+	RunE:              runMissionRename,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
@@ -48,16 +55,6 @@ func runMissionRename(cmd *cobra.Command, args []string) error {
 		return stacktrace.NewError("no mission ID provided; pass a mission ID or set $AGENC_CALLING_MISSION_UUID")
 	}
 
-	// Resolve the active session
-	sessions, err := client.ListMissionSessions(missionID)
-	if err != nil {
-		return stacktrace.Propagate(err, "failed to list sessions for mission %s", missionID)
-	}
-	if len(sessions) == 0 {
-		return stacktrace.NewError("no sessions found for mission %s", missionID)
-	}
-	activeSession := sessions[0] // already sorted by updated_at DESC
-
 	// Get title from args or prompt
 	var title string
 	if len(args) >= 2 {
@@ -72,17 +69,17 @@ func runMissionRename(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	req := server.UpdateSessionRequest{
-		AgencCustomTitle: &title,
+	req := server.UpdateMissionRequest{
+		DisplayName: &title,
 	}
-	if err := client.UpdateSession(activeSession.ID, req); err != nil {
-		return stacktrace.Propagate(err, "failed to rename session")
+	if err := client.UpdateMission(missionID, req); err != nil {
+		return stacktrace.Propagate(err, "failed to rename mission")
 	}
 
 	if title == "" {
-		fmt.Println("Session title cleared.")
+		fmt.Println("Mission display name cleared.")
 	} else {
-		fmt.Printf("Session renamed to %q.\n", title)
+		fmt.Printf("Mission renamed to %q.\n", title)
 	}
 	return nil
 }
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+// replayPromptAckTimeout is how long 'agenc mission replay' waits for Claude
+// to report a sent prompt before assuming the keystrokes were lost (e.g.
+// typed while Claude was still starting up).
+const replayPromptAckTimeout = 30 * time.Second
+
+var replayIntoFlag string
+
+var missionReplayCmd = &cobra.Command{
+	Use:   replayCmdStr + " <mission-id>",
+	Short: "Feed a mission's prompts into a fresh mission",
+	Long: fmt.Sprintf(`Feed the prompts submitted to a mission into another, fresh mission, one at a
+time, to reproduce a session.
+
+Each prompt is typed into the target mission's pane once Claude is idle, and
+the next is sent only after Claude finishes responding. The target must be
+running and must not have received any prompts yet; create one with
+'agenc mission new' (without a prompt) first. Replay stops if Claude needs
+your attention or the mission exits.
+
+See the prompts that will be replayed with 'agenc mission prompts'.
+
+Examples:
+  agenc mission new owner/repo
+  agenc mission replay abc12345 --%s def67890`, replayIntoFlagName),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionReplay,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionReplayCmd.Flags().StringVar(&replayIntoFlag, replayIntoFlagName, "", "fresh, running mission to feed the prompts into (required)")
+	_ = missionReplayCmd.MarkFlagRequired(replayIntoFlagName)
+	missionCmd.AddCommand(missionReplayCmd)
+}
+
+func runMissionReplay(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	sourceID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+	targetID, err := client.ResolveMissionID(replayIntoFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve --%s mission ID", replayIntoFlagName)
+	}
+	if sourceID == targetID {
+		return stacktrace.NewError("cannot replay a mission into itself; pass a fresh mission to --%s", replayIntoFlagName)
+	}
+
+	prompts, err := client.ListMissionPrompts(sourceID)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get prompts for mission %s", database.ShortID(sourceID))
+	}
+	if len(prompts) == 0 {
+		return stacktrace.NewError("mission %s has no recorded prompts to replay", database.ShortID(sourceID))
+	}
+
+	target, err := client.GetMission(targetID)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get mission %s", database.ShortID(targetID))
+	}
+	if target.PromptCount > 0 {
+		return stacktrace.NewError(
+			"mission %s has already received %d prompt(s); replay into a fresh mission (create one with 'agenc %s %s')",
+			target.ShortID, target.PromptCount, missionCmdStr, newCmdStr,
+		)
+	}
+
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	for i, prompt := range prompts {
+		if err := waitForReplayIdle(ctx, client, targetID, i, time.Time{}); err != nil {
+			return stacktrace.Propagate(err, "stopped before prompt %d of %d", i+1, len(prompts))
+		}
+		if err := client.SendKeys(targetID, []string{prompt.Prompt, "Enter"}); err != nil {
+			return stacktrace.Propagate(err, "failed to send prompt %d of %d", i+1, len(prompts))
+		}
+		fmt.Printf("[%d/%d] %s\n", i+1, len(prompts), truncatePrompt(prompt.Prompt, 60))
+		if err := waitForReplayIdle(ctx, client, targetID, i+1, time.Now()); err != nil {
+			return stacktrace.Propagate(err, "stopped during prompt %d of %d", i+1, len(prompts))
+		}
+	}
+
+	fmt.Printf("Replayed %d prompt(s) from mission %s into mission %s\n", len(prompts), database.ShortID(sourceID), target.ShortID)
+	return nil
+}
+
+// waitForReplayIdle polls the target mission until Claude has received
+// wantPromptCount prompts and is idle again. A non-zero sentAt is when the
+// latest prompt was sent; if Claude hasn't reported it within
+// replayPromptAckTimeout, the keystrokes are assumed lost.
+func waitForReplayIdle(ctx context.Context, client *server.Client, missionID string, wantPromptCount int, sentAt time.Time) error {
+	ticker := time.NewTicker(runPollInterval)
+	defer ticker.Stop()
+
+	for {
+		missionRecord, err := client.GetMission(missionID)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to fetch mission state")
+		}
+		elapsed := time.Duration(0)
+		if !sentAt.IsZero() {
+			elapsed = time.Since(sentAt)
+		}
+		if done, err := classifyReplayState(missionRecord, wantPromptCount, elapsed); done || err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return stacktrace.NewError("interrupted")
+		case <-ticker.C:
+		}
+	}
+}
+
+// classifyReplayState decides whether a replay can move on given the target
+// mission's latest state: it can once Claude has received wantPromptCount
+// prompts and gone idle. elapsed is the time since the latest prompt was
+// sent, or zero before sending. Pure function for testability.
+func classifyReplayState(m *database.Mission, wantPromptCount int, elapsed time.Duration) (bool, error) {
+	if m.ClaudeState == nil {
+		return false, stacktrace.NewError("mission %s is not running; start it with 'agenc %s %s %s'", m.ShortID, missionCmdStr, attachCmdStr, m.ShortID)
+	}
+	if *m.ClaudeState == "needs_attention" {
+		return false, stacktrace.NewError("Claude is waiting for input; attach with 'agenc %s %s %s'", missionCmdStr, attachCmdStr, m.ShortID)
+	}
+	if m.PromptCount < wantPromptCount {
+		if elapsed > replayPromptAckTimeout {
+			return false, stacktrace.NewError("Claude did not receive the prompt within %s", replayPromptAckTimeout)
+		}
+		return false, nil
+	}
+	return *m.ClaudeState == "idle", nil
+}
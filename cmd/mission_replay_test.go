@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestClassifyReplayState(t *testing.T) {
+	state := func(s string) *string { return &s }
+
+	tests := []struct {
+		name            string
+		claudeState     *string
+		promptCount     int
+		wantPromptCount int
+		elapsed         time.Duration
+		wantDone        bool
+		wantErr         bool
+	}{
+		{name: "fresh mission idle", claudeState: state("idle"), wantDone: true},
+		{name: "not running", wantErr: true},
+		{name: "prompt not yet received", claudeState: state("idle"), wantPromptCount: 1, elapsed: time.Second},
+		{name: "prompt lost", claudeState: state("idle"), wantPromptCount: 1, elapsed: replayPromptAckTimeout + time.Second, wantErr: true},
+		{name: "busy with prompt", claudeState: state("busy"), promptCount: 1, wantPromptCount: 1, elapsed: time.Minute},
+		{name: "idle after prompt", claudeState: state("idle"), promptCount: 1, wantPromptCount: 1, elapsed: time.Minute, wantDone: true},
+		{name: "needs attention", claudeState: state("needs_attention"), promptCount: 1, wantPromptCount: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &database.Mission{ShortID: "abc12345", ClaudeState: tt.claudeState, PromptCount: tt.promptCount}
+			done, err := classifyReplayState(m, tt.wantPromptCount, tt.elapsed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if done != tt.wantDone {
+				t.Errorf("done = %v, want %v", done, tt.wantDone)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+var missionRepointRebaseFlag bool
+
+var missionRepointCmd = &cobra.Command{
+	Use:   repointCmdStr + " <mission-id> <repo>",
+	Short: "Move a mission's workspace to another repo, keeping the conversation",
+	Long: fmt.Sprintf(`Move a mission's workspace to another repo, keeping the conversation.
+
+Useful when an exploration mission graduates into a real project: the mission
+keeps its ID, window, and Claude conversation, but from now on works in the
+new repo. The repo accepts the same references as '%s %s %s' (owner/repo,
+URLs, local paths) and is cloned into the library if needed.
+
+By default the workspace is replaced with a fresh copy of the new repo (or a
+worktree, per the repo's workspaceMode). The old workspace is kept in the
+mission directory as agent-previous/, so nothing in it is lost; an earlier
+agent-previous/ is replaced.
+
+With --%s, the workspace is kept and the commits the mission made on top of
+its old repo are replayed onto the new repo's default branch, with
+uncommitted changes carried along. The rebase is aborted, leaving everything
+as it was, if it conflicts.
+
+A mission running in tmux is reloaded around the swap, like '%s %s %s'.
+
+Examples:
+  %s %s %s 2b4c8f1a mieubrisse/new-project
+  %s %s %s 2b4c8f1a github.com/mieubrisse/fork --%s`,
+		agencCmdStr, missionCmdStr, newCmdStr,
+		rebaseFlagName,
+		agencCmdStr, missionCmdStr, reloadCmdStr,
+		agencCmdStr, missionCmdStr, repointCmdStr,
+		agencCmdStr, missionCmdStr, repointCmdStr, rebaseFlagName,
+	),
+	Args:              cobra.ExactArgs(2),
+	RunE:              runMissionRepoint,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionRepointCmd.Flags().BoolVar(&missionRepointRebaseFlag, rebaseFlagName, false, "keep the workspace and rebase the mission's commits onto the new repo")
+	missionCmd.AddCommand(missionRepointCmd)
+}
+
+func runMissionRepoint(cmd *cobra.Command, args []string) error {
+	if !looksLikeMissionID(args[0]) {
+		return stacktrace.NewError("not a valid mission ID: %s", args[0])
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	result, err := ResolveRepoInput(args[1], "Select repo: ")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve repo '%s'", args[1])
+	}
+
+	mode := mission.RepointModeClone
+	if missionRepointRebaseFlag {
+		mode = mission.RepointModeRebase
+	}
+
+	resp, err := client.RepointMission(missionID, result.RepoName, mode)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to repoint mission")
+	}
+
+	fmt.Printf("Mission '%s' now works on %s\n", resp.ShortID, displayGitRepo(resp.Repo))
+	if resp.PreviousAgentDirpath != "" {
+		fmt.Printf("Previous workspace kept at %s\n", resp.PreviousAgentDirpath)
+	}
+	return nil
+}
@@ -18,11 +18,12 @@ var runWrapperFlag bool
 var resumePromptFlag string
 
 var missionResumeCmd = &cobra.Command{
-	Use:    resumeCmdStr + " [mission-id]",
-	Short:  "Internal: run the wrapper process directly",
-	Hidden: true,
-	Args:   cobra.ArbitraryArgs,
-	RunE:   runMissionResume,
+	Use:               resumeCmdStr + " [mission-id]",
+	Short:             "Internal: run the wrapper process directly",
+	Hidden:            true,
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionResume,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
@@ -70,6 +71,11 @@ func doRunWrapperDirect(missionID string, initialPrompt string) error {
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to resolve agenc directory")
 	}
+	// Pin the directory so the agenc CLI run by Claude inside this mission
+	// keeps talking to this mission's server after a profile switch
+	if err := os.Setenv(agencDirpathEnvVar, agencDirpath); err != nil {
+		return stacktrace.Propagate(err, "failed to set %s", agencDirpathEnvVar)
+	}
 
 	client, err := serverClient()
 	if err != nil {
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/mattn/go-isatty"
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
 
+	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/server"
 )
@@ -17,16 +21,40 @@ var missionRmCmd = &cobra.Command{
 	Long: `Stop and permanently remove one or more missions.
 
 Without arguments, opens an interactive fzf picker showing all missions.
-With arguments, accepts one or more mission IDs (short 8-char hex or full UUID).`,
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionRm,
+With arguments, accepts one or more mission IDs (short 8-char hex or full UUID).
+
+With --all, --repo, or --older-than, removes every matching mission at once
+instead; --repo and --older-than can be combined. --older-than counts from a
+mission's last user prompt (or its creation, if never prompted) and takes a
+number of days like "7d". Use --dry-run to list the matches first.
+Removing in bulk asks for confirmation unless --force is set.
+
+If a mission's auto-branch was pushed, cleanupRemoteOnDelete decides whether
+removing it also deletes the branch from origin and closes the branch's draft
+PR: "always", "never" (the default), or "ask", which prompts for each mission
+with a pushed branch. --cleanup-remote (or --cleanup-remote=false) overrides
+it. Branches whose PR is ready for review are always left alone.`,
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionRm,
+	ValidArgsFunction: completeMissionIDs,
 }
 
 func init() {
+	addMissionBatchFlags(missionRmCmd)
+	missionRmCmd.Flags().BoolP(forceFlagName, "f", false, "skip the confirmation prompt when removing in bulk")
+	missionRmCmd.Flags().Bool(cleanupRemoteFlagName, false, "delete the mission's pushed auto-branch and close its draft PR (overrides cleanupRemoteOnDelete)")
 	missionCmd.AddCommand(missionRmCmd)
 }
 
 func runMissionRm(cmd *cobra.Command, args []string) error {
+	filter, isBatch, err := missionBatchFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if isBatch {
+		return runMissionBatch(cmd, args, server.MissionBatchActionDelete, "Removed", filter, true)
+	}
+
 	client, err := serverClient()
 	if err != nil {
 		return err
@@ -36,7 +64,7 @@ func runMissionRm(cmd *cobra.Command, args []string) error {
 	// resolve and remove each one directly without going through the picker.
 	if len(args) > 1 && allLookLikeMissionIDs(args) {
 		for _, idArg := range args {
-			if err := client.DeleteMission(idArg); err != nil {
+			if err := removeMission(cmd, client, idArg); err != nil {
 				return stacktrace.Propagate(err, "failed to remove mission '%s'", idArg)
 			}
 			fmt.Printf("Removed mission: %s\n", idArg)
@@ -92,10 +120,79 @@ func runMissionRm(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, entry := range result.Items {
-		if err := client.DeleteMission(entry.MissionID); err != nil {
+		if err := removeMission(cmd, client, entry.MissionID); err != nil {
 			return stacktrace.Propagate(err, "failed to remove mission %s", entry.ShortID)
 		}
 		fmt.Printf("Removed mission: %s\n", database.ShortID(entry.MissionID))
 	}
 	return nil
 }
+
+// removeMission deletes one mission, first deciding whether to clean up its
+// pushed auto-branch, and reports what was cleaned up on origin.
+func removeMission(cmd *cobra.Command, client *server.Client, missionID string) error {
+	cleanupRemote, err := decideRemoteCleanup(cmd, client, missionID)
+	if err != nil {
+		return err
+	}
+	resp, err := client.DeleteMissionWithRemoteCleanup(missionID, cleanupRemote)
+	if err != nil {
+		return err
+	}
+
+	remoteCleanup := resp.RemoteCleanup
+	if remoteCleanup == nil || (remoteCleanup.Branch == "" && remoteCleanup.Error == "") {
+		return nil
+	}
+	switch {
+	case remoteCleanup.Error != "":
+		fmt.Printf("Warning: failed to clean up the mission's remote branch: %s\n", remoteCleanup.Error)
+	case remoteCleanup.SkipReason != "":
+		fmt.Printf("Left remote branch '%s' in place: %s\n", remoteCleanup.Branch, remoteCleanup.SkipReason)
+	case remoteCleanup.Cleaned:
+		if remoteCleanup.PRURL != "" {
+			fmt.Printf("Closed draft pull request: %s\n", remoteCleanup.PRURL)
+		}
+		fmt.Printf("Deleted remote branch: %s\n", remoteCleanup.Branch)
+	}
+	return nil
+}
+
+// decideRemoteCleanup returns whether removing the mission should clean up
+// its pushed auto-branch: --cleanup-remote when given, else
+// cleanupRemoteOnDelete. "ask" prompts only when the mission has a branch to
+// delete and stdin is a terminal; otherwise it counts as "never".
+func decideRemoteCleanup(cmd *cobra.Command, client *server.Client, missionID string) (bool, error) {
+	if cmd.Flags().Changed(cleanupRemoteFlagName) {
+		return cmd.Flags().GetBool(cleanupRemoteFlagName)
+	}
+
+	cfg, err := readConfig()
+	if err != nil {
+		return false, err
+	}
+	switch cfg.GetCleanupRemoteOnDelete() {
+	case config.CleanupRemoteAlways:
+		return true, nil
+	case config.CleanupRemoteAsk:
+		if !isatty.IsTerminal(os.Stdin.Fd()) {
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+
+	plan, err := client.GetMissionRemoteCleanup(missionID)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to check the mission's remote branch")
+	}
+	if plan.Branch == "" || plan.SkipReason != "" {
+		return false, nil
+	}
+
+	prompt := fmt.Sprintf("Mission %s pushed branch '%s'. Delete it from origin", database.ShortID(missionID), plan.Branch)
+	if plan.PRURL != "" {
+		prompt += " and close draft PR " + plan.PRURL
+	}
+	return promptYesNo(bufio.NewReader(os.Stdin), prompt+"? [y/N] ")
+}
@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
 
 	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
 )
 
 var searchJSONFlag bool
 var searchLimitFlag int
+var searchGrepFlag bool
 
 var missionSearchCmd = &cobra.Command{
 	Use:   searchCmdStr + " <query>",
@@ -24,7 +27,13 @@ Searches user messages, assistant responses, session titles, and mission prompts
 Results are ranked by relevance using BM25.
 
 The search index is populated automatically by the server. New content becomes
-searchable within ~30 seconds of being written.`,
+searchable within ~30 seconds of being written.
+
+With --grep, the session transcripts of every mission (including archived ones)
+are scanned directly for a literal, case-insensitive match instead. Every
+matching message is listed with its mission ID and timestamp, newest first.
+This is slower than the index but finds exact phrases and content written
+moments ago.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runMissionSearch,
 }
@@ -33,6 +42,7 @@ func init() {
 	missionCmd.AddCommand(missionSearchCmd)
 	missionSearchCmd.Flags().BoolVar(&searchJSONFlag, "json", false, "output results as JSON")
 	missionSearchCmd.Flags().IntVar(&searchLimitFlag, "limit", 20, "maximum number of results")
+	missionSearchCmd.Flags().BoolVar(&searchGrepFlag, "grep", false, "scan transcripts for a literal match and list every matching message with its timestamp")
 }
 
 func runMissionSearch(cmd *cobra.Command, args []string) error {
@@ -42,19 +52,21 @@ func runMissionSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	query := strings.Join(args, " ")
+	if searchGrepFlag {
+		return runMissionSearchGrep(client, query)
+	}
+
 	results, err := client.SearchMissions(query, searchLimitFlag)
 	if err != nil {
 		return stacktrace.Propagate(err, "search failed")
 	}
 
-	if searchJSONFlag {
+	if searchJSONFlag || isStructuredOutput() {
 		// Strip binary markers from snippets for JSON output
 		for i := range results {
 			results[i].Snippet = database.StripSnippetMarkers(results[i].Snippet)
 		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(results)
+		return printSearchResults(results)
 	}
 
 	if len(results) == 0 {
@@ -85,3 +97,47 @@ func runMissionSearch(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runMissionSearchGrep lists every transcript message matching query, newest
+// first, with the mission and timestamp it came from.
+func runMissionSearchGrep(client *server.Client, query string) error {
+	matches, err := client.SearchTranscripts(query, searchLimitFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "transcript search failed")
+	}
+
+	if searchJSONFlag || isStructuredOutput() {
+		for i := range matches {
+			matches[i].Snippet = database.StripSnippetMarkers(matches[i].Snippet)
+		}
+		return printSearchResults(matches)
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No results.")
+		return nil
+	}
+
+	for _, m := range matches {
+		when := "--"
+		if m.Timestamp != nil {
+			if t, err := time.Parse(time.RFC3339, *m.Timestamp); err == nil {
+				when = t.Local().Format("2006-01-02 15:04")
+			}
+		}
+		fmt.Printf("%s  %s  %s\n", m.ShortID, when, m.Role)
+		fmt.Printf("  %s\n\n", database.ColorizeSnippet(m.Snippet))
+	}
+	return nil
+}
+
+// printSearchResults writes search results for --json (always JSON) or the
+// global --output flag.
+func printSearchResults(v any) error {
+	if isStructuredOutput() {
+		return printStructured(v)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -33,9 +33,19 @@ func init() {
 // scannable even when many missions match.
 const substringMergeCap = 30
 
+// missionSearchFzfHeaders are the visible columns of the mission picker and
+// its search-fzf reloads.
+var missionSearchFzfHeaders = []string{"ID", "●", "STATUS", "LAST PROMPT", "TITLE", "REPO", "SUMMARY", "MATCH"}
+
+// Column widths for the free-text picker columns.
+const (
+	searchFzfTitleMaxLen   = 30
+	searchFzfSummaryMaxLen = 40
+)
+
 // searchFzfRow is one row of the mission search-fzf output: the leading
 // short-ID is consumed by fzf as the result index; cols are the visible
-// table columns (LAST PROMPT, ID, SESSION, REPO, MATCH).
+// table columns (missionSearchFzfHeaders).
 type searchFzfRow struct {
 	shortID string
 	cols    []string
@@ -55,6 +65,14 @@ func runMissionSearchFzf(cmd *cobra.Command, args []string) error {
 
 	cfg, _ := readConfig()
 
+	// Listing errors are tolerated: search results are still shown, just
+	// without the status and summary that only the mission list carries
+	allMissions, _ := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true})
+	missionsByID := make(map[string]*database.Mission, len(allMissions))
+	for _, m := range allMissions {
+		missionsByID[m.ID] = m
+	}
+
 	var rows []searchFzfRow
 	seenMissionIDs := make(map[string]bool)
 
@@ -63,13 +81,7 @@ func runMissionSearchFzf(cmd *cobra.Command, args []string) error {
 	// picker would return no results when searching by ID.
 	if looksLikeMissionID(query) {
 		if m, resolveErr := client.GetMission(query); resolveErr == nil {
-			session := resolveSessionName(m)
-			repo := formatRepoDisplay(m.GitRepo, m.IsAdjutant, cfg)
-			lastPrompt := formatLastPrompt(m.LastUserPromptAt, m.CreatedAt)
-			rows = append(rows, searchFzfRow{
-				shortID: m.ShortID,
-				cols:    []string{m.ShortID, attachedDot(m.IsAttached), lastPrompt, session, repo, ""},
-			})
+			rows = append(rows, missionSearchFzfRow(m, cfg, ""))
 			seenMissionIDs[m.ID] = true
 		}
 	}
@@ -86,6 +98,14 @@ func runMissionSearchFzf(cmd *cobra.Command, args []string) error {
 		}
 		seenMissionIDs[r.MissionID] = true
 
+		snippet := strings.ReplaceAll(r.Snippet, "\n", " ")
+		snippet = database.ColorizeSnippet(snippet)
+
+		if m, ok := missionsByID[r.MissionID]; ok {
+			rows = append(rows, missionSearchFzfRow(m, cfg, snippet))
+			continue
+		}
+
 		shortID := r.ShortID
 		if shortID == "" && len(r.MissionID) >= 8 {
 			shortID = r.MissionID[:8]
@@ -93,74 +113,96 @@ func runMissionSearchFzf(cmd *cobra.Command, args []string) error {
 
 		session := r.ResolvedSessionTitle
 		if session == "" {
-			session = truncatePrompt(r.Prompt, 30)
+			session = truncatePrompt(r.Prompt, searchFzfTitleMaxLen)
 		} else {
-			session = truncatePrompt(session, 30)
+			session = truncatePrompt(session, searchFzfTitleMaxLen)
 		}
 
 		repo := formatRepoDisplay(r.GitRepo, false, cfg)
 
-		snippet := strings.ReplaceAll(r.Snippet, "\n", " ")
-		snippet = database.ColorizeSnippet(snippet)
-
 		lastPrompt := formatLastPromptFromStrings(r.LastUserPromptAt, r.CreatedAt)
 
 		rows = append(rows, searchFzfRow{
 			shortID: shortID,
-			cols:    []string{shortID, attachedDot(r.IsAttached), lastPrompt, session, repo, snippet},
+			cols:    []string{shortID, attachedDot(r.IsAttached), "--", lastPrompt, session, repo, "", snippet},
 		})
 	}
 
-	// Merge: case-insensitive substring matches over ListMissions for
+	// Merge: case-insensitive substring matches over the mission list for
 	// missions not seen via FTS. This recovers unprompted missions and
 	// any whose ResolvedSessionTitle/repo aren't in the FTS index.
-	rows = appendSubstringMatches(client, cfg, rows, query, seenMissionIDs)
+	rows = appendSubstringMatches(allMissions, cfg, rows, query, seenMissionIDs)
+
+	fmt.Print(formatSearchFzfInput(rows))
+	return nil
+}
+
+// missionSearchFzfRow builds a picker row for a mission: its attachment,
+// idle/busy status, last prompt time, window title, repo, AI summary, and the
+// search match snippet, if any.
+func missionSearchFzfRow(m *database.Mission, cfg *config.AgencConfig, match string) searchFzfRow {
+	summary := ""
+	if m.AISummary != "" {
+		summary = truncatePrompt(m.AISummary, searchFzfSummaryMaxLen)
+	}
+	return searchFzfRow{
+		shortID: m.ShortID,
+		cols: []string{
+			m.ShortID,
+			attachedDot(m.IsAttached),
+			colorizeStatus(getMissionStatus(m.ID, m.Status, m.ClaudeState)),
+			formatLastPrompt(m.LastUserPromptAt, m.CreatedAt),
+			truncatePrompt(resolveSessionName(m), searchFzfTitleMaxLen),
+			formatRepoDisplay(m.GitRepo, m.IsAdjutant, cfg),
+			summary,
+			match,
+		},
+	}
+}
 
+// formatSearchFzfInput renders rows through tableprinter for alignment and
+// prefixes each line with its mission short ID, the hidden column fzf
+// returns on selection. The header line is dropped; the picker shows its own.
+func formatSearchFzfInput(rows []searchFzfRow) string {
 	if len(rows) == 0 {
-		return nil
+		return ""
 	}
 
-	// Render through tableprinter for alignment
 	var buf strings.Builder
-	tbl := tableprinter.NewTable("ID", "●", "LAST PROMPT", "SESSION", "REPO", "MATCH").WithWriter(&buf)
+	tbl := tableprinter.NewTable(toAnySlice(missionSearchFzfHeaders)...).WithWriter(&buf)
 	for _, r := range rows {
 		tbl.AddRow(toAnySlice(r.cols)...)
 	}
 	tbl.Print()
 
-	// Output: header line (skip) + data lines prefixed with mission short ID
+	var input strings.Builder
 	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
-	// First line is the header — fzf will use it if --header-lines is set,
-	// but since the caller already has headers, skip it
 	for i, line := range lines {
 		if i == 0 {
 			continue // skip header
 		}
 		idx := i - 1
 		if idx < len(rows) {
-			fmt.Printf("%s\t%s\n", rows[idx].shortID, line)
+			input.WriteString(rows[idx].shortID)
+			input.WriteString("\t")
+			input.WriteString(line)
+			input.WriteString("\n")
 		}
 	}
-
-	return nil
+	return input.String()
 }
 
-// appendSubstringMatches walks ListMissions and appends rows for missions
+// appendSubstringMatches walks allMissions and appends rows for missions
 // not already seen via FTS that match the query as a case-insensitive
 // substring of ResolvedSessionTitle, Prompt, or GitRepo. Capped at
-// substringMergeCap. ListMissions errors are tolerated silently — FTS
-// results alone are returned.
+// substringMergeCap.
 func appendSubstringMatches(
-	client *server.Client,
+	allMissions []*database.Mission,
 	cfg *config.AgencConfig,
 	rows []searchFzfRow,
 	query string,
 	seenMissionIDs map[string]bool,
 ) []searchFzfRow {
-	allMissions, listErr := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true})
-	if listErr != nil {
-		return rows
-	}
 	lowerQuery := strings.ToLower(query)
 	appended := 0
 	for _, m := range allMissions {
@@ -175,14 +217,7 @@ func appendSubstringMatches(
 		}
 		seenMissionIDs[m.ID] = true
 		appended++
-
-		session := truncatePrompt(resolveSessionName(m), 30)
-		repo := formatRepoDisplay(m.GitRepo, m.IsAdjutant, cfg)
-		lastPrompt := formatLastPrompt(m.LastUserPromptAt, m.CreatedAt)
-		rows = append(rows, searchFzfRow{
-			shortID: m.ShortID,
-			cols:    []string{m.ShortID, attachedDot(m.IsAttached), lastPrompt, session, repo, ""},
-		})
+		rows = append(rows, missionSearchFzfRow(m, cfg, ""))
 	}
 	return rows
 }
@@ -224,31 +259,29 @@ func printRecentMissionsForFzf() error {
 		return err
 	}
 
-	missions, err := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true})
+	rows, err := recentMissionSearchFzfRows(client)
 	if err != nil {
 		return err
 	}
+	fmt.Print(formatSearchFzfInput(rows))
+	return nil
+}
 
-	sortMissionsForPicker(missions)
-	entries := buildMissionPickerEntries(missions, 30)
-
-	var buf strings.Builder
-	tbl := tableprinter.NewTable("ID", "●", "LAST PROMPT", "SESSION", "REPO", "MATCH").WithWriter(&buf)
-	for _, e := range entries {
-		tbl.AddRow(e.ShortID, attachedDot(e.IsAttached), e.LastPrompt, e.Session, e.Repo, "")
+// recentMissionSearchFzfRows returns picker rows for the 30 most relevant
+// missions (see sortMissionsForPicker), shown before anything is typed.
+func recentMissionSearchFzfRows(client *server.Client) ([]searchFzfRow, error) {
+	missions, err := client.ListMissions(server.ListMissionsRequest{IncludeArchived: true})
+	if err != nil {
+		return nil, err
 	}
-	tbl.Print()
 
-	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
-	for i, line := range lines {
-		if i == 0 {
-			continue
-		}
-		idx := i - 1
-		if idx < len(entries) {
-			fmt.Printf("%s\t%s\n", entries[idx].ShortID, line)
-		}
-	}
+	sortMissionsForPicker(missions)
+	missions = missions[:min(len(missions), 30)]
 
-	return nil
+	cfg, _ := readConfig()
+	rows := make([]searchFzfRow, 0, len(missions))
+	for _, m := range missions {
+		rows = append(rows, missionSearchFzfRow(m, cfg, ""))
+	}
+	return rows, nil
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/odyssey/agenc/internal/database"
@@ -44,3 +45,56 @@ func TestMatchMissionSubstring_EmptyFieldsDoNotFalseMatch(t *testing.T) {
 		t.Fatal("empty fields should not match a non-empty query")
 	}
 }
+
+func TestMissionSearchFzfRow(t *testing.T) {
+	state := "busy"
+	m := &database.Mission{
+		ID:          "2b4c8f1a-0000-0000-0000-000000000000",
+		ShortID:     "2b4c8f1a",
+		Status:      "active",
+		ClaudeState: &state,
+		Prompt:      "fix the login flow",
+		AISummary:   "Fixing the OAuth redirect in the login flow",
+	}
+
+	row := missionSearchFzfRow(m, nil, "match")
+
+	if row.shortID != "2b4c8f1a" {
+		t.Errorf("expected short ID index, got %q", row.shortID)
+	}
+	if len(row.cols) != len(missionSearchFzfHeaders) {
+		t.Fatalf("expected %d columns, got %d", len(missionSearchFzfHeaders), len(row.cols))
+	}
+	if row.cols[2] != colorizeStatus(StatusBusy) {
+		t.Errorf("expected busy status, got %q", row.cols[2])
+	}
+	if row.cols[4] != "fix the login flow" {
+		t.Errorf("expected title from prompt, got %q", row.cols[4])
+	}
+	if row.cols[6] != "Fixing the OAuth redirect in the login f…" {
+		t.Errorf("expected truncated summary, got %q", row.cols[6])
+	}
+	if row.cols[7] != "match" {
+		t.Errorf("expected match snippet, got %q", row.cols[7])
+	}
+}
+
+func TestFormatSearchFzfInput(t *testing.T) {
+	if got := formatSearchFzfInput(nil); got != "" {
+		t.Errorf("expected no input for no rows, got %q", got)
+	}
+
+	rows := []searchFzfRow{
+		{shortID: "aaaaaaaa", cols: []string{"aaaaaaaa", "", "IDLE", "1h ago", "one", "repo", "", ""}},
+		{shortID: "bbbbbbbb", cols: []string{"bbbbbbbb", "", "BUSY", "2h ago", "two", "repo", "", ""}},
+	}
+	lines := strings.Split(strings.TrimSuffix(formatSearchFzfInput(rows), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per row without the header, got %q", lines)
+	}
+	for i, shortID := range []string{"aaaaaaaa", "bbbbbbbb"} {
+		if !strings.HasPrefix(lines[i], shortID+"\t"+shortID) {
+			t.Errorf("expected line %d to start with its index column, got %q", i, lines[i])
+		}
+	}
+}
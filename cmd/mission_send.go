@@ -1,15 +1,85 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
 )
 
 var missionSendCmd = &cobra.Command{
-	Use:    sendCmdStr,
-	Short:  "Send messages to a running mission wrapper",
-	Hidden: true,
+	Use:   sendCmdStr + " <mission-id> [message...]",
+	Short: "Send a prompt to a running mission's Claude",
+	Long: `Send a prompt to a running mission's Claude, as if typed into its window.
+
+The message is pasted into the mission's tmux pane in one piece, so multi-line
+messages arrive intact, and then submitted. If Claude is busy, it queues the
+message like any prompt typed while it works. The mission must be running;
+start it first with 'agenc mission attach'.
+
+The message is the remaining arguments joined with spaces, or stdin when no
+message arguments are given.
+
+Examples:
+  agenc mission send abc123 "also update the changelog"
+  git diff | agenc mission send abc123
+  agenc mission send abc123 < review-notes.md
+
+Unlike 'agenc mission send-keys', the text is never interpreted as tmux key
+names, and Enter is pressed for you.`,
+	Args:              cobra.MinimumNArgs(1),
+	RunE:              runMissionSend,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
 	missionCmd.AddCommand(missionSendCmd)
 }
+
+func runMissionSend(cmd *cobra.Command, args []string) error {
+	message := strings.Join(args[1:], " ")
+	if message == "" && !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		const maxStdinBytes = 1 << 20 // 1 MiB
+		data, err := io.ReadAll(io.LimitReader(os.Stdin, maxStdinBytes+1))
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read stdin")
+		}
+		if len(data) > maxStdinBytes {
+			return stacktrace.NewError("stdin exceeds maximum size of %d bytes", maxStdinBytes)
+		}
+		message = string(data)
+	}
+	if strings.TrimSpace(message) == "" {
+		return stacktrace.NewError(
+			"no message provided — pass it as arguments or pipe it via stdin\n\n"+
+				"Examples:\n"+
+				"  %s %s %s abc123 \"also update the changelog\"\n"+
+				"  git diff | %s %s %s abc123",
+			agencCmdStr, missionCmdStr, sendCmdStr,
+			agencCmdStr, missionCmdStr, sendCmdStr,
+		)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	missionID, err := client.ResolveMissionID(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve mission ID")
+	}
+
+	if err := client.SendMissionMessage(missionID, message); err != nil {
+		return stacktrace.Propagate(err, "failed to send message to mission %s", database.ShortID(missionID))
+	}
+
+	fmt.Printf("Sent message to mission %s\n", database.ShortID(missionID))
+	return nil
+}
@@ -20,12 +20,17 @@ const (
 var missionSendClaudeUpdateCmd = &cobra.Command{
 	Use:   claudeUpdateCmdStr + " <mission-uuid> <event>",
 	Short: "Send a Claude hook event to the mission wrapper",
+	// Internal: invoked by the Claude hooks AgenC installs, not by users
+	Hidden: true,
 	Long: `Send a Claude hook event to the mission wrapper via its unix socket.
 
 This command is called by Claude Code hooks (Stop, UserPromptSubmit, Notification,
 PostToolUse, PostToolUseFailure) to report state changes. For Notification events,
-hook JSON is read from stdin (with a timeout) to extract notification_type. All
-other events skip stdin entirely to avoid blocking when Claude Code doesn't close it.
+hook JSON is read from stdin (with a timeout) to extract notification_type; for
+PostToolUse and PostToolUseFailure, to extract tool_name for the mission timeline;
+for UserPromptSubmit, to extract the prompt for the mission's prompt history.
+All other events skip stdin entirely to avoid blocking when Claude Code doesn't
+close it.
 
 Always exits 0, even on failure, to avoid blocking Claude.`,
 	Args:               cobra.ExactArgs(2),
@@ -43,13 +48,15 @@ func runMissionSendClaudeUpdate(cmd *cobra.Command, args []string) error {
 	missionID := args[0]
 	event := args[1]
 
-	// Only read stdin for Notification events (to extract notification_type).
-	// Other events (Stop, UserPromptSubmit, PostToolUse, PostToolUseFailure)
-	// don't pass useful data via stdin, and Claude Code may not close stdin
-	// for them — causing io.ReadAll to block indefinitely.
-	var notificationType string
-	if event == "Notification" {
-		notificationType = extractNotificationType(os.Stdin)
+	// Only read stdin for events whose payload we use: notification_type for
+	// Notification, tool_name for PostToolUse/PostToolUseFailure, prompt for
+	// UserPromptSubmit. Stop doesn't pass useful data via stdin, and Claude
+	// Code may not close stdin for it — causing io.ReadAll to block
+	// indefinitely.
+	var payload hookPayload
+	switch event {
+	case "Notification", "PostToolUse", "PostToolUseFailure", "UserPromptSubmit":
+		payload = readHookPayload(os.Stdin)
 	}
 
 	agencDirpath, err := config.GetAgencDirpath()
@@ -62,7 +69,12 @@ func runMissionSendClaudeUpdate(cmd *cobra.Command, args []string) error {
 
 	// Use a short timeout to avoid blocking Claude if the wrapper is unresponsive
 	client := wrapper.NewWrapperClient(socketFilepath, claudeUpdateClientTimeout)
-	if err := client.SendClaudeUpdate(event, notificationType); err != nil {
+	if err := client.SendClaudeUpdate(wrapper.ClaudeUpdateRequest{
+		Event:            event,
+		NotificationType: payload.NotificationType,
+		ToolName:         payload.ToolName,
+		Prompt:           payload.Prompt,
+	}); err != nil {
 		// Silently fail — never block Claude
 		return nil
 	}
@@ -70,14 +82,21 @@ func runMissionSendClaudeUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// extractNotificationType reads stdin with a short timeout and extracts the
-// notification_type field from the hook JSON payload. Returns empty string if
-// stdin is empty, the field is not present, or the read times out.
+// hookPayload holds the fields agenc uses from a Claude hook's JSON payload.
+type hookPayload struct {
+	NotificationType string `json:"notification_type"`
+	ToolName         string `json:"tool_name"`
+	Prompt           string `json:"prompt"`
+}
+
+// readHookPayload reads stdin with a short timeout and decodes the hook JSON
+// payload. Returns the zero payload if stdin is empty, isn't valid JSON, or
+// the read times out.
 //
 // The timeout prevents blocking if Claude Code doesn't close stdin. This is a
 // CLI process that exits immediately after, so a leaked goroutine on timeout
 // is acceptable.
-func extractNotificationType(reader io.Reader) string {
+func readHookPayload(reader io.Reader) hookPayload {
 	type readResult struct {
 		data []byte
 		err  error
@@ -92,22 +111,20 @@ func extractNotificationType(reader io.Reader) string {
 	select {
 	case res := <-ch:
 		if res.err != nil {
-			return ""
+			return hookPayload{}
 		}
 		data = res.data
 	case <-time.After(stdinReadTimeout):
-		return ""
+		return hookPayload{}
 	}
 
 	if len(data) == 0 {
-		return ""
+		return hookPayload{}
 	}
 
-	var payload struct {
-		NotificationType string `json:"notification_type"`
-	}
+	var payload hookPayload
 	if err := json.Unmarshal(data, &payload); err != nil {
-		return ""
+		return hookPayload{}
 	}
-	return payload.NotificationType
+	return payload
 }
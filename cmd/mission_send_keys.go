@@ -26,8 +26,9 @@ Examples:
   agenc mission send-keys abc123 C-c
   echo "fix the bug" | agenc mission send-keys abc123
   echo "fix the bug" | agenc mission send-keys abc123 Enter`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runMissionSendKeys,
+	Args:              cobra.MinimumNArgs(1),
+	RunE:              runMissionSendKeys,
+	ValidArgsFunction: completeMissionID,
 }
 
 func init() {
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var shareRemoveFlag bool
+
+var missionShareCmd = &cobra.Command{
+	Use:   shareCmdStr + " <mission-id> [user]",
+	Short: "Share a mission with another user in multi-user mode",
+	Long: fmt.Sprintf(`Share a mission with another user in multi-user mode.
+
+In multi-user mode (multiUser.tmuxSocket in config.yml), each mission belongs
+to the user who created it, and the server only lets that user, the users it
+is shared with, and the user running the server attach to it, read its
+output, send it keys, or stop, reload, archive, or delete it. Sharing lets
+another user on the host pair on the mission from their own terminal. Only
+the owner can change sharing.
+
+Ownership is not a security boundary: every user with access to the shared
+tmux socket can reach any mission window through tmux directly.
+
+With no user, prints the mission's owner and who it is shared with.
+
+Examples:
+  %s %s %s 2b4c8f1a bob             # let bob attach to the mission
+  %s %s %s 2b4c8f1a bob --%s    # revoke bob's access
+  %s %s %s 2b4c8f1a                 # show owner and sharing`,
+		agencCmdStr, missionCmdStr, shareCmdStr,
+		agencCmdStr, missionCmdStr, shareCmdStr, removeFlagName,
+		agencCmdStr, missionCmdStr, shareCmdStr,
+	),
+	Args:              cobra.RangeArgs(1, 2),
+	RunE:              runMissionShare,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionShareCmd.Flags().BoolVar(&shareRemoveFlag, removeFlagName, false, "revoke the user's access instead of granting it")
+	missionCmd.AddCommand(missionShareCmd)
+}
+
+func runMissionShare(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	mission, err := client.GetMission(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get mission %s", args[0])
+	}
+
+	if len(args) == 1 {
+		printMissionSharing(mission.ShortID, mission.Owner, mission.SharedWith)
+		return nil
+	}
+
+	resp, err := client.ShareMission(mission.ID, args[1], shareRemoveFlag)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to update sharing for mission %s", mission.ShortID)
+	}
+
+	printMissionSharing(resp.ShortID, resp.Owner, resp.SharedWith)
+	return nil
+}
+
+func printMissionSharing(shortID string, owner string, sharedWith []string) {
+	if owner == "" {
+		fmt.Printf("Mission '%s' has no owner; every user can access it\n", shortID)
+		return
+	}
+	if len(sharedWith) == 0 {
+		fmt.Printf("Mission '%s' belongs to %s and is not shared\n", shortID, owner)
+		return
+	}
+	fmt.Printf("Mission '%s' belongs to %s and is shared with %s\n", shortID, owner, strings.Join(sharedWith, ", "))
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var missionStatsCmd = &cobra.Command{
+	Use:   statsCmdStr + " [mission-id]",
+	Short: "Show token usage, wall-clock time, and restarts for missions",
+	Long: `Show resource usage accounting for missions.
+
+With a mission ID, prints the full breakdown for that mission. Without one,
+lists every mission that has reported usage (including archived missions),
+ordered by total tokens, followed by per-cron-job totals so you can see which
+cron jobs consume the most quota.
+
+Token counts are derived from the mission's Claude session transcripts and
+include cache reads and cache writes. Wall-clock time is how long the mission
+wrapper has been running. Restarts count Claude spawns beyond the first
+(reloads, rebuilds, and resumes).
+
+Examples:
+  agenc mission stats
+  agenc mission stats abc12345`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runMissionStats,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionCmd.AddCommand(missionStatsCmd)
+}
+
+func runMissionStats(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		stats, err := client.GetMissionStats(args[0])
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to get stats for mission %s", args[0])
+		}
+		if isStructuredOutput() {
+			return printStructured(stats)
+		}
+		printMissionStatsDetail(stats)
+		return nil
+	}
+
+	allStats, err := client.ListMissionStats()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list mission stats")
+	}
+	if isStructuredOutput() {
+		return printStructured(allStats)
+	}
+	if len(allStats) == 0 {
+		fmt.Println("No mission usage recorded yet.")
+		return nil
+	}
+
+	cfg, _ := readConfig()
+	tbl := tableprinter.NewTable("ID", "SOURCE", "REPO", "TOKENS", "WALL CLOCK", "RESTARTS")
+	for _, s := range allStats {
+		tbl.AddRow(
+			s.ShortID,
+			formatStatsSource(s),
+			formatRepoDisplay(s.GitRepo, false, cfg),
+			formatTokenCount(s.TotalTokens),
+			formatMissionDuration(time.Duration(s.WallClockSeconds)*time.Second),
+			s.Restarts,
+		)
+	}
+	tbl.Print()
+
+	cronTotals := aggregateStatsByCron(allStats)
+	if len(cronTotals) > 0 {
+		fmt.Println()
+		cronTbl := tableprinter.NewTable("CRON", "RUNS", "TOKENS", "WALL CLOCK")
+		for _, c := range cronTotals {
+			cronTbl.AddRow(
+				c.name,
+				c.runs,
+				formatTokenCount(c.tokens),
+				formatMissionDuration(time.Duration(c.wallClockSeconds)*time.Second),
+			)
+		}
+		cronTbl.Print()
+	}
+	return nil
+}
+
+func printMissionStatsDetail(s *server.MissionStatsResponse) {
+	fmt.Printf("ID:             %s\n", s.ShortID)
+	fmt.Printf("Source:         %s\n", formatStatsSource(*s))
+	fmt.Printf("Input tokens:   %s\n", formatTokenCount(s.InputTokens))
+	fmt.Printf("Output tokens:  %s\n", formatTokenCount(s.OutputTokens))
+	fmt.Printf("Cache reads:    %s\n", formatTokenCount(s.CacheReadTokens))
+	fmt.Printf("Cache writes:   %s\n", formatTokenCount(s.CacheCreationTokens))
+	fmt.Printf("Total tokens:   %s\n", formatTokenCount(s.TotalTokens))
+	fmt.Printf("Wall clock:     %s\n", formatMissionDuration(time.Duration(s.WallClockSeconds)*time.Second))
+	fmt.Printf("Claude starts:  %d (%d restarts)\n", s.ClaudeStarts, s.Restarts)
+}
+
+// formatStatsSource describes where a mission came from: the cron job name
+// for cron-spawned missions, otherwise the raw source or "manual".
+func formatStatsSource(s server.MissionStatsResponse) string {
+	if s.CronName != "" {
+		return "cron:" + s.CronName
+	}
+	if s.Source != nil && *s.Source != "" {
+		return *s.Source
+	}
+	return "manual"
+}
+
+// formatTokenCount renders a token count compactly (e.g. 950, 12.3K, 4.1M).
+func formatTokenCount(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fK", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+type cronStatsTotal struct {
+	name             string
+	runs             int
+	tokens           int64
+	wallClockSeconds int64
+}
+
+// aggregateStatsByCron sums usage across missions spawned by the same cron
+// job, ordered by total tokens descending.
+func aggregateStatsByCron(allStats []server.MissionStatsResponse) []cronStatsTotal {
+	byName := make(map[string]*cronStatsTotal)
+	for _, s := range allStats {
+		if s.CronName == "" {
+			continue
+		}
+		total, ok := byName[s.CronName]
+		if !ok {
+			total = &cronStatsTotal{name: s.CronName}
+			byName[s.CronName] = total
+		}
+		total.runs++
+		total.tokens += s.TotalTokens
+		total.wallClockSeconds += s.WallClockSeconds
+	}
+
+	result := make([]cronStatsTotal, 0, len(byName))
+	for _, total := range byName {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].tokens != result[j].tokens {
+			return result[i].tokens > result[j].tokens
+		}
+		return result[i].name < result[j].name
+	})
+	return result
+}
@@ -17,16 +17,31 @@ var missionStopCmd = &cobra.Command{
 	Long: `Stop one or more mission wrapper processes.
 
 Without arguments, opens an interactive fzf picker showing running missions.
-With arguments, accepts a mission ID (short 8-char hex or full UUID).`,
-	Args: cobra.ArbitraryArgs,
-	RunE: runMissionStop,
+With arguments, accepts a mission ID (short 8-char hex or full UUID).
+
+With --all, --repo, or --older-than, stops every matching running mission at
+once instead; --repo and --older-than can be combined. --older-than counts from a
+mission's last user prompt (or its creation, if never prompted) and takes a
+number of days like "7d". Use --dry-run to list the matches first.`,
+	Args:              cobra.ArbitraryArgs,
+	RunE:              runMissionStop,
+	ValidArgsFunction: completeMissionIDs,
 }
 
 func init() {
+	addMissionBatchFlags(missionStopCmd)
 	missionCmd.AddCommand(missionStopCmd)
 }
 
 func runMissionStop(cmd *cobra.Command, args []string) error {
+	filter, isBatch, err := missionBatchFilterFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if isBatch {
+		return runMissionBatch(cmd, args, server.MissionBatchActionStop, "Stopped", filter, false)
+	}
+
 	client, err := serverClient()
 	if err != nil {
 		return err
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var summarizeNowFlag bool
+
+var missionSummarizeCmd = &cobra.Command{
+	Use:   summarizeCmdStr + " <mission-id>",
+	Short: "Show or regenerate a mission's AI summary",
+	Long: fmt.Sprintf(`Show a mission's AI summary: one sentence on what the mission is working on
+and where it stands, generated from its recent conversation.
+
+The server keeps summaries up to date according to missionSummary in
+config.yml: every N prompts (the default), whenever Claude goes idle, or
+never. With --%s, a fresh summary is generated immediately regardless of that
+setting. Summaries also appear in '%s %s ls' and the dashboard.
+
+Examples:
+  %s %s %s 2b4c8f1a          # print the stored summary
+  %s %s %s 2b4c8f1a --%s    # regenerate it now`,
+		nowFlagName,
+		agencCmdStr, missionCmdStr,
+		agencCmdStr, missionCmdStr, summarizeCmdStr,
+		agencCmdStr, missionCmdStr, summarizeCmdStr, nowFlagName,
+	),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionSummarize,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionSummarizeCmd.Flags().BoolVar(&summarizeNowFlag, nowFlagName, false, "generate a fresh summary now")
+	missionCmd.AddCommand(missionSummarizeCmd)
+}
+
+func runMissionSummarize(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	mission, err := client.GetMission(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get mission %s", args[0])
+	}
+
+	if summarizeNowFlag {
+		mission, err = client.SummarizeMission(mission.ID)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to summarize mission %s", args[0])
+		}
+	}
+
+	if mission.AISummary == "" {
+		fmt.Printf("Mission %s has no summary yet; run with --%s to generate one\n", mission.ShortID, nowFlagName)
+		return nil
+	}
+	fmt.Println(mission.AISummary)
+	return nil
+}
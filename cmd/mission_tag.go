@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var tagRemoveFlag bool
+
+var missionTagCmd = &cobra.Command{
+	Use:   tagCmdStr + " <mission-id> [tag...]",
+	Short: "Add or remove tags on a mission",
+	Long: `Add or remove tags on a mission.
+
+Tags are free-form labels (letters, digits, '.', '_', '/', ':', '-') used to
+slice missions by project or purpose. With no tags, prints the mission's
+current tags. Filter by tag with 'agenc mission ls --tag <tag>'.
+
+Examples:
+  agenc mission tag abc12345 billing urgent      # add two tags
+  agenc mission tag abc12345 --remove urgent     # remove a tag
+  agenc mission tag abc12345                     # show current tags`,
+	Args:              cobra.MinimumNArgs(1),
+	RunE:              runMissionTag,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionTagCmd.Flags().BoolVar(&tagRemoveFlag, removeFlagName, false, "remove the given tags instead of adding them")
+	missionCmd.AddCommand(missionTagCmd)
+}
+
+func runMissionTag(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	mission, err := client.GetMission(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get mission %s", args[0])
+	}
+
+	requested := args[1:]
+	if len(requested) == 0 {
+		printMissionTags(mission.ShortID, mission.Tags)
+		return nil
+	}
+	for _, tag := range requested {
+		if err := database.ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
+	tags := applyTagChanges(mission.Tags, requested, tagRemoveFlag)
+	if err := client.UpdateMission(mission.ID, server.UpdateMissionRequest{Tags: &tags}); err != nil {
+		return stacktrace.Propagate(err, "failed to update tags for mission %s", mission.ShortID)
+	}
+
+	printMissionTags(mission.ShortID, tags)
+	return nil
+}
+
+// applyTagChanges returns the sorted tag set that results from adding (or,
+// when remove is true, removing) the requested tags from current.
+func applyTagChanges(current []string, requested []string, remove bool) []string {
+	set := make(map[string]bool, len(current)+len(requested))
+	for _, tag := range current {
+		set[tag] = true
+	}
+	for _, tag := range requested {
+		if remove {
+			delete(set, tag)
+		} else {
+			set[tag] = true
+		}
+	}
+
+	result := make([]string, 0, len(set))
+	for tag := range set {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func printMissionTags(shortID string, tags []string) {
+	if len(tags) == 0 {
+		fmt.Printf("Mission %s has no tags.\n", shortID)
+		return
+	}
+	fmt.Printf("Mission %s tags: %s\n", shortID, strings.Join(tags, ", "))
+}
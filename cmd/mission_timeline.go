@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var timelineSinceFlag string
+var timelineLimitFlag int
+
+var missionTimelineCmd = &cobra.Command{
+	Use:   timelineCmdStr + " <mission-id>",
+	Short: "Show what happened in a mission, event by event",
+	Long: fmt.Sprintf(`Show a mission's activity timeline, oldest first.
+
+Unlike 'agenc events', which only remembers recent events since the server
+started, the timeline is stored in the database for the life of the mission,
+so you can look back at what an agent did overnight:
+
+  %-21s you (or a cron, or another agent) sent Claude a prompt
+  %-21s Claude ran a tool (failed=true when the tool failed)
+  %-21s a mission's Claude finished responding and is waiting for input
+  %-21s the mission was reloaded, or restarted after a crash
+  %-21s the wrapper crashed
+  %-21s the running wrapper stopped answering health pings
+  %-21s Claude exited, with its exit code
+  %-21s the mission was archived
+  %-21s the mission pushed to the repo's default branch
+  %-21s the mission refreshed the shared Claude credentials
+
+Tool names are not recorded for devcontainer missions.
+
+Examples:
+  agenc mission timeline abc12345
+  agenc mission timeline abc12345 --since 2026-06-01T22:00:00+02:00
+  agenc mission timeline abc12345 --limit 0 -o json`,
+		server.EventPromptSubmitted, server.EventToolRun, server.EventMissionIdle,
+		server.EventMissionRestarted, server.EventMissionCrashed, server.EventMissionUnresponsive, server.EventMissionExited,
+		server.EventMissionArchived, server.EventRefUpdated, server.EventCredentialRefreshed),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionTimeline,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionTimelineCmd.Flags().StringVar(&timelineSinceFlag, timelineSinceFlagName, "", "only show events on or after this time (YYYY-MM-DD or RFC3339)")
+	missionTimelineCmd.Flags().IntVar(&timelineLimitFlag, timelineLimitFlagName, 200, "show only the most recent N events (0 for all)")
+	missionCmd.AddCommand(missionTimelineCmd)
+}
+
+func runMissionTimeline(cmd *cobra.Command, args []string) error {
+	if timelineLimitFlag < 0 {
+		return stacktrace.NewError("--%s must be zero or greater", timelineLimitFlagName)
+	}
+	var since time.Time
+	if timelineSinceFlag != "" {
+		var err error
+		if since, err = parseTimeFlag(timelineSinceFlag, true); err != nil {
+			return stacktrace.NewError("invalid --%s value %q: %s", timelineSinceFlagName, timelineSinceFlag, err)
+		}
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	// Fetch one extra event so we can tell the user when output was truncated
+	fetchLimit := timelineLimitFlag
+	if fetchLimit > 0 {
+		fetchLimit++
+	}
+	events, err := client.ListMissionEvents(args[0], since, fetchLimit)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get timeline for mission %s", args[0])
+	}
+
+	truncated := timelineLimitFlag > 0 && len(events) > timelineLimitFlag
+	if truncated {
+		events = events[1:]
+	}
+
+	if isStructuredOutput() {
+		return printStructured(events)
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No events recorded for this mission.")
+		return nil
+	}
+
+	if truncated {
+		fmt.Printf("...showing the %d most recent events. Use --%s to see more.\n\n", timelineLimitFlag, timelineLimitFlagName)
+	}
+
+	tbl := tableprinter.NewTable("TIME", "EVENT", "DETAILS")
+	for _, event := range events {
+		tbl.AddRow(
+			event.Time.Local().Format("2006-01-02 15:04:05"),
+			event.Type,
+			formatEventData(event.Data),
+		)
+	}
+	tbl.Print()
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/session"
+)
+
+var missionTranscriptSessionFlag string
+var missionTranscriptFormatFlag string
+
+var missionTranscriptCmd = &cobra.Command{
+	Use:   transcriptCmdStr + " <mission-id>",
+	Short: "Export a mission's session transcript as Markdown, HTML, or JSON",
+	Long: fmt.Sprintf(`Export a mission's session transcript as a readable document.
+
+Renders the conversation for sharing with teammates who don't use AgenC: user
+and assistant messages in order, with each tool call collapsed into a
+<details> block that expands to its input and result. Thinking blocks are
+left out, and very long tool results are cut.
+
+Formats (--%s):
+  md     Markdown (default); renders on GitHub, in PRs, and in most wikis
+  html   a self-contained HTML page
+  json   the structured transcript, for further processing
+
+Uses the mission's current session unless --%s picks another one (full
+UUID or short ID; see '%s %s %s'). The document is written to stdout.
+
+Example:
+  %s %s %s 2b4c8f1a > transcript.md
+  %s %s %s 2b4c8f1a --%s html > transcript.html
+  %s %s %s 2b4c8f1a --%s 18749fb5 --%s json`,
+		formatFlagName,
+		transcriptSessionFlagName, agencCmdStr, sessionCmdStr, lsCmdStr,
+		agencCmdStr, missionCmdStr, transcriptCmdStr,
+		agencCmdStr, missionCmdStr, transcriptCmdStr, formatFlagName,
+		agencCmdStr, missionCmdStr, transcriptCmdStr, transcriptSessionFlagName, formatFlagName,
+	),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runMissionTranscript,
+	ValidArgsFunction: completeMissionID,
+}
+
+func init() {
+	missionTranscriptCmd.Flags().StringVar(&missionTranscriptSessionFlag, transcriptSessionFlagName, "", "session to export (default: the mission's current session)")
+	missionTranscriptCmd.Flags().StringVar(&missionTranscriptFormatFlag, formatFlagName, string(session.TranscriptFormatMarkdown), "output format: md, html, or json")
+	missionCmd.AddCommand(missionTranscriptCmd)
+}
+
+func runMissionTranscript(cmd *cobra.Command, args []string) error {
+	format := session.TranscriptFormat(missionTranscriptFormatFlag)
+	switch format {
+	case session.TranscriptFormatMarkdown, session.TranscriptFormatHTML, session.TranscriptFormatJSON:
+	default:
+		return stacktrace.NewError("invalid --%s '%s'; must be 'md', 'html', or 'json'", formatFlagName, missionTranscriptFormatFlag)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	mission, err := client.GetMission(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get mission %s", args[0])
+	}
+
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get agenc directory path")
+	}
+	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(agencDirpath, mission.ID)
+
+	var jsonlFilepath string
+	if missionTranscriptSessionFlag != "" {
+		sessionID, err := client.ResolveSessionID(missionTranscriptSessionFlag)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to resolve session ID '%s'", missionTranscriptSessionFlag)
+		}
+		jsonlFilepath = session.FindMissionSessionJSONLPath(claudeConfigDirpath, mission.ID, sessionID)
+		if jsonlFilepath == "" {
+			return stacktrace.NewError("session %s does not belong to mission %s", missionTranscriptSessionFlag, mission.ShortID)
+		}
+	} else {
+		jsonlFilepath = session.FindActiveJSONLPath(claudeConfigDirpath, mission.ID)
+		if jsonlFilepath == "" {
+			return stacktrace.NewError("no current session found for mission %s", mission.ShortID)
+		}
+	}
+
+	transcript, err := session.BuildTranscript(jsonlFilepath)
+	if err != nil {
+		return err
+	}
+	transcript.Title = "Mission " + mission.ShortID
+	if mission.SessionName != "" {
+		transcript.Title += ": " + mission.SessionName
+	}
+
+	if err := session.RenderTranscript(transcript, format, os.Stdout); err != nil {
+		return stacktrace.Propagate(err, "failed to render transcript")
+	}
+	if len(transcript.Messages) == 0 {
+		fmt.Fprint(os.Stderr, emptySessionMessage)
+	}
+	return nil
+}
@@ -41,6 +41,10 @@ func runNotificationsLs(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to list notifications")
 	}
 
+	if isStructuredOutput() {
+		return printStructured(list)
+	}
+
 	if len(list) == 0 {
 		if all {
 			fmt.Println("No notifications.")
@@ -87,7 +91,13 @@ func formatNotificationWhen(ts string) string {
 	if err != nil {
 		return ts
 	}
-	d := time.Since(t)
+	return formatTimeAgo(t, time.Now())
+}
+
+// formatTimeAgo renders t relative to now ("4m ago", "2h ago", "3d ago"),
+// falling back to a date for anything older than a week.
+func formatTimeAgo(t time.Time, now time.Time) string {
+	d := now.Sub(t)
 	switch {
 	case d < time.Minute:
 		return "just now"
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/goccy/go-yaml"
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by the global --output flag.
+const (
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+	outputFormatYAML  = "yaml"
+)
+
+var outputFormatFlag string
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormatFlag, outputFlagName, "o", outputFormatTable,
+		fmt.Sprintf("output format for list and get commands: %s, %s, or %s", outputFormatTable, outputFormatJSON, outputFormatYAML))
+}
+
+// validateOutputFormat rejects unknown --output values before any command
+// runs. Commands with their own --output flag (e.g. mission export's bundle
+// path) shadow the global one and leave outputFormatFlag at its default.
+func validateOutputFormat(cmd *cobra.Command, args []string) error {
+	switch outputFormatFlag {
+	case outputFormatTable, outputFormatJSON, outputFormatYAML:
+		return nil
+	default:
+		return stacktrace.NewError("invalid --%s '%s'; must be %s, %s, or %s",
+			outputFlagName, outputFormatFlag, outputFormatTable, outputFormatJSON, outputFormatYAML)
+	}
+}
+
+// isStructuredOutput reports whether --output asks for machine-readable
+// output instead of the human-oriented table.
+func isStructuredOutput() bool {
+	return outputFormatFlag == outputFormatJSON || outputFormatFlag == outputFormatYAML
+}
+
+// printStructured writes v to stdout as indented JSON or YAML per --output.
+func printStructured(v any) error {
+	return writeStructured(os.Stdout, outputFormatFlag, v)
+}
+
+// writeStructured encodes v as indented JSON, or as YAML when format is
+// yaml. YAML is converted from the JSON encoding so both formats share the
+// same field names. A nil slice is written as [] rather than null so scripts
+// can always iterate the result.
+func writeStructured(w io.Writer, format string, v any) error {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		v = reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to encode output as JSON")
+	}
+	if format == outputFormatYAML {
+		data, err = yaml.JSONToYAML(data)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to encode output as YAML")
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
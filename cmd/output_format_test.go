@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteStructured(t *testing.T) {
+	type row struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+	rows := []row{{Name: "alpha", Tags: []string{"x"}}}
+
+	tests := []struct {
+		name   string
+		format string
+		value  any
+		want   string
+	}{
+		{"json", outputFormatJSON, rows, "[\n  {\n    \"name\": \"alpha\",\n    \"tags\": [\n      \"x\"\n    ]\n  }\n]\n"},
+		{"yaml", outputFormatYAML, rows, "- name: alpha\n  tags:\n  - x\n"},
+		{"nil slice json", outputFormatJSON, []row(nil), "[]\n"},
+		{"nil slice yaml", outputFormatYAML, []row(nil), "[]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeStructured(&buf, tt.format, tt.value); err != nil {
+				t.Fatalf("writeStructured failed: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	defer func(prev string) { outputFormatFlag = prev }(outputFormatFlag)
+
+	for _, format := range []string{outputFormatTable, outputFormatJSON, outputFormatYAML} {
+		outputFormatFlag = format
+		if err := validateOutputFormat(nil, nil); err != nil {
+			t.Errorf("expected %q to be valid, got %v", format, err)
+		}
+	}
+	outputFormatFlag = "xml"
+	if err := validateOutputFormat(nil, nil); err == nil {
+		t.Error("expected error for unknown output format")
+	}
+}
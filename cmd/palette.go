@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var paletteCmd = &cobra.Command{
+	Use:   paletteCmdStr,
+	Short: "Open the AgenC command palette from any shell",
+	Long: `Presents the same fzf-based command picker as the tmux palette keybinding,
+but runs in the current shell: no tmux session or keybindings required. The
+chosen command runs in the foreground, with its output printed to the terminal.
+
+Mission-scoped commands (those referencing $AGENC_CALLING_MISSION_UUID) apply
+to the mission in $AGENC_CALLING_MISSION_UUID when it is set, or else to the
+mission whose agent directory contains the current directory. Outside tmux,
+commands that drive tmux are hidden.
+
+Commands containing {{input:Label}} placeholders prompt for each value before
+running; submitting an empty value cancels. --run skips the picker and runs
+the named command directly.`,
+	Args: cobra.NoArgs,
+	RunE: runPalette,
+}
+
+func init() {
+	rootCmd.AddCommand(paletteCmd)
+	paletteCmd.Flags().String(paletteRunFlagName, "", "run the named palette command directly, skipping the picker")
+}
+
+func runPalette(cmd *cobra.Command, args []string) error {
+	callingMissionUUID, err := resolvePaletteCallingMission()
+	if err != nil {
+		return err
+	}
+
+	runName, err := cmd.Flags().GetString(paletteRunFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", paletteRunFlagName)
+	}
+	if runName != "" {
+		entry, err := findPaletteCommand(runName)
+		if err != nil {
+			return err
+		}
+		if entry.IsMissionScoped() && callingMissionUUID == "" {
+			return stacktrace.NewError("palette command '%s' needs a mission; run it from a mission's agent directory or set $%s", runName, config.CallingMissionUUIDEnvVar)
+		}
+		return runPaletteCommandInShell(entry, callingMissionUUID)
+	}
+
+	entries, err := buildPaletteEntries(callingMissionUUID, isInsideTmux())
+	if err != nil {
+		return err
+	}
+
+	selectedEntry, err := pickPaletteEntry(entries)
+	if err != nil || selectedEntry == nil {
+		return err
+	}
+
+	return runPaletteCommandInShell(*selectedEntry, callingMissionUUID)
+}
+
+// resolvePaletteCallingMission returns the mission palette commands should
+// act on: $AGENC_CALLING_MISSION_UUID when set, otherwise the mission whose
+// agent directory contains the working directory. Returns "" when there is
+// neither.
+func resolvePaletteCallingMission() (string, error) {
+	if missionID := os.Getenv(config.CallingMissionUUIDEnvVar); missionID != "" {
+		return missionID, nil
+	}
+
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to get agenc dirpath")
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to get working directory")
+	}
+	missionID, _ := config.MissionIDFromDirpath(agencDirpath, cwd)
+	return missionID, nil
+}
+
+// runPaletteCommandInShell prepares the entry's command (see
+// preparePaletteCommand) and runs it in the foreground of the current shell.
+func runPaletteCommandInShell(entry config.ResolvedPaletteCommand, callingMissionUUID string) error {
+	entry, ok, err := preparePaletteCommand(entry)
+	if err != nil || !ok {
+		return err
+	}
+
+	shellCmd := buildPaletteShellCommand(entry, callingMissionUUID)
+	shellCmd.Stdin = os.Stdin
+	shellCmd.Stdout = os.Stdout
+	shellCmd.Stderr = os.Stderr
+	if err := shellCmd.Run(); err != nil {
+		return stacktrace.Propagate(err, "palette command '%s' failed", entry.Name)
+	}
+	return nil
+}
+
+// buildPaletteShellCommand returns the shell invocation for a palette entry,
+// passing the calling mission to mission-scoped commands through the
+// environment.
+func buildPaletteShellCommand(entry config.ResolvedPaletteCommand, callingMissionUUID string) *exec.Cmd {
+	shellCmd := exec.Command("sh", "-c", entry.Command)
+	shellCmd.Env = os.Environ()
+	if entry.IsMissionScoped() && callingMissionUUID != "" {
+		shellCmd.Env = append(shellCmd.Env, config.CallingMissionUUIDEnvVar+"="+callingMissionUUID)
+	}
+	return shellCmd
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestResolvePaletteCallingMission(t *testing.T) {
+	agencDirpath := t.TempDir()
+	t.Setenv("AGENC_DIRPATH", agencDirpath)
+	missionID := "5bbe8434-0000-0000-0000-000000000000"
+	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
+	if err := os.MkdirAll(filepath.Join(agentDirpath, "src"), 0755); err != nil {
+		t.Fatalf("failed to create agent dir: %v", err)
+	}
+
+	t.Setenv(config.CallingMissionUUIDEnvVar, "")
+	t.Chdir(filepath.Join(agentDirpath, "src"))
+	if got, err := resolvePaletteCallingMission(); err != nil || got != missionID {
+		t.Errorf("expected mission %s from the working directory, got %q (err %v)", missionID, got, err)
+	}
+
+	t.Setenv(config.CallingMissionUUIDEnvVar, "from-env")
+	if got, _ := resolvePaletteCallingMission(); got != "from-env" {
+		t.Errorf("expected $%s to take precedence, got %q", config.CallingMissionUUIDEnvVar, got)
+	}
+
+	t.Setenv(config.CallingMissionUUIDEnvVar, "")
+	t.Chdir(t.TempDir())
+	if got, _ := resolvePaletteCallingMission(); got != "" {
+		t.Errorf("expected no mission outside a mission agent dir, got %q", got)
+	}
+}
+
+func TestBuildPaletteShellCommand(t *testing.T) {
+	envEntry := config.CallingMissionUUIDEnvVar + "=abc"
+	hasMissionEnv := func(env []string) bool {
+		for _, kv := range env {
+			if kv == envEntry {
+				return true
+			}
+		}
+		return false
+	}
+
+	scoped := config.ResolvedPaletteCommand{Name: "stop", Command: "agenc mission stop $" + config.CallingMissionUUIDEnvVar}
+	shellCmd := buildPaletteShellCommand(scoped, "abc")
+	if got := strings.Join(shellCmd.Args, " "); got != "sh -c "+scoped.Command {
+		t.Errorf("unexpected args: %s", got)
+	}
+	if !hasMissionEnv(shellCmd.Env) {
+		t.Error("expected the calling mission in a mission-scoped command's environment")
+	}
+
+	unscoped := config.ResolvedPaletteCommand{Name: "ls", Command: "agenc mission ls"}
+	if hasMissionEnv(buildPaletteShellCommand(unscoped, "abc").Env) {
+		t.Error("expected no calling mission for a command that doesn't reference it")
+	}
+}
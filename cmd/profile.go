@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var profileFlag string
+
+var profileCmd = &cobra.Command{
+	Use:   profileCmdStr,
+	Short: "Manage profiles for running several AgenC installations",
+	Long: fmt.Sprintf(`Manage profiles: named AgenC directories, each with its own server, pool
+session, repos, and missions.
+
+Profiles are defined in ~/%s:
+
+  current: work            # used when neither --%s nor AGENC_DIRPATH is set
+  profiles:
+    work:
+      dirpath: ~/.agenc-work
+    oss:
+      dirpath: ~/.agenc-oss
+
+The profile named %q is ~/.agenc unless the file defines it. Run any
+command against a profile with 'agenc --%s <name> ...', or change the current
+profile with '%s %s %s <name>'. AGENC_DIRPATH, when set, takes precedence
+over the current profile.`,
+		config.ProfilesFilename, profileFlagName, config.DefaultProfileName, profileFlagName,
+		agencCmdStr, profileCmdStr, switchCmdStr),
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&profileFlag, profileFlagName, "", "run against this profile's AgenC directory (see 'agenc profile')")
+	_ = rootCmd.RegisterFlagCompletionFunc(profileFlagName, completeProfileName)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// applyProfileFlag points the process at the --profile directory before any
+// command runs. Setting AGENC_DIRPATH (rather than only resolving a path)
+// carries the profile into the server and wrappers the command starts.
+func applyProfileFlag() error {
+	if profileFlag == "" {
+		return nil
+	}
+	if err := config.UseProfile(profileFlag); err != nil {
+		return stacktrace.Propagate(err, "failed to use profile '%s'", profileFlag)
+	}
+	return nil
+}
+
+// completeProfileName completes profile names for --profile and
+// 'profile switch'.
+func completeProfileName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	profiles, _, err := config.ReadProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return profiles.Names(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var profileLsCmd = &cobra.Command{
+	Use:   lsCmdStr,
+	Short: "List profiles",
+	Long: `List profiles with their AgenC directory and whether their server is
+running. The profile this command resolved to is marked with *.`,
+	Args: cobra.NoArgs,
+	RunE: runProfileLs,
+}
+
+func init() {
+	profileCmd.AddCommand(profileLsCmd)
+}
+
+// profileListEntry is one row of 'profile ls'.
+type profileListEntry struct {
+	Name          string `json:"name"`
+	Dirpath       string `json:"dirpath"`
+	Active        bool   `json:"active"`
+	ServerRunning bool   `json:"server_running"`
+}
+
+func runProfileLs(cmd *cobra.Command, args []string) error {
+	profiles, _, err := config.ReadProfiles()
+	if err != nil {
+		return err
+	}
+	activeDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve agenc directory")
+	}
+
+	var entries []profileListEntry
+	for _, name := range profiles.Names() {
+		dirpath, err := profiles.ResolveDirpath(name)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, profileListEntry{
+			Name:          name,
+			Dirpath:       dirpath,
+			Active:        dirpath == activeDirpath,
+			ServerRunning: server.IsRunning(config.GetServerPIDFilepath(dirpath)),
+		})
+	}
+
+	if isStructuredOutput() {
+		return printStructured(entries)
+	}
+
+	tbl := tableprinter.NewTable("", "NAME", "DIRPATH", "SERVER")
+	for _, entry := range entries {
+		marker := ""
+		if entry.Active {
+			marker = "*"
+		}
+		serverStatus := "stopped"
+		if entry.ServerRunning {
+			serverStatus = "running"
+		}
+		tbl.AddRow(marker, entry.Name, entry.Dirpath, serverStatus)
+	}
+	tbl.Print()
+	if profileFlag == "" && len(profiles.Profiles) == 0 {
+		fmt.Printf("\nNo profiles defined; see '%s %s --help'.\n", agencCmdStr, profileCmdStr)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var profileSwitchCmd = &cobra.Command{
+	Use:   switchCmdStr + " <name>",
+	Short: "Change the current profile",
+	Long: fmt.Sprintf(`Set the profile used by commands run without --%s or AGENC_DIRPATH.
+
+Servers and missions already running keep using their own directory, so
+switching never disturbs them. Switch back to ~/.agenc with:
+
+  %s %s %s %s`, profileFlagName, agencCmdStr, profileCmdStr, switchCmdStr, config.DefaultProfileName),
+	Args:              cobra.ExactArgs(1),
+	RunE:              runProfileSwitch,
+	ValidArgsFunction: completeProfileName,
+}
+
+func init() {
+	profileCmd.AddCommand(profileSwitchCmd)
+}
+
+func runProfileSwitch(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	profiles, cm, err := config.ReadProfiles()
+	if err != nil {
+		return err
+	}
+	dirpath, err := profiles.ResolveDirpath(name)
+	if err != nil {
+		return err
+	}
+
+	if _, defined := profiles.Profiles[name]; !defined {
+		// The built-in default profile is the absence of a current profile
+		profiles.Current = ""
+	} else {
+		profiles.Current = name
+	}
+	if err := config.WriteProfiles(profiles, cm); err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to profile '%s' (%s)\n", name, dirpath)
+	if profileFlag == "" && os.Getenv(agencDirpathEnvVar) != "" {
+		fmt.Printf("Note: AGENC_DIRPATH is set in this shell and takes precedence; unset it to use the profile.\n")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var projectCmd = &cobra.Command{
+	Use:   projectCmdStr,
+	Short: "Group a repo, missions, cron jobs, and notes into projects",
+	Long: `A project groups the work on one effort: a repo, the missions working on it,
+the cron jobs that feed it, and free-form notes.
+
+Missions join a project with 'agenc mission new --project <name>' or
+'agenc project add'. Cron jobs join one with 'project: <name>' in their
+config.yml entry; each run's mission then joins the project. Missions started
+from inside a project mission, and clones of one, join the same project.
+
+Scope views to a project with 'agenc mission ls --project <name>',
+'agenc dashboard --project <name>', or 'agenc project status <name>'.`,
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+}
+
+// getProjectRepo returns the repo of the named project, or "" when it has
+// none.
+func getProjectRepo(name string) (string, error) {
+	client, err := serverClient()
+	if err != nil {
+		return "", err
+	}
+	project, err := client.GetProject(name)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to get project '%s'", name)
+	}
+	return project.GitRepo, nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var projectAddRemoveFlag bool
+
+var projectAddCmd = &cobra.Command{
+	Use:   addCmdStr + " <project> <mission-id>...",
+	Short: "Move missions into a project",
+	Long: `Move missions into a project. A mission belongs to at most one project, so
+this takes it out of any project it was in. With --remove, takes the missions
+out of the project instead.
+
+Examples:
+  agenc project add billing abc12345 def67890
+  agenc project add billing --remove abc12345`,
+	Args:              cobra.MinimumNArgs(2),
+	RunE:              runProjectAdd,
+	ValidArgsFunction: completeProjectAddArgs,
+}
+
+func init() {
+	projectAddCmd.Flags().BoolVar(&projectAddRemoveFlag, removeFlagName, false, "take the missions out of the project instead")
+	projectCmd.AddCommand(projectAddCmd)
+}
+
+func runProjectAdd(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	projectName := args[0]
+	if _, err := client.GetProject(projectName); err != nil {
+		return stacktrace.Propagate(err, "failed to get project '%s'", projectName)
+	}
+
+	for _, missionID := range args[1:] {
+		mission, err := client.GetMission(missionID)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to get mission %s", missionID)
+		}
+
+		newProject := projectName
+		if projectAddRemoveFlag {
+			if mission.Project != projectName {
+				fmt.Printf("Mission %s is not in project '%s'\n", mission.ShortID, projectName)
+				continue
+			}
+			newProject = ""
+		}
+
+		if err := client.UpdateMission(mission.ID, server.UpdateMissionRequest{Project: &newProject}); err != nil {
+			return stacktrace.Propagate(err, "failed to update project of mission %s", mission.ShortID)
+		}
+		if projectAddRemoveFlag {
+			fmt.Printf("Removed mission %s from project '%s'\n", mission.ShortID, projectName)
+		} else {
+			fmt.Printf("Added mission %s to project '%s'\n", mission.ShortID, projectName)
+		}
+	}
+	return nil
+}
+
+// completeProjectAddArgs completes the project name first, then mission IDs.
+func completeProjectAddArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return projectNameCompletions(), cobra.ShellCompDirectiveNoFileComp
+	}
+	return missionIDCompletions(args[1:]), cobra.ShellCompDirectiveNoFileComp
+}
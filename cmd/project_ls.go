@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var projectLsCmd = &cobra.Command{
+	Use:   lsCmdStr,
+	Short: "List projects",
+	Args:  cobra.NoArgs,
+	RunE:  runProjectLs,
+}
+
+func init() {
+	projectCmd.AddCommand(projectLsCmd)
+}
+
+func runProjectLs(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := client.ListProjects()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list projects")
+	}
+	if isStructuredOutput() {
+		return printStructured(projects)
+	}
+
+	if len(projects) == 0 {
+		fmt.Printf("No projects. Create one with '%s %s %s <name>'.\n", agencCmdStr, projectCmdStr, newCmdStr)
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("NAME", "REPO", "MISSIONS", "CRONS", "NOTES")
+	for _, p := range projects {
+		crons := "--"
+		if len(p.Crons) > 0 {
+			crons = strings.Join(p.Crons, ", ")
+		}
+		tbl.AddRow(p.Name, displayGitRepo(p.GitRepo), p.MissionCount, crons, truncatePrompt(p.Notes, summaryColumnMaxLen))
+	}
+	tbl.Print()
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var projectNewRepoFlag string
+var projectNewNotesFlag string
+
+var projectNewCmd = &cobra.Command{
+	Use:   newCmdStr + " <name>",
+	Short: "Create a project",
+	Long: `Create a project. Names start with a letter and may contain letters, digits,
+'.', '_', and '-'.
+
+--repo sets the repo that missions started with 'agenc mission new --project'
+clone when no repo is given. --notes records free-form notes, shown by
+'agenc project status'.
+
+Examples:
+  agenc project new billing --repo owner/billing-service
+  agenc project new launch --notes "Q3 launch: landing page + pricing"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectNew,
+}
+
+func init() {
+	projectNewCmd.Flags().StringVar(&projectNewRepoFlag, projectRepoFlagName, "", "repo the project's missions work on")
+	projectNewCmd.Flags().StringVar(&projectNewNotesFlag, projectNotesFlagName, "", "free-form notes about the project")
+	_ = projectNewCmd.RegisterFlagCompletionFunc(projectRepoFlagName, completeRepoFlag)
+	projectCmd.AddCommand(projectNewCmd)
+}
+
+func runProjectNew(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := config.ValidateProjectName(name); err != nil {
+		return err
+	}
+
+	repo := projectNewRepoFlag
+	if repo != "" {
+		result, err := ResolveRepoInput(repo, "Select repo: ")
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to resolve repo")
+		}
+		repo = result.RepoName
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	project, err := client.CreateProject(server.CreateProjectRequest{
+		Name:    name,
+		GitRepo: repo,
+		Notes:   projectNewNotesFlag,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create project")
+	}
+
+	fmt.Printf("Created project '%s'\n", project.Name)
+	return nil
+}
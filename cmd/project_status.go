@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var projectStatusAllFlag bool
+
+var projectStatusCmd = &cobra.Command{
+	Use:   statusCmdStr + " [project]",
+	Short: "Show a project's repo, notes, cron jobs, and missions",
+	Long: `Show a project's repo, notes, and cron jobs, and the status of its missions.
+Without an argument, opens an fzf picker over your projects.
+
+Examples:
+  agenc project status billing
+  agenc project status billing --all     # include archived missions`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runProjectStatus,
+	ValidArgsFunction: completeProjectName,
+}
+
+func init() {
+	projectStatusCmd.Flags().BoolVarP(&projectStatusAllFlag, allFlagName, "a", false, "include archived missions")
+	projectCmd.AddCommand(projectStatusCmd)
+}
+
+// projectStatusOutput is the --output json|yaml form of 'project status'.
+type projectStatusOutput struct {
+	server.ProjectResponse
+	Missions []missionOutput `json:"missions"`
+}
+
+func runProjectStatus(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	project, err := resolveProject(client, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return nil // user cancelled fzf
+	}
+
+	missions, err := client.ListMissions(server.ListMissionsRequest{
+		IncludeArchived: projectStatusAllFlag,
+		Project:         project.Name,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list missions in project '%s'", project.Name)
+	}
+
+	if isStructuredOutput() {
+		output := projectStatusOutput{ProjectResponse: *project, Missions: make([]missionOutput, 0, len(missions))}
+		for _, m := range missions {
+			output.Missions = append(output.Missions, newMissionOutput(m))
+		}
+		return printStructured(output)
+	}
+
+	printProjectStatus(project, missions)
+	return nil
+}
+
+// resolveProject returns the named project, or with an empty name lets the
+// user pick one with fzf. Returns nil when the user cancels the picker.
+func resolveProject(client *server.Client, name string) (*server.ProjectResponse, error) {
+	result, err := Resolve(name, Resolver[server.ProjectResponse]{
+		TryCanonical: func(input string) (server.ProjectResponse, bool, error) {
+			project, err := client.GetProject(input)
+			if err != nil {
+				return server.ProjectResponse{}, false, stacktrace.Propagate(err, "failed to get project '%s'", input)
+			}
+			return *project, true, nil
+		},
+		GetItems: func() ([]server.ProjectResponse, error) {
+			projects, err := client.ListProjects()
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "failed to list projects")
+			}
+			return projects, nil
+		},
+		FormatRow: func(p server.ProjectResponse) []string {
+			return []string{p.Name, displayGitRepo(p.GitRepo), fmt.Sprintf("%d", p.MissionCount)}
+		},
+		FzfPrompt:  "Select project: ",
+		FzfHeaders: []string{"PROJECT", "REPO", "MISSIONS"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.WasCancelled || len(result.Items) == 0 {
+		return nil, nil
+	}
+	return &result.Items[0], nil
+}
+
+func printProjectStatus(project *server.ProjectResponse, missions []*database.Mission) {
+	cfg, _ := readConfig()
+
+	fmt.Printf("Project:  %s\n", project.Name)
+	fmt.Printf("Repo:     %s\n", formatRepoDisplay(project.GitRepo, false, cfg))
+	crons := "--"
+	if len(project.Crons) > 0 {
+		crons = strings.Join(project.Crons, ", ")
+	}
+	fmt.Printf("Crons:    %s\n", crons)
+	if project.Notes != "" {
+		fmt.Println()
+		fmt.Println(project.Notes)
+	}
+
+	fmt.Println()
+	if len(missions) == 0 {
+		fmt.Printf("No missions. Start one with '%s %s %s --%s %s'.\n", agencCmdStr, missionCmdStr, newCmdStr, projectFlagName, project.Name)
+		return
+	}
+
+	counts := make(map[MissionDisplayStatus]int)
+	tbl := tableprinter.NewTable("ID", "LAST PROMPT", "STATUS", "SESSION", "REPO", "PR")
+	for _, m := range missions {
+		status := getMissionDisplayStatus(m)
+		counts[status]++
+		tbl.AddRow(
+			m.ShortID,
+			formatLastPrompt(m.LastUserPromptAt, m.CreatedAt),
+			colorizeStatus(status),
+			truncatePrompt(resolveSessionName(m), defaultPromptMaxLen),
+			formatRepoDisplay(m.GitRepo, m.IsAdjutant, cfg),
+			formatPRDisplay(m.PRURL),
+		)
+	}
+	fmt.Printf("Missions: %s\n\n", formatStatusCounts(len(missions), counts))
+	tbl.Print()
+}
+
+// projectStatusOrder is the order statuses are listed in a project's
+// mission summary line.
+var projectStatusOrder = []MissionDisplayStatus{
+	StatusBusy, StatusWaiting, StatusRunning, StatusIdle, StatusQueued, StatusSuspended, StatusStopped, StatusCrashed, StatusArchived,
+}
+
+// formatStatusCounts renders a mission total with its per-status breakdown,
+// e.g. "5 (2 busy, 1 idle, 2 stopped)".
+func formatStatusCounts(total int, counts map[MissionDisplayStatus]int) string {
+	var parts []string
+	for _, status := range projectStatusOrder {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[status], strings.ToLower(string(status))))
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d", total)
+	}
+	return fmt.Sprintf("%d (%s)", total, strings.Join(parts, ", "))
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var projectUpdateCmd = &cobra.Command{
+	Use:   updateCmdStr + " <name>",
+	Short: "Change a project's repo or notes",
+	Long: `Change a project's repo or notes. Pass an empty value to clear one.
+
+Examples:
+  agenc project update billing --repo owner/billing-v2
+  agenc project update billing --notes "Invoices ship Friday"
+  agenc project update billing --notes ""`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runProjectUpdate,
+	ValidArgsFunction: completeProjectName,
+}
+
+func init() {
+	projectUpdateCmd.Flags().String(projectRepoFlagName, "", "repo the project's missions work on")
+	projectUpdateCmd.Flags().String(projectNotesFlagName, "", "free-form notes about the project")
+	_ = projectUpdateCmd.RegisterFlagCompletionFunc(projectRepoFlagName, completeRepoFlag)
+	projectCmd.AddCommand(projectUpdateCmd)
+}
+
+func runProjectUpdate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	var req server.UpdateProjectRequest
+	if cmd.Flags().Changed(projectRepoFlagName) {
+		repo, _ := cmd.Flags().GetString(projectRepoFlagName)
+		if repo != "" {
+			result, err := ResolveRepoInput(repo, "Select repo: ")
+			if err != nil {
+				return stacktrace.Propagate(err, "failed to resolve repo")
+			}
+			repo = result.RepoName
+		}
+		req.GitRepo = &repo
+	}
+	if cmd.Flags().Changed(projectNotesFlagName) {
+		notes, _ := cmd.Flags().GetString(projectNotesFlagName)
+		req.Notes = &notes
+	}
+	if req.GitRepo == nil && req.Notes == nil {
+		return stacktrace.NewError("nothing to update; pass --%s and/or --%s", projectRepoFlagName, projectNotesFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.UpdateProject(name, req); err != nil {
+		return stacktrace.Propagate(err, "failed to update project '%s'", name)
+	}
+
+	fmt.Printf("Updated project '%s'\n", name)
+	return nil
+}
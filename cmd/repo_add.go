@@ -20,8 +20,13 @@ Accepts any of these formats:
   github.com/owner/repo                - canonical name
   https://github.com/owner/repo        - HTTPS URL
   git@github.com:owner/repo.git        - SSH URL
+  git@git.internal:team/svc.git        - any other git host (SSH or HTTPS)
   /path/to/local/clone                 - local filesystem path
 
+Repos on other hosts are cloned from the URL exactly as given and stored
+under their host, e.g. 'git.internal/team/svc'. Use that name anywhere a
+repo is expected, including repoConfig keys (title, emoji, ...).
+
 Tip: Single-word shorthand works automatically if you're logged into gh (gh auth login)
 
 For shorthand formats, the clone protocol (SSH vs HTTPS) is auto-detected
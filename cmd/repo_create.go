@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var repoCreateCmd = &cobra.Command{
+	Use:   createCmdStr + " <name>",
+	Short: "Create a new GitHub repository and add it to the repo library",
+	Long: fmt.Sprintf(`Create a new GitHub repository with the gh CLI, clone it into the repo
+library, and optionally start a mission in it.
+
+The name is owner/repo, or a bare repo name to create it under the account
+you're logged into with 'gh auth login'. Repos are public unless --%s is set.
+
+Use --%s owner/template-repo to generate the repo from a template. Without a
+template, --%s (e.g. mit, apache-2.0) and --%s (e.g. Go, Node) initialize
+the repo with a license and .gitignore; they cannot be combined with a
+template, which supplies its own initial files.
+
+Use --%s to start a mission in the new repo once it's cloned, or --%s to
+start one with an initial prompt.
+
+Example:
+  %s %s %s my-app --%s acme/go-service-template --%s --%s "Wire up the HTTP server"`,
+		repoCreatePrivateFlagName,
+		repoCreateTemplateFlagName, repoCreateLicenseFlagName, repoCreateGitignoreFlagName,
+		repoCreateMissionFlagName, promptFlagName,
+		agencCmdStr, repoCmdStr, createCmdStr, repoCreateTemplateFlagName, repoCreatePrivateFlagName, promptFlagName),
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoCreate,
+}
+
+func init() {
+	repoCreateCmd.Flags().String(repoCreateTemplateFlagName, "", "template repository to generate from (owner/repo)")
+	repoCreateCmd.Flags().Bool(repoCreatePrivateFlagName, false, "make the repository private")
+	repoCreateCmd.Flags().String(repoCreateLicenseFlagName, "", "license to initialize the repository with (e.g. mit)")
+	repoCreateCmd.Flags().String(repoCreateGitignoreFlagName, "", ".gitignore template to initialize the repository with (e.g. Go)")
+	repoCreateCmd.Flags().String(repoConfigDescriptionFlagName, "", "description for the GitHub repo and the repo library")
+	repoCreateCmd.Flags().Bool(repoCreateMissionFlagName, false, "start a mission in the new repo")
+	repoCreateCmd.Flags().String(promptFlagName, "", "start a mission in the new repo with this initial prompt")
+	repoCmd.AddCommand(repoCreateCmd)
+}
+
+func runRepoCreate(cmd *cobra.Command, args []string) error {
+	req := server.CreateRepoRequest{Name: args[0]}
+	var err error
+	if req.Template, err = cmd.Flags().GetString(repoCreateTemplateFlagName); err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoCreateTemplateFlagName)
+	}
+	if req.Private, err = cmd.Flags().GetBool(repoCreatePrivateFlagName); err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoCreatePrivateFlagName)
+	}
+	if req.License, err = cmd.Flags().GetString(repoCreateLicenseFlagName); err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoCreateLicenseFlagName)
+	}
+	if req.Gitignore, err = cmd.Flags().GetString(repoCreateGitignoreFlagName); err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoCreateGitignoreFlagName)
+	}
+	if req.Description, err = cmd.Flags().GetString(repoConfigDescriptionFlagName); err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoConfigDescriptionFlagName)
+	}
+	startMission, err := cmd.Flags().GetBool(repoCreateMissionFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoCreateMissionFlagName)
+	}
+	prompt, err := cmd.Flags().GetString(promptFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", promptFlagName)
+	}
+
+	if req.Template != "" && (req.License != "" || req.Gitignore != "") {
+		return stacktrace.NewError("--%s and --%s cannot be combined with --%s",
+			repoCreateLicenseFlagName, repoCreateGitignoreFlagName, repoCreateTemplateFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Creating '%s' on GitHub...\n", req.Name)
+	resp, err := client.CreateRepo(req)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create repo '%s'", req.Name)
+	}
+	fmt.Printf("Created '%s'\n", resp.Name)
+
+	if !startMission && prompt == "" {
+		return nil
+	}
+	return createAndLaunchMission(resp.Name, prompt)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var repoForkCmd = &cobra.Command{
+	Use:   forkCmdStr + " <owner/repo>",
+	Short: "Fork a GitHub repository and add the fork and its upstream to the repo library",
+	Long: fmt.Sprintf(`Fork a GitHub repository through the GitHub API (using the gh CLI's login),
+then clone both the upstream and the fork into the repo library.
+
+The fork remembers its upstream (the 'upstream' key in its repoConfig), and
+every mission created from the fork gets an 'upstream' remote pointing at the
+parent repo, with upstream/* branches ready to diff and rebase against — so
+PRs back to the parent work without any git setup.
+
+The fork goes to the account you're logged into with 'gh auth login', or to
+--%s. Use --%s to give the fork a different repo name. Forking a repo you
+have already forked reuses the existing fork.
+
+Use --%s to start a mission in the fork once it's cloned, or --%s to start
+one with an initial prompt.
+
+Example:
+  %s %s %s acme/widgets --%s "Fix the flaky retry test and open a PR upstream"`,
+		repoForkOrgFlagName, repoForkNameFlagName,
+		repoCreateMissionFlagName, promptFlagName,
+		agencCmdStr, repoCmdStr, forkCmdStr, promptFlagName),
+	Args: cobra.ExactArgs(1),
+	RunE: runRepoFork,
+}
+
+func init() {
+	repoForkCmd.Flags().String(repoForkOrgFlagName, "", "organization to fork into (default: your own account)")
+	repoForkCmd.Flags().String(repoForkNameFlagName, "", "repo name for the fork (default: the upstream's name)")
+	repoForkCmd.Flags().Bool(repoCreateMissionFlagName, false, "start a mission in the fork")
+	repoForkCmd.Flags().String(promptFlagName, "", "start a mission in the fork with this initial prompt")
+	repoCmd.AddCommand(repoForkCmd)
+}
+
+func runRepoFork(cmd *cobra.Command, args []string) error {
+	req := server.ForkRepoRequest{Upstream: args[0]}
+	var err error
+	if req.Organization, err = cmd.Flags().GetString(repoForkOrgFlagName); err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoForkOrgFlagName)
+	}
+	if req.Name, err = cmd.Flags().GetString(repoForkNameFlagName); err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoForkNameFlagName)
+	}
+	startMission, err := cmd.Flags().GetBool(repoCreateMissionFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoCreateMissionFlagName)
+	}
+	prompt, err := cmd.Flags().GetString(promptFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", promptFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Forking '%s' on GitHub...\n", req.Upstream)
+	resp, err := client.ForkRepo(req)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to fork '%s'", req.Upstream)
+	}
+	fmt.Printf("Forked '%s' to '%s'\n", resp.Upstream, resp.Name)
+
+	if !startMission && prompt == "" {
+		return nil
+	}
+	return createAndLaunchMission(resp.Name, prompt)
+}
@@ -20,6 +20,17 @@ func init() {
 	repoCmd.AddCommand(repoLsCmd)
 }
 
+// repoOutput is the --output json|yaml form of a repo library entry.
+type repoOutput struct {
+	Name              string `json:"name"`
+	Title             string `json:"title"`
+	Emoji             string `json:"emoji"`
+	Description       string `json:"description"`
+	Synced            bool   `json:"synced"`
+	Path              string `json:"path"`
+	WriteableCopyPath string `json:"writeable_copy_path"`
+}
+
 func runRepoLs(cmd *cobra.Command, args []string) error {
 	client, err := serverClient()
 	if err != nil {
@@ -31,13 +42,32 @@ func runRepoLs(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to list repos")
 	}
 
+	cfg, _ := readConfig()
+
+	if isStructuredOutput() {
+		outputs := make([]repoOutput, 0, len(repos))
+		for _, r := range repos {
+			output := repoOutput{
+				Name:              r.Name,
+				Synced:            r.Synced,
+				Path:              r.Path,
+				WriteableCopyPath: r.WriteableCopyPath,
+			}
+			if cfg != nil {
+				output.Title = cfg.GetRepoTitle(r.Name)
+				output.Emoji = cfg.GetRepoEmoji(r.Name)
+				output.Description = cfg.GetRepoDescription(r.Name)
+			}
+			outputs = append(outputs, output)
+		}
+		return printStructured(outputs)
+	}
+
 	if len(repos) == 0 {
 		fmt.Println("No repositories in the repo library.")
 		return nil
 	}
 
-	cfg, _ := readConfig()
-
 	type repoRow struct {
 		emoji         string
 		title         string
@@ -28,8 +28,9 @@ Accepts any of these formats for both arguments:
 
 Example:
   agenc repo mv old-owner/my-repo new-owner/my-repo`,
-	Args: cobra.ExactArgs(2),
-	RunE: runRepoMv,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runRepoMv,
+	ValidArgsFunction: completeRepoName,
 }
 
 func init() {
@@ -30,7 +30,8 @@ Accepts any of these formats:
   https://github.com/owner/repo        - URL
 
 Tip: Single-word shorthand works automatically if you're logged into gh (gh auth login)`,
-	RunE: runRepoRm,
+	RunE:              runRepoRm,
+	ValidArgsFunction: completeRepoNames,
 }
 
 func init() {
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/repo"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var repoSyncGithubCmd = &cobra.Command{
+	Use:   syncGithubCmdStr,
+	Short: "Pick repositories from your GitHub account to add to the repo library",
+	Long: fmt.Sprintf(`List the repositories of your GitHub account (or an organization with --%s)
+and pick the ones to add to the repo library in an fzf multi-select (TAB to
+mark several). Repos already in the library are left out of the list.
+
+Listing uses the gh CLI, so you must be logged in with 'gh auth login'.
+Archived repos are hidden unless --%s is set. Each picked repo is cloned like
+'%s %s %s', with its GitHub description recorded; use --%s to keep them
+continuously synced by the server.
+
+Examples:
+  %s %s %s
+  %s %s %s --%s acme --%s`,
+		repoSyncGithubOrgFlagName, repoSyncGithubIncludeArchivedFlagName,
+		agencCmdStr, repoCmdStr, addCmdStr, repoConfigAlwaysSyncedFlagName,
+		agencCmdStr, repoCmdStr, syncGithubCmdStr,
+		agencCmdStr, repoCmdStr, syncGithubCmdStr, repoSyncGithubOrgFlagName, repoConfigAlwaysSyncedFlagName),
+	Args: cobra.NoArgs,
+	RunE: runRepoSyncGithub,
+}
+
+func init() {
+	repoSyncGithubCmd.Flags().String(repoSyncGithubOrgFlagName, "", "list this organization's (or user's) repos instead of your own")
+	repoSyncGithubCmd.Flags().Bool(repoSyncGithubIncludeArchivedFlagName, false, "include archived repos in the list")
+	repoSyncGithubCmd.Flags().Bool(repoConfigAlwaysSyncedFlagName, false, "keep the added repos continuously synced by the server")
+	repoCmd.AddCommand(repoSyncGithubCmd)
+}
+
+func runRepoSyncGithub(cmd *cobra.Command, args []string) error {
+	org, err := cmd.Flags().GetString(repoSyncGithubOrgFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoSyncGithubOrgFlagName)
+	}
+	includeArchived, err := cmd.Flags().GetBool(repoSyncGithubIncludeArchivedFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoSyncGithubIncludeArchivedFlagName)
+	}
+	alwaysSynced, err := cmd.Flags().GetBool(repoConfigAlwaysSyncedFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", repoConfigAlwaysSyncedFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	libraryRepos, err := client.ListRepos()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list repos")
+	}
+
+	githubRepos, err := repo.ListGitHubRepos(org, includeArchived)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list GitHub repositories")
+	}
+	candidates := filterReposNotInLibrary(githubRepos, libraryRepos)
+	if len(candidates) == 0 {
+		fmt.Println("Every listed GitHub repository is already in the repo library")
+		return nil
+	}
+
+	var rows [][]string
+	for _, githubRepo := range candidates {
+		rows = append(rows, []string{
+			githubRepo.NameWithOwner,
+			formatGitHubRepoFlags(githubRepo),
+			truncatePrompt(githubRepo.Description, 60),
+		})
+	}
+	indices, err := runFzfPicker(FzfPickerConfig{
+		Prompt:      "Add repos (TAB to select): ",
+		Headers:     []string{"REPO", "FLAGS", "DESCRIPTION"},
+		Rows:        rows,
+		MultiSelect: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	var failed int
+	for _, idx := range indices {
+		githubRepo := candidates[idx]
+		req := server.AddRepoRequest{Reference: "github.com/" + githubRepo.NameWithOwner}
+		if alwaysSynced {
+			req.AlwaysSynced = &alwaysSynced
+		}
+		if githubRepo.Description != "" {
+			description := githubRepo.Description
+			req.Description = &description
+		}
+
+		resp, err := client.AddRepo(req)
+		if err != nil {
+			fmt.Printf("Failed to add '%s': %v\n", githubRepo.NameWithOwner, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Added '%s'\n", resp.Name)
+	}
+
+	if failed > 0 {
+		return stacktrace.NewError("failed to add %d of %d repos", failed, len(indices))
+	}
+	return nil
+}
+
+// filterReposNotInLibrary returns the GitHub repos that aren't already in the
+// repo library, preserving order.
+func filterReposNotInLibrary(githubRepos []repo.GitHubRepo, libraryRepos []server.RepoResponse) []repo.GitHubRepo {
+	inLibrary := make(map[string]bool, len(libraryRepos))
+	for _, libraryRepo := range libraryRepos {
+		inLibrary[libraryRepo.Name] = true
+	}
+	var result []repo.GitHubRepo
+	for _, githubRepo := range githubRepos {
+		if !inLibrary["github.com/"+githubRepo.NameWithOwner] {
+			result = append(result, githubRepo)
+		}
+	}
+	return result
+}
+
+// formatGitHubRepoFlags summarizes a repo's visibility and status for the
+// picker, e.g. "private, fork".
+func formatGitHubRepoFlags(githubRepo repo.GitHubRepo) string {
+	flags := "public"
+	if githubRepo.IsPrivate {
+		flags = "private"
+	}
+	if githubRepo.IsFork {
+		flags += ", fork"
+	}
+	if githubRepo.IsArchived {
+		flags += ", archived"
+	}
+	return flags
+}
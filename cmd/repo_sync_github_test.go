@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/odyssey/agenc/internal/repo"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+func TestFilterReposNotInLibrary(t *testing.T) {
+	githubRepos := []repo.GitHubRepo{
+		{NameWithOwner: "acme/api"},
+		{NameWithOwner: "acme/web"},
+		{NameWithOwner: "acme/docs"},
+	}
+	libraryRepos := []server.RepoResponse{{Name: "github.com/acme/web"}}
+
+	got := filterReposNotInLibrary(githubRepos, libraryRepos)
+	if len(got) != 2 || got[0].NameWithOwner != "acme/api" || got[1].NameWithOwner != "acme/docs" {
+		t.Errorf("expected acme/api and acme/docs, got %+v", got)
+	}
+}
+
+func TestFormatGitHubRepoFlags(t *testing.T) {
+	tests := []struct {
+		repo repo.GitHubRepo
+		want string
+	}{
+		{repo.GitHubRepo{}, "public"},
+		{repo.GitHubRepo{IsPrivate: true, IsFork: true}, "private, fork"},
+		{repo.GitHubRepo{IsArchived: true}, "public, archived"},
+	}
+	for _, tt := range tests {
+		if got := formatGitHubRepoFlags(tt.repo); got != tt.want {
+			t.Errorf("formatGitHubRepoFlags(%+v) = %q, want %q", tt.repo, got, tt.want)
+		}
+	}
+}
@@ -30,6 +30,10 @@ func runRepoWriteableCopyLs(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to list writeable copies")
 	}
 
+	if isStructuredOutput() {
+		return printStructured(copies)
+	}
+
 	if len(copies) == 0 {
 		fmt.Println("No writeable copies configured.")
 		fmt.Println()
@@ -25,8 +25,9 @@ Setting a writeable copy implies always-synced=true.
 
 After this command writes the config, the AgenC server picks up the change,
 clones the repo to the path if it doesn't exist, and starts the sync loop.`,
-	Args: cobra.ExactArgs(2),
-	RunE: runRepoWriteableCopySet,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runRepoWriteableCopySet,
+	ValidArgsFunction: completeRepoName,
 }
 
 func init() {
@@ -19,8 +19,9 @@ NOT deleted; the user can remove it manually if desired.
 
 The repo can be in any of the formats accepted by 'agenc repo add' — shorthand
 ('owner/repo'), canonical name ('github.com/owner/repo'), or full URL.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runRepoWriteableCopyUnset,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runRepoWriteableCopyUnset,
+	ValidArgsFunction: completeRepoName,
 }
 
 func init() {
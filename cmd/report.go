@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var (
+	reportTodayFlag bool
+	reportWeekFlag  bool
+)
+
+var reportCmd = &cobra.Command{
+	Use:   reportCmdStr,
+	Short: "Report agent-hours per repo and per cron job",
+	Long: `Summarize how long agents spent working, per repo and per cron job — e.g. to
+bill client work done through agents.
+
+Agent time is the time Claude spends busy on a prompt: from the prompt's
+submission until Claude stops and waits for input. Time spent idle, waiting
+for your next prompt, doesn't count. A headless (cron) mission is busy from
+start to exit. Time is recorded as each turn finishes, so a turn still in
+progress shows up once it ends. Time stays attributed to its repo and cron job
+after the mission is deleted.
+
+--today (the default) covers the current calendar day, --week the current
+week starting Monday, both in local time.
+
+Examples:
+  agenc report
+  agenc report --week
+  agenc report --week --output json`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().BoolVar(&reportTodayFlag, reportTodayFlagName, false, "report on today (the default)")
+	reportCmd.Flags().BoolVar(&reportWeekFlag, reportWeekFlagName, false, "report on the current week, starting Monday")
+	reportCmd.MarkFlagsMutuallyExclusive(reportTodayFlagName, reportWeekFlagName)
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	from, to := reportWindow(time.Now(), reportWeekFlag)
+	report, err := client.GetAgentTimeReport(from, to)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get agent time report")
+	}
+	if isStructuredOutput() {
+		return printStructured(report)
+	}
+
+	period := "Today"
+	if reportWeekFlag {
+		period = "This week"
+	}
+	fmt.Printf("%s (%s – %s): %s of agent time\n",
+		period, from.Format("Mon Jan 2"), to.Add(-time.Second).Format("Mon Jan 2"),
+		formatAgentHours(report.TotalSeconds))
+	if report.TotalSeconds == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	repoTbl := tableprinter.NewTable("REPO", "AGENT TIME", "HOURS")
+	for _, total := range report.Repos {
+		repoTbl.AddRow(displayGitRepo(total.Name), formatAgentHours(total.Seconds), fmt.Sprintf("%.2f", float64(total.Seconds)/3600))
+	}
+	repoTbl.Print()
+
+	if len(report.Crons) > 0 {
+		fmt.Println()
+		cronTbl := tableprinter.NewTable("CRON", "AGENT TIME", "HOURS")
+		for _, total := range report.Crons {
+			cronTbl.AddRow(total.Name, formatAgentHours(total.Seconds), fmt.Sprintf("%.2f", float64(total.Seconds)/3600))
+		}
+		cronTbl.Print()
+	}
+	return nil
+}
+
+// reportWindow returns the [from, to) bounds of the report period containing
+// now: its calendar day, or with week set its Monday-to-Sunday week.
+func reportWindow(now time.Time, week bool) (time.Time, time.Time) {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !week {
+		return from, from.AddDate(0, 0, 1)
+	}
+	daysSinceMonday := (int(from.Weekday()) + 6) % 7
+	from = from.AddDate(0, 0, -daysSinceMonday)
+	return from, from.AddDate(0, 0, 7)
+}
+
+// formatAgentHours renders agent time as hours and minutes (e.g. 3h 05m, 12m).
+func formatAgentHours(seconds int64) string {
+	d := time.Duration(seconds) * time.Second
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
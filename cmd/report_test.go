@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportWindow(t *testing.T) {
+	loc := time.FixedZone("test", -7*3600)
+	// Friday afternoon
+	now := time.Date(2026, 10, 16, 15, 30, 0, 0, loc)
+
+	from, to := reportWindow(now, false)
+	if want := time.Date(2026, 10, 16, 0, 0, 0, 0, loc); !from.Equal(want) || !to.Equal(want.AddDate(0, 0, 1)) {
+		t.Errorf("today: got [%v, %v)", from, to)
+	}
+
+	from, to = reportWindow(now, true)
+	if want := time.Date(2026, 10, 12, 0, 0, 0, 0, loc); !from.Equal(want) || !to.Equal(want.AddDate(0, 0, 7)) {
+		t.Errorf("week: got [%v, %v), want to start Monday %v", from, to, want)
+	}
+
+	// On a Sunday the week still started the previous Monday
+	from, _ = reportWindow(time.Date(2026, 10, 18, 9, 0, 0, 0, loc), true)
+	if want := time.Date(2026, 10, 12, 0, 0, 0, 0, loc); !from.Equal(want) {
+		t.Errorf("sunday: got week starting %v, want %v", from, want)
+	}
+}
+
+func TestFormatAgentHours(t *testing.T) {
+	for seconds, want := range map[int64]string{0: "0m", 750: "12m", 3600: "1h 00m", 11100: "3h 05m"} {
+		if got := formatAgentHours(seconds); got != want {
+			t.Errorf("formatAgentHours(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
@@ -1,13 +1,29 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 )
 
 var rootCmd = &cobra.Command{
-	Use:          agencCmdStr,
-	Short:        "The AgenC — agent mission management CLI",
-	SilenceUsage: true,
+	Use:               agencCmdStr,
+	Short:             "The AgenC — agent mission management CLI",
+	SilenceUsage:      true,
+	PersistentPreRunE: runRootPersistentPreRun,
+}
+
+// runRootPersistentPreRun applies the global flags before any command runs,
+// then records state-changing commands in the audit log.
+func runRootPersistentPreRun(cmd *cobra.Command, args []string) error {
+	if err := applyProfileFlag(); err != nil {
+		return err
+	}
+	if err := validateOutputFormat(cmd, args); err != nil {
+		return err
+	}
+	recordCLIAudit(cmd, args)
+	return nil
 }
 
 // Execute runs the root command.
@@ -15,6 +31,24 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// ExitCodeError is returned by commands whose process exit code carries
+// meaning (e.g. `agenc run` in CI). main exits with Code instead of 1.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
 // GetRootCmd returns the root command for documentation generation.
 func GetRootCmd() *cobra.Command {
 	return rootCmd
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/session"
+)
+
+const (
+	// runPollInterval is how often `agenc run` checks the mission's state and
+	// transcript.
+	runPollInterval = time.Second
+
+	// runWrapperStartupGrace is how long `agenc run` waits for the wrapper to
+	// come up before declaring the mission failed to start.
+	runWrapperStartupGrace = 30 * time.Second
+
+	// runExitCodeFailed is the exit code when the mission fails to complete.
+	runExitCodeFailed = 1
+
+	// runExitCodeTimeout is the exit code when --timeout elapses, matching
+	// timeout(1).
+	runExitCodeTimeout = 124
+
+	// runExitCodeInterrupted is the exit code on Ctrl-C or SIGTERM.
+	runExitCodeInterrupted = 130
+)
+
+// Run outcome statuses, reported in --json output.
+const (
+	runStatusSucceeded   = "succeeded"
+	runStatusFailed      = "failed"
+	runStatusTimeout     = "timeout"
+	runStatusInterrupted = "interrupted"
+)
+
+var runRepoFlag string
+var runTimeoutFlag time.Duration
+var runJSONFlag bool
+
+var runCmd = &cobra.Command{
+	Use:   runCmdStr + " <prompt>",
+	Short: "Run a one-shot headless mission and wait for it to finish",
+	Long: fmt.Sprintf(`Run a one-shot headless mission and wait for it to finish.
+
+Creates a headless mission with the given prompt, streams Claude's transcript
+to stdout as it is written, and exits once Claude finishes its turn. Status
+messages go to stderr, so stdout can be piped. The mission's wrapper is
+stopped when the run ends; the mission itself is kept so its transcript can be
+reviewed later with 'agenc mission print'.
+
+If Claude stops to wait for input (e.g. a permission prompt), the run fails
+but the mission is left running so you can attach to it.
+
+With --%s, nothing is streamed; a single JSON object describing the run
+(mission ID, status, duration, and Claude's final message) is printed to
+stdout when the run ends.
+
+Exit codes:
+  0    Claude finished its turn
+  1    the mission failed (wrapper died, Claude needs input, or an error)
+  124  --%s elapsed
+  130  interrupted
+
+Examples:
+  agenc run "Summarize the open TODOs in this repo" --%s owner/repo
+  agenc run "Fix the failing lint checks" --%s owner/repo --%s 30m
+  agenc run "List stale branches" --%s owner/repo --%s | jq -r .result`,
+		runJSONFlagName,
+		runTimeoutFlagName,
+		runRepoFlagName,
+		runRepoFlagName, runTimeoutFlagName,
+		runRepoFlagName, runJSONFlagName,
+	),
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRun,
+}
+
+func init() {
+	runCmd.Flags().StringVar(&runRepoFlag, runRepoFlagName, "", "repo to run the mission in (URL, owner/repo, or local path); omit for a blank mission")
+	runCmd.Flags().DurationVar(&runTimeoutFlag, runTimeoutFlagName, 0, "maximum time to wait for the mission to finish (e.g. 30m); 0 waits indefinitely")
+	runCmd.Flags().BoolVar(&runJSONFlag, runJSONFlagName, false, "print a JSON summary instead of streaming the transcript")
+	_ = runCmd.RegisterFlagCompletionFunc(runRepoFlagName, completeRepoFlag)
+	rootCmd.AddCommand(runCmd)
+}
+
+// runResult is the --json output of `agenc run`.
+type runResult struct {
+	MissionID       string `json:"mission_id"`
+	ShortID         string `json:"short_id"`
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+	DurationSeconds int    `json:"duration_seconds"`
+	Result          string `json:"result"`
+}
+
+// runOutcome is how a run ended. keepMission leaves the wrapper running so the
+// user can attach to it.
+type runOutcome struct {
+	status      string
+	err         error
+	keepMission bool
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	prompt := strings.TrimSpace(strings.Join(args, " "))
+	if prompt == "" {
+		return stacktrace.NewError("prompt cannot be empty")
+	}
+	if runTimeoutFlag < 0 {
+		return stacktrace.NewError("--%s must be zero or greater", runTimeoutFlagName)
+	}
+
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	ensureServerRunning()
+
+	repoName := ""
+	if runRepoFlag != "" {
+		result, err := ResolveRepoInput(runRepoFlag, "Select repo: ")
+		if err != nil {
+			return err
+		}
+		repoName = result.RepoName
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	missionRecord, err := client.CreateMission(server.CreateMissionRequest{
+		Repo:     repoName,
+		Prompt:   prompt,
+		Headless: true,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create mission")
+	}
+	fmt.Fprintf(os.Stderr, "Started mission %s\n", missionRecord.ShortID)
+
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	if runTimeoutFlag > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, runTimeoutFlag)
+		defer cancel()
+	}
+
+	var transcript io.Writer = os.Stdout
+	if runJSONFlag {
+		transcript = nil
+	}
+
+	startedAt := time.Now()
+	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(agencDirpath, missionRecord.ID)
+	outcome := waitForRunCompletion(ctx, client, claudeConfigDirpath, missionRecord.ID, transcript)
+
+	if !outcome.keepMission {
+		if err := client.StopMission(missionRecord.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to stop mission %s: %v\n", missionRecord.ShortID, err)
+		}
+	}
+
+	if runJSONFlag {
+		result := runResult{
+			MissionID:       missionRecord.ID,
+			ShortID:         missionRecord.ShortID,
+			Status:          outcome.status,
+			DurationSeconds: int(time.Since(startedAt).Seconds()),
+		}
+		if outcome.err != nil {
+			result.Error = outcome.err.Error()
+		}
+		if jsonlFilepath := session.FindActiveJSONLPath(claudeConfigDirpath, missionRecord.ID); jsonlFilepath != "" {
+			result.Result = session.ExtractLastAssistantText(jsonlFilepath)
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to marshal run result")
+		}
+		fmt.Println(string(data))
+
+		if outcome.status == runStatusSucceeded {
+			return nil
+		}
+		// The JSON already describes the failure; only the exit code remains
+		cmd.SilenceErrors = true
+		return &ExitCodeError{Code: runExitCode(outcome.status)}
+	}
+
+	if outcome.status == runStatusSucceeded {
+		fmt.Fprintf(os.Stderr, "Mission %s finished in %s\n", missionRecord.ShortID, formatMissionDuration(time.Since(startedAt)))
+		return nil
+	}
+	return &ExitCodeError{
+		Code: runExitCode(outcome.status),
+		Err:  stacktrace.Propagate(outcome.err, "mission %s did not finish", missionRecord.ShortID),
+	}
+}
+
+// waitForRunCompletion polls the mission until Claude finishes its first turn,
+// the mission fails, or ctx ends. When transcript is non-nil, new transcript
+// entries are written to it as they appear.
+func waitForRunCompletion(ctx context.Context, client *server.Client, claudeConfigDirpath string, missionID string, transcript io.Writer) runOutcome {
+	var follower *session.JSONLFollower
+	entriesWritten := 0
+	streamTranscript := func() {
+		if transcript == nil {
+			return
+		}
+		if follower == nil {
+			jsonlFilepath := session.FindActiveJSONLPath(claudeConfigDirpath, missionID)
+			if jsonlFilepath == "" {
+				return
+			}
+			follower = session.NewJSONLFollower(jsonlFilepath)
+		}
+		lines, err := follower.ReadNewLines()
+		if err != nil {
+			return
+		}
+		for _, line := range lines {
+			formatted := session.FormatEntry(line)
+			if formatted == "" {
+				continue
+			}
+			if entriesWritten > 0 {
+				fmt.Fprintln(transcript)
+			}
+			fmt.Fprint(transcript, formatted)
+			entriesWritten++
+		}
+	}
+
+	startedAt := time.Now()
+	sawWrapper := false
+	ticker := time.NewTicker(runPollInterval)
+	defer ticker.Stop()
+
+	for {
+		streamTranscript()
+
+		missionRecord, err := client.GetMission(missionID)
+		if err != nil {
+			return runOutcome{status: runStatusFailed, err: stacktrace.Propagate(err, "failed to fetch mission state")}
+		}
+		if missionRecord.ClaudeState != nil {
+			sawWrapper = true
+		}
+		if outcome, done := classifyRunState(missionRecord, sawWrapper, time.Since(startedAt)); done {
+			// Claude writes its final entries before the Stop hook fires
+			streamTranscript()
+			return outcome
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return runOutcome{status: runStatusTimeout, err: stacktrace.NewError("timed out after %s", runTimeoutFlag)}
+			}
+			return runOutcome{status: runStatusInterrupted, err: stacktrace.NewError("interrupted")}
+		case <-ticker.C:
+		}
+	}
+}
+
+// classifyRunState decides whether a one-shot run has ended given the
+// mission's latest state. A run succeeds once Claude has received the prompt
+// (prompt_count > 0) and gone idle again. Pure function for testability.
+func classifyRunState(m *database.Mission, sawWrapper bool, elapsed time.Duration) (runOutcome, bool) {
+	if m.ClaudeState == nil {
+		if sawWrapper {
+			return runOutcome{status: runStatusFailed, err: stacktrace.NewError("the mission's wrapper exited before Claude finished")}, true
+		}
+		if elapsed > runWrapperStartupGrace {
+			return runOutcome{status: runStatusFailed, err: stacktrace.NewError("the mission's wrapper did not start within %s", runWrapperStartupGrace)}, true
+		}
+		return runOutcome{}, false
+	}
+
+	switch *m.ClaudeState {
+	case "needs_attention":
+		return runOutcome{
+			status:      runStatusFailed,
+			err:         stacktrace.NewError("Claude is waiting for input; attach with 'agenc %s %s %s'", missionCmdStr, attachCmdStr, m.ShortID),
+			keepMission: true,
+		}, true
+	case "idle":
+		if m.PromptCount > 0 {
+			return runOutcome{status: runStatusSucceeded}, true
+		}
+	}
+	return runOutcome{}, false
+}
+
+// runExitCode maps a run status to the process exit code.
+func runExitCode(status string) int {
+	switch status {
+	case runStatusSucceeded:
+		return 0
+	case runStatusTimeout:
+		return runExitCodeTimeout
+	case runStatusInterrupted:
+		return runExitCodeInterrupted
+	default:
+		return runExitCodeFailed
+	}
+}
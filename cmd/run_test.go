@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestClassifyRunState(t *testing.T) {
+	state := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		claudeState *string
+		promptCount int
+		sawWrapper  bool
+		elapsed     time.Duration
+		wantDone    bool
+		wantStatus  string
+		wantKeep    bool
+	}{
+		{name: "wrapper starting", elapsed: time.Second},
+		{name: "wrapper never started", elapsed: runWrapperStartupGrace + time.Second, wantDone: true, wantStatus: runStatusFailed},
+		{name: "wrapper exited", sawWrapper: true, promptCount: 1, wantDone: true, wantStatus: runStatusFailed},
+		{name: "idle before prompt submitted", claudeState: state("idle"), sawWrapper: true},
+		{name: "busy", claudeState: state("busy"), promptCount: 1, sawWrapper: true},
+		{name: "idle after prompt", claudeState: state("idle"), promptCount: 1, sawWrapper: true, wantDone: true, wantStatus: runStatusSucceeded},
+		{name: "needs attention", claudeState: state("needs_attention"), promptCount: 1, sawWrapper: true, wantDone: true, wantStatus: runStatusFailed, wantKeep: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &database.Mission{ShortID: "abc12345", ClaudeState: tt.claudeState, PromptCount: tt.promptCount}
+			outcome, done := classifyRunState(m, tt.sawWrapper, tt.elapsed)
+			if done != tt.wantDone {
+				t.Fatalf("done = %v, want %v", done, tt.wantDone)
+			}
+			if !done {
+				return
+			}
+			if outcome.status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", outcome.status, tt.wantStatus)
+			}
+			if outcome.keepMission != tt.wantKeep {
+				t.Errorf("keepMission = %v, want %v", outcome.keepMission, tt.wantKeep)
+			}
+			if (outcome.status == runStatusSucceeded) != (outcome.err == nil) {
+				t.Errorf("unexpected err %v for status %q", outcome.err, outcome.status)
+			}
+		})
+	}
+}
+
+func TestRunExitCode(t *testing.T) {
+	cases := map[string]int{
+		runStatusSucceeded:   0,
+		runStatusFailed:      runExitCodeFailed,
+		runStatusTimeout:     runExitCodeTimeout,
+		runStatusInterrupted: runExitCodeInterrupted,
+	}
+	for status, want := range cases {
+		if got := runExitCode(status); got != want {
+			t.Errorf("runExitCode(%q) = %d, want %d", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/secrets"
+)
+
+var secretCmd = &cobra.Command{
+	Use:   secretCmdStr,
+	Short: "Manage secrets referenced as secret://NAME in config",
+	Long: fmt.Sprintf(`Manage named secrets that config.yml can reference without containing them.
+
+Values are kept in the OS credential store: the macOS Keychain, the Secret
+Service (GNOME Keyring, KWallet) on Linux, or an encrypted file under
+$AGENC_DIRPATH when no keyring is available. Only the names are written to
+disk in plaintext.
+
+Reference a secret as %sNAME in:
+  - cron env values ('agenc config cron add --env KEY=%sNAME'), resolved
+    when the run's Claude is spawned
+  - a repo's postUpdateHook, resolved each time the hook runs
+  - palette commands, resolved when the command is dispatched
+
+In commands, each value is substituted single-quoted, as one shell word.`,
+		secrets.RefPrefix, secrets.RefPrefix),
+}
+
+func init() {
+	rootCmd.AddCommand(secretCmd)
+}
+
+// openSecretStore returns the secret store for the configured agenc
+// directory.
+func openSecretStore() (*secrets.Store, error) {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.Open(agencDirpath)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/secrets"
+)
+
+var secretGetCmd = &cobra.Command{
+	Use:   getCmdStr + " <name>",
+	Short: "Print a secret's value",
+	Long: `Print a secret's value to stdout, for use in scripts:
+
+  export GITHUB_TOKEN=$(agenc secret get GITHUB_TOKEN)`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runSecretGet,
+	ValidArgsFunction: completeSecretName,
+}
+
+func init() {
+	secretCmd.AddCommand(secretGetCmd)
+}
+
+func runSecretGet(cmd *cobra.Command, args []string) error {
+	store, err := openSecretStore()
+	if err != nil {
+		return err
+	}
+
+	value, err := store.Get(args[0])
+	if err == secrets.ErrNotFound {
+		return stacktrace.NewError("secret '%s' not found", args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(value)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var secretLsCmd = &cobra.Command{
+	Use:   lsCmdStr,
+	Short: "List secrets",
+	Long: `List secret names and when each was last set.
+
+Values are never shown; use 'agenc secret get' for that.`,
+	Args: cobra.NoArgs,
+	RunE: runSecretLs,
+}
+
+func init() {
+	secretCmd.AddCommand(secretLsCmd)
+}
+
+func runSecretLs(cmd *cobra.Command, args []string) error {
+	store, err := openSecretStore()
+	if err != nil {
+		return err
+	}
+
+	list, err := store.List()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list secrets")
+	}
+	if isStructuredOutput() {
+		return printStructured(list)
+	}
+	if len(list) == 0 {
+		fmt.Println("No secrets.")
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("NAME", "UPDATED")
+	for _, secret := range list {
+		tbl.AddRow(secret.Name, secret.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	tbl.Print()
+	return nil
+}
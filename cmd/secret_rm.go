@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/secrets"
+)
+
+var secretRmCmd = &cobra.Command{
+	Use:   rmCmdStr + " <name>",
+	Short: "Delete a secret",
+	Long: `Delete a secret from the credential store.
+
+Config values still referencing it fail to resolve until it is set again.`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runSecretRm,
+	ValidArgsFunction: completeSecretName,
+}
+
+func init() {
+	secretCmd.AddCommand(secretRmCmd)
+}
+
+func runSecretRm(cmd *cobra.Command, args []string) error {
+	store, err := openSecretStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Remove(args[0]); err != nil {
+		if err == secrets.ErrNotFound {
+			return stacktrace.NewError("secret '%s' not found", args[0])
+		}
+		return err
+	}
+
+	fmt.Printf("Deleted secret '%s'\n", args[0])
+	return nil
+}
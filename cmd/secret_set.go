@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var secretSetCmd = &cobra.Command{
+	Use:   setCmdStr + " <name>",
+	Short: "Create or replace a secret",
+	Long: `Create or replace a secret.
+
+The value is never taken as an argument, so it stays out of shell history.
+At a terminal it is prompted for without echo; otherwise it is read from
+stdin, with one trailing newline removed:
+
+  agenc secret set GITHUB_TOKEN
+  gh auth token | agenc secret set GITHUB_TOKEN
+
+Names must start with a letter or underscore and contain only letters,
+numbers, and underscores, like environment variable names.`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runSecretSet,
+	ValidArgsFunction: completeSecretName,
+}
+
+func init() {
+	secretCmd.AddCommand(secretSetCmd)
+}
+
+func runSecretSet(cmd *cobra.Command, args []string) error {
+	store, err := openSecretStore()
+	if err != nil {
+		return err
+	}
+
+	value, err := readSecretValue(args[0])
+	if err != nil {
+		return err
+	}
+	if err := store.Set(args[0], value); err != nil {
+		return err
+	}
+
+	fmt.Printf("Stored secret '%s' (%s backend)\n", args[0], store.BackendName())
+	return nil
+}
+
+// readSecretValue prompts for a value without echo at a terminal, or reads it
+// from stdin otherwise.
+func readSecretValue(name string) (string, error) {
+	if isatty.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprintf(os.Stderr, "Value for %s: ", name)
+		value, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", stacktrace.Propagate(err, "failed to read secret value")
+		}
+		return string(value), nil
+	}
+
+	value, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to read secret value from stdin")
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(value), "\n"), "\r"), nil
+}
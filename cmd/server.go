@@ -5,6 +5,9 @@ import "github.com/spf13/cobra"
 var serverCmd = &cobra.Command{
 	Use:   serverCmdStr,
 	Short: "Manage the AgenC server",
+	// The server was called the daemon before it took over the API; keep
+	// muscle memory like 'agenc daemon upgrade' working.
+	Aliases: []string{"daemon"},
 }
 
 func init() {
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var serverInstallCmd = &cobra.Command{
+	Use:   installCmdStr,
+	Short: "Run the AgenC server as a launchd/systemd service",
+	Long: fmt.Sprintf(`Run the AgenC server as a launchd/systemd service.
+
+By default the server is started on demand by the next agenc command and
+nothing brings it back if it crashes. This installs a service that starts the
+server on login and restarts it whenever it exits unexpectedly:
+
+  macOS   a launchd agent in ~/Library/LaunchAgents (KeepAlive on failure)
+  Linux   a systemd user unit in ~/.config/systemd/user (Restart=on-failure)
+
+The service runs the current agenc binary with this AGENC_DIRPATH and your
+current PATH, and logs to the server log ('agenc server logs'). Any running
+server is stopped first so the service can take over.
+
+'agenc server stop' still stops the server, and the service leaves it stopped;
+'agenc server start' (or any command that needs the server) starts it through
+the service again. Re-run this after moving the agenc binary or changing PATH.
+On Linux, run 'loginctl enable-linger' to also start the server at boot
+without logging in.
+
+Remove the service with '%s %s %s'.`, agencCmdStr, serverCmdStr, uninstallCmdStr),
+	Args: cobra.NoArgs,
+	RunE: runServerInstall,
+}
+
+func init() {
+	serverCmd.AddCommand(serverInstallCmd)
+}
+
+func runServerInstall(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	svc, err := getServerService(agencDirpath)
+	if err != nil {
+		return err
+	}
+	execPath, err := os.Executable()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve agenc binary path")
+	}
+
+	// The service's server can't take the server lock while another holds it
+	pidFilepath := config.GetServerPIDFilepath(agencDirpath)
+	if err := server.StopServer(pidFilepath); err != nil {
+		return stacktrace.Propagate(err, "failed to stop the running server")
+	}
+
+	if err := svc.install(agencDirpath, execPath); err != nil {
+		return err
+	}
+
+	if err := server.WaitForReady(config.GetServerSocketFilepath(agencDirpath)); err != nil {
+		return stacktrace.Propagate(err, "installed %s service '%s' but the server failed to become ready; check '%s %s logs'", svc.kind, svc.label, agencCmdStr, serverCmdStr)
+	}
+	pid, _ := server.ReadPID(pidFilepath)
+	fmt.Printf("Installed %s service '%s' (%s).\n", svc.kind, svc.label, svc.filepath)
+	fmt.Printf("Server running (PID %d); it starts on login and restarts if it crashes.\n", pid)
+	return nil
+}
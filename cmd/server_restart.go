@@ -14,6 +14,7 @@ var serverRestartCmd = &cobra.Command{
 
 func init() {
 	serverCmd.AddCommand(serverRestartCmd)
+	serverRestartCmd.Flags().String(listenFlagName, "", "also serve the API on a loopback TCP address, e.g. tcp:127.0.0.1:7777 (overrides serverListen)")
 }
 
 func runServerRestart(cmd *cobra.Command, args []string) error {
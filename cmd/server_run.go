@@ -26,6 +26,7 @@ var serverRunCmd = &cobra.Command{
 
 func init() {
 	serverCmd.AddCommand(serverRunCmd)
+	serverRunCmd.Flags().String(listenFlagName, "", "also serve the API on a loopback TCP address (overrides serverListen)")
 }
 
 // runServerRun is the actual server process. It is invoked by ForkServer as a
@@ -35,6 +36,11 @@ func runServerRun(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	// Pin the directory so wrappers and hooks started by the server keep
+	// using it even if the current profile is switched later
+	if err := os.Setenv(agencDirpathEnvVar, agencDirpath); err != nil {
+		return stacktrace.Propagate(err, "failed to set %s", agencDirpathEnvVar)
+	}
 
 	pidFilepath := config.GetServerPIDFilepath(agencDirpath)
 
@@ -53,6 +59,9 @@ func runServerRun(cmd *cobra.Command, args []string) error {
 
 	socketFilepath := config.GetServerSocketFilepath(agencDirpath)
 	srv := server.NewServer(agencDirpath, socketFilepath, logger)
+	if listenSpec, _ := cmd.Flags().GetString(listenFlagName); listenSpec != "" {
+		srv.SetListenOverride(listenSpec)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
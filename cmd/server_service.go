@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/launchd"
+	"github.com/odyssey/agenc/internal/systemd"
+)
+
+// serverServiceKind is the service manager supervising the server.
+type serverServiceKind string
+
+const (
+	serverServiceLaunchd serverServiceKind = "launchd"
+	serverServiceSystemd serverServiceKind = "systemd"
+)
+
+// serverService is the launchd job (macOS) or systemd user unit (Linux) that
+// 'agenc server install' sets up to supervise the server.
+type serverService struct {
+	kind serverServiceKind
+	// label is the launchd label or the systemd unit name without ".service"
+	label    string
+	filepath string
+}
+
+// getServerService returns the server service for this platform. Errors on
+// platforms with neither launchd nor systemd.
+func getServerService(agencDirpath string) (*serverService, error) {
+	label := config.GetServerServiceLabel(agencDirpath)
+	switch runtime.GOOS {
+	case "darwin":
+		dirpath, err := launchd.PlistDirpath()
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to get LaunchAgents directory")
+		}
+		return &serverService{kind: serverServiceLaunchd, label: label, filepath: filepath.Join(dirpath, label+".plist")}, nil
+	case "linux":
+		dirpath, err := systemd.UnitDirpath()
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to get systemd user unit directory")
+		}
+		return &serverService{kind: serverServiceSystemd, label: label, filepath: filepath.Join(dirpath, systemd.UnitFilename(label))}, nil
+	default:
+		return nil, stacktrace.NewError("running the server as a service is not supported on %s", runtime.GOOS)
+	}
+}
+
+// installedServerService returns the server service if it is installed, or
+// nil if it isn't or the platform has no supported service manager.
+func installedServerService(agencDirpath string) *serverService {
+	svc, err := getServerService(agencDirpath)
+	if err != nil || !svc.isInstalled() {
+		return nil
+	}
+	return svc
+}
+
+// isInstalled reports whether the service file exists.
+func (s *serverService) isInstalled() bool {
+	_, err := os.Stat(s.filepath)
+	return err == nil
+}
+
+// install writes the service file for running `<execPath> server run` and
+// loads it, which starts the server. An existing service is replaced. The
+// service restarts the server whenever it exits unsuccessfully; 'agenc
+// server stop' makes it exit cleanly, so a deliberate stop sticks.
+func (s *serverService) install(agencDirpath string, execPath string) error {
+	env := map[string]string{
+		agencDirpathEnvVar: agencDirpath,
+		// Service managers start jobs with a minimal PATH; missions need the
+		// user's to find claude, git, gh, and tmux
+		"PATH": os.Getenv("PATH"),
+	}
+	args := []string{execPath, serverCmdStr, runCmdStr}
+	logFilepath := config.GetServerLogFilepath(agencDirpath)
+
+	if err := os.MkdirAll(filepath.Dir(s.filepath), 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create directory for '%s'", s.filepath)
+	}
+
+	switch s.kind {
+	case serverServiceLaunchd:
+		plist := &launchd.Plist{
+			Label:                s.label,
+			ProgramArguments:     args,
+			EnvironmentVariables: env,
+			StandardOutPath:      logFilepath,
+			StandardErrorPath:    logFilepath,
+			RunAtLoad:            true,
+			KeepAliveOnFailure:   true,
+		}
+		data, err := plist.GeneratePlistXML()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to generate plist")
+		}
+
+		manager := launchd.NewManager()
+		if err := manager.UnloadPlist(s.filepath); err != nil && s.isInstalled() {
+			return stacktrace.Propagate(err, "failed to unload existing server service")
+		}
+		if err := os.WriteFile(s.filepath, data, 0644); err != nil {
+			return stacktrace.Propagate(err, "failed to write plist '%s'", s.filepath)
+		}
+		if err := manager.LoadPlist(s.filepath); err != nil {
+			return stacktrace.Propagate(err, "failed to load server service")
+		}
+	case serverServiceSystemd:
+		if err := systemd.VerifySystemctlAvailable(); err != nil {
+			return err
+		}
+		unit := &systemd.Unit{
+			Description:          "AgenC server (" + agencDirpath + ")",
+			ExecStart:            args,
+			EnvironmentVariables: env,
+			StandardOutPath:      logFilepath,
+			StandardErrorPath:    logFilepath,
+			RestartOnFailure:     true,
+		}
+		if err := os.WriteFile(s.filepath, unit.GenerateUnitFile(), 0644); err != nil {
+			return stacktrace.Propagate(err, "failed to write unit file '%s'", s.filepath)
+		}
+		if err := systemd.NewManager().EnableUnit(systemd.UnitFilename(s.label)); err != nil {
+			return stacktrace.Propagate(err, "failed to enable server service")
+		}
+	}
+	return nil
+}
+
+// uninstall stops the service and removes its file.
+func (s *serverService) uninstall() error {
+	switch s.kind {
+	case serverServiceLaunchd:
+		if err := launchd.NewManager().RemovePlist(s.filepath); err != nil {
+			return stacktrace.Propagate(err, "failed to remove server service")
+		}
+	case serverServiceSystemd:
+		manager := systemd.NewManager()
+		if err := manager.DisableUnit(systemd.UnitFilename(s.label)); err != nil {
+			return stacktrace.Propagate(err, "failed to disable server service")
+		}
+		if err := os.Remove(s.filepath); err != nil && !os.IsNotExist(err) {
+			return stacktrace.Propagate(err, "failed to delete unit file '%s'", s.filepath)
+		}
+		if err := manager.ReloadUnits(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start asks the service manager to start the server now.
+func (s *serverService) start() error {
+	switch s.kind {
+	case serverServiceLaunchd:
+		return launchd.NewManager().StartJob(s.label)
+	case serverServiceSystemd:
+		return systemd.NewManager().StartUnit(systemd.UnitFilename(s.label))
+	}
+	return nil
+}
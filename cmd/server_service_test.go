@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestGetServerService(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		if _, err := getServerService(t.TempDir()); err == nil {
+			t.Fatal("expected an error on a platform without launchd or systemd")
+		}
+		return
+	}
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, ".config"))
+	agencDirpath := filepath.Join(tmpDir, ".agenc")
+
+	svc, err := getServerService(agencDirpath)
+	if err != nil {
+		t.Fatalf("getServerService returned error: %v", err)
+	}
+	if svc.label != "agenc-server" {
+		t.Errorf("expected label 'agenc-server', got %q", svc.label)
+	}
+
+	wantFilepath := filepath.Join(tmpDir, ".config", "systemd", "user", "agenc-server.service")
+	if runtime.GOOS == "darwin" {
+		wantFilepath = filepath.Join(tmpDir, "Library", "LaunchAgents", "agenc-server.plist")
+	}
+	if svc.filepath != wantFilepath {
+		t.Errorf("expected service file %q, got %q", wantFilepath, svc.filepath)
+	}
+
+	if installedServerService(agencDirpath) != nil {
+		t.Error("expected no installed service before the file exists")
+	}
+	if err := os.MkdirAll(filepath.Dir(svc.filepath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(svc.filepath, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if installedServerService(agencDirpath) == nil {
+		t.Error("expected the service to be reported installed once its file exists")
+	}
+}
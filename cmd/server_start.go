@@ -13,11 +13,22 @@ import (
 var serverStartCmd = &cobra.Command{
 	Use:   startCmdStr,
 	Short: "Start the AgenC server",
-	RunE:  runServerStart,
+	Long: `Start the AgenC server.
+
+The server always listens on a unix socket that only the current user can
+reach. Pass --listen (or set serverListen in config.yml) to additionally
+expose the API on a loopback TCP address for local GUI tools:
+
+  agenc server start --listen tcp:127.0.0.1:7777
+
+Every request on the TCP listener must carry a bearer token created with
+'agenc config token create'.`,
+	RunE: runServerStart,
 }
 
 func init() {
 	serverCmd.AddCommand(serverStartCmd)
+	serverStartCmd.Flags().String(listenFlagName, "", "also serve the API on a loopback TCP address, e.g. tcp:127.0.0.1:7777 (overrides serverListen)")
 }
 
 func runServerStart(cmd *cobra.Command, args []string) error {
@@ -25,10 +36,17 @@ func runServerStart(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	return forkServer(agencDirpath)
+	listenSpec, err := cmd.Flags().GetString(listenFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", listenFlagName)
+	}
+	return forkServer(agencDirpath, listenSpec)
 }
 
-func forkServer(agencDirpath string) error {
+// forkServer starts the server in the background and waits for it to accept
+// connections. A non-empty listenSpec is passed through as --listen. When the
+// server service is installed, the service manager starts it instead.
+func forkServer(agencDirpath string, listenSpec string) error {
 	pidFilepath := config.GetServerPIDFilepath(agencDirpath)
 	logFilepath := config.GetServerLogFilepath(agencDirpath)
 
@@ -38,7 +56,30 @@ func forkServer(agencDirpath string) error {
 		return nil
 	}
 
-	if err := server.ForkServer(logFilepath, pidFilepath); err != nil {
+	if svc := installedServerService(agencDirpath); svc != nil {
+		if listenSpec != "" {
+			return stacktrace.NewError("--%s can't be used while the server runs as a %s service; set serverListen in config.yml instead", listenFlagName, svc.kind)
+		}
+		if err := svc.start(); err != nil {
+			return stacktrace.Propagate(err, "failed to start the server through %s", svc.kind)
+		}
+		if err := server.WaitForReady(config.GetServerSocketFilepath(agencDirpath)); err != nil {
+			return stacktrace.Propagate(err, "server service started but the server failed to become ready")
+		}
+		newPID, _ := server.ReadPID(pidFilepath)
+		fmt.Printf("Server started by %s (PID %d).\n", svc.kind, newPID)
+		return nil
+	}
+
+	var extraArgs []string
+	if listenSpec != "" {
+		if _, err := config.ParseServerListenAddr(listenSpec); err != nil {
+			return err
+		}
+		extraArgs = append(extraArgs, "--"+listenFlagName, listenSpec)
+	}
+
+	if err := server.ForkServer(logFilepath, pidFilepath, extraArgs...); err != nil {
 		return stacktrace.Propagate(err, "failed to fork server")
 	}
 
@@ -54,8 +95,9 @@ func forkServer(agencDirpath string) error {
 }
 
 // ensureServerRunning idempotently starts the server if not already running,
-// and waits for it to be ready to accept connections. Resolves the agenc
-// directory path internally via config.GetAgencDirpath().
+// and waits for it to be ready to accept connections. Starts it through the
+// server service when one is installed. Resolves the agenc directory path
+// internally via config.GetAgencDirpath().
 func ensureServerRunning() {
 	agencDirpath, err := config.GetAgencDirpath()
 	if err != nil {
@@ -66,9 +108,15 @@ func ensureServerRunning() {
 	if server.IsRunning(pidFilepath) {
 		return
 	}
-	logFilepath := config.GetServerLogFilepath(agencDirpath)
-	if err := server.ForkServer(logFilepath, pidFilepath); err != nil {
-		return
+	if svc := installedServerService(agencDirpath); svc != nil {
+		if err := svc.start(); err != nil {
+			return
+		}
+	} else {
+		logFilepath := config.GetServerLogFilepath(agencDirpath)
+		if err := server.ForkServer(logFilepath, pidFilepath); err != nil {
+			return
+		}
 	}
 	socketFilepath := config.GetServerSocketFilepath(agencDirpath)
 	_ = server.WaitForReady(socketFilepath)
@@ -3,23 +3,52 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
 )
 
 var serverStatusCmd = &cobra.Command{
 	Use:   statusCmdStr,
 	Short: "Check AgenC server status",
-	RunE:  runServerStatus,
+	Long: `Check whether the AgenC server is running and whether its background loops
+are healthy.
+
+--verbose also shows the server's version and uptime, the mission start
+queue, whether quiet hours are active, the last cron sync to launchd, and for
+each cron its next fire time and last run. Use it to find out why a cron
+didn't run without reading the server logs.`,
+	RunE: runServerStatus,
 }
 
+var serverStatusVerboseFlag bool
+
 func init() {
+	serverStatusCmd.Flags().BoolVarP(&serverStatusVerboseFlag, verboseFlagName, "v", false, "also show uptime, queue depth, cron sync stats, and each cron's next fire time")
 	serverCmd.AddCommand(serverStatusCmd)
 }
 
+// serverStatusOutput is the --output json|yaml form of `server status`.
+// Health is nil when the server is not running or its health endpoint could
+// not be reached, in which case HealthError says why. Status is only filled
+// with --verbose.
+type serverStatusOutput struct {
+	Running     bool                   `json:"running"`
+	PID         int                    `json:"pid,omitempty"`
+	Health      *server.HealthResponse `json:"health"`
+	HealthError string                 `json:"health_error,omitempty"`
+	// Service is the service manager supervising the server ("launchd" or
+	// "systemd"), empty when 'agenc server install' hasn't been run
+	Service     string                       `json:"service,omitempty"`
+	Status      *server.ServerStatusResponse `json:"status,omitempty"`
+	StatusError string                       `json:"status_error,omitempty"`
+}
+
 func runServerStatus(cmd *cobra.Command, args []string) error {
 	agencDirpath, err := ensureConfigured()
 	if err != nil {
@@ -32,12 +61,44 @@ func runServerStatus(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var serviceKind string
+	svc := installedServerService(agencDirpath)
+	if svc != nil {
+		serviceKind = string(svc.kind)
+	}
+
 	if pid <= 0 || !server.IsRunning(pidFilepath) {
+		if isStructuredOutput() {
+			return printStructured(serverStatusOutput{Service: serviceKind})
+		}
 		fmt.Println("Server is not running.")
 		return nil
 	}
 
+	if isStructuredOutput() {
+		output := serverStatusOutput{Running: true, PID: pid, Service: serviceKind}
+		client := server.NewClient(config.GetServerSocketFilepath(agencDirpath))
+		health, err := client.GetHealth()
+		if err != nil {
+			output.HealthError = err.Error()
+		} else {
+			output.Health = health
+		}
+		if serverStatusVerboseFlag {
+			status, err := client.GetServerStatus()
+			if err != nil {
+				output.StatusError = err.Error()
+			} else {
+				output.Status = status
+			}
+		}
+		return printStructured(output)
+	}
+
 	fmt.Printf("Server is running (PID %d).\n", pid)
+	if svc != nil {
+		fmt.Printf("Supervised by %s service '%s'.\n", svc.kind, svc.label)
+	}
 
 	// Try to get detailed health from the server
 	socketFilepath := config.GetServerSocketFilepath(agencDirpath)
@@ -70,5 +131,93 @@ func runServerStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if !serverStatusVerboseFlag {
+		return nil
+	}
+	status, err := client.GetServerStatus()
+	if err != nil {
+		fmt.Printf("\n  (could not reach status endpoint: %v)\n", err)
+		return nil
+	}
+	printServerStatusDetails(status, time.Now())
 	return nil
 }
+
+// printServerStatusDetails renders the --verbose part of `server status`.
+func printServerStatusDetails(status *server.ServerStatusResponse, now time.Time) {
+	fmt.Println()
+	fmt.Printf("Version:      %s\n", status.Version)
+	if !status.StartedAt.IsZero() {
+		uptime := (time.Duration(status.UptimeSeconds) * time.Second).String()
+		fmt.Printf("Uptime:       %s (since %s)\n", uptime, status.StartedAt.Local().Format("2006-01-02 15:04"))
+	}
+	queueCap := "no cap"
+	if status.MissionsMaxConcurrent > 0 {
+		queueCap = fmt.Sprintf("cap %d", status.MissionsMaxConcurrent)
+	}
+	fmt.Printf("Start queue:  %d waiting (%s)\n", status.QueueDepth, queueCap)
+	quietHours := "inactive"
+	if status.QuietHoursActive {
+		quietHours = ansiYellow + "active" + ansiReset + " (scheduled crons are deferred)"
+	}
+	fmt.Printf("Quiet hours:  %s\n", quietHours)
+
+	fmt.Println()
+	fmt.Println("Cron sync:")
+	syncStats := status.CronSync
+	if syncStats.Syncs == 0 {
+		fmt.Println("  no sync to launchd since the server started")
+	} else {
+		fmt.Printf("  last sync %s (took %s): %d synced, %d failed; %d syncs since start\n",
+			formatTimeAgo(syncStats.LastSyncAt, now), syncStats.LastDuration, syncStats.LastSynced, len(syncStats.CronErrors), syncStats.Syncs)
+		if syncStats.LastError != "" {
+			fmt.Printf("  %serror:%s %s\n", ansiRed, ansiReset, syncStats.LastError)
+		}
+	}
+
+	if len(status.Crons) == 0 {
+		return
+	}
+	fmt.Println()
+	tbl := tableprinter.NewTable("CRON", "NEXT FIRE", "LAST RUN", "STATUS", "DETAIL")
+	for _, cronStatus := range status.Crons {
+		lastRun, runStatus := "--", "--"
+		if cronStatus.LastRun != nil {
+			lastRun = cronStatus.LastRun.StartedAt.Local().Format("2006-01-02 15:04")
+			runStatus = colorizeCronRunStatus(cronStatus.LastRun.Status)
+		}
+		tbl.AddRow(cronStatus.Name, formatCronNextFire(cronStatus), lastRun, runStatus, formatCronStatusDetail(cronStatus))
+	}
+	tbl.Print()
+}
+
+// formatCronNextFire returns when a cron fires next, or why it won't.
+func formatCronNextFire(cronStatus server.CronScheduleStatus) string {
+	switch {
+	case !cronStatus.Enabled:
+		return ansiYellow + "disabled" + ansiReset
+	case !cronStatus.Scheduled:
+		return ansiYellow + "not scheduled" + ansiReset
+	case len(cronStatus.NextFires) == 0:
+		return "--"
+	default:
+		return cronStatus.NextFires[0].Local().Format("2006-01-02 15:04")
+	}
+}
+
+// formatCronStatusDetail explains a cron's state: a failed launchd sync comes
+// first since it keeps the cron from firing at all, then the upstream it
+// waits on, then the detail of its last run.
+func formatCronStatusDetail(cronStatus server.CronScheduleStatus) string {
+	var parts []string
+	if cronStatus.SyncError != "" {
+		parts = append(parts, ansiRed+"sync failed: "+cronStatus.SyncError+ansiReset)
+	}
+	if cronStatus.After != "" {
+		parts = append(parts, "after "+cronStatus.After)
+	}
+	if cronStatus.LastRun != nil && cronStatus.LastRun.Detail != "" {
+		parts = append(parts, cronStatus.LastRun.Detail)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+func TestFormatCronNextFire(t *testing.T) {
+	next := time.Date(2026, 6, 1, 6, 0, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		status server.CronScheduleStatus
+		want   string
+	}{
+		{"scheduled", server.CronScheduleStatus{Enabled: true, Scheduled: true, NextFires: []time.Time{next}}, "2026-06-01 06:00"},
+		{"disabled", server.CronScheduleStatus{Scheduled: true}, "disabled"},
+		{"past one-shot", server.CronScheduleStatus{Enabled: true}, "not scheduled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCronNextFire(tt.status); !strings.Contains(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatCronStatusDetail(t *testing.T) {
+	status := server.CronScheduleStatus{
+		SyncError: "failed to load plist",
+		After:     "fetch",
+		LastRun:   &server.CronRunResponse{Status: "skipped", Detail: "upstream cron 'fetch' has not succeeded today"},
+	}
+	got := formatCronStatusDetail(status)
+	syncIdx := strings.Index(got, "sync failed: failed to load plist")
+	afterIdx := strings.Index(got, "after fetch")
+	runIdx := strings.Index(got, "has not succeeded today")
+	if syncIdx < 0 || afterIdx < syncIdx || runIdx < afterIdx {
+		t.Errorf("expected sync error, upstream, then last run detail, got %q", got)
+	}
+	if got := formatCronStatusDetail(server.CronScheduleStatus{}); got != "" {
+		t.Errorf("expected no detail for a healthy cron, got %q", got)
+	}
+}
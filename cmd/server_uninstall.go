@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var serverUninstallCmd = &cobra.Command{
+	Use:   uninstallCmdStr,
+	Short: "Remove the launchd/systemd service for the AgenC server",
+	Long: `Remove the launchd/systemd service for the AgenC server.
+
+Stops the server and deletes the service installed by 'agenc server install'.
+The server goes back to being started on demand by the next agenc command.`,
+	Args: cobra.NoArgs,
+	RunE: runServerUninstall,
+}
+
+func init() {
+	serverCmd.AddCommand(serverUninstallCmd)
+}
+
+func runServerUninstall(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	svc, err := getServerService(agencDirpath)
+	if err != nil {
+		return err
+	}
+	if !svc.isInstalled() {
+		fmt.Println("Server service is not installed.")
+		return nil
+	}
+
+	if err := svc.uninstall(); err != nil {
+		return err
+	}
+	// Unloading stops the service's server; clear out its PID file too
+	_ = server.StopServer(config.GetServerPIDFilepath(agencDirpath))
+
+	fmt.Printf("Removed %s service '%s'. The server is stopped and will start on demand.\n", svc.kind, svc.label)
+	return nil
+}
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/version"
+)
+
+const (
+	agencGitHubRepo        = "mieubrisse/agenc"
+	githubReleasesAPIURL   = "https://api.github.com/repos/" + agencGitHubRepo + "/releases"
+	githubReleasesDownload = "https://github.com/" + agencGitHubRepo + "/releases/download"
+	releaseChecksumsName   = "checksums.txt"
+	releaseDownloadTimeout = 5 * time.Minute
+)
+
+// installMethod is how the running agenc binary was installed, which decides
+// how 'agenc server upgrade' replaces it.
+type installMethod string
+
+const (
+	installMethodHomebrew installMethod = "homebrew"
+	installMethodApt      installMethod = "apt"
+	installMethodRelease  installMethod = "release"
+)
+
+var serverUpgradeCmd = &cobra.Command{
+	Use:   upgradeCmdStr,
+	Short: "Upgrade agenc and hand running missions over to the new binary",
+	Long: `Upgrade agenc and hand running missions over to the new binary.
+
+Installs the latest release using the same method agenc was installed with:
+
+  homebrew   brew update && brew upgrade agenc
+  apt        sudo apt-get update && sudo apt-get install --only-upgrade agenc
+  release    downloads the release archive from GitHub (from the
+             releaseChannel), verifies its checksum and signature, and
+             replaces the agenc binary in place
+
+Then restarts the server on the new binary and queues a reload for every
+running mission. Each reload fires when Claude next goes idle, so in-flight
+turns finish first and conversations resume in the same tmux pane — nothing
+is lost. Missions keep running the old binary until their reload fires.
+
+If agenc was already upgraded (e.g. with 'brew upgrade agenc'), pass
+--skip-install to only restart the server and reload missions.
+
+The server's in-memory mission queue (missionsMaxConcurrent) does not survive
+the restart; queued missions are left created but stopped.`,
+	Args: cobra.NoArgs,
+	RunE: runServerUpgrade,
+}
+
+func init() {
+	serverCmd.AddCommand(serverUpgradeCmd)
+	serverUpgradeCmd.Flags().Bool(skipInstallFlagName, false, "don't install anything; only restart the server and reload running missions")
+}
+
+func runServerUpgrade(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	skipInstall, err := cmd.Flags().GetBool(skipInstallFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", skipInstallFlagName)
+	}
+
+	execPath, err := os.Executable()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve agenc binary path")
+	}
+	if resolved, err := filepath.EvalSymlinks(execPath); err == nil {
+		execPath = resolved
+	}
+
+	newBinpath := execPath
+	if !skipInstall {
+		method := detectInstallMethod(execPath)
+		fmt.Printf("Upgrading agenc %s (installed via %s)...\n", version.Version, method)
+		newBinpath, err = installLatestAgenc(method, execPath, configuredReleaseChannel(agencDirpath))
+		if err != nil {
+			return err
+		}
+
+		newVersion, err := readBinaryVersion(newBinpath)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read the version of the installed binary at %s", newBinpath)
+		}
+		if newVersion == version.Version {
+			fmt.Printf("agenc is already up to date (%s).\n", newVersion)
+			return nil
+		}
+		fmt.Printf("Installed agenc %s.\n", newVersion)
+	}
+
+	return restartServerOnBinary(agencDirpath, newBinpath)
+}
+
+// restartServerOnBinary restarts the server through the binary at binpath
+// and queues a reload for every running mission so it moves to that binary
+// too. Restarting through binpath matters: the running CLI's own path may
+// already be gone (Homebrew removes the old keg on cleanup).
+func restartServerOnBinary(agencDirpath string, binpath string) error {
+	restartCmd := exec.Command(binpath, serverCmdStr, restartCmdStr)
+	restartCmd.Stdout = os.Stdout
+	restartCmd.Stderr = os.Stderr
+	if err := restartCmd.Run(); err != nil {
+		return stacktrace.Propagate(err, "failed to restart the server on the new binary")
+	}
+
+	client := server.NewClient(config.GetServerSocketFilepath(agencDirpath))
+	return reloadRunningMissionsForUpgrade(client)
+}
+
+// reloadRunningMissionsForUpgrade queues an on-idle reload for every running
+// mission so its wrapper respawns on the restarted server's binary. A failure
+// on one mission is reported and the rest still reload.
+func reloadRunningMissionsForUpgrade(client *server.Client) error {
+	missions, err := client.ListMissions(server.ListMissionsRequest{})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list missions")
+	}
+
+	runningMissions := filterRunningMissions(missions)
+	if len(runningMissions) == 0 {
+		fmt.Println("No running missions to reload.")
+		return nil
+	}
+
+	queued := 0
+	for _, m := range runningMissions {
+		if err := client.ReloadMission(m.ID, "", true); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Failed to queue reload for mission %s: %v\n", database.ShortID(m.ID), err)
+			continue
+		}
+		queued++
+	}
+	fmt.Printf("Queued reloads for %d of %d running mission(s); each fires when Claude is next idle.\n", queued, len(runningMissions))
+	return nil
+}
+
+// detectInstallMethod infers how the binary at execPath (symlinks resolved)
+// was installed.
+func detectInstallMethod(execPath string) installMethod {
+	if strings.Contains(execPath, "/Cellar/agenc/") {
+		return installMethodHomebrew
+	}
+	if execPath == "/usr/bin/agenc" {
+		if err := exec.Command("dpkg", "-S", execPath).Run(); err == nil {
+			return installMethodApt
+		}
+	}
+	return installMethodRelease
+}
+
+// installLatestAgenc installs the latest agenc release with the given method
+// and returns the path of the new binary. channel picks the GitHub release
+// when installing from one; package managers have their own.
+func installLatestAgenc(method installMethod, execPath string, channel string) (string, error) {
+	switch method {
+	case installMethodHomebrew:
+		if err := runInteractive("brew", "update"); err != nil {
+			return "", stacktrace.Propagate(err, "brew update failed")
+		}
+		if err := runInteractive("brew", "upgrade", agencCmdStr); err != nil {
+			return "", stacktrace.Propagate(err, "brew upgrade failed")
+		}
+		prefix, err := exec.Command("brew", "--prefix").Output()
+		if err != nil {
+			return "", stacktrace.Propagate(err, "failed to get the Homebrew prefix")
+		}
+		return filepath.Join(strings.TrimSpace(string(prefix)), "bin", agencCmdStr), nil
+	case installMethodApt:
+		if err := runInteractive("sudo", "apt-get", "update"); err != nil {
+			return "", stacktrace.Propagate(err, "apt-get update failed")
+		}
+		if err := runInteractive("sudo", "apt-get", "install", "--only-upgrade", "-y", agencCmdStr); err != nil {
+			return "", stacktrace.Propagate(err, "apt-get install failed")
+		}
+		return execPath, nil
+	default:
+		if err := installLatestRelease(execPath, channel); err != nil {
+			return "", err
+		}
+		return execPath, nil
+	}
+}
+
+// runInteractive runs a command attached to the terminal so package managers
+// can show progress and prompt for a sudo password.
+func runInteractive(name string, args ...string) error {
+	c := exec.Command(name, args...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// readBinaryVersion runs '<binpath> version' and returns the version it
+// reports.
+func readBinaryVersion(binpath string) (string, error) {
+	output, err := exec.Command(binpath, versionCmdStr).Output()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "'%s %s' failed", binpath, versionCmdStr)
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return "", stacktrace.NewError("'%s %s' printed nothing", binpath, versionCmdStr)
+	}
+	return fields[len(fields)-1], nil
+}
+
+// installLatestRelease installs the newest GitHub release on channel over
+// the binary at execPath. Already being on that version or a newer one is a
+// no-op.
+func installLatestRelease(execPath string, channel string) error {
+	httpClient := &http.Client{Timeout: releaseDownloadTimeout}
+	release, err := fetchChannelRelease(httpClient, channel)
+	if err != nil {
+		return err
+	}
+	if !isNewerRelease(release.TagName, version.Version) {
+		return nil
+	}
+	return installRelease(httpClient, execPath, release.TagName, true)
+}
+
+// installRelease downloads the release archive for tag and this platform,
+// verifies it against the release checksums and, with verifySignature, its
+// build provenance attestation, then atomically replaces the binary at
+// execPath.
+func installRelease(httpClient *http.Client, execPath string, tag string, verifySignature bool) error {
+	archiveName := releaseArchiveName(strings.TrimPrefix(tag, "v"), runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("Downloading %s...\n", archiveName)
+
+	checksums, err := httpGetBytes(httpClient, fmt.Sprintf("%s/%s/%s", githubReleasesDownload, tag, releaseChecksumsName))
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to download release checksums")
+	}
+	wantChecksum, err := findReleaseChecksum(string(checksums), archiveName)
+	if err != nil {
+		return err
+	}
+
+	archive, err := httpGetBytes(httpClient, fmt.Sprintf("%s/%s/%s", githubReleasesDownload, tag, archiveName))
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to download %s", archiveName)
+	}
+	gotChecksum := sha256.Sum256(archive)
+	if hex.EncodeToString(gotChecksum[:]) != wantChecksum {
+		return stacktrace.NewError("checksum mismatch for %s; refusing to install", archiveName)
+	}
+	if verifySignature {
+		if err := verifyReleaseAttestation(archiveName, archive); err != nil {
+			return err
+		}
+	}
+
+	binary, err := extractBinaryFromArchive(archive, agencCmdStr)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to extract %s from %s", agencCmdStr, archiveName)
+	}
+	return replaceBinary(execPath, binary)
+}
+
+func httpGetBytes(httpClient *http.Client, url string) ([]byte, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "GET %s failed", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, stacktrace.NewError("GET %s returned %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// releaseArchiveName returns the release archive name for a platform,
+// matching the archive name_template in .goreleaser.yaml.
+func releaseArchiveName(releaseVersion string, goos string, goarch string) string {
+	return fmt.Sprintf("%s_%s_%s_%s.tar.gz", agencCmdStr, releaseVersion, goos, goarch)
+}
+
+// findReleaseChecksum returns the sha256 for filename from a checksums.txt
+// in sha256sum format.
+func findReleaseChecksum(checksums string, filename string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == filename {
+			return fields[0], nil
+		}
+	}
+	return "", stacktrace.NewError("no checksum for %s in the release; this platform may not be supported", filename)
+}
+
+// extractBinaryFromArchive returns the contents of the file named binaryName
+// at the top level of a .tar.gz archive.
+func extractBinaryFromArchive(archive []byte, binaryName string) ([]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to open gzip stream")
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, stacktrace.NewError("archive does not contain %s", binaryName)
+		}
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to read archive")
+		}
+		if header.Typeflag == tar.TypeReg && header.Name == binaryName {
+			return io.ReadAll(tarReader)
+		}
+	}
+}
+
+// replaceBinary atomically swaps the file at binpath for contents. Running
+// processes keep the old inode, so live wrappers are unaffected until they
+// reload.
+func replaceBinary(binpath string, contents []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(binpath), "."+agencCmdStr+"-upgrade-*")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to stage the new binary next to %s (is the directory writable?)", binpath)
+	}
+	tmpFilepath := tmpFile.Name()
+	defer os.Remove(tmpFilepath)
+
+	if _, err := tmpFile.Write(contents); err != nil {
+		tmpFile.Close()
+		return stacktrace.Propagate(err, "failed to write the new binary")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return stacktrace.Propagate(err, "failed to write the new binary")
+	}
+	if err := os.Chmod(tmpFilepath, 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to make the new binary executable")
+	}
+	if err := os.Rename(tmpFilepath, binpath); err != nil {
+		return stacktrace.Propagate(err, "failed to replace %s", binpath)
+	}
+	return nil
+}
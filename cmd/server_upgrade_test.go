@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+)
+
+func TestReleaseArchiveName(t *testing.T) {
+	if got, want := releaseArchiveName("1.4.0", "darwin", "arm64"), "agenc_1.4.0_darwin_arm64.tar.gz"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFindReleaseChecksum(t *testing.T) {
+	checksums := "aaa111  agenc_1.4.0_darwin_arm64.tar.gz\nbbb222  agenc_1.4.0_linux_amd64.tar.gz\n"
+
+	got, err := findReleaseChecksum(checksums, "agenc_1.4.0_linux_amd64.tar.gz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "bbb222" {
+		t.Errorf("got %q, want %q", got, "bbb222")
+	}
+
+	if _, err := findReleaseChecksum(checksums, "agenc_1.4.0_windows_amd64.tar.gz"); err == nil {
+		t.Error("expected an error for a missing platform")
+	}
+}
+
+func TestExtractBinaryFromArchive(t *testing.T) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, contents := range map[string]string{"README.md": "docs", "agenc": "binary"} {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(contents)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		if _, err := tarWriter.Write([]byte(contents)); err != nil {
+			t.Fatalf("failed to write contents: %v", err)
+		}
+	}
+	tarWriter.Close()
+	gzipWriter.Close()
+
+	got, err := extractBinaryFromArchive(buf.Bytes(), "agenc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "binary" {
+		t.Errorf("got %q, want %q", got, "binary")
+	}
+
+	if _, err := extractBinaryFromArchive(buf.Bytes(), "missing"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestDetectInstallMethod(t *testing.T) {
+	if got := detectInstallMethod("/opt/homebrew/Cellar/agenc/1.4.0/bin/agenc"); got != installMethodHomebrew {
+		t.Errorf("Cellar path: got %q, want %q", got, installMethodHomebrew)
+	}
+	if got := detectInstallMethod("/home/user/.local/bin/agenc"); got != installMethodRelease {
+		t.Errorf("local path: got %q, want %q", got, installMethodRelease)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -10,9 +11,10 @@ import (
 )
 
 // checkServerVersion compares the running server's version against the CLI
-// version. If the versions differ, it prints a warning. Also stops any stale
-// daemon process from a pre-server version. All errors are silently ignored —
-// this check must never block CLI commands.
+// version. If the versions differ, it prints a warning — a stronger one when
+// their API versions are incompatible, since commands will then fail. Also
+// stops any stale daemon process from a pre-server version. All errors are
+// silently ignored — this check must never block CLI commands.
 func checkServerVersion(agencDirpath string) {
 	// Clean up any leftover daemon directory from a pre-server version of agenc.
 	cleanupDaemonDir(agencDirpath)
@@ -23,25 +25,52 @@ func checkServerVersion(agencDirpath string) {
 		return
 	}
 
-	// Use the health endpoint to get the server version
 	socketFilepath := config.GetServerSocketFilepath(agencDirpath)
 	client := server.NewClient(socketFilepath)
 
-	var healthResp struct {
-		Status  string `json:"status"`
-		Version string `json:"version"`
+	serverVersion, err := fetchServerVersion(client)
+	if err != nil {
+		var apiErr *version.IncompatibleAPIError
+		if errors.As(err, &apiErr) {
+			fmt.Fprintf(os.Stderr, "⚠ %s.\n", apiErr)
+		}
+		return
 	}
-	if err := client.Get("/health", &healthResp); err != nil {
+	if serverVersion.MinSupportedClientAPIVersion > version.ServerAPIVersion {
+		fmt.Fprintf(os.Stderr, "⚠ Server is running %s, which requires API v%d or newer, but CLI %s speaks API v%d. Run 'agenc upgrade'.\n",
+			serverVersion.Version, serverVersion.MinSupportedClientAPIVersion, version.Version, version.ServerAPIVersion)
 		return
 	}
 
-	serverVersion := healthResp.Version
 	cliVersion := version.Version
-	if serverVersion == cliVersion || serverVersion == "" {
+	if serverVersion.Version == cliVersion || serverVersion.Version == "" {
 		return
 	}
 
-	fmt.Fprintf(os.Stderr, "⚠ Server is running %s but CLI is %s. Run 'agenc server restart' to upgrade.\n", serverVersion, cliVersion)
+	fmt.Fprintf(os.Stderr, "⚠ Server is running %s but CLI is %s. Run 'agenc server restart' to upgrade.\n", serverVersion.Version, cliVersion)
+}
+
+// fetchServerVersion asks the server for its versions via GET /version,
+// falling back to the release version from GET /health for servers that
+// predate the version endpoint.
+func fetchServerVersion(client *server.Client) (*server.VersionResponse, error) {
+	serverVersion, err := client.GetVersion()
+	if err == nil {
+		return serverVersion, nil
+	}
+	var apiErr *version.IncompatibleAPIError
+	if errors.As(err, &apiErr) {
+		return nil, err
+	}
+
+	var healthResp struct {
+		Status  string `json:"status"`
+		Version string `json:"version"`
+	}
+	if err := client.Get("/health", &healthResp); err != nil {
+		return nil, err
+	}
+	return &server.VersionResponse{Version: healthResp.Version}, nil
 }
 
 // stopStaleDaemon stops any leftover daemon process from a pre-server version
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
@@ -20,9 +21,21 @@ var sessionLsCmd = &cobra.Command{
 
 func init() {
 	sessionLsCmd.Flags().StringVar(&sessionLsMissionFlag, "mission", "", "filter by mission ID or short ID")
+	_ = sessionLsCmd.RegisterFlagCompletionFunc("mission", completeMissionFlag)
 	sessionCmd.AddCommand(sessionLsCmd)
 }
 
+// sessionOutput is the --output json|yaml form of a session.
+type sessionOutput struct {
+	ID          string    `json:"id"`
+	ShortID     string    `json:"short_id"`
+	MissionID   string    `json:"mission_id"`
+	Title       string    `json:"title"`
+	AutoSummary string    `json:"auto_summary"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 func runSessionLs(cmd *cobra.Command, args []string) error {
 	client, err := serverClient()
 	if err != nil {
@@ -39,6 +52,22 @@ func runSessionLs(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to list sessions")
 	}
 
+	if isStructuredOutput() {
+		outputs := make([]sessionOutput, 0, len(sessions))
+		for _, s := range sessions {
+			outputs = append(outputs, sessionOutput{
+				ID:          s.ID,
+				ShortID:     s.ShortID,
+				MissionID:   s.MissionID,
+				Title:       resolveSessionTitle(s),
+				AutoSummary: s.AutoSummary,
+				CreatedAt:   s.CreatedAt,
+				UpdatedAt:   s.UpdatedAt,
+			})
+		}
+		return printStructured(outputs)
+	}
+
 	if len(sessions) == 0 {
 		fmt.Println("No sessions.")
 		return nil
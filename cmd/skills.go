@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var skillsCmd = &cobra.Command{
+	Use:   skillsCmdStr,
+	Short: "Install Claude skills from git repos",
+	Long: `Install Claude skills from git repos into ~/.claude/skills.
+
+'agenc skills add owner/skill-repo' clones the repo into ~/.claude/skills and
+commits it to the shadow repo as plain files, along with a record of where it
+came from. With a shadow repo remote set ('agenc config shadow remote set'),
+other machines receive the skill on their next sync, so every machine runs
+the same skills. Running missions pick up the change without a reload.
+
+Skills written by hand in ~/.claude/skills are listed too, but only skills
+installed with 'agenc skills add' can be updated or removed here.`,
+}
+
+func init() {
+	rootCmd.AddCommand(skillsCmd)
+}
+
+// printRefreshedMissions reports how many running missions a skill change
+// reached.
+func printRefreshedMissions(resp *server.SkillChangeResponse) {
+	if resp.RefreshedMissions > 0 {
+		fmt.Printf("Refreshed skills in %d running mission(s)\n", resp.RefreshedMissions)
+	}
+}
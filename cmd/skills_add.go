@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var skillsAddCmd = &cobra.Command{
+	Use:   addCmdStr + " <repo>",
+	Short: "Install a skill from a git repo",
+	Long: `Install a skill from a git repo into ~/.claude/skills.
+
+The repo is given as owner/repo, host/owner/repo, or a git URL, and must have
+a SKILL.md at its root. It is installed under the repo's name unless --name
+is given, committed to the shadow repo, and copied into running missions.
+
+Examples:
+  agenc skills add owner/pdf-skill
+  agenc skills add git@github.com:owner/review-skill.git --name=review`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillsAdd,
+}
+
+func init() {
+	skillsCmd.AddCommand(skillsAddCmd)
+	skillsAddCmd.Flags().String(skillsAddNameFlagName, "", "name to install the skill under (default: the repo's name)")
+}
+
+func runSkillsAdd(cmd *cobra.Command, args []string) error {
+	name, err := cmd.Flags().GetString(skillsAddNameFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", skillsAddNameFlagName)
+	}
+
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.AddSkill(server.AddSkillRequest{Repo: args[0], Name: name})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to install skill from '%s'", args[0])
+	}
+
+	fmt.Printf("Installed skill '%s' from %s at %s\n", resp.Skill.Name, resp.Skill.Repo, shortCommit(resp.Skill.Commit))
+	printRefreshedMissions(resp)
+	return nil
+}
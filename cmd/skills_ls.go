@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+var skillsLsCmd = &cobra.Command{
+	Use:   lsCmdStr,
+	Short: "List skills",
+	Long: `List the skills in ~/.claude/skills, with the repo and commit each installed
+skill came from. Skills written by hand show '--' as their repo.`,
+	Args: cobra.NoArgs,
+	RunE: runSkillsLs,
+}
+
+func init() {
+	skillsCmd.AddCommand(skillsLsCmd)
+}
+
+func runSkillsLs(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	skills, err := client.ListSkills()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list skills")
+	}
+	if isStructuredOutput() {
+		return printStructured(skills)
+	}
+	if len(skills) == 0 {
+		fmt.Printf("No skills. Install one with '%s %s %s owner/skill-repo'.\n", agencCmdStr, skillsCmdStr, addCmdStr)
+		return nil
+	}
+
+	tbl := tableprinter.NewTable("NAME", "REPO", "COMMIT", "INSTALLED")
+	for _, skill := range skills {
+		if skill.Repo == "" {
+			tbl.AddRow(skill.Name, "--", "--", "--")
+			continue
+		}
+		installed := "--"
+		if skill.InstalledAt != nil {
+			installed = skill.InstalledAt.Local().Format("2006-01-02 15:04")
+		}
+		tbl.AddRow(skill.Name, displayGitRepo(skill.Repo), shortCommit(skill.Commit), installed)
+	}
+	tbl.Print()
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var skillsRmCmd = &cobra.Command{
+	Use:   rmCmdStr + " <name>",
+	Short: "Remove an installed skill",
+	Long: `Remove a skill installed with 'agenc skills add' from ~/.claude/skills and the
+shadow repo. Running missions stop seeing it without a reload.`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runSkillsRm,
+	ValidArgsFunction: completeManagedSkillName,
+}
+
+func init() {
+	skillsCmd.AddCommand(skillsRmCmd)
+}
+
+func runSkillsRm(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.RemoveSkill(args[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to remove skill '%s'", args[0])
+	}
+
+	fmt.Printf("Removed skill '%s'\n", resp.Skill.Name)
+	printRefreshedMissions(resp)
+	return nil
+}
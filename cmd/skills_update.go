@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+)
+
+var skillsUpdateCmd = &cobra.Command{
+	Use:   updateCmdStr + " [name]",
+	Short: "Update installed skills to their repos' latest commit",
+	Long: `Pull the latest commit of an installed skill's repo and install it if it
+changed. Without a name, every skill installed with 'agenc skills add' is
+updated.`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runSkillsUpdate,
+	ValidArgsFunction: completeManagedSkillName,
+}
+
+func init() {
+	skillsCmd.AddCommand(skillsUpdateCmd)
+}
+
+func runSkillsUpdate(cmd *cobra.Command, args []string) error {
+	client, err := serverClient()
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		skills, err := client.ListSkills()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to list skills")
+		}
+		for _, skill := range skills {
+			if skill.Repo != "" {
+				names = append(names, skill.Name)
+			}
+		}
+		if len(names) == 0 {
+			fmt.Println("No skills installed from repos.")
+			return nil
+		}
+	}
+
+	var failed []string
+	for _, name := range names {
+		resp, err := client.UpdateSkill(name)
+		if err != nil {
+			fmt.Printf("Failed to update skill '%s': %v\n", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		if !resp.Changed {
+			fmt.Printf("Skill '%s' is up to date (%s)\n", name, shortCommit(resp.Skill.Commit))
+			continue
+		}
+		fmt.Printf("Updated skill '%s' to %s\n", name, shortCommit(resp.Skill.Commit))
+		printRefreshedMissions(resp)
+	}
+	if len(failed) > 0 {
+		return stacktrace.NewError("failed to update %d skill(s)", len(failed))
+	}
+	return nil
+}
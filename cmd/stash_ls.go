@@ -30,6 +30,10 @@ func runStashLs(cmd *cobra.Command, args []string) error {
 		return stacktrace.Propagate(err, "failed to list stashes")
 	}
 
+	if isStructuredOutput() {
+		return printStructured(stashes)
+	}
+
 	if len(stashes) == 0 {
 		fmt.Println("No stashed workspaces.")
 		return nil
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/tableprinter"
+)
+
+// deferredErrorMaxLen caps the last error shown per deferred operation.
+const deferredErrorMaxLen = 60
+
+var statusCmd = &cobra.Command{
+	Use:   statusCmdStr,
+	Short: "Show whether AgenC is running and can reach git remotes",
+	Long: `Show whether the AgenC server is running and whether it can reach git remotes.
+
+When GitHub or another remote is unreachable, AgenC keeps working offline:
+new missions start from the repo library's cached clone instead of failing
+on a fetch, and repo syncs that can't reach their remote are deferred and
+retried with backoff (1 minute, doubling up to 30) until connectivity
+returns.
+
+--connectivity also lists each deferred sync with its attempts, next retry,
+and last error.`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+var statusConnectivityFlag bool
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusConnectivityFlag, connectivityFlagName, false, "also list repo syncs deferred until their remote is reachable")
+	rootCmd.AddCommand(statusCmd)
+}
+
+// statusOutput is the --output json|yaml form of `status`. Connectivity is
+// nil when the server is not running or could not be reached, in which case
+// ConnectivityError says why.
+type statusOutput struct {
+	Running           bool                         `json:"running"`
+	PID               int                          `json:"pid,omitempty"`
+	Connectivity      *server.ConnectivityResponse `json:"connectivity,omitempty"`
+	ConnectivityError string                       `json:"connectivity_error,omitempty"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+
+	pidFilepath := config.GetServerPIDFilepath(agencDirpath)
+	pid, err := server.ReadPID(pidFilepath)
+	if err != nil {
+		return err
+	}
+	if pid <= 0 || !server.IsRunning(pidFilepath) {
+		if isStructuredOutput() {
+			return printStructured(statusOutput{})
+		}
+		fmt.Printf("Server is not running; start it with '%s %s %s'.\n", agencCmdStr, serverCmdStr, startCmdStr)
+		return nil
+	}
+
+	client := server.NewClient(config.GetServerSocketFilepath(agencDirpath))
+	connectivity, connectivityErr := client.GetConnectivity()
+
+	if isStructuredOutput() {
+		output := statusOutput{Running: true, PID: pid, Connectivity: connectivity}
+		if connectivityErr != nil {
+			output.ConnectivityError = connectivityErr.Error()
+		}
+		return printStructured(output)
+	}
+
+	fmt.Printf("Server:        running (PID %d)\n", pid)
+	if connectivityErr != nil {
+		fmt.Printf("Connectivity:  unknown (%v)\n", connectivityErr)
+		return nil
+	}
+	now := time.Now()
+	fmt.Printf("Connectivity:  %s\n", formatConnectivitySummary(connectivity, now))
+
+	if !statusConnectivityFlag {
+		if len(connectivity.Deferred) > 0 {
+			fmt.Printf("\nRun '%s %s --%s' to see the deferred syncs.\n", agencCmdStr, statusCmdStr, connectivityFlagName)
+		}
+		return nil
+	}
+	printConnectivityDetails(connectivity, now)
+	return nil
+}
+
+// formatConnectivitySummary renders the one-line connectivity state: online
+// or offline, and how many repo syncs are deferred.
+func formatConnectivitySummary(connectivity *server.ConnectivityResponse, now time.Time) string {
+	summary := ansiGreen + "online" + ansiReset
+	if !connectivity.Online {
+		summary = ansiYellow + "offline" + ansiReset
+		if connectivity.OfflineSince != nil {
+			summary += " since " + formatTimeAgo(*connectivity.OfflineSince, now)
+		}
+	}
+	switch len(connectivity.Deferred) {
+	case 0:
+	case 1:
+		summary += "; 1 repo sync deferred"
+	default:
+		summary += fmt.Sprintf("; %d repo syncs deferred", len(connectivity.Deferred))
+	}
+	return summary
+}
+
+// printConnectivityDetails renders the --connectivity part of `status`.
+func printConnectivityDetails(connectivity *server.ConnectivityResponse, now time.Time) {
+	lastOnline := "not since the server started"
+	if connectivity.LastOnlineAt != nil {
+		lastOnline = formatTimeAgo(*connectivity.LastOnlineAt, now)
+	}
+	fmt.Printf("Last reached:  %s\n", lastOnline)
+	if connectivity.LastError != "" {
+		fmt.Printf("Last error:    %s\n", connectivity.LastError)
+	}
+
+	fmt.Println()
+	if len(connectivity.Deferred) == 0 {
+		fmt.Println("No deferred repo syncs.")
+		return
+	}
+	tbl := tableprinter.NewTable("REPO", "OPERATION", "ATTEMPTS", "DEFERRED", "NEXT RETRY", "LAST ERROR")
+	for _, op := range connectivity.Deferred {
+		tbl.AddRow(
+			displayGitRepo(op.Repo),
+			op.Operation,
+			fmt.Sprintf("%d", op.Attempts),
+			formatTimeAgo(op.DeferredAt, now),
+			formatNextRetry(op.NextAttemptAt, now),
+			truncatePrompt(op.LastError, deferredErrorMaxLen),
+		)
+	}
+	tbl.Print()
+}
+
+// formatNextRetry renders when a deferred operation is retried next. Repo
+// syncs run once a minute, so an operation already due waits for the next
+// cycle.
+func formatNextRetry(nextAttemptAt time.Time, now time.Time) string {
+	wait := nextAttemptAt.Sub(now)
+	if wait < time.Minute {
+		return "next sync cycle"
+	}
+	return fmt.Sprintf("in %dm", int(wait.Round(time.Minute).Minutes()))
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+func TestFormatConnectivitySummary(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	offlineSince := now.Add(-25 * time.Minute)
+	tests := []struct {
+		name         string
+		connectivity server.ConnectivityResponse
+		want         []string
+	}{
+		{"online", server.ConnectivityResponse{Online: true}, []string{"online"}},
+		{"offline", server.ConnectivityResponse{
+			OfflineSince: &offlineSince,
+			Deferred:     []server.DeferredOperation{{Repo: "github.com/owner/app"}, {Repo: "github.com/owner/lib"}},
+		}, []string{"offline", "since 25m ago", "2 repo syncs deferred"}},
+		{"one deferred", server.ConnectivityResponse{
+			Online:   true,
+			Deferred: []server.DeferredOperation{{Repo: "github.com/owner/app"}},
+		}, []string{"online", "1 repo sync deferred"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatConnectivitySummary(&tt.connectivity, now)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in %q", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatNextRetry(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	if got := formatNextRetry(now.Add(-time.Minute), now); got != "next sync cycle" {
+		t.Errorf("expected a due retry to wait for the next cycle, got %q", got)
+	}
+	if got := formatNextRetry(now.Add(8*time.Minute+10*time.Second), now); got != "in 8m" {
+		t.Errorf("expected %q, got %q", "in 8m", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 var tmuxAttachCmd = &cobra.Command{
@@ -56,7 +57,7 @@ func runTmuxAttach(cmd *cobra.Command, args []string) error {
 
 	// Attach to the session. If the session was destroyed (e.g. user cancelled
 	// the picker before we attached), exit cleanly.
-	attachCmd := exec.Command("tmux", "attach-session", "-t", "="+sessionName)
+	attachCmd := tmux.Command("attach-session", "-t", "="+sessionName)
 	attachCmd.Stdin = os.Stdin
 	attachCmd.Stdout = os.Stdout
 	attachCmd.Stderr = os.Stderr
@@ -95,7 +96,7 @@ func createTmuxSession(agencBinaryPath string, sessionName string) error {
 	}
 	initialCmd += agencBinaryPath + " " + missionCmdStr + " " + newCmdStr
 
-	newSessionCmd := exec.Command("tmux",
+	newSessionCmd := tmux.Command(
 		"new-session",
 		"-d",
 		"-s", sessionName,
@@ -127,7 +128,7 @@ func shellQuote(s string) string {
 
 // setTmuxSessionEnv sets an environment variable on the given tmux session.
 func setTmuxSessionEnv(sessionName string, key string, value string) error {
-	err := exec.Command("tmux", "set-environment", "-t", "="+sessionName, key, value).Run()
+	err := tmux.Command("set-environment", "-t", "="+sessionName, key, value).Run()
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to set tmux session environment variable %s", key)
 	}
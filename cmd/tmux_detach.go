@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"os"
-	"os/exec"
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 var tmuxDetachCmd = &cobra.Command{
@@ -20,7 +21,7 @@ func init() {
 }
 
 func runTmuxDetach(cmd *cobra.Command, args []string) error {
-	detachCmd := exec.Command("tmux", "detach-client")
+	detachCmd := tmux.Command("detach-client")
 	detachCmd.Stdin = os.Stdin
 	detachCmd.Stdout = os.Stdout
 	detachCmd.Stderr = os.Stderr
@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/odyssey/agenc/internal/config"
@@ -47,7 +46,7 @@ func checkTmuxVersion() error {
 // Uses tmux exact-match syntax (=name) to prevent prefix matching
 // (e.g., "agenc" would otherwise match "agenc-pool").
 func tmuxSessionExists(sessionName string) bool {
-	err := exec.Command("tmux", "has-session", "-t", "="+sessionName).Run()
+	err := agentmux.Command("has-session", "-t", "="+sessionName).Run()
 	return err == nil
 }
 
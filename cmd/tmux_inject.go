@@ -133,19 +133,24 @@ func buildSentinelBlock(displayFilepath string) string {
 // source-file directive in the user's tmux.conf. displayFilepath is the
 // portable form (with ~ instead of $HOME) written into the directive.
 func injectTmuxConfSourceLine(displayFilepath string) error {
+	return upsertTmuxConfBlock(sentinelBegin, sentinelEnd, buildSentinelBlock(displayFilepath), "source-file directive")
+}
+
+// upsertTmuxConfBlock idempotently adds sentinelBlock to the user's tmux.conf,
+// or replaces the block between beginSentinel and endSentinel if one is
+// already there. description names the block in progress messages.
+func upsertTmuxConfBlock(beginSentinel string, endSentinel string, sentinelBlock string, description string) error {
 	tmuxConfFilepath, exists, err := findTmuxConfFilepath()
 	if err != nil {
 		return err
 	}
 
-	sentinelBlock := buildSentinelBlock(displayFilepath)
-
 	if !exists {
 		// Create the file with just the sentinel block
 		if err := os.WriteFile(tmuxConfFilepath, []byte(sentinelBlock+"\n"), 0644); err != nil {
 			return stacktrace.Propagate(err, "failed to create '%s'", tmuxConfFilepath)
 		}
-		fmt.Printf("Created %s with source-file directive\n", tmuxConfFilepath)
+		fmt.Printf("Created %s with %s\n", tmuxConfFilepath, description)
 		return nil
 	}
 
@@ -155,23 +160,23 @@ func injectTmuxConfSourceLine(displayFilepath string) error {
 	}
 	fileContent := string(content)
 
-	beginIdx := strings.Index(fileContent, sentinelBegin)
-	endIdx := strings.Index(fileContent, sentinelEnd)
+	beginIdx := strings.Index(fileContent, beginSentinel)
+	endIdx := strings.Index(fileContent, endSentinel)
 
 	if beginIdx >= 0 && endIdx >= 0 {
 		// Sentinel block exists — check if it's identical
-		existingBlock := fileContent[beginIdx : endIdx+len(sentinelEnd)]
+		existingBlock := fileContent[beginIdx : endIdx+len(endSentinel)]
 		if existingBlock == sentinelBlock {
 			fmt.Printf("Already configured in %s\n", tmuxConfFilepath)
 			return nil
 		}
 
-		// Different path — replace the block
-		newContent := fileContent[:beginIdx] + sentinelBlock + fileContent[endIdx+len(sentinelEnd):]
+		// Different content — replace the block
+		newContent := fileContent[:beginIdx] + sentinelBlock + fileContent[endIdx+len(endSentinel):]
 		if err := os.WriteFile(tmuxConfFilepath, []byte(newContent), 0644); err != nil {
 			return stacktrace.Propagate(err, "failed to update '%s'", tmuxConfFilepath)
 		}
-		fmt.Printf("Updated source-file directive in %s\n", tmuxConfFilepath)
+		fmt.Printf("Updated %s in %s\n", description, tmuxConfFilepath)
 		return nil
 	}
 
@@ -185,6 +190,6 @@ func injectTmuxConfSourceLine(displayFilepath string) error {
 	if err := os.WriteFile(tmuxConfFilepath, []byte(appendContent), 0644); err != nil {
 		return stacktrace.Propagate(err, "failed to update '%s'", tmuxConfFilepath)
 	}
-	fmt.Printf("Added source-file directive to %s\n", tmuxConfFilepath)
+	fmt.Printf("Added %s to %s\n", description, tmuxConfFilepath)
 	return nil
 }
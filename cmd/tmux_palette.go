@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -10,6 +12,8 @@ import (
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/secrets"
+	"github.com/odyssey/agenc/internal/tmux"
 	"github.com/spf13/cobra"
 )
 
@@ -24,6 +28,11 @@ run-shell from echoing it into the active pane.
 
 On cancel (Ctrl-C or Esc), the popup closes with no action.
 
+Commands containing {{input:Label}} placeholders prompt for each value in the
+popup before dispatch; submitting an empty value cancels. --run skips the
+picker and goes straight to those prompts for the named command — this is how
+keybindings for such commands are wired.
+
 This command is designed to be invoked by the palette keybinding
 (prefix + a, k).`,
 	Args: cobra.NoArgs,
@@ -32,15 +41,17 @@ This command is designed to be invoked by the palette keybinding
 
 func init() {
 	tmuxCmd.AddCommand(tmuxPaletteCmd)
+	tmuxPaletteCmd.Flags().String(paletteRunFlagName, "", "run the named palette command directly, skipping the picker")
 }
 
 // buildPaletteEntries returns the resolved palette entries from config,
 // followed by "Open <repo>" entries for each repo in the library.
 // Only entries with a non-empty Title are included in the palette.
 // Mission-scoped entries are excluded when callingMissionUUID is empty (i.e.
-// the palette was opened from a pane that is not running a mission).
+// the palette was opened from a pane that is not running a mission), and
+// entries that drive tmux are excluded when insideTmux is false.
 // On config read failure, returns an error.
-func buildPaletteEntries(callingMissionUUID string) ([]config.ResolvedPaletteCommand, error) {
+func buildPaletteEntries(callingMissionUUID string, insideTmux bool) ([]config.ResolvedPaletteCommand, error) {
 	agencDirpath, err := config.GetAgencDirpath()
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to get agenc dirpath")
@@ -62,6 +73,9 @@ func buildPaletteEntries(callingMissionUUID string) ([]config.ResolvedPaletteCom
 		if cmd.IsMissionScoped() && callingMissionUUID == "" {
 			continue
 		}
+		if cmd.RequiresTmux() && !insideTmux {
+			continue
+		}
 		entries = append(entries, cmd)
 	}
 
@@ -173,11 +187,35 @@ func runTmuxPalette(cmd *cobra.Command, args []string) error {
 	}
 
 	callingMissionUUID := os.Getenv(config.CallingMissionUUIDEnvVar)
-	entries, err := buildPaletteEntries(callingMissionUUID)
+
+	runName, err := cmd.Flags().GetString(paletteRunFlagName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read --%s flag", paletteRunFlagName)
+	}
+	if runName != "" {
+		entry, err := findPaletteCommand(runName)
+		if err != nil {
+			return err
+		}
+		return dispatchPaletteCommand(entry, callingMissionUUID)
+	}
+
+	entries, err := buildPaletteEntries(callingMissionUUID, true)
 	if err != nil {
 		return err
 	}
 
+	selectedEntry, err := pickPaletteEntry(entries)
+	if err != nil || selectedEntry == nil {
+		return err
+	}
+
+	return dispatchPaletteCommand(*selectedEntry, callingMissionUUID)
+}
+
+// pickPaletteEntry shows the entries in fzf and returns the one selected, or
+// nil when the user cancels.
+func pickPaletteEntry(entries []config.ResolvedPaletteCommand) (*config.ResolvedPaletteCommand, error) {
 	// Build fzf input: one line per entry.
 	// Variation selectors (U+FE0F) are stripped so that emoji width is
 	// consistent across tmux, the terminal, and fzf — preventing layout jitter.
@@ -209,13 +247,13 @@ func runTmuxPalette(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		// fzf exits with code 130 on Ctrl-C/Esc — treat as clean cancel
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 130 {
-			return nil
+			return nil, nil
 		}
 		// fzf exits with code 1 when no match — also treat as cancel
 		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
-			return nil
+			return nil, nil
 		}
-		return stacktrace.Propagate(err, "fzf selection failed")
+		return nil, stacktrace.Propagate(err, "fzf selection failed")
 	}
 
 	// Parse selection: extract the title (everything before "  —  ")
@@ -227,20 +265,44 @@ func runTmuxPalette(cmd *cobra.Command, args []string) error {
 
 	// Find the matching palette entry (compare against the plain display title
 	// since fzf strips ANSI codes from its output)
-	var selectedEntry *config.ResolvedPaletteCommand
 	for i := range entries {
 		if plainDisplayTitle(entries[i]) == selectedTitle {
-			selectedEntry = &entries[i]
-			break
+			return &entries[i], nil
+		}
+	}
+	return nil, stacktrace.NewError("unknown palette selection: %q", selectedTitle)
+}
+
+// findPaletteCommand returns the resolved palette command with the given name,
+// including keybinding-only commands that have no palette title.
+func findPaletteCommand(name string) (config.ResolvedPaletteCommand, error) {
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return config.ResolvedPaletteCommand{}, stacktrace.Propagate(err, "failed to get agenc dirpath")
+	}
+	cfg, _, err := config.ReadAgencConfig(agencDirpath)
+	if err != nil {
+		return config.ResolvedPaletteCommand{}, stacktrace.Propagate(err, "failed to read config for palette commands")
+	}
+	for _, entry := range cfg.GetResolvedPaletteCommands() {
+		if entry.Name == name {
+			return entry, nil
 		}
 	}
-	if selectedEntry == nil {
-		return stacktrace.NewError("unknown palette selection: %q", selectedTitle)
+	return config.ResolvedPaletteCommand{}, stacktrace.NewError("palette command '%s' not found", name)
+}
+
+// dispatchPaletteCommand prepares the entry's command (see
+// preparePaletteCommand) and hands it to the tmux server via run-shell -b.
+func dispatchPaletteCommand(entry config.ResolvedPaletteCommand, callingMissionUUID string) error {
+	entry, ok, err := preparePaletteCommand(entry)
+	if err != nil || !ok {
+		return err
 	}
 
-	fullCommand := buildPaletteDispatchCommand(*selectedEntry, callingMissionUUID)
+	fullCommand := buildPaletteDispatchCommand(entry, callingMissionUUID)
 
-	runShellCmd := exec.Command("tmux", "run-shell", "-b", fullCommand)
+	runShellCmd := tmux.Command("run-shell", "-b", fullCommand)
 	runShellCmd.Stdout = os.Stdout
 	runShellCmd.Stderr = os.Stderr
 	if err := runShellCmd.Run(); err != nil {
@@ -250,6 +312,66 @@ func runTmuxPalette(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// preparePaletteCommand resolves the entry's secret://NAME references and
+// fills in its {{input:Label}} placeholders, prompting on the terminal.
+// Returns ok=false when an empty answer cancels the command.
+func preparePaletteCommand(entry config.ResolvedPaletteCommand) (config.ResolvedPaletteCommand, bool, error) {
+	// Secrets go first so a typed input that happens to look like a
+	// reference is never expanded
+	if secrets.HasRefs(entry.Command) {
+		agencDirpath, err := config.GetAgencDirpath()
+		if err != nil {
+			return entry, false, stacktrace.Propagate(err, "failed to get agenc dirpath")
+		}
+		store, err := secrets.Open(agencDirpath)
+		if err != nil {
+			return entry, false, err
+		}
+		expanded, err := store.ExpandShellCommand(entry.Command)
+		if err != nil {
+			return entry, false, stacktrace.Propagate(err, "failed to resolve secrets for palette command '%s'", entry.Name)
+		}
+		entry.Command = expanded
+	}
+
+	if entry.HasInputs() {
+		values, ok, err := promptPaletteInputs(entry, os.Stdin, os.Stdout)
+		if err != nil || !ok {
+			return entry, false, err
+		}
+		entry.Command = entry.FillInputs(values)
+	}
+	return entry, true, nil
+}
+
+// promptPaletteInputs asks for each of the entry's input labels, one line at
+// a time. Returns ok=false when the user submits an empty value or closes
+// the input (Ctrl-D), meaning the command should not run.
+func promptPaletteInputs(entry config.ResolvedPaletteCommand, in io.Reader, out io.Writer) (map[string]string, bool, error) {
+	title := entry.Title
+	if title == "" {
+		title = entry.Name
+	}
+	fmt.Fprintf(out, "%s%s%s\n", ansiBold, stripVariationSelectors(title), ansiReset)
+	fmt.Fprintf(out, "%s(empty value cancels)%s\n\n", ansiDarkGray, ansiReset)
+
+	reader := bufio.NewReader(in)
+	values := make(map[string]string)
+	for _, label := range entry.InputLabels() {
+		fmt.Fprintf(out, "%s: ", label)
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, false, stacktrace.Propagate(err, "failed to read value for '%s'", label)
+		}
+		value := strings.TrimSpace(line)
+		if value == "" {
+			return nil, false, nil
+		}
+		values[label] = value
+	}
+	return values, true, nil
+}
+
 // buildPaletteDispatchCommand assembles the full shell command for a palette
 // entry, including env exports and output redirection. It handles two contexts:
 //   - run-shell (no pane): exports AGENC_CALLING_PANE_ID and
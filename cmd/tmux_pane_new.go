@@ -2,11 +2,12 @@ package cmd
 
 import (
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 const (
@@ -69,7 +70,7 @@ func runTmuxPaneNew(cmd *cobra.Command, args []string) error {
 	}
 	tmuxArgs = append(tmuxArgs, userCommand)
 
-	splitCmd := exec.Command("tmux", tmuxArgs...)
+	splitCmd := tmux.Command(tmuxArgs...)
 	splitCmd.Stdout = os.Stdout
 	splitCmd.Stderr = os.Stderr
 
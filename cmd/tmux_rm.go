@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"fmt"
-	"os/exec"
 
 	"github.com/mieubrisse/stacktrace"
 	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 var tmuxRmCmd = &cobra.Command{
@@ -36,7 +37,7 @@ func runTmuxRm(cmd *cobra.Command, args []string) error {
 	// Kill the tmux session. This sends SIGHUP to all processes in the session.
 	// The wrapper handles SIGHUP gracefully (forwards to Claude, waits for exit,
 	// runs deferred cleanup including PID file removal).
-	if err := exec.Command("tmux", "kill-session", "-t", "="+sessionName).Run(); err != nil {
+	if err := tmux.Command("kill-session", "-t", "="+sessionName).Run(); err != nil {
 		return stacktrace.Propagate(err, "failed to kill tmux session")
 	}
 
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/launchd"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+var tmuxStatusCmd = &cobra.Command{
+	Use:   statusCmdStr,
+	Short: "Print a compact AgenC status segment for the tmux status bar",
+	Long: fmt.Sprintf(`Print a one-line AgenC status segment for tmux's status-right, e.g.:
+
+  agenc 3 running · 1 busy · 1 attention · cron 14:00
+
+It shows how many missions have a running wrapper, how many of those have
+Claude working or waiting on you, and when the next enabled cron fires
+(today's crons show just the time, later ones the weekday or date). Zero counts and the cron part are left
+out when empty.
+
+The command reads the database and config directly and asks each running
+wrapper for its state over its socket; it never goes through the AgenC server,
+so it stays cheap enough for tmux to run on every status refresh. It prints
+nothing and exits 0 when AgenC isn't set up, so a broken install doesn't
+clutter the status bar.
+
+'%s %s %s %s' adds the segment to status-right in your tmux.conf.`,
+		agencCmdStr, tmuxCmdStr, statusCmdStr, tmuxStatusSetupCmdStr),
+	Args: cobra.NoArgs,
+	RunE: runTmuxStatus,
+}
+
+func init() {
+	tmuxCmd.AddCommand(tmuxStatusCmd)
+}
+
+// tmuxStatusCounts holds the mission counts shown in the status segment.
+type tmuxStatusCounts struct {
+	running   int
+	busy      int
+	attention int
+}
+
+func runTmuxStatus(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return nil
+	}
+
+	// Don't create a database just to report on it
+	dbFilepath := config.GetDatabaseFilepath(agencDirpath)
+	if _, err := os.Stat(dbFilepath); err != nil {
+		return nil
+	}
+	db, err := database.Open(dbFilepath)
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	missions, err := db.ListMissions(database.ListMissionsParams{})
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	var nextCron *time.Time
+	if cfg, _, err := config.ReadAgencConfig(agencDirpath); err == nil {
+		nextCron = findNextCronFire(cfg.Crons, now)
+	}
+
+	fmt.Println(formatTmuxStatus(countTmuxStatusMissions(agencDirpath, missions), nextCron, now))
+	return nil
+}
+
+// countTmuxStatusMissions counts the missions with a running wrapper and their
+// Claude states, querying the wrappers in parallel.
+func countTmuxStatusMissions(agencDirpath string, missions []*database.Mission) tmuxStatusCounts {
+	var counts tmuxStatusCounts
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, m := range missions {
+		pid, err := server.ReadPID(config.GetMissionPIDFilepath(agencDirpath, m.ID))
+		if err != nil || pid == 0 || !server.IsProcessRunning(pid) {
+			continue
+		}
+		counts.running++
+
+		wg.Add(1)
+		go func(missionID string) {
+			defer wg.Done()
+			state := server.QueryWrapperClaudeState(agencDirpath, missionID)
+			if state == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			switch *state {
+			case "busy":
+				counts.busy++
+			case "needs_attention":
+				counts.attention++
+			}
+		}(m.ID)
+	}
+	wg.Wait()
+	return counts
+}
+
+// findNextCronFire returns the earliest upcoming fire time among the enabled
+// crons, or nil if none is scheduled.
+func findNextCronFire(crons map[string]config.CronConfig, now time.Time) *time.Time {
+	var next *time.Time
+	for _, cronCfg := range crons {
+		if !cronCfg.IsEnabled() {
+			continue
+		}
+		var fireTime time.Time
+		if cronCfg.IsOneShot() {
+			runAt, err := config.ParseCronRunAt(cronCfg.RunAt)
+			if err != nil || !runAt.After(now) {
+				continue
+			}
+			fireTime = runAt
+		} else {
+			interval, err := launchd.ParseCronExpression(cronCfg.Schedule)
+			if err != nil {
+				continue
+			}
+			var ok bool
+			if fireTime, ok = interval.Next(now); !ok {
+				continue
+			}
+		}
+		if next == nil || fireTime.Before(*next) {
+			next = &fireTime
+		}
+	}
+	return next
+}
+
+// formatTmuxStatus renders the status segment. Zero busy/attention counts and
+// a missing next cron are omitted; the next cron shows just the time when it
+// is today, the weekday within the coming week, and the date after that.
+func formatTmuxStatus(counts tmuxStatusCounts, nextCron *time.Time, now time.Time) string {
+	parts := []string{fmt.Sprintf("agenc %d running", counts.running)}
+	if counts.busy > 0 {
+		parts = append(parts, fmt.Sprintf("%d busy", counts.busy))
+	}
+	if counts.attention > 0 {
+		parts = append(parts, fmt.Sprintf("%d attention", counts.attention))
+	}
+	if nextCron != nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		layout := "15:04"
+		switch {
+		case !nextCron.Before(today.AddDate(0, 0, 7)):
+			layout = "Jan 2 15:04"
+		case !nextCron.Before(today.AddDate(0, 0, 1)):
+			layout = "Mon 15:04"
+		}
+		parts = append(parts, "cron "+nextCron.Format(layout))
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	agentmux "github.com/odyssey/agenc/internal/tmux"
+)
+
+const (
+	statusSentinelBegin = "# >>> AgenC status >>>"
+	statusSentinelEnd   = "# <<< AgenC status <<<"
+)
+
+var tmuxStatusSetupRemoveFlag bool
+
+var tmuxStatusSetupCmd = &cobra.Command{
+	Use:   tmuxStatusSetupCmdStr,
+	Short: "Add the AgenC status segment to tmux's status-right",
+	Long: fmt.Sprintf(`Add a sentinel-wrapped block to your tmux.conf that appends the output of
+'%s %s %s' to status-right. If a tmux server is running, the segment is
+applied immediately. tmux refreshes it every status-interval seconds
+(15 by default).
+
+Run again after moving the agenc binary to update the path in the block.
+With --%s, the block is removed instead; restart tmux or reset status-right
+to drop the segment from a running server.`,
+		agencCmdStr, tmuxCmdStr, statusCmdStr, removeFlagName),
+	Args: cobra.NoArgs,
+	RunE: runTmuxStatusSetup,
+}
+
+func init() {
+	tmuxStatusSetupCmd.Flags().BoolVar(&tmuxStatusSetupRemoveFlag, removeFlagName, false, "remove the status segment from tmux.conf")
+	tmuxStatusCmd.AddCommand(tmuxStatusSetupCmd)
+}
+
+func runTmuxStatusSetup(cmd *cobra.Command, args []string) error {
+	if tmuxStatusSetupRemoveFlag {
+		tmuxConfFilepath, removed, err := removeTmuxConfBlock(statusSentinelBegin, statusSentinelEnd)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			fmt.Printf("No AgenC status segment found in %s\n", tmuxConfFilepath)
+			return nil
+		}
+		fmt.Printf("Removed AgenC status segment from %s\n", tmuxConfFilepath)
+		return nil
+	}
+
+	if config.IsTestEnv() {
+		return stacktrace.NewError("tmux status setup is disabled in test environments (AGENC_TEST_ENV is set)")
+	}
+
+	binaryPath, err := resolveAgencBinaryPath()
+	if err != nil {
+		return err
+	}
+	// Use ~ in the path so tmux.conf is portable across machines
+	displayBinaryPath := contractHomePath(binaryPath)
+
+	block := statusSentinelBegin + "\n" + buildTmuxStatusRightLine(displayBinaryPath) + "\n" + statusSentinelEnd
+	if err := upsertTmuxConfBlock(statusSentinelBegin, statusSentinelEnd, block, "AgenC status segment"); err != nil {
+		return err
+	}
+
+	// Apply to a running tmux server too, unless an earlier setup already did
+	segment := tmuxStatusRightSegment(displayBinaryPath)
+	if current, err := agentmux.Command("show-options", "-gv", "status-right").Output(); err == nil && !strings.Contains(string(current), segment) {
+		if output, err := agentmux.Command("set-option", "-ag", "status-right", segment).CombinedOutput(); err != nil {
+			fmt.Printf("Warning: failed to apply status segment to running tmux server: %s\n", strings.TrimSpace(string(output)))
+		} else {
+			fmt.Println("Applied status segment to running tmux server")
+		}
+	}
+	return nil
+}
+
+// tmuxStatusRightSegment is the status-right text that runs the status
+// command. tmux runs #() through sh, so a ~ in agencBinaryPath is expanded.
+func tmuxStatusRightSegment(agencBinaryPath string) string {
+	return fmt.Sprintf(" #(%s %s %s)", agencBinaryPath, tmuxCmdStr, statusCmdStr)
+}
+
+// buildTmuxStatusRightLine returns the tmux.conf line that appends the status
+// segment to status-right.
+func buildTmuxStatusRightLine(agencBinaryPath string) string {
+	return fmt.Sprintf("set -ag status-right '%s'", tmuxStatusRightSegment(agencBinaryPath))
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestFormatTmuxStatus(t *testing.T) {
+	// Wednesday 2026-03-04 10:30
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.Local)
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2026, 3, day, hour, 0, 0, 0, time.Local)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		counts   tmuxStatusCounts
+		nextCron *time.Time
+		want     string
+	}{
+		{"nothing running", tmuxStatusCounts{}, nil, "agenc 0 running"},
+		{"all counts", tmuxStatusCounts{running: 3, busy: 1, attention: 1}, nil, "agenc 3 running · 1 busy · 1 attention"},
+		{"cron today", tmuxStatusCounts{running: 2, busy: 2}, at(4, 14), "agenc 2 running · 2 busy · cron 14:00"},
+		{"cron this week", tmuxStatusCounts{}, at(6, 9), "agenc 0 running · cron Fri 09:00"},
+		{"cron next week", tmuxStatusCounts{}, at(11, 9), "agenc 0 running · cron Mar 11 09:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatTmuxStatus(tt.counts, tt.nextCron, now); got != tt.want {
+				t.Errorf("formatTmuxStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindNextCronFire(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.Local)
+	disabled := false
+	crons := map[string]config.CronConfig{
+		"nightly":  {Schedule: "0 2 * * *"},
+		"noon":     {Schedule: "0 12 * * *"},
+		"disabled": {Schedule: "45 10 * * *", Enabled: &disabled},
+	}
+
+	got := findNextCronFire(crons, now)
+	want := time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local)
+	if got == nil || !got.Equal(want) {
+		t.Errorf("findNextCronFire() = %v, want %v", got, want)
+	}
+
+	crons["one-shot"] = config.CronConfig{RunAt: "2026-03-04 11:15"}
+	crons["past-one-shot"] = config.CronConfig{RunAt: "2026-03-04 10:00"}
+	got = findNextCronFire(crons, now)
+	want = time.Date(2026, 3, 4, 11, 15, 0, 0, time.Local)
+	if got == nil || !got.Equal(want) {
+		t.Errorf("findNextCronFire() with one-shots = %v, want %v", got, want)
+	}
+
+	if got := findNextCronFire(nil, now); got != nil {
+		t.Errorf("expected nil with no crons, got %v", got)
+	}
+}
@@ -28,35 +28,51 @@ func init() {
 }
 
 func runTmuxUninject(cmd *cobra.Command, args []string) error {
-	tmuxConfFilepath, exists, err := findTmuxConfFilepath()
+	tmuxConfFilepath, removed, err := removeTmuxConfBlock(sentinelBegin, sentinelEnd)
 	if err != nil {
 		return err
 	}
-
-	if !exists {
-		fmt.Printf("No tmux.conf found at %s\n", tmuxConfFilepath)
+	if !removed {
+		fmt.Printf("No AgenC keybindings found in %s\n", tmuxConfFilepath)
 		fmt.Println("Nothing to uninject")
 		return nil
 	}
 
+	fmt.Printf("Removed AgenC keybindings from %s\n", tmuxConfFilepath)
+	fmt.Println("\nNote: If you have a running tmux server, you may need to restart it")
+	fmt.Println("or manually unbind keys for changes to take full effect.")
+
+	return nil
+}
+
+// removeTmuxConfBlock removes the block between beginSentinel and endSentinel
+// (inclusive) from the user's tmux.conf. Returns the tmux.conf path and
+// whether a block was found and removed.
+func removeTmuxConfBlock(beginSentinel string, endSentinel string) (string, bool, error) {
+	tmuxConfFilepath, exists, err := findTmuxConfFilepath()
+	if err != nil {
+		return "", false, err
+	}
+	if !exists {
+		return tmuxConfFilepath, false, nil
+	}
+
 	content, err := os.ReadFile(tmuxConfFilepath)
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to read '%s'", tmuxConfFilepath)
+		return "", false, stacktrace.Propagate(err, "failed to read '%s'", tmuxConfFilepath)
 	}
 	fileContent := string(content)
 
-	beginIdx := strings.Index(fileContent, sentinelBegin)
-	endIdx := strings.Index(fileContent, sentinelEnd)
+	beginIdx := strings.Index(fileContent, beginSentinel)
+	endIdx := strings.Index(fileContent, endSentinel)
 
 	if beginIdx < 0 || endIdx < 0 {
-		fmt.Printf("No AgenC keybindings found in %s\n", tmuxConfFilepath)
-		fmt.Println("Nothing to uninject")
-		return nil
+		return tmuxConfFilepath, false, nil
 	}
 
 	// Remove the entire sentinel block, including the sentinels themselves
 	beforeBlock := fileContent[:beginIdx]
-	afterBlock := fileContent[endIdx+len(sentinelEnd):]
+	afterBlock := fileContent[endIdx+len(endSentinel):]
 
 	// Clean up: remove trailing newline from beforeBlock and leading newline from afterBlock
 	// to avoid leaving blank lines where the block was
@@ -75,12 +91,7 @@ func runTmuxUninject(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := os.WriteFile(tmuxConfFilepath, []byte(newContent), 0644); err != nil {
-		return stacktrace.Propagate(err, "failed to update '%s'", tmuxConfFilepath)
+		return "", false, stacktrace.Propagate(err, "failed to update '%s'", tmuxConfFilepath)
 	}
-
-	fmt.Printf("Removed AgenC keybindings from %s\n", tmuxConfFilepath)
-	fmt.Println("\nNote: If you have a running tmux server, you may need to restart it")
-	fmt.Println("or manually unbind keys for changes to take full effect.")
-
-	return nil
+	return tmuxConfFilepath, true, nil
 }
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+	"github.com/spf13/cobra"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/version"
+)
+
+// githubReleasesPageSize is how many of the most recent releases are
+// searched for the newest one on a channel.
+const githubReleasesPageSize = 30
+
+// githubRelease is the part of a GitHub release that upgrades read.
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+var upgradeCmd = &cobra.Command{
+	Use:   upgradeCmdStr,
+	Short: "Upgrade agenc to the newest GitHub release on your release channel",
+	Long: fmt.Sprintf(`Upgrade agenc straight from GitHub releases, without waiting for Homebrew or
+apt to pick up the new version.
+
+The release channel comes from releaseChannel in config.yml:
+
+  %-8s the newest full release (the default)
+  %-8s the newest release including prereleases (e.g. 1.5.0-beta.2)
+
+--channel overrides it for one upgrade. Switch for good with
+'agenc config set releaseChannel beta'. agenc never moves to an older version,
+so going back to stable from a beta waits for the next stable release.
+
+The release archive for this platform is downloaded and checked against the
+release's checksums.txt and its GitHub build provenance attestation ('gh
+attestation verify'), which proves it was built by the agenc release workflow.
+The binary is then swapped in place atomically, the server is restarted on
+it, and every running mission is queued to reload when Claude is next idle,
+as 'agenc server upgrade' does.
+
+If agenc was installed with Homebrew or apt, its binary is replaced in place
+all the same; the next package upgrade puts the packaged version back.
+
+Examples:
+  agenc upgrade
+  agenc upgrade --check
+  agenc upgrade --channel beta`,
+		config.ReleaseChannelStable, config.ReleaseChannelBeta,
+	),
+	Args: cobra.NoArgs,
+	RunE: runUpgrade,
+}
+
+func init() {
+	upgradeCmd.Flags().String(releaseChannelFlagName, "", "release channel to upgrade from: stable or beta (default: releaseChannel from config)")
+	upgradeCmd.Flags().Bool(upgradeCheckFlagName, false, "only report whether a newer release is available")
+	upgradeCmd.Flags().Bool(skipSignatureCheckFlagName, false, "install without verifying the release's build provenance attestation (the checksum is still verified)")
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	agencDirpath, err := ensureConfigured()
+	if err != nil {
+		return err
+	}
+	channel := configuredReleaseChannel(agencDirpath)
+	if cmd.Flags().Changed(releaseChannelFlagName) {
+		channel, _ = cmd.Flags().GetString(releaseChannelFlagName)
+		if err := config.ValidateReleaseChannel(channel); err != nil {
+			return stacktrace.Propagate(err, "invalid --%s", releaseChannelFlagName)
+		}
+	}
+	checkOnly, _ := cmd.Flags().GetBool(upgradeCheckFlagName)
+	skipSignatureCheck, _ := cmd.Flags().GetBool(skipSignatureCheckFlagName)
+
+	httpClient := &http.Client{Timeout: releaseDownloadTimeout}
+	release, err := fetchChannelRelease(httpClient, channel)
+	if err != nil {
+		return err
+	}
+	latestVersion := strings.TrimPrefix(release.TagName, "v")
+	if !isNewerRelease(release.TagName, version.Version) {
+		fmt.Printf("agenc %s is up to date (newest %s release: %s).\n", version.Version, channel, latestVersion)
+		return nil
+	}
+	if checkOnly {
+		fmt.Printf("agenc %s is available on the %s channel (installed: %s). Run 'agenc %s' to install it.\n", latestVersion, channel, version.Version, upgradeCmdStr)
+		return nil
+	}
+
+	execPath, err := os.Executable()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve agenc binary path")
+	}
+	if resolved, err := filepath.EvalSymlinks(execPath); err == nil {
+		execPath = resolved
+	}
+	if method := detectInstallMethod(execPath); method != installMethodRelease {
+		fmt.Printf("Note: agenc was installed with %s; replacing its binary in place. The next %s upgrade will put the packaged version back.\n", method, method)
+	}
+
+	fmt.Printf("Upgrading agenc %s to %s (%s channel)...\n", version.Version, latestVersion, channel)
+	if err := installRelease(httpClient, execPath, release.TagName, !skipSignatureCheck); err != nil {
+		return err
+	}
+	fmt.Printf("Installed agenc %s.\n", latestVersion)
+
+	if !server.IsRunning(config.GetServerPIDFilepath(agencDirpath)) {
+		fmt.Println("The server isn't running; it will start on the new binary next time.")
+		return nil
+	}
+	return restartServerOnBinary(agencDirpath, execPath)
+}
+
+// configuredReleaseChannel returns the releaseChannel from config.yml,
+// falling back to stable when the config can't be read.
+func configuredReleaseChannel(agencDirpath string) string {
+	cfg, _, err := config.ReadAgencConfig(agencDirpath)
+	if err != nil {
+		return config.ReleaseChannelStable
+	}
+	return cfg.GetReleaseChannel()
+}
+
+// fetchChannelRelease returns the newest published release on channel.
+func fetchChannelRelease(httpClient *http.Client, channel string) (githubRelease, error) {
+	body, err := httpGetBytes(httpClient, fmt.Sprintf("%s?per_page=%d", githubReleasesAPIURL, githubReleasesPageSize))
+	if err != nil {
+		return githubRelease{}, stacktrace.Propagate(err, "failed to look up agenc releases")
+	}
+	var releases []githubRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return githubRelease{}, stacktrace.Propagate(err, "failed to parse agenc releases")
+	}
+	release, ok := selectChannelRelease(releases, channel)
+	if !ok {
+		return githubRelease{}, stacktrace.NewError("no %s release of agenc found", channel)
+	}
+	return release, nil
+}
+
+// selectChannelRelease picks the highest-versioned release on channel:
+// stable skips prereleases, beta takes them too. Drafts and tags that aren't
+// versions are skipped.
+func selectChannelRelease(releases []githubRelease, channel string) (githubRelease, bool) {
+	var best githubRelease
+	found := false
+	for _, release := range releases {
+		if release.Draft || (release.Prerelease && channel != config.ReleaseChannelBeta) {
+			continue
+		}
+		if _, ok := version.Compare(release.TagName, release.TagName); !ok {
+			continue
+		}
+		if !found || isNewerRelease(release.TagName, best.TagName) {
+			best, found = release, true
+		}
+	}
+	return best, found
+}
+
+// isNewerRelease reports whether the release tagged tag is newer than
+// current. A current version that isn't a release, like a development
+// build's, counts as older than any other version.
+func isNewerRelease(tag string, current string) bool {
+	cmp, ok := version.Compare(tag, current)
+	if !ok {
+		return strings.TrimPrefix(tag, "v") != strings.TrimPrefix(current, "v")
+	}
+	return cmp > 0
+}
+
+// verifyReleaseAttestation checks a downloaded release archive against its
+// GitHub build provenance attestation with 'gh attestation verify', proving
+// it was built by a workflow in the agenc repo.
+func verifyReleaseAttestation(archiveName string, archive []byte) error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return stacktrace.NewError("verifying the release signature needs the GitHub CLI (gh); install it or pass --%s", skipSignatureCheckFlagName)
+	}
+	tmpDirpath, err := os.MkdirTemp("", agencCmdStr+"-upgrade-")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create a temp directory for signature verification")
+	}
+	defer os.RemoveAll(tmpDirpath)
+
+	archiveFilepath := filepath.Join(tmpDirpath, archiveName)
+	if err := os.WriteFile(archiveFilepath, archive, 0600); err != nil {
+		return stacktrace.Propagate(err, "failed to write %s for signature verification", archiveName)
+	}
+	output, err := exec.Command("gh", "attestation", "verify", archiveFilepath, "--repo", agencGitHubRepo).CombinedOutput()
+	if err != nil {
+		return stacktrace.NewError("signature verification failed for %s; refusing to install: %s", archiveName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
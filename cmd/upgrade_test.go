@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestSelectChannelRelease(t *testing.T) {
+	releases := []githubRelease{
+		{TagName: "v1.6.0-beta.1", Draft: true, Prerelease: true},
+		{TagName: "v1.5.0-beta.2", Prerelease: true},
+		{TagName: "v1.4.1"},
+		{TagName: "nightly"},
+		{TagName: "v1.4.0"},
+	}
+
+	tests := []struct {
+		channel string
+		want    string
+	}{
+		{config.ReleaseChannelStable, "v1.4.1"},
+		{config.ReleaseChannelBeta, "v1.5.0-beta.2"},
+	}
+	for _, tt := range tests {
+		got, ok := selectChannelRelease(releases, tt.channel)
+		if !ok {
+			t.Fatalf("%s: expected a release", tt.channel)
+		}
+		if got.TagName != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.channel, got.TagName, tt.want)
+		}
+	}
+
+	if _, ok := selectChannelRelease([]githubRelease{{TagName: "v2.0.0-rc.1", Prerelease: true}}, config.ReleaseChannelStable); ok {
+		t.Error("expected no stable release among prereleases")
+	}
+}
+
+func TestIsNewerRelease(t *testing.T) {
+	tests := []struct {
+		tag     string
+		current string
+		want    bool
+	}{
+		{"v1.4.1", "1.4.0", true},
+		{"v1.4.0", "1.4.0", false},
+		{"v1.4.0", "1.5.0-beta.2", false},
+		{"v1.5.0", "1.5.0-beta.2", true},
+		{"v1.5.0-beta.3", "1.5.0-beta.2", true},
+		{"v1.4.0", "unknown", true},
+	}
+	for _, tt := range tests {
+		if got := isNewerRelease(tt.tag, tt.current); got != tt.want {
+			t.Errorf("isNewerRelease(%q, %q) = %v, want %v", tt.tag, tt.current, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+// Package audit implements AgenC's append-only audit log: one JSON entry per
+// state-changing CLI command or API call, written as JSONL to one file per
+// day under $AGENC_DIRPATH/audit/. The CLI and the server each write their
+// own entries ("dual-write"); entries from one CLI invocation share its
+// invocation ID with the API calls it made.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// Entry sources.
+const (
+	SourceCLI = "cli"
+	SourceAPI = "api"
+)
+
+// Actor kinds: who was behind an entry.
+const (
+	// ActorMission is an agent: the command ran inside a mission (its
+	// AGENC_MISSION_UUID was set)
+	ActorMission = "mission"
+	// ActorTTY is a person at a terminal
+	ActorTTY = "tty"
+	// ActorScript is a non-interactive process outside any mission, such as
+	// a launchd cron job or a shell script
+	ActorScript = "script"
+	// ActorToken is an API client authenticated with a bearer token
+	ActorToken = "token"
+	// ActorUser is an API call over the unix socket that didn't identify a
+	// mission, attributed to the OS user that made it
+	ActorUser = "user"
+)
+
+// HTTP headers the CLI's server client sends so API entries can be
+// attributed and tied back to the CLI entry.
+const (
+	MissionHeader    = "X-Agenc-Mission-Uuid"
+	InvocationHeader = "X-Agenc-Invocation-Id"
+)
+
+// redactedValue replaces the values of parameters that look like secrets.
+const redactedValue = "[redacted]"
+
+// sensitiveKeyParts mark a parameter name as holding a secret.
+var sensitiveKeyParts = []string{"password", "secret", "token", "credential", "authorization", "key"}
+
+// envParamKey names the parameter holding environment variables, as a
+// KEY=VALUE list (the --env flag) or a map (API bodies). Any variable may
+// hold a secret, so all their values are redacted and only the names kept.
+const envParamKey = "env"
+
+// Actor identifies who was behind an entry. Mission UUIDs are as reported
+// by the caller's environment.
+type Actor struct {
+	Kind      string `json:"kind"`
+	MissionID string `json:"mission_id,omitempty"`
+	User      string `json:"user,omitempty"`
+	// Token is the name of the API token, for ActorToken
+	Token string `json:"token,omitempty"`
+}
+
+// String renders the actor for display, e.g. "mission 2b4c8f1a" or
+// "tty (alice)".
+func (a Actor) String() string {
+	switch a.Kind {
+	case ActorMission:
+		shortID := a.MissionID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		return "mission " + shortID
+	case ActorToken:
+		return "token " + a.Token
+	}
+	if a.User == "" {
+		return a.Kind
+	}
+	return a.Kind + " (" + a.User + ")"
+}
+
+// Entry is one line of the audit log.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+	// InvocationID ties a CLI command's entry to the entries of the API
+	// calls it made
+	InvocationID string `json:"invocation_id,omitempty"`
+	Actor        Actor  `json:"actor"`
+	// Action is the command ("agenc mission rm") or the route pattern
+	// ("DELETE /missions/{id}")
+	Action string `json:"action"`
+	// Path is the request path of an API call
+	Path string `json:"path,omitempty"`
+	// Params holds the command's arguments and flags, or the call's query
+	// and body, with secret-looking values redacted
+	Params map[string]any `json:"params,omitempty"`
+	// Status is the HTTP status of an API call
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Matches reports whether query appears, case-insensitively, anywhere in
+// the entry's JSON form.
+func (e Entry) Matches(query string) bool {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(data)), strings.ToLower(query))
+}
+
+// Append writes entry to the day's audit file, creating the audit directory
+// if needed. The file is opened in append mode for each entry, so the CLI
+// and the server can write concurrently.
+func Append(agencDirpath string, entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entry.Params = Redact(entry.Params)
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to encode audit entry")
+	}
+
+	auditDirpath := config.GetAuditDirpath(agencDirpath)
+	if err := os.MkdirAll(auditDirpath, 0700); err != nil {
+		return stacktrace.Propagate(err, "failed to create audit directory '%s'", auditDirpath)
+	}
+	auditFilepath := filepath.Join(auditDirpath, FileName(entry.Time))
+	file, err := os.OpenFile(auditFilepath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open audit file '%s'", auditFilepath)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return stacktrace.Propagate(err, "failed to write audit file '%s'", auditFilepath)
+	}
+	return nil
+}
+
+// FileName returns the name of the audit file holding entries written at t:
+// its local date, e.g. "2026-10-17.jsonl".
+func FileName(t time.Time) string {
+	return t.Local().Format(time.DateOnly) + ".jsonl"
+}
+
+// ListFiles returns the paths of the audit files, oldest first.
+func ListFiles(agencDirpath string) ([]string, error) {
+	auditDirpath := config.GetAuditDirpath(agencDirpath)
+	dirEntries, err := os.ReadDir(auditDirpath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read audit directory '%s'", auditDirpath)
+	}
+	var filepaths []string
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() && strings.HasSuffix(dirEntry.Name(), ".jsonl") {
+			filepaths = append(filepaths, filepath.Join(auditDirpath, dirEntry.Name()))
+		}
+	}
+	slices.Sort(filepaths)
+	return filepaths, nil
+}
+
+// Read returns the entries written at or after since (all entries for a zero
+// since), oldest first. Lines that aren't valid entries are skipped.
+func Read(agencDirpath string, since time.Time) ([]Entry, error) {
+	filepaths, err := ListFiles(agencDirpath)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, auditFilepath := range filepaths {
+		// Files are named by date, so whole days before since can be skipped
+		if !since.IsZero() && filepath.Base(auditFilepath) < FileName(since) {
+			continue
+		}
+		fileEntries, err := ReadFile(auditFilepath)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range fileEntries {
+			if !entry.Time.Before(since) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// ReadFile returns the entries in one audit file, skipping lines that aren't
+// valid entries.
+func ReadFile(auditFilepath string) ([]Entry, error) {
+	file, err := os.Open(auditFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to open audit file '%s'", auditFilepath)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		if entry, ok := ParseLine(scanner.Bytes()); ok {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read audit file '%s'", auditFilepath)
+	}
+	return entries, nil
+}
+
+// ParseLine decodes one audit file line, reporting false for blank or
+// malformed lines (e.g. one cut short by a crash).
+func ParseLine(line []byte) (Entry, bool) {
+	var entry Entry
+	if len(strings.TrimSpace(string(line))) == 0 || json.Unmarshal(line, &entry) != nil || entry.Time.IsZero() {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// Redact returns a copy of params in which the values of keys that look like
+// secrets (passwords, tokens, credentials, keys) and of environment variables
+// are replaced, at any depth.
+func Redact(params map[string]any) map[string]any {
+	if params == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(params))
+	for key, value := range params {
+		switch {
+		case isSensitiveKey(key):
+			redacted[key] = redactedValue
+		case strings.EqualFold(key, envParamKey):
+			redacted[key] = redactEnv(value)
+		default:
+			redacted[key] = redactValue(value)
+		}
+	}
+	return redacted
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return Redact(v)
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			if entry, ok := item.(string); ok {
+				redacted[i] = redactSensitiveEntry(entry)
+				continue
+			}
+			redacted[i] = redactValue(item)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for i, entry := range v {
+			redacted[i] = redactSensitiveEntry(entry)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+// redactSensitiveEntry redacts a list item of the form KEY=VALUE, as repeatable
+// flags take, when KEY looks like a secret's name.
+func redactSensitiveEntry(entry string) string {
+	key, _, ok := strings.Cut(entry, "=")
+	if !ok || strings.ContainsAny(key, " \t\n") || !isSensitiveKey(key) {
+		return entry
+	}
+	return redactEnvEntry(entry)
+}
+
+// redactEnv redacts every variable's value in an env parameter: the values of
+// a map, and the VALUE of KEY=VALUE entries.
+func redactEnv(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key := range v {
+			redacted[key] = redactedValue
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactEnv(item)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for i, entry := range v {
+			redacted[i] = redactEnvEntry(entry)
+		}
+		return redacted
+	case string:
+		return redactEnvEntry(v)
+	default:
+		return redactValue(value)
+	}
+}
+
+// redactEnvEntry turns "KEY=VALUE" into "KEY=[redacted]". Entries without a
+// value, like an empty string that clears the env, are kept.
+func redactEnvEntry(entry string) string {
+	key, value, ok := strings.Cut(entry, "=")
+	if !ok || value == "" {
+		return entry
+	}
+	return key + "=" + redactedValue
+}
+
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	agencDirpath := t.TempDir()
+	yesterday := time.Now().Add(-24 * time.Hour)
+	now := time.Now()
+
+	entries := []Entry{
+		{Time: yesterday, Source: SourceCLI, Actor: Actor{Kind: ActorTTY, User: "alice"}, Action: "agenc mission new"},
+		{Time: now, Source: SourceAPI, Actor: Actor{Kind: ActorMission, MissionID: "2b4c8f1a-0000"}, Action: "DELETE /missions/{id}", Path: "/missions/abc", Status: 200},
+	}
+	for _, entry := range entries {
+		if err := Append(agencDirpath, entry); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	all, err := Read(agencDirpath, time.Time{})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(all) != 2 || all[0].Action != "agenc mission new" || all[1].Action != "DELETE /missions/{id}" {
+		t.Fatalf("expected both entries oldest first, got %+v", all)
+	}
+
+	recent, err := Read(agencDirpath, now.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Actor.MissionID != "2b4c8f1a-0000" {
+		t.Fatalf("expected only today's entry, got %+v", recent)
+	}
+
+	filepaths, err := ListFiles(agencDirpath)
+	if err != nil {
+		t.Fatalf("ListFiles failed: %v", err)
+	}
+	if len(filepaths) != 2 {
+		t.Fatalf("expected one file per day, got %v", filepaths)
+	}
+	info, err := os.Stat(filepaths[1])
+	if err != nil {
+		t.Fatalf("stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected audit file mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestRead_NoAuditDir(t *testing.T) {
+	entries, err := Read(t.TempDir(), time.Time{})
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got %+v", entries)
+	}
+}
+
+func TestReadFile_SkipsMalformedLines(t *testing.T) {
+	auditFilepath := filepath.Join(t.TempDir(), "2026-10-17.jsonl")
+	content := `{"time":"2026-10-17T10:00:00Z","source":"cli","actor":{"kind":"tty"},"action":"agenc mission new"}
+
+not json
+{"source":"cli"}
+{"time":"2026-10-17T10:01:00Z","source":"cli","actor":{"kind":"tty"},"action":"agenc mission rm"}
+{"time":"2026-10-17T10:02`
+	if err := os.WriteFile(auditFilepath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	entries, err := ReadFile(auditFilepath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if len(entries) != 2 || entries[1].Action != "agenc mission rm" {
+		t.Errorf("expected the two valid entries, got %+v", entries)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	params := map[string]any{
+		"args": []any{"mission-1"},
+		"body": map[string]any{
+			"name":        "work",
+			"apiToken":    "abc",
+			"credentials": map[string]any{"user": "x"},
+			"env":         []any{map[string]any{"DB_PASSWORD": "hunter2"}},
+		},
+		"Authorization": "Bearer xyz",
+	}
+
+	redacted := Redact(params)
+
+	body := redacted["body"].(map[string]any)
+	if body["name"] != "work" {
+		t.Errorf("expected name to be kept, got %v", body["name"])
+	}
+	if body["apiToken"] != redactedValue || body["credentials"] != redactedValue {
+		t.Errorf("expected token and credentials to be redacted, got %+v", body)
+	}
+	if env := body["env"].([]any)[0].(map[string]any); env["DB_PASSWORD"] != redactedValue {
+		t.Errorf("expected nested password to be redacted, got %+v", env)
+	}
+	if redacted["Authorization"] != redactedValue {
+		t.Errorf("expected Authorization to be redacted, got %v", redacted["Authorization"])
+	}
+	if params["Authorization"] != "Bearer xyz" {
+		t.Error("Redact must not modify its input")
+	}
+}
+
+func TestRedact_EnvAndKeyValueEntries(t *testing.T) {
+	params := map[string]any{
+		"args": []string{"github.com/owner/repo"},
+		"flags": map[string]any{
+			"env": []string{"GITHUB_TOKEN=ghp_abc", "DEBUG=1", ""},
+			"set": []string{"API_KEY=sk-123", "model=opus"},
+		},
+		"body": map[string]any{
+			"API_KEY": "sk-456",
+			"env":     map[string]any{"DATABASE_URL": "postgres://u:p@db", "DEBUG": "1"},
+			"prompt":  "fix the key=value parser",
+		},
+	}
+
+	redacted := Redact(params)
+
+	flags := redacted["flags"].(map[string]any)
+	if env := flags["env"].([]string); !slices.Equal(env, []string{"GITHUB_TOKEN=[redacted]", "DEBUG=[redacted]", ""}) {
+		t.Errorf("expected --env values redacted and names kept, got %v", env)
+	}
+	if set := flags["set"].([]string); !slices.Equal(set, []string{"API_KEY=[redacted]", "model=opus"}) {
+		t.Errorf("expected only the secret-looking entry redacted, got %v", set)
+	}
+	if args := redacted["args"].([]string); !slices.Equal(args, []string{"github.com/owner/repo"}) {
+		t.Errorf("expected args kept, got %v", args)
+	}
+
+	body := redacted["body"].(map[string]any)
+	if body["API_KEY"] != redactedValue {
+		t.Errorf("expected API_KEY to be redacted, got %v", body["API_KEY"])
+	}
+	env := body["env"].(map[string]any)
+	if env["DATABASE_URL"] != redactedValue || env["DEBUG"] != redactedValue {
+		t.Errorf("expected every env value redacted, got %+v", env)
+	}
+	if body["prompt"] != "fix the key=value parser" {
+		t.Errorf("expected prompt kept, got %v", body["prompt"])
+	}
+	if params["flags"].(map[string]any)["env"].([]string)[0] != "GITHUB_TOKEN=ghp_abc" {
+		t.Error("Redact must not modify its input")
+	}
+}
+
+func TestEntryMatches(t *testing.T) {
+	entry := Entry{
+		Source: SourceCLI,
+		Actor:  Actor{Kind: ActorMission, MissionID: "2b4c8f1a"},
+		Action: "agenc mission rm",
+		Params: map[string]any{"args": []any{"Feature-Branch"}},
+	}
+	for _, query := range []string{"mission rm", "feature-branch", "2B4C8F1A"} {
+		if !entry.Matches(query) {
+			t.Errorf("expected %q to match", query)
+		}
+	}
+	if entry.Matches("cron") {
+		t.Error("expected \"cron\" not to match")
+	}
+}
+
+func TestActorString(t *testing.T) {
+	tests := []struct {
+		actor    Actor
+		expected string
+	}{
+		{Actor{Kind: ActorMission, MissionID: "2b4c8f1a-1234-5678"}, "mission 2b4c8f1a"},
+		{Actor{Kind: ActorToken, Token: "ci"}, "token ci"},
+		{Actor{Kind: ActorTTY, User: "alice"}, "tty (alice)"},
+		{Actor{Kind: ActorScript}, "script"},
+	}
+	for _, tt := range tests {
+		if got := tt.actor.String(); got != tt.expected {
+			t.Errorf("String() = %q, expected %q", got, tt.expected)
+		}
+	}
+}
+
+func TestReadNewEntries_WaitsForCompleteLines(t *testing.T) {
+	auditFilepath := filepath.Join(config.GetAuditDirpath(t.TempDir()), "2026-10-17.jsonl")
+	var got []Entry
+	onEntry := func(entry Entry) { got = append(got, entry) }
+
+	offset, err := readNewEntries(auditFilepath, 0, onEntry)
+	if err != nil || offset != 0 {
+		t.Fatalf("expected a missing file to have no entries, got offset %d, err %v", offset, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(auditFilepath), 0700); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	first := `{"time":"2026-10-17T10:00:00Z","source":"cli","actor":{"kind":"tty"},"action":"first"}` + "\n"
+	partial := `{"time":"2026-10-17T10:01:00Z","source":"cli",`
+	if err := os.WriteFile(auditFilepath, []byte(first+partial), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	offset, err = readNewEntries(auditFilepath, 0, onEntry)
+	if err != nil {
+		t.Fatalf("readNewEntries failed: %v", err)
+	}
+	if len(got) != 1 || offset != int64(len(first)) {
+		t.Fatalf("expected only the complete line, got %+v at offset %d", got, offset)
+	}
+
+	rest := `"actor":{"kind":"tty"},"action":"second"}` + "\n"
+	if err := os.WriteFile(auditFilepath, []byte(first+partial+rest), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := readNewEntries(auditFilepath, offset, onEntry); err != nil {
+		t.Fatalf("readNewEntries failed: %v", err)
+	}
+	if len(got) != 2 || got[1].Action != "second" {
+		t.Errorf("expected the finished line on the next read, got %+v", got)
+	}
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// Follow calls onEntry for each entry appended to the audit log after it
+// starts, polling every pollInterval and moving on to the next day's file
+// when one appears, until ctx is cancelled.
+func Follow(ctx context.Context, agencDirpath string, pollInterval time.Duration, onEntry func(Entry)) error {
+	currentFilepath := filepath.Join(config.GetAuditDirpath(agencDirpath), FileName(time.Now()))
+	var offset int64
+	if info, err := os.Stat(currentFilepath); err == nil {
+		offset = info.Size()
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		var err error
+		if offset, err = readNewEntries(currentFilepath, offset, onEntry); err != nil {
+			return err
+		}
+		filepaths, err := ListFiles(agencDirpath)
+		if err != nil {
+			return err
+		}
+		if len(filepaths) > 0 && filepaths[len(filepaths)-1] > currentFilepath {
+			currentFilepath = filepaths[len(filepaths)-1]
+			if offset, err = readNewEntries(currentFilepath, 0, onEntry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readNewEntries calls onEntry for each complete line of auditFilepath past
+// offset and returns the offset just after the last complete line, so a line
+// still being written is read on the next poll. A missing file has no
+// entries yet.
+func readNewEntries(auditFilepath string, offset int64, onEntry func(Entry)) (int64, error) {
+	file, err := os.Open(auditFilepath)
+	if os.IsNotExist(err) {
+		return offset, nil
+	}
+	if err != nil {
+		return offset, stacktrace.Propagate(err, "failed to open audit file '%s'", auditFilepath)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, stacktrace.Propagate(err, "failed to seek audit file '%s'", auditFilepath)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return offset, stacktrace.Propagate(err, "failed to read audit file '%s'", auditFilepath)
+	}
+	complete := data[:bytes.LastIndexByte(data, '\n')+1]
+	for _, line := range bytes.Split(complete, []byte("\n")) {
+		if entry, ok := ParseLine(line); ok {
+			onEntry(entry)
+		}
+	}
+	return offset + int64(len(complete)), nil
+}
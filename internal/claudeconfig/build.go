@@ -9,12 +9,15 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/mieubrisse/stacktrace"
 
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/credstore"
 	"github.com/odyssey/agenc/internal/session"
+	"github.com/odyssey/agenc/internal/wsl"
 )
 
 const (
@@ -36,9 +39,12 @@ var TrackableItemNames = []string{
 // BuildMissionConfigDir creates and populates the per-mission claude config
 // directory from the shadow repo. It copies tracked files with path rewriting,
 // applies AgenC modifications (merged CLAUDE.md, merged settings.json with
-// hooks), copies and patches .claude.json, dumps credentials, and symlinks
-// plugins to ~/.claude/plugins.
-func BuildMissionConfigDir(agencDirpath string, missionID string, trustedMcpServers *config.TrustedMcpServers, containerized bool) error {
+// hooks), copies and patches .claude.json (MCP trust and the repo's declared
+// MCP servers), dumps credentials, and symlinks plugins to ~/.claude/plugins.
+// claudeMdAppend is the repo's claudeMdAppend setting (a file path or inline
+// content), appended to the merged CLAUDE.md. extraDenyEntries are appended
+// to settings.json's permission deny list.
+func BuildMissionConfigDir(agencDirpath string, missionID string, trustedMcpServers *config.TrustedMcpServers, mcpServers map[string]config.McpServerConfig, claudeMdAppend string, containerized bool, extraDenyEntries []string) error {
 	shadowDirpath := GetShadowRepoDirpath(agencDirpath)
 	missionDirpath := config.GetMissionDirpath(agencDirpath, missionID)
 	claudeConfigDirpath := filepath.Join(missionDirpath, MissionClaudeConfigDirname)
@@ -70,8 +76,13 @@ func BuildMissionConfigDir(agencDirpath string, missionID string, trustedMcpServ
 
 	agencModsDirpath := config.GetClaudeModificationsDirpath(agencDirpath)
 
-	// CLAUDE.md: merge user content + agenc modifications
-	if err := buildMergedClaudeMd(shadowDirpath, agencModsDirpath, claudeConfigDirpath); err != nil {
+	repoClaudeMd, err := config.ResolveClaudeMdAppend(agencDirpath, claudeMdAppend)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve the repo's claudeMdAppend")
+	}
+
+	// CLAUDE.md: merge user content + agenc modifications + repo overlay
+	if err := buildMergedClaudeMd(shadowDirpath, agencModsDirpath, repoClaudeMd, claudeConfigDirpath); err != nil {
 		return stacktrace.Propagate(err, "failed to build merged CLAUDE.md")
 	}
 
@@ -84,8 +95,14 @@ func BuildMissionConfigDir(agencDirpath string, missionID string, trustedMcpServ
 		}
 	}
 
+	// Refuse malformed hooks, permissions, or statusLine up front rather than
+	// writing a settings.json Claude silently misreads
+	if err := ValidateMissionSettingsSources(agencDirpath); err != nil {
+		return err
+	}
+
 	// settings.json: merge user settings + agenc modifications + hooks/deny
-	if err := buildMergedSettings(shadowDirpath, agencModsDirpath, claudeConfigDirpath, agencDirpath, missionID, containerized); err != nil {
+	if err := buildMergedSettings(shadowDirpath, agencModsDirpath, claudeConfigDirpath, agencDirpath, missionID, containerized, extraDenyEntries); err != nil {
 		return stacktrace.Propagate(err, "failed to build merged settings.json")
 	}
 
@@ -97,8 +114,10 @@ func BuildMissionConfigDir(agencDirpath string, missionID string, trustedMcpServ
 		}
 	}
 
-	// Copy and patch .claude.json with trust entry for mission agent dir
-	if err := copyAndPatchClaudeJSON(claudeConfigDirpath, missionAgentDirpath, trustedMcpServers); err != nil {
+	// Copy and patch .claude.json with trust entry for the directory Claude
+	// runs in (the agent dir, or its subpath)
+	missionWorkDirpath := config.GetMissionWorkDirpath(agencDirpath, missionID)
+	if err := copyAndPatchClaudeJSON(claudeConfigDirpath, missionWorkDirpath, trustedMcpServers, mcpServers); err != nil {
 		return stacktrace.Propagate(err, "failed to copy and patch .claude.json")
 	}
 
@@ -159,8 +178,12 @@ func EnsureShadowRepo(agencDirpath string) error {
 		return stacktrace.Propagate(err, "failed to initialize shadow repo")
 	}
 
-	// Ingest from ~/.claude
-	userClaudeDirpath, err := config.GetUserClaudeDirpath()
+	// Ingest from ~/.claude (or the Windows profile's, with wsl.windowsClaude)
+	cfg, _, err := config.ReadAgencConfig(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read config")
+	}
+	userClaudeDirpath, err := cfg.UserClaudeDirpath()
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to determine ~/.claude path")
 	}
@@ -197,15 +220,16 @@ func GetMissionClaudeConfigDirpath(agencDirpath string, missionID string) string
 }
 
 // buildMergedClaudeMd assembles the final CLAUDE.md for a mission by combining
-// the user's two CLAUDE.md sources:
+// the user's CLAUDE.md sources:
 //  1. User's CLAUDE.md (from the shadow repo / ~/.claude)
 //  2. User's claude-modifications CLAUDE.md (from ~/.agenc/config/claude-modifications)
+//  3. The mission repo's claudeMdAppend content (repoClaudeMd), if any
 //
 // AgenC operating context is no longer prepended here — it's delivered to the
 // agent via a SessionStart hook that runs `agenc prime` (see overrides.go).
 // Path rewriting resolves `~/.claude/...` references in user content into the
 // per-mission snapshot.
-func buildMergedClaudeMd(shadowDirpath string, agencModsDirpath string, destDirpath string) error {
+func buildMergedClaudeMd(shadowDirpath string, agencModsDirpath string, repoClaudeMd string, destDirpath string) error {
 	destFilepath := filepath.Join(destDirpath, "CLAUDE.md")
 
 	userClaudeContent, err := os.ReadFile(filepath.Join(shadowDirpath, "CLAUDE.md"))
@@ -218,7 +242,7 @@ func buildMergedClaudeMd(shadowDirpath string, agencModsDirpath string, destDirp
 		return stacktrace.Propagate(err, "failed to read agenc modifications CLAUDE.md")
 	}
 
-	mergedUserContent := MergeClaudeMd(userClaudeContent, modsClaudeContent)
+	mergedUserContent := MergeClaudeMd(MergeClaudeMd(userClaudeContent, modsClaudeContent), []byte(repoClaudeMd))
 	mergedClaudeMd := RewriteClaudePaths(mergedUserContent, destDirpath)
 
 	if mergedClaudeMd == nil {
@@ -232,7 +256,7 @@ func buildMergedClaudeMd(shadowDirpath string, agencModsDirpath string, destDirp
 // buildMergedSettings reads user settings from shadow repo and agenc
 // modifications, deep-merges them, adds agenc hooks/deny, then selectively
 // rewrites paths (preserving permission entries). Writes to dest.
-func buildMergedSettings(shadowDirpath string, agencModsDirpath string, destDirpath string, agencDirpath string, missionID string, containerized bool) error {
+func buildMergedSettings(shadowDirpath string, agencModsDirpath string, destDirpath string, agencDirpath string, missionID string, containerized bool, extraDenyEntries []string) error {
 	destFilepath := filepath.Join(destDirpath, "settings.json")
 
 	userSettingsData, err := os.ReadFile(filepath.Join(shadowDirpath, "settings.json"))
@@ -253,8 +277,9 @@ func buildMergedSettings(shadowDirpath string, agencModsDirpath string, destDirp
 		}
 	}
 
-	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
-	mergedData, err := MergeSettings(userSettingsData, modsSettingsData, agencDirpath, agentDirpath, destDirpath, containerized)
+	// Missions created with --path only get access to their subpath
+	workDirpath := config.GetMissionWorkDirpath(agencDirpath, missionID)
+	mergedData, err := MergeSettings(userSettingsData, modsSettingsData, agencDirpath, workDirpath, destDirpath, containerized, extraDenyEntries)
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to merge settings")
 	}
@@ -357,8 +382,10 @@ func copyDirWithRewriting(srcDirpath string, dstDirpath string, claudeConfigDirp
 // Lookup order: ~/.claude/.claude.json (primary), ~/.claude.json (fallback).
 // If trustedMcpServers is non-nil, the trust entry also includes
 // enabledMcpjsonServers and disabledMcpjsonServers to skip Claude Code's
-// MCP consent prompt.
-func copyAndPatchClaudeJSON(claudeConfigDirpath string, missionAgentDirpath string, trustedMcpServers *config.TrustedMcpServers) error {
+// MCP consent prompt. Non-empty mcpServers are written to the entry's
+// mcpServers, where Claude Code loads them as local-scope servers for the
+// agent directory — no .mcp.json in the repo and no consent prompt.
+func copyAndPatchClaudeJSON(claudeConfigDirpath string, missionAgentDirpath string, trustedMcpServers *config.TrustedMcpServers, mcpServers map[string]config.McpServerConfig) error {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to determine home directory")
@@ -373,6 +400,10 @@ func copyAndPatchClaudeJSON(claudeConfigDirpath string, missionAgentDirpath stri
 		srcFilepath = primaryFilepath
 	} else if _, err := os.Stat(fallbackFilepath); err == nil {
 		srcFilepath = fallbackFilepath
+	} else if windowsFilepath := findWindowsClaudeJSON(); windowsFilepath != "" {
+		// Under WSL, a user who only logged in with the Windows build of
+		// Claude Code has .claude.json in their Windows profile
+		srcFilepath = windowsFilepath
 	} else {
 		return stacktrace.NewError(
 			".claude.json not found at '%s' or '%s'; run 'claude login' first",
@@ -414,6 +445,9 @@ func copyAndPatchClaudeJSON(claudeConfigDirpath string, missionAgentDirpath stri
 			trustEntry["disabledMcpjsonServers"] = []string{}
 		}
 	}
+	if len(mcpServers) > 0 {
+		trustEntry["mcpServers"] = mcpServers
+	}
 	trustEntryData, err := json.Marshal(trustEntry)
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to marshal trust entry")
@@ -442,7 +476,25 @@ func copyAndPatchClaudeJSON(claudeConfigDirpath string, missionAgentDirpath stri
 	return nil
 }
 
-// ComputeCredentialServiceName returns the macOS Keychain service name for a
+// findWindowsClaudeJSON returns the .claude.json in the Windows profile when
+// running under WSL, or the empty string if there is none.
+func findWindowsClaudeJSON() string {
+	windowsHomeDirpath := wsl.WindowsHomeDirpath()
+	if windowsHomeDirpath == "" {
+		return ""
+	}
+	for _, candidate := range []string{
+		filepath.Join(windowsHomeDirpath, ".claude", ".claude.json"),
+		filepath.Join(windowsHomeDirpath, ".claude.json"),
+	} {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// ComputeCredentialServiceName returns the credential store service name for a
 // per-mission credential entry. The name is "Claude Code-credentials-<hash>"
 // where <hash> is the first 8 hex characters of the SHA-256 of the
 // claudeConfigDirpath. Claude Code uses this naming convention when
@@ -453,84 +505,174 @@ func ComputeCredentialServiceName(claudeConfigDirpath string) string {
 	return "Claude Code-credentials-" + hashPrefix
 }
 
-// GlobalCredentialServiceName is the macOS Keychain service name for the
+// GlobalCredentialServiceName is the credential store service name for the
 // global Claude Code credential entry (used when CLAUDE_CONFIG_DIR is unset).
 const GlobalCredentialServiceName = "Claude Code-credentials"
 
-// ReadKeychainCredentials reads the credential blob from the macOS Keychain
-// entry with the given service name. Returns the raw credential string (trimmed)
-// or an error if the entry does not exist or cannot be read.
-func ReadKeychainCredentials(serviceName string) (string, error) {
-	user := os.Getenv("USER")
-	if user == "" {
-		return "", stacktrace.NewError("USER environment variable not set")
+// CredentialsFilename is the file inside a Claude config directory where
+// Claude Code keeps its OAuth and MCP OAuth tokens on platforms where it
+// doesn't use a keychain (Linux, including WSL).
+const CredentialsFilename = ".credentials.json"
+
+// credentialsInConfigDir reports whether Claude Code keeps its credentials in
+// CredentialsFilename instead of the macOS Keychain. Only macOS Claude Code
+// talks to a credential store, so everywhere else the global entry is
+// ~/.claude/.credentials.json and a mission's entry is the same file in its
+// claude-config directory. A variable so tests can exercise the file path on
+// any platform.
+var credentialsInConfigDir = runtime.GOOS != "darwin"
+
+// ReadCredentials reads the credential blob with the given service name from
+// the platform credential store (see credstore.Default). Returns the raw
+// credential string (trimmed) or an error if the entry does not exist or
+// cannot be read.
+func ReadCredentials(serviceName string) (string, error) {
+	store, err := credstore.Default()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to open credential store")
 	}
+	credential, err := store.Read(serviceName)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to read credentials for service '%s' from %s store", serviceName, store.Name())
+	}
+	return credential, nil
+}
 
-	readCmd := exec.Command("security", "find-generic-password", "-a", user, "-w", "-s", serviceName)
-	credentialData, err := readCmd.Output()
+// WriteCredentials writes (or replaces) a credential blob with the given
+// service name in the platform credential store.
+func WriteCredentials(serviceName string, credential string) error {
+	store, err := credstore.Default()
 	if err != nil {
-		return "", stacktrace.Propagate(err, "failed to read Keychain entry for service '%s'", serviceName)
+		return stacktrace.Propagate(err, "failed to open credential store")
+	}
+	if err := store.Write(serviceName, credential); err != nil {
+		return stacktrace.Propagate(err, "failed to write credentials for service '%s' to %s store", serviceName, store.Name())
 	}
+	return nil
+}
 
-	return strings.TrimSpace(string(credentialData)), nil
+// ReadGlobalCredentials reads the user's own Claude Code credentials: the
+// "Claude Code-credentials" entry on macOS, ~/.claude/.credentials.json
+// elsewhere.
+func ReadGlobalCredentials() (string, error) {
+	if !credentialsInConfigDir {
+		return ReadCredentials(GlobalCredentialServiceName)
+	}
+	globalClaudeDirpath, err := globalClaudeDirpath()
+	if err != nil {
+		return "", err
+	}
+	return readCredentialsFile(globalClaudeDirpath)
 }
 
-// WriteKeychainCredentials writes (or replaces) a credential blob in the macOS
-// Keychain entry with the given service name. Any existing entry is deleted
-// first to allow an idempotent overwrite.
-func WriteKeychainCredentials(serviceName string, credential string) error {
-	user := os.Getenv("USER")
-	if user == "" {
-		return stacktrace.NewError("USER environment variable not set")
+// WriteGlobalCredentials replaces the user's own Claude Code credentials.
+func WriteGlobalCredentials(credential string) error {
+	if !credentialsInConfigDir {
+		return WriteCredentials(GlobalCredentialServiceName, credential)
+	}
+	globalClaudeDirpath, err := globalClaudeDirpath()
+	if err != nil {
+		return err
 	}
+	return writeCredentialsFile(globalClaudeDirpath, credential)
+}
 
-	// Delete any existing entry (ignore errors — may not exist)
-	deleteCmd := exec.Command("security", "delete-generic-password", "-a", user, "-s", serviceName)
-	_ = deleteCmd.Run()
+// ReadMissionCredentials reads the credentials Claude Code uses when
+// CLAUDE_CONFIG_DIR is claudeConfigDirpath.
+func ReadMissionCredentials(claudeConfigDirpath string) (string, error) {
+	if !credentialsInConfigDir {
+		return ReadCredentials(ComputeCredentialServiceName(claudeConfigDirpath))
+	}
+	return readCredentialsFile(claudeConfigDirpath)
+}
 
-	addCmd := exec.Command("security", "add-generic-password", "-a", user, "-s", serviceName, "-w", credential)
-	if err := addCmd.Run(); err != nil {
-		return stacktrace.Propagate(err, "failed to write credentials to Keychain service '%s'", serviceName)
+// WriteMissionCredentials replaces the credentials Claude Code uses when
+// CLAUDE_CONFIG_DIR is claudeConfigDirpath.
+func WriteMissionCredentials(claudeConfigDirpath string, credential string) error {
+	if !credentialsInConfigDir {
+		return WriteCredentials(ComputeCredentialServiceName(claudeConfigDirpath), credential)
 	}
+	return writeCredentialsFile(claudeConfigDirpath, credential)
+}
 
+func globalClaudeDirpath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to get user home directory")
+	}
+	return filepath.Join(homeDir, userClaudeDirname), nil
+}
+
+func readCredentialsFile(claudeDirpath string) (string, error) {
+	credentialsFilepath := filepath.Join(claudeDirpath, CredentialsFilename)
+	data, err := os.ReadFile(credentialsFilepath)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to read credentials from '%s'", credentialsFilepath)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeCredentialsFile replaces claudeDirpath's credentials file with mode
+// 0600. The new content is written to a temporary file and renamed into
+// place so a Claude process reading the file never sees it half-written.
+func writeCredentialsFile(claudeDirpath string, credential string) error {
+	if err := os.MkdirAll(claudeDirpath, 0700); err != nil {
+		return stacktrace.Propagate(err, "failed to create directory '%s'", claudeDirpath)
+	}
+	credentialsFilepath := filepath.Join(claudeDirpath, CredentialsFilename)
+	tmpFile, err := os.CreateTemp(claudeDirpath, CredentialsFilename+".tmp-*")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create temporary credentials file in '%s'", claudeDirpath)
+	}
+	tmpFilepath := tmpFile.Name()
+	defer os.Remove(tmpFilepath)
+
+	// CreateTemp already opens the file 0600
+	if _, err := tmpFile.WriteString(credential); err != nil {
+		tmpFile.Close()
+		return stacktrace.Propagate(err, "failed to write temporary credentials file '%s'", tmpFilepath)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return stacktrace.Propagate(err, "failed to close temporary credentials file '%s'", tmpFilepath)
+	}
+	if err := os.Rename(tmpFilepath, credentialsFilepath); err != nil {
+		return stacktrace.Propagate(err, "failed to move credentials into '%s'", credentialsFilepath)
+	}
 	return nil
 }
 
-// CloneKeychainCredentials reads the user's credentials from the default macOS
-// Keychain entry ("Claude Code-credentials") and clones them into a per-mission
-// entry keyed by claudeConfigDirpath. This avoids writing credential files to disk.
-func CloneKeychainCredentials(claudeConfigDirpath string) error {
-	credential, err := ReadKeychainCredentials(GlobalCredentialServiceName)
+// CloneCredentials copies the user's global credentials into the entry
+// Claude Code reads for claudeConfigDirpath: a per-mission keychain entry on
+// macOS, <claudeConfigDirpath>/.credentials.json elsewhere.
+func CloneCredentials(claudeConfigDirpath string) error {
+	credential, err := ReadGlobalCredentials()
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to read credentials from Keychain; run 'claude login' first")
+		return stacktrace.Propagate(err, "failed to read global credentials; run 'claude login' first")
 	}
 
-	targetService := ComputeCredentialServiceName(claudeConfigDirpath)
-	if err := WriteKeychainCredentials(targetService, credential); err != nil {
-		return stacktrace.Propagate(err, "failed to clone credentials to per-mission Keychain entry")
+	if err := WriteMissionCredentials(claudeConfigDirpath, credential); err != nil {
+		return stacktrace.Propagate(err, "failed to clone credentials to per-mission entry")
 	}
 
 	return nil
 }
 
-// WriteBackKeychainCredentials merges per-mission Keychain credentials back
-// into the global entry. This propagates MCP OAuth tokens acquired during a
-// mission so that subsequent missions inherit them without re-authentication.
+// WriteBackCredentials merges per-mission credentials back into the global
+// entry. This propagates MCP OAuth tokens acquired during a mission so that
+// subsequent missions inherit them without re-authentication.
 //
 // The merge uses MergeCredentialJSON: top-level keys are replaced by the
 // per-mission overlay, and mcpOAuth entries are merged per-server using
 // expiresAt to keep the newest token. If either side fails to parse or read,
 // the function returns nil (non-fatal) to avoid blocking wrapper exit.
-func WriteBackKeychainCredentials(claudeConfigDirpath string) error {
-	missionService := ComputeCredentialServiceName(claudeConfigDirpath)
-
-	missionCred, err := ReadKeychainCredentials(missionService)
+func WriteBackCredentials(claudeConfigDirpath string) error {
+	missionCred, err := ReadMissionCredentials(claudeConfigDirpath)
 	if err != nil {
 		// Per-mission entry may not exist (e.g. mission never ran Claude)
 		return nil
 	}
 
-	globalCred, err := ReadKeychainCredentials(GlobalCredentialServiceName)
+	globalCred, err := ReadGlobalCredentials()
 	if err != nil {
 		// Global entry missing — nothing to merge into
 		return nil
@@ -546,31 +688,32 @@ func WriteBackKeychainCredentials(claudeConfigDirpath string) error {
 		return nil
 	}
 
-	if err := WriteKeychainCredentials(GlobalCredentialServiceName, string(merged)); err != nil {
-		return stacktrace.Propagate(err, "failed to write merged credentials back to global Keychain entry")
+	if err := WriteGlobalCredentials(string(merged)); err != nil {
+		return stacktrace.Propagate(err, "failed to write merged credentials back to global entry")
 	}
 
 	return nil
 }
 
-// DeleteKeychainCredentials removes the per-mission Keychain credential entry.
-// Errors are silently ignored if the entry does not exist (idempotent cleanup).
-func DeleteKeychainCredentials(claudeConfigDirpath string) error {
-	user := os.Getenv("USER")
-	if user == "" {
-		return stacktrace.NewError("USER environment variable not set")
+// DeleteCredentials removes the per-mission credential entry. Deleting a
+// missing entry is not an error (idempotent cleanup).
+func DeleteCredentials(claudeConfigDirpath string) error {
+	if credentialsInConfigDir {
+		credentialsFilepath := filepath.Join(claudeConfigDirpath, CredentialsFilename)
+		if err := os.Remove(credentialsFilepath); err != nil && !os.IsNotExist(err) {
+			return stacktrace.Propagate(err, "failed to delete credentials file '%s'", credentialsFilepath)
+		}
+		return nil
 	}
 
-	targetService := ComputeCredentialServiceName(claudeConfigDirpath)
-
-	deleteCmd := exec.Command("security", "delete-generic-password", "-a", user, "-s", targetService)
-	output, err := deleteCmd.CombinedOutput()
+	store, err := credstore.Default()
 	if err != nil {
-		// Ignore "item not found" errors — the entry may not exist
-		if strings.Contains(string(output), "SecKeychainSearchCopyNext") {
-			return nil
-		}
-		return stacktrace.Propagate(err, "failed to delete Keychain credentials for service '%s'", targetService)
+		return stacktrace.Propagate(err, "failed to open credential store")
+	}
+
+	targetService := ComputeCredentialServiceName(claudeConfigDirpath)
+	if err := store.Delete(targetService); err != nil {
+		return stacktrace.Propagate(err, "failed to delete credentials for service '%s' from %s store", targetService, store.Name())
 	}
 
 	return nil
@@ -660,11 +803,11 @@ func ExtractExpiresAtFromJSON(credentialJSON []byte) float64 {
 	return expiresAt
 }
 
-// GetCredentialExpiresAt reads the global Keychain credentials and returns
+// GetCredentialExpiresAt reads the global credentials and returns
 // the expiresAt timestamp (in Unix seconds) for the claudeAiOauth token.
 // Returns 0 if the credential cannot be read or the expiresAt field is missing.
 func GetCredentialExpiresAt() float64 {
-	credential, err := ReadKeychainCredentials(GlobalCredentialServiceName)
+	credential, err := ReadCredentials(GlobalCredentialServiceName)
 	if err != nil {
 		return 0
 	}
@@ -688,6 +831,19 @@ func GetLastSessionID(agencDirpath string, missionID string) string {
 		return sessionIDs[0]
 	}
 
+	// A mission whose claude-config hasn't been built yet (e.g. a clone with
+	// a forked conversation, before its first spawn) has no projects link, so
+	// look in its project directory under ~/.claude directly
+	if _, err := os.Lstat(filepath.Join(claudeConfigDirpath, "projects")); os.IsNotExist(err) {
+		projectDirpath, err := ComputeProjectDirpath(config.GetMissionWorkDirpath(agencDirpath, missionID))
+		if err != nil {
+			return ""
+		}
+		if sessionIDs := session.ListProjectSessionIDs(projectDirpath); len(sessionIDs) > 0 {
+			return sessionIDs[0]
+		}
+	}
+
 	return ""
 }
 
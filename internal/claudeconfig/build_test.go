@@ -55,6 +55,70 @@ func TestComputeCredentialServiceName(t *testing.T) {
 	})
 }
 
+func TestCredentials_ConfigDirFile(t *testing.T) {
+	original := credentialsInConfigDir
+	credentialsInConfigDir = true
+	t.Cleanup(func() { credentialsInConfigDir = original })
+
+	homeDir := setupFakeHome(t)
+	globalFilepath := filepath.Join(homeDir, ".claude", CredentialsFilename)
+	if err := os.MkdirAll(filepath.Dir(globalFilepath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	globalCred := `{"claudeAiOauth":{"accessToken":"base-token"}}`
+	if err := os.WriteFile(globalFilepath, []byte(globalCred), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	claudeConfigDirpath := filepath.Join(t.TempDir(), "claude-config")
+	if err := CloneCredentials(claudeConfigDirpath); err != nil {
+		t.Fatalf("CloneCredentials failed: %v", err)
+	}
+
+	missionFilepath := filepath.Join(claudeConfigDirpath, CredentialsFilename)
+	info, err := os.Stat(missionFilepath)
+	if err != nil {
+		t.Fatalf("expected credentials cloned into the mission's config dir: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %o", info.Mode().Perm())
+	}
+	if got, _ := os.ReadFile(missionFilepath); string(got) != globalCred {
+		t.Errorf("expected cloned credentials %q, got %q", globalCred, got)
+	}
+
+	// An MCP server authorized inside the mission flows back to the global file
+	missionCred := `{"claudeAiOauth":{"accessToken":"base-token"},"mcpOAuth":{"todoist|abc":{"accessToken":"tok1","expiresAt":1000}}}`
+	if err := WriteMissionCredentials(claudeConfigDirpath, missionCred); err != nil {
+		t.Fatalf("WriteMissionCredentials failed: %v", err)
+	}
+	if err := WriteBackCredentials(claudeConfigDirpath); err != nil {
+		t.Fatalf("WriteBackCredentials failed: %v", err)
+	}
+	merged, err := ReadGlobalCredentials()
+	if err != nil {
+		t.Fatalf("ReadGlobalCredentials failed: %v", err)
+	}
+	if !strings.Contains(merged, "todoist|abc") {
+		t.Errorf("expected the mission's MCP token merged into the global file, got %s", merged)
+	}
+	if info, err := os.Stat(globalFilepath); err != nil {
+		t.Errorf("failed to stat the global credentials file: %v", err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("expected the global file to stay 0600, got %o", info.Mode().Perm())
+	}
+
+	if err := DeleteCredentials(claudeConfigDirpath); err != nil {
+		t.Fatalf("DeleteCredentials failed: %v", err)
+	}
+	if _, err := os.Stat(missionFilepath); !os.IsNotExist(err) {
+		t.Errorf("expected the mission's credentials file removed, got %v", err)
+	}
+	if err := DeleteCredentials(claudeConfigDirpath); err != nil {
+		t.Errorf("expected deleting missing credentials to succeed, got %v", err)
+	}
+}
+
 func TestCopyAndPatchClaudeJSON_NoTrust(t *testing.T) {
 	homeDir := setupFakeHome(t)
 	claudeJSONPath := filepath.Join(homeDir, ".claude", ".claude.json")
@@ -68,7 +132,7 @@ func TestCopyAndPatchClaudeJSON_NoTrust(t *testing.T) {
 	destDir := t.TempDir()
 	agentDir := "/fake/agent/dir"
 
-	if err := copyAndPatchClaudeJSON(destDir, agentDir, nil); err != nil {
+	if err := copyAndPatchClaudeJSON(destDir, agentDir, nil, nil); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -98,7 +162,7 @@ func TestCopyAndPatchClaudeJSON_TrustAll(t *testing.T) {
 	agentDir := "/fake/agent/dir"
 	trust := &config.TrustedMcpServers{All: true}
 
-	if err := copyAndPatchClaudeJSON(destDir, agentDir, trust); err != nil {
+	if err := copyAndPatchClaudeJSON(destDir, agentDir, trust, nil); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -133,7 +197,7 @@ func TestCopyAndPatchClaudeJSON_TrustList(t *testing.T) {
 	agentDir := "/fake/agent/dir"
 	trust := &config.TrustedMcpServers{List: []string{"github", "sentry"}}
 
-	if err := copyAndPatchClaudeJSON(destDir, agentDir, trust); err != nil {
+	if err := copyAndPatchClaudeJSON(destDir, agentDir, trust, nil); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -155,6 +219,45 @@ func TestCopyAndPatchClaudeJSON_TrustList(t *testing.T) {
 	}
 }
 
+func TestCopyAndPatchClaudeJSON_McpServers(t *testing.T) {
+	homeDir := setupFakeHome(t)
+	claudeJSONPath := filepath.Join(homeDir, ".claude", ".claude.json")
+	if err := os.MkdirAll(filepath.Dir(claudeJSONPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(claudeJSONPath, []byte(`{"projects":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := t.TempDir()
+	agentDir := "/fake/agent/dir"
+	mcpServers := map[string]config.McpServerConfig{
+		"acme":   {Command: "npx", Args: []string{"-y", "@acme/mcp"}, Env: map[string]string{"ACME_TOKEN": "${ACME_TOKEN}"}},
+		"remote": {Type: config.McpServerTypeHTTP, URL: "https://mcp.example.com"},
+	}
+
+	if err := copyAndPatchClaudeJSON(destDir, agentDir, nil, mcpServers); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := readClaudeJSONProjectEntry(t, destDir, agentDir)
+	servers, ok := result["mcpServers"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected mcpServers object, got %v", result["mcpServers"])
+	}
+	acme, ok := servers["acme"].(map[string]interface{})
+	if !ok || acme["command"] != "npx" {
+		t.Errorf("expected acme stdio server with command npx, got %v", servers["acme"])
+	}
+	if _, hasURL := acme["url"]; hasURL {
+		t.Error("expected empty fields to be omitted from the stdio server")
+	}
+	remote, ok := servers["remote"].(map[string]interface{})
+	if !ok || remote["type"] != "http" || remote["url"] != "https://mcp.example.com" {
+		t.Errorf("expected remote http server, got %v", servers["remote"])
+	}
+}
+
 func TestComputeProjectDirpath(t *testing.T) {
 	result, err := ComputeProjectDirpath("/Users/odyssey/.agenc/missions/abc-123/agent")
 	if err != nil {
@@ -190,7 +293,7 @@ func TestBuildMergedClaudeMd(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := buildMergedClaudeMd(shadowDir, agencModsDir, destDir); err != nil {
+	if err := buildMergedClaudeMd(shadowDir, agencModsDir, "", destDir); err != nil {
 		t.Fatalf("buildMergedClaudeMd failed: %v", err)
 	}
 
@@ -281,3 +384,46 @@ func readClaudeJSONProjectEntry(t *testing.T, destDir string, agentDir string) m
 	}
 	return entry
 }
+
+// TestBuildMergedClaudeMd_RepoOverlay verifies that a repo's claudeMdAppend
+// content lands after the user's CLAUDE.md and claude-modifications layers.
+func TestBuildMergedClaudeMd_RepoOverlay(t *testing.T) {
+	homeDir := setupFakeHome(t)
+	agencDirpath := filepath.Join(homeDir, ".agenc")
+	shadowDir := filepath.Join(agencDirpath, ShadowRepoDirname)
+	agencModsDir := filepath.Join(agencDirpath, "config", "claude-modifications")
+	destDir := filepath.Join(agencDirpath, "missions", "test-mission", "claude-config")
+	for _, d := range []string{shadowDir, agencModsDir, destDir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(shadowDir, "CLAUDE.md"), []byte("user layer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agencModsDir, "CLAUDE.md"), []byte("mods layer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := buildMergedClaudeMd(shadowDir, agencModsDir, "repo layer", destDir); err != nil {
+		t.Fatalf("buildMergedClaudeMd failed: %v", err)
+	}
+	out, err := os.ReadFile(filepath.Join(destDir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("failed to read merged CLAUDE.md: %v", err)
+	}
+	if want := "user layer\n\nmods layer\n\nrepo layer\n"; string(out) != want {
+		t.Errorf("merged CLAUDE.md = %q, want %q", out, want)
+	}
+
+	// Without user or mods content, the repo overlay stands alone
+	_ = os.Remove(filepath.Join(shadowDir, "CLAUDE.md"))
+	_ = os.Remove(filepath.Join(agencModsDir, "CLAUDE.md"))
+	if err := buildMergedClaudeMd(shadowDir, agencModsDir, "repo layer", destDir); err != nil {
+		t.Fatalf("buildMergedClaudeMd failed: %v", err)
+	}
+	out, _ = os.ReadFile(filepath.Join(destDir, "CLAUDE.md"))
+	if string(out) != "repo layer\n" {
+		t.Errorf("merged CLAUDE.md = %q, want %q", out, "repo layer\n")
+	}
+}
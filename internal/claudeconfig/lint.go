@@ -0,0 +1,305 @@
+package claudeconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// knownHookEvents lists the hook events Claude Code fires. Hooks registered
+// under any other name never run, so they are reported as warnings.
+var knownHookEvents = map[string]bool{
+	"PreToolUse":        true,
+	"PostToolUse":       true,
+	"Notification":      true,
+	"UserPromptSubmit":  true,
+	"Stop":              true,
+	"SubagentStart":     true,
+	"SubagentStop":      true,
+	"PreCompact":        true,
+	"SessionStart":      true,
+	"SessionEnd":        true,
+	"PermissionRequest": true,
+}
+
+// knownHookTypes lists the values a hook's "type" field accepts.
+var knownHookTypes = map[string]bool{
+	"command": true,
+	"prompt":  true,
+}
+
+// knownPermissionModes lists the values permissions.defaultMode accepts.
+var knownPermissionModes = map[string]bool{
+	"default":           true,
+	"acceptEdits":       true,
+	"plan":              true,
+	"bypassPermissions": true,
+	"dontAsk":           true,
+}
+
+// SettingsIssue is a single problem found in a Claude settings.json. Severity
+// uses the config.yml severities: errors are settings AgenC refuses to build a
+// mission from, warnings are settings Claude silently ignores.
+type SettingsIssue struct {
+	Severity string
+	Path     string
+	Message  string
+}
+
+// String formats the issue as "path: message", omitting the path when empty.
+func (i SettingsIssue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// SettingsLintResult holds the issues found in one settings.json file.
+type SettingsLintResult struct {
+	Filepath string
+	Issues   []SettingsIssue
+}
+
+// LintSettings checks settings.json content for the sections AgenC merges
+// into every mission: hooks, permissions, and statusLine. Other keys are left
+// to Claude. Issues are returned sorted by path.
+func LintSettings(data []byte) []SettingsIssue {
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return []SettingsIssue{{Severity: config.ConfigIssueSeverityError, Message: "not a JSON object: " + err.Error()}}
+	}
+
+	l := &settingsLinter{}
+	if raw, ok := settings["hooks"]; ok {
+		l.lintHooks(raw)
+	}
+	if raw, ok := settings["permissions"]; ok {
+		l.lintPermissions(raw)
+	}
+	if raw, ok := settings["statusLine"]; ok {
+		l.lintStatusLine(raw)
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool { return l.issues[i].Path < l.issues[j].Path })
+	return l.issues
+}
+
+// LintMissionSettingsSources lints the two settings.json files every mission's
+// settings are merged from: the user's (via the shadow repo) and the AgenC
+// modifications. Missing files are skipped.
+func LintMissionSettingsSources(agencDirpath string) ([]SettingsLintResult, error) {
+	sourceFilepaths := []string{
+		filepath.Join(GetShadowRepoDirpath(agencDirpath), "settings.json"),
+		filepath.Join(config.GetClaudeModificationsDirpath(agencDirpath), "settings.json"),
+	}
+
+	var results []SettingsLintResult
+	for _, sourceFilepath := range sourceFilepaths {
+		data, err := os.ReadFile(sourceFilepath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, stacktrace.Propagate(err, "failed to read '%s'", sourceFilepath)
+		}
+		results = append(results, SettingsLintResult{Filepath: sourceFilepath, Issues: LintSettings(data)})
+	}
+	return results, nil
+}
+
+// ValidateMissionSettingsSources returns an error listing every error-severity
+// issue in the mission settings sources, or nil if a mission can be built from
+// them. Warnings are left to 'agenc config lint-claude'.
+func ValidateMissionSettingsSources(agencDirpath string) error {
+	results, err := LintMissionSettingsSources(agencDirpath)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			if issue.Severity == config.ConfigIssueSeverityError {
+				lines = append(lines, result.Filepath+": "+issue.String())
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return stacktrace.NewError("invalid Claude settings (run 'agenc config lint-claude' for details):\n%s", strings.Join(lines, "\n"))
+}
+
+// settingsLinter accumulates issues while walking a settings.json.
+type settingsLinter struct {
+	issues []SettingsIssue
+}
+
+func (l *settingsLinter) errorf(path string, format string, args ...any) {
+	l.issues = append(l.issues, SettingsIssue{Severity: config.ConfigIssueSeverityError, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *settingsLinter) warnf(path string, format string, args ...any) {
+	l.issues = append(l.issues, SettingsIssue{Severity: config.ConfigIssueSeverityWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// lintHooks checks the hooks section: an object of event name to a list of
+// matcher groups, each holding a list of hooks.
+func (l *settingsLinter) lintHooks(raw json.RawMessage) {
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &events); err != nil {
+		l.errorf("hooks", "must be an object of hook event to matcher list")
+		return
+	}
+
+	for event, eventRaw := range events {
+		eventPath := "hooks." + event
+		if !knownHookEvents[event] {
+			l.warnf(eventPath, "unknown hook event; Claude never runs these hooks")
+		}
+
+		var groups []json.RawMessage
+		if err := json.Unmarshal(eventRaw, &groups); err != nil {
+			l.errorf(eventPath, "must be a list of matcher groups")
+			continue
+		}
+		for i, groupRaw := range groups {
+			l.lintHookGroup(fmt.Sprintf("%s[%d]", eventPath, i), groupRaw)
+		}
+	}
+}
+
+// lintHookGroup checks one matcher group: {"matcher": "...", "hooks": [...]}.
+func (l *settingsLinter) lintHookGroup(path string, raw json.RawMessage) {
+	var group map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &group); err != nil {
+		l.errorf(path, "must be an object with a 'hooks' list")
+		return
+	}
+	if matcherRaw, ok := group["matcher"]; ok && !isJSONString(matcherRaw) {
+		l.errorf(path+".matcher", "must be a string")
+	}
+
+	hooksRaw, ok := group["hooks"]
+	if !ok {
+		l.errorf(path, "missing 'hooks' list")
+		return
+	}
+	var hooks []json.RawMessage
+	if err := json.Unmarshal(hooksRaw, &hooks); err != nil {
+		l.errorf(path+".hooks", "must be a list of hooks")
+		return
+	}
+	for i, hookRaw := range hooks {
+		l.lintHook(fmt.Sprintf("%s.hooks[%d]", path, i), hookRaw)
+	}
+}
+
+// lintHook checks a single hook: its type, the command or prompt it runs, and
+// an optional numeric timeout.
+func (l *settingsLinter) lintHook(path string, raw json.RawMessage) {
+	var hook map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &hook); err != nil {
+		l.errorf(path, "must be an object")
+		return
+	}
+
+	var hookType string
+	if err := json.Unmarshal(hook["type"], &hookType); err != nil || hookType == "" {
+		l.errorf(path+".type", "must be one of: command, prompt")
+		return
+	}
+	if !knownHookTypes[hookType] {
+		l.errorf(path+".type", "unknown hook type '%s' (expected command or prompt)", hookType)
+		return
+	}
+	if !isNonEmptyJSONString(hook[hookType]) {
+		l.errorf(path+"."+hookType, "%s hooks need a non-empty '%s' string", hookType, hookType)
+	}
+	if timeoutRaw, ok := hook["timeout"]; ok {
+		var timeout float64
+		if err := json.Unmarshal(timeoutRaw, &timeout); err != nil || timeout <= 0 {
+			l.errorf(path+".timeout", "must be a positive number of seconds")
+		}
+	}
+}
+
+// lintPermissions checks the permission rule lists and the default mode.
+func (l *settingsLinter) lintPermissions(raw json.RawMessage) {
+	var permissions map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &permissions); err != nil {
+		l.errorf("permissions", "must be an object")
+		return
+	}
+
+	for _, key := range []string{"allow", "deny", "ask", "additionalDirectories"} {
+		listRaw, ok := permissions[key]
+		if !ok {
+			continue
+		}
+		path := "permissions." + key
+		var entries []json.RawMessage
+		if err := json.Unmarshal(listRaw, &entries); err != nil {
+			l.errorf(path, "must be a list of strings")
+			continue
+		}
+		for i, entryRaw := range entries {
+			if !isNonEmptyJSONString(entryRaw) {
+				l.errorf(fmt.Sprintf("%s[%d]", path, i), "must be a non-empty string")
+			}
+		}
+	}
+
+	if modeRaw, ok := permissions["defaultMode"]; ok {
+		var mode string
+		if err := json.Unmarshal(modeRaw, &mode); err != nil || !knownPermissionModes[mode] {
+			l.errorf("permissions.defaultMode", "must be one of: %s", strings.Join(sortedKeys(knownPermissionModes), ", "))
+		}
+	}
+}
+
+// lintStatusLine checks the statusLine section: a command to run.
+func (l *settingsLinter) lintStatusLine(raw json.RawMessage) {
+	var statusLine map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &statusLine); err != nil {
+		l.errorf("statusLine", "must be an object")
+		return
+	}
+
+	var statusLineType string
+	if err := json.Unmarshal(statusLine["type"], &statusLineType); err != nil || statusLineType != "command" {
+		l.errorf("statusLine.type", "must be \"command\"")
+	}
+	if !isNonEmptyJSONString(statusLine["command"]) {
+		l.errorf("statusLine.command", "must be a non-empty string")
+	}
+}
+
+// isJSONString reports whether raw holds a JSON string.
+func isJSONString(raw json.RawMessage) bool {
+	var s string
+	return json.Unmarshal(raw, &s) == nil
+}
+
+// isNonEmptyJSONString reports whether raw holds a JSON string with
+// non-whitespace content.
+func isNonEmptyJSONString(raw json.RawMessage) bool {
+	var s string
+	return json.Unmarshal(raw, &s) == nil && strings.TrimSpace(s) != ""
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,114 @@
+package claudeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestLintSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		settings   string
+		wantIssues []string
+	}{
+		{
+			name: "valid settings",
+			settings: `{
+				"model": "opus",
+				"hooks": {"PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "guard.sh", "timeout": 5}]}]},
+				"permissions": {"allow": ["Bash(npm:*)"], "deny": [], "defaultMode": "acceptEdits"},
+				"statusLine": {"type": "command", "command": "status.sh"}
+			}`,
+		},
+		{
+			name:       "not an object",
+			settings:   `[1, 2]`,
+			wantIssues: []string{"error: not a JSON object"},
+		},
+		{
+			name:     "malformed hooks",
+			settings: `{"hooks": {"PreToolUse": [{"hooks": [{"type": "command"}, {"type": "script", "command": "x"}]}, {"matcher": 3}], "Stopp": []}}`,
+			wantIssues: []string{
+				"error: hooks.PreToolUse[0].hooks[0].command: command hooks need a non-empty 'command' string",
+				"error: hooks.PreToolUse[0].hooks[1].type: unknown hook type 'script'",
+				"error: hooks.PreToolUse[1]: missing 'hooks' list",
+				"error: hooks.PreToolUse[1].matcher: must be a string",
+				"warning: hooks.Stopp: unknown hook event",
+			},
+		},
+		{
+			name:     "hook event not a list",
+			settings: `{"hooks": {"Stop": {"type": "command", "command": "x"}}}`,
+			wantIssues: []string{
+				"error: hooks.Stop: must be a list of matcher groups",
+			},
+		},
+		{
+			name:     "malformed permissions",
+			settings: `{"permissions": {"allow": "Bash", "deny": ["Read", 4], "defaultMode": "yolo"}}`,
+			wantIssues: []string{
+				"error: permissions.allow: must be a list of strings",
+				"error: permissions.defaultMode: must be one of",
+				"error: permissions.deny[1]: must be a non-empty string",
+			},
+		},
+		{
+			name:     "statusLine without command",
+			settings: `{"statusLine": {"type": "text"}}`,
+			wantIssues: []string{
+				"error: statusLine.command: must be a non-empty string",
+				"error: statusLine.type: must be \"command\"",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintSettings([]byte(tt.settings))
+			if len(issues) != len(tt.wantIssues) {
+				t.Fatalf("expected %d issues, got %d: %v", len(tt.wantIssues), len(issues), issues)
+			}
+			for i, want := range tt.wantIssues {
+				got := issues[i].Severity + ": " + issues[i].String()
+				if !strings.HasPrefix(got, want) {
+					t.Errorf("issue %d: expected prefix %q, got %q", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMissionSettingsSources(t *testing.T) {
+	agencDirpath := t.TempDir()
+	if err := ValidateMissionSettingsSources(agencDirpath); err != nil {
+		t.Fatalf("expected no error without settings files, got %v", err)
+	}
+
+	shadowDirpath := GetShadowRepoDirpath(agencDirpath)
+	if err := os.MkdirAll(shadowDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	shadowSettings := `{"hooks": {"Stopp": [{"hooks": [{"type": "command", "command": "x"}]}]}}`
+	if err := os.WriteFile(filepath.Join(shadowDirpath, "settings.json"), []byte(shadowSettings), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateMissionSettingsSources(agencDirpath); err != nil {
+		t.Fatalf("expected warnings not to fail validation, got %v", err)
+	}
+
+	modsDirpath := config.GetClaudeModificationsDirpath(agencDirpath)
+	if err := os.MkdirAll(modsDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modsDirpath, "settings.json"), []byte(`{"statusLine": "status.sh"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := ValidateMissionSettingsSources(agencDirpath)
+	if err == nil || !strings.Contains(err.Error(), "statusLine: must be an object") {
+		t.Fatalf("expected the statusLine error, got %v", err)
+	}
+}
@@ -223,8 +223,9 @@ func MergeClaudeMd(userContent []byte, modsContent []byte) []byte {
 // them, and appends agenc operational overrides (hooks + allow/deny permissions).
 // Returns the final merged JSON bytes. agentDirpath is the mission's working
 // directory to allow access to. claudeConfigDirpath is the per-mission config
-// directory that should be denied from agent access.
-func MergeSettings(userSettingsData []byte, modsSettingsData []byte, agencDirpath string, agentDirpath string, claudeConfigDirpath string, containerized bool) ([]byte, error) {
+// directory that should be denied from agent access. extraDenyEntries are
+// appended to the deny list as-is (e.g. review mode's push rules).
+func MergeSettings(userSettingsData []byte, modsSettingsData []byte, agencDirpath string, agentDirpath string, claudeConfigDirpath string, containerized bool, extraDenyEntries []string) ([]byte, error) {
 	// Default to empty objects if nil
 	if userSettingsData == nil {
 		userSettingsData = []byte("{}")
@@ -257,7 +258,7 @@ func MergeSettings(userSettingsData []byte, modsSettingsData []byte, agencDirpat
 	}
 
 	// Append agenc operational overrides (hooks + allow/deny permissions)
-	mergedData, err := MergeSettingsWithAgencOverrides(mergedBase, agencDirpath, agentDirpath, claudeConfigDirpath, containerized)
+	mergedData, err := MergeSettingsWithAgencOverrides(mergedBase, agencDirpath, agentDirpath, claudeConfigDirpath, containerized, extraDenyEntries)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to merge settings with agenc overrides")
 	}
@@ -307,7 +308,7 @@ func mergeAgencHooks(settings map[string]json.RawMessage, hookEntries map[string
 
 // mergeAgencPermissions reads the existing permissions from settings, appends
 // the agenc allow/deny entries, and returns the merged permissions as a json.RawMessage.
-func mergeAgencPermissions(settings map[string]json.RawMessage, agencDirpath string, agentDirpath string, claudeConfigDirpath string) (json.RawMessage, error) {
+func mergeAgencPermissions(settings map[string]json.RawMessage, agencDirpath string, agentDirpath string, claudeConfigDirpath string, extraDenyEntries []string) (json.RawMessage, error) {
 	var permsMap map[string]json.RawMessage
 	if existingPerms, ok := settings["permissions"]; ok {
 		if err := json.Unmarshal(existingPerms, &permsMap); err != nil {
@@ -344,6 +345,7 @@ func mergeAgencPermissions(settings map[string]json.RawMessage, agencDirpath str
 	if claudeConfigDirpath != "" {
 		mergedDeny = append(mergedDeny, BuildClaudeConfigDenyEntries(claudeConfigDirpath)...)
 	}
+	mergedDeny = append(mergedDeny, extraDenyEntries...)
 	denyBytes, err := json.Marshal(mergedDeny)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to marshal merged deny array")
@@ -357,6 +359,15 @@ func mergeAgencPermissions(settings map[string]json.RawMessage, agencDirpath str
 	return json.RawMessage(permsBytes), nil
 }
 
+// mergeAgencStatusLine points Claude's statusline at the mission's statusline
+// message file, unless the user already configured a statusLine of their own.
+func mergeAgencStatusLine(settings map[string]json.RawMessage, claudeConfigDirpath string) {
+	if _, ok := settings["statusLine"]; ok {
+		return
+	}
+	settings["statusLine"] = BuildStatusLineEntry(claudeConfigDirpath)
+}
+
 // mergeAgencSandbox ensures the AgenC server socket is included in the
 // sandbox.network.allowUnixSockets list so agents can reach the server.
 func mergeAgencSandbox(settings map[string]json.RawMessage, agencDirpath string) error {
@@ -424,7 +435,8 @@ func mergeAgencSandbox(settings map[string]json.RawMessage, agencDirpath string)
 // bytes. The existing hooks and permissions are preserved; agenc entries are
 // appended. agentDirpath is the mission's working directory to allow access to.
 // claudeConfigDirpath is the per-mission config directory to deny agent access to.
-func MergeSettingsWithAgencOverrides(settingsData []byte, agencDirpath string, agentDirpath string, claudeConfigDirpath string, containerized bool) ([]byte, error) {
+// extraDenyEntries are appended to the deny list after agenc's own entries.
+func MergeSettingsWithAgencOverrides(settingsData []byte, agencDirpath string, agentDirpath string, claudeConfigDirpath string, containerized bool, extraDenyEntries []string) ([]byte, error) {
 	var settings map[string]json.RawMessage
 	if err := json.Unmarshal(settingsData, &settings); err != nil {
 		return nil, stacktrace.Propagate(err, "failed to parse settings JSON")
@@ -443,7 +455,7 @@ func MergeSettingsWithAgencOverrides(settingsData []byte, agencDirpath string, a
 	}
 	settings["hooks"] = mergedHooks
 
-	mergedPerms, err := mergeAgencPermissions(settings, agencDirpath, agentDirpath, claudeConfigDirpath)
+	mergedPerms, err := mergeAgencPermissions(settings, agencDirpath, agentDirpath, claudeConfigDirpath, extraDenyEntries)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "")
 	}
@@ -453,6 +465,12 @@ func MergeSettingsWithAgencOverrides(settingsData []byte, agencDirpath string, a
 		return nil, stacktrace.Propagate(err, "")
 	}
 
+	// The statusline message file lives in the host mission directory, which
+	// is not mounted into containers.
+	if !containerized {
+		mergeAgencStatusLine(settings, claudeConfigDirpath)
+	}
+
 	result, err := json.MarshalIndent(settings, "", "  ")
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to marshal merged settings")
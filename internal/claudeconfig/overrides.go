@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/odyssey/agenc/internal/config"
 )
 
 // AgencHooksDirname is the per-mission claude-config subdirectory that holds
@@ -58,9 +60,9 @@ var staticContainerHookEntries map[string]json.RawMessage
 func init() {
 	staticAgencHookEntries = make(map[string]json.RawMessage, len(agencHookEventNames)+1)
 	for _, eventName := range agencHookEventNames {
-		// The Go command handler (runMissionSendClaudeUpdate) skips stdin for
-		// non-Notification events and uses a timeout for Notification events,
-		// so no shell-level stdin redirect is needed here. Shell redirects like
+		// The Go command handler (runMissionSendClaudeUpdate) only reads stdin
+		// for Notification and PostToolUse* events, and with a timeout, so no
+		// shell-level stdin redirect is needed here. Shell redirects like
 		// "< /dev/null" cannot be used because Claude Code may tokenize the
 		// command string rather than passing it to sh -c, causing the redirect
 		// tokens to be interpreted as extra positional arguments.
@@ -150,6 +152,19 @@ func buildRepoLibraryGuardHookEntry(claudeConfigDirpath string) json.RawMessage
 	return json.RawMessage(entry)
 }
 
+// BuildStatusLineEntry returns the statusLine setting that prints the
+// mission's statusline message file (written by the wrapper, e.g. with the
+// mission's budget usage). The file sits next to claude-config in the
+// mission directory; a missing file prints nothing.
+func BuildStatusLineEntry(claudeConfigDirpath string) json.RawMessage {
+	messageFilepath := filepath.Join(filepath.Dir(claudeConfigDirpath), config.StatuslineMessageFilename)
+
+	command := fmt.Sprintf("cat %s 2>/dev/null || true", messageFilepath)
+	commandJSON, _ := json.Marshal(command)
+
+	return json.RawMessage(fmt.Sprintf(`{"type":"command","command":%s}`, string(commandJSON)))
+}
+
 // AgencFilePermissionTools lists the Claude Code file-access tools used to
 // construct both allow and deny permission entries.
 var AgencFilePermissionTools = []string{
@@ -260,6 +275,38 @@ func BuildClaudeConfigDenyEntries(claudeConfigDirpath string) []string {
 	return entries
 }
 
+// reviewDenyPushPatterns are Bash patterns denied in review mode regardless
+// of branch: pushes that could reach a protected branch without naming it,
+// and ways of rewriting the remote push mapping that sends pushes to holding
+// branches.
+var reviewDenyPushPatterns = []string{
+	"git push *--all*",
+	"git push *--mirror*",
+	"git push *--delete*",
+	"git config *remote.*.push*",
+	"git -c *push*",
+	"gh pr merge*",
+}
+
+// BuildReviewDenyEntries constructs permission deny entries for a
+// reviewRequired mission: direct pushes to any of protectedBranches, plus the
+// push forms that would sidestep the holding-branch mapping. These are a
+// guard rail for the agent, not a security boundary — the holding-branch
+// refspec and origin's own branch protection do the real gating.
+func BuildReviewDenyEntries(protectedBranches []string) []string {
+	entries := make([]string, 0, len(protectedBranches)*4+len(reviewDenyPushPatterns))
+	for _, branch := range protectedBranches {
+		for _, dest := range []string{branch, "refs/heads/" + branch} {
+			entries = append(entries, "Bash(git push *:"+dest+")")
+			entries = append(entries, "Bash(git push *:"+dest+" *)")
+		}
+	}
+	for _, pattern := range reviewDenyPushPatterns {
+		entries = append(entries, "Bash("+pattern+")")
+	}
+	return entries
+}
+
 // isFileName returns true if the name looks like a file (contains a dot
 // indicating an extension) rather than a directory.
 func isFileName(name string) bool {
@@ -241,3 +241,31 @@ func TestClaudeConfigProtectedItems_IncludesAgencHooks(t *testing.T) {
 	t.Errorf("expected %q in claudeConfigProtectedItems, got %v",
 		AgencHooksDirname, claudeConfigProtectedItems)
 }
+
+func TestBuildReviewDenyEntries(t *testing.T) {
+	entries := BuildReviewDenyEntries([]string{"main"})
+
+	for _, want := range []string{
+		"Bash(git push *:main)",
+		"Bash(git push *:main *)",
+		"Bash(git push *:refs/heads/main)",
+		"Bash(git push *:refs/heads/main *)",
+		"Bash(git push *--mirror*)",
+		"Bash(git config *remote.*.push*)",
+	} {
+		found := false
+		for _, entry := range entries {
+			if entry == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected deny entry %q, got %v", want, entries)
+		}
+	}
+
+	if got := len(BuildReviewDenyEntries(nil)); got != len(reviewDenyPushPatterns) {
+		t.Errorf("expected only the branch-independent entries without protected branches, got %d", got)
+	}
+}
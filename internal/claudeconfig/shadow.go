@@ -6,10 +6,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/wsl"
 )
 
 const (
@@ -85,6 +88,12 @@ func InitShadowRepo(agencDirpath string) (string, error) {
 // userClaudeDirpath is the path to ~/.claude (or equivalent).
 // shadowDirpath is the path to the shadow repo.
 func IngestFromClaudeDir(userClaudeDirpath string, shadowDirpath string) error {
+	return IngestFromClaudeDirWithMessage(userClaudeDirpath, shadowDirpath, "Sync from ~/.claude")
+}
+
+// IngestFromClaudeDirWithMessage is IngestFromClaudeDir with the commit
+// message given, for changes agenc itself made to ~/.claude.
+func IngestFromClaudeDirWithMessage(userClaudeDirpath string, shadowDirpath string, message string) error {
 	changed := false
 
 	// Ingest tracked files
@@ -116,7 +125,7 @@ func IngestFromClaudeDir(userClaudeDirpath string, shadowDirpath string) error {
 	}
 
 	if changed {
-		if err := commitShadowChanges(shadowDirpath, "Sync from ~/.claude"); err != nil {
+		if err := commitShadowChanges(shadowDirpath, message); err != nil {
 			return stacktrace.Propagate(err, "failed to commit shadow repo changes")
 		}
 	}
@@ -132,7 +141,15 @@ func IngestFromClaudeDir(userClaudeDirpath string, shadowDirpath string) error {
 //   - Absolute: /Users/name/.claude → targetDirpath
 //   - ${HOME}/.claude → targetDirpath
 //   - ~/.claude → targetDirpath
+//
+// Under WSL, references to the Windows profile's .claude are normalized too
+// (see rewriteWindowsClaudePaths), since Claude config edited by the Windows
+// build of Claude Code records paths in Windows form.
 func RewriteClaudePaths(content []byte, targetDirpath string) []byte {
+	if windowsHomeDirpath := wsl.WindowsHomeDirpath(); windowsHomeDirpath != "" {
+		content = rewriteWindowsClaudePaths(content, targetDirpath, windowsHomeDirpath)
+	}
+
 	homeDirpath, err := os.UserHomeDir()
 	if err != nil {
 		// If we can't determine home, only rewrite tilde form
@@ -176,6 +193,65 @@ func RewriteClaudePaths(content []byte, targetDirpath string) []byte {
 	return content
 }
 
+// windowsPathSepPattern matches a Windows path separator as it appears in
+// plain text (\ or /) or in JSON strings (an escaped \\).
+const windowsPathSepPattern = `(?:\\\\|\\|/)`
+
+// rewriteWindowsClaudePaths replaces references to the .claude directory in
+// the Windows profile at windowsHomeDirpath (a Linux path such as
+// /mnt/c/Users/name) with targetDirpath. Matches are case-insensitive and
+// cover the drive form (C:\Users\name\.claude, with \, / or JSON-escaped
+// separators), %USERPROFILE%\.claude, and the /mnt/c/Users/name/.claude
+// mount. Separators in the rest of a matched path become /, so
+// C:\Users\name\.claude\hooks\a.sh becomes targetDirpath/hooks/a.sh.
+func rewriteWindowsClaudePaths(content []byte, targetDirpath string, windowsHomeDirpath string) []byte {
+	homeSegments := strings.Split(strings.Trim(windowsHomeDirpath, "/"), "/")
+	if len(homeSegments) < 2 || homeSegments[0] != "mnt" {
+		return content
+	}
+	quoted := make([]string, 0, len(homeSegments)-2)
+	for _, segment := range homeSegments[2:] {
+		quoted = append(quoted, regexp.QuoteMeta(segment))
+	}
+	homeRest := strings.Join(quoted, windowsPathSepPattern)
+	drivePrefix := regexp.QuoteMeta(homeSegments[1]) + ":"
+	mountPrefix := "/mnt/" + regexp.QuoteMeta(homeSegments[1])
+	if homeRest != "" {
+		drivePrefix += windowsPathSepPattern + homeRest
+		mountPrefix += "/" + strings.Join(quoted, "/")
+	}
+
+	claudeDirRegex := regexp.MustCompile(
+		`(?i)(?:` + drivePrefix + `|%USERPROFILE%|` + mountPrefix + `)` +
+			windowsPathSepPattern + regexp.QuoteMeta(userClaudeDirname) +
+			`((?:` + windowsPathSepPattern + `[^\\/\s"'` + "`" + `<>|:*?]+)*` + windowsPathSepPattern + `?)`,
+	)
+	sepRegex := regexp.MustCompile(windowsPathSepPattern)
+
+	var result []byte
+	last := 0
+	for _, match := range claudeDirRegex.FindAllSubmatchIndex(content, -1) {
+		// Skip longer names that merely start with .claude (e.g. .claude.json)
+		if match[1] < len(content) && match[3] == match[2] && isPathNameByte(content[match[1]]) {
+			continue
+		}
+		result = append(result, content[last:match[0]]...)
+		result = append(result, targetDirpath...)
+		result = append(result, sepRegex.ReplaceAll(content[match[2]:match[3]], []byte("/"))...)
+		last = match[1]
+	}
+	if result == nil {
+		return content
+	}
+	return append(result, content[last:]...)
+}
+
+// isPathNameByte reports whether b can continue a file name.
+func isPathNameByte(b byte) bool {
+	return b == '.' || b == '-' || b == '_' ||
+		('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
 // isTextFile returns true if the file extension suggests a text file that
 // should have path normalization applied.
 func isTextFile(filepath string) bool {
@@ -0,0 +1,245 @@
+package claudeconfig
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// ShadowRemoteName is the git remote the shadow repo is backed up to and
+// synced with.
+const ShadowRemoteName = "origin"
+
+// Conflict preferences for SyncShadowRemote: which side wins where the
+// local and remote Claude config changed the same lines.
+const (
+	ShadowPreferLocal  = "local"
+	ShadowPreferRemote = "remote"
+)
+
+// ShadowSyncResult reports what a shadow repo sync did.
+type ShadowSyncResult struct {
+	// Pulled is true when commits from the remote were merged in and written
+	// back to ~/.claude.
+	Pulled bool
+	// Pushed is true when local commits were pushed to the remote.
+	Pushed bool
+}
+
+// GetShadowRemoteURL returns the URL of the shadow repo's remote, or an empty
+// string when none is set (or the shadow repo doesn't exist yet).
+func GetShadowRemoteURL(agencDirpath string) (string, error) {
+	shadowDirpath := GetShadowRepoDirpath(agencDirpath)
+	if _, err := os.Stat(filepath.Join(shadowDirpath, ".git")); err != nil {
+		return "", nil
+	}
+	remotes, err := runShadowGit(shadowDirpath, "remote")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to list shadow repo remotes")
+	}
+	if !containsLine(remotes, ShadowRemoteName) {
+		return "", nil
+	}
+	url, err := runShadowGit(shadowDirpath, "remote", "get-url", ShadowRemoteName)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to read shadow repo remote URL")
+	}
+	return url, nil
+}
+
+// SetShadowRemote points the shadow repo's remote at url, creating the shadow
+// repo first if needed.
+func SetShadowRemote(agencDirpath string, url string) error {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return stacktrace.NewError("remote URL cannot be empty")
+	}
+	shadowDirpath, err := InitShadowRepo(agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to initialize shadow repo")
+	}
+
+	existingURL, err := GetShadowRemoteURL(agencDirpath)
+	if err != nil {
+		return err
+	}
+	args := []string{"remote", "add", ShadowRemoteName, url}
+	if existingURL != "" {
+		args = []string{"remote", "set-url", ShadowRemoteName, url}
+	}
+	if _, err := runShadowGit(shadowDirpath, args...); err != nil {
+		return stacktrace.Propagate(err, "failed to set shadow repo remote")
+	}
+	return nil
+}
+
+// RemoveShadowRemote removes the shadow repo's remote, stopping backup and
+// sync. No-op when none is set.
+func RemoveShadowRemote(agencDirpath string) error {
+	existingURL, err := GetShadowRemoteURL(agencDirpath)
+	if err != nil || existingURL == "" {
+		return err
+	}
+	if _, err := runShadowGit(GetShadowRepoDirpath(agencDirpath), "remote", "remove", ShadowRemoteName); err != nil {
+		return stacktrace.Propagate(err, "failed to remove shadow repo remote")
+	}
+	return nil
+}
+
+// SyncShadowRemote pulls the remote's commits into the shadow repo, writes
+// any config they changed back to userClaudeDirpath, and pushes local
+// commits. Callers should ingest from userClaudeDirpath first so local edits
+// are committed before merging. Divergent edits are merged; on a conflict the
+// merge is aborted and an error returned, leaving both sides untouched,
+// unless prefer (ShadowPreferLocal or ShadowPreferRemote) picks a winner for
+// conflicting lines. No-op when no remote is set.
+func SyncShadowRemote(agencDirpath string, userClaudeDirpath string, prefer string) (ShadowSyncResult, error) {
+	var result ShadowSyncResult
+	mergeArgs := []string{"merge", "--no-edit", "--allow-unrelated-histories"}
+	switch prefer {
+	case "":
+	case ShadowPreferLocal:
+		mergeArgs = append(mergeArgs, "-X", "ours")
+	case ShadowPreferRemote:
+		mergeArgs = append(mergeArgs, "-X", "theirs")
+	default:
+		return result, stacktrace.NewError("invalid conflict preference '%s'; must be '%s' or '%s'", prefer, ShadowPreferLocal, ShadowPreferRemote)
+	}
+
+	remoteURL, err := GetShadowRemoteURL(agencDirpath)
+	if err != nil || remoteURL == "" {
+		return result, err
+	}
+	shadowDirpath := GetShadowRepoDirpath(agencDirpath)
+
+	// An unborn HEAD (nothing ingested yet) has no branch to sync
+	if _, err := runShadowGit(shadowDirpath, "rev-parse", "--verify", "HEAD"); err != nil {
+		return result, nil
+	}
+	branch, err := runShadowGit(shadowDirpath, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return result, stacktrace.Propagate(err, "failed to determine shadow repo branch")
+	}
+
+	if _, err := runShadowGit(shadowDirpath, "fetch", ShadowRemoteName); err != nil {
+		return result, stacktrace.Propagate(err, "failed to fetch from '%s'", remoteURL)
+	}
+
+	remoteRef := ShadowRemoteName + "/" + branch
+	if _, err := runShadowGit(shadowDirpath, "rev-parse", "--verify", remoteRef); err == nil {
+		headBefore, err := runShadowGit(shadowDirpath, "rev-parse", "HEAD")
+		if err != nil {
+			return result, stacktrace.Propagate(err, "failed to read shadow repo HEAD")
+		}
+		// Histories are unrelated the first time a machine syncs with a remote
+		// another machine already pushed to
+		if _, err := runShadowGit(shadowDirpath, append(mergeArgs, remoteRef)...); err != nil {
+			_, _ = runShadowGit(shadowDirpath, "merge", "--abort")
+			return result, stacktrace.Propagate(err, "Claude config on this machine conflicts with '%s'", remoteURL)
+		}
+		headAfter, err := runShadowGit(shadowDirpath, "rev-parse", "HEAD")
+		if err != nil {
+			return result, stacktrace.Propagate(err, "failed to read shadow repo HEAD")
+		}
+		if headAfter != headBefore {
+			if err := ExportToClaudeDir(shadowDirpath, userClaudeDirpath); err != nil {
+				return result, stacktrace.Propagate(err, "failed to write pulled config to '%s'", userClaudeDirpath)
+			}
+			result.Pulled = true
+		}
+	}
+
+	ahead, err := runShadowGit(shadowDirpath, "rev-list", "--count", remoteRef+"..HEAD")
+	if err != nil {
+		// The remote branch doesn't exist yet: everything is ahead
+		ahead = "1"
+	}
+	if ahead != "0" {
+		if _, err := runShadowGit(shadowDirpath, "push", ShadowRemoteName, "HEAD:refs/heads/"+branch); err != nil {
+			return result, stacktrace.Propagate(err, "failed to push to '%s'", remoteURL)
+		}
+		result.Pushed = true
+	}
+	return result, nil
+}
+
+// ExportToClaudeDir copies the tracked files from the shadow repo into the
+// user's ~/.claude directory — the reverse of IngestFromClaudeDir — so config
+// pulled from a remote takes effect. Tracked items missing from the shadow
+// repo are removed from userClaudeDirpath. Symlinked tracked items are
+// written through to their targets; a symlinked item missing from the
+// shadow repo has the link removed, never its target.
+func ExportToClaudeDir(shadowDirpath string, userClaudeDirpath string) error {
+	if err := os.MkdirAll(userClaudeDirpath, 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create '%s'", userClaudeDirpath)
+	}
+
+	for _, fileName := range TrackedFileNames {
+		srcFilepath := filepath.Join(shadowDirpath, fileName)
+		dstFilepath := resolveExportTarget(srcFilepath, filepath.Join(userClaudeDirpath, fileName))
+		if _, err := ingestFile(srcFilepath, dstFilepath); err != nil {
+			return stacktrace.Propagate(err, "failed to export file '%s'", fileName)
+		}
+	}
+
+	for _, dirName := range TrackedDirNames {
+		srcDirpath := filepath.Join(shadowDirpath, dirName)
+		dstDirpath := resolveExportTarget(srcDirpath, filepath.Join(userClaudeDirpath, dirName))
+		if _, err := ingestDir(srcDirpath, dstDirpath); err != nil {
+			return stacktrace.Propagate(err, "failed to export directory '%s'", dirName)
+		}
+	}
+	return nil
+}
+
+// resolveExportTarget resolves dstPath through symlinks when both it and
+// srcPath exist, so exports update a symlink's target (e.g. a dotfiles
+// checkout) rather than replacing the link.
+func resolveExportTarget(srcPath string, dstPath string) string {
+	if _, err := os.Stat(srcPath); err != nil {
+		return dstPath
+	}
+	resolved, err := resolveSymlink(dstPath)
+	if err != nil {
+		return dstPath
+	}
+	return resolved
+}
+
+// runShadowGit runs a git command in the shadow repo as the AgenC identity,
+// never prompting for credentials, and returns its trimmed stdout.
+func runShadowGit(shadowDirpath string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = shadowDirpath
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_AUTHOR_NAME=AgenC",
+		"GIT_AUTHOR_EMAIL=agenc@local",
+		"GIT_COMMITTER_NAME=AgenC",
+		"GIT_COMMITTER_EMAIL=agenc@local",
+	)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", stacktrace.NewError("git %s failed: %s (error: %v)", strings.Join(args, " "), strings.TrimSpace(stderr.String()), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// containsLine reports whether output has a line equal to want.
+func containsLine(output string, want string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == want {
+			return true
+		}
+	}
+	return false
+}
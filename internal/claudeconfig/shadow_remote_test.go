@@ -0,0 +1,190 @@
+package claudeconfig
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newShadowTestMachine sets up an agenc dir with an initialized shadow repo
+// and a ~/.claude holding the given files, ingested into the shadow repo.
+func newShadowTestMachine(t *testing.T, files map[string]string) (string, string) {
+	t.Helper()
+	agencDirpath := t.TempDir()
+	userClaudeDirpath := t.TempDir()
+	for name, content := range files {
+		writeTestFile(t, filepath.Join(userClaudeDirpath, name), content)
+	}
+	shadowDirpath, err := InitShadowRepo(agencDirpath)
+	if err != nil {
+		t.Fatalf("InitShadowRepo failed: %v", err)
+	}
+	if err := IngestFromClaudeDir(userClaudeDirpath, shadowDirpath); err != nil {
+		t.Fatalf("IngestFromClaudeDir failed: %v", err)
+	}
+	return agencDirpath, userClaudeDirpath
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read '%s': %v", path, err)
+	}
+	return string(data)
+}
+
+func syncShadow(t *testing.T, agencDirpath string, userClaudeDirpath string) ShadowSyncResult {
+	t.Helper()
+	if err := IngestFromClaudeDir(userClaudeDirpath, GetShadowRepoDirpath(agencDirpath)); err != nil {
+		t.Fatalf("IngestFromClaudeDir failed: %v", err)
+	}
+	result, err := SyncShadowRemote(agencDirpath, userClaudeDirpath, "")
+	if err != nil {
+		t.Fatalf("SyncShadowRemote failed: %v", err)
+	}
+	return result
+}
+
+func TestShadowRemote_SetShowRemove(t *testing.T) {
+	agencDirpath := t.TempDir()
+
+	url, err := GetShadowRemoteURL(agencDirpath)
+	if err != nil || url != "" {
+		t.Fatalf("expected no remote before the shadow repo exists, got %q (%v)", url, err)
+	}
+	if result, err := SyncShadowRemote(agencDirpath, t.TempDir(), ""); err != nil || result.Pulled || result.Pushed {
+		t.Fatalf("expected sync without a remote to be a no-op, got %+v (%v)", result, err)
+	}
+
+	for _, remote := range []string{"git@example.com:me/one.git", "git@example.com:me/two.git"} {
+		if err := SetShadowRemote(agencDirpath, remote); err != nil {
+			t.Fatalf("SetShadowRemote failed: %v", err)
+		}
+		if url, err := GetShadowRemoteURL(agencDirpath); err != nil || url != remote {
+			t.Fatalf("expected remote %q, got %q (%v)", remote, url, err)
+		}
+	}
+
+	if err := RemoveShadowRemote(agencDirpath); err != nil {
+		t.Fatalf("RemoveShadowRemote failed: %v", err)
+	}
+	if url, err := GetShadowRemoteURL(agencDirpath); err != nil || url != "" {
+		t.Fatalf("expected no remote after removal, got %q (%v)", url, err)
+	}
+	if err := RemoveShadowRemote(agencDirpath); err != nil {
+		t.Fatalf("expected removing a missing remote to be a no-op, got %v", err)
+	}
+}
+
+func TestSyncShadowRemote_AcrossMachines(t *testing.T) {
+	remoteDirpath := t.TempDir()
+	if output, err := exec.Command("git", "init", "--bare", remoteDirpath).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare failed: %v\n%s", err, output)
+	}
+
+	agencA, claudeA := newShadowTestMachine(t, map[string]string{"CLAUDE.md": "from A\n"})
+	agencB, claudeB := newShadowTestMachine(t, map[string]string{"skills/review/SKILL.md": "review\n"})
+	for _, agencDirpath := range []string{agencA, agencB} {
+		if err := SetShadowRemote(agencDirpath, remoteDirpath); err != nil {
+			t.Fatalf("SetShadowRemote failed: %v", err)
+		}
+	}
+
+	if result := syncShadow(t, agencA, claudeA); result.Pulled || !result.Pushed {
+		t.Errorf("expected the first machine to only push, got %+v", result)
+	}
+
+	// The second machine merges the first's unrelated history and pushes both
+	if result := syncShadow(t, agencB, claudeB); !result.Pulled || !result.Pushed {
+		t.Errorf("expected the second machine to pull and push, got %+v", result)
+	}
+	if got := readTestFile(t, filepath.Join(claudeB, "CLAUDE.md")); got != "from A\n" {
+		t.Errorf("expected CLAUDE.md from the first machine, got %q", got)
+	}
+
+	if result := syncShadow(t, agencA, claudeA); !result.Pulled || result.Pushed {
+		t.Errorf("expected the first machine to pull the merge, got %+v", result)
+	}
+	if got := readTestFile(t, filepath.Join(claudeA, "skills", "review", "SKILL.md")); got != "review\n" {
+		t.Errorf("expected the skill from the second machine, got %q", got)
+	}
+
+	if result := syncShadow(t, agencA, claudeA); result.Pulled || result.Pushed {
+		t.Errorf("expected nothing to sync, got %+v", result)
+	}
+
+	// Conflicting edits stop the sync and leave ~/.claude as it was
+	writeTestFile(t, filepath.Join(claudeA, "CLAUDE.md"), "edited on A\n")
+	writeTestFile(t, filepath.Join(claudeB, "CLAUDE.md"), "edited on B\n")
+	syncShadow(t, agencA, claudeA)
+	if err := IngestFromClaudeDir(claudeB, GetShadowRepoDirpath(agencB)); err != nil {
+		t.Fatalf("IngestFromClaudeDir failed: %v", err)
+	}
+	if _, err := SyncShadowRemote(agencB, claudeB, ""); err == nil {
+		t.Fatal("expected a conflict error")
+	}
+	if got := readTestFile(t, filepath.Join(claudeB, "CLAUDE.md")); got != "edited on B\n" {
+		t.Errorf("expected ~/.claude to be untouched after a conflict, got %q", got)
+	}
+	if got := readTestFile(t, filepath.Join(GetShadowRepoDirpath(agencB), "CLAUDE.md")); got != "edited on B\n" {
+		t.Errorf("expected the aborted merge to leave the shadow repo clean, got %q", got)
+	}
+
+	// Preferring the remote resolves the conflict in its favor
+	result, err := SyncShadowRemote(agencB, claudeB, ShadowPreferRemote)
+	if err != nil {
+		t.Fatalf("SyncShadowRemote preferring remote failed: %v", err)
+	}
+	if !result.Pulled || !result.Pushed {
+		t.Errorf("expected the resolved merge to be pulled and pushed, got %+v", result)
+	}
+	if got := readTestFile(t, filepath.Join(claudeB, "CLAUDE.md")); got != "edited on A\n" {
+		t.Errorf("expected the remote's CLAUDE.md to win, got %q", got)
+	}
+}
+
+func TestExportToClaudeDir_WritesThroughSymlinks(t *testing.T) {
+	shadowDirpath := t.TempDir()
+	userClaudeDirpath := t.TempDir()
+	dotfilesDirpath := t.TempDir()
+
+	writeTestFile(t, filepath.Join(shadowDirpath, "CLAUDE.md"), "pulled\n")
+	writeTestFile(t, filepath.Join(dotfilesDirpath, "CLAUDE.md"), "old\n")
+	if err := os.Symlink(filepath.Join(dotfilesDirpath, "CLAUDE.md"), filepath.Join(userClaudeDirpath, "CLAUDE.md")); err != nil {
+		t.Fatal(err)
+	}
+	// A symlinked item the shadow repo lacks loses the link, not its target
+	writeTestFile(t, filepath.Join(dotfilesDirpath, "agents", "a.md"), "agent\n")
+	if err := os.Symlink(filepath.Join(dotfilesDirpath, "agents"), filepath.Join(userClaudeDirpath, "agents")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExportToClaudeDir(shadowDirpath, userClaudeDirpath); err != nil {
+		t.Fatalf("ExportToClaudeDir failed: %v", err)
+	}
+
+	if got := readTestFile(t, filepath.Join(dotfilesDirpath, "CLAUDE.md")); got != "pulled\n" {
+		t.Errorf("expected the symlink target to be updated, got %q", got)
+	}
+	if info, err := os.Lstat(filepath.Join(userClaudeDirpath, "CLAUDE.md")); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("expected CLAUDE.md to stay a symlink (%v)", err)
+	}
+	if _, err := os.Lstat(filepath.Join(userClaudeDirpath, "agents")); !os.IsNotExist(err) {
+		t.Errorf("expected the agents link to be removed, got %v", err)
+	}
+	if got := readTestFile(t, filepath.Join(dotfilesDirpath, "agents", "a.md")); got != "agent\n" {
+		t.Errorf("expected the agents link target to survive, got %q", got)
+	}
+}
@@ -80,6 +80,68 @@ func TestRewriteClaudePaths(t *testing.T) {
 	}
 }
 
+func TestRewriteWindowsClaudePaths(t *testing.T) {
+	targetDirpath := "/home/testuser/.agenc/missions/abc-123/claude-config"
+	windowsHomeDirpath := "/mnt/c/Users/Test User"
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "drive path with backslashes",
+			input:    `Read C:\Users\Test User\.claude\skills\deploy\SKILL.md first`,
+			expected: `Read ` + targetDirpath + `/skills/deploy/SKILL.md first`,
+		},
+		{
+			name:     "JSON-escaped drive path",
+			input:    `{"command": "bash C:\\Users\\Test User\\.claude\\hooks\\style.sh"}`,
+			expected: `{"command": "bash ` + targetDirpath + `/hooks/style.sh"}`,
+		},
+		{
+			name:     "drive path with forward slashes, different case",
+			input:    `"installPath": "c:/users/test user/.claude/plugins/cache"`,
+			expected: `"installPath": "` + targetDirpath + `/plugins/cache"`,
+		},
+		{
+			name:     "drive mount path",
+			input:    `source /mnt/c/Users/Test User/.claude/env.sh`,
+			expected: `source ` + targetDirpath + `/env.sh`,
+		},
+		{
+			name:     "USERPROFILE variable",
+			input:    `%USERPROFILE%\.claude`,
+			expected: targetDirpath,
+		},
+		{
+			name:     "trailing separator",
+			input:    `dir: C:\Users\Test User\.claude\ ok`,
+			expected: `dir: ` + targetDirpath + `/ ok`,
+		},
+		{
+			name:     "does not replace .claude.json",
+			input:    `C:\Users\Test User\.claude.json`,
+			expected: `C:\Users\Test User\.claude.json`,
+		},
+		{
+			name:     "does not replace another user's profile",
+			input:    `C:\Users\Other\.claude\CLAUDE.md`,
+			expected: `C:\Users\Other\.claude\CLAUDE.md`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rewriteWindowsClaudePaths([]byte(tt.input), targetDirpath, windowsHomeDirpath)
+			if string(result) != tt.expected {
+				t.Errorf("rewriteWindowsClaudePaths:\n  input:    %s\n  expected: %s\n  got:      %s",
+					tt.input, tt.expected, string(result))
+			}
+		})
+	}
+}
+
 func TestIsTextFile(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -752,7 +814,7 @@ func TestCopyAndPatchClaudeJSON(t *testing.T) {
 	t.Setenv("HOME", filepath.Join(tmpDir, "source"))
 
 	missionAgentDirpath := "/tmp/claude/missions/test-123/agent"
-	if err := copyAndPatchClaudeJSON(destDirpath, missionAgentDirpath, nil); err != nil {
+	if err := copyAndPatchClaudeJSON(destDirpath, missionAgentDirpath, nil, nil); err != nil {
 		t.Fatalf("copyAndPatchClaudeJSON failed: %v", err)
 	}
 
@@ -842,3 +904,40 @@ func splitLines(s string) []string {
 	}
 	return lines
 }
+
+func TestCountCommitsBehind(t *testing.T) {
+	tmpDir := t.TempDir()
+	claudeDirpath := filepath.Join(tmpDir, ".claude")
+	if err := os.MkdirAll(claudeDirpath, 0755); err != nil {
+		t.Fatalf("failed to create .claude dir: %v", err)
+	}
+	shadowDirpath, err := InitShadowRepo(tmpDir)
+	if err != nil {
+		t.Fatalf("InitShadowRepo failed: %v", err)
+	}
+
+	var commits []string
+	for _, content := range []string{"one", "two", "three"} {
+		if err := os.WriteFile(filepath.Join(claudeDirpath, "CLAUDE.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write CLAUDE.md: %v", err)
+		}
+		if err := IngestFromClaudeDir(claudeDirpath, shadowDirpath); err != nil {
+			t.Fatalf("IngestFromClaudeDir failed: %v", err)
+		}
+		commits = append(commits, GetShadowRepoCommitHash(tmpDir))
+	}
+	head := commits[2]
+
+	if got := CountCommitsBehind(tmpDir, head, head); got != 0 {
+		t.Errorf("HEAD vs HEAD: got %d, want 0", got)
+	}
+	if got := CountCommitsBehind(tmpDir, commits[1], head); got != 1 {
+		t.Errorf("one commit back: got %d, want 1", got)
+	}
+	if got := CountCommitsBehind(tmpDir, commits[0], head); got != 2 {
+		t.Errorf("two commits back: got %d, want 2", got)
+	}
+	if got := CountCommitsBehind(tmpDir, "0123456789abcdef0123456789abcdef01234567", head); got != -1 {
+		t.Errorf("unknown commit: got %d, want -1", got)
+	}
+}
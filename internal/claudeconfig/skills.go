@@ -0,0 +1,321 @@
+package claudeconfig
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+const (
+	// skillsDirname is the tracked directory Claude loads skills from.
+	skillsDirname = "skills"
+
+	// SkillSourceFilename is the file recording where an installed skill was
+	// cloned from. Its presence marks a skill as installed by 'agenc skills
+	// add' rather than written by hand.
+	SkillSourceFilename = ".agenc-skill.json"
+
+	// skillEntrypointFilename is the file every skill directory must contain.
+	skillEntrypointFilename = "SKILL.md"
+)
+
+// skillNameRegex matches valid skill directory names.
+var skillNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// SkillSource records the repo an installed skill came from. It is written
+// into the skill's directory so it travels with the shadow repo, making the
+// install reproducible on other machines.
+type SkillSource struct {
+	Repo        string    `json:"repo"`
+	CloneURL    string    `json:"clone_url"`
+	Commit      string    `json:"commit"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// InstalledSkill is one directory under ~/.claude/skills. Source is nil for
+// skills written by hand.
+type InstalledSkill struct {
+	Name   string
+	Source *SkillSource
+}
+
+// ValidateSkillName returns an error if name can't be used as a skill
+// directory name.
+func ValidateSkillName(name string) error {
+	if !skillNameRegex.MatchString(name) {
+		return stacktrace.NewError("invalid skill name '%s'; use letters, numbers, '.', '-', and '_' (max 64 characters, not starting with '.', '-', or '_')", name)
+	}
+	return nil
+}
+
+// SkillNameFromRepo returns the default skill name for a repo: its last
+// path segment.
+func SkillNameFromRepo(repoName string) string {
+	return filepath.Base(strings.TrimSuffix(repoName, ".git"))
+}
+
+// ListSkills returns the skills under userClaudeDirpath's skills directory,
+// sorted by name.
+func ListSkills(userClaudeDirpath string) ([]InstalledSkill, error) {
+	skillsDirpath := filepath.Join(userClaudeDirpath, skillsDirname)
+	entries, err := os.ReadDir(skillsDirpath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to read '%s'", skillsDirpath)
+	}
+
+	var skills []InstalledSkill
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		// Skill directories may be symlinks; follow them
+		info, err := os.Stat(filepath.Join(skillsDirpath, entry.Name()))
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		source, err := readSkillSource(filepath.Join(skillsDirpath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, InstalledSkill{Name: entry.Name(), Source: source})
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		return skills[i].Name < skills[j].Name
+	})
+	return skills, nil
+}
+
+// GetSkill returns the named skill, or nil if it isn't installed.
+func GetSkill(userClaudeDirpath string, name string) (*InstalledSkill, error) {
+	skillDirpath := filepath.Join(userClaudeDirpath, skillsDirname, name)
+	info, err := os.Stat(skillDirpath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to stat '%s'", skillDirpath)
+	}
+	if !info.IsDir() {
+		return nil, nil
+	}
+	source, err := readSkillSource(skillDirpath)
+	if err != nil {
+		return nil, err
+	}
+	return &InstalledSkill{Name: name, Source: source}, nil
+}
+
+// InstallSkill clones cloneURL and installs its contents (minus .git) as the
+// skill name under userClaudeDirpath, recording the source alongside. The
+// repo must have a SKILL.md at its root. Fails if a skill of that name is
+// already installed.
+func InstallSkill(userClaudeDirpath string, name string, repoName string, cloneURL string) (*SkillSource, error) {
+	if err := ValidateSkillName(name); err != nil {
+		return nil, err
+	}
+	existing, err := GetSkill(userClaudeDirpath, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, stacktrace.NewError("skill '%s' is already installed", name)
+	}
+	return replaceSkill(userClaudeDirpath, name, repoName, cloneURL)
+}
+
+// UpdateSkill re-clones an installed skill's source repo and replaces the
+// skill with it if the repo's HEAD moved. Returns the skill's source after
+// the update and whether anything changed.
+func UpdateSkill(userClaudeDirpath string, name string) (*SkillSource, bool, error) {
+	source, err := getManagedSkillSource(userClaudeDirpath, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	remoteCommit, err := lsRemoteHead(source.CloneURL)
+	if err != nil {
+		return nil, false, stacktrace.Propagate(err, "failed to check '%s' for updates", source.Repo)
+	}
+	if remoteCommit == source.Commit {
+		return source, false, nil
+	}
+
+	updated, err := replaceSkill(userClaudeDirpath, name, source.Repo, source.CloneURL)
+	if err != nil {
+		return nil, false, err
+	}
+	return updated, updated.Commit != source.Commit, nil
+}
+
+// RemoveSkill deletes a skill installed by InstallSkill. Skills written by
+// hand are refused so they aren't lost to a typo.
+func RemoveSkill(userClaudeDirpath string, name string) error {
+	if _, err := getManagedSkillSource(userClaudeDirpath, name); err != nil {
+		return err
+	}
+	skillDirpath := filepath.Join(userClaudeDirpath, skillsDirname, name)
+	if err := os.RemoveAll(skillDirpath); err != nil {
+		return stacktrace.Propagate(err, "failed to remove '%s'", skillDirpath)
+	}
+	return nil
+}
+
+// RefreshMissionSkills recopies the shadow repo's skills into a mission's
+// claude-config, so a running mission sees skill changes without a full
+// config rebuild. A mission without a claude-config directory is left alone.
+func RefreshMissionSkills(agencDirpath string, missionID string) error {
+	claudeConfigDirpath := filepath.Join(config.GetMissionDirpath(agencDirpath, missionID), MissionClaudeConfigDirname)
+	if _, err := os.Stat(claudeConfigDirpath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return stacktrace.Propagate(err, "failed to stat '%s'", claudeConfigDirpath)
+	}
+
+	srcDirpath := filepath.Join(GetShadowRepoDirpath(agencDirpath), skillsDirname)
+	dstDirpath := filepath.Join(claudeConfigDirpath, skillsDirname)
+	if err := os.RemoveAll(dstDirpath); err != nil {
+		return stacktrace.Propagate(err, "failed to remove '%s'", dstDirpath)
+	}
+	if _, err := os.Stat(srcDirpath); os.IsNotExist(err) {
+		return nil
+	}
+	if err := copyDirWithRewriting(srcDirpath, dstDirpath, claudeConfigDirpath); err != nil {
+		return stacktrace.Propagate(err, "failed to copy skills into mission config")
+	}
+	return nil
+}
+
+// getManagedSkillSource returns the source of a skill installed by
+// InstallSkill, or an error if the skill is missing or was written by hand.
+func getManagedSkillSource(userClaudeDirpath string, name string) (*SkillSource, error) {
+	skill, err := GetSkill(userClaudeDirpath, name)
+	if err != nil {
+		return nil, err
+	}
+	if skill == nil {
+		return nil, stacktrace.NewError("skill '%s' is not installed", name)
+	}
+	if skill.Source == nil {
+		return nil, stacktrace.NewError("skill '%s' was not installed with 'agenc skills add'; manage it in ~/.claude/skills directly", name)
+	}
+	return skill.Source, nil
+}
+
+// replaceSkill clones cloneURL into a staging directory next to the skills
+// directory, then swaps it in for the skill name. Staging outside skills/
+// keeps the half-cloned repo out of the config watcher's view.
+func replaceSkill(userClaudeDirpath string, name string, repoName string, cloneURL string) (*SkillSource, error) {
+	skillsDirpath := filepath.Join(userClaudeDirpath, skillsDirname)
+	if err := os.MkdirAll(skillsDirpath, 0755); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to create '%s'", skillsDirpath)
+	}
+	stagingDirpath, err := os.MkdirTemp(userClaudeDirpath, ".agenc-skill-")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to create staging directory")
+	}
+	defer os.RemoveAll(stagingDirpath)
+
+	cloneDirpath := filepath.Join(stagingDirpath, name)
+	if output, err := runSkillGit(stagingDirpath, "clone", "--depth", "1", cloneURL, cloneDirpath); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to clone '%s': %s", cloneURL, output)
+	}
+	commit, err := runSkillGit(cloneDirpath, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read the cloned commit of '%s'", repoName)
+	}
+	if _, err := os.Stat(filepath.Join(cloneDirpath, skillEntrypointFilename)); err != nil {
+		return nil, stacktrace.NewError("'%s' is not a skill repo: it has no %s at its root", repoName, skillEntrypointFilename)
+	}
+	// The skill is tracked as plain files in the shadow repo; a nested .git
+	// would be recorded as an unresolvable submodule instead
+	if err := os.RemoveAll(filepath.Join(cloneDirpath, ".git")); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to remove the clone's .git directory")
+	}
+
+	source := &SkillSource{
+		Repo:        repoName,
+		CloneURL:    cloneURL,
+		Commit:      commit,
+		InstalledAt: time.Now().UTC(),
+	}
+	if err := writeSkillSource(cloneDirpath, source); err != nil {
+		return nil, err
+	}
+
+	skillDirpath := filepath.Join(skillsDirpath, name)
+	if err := os.RemoveAll(skillDirpath); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to remove the previous '%s'", skillDirpath)
+	}
+	if err := os.Rename(cloneDirpath, skillDirpath); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to move skill into '%s'", skillDirpath)
+	}
+	return source, nil
+}
+
+// readSkillSource reads the source file in skillDirpath, returning nil if
+// there is none.
+func readSkillSource(skillDirpath string) (*SkillSource, error) {
+	data, err := os.ReadFile(filepath.Join(skillDirpath, SkillSourceFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to read skill source in '%s'", skillDirpath)
+	}
+	var source SkillSource
+	if err := json.Unmarshal(data, &source); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse '%s'", filepath.Join(skillDirpath, SkillSourceFilename))
+	}
+	return &source, nil
+}
+
+func writeSkillSource(skillDirpath string, source *SkillSource) error {
+	data, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to marshal skill source")
+	}
+	if err := os.WriteFile(filepath.Join(skillDirpath, SkillSourceFilename), append(data, '\n'), 0644); err != nil {
+		return stacktrace.Propagate(err, "failed to write skill source")
+	}
+	return nil
+}
+
+// lsRemoteHead returns the commit cloneURL's HEAD points at.
+func lsRemoteHead(cloneURL string) (string, error) {
+	output, err := runSkillGit("", "ls-remote", cloneURL, "HEAD")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "git ls-remote failed: %s", output)
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", stacktrace.NewError("'%s' has no HEAD", cloneURL)
+	}
+	return fields[0], nil
+}
+
+// runSkillGit runs git in dirpath without prompting for credentials and
+// returns its trimmed combined output.
+func runSkillGit(dirpath string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dirpath
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
@@ -0,0 +1,169 @@
+package claudeconfig
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// newSkillTestRepo creates a git repo holding files and returns its path,
+// usable as a clone URL, and a function that commits more files to it.
+func newSkillTestRepo(t *testing.T, files map[string]string) (string, func(files map[string]string)) {
+	t.Helper()
+	repoDirpath := filepath.Join(t.TempDir(), "pdf-skill")
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@test.com", "-c", "user.name=Test"}, args...)...)
+		cmd.Dir = repoDirpath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+	}
+	commit := func(files map[string]string) {
+		t.Helper()
+		for name, content := range files {
+			writeTestFile(t, filepath.Join(repoDirpath, name), content)
+		}
+		runGit("add", "-A")
+		runGit("commit", "-m", "update")
+	}
+	if err := os.MkdirAll(repoDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit("init")
+	commit(files)
+	return repoDirpath, commit
+}
+
+func TestInstallSkill(t *testing.T) {
+	userClaudeDirpath := t.TempDir()
+	repoDirpath, _ := newSkillTestRepo(t, map[string]string{"SKILL.md": "# PDF", "scripts/run.sh": "echo hi"})
+
+	source, err := InstallSkill(userClaudeDirpath, "pdf", "github.com/owner/pdf-skill", repoDirpath)
+	if err != nil {
+		t.Fatalf("InstallSkill failed: %v", err)
+	}
+	if source.Repo != "github.com/owner/pdf-skill" || len(source.Commit) != 40 {
+		t.Errorf("unexpected source %+v", source)
+	}
+
+	skillDirpath := filepath.Join(userClaudeDirpath, "skills", "pdf")
+	if got := readTestFile(t, filepath.Join(skillDirpath, "scripts", "run.sh")); got != "echo hi" {
+		t.Errorf("expected the repo's files installed, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(skillDirpath, ".git")); !os.IsNotExist(err) {
+		t.Error("expected the clone's .git directory removed")
+	}
+
+	if _, err := InstallSkill(userClaudeDirpath, "pdf", "github.com/owner/pdf-skill", repoDirpath); err == nil {
+		t.Error("expected installing over an existing skill to fail")
+	}
+
+	// No staging directories are left behind
+	entries, err := os.ReadDir(userClaudeDirpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only skills/ in ~/.claude, got %d entries", len(entries))
+	}
+}
+
+func TestInstallSkill_RequiresSkillMd(t *testing.T) {
+	userClaudeDirpath := t.TempDir()
+	repoDirpath, _ := newSkillTestRepo(t, map[string]string{"README.md": "not a skill"})
+
+	_, err := InstallSkill(userClaudeDirpath, "readme", "github.com/owner/readme", repoDirpath)
+	if err == nil || !strings.Contains(err.Error(), "SKILL.md") {
+		t.Fatalf("expected a missing SKILL.md error, got %v", err)
+	}
+	if skill, _ := GetSkill(userClaudeDirpath, "readme"); skill != nil {
+		t.Error("expected nothing installed")
+	}
+}
+
+func TestListSkills(t *testing.T) {
+	userClaudeDirpath := t.TempDir()
+	repoDirpath, _ := newSkillTestRepo(t, map[string]string{"SKILL.md": "# PDF"})
+	if _, err := InstallSkill(userClaudeDirpath, "pdf", "github.com/owner/pdf-skill", repoDirpath); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(userClaudeDirpath, "skills", "handwritten", "SKILL.md"), "# Mine")
+
+	skills, err := ListSkills(userClaudeDirpath)
+	if err != nil {
+		t.Fatalf("ListSkills failed: %v", err)
+	}
+	if len(skills) != 2 || skills[0].Name != "handwritten" || skills[1].Name != "pdf" {
+		t.Fatalf("unexpected skills %+v", skills)
+	}
+	if skills[0].Source != nil || skills[1].Source == nil {
+		t.Errorf("expected only pdf to have a source, got %+v", skills)
+	}
+
+	if err := RemoveSkill(userClaudeDirpath, "handwritten"); err == nil {
+		t.Error("expected removing a hand-written skill to be refused")
+	}
+	if err := RemoveSkill(userClaudeDirpath, "pdf"); err != nil {
+		t.Fatalf("RemoveSkill failed: %v", err)
+	}
+	if skill, _ := GetSkill(userClaudeDirpath, "pdf"); skill != nil {
+		t.Error("expected pdf removed")
+	}
+}
+
+func TestUpdateSkill(t *testing.T) {
+	userClaudeDirpath := t.TempDir()
+	repoDirpath, commit := newSkillTestRepo(t, map[string]string{"SKILL.md": "v1"})
+	installed, err := InstallSkill(userClaudeDirpath, "pdf", "github.com/owner/pdf-skill", repoDirpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, changed, err := UpdateSkill(userClaudeDirpath, "pdf"); err != nil || changed {
+		t.Fatalf("expected no change before the repo moved, got changed=%v err=%v", changed, err)
+	}
+
+	commit(map[string]string{"SKILL.md": "v2"})
+	updated, changed, err := UpdateSkill(userClaudeDirpath, "pdf")
+	if err != nil || !changed {
+		t.Fatalf("expected an update, got changed=%v err=%v", changed, err)
+	}
+	if updated.Commit == installed.Commit {
+		t.Error("expected the recorded commit to move")
+	}
+	if got := readTestFile(t, filepath.Join(userClaudeDirpath, "skills", "pdf", "SKILL.md")); got != "v2" {
+		t.Errorf("expected the new version installed, got %q", got)
+	}
+}
+
+func TestRefreshMissionSkills(t *testing.T) {
+	agencDirpath, _ := newShadowTestMachine(t, map[string]string{
+		"skills/pdf/SKILL.md": "Read ~/.claude/skills/pdf/ref.md",
+	})
+	claudeConfigDirpath := filepath.Join(config.GetMissionDirpath(agencDirpath, "m1"), MissionClaudeConfigDirname)
+	writeTestFile(t, filepath.Join(claudeConfigDirpath, "skills", "removed", "SKILL.md"), "# Gone")
+
+	if err := RefreshMissionSkills(agencDirpath, "m1"); err != nil {
+		t.Fatalf("RefreshMissionSkills failed: %v", err)
+	}
+	got := readTestFile(t, filepath.Join(claudeConfigDirpath, "skills", "pdf", "SKILL.md"))
+	if got != "Read "+claudeConfigDirpath+"/skills/pdf/ref.md" {
+		t.Errorf("expected the skill copied with paths rewritten, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(claudeConfigDirpath, "skills", "removed")); !os.IsNotExist(err) {
+		t.Error("expected skills no longer in the shadow repo removed")
+	}
+
+	// Missions without a claude-config are left alone
+	if err := RefreshMissionSkills(agencDirpath, "m2"); err != nil {
+		t.Fatalf("RefreshMissionSkills failed: %v", err)
+	}
+	if _, err := os.Stat(config.GetMissionDirpath(agencDirpath, "m2")); !os.IsNotExist(err) {
+		t.Error("expected no directory created for a mission without config")
+	}
+}
@@ -2,34 +2,52 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 	"unicode"
 
 	"github.com/goccy/go-yaml"
 	"github.com/mieubrisse/stacktrace"
 
 	"github.com/odyssey/agenc/internal/launchd"
+	"github.com/odyssey/agenc/internal/notify"
 	"github.com/odyssey/agenc/internal/sleep"
+	"github.com/odyssey/agenc/internal/wsl"
 )
 
-// canonicalRepoRegex matches the canonical repo format: github.com/owner/repo
-var canonicalRepoRegex = regexp.MustCompile(`^github\.com/[^/]+/[^/]+$`)
+// canonicalRepoRegex matches the canonical repo format: host/owner/repo (e.g.
+// github.com/owner/repo or git.internal/team/svc). The host may be an SSH
+// config alias, so it need not contain a dot.
+var canonicalRepoRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.-]*/[^/]+/[^/]+$`)
 
 // CronConfig represents the configuration for a single cron job.
 type CronConfig struct {
-	ID                   string `yaml:"id,omitempty"`                   // UUID, auto-generated by cron new
-	Schedule             string `yaml:"schedule"`                       // Cron expression (5 or 6 fields)
-	Prompt               string `yaml:"prompt"`                         // Initial prompt for the mission
-	Description          string `yaml:"description,omitempty"`          // Human-readable description
-	Repo                 string `yaml:"repo,omitempty"`                 // Git repo to clone into workspace
-	Enabled              *bool  `yaml:"enabled,omitempty"`              // Defaults to true if omitted
-	NotificationsEnabled *bool  `yaml:"notificationsEnabled,omitempty"` // Whether triggers produce a cron.triggered notification. Defaults to true if omitted.
+	ID                         string            `yaml:"id,omitempty"`                         // UUID, auto-generated by cron new
+	Schedule                   string            `yaml:"schedule,omitempty"`                   // Cron expression (5 or 6 fields); mutually exclusive with RunAt
+	RunAt                      string            `yaml:"runAt,omitempty"`                      // One-shot fire time (see ParseCronRunAt); mutually exclusive with Schedule
+	Prompt                     string            `yaml:"prompt"`                               // Initial prompt for the mission
+	Description                string            `yaml:"description,omitempty"`                // Human-readable description
+	Repo                       string            `yaml:"repo,omitempty"`                       // Git repo to clone into workspace
+	Ref                        string            `yaml:"ref,omitempty"`                        // Branch, tag, or commit each run checks out instead of the repo's default branch; requires Repo
+	Enabled                    *bool             `yaml:"enabled,omitempty"`                    // Defaults to true if omitted
+	NotificationsEnabled       *bool             `yaml:"notificationsEnabled,omitempty"`       // Whether triggers produce a cron.triggered notification. Defaults to true if omitted.
+	After                      string            `yaml:"after,omitempty"`                      // Name of an upstream cron whose latest run must have succeeded today before this one starts
+	MaxPrompts                 int               `yaml:"maxPrompts,omitempty"`                 // Stop each run's Claude after this many prompts (0 = no limit)
+	BudgetUSD                  float64           `yaml:"budgetUsd,omitempty"`                  // Stop each run's Claude once its estimated spend reaches this many USD (0 = no limit)
+	Env                        map[string]string `yaml:"env,omitempty"`                        // Extra environment for each run's Claude; values may reference secret://NAME
+	Notify                     []string          `yaml:"notify,omitempty"`                     // Targets ("slack:#reports", "email:me@example.com") told when each run starts, succeeds, or fails
+	Project                    string            `yaml:"project,omitempty"`                    // Project each run's mission joins (see 'agenc project')
+	IgnoreQuietHours           bool              `yaml:"ignoreQuietHours,omitempty"`           // Run and notify during quietHours instead of deferring until they end
+	ResumePolicy               string            `yaml:"resumePolicy,omitempty"`               // Whether a run starts fresh, continues the previous run's conversation, or forks it when the repo has moved on (see ParseCronResumePolicy)
+	AlertOnConsecutiveFailures int               `yaml:"alertOnConsecutiveFailures,omitempty"` // Alert through the notification subsystem once this many runs in a row have failed (0 = never)
 }
 
 // IsEnabled returns whether the cron job is enabled. Defaults to true if not explicitly set.
@@ -40,6 +58,12 @@ func (c *CronConfig) IsEnabled() bool {
 	return *c.Enabled
 }
 
+// IsOneShot returns whether the cron fires once at RunAt instead of on a
+// recurring schedule.
+func (c *CronConfig) IsOneShot() bool {
+	return c.RunAt != ""
+}
+
 // AreNotificationsEnabled returns whether triggers of this cron should produce
 // a cron.triggered notification. Defaults to true if not explicitly set.
 func (c *CronConfig) AreNotificationsEnabled() bool {
@@ -122,6 +146,11 @@ var BuiltinPaletteCommands = map[string]PaletteCommandConfig{
 		Command:        StringPtr("agenc mission detach $AGENC_CALLING_MISSION_UUID"),
 		TmuxKeybinding: StringPtr("-n C-i"),
 	},
+	"projectDashboard": {
+		Title:       StringPtr("📁  Project Dashboard"),
+		Description: StringPtr("Open the dashboard showing one project's missions"),
+		Command:     StringPtr(`tmux display-popup -E -w 95% -h 90% "agenc dashboard --project {{input:Project}}"`),
+	},
 	"sideShell": {
 		Title:          StringPtr("🐚  Side Shell"),
 		Description:    StringPtr("Split pane and open a shell in the current mission's workspace"),
@@ -139,6 +168,21 @@ var BuiltinPaletteCommands = map[string]PaletteCommandConfig{
 		Description: StringPtr("Open a shell in a new window"),
 		Command:     StringPtr(`tmux new-window -a -c "${AGENC_DIRPATH:-$HOME/.agenc}/missions/$AGENC_CALLING_MISSION_UUID/agent" $SHELL`),
 	},
+	"openMissionDir": {
+		Title:       StringPtr("📂  Open Mission Folder"),
+		Description: StringPtr("Open the focused mission's agent directory in the file manager"),
+		Command:     StringPtr("agenc mission open $AGENC_CALLING_MISSION_UUID --dir"),
+	},
+	"openMissionPR": {
+		Title:       StringPtr("🔗  Open Pull Request"),
+		Description: StringPtr("Open the focused mission's pull request in the browser"),
+		Command:     StringPtr("agenc mission open $AGENC_CALLING_MISSION_UUID --pr"),
+	},
+	"followMissionLogs": {
+		Title:       StringPtr("📜  Follow Mission Logs"),
+		Description: StringPtr("Split pane and follow the focused mission's Claude output log"),
+		Command:     StringPtr(`tmux split-window -h "agenc mission open $AGENC_CALLING_MISSION_UUID --logs"`),
+	},
 	"copyMissionUuid": {
 		Title:       StringPtr("📋  Copy Mission ID"),
 		Description: StringPtr("Copy the focused mission's UUID to the clipboard"),
@@ -146,7 +190,7 @@ var BuiltinPaletteCommands = map[string]PaletteCommandConfig{
 	},
 	"renameSession": {
 		Title:          StringPtr("✨  Rename Session"),
-		Description:    StringPtr("Rename the focused mission's window"),
+		Description:    StringPtr("Rename the focused mission"),
 		Command:        StringPtr(`tmux display-popup -E -w 68% -h 63% "agenc mission rename $AGENC_CALLING_MISSION_UUID"`),
 		TmuxKeybinding: StringPtr("-n C-."),
 	},
@@ -216,9 +260,13 @@ var builtinPaletteCommandOrder = []string{
 	"newMission",
 	"switchMission",
 	"detachMission",
+	"projectDashboard",
 	"sideShell",
 	"draft",
 	"shell",
+	"openMissionDir",
+	"openMissionPR",
+	"followMissionLogs",
 	"copyMissionUuid",
 	"renameSession",
 	"stopMission",
@@ -251,6 +299,11 @@ const CallingMissionUUIDEnvVar = "AGENC_CALLING_MISSION_UUID"
 // "which session am I being invoked from?" — primarily attach/detach.
 const CallingSessionNameEnvVar = "AGENC_CALLING_SESSION_NAME"
 
+// CallingPaneIDEnvVar is the environment variable name that carries the tmux
+// pane the user pressed a keybinding in (#{pane_id}, captured at key-press
+// time), so commands run in popups or run-shell know the user's actual pane.
+const CallingPaneIDEnvVar = "AGENC_CALLING_PANE_ID"
+
 // ResolvedPaletteCommand is a palette command with all defaults applied and
 // the agenc binary substituted in the command string.
 type ResolvedPaletteCommand struct {
@@ -269,6 +322,13 @@ func (c ResolvedPaletteCommand) IsMissionScoped() bool {
 	return strings.Contains(c.Command, CallingMissionUUIDEnvVar)
 }
 
+// RequiresTmux returns true if the command drives tmux (directly or through
+// an `agenc tmux` subcommand), meaning it can only run from inside a tmux
+// session.
+func (c ResolvedPaletteCommand) RequiresTmux() bool {
+	return strings.Contains(c.Command, "tmux ")
+}
+
 // FormatKeybinding returns the human-readable keybinding string for this
 // command (e.g. "prefix → a → d" for table-scoped keys, or "C-n" for root
 // table keys), or an empty string if no keybinding is set.
@@ -284,6 +344,57 @@ func (c ResolvedPaletteCommand) FormatKeybinding() string {
 	return fmt.Sprintf("prefix → a → %s", c.TmuxKeybinding)
 }
 
+// paletteInputPlaceholderRegex matches {{input:Label}} placeholders in palette
+// command strings.
+var paletteInputPlaceholderRegex = regexp.MustCompile(`\{\{\s*input:([^{}]*)\}\}`)
+
+// InputLabels returns the labels of the command's {{input:Label}} placeholders
+// in order of first appearance. A label used more than once is asked for once.
+func (c ResolvedPaletteCommand) InputLabels() []string {
+	return paletteInputLabels(c.Command)
+}
+
+// HasInputs returns true if the command has {{input:Label}} placeholders the
+// user must fill in before it runs.
+func (c ResolvedPaletteCommand) HasInputs() bool {
+	return paletteInputPlaceholderRegex.MatchString(c.Command)
+}
+
+// FillInputs returns the command with each {{input:Label}} placeholder
+// replaced by values[Label], single-quoted for the shell so the value is
+// always passed as one literal word.
+func (c ResolvedPaletteCommand) FillInputs(values map[string]string) string {
+	return paletteInputPlaceholderRegex.ReplaceAllStringFunc(c.Command, func(placeholder string) string {
+		label := strings.TrimSpace(paletteInputPlaceholderRegex.FindStringSubmatch(placeholder)[1])
+		return "'" + strings.ReplaceAll(values[label], "'", `'"'"'`) + "'"
+	})
+}
+
+func paletteInputLabels(command string) []string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, match := range paletteInputPlaceholderRegex.FindAllStringSubmatch(command, -1) {
+		label := strings.TrimSpace(match[1])
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// ValidatePaletteCommandInputs checks that every {{input:Label}} placeholder
+// in a palette command string has a non-empty label.
+func ValidatePaletteCommandInputs(command string) error {
+	for _, label := range paletteInputLabels(command) {
+		if label == "" {
+			return stacktrace.NewError("palette command has an {{input:}} placeholder with no label; use {{input:Label}}")
+		}
+	}
+	return nil
+}
+
 // DefaultPaletteTmuxKeybinding is the default bind-key arguments for the
 // command palette keybinding. The value is inserted verbatim after "bind-key"
 // in the generated tmux keybindings file, so it can include table specifiers
@@ -350,7 +461,7 @@ func (t *TmuxWindowTitleConfig) GetAttentionForegroundColor() string {
 	return DefaultTmuxWindowTitleAttentionFg
 }
 
-// IsCanonicalRepoName reports whether the given string is in canonical format (github.com/owner/repo).
+// IsCanonicalRepoName reports whether the given string is in canonical format (host/owner/repo).
 func IsCanonicalRepoName(name string) bool {
 	return canonicalRepoRegex.MatchString(name)
 }
@@ -371,10 +482,134 @@ type RepoConfig struct {
 	TrustedMcpServers *TrustedMcpServers `yaml:"trustedMcpServers,omitempty"`
 	DefaultModel      string             `yaml:"defaultModel,omitempty"`
 	PostUpdateHook    string             `yaml:"postUpdateHook,omitempty"`
-	ClaudeArgs        []string           `yaml:"claudeArgs,omitempty"`
-	WriteableCopy     string             `yaml:"writeableCopy,omitempty"`
+	// PostUpdateHookCache lists repo-relative paths (e.g. node_modules) that
+	// the postUpdateHook populates and that are shared between the library
+	// clone and every mission through a per-repo cache directory.
+	PostUpdateHookCache []string `yaml:"postUpdateHookCache,omitempty"`
+	ClaudeArgs          []string `yaml:"claudeArgs,omitempty"`
+	WriteableCopy       string   `yaml:"writeableCopy,omitempty"`
+	WorkspaceMode       string   `yaml:"workspaceMode,omitempty"`
+	// AutoBranch checks out a fresh branch for every new mission instead of
+	// leaving the agent on the repo's default branch.
+	AutoBranch bool `yaml:"autoBranch,omitempty"`
+	// AutoBranchTemplate names the branch AutoBranch creates. Supports the
+	// {shortID}, {missionID}, and {slug} placeholders; defaults to
+	// DefaultAutoBranchTemplate.
+	AutoBranchTemplate string `yaml:"autoBranchTemplate,omitempty"`
+	// McpServers declares MCP servers, keyed by server name, that every
+	// mission for the repo gets without the repo shipping a .mcp.json.
+	McpServers map[string]McpServerConfig `yaml:"mcpServers,omitempty"`
+	// Isolation is IsolationContainer to run every mission's Claude for the
+	// repo in a sandbox container (see SandboxConfig); empty or IsolationHost
+	// runs it on the host.
+	Isolation string `yaml:"isolation,omitempty"`
+	// Upstream is the canonical name of the repo this one is a fork of. New
+	// missions in the repo get an "upstream" remote pointing at it.
+	Upstream string `yaml:"upstream,omitempty"`
+	// Backend is the agent backend new missions in the repo run: "claude"
+	// (the default), a built-in backend such as "codex", or an agentBackends
+	// entry.
+	Backend string `yaml:"backend,omitempty"`
+	// ClaudeMdAppend is extra CLAUDE.md content for missions in the repo:
+	// either the path to a markdown file (absolute, ~/-prefixed, or relative
+	// to the config directory) or the instructions inline. See
+	// ResolveClaudeMdAppend.
+	ClaudeMdAppend string `yaml:"claudeMdAppend,omitempty"`
+	// NetworkPolicy restricts the hosts missions for the repo can reach.
+	// Nil leaves network access unrestricted.
+	NetworkPolicy *NetworkPolicyConfig `yaml:"networkPolicy,omitempty"`
+	// Env sets environment variables for every mission's Claude in the repo;
+	// values may reference secret://NAME. A mission's own --env overrides
+	// win over it.
+	Env map[string]string `yaml:"env,omitempty"`
+	// ReviewRequired puts missions for the repo behind a human approval
+	// gate: their pushes land on agenc/review/<branch> holding branches
+	// until 'agenc mission approve' fast-forwards the real branch, and Claude
+	// is denied direct pushes to the repo's default branch.
+	ReviewRequired bool `yaml:"reviewRequired,omitempty"`
+	// WindowTitleTemplate overrides the global windowTitleTemplate for the
+	// repo's missions.
+	WindowTitleTemplate string `yaml:"windowTitleTemplate,omitempty"`
+}
+
+// NetworkPolicyConfig restricts a mission's outbound network access to an
+// allowlist of hosts through a local HTTP proxy that refuses and logs
+// connections to other hosts. On Linux the agent runs in a network namespace
+// where the proxy is the only way out; elsewhere it is only pointed at the
+// proxy through HTTPS_PROXY and friends, which it can ignore.
+type NetworkPolicyConfig struct {
+	// AllowedHosts lists the hosts missions may connect to. Each entry
+	// matches that host and its subdomains. NetworkPolicyBuiltinHosts are
+	// always allowed so Claude itself keeps working.
+	AllowedHosts []string `yaml:"allowedHosts"`
+}
+
+// NetworkPolicyBuiltinHosts are allowed under every networkPolicy: the hosts
+// Claude Code needs for the API and sign-in.
+var NetworkPolicyBuiltinHosts = []string{"anthropic.com", "claude.ai"}
+
+// networkPolicyHostRegex matches a hostname or IPv4 address, without scheme,
+// port, or path.
+var networkPolicyHostRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// ValidateNetworkPolicyHost returns an error if host is not a bare hostname
+// such as "github.com".
+func ValidateNetworkPolicyHost(host string) error {
+	if !networkPolicyHostRegex.MatchString(host) {
+		return stacktrace.NewError("allowed host must be a lowercase hostname like 'github.com' (no scheme, port, path, or wildcard), got '%s'", host)
+	}
+	return nil
 }
 
+// AllowsHost reports whether the policy lets missions connect to host, which
+// may carry a port. Hostnames are compared case-insensitively. A nil policy
+// allows everything.
+func (n *NetworkPolicyConfig) AllowsHost(host string) bool {
+	if n == nil {
+		return true
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, hosts := range [][]string{NetworkPolicyBuiltinHosts, n.AllowedHosts} {
+		for _, allowed := range hosts {
+			allowed = strings.ToLower(allowed)
+			if host == allowed || strings.HasSuffix(host, "."+allowed) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Isolation modes control where a mission's Claude process runs.
+const (
+	// IsolationHost runs Claude directly on the host (the default).
+	IsolationHost = "host"
+	// IsolationContainer runs Claude in a Docker or Podman container that
+	// only sees the mission's agent dir and claude-config.
+	IsolationContainer = "container"
+)
+
+// Workspace modes control how a mission's agent/ directory is populated from
+// the repo library clone.
+const (
+	// WorkspaceModeCopy rsyncs a full independent copy of the library clone
+	// (the default).
+	WorkspaceModeCopy = "copy"
+	// WorkspaceModeWorktree creates a linked `git worktree` of the library
+	// clone on a per-mission branch, sharing its object store.
+	WorkspaceModeWorktree = "worktree"
+)
+
+// DefaultAutoBranchTemplate is the branch name template used when a repo has
+// autoBranch enabled but no autoBranchTemplate.
+const DefaultAutoBranchTemplate = "agenc/{shortID}-{slug}"
+
+// autoBranchPlaceholders are the placeholders an autoBranchTemplate may use.
+var autoBranchPlaceholders = []string{"{shortID}", "{missionID}", "{slug}"}
+
 // TrustedMcpServers configures MCP server trust for a repository.
 // Supports two formats: "all" (trust every server in .mcp.json) or
 // a list of named servers to trust.
@@ -416,11 +651,62 @@ func (t TrustedMcpServers) MarshalYAML() (interface{}, error) {
 	return t.List, nil
 }
 
+// MCP server transports accepted in McpServerConfig.Type. An empty type means
+// stdio, matching Claude Code.
+const (
+	McpServerTypeStdio = "stdio"
+	McpServerTypeSSE   = "sse"
+	McpServerTypeHTTP  = "http"
+)
+
+// mcpServerNameRegex matches the server names Claude Code accepts.
+var mcpServerNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// McpServerConfig is one MCP server definition from repoConfig.<repo>.mcpServers.
+// It uses Claude Code's mcpServers JSON shape so it can be injected verbatim:
+// stdio servers set command (plus optional args and env); sse and http
+// servers set url (plus optional headers).
+type McpServerConfig struct {
+	Type    string            `yaml:"type,omitempty" json:"type,omitempty"`
+	Command string            `yaml:"command,omitempty" json:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	URL     string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
 // SleepModeConfig defines time windows during which mission and cron creation is blocked.
 type SleepModeConfig struct {
 	Windows []sleep.WindowDef `yaml:"windows"`
 }
 
+// QuietHoursConfig defines a daily window (HH:MM, 24-hour) during which
+// scheduled crons are deferred until the window ends and Slack, email, and
+// desktop notifications are held back. End may be earlier than Start for a
+// window that crosses midnight.
+type QuietHoursConfig struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// window returns the quiet hours as a sleep window covering every day.
+func (q *QuietHoursConfig) window() sleep.WindowDef {
+	return sleep.WindowDef{
+		Days:  []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"},
+		Start: q.Start,
+		End:   q.End,
+	}
+}
+
+// IsActive reports whether now falls within the quiet hours. A nil config has
+// no quiet hours.
+func (q *QuietHoursConfig) IsActive(now time.Time) bool {
+	if q == nil {
+		return false
+	}
+	return sleep.IsActive([]sleep.WindowDef{q.window()}, now)
+}
+
 // AgencConfig represents the contents of config.yml.
 type AgencConfig struct {
 	RepoConfigs           map[string]RepoConfig           `yaml:"repoConfig,omitempty"`
@@ -431,12 +717,485 @@ type AgencConfig struct {
 	DefaultModel          string                          `yaml:"defaultModel,omitempty"`
 	ClaudeArgs            []string                        `yaml:"claudeArgs,omitempty"`
 	SleepMode             *SleepModeConfig                `yaml:"sleepMode,omitempty"`
-	SessionTitleMaxWords  int                             `yaml:"sessionTitleMaxWords,omitempty"`
+	// QuietHours defers scheduled crons and holds back notifications during
+	// a daily window. Crons with ignoreQuietHours run regardless.
+	QuietHours           *QuietHoursConfig `yaml:"quietHours,omitempty"`
+	SessionTitleMaxWords int               `yaml:"sessionTitleMaxWords,omitempty"`
 	// AttachedMissionLimit caps how many missions may be simultaneously attached
 	// to non-pool tmux sessions. Nil means no cap. Zero means no attachments are
 	// permitted (literal reading — set explicitly via hand-edit; CLI `config set`
 	// rejects zero to prevent accidental full lockout).
 	AttachedMissionLimit *int `yaml:"attachedMissionLimit,omitempty"`
+	// MissionsMaxConcurrent caps how many interactive (non-cron) missions may
+	// run at once. New missions beyond the cap are queued by the server and
+	// started in order as running missions stop. Zero means no cap.
+	MissionsMaxConcurrent int `yaml:"missionsMaxConcurrent,omitempty"`
+	// AutoRestartCrashed makes the server respawn the wrapper of an
+	// interactive mission it has marked crashed (wrapper died without
+	// cleaning up, or stopped heartbeating).
+	AutoRestartCrashed bool `yaml:"autoRestartCrashed,omitempty"`
+	// SuspendAfterIdle stops the wrapper of any mission whose Claude has been
+	// idle this long, including missions open in a tmux session, to free the
+	// memory its Claude process holds. Written as a Go duration (e.g. "4h").
+	// The conversation is kept and attaching resumes it. Empty leaves only
+	// the built-in stop for idle missions not open anywhere.
+	SuspendAfterIdle string `yaml:"suspendAfterIdle,omitempty"`
+	// WindowTitleTemplate renders each mission's tmux window title from
+	// placeholders like {repo}, {branch}, and {status-emoji} (see
+	// WindowTitlePlaceholders). The wrapper re-renders it whenever Claude's
+	// state changes. A repo's own windowTitleTemplate overrides it; empty
+	// keeps the plain title.
+	WindowTitleTemplate string `yaml:"windowTitleTemplate,omitempty"`
+	// Statusline configures the statusline AgenC shows under each mission's
+	// Claude prompt when the user has no statusLine of their own.
+	Statusline *StatuslineConfig `yaml:"statusline,omitempty"`
+	// ServerListen optionally exposes the server API on a loopback TCP address
+	// (e.g. "tcp:127.0.0.1:7777") in addition to the unix socket. Requests on
+	// the TCP listener must carry a bearer token from `agenc config token
+	// create`. Read at server startup; changing it requires a server restart.
+	ServerListen string `yaml:"serverListen,omitempty"`
+	// GitHubWebhook enables the POST /webhooks/github receiver, which
+	// refreshes a repo library clone as soon as GitHub reports a push to its
+	// default branch. Nil disables the receiver.
+	GitHubWebhook *GitHubWebhookConfig `yaml:"githubWebhook,omitempty"`
+	// MissionAutoArchiveAfter archives missions whose last heartbeat is older
+	// than this many days, written as "<N>d" (e.g. "30d"). Empty disables
+	// auto-archiving.
+	MissionAutoArchiveAfter string `yaml:"missionAutoArchiveAfter,omitempty"`
+	// MissionAutoDeleteAfter permanently deletes archived missions that have
+	// been archived for longer than this many days ("<N>d"). Empty disables
+	// auto-deletion.
+	MissionAutoDeleteAfter string `yaml:"missionAutoDeleteAfter,omitempty"`
+	// MissionSizeLimit flags missions whose agent directory grows past a
+	// size limit, and optionally cleans them up or keeps them from being
+	// archived until they are slimmed down.
+	MissionSizeLimit *MissionSizeLimitConfig `yaml:"missionSizeLimit,omitempty"`
+	// Notifications controls alerts delivered outside tmux.
+	Notifications *NotificationsConfig `yaml:"notifications,omitempty"`
+	// LifecycleHooks are shell commands the wrapper runs at mission
+	// lifecycle events.
+	LifecycleHooks *LifecycleHooksConfig `yaml:"lifecycleHooks,omitempty"`
+	// MultiUser lets several OS users on one host share the pool session and
+	// work on each other's missions. Read at server startup; changing it
+	// requires a server restart.
+	MultiUser *MultiUserConfig `yaml:"multiUser,omitempty"`
+	// Sandbox configures the container runtime and image used by missions
+	// with container isolation.
+	Sandbox *SandboxConfig `yaml:"sandbox,omitempty"`
+	// MissionSummary controls the AI-generated summary of what each mission
+	// is doing, shown in 'mission ls' and the dashboard.
+	MissionSummary *MissionSummaryConfig `yaml:"missionSummary,omitempty"`
+	// RateLimit throttles the server API so a runaway script cannot create
+	// work faster than the server can handle it.
+	RateLimit *RateLimitConfig `yaml:"rateLimit,omitempty"`
+	// AgentBackends defines command-template agent backends, keyed by the
+	// name missions and repos select them with.
+	AgentBackends map[string]AgentBackendConfig `yaml:"agentBackends,omitempty"`
+	// TerminalBackend is the terminal that opens mission windows when
+	// 'mission attach' runs outside tmux: "tmux" (the default), "wezterm",
+	// or "zellij". Missions always run in the tmux pool session.
+	TerminalBackend string `yaml:"terminalBackend,omitempty"`
+	// Tmux configures how mission windows are arranged across tmux sessions.
+	Tmux *TmuxConfig `yaml:"tmux,omitempty"`
+	// CleanupRemoteOnDelete controls whether deleting a mission also deletes
+	// its pushed auto-branch from origin and closes the branch's draft PR:
+	// "always", "never" (the default), or "ask" ('mission rm' prompts;
+	// everything else treats it as "never").
+	CleanupRemoteOnDelete string `yaml:"cleanupRemoteOnDelete,omitempty"`
+	// ReleaseChannel picks the GitHub releases 'agenc upgrade' installs:
+	// "stable" (the default) or "beta", which also takes prereleases.
+	ReleaseChannel string `yaml:"releaseChannel,omitempty"`
+	// DailyDigest makes the server write a digest of each day's missions,
+	// cron runs, prompts, and failures, and optionally send it to notify
+	// targets. Nil disables the digest.
+	DailyDigest *DailyDigestConfig `yaml:"dailyDigest,omitempty"`
+	// WSL configures AgenC running inside the Windows Subsystem for Linux.
+	WSL *WSLConfig `yaml:"wsl,omitempty"`
+}
+
+// DefaultDailyDigestTime is the local time the previous day's digest is
+// generated when dailyDigest.time is unset.
+const DefaultDailyDigestTime = "07:00"
+
+// DailyDigestConfig configures the daily digest.
+type DailyDigestConfig struct {
+	// Time is the local time (HH:MM, 24-hour) the previous day's digest is
+	// generated. Defaults to DefaultDailyDigestTime.
+	Time string `yaml:"time,omitempty"`
+	// Template is a Go text/template file that renders the digest's text,
+	// relative to $AGENC_DIRPATH/config/ unless absolute. Empty uses the
+	// built-in template.
+	Template string `yaml:"template,omitempty"`
+	// Notify lists targets ("email:me@example.com",
+	// "webhook:https://example.com/hook") the rendered digest is sent to.
+	Notify []string `yaml:"notify,omitempty"`
+}
+
+// GetTime returns the time of day the digest is generated, defaulting to
+// DefaultDailyDigestTime.
+func (d *DailyDigestConfig) GetTime() string {
+	if d.Time == "" {
+		return DefaultDailyDigestTime
+	}
+	return d.Time
+}
+
+// GetTemplateFilepath returns the absolute path of the custom digest
+// template, or "" when the built-in template is used.
+func (d *DailyDigestConfig) GetTemplateFilepath(agencDirpath string) string {
+	if d.Template == "" {
+		return ""
+	}
+	if filepath.IsAbs(d.Template) {
+		return d.Template
+	}
+	return filepath.Join(GetConfigDirpath(agencDirpath), d.Template)
+}
+
+// NotificationsConfig controls alerts delivered outside tmux.
+type NotificationsConfig struct {
+	// Desktop sends a native desktop notification (terminal-notifier or
+	// osascript on macOS, notify-send on Linux) when a mission finishes its
+	// turn or waits for permission while its tmux window is not focused.
+	Desktop bool `yaml:"desktop,omitempty"`
+	// Slack delivers cron 'notify' targets of the form slack:#channel.
+	Slack *SlackNotificationsConfig `yaml:"slack,omitempty"`
+	// Email delivers cron 'notify' targets of the form email:address.
+	Email *EmailNotificationsConfig `yaml:"email,omitempty"`
+}
+
+// SlackNotificationsConfig configures Slack delivery for cron notify targets.
+type SlackNotificationsConfig struct {
+	// Token is a Slack bot token with chat:write, usually a secret://NAME
+	// reference. The bot must be a member of the target channels.
+	Token string `yaml:"token"`
+}
+
+// EmailNotificationsConfig configures SMTP delivery for cron notify targets.
+type EmailNotificationsConfig struct {
+	SMTPHost string `yaml:"smtpHost"`
+	SMTPPort int    `yaml:"smtpPort,omitempty"` // Defaults to 587
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"` // Usually a secret://NAME reference
+	From     string `yaml:"from"`
+}
+
+// GitHubWebhookConfig configures the GitHub webhook receiver.
+type GitHubWebhookConfig struct {
+	// Secret is the webhook secret GitHub signs deliveries with, usually a
+	// secret://NAME reference.
+	Secret string `yaml:"secret"`
+}
+
+// ValidateCronNotifyTargets checks that each cron notify target parses and
+// that delivery for its kind is configured under notifications.
+func (c *AgencConfig) ValidateCronNotifyTargets(targets []string) error {
+	for _, rawTarget := range targets {
+		target, err := notify.ParseTarget(rawTarget)
+		if err != nil {
+			return err
+		}
+		if !c.hasNotifier(target.Kind) {
+			return stacktrace.NewError("notify target '%s' needs notifications.%s to be configured", target, target.Kind)
+		}
+	}
+	return nil
+}
+
+// hasNotifier returns whether delivery for the given notify target kind is
+// configured under notifications. Webhooks need no configuration.
+func (c *AgencConfig) hasNotifier(kind string) bool {
+	if kind == notify.KindWebhook {
+		return true
+	}
+	if c.Notifications == nil {
+		return false
+	}
+	switch kind {
+	case notify.KindSlack:
+		return c.Notifications.Slack != nil
+	case notify.KindEmail:
+		return c.Notifications.Email != nil
+	}
+	return false
+}
+
+// IsDesktopNotificationsEnabled returns whether notifications.desktop is on.
+func (c *AgencConfig) IsDesktopNotificationsEnabled() bool {
+	return c.Notifications != nil && c.Notifications.Desktop
+}
+
+// Terminals that can open mission windows.
+const (
+	TerminalBackendTmux    = "tmux"
+	TerminalBackendWezterm = "wezterm"
+	TerminalBackendZellij  = "zellij"
+)
+
+// GetTerminalBackend returns the configured terminal backend, defaulting to
+// TerminalBackendTmux.
+func (c *AgencConfig) GetTerminalBackend() string {
+	if c.TerminalBackend == "" {
+		return TerminalBackendTmux
+	}
+	return c.TerminalBackend
+}
+
+// TmuxConfig configures how mission windows are arranged across tmux
+// sessions.
+type TmuxConfig struct {
+	// Layout is where new and attached missions' windows are linked: one of
+	// the TmuxLayout* constants. Defaults to TmuxLayoutPool.
+	Layout string `yaml:"layout,omitempty"`
+}
+
+// Layouts for tmux.layout. Mission windows always live in the pool session;
+// the layout decides which user-facing session they are linked into.
+const (
+	// TmuxLayoutPool links windows into the session the mission was created
+	// or attached from
+	TmuxLayoutPool = "pool"
+	// TmuxLayoutPerRepo links windows into one session per repo
+	TmuxLayoutPerRepo = "perRepo"
+	// TmuxLayoutPerProject links windows into one session per project
+	TmuxLayoutPerProject = "perProject"
+)
+
+// GetTmuxLayout returns the configured tmux layout, defaulting to
+// TmuxLayoutPool.
+func (c *AgencConfig) GetTmuxLayout() string {
+	if c.Tmux == nil || c.Tmux.Layout == "" {
+		return TmuxLayoutPool
+	}
+	return c.Tmux.Layout
+}
+
+// Remote cleanup modes for cleanupRemoteOnDelete.
+const (
+	CleanupRemoteAsk    = "ask"
+	CleanupRemoteAlways = "always"
+	CleanupRemoteNever  = "never"
+)
+
+// GetCleanupRemoteOnDelete returns the configured remote cleanup mode,
+// defaulting to CleanupRemoteNever.
+func (c *AgencConfig) GetCleanupRemoteOnDelete() string {
+	if c.CleanupRemoteOnDelete == "" {
+		return CleanupRemoteNever
+	}
+	return c.CleanupRemoteOnDelete
+}
+
+// Release channels for releaseChannel.
+const (
+	ReleaseChannelStable = "stable"
+	ReleaseChannelBeta   = "beta"
+)
+
+// GetReleaseChannel returns the configured release channel, defaulting to
+// ReleaseChannelStable.
+func (c *AgencConfig) GetReleaseChannel() string {
+	if c.ReleaseChannel == "" {
+		return ReleaseChannelStable
+	}
+	return c.ReleaseChannel
+}
+
+// WSLConfig configures AgenC running inside WSL.
+type WSLConfig struct {
+	// WindowsClaude runs the Windows build of Claude Code (claude.exe) and
+	// ingests the Claude config from the Windows user's profile rather than
+	// the Linux home. Ignored outside WSL.
+	WindowsClaude bool `yaml:"windowsClaude,omitempty"`
+}
+
+// UsesWindowsClaude returns whether wsl.windowsClaude is on and the process
+// is running inside WSL.
+func (c *AgencConfig) UsesWindowsClaude() bool {
+	return c.WSL != nil && c.WSL.WindowsClaude && wsl.IsWSL() && wsl.WindowsHomeDirpath() != ""
+}
+
+// UserClaudeDirpath returns the Claude config directory AgenC ingests: the
+// Windows profile's .claude with wsl.windowsClaude, else ~/.claude.
+func (c *AgencConfig) UserClaudeDirpath() (string, error) {
+	if c.UsesWindowsClaude() {
+		return filepath.Join(wsl.WindowsHomeDirpath(), UserClaudeDirname), nil
+	}
+	return GetUserClaudeDirpath()
+}
+
+// LifecycleHooksConfig holds shell commands the wrapper runs (via sh -c) at
+// mission lifecycle events, with mission metadata in AGENC_* environment
+// variables. Empty commands are skipped.
+type LifecycleHooksConfig struct {
+	// OnMissionStart runs after the wrapper spawns Claude.
+	OnMissionStart string `yaml:"onMissionStart,omitempty"`
+	// OnClaudeIdle runs when Claude finishes a turn and waits for input.
+	OnClaudeIdle string `yaml:"onClaudeIdle,omitempty"`
+	// OnClaudeBusy runs when Claude starts working on a prompt.
+	OnClaudeBusy string `yaml:"onClaudeBusy,omitempty"`
+	// OnMissionEnd runs after Claude exits, with its exit code in
+	// AGENC_EXIT_CODE.
+	OnMissionEnd string `yaml:"onMissionEnd,omitempty"`
+}
+
+// GetLifecycleHooks returns the lifecycle hooks, returning an empty config
+// (no hooks) if the key is not set.
+func (c *AgencConfig) GetLifecycleHooks() *LifecycleHooksConfig {
+	if c.LifecycleHooks != nil {
+		return c.LifecycleHooks
+	}
+	return &LifecycleHooksConfig{}
+}
+
+// MultiUserConfig puts the pool session on a tmux socket shared by a group of
+// OS users. Missions record the user who created them, and the server only
+// serves a mission's requests to that user, the users they share the mission
+// with, and the user running the server. The shared tmux server itself has
+// no per-mission access control: every user granted access to it can reach
+// every mission window directly, so only list users who trust each other.
+type MultiUserConfig struct {
+	// TmuxSocket is the absolute path of the tmux socket hosting the pool
+	// session. Setting it turns multi-user mode on.
+	TmuxSocket string `yaml:"tmuxSocket,omitempty"`
+	// Group is the OS group given read-write access to the tmux socket and
+	// the server socket.
+	Group string `yaml:"group,omitempty"`
+	// Users are the OS users granted access to the shared tmux server.
+	Users []string `yaml:"users,omitempty"`
+}
+
+// IsMultiUserEnabled returns whether multiUser.tmuxSocket is set.
+func (c *AgencConfig) IsMultiUserEnabled() bool {
+	return c.MultiUser != nil && c.MultiUser.TmuxSocket != ""
+}
+
+// GetTmuxSocket returns the shared tmux socket path, or the empty string
+// (meaning the user's default tmux server) outside multi-user mode.
+func (c *AgencConfig) GetTmuxSocket() string {
+	if !c.IsMultiUserEnabled() {
+		return ""
+	}
+	return c.MultiUser.TmuxSocket
+}
+
+// Container runtimes supported for sandboxed missions.
+const (
+	SandboxRuntimeDocker = "docker"
+	SandboxRuntimePodman = "podman"
+)
+
+// DefaultSandboxImage is the image sandboxed missions run in when
+// sandbox.image is unset. Claude Code is installed into it with npm on each
+// start if the image doesn't already provide a claude binary.
+const DefaultSandboxImage = "node:lts"
+
+// SandboxConfig controls the containers that missions with container
+// isolation run Claude in.
+type SandboxConfig struct {
+	// Runtime is SandboxRuntimeDocker or SandboxRuntimePodman. Empty picks
+	// whichever is on PATH, preferring docker.
+	Runtime string `yaml:"runtime,omitempty"`
+	// Image is the container image; defaults to DefaultSandboxImage. An
+	// image with Claude Code preinstalled starts much faster.
+	Image string `yaml:"image,omitempty"`
+}
+
+// GetSandboxRuntime returns the configured container runtime, or the empty
+// string to auto-detect.
+func (c *AgencConfig) GetSandboxRuntime() string {
+	if c.Sandbox == nil {
+		return ""
+	}
+	return c.Sandbox.Runtime
+}
+
+// GetSandboxImage returns the sandbox container image, defaulting to
+// DefaultSandboxImage.
+func (c *AgencConfig) GetSandboxImage() string {
+	if c.Sandbox != nil && c.Sandbox.Image != "" {
+		return c.Sandbox.Image
+	}
+	return DefaultSandboxImage
+}
+
+// IsContainerIsolated returns whether the repo's missions run in a sandbox
+// container.
+func (c *AgencConfig) IsContainerIsolated(repoName string) bool {
+	rc, ok := c.RepoConfigs[repoName]
+	return ok && rc.Isolation == IsolationContainer
+}
+
+// When the server regenerates a mission's AI summary.
+const (
+	// MissionSummaryTriggerPrompts regenerates it every everyPrompts prompts
+	MissionSummaryTriggerPrompts = "prompts"
+	// MissionSummaryTriggerIdle regenerates it whenever Claude goes idle
+	// after new prompts
+	MissionSummaryTriggerIdle = "idle"
+	// MissionSummaryTriggerNever turns automatic summaries off; 'mission
+	// summarize --now' still works
+	MissionSummaryTriggerNever = "never"
+)
+
+// DefaultMissionSummaryEveryPrompts is how many prompts pass between
+// summaries under the "prompts" trigger when everyPrompts is unset.
+const DefaultMissionSummaryEveryPrompts = 10
+
+// MissionSummaryConfig controls AI mission summaries.
+type MissionSummaryConfig struct {
+	// Trigger is one of the MissionSummaryTrigger* constants; defaults to
+	// MissionSummaryTriggerPrompts.
+	Trigger string `yaml:"trigger,omitempty"`
+	// EveryPrompts is the prompt interval for the "prompts" trigger.
+	EveryPrompts int `yaml:"everyPrompts,omitempty"`
+	// Model is the Claude model that writes summaries; empty uses the same
+	// Haiku model as session titles.
+	Model string `yaml:"model,omitempty"`
+}
+
+// StatuslineConfig configures the statusline under each mission's Claude
+// prompt.
+type StatuslineConfig struct {
+	// Segments are the StatuslineSegments to show, in order; defaults to
+	// DefaultStatuslineSegments.
+	Segments []string `yaml:"segments,omitempty"`
+}
+
+// GetStatuslineSegments returns the segments of each mission's statusline,
+// defaulting to DefaultStatuslineSegments.
+func (c *AgencConfig) GetStatuslineSegments() []string {
+	if c.Statusline == nil || len(c.Statusline.Segments) == 0 {
+		return DefaultStatuslineSegments
+	}
+	return c.Statusline.Segments
+}
+
+// GetMissionSummaryTrigger returns when mission summaries are regenerated,
+// defaulting to MissionSummaryTriggerPrompts.
+func (c *AgencConfig) GetMissionSummaryTrigger() string {
+	if c.MissionSummary == nil || c.MissionSummary.Trigger == "" {
+		return MissionSummaryTriggerPrompts
+	}
+	return c.MissionSummary.Trigger
+}
+
+// GetMissionSummaryEveryPrompts returns the prompt interval for the
+// "prompts" trigger, defaulting to DefaultMissionSummaryEveryPrompts.
+func (c *AgencConfig) GetMissionSummaryEveryPrompts() int {
+	if c.MissionSummary == nil || c.MissionSummary.EveryPrompts == 0 {
+		return DefaultMissionSummaryEveryPrompts
+	}
+	return c.MissionSummary.EveryPrompts
+}
+
+// GetMissionSummaryModel returns the configured summary model, or the empty
+// string for the default.
+func (c *AgencConfig) GetMissionSummaryModel() string {
+	if c.MissionSummary == nil {
+		return ""
+	}
+	return c.MissionSummary.Model
 }
 
 // GetPaletteTmuxKeybinding returns the tmux key for the command palette,
@@ -467,6 +1226,61 @@ func (c *AgencConfig) GetSessionTitleMaxWords() int {
 	return c.SessionTitleMaxWords
 }
 
+// GetMissionAutoArchiveAfter returns the auto-archive threshold, or zero when
+// auto-archiving is disabled or the value is malformed.
+func (c *AgencConfig) GetMissionAutoArchiveAfter() time.Duration {
+	d, _ := ParseRetentionDays(c.MissionAutoArchiveAfter)
+	return d
+}
+
+// GetWindowTitleTemplate returns the windowTitleTemplate for a repo's
+// missions: the repo's own template, else the global one. Empty means the
+// plain title.
+func (c *AgencConfig) GetWindowTitleTemplate(repoName string) string {
+	if rc, ok := c.RepoConfigs[repoName]; ok && rc.WindowTitleTemplate != "" {
+		return rc.WindowTitleTemplate
+	}
+	return c.WindowTitleTemplate
+}
+
+// GetSuspendAfterIdle returns the idle-suspend threshold, or zero when
+// suspending is disabled or the value is malformed.
+func (c *AgencConfig) GetSuspendAfterIdle() time.Duration {
+	d, _ := ParseSuspendAfterIdle(c.SuspendAfterIdle)
+	return d
+}
+
+// GetMissionAutoDeleteAfter returns the auto-delete threshold, or zero when
+// auto-deletion is disabled or the value is malformed.
+func (c *AgencConfig) GetMissionAutoDeleteAfter() time.Duration {
+	d, _ := ParseRetentionDays(c.MissionAutoDeleteAfter)
+	return d
+}
+
+// MissionSizeLimitConfig flags missions whose agent directory exceeds MaxSize.
+type MissionSizeLimitConfig struct {
+	// MaxSize is the agent directory size that flags a mission, e.g. "5GB".
+	// Units are powers of 1024.
+	MaxSize string `yaml:"maxSize"`
+	// CleanupHook is a shell command run (via sh -c) in a flagged mission's
+	// agent directory while its wrapper is stopped, e.g.
+	// "rm -rf node_modules". Empty disables cleanup.
+	CleanupHook string `yaml:"cleanupHook,omitempty"`
+	// BlockArchive refuses to archive a flagged mission, by hand or by
+	// missionAutoArchiveAfter, until it is back under MaxSize.
+	BlockArchive bool `yaml:"blockArchive,omitempty"`
+}
+
+// GetMissionMaxSizeBytes returns the agent directory size limit in bytes, or
+// zero when no limit is configured or the value is malformed.
+func (c *AgencConfig) GetMissionMaxSizeBytes() int64 {
+	if c.MissionSizeLimit == nil {
+		return 0
+	}
+	sizeBytes, _ := ParseByteSize(c.MissionSizeLimit.MaxSize)
+	return sizeBytes
+}
+
 // GetAllSyncedRepos returns the sorted list of repo names that have alwaysSynced enabled.
 func (c *AgencConfig) GetAllSyncedRepos() []string {
 	var repos []string
@@ -537,6 +1351,15 @@ func (c *AgencConfig) GetRepoDescription(repoName string) string {
 	return ""
 }
 
+// GetRepoUpstream returns the canonical name of the repo that repoName is a
+// fork of, or empty string if none is set.
+func (c *AgencConfig) GetRepoUpstream(repoName string) string {
+	if rc, ok := c.RepoConfigs[repoName]; ok {
+		return rc.Upstream
+	}
+	return ""
+}
+
 // GetRepoConfig returns the config for a repo and whether it exists.
 func (c *AgencConfig) GetRepoConfig(repoName string) (RepoConfig, bool) {
 	rc, ok := c.RepoConfigs[repoName]
@@ -590,6 +1413,75 @@ func (c *AgencConfig) GetDefaultModel(repoName string) string {
 	return c.DefaultModel
 }
 
+// GetWorkspaceMode returns the workspace mode for a given repo, defaulting to
+// WorkspaceModeCopy when unset.
+func (c *AgencConfig) GetWorkspaceMode(repoName string) string {
+	if rc, ok := c.RepoConfigs[repoName]; ok && rc.WorkspaceMode != "" {
+		return rc.WorkspaceMode
+	}
+	return WorkspaceModeCopy
+}
+
+// GetAutoBranchTemplate returns the branch name template for new missions in
+// the given repo, or "" when autoBranch is not enabled for it.
+func (c *AgencConfig) GetAutoBranchTemplate(repoName string) string {
+	rc, ok := c.RepoConfigs[repoName]
+	if !ok || !rc.AutoBranch {
+		return ""
+	}
+	if rc.AutoBranchTemplate != "" {
+		return rc.AutoBranchTemplate
+	}
+	return DefaultAutoBranchTemplate
+}
+
+// GetPostUpdateHookCache returns the dependency cache paths for a given repo,
+// or nil when none are configured.
+func (c *AgencConfig) GetPostUpdateHookCache(repoName string) []string {
+	if rc, ok := c.RepoConfigs[repoName]; ok {
+		return rc.PostUpdateHookCache
+	}
+	return nil
+}
+
+// IsClaudeMdAppendPath reports whether a claudeMdAppend value names a file
+// rather than holding the content inline: a single line that starts with /,
+// ~/, ./, or ../, or ends in .md.
+func IsClaudeMdAppendPath(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.Contains(value, "\n") {
+		return false
+	}
+	for _, prefix := range []string{"/", "~/", "./", "../"} {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(value, ".md") && !strings.ContainsAny(value, " \t")
+}
+
+// ResolveClaudeMdAppend returns the CLAUDE.md content a claudeMdAppend value
+// stands for: the contents of the file it names (relative paths resolve
+// against the config directory) or the value itself when it is inline.
+func ResolveClaudeMdAppend(agencDirpath string, value string) (string, error) {
+	if !IsClaudeMdAppendPath(value) {
+		return strings.TrimSpace(value), nil
+	}
+
+	path, err := expandTilde(strings.TrimSpace(value))
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(GetConfigDirpath(agencDirpath), path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to read claudeMdAppend file '%s'", path)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 // GetClaudeArgs returns the merged Claude CLI args for a given repo.
 // Precedence: global claudeArgs first, then per-repo claudeArgs appended.
 func (c *AgencConfig) GetClaudeArgs(repoName string) []string {
@@ -635,7 +1527,7 @@ func AcquireConfigLock(agencDirpath string) (func(), error) {
 
 // ReadAgencConfig reads and parses config.yml. Returns an empty config if the
 // file does not exist. Returns an error if any repoConfig key is not in
-// canonical format (github.com/owner/repo).
+// canonical format (host/owner/repo).
 // The returned yaml.CommentMap captures any YAML comments for round-trip
 // preservation; callers that only read config may discard it with _.
 func ReadAgencConfig(agencDirpath string) (*AgencConfig, yaml.CommentMap, error) {
@@ -649,6 +1541,19 @@ func ReadAgencConfig(agencDirpath string) (*AgencConfig, yaml.CommentMap, error)
 		return nil, nil, stacktrace.Propagate(err, "failed to read config file '%s'", configFilepath)
 	}
 
+	// Lint against the schema first so errors carry a line, column, and field
+	// path instead of the decoder's generic message
+	if issues := lintConfigYAML(data); hasConfigErrors(issues) {
+		return nil, nil, configIssuesError(configFilepath, issues)
+	}
+
+	return parseAgencConfig(data, configFilepath)
+}
+
+// parseAgencConfig decodes config.yml content and runs the semantic
+// validation and normalization shared by ReadAgencConfig and
+// ValidateConfigFile.
+func parseAgencConfig(data []byte, configFilepath string) (*AgencConfig, yaml.CommentMap, error) {
 	var cfg AgencConfig
 	cm := yaml.CommentMap{}
 	if err := yaml.UnmarshalWithOptions(data, &cfg, yaml.CommentToMap(cm)); err != nil {
@@ -667,6 +1572,10 @@ func ReadAgencConfig(agencDirpath string) (*AgencConfig, yaml.CommentMap, error)
 		return nil, nil, err
 	}
 
+	if err := validateAgentBackends(&cfg, configFilepath); err != nil {
+		return nil, nil, err
+	}
+
 	// Validate uniqueness of titles and keybindings across the resolved set
 	if err := validatePaletteUniqueness(&cfg, configFilepath); err != nil {
 		return nil, nil, err
@@ -676,6 +1585,73 @@ func ReadAgencConfig(agencDirpath string) (*AgencConfig, yaml.CommentMap, error)
 		return nil, nil, err
 	}
 
+	if err := validateQuietHours(&cfg); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "invalid quietHours config in %s", configFilepath)
+	}
+
+	if err := validateMultiUser(&cfg); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "invalid multiUser config in %s", configFilepath)
+	}
+
+	if err := validateDailyDigest(&cfg); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "invalid dailyDigest config in %s", configFilepath)
+	}
+
+	if cfg.MissionSizeLimit != nil {
+		if _, err := ParseByteSize(cfg.MissionSizeLimit.MaxSize); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "invalid missionSizeLimit.maxSize in %s", configFilepath)
+		}
+	}
+
+	if cfg.Sandbox != nil && cfg.Sandbox.Runtime != "" {
+		if err := ValidateSandboxRuntime(cfg.Sandbox.Runtime); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "invalid sandbox config in %s", configFilepath)
+		}
+	}
+
+	if cfg.TerminalBackend != "" {
+		if err := ValidateTerminalBackend(cfg.TerminalBackend); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "invalid config in %s", configFilepath)
+		}
+	}
+
+	if cfg.Tmux != nil && cfg.Tmux.Layout != "" {
+		if err := ValidateTmuxLayout(cfg.Tmux.Layout); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "invalid tmux config in %s", configFilepath)
+		}
+	}
+
+	if cfg.CleanupRemoteOnDelete != "" {
+		if err := ValidateCleanupRemoteOnDelete(cfg.CleanupRemoteOnDelete); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "invalid config in %s", configFilepath)
+		}
+	}
+
+	if cfg.ReleaseChannel != "" {
+		if err := ValidateReleaseChannel(cfg.ReleaseChannel); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "invalid config in %s", configFilepath)
+		}
+	}
+
+	if cfg.Statusline != nil {
+		for _, segment := range cfg.Statusline.Segments {
+			if err := ValidateStatuslineSegment(segment); err != nil {
+				return nil, nil, stacktrace.Propagate(err, "invalid statusline config in %s", configFilepath)
+			}
+		}
+	}
+
+	if cfg.MissionSummary != nil {
+		if cfg.MissionSummary.Trigger != "" {
+			if err := ValidateMissionSummaryTrigger(cfg.MissionSummary.Trigger); err != nil {
+				return nil, nil, stacktrace.Propagate(err, "invalid missionSummary config in %s", configFilepath)
+			}
+		}
+		if cfg.MissionSummary.EveryPrompts < 0 {
+			return nil, nil, stacktrace.NewError("invalid missionSummary config in %s: everyPrompts cannot be negative", configFilepath)
+		}
+	}
+
 	// Validate and populate defaults
 	if err := ValidateAndPopulateDefaults(&cfg); err != nil {
 		return nil, nil, stacktrace.Propagate(err, "validation failed for %s", configFilepath)
@@ -687,18 +1663,225 @@ func ReadAgencConfig(agencDirpath string) (*AgencConfig, yaml.CommentMap, error)
 }
 
 // validateRepoConfigs initializes the RepoConfigs map if nil and validates that
-// every key matches the canonical "github.com/owner/repo" format.
+// every key matches the canonical "host/owner/repo" format.
 func validateRepoConfigs(cfg *AgencConfig, configFilepath string) error {
 	if cfg.RepoConfigs == nil {
 		cfg.RepoConfigs = make(map[string]RepoConfig)
 	}
-	for repoName := range cfg.RepoConfigs {
+	for repoName, rc := range cfg.RepoConfigs {
 		if !canonicalRepoRegex.MatchString(repoName) {
 			return stacktrace.NewError(
-				"invalid repoConfig key '%s' in %s; must be in canonical format 'github.com/owner/repo'",
+				"invalid repoConfig key '%s' in %s; must be in canonical format 'host/owner/repo'",
 				repoName, configFilepath,
 			)
 		}
+		if rc.WorkspaceMode != "" {
+			if err := ValidateWorkspaceMode(rc.WorkspaceMode); err != nil {
+				return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+			}
+		}
+		if rc.Isolation != "" {
+			if err := ValidateIsolation(rc.Isolation); err != nil {
+				return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+			}
+		}
+		if rc.NetworkPolicy != nil {
+			for _, host := range rc.NetworkPolicy.AllowedHosts {
+				if err := ValidateNetworkPolicyHost(host); err != nil {
+					return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+				}
+			}
+			if rc.Isolation == IsolationContainer {
+				return stacktrace.NewError(
+					"invalid repoConfig for '%s' in %s: networkPolicy is not supported with isolation '%s'",
+					repoName, configFilepath, IsolationContainer)
+			}
+		}
+		for key := range rc.Env {
+			if err := ValidateEnvVarName(key); err != nil {
+				return stacktrace.Propagate(err, "invalid repoConfig env for '%s' in %s", repoName, configFilepath)
+			}
+		}
+		if rc.AutoBranchTemplate != "" {
+			if err := ValidateAutoBranchTemplate(rc.AutoBranchTemplate); err != nil {
+				return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+			}
+		}
+		if err := ValidateWindowTitleTemplate(rc.WindowTitleTemplate); err != nil {
+			return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+		}
+		for _, cachePath := range rc.PostUpdateHookCache {
+			if err := ValidateDependencyCachePath(cachePath); err != nil {
+				return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+			}
+		}
+		for serverName, serverCfg := range rc.McpServers {
+			if err := ValidateMcpServer(serverName, serverCfg); err != nil {
+				return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateWorkspaceMode returns an error if mode is not a known workspace mode.
+func ValidateWorkspaceMode(mode string) error {
+	if mode != WorkspaceModeCopy && mode != WorkspaceModeWorktree {
+		return stacktrace.NewError("workspaceMode must be '%s' or '%s', got '%s'", WorkspaceModeCopy, WorkspaceModeWorktree, mode)
+	}
+	return nil
+}
+
+// ValidateIsolation returns an error if mode is not a known isolation mode.
+func ValidateIsolation(mode string) error {
+	if mode != IsolationHost && mode != IsolationContainer {
+		return stacktrace.NewError("isolation must be '%s' or '%s', got '%s'", IsolationHost, IsolationContainer, mode)
+	}
+	return nil
+}
+
+// ValidateSandboxRuntime returns an error if runtime is not a supported
+// container runtime.
+func ValidateSandboxRuntime(runtime string) error {
+	if runtime != SandboxRuntimeDocker && runtime != SandboxRuntimePodman {
+		return stacktrace.NewError("runtime must be '%s' or '%s', got '%s'", SandboxRuntimeDocker, SandboxRuntimePodman, runtime)
+	}
+	return nil
+}
+
+// ValidateTerminalBackend returns an error if backend is not one of the
+// TerminalBackend* constants.
+func ValidateTerminalBackend(backend string) error {
+	switch backend {
+	case TerminalBackendTmux, TerminalBackendWezterm, TerminalBackendZellij:
+		return nil
+	}
+	return stacktrace.NewError("terminalBackend must be '%s', '%s', or '%s', got '%s'", TerminalBackendTmux, TerminalBackendWezterm, TerminalBackendZellij, backend)
+}
+
+// ValidateTmuxLayout returns an error if layout is not one of the TmuxLayout*
+// constants.
+func ValidateTmuxLayout(layout string) error {
+	switch layout {
+	case TmuxLayoutPool, TmuxLayoutPerRepo, TmuxLayoutPerProject:
+		return nil
+	}
+	return stacktrace.NewError("tmux.layout must be '%s', '%s', or '%s', got '%s'", TmuxLayoutPool, TmuxLayoutPerRepo, TmuxLayoutPerProject, layout)
+}
+
+// ValidateCleanupRemoteOnDelete returns an error if mode is not one of the
+// CleanupRemote* constants.
+func ValidateCleanupRemoteOnDelete(mode string) error {
+	switch mode {
+	case CleanupRemoteAsk, CleanupRemoteAlways, CleanupRemoteNever:
+		return nil
+	}
+	return stacktrace.NewError("cleanupRemoteOnDelete must be '%s', '%s', or '%s', got '%s'", CleanupRemoteAsk, CleanupRemoteAlways, CleanupRemoteNever, mode)
+}
+
+// ValidateReleaseChannel returns an error if channel is not one of the
+// ReleaseChannel* constants.
+func ValidateReleaseChannel(channel string) error {
+	switch channel {
+	case ReleaseChannelStable, ReleaseChannelBeta:
+		return nil
+	}
+	return stacktrace.NewError("releaseChannel must be '%s' or '%s', got '%s'", ReleaseChannelStable, ReleaseChannelBeta, channel)
+}
+
+// ValidateMissionSummaryTrigger returns an error if trigger is not one of the
+// MissionSummaryTrigger* constants.
+func ValidateMissionSummaryTrigger(trigger string) error {
+	switch trigger {
+	case MissionSummaryTriggerPrompts, MissionSummaryTriggerIdle, MissionSummaryTriggerNever:
+		return nil
+	}
+	return stacktrace.NewError("trigger must be '%s', '%s', or '%s', got '%s'",
+		MissionSummaryTriggerPrompts, MissionSummaryTriggerIdle, MissionSummaryTriggerNever, trigger)
+}
+
+// ValidateAutoBranchTemplate returns an error if template uses an unknown
+// placeholder or does not include {shortID} or {missionID}. Requiring a
+// mission ID keeps branch names unique across missions.
+func ValidateAutoBranchTemplate(template string) error {
+	rest := template
+	for _, placeholder := range autoBranchPlaceholders {
+		rest = strings.ReplaceAll(rest, placeholder, "")
+	}
+	if strings.ContainsAny(rest, "{}") {
+		return stacktrace.NewError("autoBranchTemplate '%s' uses an unknown placeholder; supported placeholders are %s", template, strings.Join(autoBranchPlaceholders, ", "))
+	}
+	if !strings.Contains(template, "{shortID}") && !strings.Contains(template, "{missionID}") {
+		return stacktrace.NewError("autoBranchTemplate '%s' must include {shortID} or {missionID} so every mission gets its own branch", template)
+	}
+	return nil
+}
+
+// ValidateWindowTitleTemplate returns an error if template uses a
+// placeholder other than WindowTitlePlaceholders.
+func ValidateWindowTitleTemplate(template string) error {
+	rest := template
+	for _, placeholder := range WindowTitlePlaceholders {
+		rest = strings.ReplaceAll(rest, placeholder, "")
+	}
+	if strings.ContainsAny(rest, "{}") {
+		return stacktrace.NewError("windowTitleTemplate '%s' uses an unknown placeholder; supported placeholders are %s", template, strings.Join(WindowTitlePlaceholders, ", "))
+	}
+	return nil
+}
+
+// ValidateDependencyCachePath returns an error if path is not a clean,
+// repo-relative path that stays inside the repo and outside .git.
+func ValidateDependencyCachePath(path string) error {
+	if path == "" {
+		return stacktrace.NewError("postUpdateHookCache paths cannot be empty")
+	}
+	if filepath.IsAbs(path) {
+		return stacktrace.NewError("postUpdateHookCache path '%s' must be relative to the repo root", path)
+	}
+	cleaned := filepath.Clean(path)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return stacktrace.NewError("postUpdateHookCache path '%s' must stay inside the repo", path)
+	}
+	if cleaned == ".git" || strings.HasPrefix(cleaned, ".git/") {
+		return stacktrace.NewError("postUpdateHookCache path '%s' cannot be inside .git", path)
+	}
+	return nil
+}
+
+// ValidateMcpServerName returns an error if name is not a valid MCP server
+// name.
+func ValidateMcpServerName(name string) error {
+	if !mcpServerNameRegex.MatchString(name) {
+		return stacktrace.NewError("invalid MCP server name '%s'; must contain only letters, digits, hyphens, and underscores", name)
+	}
+	return nil
+}
+
+// ValidateMcpServer returns an error if the named server definition is
+// incomplete or mixes transports: stdio servers need a command, sse and http
+// servers need a url, and neither may set the other's fields.
+func ValidateMcpServer(name string, server McpServerConfig) error {
+	if err := ValidateMcpServerName(name); err != nil {
+		return err
+	}
+	switch server.Type {
+	case "", McpServerTypeStdio:
+		if server.Command == "" {
+			return stacktrace.NewError("MCP server '%s' must set a command", name)
+		}
+		if server.URL != "" || len(server.Headers) > 0 {
+			return stacktrace.NewError("MCP server '%s' is a stdio server; url and headers only apply to sse and http servers", name)
+		}
+	case McpServerTypeSSE, McpServerTypeHTTP:
+		if server.URL == "" {
+			return stacktrace.NewError("MCP server '%s' must set a url", name)
+		}
+		if server.Command != "" || len(server.Args) > 0 || len(server.Env) > 0 {
+			return stacktrace.NewError("MCP server '%s' is an %s server; command, args, and env only apply to stdio servers", name, server.Type)
+		}
+	default:
+		return stacktrace.NewError("MCP server '%s' has unknown type '%s'; must be '%s', '%s', or '%s'", name, server.Type, McpServerTypeStdio, McpServerTypeSSE, McpServerTypeHTTP)
 	}
 	return nil
 }
@@ -713,22 +1896,101 @@ func validateCronConfigs(cfg *AgencConfig, configFilepath string) error {
 		if err := ValidateCronName(name); err != nil {
 			return stacktrace.Propagate(err, "invalid cron name in %s", configFilepath)
 		}
-		if err := ValidateCronSchedule(cronCfg.Schedule); err != nil {
-			return stacktrace.Propagate(err, "invalid schedule for cron '%s' in %s", name, configFilepath)
+		switch {
+		case cronCfg.Schedule != "" && cronCfg.RunAt != "":
+			return stacktrace.NewError("cron '%s' in %s cannot have both a schedule and a runAt", name, configFilepath)
+		case cronCfg.RunAt != "":
+			if _, err := ParseCronRunAt(cronCfg.RunAt); err != nil {
+				return stacktrace.Propagate(err, "invalid runAt for cron '%s' in %s", name, configFilepath)
+			}
+		default:
+			if err := ValidateCronSchedule(cronCfg.Schedule); err != nil {
+				return stacktrace.Propagate(err, "invalid schedule for cron '%s' in %s", name, configFilepath)
+			}
 		}
 		if cronCfg.Prompt == "" {
 			return stacktrace.NewError("cron '%s' in %s must have a prompt", name, configFilepath)
 		}
 		if cronCfg.Repo != "" && !canonicalRepoRegex.MatchString(cronCfg.Repo) {
 			return stacktrace.NewError(
-				"invalid repo '%s' for cron '%s' in %s; must be in canonical format 'github.com/owner/repo'",
+				"invalid repo '%s' for cron '%s' in %s; must be in canonical format 'host/owner/repo'",
 				cronCfg.Repo, name, configFilepath,
 			)
 		}
+		if cronCfg.Ref != "" {
+			if cronCfg.Repo == "" {
+				return stacktrace.NewError("cron '%s' in %s has a ref but no repo", name, configFilepath)
+			}
+			if err := ValidateCronRef(cronCfg.Ref); err != nil {
+				return stacktrace.Propagate(err, "invalid ref for cron '%s' in %s", name, configFilepath)
+			}
+		}
+		if err := cfg.ValidateCronNotifyTargets(cronCfg.Notify); err != nil {
+			return stacktrace.Propagate(err, "invalid notify for cron '%s' in %s", name, configFilepath)
+		}
+		if _, err := ParseCronResumePolicy(cronCfg.ResumePolicy); err != nil {
+			return stacktrace.Propagate(err, "invalid resumePolicy for cron '%s' in %s", name, configFilepath)
+		}
+		if cronCfg.AlertOnConsecutiveFailures < 0 {
+			return stacktrace.NewError("alertOnConsecutiveFailures for cron '%s' in %s cannot be negative", name, configFilepath)
+		}
+	}
+	if err := ValidateCronDependencies(cfg.Crons); err != nil {
+		return stacktrace.Propagate(err, "invalid cron dependencies in %s", configFilepath)
+	}
+	return nil
+}
+
+// ValidateCronDependencies checks that every cron's 'after' names another
+// existing cron and that following 'after' links never loops back.
+func ValidateCronDependencies(crons map[string]CronConfig) error {
+	for name, cronCfg := range crons {
+		if cronCfg.After == "" {
+			continue
+		}
+		if cronCfg.After == name {
+			return stacktrace.NewError("cron '%s' cannot run after itself", name)
+		}
+		if _, ok := crons[cronCfg.After]; !ok {
+			return stacktrace.NewError("cron '%s' runs after unknown cron '%s'", name, cronCfg.After)
+		}
+
+		// Each cron has at most one upstream, so a cycle shows up as a
+		// revisit while walking the chain
+		seen := map[string]bool{name: true}
+		for upstream := cronCfg.After; upstream != ""; upstream = crons[upstream].After {
+			if seen[upstream] {
+				return stacktrace.NewError("cron '%s' is part of an 'after' cycle", name)
+			}
+			seen[upstream] = true
+		}
 	}
 	return nil
 }
 
+// GetCronByID returns the name and config of the cron with the given ID.
+func (c *AgencConfig) GetCronByID(cronID string) (string, CronConfig, bool) {
+	for name, cronCfg := range c.Crons {
+		if cronCfg.ID == cronID {
+			return name, cronCfg, true
+		}
+	}
+	return "", CronConfig{}, false
+}
+
+// GetCronDependents returns the sorted names of crons whose 'after' names
+// cronName.
+func (c *AgencConfig) GetCronDependents(cronName string) []string {
+	var dependents []string
+	for name, cronCfg := range c.Crons {
+		if cronCfg.After == cronName {
+			dependents = append(dependents, name)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
 // validateSleepMode validates each window in the sleep mode configuration, if present.
 func validateSleepMode(cfg *AgencConfig) error {
 	if cfg.SleepMode == nil {
@@ -742,6 +2004,64 @@ func validateSleepMode(cfg *AgencConfig) error {
 	return nil
 }
 
+// validateQuietHours checks the quietHours start and end times, if present.
+func validateQuietHours(cfg *AgencConfig) error {
+	if cfg.QuietHours == nil {
+		return nil
+	}
+	if err := sleep.ValidateWindow(cfg.QuietHours.window()); err != nil {
+		return stacktrace.Propagate(err, "quietHours")
+	}
+	return nil
+}
+
+// validateDailyDigest checks the dailyDigest time and notify targets, if
+// present.
+func validateDailyDigest(cfg *AgencConfig) error {
+	if cfg.DailyDigest == nil {
+		return nil
+	}
+	if cfg.DailyDigest.Time != "" {
+		if err := sleep.ValidateTime(cfg.DailyDigest.Time); err != nil {
+			return stacktrace.Propagate(err, "invalid time")
+		}
+	}
+	if err := cfg.ValidateCronNotifyTargets(cfg.DailyDigest.Notify); err != nil {
+		return stacktrace.Propagate(err, "invalid notify")
+	}
+	return nil
+}
+
+// validateMultiUser checks the multiUser block, if present: the tmux socket
+// must be an absolute path and user names must be non-empty.
+func validateMultiUser(cfg *AgencConfig) error {
+	if cfg.MultiUser == nil {
+		return nil
+	}
+	if socket := cfg.MultiUser.TmuxSocket; socket != "" && !filepath.IsAbs(socket) {
+		return stacktrace.NewError("tmuxSocket must be an absolute path, got '%s'", socket)
+	}
+	for i, user := range cfg.MultiUser.Users {
+		if strings.TrimSpace(user) == "" {
+			return stacktrace.NewError("users[%d] is empty", i)
+		}
+	}
+	return nil
+}
+
+// ValidateMissionsMaxConcurrent returns an error if v is not a positive
+// integer. Called by CLI `config set missionsMaxConcurrent <n>`; use `config
+// unset` to remove the cap.
+func ValidateMissionsMaxConcurrent(v int) error {
+	if v < 1 {
+		return stacktrace.NewError(
+			"missionsMaxConcurrent must be a positive integer, got %d; use `agenc config unset missionsMaxConcurrent` to remove the cap",
+			v,
+		)
+	}
+	return nil
+}
+
 // ValidateAttachedMissionLimit returns an error if v is not a positive integer.
 // Called by CLI `config set attachedMissionLimit <n>` to reject zero and negative
 // values loudly. Hand-edited config values are read literally — a `0` in
@@ -771,6 +2091,89 @@ func ValidateSessionTitleMaxWords(v int) error {
 	return nil
 }
 
+// ParseRetentionDays parses a mission retention period written as "<N>d"
+// (e.g. "30d") where N is a positive number of days. An empty string means
+// "disabled" and returns zero.
+func ParseRetentionDays(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	daysStr, ok := strings.CutSuffix(value, "d")
+	if !ok {
+		return 0, stacktrace.NewError("retention period '%s' must be a number of days like '30d'", value)
+	}
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days <= 0 {
+		return 0, stacktrace.NewError("retention period '%s' must be a positive number of days like '30d'", value)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// ParseSuspendAfterIdle parses a suspendAfterIdle value: a positive Go
+// duration such as "4h" or "90m". Empty returns zero (disabled).
+func ParseSuspendAfterIdle(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, stacktrace.NewError("suspendAfterIdle '%s' must be a positive duration like '4h' or '90m'", value)
+	}
+	return d, nil
+}
+
+// byteSizeUnits maps the unit suffixes ParseByteSize accepts to their
+// multipliers. Units are powers of 1024 whether or not they are written with
+// the "i".
+var byteSizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"K":   1 << 10,
+	"KB":  1 << 10,
+	"KIB": 1 << 10,
+	"M":   1 << 20,
+	"MB":  1 << 20,
+	"MIB": 1 << 20,
+	"G":   1 << 30,
+	"GB":  1 << 30,
+	"GIB": 1 << 30,
+	"T":   1 << 40,
+	"TB":  1 << 40,
+	"TIB": 1 << 40,
+}
+
+// ParseByteSize parses a size like "500MB", "1.5GB", or "2GiB" into bytes.
+func ParseByteSize(value string) (int64, error) {
+	trimmed := strings.TrimSpace(value)
+	numEnd := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if numEnd == -1 {
+		numEnd = len(trimmed)
+	}
+	number, err := strconv.ParseFloat(trimmed[:numEnd], 64)
+	multiplier, ok := byteSizeUnits[strings.ToUpper(strings.TrimSpace(trimmed[numEnd:]))]
+	if err != nil || !ok || number <= 0 {
+		return 0, stacktrace.NewError("size '%s' must be a positive size like '500MB' or '5GB'", value)
+	}
+	return int64(number * float64(multiplier)), nil
+}
+
+// FormatByteSize renders a byte count with a binary unit suffix, e.g.
+// "1.5 GiB".
+func FormatByteSize(sizeBytes int64) string {
+	const unit = 1024
+	if sizeBytes < unit {
+		return fmt.Sprintf("%d B", sizeBytes)
+	}
+	div, exp := int64(unit), 0
+	for n := sizeBytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(sizeBytes)/float64(div), "KMGTPE"[exp])
+}
+
 // validatePaletteCommandConfigs initializes the PaletteCommands map if nil and
 // validates each entry's name, and requires title and command for non-builtin,
 // non-disabled entries that have content.
@@ -782,6 +2185,11 @@ func validatePaletteCommandConfigs(cfg *AgencConfig, configFilepath string) erro
 		if err := ValidatePaletteCommandName(name); err != nil {
 			return stacktrace.Propagate(err, "invalid palette command name in %s", configFilepath)
 		}
+		if cmdCfg.Command != nil {
+			if err := ValidatePaletteCommandInputs(*cmdCfg.Command); err != nil {
+				return stacktrace.Propagate(err, "invalid command for palette command '%s' in %s", name, configFilepath)
+			}
+		}
 		// Custom (non-builtin) entries with content must have title and command
 		_, isBuiltin := BuiltinPaletteCommands[name]
 		if !isBuiltin && !cmdCfg.IsEmpty() && !cmdCfg.Disabled {
@@ -841,6 +2249,11 @@ func EnsureConfigFile(agencDirpath string) error {
 // cronNameRegex matches valid cron names: alphanumeric, hyphens, underscores.
 var cronNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
 
+var projectNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9._-]*$`)
+
+// envVarNameRegex matches valid environment variable names.
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // ValidatePaletteCommandName checks whether a palette command name is valid.
 // Names follow the same rules as cron names: start with a letter,
 // contain only letters, numbers, hyphens, and underscores, max 64 characters.
@@ -994,6 +2407,21 @@ func extractAgencTableKey(paletteKeybinding string) string {
 	return ""
 }
 
+// ValidateProjectName checks whether a project name is valid. Project names
+// must start with a letter and contain only letters, numbers, '.', '-', and '_'.
+func ValidateProjectName(name string) error {
+	if name == "" {
+		return stacktrace.NewError("project name cannot be empty")
+	}
+	if len(name) > 64 {
+		return stacktrace.NewError("project name too long (max 64 characters)")
+	}
+	if !projectNameRegex.MatchString(name) {
+		return stacktrace.NewError("project name '%s' is invalid; must start with a letter and contain only letters, numbers, '.', hyphens, and underscores", name)
+	}
+	return nil
+}
+
 // ValidateCronName checks whether a cron name is valid.
 // Cron names must start with a letter and contain only letters, numbers, hyphens, and underscores.
 func ValidateCronName(name string) error {
@@ -1009,6 +2437,15 @@ func ValidateCronName(name string) error {
 	return nil
 }
 
+// ValidateEnvVarName checks whether name is a valid environment variable
+// name, as used for cron, repo, and mission env keys.
+func ValidateEnvVarName(name string) error {
+	if !envVarNameRegex.MatchString(name) {
+		return stacktrace.NewError("environment variable name '%s' is invalid; must start with a letter or underscore and contain only letters, numbers, and underscores", name)
+	}
+	return nil
+}
+
 // ValidateCronSchedule checks whether a cron schedule expression is valid
 // and compatible with macOS launchd's StartCalendarInterval. Only simple
 // 5-field expressions with integer values or '*' are supported — no ranges,
@@ -1024,6 +2461,25 @@ func ValidateCronSchedule(schedule string) error {
 	return nil
 }
 
+// cronRunAtLocalLayouts are the runAt formats without a zone, read in local
+// time.
+var cronRunAtLocalLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04"}
+
+// ParseCronRunAt parses a one-shot cron's fire time: RFC3339, or
+// "YYYY-MM-DD HH:MM" in local time. launchd fires on whole minutes, so
+// seconds are dropped.
+func ParseCronRunAt(runAt string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, runAt); err == nil {
+		return t.Truncate(time.Minute), nil
+	}
+	for _, layout := range cronRunAtLocalLayouts {
+		if t, err := time.ParseInLocation(layout, runAt, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, stacktrace.NewError("invalid runAt '%s'; use RFC3339 (e.g., '2026-11-02T09:00:00-08:00') or 'YYYY-MM-DD HH:MM' in local time", runAt)
+}
+
 // ValidateGitRepoURL validates a git repository URL using full URL parsing.
 // Accepts both HTTPS (https://github.com/owner/repo) and SSH (git@github.com:owner/repo) formats.
 func ValidateGitRepoURL(repoURL string) error {
@@ -1168,3 +2624,75 @@ func ValidateAndPopulateDefaults(cfg *AgencConfig) error {
 
 	return nil
 }
+
+// DefaultMaxConcurrentRequests is how many API requests the server handles at
+// once when rateLimit.maxConcurrentRequests is unset.
+const DefaultMaxConcurrentRequests = 64
+
+// DefaultEndpointRateLimits apply unless rateLimit.endpoints overrides them.
+// Mission creation is limited by default: each mission starts a wrapper, a
+// Claude process, and a workspace copy, so a script stuck in a loop can pile
+// them up faster than they can be stopped and cleaned up.
+var DefaultEndpointRateLimits = map[string]EndpointRateLimit{
+	"POST /missions": {PerMinute: 60, Burst: 20},
+}
+
+// RateLimitConfig throttles the server API. Requests over a limit get a 429
+// response with a Retry-After header.
+type RateLimitConfig struct {
+	// MaxConcurrentRequests caps how many requests the server handles at
+	// once; defaults to DefaultMaxConcurrentRequests. Streaming requests
+	// (follow=true) and health checks don't count against it.
+	MaxConcurrentRequests int `yaml:"maxConcurrentRequests,omitempty"`
+	// Endpoints limits the request rate per route, keyed by the route's
+	// "METHOD /path" pattern as registered by the server (e.g.
+	// "POST /missions" or "POST /missions/{id}/stop"). Entries replace the
+	// defaults for the same route; a perMinute of 0 removes the limit.
+	Endpoints map[string]EndpointRateLimit `yaml:"endpoints,omitempty"`
+}
+
+// EndpointRateLimit is a token-bucket limit on one route.
+type EndpointRateLimit struct {
+	// PerMinute is the sustained number of requests allowed per minute.
+	PerMinute int `yaml:"perMinute"`
+	// Burst is how many requests may arrive at once before the per-minute
+	// rate applies; defaults to PerMinute.
+	Burst int `yaml:"burst,omitempty"`
+}
+
+// GetMaxConcurrentRequests returns the concurrent request cap, defaulting to
+// DefaultMaxConcurrentRequests.
+func (c *AgencConfig) GetMaxConcurrentRequests() int {
+	if c.RateLimit == nil || c.RateLimit.MaxConcurrentRequests == 0 {
+		return DefaultMaxConcurrentRequests
+	}
+	return c.RateLimit.MaxConcurrentRequests
+}
+
+// GetEndpointRateLimit returns the rate limit for a route pattern, applying
+// the defaults. ok is false when the route is not limited.
+func (c *AgencConfig) GetEndpointRateLimit(pattern string) (limit EndpointRateLimit, ok bool) {
+	limit, ok = DefaultEndpointRateLimits[pattern]
+	if c.RateLimit != nil {
+		if configured, found := c.RateLimit.Endpoints[pattern]; found {
+			limit, ok = configured, true
+		}
+	}
+	if !ok || limit.PerMinute <= 0 {
+		return EndpointRateLimit{}, false
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = limit.PerMinute
+	}
+	return limit, true
+}
+
+// ValidateRateLimitPattern checks that an endpoint key has the
+// "METHOD /path" shape of a server route pattern.
+func ValidateRateLimitPattern(pattern string) error {
+	method, path, found := strings.Cut(pattern, " ")
+	if !found || method == "" || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+		return stacktrace.NewError("invalid endpoint '%s'; expected a route pattern like 'POST /missions'", pattern)
+	}
+	return nil
+}
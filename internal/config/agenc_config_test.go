@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
 )
@@ -89,6 +90,65 @@ sleepMode:
 	}
 }
 
+func TestValidateQuietHours(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		errSubstr string
+	}{
+		{name: "no quietHours section", yaml: "{}"},
+		{name: "overnight window", yaml: "quietHours:\n  start: \"23:00\"\n  end: \"07:00\"\n"},
+		{name: "invalid start", yaml: "quietHours:\n  start: \"25:00\"\n  end: \"07:00\"\n", errSubstr: "hour out of range"},
+		{name: "start equals end", yaml: "quietHours:\n  start: \"07:00\"\n  end: \"07:00\"\n", errSubstr: "start and end must differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeConfigYAML(t, tmpDir, tt.yaml)
+
+			_, _, err := ReadAgencConfig(tmpDir)
+			if tt.errSubstr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errSubstr) {
+				t.Fatalf("expected error containing %q, got: %v", tt.errSubstr, err)
+			}
+		})
+	}
+}
+
+func TestQuietHoursIsActive(t *testing.T) {
+	quietHours := &QuietHoursConfig{Start: "23:00", End: "07:00"}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 14, hour, minute, 0, 0, time.Local)
+	}
+
+	for _, tc := range []struct {
+		now  time.Time
+		want bool
+	}{
+		{at(22, 59), false},
+		{at(23, 0), true},
+		{at(3, 30), true},
+		{at(6, 59), true},
+		{at(7, 0), false},
+		{at(12, 0), false},
+	} {
+		if got := quietHours.IsActive(tc.now); got != tc.want {
+			t.Errorf("IsActive(%s) = %v, want %v", tc.now.Format("15:04"), got, tc.want)
+		}
+	}
+
+	var unset *QuietHoursConfig
+	if unset.IsActive(at(3, 30)) {
+		t.Error("expected nil quiet hours to never be active")
+	}
+}
+
 func TestReadWriteAgencConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	configDirpath := filepath.Join(tmpDir, ConfigDirname)
@@ -180,6 +240,494 @@ repoConfig:
 	}
 }
 
+func TestReadAgencConfig_InvalidWorkspaceMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/repo:
+    workspaceMode: symlink
+`)
+
+	_, _, err := ReadAgencConfig(tmpDir)
+	if err == nil {
+		t.Fatal("expected error for invalid workspaceMode, got nil")
+	}
+}
+
+func TestReadAgencConfig_MultiUser(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+multiUser:
+  tmuxSocket: /srv/agenc/tmux.sock
+  group: agenc
+  users: [alice, bob]
+`)
+
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if !cfg.IsMultiUserEnabled() || cfg.GetTmuxSocket() != "/srv/agenc/tmux.sock" {
+		t.Errorf("expected multi-user mode on the shared socket, got %+v", cfg.MultiUser)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+multiUser:
+  tmuxSocket: relative/tmux.sock
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for a relative tmuxSocket, got nil")
+	}
+}
+
+func TestReadAgencConfig_Sandbox(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+sandbox:
+  runtime: podman
+repoConfig:
+  github.com/owner/untrusted:
+    isolation: container
+`)
+
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if !cfg.IsContainerIsolated("github.com/owner/untrusted") || cfg.IsContainerIsolated("github.com/owner/other") {
+		t.Error("expected only github.com/owner/untrusted to be container-isolated")
+	}
+	if cfg.GetSandboxRuntime() != SandboxRuntimePodman || cfg.GetSandboxImage() != DefaultSandboxImage {
+		t.Errorf("expected podman with the default image, got %q and %q", cfg.GetSandboxRuntime(), cfg.GetSandboxImage())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/untrusted:
+    isolation: vm
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an unknown isolation mode, got nil")
+	}
+}
+
+func TestReadAgencConfig_TerminalBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig: {}
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetTerminalBackend() != TerminalBackendTmux {
+		t.Errorf("expected tmux by default, got %q", cfg.GetTerminalBackend())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+terminalBackend: wezterm
+wsl:
+  windowsClaude: true
+`)
+	cfg, _, err = ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetTerminalBackend() != TerminalBackendWezterm || !cfg.WSL.WindowsClaude {
+		t.Errorf("expected wezterm with windowsClaude, got %q and %+v", cfg.GetTerminalBackend(), cfg.WSL)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+terminalBackend: kitty
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an unknown terminal backend, got nil")
+	}
+}
+
+func TestReadAgencConfig_CleanupRemoteOnDelete(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig: {}
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetCleanupRemoteOnDelete() != CleanupRemoteNever {
+		t.Errorf("expected never by default, got %q", cfg.GetCleanupRemoteOnDelete())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+cleanupRemoteOnDelete: ask
+`)
+	cfg, _, err = ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetCleanupRemoteOnDelete() != CleanupRemoteAsk {
+		t.Errorf("expected ask, got %q", cfg.GetCleanupRemoteOnDelete())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+cleanupRemoteOnDelete: sometimes
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an unknown cleanup mode, got nil")
+	}
+}
+
+func TestReadAgencConfig_ReleaseChannel(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig: {}
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetReleaseChannel() != ReleaseChannelStable {
+		t.Errorf("expected stable by default, got %q", cfg.GetReleaseChannel())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+releaseChannel: beta
+`)
+	cfg, _, err = ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetReleaseChannel() != ReleaseChannelBeta {
+		t.Errorf("expected beta, got %q", cfg.GetReleaseChannel())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+releaseChannel: nightly
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an unknown release channel, got nil")
+	}
+}
+
+func TestReadAgencConfig_DailyDigest(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+dailyDigest:
+  template: digest.tmpl
+  notify:
+    - webhook:https://example.com/hooks/agenc
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if got := cfg.DailyDigest.GetTime(); got != DefaultDailyDigestTime {
+		t.Errorf("expected the default time, got %q", got)
+	}
+	if got, want := cfg.DailyDigest.GetTemplateFilepath(tmpDir), filepath.Join(tmpDir, ConfigDirname, "digest.tmpl"); got != want {
+		t.Errorf("expected template %q, got %q", want, got)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+dailyDigest:
+  time: "7am"
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for a malformed time, got nil")
+	}
+
+	writeConfigYAML(t, tmpDir, `
+dailyDigest:
+  notify:
+    - email:me@example.com
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an email target without notifications.email, got nil")
+	}
+}
+
+func TestReadAgencConfig_CronNotify(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+notifications:
+  slack:
+    token: secret://SLACK_BOT_TOKEN
+crons:
+  nightly:
+    schedule: "0 3 * * *"
+    prompt: Run the report
+    notify: ["slack:#reports"]
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if got := cfg.Crons["nightly"].Notify; len(got) != 1 || got[0] != "slack:#reports" {
+		t.Errorf("expected the slack target, got %v", got)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+notifications:
+  slack:
+    token: secret://SLACK_BOT_TOKEN
+crons:
+  nightly:
+    schedule: "0 3 * * *"
+    prompt: Run the report
+    notify: ["email:me@example.com"]
+`)
+	_, _, err = ReadAgencConfig(tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "needs notifications.email to be configured") {
+		t.Fatalf("expected an error for an unconfigured notifier, got %v", err)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+crons:
+  nightly:
+    schedule: "0 3 * * *"
+    prompt: Run the report
+    notify: ["pager:oncall"]
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an unknown notify target kind, got nil")
+	}
+}
+
+func TestReadAgencConfig_GitHubWebhook(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+githubWebhook:
+  secret: secret://GITHUB_WEBHOOK_SECRET
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GitHubWebhook == nil || cfg.GitHubWebhook.Secret != "secret://GITHUB_WEBHOOK_SECRET" {
+		t.Errorf("expected the webhook secret reference, got %+v", cfg.GitHubWebhook)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+githubWebhook:
+  secret: ""
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an empty webhook secret, got nil")
+	}
+}
+
+func TestReadAgencConfig_MissionSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig: {}
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetMissionSummaryTrigger() != MissionSummaryTriggerPrompts || cfg.GetMissionSummaryEveryPrompts() != DefaultMissionSummaryEveryPrompts {
+		t.Errorf("expected prompts trigger every %d prompts by default, got %q every %d",
+			DefaultMissionSummaryEveryPrompts, cfg.GetMissionSummaryTrigger(), cfg.GetMissionSummaryEveryPrompts())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+missionSummary:
+  trigger: idle
+  model: claude-sonnet-4-5
+`)
+	cfg, _, err = ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetMissionSummaryTrigger() != MissionSummaryTriggerIdle || cfg.GetMissionSummaryModel() != "claude-sonnet-4-5" {
+		t.Errorf("expected idle trigger with claude-sonnet-4-5, got %q with %q", cfg.GetMissionSummaryTrigger(), cfg.GetMissionSummaryModel())
+	}
+
+	writeConfigYAML(t, tmpDir, `
+missionSummary:
+  trigger: hourly
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an unknown summary trigger, got nil")
+	}
+}
+
+func TestReadAgencConfig_RateLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig: {}
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetMaxConcurrentRequests() != DefaultMaxConcurrentRequests {
+		t.Errorf("expected %d concurrent requests by default, got %d", DefaultMaxConcurrentRequests, cfg.GetMaxConcurrentRequests())
+	}
+	if limit, ok := cfg.GetEndpointRateLimit("POST /missions"); !ok || limit != DefaultEndpointRateLimits["POST /missions"] {
+		t.Errorf("expected the default mission creation limit, got %+v (ok=%v)", limit, ok)
+	}
+	if _, ok := cfg.GetEndpointRateLimit("GET /missions"); ok {
+		t.Error("expected routes without a limit to be unlimited")
+	}
+
+	writeConfigYAML(t, tmpDir, `
+rateLimit:
+  maxConcurrentRequests: 8
+  endpoints:
+    "POST /missions":
+      perMinute: 0
+    "POST /missions/{id}/stop":
+      perMinute: 10
+`)
+	cfg, _, err = ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if cfg.GetMaxConcurrentRequests() != 8 {
+		t.Errorf("expected 8 concurrent requests, got %d", cfg.GetMaxConcurrentRequests())
+	}
+	if _, ok := cfg.GetEndpointRateLimit("POST /missions"); ok {
+		t.Error("expected perMinute 0 to remove the default limit")
+	}
+	if limit, ok := cfg.GetEndpointRateLimit("POST /missions/{id}/stop"); !ok || limit.Burst != 10 {
+		t.Errorf("expected burst to default to perMinute, got %+v (ok=%v)", limit, ok)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+rateLimit:
+  endpoints:
+    "/missions":
+      perMinute: 10
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for an endpoint without a method, got nil")
+	}
+}
+
+func TestRepoConfig_GetWorkspaceMode(t *testing.T) {
+	cfg := &AgencConfig{
+		RepoConfigs: map[string]RepoConfig{
+			"github.com/owner/repo1": {WorkspaceMode: WorkspaceModeWorktree},
+			"github.com/owner/repo2": {},
+		},
+	}
+
+	if got := cfg.GetWorkspaceMode("github.com/owner/repo1"); got != WorkspaceModeWorktree {
+		t.Errorf("expected '%s', got '%s'", WorkspaceModeWorktree, got)
+	}
+	if got := cfg.GetWorkspaceMode("github.com/owner/repo2"); got != WorkspaceModeCopy {
+		t.Errorf("expected default '%s', got '%s'", WorkspaceModeCopy, got)
+	}
+	if got := cfg.GetWorkspaceMode("github.com/owner/nonexistent"); got != WorkspaceModeCopy {
+		t.Errorf("expected default '%s' for nonexistent repo, got '%s'", WorkspaceModeCopy, got)
+	}
+}
+
+func TestRepoConfig_GetAutoBranchTemplate(t *testing.T) {
+	cfg := &AgencConfig{
+		RepoConfigs: map[string]RepoConfig{
+			"github.com/owner/repo1": {AutoBranch: true},
+			"github.com/owner/repo2": {AutoBranch: true, AutoBranchTemplate: "feature/{slug}-{shortID}"},
+			"github.com/owner/repo3": {AutoBranchTemplate: "feature/{shortID}"},
+		},
+	}
+
+	if got := cfg.GetAutoBranchTemplate("github.com/owner/repo1"); got != DefaultAutoBranchTemplate {
+		t.Errorf("expected default '%s', got '%s'", DefaultAutoBranchTemplate, got)
+	}
+	if got := cfg.GetAutoBranchTemplate("github.com/owner/repo2"); got != "feature/{slug}-{shortID}" {
+		t.Errorf("expected custom template, got '%s'", got)
+	}
+	if got := cfg.GetAutoBranchTemplate("github.com/owner/repo3"); got != "" {
+		t.Errorf("expected empty template when autoBranch is off, got '%s'", got)
+	}
+	if got := cfg.GetAutoBranchTemplate("github.com/owner/nonexistent"); got != "" {
+		t.Errorf("expected empty template for nonexistent repo, got '%s'", got)
+	}
+}
+
+func TestValidateAutoBranchTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  bool
+	}{
+		{DefaultAutoBranchTemplate, false},
+		{"agenc/{missionID}", false},
+		{"{slug}/{shortID}", false},
+		{"agenc/{slug}", true},
+		{"agenc/{shortID}-{title}", true},
+		{"agenc/{shortID", true},
+	}
+	for _, tt := range tests {
+		err := ValidateAutoBranchTemplate(tt.template)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateAutoBranchTemplate(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateProjectName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"billing", false},
+		{"q3.launch-v2_final", false},
+		{"", true},
+		{"2026-launch", true},
+		{"has space", true},
+		{"a/b", true},
+		{strings.Repeat("a", 65), true},
+	}
+	for _, tt := range tests {
+		err := ValidateProjectName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateProjectName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateDependencyCachePath(t *testing.T) {
+	valid := []string{"node_modules", ".venv", "web/node_modules", "./vendor"}
+	for _, p := range valid {
+		if err := ValidateDependencyCachePath(p); err != nil {
+			t.Errorf("ValidateDependencyCachePath(%q) unexpected error: %v", p, err)
+		}
+	}
+	invalid := []string{"", ".", "/abs/path", "..", "../outside", "a/../../b", ".git", ".git/hooks"}
+	for _, p := range invalid {
+		if err := ValidateDependencyCachePath(p); err == nil {
+			t.Errorf("ValidateDependencyCachePath(%q) expected error, got nil", p)
+		}
+	}
+}
+
+func TestValidateMcpServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  McpServerConfig
+		wantErr bool
+	}{
+		{"stdio-default", McpServerConfig{Command: "npx", Args: []string{"-y", "@acme/mcp"}, Env: map[string]string{"TOKEN": "${ACME_TOKEN}"}}, false},
+		{"stdio", McpServerConfig{Type: McpServerTypeStdio, Command: "acme-mcp"}, false},
+		{"remote", McpServerConfig{Type: McpServerTypeHTTP, URL: "https://mcp.acme.dev", Headers: map[string]string{"Authorization": "Bearer x"}}, false},
+		{"sse", McpServerConfig{Type: McpServerTypeSSE, URL: "https://mcp.acme.dev/sse"}, false},
+		{"no-command", McpServerConfig{Args: []string{"serve"}}, true},
+		{"no-url", McpServerConfig{Type: McpServerTypeHTTP}, true},
+		{"mixed", McpServerConfig{Command: "acme-mcp", URL: "https://mcp.acme.dev"}, true},
+		{"remote-with-env", McpServerConfig{Type: McpServerTypeSSE, URL: "https://mcp.acme.dev", Env: map[string]string{"A": "b"}}, true},
+		{"bad-type", McpServerConfig{Type: "websocket", URL: "wss://mcp.acme.dev"}, true},
+		{"bad name", McpServerConfig{Command: "acme-mcp"}, true},
+	}
+	for _, tt := range tests {
+		err := ValidateMcpServer(tt.name, tt.server)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateMcpServer(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
 func TestWriteReadPreservesComments(t *testing.T) {
 	tmpDir := t.TempDir()
 	configDirpath := filepath.Join(tmpDir, ConfigDirname)
@@ -483,6 +1031,12 @@ func TestIsCanonicalRepoName(t *testing.T) {
 	if !IsCanonicalRepoName("github.com/owner/repo") {
 		t.Error("expected github.com/owner/repo to be canonical")
 	}
+	if !IsCanonicalRepoName("git.internal/team/svc") {
+		t.Error("expected git.internal/team/svc to be canonical")
+	}
+	if IsCanonicalRepoName("git@git.internal:team/svc") {
+		t.Error("expected an SSH URL to not be canonical")
+	}
 	if IsCanonicalRepoName("owner/repo") {
 		t.Error("expected owner/repo to not be canonical")
 	}
@@ -491,6 +1045,31 @@ func TestIsCanonicalRepoName(t *testing.T) {
 	}
 }
 
+func TestValidateCronDependencies(t *testing.T) {
+	cron := func(after string) CronConfig {
+		return CronConfig{Schedule: "0 9 * * *", Prompt: "p", After: after}
+	}
+	tests := []struct {
+		name    string
+		crons   map[string]CronConfig
+		wantErr bool
+	}{
+		{"no dependencies", map[string]CronConfig{"a": cron(""), "b": cron("")}, false},
+		{"chain", map[string]CronConfig{"fetch": cron(""), "summarize": cron("fetch"), "publish": cron("summarize")}, false},
+		{"self", map[string]CronConfig{"a": cron("a")}, true},
+		{"unknown upstream", map[string]CronConfig{"a": cron("missing")}, true},
+		{"cycle", map[string]CronConfig{"a": cron("c"), "b": cron("a"), "c": cron("b")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCronDependencies(tt.crons)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 // --- Palette commands tests ---
 
 func writeConfigYAML(t *testing.T, tmpDir string, content string) {
@@ -830,6 +1409,45 @@ paletteCommands:
 	}
 }
 
+func TestPaletteCommands_InputPlaceholders(t *testing.T) {
+	cmd := ResolvedPaletteCommand{
+		Command: "agenc mission new {{input:Repo}} --prompt {{ input:Prompt }} && echo {{input:Repo}}",
+	}
+
+	labels := cmd.InputLabels()
+	if len(labels) != 2 || labels[0] != "Repo" || labels[1] != "Prompt" {
+		t.Fatalf("expected [Repo Prompt], got %v", labels)
+	}
+
+	got := cmd.FillInputs(map[string]string{"Repo": "owner/repo", "Prompt": "fix the user's bug; rm -rf /"})
+	want := `agenc mission new 'owner/repo' --prompt 'fix the user'"'"'s bug; rm -rf /' && echo 'owner/repo'`
+	if got != want {
+		t.Errorf("FillInputs:\n got %s\nwant %s", got, want)
+	}
+
+	if (ResolvedPaletteCommand{Command: "agenc mission new"}).HasInputs() {
+		t.Error("expected a command without placeholders to have no inputs")
+	}
+}
+
+func TestPaletteCommands_InputPlaceholderWithoutLabel(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+paletteCommands:
+  custom1:
+    title: "My Command"
+    command: "agenc mission new {{input:}}"
+`)
+
+	_, _, err := ReadAgencConfig(tmpDir)
+	if err == nil {
+		t.Fatal("expected error for an unlabeled placeholder, got nil")
+	}
+	if !strings.Contains(err.Error(), "no label") {
+		t.Errorf("expected error mentioning the missing label, got: %v", err)
+	}
+}
+
 func TestPaletteCommands_CustomMissingCommand(t *testing.T) {
 	tmpDir := t.TempDir()
 	writeConfigYAML(t, tmpDir, `
@@ -1210,6 +1828,42 @@ func TestValidateCronSchedule(t *testing.T) {
 	}
 }
 
+func TestParseCronRunAt(t *testing.T) {
+	got, err := ParseCronRunAt("2026-11-02T09:45:30Z")
+	if err != nil || !got.Equal(time.Date(2026, 11, 2, 9, 45, 0, 0, time.UTC)) {
+		t.Errorf("RFC3339: got %v, %v", got, err)
+	}
+	got, err = ParseCronRunAt("2026-11-02 09:45")
+	if err != nil || !got.Equal(time.Date(2026, 11, 2, 9, 45, 0, 0, time.Local)) {
+		t.Errorf("local time: got %v, %v", got, err)
+	}
+	if _, err := ParseCronRunAt("tomorrow 9am"); err == nil {
+		t.Error("expected relative times to be rejected in config")
+	}
+}
+
+func TestValidateCronConfigs_RunAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		cronCfg CronConfig
+		wantErr bool
+	}{
+		{"runAt only", CronConfig{RunAt: "2026-11-02 09:45", Prompt: "p"}, false},
+		{"both schedule and runAt", CronConfig{Schedule: "0 9 * * *", RunAt: "2026-11-02 09:45", Prompt: "p"}, true},
+		{"neither schedule nor runAt", CronConfig{Prompt: "p"}, true},
+		{"invalid runAt", CronConfig{RunAt: "soon", Prompt: "p"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &AgencConfig{Crons: map[string]CronConfig{"job": tt.cronCfg}}
+			err := validateCronConfigs(cfg, "config.yml")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCronConfigs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateAndPopulateDefaults(t *testing.T) {
 	t.Run("sanitizes cron prompt with control characters", func(t *testing.T) {
 		cfg := &AgencConfig{
@@ -1887,3 +2541,279 @@ func TestValidateSessionTitleMaxWords(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRetentionDays(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "1d", want: 24 * time.Hour},
+		{value: "0d", wantErr: true},
+		{value: "-5d", wantErr: true},
+		{value: "30", wantErr: true},
+		{value: "12h", wantErr: true},
+		{value: "d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRetentionDays(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseRetentionDays(%q) = %v, expected error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRetentionDays(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseSuspendAfterIdle(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "4h", want: 4 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "0s", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "4", wantErr: true},
+		{value: "2d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSuspendAfterIdle(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseSuspendAfterIdle(%q) = %v, expected error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSuspendAfterIdle(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestReadAgencConfig_InvalidSuspendAfterIdle(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, "suspendAfterIdle: soon\n")
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Error("expected error for invalid suspendAfterIdle")
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "1024", want: 1024},
+		{value: "500MB", want: 500 << 20},
+		{value: "5GB", want: 5 << 30},
+		{value: "2GiB", want: 2 << 30},
+		{value: "1.5g", want: 3 << 29},
+		{value: "1 TB", want: 1 << 40},
+		{value: "", wantErr: true},
+		{value: "0GB", wantErr: true},
+		{value: "-1GB", wantErr: true},
+		{value: "GB", wantErr: true},
+		{value: "5PB", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseByteSize(%q) = %v, expected error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int64]string{
+		512:           "512 B",
+		1536:          "1.5 KiB",
+		5 << 30:       "5.0 GiB",
+		3 << 29:       "1.5 GiB",
+		(1 << 40) + 1: "1.0 TiB",
+	}
+	for sizeBytes, want := range tests {
+		if got := FormatByteSize(sizeBytes); got != want {
+			t.Errorf("FormatByteSize(%d) = %q, want %q", sizeBytes, got, want)
+		}
+	}
+}
+
+func TestReadAgencConfig_MissionSizeLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+missionSizeLimit:
+  maxSize: 2GB
+  cleanupHook: rm -rf node_modules
+  blockArchive: true
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if got := cfg.GetMissionMaxSizeBytes(); got != 2<<30 {
+		t.Errorf("expected a 2 GiB limit, got %d", got)
+	}
+	if !cfg.MissionSizeLimit.BlockArchive || cfg.MissionSizeLimit.CleanupHook != "rm -rf node_modules" {
+		t.Errorf("unexpected missionSizeLimit: %+v", cfg.MissionSizeLimit)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+missionSizeLimit:
+  maxSize: huge
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Fatal("expected error for a malformed maxSize, got nil")
+	}
+
+	if got := (&AgencConfig{}).GetMissionMaxSizeBytes(); got != 0 {
+		t.Errorf("expected no limit by default, got %d", got)
+	}
+}
+
+func TestResolveClaudeMdAppend(t *testing.T) {
+	agencDirpath := t.TempDir()
+	configDirpath := GetConfigDirpath(agencDirpath)
+	if err := os.MkdirAll(filepath.Join(configDirpath, "repo-notes"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	relFilepath := filepath.Join(configDirpath, "repo-notes", "owner-repo.md")
+	if err := os.WriteFile(relFilepath, []byte("\nFrom the config dir\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	absFilepath := filepath.Join(t.TempDir(), "instructions.md")
+	if err := os.WriteFile(absFilepath, []byte("From an absolute path"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"inline", "  Run make lint before committing.  ", "Run make lint before committing.", false},
+		{"inline multi-line mentioning a path", "See ./docs first.\nThen /explain.", "See ./docs first.\nThen /explain.", false},
+		{"relative path", "repo-notes/owner-repo.md", "From the config dir", false},
+		{"dot-relative path", "./repo-notes/owner-repo.md", "From the config dir", false},
+		{"absolute path", absFilepath, "From an absolute path", false},
+		{"missing file", "./missing.md", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveClaudeMdAppend(agencDirpath, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveClaudeMdAppend(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveClaudeMdAppend(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadAgencConfig_NetworkPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/untrusted:
+    networkPolicy:
+      allowedHosts:
+        - github.com
+        - registry.npmjs.org
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	rc, _ := cfg.GetRepoConfig("github.com/owner/untrusted")
+	policy := rc.NetworkPolicy
+	for host, want := range map[string]bool{
+		"github.com":          true,
+		"API.GitHub.com:443":  true,
+		"api.github.com:443":  true,
+		"registry.npmjs.org":  true,
+		"api.anthropic.com":   true,
+		"notgithub.com":       false,
+		"github.com.evil.com": false,
+		"example.com:80":      false,
+	} {
+		if got := policy.AllowsHost(host); got != want {
+			t.Errorf("AllowsHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+	if !(&NetworkPolicyConfig{AllowedHosts: []string{"Internal.Example.com"}}).AllowsHost("internal.example.COM") {
+		t.Error("expected allowed hosts to match case-insensitively")
+	}
+	if !(*NetworkPolicyConfig)(nil).AllowsHost("example.com") {
+		t.Error("expected a nil policy to allow everything")
+	}
+
+	for _, bad := range []string{"https://github.com", "github.com:443", "*.github.com", "GitHub.com"} {
+		writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/untrusted:
+    networkPolicy:
+      allowedHosts: ["`+bad+`"]
+`)
+		if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+			t.Errorf("expected error for allowed host %q, got nil", bad)
+		}
+	}
+
+	writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/untrusted:
+    isolation: container
+    networkPolicy:
+      allowedHosts: [github.com]
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Error("expected error combining networkPolicy with container isolation, got nil")
+	}
+}
+
+func TestReadAgencConfig_RepoEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/api:
+    env:
+      DATABASE_URL: postgres://localhost/api_dev
+      NPM_TOKEN: secret://npm-token
+`)
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	rc, _ := cfg.GetRepoConfig("github.com/owner/api")
+	if rc.Env["DATABASE_URL"] != "postgres://localhost/api_dev" || rc.Env["NPM_TOKEN"] != "secret://npm-token" {
+		t.Errorf("unexpected repo env %v", rc.Env)
+	}
+
+	writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/api:
+    env:
+      1BAD: value
+`)
+	if _, _, err := ReadAgencConfig(tmpDir); err == nil {
+		t.Error("expected error for an invalid env var name, got nil")
+	}
+}
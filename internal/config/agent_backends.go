@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Agent backends a mission can run. Any other name refers to an
+// agentBackends entry in config.yml.
+const (
+	// AgentBackendClaude runs Claude Code (the default).
+	AgentBackendClaude = "claude"
+	// AgentBackendCodex runs the OpenAI Codex CLI. Its commands can be
+	// overridden with an agentBackends entry named "codex".
+	AgentBackendCodex = "codex"
+)
+
+// AgentBackendPromptPlaceholder is replaced with the prompt in an agent
+// backend's command templates. An argument that is exactly the placeholder is
+// dropped when there is no prompt.
+const AgentBackendPromptPlaceholder = "{{prompt}}"
+
+// MissionBackendFilename holds the backend a mission was created with.
+const MissionBackendFilename = "backend"
+
+// AgentBackendConfig defines a command-template agent backend: the argv the
+// wrapper runs, in the mission's working directory, for each kind of spawn.
+type AgentBackendConfig struct {
+	// Interactive starts a new conversation in the mission's tmux pane.
+	Interactive []string `yaml:"interactive"`
+	// Headless runs a prompt to completion without a terminal, for headless
+	// missions and crons. Backends without one can't run headless.
+	Headless []string `yaml:"headless,omitempty"`
+	// Resume continues the last conversation when a mission is resumed or
+	// reloaded. Without one, resumes start a new conversation.
+	Resume []string `yaml:"resume,omitempty"`
+}
+
+// builtinAgentBackends are the command-template backends available without
+// any config.
+var builtinAgentBackends = map[string]AgentBackendConfig{
+	AgentBackendCodex: {
+		Interactive: []string{"codex", AgentBackendPromptPlaceholder},
+		Headless:    []string{"codex", "exec", AgentBackendPromptPlaceholder},
+		Resume:      []string{"codex", "resume", "--last"},
+	},
+}
+
+var agentBackendNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// ValidateAgentBackendName returns an error if name can't name an
+// agentBackends entry.
+func ValidateAgentBackendName(name string) error {
+	if !agentBackendNameRegex.MatchString(name) {
+		return stacktrace.NewError("invalid agent backend name '%s'; must start with a letter and contain only letters, digits, hyphens, and underscores", name)
+	}
+	if name == AgentBackendClaude {
+		return stacktrace.NewError("agent backend '%s' is built in and can't be redefined", AgentBackendClaude)
+	}
+	return nil
+}
+
+// GetAgentBackendTemplate returns the command templates for a non-Claude
+// backend. Entries in agentBackends take precedence over the built-in ones.
+func (c *AgencConfig) GetAgentBackendTemplate(name string) (AgentBackendConfig, bool) {
+	if backendCfg, ok := c.AgentBackends[name]; ok {
+		return backendCfg, true
+	}
+	backendCfg, ok := builtinAgentBackends[name]
+	return backendCfg, ok
+}
+
+// ValidateAgentBackend returns an error if name is neither Claude nor a
+// known command-template backend.
+func (c *AgencConfig) ValidateAgentBackend(name string) error {
+	if name == AgentBackendClaude {
+		return nil
+	}
+	if _, ok := c.GetAgentBackendTemplate(name); !ok {
+		return stacktrace.NewError("unknown agent backend '%s'; available: %s", name, strings.Join(c.AgentBackendNames(), ", "))
+	}
+	return nil
+}
+
+// AgentBackendNames returns every backend a mission can use, sorted.
+func (c *AgencConfig) AgentBackendNames() []string {
+	names := []string{AgentBackendClaude}
+	for name := range builtinAgentBackends {
+		names = append(names, name)
+	}
+	for name := range c.AgentBackends {
+		if _, isBuiltin := builtinAgentBackends[name]; !isBuiltin {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetBackend returns the agent backend for new missions in the given repo,
+// defaulting to AgentBackendClaude.
+func (c *AgencConfig) GetBackend(repoName string) string {
+	if rc, ok := c.RepoConfigs[repoName]; ok && rc.Backend != "" {
+		return rc.Backend
+	}
+	return AgentBackendClaude
+}
+
+// validateAgentBackends checks the agentBackends definitions and that every
+// repoConfig backend names a known backend.
+func validateAgentBackends(cfg *AgencConfig, configFilepath string) error {
+	for name, backendCfg := range cfg.AgentBackends {
+		if err := ValidateAgentBackendName(name); err != nil {
+			return stacktrace.Propagate(err, "invalid agentBackends entry in %s", configFilepath)
+		}
+		if len(backendCfg.Interactive) == 0 || backendCfg.Interactive[0] == "" {
+			return stacktrace.NewError("invalid agentBackends entry '%s' in %s: interactive must name a command", name, configFilepath)
+		}
+		for field, template := range map[string][]string{"headless": backendCfg.Headless, "resume": backendCfg.Resume} {
+			if len(template) > 0 && template[0] == "" {
+				return stacktrace.NewError("invalid agentBackends entry '%s' in %s: %s must name a command", name, configFilepath, field)
+			}
+		}
+	}
+	for repoName, rc := range cfg.RepoConfigs {
+		if rc.Backend == "" {
+			continue
+		}
+		if err := cfg.ValidateAgentBackend(rc.Backend); err != nil {
+			return stacktrace.Propagate(err, "invalid repoConfig for '%s' in %s", repoName, configFilepath)
+		}
+		if rc.Backend != AgentBackendClaude && rc.Isolation == IsolationContainer {
+			return stacktrace.NewError("invalid repoConfig for '%s' in %s: isolation '%s' is only supported with the '%s' backend", repoName, configFilepath, IsolationContainer, AgentBackendClaude)
+		}
+	}
+	return nil
+}
+
+// GetMissionBackendFilepath returns the path to the file holding the agent
+// backend a mission was created with via --backend.
+func GetMissionBackendFilepath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), MissionBackendFilename)
+}
+
+// ReadMissionBackend returns the backend a mission was created with, or ""
+// when it uses its repo's backend.
+func ReadMissionBackend(agencDirpath string, missionID string) string {
+	data, err := os.ReadFile(GetMissionBackendFilepath(agencDirpath, missionID))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
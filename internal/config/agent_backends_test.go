@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAgentBackends_Resolution(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `
+repoConfig:
+  github.com/owner/repo:
+    backend: aider
+agentBackends:
+  aider:
+    interactive: [aider, "{{prompt}}"]
+  codex:
+    interactive: [codex, --full-auto, "{{prompt}}"]
+`)
+
+	cfg, _, err := ReadAgencConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	if got := cfg.GetBackend("github.com/owner/repo"); got != "aider" {
+		t.Errorf("expected the repo's backend, got %q", got)
+	}
+	if got := cfg.GetBackend("github.com/owner/other"); got != AgentBackendClaude {
+		t.Errorf("expected the claude default, got %q", got)
+	}
+	if codex, _ := cfg.GetAgentBackendTemplate(AgentBackendCodex); codex.Interactive[1] != "--full-auto" {
+		t.Errorf("expected the config entry to override the built-in codex backend, got %v", codex.Interactive)
+	}
+	if got, want := cfg.AgentBackendNames(), []string{"aider", "claude", "codex"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected backends %v, got %v", want, got)
+	}
+	if err := cfg.ValidateAgentBackend("gemini"); err == nil || !strings.Contains(err.Error(), "aider, claude, codex") {
+		t.Errorf("expected an unknown-backend error listing the backends, got %v", err)
+	}
+}
+
+func TestAgentBackends_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		yaml       string
+		wantSubstr string
+	}{
+		{
+			name: "redefines claude",
+			yaml: `
+agentBackends:
+  claude:
+    interactive: [claude]
+`,
+			wantSubstr: "built in",
+		},
+		{
+			name: "empty interactive command",
+			yaml: `
+agentBackends:
+  aider:
+    interactive: []
+`,
+			wantSubstr: "interactive must name a command",
+		},
+		{
+			name: "unknown repo backend",
+			yaml: `
+repoConfig:
+  github.com/owner/repo:
+    backend: gemini
+`,
+			wantSubstr: "unknown agent backend 'gemini'",
+		},
+		{
+			name: "container isolation",
+			yaml: `
+repoConfig:
+  github.com/owner/repo:
+    backend: codex
+    isolation: container
+`,
+			wantSubstr: "only supported with the 'claude' backend",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeConfigYAML(t, tmpDir, tt.yaml)
+			_, _, err := ReadAgencConfig(tmpDir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantSubstr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantSubstr, err)
+			}
+		})
+	}
+}
+
+func TestReadMissionBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+	if got := ReadMissionBackend(tmpDir, "mission-id"); got != "" {
+		t.Errorf("expected no backend without the file, got %q", got)
+	}
+	if err := os.MkdirAll(GetMissionDirpath(tmpDir, "mission-id"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetMissionBackendFilepath(tmpDir, "mission-id"), []byte("codex\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := ReadMissionBackend(tmpDir, "mission-id"); got != "codex" {
+		t.Errorf("expected codex, got %q", got)
+	}
+}
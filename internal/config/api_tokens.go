@@ -0,0 +1,202 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// apiTokenPrefix marks AgenC API tokens so they are recognizable in scripts
+// and secret scanners.
+const apiTokenPrefix = "agenc_"
+
+// apiTokenSecretBytes is the number of random bytes in a token's secret part.
+const apiTokenSecretBytes = 32
+
+var apiTokenNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// APIToken is a stored bearer token for the server's TCP listener. Only the
+// SHA-256 hash of the token is persisted; the plaintext is shown once at
+// creation time.
+type APIToken struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValidateAPITokenName checks whether a token name is valid. Token names
+// follow the same rules as cron names.
+func ValidateAPITokenName(name string) error {
+	if name == "" {
+		return stacktrace.NewError("token name cannot be empty")
+	}
+	if len(name) > 64 {
+		return stacktrace.NewError("token name too long (max 64 characters)")
+	}
+	if !apiTokenNameRegex.MatchString(name) {
+		return stacktrace.NewError("token name '%s' is invalid; must start with a letter and contain only letters, numbers, hyphens, and underscores", name)
+	}
+	return nil
+}
+
+// ReadAPITokens returns all stored API tokens. Returns an empty slice if the
+// token file does not exist.
+func ReadAPITokens(agencDirpath string) ([]APIToken, error) {
+	tokensFilepath := GetAPITokensFilepath(agencDirpath)
+	data, err := os.ReadFile(tokensFilepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []APIToken{}, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to read API token file '%s'", tokensFilepath)
+	}
+
+	var tokens []APIToken
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse API token file '%s'", tokensFilepath)
+	}
+	return tokens, nil
+}
+
+// writeAPITokens atomically replaces the token file with 600 permissions.
+func writeAPITokens(agencDirpath string, tokens []APIToken) error {
+	tokensFilepath := GetAPITokensFilepath(agencDirpath)
+	if err := os.MkdirAll(filepath.Dir(tokensFilepath), 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create server directory")
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to marshal API tokens")
+	}
+
+	tmpFilepath := tokensFilepath + ".tmp"
+	if err := os.WriteFile(tmpFilepath, append(data, '\n'), 0600); err != nil {
+		return stacktrace.Propagate(err, "failed to write API token file '%s'", tmpFilepath)
+	}
+	if err := os.Rename(tmpFilepath, tokensFilepath); err != nil {
+		_ = os.Remove(tmpFilepath)
+		return stacktrace.Propagate(err, "failed to replace API token file '%s'", tokensFilepath)
+	}
+	return nil
+}
+
+// CreateAPIToken generates a new token with the given name, stores its hash,
+// and returns the plaintext token along with the stored record. The plaintext
+// cannot be recovered later.
+func CreateAPIToken(agencDirpath string, name string) (string, *APIToken, error) {
+	if err := ValidateAPITokenName(name); err != nil {
+		return "", nil, err
+	}
+
+	tokens, err := ReadAPITokens(agencDirpath)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, t := range tokens {
+		if t.Name == name {
+			return "", nil, stacktrace.NewError("an API token named '%s' already exists", name)
+		}
+	}
+
+	secret := make([]byte, apiTokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, stacktrace.Propagate(err, "failed to generate API token")
+	}
+	plaintext := apiTokenPrefix + hex.EncodeToString(secret)
+
+	idBytes := make([]byte, 4)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", nil, stacktrace.Propagate(err, "failed to generate API token ID")
+	}
+
+	token := APIToken{
+		ID:        hex.EncodeToString(idBytes),
+		Name:      name,
+		Hash:      hashAPIToken(plaintext),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := writeAPITokens(agencDirpath, append(tokens, token)); err != nil {
+		return "", nil, err
+	}
+	return plaintext, &token, nil
+}
+
+// RevokeAPIToken deletes the token whose name or ID matches nameOrID and
+// returns the removed record.
+func RevokeAPIToken(agencDirpath string, nameOrID string) (*APIToken, error) {
+	tokens, err := ReadAPITokens(agencDirpath)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, t := range tokens {
+		if t.Name == nameOrID || t.ID == nameOrID {
+			remaining := append(tokens[:i:i], tokens[i+1:]...)
+			if err := writeAPITokens(agencDirpath, remaining); err != nil {
+				return nil, err
+			}
+			return &t, nil
+		}
+	}
+	return nil, stacktrace.NewError("no API token with name or ID '%s'", nameOrID)
+}
+
+// FindAPIToken returns the stored token matching the given plaintext, or nil
+// if none matches. Every stored hash is compared in constant time.
+func FindAPIToken(tokens []APIToken, plaintext string) *APIToken {
+	if !strings.HasPrefix(plaintext, apiTokenPrefix) {
+		return nil
+	}
+	hash := []byte(hashAPIToken(plaintext))
+
+	var found *APIToken
+	for i := range tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(tokens[i].Hash)) == 1 {
+			found = &tokens[i]
+		}
+	}
+	return found
+}
+
+func hashAPIToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseServerListenAddr parses a listen spec of the form "tcp:HOST:PORT" and
+// returns the "HOST:PORT" address to listen on. Only loopback hosts are
+// accepted: the TCP listener is meant for local GUI tools, not for exposing
+// the API to the network.
+func ParseServerListenAddr(spec string) (string, error) {
+	addr, ok := strings.CutPrefix(spec, "tcp:")
+	if !ok {
+		return "", stacktrace.NewError("invalid listen address '%s'; expected tcp:127.0.0.1:PORT", spec)
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", stacktrace.NewError("invalid listen address '%s'; expected tcp:127.0.0.1:PORT", spec)
+	}
+	if port == "" {
+		return "", stacktrace.NewError("listen address '%s' is missing a port", spec)
+	}
+	if host != "localhost" {
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return "", stacktrace.NewError("listen address '%s' must use a loopback host (127.0.0.1, ::1, or localhost)", spec)
+		}
+	}
+	return addr, nil
+}
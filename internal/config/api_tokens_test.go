@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAPITokens_CreateFindRevoke(t *testing.T) {
+	agencDirpath := t.TempDir()
+
+	plaintext, token, err := CreateAPIToken(agencDirpath, "gui")
+	if err != nil {
+		t.Fatalf("CreateAPIToken failed: %v", err)
+	}
+	if !strings.HasPrefix(plaintext, apiTokenPrefix) {
+		t.Errorf("token %q missing prefix %q", plaintext, apiTokenPrefix)
+	}
+
+	info, err := os.Stat(GetAPITokensFilepath(agencDirpath))
+	if err != nil {
+		t.Fatalf("token file not written: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected token file mode 0600, got %o", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(GetAPITokensFilepath(agencDirpath))
+	if strings.Contains(string(data), plaintext) {
+		t.Error("plaintext token must not be stored")
+	}
+
+	if _, _, err := CreateAPIToken(agencDirpath, "gui"); err == nil {
+		t.Error("expected error for duplicate token name")
+	}
+
+	tokens, err := ReadAPITokens(agencDirpath)
+	if err != nil {
+		t.Fatalf("ReadAPITokens failed: %v", err)
+	}
+	if found := FindAPIToken(tokens, plaintext); found == nil || found.ID != token.ID {
+		t.Fatalf("expected to find token %s, got %+v", token.ID, found)
+	}
+	if found := FindAPIToken(tokens, plaintext+"x"); found != nil {
+		t.Error("expected no match for a wrong token")
+	}
+
+	if _, err := RevokeAPIToken(agencDirpath, token.ID); err != nil {
+		t.Fatalf("RevokeAPIToken failed: %v", err)
+	}
+	tokens, _ = ReadAPITokens(agencDirpath)
+	if FindAPIToken(tokens, plaintext) != nil {
+		t.Error("revoked token still matches")
+	}
+	if _, err := RevokeAPIToken(agencDirpath, "gui"); err == nil {
+		t.Error("expected error revoking an unknown token")
+	}
+}
+
+func TestParseServerListenAddr(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{spec: "tcp:127.0.0.1:7777", want: "127.0.0.1:7777"},
+		{spec: "tcp:[::1]:7777", want: "[::1]:7777"},
+		{spec: "tcp:localhost:7777", want: "localhost:7777"},
+		{spec: "tcp:0.0.0.0:7777", wantErr: true},
+		{spec: "tcp:192.168.1.5:7777", wantErr: true},
+		{spec: "tcp:example.com:7777", wantErr: true},
+		{spec: "tcp:127.0.0.1", wantErr: true},
+		{spec: "tcp:127.0.0.1:", wantErr: true},
+		{spec: "127.0.0.1:7777", wantErr: true},
+		{spec: "unix:/tmp/agenc.sock", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseServerListenAddr(tt.spec)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseServerListenAddr(%q) = %q, expected error", tt.spec, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseServerListenAddr(%q) = %q, %v; want %q", tt.spec, got, err, tt.want)
+		}
+	}
+}
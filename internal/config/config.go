@@ -34,9 +34,12 @@ const (
 	RequestsLogFilename   = "requests.log"
 	ServerSocketFilename  = "server.sock"
 	ServerLockFilename    = "server.lock"
+	APITokensFilename     = "api-tokens.json"
 	ConfigFilename        = "config.yml"
 
 	AgentDirname                    = "agent"
+	PreviousAgentDirname            = "agent-previous"
+	ScratchDirname                  = "scratch"
 	PIDFilename                     = "pid"
 	GlobalSettingsFilename          = "settings.json"
 	GlobalClaudeMdFilename          = "CLAUDE.md"
@@ -45,25 +48,44 @@ const (
 	HistoryFilename                 = "history.jsonl"
 	SecretsEnvFilename              = "secrets.env"
 	ClaudeOutputLogFilename         = "claude-output.log"
+	CrashReportFilename             = "crash-report.txt"
 	TmuxKeybindingsFilename         = "tmux-keybindings.conf"
 	WrapperSocketFilename           = "wrapper.sock"
+	NetworkProxySocketFilename      = "network-proxy.sock"
+	StatuslineMessageFilename       = "statusline-message"
 	CLIName                         = "agenc"
 	MissionUUIDEnvVar               = "AGENC_MISSION_UUID"
+	MissionScratchDirpathEnvVar     = "AGENC_MISSION_SCRATCH_DIRPATH"
 	MissionSourceEnvVar             = "AGENC_MISSION_SOURCE"
 	MissionSourceMetadataEnvVar     = "AGENC_MISSION_SOURCE_METADATA"
 	AdjutantMarkerFilename          = ".adjutant"
+	SandboxMarkerFilename           = ".sandbox"
+	MissionSubpathFilename          = "subpath"
 	GlobalCredentialsExpiryFilename = "global-credentials-expiry"
 	CacheDirname                    = "cache"
+	DependencyCacheDirname          = "deps"
 	OAuthTokenFilename              = "oauth-token"
 	StashDirname                    = "stash"
+	CredentialStoreDirname          = "credentials"
+	SecretsIndexFilename            = "secrets.json"
+	AuditDirname                    = "audit"
+	DigestsDirname                  = "digests"
 )
 
 // GetAgencDirpath returns the agenc config directory path, reading from
-// the AGENC_DIRPATH environment variable or defaulting to ~/.agenc (configurable).
+// the AGENC_DIRPATH environment variable, then the current profile in
+// ~/.agenc-profiles.yml, and defaulting to ~/.agenc.
 func GetAgencDirpath() (string, error) {
 	if envVal := os.Getenv(agencDirpathEnvVar); envVal != "" {
 		return envVal, nil
 	}
+	profiles, _, err := ReadProfiles()
+	if err != nil {
+		return "", err
+	}
+	if profiles.Current != "" {
+		return profiles.ResolveDirpath(profiles.Current)
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", stacktrace.Propagate(err, "failed to determine home directory")
@@ -160,6 +182,13 @@ func GetServerLogFilepath(agencDirpath string) string {
 	return filepath.Join(agencDirpath, ServerDirname, ServerLogFilename)
 }
 
+// GetAPITokensFilepath returns the path to the API token store. It lives in
+// the server directory rather than the git-tracked config directory so token
+// hashes are never committed.
+func GetAPITokensFilepath(agencDirpath string) string {
+	return filepath.Join(agencDirpath, ServerDirname, APITokensFilename)
+}
+
 // GetServerSocketFilepath returns the path to the server unix socket file.
 func GetServerSocketFilepath(agencDirpath string) string {
 	return filepath.Join(agencDirpath, ServerDirname, ServerSocketFilename)
@@ -181,6 +210,31 @@ func GetStashDirpath(agencDirpath string) string {
 	return filepath.Join(agencDirpath, StashDirname)
 }
 
+// GetAuditDirpath returns the path to the audit log directory, which holds
+// one JSONL file of audit entries per day.
+func GetAuditDirpath(agencDirpath string) string {
+	return filepath.Join(agencDirpath, AuditDirname)
+}
+
+// GetDigestsDirpath returns the path to the daily digest directory, which
+// holds a JSON digest and its rendered text for each day.
+func GetDigestsDirpath(agencDirpath string) string {
+	return filepath.Join(agencDirpath, DigestsDirname)
+}
+
+// GetCredentialStoreDirpath returns the path to the encrypted-file credential
+// store, used on systems without a keyring.
+func GetCredentialStoreDirpath(agencDirpath string) string {
+	return filepath.Join(agencDirpath, CredentialStoreDirname)
+}
+
+// GetSecretsIndexFilepath returns the path to the list of secret names
+// managed by 'agenc secret'. Only names live here; values are kept in the
+// credential store.
+func GetSecretsIndexFilepath(agencDirpath string) string {
+	return filepath.Join(agencDirpath, SecretsIndexFilename)
+}
+
 // GetDatabaseFilepath returns the path to the SQLite database file.
 func GetDatabaseFilepath(agencDirpath string) string {
 	return filepath.Join(agencDirpath, "database.sqlite")
@@ -202,11 +256,78 @@ func GetMissionDirpath(agencDirpath string, missionID string) string {
 }
 
 // GetMissionAgentDirpath returns the path to the agent/ subdirectory within
-// a mission. This is the Claude Code project root.
+// a mission. This is the Claude Code project root unless the mission was
+// created with a subpath (see GetMissionWorkDirpath).
 func GetMissionAgentDirpath(agencDirpath string, missionID string) string {
 	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), AgentDirname)
 }
 
+// MissionIDFromDirpath returns the ID of the mission whose agent directory
+// contains dirpath (or is dirpath itself). Symlinks are resolved on both sides
+// so paths under a symlinked AgenC directory still match. Returns ok=false
+// when dirpath is not inside any mission's agent directory.
+func MissionIDFromDirpath(agencDirpath string, dirpath string) (string, bool) {
+	missionsDirpath := GetMissionsDirpath(agencDirpath)
+	if resolved, err := filepath.EvalSymlinks(missionsDirpath); err == nil {
+		missionsDirpath = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(dirpath); err == nil {
+		dirpath = resolved
+	}
+
+	relpath, err := filepath.Rel(missionsDirpath, dirpath)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(filepath.ToSlash(relpath), "/")
+	if len(parts) < 2 || parts[0] == ".." || parts[0] == "." || parts[1] != AgentDirname {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// GetMissionSubpathFilepath returns the path to the file holding a mission's
+// subpath: the directory within agent/ that Claude runs in, for missions
+// created with --path.
+func GetMissionSubpathFilepath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), MissionSubpathFilename)
+}
+
+// ReadMissionSubpath returns a mission's subpath, or "" when Claude runs in
+// the agent directory itself.
+func ReadMissionSubpath(agencDirpath string, missionID string) string {
+	data, err := os.ReadFile(GetMissionSubpathFilepath(agencDirpath, missionID))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// GetMissionWorkDirpath returns the directory Claude runs in for a mission:
+// the agent directory, or its subpath when the mission has one. This is the
+// Claude Code project root, so it keys the mission's project directory under
+// ~/.claude/projects/.
+func GetMissionWorkDirpath(agencDirpath string, missionID string) string {
+	agentDirpath := GetMissionAgentDirpath(agencDirpath, missionID)
+	if subpath := ReadMissionSubpath(agencDirpath, missionID); subpath != "" {
+		return filepath.Join(agentDirpath, subpath)
+	}
+	return agentDirpath
+}
+
+// GetMissionPreviousAgentDirpath returns where a mission's old agent/
+// directory is kept after the mission is re-pointed at another repo.
+func GetMissionPreviousAgentDirpath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), PreviousAgentDirname)
+}
+
+// GetMissionScratchDirpath returns the path to a mission's scratch/
+// directory: a sibling of agent/, outside the repo's working tree, for temp
+// files the agent wants to keep until the mission is archived.
+func GetMissionScratchDirpath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), ScratchDirname)
+}
+
 // GetMissionPIDFilepath returns the path to the wrapper PID file for a mission.
 func GetMissionPIDFilepath(agencDirpath string, missionID string) string {
 	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), PIDFilename)
@@ -229,6 +350,12 @@ func GetMissionClaudeOutputLogFilepath(agencDirpath string, missionID string) st
 	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), ClaudeOutputLogFilename)
 }
 
+// GetMissionCrashReportFilepath returns the path to the report the wrapper
+// writes when Claude exits unexpectedly. Each crash overwrites the last.
+func GetMissionCrashReportFilepath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), CrashReportFilename)
+}
+
 // GetConfigDirpath returns the path to the user-editable config directory
 // ($AGENC/config/), intended to be Git-controlled.
 func GetConfigDirpath(agencDirpath string) string {
@@ -241,11 +368,23 @@ func GetHistoryFilepath(agencDirpath string) string {
 	return filepath.Join(GetGlobalClaudeDirpath(agencDirpath), HistoryFilename)
 }
 
+// GetMissionStatuslineMessageFilepath returns the path to the file whose
+// contents AgenC shows in a mission's Claude statusline.
+func GetMissionStatuslineMessageFilepath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), StatuslineMessageFilename)
+}
+
 // GetMissionSocketFilepath returns the path to the wrapper unix socket for a mission.
 func GetMissionSocketFilepath(agencDirpath string, missionID string) string {
 	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), WrapperSocketFilename)
 }
 
+// GetMissionNetworkProxySocketFilepath returns the path to the unix socket of
+// a mission's network policy proxy.
+func GetMissionNetworkProxySocketFilepath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), NetworkProxySocketFilename)
+}
+
 // GetTmuxKeybindingsFilepath returns the path to the agenc-managed tmux
 // keybindings configuration file.
 func GetTmuxKeybindingsFilepath(agencDirpath string) string {
@@ -273,6 +412,19 @@ func GetMissionAdjutantMarkerFilepath(agencDirpath string, missionID string) str
 	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), AdjutantMarkerFilename)
 }
 
+// GetMissionSandboxMarkerFilepath returns the path to the sandbox marker file
+// for a mission. The presence of this file means the mission was created with
+// --sandbox and runs Claude in a container regardless of its repo's isolation.
+func GetMissionSandboxMarkerFilepath(agencDirpath string, missionID string) string {
+	return filepath.Join(GetMissionDirpath(agencDirpath, missionID), SandboxMarkerFilename)
+}
+
+// IsMissionSandboxed reports whether a mission has the sandbox marker file.
+func IsMissionSandboxed(agencDirpath string, missionID string) bool {
+	_, err := os.Stat(GetMissionSandboxMarkerFilepath(agencDirpath, missionID))
+	return err == nil
+}
+
 // IsMissionAdjutant reports whether a mission is an adjutant mission
 // by checking for the presence of the adjutant marker file.
 //
@@ -358,6 +510,14 @@ func GetCacheDirpath(agencDirpath string) string {
 	return filepath.Join(agencDirpath, CacheDirname)
 }
 
+// GetRepoDependencyCacheDirpath returns the shared dependency cache for a repo
+// ($AGENC_DIRPATH/cache/deps/<repoName>/). Paths listed in the repo's
+// postUpdateHookCache live here and are symlinked into the library clone and
+// every mission workspace.
+func GetRepoDependencyCacheDirpath(agencDirpath string, repoName string) string {
+	return filepath.Join(GetCacheDirpath(agencDirpath), DependencyCacheDirname, repoName)
+}
+
 // GetOAuthTokenFilepath returns the path to the cached OAuth token file.
 func GetOAuthTokenFilepath(agencDirpath string) string {
 	return filepath.Join(GetCacheDirpath(agencDirpath), OAuthTokenFilename)
@@ -234,3 +234,41 @@ func TestGetMissionAdjutantMarkerFilepath(t *testing.T) {
 		t.Errorf("expected %q, got %q", expected, result)
 	}
 }
+
+func TestMissionIDFromDirpath(t *testing.T) {
+	agencDirpath := t.TempDir()
+	missionID := "5bbe8434-0000-0000-0000-000000000000"
+	agentDirpath := GetMissionAgentDirpath(agencDirpath, missionID)
+	nestedDirpath := filepath.Join(agentDirpath, "src", "pkg")
+	if err := os.MkdirAll(nestedDirpath, 0755); err != nil {
+		t.Fatalf("failed to create agent dir: %v", err)
+	}
+
+	// A symlink to the AgenC dir still resolves to the mission
+	linkDirpath := filepath.Join(t.TempDir(), "agenc-link")
+	if err := os.Symlink(agencDirpath, linkDirpath); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		dirpath string
+		wantID  string
+		wantOK  bool
+	}{
+		{"agent dir", agentDirpath, missionID, true},
+		{"nested in agent dir", nestedDirpath, missionID, true},
+		{"through symlink", filepath.Join(linkDirpath, MissionsDirname, missionID, AgentDirname, "src"), missionID, true},
+		{"mission dir itself", GetMissionDirpath(agencDirpath, missionID), "", false},
+		{"missions dir", GetMissionsDirpath(agencDirpath), "", false},
+		{"outside agenc dir", t.TempDir(), "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, gotOK := MissionIDFromDirpath(agencDirpath, tt.dirpath)
+			if gotID != tt.wantID || gotOK != tt.wantOK {
+				t.Errorf("MissionIDFromDirpath(%q) = (%q, %v), want (%q, %v)", tt.dirpath, gotID, gotOK, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"regexp"
+	"slices"
+)
+
+// Variables a cron prompt may reference as {{name}}; the server fills them
+// in when a run starts.
+const (
+	// CronPromptVarDate is the local date the run starts, as 2006-01-02
+	CronPromptVarDate = "date"
+	// CronPromptVarTime is the local time the run starts, as 15:04
+	CronPromptVarTime = "time"
+	// CronPromptVarWeekday is the local weekday the run starts, as Monday
+	CronPromptVarWeekday = "weekday"
+	// CronPromptVarCronName is the cron's name
+	CronPromptVarCronName = "cronName"
+	// CronPromptVarRepo is the cron's repo, empty for a blank cron
+	CronPromptVarRepo = "repo"
+	// CronPromptVarLastRun is when the cron's previous run started, as
+	// RFC3339, or "never"
+	CronPromptVarLastRun = "lastRun"
+	// CronPromptVarLastRunStatus is how the cron's previous run went
+	// (succeeded, failed, or running), or "never"
+	CronPromptVarLastRunStatus = "lastRunStatus"
+	// CronPromptVarLastSuccess is when the cron's last successful run ended,
+	// as RFC3339, or "never"
+	CronPromptVarLastSuccess = "lastSuccess"
+	// CronPromptVarRepoDefaultBranchHead is the commit SHA at the head of the
+	// cron repo's default branch, empty for a blank cron
+	CronPromptVarRepoDefaultBranchHead = "repoDefaultBranchHead"
+)
+
+// CronPromptNever is the value of the lastRun, lastRunStatus, and lastSuccess
+// variables for a cron without such a run.
+const CronPromptNever = "never"
+
+// CronPromptVariables lists the variables a cron prompt may reference.
+var CronPromptVariables = []string{
+	CronPromptVarDate,
+	CronPromptVarTime,
+	CronPromptVarWeekday,
+	CronPromptVarCronName,
+	CronPromptVarRepo,
+	CronPromptVarLastRun,
+	CronPromptVarLastRunStatus,
+	CronPromptVarLastSuccess,
+	CronPromptVarRepoDefaultBranchHead,
+}
+
+// cronPromptVarRegex matches a {{name}} placeholder, allowing spaces inside
+// the braces.
+var cronPromptVarRegex = regexp.MustCompile(`\{\{\s*([A-Za-z][A-Za-z0-9]*)\s*\}\}`)
+
+// CronPromptReferences returns the known variables prompt references, in
+// order of first use.
+func CronPromptReferences(prompt string) []string {
+	var names []string
+	for _, match := range cronPromptVarRegex.FindAllStringSubmatch(prompt, -1) {
+		if slices.Contains(CronPromptVariables, match[1]) && !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// RenderCronPrompt replaces each {{name}} placeholder of a known variable in
+// prompt with values[name]. Placeholders of unknown variables are left as
+// written, so prompts that show template syntax to Claude keep it.
+func RenderCronPrompt(prompt string, values map[string]string) string {
+	return cronPromptVarRegex.ReplaceAllStringFunc(prompt, func(placeholder string) string {
+		name := cronPromptVarRegex.FindStringSubmatch(placeholder)[1]
+		if !slices.Contains(CronPromptVariables, name) {
+			return placeholder
+		}
+		return values[name]
+	})
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCronPromptReferences(t *testing.T) {
+	got := CronPromptReferences("Summarize commits since {{lastRun}} on {{ weekday }}; last time {{lastRun}} went {{lastRunStatus}}. Ignore {{unknown}}.")
+	want := []string{CronPromptVarLastRun, CronPromptVarWeekday, CronPromptVarLastRunStatus}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := CronPromptReferences("No variables here"); len(got) != 0 {
+		t.Errorf("expected no references, got %v", got)
+	}
+}
+
+func TestRenderCronPrompt(t *testing.T) {
+	values := map[string]string{
+		CronPromptVarDate:    "2026-10-17",
+		CronPromptVarLastRun: CronPromptNever,
+	}
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"Report for {{date}}", "Report for 2026-10-17"},
+		{"Since {{ lastRun }}", "Since never"},
+		{"Keep {{unknown}} and {{ not a var }}", "Keep {{unknown}} and {{ not a var }}"},
+		{"Head: {{repoDefaultBranchHead}}.", "Head: ."},
+	}
+	for _, tt := range tests {
+		if got := RenderCronPrompt(tt.prompt, values); got != tt.want {
+			t.Errorf("RenderCronPrompt(%q) = %q, want %q", tt.prompt, got, tt.want)
+		}
+	}
+}
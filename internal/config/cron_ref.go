@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// cronRefForbiddenChars are characters git never allows in a ref name (see
+// git-check-ref-format(1)).
+const cronRefForbiddenChars = " ~^:?*[\\"
+
+// ValidateCronRef checks that ref is usable as a cron's ref: a branch, tag,
+// or commit SHA that git could accept. Whether the ref exists in the repo is
+// only known when a run resolves it.
+func ValidateCronRef(ref string) error {
+	if ref == "" {
+		return stacktrace.NewError("ref cannot be empty")
+	}
+	if strings.HasPrefix(ref, "-") {
+		return stacktrace.NewError("ref '%s' cannot start with '-'", ref)
+	}
+	if ref == "@" || strings.Contains(ref, "@{") || strings.Contains(ref, "..") || strings.Contains(ref, "//") {
+		return stacktrace.NewError("ref '%s' is not a valid git ref name", ref)
+	}
+	if strings.HasPrefix(ref, "/") || strings.HasSuffix(ref, "/") || strings.HasSuffix(ref, ".") || strings.HasSuffix(ref, ".lock") {
+		return stacktrace.NewError("ref '%s' is not a valid git ref name", ref)
+	}
+	for _, r := range ref {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(cronRefForbiddenChars, r) {
+			return stacktrace.NewError("ref '%s' cannot contain %q", ref, r)
+		}
+	}
+	for _, component := range strings.Split(ref, "/") {
+		if strings.HasPrefix(component, ".") {
+			return stacktrace.NewError("ref '%s' has a component starting with '.'", ref)
+		}
+	}
+	return nil
+}
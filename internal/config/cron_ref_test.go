@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestValidateCronRef(t *testing.T) {
+	for _, ref := range []string{"main", "release/2026.10", "v1.4.2", "feature/nightly-report", "3f9c2ab", "8d1e6f0c4b7a9e2d5f3c1b0a8e7d6c5b4a3f2e1d"} {
+		t.Run(ref, func(t *testing.T) {
+			if err := ValidateCronRef(ref); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCronRef_Rejects(t *testing.T) {
+	for _, ref := range []string{"", "-main", "my branch", "main..dev", "HEAD~1", "v1^", "a:b", "feat*", "release/", "/main", "main.lock", "main.", "feature/.hidden", "main@{1}", "@", "a//b", "tab\there"} {
+		t.Run(ref, func(t *testing.T) {
+			if err := ValidateCronRef(ref); err == nil {
+				t.Errorf("expected an error for %q", ref)
+			}
+		})
+	}
+}
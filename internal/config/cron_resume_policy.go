@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+const (
+	// CronResumeFresh starts every run as a new mission. It is the default.
+	CronResumeFresh = "fresh"
+
+	// CronResumeContinue sends each run's prompt to the previous run's
+	// mission, continuing its conversation.
+	CronResumeContinue = "continue"
+
+	// CronResumeForkIfBehind continues the previous run's conversation
+	// unless its checkout is more than N commits behind the repo's default
+	// branch, in which case the conversation is forked into a new mission
+	// on a fresh checkout. Written as "forkIfBehind(N)".
+	CronResumeForkIfBehind = "forkIfBehind"
+)
+
+// CronResumePolicy is a parsed cron resumePolicy.
+type CronResumePolicy struct {
+	Mode string // CronResumeFresh, CronResumeContinue, or CronResumeForkIfBehind
+
+	// MaxCommitsBehind is how far the previous run's checkout may trail the
+	// default branch before forkIfBehind forks instead of continuing.
+	MaxCommitsBehind int
+}
+
+// ParseCronResumePolicy parses a cron resumePolicy: "fresh", "continue", or
+// "forkIfBehind(N)" with N a non-negative number of commits. An empty string
+// means "fresh".
+func ParseCronResumePolicy(value string) (CronResumePolicy, error) {
+	switch value {
+	case "", CronResumeFresh:
+		return CronResumePolicy{Mode: CronResumeFresh}, nil
+	case CronResumeContinue:
+		return CronResumePolicy{Mode: CronResumeContinue}, nil
+	}
+
+	arg, ok := strings.CutPrefix(value, CronResumeForkIfBehind+"(")
+	if ok {
+		arg, ok = strings.CutSuffix(arg, ")")
+	}
+	if !ok {
+		return CronResumePolicy{}, stacktrace.NewError(
+			"resumePolicy must be '%s', '%s', or '%s(N)', got '%s'",
+			CronResumeFresh, CronResumeContinue, CronResumeForkIfBehind, value,
+		)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil || n < 0 {
+		return CronResumePolicy{}, stacktrace.NewError(
+			"%s needs a non-negative number of commits, got '%s'", CronResumeForkIfBehind, value,
+		)
+	}
+	return CronResumePolicy{Mode: CronResumeForkIfBehind, MaxCommitsBehind: n}, nil
+}
+
+// GetResumePolicy returns the cron's parsed resumePolicy, falling back to
+// "fresh" if it is unset or invalid.
+func (c *CronConfig) GetResumePolicy() CronResumePolicy {
+	policy, err := ParseCronResumePolicy(c.ResumePolicy)
+	if err != nil {
+		return CronResumePolicy{Mode: CronResumeFresh}
+	}
+	return policy
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestParseCronResumePolicy(t *testing.T) {
+	tests := []struct {
+		value string
+		want  CronResumePolicy
+	}{
+		{value: "", want: CronResumePolicy{Mode: CronResumeFresh}},
+		{value: "fresh", want: CronResumePolicy{Mode: CronResumeFresh}},
+		{value: "continue", want: CronResumePolicy{Mode: CronResumeContinue}},
+		{value: "forkIfBehind(20)", want: CronResumePolicy{Mode: CronResumeForkIfBehind, MaxCommitsBehind: 20}},
+		{value: "forkIfBehind( 0 )", want: CronResumePolicy{Mode: CronResumeForkIfBehind}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseCronResumePolicy(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCronResumePolicy_Rejects(t *testing.T) {
+	for _, value := range []string{"resume", "Continue", "forkIfBehind", "forkIfBehind()", "forkIfBehind(-1)", "forkIfBehind(ten)", "forkIfBehind(5"} {
+		t.Run(value, func(t *testing.T) {
+			if _, err := ParseCronResumePolicy(value); err == nil {
+				t.Errorf("expected an error for %q", value)
+			}
+		})
+	}
+}
+
+func TestValidateCronConfigs_ResumePolicy(t *testing.T) {
+	cfg := &AgencConfig{Crons: map[string]CronConfig{
+		"nightly": {Schedule: "0 6 * * *", Prompt: "Summarize", ResumePolicy: "forkIfBehind(x)"},
+	}}
+	if err := validateCronConfigs(cfg, "config.yml"); err == nil {
+		t.Error("expected an invalid resumePolicy rejected")
+	}
+
+	cfg.Crons["nightly"] = CronConfig{Schedule: "0 6 * * *", Prompt: "Summarize", ResumePolicy: "forkIfBehind(10)"}
+	if err := validateCronConfigs(cfg, "config.yml"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/launchd"
+)
+
+var (
+	// schedulePhraseAtRegex splits "<period> at <time>" phrases.
+	schedulePhraseAtRegex = regexp.MustCompile(`^(.*?)\s+at\s+(.+)$`)
+
+	// scheduleClockRegex matches "9", "9am", "9:30", "9:30 pm", "21:00".
+	scheduleClockRegex = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+
+	// scheduleMinuteRegex matches the minute of an hourly phrase: ":15" or
+	// "minute 15".
+	scheduleMinuteRegex = regexp.MustCompile(`^(?::|minute\s+)(\d{1,2})$`)
+
+	// scheduleOrdinalRegex matches a day of the month: "1", "1st", "22nd".
+	scheduleOrdinalRegex = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+
+	// scheduleEveryNRegex matches interval phrases launchd cannot express,
+	// e.g. "every 15 minutes".
+	scheduleEveryNRegex = regexp.MustCompile(`^every\s+\d+\s+(minutes|hours|days|weeks|months)$`)
+)
+
+// scheduleWeekdays maps day names, full and abbreviated, to cron weekday
+// numbers.
+var scheduleWeekdays = map[string]int{
+	"sunday": 0, "sun": 0,
+	"monday": 1, "mon": 1,
+	"tuesday": 2, "tue": 2, "tues": 2,
+	"wednesday": 3, "wed": 3,
+	"thursday": 4, "thu": 4, "thur": 4, "thurs": 4,
+	"friday": 5, "fri": 5,
+	"saturday": 6, "sat": 6,
+}
+
+// scheduleMonths maps month names, full and abbreviated, to cron month
+// numbers.
+var scheduleMonths = map[string]int{
+	"january": 1, "jan": 1,
+	"february": 2, "feb": 2,
+	"march": 3, "mar": 3,
+	"april": 4, "apr": 4,
+	"may":  5,
+	"june": 6, "jun": 6,
+	"july": 7, "jul": 7,
+	"august": 8, "aug": 8,
+	"september": 9, "sep": 9, "sept": 9,
+	"october": 10, "oct": 10,
+	"november": 11, "nov": 11,
+	"december": 12, "dec": 12,
+}
+
+// ResolveCronSchedule returns input unchanged when it is already a valid cron
+// expression, and otherwise translates it as an English schedule phrase (see
+// ParseCronSchedulePhrase).
+func ResolveCronSchedule(input string) (string, error) {
+	if ValidateCronSchedule(input) == nil {
+		return input, nil
+	}
+	if len(strings.Fields(input)) == 5 && strings.IndexFunc(input, unicode.IsLetter) < 0 {
+		// Looks like a cron expression, so the cron error is the useful one
+		return "", ValidateCronSchedule(input)
+	}
+	return ParseCronSchedulePhrase(input)
+}
+
+// ParseCronSchedulePhrase translates an English schedule phrase into a cron
+// expression, e.g. "every day at 9am" to "0 9 * * *". Accepted phrases:
+//
+//   - every minute; every hour [at :MM]; hourly
+//   - every day [at TIME]; daily
+//   - every monday [at TIME]; mondays at TIME
+//   - every month on the 15th [at TIME]; on the 1st of every month; monthly
+//   - every year on march 3rd [at TIME]; yearly; annually
+//
+// TIME is "9am", "9:30 pm", "21:00", "noon", or "midnight", and defaults to
+// midnight. Phrases covering several days or intervals ("every weekday",
+// "every 15 minutes") are rejected: launchd schedules hold a single value per
+// field.
+func ParseCronSchedulePhrase(phrase string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(strings.TrimSuffix(strings.TrimSpace(phrase), "."))), " ")
+	if normalized == "" {
+		return "", stacktrace.NewError("cron schedule cannot be empty")
+	}
+
+	period, clock := normalized, ""
+	if match := schedulePhraseAtRegex.FindStringSubmatch(normalized); match != nil {
+		period, clock = match[1], match[2]
+	}
+
+	var expr string
+	var err error
+	switch {
+	case period == "every minute":
+		if clock != "" {
+			return "", stacktrace.NewError("'every minute' cannot take a time")
+		}
+		expr = "* * * * *"
+	case period == "every hour" || period == "hourly":
+		expr, err = hourlySchedule(clock)
+	case period == "every day" || period == "daily" || period == "everyday" || period == "each day":
+		expr, err = atClock(clock, "* * *")
+	case period == "monthly" || period == "every month":
+		expr, err = atClock(clock, "1 * *")
+	case period == "yearly" || period == "annually" || period == "every year":
+		expr, err = atClock(clock, "1 1 *")
+	case period == "every weekday" || period == "weekdays" || period == "on weekdays" ||
+		period == "every weekend" || period == "weekends" || period == "on weekends":
+		return "", stacktrace.NewError(
+			"'%s' covers several days, which a launchd schedule cannot express; create one cron per day instead (e.g. 'every monday at 9am')", period)
+	case scheduleEveryNRegex.MatchString(period):
+		return "", stacktrace.NewError(
+			"'%s' repeats on an interval, which a launchd schedule cannot express; use 'every hour' or 'every day' instead", period)
+	default:
+		var fields string
+		fields, err = datedScheduleFields(period)
+		if err == nil {
+			expr, err = atClock(clock, fields)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := ValidateCronSchedule(expr); err != nil {
+		return "", stacktrace.Propagate(err, "schedule phrase '%s' translated to an invalid cron expression", phrase)
+	}
+	return expr, nil
+}
+
+// datedScheduleFields returns the "day month weekday" cron fields for a
+// weekly, monthly, or yearly period such as "every friday", "every month on
+// the 15th", or "every year on march 3rd".
+func datedScheduleFields(period string) (string, error) {
+	words := strings.Fields(period)
+	if len(words) > 0 && (words[0] == "every" || words[0] == "on" || words[0] == "each") {
+		words = words[1:]
+	}
+
+	// every friday / fridays / on fridays
+	if len(words) == 1 {
+		if weekday, ok := scheduleWeekdays[strings.TrimSuffix(words[0], "s")]; ok {
+			return fmt.Sprintf("* * %d", weekday), nil
+		}
+		if weekday, ok := scheduleWeekdays[words[0]]; ok {
+			return fmt.Sprintf("* * %d", weekday), nil
+		}
+	}
+
+	rest := strings.Join(words, " ")
+
+	// month on the 15th / monthly on the 15th / the 15th of every month
+	for _, prefix := range []string{"month on the ", "monthly on the ", "month on ", "monthly on "} {
+		if ordinal, ok := strings.CutPrefix(rest, prefix); ok {
+			return monthDayField(ordinal, "*")
+		}
+	}
+	for _, suffix := range []string{" of every month", " of each month", " of the month"} {
+		if ordinal, ok := strings.CutSuffix(rest, suffix); ok {
+			return monthDayField(strings.TrimPrefix(ordinal, "the "), "*")
+		}
+	}
+
+	// year on march 3rd / yearly on march 3rd / march 3rd
+	for _, prefix := range []string{"year on ", "yearly on ", "annually on "} {
+		rest = strings.TrimPrefix(rest, prefix)
+	}
+	if monthWord, ordinal, ok := strings.Cut(rest, " "); ok {
+		if month, ok := scheduleMonths[monthWord]; ok {
+			return monthDayField(strings.TrimPrefix(ordinal, "the "), strconv.Itoa(month))
+		}
+	}
+
+	return "", stacktrace.NewError(
+		"unrecognized schedule '%s'; use a cron expression like '0 9 * * *' or a phrase like 'every day at 9am', 'every monday at 8:30am', or 'every month on the 1st at noon'", period)
+}
+
+// monthDayField returns the "day month weekday" cron fields for a day of the
+// month such as "15th" in the given month field.
+func monthDayField(ordinal string, month string) (string, error) {
+	match := scheduleOrdinalRegex.FindStringSubmatch(strings.TrimSpace(ordinal))
+	if match == nil {
+		return "", stacktrace.NewError("unrecognized day of the month '%s'; use e.g. '1st' or '15th'", ordinal)
+	}
+	day, _ := strconv.Atoi(match[1])
+	if day < 1 || day > 31 {
+		return "", stacktrace.NewError("day of the month must be between 1 and 31, got %d", day)
+	}
+	return fmt.Sprintf("%d %s *", day, month), nil
+}
+
+// hourlySchedule returns the cron expression for an hourly phrase, firing at
+// the given ":MM" minute or on the hour.
+func hourlySchedule(clock string) (string, error) {
+	if clock == "" {
+		return "0 * * * *", nil
+	}
+	match := scheduleMinuteRegex.FindStringSubmatch(clock)
+	if match == nil {
+		return "", stacktrace.NewError("unrecognized minute '%s' for an hourly schedule; use e.g. 'every hour at :15'", clock)
+	}
+	minute, _ := strconv.Atoi(match[1])
+	if minute > 59 {
+		return "", stacktrace.NewError("minute must be between 0 and 59, got %d", minute)
+	}
+	return fmt.Sprintf("%d * * * *", minute), nil
+}
+
+// atClock prefixes the "day month weekday" cron fields with the minute and
+// hour of clock, which defaults to midnight.
+func atClock(clock string, dayFields string) (string, error) {
+	hour, minute, err := parseScheduleClock(clock)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d %d %s", minute, hour, dayFields), nil
+}
+
+// parseScheduleClock parses a time of day like "9am", "9:30 pm", "21:00",
+// "noon", or "midnight". An empty clock is midnight.
+func parseScheduleClock(clock string) (int, int, error) {
+	switch clock {
+	case "", "midnight":
+		return 0, 0, nil
+	case "noon", "midday":
+		return 12, 0, nil
+	}
+
+	match := scheduleClockRegex.FindStringSubmatch(clock)
+	if match == nil {
+		return 0, 0, stacktrace.NewError("unrecognized time '%s'; use e.g. '9am', '9:30pm', '21:00', or 'noon'", clock)
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	switch meridiem := match[3]; {
+	case meridiem != "" && (hour < 1 || hour > 12):
+		return 0, 0, stacktrace.NewError("hour must be between 1 and 12 with am/pm, got '%s'", clock)
+	case meridiem == "am" && hour == 12:
+		hour = 0
+	case meridiem == "pm" && hour != 12:
+		hour += 12
+	case meridiem == "" && match[2] == "":
+		return 0, 0, stacktrace.NewError("ambiguous time '%s'; add am/pm or minutes, e.g. '%sam' or '%s:00'", clock, clock, clock)
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, stacktrace.NewError("invalid time '%s'", clock)
+	}
+	return hour, minute, nil
+}
+
+// NextCronRuns returns up to count times after after at which the cron
+// schedule fires, in after's location.
+func NextCronRuns(schedule string, after time.Time, count int) ([]time.Time, error) {
+	interval, err := launchd.ParseCronExpression(schedule)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "invalid cron schedule '%s'", schedule)
+	}
+	var runs []time.Time
+	for len(runs) < count {
+		next, ok := interval.Next(after)
+		if !ok {
+			break
+		}
+		runs = append(runs, next)
+		after = next
+	}
+	return runs, nil
+}
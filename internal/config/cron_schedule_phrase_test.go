@@ -0,0 +1,122 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedulePhrase(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{phrase: "every minute", want: "* * * * *"},
+		{phrase: "every hour", want: "0 * * * *"},
+		{phrase: "hourly at :15", want: "15 * * * *"},
+		{phrase: "every hour at minute 30", want: "30 * * * *"},
+		{phrase: "daily", want: "0 0 * * *"},
+		{phrase: "every day at 9am", want: "0 9 * * *"},
+		{phrase: "Every Day at 9:30 PM.", want: "30 21 * * *"},
+		{phrase: "everyday at 21:05", want: "5 21 * * *"},
+		{phrase: "every day at noon", want: "0 12 * * *"},
+		{phrase: "every day at 12am", want: "0 0 * * *"},
+		{phrase: "every day at 12pm", want: "0 12 * * *"},
+		{phrase: "every monday at 8:30am", want: "30 8 * * 1"},
+		{phrase: "fridays at 5pm", want: "0 17 * * 5"},
+		{phrase: "on sundays at midnight", want: "0 0 * * 0"},
+		{phrase: "every sat", want: "0 0 * * 6"},
+		{phrase: "monthly", want: "0 0 1 * *"},
+		{phrase: "every month on the 15th at 9am", want: "0 9 15 * *"},
+		{phrase: "monthly on the 1st", want: "0 0 1 * *"},
+		{phrase: "on the 22nd of every month at 6pm", want: "0 18 22 * *"},
+		{phrase: "yearly", want: "0 0 1 1 *"},
+		{phrase: "every year on march 3rd at 10am", want: "0 10 3 3 *"},
+		{phrase: "annually on dec 25", want: "0 0 25 12 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phrase, func(t *testing.T) {
+			got, err := ParseCronSchedulePhrase(tt.phrase)
+			if err != nil {
+				t.Fatalf("ParseCronSchedulePhrase(%q) returned error: %v", tt.phrase, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCronSchedulePhrase(%q) = %q, want %q", tt.phrase, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronSchedulePhrase_Rejects(t *testing.T) {
+	phrases := []string{
+		"",
+		"every weekday at 9am",
+		"weekends",
+		"every 15 minutes",
+		"every day at 9",
+		"every day at 13pm",
+		"every day at 25:00",
+		"every hour at 9am",
+		"every minute at 9am",
+		"every month on the 32nd",
+		"every blursday",
+		"whenever you feel like it",
+	}
+
+	for _, phrase := range phrases {
+		if got, err := ParseCronSchedulePhrase(phrase); err == nil {
+			t.Errorf("ParseCronSchedulePhrase(%q) = %q, expected an error", phrase, got)
+		}
+	}
+}
+
+func TestResolveCronSchedule(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "0 9 * * 1", want: "0 9 * * 1"},
+		{input: "every monday at 9am", want: "0 9 * * 1"},
+		{input: "0 9 * * 1-5", wantErr: true},
+		{input: "every weekday at 9am", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveCronSchedule(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveCronSchedule(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveCronSchedule(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNextCronRuns(t *testing.T) {
+	// Friday 2026-10-16 10:00
+	after := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	runs, err := NextCronRuns("0 9 * * 1", after, 3)
+	if err != nil {
+		t.Fatalf("NextCronRuns returned error: %v", err)
+	}
+	want := []time.Time{
+		time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC),
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("expected %d runs, got %v", len(want), runs)
+	}
+	for i := range want {
+		if !runs[i].Equal(want[i]) {
+			t.Errorf("run %d = %v, want %v", i, runs[i], want[i])
+		}
+	}
+
+	if _, err := NextCronRuns("0 9 * * 1-5", after, 3); err == nil {
+		t.Error("expected an error for an unsupported expression")
+	}
+}
@@ -0,0 +1,359 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+const (
+	// ConfigHistoryDirname is the directory name for the internal git repo
+	// that records snapshots of the config directory.
+	ConfigHistoryDirname = "config-history"
+
+	// configHistoryGitTimeout bounds each git operation on the history repo.
+	configHistoryGitTimeout = 30 * time.Second
+
+	// configHistoryFieldSep separates fields in `git log` output.
+	configHistoryFieldSep = "\x1f"
+)
+
+// configHistoryTrackedNames lists the entries of the config directory that
+// are recorded in the history repo. Everything else in config/ (e.g. a
+// user's own .git) is left alone.
+var configHistoryTrackedNames = []string{
+	ConfigFilename,
+	ClaudeModificationsDirname,
+}
+
+// ConfigHistoryEntry is one snapshot in the config history.
+type ConfigHistoryEntry struct {
+	Commit      string    `json:"commit"`
+	ShortCommit string    `json:"short_commit"`
+	Time        time.Time `json:"time"`
+	Message     string    `json:"message"`
+}
+
+// GetConfigHistoryDirpath returns the path to the config history repo.
+func GetConfigHistoryDirpath(agencDirpath string) string {
+	return filepath.Join(agencDirpath, ConfigHistoryDirname)
+}
+
+// HasConfigHistory reports whether the config history repo exists.
+func HasConfigHistory(agencDirpath string) bool {
+	_, err := os.Stat(filepath.Join(GetConfigHistoryDirpath(agencDirpath), ".git"))
+	return err == nil
+}
+
+// SnapshotConfig copies the tracked config files into the history repo
+// (creating it on first use) and commits them with message if anything
+// changed since the last snapshot. Returns whether a commit was made.
+func SnapshotConfig(agencDirpath string, message string) (bool, error) {
+	historyDirpath, err := ensureConfigHistoryRepo(agencDirpath)
+	if err != nil {
+		return false, err
+	}
+	if err := syncConfigHistoryWorktree(GetConfigDirpath(agencDirpath), historyDirpath); err != nil {
+		return false, err
+	}
+	return commitConfigHistory(historyDirpath, message)
+}
+
+// ListConfigHistory returns config snapshots, newest first. A limit of zero
+// or less returns every snapshot.
+func ListConfigHistory(agencDirpath string, limit int) ([]ConfigHistoryEntry, error) {
+	if !HasConfigHistory(agencDirpath) {
+		return []ConfigHistoryEntry{}, nil
+	}
+	historyDirpath := GetConfigHistoryDirpath(agencDirpath)
+	if !configHistoryHasCommits(historyDirpath) {
+		return []ConfigHistoryEntry{}, nil
+	}
+
+	args := []string{"log", "--format=%H" + configHistoryFieldSep + "%h" + configHistoryFieldSep + "%aI" + configHistoryFieldSep + "%s"}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	output, err := runConfigHistoryGit(historyDirpath, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ConfigHistoryEntry{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.SplitN(line, configHistoryFieldSep, 4)
+		if len(fields) != 4 {
+			continue
+		}
+		committedAt, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to parse commit time '%s'", fields[2])
+		}
+		entries = append(entries, ConfigHistoryEntry{
+			Commit:      fields[0],
+			ShortCommit: fields[1],
+			Time:        committedAt,
+			Message:     fields[3],
+		})
+	}
+	return entries, nil
+}
+
+// DiffConfig writes a unified diff from the snapshot at commit (the latest
+// snapshot when empty) to the current config directory. color forces git's
+// colored output.
+func DiffConfig(agencDirpath string, commit string, out io.Writer, color bool) error {
+	historyDirpath, err := requireConfigHistory(agencDirpath)
+	if err != nil {
+		return err
+	}
+	if commit == "" {
+		commit = "HEAD"
+	}
+	resolved, err := resolveConfigHistoryCommit(historyDirpath, commit)
+	if err != nil {
+		return err
+	}
+
+	// Mirror the live config into the worktree and stage it so the diff
+	// includes new and deleted files. The next snapshot commits it anyway.
+	if err := syncConfigHistoryWorktree(GetConfigDirpath(agencDirpath), historyDirpath); err != nil {
+		return err
+	}
+	if _, err := runConfigHistoryGit(historyDirpath, "add", "-A"); err != nil {
+		return err
+	}
+
+	colorArg := "--color=never"
+	if color {
+		colorArg = "--color=always"
+	}
+	diff, err := runConfigHistoryGit(historyDirpath, "diff", "--cached", colorArg, resolved)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(out, diff); err != nil {
+		return stacktrace.Propagate(err, "failed to write diff")
+	}
+	return nil
+}
+
+// RollbackConfig restores the tracked config files to the snapshot at
+// commit. The current config is snapshotted first so the rollback itself can
+// be undone. The restored config.yml is validated before anything in the
+// config directory is touched. Callers must hold the config lock. Returns
+// the short hash of the restored snapshot.
+func RollbackConfig(agencDirpath string, commit string) (string, error) {
+	historyDirpath, err := requireConfigHistory(agencDirpath)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := resolveConfigHistoryCommit(historyDirpath, commit)
+	if err != nil {
+		return "", err
+	}
+	shortCommit, err := runConfigHistoryGit(historyDirpath, "rev-parse", "--short", resolved)
+	if err != nil {
+		return "", err
+	}
+	shortCommit = strings.TrimSpace(shortCommit)
+
+	if _, err := SnapshotConfig(agencDirpath, "Snapshot before rollback to "+shortCommit); err != nil {
+		return "", stacktrace.Propagate(err, "failed to snapshot current config before rollback")
+	}
+
+	configYAML, err := runConfigHistoryGit(historyDirpath, "show", resolved+":"+ConfigFilename)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "snapshot %s has no %s", shortCommit, ConfigFilename)
+	}
+	if _, _, err := parseAgencConfig([]byte(configYAML), GetConfigFilepath(agencDirpath)); err != nil {
+		return "", stacktrace.Propagate(err, "snapshot %s does not hold a valid config", shortCommit)
+	}
+
+	// Reset the tracked entries in the worktree to the snapshot, dropping
+	// files that did not exist then.
+	for _, name := range configHistoryTrackedNames {
+		if err := os.RemoveAll(filepath.Join(historyDirpath, name)); err != nil {
+			return "", stacktrace.Propagate(err, "failed to clear '%s' in config history", name)
+		}
+	}
+	if _, err := runConfigHistoryGit(historyDirpath, "checkout", resolved, "--", "."); err != nil {
+		return "", err
+	}
+
+	configDirpath := GetConfigDirpath(agencDirpath)
+	for _, name := range configHistoryTrackedNames {
+		if err := replaceConfigHistoryEntry(filepath.Join(historyDirpath, name), filepath.Join(configDirpath, name)); err != nil {
+			return "", stacktrace.Propagate(err, "failed to restore '%s'", name)
+		}
+	}
+
+	if _, err := commitConfigHistory(historyDirpath, "Roll back to "+shortCommit); err != nil {
+		return "", err
+	}
+	return shortCommit, nil
+}
+
+// ensureConfigHistoryRepo creates and initializes the history repo if it does
+// not exist yet.
+func ensureConfigHistoryRepo(agencDirpath string) (string, error) {
+	historyDirpath := GetConfigHistoryDirpath(agencDirpath)
+	if HasConfigHistory(agencDirpath) {
+		return historyDirpath, nil
+	}
+
+	if err := os.MkdirAll(historyDirpath, 0755); err != nil {
+		return "", stacktrace.Propagate(err, "failed to create config history directory")
+	}
+	if _, err := runConfigHistoryGit(historyDirpath, "init"); err != nil {
+		return "", err
+	}
+	return historyDirpath, nil
+}
+
+// requireConfigHistory returns the history repo dirpath, or an error telling
+// the user there is nothing recorded yet.
+func requireConfigHistory(agencDirpath string) (string, error) {
+	historyDirpath := GetConfigHistoryDirpath(agencDirpath)
+	if !HasConfigHistory(agencDirpath) || !configHistoryHasCommits(historyDirpath) {
+		return "", stacktrace.NewError("no config history recorded yet; snapshots are taken by the server whenever the config changes")
+	}
+	return historyDirpath, nil
+}
+
+// syncConfigHistoryWorktree replaces the tracked entries in the history
+// worktree with the current contents of the config directory.
+func syncConfigHistoryWorktree(configDirpath string, historyDirpath string) error {
+	for _, name := range configHistoryTrackedNames {
+		if err := replaceConfigHistoryEntry(filepath.Join(configDirpath, name), filepath.Join(historyDirpath, name)); err != nil {
+			return stacktrace.Propagate(err, "failed to copy '%s' into config history", name)
+		}
+	}
+	return nil
+}
+
+// commitConfigHistory stages everything in the history repo and commits it
+// with message. Returns false without committing when nothing changed.
+func commitConfigHistory(historyDirpath string, message string) (bool, error) {
+	if _, err := runConfigHistoryGit(historyDirpath, "add", "-A"); err != nil {
+		return false, err
+	}
+	status, err := runConfigHistoryGit(historyDirpath, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(status) == "" {
+		return false, nil
+	}
+	if _, err := runConfigHistoryGit(historyDirpath, "commit", "-q", "-m", message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// configHistoryHasCommits reports whether the history repo has a HEAD commit.
+func configHistoryHasCommits(historyDirpath string) bool {
+	_, err := runConfigHistoryGit(historyDirpath, "rev-parse", "--verify", "-q", "HEAD")
+	return err == nil
+}
+
+// resolveConfigHistoryCommit resolves a commit-ish in the history repo to a
+// full hash.
+func resolveConfigHistoryCommit(historyDirpath string, commit string) (string, error) {
+	if strings.HasPrefix(commit, "-") {
+		return "", stacktrace.NewError("invalid commit '%s'", commit)
+	}
+	resolved, err := runConfigHistoryGit(historyDirpath, "rev-parse", "--verify", "-q", commit+"^{commit}")
+	if err != nil {
+		return "", stacktrace.NewError("unknown config snapshot '%s'; see 'agenc config history'", commit)
+	}
+	return strings.TrimSpace(resolved), nil
+}
+
+// replaceConfigHistoryEntry makes dstPath an exact copy of srcPath (a file or
+// directory), removing dstPath when srcPath does not exist.
+func replaceConfigHistoryEntry(srcPath string, dstPath string) error {
+	if err := os.RemoveAll(dstPath); err != nil {
+		return stacktrace.Propagate(err, "failed to remove '%s'", dstPath)
+	}
+	info, err := os.Stat(srcPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to stat '%s'", srcPath)
+	}
+	if !info.IsDir() {
+		return copyConfigHistoryFile(srcPath, dstPath, info.Mode().Perm())
+	}
+
+	return filepath.WalkDir(srcPath, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		relPath, err := filepath.Rel(srcPath, path)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to compute relative path")
+		}
+		target := filepath.Join(dstPath, relPath)
+		info, err := d.Info()
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to stat '%s'", path)
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyConfigHistoryFile(path, target, info.Mode().Perm())
+	})
+}
+
+// copyConfigHistoryFile copies a regular file, preserving its permissions.
+func copyConfigHistoryFile(srcFilepath string, dstFilepath string, perm os.FileMode) error {
+	data, err := os.ReadFile(srcFilepath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read '%s'", srcFilepath)
+	}
+	if err := os.MkdirAll(filepath.Dir(dstFilepath), 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create directory for '%s'", dstFilepath)
+	}
+	if err := os.WriteFile(dstFilepath, data, perm); err != nil {
+		return stacktrace.Propagate(err, "failed to write '%s'", dstFilepath)
+	}
+	return nil
+}
+
+// runConfigHistoryGit runs git in the history repo and returns its stdout.
+// Commits are authored as AgenC, like the Claude config shadow repo, so
+// snapshots work without a global git identity.
+func runConfigHistoryGit(historyDirpath string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), configHistoryGitTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = historyDirpath
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=AgenC",
+		"GIT_AUTHOR_EMAIL=agenc@local",
+		"GIT_COMMITTER_NAME=AgenC",
+		"GIT_COMMITTER_EMAIL=agenc@local",
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", stacktrace.Propagate(err, "git %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeHistoryTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestConfigHistory_SnapshotDiffRollback(t *testing.T) {
+	agencDirpath := t.TempDir()
+	configFilepath := GetConfigFilepath(agencDirpath)
+	claudeMdFilepath := filepath.Join(GetClaudeModificationsDirpath(agencDirpath), GlobalClaudeMdFilename)
+
+	writeHistoryTestFile(t, configFilepath, "defaultModel: opus\n")
+	committed, err := SnapshotConfig(agencDirpath, "first")
+	if err != nil || !committed {
+		t.Fatalf("first snapshot: committed=%v err=%v", committed, err)
+	}
+	if committed, err := SnapshotConfig(agencDirpath, "no-op"); err != nil || committed {
+		t.Fatalf("unchanged snapshot: committed=%v err=%v", committed, err)
+	}
+
+	writeHistoryTestFile(t, configFilepath, "defaultModel: sonnet\n")
+	writeHistoryTestFile(t, claudeMdFilepath, "Be terse.\n")
+
+	var diff bytes.Buffer
+	if err := DiffConfig(agencDirpath, "", &diff, false); err != nil {
+		t.Fatalf("DiffConfig failed: %v", err)
+	}
+	for _, want := range []string{"-defaultModel: opus", "+defaultModel: sonnet", "+Be terse."} {
+		if !strings.Contains(diff.String(), want) {
+			t.Errorf("diff missing %q:\n%s", want, diff.String())
+		}
+	}
+
+	if _, err := SnapshotConfig(agencDirpath, "second"); err != nil {
+		t.Fatalf("second snapshot failed: %v", err)
+	}
+	entries, err := ListConfigHistory(agencDirpath, 0)
+	if err != nil {
+		t.Fatalf("ListConfigHistory failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Message != "second" || entries[1].Message != "first" {
+		t.Fatalf("unexpected history: %+v", entries)
+	}
+
+	shortCommit, err := RollbackConfig(agencDirpath, entries[1].ShortCommit)
+	if err != nil {
+		t.Fatalf("RollbackConfig failed: %v", err)
+	}
+	if shortCommit != entries[1].ShortCommit {
+		t.Errorf("rolled back to %s, want %s", shortCommit, entries[1].ShortCommit)
+	}
+	data, err := os.ReadFile(configFilepath)
+	if err != nil || string(data) != "defaultModel: opus\n" {
+		t.Errorf("config.yml after rollback = %q (err %v)", data, err)
+	}
+	if _, err := os.Stat(claudeMdFilepath); !os.IsNotExist(err) {
+		t.Errorf("expected claude-modifications CLAUDE.md to be removed by rollback, stat err = %v", err)
+	}
+
+	entries, err = ListConfigHistory(agencDirpath, 1)
+	if err != nil || len(entries) != 1 || entries[0].Message != "Roll back to "+shortCommit {
+		t.Errorf("expected rollback commit at head, got %+v (err %v)", entries, err)
+	}
+}
+
+func TestRollbackConfig_RejectsInvalidSnapshot(t *testing.T) {
+	agencDirpath := t.TempDir()
+	configFilepath := GetConfigFilepath(agencDirpath)
+
+	writeHistoryTestFile(t, configFilepath, "defaultModel: [unclosed\n")
+	if _, err := SnapshotConfig(agencDirpath, "broken"); err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+	writeHistoryTestFile(t, configFilepath, "defaultModel: opus\n")
+
+	if _, err := RollbackConfig(agencDirpath, "HEAD"); err == nil {
+		t.Fatal("expected rollback to an invalid config to fail")
+	}
+	data, _ := os.ReadFile(configFilepath)
+	if string(data) != "defaultModel: opus\n" {
+		t.Errorf("config.yml changed by failed rollback: %q", data)
+	}
+}
+
+func TestDiffConfig_NoHistory(t *testing.T) {
+	if err := DiffConfig(t.TempDir(), "", &bytes.Buffer{}, false); err == nil {
+		t.Fatal("expected an error when no history exists")
+	}
+}
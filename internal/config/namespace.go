@@ -46,12 +46,28 @@ func GetPoolSessionName(agencDirpath string) string {
 	return baseNamePrefix + GetNamespaceSuffix(agencDirpath) + "-pool"
 }
 
+// GetTmuxGroupSessionName returns the tmux session that groups missions under
+// tmux.layout perRepo or perProject. kind is "repo" or "project" and name an
+// identifier safe for tmux session names.
+// Default: "agenc-repo-NAME". Namespaced: "agenc-HASH-repo-NAME".
+func GetTmuxGroupSessionName(agencDirpath string, kind string, name string) string {
+	return baseNamePrefix + GetNamespaceSuffix(agencDirpath) + "-" + kind + "-" + name
+}
+
 // GetCronPlistPrefix returns the prefix for cron plist filenames and labels.
 // Default: "agenc-cron.". Namespaced: "agenc-HASH-cron.".
 func GetCronPlistPrefix(agencDirpath string) string {
 	return baseNamePrefix + GetNamespaceSuffix(agencDirpath) + "-cron."
 }
 
+// GetServerServiceLabel returns the launchd label and systemd unit name
+// (without ".service") for the supervised server installed by
+// 'agenc server install'.
+// Default: "agenc-server". Namespaced: "agenc-HASH-server".
+func GetServerServiceLabel(agencDirpath string) string {
+	return baseNamePrefix + GetNamespaceSuffix(agencDirpath) + "-server"
+}
+
 // IsTestEnv returns true if AGENC_TEST_ENV is set (to any non-empty value).
 func IsTestEnv() bool {
 	return os.Getenv(TestEnvVar) != ""
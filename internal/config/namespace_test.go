@@ -121,6 +121,20 @@ func TestGetCronPlistPrefix(t *testing.T) {
 	})
 }
 
+func TestGetServerServiceLabel(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("failed to get home dir: %v", err)
+	}
+	if got := GetServerServiceLabel(filepath.Join(homeDir, ".agenc")); got != "agenc-server" {
+		t.Errorf("expected 'agenc-server', got %q", got)
+	}
+	got := GetServerServiceLabel("/tmp/test-agenc")
+	if got == "agenc-server" || got[len(got)-7:] != "-server" {
+		t.Errorf("expected a namespaced 'agenc-HASH-server' label, got %q", got)
+	}
+}
+
 func TestIsTestEnv(t *testing.T) {
 	t.Run("returns false when unset", func(t *testing.T) {
 		t.Setenv("AGENC_TEST_ENV", "")
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/mieubrisse/stacktrace"
+)
+
+// ProfilesFilename is the file in the user's home directory that maps profile
+// names to AgenC directories, for running several independent installations
+// (each with its own server, pool session, and missions).
+const ProfilesFilename = ".agenc-profiles.yml"
+
+// DefaultProfileName names the default ~/.agenc installation when no profile
+// of that name is defined.
+const DefaultProfileName = "default"
+
+var profileNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// Profiles is the content of ~/.agenc-profiles.yml.
+type Profiles struct {
+	// Current is the profile used when neither --profile nor AGENC_DIRPATH
+	// is set. Empty means the default profile.
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile is one named AgenC installation.
+type Profile struct {
+	// Dirpath is the profile's AGENC_DIRPATH. A leading ~ is expanded.
+	Dirpath string `yaml:"dirpath"`
+}
+
+// GetProfilesFilepath returns the path to ~/.agenc-profiles.yml.
+func GetProfilesFilepath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to determine home directory")
+	}
+	return filepath.Join(homeDir, ProfilesFilename), nil
+}
+
+// ReadProfiles reads ~/.agenc-profiles.yml. A missing file yields empty
+// profiles. The returned comment map preserves the file's comments for
+// WriteProfiles.
+func ReadProfiles() (*Profiles, yaml.CommentMap, error) {
+	profilesFilepath, err := GetProfilesFilepath()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(profilesFilepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Profiles{}, nil, nil
+		}
+		return nil, nil, stacktrace.Propagate(err, "failed to read profiles file '%s'", profilesFilepath)
+	}
+
+	var profiles Profiles
+	cm := yaml.CommentMap{}
+	if err := yaml.UnmarshalWithOptions(data, &profiles, yaml.CommentToMap(cm)); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "failed to parse profiles file '%s'", profilesFilepath)
+	}
+	for name, profile := range profiles.Profiles {
+		if !profileNameRegex.MatchString(name) {
+			return nil, nil, stacktrace.NewError("invalid profile name '%s' in '%s': use letters, digits, '-', and '_'", name, profilesFilepath)
+		}
+		if strings.TrimSpace(profile.Dirpath) == "" {
+			return nil, nil, stacktrace.NewError("profile '%s' in '%s' has no dirpath", name, profilesFilepath)
+		}
+	}
+	if profiles.Current != "" && profiles.Current != DefaultProfileName {
+		if _, ok := profiles.Profiles[profiles.Current]; !ok {
+			return nil, nil, stacktrace.NewError("current profile '%s' in '%s' is not defined under profiles", profiles.Current, profilesFilepath)
+		}
+	}
+	return &profiles, cm, nil
+}
+
+// WriteProfiles writes ~/.agenc-profiles.yml, keeping the comments in cm.
+func WriteProfiles(profiles *Profiles, cm yaml.CommentMap) error {
+	profilesFilepath, err := GetProfilesFilepath()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if cm != nil {
+		data, err = yaml.MarshalWithOptions(profiles, yaml.WithComment(cm))
+	} else {
+		data, err = yaml.Marshal(profiles)
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to marshal profiles")
+	}
+	if err := os.WriteFile(profilesFilepath, data, 0644); err != nil {
+		return stacktrace.Propagate(err, "failed to write profiles file '%s'", profilesFilepath)
+	}
+	return nil
+}
+
+// Names returns the profile names, sorted, including the built-in default
+// profile.
+func (p *Profiles) Names() []string {
+	names := []string{}
+	if _, ok := p.Profiles[DefaultProfileName]; !ok {
+		names = append(names, DefaultProfileName)
+	}
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveDirpath returns the AgenC directory of the named profile, with a
+// leading ~ expanded and the path cleaned so it matches the namespace
+// computed for the same directory reached through AGENC_DIRPATH.
+func (p *Profiles) ResolveDirpath(name string) (string, error) {
+	profile, ok := p.Profiles[name]
+	if !ok && name == DefaultProfileName {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", stacktrace.Propagate(err, "failed to determine home directory")
+		}
+		return filepath.Join(homeDir, defaultAgencDirname), nil
+	}
+	if !ok {
+		return "", stacktrace.NewError("unknown profile '%s'; define it under profiles in ~/%s", name, ProfilesFilename)
+	}
+	dirpath := profile.Dirpath
+	if dirpath == "~" || strings.HasPrefix(dirpath, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", stacktrace.Propagate(err, "failed to determine home directory")
+		}
+		dirpath = filepath.Join(homeDir, strings.TrimPrefix(dirpath, "~"))
+	}
+	if !filepath.IsAbs(dirpath) {
+		return "", stacktrace.NewError("profile '%s' dirpath must be absolute or start with ~, got '%s'", name, profile.Dirpath)
+	}
+	return filepath.Clean(dirpath), nil
+}
+
+// UseProfile points this process and everything it starts at the named
+// profile's AgenC directory by setting AGENC_DIRPATH.
+func UseProfile(name string) error {
+	profiles, _, err := ReadProfiles()
+	if err != nil {
+		return err
+	}
+	dirpath, err := profiles.ResolveDirpath(name)
+	if err != nil {
+		return err
+	}
+	return os.Setenv(agencDirpathEnvVar, dirpath)
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeProfilesYAML(t *testing.T, homeDir string, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(homeDir, ProfilesFilename), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write profiles file: %v", err)
+	}
+}
+
+func TestGetAgencDirpath_Profiles(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(agencDirpathEnvVar, "")
+
+	// No profiles file: the default directory
+	dirpath, err := GetAgencDirpath()
+	if err != nil {
+		t.Fatalf("GetAgencDirpath failed: %v", err)
+	}
+	if want := filepath.Join(homeDir, defaultAgencDirname); dirpath != want {
+		t.Errorf("expected %q, got %q", want, dirpath)
+	}
+
+	writeProfilesYAML(t, homeDir, `
+current: work
+profiles:
+  work:
+    dirpath: ~/.agenc-work/
+`)
+	dirpath, err = GetAgencDirpath()
+	if err != nil {
+		t.Fatalf("GetAgencDirpath failed: %v", err)
+	}
+	if want := filepath.Join(homeDir, ".agenc-work"); dirpath != want {
+		t.Errorf("expected the current profile's directory %q, got %q", want, dirpath)
+	}
+
+	// AGENC_DIRPATH wins over the current profile
+	t.Setenv(agencDirpathEnvVar, "/explicit")
+	dirpath, err = GetAgencDirpath()
+	if err != nil {
+		t.Fatalf("GetAgencDirpath failed: %v", err)
+	}
+	if dirpath != "/explicit" {
+		t.Errorf("expected AGENC_DIRPATH to take precedence, got %q", dirpath)
+	}
+}
+
+func TestReadProfiles_Invalid(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"undefined current", "current: nope\nprofiles:\n  work:\n    dirpath: /w\n"},
+		{"missing dirpath", "profiles:\n  work: {}\n"},
+		{"bad name", "profiles:\n  'a b':\n    dirpath: /w\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeProfilesYAML(t, homeDir, tt.content)
+			if _, _, err := ReadProfiles(); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+
+	writeProfilesYAML(t, homeDir, "profiles:\n  work:\n    dirpath: relative/path\n")
+	profiles, _, err := ReadProfiles()
+	if err != nil {
+		t.Fatalf("ReadProfiles failed: %v", err)
+	}
+	if _, err := profiles.ResolveDirpath("work"); err == nil {
+		t.Error("expected an error for a relative dirpath, got nil")
+	}
+	if names := profiles.Names(); len(names) != 2 || names[0] != DefaultProfileName || names[1] != "work" {
+		t.Errorf("expected [default work], got %v", names)
+	}
+}
@@ -0,0 +1,967 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/notify"
+	"github.com/odyssey/agenc/internal/sleep"
+)
+
+// Config issue severities. Errors make config.yml unloadable; warnings (such as
+// unknown keys, which the loader ignores) are only surfaced by `config validate`.
+const (
+	ConfigIssueSeverityError   = "error"
+	ConfigIssueSeverityWarning = "warning"
+)
+
+// ConfigIssue is a single problem found while validating config.yml. Line and
+// Column are 1-based positions in the YAML source; both are zero for issues
+// that cannot be pinned to one node (e.g. cross-entry uniqueness checks).
+type ConfigIssue struct {
+	Severity string
+	Path     string
+	Line     int
+	Column   int
+	Message  string
+}
+
+// String formats the issue as "line:col: path: message", omitting the
+// position or path when unknown.
+func (i ConfigIssue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", i.Line, i.Column)
+	}
+	if i.Path != "" {
+		b.WriteString(i.Path)
+		b.WriteString(": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// ValidateConfigFile lints a config.yml without applying it: YAML syntax, the
+// config schema (field names, types, required fields, allowed values), and the
+// same semantic checks ReadAgencConfig performs. Issues are returned in source
+// order. A missing file yields no issues, matching ReadAgencConfig.
+func ValidateConfigFile(configFilepath string) ([]ConfigIssue, error) {
+	data, err := os.ReadFile(configFilepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to read config file '%s'", configFilepath)
+	}
+
+	issues := lintConfigYAML(data)
+	if hasConfigErrors(issues) {
+		return issues, nil
+	}
+
+	// The schema passed; run the loader's semantic checks (e.g. palette title
+	// and keybinding uniqueness) so validate accepts exactly what loads.
+	if _, _, err := parseAgencConfig(data, configFilepath); err != nil {
+		issues = append(issues, ConfigIssue{
+			Severity: ConfigIssueSeverityError,
+			Message:  fmt.Sprintf("%#s", err),
+		})
+	}
+	return issues, nil
+}
+
+// hasConfigErrors reports whether any issue has error severity.
+func hasConfigErrors(issues []ConfigIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == ConfigIssueSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// configIssuesError folds the error-severity issues into a single error for
+// ReadAgencConfig, one issue per line prefixed by the file path.
+func configIssuesError(configFilepath string, issues []ConfigIssue) error {
+	var lines []string
+	for _, issue := range issues {
+		if issue.Severity == ConfigIssueSeverityError {
+			lines = append(lines, configFilepath+":"+issue.String())
+		}
+	}
+	return stacktrace.NewError("invalid config:\n%s", strings.Join(lines, "\n"))
+}
+
+// lintConfigYAML parses raw config.yml content and checks it against
+// agencConfigSchema, returning issues in source order.
+func lintConfigYAML(data []byte) []ConfigIssue {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		issue := ConfigIssue{Severity: ConfigIssueSeverityError, Message: err.Error()}
+		if yamlErr, ok := err.(yaml.Error); ok {
+			issue.Message = yamlErr.GetMessage()
+			setIssuePosition(&issue, yamlErr.GetToken())
+		}
+		return []ConfigIssue{issue}
+	}
+
+	var issues []ConfigIssue
+	for _, doc := range file.Docs {
+		if doc.Body == nil {
+			continue
+		}
+		agencConfigSchema.validate(doc.Body, "", "", &issues)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+	return issues
+}
+
+// ============================================================================
+// Schema
+// ============================================================================
+
+type schemaKind int
+
+const (
+	schemaKindObject schemaKind = iota // fixed set of named properties
+	schemaKindMap                      // arbitrary keys, uniform values
+	schemaKindArray
+	schemaKindString
+	schemaKindInt
+	schemaKindBool
+)
+
+// schemaNode describes the expected shape of one YAML node, in the spirit of
+// JSON Schema: objects list their properties and required keys, maps constrain
+// their keys and values, arrays their items. check runs extra validation on a
+// node that already has the right shape; key is the node's property or map key.
+type schemaNode struct {
+	kind        schemaKind
+	description string // used in type-mismatch messages, e.g. `"all" or a list of server names`
+	properties  map[string]*schemaNode
+	required    []string
+	keyCheck    func(key string) error
+	values      *schemaNode
+	items       *schemaNode
+	anyOf       []*schemaNode
+	check       func(key string, n ast.Node) error
+}
+
+// agencConfigSchema mirrors AgencConfig. TestAgencConfigSchemaCoversStruct
+// keeps the two in sync.
+var agencConfigSchema = &schemaNode{
+	kind: schemaKindObject,
+	properties: map[string]*schemaNode{
+		"repoConfig": {
+			kind:     schemaKindMap,
+			keyCheck: checkCanonicalRepoName,
+			values:   repoConfigSchema,
+		},
+		"crons": {
+			kind:     schemaKindMap,
+			keyCheck: ValidateCronName,
+			values:   cronConfigSchema,
+		},
+		"paletteCommands": {
+			kind:     schemaKindMap,
+			keyCheck: ValidatePaletteCommandName,
+			values:   paletteCommandSchema,
+		},
+		"paletteTmuxKeybinding": {kind: schemaKindString},
+		"tmuxWindowTitle": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"busyBackgroundColor":      {kind: schemaKindString},
+				"busyForegroundColor":      {kind: schemaKindString},
+				"attentionBackgroundColor": {kind: schemaKindString},
+				"attentionForegroundColor": {kind: schemaKindString},
+			},
+		},
+		"defaultModel": {kind: schemaKindString},
+		"claudeArgs":   {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString}},
+		"sleepMode": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"windows": {kind: schemaKindArray, items: sleepWindowSchema},
+			},
+		},
+		"quietHours": {
+			kind:     schemaKindObject,
+			required: []string{"start", "end"},
+			properties: map[string]*schemaNode{
+				"start": {kind: schemaKindString, check: stringCheck(sleep.ValidateTime)},
+				"end":   {kind: schemaKindString, check: stringCheck(sleep.ValidateTime)},
+			},
+		},
+		"sessionTitleMaxWords": {
+			kind: schemaKindInt,
+			check: intCheck(func(v int) error {
+				// Zero reads as "unset" on load; see ValidateAndPopulateDefaults
+				if v == 0 {
+					return nil
+				}
+				return ValidateSessionTitleMaxWords(v)
+			}),
+		},
+		"attachedMissionLimit": {
+			kind: schemaKindInt,
+			check: intCheck(func(v int) error {
+				if v < 0 {
+					return stacktrace.NewError("attachedMissionLimit cannot be negative, got %d", v)
+				}
+				return nil
+			}),
+		},
+		"missionsMaxConcurrent": {
+			kind: schemaKindInt,
+			check: intCheck(func(v int) error {
+				if v < 0 {
+					return stacktrace.NewError("missionsMaxConcurrent cannot be negative, got %d", v)
+				}
+				return nil
+			}),
+		},
+		"missionSizeLimit": {
+			kind:     schemaKindObject,
+			required: []string{"maxSize"},
+			properties: map[string]*schemaNode{
+				"maxSize": {kind: schemaKindString, check: stringCheck(func(v string) error {
+					_, err := ParseByteSize(v)
+					return err
+				})},
+				"cleanupHook":  {kind: schemaKindString},
+				"blockArchive": {kind: schemaKindBool},
+			},
+		},
+		"githubWebhook": {
+			kind:       schemaKindObject,
+			required:   []string{"secret"},
+			properties: map[string]*schemaNode{"secret": {kind: schemaKindString, check: stringCheck(requireNonEmpty)}},
+		},
+		"notifications": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"desktop": {kind: schemaKindBool},
+				"slack": {
+					kind:       schemaKindObject,
+					required:   []string{"token"},
+					properties: map[string]*schemaNode{"token": {kind: schemaKindString, check: stringCheck(requireNonEmpty)}},
+				},
+				"email": {
+					kind:     schemaKindObject,
+					required: []string{"smtpHost", "from"},
+					properties: map[string]*schemaNode{
+						"smtpHost": {kind: schemaKindString, check: stringCheck(requireNonEmpty)},
+						"smtpPort": {kind: schemaKindInt, check: intCheck(func(v int) error {
+							if v < 0 || v > 65535 {
+								return stacktrace.NewError("smtpPort must be a port number, got %d", v)
+							}
+							return nil
+						})},
+						"username": {kind: schemaKindString},
+						"password": {kind: schemaKindString},
+						"from":     {kind: schemaKindString, check: stringCheck(requireNonEmpty)},
+					},
+				},
+			},
+		},
+		"dailyDigest": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"time":     {kind: schemaKindString, check: stringCheck(sleep.ValidateTime)},
+				"template": {kind: schemaKindString},
+				"notify": {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString, check: stringCheck(func(v string) error {
+					_, err := notify.ParseTarget(v)
+					return err
+				})}},
+			},
+		},
+		"lifecycleHooks": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"onMissionStart": {kind: schemaKindString},
+				"onClaudeIdle":   {kind: schemaKindString},
+				"onClaudeBusy":   {kind: schemaKindString},
+				"onMissionEnd":   {kind: schemaKindString},
+			},
+		},
+		"multiUser": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"tmuxSocket": {kind: schemaKindString},
+				"group":      {kind: schemaKindString},
+				"users":      {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString}},
+			},
+		},
+		"sandbox": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"runtime": {kind: schemaKindString, check: stringCheck(ValidateSandboxRuntime)},
+				"image":   {kind: schemaKindString},
+			},
+		},
+		"statusline": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"segments": {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString, check: stringCheck(ValidateStatuslineSegment)}},
+			},
+		},
+		"missionSummary": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"trigger": {kind: schemaKindString, check: stringCheck(ValidateMissionSummaryTrigger)},
+				"everyPrompts": {kind: schemaKindInt, check: intCheck(func(v int) error {
+					if v < 1 {
+						return stacktrace.NewError("everyPrompts must be at least 1, got %d", v)
+					}
+					return nil
+				})},
+				"model": {kind: schemaKindString},
+			},
+		},
+		"agentBackends": {
+			kind:     schemaKindMap,
+			keyCheck: ValidateAgentBackendName,
+			values:   agentBackendSchema,
+		},
+		"rateLimit": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"maxConcurrentRequests": {kind: schemaKindInt, check: intCheck(func(v int) error {
+					if v < 1 {
+						return stacktrace.NewError("maxConcurrentRequests must be at least 1, got %d", v)
+					}
+					return nil
+				})},
+				"endpoints": {
+					kind:     schemaKindMap,
+					keyCheck: ValidateRateLimitPattern,
+					values:   endpointRateLimitSchema,
+				},
+			},
+		},
+		"terminalBackend":       {kind: schemaKindString, check: stringCheck(ValidateTerminalBackend)},
+		"cleanupRemoteOnDelete": {kind: schemaKindString, check: stringCheck(ValidateCleanupRemoteOnDelete)},
+		"releaseChannel":        {kind: schemaKindString, check: stringCheck(ValidateReleaseChannel)},
+		"tmux": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"layout": {kind: schemaKindString, check: stringCheck(ValidateTmuxLayout)},
+			},
+		},
+		"wsl": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"windowsClaude": {kind: schemaKindBool},
+			},
+		},
+		"autoRestartCrashed":  {kind: schemaKindBool},
+		"windowTitleTemplate": {kind: schemaKindString, check: stringCheck(ValidateWindowTitleTemplate)},
+		"suspendAfterIdle": {
+			kind: schemaKindString,
+			check: stringCheck(func(v string) error {
+				_, err := ParseSuspendAfterIdle(v)
+				return err
+			}),
+		},
+		"missionAutoArchiveAfter": retentionDaysSchema,
+		"missionAutoDeleteAfter":  retentionDaysSchema,
+		"serverListen": {
+			kind: schemaKindString,
+			check: stringCheck(func(v string) error {
+				_, err := ParseServerListenAddr(v)
+				return err
+			}),
+		},
+	},
+}
+
+var endpointRateLimitSchema = &schemaNode{
+	kind:     schemaKindObject,
+	required: []string{"perMinute"},
+	properties: map[string]*schemaNode{
+		"perMinute": {kind: schemaKindInt, check: nonNegativeIntCheck},
+		"burst":     {kind: schemaKindInt, check: nonNegativeIntCheck},
+	},
+}
+
+var nonNegativeIntCheck = intCheck(func(v int) error {
+	if v < 0 {
+		return stacktrace.NewError("cannot be negative, got %d", v)
+	}
+	return nil
+})
+
+var retentionDaysSchema = &schemaNode{
+	kind: schemaKindString,
+	check: stringCheck(func(v string) error {
+		_, err := ParseRetentionDays(v)
+		return err
+	}),
+}
+
+var repoConfigSchema = &schemaNode{
+	kind: schemaKindObject,
+	properties: map[string]*schemaNode{
+		"alwaysSynced": {kind: schemaKindBool},
+		"emoji":        {kind: schemaKindString},
+		"title":        {kind: schemaKindString},
+		"description":  {kind: schemaKindString},
+		"trustedMcpServers": {
+			description: `"all" or a list of server names`,
+			anyOf: []*schemaNode{
+				{kind: schemaKindString, check: stringCheck(func(v string) error {
+					if v != "all" {
+						return stacktrace.NewError("must be \"all\" or a list of server names, got %q", v)
+					}
+					return nil
+				})},
+				{kind: schemaKindArray, items: &schemaNode{kind: schemaKindString}, check: func(_ string, n ast.Node) error {
+					if seq, ok := n.(*ast.SequenceNode); ok && len(seq.Values) == 0 {
+						return stacktrace.NewError("empty list is not valid; use \"all\" to trust all servers, or list at least one server name")
+					}
+					return nil
+				}},
+			},
+		},
+		"mcpServers": {
+			kind:     schemaKindMap,
+			keyCheck: ValidateMcpServerName,
+			values:   mcpServerSchema,
+		},
+		"defaultModel":        {kind: schemaKindString},
+		"postUpdateHook":      {kind: schemaKindString},
+		"postUpdateHookCache": {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString, check: stringCheck(ValidateDependencyCachePath)}},
+		"claudeArgs":          {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString}},
+		"writeableCopy":       {kind: schemaKindString},
+		"workspaceMode":       {kind: schemaKindString, check: stringCheck(ValidateWorkspaceMode)},
+		"autoBranch":          {kind: schemaKindBool},
+		"autoBranchTemplate":  {kind: schemaKindString, check: stringCheck(ValidateAutoBranchTemplate)},
+		"isolation":           {kind: schemaKindString, check: stringCheck(ValidateIsolation)},
+		"upstream":            {kind: schemaKindString},
+		"backend":             {kind: schemaKindString},
+		"claudeMdAppend":      {kind: schemaKindString},
+		"networkPolicy": {
+			kind: schemaKindObject,
+			properties: map[string]*schemaNode{
+				"allowedHosts": {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString, check: stringCheck(ValidateNetworkPolicyHost)}},
+			},
+		},
+		"env": {
+			kind:     schemaKindMap,
+			keyCheck: ValidateEnvVarName,
+			values:   &schemaNode{kind: schemaKindString},
+		},
+		"reviewRequired":      {kind: schemaKindBool},
+		"windowTitleTemplate": {kind: schemaKindString, check: stringCheck(ValidateWindowTitleTemplate)},
+	},
+}
+
+var agentBackendTemplateSchema = &schemaNode{kind: schemaKindArray, items: &schemaNode{kind: schemaKindString}}
+
+var agentBackendSchema = &schemaNode{
+	kind:     schemaKindObject,
+	required: []string{"interactive"},
+	properties: map[string]*schemaNode{
+		"interactive": agentBackendTemplateSchema,
+		"headless":    agentBackendTemplateSchema,
+		"resume":      agentBackendTemplateSchema,
+	},
+}
+
+var mcpServerSchema = &schemaNode{
+	kind: schemaKindObject,
+	properties: map[string]*schemaNode{
+		"type": {kind: schemaKindString, check: stringCheck(func(v string) error {
+			switch v {
+			case McpServerTypeStdio, McpServerTypeSSE, McpServerTypeHTTP:
+				return nil
+			}
+			return stacktrace.NewError("must be '%s', '%s', or '%s', got '%s'", McpServerTypeStdio, McpServerTypeSSE, McpServerTypeHTTP, v)
+		})},
+		"command": {kind: schemaKindString},
+		"args":    {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString}},
+		"env":     {kind: schemaKindMap, values: &schemaNode{kind: schemaKindString}},
+		"url":     {kind: schemaKindString},
+		"headers": {kind: schemaKindMap, values: &schemaNode{kind: schemaKindString}},
+	},
+}
+
+var cronConfigSchema = &schemaNode{
+	kind:     schemaKindObject,
+	required: []string{"prompt"}, // plus exactly one of schedule/runAt, checked by validateCronConfigs
+	properties: map[string]*schemaNode{
+		"id":       {kind: schemaKindString},
+		"schedule": {kind: schemaKindString, check: stringCheck(ValidateCronSchedule)},
+		"runAt": {kind: schemaKindString, check: stringCheck(func(v string) error {
+			_, err := ParseCronRunAt(v)
+			return err
+		})},
+		"prompt":      {kind: schemaKindString, check: stringCheck(requireNonEmpty)},
+		"description": {kind: schemaKindString},
+		"repo": {kind: schemaKindString, check: stringCheck(func(v string) error {
+			if v == "" {
+				return nil
+			}
+			return checkCanonicalRepoName(v)
+		})},
+		"ref": {kind: schemaKindString, check: stringCheck(func(v string) error {
+			if v == "" {
+				return nil
+			}
+			return ValidateCronRef(v)
+		})},
+		"enabled":              {kind: schemaKindBool},
+		"notificationsEnabled": {kind: schemaKindBool},
+		"after": {kind: schemaKindString, check: stringCheck(func(v string) error {
+			if v == "" {
+				return nil
+			}
+			return ValidateCronName(v)
+		})},
+		"maxPrompts": {kind: schemaKindInt, check: intCheck(func(v int) error {
+			if v < 0 {
+				return stacktrace.NewError("maxPrompts cannot be negative, got %d", v)
+			}
+			return nil
+		})},
+		// Strings accept any scalar, so this admits both 5 and 2.50; the check
+		// rejects non-numeric values.
+		"budgetUsd": {kind: schemaKindString, description: "a number", check: floatCheck(func(v float64) error {
+			if v < 0 {
+				return stacktrace.NewError("budgetUsd cannot be negative, got %v", v)
+			}
+			return nil
+		})},
+		"env": {
+			kind:     schemaKindMap,
+			keyCheck: ValidateEnvVarName,
+			values:   &schemaNode{kind: schemaKindString},
+		},
+		"notify": {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString, check: stringCheck(func(v string) error {
+			_, err := notify.ParseTarget(v)
+			return err
+		})}},
+		"project": {kind: schemaKindString, check: stringCheck(func(v string) error {
+			if v == "" {
+				return nil
+			}
+			return ValidateProjectName(v)
+		})},
+		"ignoreQuietHours": {kind: schemaKindBool},
+		"resumePolicy": {kind: schemaKindString, check: stringCheck(func(v string) error {
+			_, err := ParseCronResumePolicy(v)
+			return err
+		})},
+		"alertOnConsecutiveFailures": {kind: schemaKindInt, check: intCheck(func(v int) error {
+			if v < 0 {
+				return stacktrace.NewError("alertOnConsecutiveFailures cannot be negative, got %d", v)
+			}
+			return nil
+		})},
+	},
+}
+
+var paletteCommandSchema = &schemaNode{
+	kind: schemaKindObject,
+	properties: map[string]*schemaNode{
+		"title":          {kind: schemaKindString},
+		"description":    {kind: schemaKindString},
+		"command":        {kind: schemaKindString},
+		"tmuxKeybinding": {kind: schemaKindString},
+		"disabled":       {kind: schemaKindBool},
+	},
+	check: func(name string, n ast.Node) error {
+		// Custom (non-builtin) entries with content must have title and command
+		var cmdCfg PaletteCommandConfig
+		if err := yaml.NodeToValue(n, &cmdCfg); err != nil {
+			return nil // shape errors are already reported per field
+		}
+		if _, isBuiltin := BuiltinPaletteCommands[name]; isBuiltin || cmdCfg.IsEmpty() || cmdCfg.Disabled {
+			return nil
+		}
+		if cmdCfg.Title == nil || *cmdCfg.Title == "" {
+			return stacktrace.NewError("custom palette command must have a title")
+		}
+		if cmdCfg.Command == nil || *cmdCfg.Command == "" {
+			return stacktrace.NewError("custom palette command must have a command")
+		}
+		return nil
+	},
+}
+
+var sleepWindowSchema = &schemaNode{
+	kind:     schemaKindObject,
+	required: []string{"days", "start", "end"},
+	properties: map[string]*schemaNode{
+		"days": {kind: schemaKindArray, items: &schemaNode{kind: schemaKindString}, check: func(_ string, n ast.Node) error {
+			var days []string
+			if err := yaml.NodeToValue(n, &days); err != nil {
+				return nil
+			}
+			return sleep.ValidateDays(days)
+		}},
+		"start": {kind: schemaKindString, check: stringCheck(sleep.ValidateTime)},
+		"end":   {kind: schemaKindString, check: stringCheck(sleep.ValidateTime)},
+	},
+	check: func(_ string, n ast.Node) error {
+		var w sleep.WindowDef
+		if err := yaml.NodeToValue(n, &w); err != nil {
+			return nil
+		}
+		if w.Start != "" && w.Start == w.End {
+			return stacktrace.NewError("window start and end must differ (both are %q)", w.Start)
+		}
+		return nil
+	},
+}
+
+func checkCanonicalRepoName(name string) error {
+	if !canonicalRepoRegex.MatchString(name) {
+		return stacktrace.NewError("'%s' must be in canonical format 'host/owner/repo'", name)
+	}
+	return nil
+}
+
+func requireNonEmpty(v string) error {
+	if v == "" {
+		return stacktrace.NewError("cannot be empty")
+	}
+	return nil
+}
+
+// stringCheck adapts a string validator to a schemaNode check.
+func stringCheck(fn func(string) error) func(string, ast.Node) error {
+	return func(_ string, n ast.Node) error {
+		var v string
+		if err := yaml.NodeToValue(n, &v); err != nil {
+			return err
+		}
+		return fn(v)
+	}
+}
+
+// intCheck adapts an int validator to a schemaNode check.
+func intCheck(fn func(int) error) func(string, ast.Node) error {
+	return func(_ string, n ast.Node) error {
+		var v int
+		if err := yaml.NodeToValue(n, &v); err != nil {
+			return err
+		}
+		return fn(v)
+	}
+}
+
+// floatCheck adapts a float validator to a schemaNode check.
+func floatCheck(fn func(float64) error) func(string, ast.Node) error {
+	return func(_ string, n ast.Node) error {
+		var v float64
+		if err := yaml.NodeToValue(n, &v); err != nil {
+			return err
+		}
+		return fn(v)
+	}
+}
+
+// ============================================================================
+// Validation walk
+// ============================================================================
+
+// yamlNodeKind classifies a YAML node for schema matching.
+type yamlNodeKind int
+
+const (
+	yamlNodeNull yamlNodeKind = iota
+	yamlNodeMapping
+	yamlNodeSequence
+	yamlNodeString
+	yamlNodeInt
+	yamlNodeBool
+	yamlNodeOtherScalar
+	yamlNodeUnknown // aliases and anything else the walk cannot see through
+)
+
+func (k yamlNodeKind) String() string {
+	switch k {
+	case yamlNodeNull:
+		return "null"
+	case yamlNodeMapping:
+		return "a mapping"
+	case yamlNodeSequence:
+		return "a list"
+	case yamlNodeInt:
+		return "an integer"
+	case yamlNodeBool:
+		return "a boolean"
+	case yamlNodeString:
+		return "a string"
+	default:
+		return "a scalar"
+	}
+}
+
+func (s *schemaNode) expectation() string {
+	if s.description != "" {
+		return s.description
+	}
+	switch s.kind {
+	case schemaKindObject, schemaKindMap:
+		return "a mapping"
+	case schemaKindArray:
+		return "a list"
+	case schemaKindInt:
+		return "an integer"
+	case schemaKindBool:
+		return "a boolean (true or false)"
+	default:
+		return "a string"
+	}
+}
+
+// accepts reports whether a node of the given kind has the shape s expects.
+// Strings accept any scalar because the decoder stringifies them.
+func (s *schemaNode) accepts(kind yamlNodeKind) bool {
+	switch s.kind {
+	case schemaKindObject, schemaKindMap:
+		return kind == yamlNodeMapping
+	case schemaKindArray:
+		return kind == yamlNodeSequence
+	case schemaKindInt:
+		return kind == yamlNodeInt
+	case schemaKindBool:
+		return kind == yamlNodeBool
+	default:
+		return kind == yamlNodeString || kind == yamlNodeInt || kind == yamlNodeBool || kind == yamlNodeOtherScalar
+	}
+}
+
+// validate checks node against s, appending issues. path is the dotted path
+// to node and key is its property or map key.
+func (s *schemaNode) validate(node ast.Node, path string, key string, issues *[]ConfigIssue) {
+	node = unwrapYAMLNode(node)
+	kind := classifyYAMLNode(node)
+	if kind == yamlNodeUnknown || kind == yamlNodeNull {
+		// Nulls decode to zero values; required-ness is checked by the parent
+		return
+	}
+
+	if len(s.anyOf) > 0 {
+		for _, alt := range s.anyOf {
+			if alt.accepts(kind) {
+				alt.validate(node, path, key, issues)
+				return
+			}
+		}
+		addConfigIssue(issues, node, path, "expected %s, got %s", s.expectation(), kind)
+		return
+	}
+
+	if !s.accepts(kind) {
+		addConfigIssue(issues, node, path, "expected %s, got %s", s.expectation(), kind)
+		return
+	}
+
+	switch s.kind {
+	case schemaKindObject:
+		present := map[string]bool{}
+		for _, entry := range mappingEntries(node) {
+			name, ok := mappingKeyString(entry)
+			if !ok {
+				continue
+			}
+			childPath := joinConfigPath(path, name)
+			prop, known := s.properties[name]
+			if !known {
+				*issues = append(*issues, ConfigIssue{
+					Severity: ConfigIssueSeverityWarning,
+					Path:     childPath,
+					Line:     nodeLine(entry.Key),
+					Column:   nodeColumn(entry.Key),
+					Message:  "unknown field (ignored)" + suggestConfigField(name, s.properties),
+				})
+				continue
+			}
+			if classifyYAMLNode(unwrapYAMLNode(entry.Value)) != yamlNodeNull {
+				present[name] = true
+			}
+			prop.validate(entry.Value, childPath, name, issues)
+		}
+		// Report missing fields at the mapping's first key, which is where the
+		// entry visibly starts; the mapping node itself points at a colon
+		entries := mappingEntries(node)
+		for _, name := range s.required {
+			if !present[name] {
+				var at ast.Node = node
+				if len(entries) > 0 {
+					at = entries[0].Key
+				}
+				addConfigIssue(issues, at, path, "missing required field '%s'", name)
+			}
+		}
+
+	case schemaKindMap:
+		for _, entry := range mappingEntries(node) {
+			name, ok := mappingKeyString(entry)
+			if !ok {
+				continue
+			}
+			childPath := joinConfigPath(path, name)
+			if s.keyCheck != nil {
+				if err := s.keyCheck(name); err != nil {
+					addConfigIssue(issues, entry.Key, childPath, "%#s", err)
+					continue
+				}
+			}
+			if s.values != nil {
+				s.values.validate(entry.Value, childPath, name, issues)
+			}
+		}
+
+	case schemaKindArray:
+		if seq, ok := node.(*ast.SequenceNode); ok && s.items != nil {
+			for i, item := range seq.Values {
+				s.items.validate(item, fmt.Sprintf("%s[%d]", path, i), key, issues)
+			}
+		}
+	}
+
+	if s.check != nil {
+		if err := s.check(key, node); err != nil {
+			addConfigIssue(issues, node, path, "%#s", err)
+		}
+	}
+}
+
+func addConfigIssue(issues *[]ConfigIssue, node ast.Node, path string, format string, args ...any) {
+	*issues = append(*issues, ConfigIssue{
+		Severity: ConfigIssueSeverityError,
+		Path:     path,
+		Line:     nodeLine(node),
+		Column:   nodeColumn(node),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// unwrapYAMLNode strips anchors, tags, and documents to reach the value node.
+func unwrapYAMLNode(node ast.Node) ast.Node {
+	for {
+		switch n := node.(type) {
+		case *ast.AnchorNode:
+			node = n.Value
+		case *ast.TagNode:
+			node = n.Value
+		case *ast.DocumentNode:
+			node = n.Body
+		default:
+			return node
+		}
+	}
+}
+
+func classifyYAMLNode(node ast.Node) yamlNodeKind {
+	switch node.(type) {
+	case nil, *ast.NullNode:
+		return yamlNodeNull
+	case *ast.MappingNode, *ast.MappingValueNode:
+		return yamlNodeMapping
+	case *ast.SequenceNode:
+		return yamlNodeSequence
+	case *ast.StringNode, *ast.LiteralNode:
+		return yamlNodeString
+	case *ast.IntegerNode:
+		return yamlNodeInt
+	case *ast.BoolNode:
+		return yamlNodeBool
+	case *ast.FloatNode, *ast.InfinityNode, *ast.NanNode:
+		return yamlNodeOtherScalar
+	default:
+		return yamlNodeUnknown
+	}
+}
+
+// mappingEntries returns the key/value pairs of a mapping node. The parser
+// represents a single-entry mapping as a bare MappingValueNode.
+func mappingEntries(node ast.Node) []*ast.MappingValueNode {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		return n.Values
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}
+	}
+	return nil
+}
+
+// mappingKeyString returns an entry's key as a string. Merge keys ("<<") and
+// non-scalar keys are skipped.
+func mappingKeyString(entry *ast.MappingValueNode) (string, bool) {
+	if entry.Key == nil || entry.Key.IsMergeKey() {
+		return "", false
+	}
+	scalar, ok := entry.Key.(ast.ScalarNode)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprint(scalar.GetValue()), true
+}
+
+// configPathSegmentRegex matches keys that can appear bare in a dotted path.
+var configPathSegmentRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// joinConfigPath appends key to a dotted path, bracket-quoting keys that
+// contain dots or other punctuation (e.g. repoConfig["github.com/o/r"]).
+func joinConfigPath(path string, key string) string {
+	if !configPathSegmentRegex.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// suggestConfigField returns a "did you mean" hint when name differs from a
+// known property only by case, which is the most common config.yml typo.
+func suggestConfigField(name string, properties map[string]*schemaNode) string {
+	for known := range properties {
+		if strings.EqualFold(known, name) {
+			return fmt.Sprintf("; did you mean '%s'?", known)
+		}
+	}
+	return ""
+}
+
+func setIssuePosition(issue *ConfigIssue, tk *token.Token) {
+	if tk != nil && tk.Position != nil {
+		issue.Line = tk.Position.Line
+		issue.Column = tk.Position.Column
+	}
+}
+
+func nodeLine(node ast.Node) int {
+	if node == nil || node.GetToken() == nil || node.GetToken().Position == nil {
+		return 0
+	}
+	return node.GetToken().Position.Line
+}
+
+func nodeColumn(node ast.Node) int {
+	if node == nil || node.GetToken() == nil || node.GetToken().Position == nil {
+		return 0
+	}
+	return node.GetToken().Position.Column
+}
@@ -0,0 +1,307 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintConfigYAML_ReportsLineAndPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		yaml       string
+		wantLine   int
+		wantColumn int
+		wantPath   string
+		wantSubstr string
+	}{
+		{
+			name: "bad workspaceMode",
+			yaml: `repoConfig:
+  github.com/owner/repo:
+    workspaceMode: symlink
+`,
+			wantLine:   3,
+			wantColumn: 20,
+			wantPath:   `repoConfig["github.com/owner/repo"].workspaceMode`,
+			wantSubstr: "workspaceMode must be",
+		},
+		{
+			name: "wrong type for bool",
+			yaml: `repoConfig:
+  github.com/owner/repo:
+    alwaysSynced: sometimes
+`,
+			wantLine:   3,
+			wantColumn: 19,
+			wantPath:   `repoConfig["github.com/owner/repo"].alwaysSynced`,
+			wantSubstr: "expected a boolean",
+		},
+		{
+			name: "non-canonical repo key",
+			yaml: `repoConfig:
+  owner/repo:
+    alwaysSynced: true
+`,
+			wantLine:   2,
+			wantColumn: 3,
+			wantPath:   `repoConfig["owner/repo"]`,
+			wantSubstr: "canonical format",
+		},
+		{
+			name: "invalid cron schedule",
+			yaml: `crons:
+  daily:
+    prompt: hi
+    schedule: every day
+`,
+			wantLine:   4,
+			wantColumn: 15,
+			wantPath:   "crons.daily.schedule",
+			wantSubstr: "invalid cron schedule",
+		},
+		{
+			name: "missing required cron prompt",
+			yaml: `crons:
+  daily:
+    schedule: "0 9 * * *"
+`,
+			wantLine:   3,
+			wantColumn: 5,
+			wantPath:   "crons.daily",
+			wantSubstr: "missing required field 'prompt'",
+		},
+		{
+			name: "sleep window time",
+			yaml: `sleepMode:
+  windows:
+    - days: [mon]
+      start: "25:00"
+      end: "07:00"
+`,
+			wantLine:   4,
+			wantColumn: 14,
+			wantPath:   "sleepMode.windows[0].start",
+			wantSubstr: "hour out of range",
+		},
+		{
+			name: "non-numeric cron budget",
+			yaml: `crons:
+  daily:
+    prompt: hi
+    schedule: "0 9 * * *"
+    budgetUsd: lots
+`,
+			wantLine:   5,
+			wantColumn: 16,
+			wantPath:   "crons.daily.budgetUsd",
+			wantSubstr: "",
+		},
+		{
+			name: "unknown MCP server type",
+			yaml: `repoConfig:
+  github.com/owner/repo:
+    mcpServers:
+      acme:
+        type: websocket
+`,
+			wantLine:   5,
+			wantColumn: 15,
+			wantPath:   `repoConfig["github.com/owner/repo"].mcpServers.acme.type`,
+			wantSubstr: "must be 'stdio', 'sse', or 'http'",
+		},
+		{
+			name: "list where string expected",
+			yaml: `defaultModel:
+  - opus
+`,
+			wantLine:   2,
+			wantColumn: 3,
+			wantPath:   "defaultModel",
+			wantSubstr: "expected a string, got a list",
+		},
+		{
+			name:       "syntax error",
+			yaml:       "crons:\n  daily: [unclosed\n",
+			wantLine:   2,
+			wantSubstr: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := lintConfigYAML([]byte(tt.yaml))
+			if !hasConfigErrors(issues) {
+				t.Fatalf("expected an error issue, got %v", issues)
+			}
+			issue := issues[0]
+			if issue.Line != tt.wantLine {
+				t.Errorf("line = %d, want %d (%s)", issue.Line, tt.wantLine, issue)
+			}
+			if tt.wantColumn != 0 && issue.Column != tt.wantColumn {
+				t.Errorf("column = %d, want %d (%s)", issue.Column, tt.wantColumn, issue)
+			}
+			if issue.Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", issue.Path, tt.wantPath)
+			}
+			if !strings.Contains(issue.Message, tt.wantSubstr) {
+				t.Errorf("message %q does not contain %q", issue.Message, tt.wantSubstr)
+			}
+		})
+	}
+}
+
+func TestLintConfigYAML_ValidConfig(t *testing.T) {
+	issues := lintConfigYAML([]byte(`
+repoConfig:
+  github.com/owner/repo:
+    alwaysSynced: true
+    emoji: "🚀"
+    trustedMcpServers: all
+    workspaceMode: worktree
+    claudeArgs: [--verbose]
+    backend: aider
+  github.com/owner/other:
+    trustedMcpServers:
+      - github
+crons:
+  daily:
+    schedule: "0 9 * * *"
+    prompt: Summarize yesterday
+    repo: github.com/owner/repo
+    enabled: true
+    maxPrompts: 5
+    budgetUsd: 2.50
+  weekly:
+    schedule: "0 9 * * 1"
+    prompt: Summarize last week
+    budgetUsd: 10
+paletteCommands:
+  myCmd:
+    title: My command
+    command: agenc mission new
+  newMission:
+    disabled: true
+sessionTitleMaxWords: 10
+sleepMode:
+  windows:
+    - days: [mon, tue]
+      start: "23:00"
+      end: "07:00"
+agentBackends:
+  aider:
+    interactive: [aider, --message, "{{prompt}}"]
+    headless: [aider, --yes, --message, "{{prompt}}"]
+`))
+	if len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestLintConfigYAML_UnknownFieldIsWarning(t *testing.T) {
+	issues := lintConfigYAML([]byte(`
+defaultmodel: opus
+repoConfig:
+  github.com/owner/repo:
+    synced: true
+`))
+	if hasConfigErrors(issues) {
+		t.Fatalf("unknown fields should not be errors, got %v", issues)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", issues)
+	}
+	if issues[0].Path != "defaultmodel" || !strings.Contains(issues[0].Message, "did you mean 'defaultModel'") {
+		t.Errorf("unexpected first warning: %s", issues[0])
+	}
+	if issues[1].Path != `repoConfig["github.com/owner/repo"].synced` {
+		t.Errorf("unexpected second warning path: %s", issues[1].Path)
+	}
+}
+
+func TestReadAgencConfig_SchemaErrorIncludesPosition(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `crons:
+  daily:
+    schedule: "0 9 * * *"
+    prompt: hi
+    enabled: yes please
+`)
+
+	_, _, err := ReadAgencConfig(tmpDir)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "config.yml:5:14: crons.daily.enabled: expected a boolean") {
+		t.Errorf("error does not carry position and path: %v", err)
+	}
+}
+
+func TestValidateConfigFile_SemanticErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigYAML(t, tmpDir, `paletteCommands:
+  first:
+    title: Same
+    command: echo one
+  second:
+    title: Same
+    command: echo two
+`)
+
+	issues, err := ValidateConfigFile(GetConfigFilepath(tmpDir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasConfigErrors(issues) {
+		t.Fatal("expected a duplicate-title error")
+	}
+}
+
+func TestValidateConfigFile_MissingFile(t *testing.T) {
+	issues, err := ValidateConfigFile(GetConfigFilepath(t.TempDir()))
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("expected no issues for a missing file, got %v, %v", issues, err)
+	}
+}
+
+// TestAgencConfigSchemaCoversStruct guards against adding a config field
+// without teaching the schema about it, which would make validate warn that
+// the new field is unknown.
+func TestAgencConfigSchemaCoversStruct(t *testing.T) {
+	checkSchemaCoversStruct(t, "AgencConfig", agencConfigSchema, reflect.TypeOf(AgencConfig{}))
+}
+
+func checkSchemaCoversStruct(t *testing.T, path string, schema *schemaNode, typ reflect.Type) {
+	t.Helper()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		prop, ok := schema.properties[name]
+		if !ok {
+			t.Errorf("%s.%s (yaml %q) is missing from the config schema", path, field.Name, name)
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch {
+		case prop.kind == schemaKindObject && fieldType.Kind() == reflect.Struct:
+			checkSchemaCoversStruct(t, path+"."+field.Name, prop, fieldType)
+		case prop.kind == schemaKindMap && fieldType.Kind() == reflect.Map && prop.values != nil:
+			checkSchemaCoversStruct(t, path+"."+field.Name+"[]", prop.values, fieldType.Elem())
+		case prop.kind == schemaKindArray && fieldType.Kind() == reflect.Slice && prop.items != nil && prop.items.kind == schemaKindObject:
+			checkSchemaCoversStruct(t, path+"."+field.Name+"[]", prop.items, fieldType.Elem())
+		}
+	}
+}
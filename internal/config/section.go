@@ -0,0 +1,159 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/mieubrisse/stacktrace"
+)
+
+// ConfigSectionNames returns the top-level config.yml keys, sorted. Each is a
+// section that 'agenc config edit <section>' can edit on its own.
+func ConfigSectionNames() []string {
+	names := make([]string, 0, len(agencConfigSchema.properties))
+	for name := range agencConfigSchema.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsConfigSection reports whether section is a top-level config.yml key.
+func IsConfigSection(section string) bool {
+	_, ok := agencConfigSchema.properties[section]
+	return ok
+}
+
+// configSectionFieldIndex returns the index of the AgencConfig field whose
+// YAML key is section.
+func configSectionFieldIndex(section string) (int, bool) {
+	t := reflect.TypeOf(AgencConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == section {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// isConfigSectionCommentPath reports whether a CommentMap path belongs to
+// section: the section key itself or anything beneath it.
+func isConfigSectionCommentPath(path string, section string) bool {
+	prefix := "$." + section
+	return path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[")
+}
+
+// MarshalConfigSection renders one top-level section of cfg as a standalone
+// YAML document, keyed by the section name so comment paths and line numbers
+// line up with the full config. The section's comments are carried over from
+// cm. An unset section renders as a bare key ready to fill in.
+func MarshalConfigSection(cfg *AgencConfig, cm yaml.CommentMap, section string) ([]byte, error) {
+	idx, ok := configSectionFieldIndex(section)
+	if !ok {
+		return nil, stacktrace.NewError("unknown config section '%s'", section)
+	}
+
+	value := reflect.ValueOf(cfg).Elem().Field(idx)
+	if value.IsZero() || (value.Kind() == reflect.Map && value.Len() == 0) {
+		return []byte(section + ":\n"), nil
+	}
+
+	var sectionOnly AgencConfig
+	reflect.ValueOf(&sectionOnly).Elem().Field(idx).Set(value)
+
+	sectionCM := yaml.CommentMap{}
+	for path, comments := range cm {
+		if isConfigSectionCommentPath(path, section) {
+			sectionCM[path] = comments
+		}
+	}
+
+	data, err := yaml.MarshalWithOptions(&sectionOnly, yaml.WithComment(sectionCM))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to marshal config section '%s'", section)
+	}
+	return data, nil
+}
+
+// ApplyConfigSection replaces one top-level section of cfg with data, a YAML
+// document produced by MarshalConfigSection and then edited. The result is
+// validated as a whole config, exactly as ReadAgencConfig would, before
+// anything is returned; cfg and cm are left untouched. The returned comment
+// map keeps cm's comments outside the section and takes the section's
+// comments from data.
+func ApplyConfigSection(cfg *AgencConfig, cm yaml.CommentMap, section string, data []byte) (*AgencConfig, yaml.CommentMap, error) {
+	idx, ok := configSectionFieldIndex(section)
+	if !ok {
+		return nil, nil, stacktrace.NewError("unknown config section '%s'", section)
+	}
+	label := "section '" + section + "'"
+
+	if issues := lintConfigYAML(data); hasConfigErrors(issues) {
+		return nil, nil, configIssuesError(label, issues)
+	}
+
+	var topLevel map[string]any
+	if err := yaml.Unmarshal(data, &topLevel); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "failed to parse %s", label)
+	}
+	for key := range topLevel {
+		if key != section {
+			return nil, nil, stacktrace.NewError("%s may only contain the '%s' key; found '%s'", label, section, key)
+		}
+	}
+
+	var edited AgencConfig
+	editedCM := yaml.CommentMap{}
+	if err := yaml.UnmarshalWithOptions(data, &edited, yaml.CommentToMap(editedCM)); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "failed to parse %s", label)
+	}
+
+	merged := *cfg
+	reflect.ValueOf(&merged).Elem().Field(idx).Set(reflect.ValueOf(edited).Field(idx))
+
+	mergedCM := yaml.CommentMap{}
+	for path, comments := range cm {
+		if !isConfigSectionCommentPath(path, section) {
+			mergedCM[path] = comments
+		}
+	}
+	for path, comments := range editedCM {
+		if isConfigSectionCommentPath(path, section) {
+			mergedCM[path] = comments
+		}
+	}
+
+	newCfg, err := revalidateAgencConfig(&merged, label)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newCfg, mergedCM, nil
+}
+
+// RevalidateAgencConfig runs the checks ReadAgencConfig applies against a
+// config built or changed in memory, returning the normalized config that
+// ReadAgencConfig would load once it is written.
+func RevalidateAgencConfig(cfg *AgencConfig) (*AgencConfig, error) {
+	return revalidateAgencConfig(cfg, "config")
+}
+
+// revalidateAgencConfig round-trips cfg through YAML so the schema lint and
+// semantic checks see it exactly as ReadAgencConfig will after it is
+// written. label names the source in error messages.
+func revalidateAgencConfig(cfg *AgencConfig, label string) (*AgencConfig, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to marshal config")
+	}
+	if issues := lintConfigYAML(data); hasConfigErrors(issues) {
+		return nil, configIssuesError(label, issues)
+	}
+	newCfg, _, err := parseAgencConfig(data, label)
+	if err != nil {
+		return nil, err
+	}
+	return newCfg, nil
+}
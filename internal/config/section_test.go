@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+)
+
+const sectionTestConfig = `# Repos I work in
+repoConfig:
+  github.com/owner/repo:
+    # Shown in the palette
+    emoji: "🚀"
+crons:
+  # Runs before standup
+  nightly:
+    schedule: 0 6 * * *
+    prompt: Summarize yesterday
+defaultModel: sonnet # keep it cheap
+`
+
+func readSectionTestConfig(t *testing.T) (string, *AgencConfig, yaml.CommentMap) {
+	t.Helper()
+	agencDirpath := t.TempDir()
+	if err := os.MkdirAll(GetConfigDirpath(agencDirpath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetConfigFilepath(agencDirpath), []byte(sectionTestConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, cm, err := ReadAgencConfig(agencDirpath)
+	if err != nil {
+		t.Fatalf("ReadAgencConfig failed: %v", err)
+	}
+	return agencDirpath, cfg, cm
+}
+
+func TestConfigSectionNames_MatchStructFields(t *testing.T) {
+	for _, section := range ConfigSectionNames() {
+		if _, ok := configSectionFieldIndex(section); !ok {
+			t.Errorf("section '%s' has no AgencConfig field", section)
+		}
+	}
+	if !IsConfigSection("crons") || IsConfigSection("cronz") {
+		t.Error("IsConfigSection disagrees with the schema")
+	}
+}
+
+func TestMarshalConfigSection_KeepsOnlySectionAndItsComments(t *testing.T) {
+	_, cfg, cm := readSectionTestConfig(t)
+
+	data, err := MarshalConfigSection(cfg, cm, "crons")
+	if err != nil {
+		t.Fatalf("MarshalConfigSection failed: %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{"crons:", "# Runs before standup", "nightly:", "Summarize yesterday"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"repoConfig", "defaultModel", "Shown in the palette"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("expected section to omit %q, got:\n%s", unwanted, got)
+		}
+	}
+
+	empty, err := MarshalConfigSection(cfg, cm, "paletteCommands")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(empty) != "paletteCommands:\n" {
+		t.Errorf("expected a bare key for an unset section, got %q", empty)
+	}
+
+	if _, err := MarshalConfigSection(cfg, cm, "cronz"); err == nil {
+		t.Error("expected an error for an unknown section")
+	}
+}
+
+func TestApplyConfigSection_RoundTripsComments(t *testing.T) {
+	agencDirpath, cfg, cm := readSectionTestConfig(t)
+
+	edited := `crons:
+  # Runs before standup, now later
+  nightly:
+    schedule: 0 7 * * *
+    prompt: Summarize yesterday
+  weekly:
+    schedule: 0 9 * * 1
+    prompt: Plan the week
+`
+	newCfg, newCM, err := ApplyConfigSection(cfg, cm, "crons", []byte(edited))
+	if err != nil {
+		t.Fatalf("ApplyConfigSection failed: %v", err)
+	}
+	if got := newCfg.Crons["nightly"].Schedule; got != "0 7 * * *" {
+		t.Errorf("expected the edited schedule, got %q", got)
+	}
+	if _, ok := newCfg.Crons["weekly"]; !ok {
+		t.Error("expected the added cron")
+	}
+	if cfg.Crons["nightly"].Schedule != "0 6 * * *" {
+		t.Error("expected the original config untouched")
+	}
+
+	if err := WriteAgencConfig(agencDirpath, newCfg, newCM); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(GetConfigFilepath(agencDirpath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Repos I work in", "# Shown in the palette", "# keep it cheap", "# Runs before standup, now later"} {
+		if !strings.Contains(string(written), want) {
+			t.Errorf("expected written config to contain %q, got:\n%s", want, written)
+		}
+	}
+	if strings.Contains(string(written), "# Runs before standup\n") {
+		t.Errorf("expected the replaced section comment to be gone, got:\n%s", written)
+	}
+}
+
+func TestApplyConfigSection_RejectsInvalidEdits(t *testing.T) {
+	_, cfg, _ := readSectionTestConfig(t)
+
+	tests := []struct {
+		name       string
+		section    string
+		yaml       string
+		wantSubstr string
+	}{
+		{
+			name:       "schema error carries line",
+			section:    "crons",
+			yaml:       "crons:\n  nightly:\n    schedule: 0 6 * * *\n    prompt: hi\n    enabled: maybe\n",
+			wantSubstr: ":5:",
+		},
+		{
+			name:       "semantic error",
+			section:    "crons",
+			yaml:       "crons:\n  nightly:\n    schedule: not a schedule\n    prompt: hi\n",
+			wantSubstr: "nightly",
+		},
+		{
+			name:       "other section",
+			section:    "crons",
+			yaml:       "crons:\ndefaultModel: opus\n",
+			wantSubstr: "may only contain",
+		},
+		{
+			name:       "invalid repo key",
+			section:    "repoConfig",
+			yaml:       "repoConfig:\n  owner/repo:\n    emoji: x\n",
+			wantSubstr: "section 'repoConfig'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ApplyConfigSection(cfg, nil, tt.section, []byte(tt.yaml))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantSubstr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantSubstr, err)
+			}
+		})
+	}
+}
+
+func TestApplyConfigSection_EmptyClearsSection(t *testing.T) {
+	_, cfg, _ := readSectionTestConfig(t)
+	newCfg, _, err := ApplyConfigSection(cfg, nil, "crons", []byte("crons:\n"))
+	if err != nil {
+		t.Fatalf("ApplyConfigSection failed: %v", err)
+	}
+	if len(newCfg.Crons) != 0 {
+		t.Errorf("expected no crons, got %v", newCfg.Crons)
+	}
+	if newCfg.DefaultModel != "sonnet" {
+		t.Errorf("expected other sections kept, got defaultModel %q", newCfg.DefaultModel)
+	}
+}
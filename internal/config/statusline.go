@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Segments the statusline under each mission's Claude prompt can show.
+const (
+	// StatuslineSegmentMission is the mission's short ID
+	StatuslineSegmentMission = "mission"
+	// StatuslineSegmentBranch is the branch checked out in the workspace
+	StatuslineSegmentBranch = "branch"
+	// StatuslineSegmentConfigBehind is how many Claude config commits the
+	// mission is behind, shown only when it is behind
+	StatuslineSegmentConfigBehind = "configBehind"
+	// StatuslineSegmentBudget is spend and prompts against the mission's
+	// --budget-usd and --max-prompts limits, shown only when it has limits
+	StatuslineSegmentBudget = "budget"
+	// StatuslineSegmentCron is the cron that launched the mission, shown only
+	// for cron missions
+	StatuslineSegmentCron = "cron"
+)
+
+// StatuslineSegments are the segments statusline.segments may list.
+var StatuslineSegments = []string{
+	StatuslineSegmentMission,
+	StatuslineSegmentBranch,
+	StatuslineSegmentConfigBehind,
+	StatuslineSegmentBudget,
+	StatuslineSegmentCron,
+}
+
+// DefaultStatuslineSegments are shown when statusline.segments is unset.
+var DefaultStatuslineSegments = []string{StatuslineSegmentBudget}
+
+// statuslineSeparator joins rendered segments.
+const statuslineSeparator = " · "
+
+// StatuslineValues are what a mission's statusline segments render from.
+type StatuslineValues struct {
+	ShortID string
+	Branch  string
+	// ConfigCommitsBehind is how many shadow repo commits the mission's
+	// Claude config is behind HEAD, or -1 when its commit is no longer in the
+	// shadow repo's history
+	ConfigCommitsBehind int
+	// Budget is the mission's usage against its limits, empty when it has
+	// none
+	Budget   string
+	CronName string
+}
+
+// RenderStatusline renders segments in order, joined by " · ". Segments with
+// nothing to show are left out.
+func RenderStatusline(segments []string, values StatuslineValues) string {
+	var parts []string
+	for _, segment := range segments {
+		if rendered := renderStatuslineSegment(segment, values); rendered != "" {
+			parts = append(parts, rendered)
+		}
+	}
+	return strings.Join(parts, statuslineSeparator)
+}
+
+func renderStatuslineSegment(segment string, values StatuslineValues) string {
+	switch segment {
+	case StatuslineSegmentMission:
+		return values.ShortID
+	case StatuslineSegmentBranch:
+		if values.Branch == "" {
+			return ""
+		}
+		return "⎇ " + values.Branch
+	case StatuslineSegmentConfigBehind:
+		switch {
+		case values.ConfigCommitsBehind < 0:
+			return "config outdated"
+		case values.ConfigCommitsBehind > 0:
+			return fmt.Sprintf("config %d behind", values.ConfigCommitsBehind)
+		}
+		return ""
+	case StatuslineSegmentBudget:
+		return values.Budget
+	case StatuslineSegmentCron:
+		if values.CronName == "" {
+			return ""
+		}
+		return "cron " + values.CronName
+	}
+	return ""
+}
+
+// ValidateStatuslineSegment returns an error if segment is not one of
+// StatuslineSegments.
+func ValidateStatuslineSegment(segment string) error {
+	for _, known := range StatuslineSegments {
+		if segment == known {
+			return nil
+		}
+	}
+	return stacktrace.NewError("unknown statusline segment '%s'; supported segments are %s", segment, strings.Join(StatuslineSegments, ", "))
+}
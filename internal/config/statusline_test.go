@@ -0,0 +1,43 @@
+package config
+
+import "testing"
+
+func TestRenderStatusline(t *testing.T) {
+	values := StatuslineValues{
+		ShortID:             "2b4c8f1a",
+		Branch:              "agenc/2b4c8f1a-fix-login",
+		ConfigCommitsBehind: 3,
+		Budget:              "$1.23 / $5.00 · 3/10 prompts",
+	}
+	tests := []struct {
+		segments []string
+		values   StatuslineValues
+		want     string
+	}{
+		{[]string{"mission", "branch", "configBehind"}, values, "2b4c8f1a · ⎇ agenc/2b4c8f1a-fix-login · config 3 behind"},
+		// Segments with nothing to show are left out
+		{[]string{"cron", "budget", "mission"}, values, "$1.23 / $5.00 · 3/10 prompts · 2b4c8f1a"},
+		{[]string{"configBehind", "branch"}, StatuslineValues{ConfigCommitsBehind: -1}, "config outdated"},
+		{[]string{"cron", "configBehind"}, StatuslineValues{CronName: "nightly-triage"}, "cron nightly-triage"},
+		{DefaultStatuslineSegments, StatuslineValues{ShortID: "2b4c8f1a"}, ""},
+	}
+	for _, tt := range tests {
+		if got := RenderStatusline(tt.segments, tt.values); got != tt.want {
+			t.Errorf("RenderStatusline(%v) = %q, want %q", tt.segments, got, tt.want)
+		}
+	}
+}
+
+func TestGetStatuslineSegments(t *testing.T) {
+	cfg := &AgencConfig{}
+	if got := cfg.GetStatuslineSegments(); len(got) != 1 || got[0] != StatuslineSegmentBudget {
+		t.Errorf("expected the budget segment by default, got %v", got)
+	}
+	cfg.Statusline = &StatuslineConfig{Segments: []string{"mission", "branch"}}
+	if got := cfg.GetStatuslineSegments(); len(got) != 2 || got[1] != StatuslineSegmentBranch {
+		t.Errorf("expected the configured segments, got %v", got)
+	}
+	if err := ValidateStatuslineSegment("tokens"); err == nil {
+		t.Error("expected an unknown segment to be rejected")
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import "strings"
+
+// WindowTitlePlaceholders are the placeholders a windowTitleTemplate may use.
+var WindowTitlePlaceholders = []string{"{title}", "{repo}", "{shortID}", "{branch}", "{emoji}", "{status-emoji}"}
+
+// WindowTitleValues are what a windowTitleTemplate's placeholders expand to.
+type WindowTitleValues struct {
+	// Title is the mission's plain window title: its display name, session
+	// title, or repo name
+	Title string
+	// Repo is the repo's configured title, or the last segment of its name
+	Repo    string
+	ShortID string
+	// Branch is the branch checked out in the mission's workspace
+	Branch string
+	// Emoji is the repo's configured emoji
+	Emoji string
+	// StatusEmoji reflects whether Claude is working, idle, or needs attention
+	StatusEmoji string
+}
+
+// RenderWindowTitle expands a windowTitleTemplate. Placeholders with no value
+// expand to nothing, and the runs of whitespace that leaves are collapsed.
+func RenderWindowTitle(template string, values WindowTitleValues) string {
+	title := strings.NewReplacer(
+		"{title}", values.Title,
+		"{repo}", values.Repo,
+		"{shortID}", values.ShortID,
+		"{branch}", values.Branch,
+		"{emoji}", values.Emoji,
+		"{status-emoji}", values.StatusEmoji,
+	).Replace(template)
+	return strings.Join(strings.Fields(title), " ")
+}
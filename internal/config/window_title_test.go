@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestRenderWindowTitle(t *testing.T) {
+	values := WindowTitleValues{
+		Title:       "Fix login redirect",
+		Repo:        "widgets",
+		ShortID:     "2b4c8f1a",
+		Branch:      "agenc/2b4c8f1a-fix-login",
+		StatusEmoji: "🔄",
+	}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"{status-emoji} {repo}:{branch}", "🔄 widgets:agenc/2b4c8f1a-fix-login"},
+		{"{shortID} {title}", "2b4c8f1a Fix login redirect"},
+		// Empty placeholders don't leave doubled spaces behind
+		{"{emoji} {repo}  {title}", "widgets Fix login redirect"},
+		{"static", "static"},
+	}
+	for _, tt := range tests {
+		if got := RenderWindowTitle(tt.template, values); got != tt.want {
+			t.Errorf("RenderWindowTitle(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestValidateWindowTitleTemplate(t *testing.T) {
+	for _, template := range []string{"", "{status-emoji} {repo} {branch}", "{emoji} {title} ({shortID})"} {
+		if err := ValidateWindowTitleTemplate(template); err != nil {
+			t.Errorf("expected %q to be valid, got %v", template, err)
+		}
+	}
+	for _, template := range []string{"{status}", "{repo} {", "{missionID}"} {
+		if err := ValidateWindowTitleTemplate(template); err == nil {
+			t.Errorf("expected %q to be rejected", template)
+		}
+	}
+}
+
+func TestGetWindowTitleTemplate(t *testing.T) {
+	cfg := &AgencConfig{
+		WindowTitleTemplate: "{status-emoji} {title}",
+		RepoConfigs: map[string]RepoConfig{
+			"github.com/owner/custom": {WindowTitleTemplate: "{repo}:{branch}"},
+			"github.com/owner/plain":  {Emoji: "🎯"},
+		},
+	}
+	if got := cfg.GetWindowTitleTemplate("github.com/owner/custom"); got != "{repo}:{branch}" {
+		t.Errorf("expected the repo's template, got %q", got)
+	}
+	if got := cfg.GetWindowTitleTemplate("github.com/owner/plain"); got != "{status-emoji} {title}" {
+		t.Errorf("expected the global template, got %q", got)
+	}
+	if got := cfg.GetWindowTitleTemplate(""); got != "{status-emoji} {title}" {
+		t.Errorf("expected the global template for a blank mission, got %q", got)
+	}
+}
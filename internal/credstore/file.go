@@ -0,0 +1,199 @@
+package credstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+const (
+	fileStoreKeyFilename = "key"
+	fileStoreEntryExt    = ".enc"
+	fileStoreKeyBytes    = 32
+)
+
+// FileStore is the fallback backend for systems without a keyring. Each
+// credential is sealed with AES-256-GCM and written to its own file; the
+// random key lives alongside the entries in a 0600 file.
+//
+// Because the key is stored on the same disk, this protects against casual
+// disclosure (backups, dotfile syncing, grep) rather than against an attacker
+// with read access to the user's home directory.
+type FileStore struct {
+	dirpath string
+	mu      sync.Mutex
+}
+
+// NewFileStore returns a file-backed store rooted at dirpath. The directory
+// and key are created lazily on first write.
+func NewFileStore(dirpath string) *FileStore {
+	return &FileStore{dirpath: dirpath}
+}
+
+func (s *FileStore) Name() string {
+	return FileBackendName
+}
+
+func (s *FileStore) Read(serviceName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sealed, err := os.ReadFile(s.entryFilepath(serviceName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrNotFound
+		}
+		return "", stacktrace.Propagate(err, "failed to read credential file for service '%s'", serviceName)
+	}
+
+	key, err := s.loadKey(false)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", stacktrace.NewError("credential file for service '%s' is truncated", serviceName)
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(serviceName))
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to decrypt credential for service '%s'", serviceName)
+	}
+	return string(plaintext), nil
+}
+
+func (s *FileStore) Write(serviceName string, credential string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, err := s.loadKey(true)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return stacktrace.Propagate(err, "failed to generate nonce")
+	}
+	// The service name is bound as additional data so an entry file cannot be
+	// swapped in for a different service.
+	sealed := gcm.Seal(nonce, nonce, []byte(credential), []byte(serviceName))
+
+	// Write atomically so a crash never leaves a half-written entry. Each
+	// writer gets its own temp file, so concurrent processes don't clobber
+	// each other's before the rename.
+	entryFilepath := s.entryFilepath(serviceName)
+	tmpFilepath, err := s.writeTempFile(sealed)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to write credential file for service '%s'", serviceName)
+	}
+	if err := os.Rename(tmpFilepath, entryFilepath); err != nil {
+		_ = os.Remove(tmpFilepath)
+		return stacktrace.Propagate(err, "failed to install credential file for service '%s'", serviceName)
+	}
+	return nil
+}
+
+func (s *FileStore) Delete(serviceName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.entryFilepath(serviceName)); err != nil && !os.IsNotExist(err) {
+		return stacktrace.Propagate(err, "failed to delete credential file for service '%s'", serviceName)
+	}
+	return nil
+}
+
+// entryFilepath maps a service name to a filename. Service names contain
+// spaces and are user-influenced, so they are hashed rather than embedded.
+func (s *FileStore) entryFilepath(serviceName string) string {
+	sum := sha256.Sum256([]byte(serviceName))
+	return filepath.Join(s.dirpath, hex.EncodeToString(sum[:16])+fileStoreEntryExt)
+}
+
+// loadKey reads the store key, generating it (and the store directory) when
+// create is true and no key exists yet.
+func (s *FileStore) loadKey(create bool) ([]byte, error) {
+	keyFilepath := filepath.Join(s.dirpath, fileStoreKeyFilename)
+	key, err := os.ReadFile(keyFilepath)
+	if err == nil {
+		if len(key) != fileStoreKeyBytes {
+			return nil, stacktrace.NewError("credential store key at '%s' has invalid length %d", keyFilepath, len(key))
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) || !create {
+		return nil, stacktrace.Propagate(err, "failed to read credential store key at '%s'", keyFilepath)
+	}
+
+	if err := os.MkdirAll(s.dirpath, 0700); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to create credential store directory '%s'", s.dirpath)
+	}
+	key = make([]byte, fileStoreKeyBytes)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to generate credential store key")
+	}
+	// The key is written in full to a temp file and then linked into place:
+	// the link fails if another process installed a key first, and a reader
+	// never sees a partially written key
+	tmpFilepath, err := s.writeTempFile(key)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to write credential store key at '%s'", keyFilepath)
+	}
+	defer os.Remove(tmpFilepath)
+	if err := os.Link(tmpFilepath, keyFilepath); err != nil {
+		if os.IsExist(err) {
+			return s.loadKey(false)
+		}
+		return nil, stacktrace.Propagate(err, "failed to install credential store key at '%s'", keyFilepath)
+	}
+	return key, nil
+}
+
+// writeTempFile writes data to a new 0600 file with a unique name in the
+// store directory and returns its path, for the caller to move into place.
+func (s *FileStore) writeTempFile(data []byte) (string, error) {
+	f, err := os.CreateTemp(s.dirpath, ".*.tmp")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to create temp file in '%s'", s.dirpath)
+	}
+	tmpFilepath := f.Name()
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpFilepath)
+		return "", stacktrace.Propagate(err, "failed to write temp file '%s'", tmpFilepath)
+	}
+	return tmpFilepath, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to initialize cipher")
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to initialize GCM")
+	}
+	return gcm, nil
+}
@@ -0,0 +1,193 @@
+package credstore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFileStore_RoundTrip(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "credentials"))
+
+	if _, err := store.Read("Claude Code-credentials"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before write, got %v", err)
+	}
+
+	if err := store.Write("Claude Code-credentials", `{"claudeAiOauth":{"accessToken":"abc"}}`); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	got, err := store.Read("Claude Code-credentials")
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if got != `{"claudeAiOauth":{"accessToken":"abc"}}` {
+		t.Errorf("unexpected credential: %q", got)
+	}
+
+	// Overwrite replaces the previous value
+	if err := store.Write("Claude Code-credentials", "second"); err != nil {
+		t.Fatalf("overwrite failed: %v", err)
+	}
+	if got, _ := store.Read("Claude Code-credentials"); got != "second" {
+		t.Errorf("expected overwritten value, got %q", got)
+	}
+
+	if err := store.Delete("Claude Code-credentials"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Read("Claude Code-credentials"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+
+	// Deleting again is a no-op
+	if err := store.Delete("Claude Code-credentials"); err != nil {
+		t.Errorf("second Delete should succeed, got %v", err)
+	}
+}
+
+// TestFileStore_ConcurrentStores stands in for several processes sharing a
+// store directory: each FileStore has its own mutex, so only the filesystem
+// keeps them from racing on the key and entry files.
+func TestFileStore_ConcurrentStores(t *testing.T) {
+	dirpath := filepath.Join(t.TempDir(), "credentials")
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store := NewFileStore(dirpath)
+			value := fmt.Sprintf("credential-%d", i)
+			<-start
+			for range 20 {
+				if err := store.Write("shared", value); err != nil {
+					t.Errorf("Write failed: %v", err)
+					return
+				}
+			}
+			if err := store.Write(fmt.Sprintf("service-%d", i), value); err != nil {
+				t.Errorf("Write failed: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// Every entry was sealed with the one key that won
+	store := NewFileStore(dirpath)
+	for i := range 8 {
+		got, err := store.Read(fmt.Sprintf("service-%d", i))
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if got != fmt.Sprintf("credential-%d", i) {
+			t.Errorf("unexpected credential for service-%d: %q", i, got)
+		}
+	}
+	if got, err := store.Read("shared"); err != nil || !strings.HasPrefix(got, "credential-") {
+		t.Errorf("expected one writer's credential for the shared service, got %q (%v)", got, err)
+	}
+
+	tmpFilepaths, err := filepath.Glob(filepath.Join(dirpath, "*.tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmpFilepaths) > 0 {
+		t.Errorf("expected no temp files left behind, got %v", tmpFilepaths)
+	}
+}
+
+func TestFileStore_EncryptsAtRest(t *testing.T) {
+	dirpath := filepath.Join(t.TempDir(), "credentials")
+	store := NewFileStore(dirpath)
+
+	if err := store.Write("svc", "super-secret-token"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dirpath)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dirpath, entry.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if strings.Contains(string(data), "super-secret-token") {
+			t.Errorf("file %s contains plaintext credential", entry.Name())
+		}
+		info, err := entry.Info()
+		if err != nil {
+			t.Fatalf("Info failed: %v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("file %s has mode %o, want 0600", entry.Name(), info.Mode().Perm())
+		}
+	}
+}
+
+func TestFileStore_RejectsSwappedEntry(t *testing.T) {
+	dirpath := filepath.Join(t.TempDir(), "credentials")
+	store := NewFileStore(dirpath)
+
+	if err := store.Write("svc-a", "token-a"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := store.Write("svc-b", "token-b"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// Copy svc-a's sealed entry over svc-b's; decryption must fail because
+	// the service name is bound as additional data.
+	sealed, err := os.ReadFile(store.entryFilepath("svc-a"))
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if err := os.WriteFile(store.entryFilepath("svc-b"), sealed, 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if _, err := store.Read("svc-b"); err == nil {
+		t.Error("expected decryption failure for swapped entry")
+	}
+}
+
+func TestSelectStore(t *testing.T) {
+	t.Setenv("AGENC_DIRPATH", t.TempDir())
+
+	tests := []struct {
+		name     string
+		goos     string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "", goos: "darwin", wantName: KeychainBackendName},
+		{name: FileBackendName, goos: "darwin", wantName: FileBackendName},
+		{name: LibsecretBackendName, goos: "darwin", wantName: LibsecretBackendName},
+		{name: KeychainBackendName, goos: "linux", wantName: KeychainBackendName},
+		{name: "bogus", goos: "linux", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		store, err := selectStore(tt.name, tt.goos)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("selectStore(%q, %q): expected error", tt.name, tt.goos)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("selectStore(%q, %q): unexpected error: %v", tt.name, tt.goos, err)
+			continue
+		}
+		if store.Name() != tt.wantName {
+			t.Errorf("selectStore(%q, %q) = %s, want %s", tt.name, tt.goos, store.Name(), tt.wantName)
+		}
+	}
+}
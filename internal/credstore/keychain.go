@@ -0,0 +1,74 @@
+package credstore
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// keychainStore stores credentials as macOS Keychain generic passwords via
+// the `security` CLI. This is the same storage Claude Code itself uses on
+// macOS, so entries written here are readable by Claude.
+type keychainStore struct{}
+
+func newKeychainStore() *keychainStore {
+	return &keychainStore{}
+}
+
+func (s *keychainStore) Name() string {
+	return KeychainBackendName
+}
+
+func (s *keychainStore) Read(serviceName string) (string, error) {
+	user, err := currentUser()
+	if err != nil {
+		return "", err
+	}
+
+	readCmd := exec.Command("security", "find-generic-password", "-a", user, "-w", "-s", serviceName)
+	credentialData, err := readCmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 44 {
+			return "", ErrNotFound
+		}
+		return "", stacktrace.Propagate(err, "failed to read Keychain entry for service '%s'", serviceName)
+	}
+
+	return strings.TrimSpace(string(credentialData)), nil
+}
+
+func (s *keychainStore) Write(serviceName string, credential string) error {
+	user, err := currentUser()
+	if err != nil {
+		return err
+	}
+
+	// Delete any existing entry (ignore errors — may not exist)
+	deleteCmd := exec.Command("security", "delete-generic-password", "-a", user, "-s", serviceName)
+	_ = deleteCmd.Run()
+
+	addCmd := exec.Command("security", "add-generic-password", "-a", user, "-s", serviceName, "-w", credential)
+	if err := addCmd.Run(); err != nil {
+		return stacktrace.Propagate(err, "failed to write credentials to Keychain service '%s'", serviceName)
+	}
+	return nil
+}
+
+func (s *keychainStore) Delete(serviceName string) error {
+	user, err := currentUser()
+	if err != nil {
+		return err
+	}
+
+	deleteCmd := exec.Command("security", "delete-generic-password", "-a", user, "-s", serviceName)
+	output, err := deleteCmd.CombinedOutput()
+	if err != nil {
+		// Ignore "item not found" errors — the entry may not exist
+		if strings.Contains(string(output), "SecKeychainSearchCopyNext") {
+			return nil
+		}
+		return stacktrace.Propagate(err, "failed to delete Keychain credentials for service '%s'", serviceName)
+	}
+	return nil
+}
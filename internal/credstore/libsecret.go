@@ -0,0 +1,75 @@
+package credstore
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// libsecretStore stores credentials in the freedesktop Secret Service
+// (GNOME Keyring, KWallet) via the `secret-tool` CLI. Entries are keyed by
+// the attributes service=<serviceName> and account=$USER, mirroring the
+// Keychain layout.
+type libsecretStore struct{}
+
+func newLibsecretStore() *libsecretStore {
+	return &libsecretStore{}
+}
+
+func (s *libsecretStore) Name() string {
+	return LibsecretBackendName
+}
+
+func (s *libsecretStore) Read(serviceName string) (string, error) {
+	user, err := currentUser()
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("secret-tool", "lookup", "service", serviceName, "account", user)
+	output, err := cmd.Output()
+	if err != nil {
+		// secret-tool exits 1 with no output when the item does not exist
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && len(exitErr.Stderr) == 0 {
+			return "", ErrNotFound
+		}
+		return "", stacktrace.Propagate(err, "failed to read Secret Service entry for service '%s'", serviceName)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (s *libsecretStore) Write(serviceName string, credential string) error {
+	user, err := currentUser()
+	if err != nil {
+		return err
+	}
+
+	// secret-tool reads the secret from stdin, keeping it out of the process
+	// argument list. `store` replaces any item with identical attributes.
+	cmd := exec.Command("secret-tool", "store", "--label="+serviceName, "service", serviceName, "account", user)
+	cmd.Stdin = strings.NewReader(credential)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "failed to write Secret Service entry for service '%s': %s", serviceName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (s *libsecretStore) Delete(serviceName string) error {
+	user, err := currentUser()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("secret-tool", "clear", "service", serviceName, "account", user)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		// clear exits non-zero with no output when nothing matched
+		if len(strings.TrimSpace(string(output))) == 0 {
+			return nil
+		}
+		return stacktrace.Propagate(err, "failed to delete Secret Service entry for service '%s': %s", serviceName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
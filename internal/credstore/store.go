@@ -0,0 +1,129 @@
+// Package credstore provides pluggable storage for Claude Code credential
+// blobs (OAuth tokens, MCP OAuth tokens) keyed by service name.
+//
+// Backends:
+//   - keychain: macOS Keychain via the `security` CLI (default on macOS)
+//   - libsecret: GNOME Keyring / KWallet via the `secret-tool` CLI (default on
+//     Linux when secret-tool is installed and a Secret Service is reachable)
+//   - file: AES-256-GCM encrypted files under $AGENC_DIRPATH (fallback)
+//
+// The backend can be forced with the AGENC_CREDENTIAL_STORE environment
+// variable ("keychain", "libsecret", or "file").
+package credstore
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// StoreEnvVar names the environment variable that overrides backend selection.
+const StoreEnvVar = "AGENC_CREDENTIAL_STORE"
+
+// Backend names accepted by StoreEnvVar.
+const (
+	KeychainBackendName  = "keychain"
+	LibsecretBackendName = "libsecret"
+	FileBackendName      = "file"
+)
+
+// ErrNotFound is returned by Store.Read when no credential exists for the
+// requested service name.
+var ErrNotFound = errors.New("credential not found")
+
+// Store reads and writes opaque credential strings keyed by service name.
+type Store interface {
+	// Name returns the backend name (one of the *BackendName constants).
+	Name() string
+
+	// Read returns the credential for serviceName, or ErrNotFound.
+	Read(serviceName string) (string, error)
+
+	// Write creates or replaces the credential for serviceName.
+	Write(serviceName string, credential string) error
+
+	// Delete removes the credential for serviceName. Deleting a missing entry
+	// is not an error.
+	Delete(serviceName string) error
+}
+
+var (
+	defaultStore     Store
+	defaultStoreErr  error
+	defaultStoreOnce sync.Once
+)
+
+// Default returns the process-wide credential store, selecting a backend on
+// first use (see package docs).
+func Default() (Store, error) {
+	defaultStoreOnce.Do(func() {
+		defaultStore, defaultStoreErr = selectStore(os.Getenv(StoreEnvVar), runtime.GOOS)
+	})
+	return defaultStore, defaultStoreErr
+}
+
+// selectStore picks a backend by explicit name, or by platform when name is
+// empty.
+func selectStore(name string, goos string) (Store, error) {
+	switch name {
+	case KeychainBackendName:
+		return newKeychainStore(), nil
+	case LibsecretBackendName:
+		return newLibsecretStore(), nil
+	case FileBackendName:
+		return newDefaultFileStore()
+	case "":
+		// Auto-select below
+	default:
+		return nil, stacktrace.NewError("invalid %s value '%s': must be '%s', '%s', or '%s'",
+			StoreEnvVar, name, KeychainBackendName, LibsecretBackendName, FileBackendName)
+	}
+
+	if goos == "darwin" {
+		return newKeychainStore(), nil
+	}
+	if libsecretAvailable() {
+		return newLibsecretStore(), nil
+	}
+	return newDefaultFileStore()
+}
+
+// libsecretAvailable reports whether secret-tool is installed and a Secret
+// Service provider answers on the session bus.
+func libsecretAvailable() bool {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return false
+	}
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return false
+	}
+	// Looking up a key that never exists prints nothing when a provider is
+	// reachable; when none is running, secret-tool prints an error.
+	cmd := exec.Command("secret-tool", "lookup", "service", "agenc-probe", "account", "agenc-probe")
+	output, _ := cmd.CombinedOutput()
+	return len(output) == 0
+}
+
+func newDefaultFileStore() (Store, error) {
+	agencDirpath, err := config.GetAgencDirpath()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to resolve agenc directory for file credential store")
+	}
+	return NewFileStore(config.GetCredentialStoreDirpath(agencDirpath)), nil
+}
+
+// currentUser returns $USER, which the keychain and libsecret backends use as
+// the account attribute.
+func currentUser() (string, error) {
+	user := os.Getenv("USER")
+	if user == "" {
+		return "", stacktrace.NewError("USER environment variable not set")
+	}
+	return user, nil
+}
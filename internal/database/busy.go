@@ -0,0 +1,73 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const (
+	// busyRetryAttempts is how many times a write is tried when SQLite reports
+	// the database busy. Each attempt already waits out busy_timeout, so
+	// retries only kick in under sustained contention from other processes.
+	busyRetryAttempts = 3
+
+	// busyRetryBackoff is the pause before the first retry; it doubles after
+	// each one.
+	busyRetryBackoff = 100 * time.Millisecond
+)
+
+// isBusyError reports whether err is SQLite's SQLITE_BUSY or SQLITE_LOCKED
+// (including their extended codes), which clear once the other writer
+// finishes.
+func isBusyError(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+// retryOnBusy runs fn, retrying with backoff while it fails with a busy error.
+func retryOnBusy(fn func() error) error {
+	backoff := busyRetryBackoff
+	var err error
+	for attempt := 1; attempt <= busyRetryAttempts; attempt++ {
+		if err = fn(); err == nil || !isBusyError(err) {
+			return err
+		}
+		if attempt < busyRetryAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
+
+// exec runs a write statement, retrying while the database is busy.
+func (db *DB) exec(query string, args ...any) (sql.Result, error) {
+	var result sql.Result
+	err := retryOnBusy(func() error {
+		var err error
+		result, err = db.conn.Exec(query, args...)
+		return err
+	})
+	return result, err
+}
+
+// begin starts a transaction, retrying while the database is busy. Open sets
+// _txlock=immediate, so the write lock is taken here rather than at the
+// transaction's first write, where a busy error could not be waited out.
+func (db *DB) begin() (*sql.Tx, error) {
+	var tx *sql.Tx
+	err := retryOnBusy(func() error {
+		var err error
+		tx, err = db.conn.Begin()
+		return err
+	})
+	return tx, err
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// openNoWaitConn opens a raw connection to dbFilepath that fails immediately
+// with SQLITE_BUSY instead of waiting out a busy_timeout.
+func openNoWaitConn(t *testing.T, dbFilepath string) *sql.DB {
+	t.Helper()
+	conn, err := sql.Open("sqlite", dbFilepath+"?_pragma=busy_timeout(0)")
+	if err != nil {
+		t.Fatalf("failed to open connection: %v", err)
+	}
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestRetryOnBusy(t *testing.T) {
+	dbFilepath := filepath.Join(t.TempDir(), "test.sqlite")
+	db, err := Open(dbFilepath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer db.Close()
+
+	// Another process holds the write lock
+	tx, err := db.begin()
+	if err != nil {
+		t.Fatalf("begin failed: %v", err)
+	}
+
+	noWait := openNoWaitConn(t, dbFilepath)
+	write := func() error {
+		_, err := noWait.Exec("UPDATE missions SET prompt = 'x'")
+		return err
+	}
+	busyErr := write()
+	if !isBusyError(busyErr) {
+		t.Fatalf("expected a busy error while the lock is held, got %v", busyErr)
+	}
+	if isBusyError(errors.New("database is locked")) || isBusyError(nil) {
+		t.Error("expected only SQLite busy errors to count as busy")
+	}
+
+	// The lock is released before the first retry
+	go func() {
+		time.Sleep(busyRetryBackoff / 2)
+		_ = tx.Rollback()
+	}()
+	if err := retryOnBusy(write); err != nil {
+		t.Errorf("expected the write to succeed once the lock was released, got %v", err)
+	}
+
+	// Non-busy errors are returned without retrying
+	attempts := 0
+	failure := errors.New("boom")
+	if err := retryOnBusy(func() error { attempts++; return failure }); err != failure || attempts != 1 {
+		t.Errorf("expected one attempt returning the error, got %d attempts and %v", attempts, err)
+	}
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// The stress tests below open several DB handles on one file, each with its
+// own connection, to stand in for the server, wrappers, and short-lived CLI
+// processes that share the database in practice.
+
+const (
+	stressHandles    = 4
+	stressGoroutines = 8
+	stressIterations = 40
+)
+
+// openStressDBs opens n handles on the same database file, returning the
+// file's path and the handles.
+func openStressDBs(t *testing.T, n int) (string, []*DB) {
+	t.Helper()
+	dbFilepath := filepath.Join(t.TempDir(), "stress.sqlite")
+	handles := make([]*DB, n)
+	for i := range handles {
+		db, err := Open(dbFilepath)
+		if err != nil {
+			t.Fatalf("failed to open handle %d: %v", i, err)
+		}
+		t.Cleanup(func() { db.Close() })
+		handles[i] = db
+	}
+	return dbFilepath, handles
+}
+
+// runConcurrently runs fn from stressGoroutines goroutines and fails the test
+// with the first error any of them returns.
+func runConcurrently(t *testing.T, fn func(worker int) error) {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make(chan error, stressGoroutines)
+	for worker := 0; worker < stressGoroutines; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			if err := fn(worker); err != nil {
+				errs <- err
+			}
+		}(worker)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func TestConcurrentHeartbeatsAndMissionCreation(t *testing.T) {
+	_, handles := openStressDBs(t, stressHandles)
+
+	seed, err := handles[0].CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create seed mission: %v", err)
+	}
+
+	runConcurrently(t, func(worker int) error {
+		db := handles[worker%len(handles)]
+		for i := 0; i < stressIterations; i++ {
+			if worker%2 == 0 {
+				if err := db.UpdateHeartbeat(seed.ID); err != nil {
+					return fmt.Errorf("worker %d heartbeat %d: %w", worker, i, err)
+				}
+				if err := db.IncrementPromptCount(seed.ID); err != nil {
+					return fmt.Errorf("worker %d prompt count %d: %w", worker, i, err)
+				}
+				continue
+			}
+			if _, err := db.CreateMission("github.com/owner/repo", nil); err != nil {
+				return fmt.Errorf("worker %d create %d: %w", worker, i, err)
+			}
+			if _, err := db.ListMissions(ListMissionsParams{}); err != nil {
+				return fmt.Errorf("worker %d list %d: %w", worker, i, err)
+			}
+		}
+		return nil
+	})
+
+	missions, err := handles[0].ListMissions(ListMissionsParams{})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	wantMissions := 1 + (stressGoroutines/2)*stressIterations
+	if len(missions) != wantMissions {
+		t.Errorf("expected %d missions, got %d", wantMissions, len(missions))
+	}
+	got, err := handles[0].GetMission(seed.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if wantPrompts := (stressGoroutines / 2) * stressIterations; got.PromptCount != wantPrompts {
+		t.Errorf("expected %d prompts counted, got %d", wantPrompts, got.PromptCount)
+	}
+}
+
+func TestConcurrentOpenWhileWriting(t *testing.T) {
+	dbFilepath, handles := openStressDBs(t, 1)
+	mission, err := handles[0].CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	// Short-lived CLI processes (tmux status, doctor) open the database while
+	// the server keeps writing
+	runConcurrently(t, func(worker int) error {
+		for i := 0; i < stressIterations/4; i++ {
+			if worker%2 == 0 {
+				if err := handles[0].UpdateHeartbeat(mission.ID); err != nil {
+					return fmt.Errorf("worker %d heartbeat %d: %w", worker, i, err)
+				}
+				continue
+			}
+			db, err := Open(dbFilepath)
+			if err != nil {
+				return fmt.Errorf("worker %d open %d: %w", worker, i, err)
+			}
+			_, err = db.ListMissions(ListMissionsParams{})
+			db.Close()
+			if err != nil {
+				return fmt.Errorf("worker %d list %d: %w", worker, i, err)
+			}
+		}
+		return nil
+	})
+}
+
+func TestConcurrentReadThenWriteTransactions(t *testing.T) {
+	_, handles := openStressDBs(t, stressHandles)
+
+	// UpsertPauseAndNotification reads before it writes. Under deferred
+	// locking, a concurrent commit makes the write fail with SQLITE_BUSY
+	// immediately instead of waiting out busy_timeout.
+	runConcurrently(t, func(worker int) error {
+		db := handles[worker%len(handles)]
+		for i := 0; i < stressIterations; i++ {
+			repoName := fmt.Sprintf("github.com/owner/repo-%d-%d", worker, i)
+			n := &Notification{
+				ID:    fmt.Sprintf("notification-%d-%d", worker, i),
+				Kind:  "writeable_copy.conflict",
+				Title: "Conflict",
+			}
+			p := &WriteableCopyPause{RepoName: repoName, PausedReason: "rebase_conflict", NotificationID: n.ID}
+			if _, err := db.UpsertPauseAndNotification(p, n); err != nil {
+				return fmt.Errorf("worker %d upsert %d: %w", worker, i, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// CronAtJob is a one-shot cron job scheduled with 'agenc cron at'. Unlike
+// recurring crons it lives in the database rather than config.yml, and is
+// deleted once it fires.
+type CronAtJob struct {
+	ID          string // UUID, used as the cron ID of the run it starts
+	Name        string
+	RunAt       time.Time
+	Prompt      string
+	Repo        string
+	Description string
+	CreatedAt   time.Time
+}
+
+const cronAtJobColumns = "id, name, run_at, prompt, repo, description, created_at"
+
+// CreateCronAtJob inserts a one-shot cron job. The caller is responsible for
+// setting j.ID (typically a UUID); CreatedAt is set automatically if zero.
+func (db *DB) CreateCronAtJob(j *CronAtJob) error {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = time.Now().UTC()
+	}
+	_, err := db.exec(
+		"INSERT INTO cron_at_jobs ("+cronAtJobColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		j.ID, j.Name, j.RunAt.UTC().Format(time.RFC3339), j.Prompt, j.Repo, j.Description,
+		j.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to insert one-shot cron job '%s'", j.Name)
+	}
+	return nil
+}
+
+// ListCronAtJobs returns all pending one-shot cron jobs, soonest first.
+func (db *DB) ListCronAtJobs() ([]*CronAtJob, error) {
+	rows, err := db.conn.Query("SELECT " + cronAtJobColumns + " FROM cron_at_jobs ORDER BY run_at, name")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to query one-shot cron jobs")
+	}
+	defer rows.Close()
+
+	var result []*CronAtJob
+	for rows.Next() {
+		job, err := scanCronAtJob(rows)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan one-shot cron job row")
+		}
+		result = append(result, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "error iterating one-shot cron job rows")
+	}
+	return result, nil
+}
+
+// DeleteCronAtJob removes a one-shot cron job by ID. Returns whether a job
+// was deleted.
+func (db *DB) DeleteCronAtJob(id string) (bool, error) {
+	result, err := db.exec("DELETE FROM cron_at_jobs WHERE id = ?", id)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to delete one-shot cron job '%s'", id)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to read rows affected")
+	}
+	return affected > 0, nil
+}
+
+func scanCronAtJob(row rowScanner) (*CronAtJob, error) {
+	var j CronAtJob
+	var runAt, createdAt string
+	if err := row.Scan(&j.ID, &j.Name, &runAt, &j.Prompt, &j.Repo, &j.Description, &createdAt); err != nil {
+		return nil, err
+	}
+	var err error
+	if j.RunAt, err = time.Parse(time.RFC3339, runAt); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse cron_at_jobs run_at timestamp")
+	}
+	if j.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse cron_at_jobs created_at timestamp")
+	}
+	return &j, nil
+}
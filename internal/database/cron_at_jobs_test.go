@@ -0,0 +1,49 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronAtJobs_CreateListDelete(t *testing.T) {
+	db := openTestDB(t)
+
+	runAt := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	later := &CronAtJob{ID: "id-later", Name: "later", RunAt: runAt.Add(time.Hour), Prompt: "second"}
+	sooner := &CronAtJob{ID: "id-sooner", Name: "sooner", RunAt: runAt, Prompt: "first", Repo: "github.com/owner/repo"}
+	for _, j := range []*CronAtJob{later, sooner} {
+		if err := db.CreateCronAtJob(j); err != nil {
+			t.Fatalf("CreateCronAtJob failed: %v", err)
+		}
+	}
+	if err := db.CreateCronAtJob(&CronAtJob{ID: "id-dup", Name: "sooner", RunAt: runAt, Prompt: "dup"}); err == nil {
+		t.Error("expected a duplicate name to be rejected")
+	}
+
+	jobs, err := db.ListCronAtJobs()
+	if err != nil {
+		t.Fatalf("ListCronAtJobs failed: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Name != "sooner" || jobs[1].Name != "later" {
+		t.Fatalf("expected two jobs soonest first, got %+v", jobs)
+	}
+	if !jobs[0].RunAt.Equal(runAt) || jobs[0].Repo != "github.com/owner/repo" {
+		t.Errorf("expected fields to round-trip, got %+v", jobs[0])
+	}
+
+	deleted, err := db.DeleteCronAtJob("id-sooner")
+	if err != nil || !deleted {
+		t.Fatalf("DeleteCronAtJob = %v, %v; want true", deleted, err)
+	}
+	deleted, err = db.DeleteCronAtJob("id-sooner")
+	if err != nil || deleted {
+		t.Errorf("second DeleteCronAtJob = %v, %v; want false", deleted, err)
+	}
+	jobs, err = db.ListCronAtJobs()
+	if err != nil {
+		t.Fatalf("ListCronAtJobs failed: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Name != "later" {
+		t.Errorf("expected only the later job to remain, got %+v", jobs)
+	}
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Cron run statuses.
+const (
+	CronRunStatusRunning   = "running"
+	CronRunStatusSucceeded = "succeeded"
+	CronRunStatusFailed    = "failed"
+	CronRunStatusSkipped   = "skipped"
+)
+
+// Cron run triggers.
+const (
+	CronRunTriggerSchedule = "schedule"
+	CronRunTriggerManual   = "manual"
+)
+
+// CronRun records a single firing of a cron job: either the mission it
+// spawned and how that mission's first turn ended, or why the firing was
+// skipped.
+type CronRun struct {
+	ID        string
+	CronID    string
+	CronName  string
+	Trigger   string
+	MissionID *string // nil for skipped runs, or after the mission is deleted
+	Status    string
+	ExitCode  *int // set only when the run ended because Claude exited
+	Detail    string
+	StartedAt time.Time
+	EndedAt   *time.Time
+}
+
+const cronRunColumns = "id, cron_id, cron_name, trigger, mission_id, status, exit_code, detail, started_at, ended_at"
+
+// CreateCronRun inserts a new cron run row. The caller is responsible for
+// setting r.ID (typically a UUID); StartedAt is set automatically if zero.
+func (db *DB) CreateCronRun(r *CronRun) error {
+	if r.StartedAt.IsZero() {
+		r.StartedAt = time.Now().UTC()
+	}
+	var missionID sql.NullString
+	if r.MissionID != nil && *r.MissionID != "" {
+		missionID = sql.NullString{String: *r.MissionID, Valid: true}
+	}
+	var exitCode sql.NullInt64
+	if r.ExitCode != nil {
+		exitCode = sql.NullInt64{Int64: int64(*r.ExitCode), Valid: true}
+	}
+	var endedAt sql.NullString
+	if r.EndedAt != nil {
+		endedAt = sql.NullString{String: r.EndedAt.UTC().Format(time.RFC3339), Valid: true}
+	}
+	_, err := db.exec(
+		"INSERT INTO cron_runs ("+cronRunColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.ID, r.CronID, r.CronName, r.Trigger, missionID, r.Status, exitCode, r.Detail,
+		r.StartedAt.UTC().Format(time.RFC3339), endedAt,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to insert cron run for cron '%s'", r.CronID)
+	}
+	return nil
+}
+
+// FinishCronRun marks a running cron run as finished with the given status.
+// A run that has already finished is left untouched.
+func (db *DB) FinishCronRun(runID string, status string, exitCode *int, detail string) error {
+	if _, err := db.finishCronRuns("id = ?", runID, status, exitCode, detail); err != nil {
+		return stacktrace.Propagate(err, "failed to finish cron run '%s'", runID)
+	}
+	return nil
+}
+
+// FinishCronRunForMission marks the running cron run spawned by missionID as
+// finished with the given status. Runs that have already finished are left
+// untouched, so the first terminal event wins. Returns whether a run was
+// updated.
+func (db *DB) FinishCronRunForMission(missionID string, status string, exitCode *int, detail string) (bool, error) {
+	updated, err := db.finishCronRuns("mission_id = ?", missionID, status, exitCode, detail)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to finish cron run for mission '%s'", missionID)
+	}
+	return updated, nil
+}
+
+// finishCronRuns applies a terminal status to running cron runs matching the
+// given single-placeholder WHERE clause.
+func (db *DB) finishCronRuns(where string, key string, status string, exitCode *int, detail string) (bool, error) {
+	var exitCodeVal sql.NullInt64
+	if exitCode != nil {
+		exitCodeVal = sql.NullInt64{Int64: int64(*exitCode), Valid: true}
+	}
+	result, err := db.exec(
+		"UPDATE cron_runs SET status = ?, exit_code = ?, detail = ?, ended_at = ? WHERE "+where+" AND status = ?",
+		status, exitCodeVal, detail, time.Now().UTC().Format(time.RFC3339), key, CronRunStatusRunning,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ListCronRunsParams filters ListCronRuns. Zero-valued fields don't filter.
+type ListCronRunsParams struct {
+	// CronID restricts results to the runs of one cron.
+	CronID string
+
+	// Status restricts results to runs in that status.
+	Status string
+
+	// ExcludeStatus drops runs in that status, such as skipped firings when
+	// looking for the latest run that actually started a mission.
+	ExcludeStatus string
+
+	// From and To restrict results to runs started within [From, To).
+	From time.Time
+	To   time.Time
+
+	// Limit caps the number of runs returned; 0 means no limit.
+	Limit int
+}
+
+// ListCronRuns returns cron runs matching params, newest first.
+func (db *DB) ListCronRuns(params ListCronRunsParams) ([]*CronRun, error) {
+	query, args := buildListCronRunsQuery(params)
+	return db.queryCronRuns(query, args...)
+}
+
+func (db *DB) queryCronRuns(query string, args ...any) ([]*CronRun, error) {
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to query cron runs")
+	}
+	defer rows.Close()
+
+	var result []*CronRun
+	for rows.Next() {
+		run, err := scanCronRun(rows)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan cron run row")
+		}
+		result = append(result, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "error iterating cron run rows")
+	}
+	return result, nil
+}
+
+func scanCronRun(row rowScanner) (*CronRun, error) {
+	var r CronRun
+	var missionID, endedAt sql.NullString
+	var exitCode sql.NullInt64
+	var startedAt string
+	if err := row.Scan(&r.ID, &r.CronID, &r.CronName, &r.Trigger, &missionID, &r.Status, &exitCode, &r.Detail, &startedAt, &endedAt); err != nil {
+		return nil, err
+	}
+	if missionID.Valid {
+		r.MissionID = &missionID.String
+	}
+	if exitCode.Valid {
+		code := int(exitCode.Int64)
+		r.ExitCode = &code
+	}
+	t, err := time.Parse(time.RFC3339, startedAt)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse cron_runs started_at timestamp")
+	}
+	r.StartedAt = t
+	if endedAt.Valid {
+		t, err := time.Parse(time.RFC3339, endedAt.String)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to parse cron_runs ended_at timestamp")
+		}
+		r.EndedAt = &t
+	}
+	return &r, nil
+}
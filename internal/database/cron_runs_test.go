@@ -0,0 +1,110 @@
+package database
+
+import "testing"
+
+func TestCronRuns_LifecycleAndOrdering(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	running := &CronRun{
+		ID:        "run-1",
+		CronID:    "cron-a",
+		CronName:  "nightly",
+		Trigger:   CronRunTriggerSchedule,
+		MissionID: &mission.ID,
+		Status:    CronRunStatusRunning,
+	}
+	if err := db.CreateCronRun(running); err != nil {
+		t.Fatalf("CreateCronRun failed: %v", err)
+	}
+	skipped := &CronRun{
+		ID:        "run-2",
+		CronID:    "cron-a",
+		CronName:  "nightly",
+		Trigger:   CronRunTriggerSchedule,
+		Status:    CronRunStatusSkipped,
+		Detail:    "previous run still running",
+		StartedAt: running.StartedAt,
+		EndedAt:   &running.StartedAt,
+	}
+	if err := db.CreateCronRun(skipped); err != nil {
+		t.Fatalf("CreateCronRun failed: %v", err)
+	}
+	if err := db.CreateCronRun(&CronRun{ID: "run-3", CronID: "cron-b", Trigger: CronRunTriggerManual, Status: CronRunStatusRunning}); err != nil {
+		t.Fatalf("CreateCronRun failed: %v", err)
+	}
+
+	runs, err := db.ListCronRuns(ListCronRunsParams{CronID: "cron-a"})
+	if err != nil {
+		t.Fatalf("ListCronRuns failed: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != "run-2" || runs[1].ID != "run-1" {
+		t.Fatalf("expected [run-2, run-1] newest first, got %+v", runs)
+	}
+	if runs[0].MissionID != nil || runs[0].EndedAt == nil {
+		t.Errorf("expected skipped run with no mission and an end time, got %+v", runs[0])
+	}
+
+	attempted, err := db.ListCronRuns(ListCronRunsParams{CronID: "cron-a", ExcludeStatus: CronRunStatusSkipped, Limit: 1})
+	if err != nil {
+		t.Fatalf("ListCronRuns excluding skipped failed: %v", err)
+	}
+	if len(attempted) != 1 || attempted[0].ID != "run-1" {
+		t.Errorf("expected the skipped run to be ignored and run-1 returned, got %+v", attempted)
+	}
+	if none, err := db.ListCronRuns(ListCronRunsParams{CronID: "cron-unknown", ExcludeStatus: CronRunStatusSkipped, Limit: 1}); err != nil || len(none) != 0 {
+		t.Errorf("expected no runs for an unknown cron, got %+v (err %v)", none, err)
+	}
+
+	limited, err := db.ListCronRuns(ListCronRunsParams{CronID: "cron-a", Limit: 1})
+	if err != nil {
+		t.Fatalf("ListCronRuns with limit failed: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("expected 1 run with limit, got %d", len(limited))
+	}
+
+	allRunning, err := db.ListCronRuns(ListCronRunsParams{Status: CronRunStatusRunning})
+	if err != nil {
+		t.Fatalf("ListCronRuns by status failed: %v", err)
+	}
+	if len(allRunning) != 2 {
+		t.Errorf("expected 2 running runs across crons, got %d", len(allRunning))
+	}
+
+	exitCode := 2
+	updated, err := db.FinishCronRunForMission(mission.ID, CronRunStatusFailed, &exitCode, "claude exited with code 2")
+	if err != nil || !updated {
+		t.Fatalf("FinishCronRunForMission: updated=%v err=%v", updated, err)
+	}
+	// The first terminal event wins
+	updated, err = db.FinishCronRunForMission(mission.ID, CronRunStatusSucceeded, nil, "")
+	if err != nil || updated {
+		t.Fatalf("expected second finish to be a no-op: updated=%v err=%v", updated, err)
+	}
+
+	runs, err = db.ListCronRuns(ListCronRunsParams{CronID: "cron-a"})
+	if err != nil {
+		t.Fatalf("ListCronRuns failed: %v", err)
+	}
+	finished := runs[1]
+	if finished.Status != CronRunStatusFailed || finished.ExitCode == nil || *finished.ExitCode != 2 || finished.EndedAt == nil {
+		t.Errorf("unexpected finished run: %+v", finished)
+	}
+
+	// History survives mission deletion
+	if err := db.DeleteMission(mission.ID); err != nil {
+		t.Fatalf("DeleteMission failed: %v", err)
+	}
+	runs, err = db.ListCronRuns(ListCronRunsParams{CronID: "cron-a"})
+	if err != nil {
+		t.Fatalf("ListCronRuns failed: %v", err)
+	}
+	if len(runs) != 2 || runs[1].MissionID != nil {
+		t.Errorf("expected run history kept with mission_id cleared, got %+v", runs)
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/mieubrisse/stacktrace"
 
@@ -47,13 +48,42 @@ func getMigrationSteps() []migrationStep {
 		{migrateCreateNotificationsTable, "create notifications table"},
 		{migrateCreateWriteableCopyPausesTable, "create writeable_copy_pauses table"},
 		{migrateAddNotificationsMissionID, "add mission_id column to notifications"},
+		{migrateAddTags, "add tags column"},
+		{migrateCreateMissionStatsTable, "create mission_stats table"},
+		{migrateCreateCronRunsTable, "create cron_runs table"},
+		{migrateAddPRURL, "add pr_url column"},
+		{migrateAddBudgetColumns, "add mission budget columns"},
+		{migrateAddOwnershipColumns, "add mission ownership columns"},
+		{migrateAddDisplayName, "add display_name column"},
+		{migrateCreateMissionEventsTable, "create mission_events table"},
+		{migrateCreateCronAtJobsTable, "create cron_at_jobs table"},
+		{migrateCreateMissionPromptsTable, "create mission_prompts table"},
+		{migrateCreateMissionHandoffsTable, "create mission_handoffs table"},
+		{migrateCreateMissionBusyPeriodsTable, "create mission_busy_periods table"},
+		{migrateCreateProjects, "create projects table"},
+		{migrateAddLastError, "add last_error column"},
+		{migrateAddAgentDirBytes, "add agent_dir_bytes column"},
+		{migrateAddEnv, "add env column"},
+		{migrateAddGitRepos, "add git_repos column"},
+		{migrateAddGitRef, "add git_ref columns"},
 	}
 }
 
+// schemaVersion is recorded in SQLite's user_version once every migration
+// has run. It grows with the migration list, so appending a step makes
+// existing databases migrate again on their next Open.
+func schemaVersion() int {
+	return len(getMigrationSteps()) + 1
+}
+
 // Open opens or creates the SQLite database at the given filepath
 // and runs auto-migration.
 func Open(dbFilepath string) (*DB, error) {
-	dsn := dbFilepath + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	// WAL lets readers proceed alongside the single writer, busy_timeout makes
+	// a blocked writer wait rather than fail, and _txlock=immediate takes the
+	// write lock when a transaction begins, so a read-then-write transaction
+	// can't hit SQLITE_BUSY midway, which busy_timeout cannot wait out.
+	dsn := dbFilepath + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_txlock=immediate"
 	conn, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to open database at '%s'", dbFilepath)
@@ -63,27 +93,53 @@ func Open(dbFilepath string) (*DB, error) {
 	// to avoid unnecessary contention between connections in the same process.
 	conn.SetMaxOpenConns(1)
 
+	if err := migrate(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &DB{conn: conn}, nil
+}
+
+// migrate brings the schema up to date. The migrations are idempotent but
+// take the write lock, so they are skipped when user_version shows they
+// already ran; otherwise every short-lived CLI process (tmux status, doctor)
+// would contend with the server for it.
+func migrate(conn *sql.DB) error {
+	var userVersion int
+	if err := conn.QueryRow("PRAGMA user_version").Scan(&userVersion); err != nil {
+		return stacktrace.Propagate(err, "failed to read database schema version")
+	}
+	if userVersion >= schemaVersion() {
+		// Orphaned sessions can reappear whenever a writer ran without foreign
+		// keys, so this check runs on every Open; it only writes when needed
+		if err := migrateCleanOrphanedSessions(conn); err != nil {
+			return stacktrace.Propagate(err, "failed to clean orphaned sessions")
+		}
+		return nil
+	}
+
 	// Initial table creation
 	if _, err := conn.Exec(createMissionsTableSQL); err != nil {
-		conn.Close()
-		return nil, stacktrace.Propagate(err, "failed to auto-migrate database")
+		return stacktrace.Propagate(err, "failed to auto-migrate database")
 	}
 
 	// Run all migration steps
 	for _, step := range getMigrationSteps() {
 		if err := step.fn(conn); err != nil {
-			conn.Close()
-			return nil, stacktrace.Propagate(err, "failed to %s", step.desc)
+			return stacktrace.Propagate(err, "failed to %s", step.desc)
 		}
 	}
 
 	// Drop legacy mission_descriptions table
 	if _, err := conn.Exec(dropMissionDescriptionsTableSQL); err != nil {
-		conn.Close()
-		return nil, stacktrace.Propagate(err, "failed to drop mission_descriptions table")
+		return stacktrace.Propagate(err, "failed to drop mission_descriptions table")
 	}
 
-	return &DB{conn: conn}, nil
+	if _, err := conn.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion())); err != nil {
+		return stacktrace.Propagate(err, "failed to record database schema version")
+	}
+	return nil
 }
 
 // Close closes the database connection.
@@ -1,7 +1,11 @@
 package database
 
 import (
+	"database/sql"
+	"fmt"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"testing"
 	"time"
 )
@@ -341,6 +345,54 @@ func TestClearAllTmuxPanes(t *testing.T) {
 	}
 }
 
+func TestListMissions_Pagination(t *testing.T) {
+	db := openTestDB(t)
+
+	// Distinct created_at values give a fixed order: newest (index 3) first
+	var ids []string
+	for i := 0; i < 4; i++ {
+		m, err := db.CreateMission("github.com/owner/repo", nil)
+		if err != nil {
+			t.Fatalf("failed to create mission %d: %v", i, err)
+		}
+		created := fmt.Sprintf("2026-01-0%dT00:00:00Z", i+1)
+		if _, err := db.conn.Exec("UPDATE missions SET created_at = ? WHERE id = ?", created, m.ID); err != nil {
+			t.Fatalf("failed to set created_at for mission %d: %v", i, err)
+		}
+		ids = append(ids, m.ID)
+	}
+
+	missionIDs := func(params ListMissionsParams) []string {
+		t.Helper()
+		missions, err := db.ListMissions(params)
+		if err != nil {
+			t.Fatalf("failed to list missions: %v", err)
+		}
+		var result []string
+		for _, m := range missions {
+			result = append(result, m.ID)
+		}
+		return result
+	}
+
+	if got := missionIDs(ListMissionsParams{Limit: 2, Offset: 1}); !slices.Equal(got, []string{ids[2], ids[1]}) {
+		t.Errorf("limit/offset: expected %v, got %v", []string{ids[2], ids[1]}, got)
+	}
+	if got := missionIDs(ListMissionsParams{Offset: 3}); !slices.Equal(got, []string{ids[0]}) {
+		t.Errorf("offset without limit: expected %v, got %v", []string{ids[0]}, got)
+	}
+	if got := missionIDs(ListMissionsParams{Sort: MissionSortCreated, Limit: 2, After: ids[2]}); !slices.Equal(got, []string{ids[1], ids[0]}) {
+		t.Errorf("cursor: expected %v, got %v", []string{ids[1], ids[0]}, got)
+	}
+
+	if _, err := db.ListMissions(ListMissionsParams{After: "missing"}); err == nil {
+		t.Error("expected an error for an unknown cursor mission")
+	}
+	if _, err := ParseMissionSort("name"); err == nil {
+		t.Error("expected an error for an unknown sort")
+	}
+}
+
 func TestListMissions_SinceFilter(t *testing.T) {
 	db := openTestDB(t)
 
@@ -480,3 +532,526 @@ func TestListMissions_TimeFilterWithArchived(t *testing.T) {
 		t.Fatalf("expected 1 mission with IncludeArchived, got %d", len(missions))
 	}
 }
+
+func TestSetMissionTags_RoundTrip(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	if err := db.SetMissionTags(mission.ID, []string{"urgent", "billing", "urgent"}); err != nil {
+		t.Fatalf("SetMissionTags failed: %v", err)
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if len(got.Tags) != 2 || got.Tags[0] != "billing" || got.Tags[1] != "urgent" {
+		t.Errorf("expected tags [billing urgent], got %v", got.Tags)
+	}
+
+	if err := db.SetMissionTags(mission.ID, nil); err != nil {
+		t.Fatalf("SetMissionTags (clear) failed: %v", err)
+	}
+	got, err = db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Tags != nil {
+		t.Errorf("expected nil tags after clearing, got %v", got.Tags)
+	}
+}
+
+func TestSetMissionDisplayName_RoundTrip(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if mission.DisplayName != "" {
+		t.Errorf("expected empty display name on a new mission, got %q", mission.DisplayName)
+	}
+
+	if err := db.SetMissionDisplayName(mission.ID, "Billing refactor"); err != nil {
+		t.Fatalf("SetMissionDisplayName failed: %v", err)
+	}
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.DisplayName != "Billing refactor" {
+		t.Errorf("expected display name %q, got %q", "Billing refactor", got.DisplayName)
+	}
+
+	if err := db.SetMissionDisplayName(mission.ID, ""); err != nil {
+		t.Fatalf("SetMissionDisplayName (clear) failed: %v", err)
+	}
+	got, err = db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.DisplayName != "" {
+		t.Errorf("expected empty display name after clearing, got %q", got.DisplayName)
+	}
+}
+
+func TestSetMissionPRURL(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if mission.PRURL != "" {
+		t.Errorf("expected empty PR URL on a new mission, got %q", mission.PRURL)
+	}
+
+	prURL := "https://github.com/owner/repo/pull/42"
+	if err := db.SetMissionPRURL(mission.ID, prURL); err != nil {
+		t.Fatalf("SetMissionPRURL failed: %v", err)
+	}
+
+	missions, err := db.ListMissions(ListMissionsParams{})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 1 || missions[0].PRURL != prURL {
+		t.Errorf("expected PR URL %q in list results, got %+v", prURL, missions)
+	}
+
+	if err := db.SetMissionPRURL("nonexistent", prURL); err == nil {
+		t.Error("expected error for a nonexistent mission")
+	}
+}
+
+func TestSetMissionLastError(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	lastError := "claude exited with code 1"
+	if err := db.SetMissionLastError(mission.ID, lastError); err != nil {
+		t.Fatalf("SetMissionLastError failed: %v", err)
+	}
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.LastError != lastError {
+		t.Errorf("expected last error %q, got %q", lastError, got.LastError)
+	}
+
+	if err := db.SetMissionLastError(mission.ID, ""); err != nil {
+		t.Fatalf("SetMissionLastError (clear) failed: %v", err)
+	}
+	missions, err := db.ListMissions(ListMissionsParams{})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 1 || missions[0].LastError != "" {
+		t.Errorf("expected last error to be cleared, got %+v", missions)
+	}
+
+	if err := db.SetMissionLastError("nonexistent", lastError); err == nil {
+		t.Error("expected error for a nonexistent mission")
+	}
+}
+
+func TestSetMissionAgentDirBytes(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	before, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if err := db.SetMissionAgentDirBytes(mission.ID, 3<<30); err != nil {
+		t.Fatalf("SetMissionAgentDirBytes failed: %v", err)
+	}
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.AgentDirBytes != 3<<30 {
+		t.Errorf("expected agent dir bytes %d, got %d", int64(3<<30), got.AgentDirBytes)
+	}
+	if !got.UpdatedAt.Equal(before.UpdatedAt) {
+		t.Errorf("expected updated_at to be left alone, got %v (was %v)", got.UpdatedAt, before.UpdatedAt)
+	}
+}
+
+func TestCreateMission_Budget(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", &CreateMissionParams{MaxPrompts: 10, BudgetUSD: 2.5})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.MaxPrompts != 10 || got.BudgetUSD != 2.5 {
+		t.Errorf("expected max_prompts=10 budget_usd=2.5, got max_prompts=%d budget_usd=%v", got.MaxPrompts, got.BudgetUSD)
+	}
+
+	unlimited, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	got, err = db.GetMission(unlimited.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.MaxPrompts != 0 || got.BudgetUSD != 0 {
+		t.Errorf("expected no limits by default, got max_prompts=%d budget_usd=%v", got.MaxPrompts, got.BudgetUSD)
+	}
+}
+
+func TestCreateMission_Env(t *testing.T) {
+	db := openTestDB(t)
+
+	env := map[string]string{"DEBUG": "1", "API_TOKEN": "secret://api-token"}
+	mission, err := db.CreateMission("github.com/owner/repo", &CreateMissionParams{Env: env})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if len(got.Env) != 2 || got.Env["DEBUG"] != "1" || got.Env["API_TOKEN"] != "secret://api-token" {
+		t.Errorf("expected env %v, got %v", env, got.Env)
+	}
+
+	missions, err := db.ListMissions(ListMissionsParams{})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 1 || missions[0].Env["DEBUG"] != "1" {
+		t.Errorf("expected ListMissions to carry env, got %+v", missions)
+	}
+
+	plain, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	got, err = db.GetMission(plain.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Env != nil {
+		t.Errorf("expected no env by default, got %v", got.Env)
+	}
+}
+
+func TestCreateMission_GitRepos(t *testing.T) {
+	db := openTestDB(t)
+
+	repos := []string{"github.com/owner/api", "github.com/owner/client"}
+	mission, err := db.CreateMission("", &CreateMissionParams{GitRepos: repos})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if len(got.GitRepos) != 2 || got.GitRepos[0] != repos[0] || got.GitRepos[1] != repos[1] {
+		t.Errorf("expected git repos %v, got %v", repos, got.GitRepos)
+	}
+	if got.GitRepo != "" {
+		t.Errorf("expected empty git_repo for a multi-repo mission, got %q", got.GitRepo)
+	}
+
+	missions, err := db.ListMissions(ListMissionsParams{})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 1 || len(missions[0].GitRepos) != 2 {
+		t.Errorf("expected ListMissions to carry git repos, got %+v", missions)
+	}
+}
+
+func TestCreateMission_GitRef(t *testing.T) {
+	db := openTestDB(t)
+
+	const commit = "8d1e6f0c4b7a9e2d5f3c1b0a8e7d6c5b4a3f2e1d"
+	mission, err := db.CreateMission("github.com/owner/repo", &CreateMissionParams{GitRef: "v1.4.2", GitRefCommit: commit})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.GitRef != "v1.4.2" || got.GitRefCommit != commit {
+		t.Errorf("expected ref v1.4.2 at %s, got %q at %q", commit, got.GitRef, got.GitRefCommit)
+	}
+
+	missions, err := db.ListMissions(ListMissionsParams{})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 1 || missions[0].GitRefCommit != commit {
+		t.Errorf("expected ListMissions to carry the ref, got %+v", missions)
+	}
+}
+
+func TestMissionOwnership_RoundTrip(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", &CreateMissionParams{Owner: "alice"})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if err := db.SetMissionSharedWith(mission.ID, []string{"carol", "bob", "carol"}); err != nil {
+		t.Fatalf("SetMissionSharedWith failed: %v", err)
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Owner != "alice" {
+		t.Errorf("expected owner alice, got %q", got.Owner)
+	}
+	if want := []string{"bob", "carol"}; !reflect.DeepEqual(got.SharedWith, want) {
+		t.Errorf("expected shared_with %v, got %v", want, got.SharedWith)
+	}
+
+	if err := db.SetMissionSharedWith(mission.ID, nil); err != nil {
+		t.Fatalf("SetMissionSharedWith failed: %v", err)
+	}
+	got, err = db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.SharedWith != nil {
+		t.Errorf("expected no shared users, got %v", got.SharedWith)
+	}
+}
+
+func TestSetMissionTags_RejectsInvalidTag(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	for _, tag := range []string{"", "a,b", "has space", "100%"} {
+		if err := db.SetMissionTags(mission.ID, []string{tag}); err == nil {
+			t.Errorf("expected error for tag %q, got nil", tag)
+		}
+	}
+}
+
+func TestListMissions_TagFilter(t *testing.T) {
+	db := openTestDB(t)
+
+	billing, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	both, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	// "bill" must not match "billing": tags are matched whole
+	partial, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if err := db.SetMissionTags(billing.ID, []string{"billing"}); err != nil {
+		t.Fatalf("SetMissionTags failed: %v", err)
+	}
+	if err := db.SetMissionTags(both.ID, []string{"billing", "urgent"}); err != nil {
+		t.Fatalf("SetMissionTags failed: %v", err)
+	}
+	if err := db.SetMissionTags(partial.ID, []string{"bill"}); err != nil {
+		t.Fatalf("SetMissionTags failed: %v", err)
+	}
+
+	missions, err := db.ListMissions(ListMissionsParams{Tags: []string{"billing"}})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 2 {
+		t.Fatalf("expected 2 missions tagged billing, got %d", len(missions))
+	}
+
+	missions, err = db.ListMissions(ListMissionsParams{Tags: []string{"billing", "urgent"}})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 1 || missions[0].ID != both.ID {
+		t.Fatalf("expected only the mission with both tags, got %d missions", len(missions))
+	}
+}
+
+func TestSetMissionAISummary_RoundTrip(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if err := db.SetMissionAISummary(mission.ID, "Migrating billing to the new API", 12); err != nil {
+		t.Fatalf("SetMissionAISummary failed: %v", err)
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.AISummary != "Migrating billing to the new API" || got.LastSummaryPromptCount != 12 {
+		t.Errorf("expected the summary at prompt 12, got %q at %d", got.AISummary, got.LastSummaryPromptCount)
+	}
+}
+
+func TestMarkMissionCrashed_HeartbeatReactivates(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	marked, err := db.MarkMissionCrashed(mission.ID)
+	if err != nil {
+		t.Fatalf("MarkMissionCrashed failed: %v", err)
+	}
+	if !marked {
+		t.Fatal("expected active mission to be marked crashed")
+	}
+	marked, err = db.MarkMissionCrashed(mission.ID)
+	if err != nil {
+		t.Fatalf("MarkMissionCrashed failed: %v", err)
+	}
+	if marked {
+		t.Error("expected already-crashed mission not to be marked again")
+	}
+
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Status != "crashed" {
+		t.Fatalf("expected status 'crashed', got %q", got.Status)
+	}
+
+	if err := db.UpdateHeartbeat(mission.ID); err != nil {
+		t.Fatalf("UpdateHeartbeat failed: %v", err)
+	}
+	got, err = db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Status != "active" {
+		t.Errorf("expected heartbeat to reactivate the mission, got status %q", got.Status)
+	}
+}
+
+func TestMarkMissionSuspended(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	marked, err := db.MarkMissionSuspended(mission.ID)
+	if err != nil {
+		t.Fatalf("MarkMissionSuspended failed: %v", err)
+	}
+	if !marked {
+		t.Fatal("expected active mission to be marked suspended")
+	}
+	got, err := db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Status != "suspended" {
+		t.Fatalf("expected status 'suspended', got %q", got.Status)
+	}
+
+	if err := db.ClearMissionSuspended(mission.ID); err != nil {
+		t.Fatalf("ClearMissionSuspended failed: %v", err)
+	}
+	got, err = db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Status != "active" {
+		t.Errorf("expected status 'active' after clearing, got %q", got.Status)
+	}
+
+	// An archived mission is neither suspended nor revived
+	if err := db.ArchiveMission(mission.ID); err != nil {
+		t.Fatalf("ArchiveMission failed: %v", err)
+	}
+	if marked, _ := db.MarkMissionSuspended(mission.ID); marked {
+		t.Error("expected archived mission not to be marked suspended")
+	}
+	if err := db.ClearMissionSuspended(mission.ID); err != nil {
+		t.Fatalf("ClearMissionSuspended failed: %v", err)
+	}
+	if got, _ := db.GetMission(mission.ID); got.Status != "archived" {
+		t.Errorf("expected archived mission to stay archived, got %q", got.Status)
+	}
+}
+
+func TestOpen_RecordsSchemaVersion(t *testing.T) {
+	dbFilepath := filepath.Join(t.TempDir(), "test.sqlite")
+	db, err := Open(dbFilepath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	var userVersion int
+	if err := db.conn.QueryRow("PRAGMA user_version").Scan(&userVersion); err != nil {
+		t.Fatalf("failed to read user_version: %v", err)
+	}
+	if userVersion != schemaVersion() {
+		t.Errorf("expected user_version %d, got %d", schemaVersion(), userVersion)
+	}
+
+	// A reopen with the schema current skips the migrations, so a column they
+	// would clear keeps its value
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	if _, err := db.conn.Exec("UPDATE missions SET tmux_window_title = 'kept' WHERE id = ?", mission.ID); err != nil {
+		t.Fatalf("failed to set tmux_window_title: %v", err)
+	}
+	db.Close()
+
+	db, err = Open(dbFilepath)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer db.Close()
+	var title sql.NullString
+	if err := db.conn.QueryRow("SELECT tmux_window_title FROM missions WHERE id = ?", mission.ID).Scan(&title); err != nil {
+		t.Fatalf("failed to read tmux_window_title: %v", err)
+	}
+	if title.String != "kept" {
+		t.Errorf("expected migrations to be skipped on reopen, got tmux_window_title %q", title.String)
+	}
+}
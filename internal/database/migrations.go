@@ -49,6 +49,7 @@ const (
 	backfillSessionShortIDSQL    = `UPDATE sessions SET short_id = SUBSTR(id, 1, 8) WHERE short_id = '';`
 	createSessionShortIDIndexSQL = `CREATE INDEX IF NOT EXISTS idx_sessions_short_id ON sessions(short_id);`
 
+	hasOrphanedSessionsSQL   = `SELECT EXISTS (SELECT 1 FROM sessions WHERE mission_id NOT IN (SELECT id FROM missions));`
 	cleanOrphanedSessionsSQL = `DELETE FROM sessions WHERE mission_id NOT IN (SELECT id FROM missions);`
 
 	createMissionSearchIndexSQL = `CREATE VIRTUAL TABLE IF NOT EXISTS mission_search_index USING fts5(
@@ -81,6 +82,106 @@ const (
 	local_head_at_pause    TEXT    NOT NULL,
 	notification_id        TEXT    NOT NULL REFERENCES notifications(id)
 );`
+
+	addTagsColumnSQL = `ALTER TABLE missions ADD COLUMN tags TEXT NOT NULL DEFAULT '';`
+
+	addPRURLColumnSQL = `ALTER TABLE missions ADD COLUMN pr_url TEXT NOT NULL DEFAULT '';`
+
+	addMaxPromptsColumnSQL  = `ALTER TABLE missions ADD COLUMN max_prompts INTEGER NOT NULL DEFAULT 0;`
+	addBudgetUSDColumnSQL   = `ALTER TABLE missions ADD COLUMN budget_usd REAL NOT NULL DEFAULT 0;`
+	addOwnerColumnSQL       = `ALTER TABLE missions ADD COLUMN owner TEXT NOT NULL DEFAULT '';`
+	addSharedWithColumnSQL  = `ALTER TABLE missions ADD COLUMN shared_with TEXT NOT NULL DEFAULT '';`
+	addDisplayNameColumnSQL = `ALTER TABLE missions ADD COLUMN display_name TEXT NOT NULL DEFAULT '';`
+
+	createMissionStatsTableSQL = `CREATE TABLE IF NOT EXISTS mission_stats (
+	mission_id             TEXT    PRIMARY KEY REFERENCES missions(id) ON DELETE CASCADE,
+	input_tokens           INTEGER NOT NULL DEFAULT 0,
+	output_tokens          INTEGER NOT NULL DEFAULT 0,
+	cache_read_tokens      INTEGER NOT NULL DEFAULT 0,
+	cache_creation_tokens  INTEGER NOT NULL DEFAULT 0,
+	wall_clock_seconds     INTEGER NOT NULL DEFAULT 0,
+	claude_starts          INTEGER NOT NULL DEFAULT 0,
+	updated_at             TEXT    NOT NULL
+);`
+
+	createCronRunsTableSQL = `CREATE TABLE IF NOT EXISTS cron_runs (
+	id          TEXT    PRIMARY KEY,
+	cron_id     TEXT    NOT NULL,
+	cron_name   TEXT    NOT NULL DEFAULT '',
+	trigger     TEXT    NOT NULL,
+	mission_id  TEXT    REFERENCES missions(id) ON DELETE SET NULL,
+	status      TEXT    NOT NULL,
+	exit_code   INTEGER,
+	detail      TEXT    NOT NULL DEFAULT '',
+	started_at  TEXT    NOT NULL,
+	ended_at    TEXT
+);`
+	createCronRunsCronIDIndexSQL = `CREATE INDEX IF NOT EXISTS idx_cron_runs_cron_id ON cron_runs(cron_id, started_at);`
+
+	createCronAtJobsTableSQL = `CREATE TABLE IF NOT EXISTS cron_at_jobs (
+	id           TEXT    PRIMARY KEY,
+	name         TEXT    NOT NULL UNIQUE,
+	run_at       TEXT    NOT NULL,
+	prompt       TEXT    NOT NULL,
+	repo         TEXT    NOT NULL DEFAULT '',
+	description  TEXT    NOT NULL DEFAULT '',
+	created_at   TEXT    NOT NULL
+);`
+
+	createMissionEventsTableSQL = `CREATE TABLE IF NOT EXISTS mission_events (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	mission_id  TEXT    NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+	type        TEXT    NOT NULL,
+	data        TEXT    NOT NULL DEFAULT '',
+	created_at  TEXT    NOT NULL
+);`
+	createMissionEventsMissionIDIndexSQL = `CREATE INDEX IF NOT EXISTS idx_mission_events_mission_id ON mission_events(mission_id, id);`
+
+	createMissionPromptsTableSQL = `CREATE TABLE IF NOT EXISTS mission_prompts (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	mission_id  TEXT    NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+	prompt      TEXT    NOT NULL,
+	created_at  TEXT    NOT NULL
+);`
+	createMissionPromptsMissionIDIndexSQL = `CREATE INDEX IF NOT EXISTS idx_mission_prompts_mission_id ON mission_prompts(mission_id, id);`
+
+	createMissionHandoffsTableSQL = `CREATE TABLE IF NOT EXISTS mission_handoffs (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	mission_id  TEXT    NOT NULL REFERENCES missions(id) ON DELETE CASCADE,
+	note        TEXT    NOT NULL,
+	created_at  TEXT    NOT NULL
+);`
+	createMissionHandoffsMissionIDIndexSQL = `CREATE INDEX IF NOT EXISTS idx_mission_handoffs_mission_id ON mission_handoffs(mission_id, id);`
+
+	createMissionBusyPeriodsTableSQL = `CREATE TABLE IF NOT EXISTS mission_busy_periods (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	mission_id  TEXT    REFERENCES missions(id) ON DELETE SET NULL,
+	git_repo    TEXT    NOT NULL DEFAULT '',
+	cron_name   TEXT    NOT NULL DEFAULT '',
+	started_at  TEXT    NOT NULL,
+	ended_at    TEXT    NOT NULL
+);`
+	createMissionBusyPeriodsStartedAtIndexSQL = `CREATE INDEX IF NOT EXISTS idx_mission_busy_periods_started_at ON mission_busy_periods(started_at);`
+
+	createProjectsTableSQL = `CREATE TABLE IF NOT EXISTS projects (
+	name        TEXT    PRIMARY KEY,
+	git_repo    TEXT    NOT NULL DEFAULT '',
+	notes       TEXT    NOT NULL DEFAULT '',
+	created_at  TEXT    NOT NULL,
+	updated_at  TEXT    NOT NULL
+);`
+	addProjectColumnSQL           = `ALTER TABLE missions ADD COLUMN project TEXT NOT NULL DEFAULT '';`
+	createMissionsProjectIndexSQL = `CREATE INDEX IF NOT EXISTS idx_missions_project ON missions(project);`
+
+	addLastErrorColumnSQL = `ALTER TABLE missions ADD COLUMN last_error TEXT NOT NULL DEFAULT '';`
+
+	addAgentDirBytesColumnSQL = `ALTER TABLE missions ADD COLUMN agent_dir_bytes INTEGER NOT NULL DEFAULT 0;`
+
+	addEnvColumnSQL = `ALTER TABLE missions ADD COLUMN env TEXT NOT NULL DEFAULT '';`
+
+	addGitReposColumnSQL     = `ALTER TABLE missions ADD COLUMN git_repos TEXT NOT NULL DEFAULT '';`
+	addGitRefColumnSQL       = `ALTER TABLE missions ADD COLUMN git_ref TEXT NOT NULL DEFAULT '';`
+	addGitRefCommitColumnSQL = `ALTER TABLE missions ADD COLUMN git_ref_commit TEXT NOT NULL DEFAULT '';`
 )
 
 // stripTmuxPanePercentSQL removes the leading "%" from tmux_pane values that
@@ -444,8 +545,16 @@ func migrateAddSessionShortID(conn *sql.DB) error {
 
 // migrateCleanOrphanedSessions removes sessions whose mission_id does not
 // reference an existing mission. These orphans could accumulate from periods
-// when foreign key constraints were not enforced.
+// when foreign key constraints were not enforced. Checks before deleting so
+// the common no-orphans case doesn't take the write lock.
 func migrateCleanOrphanedSessions(conn *sql.DB) error {
+	var hasOrphans bool
+	if err := conn.QueryRow(hasOrphanedSessionsSQL).Scan(&hasOrphans); err != nil {
+		return err
+	}
+	if !hasOrphans {
+		return nil
+	}
 	_, err := conn.Exec(cleanOrphanedSessionsSQL)
 	return err
 }
@@ -676,3 +785,298 @@ func migrateCreateWriteableCopyPausesTable(conn *sql.DB) error {
 	}
 	return nil
 }
+
+// migrateAddTags idempotently adds the tags column to the missions table.
+// Tags are stored as a comma-separated list with no surrounding whitespace.
+func migrateAddTags(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+
+	if columns["tags"] {
+		return nil
+	}
+
+	if _, err := conn.Exec(addTagsColumnSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to add tags column")
+	}
+	return nil
+}
+
+// migrateCreateMissionStatsTable idempotently creates the mission_stats table.
+// Rows are created lazily by the first wrapper stats report for a mission.
+func migrateCreateMissionStatsTable(conn *sql.DB) error {
+	if _, err := conn.Exec(createMissionStatsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_stats table")
+	}
+	return nil
+}
+
+// migrateCreateCronRunsTable idempotently creates the cron_runs table and its
+// per-cron lookup index.
+func migrateCreateCronRunsTable(conn *sql.DB) error {
+	if _, err := conn.Exec(createCronRunsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create cron_runs table")
+	}
+	if _, err := conn.Exec(createCronRunsCronIDIndexSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create cron_runs index")
+	}
+	return nil
+}
+
+// migrateAddPRURL idempotently adds the pr_url column to the missions table.
+// It holds the URL of the pull request opened by `agenc mission pr`.
+func migrateAddPRURL(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+
+	if columns["pr_url"] {
+		return nil
+	}
+
+	if _, err := conn.Exec(addPRURLColumnSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to add pr_url column")
+	}
+	return nil
+}
+
+// migrateAddBudgetColumns idempotently adds the max_prompts and budget_usd
+// columns to the missions table. Zero means the mission has no limit.
+func migrateAddBudgetColumns(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+
+	if !columns["max_prompts"] {
+		if _, err := conn.Exec(addMaxPromptsColumnSQL); err != nil {
+			return stacktrace.Propagate(err, "failed to add max_prompts column")
+		}
+	}
+
+	if !columns["budget_usd"] {
+		if _, err := conn.Exec(addBudgetUSDColumnSQL); err != nil {
+			return stacktrace.Propagate(err, "failed to add budget_usd column")
+		}
+	}
+	return nil
+}
+
+// migrateAddOwnershipColumns idempotently adds the owner and shared_with
+// columns to the missions table. Both are empty for missions created outside
+// multi-user mode, which every user may access.
+func migrateAddOwnershipColumns(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+
+	if !columns["owner"] {
+		if _, err := conn.Exec(addOwnerColumnSQL); err != nil {
+			return stacktrace.Propagate(err, "failed to add owner column")
+		}
+	}
+
+	if !columns["shared_with"] {
+		if _, err := conn.Exec(addSharedWithColumnSQL); err != nil {
+			return stacktrace.Propagate(err, "failed to add shared_with column")
+		}
+	}
+	return nil
+}
+
+// migrateAddDisplayName idempotently adds the display_name column to the
+// missions table, holding the name set with 'agenc mission rename'.
+func migrateAddDisplayName(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+	if columns["display_name"] {
+		return nil
+	}
+
+	if _, err := conn.Exec(addDisplayNameColumnSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to add display_name column")
+	}
+	return nil
+}
+
+// migrateCreateMissionEventsTable idempotently creates the mission_events
+// table backing 'agenc mission timeline', and its per-mission lookup index.
+func migrateCreateMissionEventsTable(conn *sql.DB) error {
+	if _, err := conn.Exec(createMissionEventsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_events table")
+	}
+	if _, err := conn.Exec(createMissionEventsMissionIDIndexSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_events index")
+	}
+	return nil
+}
+
+// migrateCreateCronAtJobsTable idempotently creates the cron_at_jobs table
+// holding one-shot jobs scheduled with 'agenc cron at'.
+func migrateCreateCronAtJobsTable(conn *sql.DB) error {
+	if _, err := conn.Exec(createCronAtJobsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create cron_at_jobs table")
+	}
+	return nil
+}
+
+// migrateCreateMissionPromptsTable idempotently creates the mission_prompts
+// table backing 'agenc mission prompts' and 'agenc mission replay', and its
+// per-mission lookup index.
+func migrateCreateMissionPromptsTable(conn *sql.DB) error {
+	if _, err := conn.Exec(createMissionPromptsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_prompts table")
+	}
+	if _, err := conn.Exec(createMissionPromptsMissionIDIndexSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_prompts index")
+	}
+	return nil
+}
+
+// migrateCreateMissionHandoffsTable idempotently creates the mission_handoffs
+// table holding the notes left when archiving a mission, and its per-mission
+// lookup index.
+func migrateCreateMissionHandoffsTable(conn *sql.DB) error {
+	if _, err := conn.Exec(createMissionHandoffsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_handoffs table")
+	}
+	if _, err := conn.Exec(createMissionHandoffsMissionIDIndexSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_handoffs index")
+	}
+	return nil
+}
+
+// migrateCreateMissionBusyPeriodsTable idempotently creates the
+// mission_busy_periods table holding the spans Claude spent working, and the
+// index time-window reports scan.
+func migrateCreateMissionBusyPeriodsTable(conn *sql.DB) error {
+	if _, err := conn.Exec(createMissionBusyPeriodsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_busy_periods table")
+	}
+	if _, err := conn.Exec(createMissionBusyPeriodsStartedAtIndexSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create mission_busy_periods index")
+	}
+	return nil
+}
+
+// migrateCreateProjects idempotently creates the projects table and adds the
+// missions.project column naming the project a mission belongs to (empty for
+// none), with an index for project-scoped listings.
+func migrateCreateProjects(conn *sql.DB) error {
+	if _, err := conn.Exec(createProjectsTableSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create projects table")
+	}
+
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+	if !columns["project"] {
+		if _, err := conn.Exec(addProjectColumnSQL); err != nil {
+			return stacktrace.Propagate(err, "failed to add project column")
+		}
+	}
+
+	if _, err := conn.Exec(createMissionsProjectIndexSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to create missions project index")
+	}
+	return nil
+}
+
+// migrateAddLastError idempotently adds the last_error column to the missions
+// table, describing how Claude last exited unexpectedly.
+func migrateAddLastError(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+	if columns["last_error"] {
+		return nil
+	}
+
+	if _, err := conn.Exec(addLastErrorColumnSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to add last_error column")
+	}
+	return nil
+}
+
+// migrateAddAgentDirBytes idempotently adds the agent_dir_bytes column to the
+// missions table, holding the last measured size of the mission's agent
+// directory.
+func migrateAddAgentDirBytes(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+	if columns["agent_dir_bytes"] {
+		return nil
+	}
+
+	if _, err := conn.Exec(addAgentDirBytesColumnSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to add agent_dir_bytes column")
+	}
+	return nil
+}
+
+// migrateAddEnv idempotently adds the env column to the missions table,
+// holding the JSON object of environment overrides the mission was created
+// with.
+func migrateAddEnv(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+	if columns["env"] {
+		return nil
+	}
+
+	if _, err := conn.Exec(addEnvColumnSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to add env column")
+	}
+	return nil
+}
+
+// migrateAddGitRepos idempotently adds the git_repos column to the missions
+// table, holding the JSON list of repos a multi-repo mission checks out side
+// by side.
+func migrateAddGitRepos(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+	if columns["git_repos"] {
+		return nil
+	}
+
+	if _, err := conn.Exec(addGitReposColumnSQL); err != nil {
+		return stacktrace.Propagate(err, "failed to add git_repos column")
+	}
+	return nil
+}
+
+// migrateAddGitRef idempotently adds the git_ref and git_ref_commit columns
+// to the missions table, recording the branch, tag, or commit a pinned cron
+// run checked out and the commit it resolved to.
+func migrateAddGitRef(conn *sql.DB) error {
+	columns, err := getColumnNames(conn)
+	if err != nil {
+		return err
+	}
+	if !columns["git_ref"] {
+		if _, err := conn.Exec(addGitRefColumnSQL); err != nil {
+			return stacktrace.Propagate(err, "failed to add git_ref column")
+		}
+	}
+	if !columns["git_ref_commit"] {
+		if _, err := conn.Exec(addGitRefCommitColumnSQL); err != nil {
+			return stacktrace.Propagate(err, "failed to add git_ref_commit column")
+		}
+	}
+	return nil
+}
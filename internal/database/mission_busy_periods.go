@@ -0,0 +1,90 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// MissionBusyPeriod is one span during which a mission's agent was working on
+// a prompt, from the prompt's submission until the agent stopped. The repo
+// and cron name are copied from the mission when the period is recorded, so
+// time reports survive the mission being deleted (MissionID is then empty).
+type MissionBusyPeriod struct {
+	ID        int64
+	MissionID string
+	GitRepo   string
+	CronName  string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Duration returns how long the period lasted.
+func (p *MissionBusyPeriod) Duration() time.Duration {
+	return p.EndedAt.Sub(p.StartedAt)
+}
+
+const missionBusyPeriodColumns = "id, mission_id, git_repo, cron_name, started_at, ended_at"
+
+// CreateMissionBusyPeriod records a busy period for a mission.
+func (db *DB) CreateMissionBusyPeriod(p *MissionBusyPeriod) error {
+	result, err := db.exec(
+		"INSERT INTO mission_busy_periods (mission_id, git_repo, cron_name, started_at, ended_at) VALUES (?, ?, ?, ?, ?)",
+		p.MissionID, p.GitRepo, p.CronName,
+		p.StartedAt.UTC().Format(time.RFC3339), p.EndedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to insert busy period for mission '%s'", p.MissionID)
+	}
+	if p.ID, err = result.LastInsertId(); err != nil {
+		return stacktrace.Propagate(err, "failed to read id of inserted busy period")
+	}
+	return nil
+}
+
+// ListMissionBusyPeriods returns the busy periods that overlap [from, to),
+// oldest first. Periods straddling either bound are returned whole; callers
+// clip them to the window.
+func (db *DB) ListMissionBusyPeriods(from time.Time, to time.Time) ([]*MissionBusyPeriod, error) {
+	rows, err := db.conn.Query(
+		"SELECT "+missionBusyPeriodColumns+" FROM mission_busy_periods WHERE started_at < ? AND ended_at > ? ORDER BY started_at, id",
+		to.UTC().Format(time.RFC3339), from.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to query mission busy periods")
+	}
+	defer rows.Close()
+
+	var periods []*MissionBusyPeriod
+	for rows.Next() {
+		p, err := scanMissionBusyPeriod(rows)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan mission busy period row")
+		}
+		periods = append(periods, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to iterate mission busy period rows")
+	}
+	return periods, nil
+}
+
+func scanMissionBusyPeriod(row rowScanner) (*MissionBusyPeriod, error) {
+	var p MissionBusyPeriod
+	var missionID sql.NullString
+	var startedAt, endedAt string
+	if err := row.Scan(&p.ID, &missionID, &p.GitRepo, &p.CronName, &startedAt, &endedAt); err != nil {
+		return nil, err
+	}
+	p.MissionID = missionID.String
+
+	var err error
+	if p.StartedAt, err = time.Parse(time.RFC3339, startedAt); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission_busy_periods started_at timestamp")
+	}
+	if p.EndedAt, err = time.Parse(time.RFC3339, endedAt); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission_busy_periods ended_at timestamp")
+	}
+	return &p, nil
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMissionBusyPeriods_WindowAndMissionDeletion(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	spans := [][2]time.Duration{
+		{-2 * time.Hour, -1 * time.Hour},      // before the window
+		{-30 * time.Minute, 30 * time.Minute}, // straddles the start
+		{9 * time.Hour, 10 * time.Hour},       // inside
+		{24 * time.Hour, 25 * time.Hour},      // starts at the window's end
+	}
+	for _, span := range spans {
+		p := &MissionBusyPeriod{
+			MissionID: mission.ID,
+			GitRepo:   "github.com/owner/repo",
+			CronName:  "nightly",
+			StartedAt: day.Add(span[0]),
+			EndedAt:   day.Add(span[1]),
+		}
+		if err := db.CreateMissionBusyPeriod(p); err != nil {
+			t.Fatalf("CreateMissionBusyPeriod failed: %v", err)
+		}
+	}
+
+	periods, err := db.ListMissionBusyPeriods(day, day.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("ListMissionBusyPeriods failed: %v", err)
+	}
+	if len(periods) != 2 {
+		t.Fatalf("expected the 2 periods overlapping the window, got %d", len(periods))
+	}
+	if !periods[0].StartedAt.Equal(day.Add(-30*time.Minute)) || periods[1].Duration() != time.Hour {
+		t.Errorf("unexpected periods: %+v, %+v", periods[0], periods[1])
+	}
+	if periods[1].GitRepo != "github.com/owner/repo" || periods[1].CronName != "nightly" {
+		t.Errorf("expected repo and cron to round-trip, got %+v", periods[1])
+	}
+
+	// Deleting the mission keeps its time on the books
+	if err := db.DeleteMission(mission.ID); err != nil {
+		t.Fatalf("DeleteMission failed: %v", err)
+	}
+	periods, err = db.ListMissionBusyPeriods(day, day.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("ListMissionBusyPeriods failed: %v", err)
+	}
+	if len(periods) != 2 || periods[0].MissionID != "" || periods[0].GitRepo != "github.com/owner/repo" {
+		t.Errorf("expected periods to outlive their mission with the repo kept, got %+v", periods)
+	}
+}
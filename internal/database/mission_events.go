@@ -0,0 +1,110 @@
+package database
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// MissionEvent is one entry in a mission's activity timeline: something the
+// agent or AgenC did to the mission (a prompt, a tool run, a restart, ...).
+type MissionEvent struct {
+	ID        int64
+	MissionID string
+	Type      string
+	Data      map[string]string
+	CreatedAt time.Time
+}
+
+const missionEventColumns = "id, mission_id, type, data, created_at"
+
+// CreateMissionEvent appends an event to a mission's timeline. CreatedAt is
+// set automatically if zero.
+func (db *DB) CreateMissionEvent(e *MissionEvent) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().UTC()
+	}
+	data := ""
+	if len(e.Data) > 0 {
+		encoded, err := json.Marshal(e.Data)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to encode data for mission event '%s'", e.Type)
+		}
+		data = string(encoded)
+	}
+	result, err := db.exec(
+		"INSERT INTO mission_events (mission_id, type, data, created_at) VALUES (?, ?, ?, ?)",
+		e.MissionID, e.Type, data, e.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to insert mission event '%s' for mission '%s'", e.Type, e.MissionID)
+	}
+	if e.ID, err = result.LastInsertId(); err != nil {
+		return stacktrace.Propagate(err, "failed to read id of inserted mission event")
+	}
+	return nil
+}
+
+// ListMissionEventsParams filters ListMissionEvents. Zero-valued fields don't
+// filter.
+type ListMissionEventsParams struct {
+	// MissionID restricts results to one mission's timeline.
+	MissionID string
+
+	// Types restricts results to events of the listed types.
+	Types []string
+
+	// From and To restrict results to events recorded within [From, To).
+	From time.Time
+	To   time.Time
+
+	// Limit keeps only the most recent events; 0 means no limit.
+	Limit int
+}
+
+// ListMissionEvents returns the events matching params, oldest first.
+func (db *DB) ListMissionEvents(params ListMissionEventsParams) ([]*MissionEvent, error) {
+	query, args := buildListMissionEventsQuery(params)
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to query mission events")
+	}
+	defer rows.Close()
+
+	var result []*MissionEvent
+	for rows.Next() {
+		event, err := scanMissionEvent(rows)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan mission event row")
+		}
+		result = append(result, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "error iterating mission event rows")
+	}
+	// Queried newest first so a limit keeps the most recent events
+	slices.Reverse(result)
+	return result, nil
+}
+
+func scanMissionEvent(row rowScanner) (*MissionEvent, error) {
+	var e MissionEvent
+	var data, createdAt string
+	if err := row.Scan(&e.ID, &e.MissionID, &e.Type, &data, &createdAt); err != nil {
+		return nil, err
+	}
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &e.Data); err != nil {
+			return nil, stacktrace.Propagate(err, "failed to parse mission_events data")
+		}
+	}
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission_events created_at timestamp")
+	}
+	e.CreatedAt = t
+	return &e, nil
+}
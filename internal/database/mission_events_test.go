@@ -0,0 +1,123 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMissionEvents_ListAndCascade(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	start := time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC)
+	events := []*MissionEvent{
+		{MissionID: mission.ID, Type: "mission.prompt", CreatedAt: start},
+		{MissionID: mission.ID, Type: "mission.tool_run", Data: map[string]string{"tool": "Bash"}, CreatedAt: start.Add(time.Hour)},
+		{MissionID: mission.ID, Type: "mission.restarted", Data: map[string]string{"reason": "crash"}, CreatedAt: start.Add(2 * time.Hour)},
+	}
+	for _, e := range events {
+		if err := db.CreateMissionEvent(e); err != nil {
+			t.Fatalf("CreateMissionEvent failed: %v", err)
+		}
+	}
+
+	all, err := db.ListMissionEvents(ListMissionEventsParams{MissionID: mission.ID})
+	if err != nil {
+		t.Fatalf("ListMissionEvents failed: %v", err)
+	}
+	if len(all) != 3 || all[0].Type != "mission.prompt" || all[2].Type != "mission.restarted" {
+		t.Fatalf("expected three events oldest first, got %+v", all)
+	}
+	if all[1].Data["tool"] != "Bash" || all[0].Data != nil {
+		t.Errorf("expected data to round-trip, got %+v and %+v", all[1].Data, all[0].Data)
+	}
+
+	latest, err := db.ListMissionEvents(ListMissionEventsParams{MissionID: mission.ID, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListMissionEvents failed: %v", err)
+	}
+	if len(latest) != 2 || latest[0].Type != "mission.tool_run" || latest[1].Type != "mission.restarted" {
+		t.Errorf("expected the two most recent events oldest first, got %+v", latest)
+	}
+
+	since, err := db.ListMissionEvents(ListMissionEventsParams{MissionID: mission.ID, From: start.Add(90 * time.Minute)})
+	if err != nil {
+		t.Fatalf("ListMissionEvents failed: %v", err)
+	}
+	if len(since) != 1 || since[0].Type != "mission.restarted" {
+		t.Errorf("expected only the restart after the since time, got %+v", since)
+	}
+
+	if err := db.DeleteMission(mission.ID); err != nil {
+		t.Fatalf("DeleteMission failed: %v", err)
+	}
+	remaining, err := db.ListMissionEvents(ListMissionEventsParams{MissionID: mission.ID})
+	if err != nil {
+		t.Fatalf("ListMissionEvents failed: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected events to be deleted with the mission, got %d", len(remaining))
+	}
+}
+
+func TestListActivityBetween(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	for _, e := range []*MissionEvent{
+		{MissionID: mission.ID, Type: "mission.crashed", CreatedAt: from.Add(-time.Minute)},
+		{MissionID: mission.ID, Type: "mission.crashed", CreatedAt: from},
+		{MissionID: mission.ID, Type: "mission.tool_run", CreatedAt: from.Add(time.Hour)},
+		{MissionID: mission.ID, Type: "mission.archived", CreatedAt: from.Add(2 * time.Hour)},
+		{MissionID: mission.ID, Type: "mission.crashed", CreatedAt: to},
+	} {
+		if err := db.CreateMissionEvent(e); err != nil {
+			t.Fatalf("CreateMissionEvent failed: %v", err)
+		}
+	}
+	events, err := db.ListMissionEvents(ListMissionEventsParams{Types: []string{"mission.crashed", "mission.archived"}, From: from, To: to})
+	if err != nil {
+		t.Fatalf("ListMissionEvents failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Type != "mission.crashed" || events[1].Type != "mission.archived" {
+		t.Errorf("expected the crash and archive inside the window, got %+v", events)
+	}
+
+	for _, at := range []time.Time{from.Add(-time.Second), from.Add(3 * time.Hour), from.Add(4 * time.Hour)} {
+		if err := db.CreateMissionPrompt(&MissionPrompt{MissionID: mission.ID, Prompt: "go", CreatedAt: at}); err != nil {
+			t.Fatalf("CreateMissionPrompt failed: %v", err)
+		}
+	}
+	prompts, err := db.ListMissionPrompts(ListMissionPromptsParams{From: from, To: to})
+	if err != nil {
+		t.Fatalf("ListMissionPrompts failed: %v", err)
+	}
+	if len(prompts) != 2 {
+		t.Errorf("expected two prompts inside the window, got %d", len(prompts))
+	}
+
+	for i, at := range []time.Time{from.Add(5 * time.Hour), to.Add(time.Hour)} {
+		run := &CronRun{ID: fmt.Sprintf("run-%d", i), CronID: "cron-a", CronName: "nightly", Trigger: CronRunTriggerSchedule, Status: CronRunStatusSucceeded, StartedAt: at}
+		if err := db.CreateCronRun(run); err != nil {
+			t.Fatalf("CreateCronRun failed: %v", err)
+		}
+	}
+	runs, err := db.ListCronRuns(ListCronRunsParams{From: from, To: to})
+	if err != nil {
+		t.Fatalf("ListCronRuns failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != "run-0" {
+		t.Errorf("expected only the run started inside the window, got %+v", runs)
+	}
+}
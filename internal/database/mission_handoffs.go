@@ -0,0 +1,69 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// MissionHandoff is a note left when archiving a mission, summarizing where
+// the work stands for whoever (or whichever agent) resumes it.
+type MissionHandoff struct {
+	ID        int64
+	MissionID string
+	Note      string
+	CreatedAt time.Time
+}
+
+const missionHandoffColumns = "id, mission_id, note, created_at"
+
+// CreateMissionHandoff records a handoff note for a mission. Earlier notes are
+// kept; the latest one is current. CreatedAt is set automatically if zero.
+func (db *DB) CreateMissionHandoff(h *MissionHandoff) error {
+	if h.CreatedAt.IsZero() {
+		h.CreatedAt = time.Now().UTC()
+	}
+	result, err := db.exec(
+		"INSERT INTO mission_handoffs (mission_id, note, created_at) VALUES (?, ?, ?)",
+		h.MissionID, h.Note, h.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to insert handoff for mission '%s'", h.MissionID)
+	}
+	if h.ID, err = result.LastInsertId(); err != nil {
+		return stacktrace.Propagate(err, "failed to read id of inserted mission handoff")
+	}
+	return nil
+}
+
+// GetLatestMissionHandoff returns a mission's most recent handoff note, or nil
+// if it has none.
+func (db *DB) GetLatestMissionHandoff(missionID string) (*MissionHandoff, error) {
+	row := db.conn.QueryRow(
+		"SELECT "+missionHandoffColumns+" FROM mission_handoffs WHERE mission_id = ? ORDER BY id DESC LIMIT 1",
+		missionID,
+	)
+	h, err := scanMissionHandoff(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to get handoff for mission '%s'", missionID)
+	}
+	return h, nil
+}
+
+func scanMissionHandoff(row rowScanner) (*MissionHandoff, error) {
+	var h MissionHandoff
+	var createdAt string
+	if err := row.Scan(&h.ID, &h.MissionID, &h.Note, &createdAt); err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission_handoffs created_at timestamp")
+	}
+	h.CreatedAt = t
+	return &h, nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMissionHandoffs_LatestAndCascade(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	got, err := db.GetLatestMissionHandoff(mission.ID)
+	if err != nil {
+		t.Fatalf("GetLatestMissionHandoff failed: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected no handoff before one is recorded, got %+v", got)
+	}
+
+	// Same timestamp for both: the latest must come from insertion order
+	archivedAt := time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC)
+	for _, note := range []string{"parser done, tests pending", "tests written; CI flaky on macOS"} {
+		if err := db.CreateMissionHandoff(&MissionHandoff{MissionID: mission.ID, Note: note, CreatedAt: archivedAt}); err != nil {
+			t.Fatalf("CreateMissionHandoff failed: %v", err)
+		}
+	}
+
+	got, err = db.GetLatestMissionHandoff(mission.ID)
+	if err != nil {
+		t.Fatalf("GetLatestMissionHandoff failed: %v", err)
+	}
+	if got == nil || got.Note != "tests written; CI flaky on macOS" {
+		t.Fatalf("expected the latest handoff, got %+v", got)
+	}
+	if !got.CreatedAt.Equal(archivedAt) {
+		t.Errorf("expected created_at to round-trip, got %v", got.CreatedAt)
+	}
+
+	if err := db.DeleteMission(mission.ID); err != nil {
+		t.Fatalf("DeleteMission failed: %v", err)
+	}
+	got, err = db.GetLatestMissionHandoff(mission.ID)
+	if err != nil {
+		t.Fatalf("GetLatestMissionHandoff failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected handoffs to be deleted with their mission, got %+v", got)
+	}
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// MissionPrompt is one prompt the user submitted to a mission's agent, as
+// reported by the UserPromptSubmit hook.
+type MissionPrompt struct {
+	ID        int64
+	MissionID string
+	Prompt    string
+	CreatedAt time.Time
+}
+
+const missionPromptColumns = "id, mission_id, prompt, created_at"
+
+// CreateMissionPrompt appends a prompt to a mission's prompt history.
+// CreatedAt is set automatically if zero.
+func (db *DB) CreateMissionPrompt(p *MissionPrompt) error {
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC()
+	}
+	result, err := db.exec(
+		"INSERT INTO mission_prompts (mission_id, prompt, created_at) VALUES (?, ?, ?)",
+		p.MissionID, p.Prompt, p.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to insert prompt for mission '%s'", p.MissionID)
+	}
+	if p.ID, err = result.LastInsertId(); err != nil {
+		return stacktrace.Propagate(err, "failed to read id of inserted mission prompt")
+	}
+	return nil
+}
+
+// ListMissionPromptsParams filters ListMissionPrompts. Zero-valued fields
+// don't filter.
+type ListMissionPromptsParams struct {
+	// MissionID restricts results to the prompts of one mission.
+	MissionID string
+
+	// From and To restrict results to prompts submitted within [From, To).
+	From time.Time
+	To   time.Time
+}
+
+// ListMissionPrompts returns the prompts matching params, in the order they
+// were submitted.
+func (db *DB) ListMissionPrompts(params ListMissionPromptsParams) ([]*MissionPrompt, error) {
+	query, args := buildListMissionPromptsQuery(params)
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to query mission prompts")
+	}
+	return collectMissionPrompts(rows)
+}
+
+// collectMissionPrompts scans and closes rows of mission prompts.
+func collectMissionPrompts(rows *sql.Rows) ([]*MissionPrompt, error) {
+	defer rows.Close()
+
+	var result []*MissionPrompt
+	for rows.Next() {
+		prompt, err := scanMissionPrompt(rows)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan mission prompt row")
+		}
+		result = append(result, prompt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "error iterating mission prompt rows")
+	}
+	return result, nil
+}
+
+func scanMissionPrompt(row rowScanner) (*MissionPrompt, error) {
+	var p MissionPrompt
+	var createdAt string
+	if err := row.Scan(&p.ID, &p.MissionID, &p.Prompt, &createdAt); err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission_prompts created_at timestamp")
+	}
+	p.CreatedAt = t
+	return &p, nil
+}
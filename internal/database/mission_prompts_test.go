@@ -0,0 +1,57 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMissionPrompts_ListAndCascade(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	other, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	// Same timestamp for all three: order must come from insertion, not time
+	submittedAt := time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC)
+	for _, text := range []string{"add a CLI flag", "now write tests", "add a CLI flag"} {
+		if err := db.CreateMissionPrompt(&MissionPrompt{MissionID: mission.ID, Prompt: text, CreatedAt: submittedAt}); err != nil {
+			t.Fatalf("CreateMissionPrompt failed: %v", err)
+		}
+	}
+	if err := db.CreateMissionPrompt(&MissionPrompt{MissionID: other.ID, Prompt: "unrelated"}); err != nil {
+		t.Fatalf("CreateMissionPrompt failed: %v", err)
+	}
+
+	prompts, err := db.ListMissionPrompts(ListMissionPromptsParams{MissionID: mission.ID})
+	if err != nil {
+		t.Fatalf("ListMissionPrompts failed: %v", err)
+	}
+	if len(prompts) != 3 {
+		t.Fatalf("expected 3 prompts, got %d", len(prompts))
+	}
+	for i, want := range []string{"add a CLI flag", "now write tests", "add a CLI flag"} {
+		if prompts[i].Prompt != want {
+			t.Errorf("prompt %d: expected %q, got %q", i, want, prompts[i].Prompt)
+		}
+	}
+	if !prompts[0].CreatedAt.Equal(submittedAt) {
+		t.Errorf("expected created_at to round-trip, got %v", prompts[0].CreatedAt)
+	}
+
+	if err := db.DeleteMission(mission.ID); err != nil {
+		t.Fatalf("DeleteMission failed: %v", err)
+	}
+	remaining, err := db.ListMissionPrompts(ListMissionPromptsParams{MissionID: mission.ID})
+	if err != nil {
+		t.Fatalf("ListMissionPrompts failed: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected prompts to be deleted with their mission, got %d", len(remaining))
+	}
+}
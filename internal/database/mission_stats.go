@@ -0,0 +1,134 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// MissionStats holds per-mission resource usage accounting: cumulative token
+// usage parsed from the mission's session transcripts, wall-clock time the
+// wrapper has been running, and how many times Claude has been started.
+type MissionStats struct {
+	MissionID           string
+	InputTokens         int64
+	OutputTokens        int64
+	CacheReadTokens     int64
+	CacheCreationTokens int64
+	WallClockSeconds    int64
+	ClaudeStarts        int64
+	UpdatedAt           time.Time
+}
+
+// TotalTokens returns the sum of all token categories.
+func (s *MissionStats) TotalTokens() int64 {
+	return s.InputTokens + s.OutputTokens + s.CacheReadTokens + s.CacheCreationTokens
+}
+
+// Restarts returns the number of Claude starts beyond the first.
+func (s *MissionStats) Restarts() int64 {
+	if s.ClaudeStarts <= 1 {
+		return 0
+	}
+	return s.ClaudeStarts - 1
+}
+
+// MissionStatsUpdate describes a single stats report from a wrapper. Token
+// counts are absolute totals (the wrapper re-derives them from transcripts)
+// and replace the stored values when non-nil. Wall-clock seconds and Claude
+// starts are deltas added to the stored values.
+type MissionStatsUpdate struct {
+	InputTokens           *int64
+	OutputTokens          *int64
+	CacheReadTokens       *int64
+	CacheCreationTokens   *int64
+	WallClockSecondsDelta int64
+	ClaudeStartsDelta     int64
+}
+
+// RecordMissionStats applies a stats update for a mission, creating the row if
+// it does not yet exist.
+func (db *DB) RecordMissionStats(missionID string, update MissionStatsUpdate) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err := db.exec(`
+		INSERT INTO mission_stats (mission_id, input_tokens, output_tokens, cache_read_tokens, cache_creation_tokens, wall_clock_seconds, claude_starts, updated_at)
+		VALUES (?, COALESCE(?, 0), COALESCE(?, 0), COALESCE(?, 0), COALESCE(?, 0), ?, ?, ?)
+		ON CONFLICT(mission_id) DO UPDATE SET
+			input_tokens = COALESCE(?, input_tokens),
+			output_tokens = COALESCE(?, output_tokens),
+			cache_read_tokens = COALESCE(?, cache_read_tokens),
+			cache_creation_tokens = COALESCE(?, cache_creation_tokens),
+			wall_clock_seconds = wall_clock_seconds + excluded.wall_clock_seconds,
+			claude_starts = claude_starts + excluded.claude_starts,
+			updated_at = excluded.updated_at`,
+		missionID, update.InputTokens, update.OutputTokens, update.CacheReadTokens, update.CacheCreationTokens,
+		update.WallClockSecondsDelta, update.ClaudeStartsDelta, now,
+		update.InputTokens, update.OutputTokens, update.CacheReadTokens, update.CacheCreationTokens,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to record stats for mission '%s'", missionID)
+	}
+	return nil
+}
+
+// GetMissionStats returns the stats row for a mission.
+// Returns (nil, nil) if no stats have been recorded yet.
+func (db *DB) GetMissionStats(missionID string) (*MissionStats, error) {
+	row := db.conn.QueryRow(
+		"SELECT mission_id, input_tokens, output_tokens, cache_read_tokens, cache_creation_tokens, wall_clock_seconds, claude_starts, updated_at FROM mission_stats WHERE mission_id = ?",
+		missionID,
+	)
+	stats, err := scanMissionStats(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to get stats for mission '%s'", missionID)
+	}
+	return stats, nil
+}
+
+// ListMissionStats returns stats rows for all missions, ordered by total
+// token usage descending.
+func (db *DB) ListMissionStats() ([]*MissionStats, error) {
+	rows, err := db.conn.Query(
+		"SELECT mission_id, input_tokens, output_tokens, cache_read_tokens, cache_creation_tokens, wall_clock_seconds, claude_starts, updated_at FROM mission_stats ORDER BY (input_tokens + output_tokens + cache_read_tokens + cache_creation_tokens) DESC",
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to query mission stats")
+	}
+	defer rows.Close()
+
+	var result []*MissionStats
+	for rows.Next() {
+		stats, err := scanMissionStats(rows)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan mission stats row")
+		}
+		result = append(result, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "error iterating mission stats rows")
+	}
+	return result, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMissionStats(row rowScanner) (*MissionStats, error) {
+	var s MissionStats
+	var updatedAt string
+	if err := row.Scan(&s.MissionID, &s.InputTokens, &s.OutputTokens, &s.CacheReadTokens, &s.CacheCreationTokens, &s.WallClockSeconds, &s.ClaudeStarts, &updatedAt); err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(time.RFC3339, updatedAt)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission_stats updated_at timestamp")
+	}
+	s.UpdatedAt = t
+	return &s, nil
+}
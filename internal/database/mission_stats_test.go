@@ -0,0 +1,80 @@
+package database
+
+import "testing"
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func TestRecordMissionStats_AccumulatesDeltasAndReplacesTokens(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	stats, err := db.GetMissionStats(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMissionStats failed: %v", err)
+	}
+	if stats != nil {
+		t.Fatalf("expected nil stats before first report, got %+v", stats)
+	}
+
+	if err := db.RecordMissionStats(mission.ID, MissionStatsUpdate{ClaudeStartsDelta: 1}); err != nil {
+		t.Fatalf("RecordMissionStats failed: %v", err)
+	}
+	if err := db.RecordMissionStats(mission.ID, MissionStatsUpdate{
+		InputTokens:           int64Ptr(100),
+		OutputTokens:          int64Ptr(50),
+		WallClockSecondsDelta: 10,
+	}); err != nil {
+		t.Fatalf("RecordMissionStats failed: %v", err)
+	}
+	if err := db.RecordMissionStats(mission.ID, MissionStatsUpdate{
+		InputTokens:           int64Ptr(300),
+		WallClockSecondsDelta: 10,
+		ClaudeStartsDelta:     1,
+	}); err != nil {
+		t.Fatalf("RecordMissionStats failed: %v", err)
+	}
+
+	stats, err = db.GetMissionStats(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMissionStats failed: %v", err)
+	}
+	if stats.InputTokens != 300 {
+		t.Errorf("expected input tokens replaced with 300, got %d", stats.InputTokens)
+	}
+	if stats.OutputTokens != 50 {
+		t.Errorf("expected output tokens preserved at 50 when omitted, got %d", stats.OutputTokens)
+	}
+	if stats.WallClockSeconds != 20 {
+		t.Errorf("expected wall clock 20s, got %d", stats.WallClockSeconds)
+	}
+	if stats.ClaudeStarts != 2 || stats.Restarts() != 1 {
+		t.Errorf("expected 2 starts / 1 restart, got %d / %d", stats.ClaudeStarts, stats.Restarts())
+	}
+}
+
+func TestMissionStats_DeletedWithMission(t *testing.T) {
+	db := openTestDB(t)
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if err := db.RecordMissionStats(mission.ID, MissionStatsUpdate{ClaudeStartsDelta: 1}); err != nil {
+		t.Fatalf("RecordMissionStats failed: %v", err)
+	}
+	if err := db.DeleteMission(mission.ID); err != nil {
+		t.Fatalf("DeleteMission failed: %v", err)
+	}
+
+	all, err := db.ListMissionStats()
+	if err != nil {
+		t.Fatalf("ListMissionStats failed: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("expected stats to cascade-delete with mission, got %d rows", len(all))
+	}
+}
@@ -2,7 +2,10 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,6 +13,8 @@ import (
 	"github.com/mieubrisse/stacktrace"
 )
 
+var tagRegex = regexp.MustCompile(`^[A-Za-z0-9._/:-]+$`)
+
 // Mission represents a row in the missions table.
 type Mission struct {
 	ID                   string
@@ -29,9 +34,57 @@ type Mission struct {
 	ConfigCommit         *string
 	TmuxPane             *string
 	PromptCount          int
+	Tags                 []string
+	PRURL                string
+	MaxPrompts           int
+	BudgetUSD            float64
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 
+	// Owner is the OS user who created the mission in multi-user mode, and
+	// SharedWith the other users they granted access. Both are empty outside
+	// multi-user mode.
+	Owner      string
+	SharedWith []string
+
+	// DisplayName is the name given with 'agenc mission rename'. When set it
+	// takes precedence over session titles everywhere the mission is shown.
+	DisplayName string
+
+	// AISummary is the AI-generated description of what the mission is doing,
+	// and LastSummaryPromptCount the prompt_count it was generated at. See
+	// missionSummary in config.yml.
+	AISummary              string
+	LastSummaryPromptCount int
+
+	// Project is the name of the project the mission belongs to, or empty.
+	Project string
+
+	// LastError describes how Claude last exited unexpectedly (non-zero exit
+	// code), or is empty. Cleared on the next clean exit. The full crash
+	// report is written to the mission's crash-report.txt.
+	LastError string
+
+	// AgentDirBytes is the size of the mission's agent directory when the
+	// server last measured it, or 0 if it has not been measured.
+	AgentDirBytes int64
+
+	// Env holds the environment overrides given at creation ('agenc mission
+	// new --env'), applied to every Claude spawn of the mission. Values may
+	// be secret://NAME references, resolved at spawn time.
+	Env map[string]string
+
+	// GitRepos lists the repos of a multi-repo mission, each checked out in
+	// its own directory under agent/. GitRepo is empty for such missions,
+	// since agent/ itself is not a git repo.
+	GitRepos []string
+
+	// GitRef is the branch, tag, or commit the mission was pinned to at
+	// creation (a cron's ref), and GitRefCommit the commit it resolved to.
+	// Both are empty for missions started from the default branch.
+	GitRef       string
+	GitRefCommit string
+
 	// ResolvedSessionTitle is a transient field (not stored in the database).
 	// It is populated by the server from the active session's title chain:
 	// custom_title > agenc_custom_title > auto_summary.
@@ -49,6 +102,32 @@ type Mission struct {
 	// the database. True if the mission's tmux pane is currently linked into a
 	// session outside the pool (i.e. the mission is "attached").
 	IsAttached bool
+
+	// QueuePosition is a transient field populated by the server API, not
+	// stored in the database. The mission's 1-based place in the start queue
+	// while it waits for a slot under missionsMaxConcurrent; 0 otherwise.
+	QueuePosition int
+
+	// BranchConflicts is a transient field populated by the server API, not
+	// stored in the database. When the mission is created, the short IDs of
+	// the other active missions on the same repo and branch.
+	BranchConflicts []string
+
+	// UsedCachedClone is a transient field populated by the server API, not
+	// stored in the database. When the mission is created, whether it started
+	// from the library's cached clone because the remote was unreachable.
+	UsedCachedClone bool
+
+	// IsOversized is a transient field populated by the server API, not
+	// stored in the database. True if AgentDirBytes exceeds the
+	// missionSizeLimit maxSize.
+	IsOversized bool
+
+	// WrapperHealth is a transient field populated by the server API, not
+	// stored in the database. The status of the server's recent pings of the
+	// mission's wrapper ("healthy", "degraded", or "unresponsive"), or empty
+	// when the wrapper isn't running or hasn't been pinged yet.
+	WrapperHealth string
 }
 
 // CreateMissionParams holds optional parameters for creating a mission.
@@ -57,6 +136,28 @@ type CreateMissionParams struct {
 	SourceID       *string
 	SourceMetadata *string
 	ConfigCommit   *string
+
+	// MaxPrompts and BudgetUSD cap the mission's prompt count and estimated
+	// spend; the wrapper stops Claude once either is reached. Zero means no
+	// limit.
+	MaxPrompts int
+	BudgetUSD  float64
+
+	// Owner is the OS user creating the mission in multi-user mode.
+	Owner string
+
+	// Project is the name of the project the mission belongs to, if any.
+	Project string
+
+	// Env holds the mission's environment overrides.
+	Env map[string]string
+
+	// GitRepos lists the repos of a multi-repo mission.
+	GitRepos []string
+
+	// GitRef and GitRefCommit record the ref the mission was pinned to.
+	GitRef       string
+	GitRefCommit string
 }
 
 // ListMissionsParams holds optional parameters for filtering missions.
@@ -66,6 +167,52 @@ type ListMissionsParams struct {
 	SourceID        *string
 	Since           *time.Time
 	Until           *time.Time
+
+	// Tags restricts results to missions carrying every listed tag.
+	Tags []string
+
+	// Project restricts results to missions in the named project.
+	Project string
+
+	// Sort selects the order; empty means MissionSortActivity.
+	Sort MissionSort
+
+	// Limit caps the number of missions returned; 0 means no limit.
+	Limit int
+
+	// Offset skips that many missions from the start of the ordered results.
+	Offset int
+
+	// After, when set to a mission ID, returns only the missions ordered after
+	// that mission (keyset paging). Unlike Offset, pages stay consistent when
+	// missions are created or deleted between requests.
+	After string
+}
+
+// MissionSort selects the order ListMissions returns missions in. Every
+// order is newest first, with ties kept in creation order, so paging through
+// the results is stable.
+type MissionSort string
+
+const (
+	// MissionSortActivity orders by the last user prompt, falling back to
+	// the creation time for missions never prompted.
+	MissionSortActivity MissionSort = "activity"
+	MissionSortCreated  MissionSort = "created"
+	MissionSortUpdated  MissionSort = "updated"
+)
+
+// ParseMissionSort validates a sort name; an empty name yields
+// MissionSortActivity.
+func ParseMissionSort(name string) (MissionSort, error) {
+	switch order := MissionSort(name); order {
+	case "":
+		return MissionSortActivity, nil
+	case MissionSortActivity, MissionSortCreated, MissionSortUpdated:
+		return order, nil
+	default:
+		return "", stacktrace.NewError("invalid sort '%s'; must be one of: %s, %s, %s", name, MissionSortActivity, MissionSortCreated, MissionSortUpdated)
+	}
 }
 
 // CreateMission inserts a new mission and returns it.
@@ -75,16 +222,38 @@ func (db *DB) CreateMission(gitRepo string, params *CreateMissionParams) (*Missi
 	now := time.Now().UTC().Format(time.RFC3339)
 
 	var configCommit, source, sourceID, sourceMetadata *string
+	var maxPrompts int
+	var budgetUSD float64
+	var owner, project string
+	var env map[string]string
+	var gitRepos []string
+	var gitRef, gitRefCommit string
 	if params != nil {
 		configCommit = params.ConfigCommit
 		source = params.Source
 		sourceID = params.SourceID
 		sourceMetadata = params.SourceMetadata
+		maxPrompts = params.MaxPrompts
+		budgetUSD = params.BudgetUSD
+		owner = params.Owner
+		project = params.Project
+		env = params.Env
+		gitRepos = params.GitRepos
+		gitRef = params.GitRef
+		gitRefCommit = params.GitRefCommit
+	}
+	envJSON, err := encodeMissionEnv(env)
+	if err != nil {
+		return nil, err
+	}
+	gitReposJSON, err := encodeMissionGitRepos(gitRepos)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := db.conn.Exec(
-		"INSERT INTO missions (id, short_id, git_repo, status, config_commit, source, source_id, source_metadata, created_at, updated_at) VALUES (?, ?, ?, 'active', ?, ?, ?, ?, ?, ?)",
-		id, shortID, gitRepo, configCommit, source, sourceID, sourceMetadata, now, now,
+	_, err = db.exec(
+		"INSERT INTO missions (id, short_id, git_repo, status, config_commit, source, source_id, source_metadata, max_prompts, budget_usd, owner, project, env, git_repos, git_ref, git_ref_commit, created_at, updated_at) VALUES (?, ?, ?, 'active', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, shortID, gitRepo, configCommit, source, sourceID, sourceMetadata, maxPrompts, budgetUSD, owner, project, envJSON, gitReposJSON, gitRef, gitRefCommit, now, now,
 	)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to insert mission")
@@ -99,16 +268,118 @@ func (db *DB) CreateMission(gitRepo string, params *CreateMissionParams) (*Missi
 		SourceID:       sourceID,
 		SourceMetadata: sourceMetadata,
 		ConfigCommit:   configCommit,
+		MaxPrompts:     maxPrompts,
+		BudgetUSD:      budgetUSD,
+		Owner:          owner,
+		Project:        project,
+		Env:            env,
+		GitRepos:       gitRepos,
+		GitRef:         gitRef,
+		GitRefCommit:   gitRefCommit,
 		CreatedAt:      time.Now().UTC(),
 		UpdatedAt:      time.Now().UTC(),
 	}, nil
 }
 
+// InsertImportedMission inserts a mission row carried over from another
+// machine (see `agenc mission import`), preserving its ID, creation time,
+// and conversation metadata. Machine-local fields (heartbeat, tmux pane,
+// cron linkage, config commit) start empty.
+func (db *DB) InsertImportedMission(m *Mission) error {
+	for _, tag := range m.Tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err := db.exec(
+		`INSERT INTO missions (id, short_id, prompt, status, git_repo, last_user_prompt_at, session_name, session_name_updated_at, source, source_id, source_metadata, prompt_count, tags, pr_url, display_name, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.ID, ShortID(m.ID), m.Prompt, m.Status, m.GitRepo,
+		formatNullableTime(m.LastUserPromptAt), m.SessionName, formatNullableTime(m.SessionNameUpdatedAt),
+		m.Source, m.SourceID, m.SourceMetadata, m.PromptCount, joinTags(m.Tags), m.PRURL, m.DisplayName,
+		m.CreatedAt.UTC().Format(time.RFC3339), now,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to insert imported mission '%s'", m.ID)
+	}
+	return nil
+}
+
+// encodeMissionEnv serializes env overrides for the env column; no overrides
+// is stored as an empty string.
+func encodeMissionEnv(env map[string]string) (string, error) {
+	if len(env) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to encode mission env")
+	}
+	return string(data), nil
+}
+
+// decodeMissionEnv parses the env column, the inverse of encodeMissionEnv.
+func decodeMissionEnv(envJSON string) (map[string]string, error) {
+	if envJSON == "" {
+		return nil, nil
+	}
+	var env map[string]string
+	if err := json.Unmarshal([]byte(envJSON), &env); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission env")
+	}
+	return env, nil
+}
+
+// encodeMissionGitRepos serializes a multi-repo mission's repos for the
+// git_repos column; single-repo and blank missions store an empty string.
+func encodeMissionGitRepos(gitRepos []string) (string, error) {
+	if len(gitRepos) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(gitRepos)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to encode mission git repos")
+	}
+	return string(data), nil
+}
+
+// decodeMissionGitRepos parses the git_repos column, the inverse of
+// encodeMissionGitRepos.
+func decodeMissionGitRepos(gitReposJSON string) ([]string, error) {
+	if gitReposJSON == "" {
+		return nil, nil
+	}
+	var gitRepos []string
+	if err := json.Unmarshal([]byte(gitReposJSON), &gitRepos); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse mission git repos")
+	}
+	return gitRepos, nil
+}
+
+// formatNullableTime formats t as RFC3339 for storage, or nil when t is nil.
+func formatNullableTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
 // ListMissions returns missions ordered by the most recent activity timestamp
 // (newest of last_heartbeat or created_at) descending.
 // If params.IncludeArchived is true, all missions are returned; otherwise archived missions are excluded.
 func (db *DB) ListMissions(params ListMissionsParams) ([]*Mission, error) {
-	query, args := buildListMissionsQuery(params)
+	var afterKeys []interface{}
+	if params.After != "" {
+		keys, err := db.missionSortKeys(params.After, params.Sort)
+		if err != nil {
+			return nil, err
+		}
+		afterKeys = keys
+	}
+	query, args := buildListMissionsQuery(params, afterKeys)
 
 	rows, err := db.conn.Query(query, args...)
 	if err != nil {
@@ -119,12 +390,34 @@ func (db *DB) ListMissions(params ListMissionsParams) ([]*Mission, error) {
 	return scanMissions(rows)
 }
 
+// missionSortKeys returns the values of the sort expressions for one
+// mission, which anchor a keyset page that starts after it.
+func (db *DB) missionSortKeys(missionID string, order MissionSort) ([]interface{}, error) {
+	terms := missionSortTerms(order)
+	exprs := make([]string, len(terms))
+	keys := make([]interface{}, len(terms))
+	dests := make([]interface{}, len(terms))
+	for i, term := range terms {
+		exprs[i] = term.expr
+		dests[i] = &keys[i]
+	}
+
+	row := db.conn.QueryRow("SELECT "+strings.Join(exprs, ", ")+" FROM missions WHERE id = ?", missionID)
+	if err := row.Scan(dests...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, stacktrace.NewError("cursor mission '%s' not found", missionID)
+		}
+		return nil, stacktrace.Propagate(err, "failed to read sort keys of mission '%s'", missionID)
+	}
+	return keys, nil
+}
+
 // GetMission returns a single mission by ID.
 // Returns (nil, nil) if the mission is not found.
 // Returns (nil, error) only for actual database failures.
 func (db *DB) GetMission(id string) (*Mission, error) {
 	row := db.conn.QueryRow(
-		"SELECT id, short_id, prompt, status, git_repo, last_heartbeat, last_user_prompt_at, session_name, session_name_updated_at, cron_id, cron_name, config_commit, tmux_pane, prompt_count, created_at, updated_at, source, source_id, source_metadata FROM missions WHERE id = ?",
+		"SELECT id, short_id, prompt, status, git_repo, last_heartbeat, last_user_prompt_at, session_name, session_name_updated_at, cron_id, cron_name, config_commit, tmux_pane, prompt_count, created_at, updated_at, source, source_id, source_metadata, tags, pr_url, max_prompts, budget_usd, owner, shared_with, display_name, ai_summary, last_summary_prompt_count, project, last_error, agent_dir_bytes, env, git_repos, git_ref, git_ref_commit FROM missions WHERE id = ?",
 		id,
 	)
 
@@ -142,7 +435,7 @@ func (db *DB) GetMission(id string) (*Mission, error) {
 // tmux pane ID, or nil if no active mission is running in that pane.
 func (db *DB) GetMissionByTmuxPane(paneID string) (*Mission, error) {
 	row := db.conn.QueryRow(
-		"SELECT id, short_id, prompt, status, git_repo, last_heartbeat, last_user_prompt_at, session_name, session_name_updated_at, cron_id, cron_name, config_commit, tmux_pane, prompt_count, created_at, updated_at, source, source_id, source_metadata FROM missions WHERE tmux_pane = ? AND status = 'active' LIMIT 1",
+		"SELECT id, short_id, prompt, status, git_repo, last_heartbeat, last_user_prompt_at, session_name, session_name_updated_at, cron_id, cron_name, config_commit, tmux_pane, prompt_count, created_at, updated_at, source, source_id, source_metadata, tags, pr_url, max_prompts, budget_usd, owner, shared_with, display_name, ai_summary, last_summary_prompt_count, project, last_error, agent_dir_bytes, env, git_repos, git_ref, git_ref_commit FROM missions WHERE tmux_pane = ? AND status = 'active' LIMIT 1",
 		paneID,
 	)
 
@@ -159,7 +452,7 @@ func (db *DB) GetMissionByTmuxPane(paneID string) (*Mission, error) {
 // ArchiveMission sets the mission status to 'archived'.
 func (db *DB) ArchiveMission(id string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	result, err := db.conn.Exec(
+	result, err := db.exec(
 		"UPDATE missions SET status = 'archived', updated_at = ? WHERE id = ?",
 		now, id,
 	)
@@ -180,7 +473,7 @@ func (db *DB) ArchiveMission(id string) error {
 // UnarchiveMission sets the mission status back to 'active'.
 func (db *DB) UnarchiveMission(id string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	result, err := db.conn.Exec(
+	result, err := db.exec(
 		"UPDATE missions SET status = 'active', updated_at = ? WHERE id = ?",
 		now, id,
 	)
@@ -200,7 +493,7 @@ func (db *DB) UnarchiveMission(id string) error {
 
 // DeleteMission permanently removes a mission from the database.
 func (db *DB) DeleteMission(id string) error {
-	result, err := db.conn.Exec("DELETE FROM missions WHERE id = ?", id)
+	result, err := db.exec("DELETE FROM missions WHERE id = ?", id)
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to delete mission '%s'", id)
 	}
@@ -218,7 +511,7 @@ func (db *DB) DeleteMission(id string) error {
 // UpdateMissionPrompt sets the cached first-user-prompt for a mission.
 func (db *DB) UpdateMissionPrompt(id string, prompt string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET prompt = ?, updated_at = ? WHERE id = ?",
 		prompt, now, id,
 	)
@@ -228,12 +521,104 @@ func (db *DB) UpdateMissionPrompt(id string, prompt string) error {
 	return nil
 }
 
+// SetMissionAISummary stores a mission's AI summary along with the prompt
+// count it covers. updated_at is left alone: a summary is derived from the
+// mission's activity, not activity itself.
+func (db *DB) SetMissionAISummary(id string, summary string, promptCount int) error {
+	_, err := db.exec(
+		"UPDATE missions SET ai_summary = ?, last_summary_prompt_count = ? WHERE id = ?",
+		summary, promptCount, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set ai_summary for mission '%s'", id)
+	}
+	return nil
+}
+
+// SetMissionDisplayName sets the name shown for a mission in place of its
+// session title. An empty name clears it.
+func (db *DB) SetMissionDisplayName(id string, displayName string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET display_name = ?, updated_at = ? WHERE id = ?",
+		displayName, now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set display_name for mission '%s'", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return stacktrace.NewError("mission '%s' not found", id)
+	}
+	return nil
+}
+
+// MarkMissionCrashed sets an active mission's status to 'crashed'. Returns
+// false when the mission was not active (already crashed, archived, or gone).
+func (db *DB) MarkMissionCrashed(id string) (bool, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET status = 'crashed', updated_at = ? WHERE id = ? AND status = 'active'",
+		now, id,
+	)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to mark mission '%s' crashed", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	return rowsAffected > 0, nil
+}
+
+// MarkMissionSuspended sets an active mission's status to 'suspended', which
+// records that the server stopped its wrapper for being idle. Returns false
+// when the mission was not active.
+func (db *DB) MarkMissionSuspended(id string) (bool, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET status = 'suspended', updated_at = ? WHERE id = ? AND status = 'active'",
+		now, id,
+	)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to mark mission '%s' suspended", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	return rowsAffected > 0, nil
+}
+
+// ClearMissionSuspended puts a suspended mission back to 'active'. Called
+// when its wrapper is started again or it is stopped deliberately. A no-op
+// for missions that are not suspended.
+func (db *DB) ClearMissionSuspended(id string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err := db.exec(
+		"UPDATE missions SET status = 'active', updated_at = ? WHERE id = ? AND status = 'suspended'",
+		now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to clear suspended status for mission '%s'", id)
+	}
+	return nil
+}
+
 // UpdateHeartbeat sets the last_heartbeat timestamp to the current time for
 // the given mission. Called periodically by the wrapper to signal liveness.
+// A heartbeat from a crashed or suspended mission means its wrapper is
+// running again, so the mission goes back to 'active'.
 func (db *DB) UpdateHeartbeat(id string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	_, err := db.conn.Exec(
-		"UPDATE missions SET last_heartbeat = ? WHERE id = ?",
+	_, err := db.exec(
+		"UPDATE missions SET last_heartbeat = ?, status = CASE WHEN status IN ('crashed', 'suspended') THEN 'active' ELSE status END WHERE id = ?",
 		now, id,
 	)
 	if err != nil {
@@ -247,7 +632,7 @@ func (db *DB) UpdateHeartbeat(id string) error {
 // update, so it does not touch updated_at.
 func (db *DB) UpdateMissionSessionName(id string, sessionName string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET session_name = ?, session_name_updated_at = ? WHERE id = ?",
 		sessionName, now, id,
 	)
@@ -257,10 +642,24 @@ func (db *DB) UpdateMissionSessionName(id string, sessionName string) error {
 	return nil
 }
 
+// UpdateMissionGitRepo changes the repo a mission works on. Used when the
+// mission's workspace is re-pointed at another repo.
+func (db *DB) UpdateMissionGitRepo(id string, gitRepo string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err := db.exec(
+		"UPDATE missions SET git_repo = ?, updated_at = ? WHERE id = ?",
+		gitRepo, now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to update git_repo for mission '%s'", id)
+	}
+	return nil
+}
+
 // UpdateMissionConfigCommit updates the config_commit column for a mission.
 func (db *DB) UpdateMissionConfigCommit(id string, configCommit string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET config_commit = ?, updated_at = ? WHERE id = ?",
 		configCommit, now, id,
 	)
@@ -272,7 +671,7 @@ func (db *DB) UpdateMissionConfigCommit(id string, configCommit string) error {
 
 // SetTmuxPane records the tmux pane ID for a mission's wrapper process.
 func (db *DB) SetTmuxPane(id string, paneID string) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET tmux_pane = ? WHERE id = ?",
 		paneID, id,
 	)
@@ -284,7 +683,7 @@ func (db *DB) SetTmuxPane(id string, paneID string) error {
 
 // ClearTmuxPane removes the tmux pane association for a mission.
 func (db *DB) ClearTmuxPane(id string) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET tmux_pane = NULL WHERE id = ?",
 		id,
 	)
@@ -298,7 +697,7 @@ func (db *DB) ClearTmuxPane(id string) error {
 // Used on server startup to clear stale pane IDs before reconciling with
 // the actual tmux state.
 func (db *DB) ClearAllTmuxPanes() error {
-	_, err := db.conn.Exec("UPDATE missions SET tmux_pane = NULL")
+	_, err := db.exec("UPDATE missions SET tmux_pane = NULL")
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to clear all tmux panes")
 	}
@@ -308,7 +707,7 @@ func (db *DB) ClearAllTmuxPanes() error {
 // IncrementPromptCount atomically increments the prompt_count for a mission.
 // Called by the wrapper on each UserPromptSubmit hook event.
 func (db *DB) IncrementPromptCount(id string) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET prompt_count = prompt_count + 1 WHERE id = ?",
 		id,
 	)
@@ -322,7 +721,7 @@ func (db *DB) IncrementPromptCount(id string) error {
 // time for the given mission. Called when a user submits a prompt.
 func (db *DB) UpdateLastUserPromptAt(id string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET last_user_prompt_at = ? WHERE id = ?",
 		now, id,
 	)
@@ -339,7 +738,7 @@ func (db *DB) SetLastUserPromptAt(id string, timestamp string) error {
 	if timestamp == "" {
 		return nil
 	}
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE missions SET last_user_prompt_at = ? WHERE id = ?",
 		timestamp, id,
 	)
@@ -349,6 +748,169 @@ func (db *DB) SetLastUserPromptAt(id string, timestamp string) error {
 	return nil
 }
 
+// SetMissionTags replaces the full tag set for a mission. Tags are
+// deduplicated and sorted before being stored; an empty slice clears them.
+func (db *DB) SetMissionTags(id string, tags []string) error {
+	for _, tag := range tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET tags = ?, updated_at = ? WHERE id = ?",
+		joinTags(tags), now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set tags for mission '%s'", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return stacktrace.NewError("mission '%s' not found", id)
+	}
+	return nil
+}
+
+// SetMissionProject moves a mission into the named project, or out of any
+// project when project is empty. The caller is responsible for checking the
+// project exists.
+func (db *DB) SetMissionProject(id string, project string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET project = ?, updated_at = ? WHERE id = ?",
+		project, now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set project for mission '%s'", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return stacktrace.NewError("mission '%s' not found", id)
+	}
+	return nil
+}
+
+// SetMissionLastError records how Claude last exited unexpectedly, or clears
+// the record when lastError is empty.
+func (db *DB) SetMissionLastError(id string, lastError string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET last_error = ?, updated_at = ? WHERE id = ?",
+		lastError, now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set last_error for mission '%s'", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return stacktrace.NewError("mission '%s' not found", id)
+	}
+	return nil
+}
+
+// SetMissionAgentDirBytes records the measured size of a mission's agent
+// directory. updated_at is left alone: measuring is not mission activity.
+func (db *DB) SetMissionAgentDirBytes(id string, sizeBytes int64) error {
+	_, err := db.exec(
+		"UPDATE missions SET agent_dir_bytes = ? WHERE id = ?",
+		sizeBytes, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set agent_dir_bytes for mission '%s'", id)
+	}
+	return nil
+}
+
+// SetMissionSharedWith replaces the set of users a mission is shared with in
+// multi-user mode. Names are deduplicated and sorted; an empty slice unshares
+// the mission.
+func (db *DB) SetMissionSharedWith(id string, users []string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET shared_with = ?, updated_at = ? WHERE id = ?",
+		joinTags(users), now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set shared users for mission '%s'", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return stacktrace.NewError("mission '%s' not found", id)
+	}
+	return nil
+}
+
+// SetMissionPRURL records the URL of the pull request opened for a mission.
+func (db *DB) SetMissionPRURL(id string, prURL string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := db.exec(
+		"UPDATE missions SET pr_url = ?, updated_at = ? WHERE id = ?",
+		prURL, now, id,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to set pr_url for mission '%s'", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return stacktrace.NewError("mission '%s' not found", id)
+	}
+	return nil
+}
+
+// ValidateTag returns an error if the tag is empty or contains characters
+// outside [A-Za-z0-9._/:-]. Commas are reserved as the storage separator.
+func ValidateTag(tag string) error {
+	if !tagRegex.MatchString(tag) {
+		return stacktrace.NewError("invalid tag '%s': tags must be non-empty and contain only letters, digits, '.', '_', '/', ':', or '-'", tag)
+	}
+	return nil
+}
+
+// joinTags deduplicates and sorts tags, returning the comma-separated storage form.
+func joinTags(tags []string) string {
+	seen := make(map[string]bool, len(tags))
+	var unique []string
+	for _, tag := range tags {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		unique = append(unique, tag)
+	}
+	sort.Strings(unique)
+	return strings.Join(unique, ",")
+}
+
+// splitTags parses the comma-separated storage form into a slice. Returns nil
+// for the empty string.
+func splitTags(stored string) []string {
+	if stored == "" {
+		return nil
+	}
+	return strings.Split(stored, ",")
+}
+
 // ShortID returns the first 8 characters of a full UUID.
 // Returns the full string if it is shorter than 8 characters.
 func ShortID(fullID string) string {
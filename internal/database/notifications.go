@@ -43,7 +43,7 @@ func (db *DB) CreateNotification(n *Notification) error {
 	if n.MissionID != nil && *n.MissionID != "" {
 		missionID = sql.NullString{String: *n.MissionID, Valid: true}
 	}
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"INSERT INTO notifications (id, kind, source_repo, mission_id, title, body_markdown, created_at, read_at) VALUES (?, ?, ?, ?, ?, ?, ?, NULL)",
 		n.ID, n.Kind, sourceRepo, missionID, n.Title, n.BodyMarkdown, n.CreatedAt.UTC().Format(time.RFC3339),
 	)
@@ -80,7 +80,7 @@ func (db *DB) ListNotifications(params ListNotificationsParams) ([]*Notification
 // MarkNotificationRead sets read_at on the given notification. Idempotent:
 // if the notification is already read, this is a no-op (read_at is preserved).
 func (db *DB) MarkNotificationRead(id string) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE notifications SET read_at = ? WHERE id = ? AND read_at IS NULL",
 		time.Now().UTC().Format(time.RFC3339), id,
 	)
@@ -93,7 +93,7 @@ func (db *DB) MarkNotificationRead(id string) error {
 // MarkNotificationUnread clears read_at on the given notification. Idempotent:
 // if the notification is already unread, this is a no-op.
 func (db *DB) MarkNotificationUnread(id string) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE notifications SET read_at = NULL WHERE id = ?",
 		id,
 	)
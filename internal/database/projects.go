@@ -0,0 +1,140 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Project groups the missions, cron jobs, and notes around one piece of
+// work, usually in a single repo. Missions name their project in the
+// missions.project column; crons name it in their config.
+type Project struct {
+	Name      string
+	GitRepo   string
+	Notes     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const projectColumns = "name, git_repo, notes, created_at, updated_at"
+
+// CreateProject inserts a new project. Fails if a project with the same name
+// already exists. The caller is responsible for validating the name (see
+// config.ValidateProjectName).
+func (db *DB) CreateProject(p *Project) error {
+	existing, err := db.GetProject(p.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return stacktrace.NewError("project '%s' already exists", p.Name)
+	}
+
+	now := time.Now().UTC()
+	if _, err := db.exec(
+		"INSERT INTO projects (name, git_repo, notes, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
+		p.Name, p.GitRepo, p.Notes, now.Format(time.RFC3339), now.Format(time.RFC3339),
+	); err != nil {
+		return stacktrace.Propagate(err, "failed to insert project '%s'", p.Name)
+	}
+	p.CreatedAt = now.Truncate(time.Second)
+	p.UpdatedAt = p.CreatedAt
+	return nil
+}
+
+// GetProject returns the named project, or (nil, nil) if it doesn't exist.
+func (db *DB) GetProject(name string) (*Project, error) {
+	row := db.conn.QueryRow("SELECT "+projectColumns+" FROM projects WHERE name = ?", name)
+	p, err := scanProject(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to get project '%s'", name)
+	}
+	return p, nil
+}
+
+// ListProjects returns all projects ordered by name.
+func (db *DB) ListProjects() ([]*Project, error) {
+	rows, err := db.conn.Query("SELECT " + projectColumns + " FROM projects ORDER BY name")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to query projects")
+	}
+	defer rows.Close()
+
+	var projects []*Project
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan project row")
+		}
+		projects = append(projects, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to iterate project rows")
+	}
+	return projects, nil
+}
+
+// UpdateProject replaces a project's repo and notes.
+func (db *DB) UpdateProject(name string, gitRepo string, notes string) error {
+	result, err := db.exec(
+		"UPDATE projects SET git_repo = ?, notes = ?, updated_at = ? WHERE name = ?",
+		gitRepo, notes, time.Now().UTC().Format(time.RFC3339), name,
+	)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to update project '%s'", name)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return stacktrace.NewError("project '%s' not found", name)
+	}
+	return nil
+}
+
+// CountMissionsByProject returns the number of non-archived missions in each
+// project that has any.
+func (db *DB) CountMissionsByProject() (map[string]int, error) {
+	rows, err := db.conn.Query("SELECT project, COUNT(*) FROM missions WHERE project != '' AND status != 'archived' GROUP BY project")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to count missions by project")
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var project string
+		var count int
+		if err := rows.Scan(&project, &count); err != nil {
+			return nil, stacktrace.Propagate(err, "failed to scan project mission count")
+		}
+		counts[project] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to iterate project mission counts")
+	}
+	return counts, nil
+}
+
+func scanProject(row rowScanner) (*Project, error) {
+	var p Project
+	var createdAt, updatedAt string
+	if err := row.Scan(&p.Name, &p.GitRepo, &p.Notes, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if p.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse projects created_at timestamp")
+	}
+	if p.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse projects updated_at timestamp")
+	}
+	return &p, nil
+}
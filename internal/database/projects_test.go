@@ -0,0 +1,104 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestProjects_CreateGetListUpdate(t *testing.T) {
+	db := openTestDB(t)
+
+	if err := db.CreateProject(&Project{Name: "billing", GitRepo: "github.com/acme/billing", Notes: "Q3 invoicing rework"}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if err := db.CreateProject(&Project{Name: "analytics"}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if err := db.CreateProject(&Project{Name: "billing"}); err == nil {
+		t.Error("expected creating a duplicate project to fail")
+	}
+
+	got, err := db.GetProject("billing")
+	if err != nil {
+		t.Fatalf("GetProject failed: %v", err)
+	}
+	if got == nil || got.GitRepo != "github.com/acme/billing" || got.Notes != "Q3 invoicing rework" {
+		t.Fatalf("unexpected project: %+v", got)
+	}
+	missing, err := db.GetProject("nope")
+	if err != nil || missing != nil {
+		t.Fatalf("expected (nil, nil) for a missing project, got (%+v, %v)", missing, err)
+	}
+
+	projects, err := db.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	if len(projects) != 2 || projects[0].Name != "analytics" || projects[1].Name != "billing" {
+		t.Fatalf("expected projects ordered by name, got %+v", projects)
+	}
+
+	if err := db.UpdateProject("billing", "", "shipped"); err != nil {
+		t.Fatalf("UpdateProject failed: %v", err)
+	}
+	got, _ = db.GetProject("billing")
+	if got.GitRepo != "" || got.Notes != "shipped" {
+		t.Errorf("expected update to apply, got %+v", got)
+	}
+	if err := db.UpdateProject("nope", "", ""); err == nil {
+		t.Error("expected updating a missing project to fail")
+	}
+}
+
+func TestProjects_MissionMembership(t *testing.T) {
+	db := openTestDB(t)
+	if err := db.CreateProject(&Project{Name: "billing"}); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	inProject, err := db.CreateMission("github.com/acme/billing", &CreateMissionParams{Project: "billing"})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	moved, err := db.CreateMission("github.com/acme/billing", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	if _, err := db.CreateMission("github.com/acme/other", nil); err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	if err := db.SetMissionProject(moved.ID, "billing"); err != nil {
+		t.Fatalf("SetMissionProject failed: %v", err)
+	}
+
+	missions, err := db.ListMissions(ListMissionsParams{Project: "billing"})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	if len(missions) != 2 {
+		t.Fatalf("expected 2 missions in project, got %d", len(missions))
+	}
+	for _, m := range missions {
+		if m.Project != "billing" {
+			t.Errorf("mission %s: expected project 'billing', got %q", m.ShortID, m.Project)
+		}
+	}
+
+	counts, err := db.CountMissionsByProject()
+	if err != nil {
+		t.Fatalf("CountMissionsByProject failed: %v", err)
+	}
+	if counts["billing"] != 2 || len(counts) != 1 {
+		t.Errorf("unexpected counts: %v", counts)
+	}
+
+	if err := db.SetMissionProject(inProject.ID, ""); err != nil {
+		t.Fatalf("SetMissionProject failed: %v", err)
+	}
+	got, err := db.GetMission(inProject.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.Project != "" {
+		t.Errorf("expected mission to leave its project, got %q", got.Project)
+	}
+}
@@ -5,10 +5,54 @@ import (
 	"time"
 )
 
+// missionSortTerm is one ORDER BY expression of a mission listing.
+type missionSortTerm struct {
+	expr string
+	desc bool
+}
+
+// missionSortTerms returns the ORDER BY expressions for a sort. Each ends
+// with rowid so the order is total, which keyset paging relies on; ties keep
+// creation order.
+func missionSortTerms(order MissionSort) []missionSortTerm {
+	switch order {
+	case MissionSortCreated:
+		return []missionSortTerm{{"created_at", true}, {"rowid", false}}
+	case MissionSortUpdated:
+		return []missionSortTerm{{"updated_at", true}, {"rowid", false}}
+	default:
+		return []missionSortTerm{{"COALESCE(last_user_prompt_at, created_at)", true}, {"created_at", true}, {"rowid", false}}
+	}
+}
+
+// buildKeysetCondition returns the condition selecting rows ordered after the
+// row whose sort expressions have the values afterKeys, with its arguments.
+// For terms (a DESC, b ASC) it yields "(a < ? OR (a = ? AND b > ?))".
+func buildKeysetCondition(terms []missionSortTerm, afterKeys []interface{}) (string, []interface{}) {
+	var alternatives []string
+	var args []interface{}
+	for i, term := range terms {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, terms[j].expr+" = ?")
+			args = append(args, afterKeys[j])
+		}
+		op := " > ?"
+		if term.desc {
+			op = " < ?"
+		}
+		parts = append(parts, term.expr+op)
+		args = append(args, afterKeys[i])
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
 // buildListMissionsQuery constructs the SQL query and arguments for ListMissions.
+// afterKeys holds the sort key values of the params.After mission, if any.
 // Returns the query string and a slice of arguments to be used with db.Query.
-func buildListMissionsQuery(params ListMissionsParams) (string, []interface{}) {
-	query := "SELECT id, short_id, prompt, status, git_repo, last_heartbeat, last_user_prompt_at, session_name, session_name_updated_at, cron_id, cron_name, config_commit, tmux_pane, prompt_count, created_at, updated_at, source, source_id, source_metadata FROM missions"
+func buildListMissionsQuery(params ListMissionsParams, afterKeys []interface{}) (string, []interface{}) {
+	query := "SELECT id, short_id, prompt, status, git_repo, last_heartbeat, last_user_prompt_at, session_name, session_name_updated_at, cron_id, cron_name, config_commit, tmux_pane, prompt_count, created_at, updated_at, source, source_id, source_metadata, tags, pr_url, max_prompts, budget_usd, owner, shared_with, display_name, ai_summary, last_summary_prompt_count, project, last_error, agent_dir_bytes, env, git_repos, git_ref, git_ref_commit FROM missions"
 
 	var conditions []string
 	var args []interface{}
@@ -32,11 +76,45 @@ func buildListMissionsQuery(params ListMissionsParams) (string, []interface{}) {
 		conditions = append(conditions, "created_at <= ?")
 		args = append(args, params.Until.UTC().Format(time.RFC3339))
 	}
+	if params.Project != "" {
+		conditions = append(conditions, "project = ?")
+		args = append(args, params.Project)
+	}
+	for _, tag := range params.Tags {
+		// Wrapping both sides in commas makes instr match whole tags only
+		conditions = append(conditions, "instr(',' || tags || ',', ?) > 0")
+		args = append(args, ","+tag+",")
+	}
+
+	terms := missionSortTerms(params.Sort)
+	if afterKeys != nil {
+		condition, keysetArgs := buildKeysetCondition(terms, afterKeys)
+		conditions = append(conditions, condition)
+		args = append(args, keysetArgs...)
+	}
 
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
-	query += " ORDER BY COALESCE(last_user_prompt_at, created_at) DESC, created_at DESC"
+
+	orderBy := make([]string, len(terms))
+	for i, term := range terms {
+		orderBy[i] = term.expr
+		if term.desc {
+			orderBy[i] += " DESC"
+		}
+	}
+	query += " ORDER BY " + strings.Join(orderBy, ", ")
+
+	// SQLite only accepts OFFSET after a LIMIT; -1 means no limit
+	if params.Limit > 0 || params.Offset > 0 {
+		limit := -1
+		if params.Limit > 0 {
+			limit = params.Limit
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, params.Offset)
+	}
 
 	return query, args
 }
@@ -69,3 +147,113 @@ func buildListNotificationsQuery(params ListNotificationsParams) (string, []inte
 
 	return query, args
 }
+
+// buildListCronRunsQuery constructs the SQL query and arguments for
+// ListCronRuns.
+func buildListCronRunsQuery(params ListCronRunsParams) (string, []interface{}) {
+	query := "SELECT " + cronRunColumns + " FROM cron_runs"
+
+	var conditions []string
+	var args []interface{}
+
+	if params.CronID != "" {
+		conditions = append(conditions, "cron_id = ?")
+		args = append(args, params.CronID)
+	}
+	if params.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, params.Status)
+	}
+	if params.ExcludeStatus != "" {
+		conditions = append(conditions, "status != ?")
+		args = append(args, params.ExcludeStatus)
+	}
+	if !params.From.IsZero() {
+		conditions = append(conditions, "started_at >= ?")
+		args = append(args, params.From.UTC().Format(time.RFC3339))
+	}
+	if !params.To.IsZero() {
+		conditions = append(conditions, "started_at < ?")
+		args = append(args, params.To.UTC().Format(time.RFC3339))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY started_at DESC, rowid DESC"
+	if params.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, params.Limit)
+	}
+
+	return query, args
+}
+
+// buildListMissionEventsQuery constructs the SQL query and arguments for
+// ListMissionEvents. Rows come back newest first; ListMissionEvents reverses
+// them.
+func buildListMissionEventsQuery(params ListMissionEventsParams) (string, []interface{}) {
+	query := "SELECT " + missionEventColumns + " FROM mission_events"
+
+	var conditions []string
+	var args []interface{}
+
+	if params.MissionID != "" {
+		conditions = append(conditions, "mission_id = ?")
+		args = append(args, params.MissionID)
+	}
+	if len(params.Types) > 0 {
+		conditions = append(conditions, "type IN (?"+strings.Repeat(", ?", len(params.Types)-1)+")")
+		for _, eventType := range params.Types {
+			args = append(args, eventType)
+		}
+	}
+	if !params.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, params.From.UTC().Format(time.RFC3339))
+	}
+	if !params.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, params.To.UTC().Format(time.RFC3339))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if params.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, params.Limit)
+	}
+
+	return query, args
+}
+
+// buildListMissionPromptsQuery constructs the SQL query and arguments for
+// ListMissionPrompts.
+func buildListMissionPromptsQuery(params ListMissionPromptsParams) (string, []interface{}) {
+	query := "SELECT " + missionPromptColumns + " FROM mission_prompts"
+
+	var conditions []string
+	var args []interface{}
+
+	if params.MissionID != "" {
+		conditions = append(conditions, "mission_id = ?")
+		args = append(args, params.MissionID)
+	}
+	if !params.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, params.From.UTC().Format(time.RFC3339))
+	}
+	if !params.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, params.To.UTC().Format(time.RFC3339))
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id ASC"
+
+	return query, args
+}
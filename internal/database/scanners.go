@@ -13,8 +13,8 @@ func scanMissions(rows *sql.Rows) ([]*Mission, error) {
 	for rows.Next() {
 		var m Mission
 		var lastHeartbeat, lastUserPromptAt, sessionNameUpdatedAt, cronID, cronName, configCommit, tmuxPane, source, sourceID, sourceMetadata sql.NullString
-		var createdAt, updatedAt string
-		if err := rows.Scan(&m.ID, &m.ShortID, &m.Prompt, &m.Status, &m.GitRepo, &lastHeartbeat, &lastUserPromptAt, &m.SessionName, &sessionNameUpdatedAt, &cronID, &cronName, &configCommit, &tmuxPane, &m.PromptCount, &createdAt, &updatedAt, &source, &sourceID, &sourceMetadata); err != nil {
+		var createdAt, updatedAt, tags, sharedWith, env, gitRepos string
+		if err := rows.Scan(&m.ID, &m.ShortID, &m.Prompt, &m.Status, &m.GitRepo, &lastHeartbeat, &lastUserPromptAt, &m.SessionName, &sessionNameUpdatedAt, &cronID, &cronName, &configCommit, &tmuxPane, &m.PromptCount, &createdAt, &updatedAt, &source, &sourceID, &sourceMetadata, &tags, &m.PRURL, &m.MaxPrompts, &m.BudgetUSD, &m.Owner, &sharedWith, &m.DisplayName, &m.AISummary, &m.LastSummaryPromptCount, &m.Project, &m.LastError, &m.AgentDirBytes, &env, &gitRepos, &m.GitRef, &m.GitRefCommit); err != nil {
 			return nil, stacktrace.Propagate(err, "failed to scan mission row")
 		}
 		if lastHeartbeat.Valid {
@@ -59,7 +59,15 @@ func scanMissions(rows *sql.Rows) ([]*Mission, error) {
 		if sourceMetadata.Valid {
 			m.SourceMetadata = &sourceMetadata.String
 		}
+		m.Tags = splitTags(tags)
+		m.SharedWith = splitTags(sharedWith)
 		var err error
+		if m.Env, err = decodeMissionEnv(env); err != nil {
+			return nil, err
+		}
+		if m.GitRepos, err = decodeMissionGitRepos(gitRepos); err != nil {
+			return nil, err
+		}
 		m.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
 		if err != nil {
 			return nil, stacktrace.Propagate(err, "failed to parse created_at timestamp")
@@ -151,8 +159,8 @@ func populateNotificationFields(n *Notification, sourceRepo, missionID sql.NullS
 func scanMission(row *sql.Row) (*Mission, error) {
 	var m Mission
 	var lastHeartbeat, lastUserPromptAt, sessionNameUpdatedAt, cronID, cronName, configCommit, tmuxPane, source, sourceID, sourceMetadata sql.NullString
-	var createdAt, updatedAt string
-	if err := row.Scan(&m.ID, &m.ShortID, &m.Prompt, &m.Status, &m.GitRepo, &lastHeartbeat, &lastUserPromptAt, &m.SessionName, &sessionNameUpdatedAt, &cronID, &cronName, &configCommit, &tmuxPane, &m.PromptCount, &createdAt, &updatedAt, &source, &sourceID, &sourceMetadata); err != nil {
+	var createdAt, updatedAt, tags, sharedWith, env, gitRepos string
+	if err := row.Scan(&m.ID, &m.ShortID, &m.Prompt, &m.Status, &m.GitRepo, &lastHeartbeat, &lastUserPromptAt, &m.SessionName, &sessionNameUpdatedAt, &cronID, &cronName, &configCommit, &tmuxPane, &m.PromptCount, &createdAt, &updatedAt, &source, &sourceID, &sourceMetadata, &tags, &m.PRURL, &m.MaxPrompts, &m.BudgetUSD, &m.Owner, &sharedWith, &m.DisplayName, &m.AISummary, &m.LastSummaryPromptCount, &m.Project, &m.LastError, &m.AgentDirBytes, &env, &gitRepos, &m.GitRef, &m.GitRefCommit); err != nil {
 		return nil, err
 	}
 	if lastHeartbeat.Valid {
@@ -197,7 +205,15 @@ func scanMission(row *sql.Row) (*Mission, error) {
 	if sourceMetadata.Valid {
 		m.SourceMetadata = &sourceMetadata.String
 	}
+	m.Tags = splitTags(tags)
+	m.SharedWith = splitTags(sharedWith)
 	var err error
+	if m.Env, err = decodeMissionEnv(env); err != nil {
+		return nil, err
+	}
+	if m.GitRepos, err = decodeMissionGitRepos(gitRepos); err != nil {
+		return nil, err
+	}
 	m.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "failed to parse created_at timestamp")
@@ -18,7 +18,7 @@ type SearchResult struct {
 // search index and updates the session's last_indexed_offset. Both operations
 // happen in a single transaction — either both succeed or neither does.
 func (db *DB) InsertSearchContentAndUpdateOffset(missionID, sessionID, content string, newOffset int64) error {
-	tx, err := db.conn.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to begin transaction")
 	}
@@ -121,7 +121,7 @@ func (db *DB) executeSearch(ftsQuery string, limit int) ([]SearchResult, error)
 // without inserting into the search index. Used when a JSONL chunk contains
 // no indexable content but the offset still needs to advance.
 func (db *DB) UpdateLastIndexedOffset(sessionID string, newOffset int64) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE sessions SET last_indexed_offset = ? WHERE id = ?",
 		newOffset, sessionID,
 	)
@@ -148,7 +148,7 @@ func StripSnippetMarkers(snippet string) string {
 
 // DeleteAllSearchContent removes all entries from the FTS5 index.
 func (db *DB) DeleteAllSearchContent() error {
-	_, err := db.conn.Exec("DELETE FROM mission_search_index")
+	_, err := db.exec("DELETE FROM mission_search_index")
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to clear search index")
 	}
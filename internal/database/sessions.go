@@ -29,7 +29,7 @@ type Session struct {
 func (db *DB) CreateSession(missionID string, sessionID string) (*Session, error) {
 	now := time.Now().UTC().Format(time.RFC3339)
 	shortID := ShortID(sessionID)
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"INSERT INTO sessions (id, short_id, mission_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
 		sessionID, shortID, missionID, now, now,
 	)
@@ -67,7 +67,7 @@ func (db *DB) GetSession(sessionID string) (*Session, error) {
 // An empty title clears the custom title.
 func (db *DB) UpdateSessionAgencCustomTitle(sessionID string, title string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE sessions SET agenc_custom_title = ?, updated_at = ? WHERE id = ?",
 		title, now, sessionID,
 	)
@@ -172,7 +172,7 @@ func scanSessions(rows *sql.Rows) ([]*Session, error) {
 // UpdateKnownFileSize updates the known_file_size for a session.
 // This is called by the file watcher when it stats a JSONL file.
 func (db *DB) UpdateKnownFileSize(sessionID string, size int64) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		"UPDATE sessions SET known_file_size = ? WHERE id = ?",
 		size, sessionID,
 	)
@@ -229,7 +229,7 @@ func (db *DB) SessionsNeedingAutoSummary() ([]*Session, error) {
 // updated_at. GetActiveSession orders by updated_at DESC, and scanner activity
 // must not displace a user-renamed session from being the active one.
 func (db *DB) UpdateCustomTitleAndOffset(sessionID, customTitle string, newOffset int64) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		`UPDATE sessions SET custom_title = ?, last_custom_title_scan_offset = ? WHERE id = ?`,
 		customTitle, newOffset, sessionID,
 	)
@@ -246,7 +246,7 @@ func (db *DB) UpdateCustomTitleAndOffset(sessionID, customTitle string, newOffse
 // updated_at. GetActiveSession orders by updated_at DESC, and scanner activity
 // must not displace a user-renamed session from being the active one.
 func (db *DB) UpdateCustomTitleScanOffset(sessionID string, newOffset int64) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		`UPDATE sessions SET last_custom_title_scan_offset = ? WHERE id = ?`,
 		newOffset, sessionID,
 	)
@@ -263,7 +263,7 @@ func (db *DB) UpdateCustomTitleScanOffset(sessionID string, newOffset int64) err
 // updated_at. GetActiveSession orders by updated_at DESC, and scanner activity
 // must not displace a user-renamed session from being the active one.
 func (db *DB) UpdateAutoSummaryAndOffset(sessionID, summary string, newOffset int64) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		`UPDATE sessions SET auto_summary = ?, last_auto_summary_scan_offset = ? WHERE id = ?`,
 		summary, newOffset, sessionID,
 	)
@@ -280,7 +280,7 @@ func (db *DB) UpdateAutoSummaryAndOffset(sessionID, summary string, newOffset in
 // updated_at. GetActiveSession orders by updated_at DESC, and scanner activity
 // must not displace a user-renamed session from being the active one.
 func (db *DB) UpdateAutoSummaryScanOffset(sessionID string, newOffset int64) error {
-	_, err := db.conn.Exec(
+	_, err := db.exec(
 		`UPDATE sessions SET last_auto_summary_scan_offset = ? WHERE id = ?`,
 		newOffset, sessionID,
 	)
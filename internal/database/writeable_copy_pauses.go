@@ -25,7 +25,7 @@ type WriteableCopyPause struct {
 // the pause nor the notification is written). The caller may pre-set
 // p.PausedAt and n.CreatedAt; both default to time.Now().UTC() when zero.
 func (db *DB) UpsertPauseAndNotification(p *WriteableCopyPause, n *Notification) (bool, error) {
-	tx, err := db.conn.Begin()
+	tx, err := db.begin()
 	if err != nil {
 		return false, stacktrace.Propagate(err, "failed to begin transaction for repo '%v'", p.RepoName)
 	}
@@ -130,7 +130,7 @@ func (db *DB) ListPauses() ([]*WriteableCopyPause, error) {
 // DeletePause removes the pause for a repo. Idempotent: deleting a non-existent
 // pause is not an error.
 func (db *DB) DeletePause(repoName string) error {
-	_, err := db.conn.Exec("DELETE FROM writeable_copy_pauses WHERE repo_name = ?", repoName)
+	_, err := db.exec("DELETE FROM writeable_copy_pauses WHERE repo_name = ?", repoName)
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to delete pause for repo '%v'", repoName)
 	}
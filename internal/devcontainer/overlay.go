@@ -11,6 +11,10 @@ const (
 	// ContainerWrapperSocketPath is the well-known path where the wrapper
 	// socket is mounted inside containers, following Docker's /var/run convention.
 	ContainerWrapperSocketPath = "/var/run/agenc/wrapper.sock"
+
+	// ContainerScratchDirpath is where the mission's scratch/ directory is
+	// mounted inside containers, outside the workspace so it stays out of git.
+	ContainerScratchDirpath = "/agenc/scratch"
 )
 
 // OverlayParams contains the parameters needed to generate a merged
@@ -34,6 +38,9 @@ type OverlayParams struct {
 	// ClaudeConfigDirpath is the mission's claude-config directory on the host
 	ClaudeConfigDirpath string
 
+	// HostScratchDirpath is the mission's scratch directory on the host
+	HostScratchDirpath string
+
 	// WrapperSocketPath is the path to wrapper.sock on the host
 	WrapperSocketPath string
 
@@ -62,40 +69,28 @@ func GenerateOverlay(params OverlayParams) error {
 	// Absolutize dockerComposeFile if present
 	absolutizeDockerComposePath(config, originalConfigDir)
 
-	// Determine container workspace path for session mount
-	containerWorkspacePath := getWorkspaceFolder(config)
-
-	// Compute session bind mount paths
-	sessionMount := ComputeSessionBindMount(params.HostAgentDirpath, containerWorkspacePath)
-
-	// Ensure host project directory exists for the bind mount
-	homeDir, err := os.UserHomeDir()
+	// Build AgenC mounts for the container's workspace path and home
+	// (from remoteUser/containerUser)
+	agencMounts, err := BuildAgencMounts(params, getWorkspaceFolder(config), getContainerHomePath(config))
 	if err != nil {
-		return fmt.Errorf("determining home directory: %w", err)
+		return err
 	}
-	hostProjectDir := filepath.Join(homeDir, ".claude", "projects", sessionMount.HostProjectDirName)
-	if err := os.MkdirAll(hostProjectDir, 0700); err != nil {
-		return fmt.Errorf("creating host project directory '%s': %w", hostProjectDir, err)
-	}
-
-	// Compute container home path from remoteUser/containerUser
-	containerHome := getContainerHomePath(config)
-
-	// Build AgenC mounts
-	agencMounts := buildAgencMounts(params, sessionMount, homeDir, containerHome)
 
 	// Concatenate mounts (repo's first, then AgenC's)
 	existingMounts := getMountsFromConfig(config)
 	allMounts := make([]interface{}, 0, len(existingMounts)+len(agencMounts))
 	allMounts = append(allMounts, existingMounts...)
-	allMounts = append(allMounts, agencMounts...)
+	for _, mount := range agencMounts {
+		allMounts = append(allMounts, mount)
+	}
 	config["mounts"] = allMounts
 
 	// Add/merge containerEnv (AgenC overrides on conflict)
 	mergeContainerEnv(config, map[string]string{
-		"AGENC_MISSION_UUID":      params.MissionID,
-		"AGENC_WRAPPER_SOCKET":    ContainerWrapperSocketPath,
-		"CLAUDE_CODE_OAUTH_TOKEN": params.OAuthToken,
+		"AGENC_MISSION_UUID":            params.MissionID,
+		"AGENC_MISSION_SCRATCH_DIRPATH": ContainerScratchDirpath,
+		"AGENC_WRAPPER_SOCKET":          ContainerWrapperSocketPath,
+		"CLAUDE_CODE_OAUTH_TOKEN":       params.OAuthToken,
 	})
 
 	// Write merged config
@@ -199,7 +194,30 @@ func getContainerHomePath(config map[string]interface{}) string {
 	return "/home/" + user
 }
 
-func buildAgencMounts(params OverlayParams, sessionMount SessionBindMount, homeDir string, containerHome string) []interface{} {
+// BuildAgencMounts returns the bind mounts, in Docker --mount syntax, that
+// give a container running Claude its claude-config, session transcripts,
+// wrapper socket, scratch directory, and shared ~/.claude data directories.
+// containerWorkspacePath is where the agent dir is mounted and containerHome
+// is the home directory of the container user. Creates the host project
+// directory the session mount needs. The wrapper socket and scratch mounts are
+// skipped when params.WrapperSocketPath or params.HostScratchDirpath is empty.
+func BuildAgencMounts(params OverlayParams, containerWorkspacePath string, containerHome string) ([]string, error) {
+	sessionMount := ComputeSessionBindMount(params.HostAgentDirpath, containerWorkspacePath)
+
+	// Ensure host project directory exists for the bind mount
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+	hostProjectDir := filepath.Join(homeDir, ".claude", "projects", sessionMount.HostProjectDirName)
+	if err := os.MkdirAll(hostProjectDir, 0700); err != nil {
+		return nil, fmt.Errorf("creating host project directory '%s': %w", hostProjectDir, err)
+	}
+
+	return buildAgencMounts(params, sessionMount, homeDir, containerHome), nil
+}
+
+func buildAgencMounts(params OverlayParams, sessionMount SessionBindMount, homeDir string, containerHome string) []string {
 	claudeProjectsDir := filepath.Join(homeDir, ".claude", "projects")
 	containerClaudeDir := containerHome + "/.claude"
 
@@ -208,7 +226,7 @@ func buildAgencMounts(params OverlayParams, sessionMount SessionBindMount, homeD
 		"todos", "tasks", "debug", "file-history", "shell-snapshots",
 	}
 
-	mounts := []interface{}{
+	mounts := []string{
 		// Claude config as ~/.claude (read-write base for .claude.json etc)
 		fmt.Sprintf("source=%s,target=%s,type=bind", params.ClaudeConfigDirpath, containerClaudeDir),
 		// CLAUDE.md read-only overlay
@@ -218,8 +236,12 @@ func buildAgencMounts(params OverlayParams, sessionMount SessionBindMount, homeD
 		// Session projects encoding translation
 		fmt.Sprintf("source=%s/%s,target=%s/projects/%s,type=bind",
 			claudeProjectsDir, sessionMount.HostProjectDirName, containerClaudeDir, sessionMount.ContainerProjectDirName),
-		// Wrapper socket
-		fmt.Sprintf("source=%s,target=%s,type=bind", params.WrapperSocketPath, ContainerWrapperSocketPath),
+	}
+	if params.WrapperSocketPath != "" {
+		mounts = append(mounts, fmt.Sprintf("source=%s,target=%s,type=bind", params.WrapperSocketPath, ContainerWrapperSocketPath))
+	}
+	if params.HostScratchDirpath != "" {
+		mounts = append(mounts, fmt.Sprintf("source=%s,target=%s,type=bind", params.HostScratchDirpath, ContainerScratchDirpath))
 	}
 
 	// Add shared Claude data directories
@@ -20,6 +20,7 @@ func newTestParams(t *testing.T, repoDevcontainerPath string) OverlayParams {
 		AgencDirpath:         "/home/user/.agenc",
 		HostAgentDirpath:     "/home/user/.agenc/missions/test-uuid-123/agent",
 		ClaudeConfigDirpath:  "/home/user/.agenc/missions/test-uuid-123/claude-config",
+		HostScratchDirpath:   "/home/user/.agenc/missions/test-uuid-123/scratch",
 		WrapperSocketPath:    "/home/user/.agenc/missions/test-uuid-123/wrapper.sock",
 		OAuthToken:           "test-token",
 	}
@@ -62,12 +63,14 @@ func TestGenerateOverlay_AddsAgencMounts(t *testing.T) {
 	mounts, ok := result["mounts"].([]interface{})
 	require.True(t, ok, "expected mounts array")
 	require.NotEmpty(t, mounts, "expected mounts to be added")
+	require.Contains(t, mounts, "source=/home/user/.agenc/missions/test-uuid-123/scratch,target="+ContainerScratchDirpath+",type=bind")
 
 	// Should have containerEnv
 	env, ok := result["containerEnv"].(map[string]interface{})
 	require.True(t, ok, "expected containerEnv")
 	require.Equal(t, "test-uuid-123", env["AGENC_MISSION_UUID"])
 	require.Equal(t, ContainerWrapperSocketPath, env["AGENC_WRAPPER_SOCKET"])
+	require.Equal(t, ContainerScratchDirpath, env["AGENC_MISSION_SCRATCH_DIRPATH"])
 	require.Equal(t, "test-token", env["CLAUDE_CODE_OAUTH_TOKEN"])
 }
 
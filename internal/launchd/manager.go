@@ -56,6 +56,21 @@ func (m *Manager) UnloadPlist(plistPath string) error {
 	return nil
 }
 
+// StartJob asks launchd to start a loaded job now, regardless of its schedule
+// or keep-alive conditions.
+func (m *Manager) StartJob(label string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "launchctl", "start", label)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to start job %s: %s", label, string(output))
+	}
+
+	return nil
+}
+
 // IsLoaded checks if a job with the given label is loaded in launchd.
 func (m *Manager) IsLoaded(label string) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
@@ -12,7 +12,8 @@ import (
 	"github.com/mieubrisse/stacktrace"
 )
 
-// Plist represents a launchd plist file for scheduling cron jobs.
+// Plist represents a launchd plist file for scheduling cron jobs or
+// supervising a long-running process.
 type Plist struct {
 	Label                 string
 	ProgramArguments      []string
@@ -20,6 +21,12 @@ type Plist struct {
 	EnvironmentVariables  map[string]string
 	StandardOutPath       string
 	StandardErrorPath     string
+
+	// RunAtLoad starts the job as soon as it is loaded.
+	RunAtLoad bool
+	// KeepAliveOnFailure restarts the job whenever it exits unsuccessfully;
+	// a clean exit (status 0) leaves it stopped.
+	KeepAliveOnFailure bool
 }
 
 // CalendarInterval represents a launchd calendar interval for scheduling.
@@ -57,6 +64,15 @@ type arrayValue struct {
 	Strings []stringValue `xml:"string"`
 }
 
+type boolValue struct {
+	XMLName xml.Name
+}
+
+// newBoolValue returns the <true/> or <false/> element for b.
+func newBoolValue(b bool) boolValue {
+	return boolValue{XMLName: xml.Name{Local: strconv.FormatBool(b)}}
+}
+
 type dictValue struct {
 	XMLName xml.Name `xml:"dict"`
 	Entries []interface{}
@@ -121,6 +137,19 @@ func (p *Plist) GeneratePlistXML() ([]byte, error) {
 		entries = append(entries, dictValue{Entries: calEntries})
 	}
 
+	// RunAtLoad
+	if p.RunAtLoad {
+		entries = append(entries, key{Value: "RunAtLoad"}, newBoolValue(true))
+	}
+
+	// KeepAlive: restart only on unsuccessful exit, so a deliberate stop
+	// (which exits 0) is respected
+	if p.KeepAliveOnFailure {
+		entries = append(entries, key{Value: "KeepAlive"}, dictValue{Entries: []interface{}{
+			key{Value: "SuccessfulExit"}, newBoolValue(false),
+		}})
+	}
+
 	// StandardOutPath
 	entries = append(entries, key{Value: "StandardOutPath"}, stringValue{Value: p.StandardOutPath})
 
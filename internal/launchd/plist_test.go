@@ -78,6 +78,35 @@ func TestGeneratePlistXML(t *testing.T) {
 	}
 }
 
+func TestGeneratePlistXML_KeepAlive(t *testing.T) {
+	plist := &Plist{
+		Label:              "agenc-server",
+		ProgramArguments:   []string{"/usr/local/bin/agenc", "server", "run"},
+		RunAtLoad:          true,
+		KeepAliveOnFailure: true,
+		StandardOutPath:    "/tmp/server.log",
+		StandardErrorPath:  "/tmp/server.log",
+	}
+	data, err := plist.GeneratePlistXML()
+	if err != nil {
+		t.Fatalf("GeneratePlistXML failed: %v", err)
+	}
+	xmlStr := string(data)
+
+	for _, want := range []string{
+		"<key>RunAtLoad</key>\n        <true></true>",
+		"<key>KeepAlive</key>",
+		"<key>SuccessfulExit</key>\n            <false></false>",
+	} {
+		if !strings.Contains(xmlStr, want) {
+			t.Errorf("expected plist to contain %q, got:\n%s", want, xmlStr)
+		}
+	}
+	if strings.Contains(xmlStr, "StartCalendarInterval") {
+		t.Error("expected no StartCalendarInterval for a keep-alive job")
+	}
+}
+
 func TestParseCronExpression(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,67 @@
+package launchd
+
+import "time"
+
+// maxScheduleSearchDays bounds the search in Next. Four years plus a day
+// always includes a Feb 29 for intervals pinned to it.
+const maxScheduleSearchDays = 4*366 + 1
+
+// Next returns the first time strictly after after, in after's location, at
+// which launchd fires the interval: every field that is set must match. The
+// boolean is false when no such time exists (e.g. day 31 in February).
+func (c *CalendarInterval) Next(after time.Time) (time.Time, bool) {
+	start := after.Truncate(time.Minute).Add(time.Minute)
+	loc := after.Location()
+
+	hours := calendarFieldValues(c.Hour, 0, 23)
+	minutes := calendarFieldValues(c.Minute, 0, 59)
+
+	for offset := 0; offset < maxScheduleSearchDays; offset++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+offset, 0, 0, 0, 0, loc)
+		if c.Month != nil && int(day.Month()) != *c.Month {
+			continue
+		}
+		if c.Day != nil && day.Day() != *c.Day {
+			continue
+		}
+		if c.Weekday != nil && int(day.Weekday()) != *c.Weekday {
+			continue
+		}
+		for _, hour := range hours {
+			for _, minute := range minutes {
+				candidate := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+				if !candidate.Before(start) {
+					return candidate, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// CalendarIntervalForTime returns the interval that fires at t's minute,
+// in local time. launchd has no year field, so the interval repeats yearly;
+// callers scheduling a one-shot job must unload it after it fires.
+func CalendarIntervalForTime(t time.Time) *CalendarInterval {
+	local := t.Local()
+	minute, hour, day, month := local.Minute(), local.Hour(), local.Day(), int(local.Month())
+	return &CalendarInterval{
+		Minute: &minute,
+		Hour:   &hour,
+		Day:    &day,
+		Month:  &month,
+	}
+}
+
+// calendarFieldValues returns the values a calendar field matches: just the
+// field's value when set, otherwise every value from min to max.
+func calendarFieldValues(field *int, min int, max int) []int {
+	if field != nil {
+		return []int{*field}
+	}
+	values := make([]int, 0, max-min+1)
+	for v := min; v <= max; v++ {
+		values = append(values, v)
+	}
+	return values
+}
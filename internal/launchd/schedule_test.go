@@ -0,0 +1,54 @@
+package launchd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarIntervalNext(t *testing.T) {
+	// Wednesday 2026-03-04 10:30
+	after := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cronExpr string
+		want     time.Time
+		wantOK   bool
+	}{
+		{"every minute", "* * * * *", time.Date(2026, 3, 4, 10, 31, 0, 0, time.UTC), true},
+		{"later today", "0 14 * * *", time.Date(2026, 3, 4, 14, 0, 0, 0, time.UTC), true},
+		{"already passed today", "0 9 * * *", time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC), true},
+		{"exactly now is not next", "30 10 * * *", time.Date(2026, 3, 5, 10, 30, 0, 0, time.UTC), true},
+		{"hourly", "15 * * * *", time.Date(2026, 3, 4, 11, 15, 0, 0, time.UTC), true},
+		{"weekday", "0 9 * * 1", time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC), true},
+		{"day of month", "0 0 1 * *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), true},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), true},
+		{"impossible date", "0 0 31 2 *", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval, err := ParseCronExpression(tt.cronExpr)
+			if err != nil {
+				t.Fatalf("ParseCronExpression(%q) failed: %v", tt.cronExpr, err)
+			}
+			got, ok := interval.Next(after)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCalendarIntervalForTime(t *testing.T) {
+	runAt := time.Date(2026, 11, 2, 9, 45, 30, 0, time.Local)
+	interval := CalendarIntervalForTime(runAt)
+	if interval.Weekday != nil {
+		t.Errorf("expected no weekday, got %d", *interval.Weekday)
+	}
+
+	got, ok := interval.Next(runAt.Add(-time.Hour))
+	want := time.Date(2026, 11, 2, 9, 45, 0, 0, time.Local)
+	if !ok || !got.Equal(want) {
+		t.Errorf("Next() = %v, %v; want %v", got, ok, want)
+	}
+}
@@ -0,0 +1,117 @@
+package mission
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// branchSlugMaxWords caps how many words of the prompt go into {slug}.
+	branchSlugMaxWords = 5
+	// branchSlugMaxLen caps the length of {slug} so branch names stay
+	// readable in `git branch` and PR titles.
+	branchSlugMaxLen = 40
+)
+
+// RenderBranchName expands an autoBranchTemplate for a mission. The {slug}
+// placeholder is derived from text (typically the mission's initial prompt);
+// when text yields no slug, separators left dangling by the empty
+// placeholder are trimmed so "agenc/{shortID}-{slug}" renders as
+// "agenc/<shortID>".
+func RenderBranchName(template string, missionID string, text string) string {
+	name := strings.NewReplacer(
+		"{shortID}", database.ShortID(missionID),
+		"{missionID}", missionID,
+		"{slug}", BranchSlug(text),
+	).Replace(template)
+
+	for strings.Contains(name, "//") {
+		name = strings.ReplaceAll(name, "//", "/")
+	}
+	for _, sep := range []string{"-/", "/-", "--"} {
+		for strings.Contains(name, sep) {
+			name = strings.ReplaceAll(name, sep, sep[:1])
+		}
+	}
+	return strings.Trim(name, "-/")
+}
+
+// BranchSlug converts free text into a lowercase, hyphen-separated slug of at
+// most branchSlugMaxWords words and branchSlugMaxLen characters. Only ASCII
+// letters and digits survive; everything else separates words.
+func BranchSlug(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	if len(words) > branchSlugMaxWords {
+		words = words[:branchSlugMaxWords]
+	}
+
+	slug := strings.Join(words, "-")
+	if len(slug) > branchSlugMaxLen {
+		slug = strings.TrimRight(slug[:branchSlugMaxLen], "-")
+	}
+	return slug
+}
+
+// ValidateBranchName returns an error if name is not a valid git branch name.
+func ValidateBranchName(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "check-ref-format", "--branch", name)
+	if err := cmd.Run(); err != nil {
+		return stacktrace.NewError("'%s' is not a valid git branch name", name)
+	}
+	return nil
+}
+
+// GetCurrentBranch returns the branch checked out in repoDirpath, or "" when
+// HEAD is detached.
+func GetCurrentBranch(repoDirpath string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "symbolic-ref", "--short", "-q", "HEAD")
+	cmd.Dir = repoDirpath
+	output, err := cmd.Output()
+	if err != nil {
+		// Exit status 1 with -q means HEAD is detached rather than an error
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", stacktrace.Propagate(err, "failed to get current branch for '%s'", repoDirpath)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// SwitchBranch checks out branchName in repoDirpath. When create is set the
+// branch is created from the current HEAD first; otherwise it must already
+// exist. Uncommitted changes are carried over, and git refuses the switch if
+// they would be overwritten.
+func SwitchBranch(repoDirpath string, branchName string, create bool) error {
+	if err := ValidateBranchName(branchName); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	args := []string{"switch"}
+	if create {
+		args = append(args, "-c")
+	}
+	args = append(args, branchName)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoDirpath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "git switch to '%s' failed: %s", branchName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
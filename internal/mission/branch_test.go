@@ -0,0 +1,116 @@
+package mission
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestBranchSlug(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Fix the login redirect", "fix-the-login-redirect"},
+		{"  Add OAuth2 support!!  ", "add-oauth2-support"},
+		{"one two three four five six seven", "one-two-three-four-five"},
+		{"Überarbeite die API", "berarbeite-die-api"},
+		{"supercalifragilisticexpialidocious antidisestablishmentarianism", "supercalifragilisticexpialidocious-antid"},
+		{"", ""},
+		{"!!!", ""},
+	}
+	for _, tt := range tests {
+		if got := BranchSlug(tt.text); got != tt.want {
+			t.Errorf("BranchSlug(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRenderBranchName(t *testing.T) {
+	missionID := "2b4c8f1a-1111-2222-3333-444455556666"
+	tests := []struct {
+		template string
+		text     string
+		want     string
+	}{
+		{config.DefaultAutoBranchTemplate, "Fix login redirect", "agenc/2b4c8f1a-fix-login-redirect"},
+		{config.DefaultAutoBranchTemplate, "", "agenc/2b4c8f1a"},
+		{"{slug}/{shortID}", "", "2b4c8f1a"},
+		{"feature/{slug}-{shortID}", "", "feature/2b4c8f1a"},
+		{"agenc/{missionID}", "ignored", "agenc/" + missionID},
+	}
+	for _, tt := range tests {
+		if got := RenderBranchName(tt.template, missionID, tt.text); got != tt.want {
+			t.Errorf("RenderBranchName(%q, %q) = %q, want %q", tt.template, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSwitchBranch(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+	startBranch := runGit(repoDirpath, "symbolic-ref", "--short", "HEAD")
+
+	if err := SwitchBranch(repoDirpath, "agenc/2b4c8f1a-fix", true); err != nil {
+		t.Fatalf("SwitchBranch create failed: %v", err)
+	}
+	if got, err := GetCurrentBranch(repoDirpath); err != nil || got != "agenc/2b4c8f1a-fix" {
+		t.Errorf("expected branch agenc/2b4c8f1a-fix, got %q (err %v)", got, err)
+	}
+
+	if err := SwitchBranch(repoDirpath, startBranch, false); err != nil {
+		t.Fatalf("SwitchBranch to existing branch failed: %v", err)
+	}
+	if got, _ := GetCurrentBranch(repoDirpath); got != startBranch {
+		t.Errorf("expected branch %s, got %q", startBranch, got)
+	}
+
+	if err := SwitchBranch(repoDirpath, "does-not-exist", false); err == nil {
+		t.Error("expected error switching to a missing branch without create")
+	}
+	if err := SwitchBranch(repoDirpath, "bad..name", true); err == nil {
+		t.Error("expected error for invalid branch name")
+	}
+
+	runGit(repoDirpath, "checkout", "--detach")
+	if got, err := GetCurrentBranch(repoDirpath); err != nil || got != "" {
+		t.Errorf("expected empty branch for detached HEAD, got %q (err %v)", got, err)
+	}
+}
+
+func TestCreateMissionDir_AutoBranch(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+	agencDirpath := t.TempDir()
+
+	tests := []struct {
+		name          string
+		missionID     string
+		workspaceMode string
+	}{
+		{"copy", "aaaaaaaa-1111", config.WorkspaceModeCopy},
+		{"worktree", "bbbbbbbb-2222", config.WorkspaceModeWorktree},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.workspaceMode == config.WorkspaceModeCopy {
+				if _, err := exec.LookPath("rsync"); err != nil {
+					t.Skip("rsync not available")
+				}
+			}
+			branchName := RenderBranchName(config.DefaultAutoBranchTemplate, tt.missionID, "Fix login")
+			if _, err := CreateMissionDir(agencDirpath, tt.missionID, "github.com/owner/repo", repoDirpath, tt.workspaceMode, branchName); err != nil {
+				t.Fatalf("CreateMissionDir failed: %v", err)
+			}
+
+			agentDirpath := config.GetMissionAgentDirpath(agencDirpath, tt.missionID)
+			if got := runGit(agentDirpath, "symbolic-ref", "--short", "HEAD"); got != branchName {
+				t.Errorf("expected agent on branch %s, got %s", branchName, got)
+			}
+			if _, err := os.Stat(filepath.Join(agentDirpath, "file.txt")); err != nil {
+				t.Errorf("expected repo contents in agent dir: %v", err)
+			}
+		})
+	}
+}
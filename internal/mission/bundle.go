@@ -0,0 +1,567 @@
+package mission
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/version"
+)
+
+// Mission bundles are tar archives (optionally gzip- or zstd-compressed) that
+// carry everything needed to pick a mission up on another machine:
+//
+//	manifest.json    BundleManifest — format version and the mission's DB row
+//	agent/           the mission's workspace, including .git
+//	claude-config/   the per-mission Claude config (symlinks and credentials excluded)
+//	transcripts/     the mission's Claude project directory (session JSONL files)
+const (
+	// BundleFormatVersion is bumped whenever the bundle layout changes
+	// incompatibly. Import rejects bundles with a newer version.
+	BundleFormatVersion = 1
+
+	bundleManifestFilename   = "manifest.json"
+	bundleAgentPrefix        = config.AgentDirname
+	bundleClaudeConfigPrefix = claudeconfig.MissionClaudeConfigDirname
+	bundleTranscriptsPrefix  = "transcripts"
+
+	// claudeCredentialsFilename is where Claude Code stores credentials on
+	// platforms without a keychain. Never exported.
+	claudeCredentialsFilename = ".credentials.json"
+)
+
+var (
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// BundleManifest is the first entry of a mission bundle.
+type BundleManifest struct {
+	FormatVersion int           `json:"format_version"`
+	AgencVersion  string        `json:"agenc_version"`
+	ExportedAt    time.Time     `json:"exported_at"`
+	Mission       BundleMission `json:"mission"`
+
+	// AgentDirpath is the mission's agent directory on the exporting machine.
+	// Import rewrites it to the new location inside transcripts.
+	AgentDirpath string `json:"agent_dirpath"`
+
+	// Subpath is the directory within agent/ that Claude runs in, for
+	// missions created with --path. Empty means agent/ itself.
+	Subpath string `json:"subpath,omitempty"`
+}
+
+// BundleMission is the portable subset of a mission's database row.
+// Machine-local state (tmux pane, heartbeat, cron linkage) is not carried.
+type BundleMission struct {
+	ID                   string     `json:"id"`
+	Prompt               string     `json:"prompt"`
+	Status               string     `json:"status"`
+	GitRepo              string     `json:"git_repo"`
+	LastUserPromptAt     *time.Time `json:"last_user_prompt_at,omitempty"`
+	SessionName          string     `json:"session_name"`
+	SessionNameUpdatedAt *time.Time `json:"session_name_updated_at,omitempty"`
+	Source               *string    `json:"source,omitempty"`
+	SourceID             *string    `json:"source_id,omitempty"`
+	SourceMetadata       *string    `json:"source_metadata,omitempty"`
+	PromptCount          int        `json:"prompt_count"`
+	Tags                 []string   `json:"tags,omitempty"`
+	PRURL                string     `json:"pr_url,omitempty"`
+	DisplayName          string     `json:"display_name,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+// newBundleMission captures the portable fields of a mission row.
+func newBundleMission(m *database.Mission) BundleMission {
+	return BundleMission{
+		ID:                   m.ID,
+		Prompt:               m.Prompt,
+		Status:               m.Status,
+		GitRepo:              m.GitRepo,
+		LastUserPromptAt:     m.LastUserPromptAt,
+		SessionName:          m.SessionName,
+		SessionNameUpdatedAt: m.SessionNameUpdatedAt,
+		Source:               m.Source,
+		SourceID:             m.SourceID,
+		SourceMetadata:       m.SourceMetadata,
+		PromptCount:          m.PromptCount,
+		Tags:                 m.Tags,
+		PRURL:                m.PRURL,
+		DisplayName:          m.DisplayName,
+		CreatedAt:            m.CreatedAt,
+	}
+}
+
+// ToMission converts the bundled row back into a database.Mission.
+func (bm BundleMission) ToMission() *database.Mission {
+	return &database.Mission{
+		ID:                   bm.ID,
+		ShortID:              database.ShortID(bm.ID),
+		Prompt:               bm.Prompt,
+		Status:               bm.Status,
+		GitRepo:              bm.GitRepo,
+		LastUserPromptAt:     bm.LastUserPromptAt,
+		SessionName:          bm.SessionName,
+		SessionNameUpdatedAt: bm.SessionNameUpdatedAt,
+		Source:               bm.Source,
+		SourceID:             bm.SourceID,
+		SourceMetadata:       bm.SourceMetadata,
+		PromptCount:          bm.PromptCount,
+		Tags:                 bm.Tags,
+		PRURL:                bm.PRURL,
+		DisplayName:          bm.DisplayName,
+		CreatedAt:            bm.CreatedAt,
+	}
+}
+
+// ============================================================================
+// Export
+// ============================================================================
+
+// ExportBundle writes a bundle for missionRecord to outputFilepath. The
+// compression is chosen from the extension: .tar.zst (requires the zstd
+// binary), .tar.gz/.tgz, or an uncompressed .tar. Worktree-mode missions
+// cannot be exported because their git history lives in the library clone.
+// The caller must ensure the mission's wrapper is not running.
+func ExportBundle(agencDirpath string, missionRecord *database.Mission, outputFilepath string) (err error) {
+	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionRecord.ID)
+	if IsWorktree(agentDirpath) {
+		return stacktrace.NewError(
+			"mission '%s' uses workspaceMode 'worktree'; its git history lives in the repo library and cannot be bundled — push branch '%s' instead",
+			missionRecord.ShortID, WorktreeBranchName(missionRecord.ID),
+		)
+	}
+	if _, err := os.Stat(agentDirpath); err != nil {
+		return stacktrace.Propagate(err, "mission '%s' has no agent directory", missionRecord.ShortID)
+	}
+
+	outFile, err := os.OpenFile(outputFilepath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create bundle file '%s'", outputFilepath)
+	}
+	defer func() {
+		if closeErr := outFile.Close(); closeErr != nil && err == nil {
+			err = stacktrace.Propagate(closeErr, "failed to close bundle file")
+		}
+		if err != nil {
+			_ = os.Remove(outputFilepath)
+		}
+	}()
+
+	compressed, finish, err := newBundleCompressor(outFile, outputFilepath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Reap the compressor on early failure; the success path finishes it below
+		if err != nil {
+			_ = finish()
+		}
+	}()
+	tw := tar.NewWriter(compressed)
+
+	manifest := BundleManifest{
+		FormatVersion: BundleFormatVersion,
+		AgencVersion:  version.Version,
+		ExportedAt:    time.Now().UTC(),
+		Mission:       newBundleMission(missionRecord),
+		AgentDirpath:  agentDirpath,
+		Subpath:       config.ReadMissionSubpath(agencDirpath, missionRecord.ID),
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to marshal bundle manifest")
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    bundleManifestFilename,
+		Mode:    0644,
+		Size:    int64(len(manifestData)),
+		ModTime: manifest.ExportedAt,
+	}); err != nil {
+		return stacktrace.Propagate(err, "failed to write bundle manifest header")
+	}
+	if _, err := tw.Write(manifestData); err != nil {
+		return stacktrace.Propagate(err, "failed to write bundle manifest")
+	}
+
+	if err := addDirToTar(tw, agentDirpath, bundleAgentPrefix, nil); err != nil {
+		return stacktrace.Propagate(err, "failed to bundle agent directory")
+	}
+
+	// The shared ~/.claude directories are symlinked into claude-config; only
+	// the mission's own snapshot is bundled, and never credentials
+	claudeConfigDirpath := filepath.Join(config.GetMissionDirpath(agencDirpath, missionRecord.ID), claudeconfig.MissionClaudeConfigDirname)
+	skipClaudeConfig := func(relpath string, d fs.DirEntry) bool {
+		return d.Type()&fs.ModeSymlink != 0 || d.Name() == claudeCredentialsFilename
+	}
+	if err := addDirToTar(tw, claudeConfigDirpath, bundleClaudeConfigPrefix, skipClaudeConfig); err != nil {
+		return stacktrace.Propagate(err, "failed to bundle claude-config directory")
+	}
+
+	projectDirpath, err := claudeconfig.ComputeProjectDirpath(filepath.Join(agentDirpath, manifest.Subpath))
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to compute Claude project directory")
+	}
+	if err := addDirToTar(tw, projectDirpath, bundleTranscriptsPrefix, nil); err != nil {
+		return stacktrace.Propagate(err, "failed to bundle transcripts")
+	}
+
+	if err := tw.Close(); err != nil {
+		return stacktrace.Propagate(err, "failed to finalize bundle archive")
+	}
+	return finish()
+}
+
+// addDirToTar adds srcDirpath's contents to tw under prefix. A missing
+// srcDirpath is skipped. Regular files, directories, and symlinks are
+// archived; sockets and other special files are ignored. skip, when non-nil,
+// excludes entries (and, for directories, their contents).
+func addDirToTar(tw *tar.Writer, srcDirpath string, prefix string, skip func(relpath string, d fs.DirEntry) bool) error {
+	if _, err := os.Lstat(srcDirpath); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(srcDirpath, func(fpath string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		relpath, err := filepath.Rel(srcDirpath, fpath)
+		if err != nil {
+			return err
+		}
+		if relpath != "." && skip != nil && skip(relpath, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		mode := info.Mode()
+		if !mode.IsRegular() && !mode.IsDir() && mode&fs.ModeSymlink == 0 {
+			return nil
+		}
+
+		var linkTarget string
+		if mode&fs.ModeSymlink != 0 {
+			if linkTarget, err = os.Readlink(fpath); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, linkTarget)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(prefix, filepath.ToSlash(relpath))
+		if mode.IsDir() {
+			header.Name += "/"
+		}
+		// Ownership is meaningless on the importing machine
+		header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !mode.IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(fpath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// newBundleCompressor wraps w in the compressor implied by the output
+// filename. The returned finish func flushes the compressor and must be
+// called after the tar writer is closed.
+func newBundleCompressor(w io.Writer, outputFilepath string) (io.Writer, func() error, error) {
+	name := strings.ToLower(outputFilepath)
+	switch {
+	case strings.HasSuffix(name, ".zst"):
+		if _, err := exec.LookPath("zstd"); err != nil {
+			return nil, nil, stacktrace.NewError("'zstd' not found in PATH; install it or write a .tar.gz bundle instead")
+		}
+		cmd := exec.Command("zstd", "-q", "-c")
+		cmd.Stdout = w
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, nil, stacktrace.Propagate(err, "failed to open zstd stdin")
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "failed to start zstd")
+		}
+		finish := func() error {
+			_ = stdin.Close()
+			if err := cmd.Wait(); err != nil {
+				return stacktrace.Propagate(err, "zstd failed: %s", strings.TrimSpace(stderr.String()))
+			}
+			return nil
+		}
+		return stdin, finish, nil
+	case strings.HasSuffix(name, ".gz"), strings.HasSuffix(name, ".tgz"):
+		gw := gzip.NewWriter(w)
+		return gw, gw.Close, nil
+	case strings.HasSuffix(name, ".tar"):
+		return w, func() error { return nil }, nil
+	default:
+		return nil, nil, stacktrace.NewError("unrecognized bundle extension for '%s'; use .tar.zst, .tar.gz, or .tar", filepath.Base(outputFilepath))
+	}
+}
+
+// ============================================================================
+// Import
+// ============================================================================
+
+// ReadBundleManifest returns a bundle's manifest without extracting anything.
+func ReadBundleManifest(bundleFilepath string) (*BundleManifest, error) {
+	tr, closeBundle, err := openBundle(bundleFilepath)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBundle()
+	return readManifestEntry(tr)
+}
+
+// ExtractBundle unpacks a bundle into the mission directory for the bundled
+// mission ID and its transcripts into this machine's Claude project directory,
+// rewriting the exporting machine's agent path in transcript files. The
+// mission directory must not already exist. On failure, anything extracted is
+// removed.
+func ExtractBundle(agencDirpath string, bundleFilepath string) (_ *BundleManifest, err error) {
+	tr, closeBundle, err := openBundle(bundleFilepath)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBundle()
+
+	manifest, err := readManifestEntry(tr)
+	if err != nil {
+		return nil, err
+	}
+	missionID := manifest.Mission.ID
+
+	missionDirpath := config.GetMissionDirpath(agencDirpath, missionID)
+	if _, err := os.Stat(missionDirpath); err == nil {
+		return nil, stacktrace.NewError("mission directory '%s' already exists", missionDirpath)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
+	subpath, err := CleanSubpath(manifest.Subpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "bundle has an invalid mission path")
+	}
+	projectDirpath, err := claudeconfig.ComputeProjectDirpath(filepath.Join(agentDirpath, subpath))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to compute Claude project directory")
+	}
+
+	// Transcripts may already exist if this mission once lived here; only
+	// remove the project directory on failure if this import created it
+	_, statErr := os.Stat(projectDirpath)
+	createdProjectDir := os.IsNotExist(statErr)
+
+	if err := os.MkdirAll(missionDirpath, 0755); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to create mission directory '%s'", missionDirpath)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(missionDirpath)
+			if createdProjectDir {
+				_ = os.RemoveAll(projectDirpath)
+			}
+		}
+	}()
+	if err := os.MkdirAll(projectDirpath, 0700); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to create Claude project directory '%s'", projectDirpath)
+	}
+
+	// os.Root confines every write (including through symlinks in the
+	// archive) to the destination directory
+	missionRoot, err := os.OpenRoot(missionDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to open mission directory")
+	}
+	defer missionRoot.Close()
+	projectRoot, err := os.OpenRoot(projectDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to open Claude project directory")
+	}
+	defer projectRoot.Close()
+
+	var pathRewrite *strings.Replacer
+	if manifest.AgentDirpath != "" && manifest.AgentDirpath != agentDirpath {
+		pathRewrite = strings.NewReplacer(manifest.AgentDirpath, agentDirpath)
+	}
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to read bundle")
+		}
+
+		name := path.Clean(strings.TrimSuffix(header.Name, "/"))
+		if !fs.ValidPath(name) {
+			return nil, stacktrace.NewError("bundle contains an invalid path '%s'", header.Name)
+		}
+
+		root, relpath := missionRoot, name
+		var rewrite *strings.Replacer
+		switch {
+		case name == bundleAgentPrefix || strings.HasPrefix(name, bundleAgentPrefix+"/"),
+			name == bundleClaudeConfigPrefix || strings.HasPrefix(name, bundleClaudeConfigPrefix+"/"):
+		case name == bundleTranscriptsPrefix:
+			continue
+		case strings.HasPrefix(name, bundleTranscriptsPrefix+"/"):
+			root, relpath = projectRoot, strings.TrimPrefix(name, bundleTranscriptsPrefix+"/")
+			rewrite = pathRewrite
+		default:
+			return nil, stacktrace.NewError("bundle contains an unexpected entry '%s'", header.Name)
+		}
+
+		if err := extractTarEntry(root, relpath, header, tr, rewrite); err != nil {
+			return nil, stacktrace.Propagate(err, "failed to extract '%s'", header.Name)
+		}
+	}
+
+	if subpath != "" {
+		if err := os.WriteFile(config.GetMissionSubpathFilepath(agencDirpath, missionID), []byte(subpath+"\n"), 0644); err != nil {
+			return nil, stacktrace.Propagate(err, "failed to record mission path")
+		}
+	}
+
+	return manifest, nil
+}
+
+// extractTarEntry writes one archive entry beneath root. For .jsonl files,
+// rewrite (when non-nil) is applied to the content.
+func extractTarEntry(root *os.Root, relpath string, header *tar.Header, r io.Reader, rewrite *strings.Replacer) error {
+	mode := fs.FileMode(header.Mode).Perm()
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return root.MkdirAll(relpath, mode|0700)
+	case tar.TypeSymlink:
+		if err := root.MkdirAll(path.Dir(relpath), 0755); err != nil {
+			return err
+		}
+		return root.Symlink(header.Linkname, relpath)
+	case tar.TypeReg:
+		if err := root.MkdirAll(path.Dir(relpath), 0755); err != nil {
+			return err
+		}
+		f, err := root.OpenFile(relpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+		if rewrite != nil && strings.HasSuffix(relpath, ".jsonl") {
+			var data []byte
+			if data, err = io.ReadAll(r); err == nil {
+				_, err = io.WriteString(f, rewrite.Replace(string(data)))
+			}
+		} else {
+			_, err = io.Copy(f, r)
+		}
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	default:
+		// Hard links, devices, etc. are never written by ExportBundle
+		return nil
+	}
+}
+
+// openBundle opens a bundle for reading, detecting compression from the
+// file's magic bytes.
+func openBundle(bundleFilepath string) (*tar.Reader, func(), error) {
+	f, err := os.Open(bundleFilepath)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "failed to open bundle '%s'", bundleFilepath)
+	}
+	br := bufio.NewReader(f)
+	magic, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, zstdMagic):
+		if _, err := exec.LookPath("zstd"); err != nil {
+			f.Close()
+			return nil, nil, stacktrace.NewError("bundle is zstd-compressed but 'zstd' was not found in PATH")
+		}
+		cmd := exec.Command("zstd", "-d", "-q", "-c")
+		cmd.Stdin = br
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			f.Close()
+			return nil, nil, stacktrace.Propagate(err, "failed to open zstd stdout")
+		}
+		if err := cmd.Start(); err != nil {
+			f.Close()
+			return nil, nil, stacktrace.Propagate(err, "failed to start zstd")
+		}
+		closeBundle := func() {
+			_ = stdout.Close()
+			_ = cmd.Wait()
+			f.Close()
+		}
+		return tar.NewReader(stdout), closeBundle, nil
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			f.Close()
+			return nil, nil, stacktrace.Propagate(err, "failed to read gzip bundle")
+		}
+		return tar.NewReader(gr), func() { gr.Close(); f.Close() }, nil
+	default:
+		return tar.NewReader(br), func() { f.Close() }, nil
+	}
+}
+
+// readManifestEntry reads and validates the manifest, which must be the
+// archive's first entry.
+func readManifestEntry(tr *tar.Reader) (*BundleManifest, error) {
+	header, err := tr.Next()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read bundle; is this an agenc mission bundle?")
+	}
+	if header.Name != bundleManifestFilename {
+		return nil, stacktrace.NewError("not an agenc mission bundle: first entry is '%s', expected '%s'", header.Name, bundleManifestFilename)
+	}
+
+	var manifest BundleManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse bundle manifest")
+	}
+	if manifest.FormatVersion > BundleFormatVersion {
+		return nil, stacktrace.NewError("bundle format version %d is newer than this agenc supports (%d); upgrade agenc to import it", manifest.FormatVersion, BundleFormatVersion)
+	}
+	if manifest.Mission.ID == "" || !fs.ValidPath(manifest.Mission.ID) || strings.Contains(manifest.Mission.ID, "/") {
+		return nil, stacktrace.NewError("bundle manifest has an invalid mission ID '%s'", manifest.Mission.ID)
+	}
+	return &manifest, nil
+}
@@ -0,0 +1,174 @@
+package mission
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// setupBundleTestMission lays out a mission on disk the way a real one looks:
+// an agent dir, a claude-config with a shared-dir symlink and credentials, and
+// a transcript in ~/.claude/projects.
+func setupBundleTestMission(t *testing.T, agencDirpath string, missionID string) {
+	t.Helper()
+	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
+	writeTestFile(t, filepath.Join(agentDirpath, "main.go"), "package main\n")
+	writeTestFile(t, filepath.Join(agentDirpath, ".git", "HEAD"), "ref: refs/heads/main\n")
+	if err := os.Symlink("main.go", filepath.Join(agentDirpath, "link.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	claudeConfigDirpath := filepath.Join(config.GetMissionDirpath(agencDirpath, missionID), claudeconfig.MissionClaudeConfigDirname)
+	writeTestFile(t, filepath.Join(claudeConfigDirpath, "settings.json"), "{}")
+	writeTestFile(t, filepath.Join(claudeConfigDirpath, claudeCredentialsFilename), "secret")
+	if err := os.Symlink(t.TempDir(), filepath.Join(claudeConfigDirpath, "plugins")); err != nil {
+		t.Fatal(err)
+	}
+
+	projectDirpath, err := claudeconfig.ComputeProjectDirpath(agentDirpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(projectDirpath, "session-1.jsonl"), `{"cwd":"`+agentDirpath+`"}`+"\n")
+}
+
+func writeTestFile(t *testing.T, fpath string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fpath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportImportBundle_RoundTrip(t *testing.T) {
+	for _, ext := range []string{".tar.gz", ".tar", ".tar.zst"} {
+		t.Run(ext, func(t *testing.T) {
+			if ext == ".tar.zst" {
+				if _, err := exec.LookPath("zstd"); err != nil {
+					t.Skip("zstd not installed")
+				}
+			}
+			t.Setenv("HOME", t.TempDir())
+
+			srcAgencDirpath := filepath.Join(t.TempDir(), "src-agenc")
+			missionID := "2b4c8f1a-0000-4000-8000-000000000001"
+			setupBundleTestMission(t, srcAgencDirpath, missionID)
+
+			sessionName := "Fix the flaky test"
+			missionRecord := &database.Mission{
+				ID:          missionID,
+				ShortID:     database.ShortID(missionID),
+				Status:      "active",
+				GitRepo:     "github.com/owner/repo",
+				SessionName: sessionName,
+				PromptCount: 3,
+				Tags:        []string{"handoff"},
+				CreatedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			}
+
+			bundleFilepath := filepath.Join(t.TempDir(), "bundle"+ext)
+			if err := ExportBundle(srcAgencDirpath, missionRecord, bundleFilepath); err != nil {
+				t.Fatalf("ExportBundle failed: %v", err)
+			}
+
+			manifest, err := ReadBundleManifest(bundleFilepath)
+			if err != nil {
+				t.Fatalf("ReadBundleManifest failed: %v", err)
+			}
+			if manifest.Mission.ID != missionID || manifest.Mission.SessionName != sessionName {
+				t.Errorf("unexpected manifest mission: %+v", manifest.Mission)
+			}
+
+			dstAgencDirpath := filepath.Join(t.TempDir(), "dst-agenc")
+			if _, err := ExtractBundle(dstAgencDirpath, bundleFilepath); err != nil {
+				t.Fatalf("ExtractBundle failed: %v", err)
+			}
+
+			dstAgentDirpath := config.GetMissionAgentDirpath(dstAgencDirpath, missionID)
+			if data, err := os.ReadFile(filepath.Join(dstAgentDirpath, "main.go")); err != nil || string(data) != "package main\n" {
+				t.Errorf("agent file not restored: %q, %v", data, err)
+			}
+			if _, err := os.Stat(filepath.Join(dstAgentDirpath, ".git", "HEAD")); err != nil {
+				t.Errorf(".git not restored: %v", err)
+			}
+			if target, err := os.Readlink(filepath.Join(dstAgentDirpath, "link.go")); err != nil || target != "main.go" {
+				t.Errorf("symlink not restored: %q, %v", target, err)
+			}
+
+			dstClaudeConfigDirpath := filepath.Join(config.GetMissionDirpath(dstAgencDirpath, missionID), claudeconfig.MissionClaudeConfigDirname)
+			if _, err := os.Stat(filepath.Join(dstClaudeConfigDirpath, "settings.json")); err != nil {
+				t.Errorf("claude-config not restored: %v", err)
+			}
+			if _, err := os.Lstat(filepath.Join(dstClaudeConfigDirpath, claudeCredentialsFilename)); !os.IsNotExist(err) {
+				t.Error("credentials must not be bundled")
+			}
+			if _, err := os.Lstat(filepath.Join(dstClaudeConfigDirpath, "plugins")); !os.IsNotExist(err) {
+				t.Error("shared-dir symlinks must not be bundled")
+			}
+
+			dstProjectDirpath, err := claudeconfig.ComputeProjectDirpath(dstAgentDirpath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			transcript, err := os.ReadFile(filepath.Join(dstProjectDirpath, "session-1.jsonl"))
+			if err != nil {
+				t.Fatalf("transcript not restored: %v", err)
+			}
+			if !strings.Contains(string(transcript), dstAgentDirpath) || strings.Contains(string(transcript), srcAgencDirpath) {
+				t.Errorf("transcript paths not rewritten: %s", transcript)
+			}
+		})
+	}
+}
+
+func TestExtractBundle_ExistingMissionDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	agencDirpath := t.TempDir()
+	missionID := "2b4c8f1a-0000-4000-8000-000000000002"
+	setupBundleTestMission(t, agencDirpath, missionID)
+
+	bundleFilepath := filepath.Join(t.TempDir(), "bundle.tar")
+	if err := ExportBundle(agencDirpath, &database.Mission{ID: missionID, ShortID: database.ShortID(missionID)}, bundleFilepath); err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+
+	// Importing onto the machine it came from must not clobber the original
+	if _, err := ExtractBundle(agencDirpath, bundleFilepath); err == nil {
+		t.Fatal("expected error when the mission directory already exists")
+	}
+	if _, err := os.Stat(config.GetMissionAgentDirpath(agencDirpath, missionID)); err != nil {
+		t.Errorf("original mission directory was damaged: %v", err)
+	}
+}
+
+func TestExportBundle_RejectsWorktree(t *testing.T) {
+	agencDirpath := t.TempDir()
+	missionID := "2b4c8f1a-0000-4000-8000-000000000003"
+	writeTestFile(t, filepath.Join(config.GetMissionAgentDirpath(agencDirpath, missionID), ".git"), "gitdir: /elsewhere\n")
+
+	bundleFilepath := filepath.Join(t.TempDir(), "bundle.tar")
+	err := ExportBundle(agencDirpath, &database.Mission{ID: missionID, ShortID: database.ShortID(missionID)}, bundleFilepath)
+	if err == nil || !strings.Contains(err.Error(), "worktree") {
+		t.Fatalf("expected worktree error, got %v", err)
+	}
+	if _, err := os.Stat(bundleFilepath); !os.IsNotExist(err) {
+		t.Error("no bundle should be written on failure")
+	}
+}
+
+func TestReadBundleManifest_NotABundle(t *testing.T) {
+	fpath := filepath.Join(t.TempDir(), "junk.tar.gz")
+	writeTestFile(t, fpath, "definitely not a tarball")
+	if _, err := ReadBundleManifest(fpath); err == nil {
+		t.Fatal("expected error for a non-bundle file")
+	}
+}
@@ -0,0 +1,135 @@
+package mission
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// LinkDependencyCache points each of cachePaths inside workspaceDirpath at the
+// matching entry under cacheDirpath, so the library clone and every mission
+// share one copy of installed dependencies. For each path:
+//
+//   - an existing symlink to the cache entry is left alone
+//   - a real file or directory is moved into the cache when the cache entry
+//     does not exist yet (seeding it), and otherwise removed in favor of the
+//     cache
+//   - a symlink to the cache entry is then created, along with an empty cache
+//     directory if nothing seeded it
+//
+// The paths are also added to the repository's info/exclude file: ignore
+// patterns like "node_modules/" only match directories, so without this the
+// symlinks would show up as untracked files and could be committed.
+func LinkDependencyCache(workspaceDirpath string, cacheDirpath string, cachePaths []string) error {
+	if len(cachePaths) == 0 {
+		return nil
+	}
+
+	for _, cachePath := range cachePaths {
+		if err := linkDependencyCachePath(workspaceDirpath, cacheDirpath, filepath.Clean(cachePath)); err != nil {
+			return err
+		}
+	}
+	return excludeFromGit(workspaceDirpath, cachePaths)
+}
+
+func linkDependencyCachePath(workspaceDirpath string, cacheDirpath string, cachePath string) error {
+	linkPath := filepath.Join(workspaceDirpath, cachePath)
+	targetPath := filepath.Join(cacheDirpath, cachePath)
+
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create dependency cache directory for '%s'", cachePath)
+	}
+
+	info, err := os.Lstat(linkPath)
+	switch {
+	case err == nil && info.Mode()&os.ModeSymlink != 0:
+		if existing, readErr := os.Readlink(linkPath); readErr == nil && existing == targetPath {
+			return nil
+		}
+		if err := os.Remove(linkPath); err != nil {
+			return stacktrace.Propagate(err, "failed to replace stale symlink '%s'", linkPath)
+		}
+	case err == nil:
+		if _, statErr := os.Stat(targetPath); os.IsNotExist(statErr) {
+			// Seed the cache from the first real copy we see
+			if err := os.Rename(linkPath, targetPath); err != nil {
+				return stacktrace.Propagate(err, "failed to move '%s' into the dependency cache", linkPath)
+			}
+		} else if err := os.RemoveAll(linkPath); err != nil {
+			return stacktrace.Propagate(err, "failed to remove '%s' in favor of the dependency cache", linkPath)
+		}
+	case !os.IsNotExist(err):
+		return stacktrace.Propagate(err, "failed to inspect '%s'", linkPath)
+	}
+
+	if _, err := os.Lstat(targetPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(targetPath, 0755); err != nil {
+			return stacktrace.Propagate(err, "failed to create dependency cache entry '%s'", targetPath)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(linkPath), 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create parent directory for '%s'", linkPath)
+	}
+	if err := os.Symlink(targetPath, linkPath); err != nil {
+		return stacktrace.Propagate(err, "failed to link '%s' to the dependency cache", linkPath)
+	}
+	return nil
+}
+
+// excludeFromGit appends root-anchored patterns for paths to the repository's
+// info/exclude file, skipping patterns already present.
+func excludeFromGit(repoDirpath string, paths []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--path-format=absolute", "--git-path", "info/exclude")
+	cmd.Dir = repoDirpath
+	output, err := cmd.Output()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to locate git exclude file for '%s'", repoDirpath)
+	}
+	excludeFilepath := strings.TrimSpace(string(output))
+
+	content, err := os.ReadFile(excludeFilepath)
+	if err != nil && !os.IsNotExist(err) {
+		return stacktrace.Propagate(err, "failed to read git exclude file '%s'", excludeFilepath)
+	}
+	existing := map[string]bool{}
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	var missing []string
+	for _, p := range paths {
+		pattern := "/" + filepath.ToSlash(filepath.Clean(p))
+		if !existing[pattern] {
+			missing = append(missing, pattern)
+			existing[pattern] = true
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludeFilepath), 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create git info directory")
+	}
+	f, err := os.OpenFile(excludeFilepath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open git exclude file '%s'", excludeFilepath)
+	}
+	defer f.Close()
+	addition := strings.Join(missing, "\n") + "\n"
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		addition = "\n" + addition
+	}
+	if _, err := f.WriteString(addition); err != nil {
+		return stacktrace.Propagate(err, "failed to update git exclude file '%s'", excludeFilepath)
+	}
+	return nil
+}
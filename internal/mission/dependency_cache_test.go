@@ -0,0 +1,64 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLinkDependencyCache(t *testing.T) {
+	libraryDirpath, runGit := initWorktreeTestRepo(t)
+	cacheDirpath := filepath.Join(t.TempDir(), "cache")
+	cachePaths := []string{"node_modules", "tools/.venv"}
+
+	// The library already has installed dependencies; they seed the cache
+	if err := os.MkdirAll(filepath.Join(libraryDirpath, "node_modules", "left-pad"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := LinkDependencyCache(libraryDirpath, cacheDirpath, cachePaths); err != nil {
+		t.Fatalf("LinkDependencyCache on library failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDirpath, "node_modules", "left-pad")); err != nil {
+		t.Errorf("expected library node_modules to seed the cache: %v", err)
+	}
+	if target, err := os.Readlink(filepath.Join(libraryDirpath, "node_modules")); err != nil || target != filepath.Join(cacheDirpath, "node_modules") {
+		t.Errorf("expected library node_modules to link to the cache, got %q (err %v)", target, err)
+	}
+	if info, err := os.Stat(filepath.Join(cacheDirpath, "tools", ".venv")); err != nil || !info.IsDir() {
+		t.Errorf("expected an empty cache entry for tools/.venv: %v", err)
+	}
+
+	// The symlinks must not show up as untracked files
+	if status := runGit(libraryDirpath, "status", "--porcelain"); status != "" {
+		t.Errorf("expected a clean status after linking, got:\n%s", status)
+	}
+
+	// A mission workspace with its own stale copy switches to the cache
+	workspaceDirpath := filepath.Join(t.TempDir(), "agent")
+	runGit(libraryDirpath, "clone", "-q", libraryDirpath, workspaceDirpath)
+	if err := os.MkdirAll(filepath.Join(workspaceDirpath, "node_modules", "stale"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := LinkDependencyCache(workspaceDirpath, cacheDirpath, cachePaths); err != nil {
+		t.Fatalf("LinkDependencyCache on workspace failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(workspaceDirpath, "node_modules", "left-pad")); err != nil {
+		t.Errorf("expected workspace to see cached dependencies: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDirpath, "node_modules", "stale")); !os.IsNotExist(err) {
+		t.Errorf("expected the workspace's stale copy to be discarded, not merged into the cache")
+	}
+
+	// Linking again is a no-op and does not duplicate exclude entries
+	if err := LinkDependencyCache(workspaceDirpath, cacheDirpath, cachePaths); err != nil {
+		t.Fatalf("second LinkDependencyCache failed: %v", err)
+	}
+	exclude, err := os.ReadFile(filepath.Join(workspaceDirpath, ".git", "info", "exclude"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(exclude), "/node_modules\n"); n != 1 {
+		t.Errorf("expected one /node_modules exclude entry, got %d", n)
+	}
+}
@@ -0,0 +1,125 @@
+package mission
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// WorkspaceDiff describes a mission workspace's changes relative to the
+// commit the mission started from.
+type WorkspaceDiff struct {
+	// BaseRef is the remote-tracking branch the workspace is compared
+	// against (e.g. "origin/main"), or empty when it has no origin.
+	BaseRef string
+	// BaseCommit is where HEAD diverged from BaseRef, or HEAD itself when
+	// there is no BaseRef. Empty when the workspace has no commits yet.
+	BaseCommit string
+	// Status is `git status --short` output, including untracked files.
+	Status string
+	// Stat is the `git diff --stat` of the working tree against BaseCommit,
+	// covering both the mission's commits and its uncommitted changes.
+	Stat string
+	// Patch is the full diff behind Stat. Only filled when requested.
+	Patch string
+}
+
+// GetWorkspaceDiff compares the working tree at repoDirpath against the merge
+// base of HEAD and the origin default branch. Untracked files appear only in
+// Status, since git diff doesn't cover them.
+func GetWorkspaceDiff(repoDirpath string, includePatch bool) (*WorkspaceDiff, error) {
+	result := &WorkspaceDiff{}
+
+	status, err := runGitRaw(repoDirpath, "status", "--short")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read workspace status")
+	}
+	result.Status = status
+
+	result.BaseRef, result.BaseCommit, err = getWorkspaceBase(repoDirpath)
+	if err != nil {
+		return nil, err
+	}
+	if result.BaseCommit == "" {
+		// Nothing committed yet, so there is nothing to diff against
+		return result, nil
+	}
+
+	if result.Stat, err = runGitRaw(repoDirpath, "diff", "--stat", result.BaseCommit); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to compute workspace diffstat")
+	}
+	if includePatch {
+		if result.Patch, err = runGitRaw(repoDirpath, "diff", result.BaseCommit); err != nil {
+			return nil, stacktrace.Propagate(err, "failed to compute workspace diff")
+		}
+	}
+	return result, nil
+}
+
+// ListModifiedFiles returns the paths, relative to repoDirpath, that the
+// workspace has changed since the commit it started from: files touched by
+// its own commits, uncommitted edits, and untracked files. Sorted.
+func ListModifiedFiles(repoDirpath string) ([]string, error) {
+	_, baseCommit, err := getWorkspaceBase(repoDirpath)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	if baseCommit != "" {
+		changed, err := runGit(repoDirpath, "-c", "core.quotePath=false", "diff", "--name-only", baseCommit)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to list changed files")
+		}
+		addLines(seen, changed)
+	}
+	untracked, err := runGit(repoDirpath, "-c", "core.quotePath=false", "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to list untracked files")
+	}
+	addLines(seen, untracked)
+	// Before the first commit every tracked file is new
+	if baseCommit == "" {
+		staged, err := runGit(repoDirpath, "-c", "core.quotePath=false", "ls-files")
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to list staged files")
+		}
+		addLines(seen, staged)
+	}
+
+	files := make([]string, 0, len(seen))
+	for path := range seen {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// getWorkspaceBase returns the remote-tracking branch a workspace is compared
+// against and where HEAD diverged from it (see WorkspaceDiff). Without an
+// origin the base is HEAD itself; both are empty before the first commit.
+func getWorkspaceBase(repoDirpath string) (string, string, error) {
+	head, err := GetHEAD(repoDirpath)
+	if err != nil {
+		return "", "", nil
+	}
+	defaultBranch, err := GetDefaultBranch(repoDirpath)
+	if err != nil {
+		return "", head, nil
+	}
+	baseRef := "origin/" + defaultBranch
+	base, err := runGit(repoDirpath, "merge-base", "HEAD", "refs/remotes/"+baseRef)
+	if err != nil {
+		return "", "", stacktrace.Propagate(err, "failed to find where the workspace diverged from %s", baseRef)
+	}
+	return baseRef, base, nil
+}
+
+func addLines(set map[string]bool, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			set[line] = true
+		}
+	}
+}
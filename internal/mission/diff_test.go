@@ -0,0 +1,114 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetWorkspaceDiff(t *testing.T) {
+	upstreamDirpath, runGit := initWorktreeTestRepo(t)
+	agentDirpath := filepath.Join(t.TempDir(), "agent")
+	runGit(filepath.Dir(agentDirpath), "clone", upstreamDirpath, agentDirpath)
+	runGit(agentDirpath, "config", "user.email", "test@test.com")
+	runGit(agentDirpath, "config", "user.name", "Test")
+	baseCommit := runGit(agentDirpath, "rev-parse", "HEAD")
+
+	// One committed change, one uncommitted change, and one untracked file
+	if err := os.WriteFile(filepath.Join(agentDirpath, "committed.txt"), []byte("committed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(agentDirpath, "add", "committed.txt")
+	runGit(agentDirpath, "commit", "-m", "mission work")
+	if err := os.WriteFile(filepath.Join(agentDirpath, "file.txt"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "untracked.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := GetWorkspaceDiff(agentDirpath, false)
+	if err != nil {
+		t.Fatalf("GetWorkspaceDiff failed: %v", err)
+	}
+	if diff.BaseCommit != baseCommit || !strings.HasPrefix(diff.BaseRef, "origin/") {
+		t.Errorf("expected base %s on an origin branch, got %s (%q)", baseCommit, diff.BaseCommit, diff.BaseRef)
+	}
+	if !strings.Contains(diff.Status, " M file.txt") || !strings.Contains(diff.Status, "?? untracked.txt") {
+		t.Errorf("expected modified and untracked files in status, got %q", diff.Status)
+	}
+	if !strings.Contains(diff.Stat, "committed.txt") || !strings.Contains(diff.Stat, "file.txt") {
+		t.Errorf("expected committed and uncommitted changes in the stat, got %q", diff.Stat)
+	}
+	if diff.Patch != "" {
+		t.Errorf("expected no patch unless requested, got %q", diff.Patch)
+	}
+
+	diff, err = GetWorkspaceDiff(agentDirpath, true)
+	if err != nil {
+		t.Fatalf("GetWorkspaceDiff failed: %v", err)
+	}
+	if !strings.Contains(diff.Patch, "+changed") || !strings.Contains(diff.Patch, "+committed") {
+		t.Errorf("expected both changes in the patch, got %q", diff.Patch)
+	}
+}
+
+func TestGetWorkspaceDiff_NoOrigin(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+	if err := os.WriteFile(filepath.Join(repoDirpath, "file.txt"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := GetWorkspaceDiff(repoDirpath, true)
+	if err != nil {
+		t.Fatalf("GetWorkspaceDiff failed: %v", err)
+	}
+	if diff.BaseRef != "" || diff.BaseCommit != runGit(repoDirpath, "rev-parse", "HEAD") {
+		t.Errorf("expected HEAD as the base without an origin, got %s (%q)", diff.BaseCommit, diff.BaseRef)
+	}
+	if !strings.Contains(diff.Patch, "+changed") {
+		t.Errorf("expected the uncommitted change in the patch, got %q", diff.Patch)
+	}
+}
+
+func TestListModifiedFiles(t *testing.T) {
+	upstreamDirpath, runGit := initWorktreeTestRepo(t)
+	agentDirpath := filepath.Join(t.TempDir(), "agent")
+	runGit(filepath.Dir(agentDirpath), "clone", upstreamDirpath, agentDirpath)
+	runGit(agentDirpath, "config", "user.email", "test@test.com")
+	runGit(agentDirpath, "config", "user.name", "Test")
+
+	files, err := ListModifiedFiles(agentDirpath)
+	if err != nil {
+		t.Fatalf("ListModifiedFiles failed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected a fresh clone to have no modified files, got %v", files)
+	}
+
+	// One committed change, one uncommitted change, and one untracked file
+	if err := os.MkdirAll(filepath.Join(agentDirpath, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "src", "committed.go"), []byte("committed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(agentDirpath, "add", "src/committed.go")
+	runGit(agentDirpath, "commit", "-m", "mission work")
+	if err := os.WriteFile(filepath.Join(agentDirpath, "file.txt"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "untracked.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err = ListModifiedFiles(agentDirpath)
+	if err != nil {
+		t.Fatalf("ListModifiedFiles failed: %v", err)
+	}
+	want := []string{"file.txt", "src/committed.go", "untracked.txt"}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, files)
+	}
+}
@@ -0,0 +1,44 @@
+package mission
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// HandoffFilename is the file a mission's handoff note is written to, at the
+// root of its agent directory.
+const HandoffFilename = "HANDOFF.md"
+
+// WriteHandoffFile writes a handoff note into agentDirpath as HANDOFF.md and,
+// when the directory is a git checkout, excludes it from git so it never shows
+// up as a change to commit.
+func WriteHandoffFile(agentDirpath string, note string, archivedAt time.Time) error {
+	handoffFilepath := filepath.Join(agentDirpath, HandoffFilename)
+	if err := os.WriteFile(handoffFilepath, []byte(buildHandoffMarkdown(note, archivedAt)), 0644); err != nil {
+		return stacktrace.Propagate(err, "failed to write '%s'", handoffFilepath)
+	}
+	if _, err := os.Stat(filepath.Join(agentDirpath, ".git")); err != nil {
+		return nil
+	}
+	return excludeFromGit(agentDirpath, []string{HandoffFilename})
+}
+
+// BuildHandoffContext renders a handoff note as context for the agent of a
+// resumed mission.
+func BuildHandoffContext(note string, archivedAt time.Time) string {
+	return fmt.Sprintf("This mission was archived on %s and has since been resumed. "+
+		"Before archiving, the user left this handoff note describing where the work stood "+
+		"(also saved as %s in the working directory). Use it to pick the work back up:\n\n%s",
+		archivedAt.Local().Format("2006-01-02 15:04"), HandoffFilename, strings.TrimSpace(note))
+}
+
+// buildHandoffMarkdown renders the contents of HANDOFF.md.
+func buildHandoffMarkdown(note string, archivedAt time.Time) string {
+	return fmt.Sprintf("# Handoff\n\n_Left when this mission was archived on %s._\n\n%s\n",
+		archivedAt.Local().Format("2006-01-02 15:04"), strings.TrimSpace(note))
+}
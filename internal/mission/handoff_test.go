@@ -0,0 +1,47 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteHandoffFile(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+	archivedAt := time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC)
+
+	if err := WriteHandoffFile(repoDirpath, "  Parser done; tests pending.\n", archivedAt); err != nil {
+		t.Fatalf("WriteHandoffFile failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(repoDirpath, HandoffFilename))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", HandoffFilename, err)
+	}
+	if !strings.HasPrefix(string(content), "# Handoff\n") || !strings.Contains(string(content), "\nParser done; tests pending.\n") {
+		t.Errorf("unexpected %s contents:\n%s", HandoffFilename, content)
+	}
+	if status := runGit(repoDirpath, "status", "--porcelain"); status != "" {
+		t.Errorf("expected %s to be excluded from git, got status:\n%s", HandoffFilename, status)
+	}
+
+	// Blank missions have no git checkout; the file is still written
+	blankDirpath := t.TempDir()
+	if err := WriteHandoffFile(blankDirpath, "notes", archivedAt); err != nil {
+		t.Fatalf("WriteHandoffFile in a non-git directory failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(blankDirpath, HandoffFilename)); err != nil {
+		t.Errorf("expected %s in the non-git directory: %v", HandoffFilename, err)
+	}
+}
+
+func TestBuildHandoffContext(t *testing.T) {
+	context := BuildHandoffContext("\nParser done; tests pending.\n", time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC))
+	if !strings.HasSuffix(context, "\n\nParser done; tests pending.") {
+		t.Errorf("expected the trimmed note at the end, got:\n%s", context)
+	}
+	if !strings.Contains(context, HandoffFilename) {
+		t.Errorf("expected the context to point at %s, got:\n%s", HandoffFilename, context)
+	}
+}
@@ -9,18 +9,24 @@ import (
 
 	"github.com/odyssey/agenc/internal/claudeconfig"
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/wsl"
 )
 
 // CreateMissionDir sets up the mission directory structure. When gitRepoSource
-// is non-empty, the repository is copied directly as the agent/ directory
-// (agent/ IS the repo). When gitRepoSource is empty, an empty agent/ directory
-// is created.
+// is non-empty, the repository becomes the agent/ directory (agent/ IS the
+// repo): with workspaceMode "worktree" it is a linked git worktree of
+// gitRepoSource on a per-mission branch, otherwise a full copy. A non-empty
+// branchName (from the repo's autoBranch setting) names the branch the agent
+// starts on: it replaces the default worktree branch, and in copy mode it is
+// created from the copied HEAD. When gitRepoSource is empty, an empty agent/
+// directory is created. An empty scratch/ directory is always created
+// alongside agent/.
 //
 // The per-mission claude config directory is built by the wrapper on every
 // Claude spawn, so this function does not pre-build it.
 //
 // Returns the mission root directory path (not the agent/ subdirectory).
-func CreateMissionDir(agencDirpath string, missionID string, gitRepoName string, gitRepoSource string) (string, error) {
+func CreateMissionDir(agencDirpath string, missionID string, gitRepoName string, gitRepoSource string, workspaceMode string, branchName string) (string, error) {
 	missionDirpath := config.GetMissionDirpath(agencDirpath, missionID)
 	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
 
@@ -28,29 +34,55 @@ func CreateMissionDir(agencDirpath string, missionID string, gitRepoName string,
 		return "", stacktrace.Propagate(err, "failed to create directory '%s'", missionDirpath)
 	}
 
-	if gitRepoSource != "" {
+	if gitRepoSource != "" && workspaceMode == config.WorkspaceModeWorktree {
+		// Link agent/ to the library clone (git creates the destination)
+		worktreeBranch := WorktreeBranchName(missionID)
+		if branchName != "" {
+			worktreeBranch = branchName
+		}
+		if err := AddWorktree(gitRepoSource, agentDirpath, worktreeBranch, "HEAD"); err != nil {
+			return "", stacktrace.Propagate(err, "failed to create git worktree for agent directory")
+		}
+	} else if gitRepoSource != "" {
 		// Copy the repo directly as agent/ (CopyRepo creates the destination)
 		if err := CopyRepo(gitRepoSource, agentDirpath); err != nil {
 			return "", stacktrace.Propagate(err, "failed to copy git repo into agent directory")
 		}
+		if branchName != "" {
+			if err := SwitchBranch(agentDirpath, branchName, true); err != nil {
+				return "", stacktrace.Propagate(err, "failed to create mission branch")
+			}
+		}
 	} else {
 		if err := os.MkdirAll(agentDirpath, 0755); err != nil {
 			return "", stacktrace.Propagate(err, "failed to create directory '%s'", agentDirpath)
 		}
 	}
 
+	scratchDirpath := config.GetMissionScratchDirpath(agencDirpath, missionID)
+	if err := os.MkdirAll(scratchDirpath, 0755); err != nil {
+		return "", stacktrace.Propagate(err, "failed to create directory '%s'", scratchDirpath)
+	}
+
 	return missionDirpath, nil
 }
 
+// windowsClaudeBinaryName is the Windows build of Claude Code, reachable from
+// WSL through interop when it is on the Windows PATH.
+const windowsClaudeBinaryName = "claude.exe"
+
 // BuildClaudeCmd constructs an exec.Cmd for running Claude in the given agent
 // directory. If a secrets.env file exists at .claude/secrets.env within the
 // agent directory, the command is wrapped with `op run` to inject 1Password
 // secrets. Otherwise, Claude is invoked directly.
 //
 // The returned command has its working directory, environment variables
-// (CLAUDE_CONFIG_DIR, AGENC_MISSION_UUID, CLAUDE_CODE_OAUTH_TOKEN), set but
+// (CLAUDE_CONFIG_DIR, AGENC_MISSION_UUID, AGENC_MISSION_SCRATCH_DIRPATH,
+// CLAUDE_CODE_OAUTH_TOKEN), set but
 // does NOT set stdin/stdout/stderr — callers should wire those as needed
 // (e.g. interactive mode connects to the terminal, headless mode uses pipes).
+// With wsl.windowsClaude under WSL, it runs claude.exe instead and forwards
+// those variables to it through WSLENV.
 func BuildClaudeCmd(agencDirpath string, missionID string, agentDirpath string, model string, extraClaudeArgs []string, claudeArgs []string) (*exec.Cmd, error) {
 	var fullArgs []string
 	if model != "" {
@@ -60,9 +92,18 @@ func BuildClaudeCmd(agencDirpath string, missionID string, agentDirpath string,
 	fullArgs = append(fullArgs, claudeArgs...)
 	claudeArgs = fullArgs
 
-	claudeBinary, err := exec.LookPath("claude")
+	// With wsl.windowsClaude, run the Windows build through WSL interop
+	windowsClaude := false
+	if cfg, _, err := config.ReadAgencConfig(agencDirpath); err == nil {
+		windowsClaude = cfg.UsesWindowsClaude()
+	}
+	claudeBinaryName := "claude"
+	if windowsClaude {
+		claudeBinaryName = windowsClaudeBinaryName
+	}
+	claudeBinary, err := exec.LookPath(claudeBinaryName)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "'claude' binary not found in PATH")
+		return nil, stacktrace.Propagate(err, "'%s' binary not found in PATH", claudeBinaryName)
 	}
 
 	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(agencDirpath, missionID)
@@ -95,6 +136,7 @@ func BuildClaudeCmd(agencDirpath string, missionID string, agentDirpath string,
 	cmd.Env = append(os.Environ(),
 		"CLAUDE_CONFIG_DIR="+claudeConfigDirpath,
 		config.MissionUUIDEnvVar+"="+missionID,
+		config.MissionScratchDirpathEnvVar+"="+config.GetMissionScratchDirpath(agencDirpath, missionID),
 	)
 
 	// Read the OAuth token — callers must ensure it exists (via
@@ -114,6 +156,12 @@ func BuildClaudeCmd(agencDirpath string, missionID string, agentDirpath string,
 	}
 	cmd.Env = append(cmd.Env, "CLAUDE_CODE_OAUTH_TOKEN="+oauthToken)
 
+	if windowsClaude {
+		// Windows processes only see variables listed in WSLENV; /p
+		// translates the config and scratch dirs to \\wsl.localhost paths
+		cmd.Env = wsl.AppendWSLENV(cmd.Env, "CLAUDE_CONFIG_DIR/p", config.MissionUUIDEnvVar, config.MissionScratchDirpathEnvVar+"/p", "CLAUDE_CODE_OAUTH_TOKEN")
+	}
+
 	return cmd, nil
 }
 
@@ -129,6 +177,22 @@ func SpawnClaude(agencDirpath string, missionID string, agentDirpath string, mod
 // the first message. Returns the running command. The caller is responsible for
 // calling cmd.Wait().
 func SpawnClaudeWithPrompt(agencDirpath string, missionID string, agentDirpath string, model string, extraClaudeArgs []string, initialPrompt string) (*exec.Cmd, error) {
+	cmd, err := BuildClaudeCmdWithPrompt(agencDirpath, missionID, agentDirpath, model, extraClaudeArgs, initialPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to start claude")
+	}
+
+	return cmd, nil
+}
+
+// BuildClaudeCmdWithPrompt is SpawnClaudeWithPrompt without the start: the
+// returned command is attached to the terminal but not yet running, for
+// callers that adjust it first.
+func BuildClaudeCmdWithPrompt(agencDirpath string, missionID string, agentDirpath string, model string, extraClaudeArgs []string, initialPrompt string) (*exec.Cmd, error) {
 	var args []string
 	if initialPrompt != "" {
 		// Pass prompt as positional argument for interactive mode with pre-filled message
@@ -143,11 +207,6 @@ func SpawnClaudeWithPrompt(agencDirpath string, missionID string, agentDirpath s
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-
-	if err := cmd.Start(); err != nil {
-		return nil, stacktrace.Propagate(err, "failed to start claude")
-	}
-
 	return cmd, nil
 }
 
@@ -193,6 +252,22 @@ func buildResumeArgs(sessionID string, initialPrompt string) []string {
 // Returns the running command. The caller is responsible for calling
 // cmd.Wait().
 func SpawnClaudeResumeWithSession(agencDirpath string, missionID string, agentDirpath string, model string, extraClaudeArgs []string, sessionID string, initialPrompt string) (*exec.Cmd, error) {
+	cmd, err := BuildClaudeResumeCmdWithSession(agencDirpath, missionID, agentDirpath, model, extraClaudeArgs, sessionID, initialPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to start claude resume")
+	}
+
+	return cmd, nil
+}
+
+// BuildClaudeResumeCmdWithSession is SpawnClaudeResumeWithSession without the
+// start: the returned command is attached to the terminal but not yet
+// running, for callers that adjust it first.
+func BuildClaudeResumeCmdWithSession(agencDirpath string, missionID string, agentDirpath string, model string, extraClaudeArgs []string, sessionID string, initialPrompt string) (*exec.Cmd, error) {
 	args := buildResumeArgs(sessionID, initialPrompt)
 
 	cmd, err := BuildClaudeCmd(agencDirpath, missionID, agentDirpath, model, extraClaudeArgs, args)
@@ -203,10 +278,5 @@ func SpawnClaudeResumeWithSession(agencDirpath string, missionID string, agentDi
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-
-	if err := cmd.Start(); err != nil {
-		return nil, stacktrace.Propagate(err, "failed to start claude resume")
-	}
-
 	return cmd, nil
 }
@@ -1,10 +1,35 @@
 package mission
 
 import (
+	"os"
 	"reflect"
 	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
 )
 
+func TestCreateMissionDir_CreatesScratchDir(t *testing.T) {
+	agencDirpath := t.TempDir()
+	missionID := "cccccccc-3333"
+
+	if _, err := CreateMissionDir(agencDirpath, missionID, "", "", config.WorkspaceModeCopy, ""); err != nil {
+		t.Fatalf("CreateMissionDir failed: %v", err)
+	}
+
+	for _, dirpath := range []string{
+		config.GetMissionAgentDirpath(agencDirpath, missionID),
+		config.GetMissionScratchDirpath(agencDirpath, missionID),
+	} {
+		info, err := os.Stat(dirpath)
+		if err != nil {
+			t.Fatalf("expected directory '%s': %v", dirpath, err)
+		}
+		if !info.IsDir() {
+			t.Errorf("expected '%s' to be a directory", dirpath)
+		}
+	}
+}
+
 func TestBuildResumeArgs(t *testing.T) {
 	tests := []struct {
 		name          string
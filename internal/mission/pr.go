@@ -0,0 +1,228 @@
+package mission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// gitPushTimeout bounds `git push` and `gh pr create`, which talk to the
+// remote and can take longer than local git operations.
+const gitPushTimeout = 2 * time.Minute
+
+// PullRequestOptions configures CreatePullRequest.
+type PullRequestOptions struct {
+	// Branch is the head branch, which must already be pushed.
+	Branch string
+	// Base is the branch to merge into; empty uses the repo's default branch.
+	Base string
+	// Title and Body describe the PR. When Title is empty, gh fills the title
+	// and body from the branch's commits.
+	Title string
+	Body  string
+	Draft bool
+}
+
+// CommitAll stages every change in repoDirpath and commits it with message.
+// Returns false without committing when the working tree is clean.
+func CommitAll(repoDirpath string, message string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	statusCmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
+	statusCmd.Dir = repoDirpath
+	statusOutput, err := statusCmd.Output()
+	if err != nil {
+		return false, stacktrace.Propagate(err, "failed to check working tree status in '%s'", repoDirpath)
+	}
+	if strings.TrimSpace(string(statusOutput)) == "" {
+		return false, nil
+	}
+
+	addCmd := exec.CommandContext(ctx, "git", "add", "-A")
+	addCmd.Dir = repoDirpath
+	if output, err := addCmd.CombinedOutput(); err != nil {
+		return false, stacktrace.Propagate(err, "git add failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	commitCmd := exec.CommandContext(ctx, "git", "commit", "-m", message)
+	commitCmd.Dir = repoDirpath
+	if output, err := commitCmd.CombinedOutput(); err != nil {
+		return false, stacktrace.Propagate(err, "git commit failed: %s", strings.TrimSpace(string(output)))
+	}
+	return true, nil
+}
+
+// PushBranch pushes branchName to origin and sets it as the upstream. The
+// destination is explicit, so a review-mode push mapping doesn't redirect it.
+func PushBranch(repoDirpath string, branchName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "push", "-u", "origin", branchName+":refs/heads/"+branchName)
+	cmd.Dir = repoDirpath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "git push of '%s' failed: %s", branchName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RemoteBranchExists reports whether origin has a branch named branchName.
+func RemoteBranchExists(repoDirpath string, branchName string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--exit-code", "--heads", "origin", "refs/heads/"+branchName)
+	cmd.Dir = repoDirpath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		// --exit-code exits 2 when the remote has no matching ref
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+			return false, nil
+		}
+		return false, stacktrace.Propagate(err, "git ls-remote for '%s' failed: %s", branchName, strings.TrimSpace(string(output)))
+	}
+	return true, nil
+}
+
+// DeleteRemoteBranch deletes branchName from origin.
+func DeleteRemoteBranch(repoDirpath string, branchName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "push", "origin", "--delete", branchName)
+	cmd.Dir = repoDirpath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "deleting remote branch '%s' failed: %s", branchName, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// OpenPullRequest describes the open pull request for a branch.
+type OpenPullRequest struct {
+	URL     string `json:"url"`
+	IsDraft bool   `json:"isDraft"`
+}
+
+// FindOpenPullRequest returns the open pull request whose head is branchName,
+// or nil if there is none.
+func FindOpenPullRequest(repoDirpath string, branchName string) (*OpenPullRequest, error) {
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to look up pull requests")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, ghBinary, "pr", "list", "--head", branchName, "--state", "open", "--json", "url,isDraft", "--jq", ".[0] // empty")
+	cmd.Dir = repoDirpath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "gh pr list failed: %s", strings.TrimSpace(string(output)))
+	}
+	return parseOpenPullRequest(output)
+}
+
+// parseOpenPullRequest decodes the single PR object FindOpenPullRequest asks
+// gh for; empty output means no open PR.
+func parseOpenPullRequest(output []byte) (*OpenPullRequest, error) {
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" {
+		return nil, nil
+	}
+	var pr OpenPullRequest
+	if err := json.Unmarshal([]byte(trimmed), &pr); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse gh pr list output: %s", trimmed)
+	}
+	return &pr, nil
+}
+
+// ClosePullRequest closes the pull request at prURL with gh, leaving comment
+// on it.
+func ClosePullRequest(repoDirpath string, prURL string, comment string) error {
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to close pull requests")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, ghBinary, "pr", "close", prURL, "--comment", comment)
+	cmd.Dir = repoDirpath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "gh pr close failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// FindPullRequest returns the URL of the open pull request whose head is
+// branchName, or "" if there is none.
+func FindPullRequest(repoDirpath string, branchName string) (string, error) {
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to open pull requests")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, ghBinary, "pr", "list", "--head", branchName, "--state", "open", "--json", "url", "--jq", ".[0].url // empty")
+	cmd.Dir = repoDirpath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "gh pr list failed: %s", strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CreatePullRequest opens a GitHub pull request with gh and returns its URL.
+func CreatePullRequest(repoDirpath string, opts PullRequestOptions) (string, error) {
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to open pull requests")
+	}
+
+	args := []string{"pr", "create", "--head", opts.Branch}
+	if opts.Base != "" {
+		args = append(args, "--base", opts.Base)
+	}
+	if opts.Title != "" {
+		args = append(args, "--title", opts.Title, "--body", opts.Body)
+	} else {
+		args = append(args, "--fill")
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, ghBinary, args...)
+	cmd.Dir = repoDirpath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "gh pr create failed: %s", strings.TrimSpace(string(output)))
+	}
+	return lastPullRequestURL(string(output)), nil
+}
+
+// lastPullRequestURL extracts the PR URL gh prints as the final line of its
+// output, after any progress messages.
+func lastPullRequestURL(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); strings.HasPrefix(line, "https://") {
+			return line
+		}
+	}
+	return strings.TrimSpace(output)
+}
@@ -0,0 +1,104 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommitAllAndPushBranch(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+
+	remoteDirpath := filepath.Join(t.TempDir(), "remote.git")
+	runGit(repoDirpath, "init", "--bare", remoteDirpath)
+	runGit(repoDirpath, "remote", "add", "origin", remoteDirpath)
+
+	committed, err := CommitAll(repoDirpath, "nothing to do")
+	if err != nil {
+		t.Fatalf("CommitAll on clean tree failed: %v", err)
+	}
+	if committed {
+		t.Error("expected no commit for a clean working tree")
+	}
+
+	if err := SwitchBranch(repoDirpath, "agenc/2b4c8f1a-fix", true); err != nil {
+		t.Fatalf("SwitchBranch failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDirpath, "new.txt"), []byte("change"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	committed, err = CommitAll(repoDirpath, "Add new file")
+	if err != nil {
+		t.Fatalf("CommitAll failed: %v", err)
+	}
+	if !committed {
+		t.Error("expected a commit for a dirty working tree")
+	}
+	if got := runGit(repoDirpath, "log", "-1", "--format=%s"); got != "Add new file" {
+		t.Errorf("expected commit subject 'Add new file', got %q", got)
+	}
+
+	if err := PushBranch(repoDirpath, "agenc/2b4c8f1a-fix"); err != nil {
+		t.Fatalf("PushBranch failed: %v", err)
+	}
+	localHead := runGit(repoDirpath, "rev-parse", "HEAD")
+	if remoteHead := runGit(remoteDirpath, "rev-parse", "agenc/2b4c8f1a-fix"); remoteHead != localHead {
+		t.Errorf("expected remote branch at %s, got %s", localHead, remoteHead)
+	}
+}
+
+func TestRemoteBranchExistsAndDelete(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+
+	remoteDirpath := filepath.Join(t.TempDir(), "remote.git")
+	runGit(repoDirpath, "init", "--bare", remoteDirpath)
+	runGit(repoDirpath, "remote", "add", "origin", remoteDirpath)
+
+	branch := "agenc/2b4c8f1a-fix"
+	if err := SwitchBranch(repoDirpath, branch, true); err != nil {
+		t.Fatalf("SwitchBranch failed: %v", err)
+	}
+	exists, err := RemoteBranchExists(repoDirpath, branch)
+	if err != nil {
+		t.Fatalf("RemoteBranchExists failed: %v", err)
+	}
+	if exists {
+		t.Error("expected the branch not to exist on origin before pushing")
+	}
+
+	if err := PushBranch(repoDirpath, branch); err != nil {
+		t.Fatalf("PushBranch failed: %v", err)
+	}
+	if exists, err := RemoteBranchExists(repoDirpath, branch); err != nil || !exists {
+		t.Fatalf("expected the pushed branch on origin, got %v, %v", exists, err)
+	}
+
+	if err := DeleteRemoteBranch(repoDirpath, branch); err != nil {
+		t.Fatalf("DeleteRemoteBranch failed: %v", err)
+	}
+	if exists, err := RemoteBranchExists(repoDirpath, branch); err != nil || exists {
+		t.Errorf("expected the branch gone from origin, got %v, %v", exists, err)
+	}
+}
+
+func TestParseOpenPullRequest(t *testing.T) {
+	pr, err := parseOpenPullRequest([]byte("\n"))
+	if err != nil || pr != nil {
+		t.Errorf("expected no PR for empty output, got %+v, %v", pr, err)
+	}
+
+	pr, err = parseOpenPullRequest([]byte(`{"isDraft":true,"url":"https://github.com/owner/repo/pull/42"}`))
+	if err != nil {
+		t.Fatalf("parseOpenPullRequest failed: %v", err)
+	}
+	if pr.URL != "https://github.com/owner/repo/pull/42" || !pr.IsDraft {
+		t.Errorf("unexpected PR %+v", pr)
+	}
+}
+
+func TestLastPullRequestURL(t *testing.T) {
+	output := "\nCreating pull request for agenc/2b4c8f1a-fix into main in owner/repo\n\nhttps://github.com/owner/repo/pull/42\n"
+	if got := lastPullRequestURL(output); got != "https://github.com/owner/repo/pull/42" {
+		t.Errorf("unexpected PR URL %q", got)
+	}
+}
@@ -0,0 +1,86 @@
+package mission
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// RepoRef is a branch, tag, or commit resolved in a repo.
+type RepoRef struct {
+	Name     string // The ref as written, e.g. "release/2.4", "v1.4.2", or a SHA
+	Commit   string // Full SHA of the commit Name resolved to
+	IsBranch bool   // Whether Name is a branch on origin
+}
+
+// ResolveRef resolves name in the repo at repoDirpath, trying a branch on
+// origin, then a tag, then anything else git can resolve to a commit (a
+// local branch or a commit SHA). Returns an error when name matches nothing.
+func ResolveRef(repoDirpath string, name string) (RepoRef, error) {
+	candidates := []struct {
+		rev      string
+		isBranch bool
+	}{
+		{"refs/remotes/origin/" + name, true},
+		{"refs/tags/" + name, false},
+		{name, false},
+	}
+	for _, candidate := range candidates {
+		commit, ok := revParseCommit(repoDirpath, candidate.rev)
+		if ok {
+			return RepoRef{Name: name, Commit: commit, IsBranch: candidate.isBranch}, nil
+		}
+	}
+	return RepoRef{}, stacktrace.NewError("ref '%s' matches no branch, tag, or commit in '%s'", name, repoDirpath)
+}
+
+// revParseCommit returns the commit rev points to, or false if it doesn't
+// name a commit in repoDirpath.
+func revParseCommit(repoDirpath string, rev string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	cmd.Dir = repoDirpath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(output)), true
+}
+
+// CheckoutRef moves the checkout at agentDirpath to ref's commit. When the
+// mission has a branch of its own (missionBranch, from autoBranch or worktree
+// mode) that branch is reset to the commit so the mission's work stays on it.
+// Otherwise a branch ref is checked out as a local branch tracking origin,
+// and a tag or commit leaves HEAD detached.
+func CheckoutRef(agentDirpath string, ref RepoRef, missionBranch string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	var args []string
+	switch {
+	case missionBranch != "":
+		args = []string{"checkout", "-B", missionBranch, ref.Commit}
+	case ref.IsBranch:
+		args = []string{"checkout", "-B", ref.Name, ref.Commit}
+	default:
+		args = []string{"checkout", "--detach", ref.Commit}
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = agentDirpath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "git checkout of '%s' failed: %s", ref.Name, strings.TrimSpace(string(output)))
+	}
+
+	if missionBranch == "" && ref.IsBranch {
+		upstreamCmd := exec.CommandContext(ctx, "git", "branch", "--set-upstream-to", "origin/"+ref.Name)
+		upstreamCmd.Dir = agentDirpath
+		if output, err := upstreamCmd.CombinedOutput(); err != nil {
+			return stacktrace.Propagate(err, "failed to track origin/%s: %s", ref.Name, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// initRefTestLibrary clones a test repo that has a "release" branch one
+// commit ahead of the default branch and a "v1" tag on the first commit, the
+// way a library clone sees them. Returns the clone and the two commits.
+func initRefTestLibrary(t *testing.T) (string, func(dir string, args ...string) string, string, string) {
+	t.Helper()
+	upstreamDirpath, runGit := initWorktreeTestRepo(t)
+	firstCommit := runGit(upstreamDirpath, "rev-parse", "HEAD")
+	runGit(upstreamDirpath, "tag", "v1")
+	runGit(upstreamDirpath, "checkout", "-b", "release")
+	if err := os.WriteFile(filepath.Join(upstreamDirpath, "release.txt"), []byte("release"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(upstreamDirpath, "add", "release.txt")
+	runGit(upstreamDirpath, "commit", "-m", "release commit")
+	releaseCommit := runGit(upstreamDirpath, "rev-parse", "HEAD")
+	runGit(upstreamDirpath, "checkout", "-")
+
+	libraryDirpath := filepath.Join(t.TempDir(), "library")
+	runGit(filepath.Dir(libraryDirpath), "clone", upstreamDirpath, libraryDirpath)
+	return libraryDirpath, runGit, firstCommit, releaseCommit
+}
+
+func TestResolveRef(t *testing.T) {
+	libraryDirpath, _, firstCommit, releaseCommit := initRefTestLibrary(t)
+
+	tests := []struct {
+		name string
+		want RepoRef
+	}{
+		{"release", RepoRef{Name: "release", Commit: releaseCommit, IsBranch: true}},
+		{"v1", RepoRef{Name: "v1", Commit: firstCommit}},
+		{firstCommit[:10], RepoRef{Name: firstCommit[:10], Commit: firstCommit}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveRef(libraryDirpath, tt.name)
+			if err != nil {
+				t.Fatalf("ResolveRef failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := ResolveRef(libraryDirpath, "does-not-exist"); err == nil {
+		t.Error("expected an error for a missing ref")
+	}
+}
+
+func TestCheckoutRef(t *testing.T) {
+	libraryDirpath, runGit, firstCommit, releaseCommit := initRefTestLibrary(t)
+	missionsDirpath := t.TempDir()
+
+	tests := []struct {
+		name          string
+		missionID     string
+		ref           string
+		missionBranch string
+		wantBranch    string
+		wantCommit    string
+	}{
+		{"mission branch", "aaaaaaaa-1111", "release", "agenc/aaaaaaaa", "agenc/aaaaaaaa", releaseCommit},
+		{"branch ref", "bbbbbbbb-2222", "release", "", "release", releaseCommit},
+		{"tag ref", "cccccccc-3333", "v1", "", "", firstCommit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentDirpath := filepath.Join(missionsDirpath, tt.missionID, "agent")
+			if err := AddWorktree(libraryDirpath, agentDirpath, WorktreeBranchName(tt.missionID), "HEAD"); err != nil {
+				t.Fatalf("AddWorktree failed: %v", err)
+			}
+			if tt.missionBranch != "" {
+				runGit(agentDirpath, "checkout", "-b", tt.missionBranch)
+			}
+
+			ref, err := ResolveRef(libraryDirpath, tt.ref)
+			if err != nil {
+				t.Fatalf("ResolveRef failed: %v", err)
+			}
+			if err := CheckoutRef(agentDirpath, ref, tt.missionBranch); err != nil {
+				t.Fatalf("CheckoutRef failed: %v", err)
+			}
+
+			if got := runGit(agentDirpath, "rev-parse", "HEAD"); got != tt.wantCommit {
+				t.Errorf("expected HEAD at %s, got %s", tt.wantCommit, got)
+			}
+			if got, err := GetCurrentBranch(agentDirpath); err != nil || got != tt.wantBranch {
+				t.Errorf("expected branch %q, got %q (err %v)", tt.wantBranch, got, err)
+			}
+			if tt.ref == "release" && tt.missionBranch == "" {
+				if got := runGit(agentDirpath, "rev-parse", "--abbrev-ref", "@{upstream}"); got != "origin/release" {
+					t.Errorf("expected upstream origin/release, got %s", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package mission
+
+import (
+	"github.com/mieubrisse/stacktrace"
+)
+
+// UpstreamRemoteName is the remote that points a fork's missions at the repo
+// it was forked from.
+const UpstreamRemoteName = "upstream"
+
+// SetRemoteURL points the named remote of the repo at repoDirpath to url,
+// adding the remote if it does not exist yet.
+func SetRemoteURL(repoDirpath string, name string, url string) error {
+	if _, err := runGit(repoDirpath, "remote", "get-url", name); err == nil {
+		if _, err := runGit(repoDirpath, "remote", "set-url", name, url); err != nil {
+			return stacktrace.Propagate(err, "failed to point %s at %s", name, url)
+		}
+		return nil
+	}
+	if _, err := runGit(repoDirpath, "remote", "add", name, url); err != nil {
+		return stacktrace.Propagate(err, "failed to add %s %s", name, url)
+	}
+	return nil
+}
+
+// AddUpstreamRemote sets the upstream remote of the workspace at agentDirpath
+// to the origin of the upstream repo's library clone, and copies that clone's
+// remote-tracking branches in as upstream/* so they are usable without a
+// network round trip.
+func AddUpstreamRemote(agentDirpath string, upstreamRepoDirpath string) error {
+	upstreamURL, err := runGit(upstreamRepoDirpath, "remote", "get-url", "origin")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read the upstream repo's origin URL")
+	}
+	if err := SetRemoteURL(agentDirpath, UpstreamRemoteName, upstreamURL); err != nil {
+		return err
+	}
+	if _, err := runGit(agentDirpath, "fetch", "--no-tags", upstreamRepoDirpath, "+refs/remotes/origin/*:refs/remotes/"+UpstreamRemoteName+"/*"); err != nil {
+		return stacktrace.Propagate(err, "failed to copy the upstream repo's remote-tracking branches")
+	}
+	return nil
+}
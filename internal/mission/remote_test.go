@@ -0,0 +1,35 @@
+package mission
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAddUpstreamRemote(t *testing.T) {
+	upstreamDirpath, runGit := initWorktreeTestRepo(t)
+	forkDirpath, _ := initWorktreeTestRepo(t)
+
+	tmpDirpath := t.TempDir()
+	upstreamLibraryDirpath := filepath.Join(tmpDirpath, "upstream-library")
+	runGit(tmpDirpath, "clone", upstreamDirpath, upstreamLibraryDirpath)
+	agentDirpath := filepath.Join(tmpDirpath, "agent")
+	runGit(tmpDirpath, "clone", forkDirpath, agentDirpath)
+
+	// Running it twice exercises both adding and re-pointing the remote
+	for range 2 {
+		if err := AddUpstreamRemote(agentDirpath, upstreamLibraryDirpath); err != nil {
+			t.Fatalf("AddUpstreamRemote failed: %v", err)
+		}
+	}
+
+	if got := runGit(agentDirpath, "remote", "get-url", UpstreamRemoteName); got != upstreamDirpath {
+		t.Errorf("expected upstream %s, got %s", upstreamDirpath, got)
+	}
+	if got := runGit(agentDirpath, "remote", "get-url", "origin"); got != forkDirpath {
+		t.Errorf("expected origin to stay %s, got %s", forkDirpath, got)
+	}
+	branch := runGit(upstreamDirpath, "rev-parse", "--abbrev-ref", "HEAD")
+	if got, want := runGit(agentDirpath, "rev-parse", "upstream/"+branch), runGit(upstreamDirpath, "rev-parse", "HEAD"); got != want {
+		t.Errorf("expected upstream/%s at %s, got %s", branch, want, got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -62,6 +63,39 @@ func ForceUpdateRepo(repoDirpath string) error {
 	return nil
 }
 
+// networkErrorMarkers are fragments of git and ssh output that mean the
+// remote couldn't be reached at all, as opposed to refusing the request.
+var networkErrorMarkers = []string{
+	"could not resolve host",
+	"could not resolve hostname",
+	"temporary failure in name resolution",
+	"name or service not known",
+	"nodename nor servname provided",
+	"network is unreachable",
+	"no route to host",
+	"connection timed out",
+	"operation timed out",
+	"connection refused",
+	"connection reset by peer",
+	"failed to connect to",
+}
+
+// IsNetworkError reports whether err, from a git operation against a remote,
+// failed because the remote was unreachable (no network, DNS failure, or a
+// timeout) rather than because of auth or a missing repo.
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, marker := range networkErrorMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetHEAD returns the current HEAD commit SHA for a repository.
 // Returns an empty string and an error if the repo has no commits or is invalid.
 func GetHEAD(repoDirpath string) (string, error) {
@@ -93,6 +127,53 @@ func GetDefaultBranch(repoDirpath string) (string, error) {
 	return strings.TrimPrefix(ref, "refs/remotes/origin/"), nil
 }
 
+// GetDefaultBranchHead returns the commit SHA at the head of the
+// repository's default branch as last fetched from origin.
+func GetDefaultBranchHead(repoDirpath string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "refs/remotes/origin/HEAD")
+	cmd.Dir = repoDirpath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to resolve the default branch head in '%s'", repoDirpath)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CountCommitsBehind returns how many commits on the default branch of the
+// library clone at libraryRepoDirpath are missing from HEAD of the checkout
+// at repoDirpath. The library's remote-tracking branch is fetched into the
+// checkout directly, so commits made only in the checkout don't count.
+func CountCommitsBehind(repoDirpath string, libraryRepoDirpath string) (int, error) {
+	defaultBranch, err := GetDefaultBranch(libraryRepoDirpath)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "failed to determine default branch")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	fetchCmd := exec.CommandContext(ctx, "git", "fetch", "--no-tags", libraryRepoDirpath, "refs/remotes/origin/"+defaultBranch)
+	fetchCmd.Dir = repoDirpath
+	if output, err := fetchCmd.CombinedOutput(); err != nil {
+		return 0, stacktrace.Propagate(err, "git fetch from library failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	countCmd := exec.CommandContext(ctx, "git", "rev-list", "--count", "HEAD..FETCH_HEAD")
+	countCmd.Dir = repoDirpath
+	output, err := countCmd.Output()
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "failed to count commits behind in '%s'", repoDirpath)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "unexpected git rev-list output '%s'", strings.TrimSpace(string(output)))
+	}
+	return count, nil
+}
+
 // ValidateGitRepo checks that the given directory is a Git repository
 // whose default branch (per origin/HEAD) exists locally.
 func ValidateGitRepo(repoDirpath string) error {
@@ -173,28 +254,46 @@ var githubHTTPSRegex = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+?)
 // githubSSHProtoRegex matches ssh://git@github.com/owner/repo or ssh://git@github.com/owner/repo.git
 var githubSSHProtoRegex = regexp.MustCompile(`^ssh://git@github\.com/([^/]+)/([^/]+?)(?:\.git)?$`)
 
-// ParseGitHubRemoteURL parses a GitHub remote URL (SSH or HTTPS) into
-// "github.com/owner/repo" format.
-func ParseGitHubRemoteURL(remoteURL string) (string, error) {
+// gitSCPRegex matches scp-style SSH remotes on any host: [user@]host:path,
+// e.g. git@git.internal:team/svc.git
+var gitSCPRegex = regexp.MustCompile(`^(?:[^@/:]+@)?([^@/:]+):([^/].*)$`)
+
+// gitURLRegex matches ssh://, git://, http://, and https:// remotes on any
+// host, with an optional user and port
+var gitURLRegex = regexp.MustCompile(`^(?:ssh|git|https?)://(?:[^@/]+@)?([^@/:]+)(?::\d+)?/(.+)$`)
+
+// ParseGitRemoteURL parses a git remote URL on any host (SSH or HTTPS) into
+// the canonical "host/owner/repo" format, e.g. git@git.internal:team/svc.git
+// becomes "git.internal/team/svc". The path must be exactly owner/repo, since
+// the repo library is laid out as host/owner/repo.
+func ParseGitRemoteURL(remoteURL string) (string, error) {
 	remoteURL = strings.TrimSpace(remoteURL)
 
-	if m := githubSSHRegex.FindStringSubmatch(remoteURL); m != nil {
-		return fmt.Sprintf("github.com/%s/%s", m[1], m[2]), nil
+	var host, path string
+	if m := gitURLRegex.FindStringSubmatch(remoteURL); m != nil {
+		host, path = m[1], m[2]
+	} else if m := gitSCPRegex.FindStringSubmatch(remoteURL); m != nil {
+		host, path = m[1], m[2]
+	} else {
+		return "", stacktrace.NewError("'%s' is not a git remote URL", remoteURL)
 	}
-	if m := githubHTTPSRegex.FindStringSubmatch(remoteURL); m != nil {
-		return fmt.Sprintf("github.com/%s/%s", m[1], m[2]), nil
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	segments := strings.Split(path, "/")
+	if len(segments) != 2 {
+		return "", stacktrace.NewError("remote URL '%s' must point at an 'owner/repo' path; nested groups are not supported", remoteURL)
 	}
-	if m := githubSSHProtoRegex.FindStringSubmatch(remoteURL); m != nil {
-		return fmt.Sprintf("github.com/%s/%s", m[1], m[2]), nil
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", stacktrace.NewError("remote URL '%s' has an invalid 'owner/repo' path", remoteURL)
+		}
 	}
-
-	return "", stacktrace.NewError("remote URL '%s' is not a GitHub URL; only GitHub repositories are supported", remoteURL)
+	return fmt.Sprintf("%s/%s/%s", strings.ToLower(host), segments[0], segments[1]), nil
 }
 
-// ExtractGitHubRepoName reads the origin remote URL from the given git repo
-// and parses it into "github.com/owner/repo" format.
-// Errors if origin is not a GitHub URL.
-func ExtractGitHubRepoName(repoDirpath string) (string, error) {
+// ExtractRepoName reads the origin remote URL from the given git repo and
+// parses it into "host/owner/repo" format.
+func ExtractRepoName(repoDirpath string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
 	defer cancel()
 
@@ -205,7 +304,7 @@ func ExtractGitHubRepoName(repoDirpath string) (string, error) {
 		return "", stacktrace.Propagate(err, "failed to read origin remote URL from '%s'", repoDirpath)
 	}
 
-	return ParseGitHubRemoteURL(strings.TrimSpace(string(output)))
+	return ParseGitRemoteURL(strings.TrimSpace(string(output)))
 }
 
 // EnsureRepoClone clones the repo into $AGENC_DIRPATH/repos/ if not already
@@ -241,9 +340,9 @@ func EnsureRepoClone(agencDirpath string, repoName string, cloneURL string) (str
 }
 
 // ParseRepoReference parses a repository reference in the format "owner/repo",
-// "github.com/owner/repo", or a full GitHub URL (SSH or HTTPS) into the
-// canonical repo name and a clone URL. If the host is omitted, github.com is
-// assumed. Extra path segments after owner/repo in a URL are ignored.
+// "host/owner/repo", or a full git URL (SSH or HTTPS) into the canonical repo
+// name and a clone URL. If the host is omitted, github.com is assumed. Extra
+// path segments after owner/repo in a GitHub URL are ignored.
 //
 // If defaultOwner is non-empty and ref is a bare repo name (no slashes),
 // it expands "repo" to "defaultOwner/repo" before parsing.
@@ -251,7 +350,10 @@ func EnsureRepoClone(agencDirpath string, repoName string, cloneURL string) (str
 // The clone URL protocol is determined as follows:
 //   - If an SSH URL is provided (git@github.com:... or ssh://...), returns SSH clone URL
 //   - If an HTTPS URL is provided, returns HTTPS clone URL
-//   - If just "owner/repo" is provided, uses preferSSH to decide (true = SSH, false = HTTPS)
+//   - If a URL on another host is provided (git@git.internal:team/svc.git), it
+//     is used as the clone URL unchanged
+//   - If just "owner/repo" or "host/owner/repo" is provided, uses preferSSH to
+//     decide (true = SSH, false = HTTPS)
 func ParseRepoReference(ref string, preferSSH bool, defaultOwner string) (repoName string, cloneURL string, err error) {
 	// Expand shorthand if it's a single word and defaultOwner is set
 	if defaultOwner != "" && !strings.Contains(ref, "/") {
@@ -288,40 +390,51 @@ func ParseRepoReference(ref string, preferSSH bool, defaultOwner string) (repoNa
 		}
 	}
 
-	// Handle shorthand formats: "owner/repo" or "github.com/owner/repo"
+	// Any other git URL, e.g. a private host only reachable over SSH. The
+	// remote may need a specific user or port, so it's cloned exactly as given.
+	if strings.Contains(ref, "://") || gitSCPRegex.MatchString(ref) {
+		repoName, err = ParseGitRemoteURL(ref)
+		if err != nil {
+			return "", "", err
+		}
+		return repoName, strings.TrimSpace(ref), nil
+	}
+
+	// Handle shorthand formats: "owner/repo" or "host/owner/repo"
 	parts := strings.Split(ref, "/")
 
+	host := "github.com"
 	var owner, repo string
 	switch len(parts) {
 	case 2:
 		// owner/repo → github.com/owner/repo
 		owner, repo = parts[0], parts[1]
 	case 3:
-		// github.com/owner/repo
-		if parts[0] != "github.com" {
-			return "", "", stacktrace.NewError("unsupported host '%s'; only github.com is supported", parts[0])
+		// github.com/owner/repo or git.internal/team/svc
+		host, owner, repo = strings.ToLower(parts[0]), parts[1], parts[2]
+		if host == "" {
+			return "", "", stacktrace.NewError("invalid repo reference '%s'; host must be non-empty", ref)
 		}
-		owner, repo = parts[1], parts[2]
 	default:
-		return "", "", stacktrace.NewError("invalid repo reference '%s'; expected 'owner/repo', 'github.com/owner/repo', or a GitHub URL", ref)
+		return "", "", stacktrace.NewError("invalid repo reference '%s'; expected 'owner/repo', 'host/owner/repo', or a git URL", ref)
 	}
 
 	if owner == "" || repo == "" {
 		return "", "", stacktrace.NewError("invalid repo reference '%s'; owner and repo must be non-empty", ref)
 	}
 
-	repoName = fmt.Sprintf("github.com/%s/%s", owner, repo)
+	repoName = fmt.Sprintf("%s/%s/%s", host, owner, repo)
 	if preferSSH {
-		cloneURL = fmt.Sprintf("git@github.com:%s/%s.git", owner, repo)
+		cloneURL = fmt.Sprintf("git@%s:%s/%s.git", host, owner, repo)
 	} else {
-		cloneURL = fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
+		cloneURL = fmt.Sprintf("https://%s/%s/%s.git", host, owner, repo)
 	}
 	return repoName, cloneURL, nil
 }
 
 // ResolveRepoCloneDirpath returns the agenc-owned clone path for a
 // git repo value (stored in the git_repo DB column). Handles both
-// old-format (absolute path) and new-format (github.com/owner/repo) values.
+// old-format (absolute path) and new-format (host/owner/repo) values.
 func ResolveRepoCloneDirpath(agencDirpath string, gitRepo string) string {
 	if strings.HasPrefix(gitRepo, "/") {
 		return gitRepo // old format: raw filesystem path
@@ -1,6 +1,7 @@
 package mission
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -91,6 +92,62 @@ func TestIsRepoStale(t *testing.T) {
 	})
 }
 
+func TestIsNetworkError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"git fetch failed: fatal: unable to access 'https://github.com/owner/repo/': Could not resolve host: github.com", true},
+		{"git fetch failed: ssh: Could not resolve hostname github.com: nodename nor servname provided, or not known", true},
+		{"git fetch failed: ssh: connect to host github.com port 22: Network is unreachable", true},
+		{"git fetch failed: fatal: unable to access 'https://github.com/owner/repo/': Failed to connect to github.com port 443 after 3 ms: Couldn't connect to server", true},
+		{"git fetch failed: git@github.com: Permission denied (publickey).", false},
+		{"git fetch failed: remote: Repository not found.", false},
+	}
+	for _, tt := range tests {
+		if got := IsNetworkError(errors.New(tt.message)); got != tt.want {
+			t.Errorf("IsNetworkError(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+	if IsNetworkError(nil) {
+		t.Error("expected nil not to be a network error")
+	}
+}
+
+func TestCountCommitsBehind(t *testing.T) {
+	upstreamDirpath, runGit := initWorktreeTestRepo(t)
+	root := t.TempDir()
+	libraryDirpath := filepath.Join(root, "library")
+	missionDirpath := filepath.Join(root, "agent")
+	runGit(root, "clone", upstreamDirpath, libraryDirpath)
+	runGit(root, "clone", libraryDirpath, missionDirpath)
+	runGit(missionDirpath, "config", "user.email", "test@test.com")
+	runGit(missionDirpath, "config", "user.name", "Test")
+
+	for i := 0; i < 3; i++ {
+		runGit(upstreamDirpath, "commit", "--allow-empty", "-m", "upstream")
+	}
+	runGit(missionDirpath, "commit", "--allow-empty", "-m", "mission only")
+
+	// The library hasn't fetched yet, so the mission isn't behind it
+	behind, err := CountCommitsBehind(missionDirpath, libraryDirpath)
+	if err != nil {
+		t.Fatalf("CountCommitsBehind failed: %v", err)
+	}
+	if behind != 0 {
+		t.Errorf("expected 0 commits behind before the library fetched, got %d", behind)
+	}
+
+	runGit(libraryDirpath, "fetch", "origin")
+	behind, err = CountCommitsBehind(missionDirpath, libraryDirpath)
+	if err != nil {
+		t.Fatalf("CountCommitsBehind failed: %v", err)
+	}
+	if behind != 3 {
+		t.Errorf("expected 3 commits behind, got %d", behind)
+	}
+}
+
 func TestParseRepoReference(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -183,10 +240,47 @@ func TestParseRepoReference(t *testing.T) {
 			wantCloneURL: "git@github.com:owner/repo.git",
 		},
 
+		// Other hosts: URLs are cloned as given, shorthand respects preferSSH
+		{
+			name:         "scp-style SSH URL on a private host",
+			ref:          "git@git.internal:team/svc.git",
+			preferSSH:    false,
+			wantRepoName: "git.internal/team/svc",
+			wantCloneURL: "git@git.internal:team/svc.git",
+		},
+		{
+			name:         "ssh:// URL with a port on a private host",
+			ref:          "ssh://deploy@Git.Internal:2222/team/svc.git",
+			preferSSH:    false,
+			wantRepoName: "git.internal/team/svc",
+			wantCloneURL: "ssh://deploy@Git.Internal:2222/team/svc.git",
+		},
+		{
+			name:         "HTTPS URL on another host",
+			ref:          "https://gitlab.com/owner/repo",
+			preferSSH:    true,
+			wantRepoName: "gitlab.com/owner/repo",
+			wantCloneURL: "https://gitlab.com/owner/repo",
+		},
+		{
+			name:         "host/owner/repo on another host with preferSSH=true",
+			ref:          "gitlab.com/owner/repo",
+			preferSSH:    true,
+			wantRepoName: "gitlab.com/owner/repo",
+			wantCloneURL: "git@gitlab.com:owner/repo.git",
+		},
+		{
+			name:         "host/owner/repo on another host with preferSSH=false",
+			ref:          "gitlab.com/owner/repo",
+			preferSSH:    false,
+			wantRepoName: "gitlab.com/owner/repo",
+			wantCloneURL: "https://gitlab.com/owner/repo.git",
+		},
+
 		// Error cases
 		{
-			name:    "unsupported host",
-			ref:     "gitlab.com/owner/repo",
+			name:    "nested group on another host",
+			ref:     "git@git.internal:group/sub/svc.git",
 			wantErr: true,
 		},
 		{
@@ -238,6 +332,46 @@ func TestParseRepoReference(t *testing.T) {
 	}
 }
 
+func TestParseGitRemoteURL(t *testing.T) {
+	tests := []struct {
+		remoteURL string
+		want      string
+		wantErr   bool
+	}{
+		{remoteURL: "git@github.com:owner/repo.git", want: "github.com/owner/repo"},
+		{remoteURL: "https://github.com/owner/repo", want: "github.com/owner/repo"},
+		{remoteURL: "ssh://git@github.com/owner/repo.git", want: "github.com/owner/repo"},
+		{remoteURL: "git@git.internal:team/svc.git", want: "git.internal/team/svc"},
+		{remoteURL: "deploy@git.internal:team/svc", want: "git.internal/team/svc"},
+		{remoteURL: "work:team/svc.git", want: "work/team/svc"},
+		{remoteURL: "ssh://git@git.internal:2222/team/svc.git", want: "git.internal/team/svc"},
+		{remoteURL: "https://user@GitLab.example.com/team/svc.git/", want: "gitlab.example.com/team/svc"},
+		{remoteURL: "git@git.internal:group/sub/svc.git", wantErr: true},
+		{remoteURL: "git@git.internal:svc.git", wantErr: true},
+		{remoteURL: "git@git.internal:team/..", wantErr: true},
+		{remoteURL: "/local/path/repo", wantErr: true},
+		{remoteURL: "owner/repo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remoteURL, func(t *testing.T) {
+			got, err := ParseGitRemoteURL(tt.remoteURL)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseGitRemoteURL(%q) = %q, expected error", tt.remoteURL, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseGitRemoteURL(%q) unexpected error: %v", tt.remoteURL, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseGitRemoteURL(%q) = %q, want %q", tt.remoteURL, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseRepoReferenceWithDefaultOwner(t *testing.T) {
 	tests := []struct {
 		name         string
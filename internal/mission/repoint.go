@@ -0,0 +1,138 @@
+package mission
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Repoint modes control how a mission's workspace moves to another repo.
+const (
+	// RepointModeClone sets the old workspace aside and starts a fresh one
+	// from the new repo.
+	RepointModeClone = "clone"
+	// RepointModeRebase keeps the workspace and replays its own commits onto
+	// the new repo's default branch.
+	RepointModeRebase = "rebase"
+)
+
+// SetAsideAgentDir moves agentDirpath to previousDirpath, replacing whatever
+// an earlier repoint left there. Linked worktrees are moved with git so the
+// library clone keeps track of them.
+func SetAsideAgentDir(agentDirpath string, previousDirpath string) error {
+	if IsWorktree(previousDirpath) {
+		if err := RemoveWorktree(previousDirpath); err != nil {
+			return stacktrace.Propagate(err, "failed to remove the previous worktree at '%s'", previousDirpath)
+		}
+	}
+	if err := os.RemoveAll(previousDirpath); err != nil {
+		return stacktrace.Propagate(err, "failed to remove '%s'", previousDirpath)
+	}
+
+	return MoveAgentDir(agentDirpath, previousDirpath)
+}
+
+// MoveAgentDir renames a workspace directory, using git for linked worktrees
+// so the library clone keeps track of them.
+func MoveAgentDir(srcDirpath string, dstDirpath string) error {
+	if !IsWorktree(srcDirpath) {
+		if err := os.Rename(srcDirpath, dstDirpath); err != nil {
+			return stacktrace.Propagate(err, "failed to move '%s' to '%s'", srcDirpath, dstDirpath)
+		}
+		return nil
+	}
+
+	commonDirpath, err := GetGitCommonDirpath(srcDirpath)
+	if err != nil {
+		return err
+	}
+	if _, err := runGit(filepath.Dir(srcDirpath), "--git-dir", commonDirpath, "worktree", "move", srcDirpath, dstDirpath); err != nil {
+		return stacktrace.Propagate(err, "failed to move worktree '%s' to '%s'", srcDirpath, dstDirpath)
+	}
+	return nil
+}
+
+// RebaseOntoRepo replays the commits the workspace made on top of its origin's
+// default branch onto the default branch of the library clone at
+// newRepoDirpath, then points origin at the new repo. A workspace without an
+// origin (e.g. a blank mission where the agent ran git init) has all of its
+// commits replayed. Uncommitted changes are autostashed. If the rebase
+// conflicts it is aborted and the workspace is left as it was.
+func RebaseOntoRepo(agentDirpath string, newRepoDirpath string) error {
+	if IsWorktree(agentDirpath) {
+		return stacktrace.NewError("worktree workspaces can't be rebased onto another repo; use the %s mode", RepointModeClone)
+	}
+	if _, err := GetHEAD(agentDirpath); err != nil {
+		return stacktrace.NewError("the workspace has no commits to rebase; use the %s mode", RepointModeClone)
+	}
+
+	newDefaultBranch, err := GetDefaultBranch(newRepoDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to determine the new repo's default branch")
+	}
+	newOriginURL, err := runGit(newRepoDirpath, "remote", "get-url", "origin")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read the new repo's origin URL")
+	}
+
+	// Work out what belongs to the mission before origin changes underneath it
+	rebaseArgs := []string{"rebase", "--autostash", "--onto", "FETCH_HEAD"}
+	if oldDefaultBranch, err := GetDefaultBranch(agentDirpath); err == nil {
+		base, err := runGit(agentDirpath, "merge-base", "HEAD", "refs/remotes/origin/"+oldDefaultBranch)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to find where the workspace diverged from origin/%s", oldDefaultBranch)
+		}
+		rebaseArgs = append(rebaseArgs, base)
+	} else {
+		rebaseArgs = append(rebaseArgs, "--root")
+	}
+
+	if _, err := runGit(agentDirpath, "fetch", newRepoDirpath, "refs/heads/"+newDefaultBranch); err != nil {
+		return stacktrace.Propagate(err, "failed to fetch the new repo's %s branch", newDefaultBranch)
+	}
+	if _, err := runGit(agentDirpath, rebaseArgs...); err != nil {
+		_, _ = runGit(agentDirpath, "rebase", "--abort")
+		return stacktrace.Propagate(err, "rebasing onto the new repo's %s branch failed; the workspace is unchanged", newDefaultBranch)
+	}
+
+	if err := SetRemoteURL(agentDirpath, "origin", newOriginURL); err != nil {
+		return err
+	}
+
+	// Replace the old repo's remote-tracking refs with the new repo's, taken
+	// from the library clone so no network round trip is needed
+	if _, err := runGit(agentDirpath, "fetch", "--prune", "--no-tags", newRepoDirpath, "+refs/remotes/origin/*:refs/remotes/origin/*"); err != nil {
+		return stacktrace.Propagate(err, "failed to copy the new repo's remote-tracking branches")
+	}
+	if _, err := runGit(agentDirpath, "remote", "set-head", "origin", newDefaultBranch); err != nil {
+		return stacktrace.Propagate(err, "failed to set origin/HEAD to %s", newDefaultBranch)
+	}
+	return nil
+}
+
+// runGit runs git in dirpath and returns its trimmed stdout.
+func runGit(dirpath string, args ...string) (string, error) {
+	output, err := runGitRaw(dirpath, args...)
+	return strings.TrimSpace(output), err
+}
+
+// runGitRaw is runGit without trimming, for output whose leading whitespace
+// is significant (e.g. `git status --short`).
+func runGitRaw(dirpath string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dirpath
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
@@ -0,0 +1,81 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRebaseOntoRepo(t *testing.T) {
+	oldUpstreamDirpath, runGit := initWorktreeTestRepo(t)
+	newUpstreamDirpath, _ := initWorktreeTestRepo(t)
+	if err := os.WriteFile(filepath.Join(newUpstreamDirpath, "new.txt"), []byte("new repo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(newUpstreamDirpath, "add", "new.txt")
+	runGit(newUpstreamDirpath, "commit", "-m", "new repo commit")
+
+	tmpDirpath := t.TempDir()
+	newLibraryDirpath := filepath.Join(tmpDirpath, "new-library")
+	runGit(tmpDirpath, "clone", newUpstreamDirpath, newLibraryDirpath)
+
+	// The mission workspace is a clone of the old repo with one commit of its own
+	agentDirpath := filepath.Join(tmpDirpath, "agent")
+	runGit(tmpDirpath, "clone", oldUpstreamDirpath, agentDirpath)
+	if err := os.WriteFile(filepath.Join(agentDirpath, "mission.txt"), []byte("work"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(agentDirpath, "config", "user.email", "test@test.com")
+	runGit(agentDirpath, "config", "user.name", "Test")
+	runGit(agentDirpath, "add", "mission.txt")
+	runGit(agentDirpath, "commit", "-m", "mission work")
+
+	if err := RebaseOntoRepo(agentDirpath, newLibraryDirpath); err != nil {
+		t.Fatalf("RebaseOntoRepo failed: %v", err)
+	}
+
+	if got := runGit(agentDirpath, "log", "-1", "--format=%s"); got != "mission work" {
+		t.Errorf("expected the mission commit on top, got %q", got)
+	}
+	if got, want := runGit(agentDirpath, "rev-parse", "HEAD~1"), runGit(newUpstreamDirpath, "rev-parse", "HEAD"); got != want {
+		t.Errorf("expected the mission commit to sit on the new repo's HEAD %s, got parent %s", want, got)
+	}
+	if _, err := os.Stat(filepath.Join(agentDirpath, "new.txt")); err != nil {
+		t.Errorf("expected the new repo's files in the workspace: %v", err)
+	}
+	if got := runGit(agentDirpath, "remote", "get-url", "origin"); got != newUpstreamDirpath {
+		t.Errorf("expected origin %s, got %s", newUpstreamDirpath, got)
+	}
+	if _, err := GetDefaultBranch(agentDirpath); err != nil {
+		t.Errorf("expected origin/HEAD to be set: %v", err)
+	}
+}
+
+func TestSetAsideAgentDir(t *testing.T) {
+	missionDirpath := t.TempDir()
+	agentDirpath := filepath.Join(missionDirpath, "agent")
+	previousDirpath := filepath.Join(missionDirpath, "agent-previous")
+
+	for _, contents := range []string{"first", "second"} {
+		if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(agentDirpath, "notes.md"), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := SetAsideAgentDir(agentDirpath, previousDirpath); err != nil {
+			t.Fatalf("SetAsideAgentDir failed: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(agentDirpath); !os.IsNotExist(err) {
+		t.Errorf("expected agent dir to be moved away, stat err: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(previousDirpath, "notes.md"))
+	if err != nil {
+		t.Fatalf("expected notes in the previous dir: %v", err)
+	}
+	if string(got) != "second" {
+		t.Errorf("expected the latest workspace to replace the earlier one, got %q", got)
+	}
+}
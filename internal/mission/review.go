@@ -0,0 +1,102 @@
+package mission
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// ReviewBranchPrefix namespaces the holding branches that pushes from a
+// reviewRequired mission land on until 'agenc mission approve' moves them
+// to their real branch.
+const ReviewBranchPrefix = "agenc/review/"
+
+// reviewPushRefspec maps every branch push without an explicit destination
+// (`git push`, `git push origin feature`) onto its holding branch.
+const reviewPushRefspec = "refs/heads/*:refs/heads/" + ReviewBranchPrefix + "*"
+
+// ReviewBranchName returns the holding branch that pushes of branchName land
+// on in review mode.
+func ReviewBranchName(branchName string) string {
+	return ReviewBranchPrefix + branchName
+}
+
+// ConfigureReviewPush turns review mode's push mapping on or off in the repo
+// at repoDirpath. When enabled, every remote gets a push refspec sending
+// branches to their holding branch; when disabled, that refspec is removed
+// again, leaving any push refspecs the user configured alone. Idempotent.
+func ConfigureReviewPush(repoDirpath string, enabled bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	remotesCmd := exec.CommandContext(ctx, "git", "remote")
+	remotesCmd.Dir = repoDirpath
+	output, err := remotesCmd.Output()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to list remotes in '%s'", repoDirpath)
+	}
+
+	valuePattern := "^" + regexp.QuoteMeta(reviewPushRefspec) + "$"
+	for _, remote := range strings.Fields(string(output)) {
+		key := "remote." + remote + ".push"
+
+		// Always clear our refspec first so enabling twice doesn't add it twice
+		unsetCmd := exec.CommandContext(ctx, "git", "config", "--unset-all", key, valuePattern)
+		unsetCmd.Dir = repoDirpath
+		if output, err := unsetCmd.CombinedOutput(); err != nil {
+			// Exit status 5 means there was nothing to unset
+			if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 5 {
+				return stacktrace.Propagate(err, "failed to clear %s: %s", key, strings.TrimSpace(string(output)))
+			}
+		}
+		if !enabled {
+			continue
+		}
+
+		addCmd := exec.CommandContext(ctx, "git", "config", "--add", key, reviewPushRefspec)
+		addCmd.Dir = repoDirpath
+		if output, err := addCmd.CombinedOutput(); err != nil {
+			return stacktrace.Propagate(err, "failed to set %s: %s", key, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// ApproveReviewBranch fast-forwards branchName on origin to its holding
+// branch and deletes the holding branch. The push is never forced, so origin
+// rejects it when the holding branch doesn't build on the real one. Returns
+// the approved commit.
+func ApproveReviewBranch(repoDirpath string, branchName string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitPushTimeout)
+	defer cancel()
+
+	reviewBranch := ReviewBranchName(branchName)
+	fetchCmd := exec.CommandContext(ctx, "git", "fetch", "origin", "refs/heads/"+reviewBranch)
+	fetchCmd.Dir = repoDirpath
+	if output, err := fetchCmd.CombinedOutput(); err != nil {
+		return "", stacktrace.Propagate(err, "failed to fetch holding branch '%s' (has the agent pushed it?): %s", reviewBranch, strings.TrimSpace(string(output)))
+	}
+
+	revCmd := exec.CommandContext(ctx, "git", "rev-parse", "FETCH_HEAD")
+	revCmd.Dir = repoDirpath
+	revOutput, err := revCmd.Output()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to resolve holding branch '%s'", reviewBranch)
+	}
+	commit := strings.TrimSpace(string(revOutput))
+
+	// Explicit destinations bypass the review push mapping
+	pushCmd := exec.CommandContext(ctx, "git", "push", "origin", commit+":refs/heads/"+branchName)
+	pushCmd.Dir = repoDirpath
+	if output, err := pushCmd.CombinedOutput(); err != nil {
+		return "", stacktrace.Propagate(err, "failed to fast-forward '%s' to '%s': %s", branchName, reviewBranch, strings.TrimSpace(string(output)))
+	}
+
+	if err := DeleteRemoteBranch(repoDirpath, reviewBranch); err != nil {
+		return commit, err
+	}
+	return commit, nil
+}
@@ -0,0 +1,72 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReviewPushAndApprove(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+
+	remoteDirpath := filepath.Join(t.TempDir(), "remote.git")
+	runGit(repoDirpath, "init", "--bare", remoteDirpath)
+	runGit(repoDirpath, "remote", "add", "origin", remoteDirpath)
+
+	branch := "feature"
+	if err := SwitchBranch(repoDirpath, branch, true); err != nil {
+		t.Fatalf("SwitchBranch failed: %v", err)
+	}
+	if err := PushBranch(repoDirpath, branch); err != nil {
+		t.Fatalf("PushBranch failed: %v", err)
+	}
+	baseHead := runGit(repoDirpath, "rev-parse", "HEAD")
+
+	// Enabling twice must not duplicate the refspec
+	for i := 0; i < 2; i++ {
+		if err := ConfigureReviewPush(repoDirpath, true); err != nil {
+			t.Fatalf("ConfigureReviewPush failed: %v", err)
+		}
+	}
+	if got := runGit(repoDirpath, "config", "--get-all", "remote.origin.push"); got != reviewPushRefspec {
+		t.Errorf("expected a single review refspec, got %q", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDirpath, "new.txt"), []byte("change"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitAll(repoDirpath, "Add new file"); err != nil {
+		t.Fatalf("CommitAll failed: %v", err)
+	}
+	newHead := runGit(repoDirpath, "rev-parse", "HEAD")
+
+	// A plain push lands on the holding branch, not the real one
+	runGit(repoDirpath, "push", "origin", branch)
+	if got := runGit(remoteDirpath, "rev-parse", ReviewBranchName(branch)); got != newHead {
+		t.Errorf("expected holding branch at %s, got %s", newHead, got)
+	}
+	if got := runGit(remoteDirpath, "rev-parse", branch); got != baseHead {
+		t.Errorf("expected %s untouched at %s, got %s", branch, baseHead, got)
+	}
+
+	commit, err := ApproveReviewBranch(repoDirpath, branch)
+	if err != nil {
+		t.Fatalf("ApproveReviewBranch failed: %v", err)
+	}
+	if commit != newHead {
+		t.Errorf("expected approved commit %s, got %s", newHead, commit)
+	}
+	if got := runGit(remoteDirpath, "rev-parse", branch); got != newHead {
+		t.Errorf("expected %s fast-forwarded to %s, got %s", branch, newHead, got)
+	}
+	if exists, err := RemoteBranchExists(repoDirpath, ReviewBranchName(branch)); err != nil || exists {
+		t.Errorf("expected the holding branch deleted, got %v, %v", exists, err)
+	}
+
+	if err := ConfigureReviewPush(repoDirpath, false); err != nil {
+		t.Fatalf("ConfigureReviewPush(false) failed: %v", err)
+	}
+	if err := ConfigureReviewPush(repoDirpath, false); err != nil {
+		t.Fatalf("ConfigureReviewPush(false) with nothing to clear failed: %v", err)
+	}
+}
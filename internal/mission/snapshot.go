@@ -0,0 +1,142 @@
+package mission
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Snapshot entry types reported in SnapshotEntry.Type.
+const (
+	SnapshotEntryFile    = "file"
+	SnapshotEntryDir     = "dir"
+	SnapshotEntrySymlink = "symlink"
+)
+
+// Snapshot is a read-only view of a mission's agent directory, for browsing
+// a mission's files without spawning it. Paths are slash-separated and
+// relative to the agent directory, as with any fs.FS. The live directory is
+// opened with OpenDirSnapshot; an archive holding the directory can serve
+// the same interface.
+type Snapshot interface {
+	fs.FS
+	io.Closer
+}
+
+// SnapshotEntry describes one file or directory in a Snapshot.
+type SnapshotEntry struct {
+	Path    string    `json:"path"`
+	Type    string    `json:"type"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// dirSnapshot serves a directory on disk through an os.Root, so symlinks
+// can't reach outside it.
+type dirSnapshot struct {
+	fs.FS
+	root *os.Root
+}
+
+func (s *dirSnapshot) Close() error {
+	return s.root.Close()
+}
+
+// OpenDirSnapshot opens a read-only Snapshot of the directory at dirpath.
+func OpenDirSnapshot(dirpath string) (Snapshot, error) {
+	root, err := os.OpenRoot(dirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to open '%s'", dirpath)
+	}
+	return &dirSnapshot{FS: root.FS(), root: root}, nil
+}
+
+// CleanSnapshotPath turns a user-supplied path into a Snapshot path: "" and
+// "/" mean the agent directory itself ("."), a leading slash is dropped, and
+// paths that climb out of the directory are rejected.
+func CleanSnapshotPath(relpath string) (string, error) {
+	cleaned := path.Clean("/" + strings.TrimSpace(relpath))
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" {
+		return ".", nil
+	}
+	if !fs.ValidPath(cleaned) {
+		return "", stacktrace.NewError("invalid path '%s'", relpath)
+	}
+	return cleaned, nil
+}
+
+// StatSnapshotEntry describes the entry at relpath without following a
+// final symlink.
+func StatSnapshotEntry(snapshot Snapshot, relpath string) (SnapshotEntry, error) {
+	info, err := fs.Lstat(snapshot, relpath)
+	if err != nil {
+		return SnapshotEntry{}, stacktrace.Propagate(err, "failed to stat '%s'", relpath)
+	}
+	return newSnapshotEntry(relpath, info), nil
+}
+
+// ListSnapshotDir lists the directory at relpath, sorted by path. With
+// recursive set, subdirectories are descended into as well, except .git
+// directories, which are listed but not expanded.
+func ListSnapshotDir(snapshot Snapshot, relpath string, recursive bool) ([]SnapshotEntry, error) {
+	if !recursive {
+		dirEntries, err := fs.ReadDir(snapshot, relpath)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to list '%s'", relpath)
+		}
+		entries := make([]SnapshotEntry, 0, len(dirEntries))
+		for _, dirEntry := range dirEntries {
+			info, err := dirEntry.Info()
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "failed to stat '%s'", dirEntry.Name())
+			}
+			entries = append(entries, newSnapshotEntry(path.Join(relpath, dirEntry.Name()), info))
+		}
+		return entries, nil
+	}
+
+	var entries []SnapshotEntry
+	err := fs.WalkDir(snapshot, relpath, func(entryPath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entryPath == relpath {
+			return nil
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, newSnapshotEntry(entryPath, info))
+		if dirEntry.IsDir() && dirEntry.Name() == ".git" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to list '%s'", relpath)
+	}
+	return entries, nil
+}
+
+func newSnapshotEntry(relpath string, info fs.FileInfo) SnapshotEntry {
+	entryType := SnapshotEntryFile
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		entryType = SnapshotEntrySymlink
+	case info.IsDir():
+		entryType = SnapshotEntryDir
+	}
+	return SnapshotEntry{
+		Path:    relpath,
+		Type:    entryType,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+}
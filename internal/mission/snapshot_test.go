@@ -0,0 +1,76 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanSnapshotPath(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "."},
+		{"/", "."},
+		{"docs/plan.md", "docs/plan.md"},
+		{"/docs/", "docs"},
+		{"docs/../notes.txt", "notes.txt"},
+		{"../../etc/passwd", "etc/passwd"},
+	}
+	for _, tt := range tests {
+		got, err := CleanSnapshotPath(tt.input)
+		if err != nil {
+			t.Errorf("CleanSnapshotPath(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CleanSnapshotPath(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestListSnapshotDir(t *testing.T) {
+	dirpath := t.TempDir()
+	for _, relpath := range []string{".git/HEAD", "docs/plan.md", "main.go"} {
+		fileFilepath := filepath.Join(dirpath, relpath)
+		if err := os.MkdirAll(filepath.Dir(fileFilepath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileFilepath, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshot, err := OpenDirSnapshot(dirpath)
+	if err != nil {
+		t.Fatalf("OpenDirSnapshot failed: %v", err)
+	}
+	defer snapshot.Close()
+
+	entries, err := ListSnapshotDir(snapshot, ".", true)
+	if err != nil {
+		t.Fatalf("ListSnapshotDir failed: %v", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	want := []string{".git", "docs", "docs/plan.md", "main.go"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %v (.git unexpanded), got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("entry %d: expected %s, got %s", i, want[i], paths[i])
+		}
+	}
+
+	docs, err := ListSnapshotDir(snapshot, "docs", false)
+	if err != nil {
+		t.Fatalf("ListSnapshotDir failed: %v", err)
+	}
+	if len(docs) != 1 || docs[0].Path != "docs/plan.md" || docs[0].Type != SnapshotEntryFile || docs[0].Size != 1 {
+		t.Errorf("expected docs/plan.md as a 1-byte file, got %+v", docs)
+	}
+}
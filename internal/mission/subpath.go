@@ -0,0 +1,48 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// CleanSubpath normalizes a mission subpath (the directory within the agent
+// directory that Claude runs in). Returns "" for the repo root. Absolute
+// paths and paths that escape the repo are rejected.
+func CleanSubpath(subpath string) (string, error) {
+	if subpath == "" {
+		return "", nil
+	}
+	if filepath.IsAbs(subpath) {
+		return "", stacktrace.NewError("path '%s' must be relative to the repo root", subpath)
+	}
+	cleaned := filepath.Clean(subpath)
+	if cleaned == "." {
+		return "", nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", stacktrace.NewError("path '%s' points outside the repo", subpath)
+	}
+	if cleaned == ".git" || strings.HasPrefix(cleaned, ".git"+string(filepath.Separator)) {
+		return "", stacktrace.NewError("path '%s' points into the repo's .git directory", subpath)
+	}
+	return cleaned, nil
+}
+
+// CheckSubpathExists verifies that subpath is a directory within
+// repoDirpath.
+func CheckSubpathExists(repoDirpath string, subpath string) error {
+	info, err := os.Stat(filepath.Join(repoDirpath, subpath))
+	if os.IsNotExist(err) {
+		return stacktrace.NewError("path '%s' does not exist in the repo", subpath)
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to check path '%s'", subpath)
+	}
+	if !info.IsDir() {
+		return stacktrace.NewError("path '%s' is not a directory", subpath)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package mission
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanSubpath(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: ""},
+		{input: ".", want: ""},
+		{input: "services/api", want: "services/api"},
+		{input: "./services/api/", want: "services/api"},
+		{input: "services/../web", want: "web"},
+		{input: "/services/api", wantErr: true},
+		{input: "..", wantErr: true},
+		{input: "services/../../etc", wantErr: true},
+		{input: ".git/hooks", wantErr: true},
+		{input: ".github", want: ".github"},
+	}
+	for _, tt := range tests {
+		got, err := CleanSubpath(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("CleanSubpath(%q): expected error, got %q", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("CleanSubpath(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CleanSubpath(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCheckSubpathExists(t *testing.T) {
+	repoDirpath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoDirpath, "services", "api"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDirpath, "README.md"), []byte("hi"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckSubpathExists(repoDirpath, "services/api"); err != nil {
+		t.Errorf("expected services/api to exist: %v", err)
+	}
+	if err := CheckSubpathExists(repoDirpath, "services/web"); err == nil {
+		t.Error("expected error for missing directory")
+	}
+	if err := CheckSubpathExists(repoDirpath, "README.md"); err == nil {
+		t.Error("expected error for a file")
+	}
+}
@@ -0,0 +1,108 @@
+package mission
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// WorkspaceReadmeFilename is the generated README at the root of a multi-repo
+// mission's agent directory, describing the repos checked out beneath it.
+const WorkspaceReadmeFilename = "README.md"
+
+// WorkspaceRepo is one repo of a multi-repo mission workspace.
+type WorkspaceRepo struct {
+	// Name is the canonical repo name, e.g. "github.com/owner/api"
+	Name string
+	// SourceDirpath is the repo library clone the checkout is copied from
+	SourceDirpath string
+	// Description is the repo's repoConfig description, if any
+	Description string
+	// BranchName, when non-empty, is created and checked out after copying
+	BranchName string
+}
+
+// WorkspaceRepoDirnames returns the directory under agent/ for each repo,
+// in order: the repo's own name, or "owner-repo" when two repos share a name.
+func WorkspaceRepoDirnames(repoNames []string) []string {
+	counts := make(map[string]int, len(repoNames))
+	for _, name := range repoNames {
+		counts[filepath.Base(name)]++
+	}
+
+	dirnames := make([]string, len(repoNames))
+	for i, name := range repoNames {
+		base := filepath.Base(name)
+		if counts[base] > 1 {
+			base = filepath.Base(filepath.Dir(name)) + "-" + base
+		}
+		dirnames[i] = base
+	}
+	return dirnames
+}
+
+// CreateWorkspaceMissionDir creates the mission directory for a multi-repo
+// mission: each repo is copied side by side into its own directory under
+// agent/ (see WorkspaceRepoDirnames), and a README describing the layout is
+// written at the root of agent/. Repos are always full copies, whatever
+// their workspaceMode, so the workspace can be cloned and deleted as a
+// plain directory. Returns the mission directory path.
+func CreateWorkspaceMissionDir(agencDirpath string, missionID string, repos []WorkspaceRepo) (string, error) {
+	missionDirpath := config.GetMissionDirpath(agencDirpath, missionID)
+	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
+
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		return "", stacktrace.Propagate(err, "failed to create directory '%s'", agentDirpath)
+	}
+
+	repoNames := make([]string, len(repos))
+	for i, r := range repos {
+		repoNames[i] = r.Name
+	}
+	dirnames := WorkspaceRepoDirnames(repoNames)
+
+	for i, r := range repos {
+		repoDirpath := filepath.Join(agentDirpath, dirnames[i])
+		if err := CopyRepo(r.SourceDirpath, repoDirpath); err != nil {
+			return "", stacktrace.Propagate(err, "failed to copy '%s' into the mission workspace", r.Name)
+		}
+		if r.BranchName != "" {
+			if err := SwitchBranch(repoDirpath, r.BranchName, true); err != nil {
+				return "", stacktrace.Propagate(err, "failed to create mission branch in '%s'", r.Name)
+			}
+		}
+	}
+
+	readmeFilepath := filepath.Join(agentDirpath, WorkspaceReadmeFilename)
+	if err := os.WriteFile(readmeFilepath, []byte(renderWorkspaceReadme(repos, dirnames)), 0644); err != nil {
+		return "", stacktrace.Propagate(err, "failed to write workspace README '%s'", readmeFilepath)
+	}
+
+	return missionDirpath, nil
+}
+
+// renderWorkspaceReadme builds the workspace README: a table of the repos
+// and where each one lives, plus how to work across them.
+func renderWorkspaceReadme(repos []WorkspaceRepo, dirnames []string) string {
+	var b strings.Builder
+	b.WriteString("# Multi-repo workspace\n\n")
+	b.WriteString("This mission works across several repositories, each checked out in its own\n")
+	b.WriteString("directory below. This directory itself is not a git repository.\n\n")
+	b.WriteString("| Directory | Repo | Description |\n")
+	b.WriteString("|-----------|------|-------------|\n")
+	for i, r := range repos {
+		description := strings.ReplaceAll(r.Description, "|", `\|`)
+		fmt.Fprintf(&b, "| `%s/` | %s | %s |\n", dirnames[i], r.Name, description)
+	}
+	b.WriteString("\nEach directory is an independent clone with its own branches and remotes.\n")
+	b.WriteString("Run git commands from inside the repo you are changing, and commit, push, and\n")
+	b.WriteString("open pull requests in each repo separately. When a change spans repos (e.g. an\n")
+	b.WriteString("API and its client), make sure every repo builds against the others' changes\n")
+	b.WriteString("before pushing.\n")
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package mission
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestWorkspaceRepoDirnames(t *testing.T) {
+	got := WorkspaceRepoDirnames([]string{
+		"github.com/acme/api",
+		"github.com/acme/client",
+		"git.internal/platform/client",
+	})
+	want := []string{"api", "acme-client", "platform-client"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WorkspaceRepoDirnames = %v, want %v", got, want)
+	}
+}
+
+func TestCreateWorkspaceMissionDir(t *testing.T) {
+	if _, err := exec.LookPath("rsync"); err != nil {
+		t.Skip("rsync not available")
+	}
+	apiDirpath, runGit := initWorktreeTestRepo(t)
+	clientDirpath, _ := initWorktreeTestRepo(t)
+	agencDirpath := t.TempDir()
+	missionID := "aaaaaaaa-1111"
+
+	_, err := CreateWorkspaceMissionDir(agencDirpath, missionID, []WorkspaceRepo{
+		{Name: "github.com/acme/api", SourceDirpath: apiDirpath, Description: "REST API"},
+		{Name: "github.com/acme/client", SourceDirpath: clientDirpath, BranchName: "feature/x"},
+	})
+	if err != nil {
+		t.Fatalf("CreateWorkspaceMissionDir failed: %v", err)
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
+	for _, dirname := range []string{"api", "client"} {
+		if _, err := os.Stat(filepath.Join(agentDirpath, dirname, "file.txt")); err != nil {
+			t.Errorf("expected %s/ to hold a checkout: %v", dirname, err)
+		}
+	}
+	if branch := runGit(filepath.Join(agentDirpath, "client"), "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature/x" {
+		t.Errorf("expected client on feature/x, got %q", branch)
+	}
+
+	readme, err := os.ReadFile(filepath.Join(agentDirpath, WorkspaceReadmeFilename))
+	if err != nil {
+		t.Fatalf("failed to read workspace README: %v", err)
+	}
+	for _, want := range []string{"| `api/` | github.com/acme/api | REST API |", "| `client/` | github.com/acme/client |"} {
+		if !strings.Contains(string(readme), want) {
+			t.Errorf("README missing %q:\n%s", want, readme)
+		}
+	}
+}
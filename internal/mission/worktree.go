@@ -0,0 +1,121 @@
+package mission
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// worktreeBranchPrefix namespaces the per-mission branches created in the
+// repo library clone for worktree-mode missions.
+const worktreeBranchPrefix = "agenc/mission-"
+
+// WorktreeBranchName returns the branch a worktree-mode mission works on.
+func WorktreeBranchName(missionID string) string {
+	return worktreeBranchPrefix + database.ShortID(missionID)
+}
+
+// IsWorktree reports whether dirpath is a linked git worktree. Linked
+// worktrees have a .git file pointing at the main repository rather than a
+// .git directory.
+func IsWorktree(dirpath string) bool {
+	info, err := os.Stat(filepath.Join(dirpath, ".git"))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// AddWorktree creates a linked worktree of repoDirpath at worktreeDirpath,
+// checked out on a new branch branchName starting at startPoint. The worktree
+// shares the library clone's object store, so no history is copied.
+func AddWorktree(repoDirpath string, worktreeDirpath string, branchName string, startPoint string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "worktree", "add", "-b", branchName, worktreeDirpath, startPoint)
+	cmd.Dir = repoDirpath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "git worktree add failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// GetGitCommonDirpath returns the absolute path of the git directory shared by
+// all worktrees of the repository containing repoDirpath. For a linked
+// worktree this is the main repository's .git directory.
+func GetGitCommonDirpath(repoDirpath string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--path-format=absolute", "--git-common-dir")
+	cmd.Dir = repoDirpath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to resolve git common dir for '%s'", repoDirpath)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CloneWorktree creates a new worktree-mode mission workspace at
+// dstWorktreeDirpath from an existing worktree at srcWorktreeDirpath. The new
+// worktree starts at the source's HEAD on branch branchName, and uncommitted
+// files are then copied over so the clone matches the source's working tree.
+func CloneWorktree(srcWorktreeDirpath string, dstWorktreeDirpath string, branchName string) error {
+	commonDirpath, err := GetGitCommonDirpath(srcWorktreeDirpath)
+	if err != nil {
+		return err
+	}
+	headCommit, err := GetHEAD(srcWorktreeDirpath)
+	if err != nil {
+		return err
+	}
+
+	if err := AddWorktree(filepath.Dir(commonDirpath), dstWorktreeDirpath, branchName, headCommit); err != nil {
+		return err
+	}
+
+	// Copy the working tree but not the .git pointer file, which must keep
+	// referring to the new worktree's own metadata.
+	cmd := exec.Command("rsync", "-a", "--exclude=/.git", srcWorktreeDirpath+"/", dstWorktreeDirpath+"/")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "failed to copy worktree contents: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RemoveWorktree deletes the linked worktree at worktreeDirpath, unregisters
+// it from the main repository, and deletes its branch if it is a mission
+// branch created by AddWorktree. Branches the agent created or switched to are
+// left in place.
+func RemoveWorktree(worktreeDirpath string) error {
+	commonDirpath, err := GetGitCommonDirpath(worktreeDirpath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitOperationTimeout)
+	defer cancel()
+
+	// Resolve the branch before the worktree disappears; empty when detached
+	branchCmd := exec.CommandContext(ctx, "git", "symbolic-ref", "--short", "-q", "HEAD")
+	branchCmd.Dir = worktreeDirpath
+	branchOutput, _ := branchCmd.Output()
+	branchName := strings.TrimSpace(string(branchOutput))
+
+	removeCmd := exec.CommandContext(ctx, "git", "--git-dir", commonDirpath, "worktree", "remove", "--force", worktreeDirpath)
+	if output, err := removeCmd.CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "git worktree remove failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	if strings.HasPrefix(branchName, worktreeBranchPrefix) {
+		deleteCmd := exec.CommandContext(ctx, "git", "--git-dir", commonDirpath, "branch", "-D", branchName)
+		if output, err := deleteCmd.CombinedOutput(); err != nil {
+			return stacktrace.Propagate(err, "failed to delete mission branch '%s': %s", branchName, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
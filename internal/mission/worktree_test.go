@@ -0,0 +1,121 @@
+package mission
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initWorktreeTestRepo creates a git repo with one commit and returns its path
+// along with a helper for running git in arbitrary directories.
+func initWorktreeTestRepo(t *testing.T) (string, func(dir string, args ...string) string) {
+	t.Helper()
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	repoDirpath := filepath.Join(t.TempDir(), "library")
+	if err := os.MkdirAll(repoDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(repoDirpath, "init")
+	runGit(repoDirpath, "config", "user.email", "test@test.com")
+	runGit(repoDirpath, "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(repoDirpath, "file.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(repoDirpath, "add", "file.txt")
+	runGit(repoDirpath, "commit", "-m", "initial commit")
+	return repoDirpath, runGit
+}
+
+func TestWorktreeLifecycle(t *testing.T) {
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+	missionsDirpath := t.TempDir()
+
+	srcDirpath := filepath.Join(missionsDirpath, "src", "agent")
+	srcBranch := WorktreeBranchName("aaaaaaaa-1111")
+	if err := AddWorktree(repoDirpath, srcDirpath, srcBranch, "HEAD"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+
+	if !IsWorktree(srcDirpath) {
+		t.Error("expected IsWorktree to be true for linked worktree")
+	}
+	if IsWorktree(repoDirpath) {
+		t.Error("expected IsWorktree to be false for main repository")
+	}
+	if got := runGit(srcDirpath, "symbolic-ref", "--short", "HEAD"); got != srcBranch {
+		t.Errorf("expected worktree on branch %s, got %s", srcBranch, got)
+	}
+
+	commonDirpath, err := GetGitCommonDirpath(srcDirpath)
+	if err != nil {
+		t.Fatalf("GetGitCommonDirpath failed: %v", err)
+	}
+	wantCommonDirpath, _ := filepath.EvalSymlinks(filepath.Join(repoDirpath, ".git"))
+	gotCommonDirpath, _ := filepath.EvalSymlinks(commonDirpath)
+	if gotCommonDirpath != wantCommonDirpath {
+		t.Errorf("expected common dir %s, got %s", wantCommonDirpath, gotCommonDirpath)
+	}
+
+	otherDirpath := filepath.Join(missionsDirpath, "other", "agent")
+	otherBranch := WorktreeBranchName("bbbbbbbb-2222")
+	if err := AddWorktree(repoDirpath, otherDirpath, otherBranch, "HEAD"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+
+	// Removal unregisters the worktree and deletes its mission branch
+	if err := RemoveWorktree(srcDirpath); err != nil {
+		t.Fatalf("RemoveWorktree failed: %v", err)
+	}
+	if _, err := os.Stat(srcDirpath); !os.IsNotExist(err) {
+		t.Errorf("expected worktree directory to be removed, stat err: %v", err)
+	}
+	if branches := runGit(repoDirpath, "branch", "--list", srcBranch); branches != "" {
+		t.Errorf("expected branch %s to be deleted, got %q", srcBranch, branches)
+	}
+	if branches := runGit(repoDirpath, "branch", "--list", otherBranch); branches == "" {
+		t.Errorf("expected branch %s of the other worktree to survive", otherBranch)
+	}
+}
+
+func TestCloneWorktree(t *testing.T) {
+	if _, err := exec.LookPath("rsync"); err != nil {
+		t.Skip("rsync not available")
+	}
+	repoDirpath, runGit := initWorktreeTestRepo(t)
+	missionsDirpath := t.TempDir()
+
+	srcDirpath := filepath.Join(missionsDirpath, "src", "agent")
+	if err := AddWorktree(repoDirpath, srcDirpath, WorktreeBranchName("aaaaaaaa-1111"), "HEAD"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDirpath, "scratch.txt"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dstDirpath := filepath.Join(missionsDirpath, "dst", "agent")
+	dstBranch := WorktreeBranchName("bbbbbbbb-2222")
+	if err := CloneWorktree(srcDirpath, dstDirpath, dstBranch); err != nil {
+		t.Fatalf("CloneWorktree failed: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dstDirpath, "scratch.txt")); err != nil || string(data) != "wip" {
+		t.Errorf("expected uncommitted file to be cloned, got %q (err: %v)", data, err)
+	}
+	if got := runGit(dstDirpath, "symbolic-ref", "--short", "HEAD"); got != dstBranch {
+		t.Errorf("expected cloned worktree on branch %s, got %s", dstBranch, got)
+	}
+	if !IsWorktree(dstDirpath) {
+		t.Error("expected cloned workspace to be its own linked worktree")
+	}
+}
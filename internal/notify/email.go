@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// DefaultSMTPPort is the submission port used when none is configured.
+const DefaultSMTPPort = 587
+
+// EmailNotifier sends messages over SMTP. Username and Password are optional;
+// when set, PLAIN auth is used (which net/smtp only allows over TLS or to
+// localhost).
+type EmailNotifier struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+
+	// sendMail is smtp.SendMail, swapped out in tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailNotifier returns an EmailNotifier sending through host:port as
+// from. A zero port uses DefaultSMTPPort.
+func NewEmailNotifier(host string, port int, username string, password string, from string) *EmailNotifier {
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+	return &EmailNotifier{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Notify sends msg to address. smtp.SendMail takes no context, so ctx is only
+// checked before sending.
+func (n *EmailNotifier) Notify(ctx context.Context, address string, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return stacktrace.Propagate(err, "email to '%s' cancelled", address)
+	}
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+	addr := net.JoinHostPort(n.Host, strconv.Itoa(n.Port))
+	if err := n.sendMail(addr, auth, n.From, []string{address}, buildEmail(n.From, address, msg, time.Now())); err != nil {
+		return stacktrace.Propagate(err, "failed to send email via %s", addr)
+	}
+	return nil
+}
+
+// buildEmail renders msg as a plain-text RFC 5322 message.
+func buildEmail(from string, to string, msg Message, now time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", strings.ReplaceAll(msg.Subject, "\n", " "))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmailNotifier(t *testing.T) {
+	n := NewEmailNotifier("smtp.example.com", 0, "", "", "agenc@example.com")
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	n.sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		return nil
+	}
+
+	msg := Message{Subject: "cron 'nightly' failed", Body: "Mission: 1234abcd\nDetail: claude exited with code 1"}
+	if err := n.Notify(context.Background(), "me@example.com", msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAddr != "smtp.example.com:587" {
+		t.Errorf("expected the default submission port, got %q", gotAddr)
+	}
+	if gotFrom != "agenc@example.com" || len(gotTo) != 1 || gotTo[0] != "me@example.com" {
+		t.Errorf("unexpected envelope: from %q to %v", gotFrom, gotTo)
+	}
+	if !strings.Contains(string(gotMsg), "Subject: cron 'nightly' failed\r\n") {
+		t.Errorf("expected the subject header, got %q", gotMsg)
+	}
+}
+
+func TestBuildEmail(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	got := string(buildEmail("a@example.com", "b@example.com", Message{Subject: "two\nlines", Body: "x\ny"}, now))
+	want := "From: a@example.com\r\nTo: b@example.com\r\nSubject: two lines\r\nDate: Fri, 02 Jan 2026 03:04:05 +0000\r\n" +
+		"MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\nx\r\ny\r\n"
+	if got != want {
+		t.Errorf("unexpected email:\n%q\nwant:\n%q", got, want)
+	}
+}
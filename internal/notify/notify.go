@@ -0,0 +1,143 @@
+// Package notify delivers short messages to targets outside AgenC, such as a
+// Slack channel, an email address, or a webhook URL. Targets are written
+// "kind:address" (slack:#reports, email:me@example.com,
+// webhook:https://example.com/hook); each kind is handled by a Notifier
+// registered on a Dispatcher, so new kinds plug in without touching callers.
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Target kinds.
+const (
+	KindSlack   = "slack"
+	KindEmail   = "email"
+	KindWebhook = "webhook"
+)
+
+// addressValidators checks the address part of a target for each known kind.
+var addressValidators = map[string]func(address string) error{
+	KindSlack:   validateSlackChannel,
+	KindEmail:   validateEmailAddress,
+	KindWebhook: validateWebhookURL,
+}
+
+// Target is a parsed notification target.
+type Target struct {
+	Kind    string
+	Address string
+}
+
+// String returns the target in its "kind:address" form.
+func (t Target) String() string {
+	return t.Kind + ":" + t.Address
+}
+
+// ParseTarget parses a "kind:address" target, checking that the kind is known
+// and the address is well-formed for it.
+func ParseTarget(s string) (Target, error) {
+	kind, address, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || kind == "" || address == "" {
+		return Target{}, stacktrace.NewError("invalid notify target '%s'; expected kind:address (e.g. slack:#reports or email:me@example.com)", s)
+	}
+	validate, ok := addressValidators[kind]
+	if !ok {
+		return Target{}, stacktrace.NewError("unknown notify target kind '%s' in '%s'; valid kinds: %s", kind, s, strings.Join(Kinds(), ", "))
+	}
+	if err := validate(address); err != nil {
+		return Target{}, stacktrace.Propagate(err, "invalid notify target '%s'", s)
+	}
+	return Target{Kind: kind, Address: address}, nil
+}
+
+// Kinds returns the known target kinds, sorted.
+func Kinds() []string {
+	kinds := make([]string, 0, len(addressValidators))
+	for kind := range addressValidators {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Message is a notification: a one-line subject and a plain-text body. Data
+// optionally carries the same content in machine-readable form, which kinds
+// that deliver JSON (webhook) send along.
+type Message struct {
+	Subject string
+	Body    string
+	Data    any
+}
+
+// Notifier delivers messages to addresses of one target kind.
+type Notifier interface {
+	Notify(ctx context.Context, address string, msg Message) error
+}
+
+// Dispatcher routes messages to the Notifier registered for each target's
+// kind.
+type Dispatcher struct {
+	notifiers map[string]Notifier
+}
+
+// NewDispatcher returns a Dispatcher with no notifiers registered.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{notifiers: map[string]Notifier{}}
+}
+
+// Register makes notifier handle targets of the given kind, replacing any
+// notifier registered for it before.
+func (d *Dispatcher) Register(kind string, notifier Notifier) {
+	d.notifiers[kind] = notifier
+}
+
+// Send delivers msg to every target. A failing or unconfigured target does not
+// stop delivery to the others; all failures are returned together.
+func (d *Dispatcher) Send(ctx context.Context, targets []Target, msg Message) error {
+	var errs []error
+	for _, target := range targets {
+		notifier, ok := d.notifiers[target.Kind]
+		if !ok {
+			errs = append(errs, stacktrace.NewError("no notifier configured for '%s'", target))
+			continue
+		}
+		if err := notifier.Notify(ctx, target.Address, msg); err != nil {
+			errs = append(errs, stacktrace.Propagate(err, "failed to notify '%s'", target))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateSlackChannel accepts a "#channel" name or a raw Slack channel ID.
+func validateSlackChannel(address string) error {
+	if address == "#" || strings.ContainsAny(address, " \t") {
+		return stacktrace.NewError("'%s' is not a Slack channel; use #name or a channel ID", address)
+	}
+	return nil
+}
+
+// validateEmailAddress accepts a bare email address.
+func validateEmailAddress(address string) error {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address {
+		return stacktrace.NewError("'%s' is not an email address", address)
+	}
+	return nil
+}
+
+// validateWebhookURL accepts an absolute http or https URL.
+func validateWebhookURL(address string) error {
+	parsed, err := url.Parse(address)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return stacktrace.NewError("'%s' is not an http or https URL", address)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Target
+		wantErr string
+	}{
+		{input: "slack:#reports", want: Target{Kind: KindSlack, Address: "#reports"}},
+		{input: "slack:C0123ABCD", want: Target{Kind: KindSlack, Address: "C0123ABCD"}},
+		{input: " email:me@example.com ", want: Target{Kind: KindEmail, Address: "me@example.com"}},
+		{input: "reports", wantErr: "expected kind:address"},
+		{input: "slack:", wantErr: "expected kind:address"},
+		{input: "sms:+15551234", wantErr: "unknown notify target kind 'sms'"},
+		{input: "slack:#my channel", wantErr: "not a Slack channel"},
+		{input: "email:Me <me@example.com>", wantErr: "not an email address"},
+		{input: "email:nope", wantErr: "not an email address"},
+		{input: "webhook:https://example.com/hooks/agenc", want: Target{Kind: KindWebhook, Address: "https://example.com/hooks/agenc"}},
+		{input: "webhook:example.com/hook", wantErr: "not an http or https URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTarget(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+type recordingNotifier struct {
+	addresses []string
+	err       error
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, address string, _ Message) error {
+	n.addresses = append(n.addresses, address)
+	return n.err
+}
+
+func TestDispatcherSend(t *testing.T) {
+	slack := &recordingNotifier{err: errors.New("channel_not_found")}
+	email := &recordingNotifier{}
+	d := NewDispatcher()
+	d.Register(KindSlack, slack)
+	d.Register(KindEmail, email)
+
+	targets := []Target{
+		{Kind: KindSlack, Address: "#reports"},
+		{Kind: KindEmail, Address: "me@example.com"},
+		{Kind: "pager", Address: "oncall"},
+	}
+	err := d.Send(context.Background(), targets, Message{Subject: "s", Body: "b"})
+	if err == nil {
+		t.Fatal("expected the Slack and unconfigured failures to be returned")
+	}
+	if !strings.Contains(err.Error(), "slack:#reports") || !strings.Contains(err.Error(), "no notifier configured for 'pager:oncall'") {
+		t.Errorf("expected both failures in the error, got %v", err)
+	}
+	if len(email.addresses) != 1 || email.addresses[0] != "me@example.com" {
+		t.Errorf("expected delivery to continue past the failing target, got %v", email.addresses)
+	}
+}
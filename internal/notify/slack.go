@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// slackPostMessageURL is Slack's chat.postMessage Web API endpoint.
+const slackPostMessageURL = "https://slack.com/api/chat.postMessage"
+
+// SlackNotifier posts messages to Slack channels with a bot token. The bot
+// must be a member of each channel it posts to.
+type SlackNotifier struct {
+	Token string
+	// APIURL overrides the chat.postMessage endpoint; empty uses Slack's.
+	APIURL string
+	Client *http.Client
+}
+
+// NewSlackNotifier returns a SlackNotifier posting with the given bot token.
+func NewSlackNotifier(token string) *SlackNotifier {
+	return &SlackNotifier{Token: token, Client: http.DefaultClient}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, address string, msg Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"channel": address,
+		"text":    "*" + msg.Subject + "*\n" + msg.Body,
+	})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to encode Slack message")
+	}
+
+	apiURL := n.APIURL
+	if apiURL == "" {
+		apiURL = slackPostMessageURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to build Slack request")
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+n.Token)
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to reach Slack")
+	}
+	defer resp.Body.Close()
+
+	// chat.postMessage reports most failures as 200 with ok=false.
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return stacktrace.NewError("unexpected Slack response (HTTP %d)", resp.StatusCode)
+	}
+	if !result.OK {
+		return stacktrace.NewError("Slack rejected the message: %s", result.Error)
+	}
+	return nil
+}
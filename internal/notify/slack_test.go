@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlackNotifier(t *testing.T) {
+	var gotAuth string
+	var gotBody map[string]string
+	ok := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		if ok {
+			w.Write([]byte(`{"ok":true}`))
+		} else {
+			w.Write([]byte(`{"ok":false,"error":"not_in_channel"}`))
+		}
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier("xoxb-test")
+	n.APIURL = srv.URL
+	msg := Message{Subject: "cron 'nightly' succeeded", Body: "Mission: 1234abcd"}
+	if err := n.Notify(context.Background(), "#reports", msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer xoxb-test" {
+		t.Errorf("expected the bot token as a bearer token, got %q", gotAuth)
+	}
+	if gotBody["channel"] != "#reports" || gotBody["text"] != "*cron 'nightly' succeeded*\nMission: 1234abcd" {
+		t.Errorf("unexpected payload: %v", gotBody)
+	}
+
+	ok = false
+	err := n.Notify(context.Background(), "#reports", msg)
+	if err == nil || !strings.Contains(err.Error(), "not_in_channel") {
+		t.Fatalf("expected Slack's error to be surfaced, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// webhookErrorBodyLimit caps how much of a failed webhook response is quoted
+// in the error.
+const webhookErrorBodyLimit = 512
+
+// webhookPayload is the JSON body POSTed to a webhook target.
+type webhookPayload struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// WebhookNotifier POSTs messages as JSON to the URL given as each target's
+// address. It needs no configuration.
+type WebhookNotifier struct {
+	Client *http.Client
+}
+
+// NewWebhookNotifier returns a WebhookNotifier using the default HTTP client.
+func NewWebhookNotifier() *WebhookNotifier {
+	return &WebhookNotifier{Client: http.DefaultClient}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, address string, msg Message) error {
+	payload, err := json.Marshal(webhookPayload{Subject: msg.Subject, Body: msg.Body, Data: msg.Data})
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to encode webhook payload")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address, bytes.NewReader(payload))
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to build webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to reach webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+		return stacktrace.NewError("webhook returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
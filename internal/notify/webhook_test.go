@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var gotBody map[string]any
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(status)
+		w.Write([]byte("nope"))
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier()
+	msg := Message{Subject: "AgenC daily digest", Body: "3 missions created", Data: map[string]int{"created": 3}}
+	if err := n.Notify(context.Background(), srv.URL, msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotBody["subject"] != "AgenC daily digest" || gotBody["body"] != "3 missions created" {
+		t.Errorf("unexpected payload: %v", gotBody)
+	}
+	if data, ok := gotBody["data"].(map[string]any); !ok || data["created"] != float64(3) {
+		t.Errorf("expected the message data in the payload, got %v", gotBody["data"])
+	}
+
+	status = http.StatusBadGateway
+	err := n.Notify(context.Background(), srv.URL, msg)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 502") {
+		t.Fatalf("expected the webhook's status to be surfaced, got %v", err)
+	}
+}
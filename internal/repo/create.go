@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+const (
+	// ghRepoCreateTimeout bounds `gh repo create`, which talks to the GitHub
+	// API and may copy a template.
+	ghRepoCreateTimeout = 2 * time.Minute
+
+	// templateContentTimeout is how long to wait for GitHub to finish
+	// generating a repo from a template before cloning it.
+	templateContentTimeout = 30 * time.Second
+
+	// templateContentPollInterval is how often the generated repo is checked
+	// for its first commit.
+	templateContentPollInterval = 2 * time.Second
+)
+
+// CreateGitHubRepoOptions configures CreateGitHubRepo.
+type CreateGitHubRepoOptions struct {
+	// Name is the new repo as owner/repo.
+	Name    string
+	Private bool
+	// Template is an owner/repo template repository to generate from.
+	Template string
+	// License is a license keyword such as "mit" or "apache-2.0".
+	License string
+	// Gitignore is a gitignore template name such as "Go" or "Node".
+	Gitignore   string
+	Description string
+}
+
+// buildGhRepoCreateArgs returns the gh arguments that create the repo
+// described by opts. GitHub can only add a license or .gitignore when
+// initializing an empty repo, so they cannot be combined with a template.
+func buildGhRepoCreateArgs(opts CreateGitHubRepoOptions) ([]string, error) {
+	if opts.Template != "" && (opts.License != "" || opts.Gitignore != "") {
+		return nil, stacktrace.NewError("a license or .gitignore cannot be combined with a template; the template supplies the initial files")
+	}
+
+	visibility := "--public"
+	if opts.Private {
+		visibility = "--private"
+	}
+	args := []string{"repo", "create", opts.Name, visibility}
+	if opts.Template != "" {
+		args = append(args, "--template", opts.Template)
+	}
+	if opts.License != "" {
+		args = append(args, "--license", opts.License)
+	}
+	if opts.Gitignore != "" {
+		args = append(args, "--gitignore", opts.Gitignore)
+	}
+	if opts.Description != "" {
+		args = append(args, "--description", opts.Description)
+	}
+	return args, nil
+}
+
+// CreateGitHubRepo creates a GitHub repository with the gh CLI. When the repo
+// is generated from a template, it also waits for GitHub to finish copying
+// the template so an immediate clone is not empty.
+func CreateGitHubRepo(opts CreateGitHubRepoOptions) error {
+	args, err := buildGhRepoCreateArgs(opts)
+	if err != nil {
+		return err
+	}
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to create repositories")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ghRepoCreateTimeout)
+	defer cancel()
+
+	if output, err := exec.CommandContext(ctx, ghBinary, args...).CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "gh repo create failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	if opts.Template != "" {
+		waitForFirstCommit(ghBinary, opts.Name)
+	}
+	return nil
+}
+
+// waitForFirstCommit polls the GitHub API until repo has a commit or
+// templateContentTimeout elapses. Best-effort: on timeout the caller clones
+// whatever is there.
+func waitForFirstCommit(ghBinary string, repoName string) {
+	deadline := time.Now().Add(templateContentTimeout)
+	for time.Now().Before(deadline) {
+		cmd := exec.Command(ghBinary, "api", "repos/"+repoName+"/commits?per_page=1", "--silent")
+		if err := cmd.Run(); err == nil {
+			return
+		}
+		time.Sleep(templateContentPollInterval)
+	}
+}
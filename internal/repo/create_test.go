@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBuildGhRepoCreateArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateGitHubRepoOptions
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "public by default",
+			opts: CreateGitHubRepoOptions{Name: "owner/app"},
+			want: []string{"repo", "create", "owner/app", "--public"},
+		},
+		{
+			name: "private with license and gitignore",
+			opts: CreateGitHubRepoOptions{Name: "owner/app", Private: true, License: "mit", Gitignore: "Go", Description: "An app"},
+			want: []string{"repo", "create", "owner/app", "--private", "--license", "mit", "--gitignore", "Go", "--description", "An app"},
+		},
+		{
+			name: "template",
+			opts: CreateGitHubRepoOptions{Name: "owner/app", Template: "owner/starter"},
+			want: []string{"repo", "create", "owner/app", "--public", "--template", "owner/starter"},
+		},
+		{
+			name:    "template with license",
+			opts:    CreateGitHubRepoOptions{Name: "owner/app", Template: "owner/starter", License: "mit"},
+			wantErr: true,
+		},
+		{
+			name:    "template with gitignore",
+			opts:    CreateGitHubRepoOptions{Name: "owner/app", Template: "owner/starter", Gitignore: "Go"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildGhRepoCreateArgs(tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got args %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
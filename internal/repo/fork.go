@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// ForkGitHubRepoOptions configures ForkGitHubRepo.
+type ForkGitHubRepoOptions struct {
+	// Upstream is the owner/repo to fork.
+	Upstream string
+	// Organization forks into this organization instead of the logged-in
+	// user's account.
+	Organization string
+	// Name gives the fork a different repo name than the upstream's.
+	Name string
+}
+
+// buildGhRepoForkArgs returns the gh arguments that fork the repo described
+// by opts through the GitHub API, printing the fork's owner/repo.
+func buildGhRepoForkArgs(opts ForkGitHubRepoOptions) []string {
+	args := []string{"api", "--method", "POST", "repos/" + opts.Upstream + "/forks"}
+	if opts.Organization != "" {
+		args = append(args, "-f", "organization="+opts.Organization)
+	}
+	if opts.Name != "" {
+		args = append(args, "-f", "name="+opts.Name)
+	}
+	return append(args, "--jq", ".full_name")
+}
+
+// ForkGitHubRepo forks a GitHub repository through the GitHub API with the gh
+// CLI and returns the fork as owner/repo. GitHub creates forks
+// asynchronously, so it also waits for the fork to have commits before
+// returning. Forking a repo you have already forked returns the existing
+// fork.
+func ForkGitHubRepo(opts ForkGitHubRepoOptions) (string, error) {
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to fork repositories")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ghRepoCreateTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, ghBinary, buildGhRepoForkArgs(opts)...).CombinedOutput()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "forking '%s' failed: %s", opts.Upstream, strings.TrimSpace(string(output)))
+	}
+	forkName := strings.TrimSpace(string(output))
+	if strings.Count(forkName, "/") != 1 {
+		return "", stacktrace.NewError("unexpected response from the GitHub API when forking '%s': %s", opts.Upstream, forkName)
+	}
+
+	waitForFirstCommit(ghBinary, forkName)
+	return forkName, nil
+}
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBuildGhRepoForkArgs(t *testing.T) {
+	got := buildGhRepoForkArgs(ForkGitHubRepoOptions{Upstream: "acme/app"})
+	want := []string{"api", "--method", "POST", "repos/acme/app/forks", "--jq", ".full_name"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	got = buildGhRepoForkArgs(ForkGitHubRepoOptions{Upstream: "acme/app", Organization: "my-org", Name: "app-fork"})
+	want = []string{"api", "--method", "POST", "repos/acme/app/forks", "-f", "organization=my-org", "-f", "name=app-fork", "--jq", ".full_name"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+const (
+	// ghIssueTimeout bounds each gh issue command.
+	ghIssueTimeout = 1 * time.Minute
+
+	// ghIssueListLimit is the most open issues fetched per label poll.
+	ghIssueListLimit = 100
+)
+
+var (
+	// ownerRepoRegex matches a GitHub repo written owner/repo.
+	ownerRepoRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+	// issueRefRegex matches an issue reference written owner/repo#123.
+	issueRefRegex = regexp.MustCompile(`^([A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+)#([0-9]+)$`)
+)
+
+// GitHubIssue is an issue fetched with the gh CLI.
+type GitHubIssue struct {
+	Number   int                  `json:"number"`
+	Title    string               `json:"title"`
+	Body     string               `json:"body"`
+	URL      string               `json:"url"`
+	Comments []GitHubIssueComment `json:"comments"`
+}
+
+// GitHubIssueComment is one comment on a GitHub issue.
+type GitHubIssueComment struct {
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Body string `json:"body"`
+}
+
+// ParseIssueRef splits an owner/repo#123 issue reference into its repo and
+// issue number.
+func ParseIssueRef(ref string) (string, int, error) {
+	matches := issueRefRegex.FindStringSubmatch(strings.TrimSpace(ref))
+	if matches == nil {
+		return "", 0, stacktrace.NewError("invalid issue reference '%s'; expected owner/repo#123", ref)
+	}
+	number, err := strconv.Atoi(matches[2])
+	if err != nil || number <= 0 {
+		return "", 0, stacktrace.NewError("invalid issue number in '%s'", ref)
+	}
+	return matches[1], number, nil
+}
+
+// IsOwnerRepo reports whether s is a GitHub repo written owner/repo.
+func IsOwnerRepo(s string) bool {
+	return ownerRepoRegex.MatchString(s)
+}
+
+// FormatIssueRef renders an issue reference as owner/repo#123.
+func FormatIssueRef(ownerRepo string, number int) string {
+	return fmt.Sprintf("%s#%d", ownerRepo, number)
+}
+
+// FetchGitHubIssue fetches an issue's title, body, and comments.
+func FetchGitHubIssue(ownerRepo string, number int) (*GitHubIssue, error) {
+	output, err := runGhIssue("issue", "view", strconv.Itoa(number), "--repo", ownerRepo, "--json", "number,title,body,url,comments")
+	if err != nil {
+		return nil, err
+	}
+	var issue GitHubIssue
+	if err := json.Unmarshal(output, &issue); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse gh issue view output")
+	}
+	return &issue, nil
+}
+
+// ListLabeledGitHubIssues returns the numbers of ownerRepo's open issues
+// carrying label, oldest first.
+func ListLabeledGitHubIssues(ownerRepo string, label string) ([]int, error) {
+	output, err := runGhIssue("issue", "list", "--repo", ownerRepo, "--label", label, "--state", "open",
+		"--limit", strconv.Itoa(ghIssueListLimit), "--json", "number")
+	if err != nil {
+		return nil, err
+	}
+	return parseGhIssueNumbers(output)
+}
+
+// CommentOnGitHubIssue posts body as a comment on an issue.
+func CommentOnGitHubIssue(ownerRepo string, number int, body string) error {
+	_, err := runGhIssue("issue", "comment", strconv.Itoa(number), "--repo", ownerRepo, "--body", body)
+	return err
+}
+
+// BuildIssuePrompt renders an issue as a mission's initial prompt: its
+// reference and title, body, and comment thread.
+func BuildIssuePrompt(ownerRepo string, issue *GitHubIssue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Work on GitHub issue %s: %s\n", FormatIssueRef(ownerRepo, issue.Number), issue.Title)
+	if issue.URL != "" {
+		fmt.Fprintf(&b, "%s\n", issue.URL)
+	}
+	if body := strings.TrimSpace(issue.Body); body != "" {
+		fmt.Fprintf(&b, "\n%s\n", body)
+	}
+	for _, comment := range issue.Comments {
+		commentBody := strings.TrimSpace(comment.Body)
+		if commentBody == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n--- Comment by @%s ---\n%s\n", comment.Author.Login, commentBody)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// parseGhIssueNumbers decodes `gh issue list --json number` output, sorted
+// ascending.
+func parseGhIssueNumbers(output []byte) ([]int, error) {
+	var issues []struct {
+		Number int `json:"number"`
+	}
+	if err := json.Unmarshal(output, &issues); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse gh issue list output")
+	}
+	numbers := make([]int, 0, len(issues))
+	for _, issue := range issues {
+		numbers = append(numbers, issue.Number)
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// runGhIssue runs a gh command and returns its stdout, surfacing gh's stderr
+// on failure.
+func runGhIssue(args ...string) ([]byte, error) {
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to read GitHub issues")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ghIssueTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, ghBinary, args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, stacktrace.NewError("gh %s %s failed: %s", args[0], args[1], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, stacktrace.Propagate(err, "failed to run gh %s %s", args[0], args[1])
+	}
+	return output, nil
+}
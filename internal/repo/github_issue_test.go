@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseIssueRef(t *testing.T) {
+	ownerRepo, number, err := ParseIssueRef("acme/web-app#123")
+	if err != nil {
+		t.Fatalf("ParseIssueRef failed: %v", err)
+	}
+	if ownerRepo != "acme/web-app" || number != 123 {
+		t.Errorf("expected acme/web-app #123, got %s #%d", ownerRepo, number)
+	}
+	if got := FormatIssueRef(ownerRepo, number); got != "acme/web-app#123" {
+		t.Errorf("expected the reference to round-trip, got %q", got)
+	}
+
+	for _, bad := range []string{"acme/web", "acme#1", "acme/web#", "acme/web#0", "acme/web#x", "https://github.com/acme/web/issues/1"} {
+		if _, _, err := ParseIssueRef(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestIsOwnerRepo(t *testing.T) {
+	if !IsOwnerRepo("acme/web.app") {
+		t.Error("expected acme/web.app to be an owner/repo")
+	}
+	for _, bad := range []string{"acme", "acme/web#1", "github.com/acme/web", "acme/web/extra"} {
+		if IsOwnerRepo(bad) {
+			t.Errorf("expected %q not to be an owner/repo", bad)
+		}
+	}
+}
+
+func TestParseGhIssueNumbers(t *testing.T) {
+	numbers, err := parseGhIssueNumbers([]byte(`[{"number": 42}, {"number": 7}]`))
+	if err != nil {
+		t.Fatalf("parseGhIssueNumbers failed: %v", err)
+	}
+	if !slices.Equal(numbers, []int{7, 42}) {
+		t.Errorf("expected [7 42], got %v", numbers)
+	}
+
+	if _, err := parseGhIssueNumbers([]byte("not json")); err == nil {
+		t.Error("expected an error for malformed output")
+	}
+}
+
+func TestBuildIssuePrompt(t *testing.T) {
+	issue := &GitHubIssue{
+		Number: 5,
+		Title:  "Login redirect loops",
+		Body:   "Steps to reproduce...\n",
+		URL:    "https://github.com/acme/web/issues/5",
+	}
+	issue.Comments = make([]GitHubIssueComment, 2)
+	issue.Comments[0].Author.Login = "alice"
+	issue.Comments[0].Body = "Happens on Safari only."
+	issue.Comments[1].Author.Login = "bob"
+
+	prompt := BuildIssuePrompt("acme/web", issue)
+	for _, want := range []string{
+		"Work on GitHub issue acme/web#5: Login redirect loops",
+		"https://github.com/acme/web/issues/5",
+		"Steps to reproduce...",
+		"--- Comment by @alice ---\nHappens on Safari only.",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected prompt to contain %q, got:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "@bob") {
+		t.Errorf("expected empty comments to be skipped, got:\n%s", prompt)
+	}
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+const (
+	// ghRepoListTimeout bounds `gh repo list`, which pages through the
+	// GitHub API.
+	ghRepoListTimeout = 2 * time.Minute
+
+	// ghRepoListLimit is the most repositories fetched from one account.
+	ghRepoListLimit = 1000
+)
+
+// GitHubRepo is a repository listed from a GitHub account or organization.
+type GitHubRepo struct {
+	// NameWithOwner is the repo as owner/repo.
+	NameWithOwner string `json:"nameWithOwner"`
+	Description   string `json:"description"`
+	IsPrivate     bool   `json:"isPrivate"`
+	IsFork        bool   `json:"isFork"`
+	IsArchived    bool   `json:"isArchived"`
+}
+
+// buildGhRepoListArgs returns the gh arguments that list owner's repos as
+// JSON. An empty owner lists the account logged into gh.
+func buildGhRepoListArgs(owner string, includeArchived bool) []string {
+	args := []string{"repo", "list"}
+	if owner != "" {
+		args = append(args, owner)
+	}
+	args = append(args,
+		"--limit", strconv.Itoa(ghRepoListLimit),
+		"--json", "nameWithOwner,description,isPrivate,isFork,isArchived",
+	)
+	if !includeArchived {
+		args = append(args, "--no-archived")
+	}
+	return args
+}
+
+// parseGhRepoList decodes `gh repo list --json` output, sorted by name.
+func parseGhRepoList(output []byte) ([]GitHubRepo, error) {
+	var repos []GitHubRepo
+	if err := json.Unmarshal(output, &repos); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse gh repo list output")
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		return strings.ToLower(repos[i].NameWithOwner) < strings.ToLower(repos[j].NameWithOwner)
+	})
+	return repos, nil
+}
+
+// ListGitHubRepos lists the repositories of a GitHub user or organization
+// with the gh CLI. An empty owner lists the account logged into gh. Archived
+// repos are skipped unless includeArchived is set.
+func ListGitHubRepos(owner string, includeArchived bool) ([]GitHubRepo, error) {
+	ghBinary, err := exec.LookPath("gh")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "'gh' (GitHub CLI) not found in PATH; required to list repositories")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ghRepoListTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, ghBinary, buildGhRepoListArgs(owner, includeArchived)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, stacktrace.NewError("gh repo list failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, stacktrace.Propagate(err, "failed to run gh repo list")
+	}
+	return parseGhRepoList(output)
+}
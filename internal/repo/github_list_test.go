@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBuildGhRepoListArgs(t *testing.T) {
+	fields := "nameWithOwner,description,isPrivate,isFork,isArchived"
+	got := buildGhRepoListArgs("", false)
+	want := []string{"repo", "list", "--limit", "1000", "--json", fields, "--no-archived"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	got = buildGhRepoListArgs("acme", true)
+	want = []string{"repo", "list", "acme", "--limit", "1000", "--json", fields}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestParseGhRepoList(t *testing.T) {
+	output := []byte(`[
+		{"nameWithOwner": "acme/web", "description": "Website", "isPrivate": false, "isFork": false, "isArchived": false},
+		{"nameWithOwner": "acme/API", "description": "", "isPrivate": true, "isFork": true, "isArchived": false}
+	]`)
+	repos, err := parseGhRepoList(output)
+	if err != nil {
+		t.Fatalf("parseGhRepoList failed: %v", err)
+	}
+	if len(repos) != 2 || repos[0].NameWithOwner != "acme/API" || repos[1].NameWithOwner != "acme/web" {
+		t.Fatalf("expected repos sorted case-insensitively, got %+v", repos)
+	}
+	if !repos[0].IsPrivate || !repos[0].IsFork || repos[1].Description != "Website" {
+		t.Errorf("fields not decoded: %+v", repos)
+	}
+
+	if _, err := parseGhRepoList([]byte("not json")); err == nil {
+		t.Error("expected an error for malformed output")
+	}
+}
@@ -18,7 +18,7 @@ const gitOperationTimeout = 30 * time.Second
 
 // RepoResolutionResult holds the outcome of resolving a repo input.
 type RepoResolutionResult struct {
-	RepoName       string // Canonical repo name (github.com/owner/repo)
+	RepoName       string // Canonical repo name (host/owner/repo)
 	CloneDirpath   string // Path to the clone in $AGENC_DIRPATH/repos/
 	WasNewlyCloned bool   // True if the repo was cloned as part of resolution
 }
@@ -26,9 +26,9 @@ type RepoResolutionResult struct {
 // LooksLikeRepoReference returns true if the input appears to be a repo
 // reference rather than search terms. A repo reference is one of:
 //   - A local filesystem path (starts with /, ., or ~)
-//   - A Git SSH URL (starts with git@ or ssh://)
+//   - A Git SSH URL (git@host:owner/repo, user@host:owner/repo, or ssh://)
 //   - An HTTPS URL (starts with https://)
-//   - A shorthand reference (owner/repo or github.com/owner/repo)
+//   - A shorthand reference (owner/repo or host/owner/repo)
 //   - A single word (repo name) if defaultGitHubUser is set
 //
 // Search terms are characterized by:
@@ -58,6 +58,11 @@ func LooksLikeRepoReference(input string) bool {
 		return true
 	}
 
+	// SSH remote with another user (deploy@git.internal:team/svc.git)
+	if strings.Contains(input, "@") && strings.Contains(input, ":") {
+		return true
+	}
+
 	// HTTPS URL
 	if strings.HasPrefix(input, "https://") {
 		return true
@@ -68,7 +73,7 @@ func LooksLikeRepoReference(input string) bool {
 		return true
 	}
 
-	// Shorthand: owner/repo or github.com/owner/repo
+	// Shorthand: owner/repo or host/owner/repo
 	// Must have exactly 1 or 2 slashes, no spaces
 	parts := strings.Split(input, "/")
 	switch len(parts) {
@@ -81,7 +86,7 @@ func LooksLikeRepoReference(input string) bool {
 		// owner/repo format - both parts must be non-empty
 		return parts[0] != "" && parts[1] != ""
 	case 3:
-		// github.com/owner/repo format - all parts must be non-empty
+		// host/owner/repo format - all parts must be non-empty
 		return parts[0] != "" && parts[1] != "" && parts[2] != ""
 	}
 
@@ -129,10 +134,10 @@ func resolveLocalPathRepo(agencDirpath string, localPath string) (*RepoResolutio
 		return nil, stacktrace.Propagate(err, "'%s' is not a valid git repository", absDirpath)
 	}
 
-	// Extract the GitHub repo name from the origin remote
-	repoName, err := mission.ExtractGitHubRepoName(absDirpath)
+	// Extract the canonical repo name from the origin remote
+	repoName, err := mission.ExtractRepoName(absDirpath)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "failed to extract GitHub repo name from '%s'", absDirpath)
+		return nil, stacktrace.Propagate(err, "failed to extract repo name from '%s'", absDirpath)
 	}
 
 	// Get the clone URL from the local repo (preserves SSH vs HTTPS preference)
@@ -0,0 +1,189 @@
+// Package report builds AgenC's daily digest: a machine-readable summary of
+// one day's missions, cron runs, prompts, and failures. The server gathers
+// the day's Activity from the database, Build turns it into a Digest, a
+// text/template renders it for people (see Render), and Write stores both
+// under $AGENC_DIRPATH/digests/.
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// DateLayout is how a digest's day is written, in its Date and file names.
+const DateLayout = "2006-01-02"
+
+// Failure kinds.
+const (
+	// FailureCron is a cron run that failed
+	FailureCron = "cron"
+	// FailureCrash is a mission whose Claude exited unexpectedly
+	FailureCrash = "crash"
+)
+
+// Activity is the raw activity of one day that a digest summarizes.
+// Missions deleted since are not part of it.
+type Activity struct {
+	// Missions are every mission, archived ones included; those created
+	// within the day are counted, and all are used to attribute prompts,
+	// archives, and crashes to repos.
+	Missions []*database.Mission
+	// Prompts are the prompts submitted within the day.
+	Prompts []*database.MissionPrompt
+	// Archives are the mission.archived events within the day.
+	Archives []*database.MissionEvent
+	// Crashes are the mission.crashed events within the day.
+	Crashes []*database.MissionEvent
+	// CronRuns are the cron runs started within the day.
+	CronRuns []*database.CronRun
+}
+
+// Digest summarizes one local day, [From, To).
+type Digest struct {
+	Date        string         `json:"date"`
+	From        time.Time      `json:"from"`
+	To          time.Time      `json:"to"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Missions    MissionTotals  `json:"missions"`
+	Prompts     int            `json:"prompts"`
+	Repos       []RepoActivity `json:"repos"`
+	Crons       []CronActivity `json:"crons"`
+	Failures    []Failure      `json:"failures"`
+}
+
+// MissionTotals counts missions created and archived during the day.
+type MissionTotals struct {
+	Created  int `json:"created"`
+	Archived int `json:"archived"`
+}
+
+// RepoActivity is the day's activity in one repo. Missions without a repo
+// are listed under an empty Repo.
+type RepoActivity struct {
+	Repo             string `json:"repo"`
+	MissionsCreated  int    `json:"missions_created"`
+	MissionsArchived int    `json:"missions_archived"`
+	Prompts          int    `json:"prompts"`
+}
+
+// CronActivity counts the outcomes of one cron's runs started during the
+// day. Running counts runs that had not finished when the digest was built.
+type CronActivity struct {
+	Name      string `json:"name"`
+	Runs      int    `json:"runs"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Skipped   int    `json:"skipped"`
+	Running   int    `json:"running"`
+}
+
+// Failure is a failed cron run or a mission crash. Name is the cron's name
+// or the crashed mission's repo.
+type Failure struct {
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	MissionID string    `json:"mission_id,omitempty"`
+	ShortID   string    `json:"short_id,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+}
+
+// DayBounds returns the start of the local day containing t and the start of
+// the next one.
+func DayBounds(t time.Time) (time.Time, time.Time) {
+	from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return from, from.AddDate(0, 0, 1)
+}
+
+// Build summarizes activity for the day [from, to). Repos and crons are
+// sorted by name and failures by time.
+func Build(from time.Time, to time.Time, activity Activity, now time.Time) *Digest {
+	digest := &Digest{
+		Date:        from.Format(DateLayout),
+		From:        from,
+		To:          to,
+		GeneratedAt: now,
+		Repos:       []RepoActivity{},
+		Crons:       []CronActivity{},
+		Failures:    []Failure{},
+	}
+
+	missionsByID := make(map[string]*database.Mission, len(activity.Missions))
+	repos := map[string]*RepoActivity{}
+	repoActivity := func(repo string) *RepoActivity {
+		if repos[repo] == nil {
+			repos[repo] = &RepoActivity{Repo: repo}
+		}
+		return repos[repo]
+	}
+	missionRepo := func(missionID string) string {
+		if m := missionsByID[missionID]; m != nil {
+			return m.GitRepo
+		}
+		return ""
+	}
+
+	for _, m := range activity.Missions {
+		missionsByID[m.ID] = m
+		if !m.CreatedAt.Before(from) && m.CreatedAt.Before(to) {
+			digest.Missions.Created++
+			repoActivity(m.GitRepo).MissionsCreated++
+		}
+	}
+	for _, e := range activity.Archives {
+		digest.Missions.Archived++
+		repoActivity(missionRepo(e.MissionID)).MissionsArchived++
+	}
+	for _, p := range activity.Prompts {
+		digest.Prompts++
+		repoActivity(missionRepo(p.MissionID)).Prompts++
+	}
+
+	crons := map[string]*CronActivity{}
+	for _, run := range activity.CronRuns {
+		cron := crons[run.CronName]
+		if cron == nil {
+			cron = &CronActivity{Name: run.CronName}
+			crons[run.CronName] = cron
+		}
+		cron.Runs++
+		switch run.Status {
+		case database.CronRunStatusSucceeded:
+			cron.Succeeded++
+		case database.CronRunStatusFailed:
+			cron.Failed++
+			failure := Failure{Time: run.StartedAt, Kind: FailureCron, Name: run.CronName, Detail: run.Detail}
+			if run.MissionID != nil {
+				failure.MissionID, failure.ShortID = *run.MissionID, database.ShortID(*run.MissionID)
+			}
+			digest.Failures = append(digest.Failures, failure)
+		case database.CronRunStatusSkipped:
+			cron.Skipped++
+		case database.CronRunStatusRunning:
+			cron.Running++
+		}
+	}
+	for _, e := range activity.Crashes {
+		digest.Failures = append(digest.Failures, Failure{
+			Time:      e.CreatedAt,
+			Kind:      FailureCrash,
+			Name:      missionRepo(e.MissionID),
+			MissionID: e.MissionID,
+			ShortID:   database.ShortID(e.MissionID),
+			Detail:    e.Data["reason"],
+		})
+	}
+
+	for _, repo := range repos {
+		digest.Repos = append(digest.Repos, *repo)
+	}
+	sort.Slice(digest.Repos, func(i, j int) bool { return digest.Repos[i].Repo < digest.Repos[j].Repo })
+	for _, cron := range crons {
+		digest.Crons = append(digest.Crons, *cron)
+	}
+	sort.Slice(digest.Crons, func(i, j int) bool { return digest.Crons[i].Name < digest.Crons[j].Name })
+	sort.SliceStable(digest.Failures, func(i, j int) bool { return digest.Failures[i].Time.Before(digest.Failures[j].Time) })
+	return digest
+}
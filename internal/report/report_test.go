@@ -0,0 +1,105 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestDayBounds(t *testing.T) {
+	loc := time.FixedZone("PDT", -7*3600)
+	from, to := DayBounds(time.Date(2026, 10, 16, 23, 30, 0, 0, loc))
+	if !from.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, loc)) || !to.Equal(time.Date(2026, 10, 17, 0, 0, 0, 0, loc)) {
+		t.Errorf("unexpected bounds %v – %v", from, to)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	missionA := "aaaaaaaa-1111-1111-1111-111111111111"
+	missionB := "bbbbbbbb-2222-2222-2222-222222222222"
+	missionC := "cccccccc-3333-3333-3333-333333333333"
+
+	activity := Activity{
+		Missions: []*database.Mission{
+			{ID: missionA, GitRepo: "github.com/owner/api", CreatedAt: from.Add(time.Hour)},
+			{ID: missionB, GitRepo: "github.com/owner/web", CreatedAt: from.Add(-48 * time.Hour)},
+			{ID: missionC, CreatedAt: from.Add(2 * time.Hour)},
+		},
+		Prompts: []*database.MissionPrompt{
+			{MissionID: missionA, CreatedAt: from.Add(time.Hour)},
+			{MissionID: missionA, CreatedAt: from.Add(3 * time.Hour)},
+			{MissionID: missionB, CreatedAt: from.Add(4 * time.Hour)},
+		},
+		Archives: []*database.MissionEvent{
+			{MissionID: missionB, Type: "mission.archived", CreatedAt: from.Add(5 * time.Hour)},
+		},
+		Crashes: []*database.MissionEvent{
+			{MissionID: missionA, Type: "mission.crashed", Data: map[string]string{"reason": "exit code 1"}, CreatedAt: from.Add(6 * time.Hour)},
+		},
+		CronRuns: []*database.CronRun{
+			{CronName: "nightly", Status: database.CronRunStatusSucceeded, StartedAt: from.Add(3 * time.Hour)},
+			{CronName: "nightly", Status: database.CronRunStatusFailed, MissionID: &missionC, Detail: "Claude exited with code 2", StartedAt: from.Add(7 * time.Hour)},
+			{CronName: "backup", Status: database.CronRunStatusSkipped, StartedAt: from.Add(2 * time.Hour)},
+		},
+	}
+	now := to.Add(7 * time.Hour)
+	digest := Build(from, to, activity, now)
+
+	if digest.Date != "2026-10-16" || !digest.GeneratedAt.Equal(now) {
+		t.Errorf("unexpected date %q or generation time %v", digest.Date, digest.GeneratedAt)
+	}
+	if digest.Missions != (MissionTotals{Created: 2, Archived: 1}) || digest.Prompts != 3 {
+		t.Errorf("unexpected totals %+v, %d prompts", digest.Missions, digest.Prompts)
+	}
+
+	wantRepos := []RepoActivity{
+		{Repo: "", MissionsCreated: 1},
+		{Repo: "github.com/owner/api", MissionsCreated: 1, Prompts: 2},
+		{Repo: "github.com/owner/web", MissionsArchived: 1, Prompts: 1},
+	}
+	if len(digest.Repos) != len(wantRepos) {
+		t.Fatalf("expected %d repos, got %+v", len(wantRepos), digest.Repos)
+	}
+	for i, want := range wantRepos {
+		if digest.Repos[i] != want {
+			t.Errorf("repo %d: expected %+v, got %+v", i, want, digest.Repos[i])
+		}
+	}
+
+	wantCrons := []CronActivity{
+		{Name: "backup", Runs: 1, Skipped: 1},
+		{Name: "nightly", Runs: 2, Succeeded: 1, Failed: 1},
+	}
+	if len(digest.Crons) != 2 || digest.Crons[0] != wantCrons[0] || digest.Crons[1] != wantCrons[1] {
+		t.Errorf("expected crons %+v, got %+v", wantCrons, digest.Crons)
+	}
+
+	if len(digest.Failures) != 2 {
+		t.Fatalf("expected two failures, got %+v", digest.Failures)
+	}
+	crash, cronFailure := digest.Failures[0], digest.Failures[1]
+	if crash.Kind != FailureCrash || crash.Name != "github.com/owner/api" || crash.ShortID != "aaaaaaaa" || crash.Detail != "exit code 1" {
+		t.Errorf("unexpected crash failure %+v", crash)
+	}
+	if cronFailure.Kind != FailureCron || cronFailure.Name != "nightly" || cronFailure.MissionID != missionC {
+		t.Errorf("unexpected cron failure %+v", cronFailure)
+	}
+}
+
+func TestWriteAndExists(t *testing.T) {
+	dirpath := t.TempDir()
+	digest := Build(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Activity{}, time.Now())
+
+	if Exists(dirpath, digest.Date) {
+		t.Fatal("expected no digest before writing")
+	}
+	if err := Write(dirpath, digest, "AgenC digest\n"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !Exists(dirpath, digest.Date) {
+		t.Error("expected the digest to exist after writing")
+	}
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// File extensions of a stored digest: the machine-readable JSON and the
+// rendered text.
+const (
+	JSONExt = ".json"
+	TextExt = ".txt"
+)
+
+// Filepaths returns where the digest for date (as DateLayout) is stored in
+// digestsDirpath.
+func Filepaths(digestsDirpath string, date string) (jsonFilepath string, textFilepath string) {
+	base := filepath.Join(digestsDirpath, date)
+	return base + JSONExt, base + TextExt
+}
+
+// Exists reports whether the digest for date has been written. The JSON file
+// is written last, so its presence means the digest is complete.
+func Exists(digestsDirpath string, date string) bool {
+	jsonFilepath, _ := Filepaths(digestsDirpath, date)
+	_, err := os.Stat(jsonFilepath)
+	return err == nil
+}
+
+// Write stores digest and its rendered text in digestsDirpath, replacing any
+// earlier digest for the same day.
+func Write(digestsDirpath string, digest *Digest, text string) error {
+	if err := os.MkdirAll(digestsDirpath, 0700); err != nil {
+		return stacktrace.Propagate(err, "failed to create digests directory '%s'", digestsDirpath)
+	}
+	jsonFilepath, textFilepath := Filepaths(digestsDirpath, digest.Date)
+	if err := os.WriteFile(textFilepath, []byte(text), 0600); err != nil {
+		return stacktrace.Propagate(err, "failed to write digest '%s'", textFilepath)
+	}
+	data, err := json.MarshalIndent(digest, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to encode digest for %s", digest.Date)
+	}
+	if err := os.WriteFile(jsonFilepath, append(data, '\n'), 0600); err != nil {
+		return stacktrace.Propagate(err, "failed to write digest '%s'", jsonFilepath)
+	}
+	return nil
+}
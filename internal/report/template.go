@@ -0,0 +1,52 @@
+package report
+
+import (
+	_ "embed"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// DefaultTemplate renders a digest as plain text. Custom templates are Go
+// text/template files executed with a *Digest.
+//
+//go:embed digest.tmpl
+var DefaultTemplate string
+
+// ParseTemplate parses text as a digest template. References to fields a
+// Digest doesn't have fail when the template is executed.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("digest").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse digest template")
+	}
+	return tmpl, nil
+}
+
+// LoadTemplate parses the digest template at templateFilepath, or
+// DefaultTemplate when templateFilepath is empty.
+func LoadTemplate(templateFilepath string) (*template.Template, error) {
+	if templateFilepath == "" {
+		return ParseTemplate(DefaultTemplate)
+	}
+	data, err := os.ReadFile(templateFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read digest template '%s'", templateFilepath)
+	}
+	tmpl, err := ParseTemplate(string(data))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "invalid digest template '%s'", templateFilepath)
+	}
+	return tmpl, nil
+}
+
+// Render executes tmpl with digest, ending the text with a single newline.
+func Render(tmpl *template.Template, digest *Digest) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, digest); err != nil {
+		return "", stacktrace.Propagate(err, "failed to render digest for %s", digest.Date)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n", nil
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender_DefaultTemplate(t *testing.T) {
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local)
+	digest := &Digest{
+		Date:     "2026-10-16",
+		Missions: MissionTotals{Created: 3, Archived: 1},
+		Prompts:  12,
+		Repos:    []RepoActivity{{Repo: "github.com/owner/api", MissionsCreated: 3, MissionsArchived: 1, Prompts: 12}},
+		Crons:    []CronActivity{{Name: "nightly", Runs: 2, Succeeded: 1, Failed: 1}},
+		Failures: []Failure{{Time: from.Add(7 * time.Hour), Kind: FailureCron, Name: "nightly", ShortID: "cccccccc", Detail: "Claude exited with code 2"}},
+	}
+	tmpl, err := LoadTemplate("")
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+	text, err := Render(tmpl, digest)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"AgenC digest for 2026-10-16",
+		"Missions: 3 created, 1 archived",
+		"Prompts:  12",
+		"  github.com/owner/api: 3 created, 1 archived, 12 prompts",
+		"  nightly: 2 runs, 1 succeeded, 1 failed\n",
+		"  07:00  cron  nightly (mission cccccccc): Claude exited with code 2\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected rendered digest to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	quiet, err := Render(tmpl, &Digest{Date: "2026-10-17"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.HasSuffix(quiet, "Failures: none\n") || strings.Contains(quiet, "Crons:") {
+		t.Errorf("expected an empty day to skip crons and report no failures, got:\n%s", quiet)
+	}
+}
+
+func TestLoadTemplate_Custom(t *testing.T) {
+	templateFilepath := filepath.Join(t.TempDir(), "digest.tmpl")
+	if err := os.WriteFile(templateFilepath, []byte("{{.Date}}: {{.Missions.Created}} missions, {{len .Failures}} failures"), 0600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	tmpl, err := LoadTemplate(templateFilepath)
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+	text, err := Render(tmpl, &Digest{Date: "2026-10-16", Missions: MissionTotals{Created: 2}})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if text != "2026-10-16: 2 missions, 0 failures\n" {
+		t.Errorf("unexpected rendering %q", text)
+	}
+
+	if err := os.WriteFile(templateFilepath, []byte("{{.Nope}}"), 0600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	tmpl, err = LoadTemplate(templateFilepath)
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+	if _, err := Render(tmpl, &Digest{}); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+
+	if _, err := LoadTemplate(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("expected an error for a missing template")
+	}
+}
@@ -0,0 +1,263 @@
+// Package secrets manages named secrets set with 'agenc secret' and resolves
+// secret://NAME references in config values.
+//
+// Values live in the platform credential store (see credstore): the macOS
+// Keychain, the Secret Service on Linux, or the encrypted-file fallback. Only
+// the list of names is kept on disk in plaintext, so secrets never land in
+// config.yml. References are resolved at the last moment — when the wrapper
+// spawns Claude, when a postUpdateHook runs, when a palette command is
+// dispatched — so rotating a secret takes effect on the next use.
+package secrets
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/credstore"
+)
+
+// RefPrefix introduces a secret reference, e.g. secret://GITHUB_TOKEN.
+const RefPrefix = "secret://"
+
+// serviceNamePrefix namespaces secrets in the credential store so they can't
+// collide with Claude's own credential entries.
+const serviceNamePrefix = "agenc-secret-"
+
+var (
+	nameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	refRegex  = regexp.MustCompile(regexp.QuoteMeta(RefPrefix) + `([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// ErrNotFound is returned by Store.Get when no secret has the given name.
+var ErrNotFound = credstore.ErrNotFound
+
+// Secret is the metadata kept for a secret; the value is never stored here.
+type Secret struct {
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ValidateName checks whether a secret name is valid. Names follow
+// environment variable rules so a secret can share its variable's name.
+func ValidateName(name string) error {
+	if name == "" {
+		return stacktrace.NewError("secret name cannot be empty")
+	}
+	if len(name) > 64 {
+		return stacktrace.NewError("secret name too long (max 64 characters)")
+	}
+	if !nameRegex.MatchString(name) {
+		return stacktrace.NewError("secret name '%s' is invalid; must start with a letter or underscore and contain only letters, numbers, and underscores", name)
+	}
+	return nil
+}
+
+// HasRefs reports whether text contains any secret://NAME reference.
+func HasRefs(text string) bool {
+	return refRegex.MatchString(text)
+}
+
+// ReferencedNames returns the distinct secret names referenced in text, in
+// order of first appearance.
+func ReferencedNames(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range refRegex.FindAllStringSubmatch(text, -1) {
+		if seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// Store reads and writes secrets for one agenc directory.
+type Store struct {
+	agencDirpath string
+	creds        credstore.Store
+}
+
+// Open returns the secret store for agencDirpath, backed by the process-wide
+// credential store.
+func Open(agencDirpath string) (*Store, error) {
+	creds, err := credstore.Default()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to open credential store")
+	}
+	return NewStore(agencDirpath, creds), nil
+}
+
+// NewStore returns a secret store that keeps values in creds.
+func NewStore(agencDirpath string, creds credstore.Store) *Store {
+	return &Store{agencDirpath: agencDirpath, creds: creds}
+}
+
+// BackendName returns the name of the credential store holding the values.
+func (s *Store) BackendName() string {
+	return s.creds.Name()
+}
+
+// Set creates or replaces the secret called name.
+func (s *Store) Set(name string, value string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if value == "" {
+		return stacktrace.NewError("secret value cannot be empty")
+	}
+	if err := s.creds.Write(serviceNamePrefix+name, value); err != nil {
+		return stacktrace.Propagate(err, "failed to store secret '%s'", name)
+	}
+
+	index, err := s.List()
+	if err != nil {
+		return err
+	}
+	index = removeSecret(index, name)
+	index = append(index, Secret{Name: name, UpdatedAt: time.Now().UTC()})
+	return s.writeIndex(index)
+}
+
+// Get returns the value of the secret called name, or ErrNotFound.
+func (s *Store) Get(name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	value, err := s.creds.Read(serviceNamePrefix + name)
+	if err != nil {
+		if err == credstore.ErrNotFound {
+			return "", ErrNotFound
+		}
+		return "", stacktrace.Propagate(err, "failed to read secret '%s'", name)
+	}
+	return value, nil
+}
+
+// Remove deletes the secret called name. Returns ErrNotFound if it doesn't
+// exist.
+func (s *Store) Remove(name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	index, err := s.List()
+	if err != nil {
+		return err
+	}
+	remaining := removeSecret(index, name)
+	if len(remaining) == len(index) {
+		return ErrNotFound
+	}
+	if err := s.creds.Delete(serviceNamePrefix + name); err != nil {
+		return stacktrace.Propagate(err, "failed to delete secret '%s'", name)
+	}
+	return s.writeIndex(remaining)
+}
+
+// List returns the stored secrets sorted by name. Returns an empty slice if
+// none have been set.
+func (s *Store) List() ([]Secret, error) {
+	indexFilepath := config.GetSecretsIndexFilepath(s.agencDirpath)
+	data, err := os.ReadFile(indexFilepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Secret{}, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to read secrets index '%s'", indexFilepath)
+	}
+
+	var index []Secret
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to parse secrets index '%s'", indexFilepath)
+	}
+	sort.Slice(index, func(i, j int) bool {
+		return index[i].Name < index[j].Name
+	})
+	return index, nil
+}
+
+// Expand replaces every secret://NAME reference in text with the secret's
+// value. Fails, naming the secret, if any referenced secret isn't set.
+func (s *Store) Expand(text string) (string, error) {
+	return s.expand(text, func(value string) string { return value })
+}
+
+// ExpandShellCommand is Expand for shell command strings: each value is
+// single-quoted so it is always passed as one literal word.
+func (s *Store) ExpandShellCommand(command string) (string, error) {
+	return s.expand(command, func(value string) string {
+		return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+	})
+}
+
+func (s *Store) expand(text string, quote func(string) string) (string, error) {
+	values := make(map[string]string)
+	for _, name := range ReferencedNames(text) {
+		value, err := s.Get(name)
+		if err == ErrNotFound {
+			return "", stacktrace.NewError("secret '%s' is not set; add it with 'agenc secret set %s'", name, name)
+		}
+		if err != nil {
+			return "", err
+		}
+		values[name] = value
+	}
+	return refRegex.ReplaceAllStringFunc(text, func(ref string) string {
+		return quote(values[strings.TrimPrefix(ref, RefPrefix)])
+	}), nil
+}
+
+// ExpandEnv returns env as KEY=VALUE entries with secret references in the
+// values resolved, sorted by key for stable ordering.
+func (s *Store) ExpandEnv(env map[string]string) ([]string, error) {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := s.Expand(env[key])
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "failed to resolve env var '%s'", key)
+		}
+		entries = append(entries, key+"="+value)
+	}
+	return entries, nil
+}
+
+func (s *Store) writeIndex(index []Secret) error {
+	indexFilepath := config.GetSecretsIndexFilepath(s.agencDirpath)
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to marshal secrets index")
+	}
+
+	tmpFilepath := indexFilepath + ".tmp"
+	if err := os.WriteFile(tmpFilepath, append(data, '\n'), 0600); err != nil {
+		return stacktrace.Propagate(err, "failed to write secrets index '%s'", tmpFilepath)
+	}
+	if err := os.Rename(tmpFilepath, indexFilepath); err != nil {
+		_ = os.Remove(tmpFilepath)
+		return stacktrace.Propagate(err, "failed to replace secrets index '%s'", indexFilepath)
+	}
+	return nil
+}
+
+func removeSecret(index []Secret, name string) []Secret {
+	remaining := make([]Secret, 0, len(index))
+	for _, secret := range index {
+		if secret.Name != name {
+			remaining = append(remaining, secret)
+		}
+	}
+	return remaining
+}
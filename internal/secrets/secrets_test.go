@@ -0,0 +1,118 @@
+package secrets
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/credstore"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	agencDirpath := t.TempDir()
+	return NewStore(agencDirpath, credstore.NewFileStore(filepath.Join(agencDirpath, "credentials")))
+}
+
+func TestStoreSetGetRemove(t *testing.T) {
+	store := newTestStore(t)
+
+	if err := store.Set("GITHUB_TOKEN", "ghp_first"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("GITHUB_TOKEN", "ghp_second"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("API_KEY", "key"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	value, err := store.Get("GITHUB_TOKEN")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if value != "ghp_second" {
+		t.Errorf("expected the latest value, got %q", value)
+	}
+
+	list, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var names []string
+	for _, secret := range list {
+		names = append(names, secret.Name)
+	}
+	if want := []string{"API_KEY", "GITHUB_TOKEN"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+
+	if err := store.Remove("GITHUB_TOKEN"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := store.Get("GITHUB_TOKEN"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound after removal, got %v", err)
+	}
+	if err := store.Remove("GITHUB_TOKEN"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound removing a missing secret, got %v", err)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"TOKEN", "_private", "api_key_2"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("expected %q to be valid: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "2FA", "my-token", "a.b", strings.Repeat("A", 65)} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.Set("TOKEN", "it's secret"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	got, err := store.Expand("Bearer secret://TOKEN")
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	if got != "Bearer it's secret" {
+		t.Errorf("unexpected expansion %q", got)
+	}
+
+	got, err = store.ExpandShellCommand("curl -H secret://TOKEN secret://TOKEN")
+	if err != nil {
+		t.Fatalf("ExpandShellCommand failed: %v", err)
+	}
+	if want := `curl -H 'it'"'"'s secret' 'it'"'"'s secret'`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if _, err := store.Expand("secret://MISSING"); err == nil || !strings.Contains(err.Error(), "MISSING") {
+		t.Errorf("expected an error naming the missing secret, got %v", err)
+	}
+
+	env, err := store.ExpandEnv(map[string]string{"B": "plain", "A": "secret://TOKEN"})
+	if err != nil {
+		t.Fatalf("ExpandEnv failed: %v", err)
+	}
+	if want := []string{"A=it's secret", "B=plain"}; !reflect.DeepEqual(env, want) {
+		t.Errorf("expected %v, got %v", want, env)
+	}
+}
+
+func TestReferencedNames(t *testing.T) {
+	got := ReferencedNames("a secret://ONE b secret://TWO c secret://ONE d secret://")
+	if want := []string{"ONE", "TWO"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if HasRefs("no references here") {
+		t.Error("expected no references")
+	}
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// BusyPeriodReport is the JSON body a wrapper sends to POST
+// /missions/{id}/busy-periods when Claude finishes working on a prompt.
+type BusyPeriodReport struct {
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+// AgentTimeTotal is the busy time attributed to one repo or cron job.
+type AgentTimeTotal struct {
+	Name    string `json:"name"`
+	Seconds int64  `json:"seconds"`
+}
+
+// AgentTimeReport is the response for GET /reports/agent-time: busy time
+// within [From, To), per repo and per cron job. Repos include cron-spawned
+// missions; missions without a repo are listed under an empty name.
+type AgentTimeReport struct {
+	From         time.Time        `json:"from"`
+	To           time.Time        `json:"to"`
+	TotalSeconds int64            `json:"total_seconds"`
+	Repos        []AgentTimeTotal `json:"repos"`
+	Crons        []AgentTimeTotal `json:"crons"`
+}
+
+// handleRecordBusyPeriod handles POST /missions/{id}/busy-periods. The
+// mission's repo and cron job are stored with the period so it still counts
+// toward them after the mission is deleted.
+func (s *Server) handleRecordBusyPeriod(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	var req BusyPeriodReport
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if req.StartedAt.IsZero() || !req.EndedAt.After(req.StartedAt) {
+		return newHTTPError(http.StatusBadRequest, "ended_at must be after started_at")
+	}
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	mission, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if mission == nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	period := &database.MissionBusyPeriod{
+		MissionID: resolvedID,
+		GitRepo:   mission.GitRepo,
+		CronName:  missionCronName(mission),
+		StartedAt: req.StartedAt,
+		EndedAt:   req.EndedAt,
+	}
+	if err := s.db.CreateMissionBusyPeriod(period); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to record busy period: %s", err.Error())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	return nil
+}
+
+// handleGetAgentTimeReport handles GET /reports/agent-time?from=...&to=...,
+// both RFC3339.
+func (s *Server) handleGetAgentTimeReport(w http.ResponseWriter, r *http.Request) error {
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid 'from' parameter: expected RFC3339 format")
+	}
+	to, err := time.Parse(time.RFC3339, r.URL.Query().Get("to"))
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid 'to' parameter: expected RFC3339 format")
+	}
+	if !to.After(from) {
+		return newHTTPError(http.StatusBadRequest, "'to' must be after 'from'")
+	}
+
+	periods, err := s.db.ListMissionBusyPeriods(from, to)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	writeJSON(w, http.StatusOK, buildAgentTimeReport(periods, from, to))
+	return nil
+}
+
+// buildAgentTimeReport totals busy periods per repo and per cron job, counting
+// only the part of each period that falls within [from, to). Totals are
+// ordered by time descending.
+func buildAgentTimeReport(periods []*database.MissionBusyPeriod, from time.Time, to time.Time) AgentTimeReport {
+	repoSeconds := make(map[string]int64)
+	cronSeconds := make(map[string]int64)
+	var totalSeconds int64
+	for _, p := range periods {
+		start, end := p.StartedAt, p.EndedAt
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+		seconds := int64(end.Sub(start) / time.Second)
+		totalSeconds += seconds
+		repoSeconds[p.GitRepo] += seconds
+		if p.CronName != "" {
+			cronSeconds[p.CronName] += seconds
+		}
+	}
+
+	return AgentTimeReport{
+		From:         from,
+		To:           to,
+		TotalSeconds: totalSeconds,
+		Repos:        sortedAgentTimeTotals(repoSeconds),
+		Crons:        sortedAgentTimeTotals(cronSeconds),
+	}
+}
+
+func sortedAgentTimeTotals(secondsByName map[string]int64) []AgentTimeTotal {
+	totals := make([]AgentTimeTotal, 0, len(secondsByName))
+	for name, seconds := range secondsByName {
+		totals = append(totals, AgentTimeTotal{Name: name, Seconds: seconds})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Seconds != totals[j].Seconds {
+			return totals[i].Seconds > totals[j].Seconds
+		}
+		return totals[i].Name < totals[j].Name
+	})
+	return totals
+}
+
+// missionCronName returns the name of the cron job that spawned a mission,
+// or "" for missions started any other way.
+func missionCronName(m *database.Mission) string {
+	if m.CronName != nil && *m.CronName != "" {
+		return *m.CronName
+	}
+	if m.Source != nil && *m.Source == "cron" && m.SourceMetadata != nil {
+		cronName, _ := parseCronSourceMetadata(*m.SourceMetadata)
+		return cronName
+	}
+	return ""
+}
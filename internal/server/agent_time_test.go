@@ -0,0 +1,94 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestBuildAgentTimeReport(t *testing.T) {
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	period := func(repo, cron string, start, end time.Duration) *database.MissionBusyPeriod {
+		return &database.MissionBusyPeriod{GitRepo: repo, CronName: cron, StartedAt: from.Add(start), EndedAt: from.Add(end)}
+	}
+	periods := []*database.MissionBusyPeriod{
+		period("github.com/acme/api", "", 9*time.Hour, 10*time.Hour),
+		period("github.com/acme/api", "nightly", -30*time.Minute, 30*time.Minute),    // clipped to 30m
+		period("github.com/acme/web", "", 23*time.Hour+30*time.Minute, 25*time.Hour), // clipped to 30m
+		period("", "", 12*time.Hour, 12*time.Hour+15*time.Minute),
+	}
+
+	report := buildAgentTimeReport(periods, from, to)
+
+	if want := int64((2*time.Hour + 15*time.Minute) / time.Second); report.TotalSeconds != want {
+		t.Errorf("expected %d total seconds, got %d", want, report.TotalSeconds)
+	}
+	wantRepos := []AgentTimeTotal{
+		{Name: "github.com/acme/api", Seconds: 5400},
+		{Name: "github.com/acme/web", Seconds: 1800},
+		{Name: "", Seconds: 900},
+	}
+	if len(report.Repos) != len(wantRepos) {
+		t.Fatalf("expected repos %+v, got %+v", wantRepos, report.Repos)
+	}
+	for i := range wantRepos {
+		if report.Repos[i] != wantRepos[i] {
+			t.Errorf("repo %d: expected %+v, got %+v", i, wantRepos[i], report.Repos[i])
+		}
+	}
+	if len(report.Crons) != 1 || report.Crons[0] != (AgentTimeTotal{Name: "nightly", Seconds: 1800}) {
+		t.Errorf("expected only the nightly cron with 30m, got %+v", report.Crons)
+	}
+}
+
+func TestHandleRecordBusyPeriod(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	source := "cron"
+	metadata := `{"cron_name":"nightly","trigger":"schedule"}`
+	missionRecord, err := srv.db.CreateMission("github.com/acme/api", &database.CreateMissionParams{Source: &source, SourceMetadata: &metadata})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	post := func(body string) error {
+		req := httptest.NewRequest(http.MethodPost, "/missions/"+missionRecord.ShortID+"/busy-periods", strings.NewReader(body))
+		req.SetPathValue("id", missionRecord.ShortID)
+		return srv.handleRecordBusyPeriod(httptest.NewRecorder(), req)
+	}
+	if err := post(`{"started_at":"2026-06-01T10:00:00Z","ended_at":"2026-06-01T09:00:00Z"}`); err == nil {
+		t.Error("expected a period ending before it starts to be rejected")
+	}
+	if err := post(`{"started_at":"2026-06-01T09:00:00Z","ended_at":"2026-06-01T09:45:00Z"}`); err != nil {
+		t.Fatalf("handleRecordBusyPeriod failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/agent-time?from=2026-06-01T00:00:00Z&to=2026-06-02T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+	if err := srv.handleGetAgentTimeReport(w, req); err != nil {
+		t.Fatalf("handleGetAgentTimeReport failed: %v", err)
+	}
+	var report AgentTimeReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if report.TotalSeconds != 2700 {
+		t.Errorf("expected 45m of agent time, got %ds", report.TotalSeconds)
+	}
+	if len(report.Repos) != 1 || report.Repos[0].Name != "github.com/acme/api" {
+		t.Errorf("expected the time under the mission's repo, got %+v", report.Repos)
+	}
+	if len(report.Crons) != 1 || report.Crons[0].Name != "nightly" {
+		t.Errorf("expected the time under the mission's cron, got %+v", report.Crons)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/reports/agent-time?from=2026-06-02T00:00:00Z&to=2026-06-01T00:00:00Z", nil)
+	if err := srv.handleGetAgentTimeReport(httptest.NewRecorder(), req); err == nil {
+		t.Error("expected an inverted window to be rejected")
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/odyssey/agenc/internal/version"
+)
+
+const (
+	// serverRestartRemedy tells a user whose client is newer than the server
+	// how to catch the server up.
+	serverRestartRemedy = "restart it with 'agenc server restart' so it runs the same agenc version as this CLI"
+
+	// clientUpgradeRemedy tells a user whose client is older than the server
+	// how to catch the client up.
+	clientUpgradeRemedy = "upgrade agenc ('agenc upgrade'), then reload running missions ('agenc mission reload <id>') so their wrappers pick up the new version"
+)
+
+// minClientAPIVersion is the oldest client API version the server serves. It
+// is version.MinServerClientAPIVersion; tests raise it to exercise rejection.
+var minClientAPIVersion = version.MinServerClientAPIVersion
+
+// VersionResponse is the JSON response for GET /version.
+type VersionResponse struct {
+	Version                      string `json:"version"`
+	APIVersion                   int    `json:"api_version"`
+	MinSupportedClientAPIVersion int    `json:"min_supported_client_api_version"`
+}
+
+// handleVersion handles GET /version: the server's release version, its API
+// version, and the oldest client API version it accepts.
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) error {
+	writeJSON(w, http.StatusOK, VersionResponse{
+		Version:                      version.Version,
+		APIVersion:                   version.ServerAPIVersion,
+		MinSupportedClientAPIVersion: minClientAPIVersion,
+	})
+	return nil
+}
+
+// apiVersionMiddleware stamps every response with the server's API version
+// and rejects requests from clients older than
+// version.MinServerClientAPIVersion with 426 Upgrade Required. Requests
+// without a version header (curl, pre-versioning clients) are served as
+// before.
+func (s *Server) apiVersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(version.APIVersionHeader, strconv.Itoa(version.ServerAPIVersion))
+
+		if err := version.CheckAPIVersion(r.Header, "this agenc client", minClientAPIVersion, clientUpgradeRemedy); err != nil {
+			writeJSON(w, http.StatusUpgradeRequired, errorResponse{Message: err.Error()})
+			if s.requestLogger != nil {
+				s.requestLogger.LogAttrs(r.Context(), slog.LevelWarn, "request",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Int("status", http.StatusUpgradeRequired),
+					slog.String("error", err.Error()),
+				)
+			}
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/version"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	srv := &Server{}
+	mux := http.NewServeMux()
+	mux.Handle("GET /version", appHandler(slog.New(slog.DiscardHandler), srv.handleVersion))
+	handler := srv.apiVersionMiddleware(mux)
+
+	for _, clientVersion := range []string{"", strconv.Itoa(version.ServerAPIVersion)} {
+		req := httptest.NewRequest(http.MethodGet, "/version", nil)
+		if clientVersion != "" {
+			req.Header.Set(version.APIVersionHeader, clientVersion)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("client version %q: expected 200, got %d: %s", clientVersion, rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get(version.APIVersionHeader); got != strconv.Itoa(version.ServerAPIVersion) {
+			t.Errorf("expected the response stamped with API version %d, got %q", version.ServerAPIVersion, got)
+		}
+		var resp VersionResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.APIVersion != version.ServerAPIVersion || resp.MinSupportedClientAPIVersion != version.MinServerClientAPIVersion {
+			t.Errorf("unexpected version response: %+v", resp)
+		}
+	}
+}
+
+func TestAPIVersionMiddleware_RejectsOldClient(t *testing.T) {
+	minClientAPIVersion = version.ServerAPIVersion + 1
+	t.Cleanup(func() { minClientAPIVersion = version.MinServerClientAPIVersion })
+
+	srv := &Server{}
+	mux := http.NewServeMux()
+	mux.Handle("GET /version", appHandler(slog.New(slog.DiscardHandler), srv.handleVersion))
+	handler := srv.apiVersionMiddleware(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set(version.APIVersionHeader, strconv.Itoa(version.ServerAPIVersion))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUpgradeRequired {
+		t.Fatalf("expected 426, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get(version.APIVersionHeader); got != strconv.Itoa(version.ServerAPIVersion) {
+		t.Errorf("expected the rejection stamped with API version %d, got %q", version.ServerAPIVersion, got)
+	}
+	var resp errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, want := range []string{"agenc upgrade", "agenc mission reload"} {
+		if !strings.Contains(resp.Message, want) {
+			t.Errorf("expected the error to tell the user to run %q, got %q", want, resp.Message)
+		}
+	}
+}
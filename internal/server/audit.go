@@ -0,0 +1,163 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/audit"
+)
+
+// maxAuditBodyBytes caps how much of a request body is recorded in the
+// audit log; larger bodies are recorded by size only.
+const maxAuditBodyBytes = 64 * 1024
+
+// maxAuditErrorBytes caps how much of an error response is kept.
+const maxAuditErrorBytes = 1024
+
+// auditExemptPatterns are state-changing routes left out of the audit log:
+// bookkeeping that wrappers send on every prompt, tool run, or heartbeat,
+// which would drown out the calls worth auditing.
+var auditExemptPatterns = map[string]bool{
+	"POST /missions/{id}/heartbeat":    true,
+	"POST /missions/{id}/claude-idle":  true,
+	"POST /missions/{id}/prompt":       true,
+	"POST /missions/{id}/stats":        true,
+	"POST /missions/{id}/busy-periods": true,
+	"POST /missions/{id}/exit":         true,
+	"POST /events":                     true,
+}
+
+// apiTokenNameContextKey holds the name of the API token a TCP request
+// authenticated with.
+type apiTokenNameContextKey struct{}
+
+// auditResponseWriter captures the status of a response, and the start of
+// its body when it is an error, for the audit log.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status    int
+	errorBody bytes.Buffer
+}
+
+func (aw *auditResponseWriter) WriteHeader(code int) {
+	if aw.status == 0 {
+		aw.status = code
+	}
+	aw.ResponseWriter.WriteHeader(code)
+}
+
+func (aw *auditResponseWriter) Write(data []byte) (int, error) {
+	if aw.status == 0 {
+		aw.status = http.StatusOK
+	}
+	if aw.status >= 400 && aw.errorBody.Len() < maxAuditErrorBytes {
+		aw.errorBody.Write(data[:min(len(data), maxAuditErrorBytes-aw.errorBody.Len())])
+	}
+	return aw.ResponseWriter.Write(data)
+}
+
+// Unwrap exposes the underlying writer so http.ResponseController can reach
+// optional interfaces such as http.Flusher.
+func (aw *auditResponseWriter) Unwrap() http.ResponseWriter {
+	return aw.ResponseWriter
+}
+
+// auditMiddleware records every state-changing request routed by mux (any
+// method but GET and HEAD, minus auditExemptPatterns) in the audit log once
+// it has been served, with its actor, route, parameters, and outcome.
+func (s *Server) auditMiddleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		if r.Method == http.MethodGet || r.Method == http.MethodHead || pattern == "" || auditExemptPatterns[pattern] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var body []byte
+		if r.Body != nil {
+			body, _ = io.ReadAll(r.Body) // a short read is served as-is; the handler reports it
+			r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		aw := &auditResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(aw, r)
+
+		entry := audit.Entry{
+			Source:       audit.SourceAPI,
+			InvocationID: r.Header.Get(audit.InvocationHeader),
+			Actor:        auditActorForRequest(r),
+			Action:       pattern,
+			Path:         r.URL.Path,
+			Params:       auditRequestParams(r, body),
+			Status:       aw.status,
+		}
+		if aw.status >= 400 {
+			entry.Error = auditErrorMessage(aw.errorBody.Bytes())
+		}
+		if err := audit.Append(s.agencDirpath, entry); err != nil {
+			s.logger.Printf("Failed to write audit entry for %s: %v", pattern, err)
+		}
+	})
+}
+
+// auditActorForRequest attributes a request: the API token it authenticated
+// with, the mission the calling CLI ran in, or the OS user on the other end
+// of the unix socket.
+func auditActorForRequest(r *http.Request) audit.Actor {
+	if tokenName, ok := r.Context().Value(apiTokenNameContextKey{}).(string); ok {
+		return audit.Actor{Kind: audit.ActorToken, Token: tokenName}
+	}
+	userName, _, _ := requestUser(r)
+	if missionID := r.Header.Get(audit.MissionHeader); missionID != "" {
+		return audit.Actor{Kind: audit.ActorMission, MissionID: missionID, User: userName}
+	}
+	return audit.Actor{Kind: audit.ActorUser, User: userName}
+}
+
+// auditRequestParams collects a request's query and body for its audit
+// entry. JSON object bodies are recorded as-is up to maxAuditBodyBytes;
+// anything else is recorded by size.
+func auditRequestParams(r *http.Request, body []byte) map[string]any {
+	params := map[string]any{}
+	if query := r.URL.Query(); len(query) > 0 {
+		queryParams := make(map[string]any, len(query))
+		for key, values := range query {
+			queryParams[key] = strings.Join(values, ",")
+		}
+		params["query"] = queryParams
+	}
+	if len(body) > 0 {
+		var bodyParams map[string]any
+		if len(body) <= maxAuditBodyBytes && json.Unmarshal(body, &bodyParams) == nil {
+			params["body"] = bodyParams
+		} else {
+			params["body_bytes"] = len(body)
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+// auditErrorMessage extracts the message of an error response, keeping only
+// its first line (stack traces are in the server log).
+func auditErrorMessage(body []byte) string {
+	var resp errorResponse
+	message := string(body)
+	if json.Unmarshal(body, &resp) == nil && resp.Message != "" {
+		message = resp.Message
+	}
+	message, _, _ = strings.Cut(strings.TrimSpace(message), "\n")
+	return message
+}
+
+// withAPITokenName records the name of the API token a request authenticated
+// with, for auditActorForRequest.
+func withAPITokenName(r *http.Request, tokenName string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), apiTokenNameContextKey{}, tokenName))
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/audit"
+)
+
+// newAuditTestHandler returns srv's audit middleware around a mux with a few
+// representative routes.
+func newAuditTestHandler(srv *Server) http.Handler {
+	mux := http.NewServeMux()
+	ok := func(w http.ResponseWriter, r *http.Request) { writeJSON(w, http.StatusOK, map[string]string{"status": "ok"}) }
+	mux.HandleFunc("GET /missions", ok)
+	mux.HandleFunc("POST /missions", ok)
+	mux.HandleFunc("POST /missions/{id}/heartbeat", ok)
+	mux.HandleFunc("DELETE /missions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, errorResponse{Message: "mission not found\nstack trace"})
+	})
+	return srv.auditMiddleware(mux, mux)
+}
+
+func readAuditEntries(t *testing.T, srv *Server) []audit.Entry {
+	t.Helper()
+	entries, err := audit.Read(srv.agencDirpath, time.Time{})
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	return entries
+}
+
+func TestAuditMiddleware_RecordsStateChangingCalls(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	handler := newAuditTestHandler(srv)
+
+	req := httptest.NewRequest("POST", "/missions?source=cron", strings.NewReader(`{"repo":"github.com/owner/repo","apiToken":"abc"}`))
+	req.Header.Set(audit.MissionHeader, "2b4c8f1a-0000")
+	req.Header.Set(audit.InvocationHeader, "inv-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := readAuditEntries(t, srv)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %+v", entries)
+	}
+	entry := entries[0]
+	if entry.Source != audit.SourceAPI || entry.Action != "POST /missions" || entry.Status != http.StatusOK || entry.InvocationID != "inv-1" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if entry.Actor.Kind != audit.ActorMission || entry.Actor.MissionID != "2b4c8f1a-0000" {
+		t.Errorf("expected mission actor, got %+v", entry.Actor)
+	}
+	body := entry.Params["body"].(map[string]any)
+	if body["repo"] != "github.com/owner/repo" || body["apiToken"] != "[redacted]" {
+		t.Errorf("expected recorded body with the token redacted, got %+v", body)
+	}
+	if query := entry.Params["query"].(map[string]any); query["source"] != "cron" {
+		t.Errorf("expected recorded query, got %+v", query)
+	}
+}
+
+func TestAuditMiddleware_RecordsErrors(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	handler := newAuditTestHandler(srv)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/missions/abc", nil))
+
+	entries := readAuditEntries(t, srv)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %+v", entries)
+	}
+	if entries[0].Status != http.StatusNotFound || entries[0].Error != "mission not found" || entries[0].Path != "/missions/abc" {
+		t.Errorf("unexpected entry: %+v", entries[0])
+	}
+	if entries[0].Actor.Kind != audit.ActorUser {
+		t.Errorf("expected user actor, got %+v", entries[0].Actor)
+	}
+}
+
+func TestAuditMiddleware_SkipsReadsAndExemptRoutes(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	handler := newAuditTestHandler(srv)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("GET", "/missions", nil),
+		httptest.NewRequest("POST", "/missions/abc/heartbeat", nil),
+		httptest.NewRequest("POST", "/unknown", nil),
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if entries := readAuditEntries(t, srv); len(entries) != 0 {
+		t.Errorf("expected no audit entries, got %+v", entries)
+	}
+}
+
+func TestAuditMiddleware_TokenActor(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	handler := newAuditTestHandler(srv)
+
+	req := withAPITokenName(httptest.NewRequest("POST", "/missions", nil), "ci")
+	req.Header.Set(audit.MissionHeader, "2b4c8f1a-0000")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := readAuditEntries(t, srv)
+	if len(entries) != 1 || entries[0].Actor.Kind != audit.ActorToken || entries[0].Actor.Token != "ci" {
+		t.Errorf("expected token actor, got %+v", entries)
+	}
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// SetListenOverride sets a TCP listen spec (e.g. "tcp:127.0.0.1:7777") that
+// takes precedence over the serverListen config key. Must be called before Run.
+func (s *Server) SetListenOverride(spec string) {
+	s.listenOverride = spec
+}
+
+// resolveListenSpec returns the TCP listen spec to use, preferring the
+// command-line override over config. Empty means unix socket only.
+func (s *Server) resolveListenSpec() string {
+	if s.listenOverride != "" {
+		return s.listenOverride
+	}
+	return s.getConfig().ServerListen
+}
+
+// startTCPListener binds the loopback TCP listener, if one is configured, and
+// returns an http.Server that serves handler behind bearer-token auth. Returns
+// a nil server and nil listener when no TCP address is configured.
+func (s *Server) startTCPListener(handler http.Handler) (*http.Server, net.Listener, error) {
+	spec := s.resolveListenSpec()
+	if spec == "" {
+		return nil, nil, nil
+	}
+
+	addr, err := config.ParseServerListenAddr(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "failed to listen on '%s'", addr)
+	}
+
+	tcpServer := &http.Server{
+		Handler: s.requireAPIToken(handler),
+	}
+	return tcpServer, listener, nil
+}
+
+// requireAPIToken wraps a handler so that every request must carry an
+// "Authorization: Bearer <token>" header matching a token created with
+// `agenc config token create`. The token file is re-read on every request so
+// revocation takes effect immediately. The unix socket is not wrapped: access
+// to it is already limited to the owning user by file permissions. GitHub
+// webhook deliveries are exempt; their handler checks the HMAC signature
+// instead, since GitHub cannot send a bearer token.
+func (s *Server) requireAPIToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == githubWebhookPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		plaintext, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || plaintext == "" {
+			s.rejectUnauthorized(w, r, "missing bearer token")
+			return
+		}
+
+		tokens, err := config.ReadAPITokens(s.agencDirpath)
+		if err != nil {
+			s.logger.Printf("Failed to read API tokens: %v", err)
+			s.rejectUnauthorized(w, r, "failed to read API tokens")
+			return
+		}
+		token := config.FindAPIToken(tokens, strings.TrimSpace(plaintext))
+		if token == nil {
+			s.rejectUnauthorized(w, r, "invalid or revoked token")
+			return
+		}
+
+		next.ServeHTTP(w, withAPITokenName(r, token.Name))
+	})
+}
+
+// rejectUnauthorized writes a 401 JSON error and records the attempt in the
+// request log. The response message is deliberately generic so callers cannot
+// distinguish unknown tokens from revoked ones.
+func (s *Server) rejectUnauthorized(w http.ResponseWriter, r *http.Request, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", `Bearer realm="agenc"`)
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(errorResponse{Message: "unauthorized"}) // response already started; encode error cannot be propagated
+
+	if s.requestLogger != nil {
+		s.requestLogger.LogAttrs(r.Context(), slog.LevelWarn, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", http.StatusUnauthorized),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.String("error", reason),
+		)
+	}
+}
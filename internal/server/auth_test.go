@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestRequireAPIToken(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	plaintext, token, err := config.CreateAPIToken(srv.agencDirpath, "gui")
+	if err != nil {
+		t.Fatalf("CreateAPIToken failed: %v", err)
+	}
+
+	handler := srv.requireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(authorization string) int {
+		req := httptest.NewRequest("GET", "/health", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve(""); code != http.StatusUnauthorized {
+		t.Errorf("no token: expected 401, got %d", code)
+	}
+	if code := serve("Bearer agenc_notarealtoken"); code != http.StatusUnauthorized {
+		t.Errorf("wrong token: expected 401, got %d", code)
+	}
+	if code := serve(plaintext); code != http.StatusUnauthorized {
+		t.Errorf("missing Bearer scheme: expected 401, got %d", code)
+	}
+	if code := serve("Bearer " + plaintext); code != http.StatusOK {
+		t.Errorf("valid token: expected 200, got %d", code)
+	}
+
+	// Revocation takes effect without restarting the server
+	if _, err := config.RevokeAPIToken(srv.agencDirpath, token.Name); err != nil {
+		t.Fatalf("RevokeAPIToken failed: %v", err)
+	}
+	if code := serve("Bearer " + plaintext); code != http.StatusUnauthorized {
+		t.Errorf("revoked token: expected 401, got %d", code)
+	}
+}
+
+func TestStartTCPListener_ListenOverride(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+
+	tcpServer, listener, err := srv.startTCPListener(http.NewServeMux())
+	if err != nil || tcpServer != nil || listener != nil {
+		t.Fatalf("expected no TCP listener by default, got %v, %v, %v", tcpServer, listener, err)
+	}
+
+	srv.SetListenOverride("tcp:0.0.0.0:0")
+	if _, _, err := srv.startTCPListener(http.NewServeMux()); err == nil {
+		t.Fatal("expected non-loopback address to be rejected")
+	}
+
+	srv.SetListenOverride("tcp:127.0.0.1:0")
+	tcpServer, listener, err = srv.startTCPListener(http.NewServeMux())
+	if err != nil {
+		t.Fatalf("startTCPListener failed: %v", err)
+	}
+	defer listener.Close()
+	if tcpServer == nil {
+		t.Fatal("expected a TCP server")
+	}
+}
@@ -34,6 +34,7 @@ func newAutoSummaryTestServer(t *testing.T) *Server {
 		agencDirpath: tmpDir,
 		logger:       log.New(os.Stderr, "", 0),
 		db:           db,
+		events:       newEventBus(),
 	}
 	srv.cachedConfig.Store(&config.AgencConfig{})
 	return srv
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/odyssey/agenc/internal/claudeconfig"
@@ -29,6 +30,32 @@ func TestMergeSettingsWithAgencOverrides(t *testing.T) {
 				assertDenyContainsAgencEntries(t, settings)
 			},
 		},
+		{
+			name:      "empty settings gets the mission statusline",
+			inputJSON: `{}`,
+			checkMerged: func(t *testing.T, settings map[string]json.RawMessage) {
+				var statusLine struct {
+					Type    string `json:"type"`
+					Command string `json:"command"`
+				}
+				if err := json.Unmarshal(settings["statusLine"], &statusLine); err != nil {
+					t.Fatalf("failed to parse statusLine: %v", err)
+				}
+				wantFilepath := "/tmp/test-agenc/missions/test-mission/statusline-message"
+				if statusLine.Type != "command" || !strings.Contains(statusLine.Command, wantFilepath) {
+					t.Errorf("expected statusLine command reading %s, got %+v", wantFilepath, statusLine)
+				}
+			},
+		},
+		{
+			name:      "existing statusLine is preserved",
+			inputJSON: `{"statusLine": {"type": "command", "command": "my-statusline"}}`,
+			checkMerged: func(t *testing.T, settings map[string]json.RawMessage) {
+				if !strings.Contains(string(settings["statusLine"]), "my-statusline") {
+					t.Errorf("user statusLine was replaced: %s", settings["statusLine"])
+				}
+			},
+		},
 		{
 			name: "existing Stop hooks are preserved and agenc hook appended",
 			inputJSON: `{
@@ -132,7 +159,7 @@ func TestMergeSettingsWithAgencOverrides(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			isContainerized := false
-			result, err := claudeconfig.MergeSettingsWithAgencOverrides([]byte(tt.inputJSON), testAgencDirpath, testAgentDirpath, testClaudeConfigDirpath, isContainerized)
+			result, err := claudeconfig.MergeSettingsWithAgencOverrides([]byte(tt.inputJSON), testAgencDirpath, testAgentDirpath, testClaudeConfigDirpath, isContainerized, nil)
 			if err != nil {
 				t.Fatalf("claudeconfig.MergeSettingsWithAgencOverrides returned error: %v", err)
 			}
@@ -149,7 +176,7 @@ func TestMergeSettingsWithAgencOverrides(t *testing.T) {
 
 func TestMergeSettingsWithAgencOverrides_InvalidJSON(t *testing.T) {
 	isContainerized := false
-	_, err := claudeconfig.MergeSettingsWithAgencOverrides([]byte(`not json`), testAgencDirpath, testAgentDirpath, testClaudeConfigDirpath, isContainerized)
+	_, err := claudeconfig.MergeSettingsWithAgencOverrides([]byte(`not json`), testAgencDirpath, testAgentDirpath, testClaudeConfigDirpath, isContainerized, nil)
 	if err == nil {
 		t.Error("expected error for invalid JSON, got nil")
 	}
@@ -1,49 +1,108 @@
 package server
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mieubrisse/stacktrace"
 
+	"github.com/odyssey/agenc/internal/audit"
+	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/sleep"
+	"github.com/odyssey/agenc/internal/version"
 )
 
 // Client is an HTTP client that connects to the AgenC server via unix socket.
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	// auditHeaders are sent with every request so the server's audit log
+	// can attribute it (see auditHeaderTransport)
+	auditHeaders http.Header
 }
 
 // NewClient creates a new client that connects to the server at the given socket path.
+// Requests made from inside a mission carry its UUID for the audit log, and
+// every request carries the client's API version; a server too old for this
+// client fails the request with a *version.IncompatibleAPIError.
 func NewClient(socketPath string) *Client {
+	auditHeaders := http.Header{}
+	if missionID := os.Getenv(config.MissionUUIDEnvVar); missionID != "" {
+		auditHeaders.Set(audit.MissionHeader, missionID)
+	}
 	return &Client{
 		httpClient: &http.Client{
-			Transport: &http.Transport{
-				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
-					return net.DialTimeout("unix", socketPath, 5*time.Second)
+			Transport: &auditHeaderTransport{
+				base: &version.APIVersionTransport{
+					Base: &http.Transport{
+						DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+							return net.DialTimeout("unix", socketPath, 5*time.Second)
+						},
+					},
+					Version:        version.ServerAPIVersion,
+					Peer:           "the agenc server",
+					MinPeerVersion: version.MinServerAPIVersion,
+					Remedy:         serverRestartRemedy,
 				},
+				headers: auditHeaders,
 			},
 			Timeout: 30 * time.Second,
 		},
 		// The host doesn't matter for unix sockets, but HTTP requires one
-		baseURL: "http://agenc",
+		baseURL:      "http://agenc",
+		auditHeaders: auditHeaders,
 	}
 }
 
+// SetAuditInvocationID tags every later request with the ID of the CLI
+// invocation making it, tying the server's audit entries to the CLI's.
+func (c *Client) SetAuditInvocationID(invocationID string) {
+	c.auditHeaders.Set(audit.InvocationHeader, invocationID)
+}
+
+// auditHeaderTransport adds the client's audit headers to every request.
+type auditHeaderTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t *auditHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.headers) > 0 {
+		req = req.Clone(req.Context())
+		for key, values := range t.headers {
+			req.Header[key] = values
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
 // Get sends a GET request and decodes the response into result.
 func (c *Client) Get(path string, result any) error {
-	resp, err := c.httpClient.Get(c.baseURL + path)
+	return c.getWith(c.httpClient, path, result)
+}
+
+// getLongRunning is Get without the client's request timeout, for endpoints
+// whose duration scales with on-disk data (e.g. transcript search).
+func (c *Client) getLongRunning(path string, result any) error {
+	return c.getWith(&http.Client{Transport: c.httpClient.Transport}, path, result)
+}
+
+func (c *Client) getWith(httpClient *http.Client, path string, result any) error {
+	resp, err := httpClient.Get(c.baseURL + path)
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to connect to server")
+		return connectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -62,6 +121,16 @@ func (c *Client) Get(path string, result any) error {
 
 // Post sends a POST request with a JSON body and decodes the response into result.
 func (c *Client) Post(path string, body any, result any) error {
+	return c.postWith(c.httpClient, path, body, result)
+}
+
+// postLongRunning is Post without the client's request timeout, for
+// endpoints whose duration scales with on-disk data (e.g. mission bundles).
+func (c *Client) postLongRunning(path string, body any, result any) error {
+	return c.postWith(&http.Client{Transport: c.httpClient.Transport}, path, body, result)
+}
+
+func (c *Client) postWith(httpClient *http.Client, path string, body any, result any) error {
 	var bodyReader io.Reader
 	if body != nil {
 		pr, pw := io.Pipe()
@@ -71,9 +140,9 @@ func (c *Client) Post(path string, body any, result any) error {
 		bodyReader = pr
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL+path, "application/json", bodyReader)
+	resp, err := httpClient.Post(c.baseURL+path, "application/json", bodyReader)
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to connect to server")
+		return connectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -92,6 +161,11 @@ func (c *Client) Post(path string, body any, result any) error {
 
 // Delete sends a DELETE request.
 func (c *Client) Delete(path string) error {
+	return c.deleteWith(path, nil)
+}
+
+// deleteWith sends a DELETE request and decodes the response into result.
+func (c *Client) deleteWith(path string, result any) error {
 	req, err := http.NewRequest(http.MethodDelete, c.baseURL+path, nil)
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to create request")
@@ -99,7 +173,7 @@ func (c *Client) Delete(path string) error {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to connect to server")
+		return connectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -107,6 +181,12 @@ func (c *Client) Delete(path string) error {
 		return c.decodeError(resp)
 	}
 
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return stacktrace.Propagate(err, "failed to decode server response")
+		}
+	}
+
 	return nil
 }
 
@@ -129,7 +209,7 @@ func (c *Client) Patch(path string, body any, result any) error {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to connect to server")
+		return connectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -151,7 +231,7 @@ func (c *Client) Patch(path string, body any, result any) error {
 func (c *Client) GetRaw(path string) ([]byte, error) {
 	resp, err := c.httpClient.Get(c.baseURL + path)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "failed to connect to server")
+		return nil, connectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -186,7 +266,7 @@ func (c *Client) Put(path string, body any, result any) error {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to connect to server")
+		return connectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -214,6 +294,12 @@ type ListMissionsRequest struct {
 	SourceID        string
 	Since           *time.Time
 	Until           *time.Time
+	Tags            []string
+	Project         string
+	Sort            string // activity (default), created, or updated
+	Limit           int    // 0 means no limit
+	Offset          int
+	Cursor          string // ID of the last mission from the previous page
 }
 
 // ListMissions fetches missions from the server with optional filtering.
@@ -224,10 +310,10 @@ func (c *Client) ListMissions(req ListMissionsRequest) ([]*database.Mission, err
 		params = append(params, "include_archived=true")
 	}
 	if req.Source != "" {
-		params = append(params, "source="+req.Source)
+		params = append(params, "source="+url.QueryEscape(req.Source))
 	}
 	if req.SourceID != "" {
-		params = append(params, "source_id="+req.SourceID)
+		params = append(params, "source_id="+url.QueryEscape(req.SourceID))
 	}
 	if req.Since != nil {
 		params = append(params, "since="+req.Since.UTC().Format(time.RFC3339))
@@ -235,6 +321,24 @@ func (c *Client) ListMissions(req ListMissionsRequest) ([]*database.Mission, err
 	if req.Until != nil {
 		params = append(params, "until="+req.Until.UTC().Format(time.RFC3339))
 	}
+	if len(req.Tags) > 0 {
+		params = append(params, "tags="+url.QueryEscape(strings.Join(req.Tags, ",")))
+	}
+	if req.Project != "" {
+		params = append(params, "project="+url.QueryEscape(req.Project))
+	}
+	if req.Sort != "" {
+		params = append(params, "sort="+url.QueryEscape(req.Sort))
+	}
+	if req.Limit > 0 {
+		params = append(params, "limit="+strconv.Itoa(req.Limit))
+	}
+	if req.Offset > 0 {
+		params = append(params, "offset="+strconv.Itoa(req.Offset))
+	}
+	if req.Cursor != "" {
+		params = append(params, "cursor="+url.QueryEscape(req.Cursor))
+	}
 	if len(params) > 0 {
 		path += "?" + strings.Join(params, "&")
 	}
@@ -279,11 +383,59 @@ func (c *Client) DeleteMission(id string) error {
 	return c.Delete("/missions/" + id)
 }
 
+// DeleteMissionWithRemoteCleanup permanently removes a mission via the
+// server, overriding cleanupRemoteOnDelete with cleanupRemote.
+func (c *Client) DeleteMissionWithRemoteCleanup(id string, cleanupRemote bool) (*DeleteMissionResponse, error) {
+	var resp DeleteMissionResponse
+	path := "/missions/" + id + "?cleanup_remote=" + strconv.FormatBool(cleanupRemote)
+	if err := c.deleteWith(path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMissionRemoteCleanup reports what deleting a mission with remote cleanup
+// would remove from origin.
+func (c *Client) GetMissionRemoteCleanup(id string) (*RemoteCleanupResponse, error) {
+	var resp RemoteCleanupResponse
+	if err := c.Get("/missions/"+id+"/remote-cleanup", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // ArchiveMission stops and archives a mission via the server.
 func (c *Client) ArchiveMission(id string) error {
 	return c.Post("/missions/"+id+"/archive", nil, nil)
 }
 
+// ArchiveMissionWithHandoff stops and archives a mission via the server,
+// first recording handoffNote as its handoff note.
+func (c *Client) ArchiveMissionWithHandoff(id string, handoffNote string) error {
+	return c.Post("/missions/"+id+"/archive", ArchiveMissionRequest{HandoffNote: handoffNote}, nil)
+}
+
+// GCMissions runs the mission retention policy now, or reports what it would
+// do when dryRun is set. Skips the request timeout since deleting many
+// mission directories can take a while.
+func (c *Client) GCMissions(dryRun bool) (*MissionGCResponse, error) {
+	var resp MissionGCResponse
+	if err := c.postLongRunning("/missions/gc", MissionGCRequest{DryRun: dryRun}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// BatchMissions applies a stop, archive, or delete action to every mission
+// matching a filter via the server.
+func (c *Client) BatchMissions(req MissionBatchRequest) (*MissionBatchResponse, error) {
+	var resp MissionBatchResponse
+	if err := c.postLongRunning("/missions/batch", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // UnarchiveMission sets a mission back to active via the server.
 func (c *Client) UnarchiveMission(id string) error {
 	return c.Post("/missions/"+id+"/unarchive", nil, nil)
@@ -308,9 +460,29 @@ func (c *Client) Heartbeat(id string, paneID string, lastUserPromptAt string) er
 	return c.Post("/missions/"+id+"/heartbeat", body, nil)
 }
 
-// RecordPrompt increments prompt_count for a mission.
-func (c *Client) RecordPrompt(id string) error {
-	return c.Post("/missions/"+id+"/prompt", nil, nil)
+// RecordPrompt increments prompt_count for a mission. A non-empty prompt is
+// also appended to the mission's prompt history.
+func (c *Client) RecordPrompt(id string, prompt string) error {
+	return c.Post("/missions/"+id+"/prompt", RecordPromptRequest{Prompt: prompt}, nil)
+}
+
+// ListMissionPrompts returns the prompts submitted to a mission, oldest first.
+func (c *Client) ListMissionPrompts(id string) ([]MissionPromptResponse, error) {
+	var prompts []MissionPromptResponse
+	if err := c.Get("/missions/"+id+"/prompts", &prompts); err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+// GetMissionHandoff fetches a mission's latest handoff note. The response's
+// Note is empty when there is none.
+func (c *Client) GetMissionHandoff(id string) (*MissionHandoffResponse, error) {
+	var resp MissionHandoffResponse
+	if err := c.Get("/missions/"+id+"/handoff", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 // ReloadMission reloads a mission's wrapper via the server. When prompt is
@@ -323,6 +495,31 @@ func (c *Client) ReloadMission(id string, prompt string, async bool) error {
 	return c.Post("/missions/"+id+"/reload", body, nil)
 }
 
+// ListStaleConfigMissions returns the running missions whose Claude config is
+// behind the shadow repo HEAD.
+func (c *Client) ListStaleConfigMissions() (*StaleConfigResponse, error) {
+	var resp StaleConfigResponse
+	if err := c.Get("/missions/stale-config", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListMissionConflicts returns the groups of active missions working on the
+// same branch of a repo, with the files more than one of them modifies. A
+// non-empty repo restricts it to that repo.
+func (c *Client) ListMissionConflicts(repo string) ([]MissionConflictGroup, error) {
+	path := "/missions/conflicts"
+	if repo != "" {
+		path += "?repo=" + url.QueryEscape(repo)
+	}
+	var groups []MissionConflictGroup
+	if err := c.Get(path, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
 // NotifyClaudeIdle tells the server that claude has just become idle for a
 // mission. Used by the wrapper to drive async-queued reloads. Best-effort:
 // the server fires any pending reload for the mission on this signal.
@@ -330,8 +527,108 @@ func (c *Client) NotifyClaudeIdle(id string) error {
 	return c.Post("/missions/"+id+"/claude-idle", nil, nil)
 }
 
+// PublishEvent publishes an event onto the server's event bus. Used by
+// wrappers to announce changes only they observe.
+func (c *Client) PublishEvent(eventType string, missionID string, data map[string]string) error {
+	return c.Post("/events", PublishEventRequest{Type: eventType, MissionID: missionID, Data: data}, nil)
+}
+
+// ListEvents returns the server's recent events, oldest first, filtered to
+// the given types (all when empty) and mission (all when empty).
+func (c *Client) ListEvents(types []string, missionID string) ([]Event, error) {
+	var events []Event
+	if err := c.Get("/events"+eventsQuery(types, missionID, false), &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListMissionEvents returns a mission's recorded activity timeline, oldest
+// first. A non-zero since skips earlier events; a limit of zero returns the
+// whole timeline.
+func (c *Client) ListMissionEvents(id string, since time.Time, limit int) ([]Event, error) {
+	query := url.Values{"limit": {strconv.Itoa(limit)}}
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	var events []Event
+	if err := c.Get("/missions/"+id+"/events?"+query.Encode(), &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// StreamEvents follows the server's event bus over Server-Sent Events,
+// calling onEvent for each matching event until ctx is cancelled or the
+// server closes the stream. The stream starts with the recent history.
+func (c *Client) StreamEvents(ctx context.Context, types []string, missionID string, onEvent func(Event)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/events"+eventsQuery(types, missionID, true), nil)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to build request")
+	}
+
+	// The stream is long-lived, so reuse the transport without the default timeout
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return connectError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return c.decodeError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			continue
+		}
+		onEvent(event)
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return stacktrace.Propagate(err, "failed to read event stream")
+	}
+	return nil
+}
+
+func eventsQuery(types []string, missionID string, follow bool) string {
+	params := url.Values{}
+	if len(types) > 0 {
+		params.Set("type", strings.Join(types, ","))
+	}
+	if missionID != "" {
+		params.Set("mission", missionID)
+	}
+	if follow {
+		params.Set("follow", "true")
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + params.Encode()
+}
+
+// ReportMissionExit tells the server that claude exited on its own with the
+// given exit code. Used by the wrapper so cron run history records failures.
+// lastError summarizes an unexpected exit; pass "" otherwise.
+func (c *Client) ReportMissionExit(id string, exitCode int, lastError string) error {
+	body := MissionExitRequest{ExitCode: exitCode, LastError: lastError}
+	return c.Post("/missions/"+id+"/exit", body, nil)
+}
+
 // AttachMission ensures the mission's wrapper is running in the pool and links
-// the pool window into the given tmux session. The caller is responsible for
+// the pool window into the given tmux session, or the mission's group session
+// when tmux.layout groups it. The caller is responsible for
 // supplying the session the user is currently attached to — pane-ID-based
 // resolution is not used here because a mission's pane can be linked into
 // multiple sessions.
@@ -340,6 +637,21 @@ func (c *Client) AttachMission(id string, tmuxSession string, noFocus bool) erro
 	return c.Post("/missions/"+id+"/attach", body, nil)
 }
 
+// AttachMissionToSession is AttachMission linking into exactly tmuxSession,
+// ignoring tmux.layout, for a session created to show only this mission.
+func (c *Client) AttachMissionToSession(id string, tmuxSession string) error {
+	body := AttachRequest{TmuxSession: tmuxSession, ExactSession: true}
+	return c.Post("/missions/"+id+"/attach", body, nil)
+}
+
+// AttachMissionSplit ensures the mission's wrapper is running in the pool and
+// joins its pane into the window of callerPane (without "%" prefix) as a
+// side-by-side split.
+func (c *Client) AttachMissionSplit(id string, tmuxSession string, callerPane string, noFocus bool) error {
+	body := AttachRequest{TmuxSession: tmuxSession, NoFocus: noFocus, Split: true, CallerPane: callerPane}
+	return c.Post("/missions/"+id+"/attach", body, nil)
+}
+
 // DetachMission unlinks the mission's pool window from the given tmux session.
 // The wrapper keeps running in the pool. Same session-resolution caveat as
 // AttachMission.
@@ -354,6 +666,12 @@ func (c *Client) SendKeys(id string, keys []string) error {
 	return c.Post("/missions/"+id+"/send-keys", body, nil)
 }
 
+// SendMissionMessage delivers message to a running mission's Claude as a
+// prompt.
+func (c *Client) SendMissionMessage(id string, message string) error {
+	return c.Post("/missions/"+id+"/send", SendMissionMessageRequest{Message: message}, nil)
+}
+
 // CreateMission creates a new mission via the server.
 func (c *Client) CreateMission(req CreateMissionRequest) (*database.Mission, error) {
 	var resp MissionResponse
@@ -363,6 +681,16 @@ func (c *Client) CreateMission(req CreateMissionRequest) (*database.Mission, err
 	return resp.ToMission(), nil
 }
 
+// ListMissionQueue returns the missions waiting for a slot under
+// missionsMaxConcurrent, in start order.
+func (c *Client) ListMissionQueue() ([]QueuedMissionResponse, error) {
+	var entries []QueuedMissionResponse
+	if err := c.Get("/missions/queue", &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 // SearchMissions searches missions by FTS query and returns ranked results.
 func (c *Client) SearchMissions(query string, limit int) ([]SearchMissionsResponse, error) {
 	var results []SearchMissionsResponse
@@ -373,6 +701,17 @@ func (c *Client) SearchMissions(query string, limit int) ([]SearchMissionsRespon
 	return results, nil
 }
 
+// SearchTranscripts scans every mission's session transcripts for messages
+// containing query and returns up to limit matches, newest first.
+func (c *Client) SearchTranscripts(query string, limit int) ([]TranscriptMatchResponse, error) {
+	var results []TranscriptMatchResponse
+	path := fmt.Sprintf("/missions/search/transcripts?q=%s&limit=%d", url.QueryEscape(query), limit)
+	if err := c.getLongRunning(path, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // ListSessions fetches all sessions across all missions.
 func (c *Client) ListSessions() ([]*database.Session, error) {
 	var responses []SessionResponse
@@ -407,6 +746,245 @@ func (c *Client) UpdateSession(sessionID string, req UpdateSessionRequest) error
 	return c.Patch("/sessions/"+sessionID, req, nil)
 }
 
+// ReportMissionStats sends a wrapper usage report for a mission.
+func (c *Client) ReportMissionStats(id string, report MissionStatsReport) error {
+	return c.Post("/missions/"+id+"/stats", report, nil)
+}
+
+// GetMissionStats fetches resource usage stats for a single mission.
+func (c *Client) GetMissionStats(id string) (*MissionStatsResponse, error) {
+	var resp MissionStatsResponse
+	if err := c.Get("/missions/"+id+"/stats", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListMissionStats fetches resource usage stats for all missions that have
+// reported usage, ordered by total tokens descending.
+func (c *Client) ListMissionStats() ([]MissionStatsResponse, error) {
+	var resp []MissionStatsResponse
+	if err := c.Get("/missions/stats", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RecordBusyPeriod reports a span Claude spent working in a mission.
+func (c *Client) RecordBusyPeriod(id string, startedAt time.Time, endedAt time.Time) error {
+	return c.Post("/missions/"+id+"/busy-periods", BusyPeriodReport{StartedAt: startedAt, EndedAt: endedAt}, nil)
+}
+
+// GetAgentTimeReport fetches busy time per repo and per cron job within
+// [from, to).
+func (c *Client) GetAgentTimeReport(from time.Time, to time.Time) (*AgentTimeReport, error) {
+	query := url.Values{
+		"from": {from.Format(time.RFC3339)},
+		"to":   {to.Format(time.RFC3339)},
+	}
+	var resp AgentTimeReport
+	if err := c.Get("/reports/agent-time?"+query.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDailyDigest builds the digest for the local day date (YYYY-MM-DD; empty
+// for yesterday) and renders it with the configured template.
+func (c *Client) GetDailyDigest(date string) (*DailyDigestResponse, error) {
+	path := "/reports/digest"
+	if date != "" {
+		path += "?" + url.Values{"date": {date}}.Encode()
+	}
+	var resp DailyDigestResponse
+	if err := c.Get(path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMissionOutput fetches a mission's log as plain text. source is "claude"
+// (claude-output.log) or "wrapper" (wrapper.log). When all is false, only the
+// last 200 lines are returned.
+func (c *Client) GetMissionOutput(id string, source string, all bool) ([]byte, error) {
+	path := "/missions/" + id + "/output?source=" + source
+	if all {
+		path += "&mode=all"
+	}
+	return c.GetRaw(path)
+}
+
+// StreamMissionOutput follows a mission's log over Server-Sent Events, calling
+// onLine for each line until ctx is cancelled or the server closes the stream.
+// The initial backlog is the last 200 lines, or the whole file when all is true.
+func (c *Client) StreamMissionOutput(ctx context.Context, id string, source string, all bool, onLine func(string)) error {
+	path := "/missions/" + id + "/output?follow=true&source=" + source
+	if all {
+		path += "&mode=all"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to build request")
+	}
+
+	// The stream is long-lived, so reuse the transport without the default timeout
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return connectError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return c.decodeError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			onLine(data)
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return stacktrace.Propagate(err, "failed to read output stream")
+	}
+	return nil
+}
+
+// OpenMissionPR commits a mission's outstanding changes, pushes its branch,
+// and opens (or finds) its GitHub pull request. Skips the 30s request timeout
+// since pushing and talking to GitHub can be slow.
+func (c *Client) OpenMissionPR(id string, req MissionPRRequest) (*MissionPRResponse, error) {
+	var resp MissionPRResponse
+	if err := c.postLongRunning("/missions/"+id+"/pr", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ApproveMission fast-forwards a reviewRequired mission's branch to its
+// holding branch. An empty branch uses the mission's checked-out branch.
+// Skips the 30s request timeout since fetching and pushing can be slow.
+func (c *Client) ApproveMission(id string, branch string) (*ApproveMissionResponse, error) {
+	var resp ApproveMissionResponse
+	if err := c.postLongRunning("/missions/"+id+"/approve", ApproveMissionRequest{Branch: branch}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExportMission writes a bundle of a stopped mission to outputPath, which
+// must be absolute.
+func (c *Client) ExportMission(id string, outputPath string) (*ExportMissionResponse, error) {
+	var resp ExportMissionResponse
+	if err := c.postLongRunning("/missions/"+id+"/export", ExportMissionRequest{OutputPath: outputPath}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportMission recreates a mission from the bundle at bundlePath, which must
+// be absolute.
+func (c *Client) ImportMission(bundlePath string) (*database.Mission, error) {
+	var resp MissionResponse
+	if err := c.postLongRunning("/missions/import", ImportMissionRequest{BundlePath: bundlePath}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.ToMission(), nil
+}
+
+// GetMissionBranch returns the branch checked out in a mission's workspace.
+func (c *Client) GetMissionBranch(id string) (*MissionBranchResponse, error) {
+	var resp MissionBranchResponse
+	if err := c.Get("/missions/"+id+"/branch", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMissionDiff returns a mission workspace's git status and its diff
+// against the commit the mission started from, with the full patch when
+// includePatch is set.
+func (c *Client) GetMissionDiff(id string, includePatch bool) (*MissionDiffResponse, error) {
+	path := "/missions/" + id + "/diff"
+	if includePatch {
+		path += "?patch=true"
+	}
+	var resp MissionDiffResponse
+	if err := c.Get(path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListMissionFiles lists relpath in a mission's agent directory ("" for the
+// directory itself), walking the whole tree below it when recursive is set.
+// Works for archived missions without unarchiving them.
+func (c *Client) ListMissionFiles(id string, relpath string, recursive bool) (*MissionFilesResponse, error) {
+	query := url.Values{"path": {relpath}}
+	if recursive {
+		query.Set("recursive", "true")
+	}
+	var resp MissionFilesResponse
+	if err := c.Get("/missions/"+id+"/files?"+query.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMissionFileContent fetches the raw contents of the file at relpath in a
+// mission's agent directory.
+func (c *Client) GetMissionFileContent(id string, relpath string) ([]byte, error) {
+	return c.GetRaw("/missions/" + id + "/files/content?" + url.Values{"path": {relpath}}.Encode())
+}
+
+// SetMissionBranch switches a mission's workspace to branch, creating it from
+// the current HEAD when create is set.
+func (c *Client) SetMissionBranch(id string, branch string, create bool) (*MissionBranchResponse, error) {
+	var resp MissionBranchResponse
+	if err := c.Post("/missions/"+id+"/branch", SetMissionBranchRequest{Branch: branch, Create: create}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RepointMission moves a mission's workspace to another repo in the library.
+// Skips the 30s request timeout since copying a large repo can be slow.
+func (c *Client) RepointMission(id string, repo string, mode string) (*RepointMissionResponse, error) {
+	var resp RepointMissionResponse
+	if err := c.postLongRunning("/missions/"+id+"/repoint", RepointMissionRequest{Repo: repo, Mode: mode}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SummarizeMission generates the mission's AI summary now and returns the
+// updated mission. Skips the 30s request timeout since the summarizer call
+// itself may take that long.
+func (c *Client) SummarizeMission(id string) (*database.Mission, error) {
+	var resp MissionResponse
+	if err := c.postLongRunning("/missions/"+id+"/summarize", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.ToMission(), nil
+}
+
+// ShareMission grants user access to a mission in multi-user mode, or revokes
+// it when remove is true.
+func (c *Client) ShareMission(id string, user string, remove bool) (*ShareMissionResponse, error) {
+	var resp ShareMissionResponse
+	if err := c.Post("/missions/"+id+"/share", ShareMissionRequest{User: user, Remove: remove}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // ============================================================================
 // High-level repo API methods
 // ============================================================================
@@ -429,6 +1007,25 @@ func (c *Client) AddRepo(req AddRepoRequest) (*AddRepoResponse, error) {
 	return &resp, nil
 }
 
+// CreateRepo creates a GitHub repo and adds it to the library via the server.
+func (c *Client) CreateRepo(req CreateRepoRequest) (*CreateRepoResponse, error) {
+	var resp CreateRepoResponse
+	if err := c.postLongRunning("/repos/create", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ForkRepo forks a GitHub repo and adds it and its upstream to the library
+// via the server.
+func (c *Client) ForkRepo(req ForkRepoRequest) (*ForkRepoResponse, error) {
+	var resp ForkRepoResponse
+	if err := c.postLongRunning("/repos/fork", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // RemoveRepo removes a repo via the server.
 func (c *Client) RemoveRepo(repoName string) error {
 	return c.Delete("/repos/" + repoName)
@@ -440,6 +1037,46 @@ func (c *Client) MoveRepo(oldName, newName string) error {
 	return c.Post("/repos/"+oldName+"/mv", req, nil)
 }
 
+// ============================================================================
+// High-level project API methods
+// ============================================================================
+
+// ListProjects fetches all projects from the server, ordered by name.
+func (c *Client) ListProjects() ([]ProjectResponse, error) {
+	var result []ProjectResponse
+	if err := c.Get("/projects", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetProject fetches a single project by name.
+func (c *Client) GetProject(name string) (*ProjectResponse, error) {
+	var result ProjectResponse
+	if err := c.Get("/projects/"+url.PathEscape(name), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateProject creates a new project and returns it.
+func (c *Client) CreateProject(req CreateProjectRequest) (*ProjectResponse, error) {
+	var result ProjectResponse
+	if err := c.Post("/projects", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateProject changes a project's repo and/or notes and returns the result.
+func (c *Client) UpdateProject(name string, req UpdateProjectRequest) (*ProjectResponse, error) {
+	var result ProjectResponse
+	if err := c.Patch("/projects/"+url.PathEscape(name), req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ============================================================================
 // High-level claude-modifications API methods
 // ============================================================================
@@ -501,6 +1138,57 @@ func (c *Client) UpdateSettingsJson(content string, expectedHash string) (*Claud
 	return &resp, nil
 }
 
+// SyncShadowRemote syncs the Claude config shadow repo with its remote now.
+// prefer ("local", "remote", or empty) picks the winner of conflicting edits.
+func (c *Client) SyncShadowRemote(prefer string) (*ShadowSyncResponse, error) {
+	var resp ShadowSyncResponse
+	if err := c.Post("/config/shadow/sync", ShadowSyncRequest{Prefer: prefer}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ============================================================================
+// High-level skills API methods
+// ============================================================================
+
+// ListSkills fetches the skills under ~/.claude/skills.
+func (c *Client) ListSkills() ([]SkillInfo, error) {
+	var skills []SkillInfo
+	if err := c.Get("/skills", &skills); err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+// AddSkill installs a skill from a repo via the server. Cloning can outlast
+// the client's request timeout, so none is applied.
+func (c *Client) AddSkill(req AddSkillRequest) (*SkillChangeResponse, error) {
+	var resp SkillChangeResponse
+	if err := c.postLongRunning("/skills", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateSkill pulls the latest version of an installed skill.
+func (c *Client) UpdateSkill(name string) (*SkillChangeResponse, error) {
+	var resp SkillChangeResponse
+	if err := c.postLongRunning("/skills/"+url.PathEscape(name)+"/update", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveSkill uninstalls a skill installed from a repo.
+func (c *Client) RemoveSkill(name string) (*SkillChangeResponse, error) {
+	var resp SkillChangeResponse
+	if err := c.deleteWith("/skills/"+url.PathEscape(name), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // ============================================================================
 // High-level server API methods
 // ============================================================================
@@ -537,6 +1225,15 @@ func (c *Client) CreateCron(req CreateCronRequest) (*CronInfo, error) {
 	return &result, nil
 }
 
+// CreateCronAt schedules a one-shot cron job via the server.
+func (c *Client) CreateCronAt(req CreateCronAtRequest) (*CronInfo, error) {
+	var result CronInfo
+	if err := c.Post("/crons/at", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // UpdateCron updates an existing cron job via the server.
 func (c *Client) UpdateCron(name string, req UpdateCronRequest) (*CronInfo, error) {
 	var result CronInfo
@@ -561,6 +1258,35 @@ func (c *Client) GetCronLogs(cronID string, all bool) ([]byte, error) {
 	return c.GetRaw(path)
 }
 
+// ListCronRuns fetches the run history of a cron job (by name or ID), newest
+// first. A limit of zero returns every recorded run.
+func (c *Client) ListCronRuns(nameOrID string, limit int) ([]CronRunResponse, error) {
+	var runs []CronRunResponse
+	path := "/crons/" + url.PathEscape(nameOrID) + "/runs?limit=" + strconv.Itoa(limit)
+	if err := c.Get(path, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// ListCronStats fetches the run stats of every configured cron job.
+func (c *Client) ListCronStats() ([]CronStats, error) {
+	var stats []CronStats
+	if err := c.Get("/crons/stats", &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetCronStats fetches the run stats of a cron job by name or ID.
+func (c *Client) GetCronStats(nameOrID string) (*CronStats, error) {
+	var stats CronStats
+	if err := c.Get("/crons/"+url.PathEscape(nameOrID)+"/stats", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
 // ============================================================================
 // High-level stash API methods
 // ============================================================================
@@ -588,7 +1314,7 @@ func (c *Client) PushStash(force bool) (*StashPushResponse, *StashPushConflictRe
 
 	resp, err := c.httpClient.Post(c.baseURL+"/stash/push", "application/json", pr)
 	if err != nil {
-		return nil, nil, stacktrace.Propagate(err, "failed to connect to server")
+		return nil, nil, connectError(err)
 	}
 	defer resp.Body.Close()
 
@@ -638,6 +1364,36 @@ func (c *Client) GetHealth() (*HealthResponse, error) {
 	return &result, nil
 }
 
+// GetVersion calls GET /version and returns the server's release and API
+// versions.
+func (c *Client) GetVersion() (*VersionResponse, error) {
+	var result VersionResponse
+	if err := c.Get("/version", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetServerStatus calls GET /server/status and returns the server's uptime,
+// queue, and cron scheduling state.
+func (c *Client) GetServerStatus() (*ServerStatusResponse, error) {
+	var result ServerStatusResponse
+	if err := c.Get("/server/status", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetConnectivity calls GET /server/connectivity and returns whether git
+// remotes are reachable and the repo syncs deferred until they are.
+func (c *Client) GetConnectivity() (*ConnectivityResponse, error) {
+	var result ConnectivityResponse
+	if err := c.Get("/server/connectivity", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ============================================================================
 // High-level sleep API methods
 // ============================================================================
@@ -745,3 +1501,14 @@ func (c *Client) decodeError(resp *http.Response) error {
 	}
 	return fmt.Errorf("%s", errResp.Message)
 }
+
+// connectError wraps a failed request. An API version mismatch caught by the
+// client's transport is returned as-is, since its message already says what
+// to do.
+func connectError(err error) error {
+	var apiErr *version.IncompatibleAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return stacktrace.Propagate(err, "failed to connect to server")
+}
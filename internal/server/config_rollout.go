@@ -0,0 +1,194 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const configStaleNotificationKind = "config.stale"
+
+// StaleMissionResponse describes a running mission whose Claude config was
+// built from an older shadow repo commit than the current HEAD.
+type StaleMissionResponse struct {
+	MissionID    string `json:"mission_id"`
+	ShortID      string `json:"short_id"`
+	GitRepo      string `json:"git_repo"`
+	ConfigCommit string `json:"config_commit"`
+
+	// CommitsBehind is how many shadow repo commits the mission's config is
+	// behind HEAD, or -1 when its commit is no longer in the shadow repo's
+	// history (e.g. after the shadow repo was recreated).
+	CommitsBehind int `json:"commits_behind"`
+
+	// Headless is true for missions without a tmux pane (e.g. cron runs).
+	// They can't be reloaded in place and pick up the new config on their
+	// next run.
+	Headless bool `json:"headless"`
+}
+
+// StaleConfigResponse is the response for GET /missions/stale-config.
+type StaleConfigResponse struct {
+	HeadCommit string                 `json:"head_commit"`
+	Missions   []StaleMissionResponse `json:"missions"`
+}
+
+// handleListStaleConfigMissions handles GET /missions/stale-config: the
+// running missions whose config is behind the shadow repo HEAD.
+func (s *Server) handleListStaleConfigMissions(w http.ResponseWriter, r *http.Request) error {
+	resp, err := s.listStaleConfigMissions()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
+
+// listStaleConfigMissions compares each running mission's config commit
+// against the shadow repo HEAD. Missions that never recorded a config commit
+// are skipped: there is nothing to compare.
+func (s *Server) listStaleConfigMissions() (StaleConfigResponse, error) {
+	headCommit := claudeconfig.GetShadowRepoCommitHash(s.agencDirpath)
+	resp := StaleConfigResponse{HeadCommit: headCommit, Missions: []StaleMissionResponse{}}
+	if headCommit == "" {
+		return resp, nil
+	}
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{})
+	if err != nil {
+		return resp, err
+	}
+	var running []*database.Mission
+	for _, m := range missions {
+		if s.isWrapperRunning(m.ID) {
+			running = append(running, m)
+		}
+	}
+
+	resp.Missions = buildStaleMissions(running, headCommit, func(missionCommit string) int {
+		return claudeconfig.CountCommitsBehind(s.agencDirpath, missionCommit, headCommit)
+	})
+	return resp, nil
+}
+
+// buildStaleMissions returns the missions whose config commit differs from
+// headCommit, with commitsBehind supplying the distance from each commit to
+// HEAD.
+func buildStaleMissions(missions []*database.Mission, headCommit string, commitsBehind func(missionCommit string) int) []StaleMissionResponse {
+	stale := []StaleMissionResponse{}
+	for _, m := range missions {
+		if m.ConfigCommit == nil || *m.ConfigCommit == "" || *m.ConfigCommit == headCommit {
+			continue
+		}
+		behind := commitsBehind(*m.ConfigCommit)
+		if behind == 0 {
+			continue
+		}
+		stale = append(stale, StaleMissionResponse{
+			MissionID:     m.ID,
+			ShortID:       m.ShortID,
+			GitRepo:       m.GitRepo,
+			ConfigCommit:  *m.ConfigCommit,
+			CommitsBehind: behind,
+			Headless:      m.TmuxPane == nil || *m.TmuxPane == "",
+		})
+	}
+	return stale
+}
+
+// checkShadowHeadChanged runs after each change to the shadow repo (ingest
+// or remote sync); callers hold shadowMu. When HEAD moved, it finds the
+// running missions left on the old config and, if there are any, offers to
+// roll the new config out to them. Best-effort: failures are logged.
+func (s *Server) checkShadowHeadChanged() {
+	headCommit := claudeconfig.GetShadowRepoCommitHash(s.agencDirpath)
+	if headCommit == "" || headCommit == s.shadowHead {
+		return
+	}
+	s.shadowHead = headCommit
+
+	resp, err := s.listStaleConfigMissions()
+	if err != nil {
+		s.logger.Printf("Config watcher: failed to find missions with stale config: %v", err)
+		return
+	}
+	s.publishEvent(EventConfigUpdated, "", map[string]string{
+		"head_commit":    headCommit,
+		"stale_missions": fmt.Sprintf("%d", len(resp.Missions)),
+	})
+	if len(resp.Missions) == 0 {
+		return
+	}
+	s.logger.Printf("Config watcher: shadow repo HEAD is now %s; %d running mission(s) on older config", shortCommit(headCommit), len(resp.Missions))
+
+	// Headless missions pick up the new config on their next run; only
+	// missions that can be reloaded in place are worth a notification.
+	var reloadable []StaleMissionResponse
+	for _, m := range resp.Missions {
+		if !m.Headless {
+			reloadable = append(reloadable, m)
+		}
+	}
+	if len(reloadable) > 0 {
+		s.notifyStaleConfig(reloadable)
+	}
+}
+
+// notifyStaleConfig leaves a notification suggesting a graceful rollout.
+// Skipped while an earlier one is still unread, so a burst of config edits
+// doesn't pile up notifications.
+func (s *Server) notifyStaleConfig(stale []StaleMissionResponse) {
+	unread, err := s.db.ListNotifications(database.ListNotificationsParams{UnreadOnly: true, Kind: configStaleNotificationKind})
+	if err != nil {
+		s.logger.Printf("Config watcher: failed to list stale config notifications: %v", err)
+		return
+	}
+	if len(unread) > 0 {
+		return
+	}
+	if err := s.db.CreateNotification(buildStaleConfigNotification(stale)); err != nil {
+		s.logger.Printf("Config watcher: failed to create stale config notification: %v", err)
+	}
+}
+
+// buildStaleConfigNotification constructs the notification listing the
+// missions behind the shadow repo HEAD.
+func buildStaleConfigNotification(stale []StaleMissionResponse) *database.Notification {
+	lines := make([]string, 0, len(stale))
+	for _, m := range stale {
+		lines = append(lines, "- "+m.ShortID+": "+FormatCommitsBehind(m.CommitsBehind))
+	}
+	body := strings.Join(lines, "\n") +
+		"\n\nRoll the new config out with `agenc mission reload --stale --graceful`; each mission reloads once Claude is idle."
+
+	return &database.Notification{
+		ID:           uuid.New().String(),
+		Kind:         configStaleNotificationKind,
+		Title:        fmt.Sprintf("Claude config changed: %d running mission(s) on older config", len(stale)),
+		BodyMarkdown: body,
+	}
+}
+
+// FormatCommitsBehind renders a StaleMissionResponse.CommitsBehind value.
+func FormatCommitsBehind(behind int) string {
+	switch {
+	case behind < 0:
+		return "config commit no longer in history"
+	case behind == 1:
+		return "1 commit behind"
+	default:
+		return fmt.Sprintf("%d commits behind", behind)
+	}
+}
+
+func shortCommit(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
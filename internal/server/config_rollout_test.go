@@ -0,0 +1,62 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestBuildStaleMissions(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	missions := []*database.Mission{
+		{ID: "current", ShortID: "current", ConfigCommit: strPtr("head"), TmuxPane: strPtr("%1")},
+		{ID: "behind", ShortID: "behind", GitRepo: "github.com/a/b", ConfigCommit: strPtr("old"), TmuxPane: strPtr("%2")},
+		{ID: "unknown", ShortID: "unknown", ConfigCommit: strPtr("gone"), TmuxPane: strPtr("%3")},
+		{ID: "headless", ShortID: "headless", ConfigCommit: strPtr("old")},
+		{ID: "no-commit", ShortID: "no-commit"},
+		{ID: "same-tree", ShortID: "same-tree", ConfigCommit: strPtr("equal")},
+	}
+	behindByCommit := map[string]int{"old": 3, "gone": -1, "equal": 0}
+
+	stale := buildStaleMissions(missions, "head", func(missionCommit string) int {
+		return behindByCommit[missionCommit]
+	})
+
+	want := []StaleMissionResponse{
+		{MissionID: "behind", ShortID: "behind", GitRepo: "github.com/a/b", ConfigCommit: "old", CommitsBehind: 3},
+		{MissionID: "unknown", ShortID: "unknown", ConfigCommit: "gone", CommitsBehind: -1},
+		{MissionID: "headless", ShortID: "headless", ConfigCommit: "old", CommitsBehind: 3, Headless: true},
+	}
+	if len(stale) != len(want) {
+		t.Fatalf("got %d stale missions, want %d: %+v", len(stale), len(want), stale)
+	}
+	for i := range want {
+		if stale[i] != want[i] {
+			t.Errorf("stale[%d] = %+v, want %+v", i, stale[i], want[i])
+		}
+	}
+}
+
+func TestBuildStaleConfigNotification(t *testing.T) {
+	n := buildStaleConfigNotification([]StaleMissionResponse{
+		{ShortID: "aaaa1111", CommitsBehind: 1},
+		{ShortID: "bbbb2222", CommitsBehind: -1},
+	})
+
+	if n.Kind != configStaleNotificationKind {
+		t.Errorf("Kind = %q, want %q", n.Kind, configStaleNotificationKind)
+	}
+	if !strings.Contains(n.Title, "2 running mission(s)") {
+		t.Errorf("Title = %q, want it to count 2 missions", n.Title)
+	}
+	for _, want := range []string{
+		"aaaa1111: 1 commit behind",
+		"bbbb2222: config commit no longer in history",
+		"agenc mission reload --stale --graceful",
+	} {
+		if !strings.Contains(n.BodyMarkdown, want) {
+			t.Errorf("body missing %q:\n%s", want, n.BodyMarkdown)
+		}
+	}
+}
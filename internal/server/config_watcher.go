@@ -22,7 +22,7 @@ const (
 // initial ingest from ~/.claude, then watches for ongoing changes.
 // It also watches the agenc config.yml file for changes to trigger cron syncing.
 func (s *Server) runConfigWatcherLoop(ctx context.Context) {
-	userClaudeDirpath, err := config.GetUserClaudeDirpath()
+	userClaudeDirpath, err := s.getConfig().UserClaudeDirpath()
 	if err != nil {
 		s.logger.Printf("Config watcher: failed to determine ~/.claude path: %v", err)
 		return
@@ -41,6 +41,10 @@ func (s *Server) runConfigWatcherLoop(ctx context.Context) {
 	// otherwise serve stale content until a notify event fires.
 	s.ingestClaudeConfig(userClaudeDirpath, shadowDirpath)
 
+	// Record the config as it stands at startup so edits made while the
+	// server was down show up as their own history entry.
+	s.snapshotConfig()
+
 	s.logger.Println("Config watcher: shadow repo ready, starting watch")
 
 	s.watchBothConfigs(ctx, userClaudeDirpath, shadowDirpath)
@@ -67,6 +71,12 @@ func (s *Server) watchBothConfigs(ctx context.Context, userClaudeDirpath string,
 		s.logger.Printf("Config watcher: failed to watch agenc config.yml: %v", err)
 	}
 
+	// Watch claude-modifications so edits to it are recorded in config history.
+	claudeModificationsDirpath := config.GetClaudeModificationsDirpath(s.agencDirpath)
+	if err := notify.Watch(filepath.Join(claudeModificationsDirpath, "..."), eventCh, notify.All); err != nil {
+		s.logger.Printf("Config watcher: failed to watch claude-modifications: %v", err)
+	}
+
 	// Watch the ~/.claude tracked directories (recursive on macOS via FSEvents).
 	s.watchTrackedDirs(eventCh, userClaudeDirpath)
 
@@ -75,6 +85,7 @@ func (s *Server) watchBothConfigs(ctx context.Context, userClaudeDirpath string,
 
 	var claudeDebounceTimer *time.Timer
 	var agencDebounceTimer *time.Timer
+	var historyDebounceTimer *time.Timer
 
 	for {
 		select {
@@ -85,6 +96,9 @@ func (s *Server) watchBothConfigs(ctx context.Context, userClaudeDirpath string,
 			if agencDebounceTimer != nil {
 				agencDebounceTimer.Stop()
 			}
+			if historyDebounceTimer != nil {
+				historyDebounceTimer.Stop()
+			}
 			return
 
 		case event := <-eventCh:
@@ -94,10 +108,19 @@ func (s *Server) watchBothConfigs(ctx context.Context, userClaudeDirpath string,
 				}
 				agencDebounceTimer = time.AfterFunc(ingestDebounce, func() {
 					s.reloadConfig()
+					s.snapshotConfig()
 				})
 				continue
 			}
 
+			if isPathUnder(event.Path(), claudeModificationsDirpath) {
+				if historyDebounceTimer != nil {
+					historyDebounceTimer.Stop()
+				}
+				historyDebounceTimer = time.AfterFunc(ingestDebounce, s.snapshotConfig)
+				continue
+			}
+
 			if !isTrackedPath(event.Path(), userClaudeDirpath) {
 				continue
 			}
@@ -186,8 +209,33 @@ func isPathUnder(child string, parent string) bool {
 
 // ingestClaudeConfig runs the ingest from ~/.claude to the shadow repo.
 func (s *Server) ingestClaudeConfig(userClaudeDirpath string, shadowDirpath string) {
+	s.shadowMu.Lock()
+	defer s.shadowMu.Unlock()
 	if err := claudeconfig.IngestFromClaudeDir(userClaudeDirpath, shadowDirpath); err != nil {
 		s.logger.Printf("Config watcher: ingest failed: %v", err)
+		return
+	}
+	s.checkShadowHeadChanged()
+}
+
+// snapshotConfig records the current config directory in the config history
+// repo. Holds the config lock so snapshots never interleave with each other
+// or with a CLI rollback. Best-effort: failures are logged.
+func (s *Server) snapshotConfig() {
+	release, err := config.AcquireConfigLock(s.agencDirpath)
+	if err != nil {
+		s.logger.Printf("Config watcher: failed to acquire config lock for history snapshot: %v", err)
+		return
+	}
+	defer release()
+
+	committed, err := config.SnapshotConfig(s.agencDirpath, "Config changed")
+	if err != nil {
+		s.logger.Printf("Config watcher: failed to snapshot config history: %v", err)
+		return
+	}
+	if committed {
+		s.logger.Println("Config watcher: recorded config history snapshot")
 	}
 }
 
@@ -201,7 +249,7 @@ func (s *Server) reloadConfig() {
 
 	s.cachedConfig.Store(cfg)
 
-	if err := s.cronSyncer.SyncCronsToLaunchd(cfg.Crons, s.logger); err != nil {
+	if err := s.cronSyncer.SyncCronsToLaunchd(s.scheduledCrons(cfg), s.logger); err != nil {
 		s.logger.Printf("Config watcher: failed to sync crons: %v", err)
 	}
 
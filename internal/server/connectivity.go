@@ -0,0 +1,249 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+const (
+	// deferredSyncMinBackoff is how long a repo sync that failed because its
+	// remote was unreachable waits before its first retry.
+	deferredSyncMinBackoff = time.Minute
+
+	// deferredSyncMaxBackoff caps the doubling wait between retries.
+	deferredSyncMaxBackoff = 30 * time.Minute
+
+	// Repo sync operations that can be deferred while offline
+	repoSyncOperationClone = "clone"
+	repoSyncOperationFetch = "fetch"
+)
+
+// DeferredOperation is a repo sync waiting for its remote to become
+// reachable again, in GET /server/connectivity.
+type DeferredOperation struct {
+	Repo string `json:"repo"`
+	// Operation is "clone" for a synced repo not in the library yet, or
+	// "fetch" for a library clone that couldn't be updated
+	Operation string `json:"operation"`
+	// Attempts is how many times the operation has failed; 0 when it was
+	// queued without trying because the server already knew it was offline
+	Attempts      int       `json:"attempts"`
+	DeferredAt    time.Time `json:"deferred_at"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// ConnectivityResponse is the JSON response for GET /server/connectivity:
+// whether git remotes are reachable and the repo syncs deferred until they
+// are.
+type ConnectivityResponse struct {
+	Online bool `json:"online"`
+	// OfflineSince is when a remote was first found unreachable; nil when
+	// online
+	OfflineSince *time.Time `json:"offline_since,omitempty"`
+	// LastOnlineAt is when a remote was last reached; nil if none has been
+	// since the server started
+	LastOnlineAt *time.Time          `json:"last_online_at,omitempty"`
+	LastError    string              `json:"last_error,omitempty"`
+	Deferred     []DeferredOperation `json:"deferred"`
+}
+
+// connectivity tracks whether git remotes are reachable, judged from the
+// server's own fetches and clones, and the repo syncs deferred while they
+// aren't. The zero value is ready to use and starts out online.
+type connectivity struct {
+	mu           sync.Mutex
+	offlineSince time.Time
+	lastOnlineAt time.Time
+	lastError    string
+	deferred     map[string]*DeferredOperation
+}
+
+// isOffline reports whether the last remote operation failed to reach its
+// remote.
+func (c *connectivity) isOffline() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.offlineSince.IsZero()
+}
+
+// recordReachable records a successful sync of repoName at now, clearing its
+// deferred operation. Returns the cleared operation (nil if there was none)
+// and, when this ends an offline stretch, when that stretch began.
+func (c *connectivity) recordReachable(repoName string, now time.Time) (*DeferredOperation, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wasOfflineSince := c.offlineSince
+	c.offlineSince = time.Time{}
+	c.lastOnlineAt = now
+	c.lastError = ""
+	op := c.deferred[repoName]
+	delete(c.deferred, repoName)
+	return op, wasOfflineSince
+}
+
+// recordUnreachable records that operation on repoName failed at now because
+// its remote was unreachable, deferring it to a retry that backs off with
+// each consecutive failure. Returns the deferred operation.
+func (c *connectivity) recordUnreachable(repoName string, operation string, err error, now time.Time) DeferredOperation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.offlineSince.IsZero() {
+		c.offlineSince = now
+	}
+	c.lastError = err.Error()
+
+	op := c.deferredOperation(repoName, operation, now)
+	op.Attempts++
+	op.LastError = err.Error()
+	op.NextAttemptAt = now.Add(deferredSyncBackoff(op.Attempts))
+	return *op
+}
+
+// queue defers operation on repoName without attempting it, for work skipped
+// because the server already knows it is offline. It is due at once, so the
+// next repo update cycle tries it. A no-op if the repo already has a deferred
+// operation.
+func (c *connectivity) queue(repoName string, operation string, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.deferred[repoName]; ok {
+		return
+	}
+	op := c.deferredOperation(repoName, operation, now)
+	op.LastError = c.lastError
+	op.NextAttemptAt = now
+}
+
+// deferredOperation returns repoName's deferred operation, creating it if
+// needed. Callers must hold mu.
+func (c *connectivity) deferredOperation(repoName string, operation string, now time.Time) *DeferredOperation {
+	if c.deferred == nil {
+		c.deferred = make(map[string]*DeferredOperation)
+	}
+	op, ok := c.deferred[repoName]
+	if !ok {
+		op = &DeferredOperation{Repo: repoName, DeferredAt: now}
+		c.deferred[repoName] = op
+	}
+	op.Operation = operation
+	return op
+}
+
+// isDue reports whether repoName may be synced at now: it has no deferred
+// operation or its retry time has come.
+func (c *connectivity) isDue(repoName string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	op, ok := c.deferred[repoName]
+	return !ok || !now.Before(op.NextAttemptAt)
+}
+
+// deferredRepos returns the repos with a deferred operation, so the repo
+// update cycle keeps retrying them even once no mission needs them.
+func (c *connectivity) deferredRepos() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	repos := make([]string, 0, len(c.deferred))
+	for repoName := range c.deferred {
+		repos = append(repos, repoName)
+	}
+	return repos
+}
+
+// snapshot returns the GET /server/connectivity view, deferred operations
+// sorted by repo.
+func (c *connectivity) snapshot() ConnectivityResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resp := ConnectivityResponse{
+		Online:    c.offlineSince.IsZero(),
+		LastError: c.lastError,
+		Deferred:  []DeferredOperation{},
+	}
+	if !c.offlineSince.IsZero() {
+		offlineSince := c.offlineSince
+		resp.OfflineSince = &offlineSince
+	}
+	if !c.lastOnlineAt.IsZero() {
+		lastOnlineAt := c.lastOnlineAt
+		resp.LastOnlineAt = &lastOnlineAt
+	}
+	for _, op := range c.deferred {
+		resp.Deferred = append(resp.Deferred, *op)
+	}
+	sort.Slice(resp.Deferred, func(i, j int) bool {
+		return resp.Deferred[i].Repo < resp.Deferred[j].Repo
+	})
+	return resp
+}
+
+// deferredSyncBackoff returns how long to wait before retrying an operation
+// that has failed attempts times: deferredSyncMinBackoff, doubling each
+// time up to deferredSyncMaxBackoff.
+func deferredSyncBackoff(attempts int) time.Duration {
+	backoff := deferredSyncMinBackoff
+	for i := 1; i < attempts && backoff < deferredSyncMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, deferredSyncMaxBackoff)
+}
+
+// handleGetConnectivity handles GET /server/connectivity.
+func (s *Server) handleGetConnectivity(w http.ResponseWriter, r *http.Request) error {
+	writeJSON(w, http.StatusOK, s.connectivity.snapshot())
+	return nil
+}
+
+// recordRepoReachable records a successful sync of repoName, logging when
+// it completes a deferred operation or ends an offline stretch.
+func (s *Server) recordRepoReachable(repoName string) {
+	now := time.Now()
+	op, wasOfflineSince := s.connectivity.recordReachable(repoName, now)
+	if !wasOfflineSince.IsZero() {
+		s.logger.Printf("Connectivity: remotes reachable again after %s offline", now.Sub(wasOfflineSince).Round(time.Second))
+	}
+	if op != nil {
+		s.logger.Printf("Repo update: deferred %s of '%s' succeeded after %d failed attempts", op.Operation, repoName, op.Attempts)
+	}
+}
+
+// deferRepoSync defers operation on repoName after err showed its remote to
+// be unreachable.
+func (s *Server) deferRepoSync(repoName string, operation string, err error) {
+	op := s.connectivity.recordUnreachable(repoName, operation, err, time.Now())
+	s.logger.Printf("Repo update: remote of '%s' unreachable, deferring %s (attempt %d, retrying in %s): %v",
+		repoName, operation, op.Attempts, time.Until(op.NextAttemptAt).Round(time.Second), err)
+}
+
+// refreshStaleLibraryClone force-pulls a library clone that hasn't been
+// fetched recently, so a new mission doesn't start from a stale copy. When
+// the remote is unreachable, or the server already knows it is offline, the
+// mission proceeds from the cached clone and the fetch is deferred until
+// connectivity returns. Returns whether the cached clone was used.
+func (s *Server) refreshStaleLibraryClone(repoName string, cloneDirpath string) bool {
+	if !mission.IsRepoStale(cloneDirpath, 24*time.Hour) {
+		return false
+	}
+	if s.connectivity.isOffline() {
+		s.logger.Printf("Mission create: offline, starting from the cached clone of stale repo '%s'", repoName)
+		s.connectivity.queue(repoName, repoSyncOperationFetch, time.Now())
+		return true
+	}
+
+	s.logger.Printf("Mission create: force-pulling stale repo '%s' before copy", repoName)
+	if err := mission.ForceUpdateRepo(cloneDirpath); err != nil {
+		s.logger.Printf("Mission create: failed to pull stale repo '%s': %v (proceeding with stale copy)", repoName, err)
+		if mission.IsNetworkError(err) {
+			s.deferRepoSync(repoName, repoSyncOperationFetch, err)
+			return true
+		}
+		return false
+	}
+	s.recordRepoReachable(repoName)
+	return false
+}
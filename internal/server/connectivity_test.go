@@ -0,0 +1,72 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeferredSyncBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{6, 30 * time.Minute},
+		{50, 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := deferredSyncBackoff(tt.attempts); got != tt.want {
+			t.Errorf("deferredSyncBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestConnectivity_DefersUntilReachable(t *testing.T) {
+	var c connectivity
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	unreachable := errors.New("ssh: Could not resolve hostname github.com")
+
+	if c.isOffline() || !c.isDue("github.com/owner/app", start) {
+		t.Fatal("expected a new tracker to be online with nothing deferred")
+	}
+
+	c.recordUnreachable("github.com/owner/app", repoSyncOperationFetch, unreachable, start)
+	op := c.recordUnreachable("github.com/owner/app", repoSyncOperationFetch, unreachable, start.Add(time.Minute))
+	if !c.isOffline() {
+		t.Error("expected an unreachable remote to mark the tracker offline")
+	}
+	if op.Attempts != 2 || !op.DeferredAt.Equal(start) || !op.NextAttemptAt.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("unexpected deferred operation %+v", op)
+	}
+	if c.isDue("github.com/owner/app", start.Add(2*time.Minute)) || !c.isDue("github.com/owner/app", start.Add(3*time.Minute)) {
+		t.Error("expected the retry held back until its backoff elapses")
+	}
+
+	// Work skipped while offline is queued once and due immediately
+	c.queue("github.com/owner/lib", repoSyncOperationFetch, start.Add(2*time.Minute))
+	c.queue("github.com/owner/app", repoSyncOperationClone, start.Add(2*time.Minute))
+	snapshot := c.snapshot()
+	if snapshot.Online || snapshot.OfflineSince == nil || !snapshot.OfflineSince.Equal(start) || len(snapshot.Deferred) != 2 {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+	if snapshot.Deferred[0].Repo != "github.com/owner/app" || snapshot.Deferred[0].Operation != repoSyncOperationFetch {
+		t.Errorf("expected queue to leave an existing deferral alone, got %+v", snapshot.Deferred[0])
+	}
+	if lib := snapshot.Deferred[1]; lib.Attempts != 0 || lib.LastError != unreachable.Error() || !c.isDue(lib.Repo, start.Add(2*time.Minute)) {
+		t.Errorf("unexpected queued operation %+v", lib)
+	}
+
+	cleared, wasOfflineSince := c.recordReachable("github.com/owner/app", start.Add(5*time.Minute))
+	if cleared == nil || cleared.Attempts != 2 || !wasOfflineSince.Equal(start) {
+		t.Errorf("expected the deferred fetch cleared and the offline stretch ended, got %+v, %v", cleared, wasOfflineSince)
+	}
+	if c.isOffline() {
+		t.Error("expected a reachable remote to mark the tracker online")
+	}
+	if repos := c.deferredRepos(); len(repos) != 1 || repos[0] != "github.com/owner/lib" {
+		t.Errorf("expected only the queued repo still deferred, got %v", repos)
+	}
+}
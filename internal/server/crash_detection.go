@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// crashDetectionInterval is how often the server looks for crashed
+	// wrappers.
+	crashDetectionInterval = 30 * time.Second
+
+	// crashHeartbeatThreshold is how long a running wrapper may go without
+	// heartbeating before it is considered hung. Longer than
+	// staleHeartbeatThreshold so a briefly busy server does not get wrappers
+	// killed.
+	crashHeartbeatThreshold = 2 * time.Minute
+
+	// crashRestartCooldown keeps autoRestartCrashed from respawning a mission
+	// in a tight loop: a mission that crashes again within this long of being
+	// restarted is left crashed.
+	crashRestartCooldown = 10 * time.Minute
+
+	missionCrashedNotificationKind = "mission.crashed"
+)
+
+// Crash reasons.
+const (
+	crashReasonWrapperDied   = "wrapper process exited without cleaning up"
+	crashReasonHeartbeatLost = "wrapper stopped heartbeating"
+)
+
+// crashedMission is an active mission whose wrapper crashed, as found by
+// detectCrashedMissions.
+type crashedMission struct {
+	mission *database.Mission
+	reason  string
+	// pid is the wrapper PID from the leftover PID file.
+	pid int
+}
+
+// wrapperPIDInfo describes a mission's wrapper PID file.
+type wrapperPIDInfo struct {
+	pid       int
+	writtenAt time.Time
+	running   bool
+}
+
+// crashRestarts records when each mission was last auto-restarted, for
+// crashRestartCooldown. The zero value is ready to use.
+type crashRestarts struct {
+	mu          sync.Mutex
+	restartedAt map[string]time.Time
+}
+
+// tryRecord records a restart of missionID at now, returning false without
+// recording if the previous restart was within crashRestartCooldown.
+func (c *crashRestarts) tryRecord(missionID string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if last, ok := c.restartedAt[missionID]; ok && now.Sub(last) < crashRestartCooldown {
+		return false
+	}
+	if c.restartedAt == nil {
+		c.restartedAt = make(map[string]time.Time)
+	}
+	c.restartedAt[missionID] = now
+	return true
+}
+
+// detectCrashedMissions returns the active missions whose wrapper crashed.
+// A wrapper removes its PID file on every clean exit, so a PID file naming a
+// dead process means it crashed. A live wrapper that heartbeated after
+// writing its PID file but not within crashHeartbeatThreshold is hung;
+// wrappers that never heartbeat (headless runs) are never judged by
+// heartbeat. pidInfo returns nil for missions without a PID file.
+func detectCrashedMissions(missions []*database.Mission, now time.Time, pidInfo func(missionID string) *wrapperPIDInfo) []crashedMission {
+	var crashed []crashedMission
+	for _, m := range missions {
+		if m.Status != "active" {
+			continue
+		}
+		info := pidInfo(m.ID)
+		if info == nil {
+			continue
+		}
+		if !info.running {
+			crashed = append(crashed, crashedMission{mission: m, reason: crashReasonWrapperDied, pid: info.pid})
+			continue
+		}
+		if m.LastHeartbeat == nil || m.LastHeartbeat.Before(info.writtenAt) {
+			continue
+		}
+		if now.Sub(*m.LastHeartbeat) > crashHeartbeatThreshold {
+			crashed = append(crashed, crashedMission{mission: m, reason: crashReasonHeartbeatLost, pid: info.pid})
+		}
+	}
+	return crashed
+}
+
+// readWrapperPIDInfo reads a mission's wrapper PID file. Returns nil if there
+// is none.
+func (s *Server) readWrapperPIDInfo(missionID string) *wrapperPIDInfo {
+	pidFilepath := config.GetMissionPIDFilepath(s.agencDirpath, missionID)
+	stat, err := os.Stat(pidFilepath)
+	if err != nil {
+		return nil
+	}
+	pid, err := ReadPID(pidFilepath)
+	if err != nil || pid == 0 {
+		return nil
+	}
+	return &wrapperPIDInfo{
+		pid:       pid,
+		writtenAt: stat.ModTime(),
+		running:   IsProcessRunning(pid),
+	}
+}
+
+// runCrashDetectionLoop periodically marks missions with crashed wrappers as
+// crashed and, with autoRestartCrashed, respawns them in the pool.
+func (s *Server) runCrashDetectionLoop(ctx context.Context) {
+	ticker := time.NewTicker(crashDetectionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runCrashDetectionCycle()
+		}
+	}
+}
+
+// runCrashDetectionCycle runs one crash scan and fails the cron runs of dead
+// wrappers, skipping both while a stash operation is stopping and restarting
+// wrappers.
+func (s *Server) runCrashDetectionCycle() {
+	if s.stashInProgress.Load() {
+		return
+	}
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: false})
+	if err != nil {
+		s.logger.Printf("Crash detection: failed to list missions: %v", err)
+		return
+	}
+
+	autoRestart := s.getConfig().AutoRestartCrashed
+	for _, c := range detectCrashedMissions(missions, time.Now(), s.readWrapperPIDInfo) {
+		if _, reloading := s.reloadsInProgress.Load(c.mission.ID); reloading {
+			continue
+		}
+		s.handleCrashedMission(c, autoRestart)
+	}
+
+	s.reconcileCronRuns()
+}
+
+// handleCrashedMission cleans up after a crashed wrapper, marks the mission
+// crashed, records the incident, and optionally respawns the wrapper.
+func (s *Server) handleCrashedMission(c crashedMission, autoRestart bool) {
+	m := c.mission
+
+	// stopWrapper kills a hung wrapper and removes the leftover PID file.
+	if err := s.stopWrapper(m.ID); err != nil {
+		s.logger.Printf("Crash detection: failed to stop wrapper for mission %s: %v", m.ShortID, err)
+		return
+	}
+	if m.TmuxPane != nil {
+		s.destroyPoolWindow(*m.TmuxPane)
+		if err := s.db.ClearTmuxPane(m.ID); err != nil {
+			s.logger.Printf("Warning: failed to clear pane for crashed mission %s: %v", m.ShortID, err)
+		}
+	}
+
+	marked, err := s.db.MarkMissionCrashed(m.ID)
+	if err != nil {
+		s.logger.Printf("Crash detection: failed to mark mission %s crashed: %v", m.ShortID, err)
+		return
+	}
+	if !marked {
+		return
+	}
+
+	restart := autoRestart && isInteractiveMission(m) && s.crashRestarts.tryRecord(m.ID, time.Now())
+	s.logger.Printf("Crash detection: mission %s crashed (%s, PID %d, last heartbeat: %v)", m.ShortID, c.reason, c.pid, m.LastHeartbeat)
+	s.publishEvent(EventMissionCrashed, m.ID, map[string]string{"reason": c.reason})
+	if err := s.db.CreateNotification(buildMissionCrashedNotification(c, restart)); err != nil {
+		s.logger.Printf("failed to create crash notification for mission %s: %v", m.ShortID, err)
+	}
+
+	if !restart {
+		if autoRestart && isInteractiveMission(m) {
+			s.logger.Printf("Crash detection: not restarting mission %s; it was restarted less than %s ago", m.ShortID, crashRestartCooldown)
+		}
+		return
+	}
+
+	if err := s.ensureWrapperInPool(m); err != nil {
+		s.logger.Printf("Crash detection: failed to restart mission %s: %v", m.ShortID, err)
+		return
+	}
+	s.logger.Printf("Crash detection: restarted mission %s", m.ShortID)
+	s.publishEvent(EventMissionRestarted, m.ID, map[string]string{"reason": "crash"})
+}
+
+// buildMissionCrashedNotification constructs the notification recording a
+// crash incident.
+func buildMissionCrashedNotification(c crashedMission, restarted bool) *database.Notification {
+	m := c.mission
+	bodyParts := []string{
+		"**Mission:** " + m.ShortID,
+		"**Reason:** " + c.reason,
+		fmt.Sprintf("**Wrapper PID:** %d", c.pid),
+	}
+	if m.GitRepo != "" {
+		bodyParts = append(bodyParts, "**Repo:** "+m.GitRepo)
+	}
+	if m.LastHeartbeat != nil {
+		bodyParts = append(bodyParts, "**Last heartbeat:** "+m.LastHeartbeat.Format(time.RFC3339))
+	}
+	if restarted {
+		bodyParts = append(bodyParts, "The wrapper was restarted automatically (`autoRestartCrashed`).")
+	} else {
+		bodyParts = append(bodyParts, "Resume it with `agenc mission resume "+m.ShortID+"`.")
+	}
+
+	missionID := m.ID
+	return &database.Notification{
+		ID:           uuid.New().String(),
+		Kind:         missionCrashedNotificationKind,
+		Title:        sanitizeNotificationTitle("Mission crashed: " + m.ShortID),
+		BodyMarkdown: strings.Join(bodyParts, "\n\n"),
+		MissionID:    &missionID,
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestDetectCrashedMissions(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+
+	missions := []*database.Mission{
+		{ID: "healthy", Status: "active", LastHeartbeat: ago(5 * time.Second)},
+		{ID: "dead", Status: "active", LastHeartbeat: ago(5 * time.Second)},
+		{ID: "hung", Status: "active", LastHeartbeat: ago(5 * time.Minute)},
+		{ID: "headless", Status: "active", LastHeartbeat: ago(3 * time.Hour)},
+		{ID: "stopped", Status: "active", LastHeartbeat: ago(3 * time.Hour)},
+		{ID: "already-crashed", Status: "crashed"},
+	}
+	pidInfos := map[string]*wrapperPIDInfo{
+		"healthy":         {pid: 1, writtenAt: *ago(time.Hour), running: true},
+		"dead":            {pid: 2, writtenAt: *ago(time.Hour), running: false},
+		"hung":            {pid: 3, writtenAt: *ago(time.Hour), running: true},
+		"headless":        {pid: 4, writtenAt: *ago(time.Hour), running: true},
+		"already-crashed": {pid: 5, writtenAt: *ago(time.Hour), running: false},
+	}
+	pidInfo := func(id string) *wrapperPIDInfo { return pidInfos[id] }
+
+	got := map[string]string{}
+	for _, c := range detectCrashedMissions(missions, now, pidInfo) {
+		got[c.mission.ID] = c.reason
+	}
+	want := map[string]string{
+		"dead": crashReasonWrapperDied,
+		"hung": crashReasonHeartbeatLost,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for id, reason := range want {
+		if got[id] != reason {
+			t.Errorf("mission %s: expected %q, got %q", id, reason, got[id])
+		}
+	}
+}
+
+func TestCrashRestarts_Cooldown(t *testing.T) {
+	var restarts crashRestarts
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if !restarts.tryRecord("m1", now) {
+		t.Fatal("expected first restart to be allowed")
+	}
+	if restarts.tryRecord("m1", now.Add(time.Minute)) {
+		t.Error("expected restart within cooldown to be refused")
+	}
+	if !restarts.tryRecord("m2", now.Add(time.Minute)) {
+		t.Error("expected other missions to be unaffected")
+	}
+	if !restarts.tryRecord("m1", now.Add(crashRestartCooldown+time.Minute)) {
+		t.Error("expected restart after cooldown to be allowed")
+	}
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// oneShotCronGrace keeps a config runAt cron loaded in launchd for a
+	// while after its fire time, so a resync can't unload it before launchd
+	// has started the run.
+	oneShotCronGrace = 5 * time.Minute
+	// oneShotResyncDelay is how long after a one-shot cron fires the server
+	// waits before unloading it, letting the launchd-started process finish.
+	oneShotResyncDelay = 30 * time.Second
+)
+
+// CreateCronAtRequest is the request body for POST /crons/at.
+type CreateCronAtRequest struct {
+	// Name defaults to "at-" plus a short random suffix.
+	Name string `json:"name,omitempty"`
+	// RunAt is the fire time in RFC3339; it must be in the future.
+	RunAt       string `json:"runAt"`
+	Prompt      string `json:"prompt"`
+	Description string `json:"description,omitempty"`
+	Repo        string `json:"repo,omitempty"`
+}
+
+// cronConfigFromAtJob converts a one-shot job into the cron config the
+// syncer and launcher understand.
+func cronConfigFromAtJob(job *database.CronAtJob) config.CronConfig {
+	return config.CronConfig{
+		ID:          job.ID,
+		RunAt:       job.RunAt.Format(time.RFC3339),
+		Prompt:      job.Prompt,
+		Description: job.Description,
+		Repo:        job.Repo,
+	}
+}
+
+// listCronAtJobs returns the pending one-shot jobs, or none when the server
+// has no database (tests) or the query fails.
+func (s *Server) listCronAtJobs() []*database.CronAtJob {
+	if s.db == nil {
+		return nil
+	}
+	jobs, err := s.db.ListCronAtJobs()
+	if err != nil {
+		s.logger.Printf("Failed to list one-shot cron jobs: %v", err)
+		return nil
+	}
+	return jobs
+}
+
+// findCronAtJob returns the pending one-shot job with the given name or ID.
+func (s *Server) findCronAtJob(nameOrID string) *database.CronAtJob {
+	for _, job := range s.listCronAtJobs() {
+		if job.Name == nameOrID || job.ID == nameOrID {
+			return job
+		}
+	}
+	return nil
+}
+
+// scheduledCrons returns the crons that should be loaded in launchd: the
+// recurring and still-pending one-shot crons from config, plus the one-shot
+// jobs from 'agenc cron at'. A config cron shadows a one-shot job of the
+// same name.
+func (s *Server) scheduledCrons(cfg *config.AgencConfig) map[string]config.CronConfig {
+	now := time.Now()
+	crons := make(map[string]config.CronConfig, len(cfg.Crons))
+	for name, cronCfg := range cfg.Crons {
+		if cronCfg.IsOneShot() {
+			runAt, err := config.ParseCronRunAt(cronCfg.RunAt)
+			if err != nil || !now.Before(runAt.Add(oneShotCronGrace)) {
+				continue
+			}
+		}
+		crons[name] = cronCfg
+	}
+	for _, job := range s.listCronAtJobs() {
+		if _, exists := crons[job.Name]; exists {
+			s.logger.Printf("Cron syncer: one-shot job '%s' is shadowed by a config cron of the same name", job.Name)
+			continue
+		}
+		if !now.Before(job.RunAt) {
+			// Missed while the server was down; fireMissedOneShotCrons runs it
+			continue
+		}
+		crons[job.Name] = cronConfigFromAtJob(job)
+	}
+	return crons
+}
+
+// completeOneShotCron retires a one-shot cron after its scheduled run started
+// a mission: a 'cron at' job is deleted, and launchd is resynced shortly
+// after so the job (whose calendar interval would recur next year) is
+// unloaded. Manual runs don't consume the job.
+func (s *Server) completeOneShotCron(req CreateMissionRequest) {
+	if _, trigger := parseCronSourceMetadata(req.SourceMetadata); trigger == database.CronRunTriggerManual {
+		return
+	}
+
+	delay := oneShotResyncDelay
+	if _, cronCfg, ok := s.getConfig().GetCronByID(req.SourceID); ok {
+		if !cronCfg.IsOneShot() {
+			return
+		}
+		// scheduledCrons keeps config runAt crons until their grace ends
+		if runAt, err := config.ParseCronRunAt(cronCfg.RunAt); err == nil {
+			delay = max(delay, time.Until(runAt.Add(oneShotCronGrace))+time.Second)
+		}
+	} else if s.db != nil {
+		deleted, err := s.db.DeleteCronAtJob(req.SourceID)
+		if err != nil {
+			s.logger.Printf("Failed to remove one-shot cron job %s after it fired: %v", req.SourceID, err)
+		}
+		if !deleted {
+			return
+		}
+	} else {
+		return
+	}
+	time.AfterFunc(delay, func() {
+		s.syncCronsAfterMutation(s.getConfig())
+	})
+}
+
+// fireMissedOneShotCrons starts one-shot crons whose fire time passed while
+// the server (or machine) was down. 'cron at' jobs still in the database
+// never fired; config runAt crons are run if they have no recorded runs.
+func (s *Server) fireMissedOneShotCrons() {
+	now := time.Now()
+	missed := make(map[string]config.CronConfig)
+	cfg := s.getConfig()
+	for name, cronCfg := range cfg.Crons {
+		if !cronCfg.IsOneShot() || !cronCfg.IsEnabled() || cronCfg.ID == "" {
+			continue
+		}
+		runAt, err := config.ParseCronRunAt(cronCfg.RunAt)
+		if err != nil || now.Before(runAt.Add(oneShotCronGrace)) {
+			continue
+		}
+		if runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: cronCfg.ID, Limit: 1}); err != nil || len(runs) > 0 {
+			continue
+		}
+		missed[name] = cronCfg
+	}
+	for _, job := range s.listCronAtJobs() {
+		if _, exists := cfg.Crons[job.Name]; !exists && !now.Before(job.RunAt) {
+			missed[job.Name] = cronConfigFromAtJob(job)
+		}
+	}
+
+	for name, cronCfg := range missed {
+		if err := s.launchCronMission(name, cronCfg); err != nil {
+			s.logger.Printf("Failed to start missed one-shot cron '%s': %v", name, err)
+			continue
+		}
+		s.logger.Printf("Started one-shot cron '%s', missed at %s", name, cronCfg.RunAt)
+	}
+}
+
+func (s *Server) handleCreateCronAt(w http.ResponseWriter, r *http.Request) error {
+	var req CreateCronAtRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if req.Name == "" {
+		req.Name = "at-" + uuid.New().String()[:8]
+	}
+	if err := config.ValidateCronName(req.Name); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
+	}
+	runAt, err := time.Parse(time.RFC3339, req.RunAt)
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid runAt %q: must be RFC3339", req.RunAt)
+	}
+	runAt = runAt.Truncate(time.Minute)
+	if !runAt.After(time.Now()) {
+		return newHTTPErrorf(http.StatusBadRequest, "runAt %s is not in the future", req.RunAt)
+	}
+	if req.Prompt == "" {
+		return newHTTPError(http.StatusBadRequest, "prompt cannot be empty")
+	}
+
+	if _, exists := s.getConfig().Crons[req.Name]; exists {
+		return newHTTPErrorf(http.StatusConflict, "cron job '%s' already exists", req.Name)
+	}
+	if s.findCronAtJob(req.Name) != nil {
+		return newHTTPErrorf(http.StatusConflict, "cron job '%s' already exists", req.Name)
+	}
+
+	job := &database.CronAtJob{
+		ID:          uuid.New().String(),
+		Name:        req.Name,
+		RunAt:       runAt,
+		Prompt:      req.Prompt,
+		Description: req.Description,
+		Repo:        req.Repo,
+	}
+	if err := s.db.CreateCronAtJob(job); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to save one-shot cron job: %s", err.Error())
+	}
+
+	s.syncCronsAfterMutation(s.getConfig())
+
+	writeJSON(w, http.StatusCreated, cronInfoFromConfig(job.Name, cronConfigFromAtJob(job)))
+	return nil
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// newCronAtTestServer returns a server with a real DB whose launchd syncs are
+// skipped via the test environment.
+func newCronAtTestServer(t *testing.T, cfg *config.AgencConfig) *Server {
+	t.Helper()
+	t.Setenv(config.TestEnvVar, "1")
+
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "config"), 0o755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	db, err := database.Open(filepath.Join(tmpDir, "database.sqlite"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	srv := &Server{
+		agencDirpath: tmpDir,
+		logger:       log.New(os.Stderr, "", 0),
+		db:           db,
+		cronSyncer:   newCronSyncerWithManager(tmpDir, newMockManager()),
+	}
+	srv.cachedConfig.Store(cfg)
+	return srv
+}
+
+func TestHandleCreateCronAt(t *testing.T) {
+	srv := newCronAtTestServer(t, &config.AgencConfig{Crons: map[string]config.CronConfig{
+		"daily": {ID: "daily-id", Schedule: "0 9 * * *", Prompt: "p"},
+	}})
+	runAt := time.Now().Add(2 * time.Hour).Truncate(time.Minute)
+
+	create := func(body string) (*httptest.ResponseRecorder, error) {
+		w := httptest.NewRecorder()
+		err := srv.handleCreateCronAt(w, httptest.NewRequest(http.MethodPost, "/crons/at", bytes.NewBufferString(body)))
+		return w, err
+	}
+
+	w, err := create(fmt.Sprintf(`{"name":"reminder","runAt":%q,"prompt":"check the deploy"}`, runAt.Format(time.RFC3339)))
+	if err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %v", w.Code, err)
+	}
+	var info CronInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if info.Name != "reminder" || info.ID == "" || info.Schedule != "" {
+		t.Errorf("unexpected cron info %+v", info)
+	}
+	if parsed, err := time.Parse(time.RFC3339, info.RunAt); err != nil || !parsed.Equal(runAt) {
+		t.Errorf("expected runAt %v, got %q", runAt, info.RunAt)
+	}
+
+	for _, tt := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{"past runAt", fmt.Sprintf(`{"runAt":%q,"prompt":"p"}`, time.Now().Add(-time.Hour).Format(time.RFC3339)), http.StatusBadRequest},
+		{"relative runAt", `{"runAt":"tomorrow 9am","prompt":"p"}`, http.StatusBadRequest},
+		{"no prompt", fmt.Sprintf(`{"runAt":%q}`, runAt.Format(time.RFC3339)), http.StatusBadRequest},
+		{"name taken by one-shot", fmt.Sprintf(`{"name":"reminder","runAt":%q,"prompt":"p"}`, runAt.Format(time.RFC3339)), http.StatusConflict},
+		{"name taken by config", fmt.Sprintf(`{"name":"daily","runAt":%q,"prompt":"p"}`, runAt.Format(time.RFC3339)), http.StatusConflict},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := create(tt.body)
+			if got := httpStatusFromError(err); err == nil || got != tt.want {
+				t.Errorf("expected %d, got %d: %v", tt.want, got, err)
+			}
+		})
+	}
+
+	// Listed alongside config crons
+	w = httptest.NewRecorder()
+	if err := srv.handleListCrons(w, httptest.NewRequest(http.MethodGet, "/crons", nil)); err != nil {
+		t.Fatalf("handleListCrons failed: %v", err)
+	}
+	var crons []CronInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &crons); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(crons) != 2 || crons[0].Name != "daily" || crons[1].Name != "reminder" {
+		t.Fatalf("expected the config cron and the one-shot job, got %+v", crons)
+	}
+
+	// Deleted by name like any other cron
+	w = httptest.NewRecorder()
+	deleteReq := httptest.NewRequest(http.MethodDelete, "/crons/reminder", nil)
+	deleteReq.SetPathValue("name", "reminder")
+	if err := srv.handleDeleteCron(w, deleteReq); err != nil {
+		t.Fatalf("handleDeleteCron failed: %v", err)
+	}
+	if jobs := srv.listCronAtJobs(); len(jobs) != 0 {
+		t.Errorf("expected the one-shot job to be deleted, got %+v", jobs)
+	}
+}
+
+func TestScheduledCrons(t *testing.T) {
+	now := time.Now()
+	srv := newCronAtTestServer(t, &config.AgencConfig{})
+	cfg := &config.AgencConfig{Crons: map[string]config.CronConfig{
+		"recurring": {ID: "r", Schedule: "0 9 * * *", Prompt: "p"},
+		"upcoming":  {ID: "u", RunAt: now.Add(time.Hour).Format(time.RFC3339), Prompt: "p"},
+		"just-due":  {ID: "j", RunAt: now.Add(-time.Minute).Format(time.RFC3339), Prompt: "p"},
+		"long-gone": {ID: "g", RunAt: now.Add(-time.Hour).Format(time.RFC3339), Prompt: "p"},
+	}}
+	for _, job := range []*database.CronAtJob{
+		{ID: "a1", Name: "at-future", RunAt: now.Add(time.Hour), Prompt: "p"},
+		{ID: "a2", Name: "at-missed", RunAt: now.Add(-time.Hour), Prompt: "p"},
+		{ID: "a3", Name: "recurring", RunAt: now.Add(time.Hour), Prompt: "shadowed"},
+	} {
+		if err := srv.db.CreateCronAtJob(job); err != nil {
+			t.Fatalf("CreateCronAtJob failed: %v", err)
+		}
+	}
+
+	crons := srv.scheduledCrons(cfg)
+	for _, name := range []string{"recurring", "upcoming", "just-due", "at-future"} {
+		if _, ok := crons[name]; !ok {
+			t.Errorf("expected '%s' to be scheduled", name)
+		}
+	}
+	for _, name := range []string{"long-gone", "at-missed"} {
+		if _, ok := crons[name]; ok {
+			t.Errorf("expected '%s' not to be scheduled", name)
+		}
+	}
+	if crons["recurring"].ID != "r" {
+		t.Errorf("expected the config cron to shadow the one-shot job, got %+v", crons["recurring"])
+	}
+	if atFuture := crons["at-future"]; atFuture.ID != "a1" || !atFuture.IsOneShot() {
+		t.Errorf("expected the one-shot job as a runAt cron, got %+v", atFuture)
+	}
+}
+
+func TestCompleteOneShotCron_DeletesAtJob(t *testing.T) {
+	srv := newCronAtTestServer(t, &config.AgencConfig{})
+	job := &database.CronAtJob{ID: "at-id", Name: "reminder", RunAt: time.Now().Add(-time.Minute), Prompt: "p"}
+	if err := srv.db.CreateCronAtJob(job); err != nil {
+		t.Fatalf("CreateCronAtJob failed: %v", err)
+	}
+
+	srv.completeOneShotCron(CreateMissionRequest{
+		Source: "cron", SourceID: "at-id", SourceMetadata: `{"cron_name":"reminder","trigger":"manual"}`,
+	})
+	if len(srv.listCronAtJobs()) != 1 {
+		t.Fatal("expected a manual run not to consume the one-shot job")
+	}
+
+	srv.completeOneShotCron(CreateMissionRequest{
+		Source: "cron", SourceID: "at-id", SourceMetadata: `{"cron_name":"reminder"}`,
+	})
+	if jobs := srv.listCronAtJobs(); len(jobs) != 0 {
+		t.Errorf("expected the scheduled run to delete the one-shot job, got %+v", jobs)
+	}
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// cronDependencyDetailPrefix starts the detail of a run skipped because its
+// upstream cron had not succeeded yet. Runs carrying it are started as soon
+// as the upstream succeeds later the same day.
+const cronDependencyDetailPrefix = "waiting for upstream cron"
+
+// upstreamSucceededToday reports whether run, an upstream cron's latest
+// attempted run, succeeded on the same local calendar day as now.
+func upstreamSucceededToday(run *database.CronRun, now time.Time) bool {
+	if run == nil || run.Status != database.CronRunStatusSucceeded || run.EndedAt == nil {
+		return false
+	}
+	return isSameLocalDay(*run.EndedAt, now)
+}
+
+// isSameLocalDay reports whether a and b fall on the same local calendar day.
+func isSameLocalDay(a time.Time, b time.Time) bool {
+	aYear, aMonth, aDay := a.Local().Date()
+	bYear, bMonth, bDay := b.Local().Date()
+	return aYear == bYear && aMonth == bMonth && aDay == bDay
+}
+
+// checkCronDependency rejects a scheduled cron firing whose 'after' upstream
+// has not succeeded today, recording the firing as a skipped run. Manual
+// triggers (`agenc cron run`) are never held back.
+func (s *Server) checkCronDependency(req CreateMissionRequest) error {
+	cronName, trigger := parseCronSourceMetadata(req.SourceMetadata)
+	if trigger == database.CronRunTriggerManual {
+		return nil
+	}
+
+	cfg := s.getConfig()
+	_, cronCfg, ok := cfg.GetCronByID(req.SourceID)
+	if !ok || cronCfg.After == "" {
+		return nil
+	}
+	upstream, ok := cfg.Crons[cronCfg.After]
+	if !ok {
+		return nil
+	}
+
+	s.reconcileCronRuns()
+
+	// The upstream's latest run that started a mission; its own skipped
+	// firings say nothing about whether it succeeded
+	attempted, err := s.db.ListCronRuns(database.ListCronRunsParams{
+		CronID:        upstream.ID,
+		ExcludeStatus: database.CronRunStatusSkipped,
+		Limit:         1,
+	})
+	if err != nil {
+		s.logger.Printf("Cron dependency check: failed to look up runs of '%s': %v", cronCfg.After, err)
+		return nil
+	}
+	var latest *database.CronRun
+	if len(attempted) > 0 {
+		latest = attempted[0]
+	}
+	if upstreamSucceededToday(latest, time.Now()) {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	skipped := &database.CronRun{
+		ID:        uuid.New().String(),
+		CronID:    req.SourceID,
+		CronName:  cronName,
+		Trigger:   database.CronRunTriggerSchedule,
+		Status:    database.CronRunStatusSkipped,
+		Detail:    cronDependencyDetailPrefix + " '" + cronCfg.After + "' to succeed today",
+		StartedAt: now,
+		EndedAt:   &now,
+	}
+	if err := s.db.CreateCronRun(skipped); err != nil {
+		s.logger.Printf("Cron dependency check: failed to record skipped run for cron %s: %v", req.SourceID, err)
+	}
+	return newHTTPErrorf(http.StatusConflict, "cron '%s' skipped: %s", cronName, skipped.Detail)
+}
+
+// startCronsWaitingOn starts downstream crons that were skipped earlier today
+// while waiting on the cron that spawned missionID, now that it succeeded.
+// Best-effort: failures are logged.
+func (s *Server) startCronsWaitingOn(missionID string) {
+	missionRecord, err := s.db.GetMission(missionID)
+	if err != nil || missionRecord == nil || missionRecord.SourceID == nil {
+		return
+	}
+	cfg := s.getConfig()
+	upstreamName, _, ok := cfg.GetCronByID(*missionRecord.SourceID)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	for _, name := range cfg.GetCronDependents(upstreamName) {
+		cronCfg := cfg.Crons[name]
+		if !cronCfg.IsEnabled() || cronCfg.ID == "" {
+			continue
+		}
+		runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: cronCfg.ID, Limit: 1})
+		if err != nil || len(runs) == 0 {
+			continue
+		}
+		last := runs[0]
+		if last.Status != database.CronRunStatusSkipped ||
+			!strings.HasPrefix(last.Detail, cronDependencyDetailPrefix) ||
+			!isSameLocalDay(last.StartedAt, now) {
+			continue
+		}
+
+		if err := s.launchCronMission(name, cronCfg); err != nil {
+			s.logger.Printf("Cron dependency: failed to start '%s' after '%s' succeeded: %v", name, upstreamName, err)
+			continue
+		}
+		s.logger.Printf("Cron dependency: started '%s' now that '%s' succeeded", name, upstreamName)
+	}
+}
+
+// launchCronMission starts a scheduled run of a cron job the same way launchd
+// does, appending output to the cron's log file.
+func (s *Server) launchCronMission(name string, cronCfg config.CronConfig) error {
+	args, err := cronMissionArgs(name, cronCfg)
+	if err != nil {
+		return err
+	}
+	execPath, err := os.Executable()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to get executable path")
+	}
+
+	logFilepath := config.GetCronLogFilepath(s.agencDirpath, cronCfg.ID)
+	if err := os.MkdirAll(filepath.Dir(logFilepath), 0755); err != nil {
+		return stacktrace.Propagate(err, "failed to create cron log directory")
+	}
+	logFile, err := os.OpenFile(logFilepath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open cron log '%s'", logFilepath)
+	}
+
+	cmd := exec.Command(execPath, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		return stacktrace.Propagate(err, "failed to start mission for cron '%s'", name)
+	}
+	go func() {
+		_ = cmd.Wait()
+		logFile.Close()
+	}()
+	return nil
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestUpstreamSucceededToday(t *testing.T) {
+	now := time.Date(2026, 6, 1, 15, 0, 0, 0, time.Local)
+	endedAt := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+
+	tests := []struct {
+		name string
+		run  *database.CronRun
+		want bool
+	}{
+		{"never ran", nil, false},
+		{"succeeded this morning", &database.CronRun{Status: database.CronRunStatusSucceeded, EndedAt: endedAt(-6 * time.Hour)}, true},
+		{"succeeded yesterday", &database.CronRun{Status: database.CronRunStatusSucceeded, EndedAt: endedAt(-24 * time.Hour)}, false},
+		{"failed today", &database.CronRun{Status: database.CronRunStatusFailed, EndedAt: endedAt(-time.Hour)}, false},
+		{"still running", &database.CronRun{Status: database.CronRunStatusRunning}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := upstreamSucceededToday(tt.run, now); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckCronDependency(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		Crons: map[string]config.CronConfig{
+			"fetch":     {ID: "cron-fetch", Schedule: "0 6 * * *"},
+			"summarize": {ID: "cron-summarize", Schedule: "0 7 * * *", After: "fetch"},
+		},
+	})
+	req := CreateMissionRequest{
+		Source:         "cron",
+		SourceID:       "cron-summarize",
+		SourceMetadata: `{"cron_name":"summarize"}`,
+	}
+
+	// Upstream has not run today: the firing is skipped and recorded
+	err := srv.checkCronDependency(req)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusConflict {
+		t.Fatalf("expected 409 while upstream has not succeeded, got %v", err)
+	}
+	runs, err := srv.db.ListCronRuns(database.ListCronRunsParams{CronID: "cron-summarize"})
+	if err != nil {
+		t.Fatalf("ListCronRuns failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Status != database.CronRunStatusSkipped {
+		t.Fatalf("expected one skipped run, got %+v", runs)
+	}
+
+	// Manual triggers are never held back
+	manualReq := req
+	manualReq.SourceMetadata = `{"cron_name":"summarize","trigger":"manual"}`
+	if err := srv.checkCronDependency(manualReq); err != nil {
+		t.Fatalf("manual trigger should not be held back: %v", err)
+	}
+
+	// Once the upstream succeeds today, the firing proceeds
+	endedAt := time.Now().UTC()
+	if err := srv.db.CreateCronRun(&database.CronRun{
+		ID:       "fetch-run",
+		CronID:   "cron-fetch",
+		CronName: "fetch",
+		Trigger:  database.CronRunTriggerSchedule,
+		Status:   database.CronRunStatusSucceeded,
+		EndedAt:  &endedAt,
+	}); err != nil {
+		t.Fatalf("CreateCronRun failed: %v", err)
+	}
+	if err := srv.checkCronDependency(req); err != nil {
+		t.Fatalf("expected firing to proceed after upstream succeeded: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/notify"
+	"github.com/odyssey/agenc/internal/secrets"
+)
+
+// cronNotifyTimeout bounds delivery of one cron run message to all of a
+// cron's notify targets.
+const cronNotifyTimeout = 30 * time.Second
+
+// cronNotifyStarted is the status reported when a cron run's mission starts;
+// finished runs report their database.CronRunStatus*.
+const cronNotifyStarted = "started"
+
+// notifyCronRunForMission sends the cron notify message for the cron run
+// spawned by missionID. No-op for missions not started by a cron.
+func (s *Server) notifyCronRunForMission(missionID string, status string, detail string) {
+	missionRecord, err := s.db.GetMission(missionID)
+	if err != nil || missionRecord == nil || missionRecord.SourceID == nil {
+		return
+	}
+	if missionRecord.Source == nil || *missionRecord.Source != "cron" {
+		return
+	}
+	s.notifyCronRun(*missionRecord.SourceID, missionID, status, detail)
+}
+
+// notifyCronRun delivers a start, success, or failure message for a run of
+// the cron identified by cronID to the cron's notify targets, in the
+// background. Best-effort: delivery failures are logged. missionID may be
+// empty when the run's mission was deleted. Messages are dropped during quiet
+// hours unless the cron sets ignoreQuietHours. A failure also raises the
+// cron's consecutive-failure alert when it reaches the threshold.
+func (s *Server) notifyCronRun(cronID string, missionID string, status string, detail string) {
+	cronName, cronCfg, ok := s.findCronByID(cronID)
+	if !ok {
+		return
+	}
+	msg := buildCronRunMessage(cronName, missionID, status, detail, config.GetMissionClaudeOutputLogFilepath(s.agencDirpath, missionID))
+	s.sendCronMessage(cronName, cronCfg, status, msg)
+	if status == database.CronRunStatusFailed {
+		s.alertOnCronFailureStreak(cronName, cronCfg, missionID)
+	}
+}
+
+// sendCronMessage delivers msg to the cron's notify targets in the
+// background, dropping it during quiet hours unless the cron sets
+// ignoreQuietHours. what names the message in logs. Best-effort: delivery
+// failures are logged.
+func (s *Server) sendCronMessage(cronName string, cronCfg config.CronConfig, what string, msg notify.Message) {
+	if len(cronCfg.Notify) == 0 {
+		return
+	}
+	if !cronCfg.IgnoreQuietHours && s.getConfig().QuietHours.IsActive(time.Now()) {
+		s.logger.Printf("Cron notify: suppressed '%s' for cron '%s' during quiet hours", what, cronName)
+		return
+	}
+
+	var targets []notify.Target
+	for _, rawTarget := range cronCfg.Notify {
+		target, err := notify.ParseTarget(rawTarget)
+		if err != nil {
+			s.logger.Printf("Cron notify: skipping target for cron '%s': %v", cronName, err)
+			continue
+		}
+		targets = append(targets, target)
+	}
+
+	cfg := s.getConfig()
+	go func() {
+		dispatcher, err := s.buildNotifyDispatcher(cfg)
+		if err != nil {
+			s.logger.Printf("Cron notify: failed to set up notifiers for cron '%s': %v", cronName, err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cronNotifyTimeout)
+		defer cancel()
+		if err := dispatcher.Send(ctx, targets, msg); err != nil {
+			s.logger.Printf("Cron notify: failed to deliver '%s' for cron '%s': %v", what, cronName, err)
+		}
+	}()
+}
+
+// findCronByID returns the name and config of the cron with the given ID.
+func (s *Server) findCronByID(cronID string) (string, config.CronConfig, bool) {
+	cfg := s.getConfig()
+	if cfg == nil || cronID == "" {
+		return "", config.CronConfig{}, false
+	}
+	for name, cronCfg := range cfg.Crons {
+		if cronCfg.ID == cronID {
+			return name, cronCfg, true
+		}
+	}
+	return "", config.CronConfig{}, false
+}
+
+// buildNotifyDispatcher registers a notifier for each delivery method
+// configured under notifications, plus webhooks, which need no
+// configuration. secret://NAME references in credentials are resolved just
+// before use.
+func (s *Server) buildNotifyDispatcher(cfg *config.AgencConfig) (*notify.Dispatcher, error) {
+	dispatcher := notify.NewDispatcher()
+	dispatcher.Register(notify.KindWebhook, notify.NewWebhookNotifier())
+	if cfg == nil || cfg.Notifications == nil {
+		return dispatcher, nil
+	}
+
+	var store *secrets.Store
+	expand := func(value string) (string, error) {
+		if !secrets.HasRefs(value) {
+			return value, nil
+		}
+		if store == nil {
+			opened, err := secrets.Open(s.agencDirpath)
+			if err != nil {
+				return "", err
+			}
+			store = opened
+		}
+		return store.Expand(value)
+	}
+
+	if slack := cfg.Notifications.Slack; slack != nil {
+		token, err := expand(slack.Token)
+		if err != nil {
+			return nil, err
+		}
+		dispatcher.Register(notify.KindSlack, notify.NewSlackNotifier(token))
+	}
+	if email := cfg.Notifications.Email; email != nil {
+		password, err := expand(email.Password)
+		if err != nil {
+			return nil, err
+		}
+		dispatcher.Register(notify.KindEmail, notify.NewEmailNotifier(email.SMTPHost, email.SMTPPort, email.Username, password, email.From))
+	}
+	return dispatcher, nil
+}
+
+// buildCronRunMessage renders a cron run notification naming the mission and
+// linking its output log.
+func buildCronRunMessage(cronName string, missionID string, status string, detail string, outputLogFilepath string) notify.Message {
+	subject := fmt.Sprintf("AgenC cron '%s' %s", cronName, status)
+	var lines []string
+	if missionID != "" {
+		subject += " (mission " + database.ShortID(missionID) + ")"
+		lines = append(lines, "Mission: "+database.ShortID(missionID))
+	}
+	if detail != "" {
+		lines = append(lines, "Detail: "+detail)
+	}
+	if missionID != "" {
+		lines = append(lines, "Output log: file://"+outputLogFilepath)
+	}
+	return notify.Message{Subject: subject, Body: strings.Join(lines, "\n")}
+}
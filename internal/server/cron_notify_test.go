@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/notify"
+)
+
+func TestBuildCronRunMessage(t *testing.T) {
+	msg := buildCronRunMessage("nightly", "1234abcd-0000-0000-0000-000000000000", "failed", "claude exited with code 1", "/agenc/missions/x/claude-output.log")
+	if msg.Subject != "AgenC cron 'nightly' failed (mission 1234abcd)" {
+		t.Errorf("unexpected subject: %q", msg.Subject)
+	}
+	wantBody := "Mission: 1234abcd\nDetail: claude exited with code 1\nOutput log: file:///agenc/missions/x/claude-output.log"
+	if msg.Body != wantBody {
+		t.Errorf("unexpected body:\n%s\nwant:\n%s", msg.Body, wantBody)
+	}
+
+	msg = buildCronRunMessage("nightly", "", "failed", "mission deleted before the run finished", "")
+	if strings.Contains(msg.Subject, "mission") || strings.Contains(msg.Body, "Output log") {
+		t.Errorf("expected no mission details without a mission, got %+v", msg)
+	}
+}
+
+func TestBuildNotifyDispatcher(t *testing.T) {
+	s := &Server{agencDirpath: t.TempDir()}
+	cfg := &config.AgencConfig{Notifications: &config.NotificationsConfig{
+		Slack: &config.SlackNotificationsConfig{Token: "xoxb-plain"},
+	}}
+	dispatcher, err := s.buildNotifyDispatcher(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Email isn't configured, so only that target fails.
+	err = dispatcher.Send(context.Background(), []notify.Target{{Kind: notify.KindEmail, Address: "me@example.com"}}, notify.Message{})
+	if err == nil || !strings.Contains(err.Error(), "no notifier configured for 'email:me@example.com'") {
+		t.Errorf("expected email to be unconfigured, got %v", err)
+	}
+
+	cfg.Notifications.Slack.Token = "secret://MISSING_TOKEN"
+	if _, err := s.buildNotifyDispatcher(cfg); err == nil || !strings.Contains(err.Error(), "MISSING_TOKEN") {
+		t.Errorf("expected the unset secret to be named, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"slices"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// renderCronRunPrompt fills in the {{name}} variables of a cron firing's
+// prompt (see config.CronPromptVariables) as of now. Only referenced
+// variables are looked up; one that can't be resolved renders empty and is
+// logged. Prompts without variables are returned unchanged.
+func (s *Server) renderCronRunPrompt(req CreateMissionRequest, now time.Time) string {
+	referenced := config.CronPromptReferences(req.Prompt)
+	if len(referenced) == 0 {
+		return req.Prompt
+	}
+
+	cronName, _ := parseCronSourceMetadata(req.SourceMetadata)
+	if name, _, ok := s.getConfig().GetCronByID(req.SourceID); ok {
+		cronName = name
+	}
+	local := now.Local()
+	values := map[string]string{
+		config.CronPromptVarDate:     local.Format("2006-01-02"),
+		config.CronPromptVarTime:     local.Format("15:04"),
+		config.CronPromptVarWeekday:  local.Weekday().String(),
+		config.CronPromptVarCronName: cronName,
+		config.CronPromptVarRepo:     req.Repo,
+	}
+
+	if slices.ContainsFunc(referenced, func(name string) bool {
+		return name == config.CronPromptVarLastRun || name == config.CronPromptVarLastRunStatus || name == config.CronPromptVarLastSuccess
+	}) {
+		runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: req.SourceID})
+		if err != nil {
+			s.logger.Printf("Cron prompt: failed to list runs of cron '%s': %v", cronName, err)
+		}
+		for name, value := range cronRunPromptValues(runs) {
+			values[name] = value
+		}
+	}
+
+	if slices.Contains(referenced, config.CronPromptVarRepoDefaultBranchHead) && req.Repo != "" {
+		libraryDirpath := config.GetRepoDirpath(s.agencDirpath, req.Repo)
+		if mission.IsRepoStale(libraryDirpath, cronResumeLibraryMaxAge) && !s.connectivity.isOffline() {
+			if err := mission.ForceUpdateRepo(libraryDirpath); err != nil {
+				s.logger.Printf("Cron prompt: failed to pull '%s': %v (using the cached clone)", req.Repo, err)
+			}
+		}
+		head, err := mission.GetDefaultBranchHead(libraryDirpath)
+		if err != nil {
+			s.logger.Printf("Cron prompt: failed to read the default branch head of '%s' for cron '%s': %v", req.Repo, cronName, err)
+		}
+		values[config.CronPromptVarRepoDefaultBranchHead] = head
+	}
+
+	return config.RenderCronPrompt(req.Prompt, values)
+}
+
+// cronRunPromptValues returns the lastRun, lastRunStatus, and lastSuccess
+// prompt variables from a cron's runs, newest first. Skipped firings aren't
+// runs.
+func cronRunPromptValues(runs []*database.CronRun) map[string]string {
+	values := map[string]string{
+		config.CronPromptVarLastRun:       config.CronPromptNever,
+		config.CronPromptVarLastRunStatus: config.CronPromptNever,
+		config.CronPromptVarLastSuccess:   config.CronPromptNever,
+	}
+	foundRun := false
+	for _, run := range runs {
+		if run.Status == database.CronRunStatusSkipped {
+			continue
+		}
+		if !foundRun {
+			values[config.CronPromptVarLastRun] = run.StartedAt.Local().Format(time.RFC3339)
+			values[config.CronPromptVarLastRunStatus] = run.Status
+			foundRun = true
+		}
+		if run.Status == database.CronRunStatusSucceeded && run.EndedAt != nil {
+			values[config.CronPromptVarLastSuccess] = run.EndedAt.Local().Format(time.RFC3339)
+			break
+		}
+	}
+	return values
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestCronRunPromptValues(t *testing.T) {
+	base := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	// Newest first, as ListCronRuns returns them
+	runs := []*database.CronRun{
+		{Status: database.CronRunStatusSkipped, StartedAt: base.Add(3 * time.Hour)},
+		cronTestRun(database.CronRunStatusFailed, base.Add(2*time.Hour), time.Minute),
+		cronTestRun(database.CronRunStatusSucceeded, base.Add(time.Hour), time.Minute),
+	}
+
+	values := cronRunPromptValues(runs)
+	if want := base.Add(2 * time.Hour).Local().Format(time.RFC3339); values[config.CronPromptVarLastRun] != want {
+		t.Errorf("expected lastRun %s (skipping the skipped firing), got %s", want, values[config.CronPromptVarLastRun])
+	}
+	if values[config.CronPromptVarLastRunStatus] != database.CronRunStatusFailed {
+		t.Errorf("expected lastRunStatus failed, got %s", values[config.CronPromptVarLastRunStatus])
+	}
+	if want := base.Add(time.Hour + time.Minute).Local().Format(time.RFC3339); values[config.CronPromptVarLastSuccess] != want {
+		t.Errorf("expected lastSuccess %s, got %s", want, values[config.CronPromptVarLastSuccess])
+	}
+
+	none := cronRunPromptValues(nil)
+	for _, name := range []string{config.CronPromptVarLastRun, config.CronPromptVarLastRunStatus, config.CronPromptVarLastSuccess} {
+		if none[name] != config.CronPromptNever {
+			t.Errorf("expected %s to be %q before any run, got %q", name, config.CronPromptNever, none[name])
+		}
+	}
+}
+
+func TestRenderCronRunPrompt(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	cronCfg := config.CronConfig{ID: "cron-1", Schedule: "0 3 * * *"}
+	srv.cachedConfig.Store(&config.AgencConfig{Crons: map[string]config.CronConfig{"nightly": cronCfg}})
+
+	startedAt := time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(time.Minute)
+	if err := srv.db.CreateCronRun(&database.CronRun{
+		ID:        uuid.New().String(),
+		CronID:    cronCfg.ID,
+		CronName:  "nightly",
+		Trigger:   database.CronRunTriggerSchedule,
+		Status:    database.CronRunStatusSucceeded,
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+	}); err != nil {
+		t.Fatalf("CreateCronRun failed: %v", err)
+	}
+
+	now := time.Date(2026, 10, 17, 3, 0, 0, 0, time.Local)
+	got := srv.renderCronRunPrompt(CreateMissionRequest{
+		Source:   "cron",
+		SourceID: cronCfg.ID,
+		Prompt:   "{{cronName}} on {{weekday}} {{date}}: summarize commits since {{lastRun}} ({{lastRunStatus}}) {{other}}",
+	}, now)
+	want := "nightly on Saturday 2026-10-17: summarize commits since " + startedAt.Local().Format(time.RFC3339) + " (succeeded) {{other}}"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
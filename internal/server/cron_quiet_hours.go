@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// cronQuietHoursDetailPrefix starts the detail of a run deferred because it
+// fired during quiet hours. Runs carrying it are started once quiet hours end.
+const cronQuietHoursDetailPrefix = "deferred until quiet hours end"
+
+// quietHoursCheckInterval is how often the server checks whether quiet hours
+// have ended.
+const quietHoursCheckInterval = time.Minute
+
+// quietHoursDeferralMaxAge bounds how old a deferred run can be and still be
+// started when quiet hours end, so a server that was down for days does not
+// replay stale firings.
+const quietHoursDeferralMaxAge = 24 * time.Hour
+
+// checkCronQuietHours defers a scheduled cron firing that lands inside the
+// configured quiet hours, recording it as a skipped run that is started once
+// quiet hours end. Manual triggers and crons with ignoreQuietHours are never
+// held back.
+func (s *Server) checkCronQuietHours(req CreateMissionRequest, now time.Time) error {
+	cronName, trigger := parseCronSourceMetadata(req.SourceMetadata)
+	if trigger == database.CronRunTriggerManual {
+		return nil
+	}
+
+	cfg := s.getConfig()
+	if !cfg.QuietHours.IsActive(now) {
+		return nil
+	}
+	_, cronCfg, ok := cfg.GetCronByID(req.SourceID)
+	if !ok || cronCfg.IgnoreQuietHours {
+		return nil
+	}
+
+	nowUTC := now.UTC()
+	skipped := &database.CronRun{
+		ID:        uuid.New().String(),
+		CronID:    req.SourceID,
+		CronName:  cronName,
+		Trigger:   database.CronRunTriggerSchedule,
+		Status:    database.CronRunStatusSkipped,
+		Detail:    cronQuietHoursDetailPrefix + " at " + cfg.QuietHours.End,
+		StartedAt: nowUTC,
+		EndedAt:   &nowUTC,
+	}
+	if err := s.db.CreateCronRun(skipped); err != nil {
+		s.logger.Printf("Quiet hours: failed to record deferred run for cron %s: %v", req.SourceID, err)
+	}
+	return newHTTPErrorf(http.StatusConflict, "cron '%s' skipped: %s", cronName, skipped.Detail)
+}
+
+// runQuietHoursLoop starts deferred crons whenever quiet hours end. The server
+// treats its own startup as the end of quiet hours, so firings deferred before
+// a restart are not lost.
+func (s *Server) runQuietHoursLoop(ctx context.Context) {
+	wasQuiet := true
+
+	ticker := time.NewTicker(quietHoursCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			isQuiet := s.getConfig().QuietHours.IsActive(now)
+			if wasQuiet && !isQuiet {
+				s.startQuietHoursDeferredCrons(now)
+			}
+			wasQuiet = isQuiet
+		}
+	}
+}
+
+// startQuietHoursDeferredCrons starts every cron whose latest run was
+// deferred by quiet hours. Several deferred firings of one cron start a single
+// run. Best-effort: failures are logged.
+func (s *Server) startQuietHoursDeferredCrons(now time.Time) {
+	cfg := s.getConfig()
+	for _, name := range s.quietHoursDeferredCrons(now) {
+		if err := s.launchCronMission(name, cfg.Crons[name]); err != nil {
+			s.logger.Printf("Quiet hours: failed to start deferred cron '%s': %v", name, err)
+			continue
+		}
+		s.logger.Printf("Quiet hours: started deferred cron '%s'", name)
+	}
+}
+
+// quietHoursDeferredCrons returns the names of enabled crons, sorted, whose
+// latest run was deferred by quiet hours within quietHoursDeferralMaxAge.
+func (s *Server) quietHoursDeferredCrons(now time.Time) []string {
+	var names []string
+	for name, cronCfg := range s.getConfig().Crons {
+		if !cronCfg.IsEnabled() || cronCfg.ID == "" {
+			continue
+		}
+		runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: cronCfg.ID, Limit: 1})
+		if err != nil || len(runs) == 0 {
+			continue
+		}
+		last := runs[0]
+		if last.Status != database.CronRunStatusSkipped ||
+			!strings.HasPrefix(last.Detail, cronQuietHoursDetailPrefix) ||
+			now.Sub(last.StartedAt) > quietHoursDeferralMaxAge {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
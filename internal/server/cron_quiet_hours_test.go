@@ -0,0 +1,71 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestCheckCronQuietHours(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		QuietHours: &config.QuietHoursConfig{Start: "23:00", End: "07:00"},
+		Crons: map[string]config.CronConfig{
+			"report": {ID: "cron-report", Schedule: "0 2 * * *"},
+			"oncall": {ID: "cron-oncall", Schedule: "0 2 * * *", IgnoreQuietHours: true},
+		},
+	})
+	req := CreateMissionRequest{
+		Source:         "cron",
+		SourceID:       "cron-report",
+		SourceMetadata: `{"cron_name":"report"}`,
+	}
+	quiet := time.Date(2026, 6, 2, 2, 0, 0, 0, time.Local)
+	daytime := time.Date(2026, 6, 2, 9, 0, 0, 0, time.Local)
+
+	// Outside quiet hours the firing proceeds
+	if err := srv.checkCronQuietHours(req, daytime); err != nil {
+		t.Fatalf("expected firing outside quiet hours to proceed, got %v", err)
+	}
+
+	// Inside quiet hours the firing is deferred and recorded
+	err := srv.checkCronQuietHours(req, quiet)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusConflict {
+		t.Fatalf("expected 409 during quiet hours, got %v", err)
+	}
+	runs, err := srv.db.ListCronRuns(database.ListCronRunsParams{CronID: "cron-report"})
+	if err != nil {
+		t.Fatalf("ListCronRuns failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Status != database.CronRunStatusSkipped {
+		t.Fatalf("expected one skipped run, got %+v", runs)
+	}
+	if got := srv.quietHoursDeferredCrons(quiet.Add(5 * time.Hour)); len(got) != 1 || got[0] != "report" {
+		t.Errorf("expected 'report' to be started when quiet hours end, got %v", got)
+	}
+	if got := srv.quietHoursDeferredCrons(quiet.Add(2 * quietHoursDeferralMaxAge)); len(got) != 0 {
+		t.Errorf("expected stale deferrals to be dropped, got %v", got)
+	}
+
+	// Manual triggers are never held back
+	manualReq := req
+	manualReq.SourceMetadata = `{"cron_name":"report","trigger":"manual"}`
+	if err := srv.checkCronQuietHours(manualReq, quiet); err != nil {
+		t.Fatalf("manual trigger should not be held back: %v", err)
+	}
+
+	// Crons with ignoreQuietHours run on schedule
+	oncallReq := CreateMissionRequest{
+		Source:         "cron",
+		SourceID:       "cron-oncall",
+		SourceMetadata: `{"cron_name":"oncall"}`,
+	}
+	if err := srv.checkCronQuietHours(oncallReq, quiet); err != nil {
+		t.Fatalf("ignoreQuietHours cron should not be held back: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// resolveCronRef resolves the ref a cron run is pinned to in the library
+// clone of its repo, fetching once if the ref is newer than the clone's last
+// fetch. Returns nil when req is not a cron run or its cron has no ref, and a
+// 400 when the ref matches nothing, so the run fails instead of silently
+// starting from the default branch.
+func (s *Server) resolveCronRef(req CreateMissionRequest, repoName string, cloneDirpath string) (*mission.RepoRef, error) {
+	if req.Source != "cron" || req.SourceID == "" || cloneDirpath == "" {
+		return nil, nil
+	}
+	cronName, cronCfg, ok := s.getConfig().GetCronByID(req.SourceID)
+	if !ok || cronCfg.Ref == "" {
+		return nil, nil
+	}
+
+	ref, err := mission.ResolveRef(cloneDirpath, cronCfg.Ref)
+	if err != nil && !s.connectivity.isOffline() {
+		if fetchErr := mission.ForceUpdateRepo(cloneDirpath); fetchErr == nil {
+			ref, err = mission.ResolveRef(cloneDirpath, cronCfg.Ref)
+		} else {
+			s.logger.Printf("Cron ref: failed to fetch '%s' looking for ref '%s': %v", repoName, cronCfg.Ref, fetchErr)
+		}
+	}
+	if err != nil {
+		return nil, newHTTPErrorf(http.StatusBadRequest, "cron '%s' is pinned to ref '%s', which matches no branch, tag, or commit in '%s'", cronName, cronCfg.Ref, repoName)
+	}
+	return &ref, nil
+}
+
+// checkoutCronRef moves a new mission's checkout to the ref its cron run was
+// pinned to. The mission's own branch (from autoBranch or worktree mode), if
+// it has one, is kept and reset to the ref's commit.
+func (s *Server) checkoutCronRef(missionRecord *database.Mission, ref *mission.RepoRef, workspaceMode string, branchName string) error {
+	if branchName == "" && workspaceMode == config.WorkspaceModeWorktree {
+		branchName = mission.WorktreeBranchName(missionRecord.ID)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+	return mission.CheckoutRef(agentDirpath, *ref, branchName)
+}
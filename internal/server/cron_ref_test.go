@@ -0,0 +1,87 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestResolveCronRef(t *testing.T) {
+	const repo = "github.com/owner/repo"
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		Crons: map[string]config.CronConfig{
+			"nightly": {ID: "cron-nightly", Schedule: "0 6 * * *", Prompt: "Summarize", Repo: repo, Ref: "v1"},
+			"hotfix":  {ID: "cron-hotfix", Schedule: "0 6 * * *", Prompt: "Summarize", Repo: repo, Ref: "hotfix"},
+			"missing": {ID: "cron-missing", Schedule: "0 6 * * *", Prompt: "Summarize", Repo: repo, Ref: "nope"},
+			"default": {ID: "cron-default", Schedule: "0 6 * * *", Prompt: "Summarize", Repo: repo},
+		},
+	})
+
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	upstreamDirpath := filepath.Join(t.TempDir(), "upstream")
+	runGit(filepath.Dir(upstreamDirpath), "init", upstreamDirpath)
+	runGit(upstreamDirpath, "-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "initial")
+	runGit(upstreamDirpath, "tag", "v1")
+	tagCommit := runGit(upstreamDirpath, "rev-parse", "HEAD")
+	libraryDirpath := config.GetRepoDirpath(srv.agencDirpath, repo)
+	runGit(srv.agencDirpath, "clone", upstreamDirpath, libraryDirpath)
+
+	request := func(cronID string) CreateMissionRequest {
+		return CreateMissionRequest{Repo: repo, Source: "cron", SourceID: cronID}
+	}
+
+	ref, err := srv.resolveCronRef(request("cron-nightly"), repo, libraryDirpath)
+	if err != nil || ref == nil || ref.Name != "v1" || ref.Commit != tagCommit {
+		t.Fatalf("expected v1 resolved to %s, got %+v (err %v)", tagCommit, ref, err)
+	}
+
+	// A branch pushed after the library's last fetch is found by fetching
+	runGit(upstreamDirpath, "branch", "hotfix")
+	ref, err = srv.resolveCronRef(request("cron-hotfix"), repo, libraryDirpath)
+	if err != nil || ref == nil || !ref.IsBranch || ref.Commit != tagCommit {
+		t.Fatalf("expected the new hotfix branch resolved, got %+v (err %v)", ref, err)
+	}
+
+	_, err = srv.resolveCronRef(request("cron-missing"), repo, libraryDirpath)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+		t.Errorf("expected 400 for a ref that matches nothing, got %v", err)
+	}
+
+	for _, req := range []CreateMissionRequest{request("cron-default"), {Repo: repo}} {
+		if ref, err := srv.resolveCronRef(req, repo, libraryDirpath); err != nil || ref != nil {
+			t.Errorf("expected no ref for %+v, got %+v (err %v)", req, ref, err)
+		}
+	}
+}
+
+func TestValidateCronRef(t *testing.T) {
+	const repo = "github.com/owner/repo"
+	if err := validateCronRef("", ""); err != nil {
+		t.Errorf("expected no ref to be valid, got %v", err)
+	}
+	if err := validateCronRef("release/2.4", repo); err != nil {
+		t.Errorf("expected a branch ref to be valid, got %v", err)
+	}
+	for _, tt := range []struct{ ref, repo string }{{"v1", ""}, {"main..dev", repo}} {
+		var httpErr *httpError
+		if err := validateCronRef(tt.ref, tt.repo); !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+			t.Errorf("expected 400 for ref %q on repo %q, got %v", tt.ref, tt.repo, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/session"
+)
+
+// Outcomes of resolveCronResume.
+const (
+	cronResumeActionFresh    = "fresh"
+	cronResumeActionContinue = "continue"
+	cronResumeActionFork     = "fork"
+)
+
+// cronResumeLibraryMaxAge is how recently the repo library must have been
+// fetched for forkIfBehind to trust its default branch without pulling first.
+const cronResumeLibraryMaxAge = 15 * time.Minute
+
+// resolveCronResume decides how a cron firing treats its previous run under
+// the cron's resumePolicy, returning the previous run's mission when it is
+// continued or forked. Anything that rules out reusing the previous run
+// (none yet, archived, no conversation, still running, repo changed) means a
+// fresh mission.
+func (s *Server) resolveCronResume(req CreateMissionRequest) (*database.Mission, string) {
+	_, cronCfg, ok := s.getConfig().GetCronByID(req.SourceID)
+	if !ok {
+		return nil, cronResumeActionFresh
+	}
+	policy := cronCfg.GetResumePolicy()
+	if policy.Mode == config.CronResumeFresh {
+		return nil, cronResumeActionFresh
+	}
+
+	previous := s.previousCronMission(req.SourceID)
+	if previous == nil || previous.GitRepo != req.Repo || !s.missionHasConversation(previous.ID) {
+		return nil, cronResumeActionFresh
+	}
+	if policy.Mode == config.CronResumeContinue || previous.GitRepo == "" || len(previous.GitRepos) > 0 {
+		return previous, cronResumeActionContinue
+	}
+
+	libraryDirpath := config.GetRepoDirpath(s.agencDirpath, previous.GitRepo)
+	if mission.IsRepoStale(libraryDirpath, cronResumeLibraryMaxAge) {
+		if err := mission.ForceUpdateRepo(libraryDirpath); err != nil {
+			s.logger.Printf("Cron resume: failed to pull '%s': %v (comparing against the stale copy)", previous.GitRepo, err)
+		}
+	}
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, previous.ID)
+	behind, err := mission.CountCommitsBehind(agentDirpath, libraryDirpath)
+	if err != nil {
+		s.logger.Printf("Cron resume: failed to compare mission %s with '%s': %v (continuing it)", previous.ShortID, previous.GitRepo, err)
+		return previous, cronResumeActionContinue
+	}
+	if behind > policy.MaxCommitsBehind {
+		s.logger.Printf("Cron resume: mission %s is %d commits behind '%s'; forking it", previous.ShortID, behind, previous.GitRepo)
+		return previous, cronResumeActionFork
+	}
+	return previous, cronResumeActionContinue
+}
+
+// previousCronMission returns the mission of the cron's most recent run, or
+// nil if it has none, it is archived, or that run is still going.
+func (s *Server) previousCronMission(cronID string) *database.Mission {
+	runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: cronID})
+	if err != nil {
+		s.logger.Printf("Cron resume: failed to list runs for cron %s: %v", cronID, err)
+		return nil
+	}
+	for _, run := range runs {
+		if run.MissionID == nil {
+			continue
+		}
+		if run.Status == database.CronRunStatusRunning {
+			return nil
+		}
+		previous, err := s.db.GetMission(*run.MissionID)
+		if err != nil || previous == nil || previous.Status == "archived" {
+			return nil
+		}
+		return previous
+	}
+	return nil
+}
+
+// missionHasConversation reports whether Claude has recorded a conversation
+// in the mission's working directory.
+func (s *Server) missionHasConversation(missionID string) bool {
+	projectDirpath, err := claudeconfig.ComputeProjectDirpath(config.GetMissionWorkDirpath(s.agencDirpath, missionID))
+	if err != nil {
+		return false
+	}
+	return len(session.ListProjectSessionIDs(projectDirpath)) > 0
+}
+
+// continueCronMission runs a cron firing in its previous run's mission,
+// restarting the wrapper so Claude resumes the conversation with the cron's
+// prompt as the next message.
+func (s *Server) continueCronMission(w http.ResponseWriter, previous *database.Mission, req CreateMissionRequest) error {
+	release, ok := s.tryAcquireReloadLock(previous.ID)
+	if !ok {
+		return newHTTPError(http.StatusConflict, "reload already in progress for mission "+previous.ShortID)
+	}
+	defer release()
+
+	if previous.TmuxPane != nil && *previous.TmuxPane != "" && tmuxPaneExists(*previous.TmuxPane) && s.isWrapperRunning(previous.ID) {
+		if err := s.reloadMissionInTmux(previous, *previous.TmuxPane, req.Prompt); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to resume mission %s: %s", previous.ShortID, err.Error())
+		}
+	} else {
+		if err := s.stopWrapper(previous.ID); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to stop wrapper: %s", err.Error())
+		}
+		if err := s.spawnWrapper(previous, req); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to resume mission %s: %s", previous.ShortID, err.Error())
+		}
+		if err := s.db.ClearMissionSuspended(previous.ID); err != nil {
+			s.logger.Printf("Warning: failed to clear suspended status for mission %s: %v", previous.ShortID, err)
+		}
+	}
+	s.logger.Printf("Cron %s continued in mission %s", req.SourceID, previous.ShortID)
+
+	s.recordCronTrigger(previous, req)
+	writeJSON(w, http.StatusOK, toMissionResponse(previous))
+	return nil
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// newCronResumeTestServer returns a test server with one cron using policy
+// and a finished run of it in a mission on repo that has a conversation.
+func newCronResumeTestServer(t *testing.T, policy string, repo string) (*Server, *database.Mission) {
+	t.Helper()
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		Crons: map[string]config.CronConfig{
+			"nightly": {ID: "cron-nightly", Schedule: "0 6 * * *", Prompt: "Summarize", Repo: repo, ResumePolicy: policy},
+		},
+	})
+
+	source, sourceID := "cron", "cron-nightly"
+	previous, err := srv.db.CreateMission(repo, &database.CreateMissionParams{Source: &source, SourceID: &sourceID})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	writeSessionJSONL(t, srv.agencDirpath, previous.ID, "session-1", `{"type":"user"}`+"\n")
+	if err := srv.db.CreateCronRun(&database.CronRun{
+		ID:        "run-1",
+		CronID:    "cron-nightly",
+		CronName:  "nightly",
+		Trigger:   database.CronRunTriggerSchedule,
+		MissionID: &previous.ID,
+		Status:    database.CronRunStatusSucceeded,
+	}); err != nil {
+		t.Fatalf("CreateCronRun failed: %v", err)
+	}
+	return srv, previous
+}
+
+func cronResumeTestRequest(repo string) CreateMissionRequest {
+	return CreateMissionRequest{Repo: repo, Source: "cron", SourceID: "cron-nightly", SourceMetadata: `{"cron_name":"nightly"}`}
+}
+
+func TestResolveCronResume_Continue(t *testing.T) {
+	srv, previous := newCronResumeTestServer(t, "continue", "")
+
+	got, action := srv.resolveCronResume(cronResumeTestRequest(""))
+	if action != cronResumeActionContinue || got == nil || got.ID != previous.ID {
+		t.Fatalf("expected the previous mission continued, got %s %+v", action, got)
+	}
+
+	// A cron pointed at another repo since its last run starts over
+	if _, action := srv.resolveCronResume(cronResumeTestRequest("github.com/owner/other")); action != cronResumeActionFresh {
+		t.Errorf("expected a fresh mission after the repo changed, got %s", action)
+	}
+
+	if err := srv.db.ArchiveMission(previous.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, action := srv.resolveCronResume(cronResumeTestRequest("")); action != cronResumeActionFresh {
+		t.Errorf("expected a fresh mission once the previous one is archived, got %s", action)
+	}
+}
+
+func TestResolveCronResume_Fresh(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, srv *Server, previous *database.Mission)
+	}{
+		{
+			name: "default policy",
+			setup: func(t *testing.T, srv *Server, previous *database.Mission) {
+				cfg := srv.getConfig()
+				cronCfg := cfg.Crons["nightly"]
+				cronCfg.ResumePolicy = ""
+				cfg.Crons["nightly"] = cronCfg
+			},
+		},
+		{
+			name: "no conversation",
+			setup: func(t *testing.T, srv *Server, previous *database.Mission) {
+				if err := os.RemoveAll(filepath.Join(srv.agencDirpath, ".claude")); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "previous run still going",
+			setup: func(t *testing.T, srv *Server, previous *database.Mission) {
+				if err := srv.db.CreateCronRun(&database.CronRun{
+					ID:        "run-2",
+					CronID:    "cron-nightly",
+					CronName:  "nightly",
+					Trigger:   database.CronRunTriggerManual,
+					MissionID: &previous.ID,
+					Status:    database.CronRunStatusRunning,
+				}); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, previous := newCronResumeTestServer(t, "continue", "")
+			tt.setup(t, srv, previous)
+			if got, action := srv.resolveCronResume(cronResumeTestRequest("")); action != cronResumeActionFresh || got != nil {
+				t.Errorf("expected a fresh mission, got %s %+v", action, got)
+			}
+		})
+	}
+}
+
+func TestResolveCronResume_ForkIfBehind(t *testing.T) {
+	const repo = "github.com/owner/repo"
+	srv, previous := newCronResumeTestServer(t, "forkIfBehind(2)", repo)
+
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+	}
+	upstreamDirpath := filepath.Join(t.TempDir(), "upstream")
+	runGit(filepath.Dir(upstreamDirpath), "init", upstreamDirpath)
+	runGit(upstreamDirpath, "-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "initial")
+	libraryDirpath := config.GetRepoDirpath(srv.agencDirpath, repo)
+	runGit(srv.agencDirpath, "clone", upstreamDirpath, libraryDirpath)
+	runGit(srv.agencDirpath, "clone", libraryDirpath, config.GetMissionAgentDirpath(srv.agencDirpath, previous.ID))
+
+	commitUpstream := func(n int) {
+		for i := 0; i < n; i++ {
+			runGit(upstreamDirpath, "-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "upstream")
+		}
+		runGit(libraryDirpath, "fetch", "origin")
+	}
+
+	commitUpstream(2)
+	if _, action := srv.resolveCronResume(cronResumeTestRequest(repo)); action != cronResumeActionContinue {
+		t.Errorf("expected a mission 2 commits behind continued, got %s", action)
+	}
+
+	commitUpstream(1)
+	got, action := srv.resolveCronResume(cronResumeTestRequest(repo))
+	if action != cronResumeActionFork || got == nil || got.ID != previous.ID {
+		t.Errorf("expected a mission 3 commits behind forked, got %s %+v", action, got)
+	}
+}
@@ -0,0 +1,319 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/notify"
+)
+
+const (
+	// cronStatsWindow is how many of a cron's most recent runs its stats
+	// cover, so a long-fixed failure stops weighing on the success rate.
+	cronStatsWindow = 100
+
+	cronFailingNotificationKind = "cron.failing"
+)
+
+// cronDurationQuantiles are the run duration percentiles reported in
+// CronStats and GET /metrics.
+var cronDurationQuantiles = []float64{0.5, 0.9, 0.99}
+
+// CronStats summarizes a cron's most recent runs, in GET /crons/{name}/stats
+// and GET /metrics.
+type CronStats struct {
+	CronID   string `json:"cron_id"`
+	CronName string `json:"cron_name"`
+	// Succeeded, Failed, and Skipped count the cron's last cronStatsWindow
+	// runs by status; runs still going aren't counted
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	// SuccessRate is Succeeded over Succeeded plus Failed; nil until a run
+	// has finished
+	SuccessRate *float64 `json:"success_rate,omitempty"`
+	// DurationSeconds maps "p50", "p90", and "p99" to run duration
+	// percentiles of the finished runs; empty until a run has finished
+	DurationSeconds map[string]float64 `json:"duration_seconds,omitempty"`
+	// ConsecutiveFailures is how many of the newest finished runs failed in a
+	// row, ignoring skipped runs
+	ConsecutiveFailures        int        `json:"consecutive_failures"`
+	AlertOnConsecutiveFailures int        `json:"alert_on_consecutive_failures,omitempty"`
+	LastSuccessAt              *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt              *time.Time `json:"last_failure_at,omitempty"`
+}
+
+// computeCronStats summarizes runs, newest first as returned by
+// database.ListCronRuns.
+func computeCronStats(cronID string, cronName string, runs []*database.CronRun) CronStats {
+	stats := CronStats{CronID: cronID, CronName: cronName}
+	var durations []float64
+	streakOver := false
+	for _, run := range runs {
+		switch run.Status {
+		case database.CronRunStatusSkipped:
+			stats.Skipped++
+			continue
+		case database.CronRunStatusSucceeded:
+			stats.Succeeded++
+			if stats.LastSuccessAt == nil {
+				stats.LastSuccessAt = run.EndedAt
+			}
+			streakOver = true
+		case database.CronRunStatusFailed:
+			stats.Failed++
+			if stats.LastFailureAt == nil {
+				stats.LastFailureAt = run.EndedAt
+			}
+			if !streakOver {
+				stats.ConsecutiveFailures++
+			}
+		default:
+			continue
+		}
+		if run.EndedAt != nil {
+			durations = append(durations, run.EndedAt.Sub(run.StartedAt).Seconds())
+		}
+	}
+
+	if finished := stats.Succeeded + stats.Failed; finished > 0 {
+		rate := float64(stats.Succeeded) / float64(finished)
+		stats.SuccessRate = &rate
+	}
+	if len(durations) > 0 {
+		sort.Float64s(durations)
+		stats.DurationSeconds = make(map[string]float64, len(cronDurationQuantiles))
+		for _, q := range cronDurationQuantiles {
+			stats.DurationSeconds[cronQuantileLabel(q)] = nearestRankPercentile(durations, q)
+		}
+	}
+	return stats
+}
+
+// nearestRankPercentile returns the q-th quantile (0 < q <= 1) of sorted by
+// the nearest-rank method.
+func nearestRankPercentile(sorted []float64, q float64) float64 {
+	rank := int(math.Ceil(q * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// cronQuantileLabel returns the CronStats.DurationSeconds key of quantile q,
+// such as "p90" for 0.9.
+func cronQuantileLabel(q float64) string {
+	return "p" + strconv.FormatFloat(q*100, 'f', -1, 64)
+}
+
+// getCronStats computes the stats of the cron named cronName from its most
+// recent runs.
+func (s *Server) getCronStats(cronName string, cronCfg config.CronConfig) (CronStats, error) {
+	runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: cronCfg.ID, Limit: cronStatsWindow})
+	if err != nil {
+		return CronStats{}, err
+	}
+	stats := computeCronStats(cronCfg.ID, cronName, runs)
+	stats.AlertOnConsecutiveFailures = cronCfg.AlertOnConsecutiveFailures
+	return stats, nil
+}
+
+// handleGetCronStats handles GET /crons/{name}/stats. The path segment may be
+// a cron name or cron ID.
+func (s *Server) handleGetCronStats(w http.ResponseWriter, r *http.Request) error {
+	nameOrID := r.PathValue("name")
+	cronName, cronCfg, ok := s.findCronByNameOrID(nameOrID)
+	if !ok {
+		return newHTTPErrorf(http.StatusNotFound, "cron job '%s' not found", nameOrID)
+	}
+
+	stats, err := s.getCronStats(cronName, cronCfg)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	writeJSON(w, http.StatusOK, stats)
+	return nil
+}
+
+// handleListCronStats handles GET /crons/stats: the stats of every
+// configured cron, sorted by name.
+func (s *Server) handleListCronStats(w http.ResponseWriter, r *http.Request) error {
+	allStats, err := s.listCronStats()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	writeJSON(w, http.StatusOK, allStats)
+	return nil
+}
+
+// handleMetrics handles GET /metrics: every configured cron's stats in the
+// Prometheus text exposition format, for scraping over the serverListen TCP
+// listener with an API token.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) error {
+	allStats, err := s.listCronStats()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(formatCronMetrics(allStats))) // response already started; write error cannot be propagated
+	return nil
+}
+
+// listCronStats returns the stats of every configured cron, sorted by name.
+func (s *Server) listCronStats() ([]CronStats, error) {
+	cfg := s.getConfig()
+	names := make([]string, 0, len(cfg.Crons))
+	for name := range cfg.Crons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	allStats := make([]CronStats, 0, len(names))
+	for _, name := range names {
+		stats, err := s.getCronStats(name, cfg.Crons[name])
+		if err != nil {
+			return nil, err
+		}
+		allStats = append(allStats, stats)
+	}
+	return allStats, nil
+}
+
+// formatCronMetrics renders cron stats in the Prometheus text exposition
+// format. Each series is labelled with the cron's name.
+func formatCronMetrics(allStats []CronStats) string {
+	var b strings.Builder
+	writeFamily := func(name string, metricType string, help string, write func(stats CronStats)) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+		for _, stats := range allStats {
+			write(stats)
+		}
+	}
+	sample := func(name string, labels string, value float64) {
+		fmt.Fprintf(&b, "%s{%s} %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	cronLabel := func(stats CronStats) string {
+		return `cron="` + escapePrometheusLabel(stats.CronName) + `"`
+	}
+
+	writeFamily("agenc_cron_runs", "gauge", fmt.Sprintf("Runs of the cron among its last %d, by status.", cronStatsWindow), func(stats CronStats) {
+		sample("agenc_cron_runs", cronLabel(stats)+`,status="succeeded"`, float64(stats.Succeeded))
+		sample("agenc_cron_runs", cronLabel(stats)+`,status="failed"`, float64(stats.Failed))
+		sample("agenc_cron_runs", cronLabel(stats)+`,status="skipped"`, float64(stats.Skipped))
+	})
+	writeFamily("agenc_cron_success_ratio", "gauge", "Share of the cron's recent finished runs that succeeded.", func(stats CronStats) {
+		if stats.SuccessRate != nil {
+			sample("agenc_cron_success_ratio", cronLabel(stats), *stats.SuccessRate)
+		}
+	})
+	writeFamily("agenc_cron_run_duration_seconds", "gauge", "Duration percentiles of the cron's recent finished runs.", func(stats CronStats) {
+		for _, q := range cronDurationQuantiles {
+			if duration, ok := stats.DurationSeconds[cronQuantileLabel(q)]; ok {
+				sample("agenc_cron_run_duration_seconds", cronLabel(stats)+`,quantile="`+strconv.FormatFloat(q, 'g', -1, 64)+`"`, duration)
+			}
+		}
+	})
+	writeFamily("agenc_cron_consecutive_failures", "gauge", "Newest runs of the cron that failed in a row.", func(stats CronStats) {
+		sample("agenc_cron_consecutive_failures", cronLabel(stats), float64(stats.ConsecutiveFailures))
+	})
+	writeFamily("agenc_cron_last_success_timestamp_seconds", "gauge", "Unix time the cron's last successful run ended.", func(stats CronStats) {
+		if stats.LastSuccessAt != nil {
+			sample("agenc_cron_last_success_timestamp_seconds", cronLabel(stats), float64(stats.LastSuccessAt.Unix()))
+		}
+	})
+	return b.String()
+}
+
+// escapePrometheusLabel escapes a label value for the Prometheus text format.
+func escapePrometheusLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// findCronByNameOrID returns the name and config of the configured cron
+// identified by a cron name or cron ID.
+func (s *Server) findCronByNameOrID(nameOrID string) (string, config.CronConfig, bool) {
+	if cronCfg, ok := s.getConfig().Crons[nameOrID]; ok {
+		return nameOrID, cronCfg, true
+	}
+	return s.findCronByID(nameOrID)
+}
+
+// alertOnCronFailureStreak raises an alert when a failed run brings the
+// cron's failure streak to its alertOnConsecutiveFailures: it publishes
+// cron.failing, records a notification, and tells the cron's notify targets.
+// The alert fires once, when the streak reaches the threshold; a success
+// resets it. Best-effort: failures are logged.
+func (s *Server) alertOnCronFailureStreak(cronName string, cronCfg config.CronConfig, missionID string) {
+	if cronCfg.AlertOnConsecutiveFailures <= 0 {
+		return
+	}
+	stats, err := s.getCronStats(cronName, cronCfg)
+	if err != nil {
+		s.logger.Printf("Cron alert: failed to compute stats for cron '%s': %v", cronName, err)
+		return
+	}
+	if stats.ConsecutiveFailures != cronCfg.AlertOnConsecutiveFailures {
+		return
+	}
+
+	s.logger.Printf("Cron alert: cron '%s' has failed %d runs in a row", cronName, stats.ConsecutiveFailures)
+	s.publishEvent(EventCronFailing, missionID, map[string]string{
+		"cron_name":            cronName,
+		"consecutive_failures": strconv.Itoa(stats.ConsecutiveFailures),
+	})
+	if err := s.db.CreateNotification(buildCronFailingNotification(stats, missionID)); err != nil {
+		s.logger.Printf("Cron alert: failed to create notification for cron '%s': %v", cronName, err)
+	}
+	s.sendCronMessage(cronName, cronCfg, "failing alert", buildCronFailingMessage(stats))
+}
+
+// buildCronFailingNotification constructs the notification recording that a
+// cron reached its alertOnConsecutiveFailures. missionID is the latest
+// failed run's mission, or empty if it was deleted.
+func buildCronFailingNotification(stats CronStats, missionID string) *database.Notification {
+	n := &database.Notification{
+		ID:           uuid.New().String(),
+		Kind:         cronFailingNotificationKind,
+		Title:        sanitizeNotificationTitle(fmt.Sprintf("Cron failing: %s (%d runs in a row)", stats.CronName, stats.ConsecutiveFailures)),
+		BodyMarkdown: strings.Join(cronFailingSummaryLines(stats, "**"), "\n\n"),
+	}
+	if missionID != "" {
+		n.MissionID = &missionID
+	}
+	return n
+}
+
+// buildCronFailingMessage renders the alert sent to a failing cron's notify
+// targets.
+func buildCronFailingMessage(stats CronStats) notify.Message {
+	return notify.Message{
+		Subject: fmt.Sprintf("AgenC cron '%s' has failed %d runs in a row", stats.CronName, stats.ConsecutiveFailures),
+		Body:    strings.Join(cronFailingSummaryLines(stats, ""), "\n"),
+	}
+}
+
+// cronFailingSummaryLines describes a failing cron, wrapping each label in
+// emphasis (such as "**" for markdown).
+func cronFailingSummaryLines(stats CronStats, emphasis string) []string {
+	label := func(name string) string { return emphasis + name + ":" + emphasis + " " }
+	lines := []string{
+		label("Cron") + stats.CronName,
+		label("Failed runs in a row") + strconv.Itoa(stats.ConsecutiveFailures),
+	}
+	if stats.SuccessRate != nil {
+		lines = append(lines, label("Recent success rate")+fmt.Sprintf("%.0f%% of the last %d finished runs", *stats.SuccessRate*100, stats.Succeeded+stats.Failed))
+	}
+	lastSuccess := "none in the last " + strconv.Itoa(cronStatsWindow) + " runs"
+	if stats.LastSuccessAt != nil {
+		lastSuccess = stats.LastSuccessAt.Local().Format(time.RFC3339)
+	}
+	lines = append(lines, label("Last success")+lastSuccess)
+	return append(lines, "See the failed runs with `agenc cron history "+stats.CronName+"`.")
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// cronTestRun returns a finished run of the given status that took duration,
+// for computeCronStats.
+func cronTestRun(status string, startedAt time.Time, duration time.Duration) *database.CronRun {
+	endedAt := startedAt.Add(duration)
+	return &database.CronRun{Status: status, StartedAt: startedAt, EndedAt: &endedAt}
+}
+
+func TestComputeCronStats(t *testing.T) {
+	base := time.Date(2026, 10, 1, 3, 0, 0, 0, time.UTC)
+	// Newest first, as ListCronRuns returns them
+	runs := []*database.CronRun{
+		{Status: database.CronRunStatusRunning, StartedAt: base.Add(6 * time.Hour)},
+		cronTestRun(database.CronRunStatusFailed, base.Add(5*time.Hour), 10*time.Second),
+		{Status: database.CronRunStatusSkipped, StartedAt: base.Add(4 * time.Hour)},
+		cronTestRun(database.CronRunStatusFailed, base.Add(3*time.Hour), 20*time.Second),
+		cronTestRun(database.CronRunStatusSucceeded, base.Add(2*time.Hour), 30*time.Second),
+		cronTestRun(database.CronRunStatusFailed, base.Add(time.Hour), 40*time.Second),
+		cronTestRun(database.CronRunStatusSucceeded, base, 50*time.Second),
+	}
+
+	stats := computeCronStats("cron-1", "nightly", runs)
+	if stats.Succeeded != 2 || stats.Failed != 3 || stats.Skipped != 1 {
+		t.Errorf("expected 2 succeeded, 3 failed, 1 skipped, got %+v", stats)
+	}
+	if stats.ConsecutiveFailures != 2 {
+		t.Errorf("expected the streak to skip over the skipped run and stop at the success, got %d", stats.ConsecutiveFailures)
+	}
+	if stats.SuccessRate == nil || *stats.SuccessRate != 0.4 {
+		t.Errorf("expected success rate 0.4, got %v", stats.SuccessRate)
+	}
+	for label, want := range map[string]float64{"p50": 30, "p90": 50, "p99": 50} {
+		if got := stats.DurationSeconds[label]; got != want {
+			t.Errorf("%s: expected %vs, got %vs", label, want, got)
+		}
+	}
+	if stats.LastSuccessAt == nil || !stats.LastSuccessAt.Equal(base.Add(2*time.Hour+30*time.Second)) {
+		t.Errorf("unexpected last success: %v", stats.LastSuccessAt)
+	}
+
+	empty := computeCronStats("cron-2", "new", nil)
+	if empty.SuccessRate != nil || empty.DurationSeconds != nil || empty.ConsecutiveFailures != 0 {
+		t.Errorf("expected no rate or durations before any run, got %+v", empty)
+	}
+}
+
+func TestFormatCronMetrics(t *testing.T) {
+	rate := 0.75
+	metrics := formatCronMetrics([]CronStats{
+		{CronName: `odd"name`, Succeeded: 3, Failed: 1, SuccessRate: &rate, DurationSeconds: map[string]float64{"p50": 12.5, "p90": 30, "p99": 30}, ConsecutiveFailures: 1},
+		{CronName: "fresh"},
+	})
+
+	for _, want := range []string{
+		"# TYPE agenc_cron_success_ratio gauge\n",
+		`agenc_cron_runs{cron="odd\"name",status="failed"} 1` + "\n",
+		`agenc_cron_success_ratio{cron="odd\"name"} 0.75` + "\n",
+		`agenc_cron_run_duration_seconds{cron="odd\"name",quantile="0.5"} 12.5` + "\n",
+		`agenc_cron_run_duration_seconds{cron="odd\"name",quantile="0.99"} 30` + "\n",
+		`agenc_cron_consecutive_failures{cron="fresh"} 0` + "\n",
+	} {
+		if !strings.Contains(metrics, want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, metrics)
+		}
+	}
+	if strings.Contains(metrics, `agenc_cron_success_ratio{cron="fresh"}`) {
+		t.Errorf("expected no success ratio for a cron without finished runs, got:\n%s", metrics)
+	}
+}
+
+func TestAlertOnCronFailureStreak(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	cronCfg := config.CronConfig{ID: "cron-1", Schedule: "0 3 * * *", AlertOnConsecutiveFailures: 2}
+	srv.cachedConfig.Store(&config.AgencConfig{Crons: map[string]config.CronConfig{"nightly": cronCfg}})
+
+	startedAt := time.Now().UTC().Add(-time.Hour)
+	failRun := func() {
+		t.Helper()
+		startedAt = startedAt.Add(time.Minute)
+		endedAt := startedAt.Add(time.Second)
+		run := &database.CronRun{
+			ID:        uuid.New().String(),
+			CronID:    cronCfg.ID,
+			CronName:  "nightly",
+			Trigger:   database.CronRunTriggerSchedule,
+			Status:    database.CronRunStatusFailed,
+			StartedAt: startedAt,
+			EndedAt:   &endedAt,
+		}
+		if err := srv.db.CreateCronRun(run); err != nil {
+			t.Fatalf("CreateCronRun failed: %v", err)
+		}
+		srv.notifyCronRun(cronCfg.ID, "", database.CronRunStatusFailed, "boom")
+	}
+	alertCount := func() int {
+		t.Helper()
+		notifications, err := srv.db.ListNotifications(database.ListNotificationsParams{Kind: cronFailingNotificationKind})
+		if err != nil {
+			t.Fatalf("ListNotifications failed: %v", err)
+		}
+		return len(notifications)
+	}
+
+	failRun()
+	if n := alertCount(); n != 0 {
+		t.Fatalf("expected no alert below the threshold, got %d", n)
+	}
+	failRun()
+	if n := alertCount(); n != 1 {
+		t.Fatalf("expected an alert once the streak reached the threshold, got %d", n)
+	}
+	failRun()
+	if n := alertCount(); n != 1 {
+		t.Errorf("expected a single alert per streak, got %d", n)
+	}
+}
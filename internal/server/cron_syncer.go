@@ -3,10 +3,14 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mieubrisse/stacktrace"
 
@@ -31,6 +35,30 @@ type CronSyncer struct {
 	cronPlistPrefix string
 	manager         launchdManager
 	mu              sync.Mutex
+	stats           CronSyncStats // guarded by mu
+}
+
+// CronSyncStats summarizes the syncs CronSyncer has run since the server
+// started.
+type CronSyncStats struct {
+	Syncs        int       `json:"syncs"`
+	LastSyncAt   time.Time `json:"last_sync_at,omitempty"`
+	LastDuration string    `json:"last_duration,omitempty"`
+	// LastSynced is how many crons the last sync loaded into launchd
+	LastSynced int `json:"last_synced"`
+	// LastError is why the last sync failed outright, if it did
+	LastError string `json:"last_error,omitempty"`
+	// CronErrors maps the crons the last sync could not load to the reason
+	CronErrors map[string]string `json:"cron_errors,omitempty"`
+}
+
+// Stats returns a snapshot of the syncer's stats.
+func (s *CronSyncer) Stats() CronSyncStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.CronErrors = maps.Clone(s.stats.CronErrors)
+	return stats
 }
 
 // NewCronSyncer creates a new CronSyncer.
@@ -65,6 +93,22 @@ func (s *CronSyncer) SyncCronsToLaunchd(crons map[string]config.CronConfig, logg
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	startedAt := time.Now()
+	synced := 0
+	cronErrors := map[string]string{}
+	var syncErr error
+	defer func() {
+		s.stats.Syncs++
+		s.stats.LastSyncAt = startedAt
+		s.stats.LastDuration = time.Since(startedAt).Round(time.Millisecond).String()
+		s.stats.LastSynced = synced
+		s.stats.LastError = ""
+		if syncErr != nil {
+			s.stats.LastError = fmt.Sprintf("%#s", syncErr)
+		}
+		s.stats.CronErrors = cronErrors
+	}()
+
 	// Remove plists for crons that no longer exist in config (also cleans up legacy-format plists)
 	if err := s.removeUnmatchedPlists(crons, logger); err != nil {
 		logger.Printf("Cron syncer: warning - failed to remove unmatched plists: %v", err)
@@ -79,30 +123,37 @@ func (s *CronSyncer) SyncCronsToLaunchd(crons map[string]config.CronConfig, logg
 	// Get the path to the agenc binary
 	execPath, err := os.Executable()
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to get executable path")
+		syncErr = stacktrace.Propagate(err, "failed to get executable path")
+		return syncErr
 	}
 
 	plistDirpath, err := launchd.PlistDirpath()
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to get plist directory")
+		syncErr = stacktrace.Propagate(err, "failed to get plist directory")
+		return syncErr
 	}
 
 	// Ensure cron log directory exists
 	cronLogDir := config.GetCronLogDirpath(s.agencDirpath)
 	if err := os.MkdirAll(cronLogDir, 0755); err != nil {
-		return stacktrace.Propagate(err, "failed to create cron log directory")
+		syncErr = stacktrace.Propagate(err, "failed to create cron log directory")
+		return syncErr
 	}
 
 	// Process each cron job
 	for name, cronCfg := range crons {
 		if cronCfg.ID == "" {
 			logger.Printf("Cron syncer: skipping '%s' - no ID configured (add an 'id' field to config.yml)", name)
+			cronErrors[name] = "no id configured"
 			continue
 		}
 
 		if err := s.syncCronJob(name, cronCfg, plistDirpath, execPath, logger); err != nil {
 			logger.Printf("Cron syncer: failed to sync '%s': %v", name, err)
+			cronErrors[name] = fmt.Sprintf("%#s", err)
+			continue
 		}
+		synced++
 	}
 
 	logger.Printf("Cron syncer: synced %d cron jobs to launchd", len(crons))
@@ -192,31 +243,29 @@ func (s *CronSyncer) syncCronJob(name string, cronCfg config.CronConfig, plistDi
 }
 
 // buildCronPlistXML constructs the launchd plist for a cron job and renders it
-// to XML. Returns (nil, nil) if the schedule is unsupported (caller should skip).
+// to XML. One-shot crons fire at their runAt minute. Returns (nil, nil) if the
+// schedule or runAt is unsupported (caller should skip).
 func (s *CronSyncer) buildCronPlistXML(name string, cronCfg config.CronConfig, label string, execPath string) ([]byte, error) {
-	calInterval, err := launchd.ParseCronExpression(cronCfg.Schedule)
-	if err != nil {
-		// Unsupported schedule — not an error, just skip
-		return nil, nil
+	var calInterval *launchd.CalendarInterval
+	if cronCfg.IsOneShot() {
+		runAt, err := config.ParseCronRunAt(cronCfg.RunAt)
+		if err != nil {
+			return nil, nil
+		}
+		calInterval = launchd.CalendarIntervalForTime(runAt)
+	} else {
+		var err error
+		if calInterval, err = launchd.ParseCronExpression(cronCfg.Schedule); err != nil {
+			// Unsupported schedule — not an error, just skip
+			return nil, nil
+		}
 	}
 
-	sourceMetadata, err := json.Marshal(map[string]string{"cron_name": name})
+	missionArgs, err := cronMissionArgs(name, cronCfg)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "failed to marshal source metadata for '%s'", name)
-	}
-
-	programArgs := []string{
-		execPath, "mission", "new", "--headless",
-		"--source", "cron",
-		"--source-id", cronCfg.ID,
-		"--source-metadata", string(sourceMetadata),
-		"--prompt", cronCfg.Prompt,
-	}
-	if cronCfg.Repo != "" {
-		programArgs = append(programArgs, cronCfg.Repo)
-	} else {
-		programArgs = append(programArgs, "--blank")
+		return nil, err
 	}
+	programArgs := append([]string{execPath}, missionArgs...)
 
 	// launchd starts processes with a minimal environment (PATH only — no HOME, no USER).
 	// The agenc binary needs HOME to locate ~/.agenc/ (see config.GetAgencDirpath), so
@@ -248,6 +297,35 @@ func (s *CronSyncer) buildCronPlistXML(name string, cronCfg config.CronConfig, l
 	return xmlData, nil
 }
 
+// cronMissionArgs returns the agenc arguments that start a scheduled run of
+// a cron job as a headless mission.
+func cronMissionArgs(name string, cronCfg config.CronConfig) ([]string, error) {
+	sourceMetadata, err := json.Marshal(map[string]string{"cron_name": name})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to marshal source metadata for '%s'", name)
+	}
+
+	args := []string{
+		"mission", "new", "--headless",
+		"--source", "cron",
+		"--source-id", cronCfg.ID,
+		"--source-metadata", string(sourceMetadata),
+		"--prompt", cronCfg.Prompt,
+	}
+	if cronCfg.MaxPrompts > 0 {
+		args = append(args, "--max-prompts", strconv.Itoa(cronCfg.MaxPrompts))
+	}
+	if cronCfg.BudgetUSD > 0 {
+		args = append(args, "--budget-usd", strconv.FormatFloat(cronCfg.BudgetUSD, 'f', -1, 64))
+	}
+	if cronCfg.Repo != "" {
+		args = append(args, cronCfg.Repo)
+	} else {
+		args = append(args, "--blank")
+	}
+	return args, nil
+}
+
 // removeUnmatchedPlists removes plist files that don't correspond to any cron in the config.
 // Matches by UUID extracted from the filename (agenc-cron.{UUID}.plist).
 func (s *CronSyncer) removeUnmatchedPlists(crons map[string]config.CronConfig, logger logger) error {
@@ -39,6 +39,35 @@ func TestSyncCronsToLaunchd_EmptyCrons(t *testing.T) {
 	}
 }
 
+func TestSyncCronsToLaunchd_RecordsStats(t *testing.T) {
+	homeDirpath := t.TempDir()
+	t.Setenv("HOME", homeDirpath)
+	if err := os.MkdirAll(filepath.Join(homeDirpath, "Library", "LaunchAgents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	mock := newMockManager()
+	syncer := newCronSyncerWithManager(t.TempDir(), mock)
+
+	crons := map[string]config.CronConfig{
+		"nightly": {ID: "nightly-id", Schedule: "0 6 * * *", Prompt: "Summarize"},
+		"no-id":   {Schedule: "0 7 * * *", Prompt: "Summarize"},
+	}
+	if err := syncer.SyncCronsToLaunchd(crons, &syncerTestLogger{}); err != nil {
+		t.Fatalf("SyncCronsToLaunchd failed: %v", err)
+	}
+
+	stats := syncer.Stats()
+	if stats.Syncs != 1 || stats.LastSyncAt.IsZero() || stats.LastError != "" {
+		t.Errorf("expected one successful sync recorded, got %+v", stats)
+	}
+	if stats.LastSynced != 1 {
+		t.Errorf("expected 1 cron synced, got %d", stats.LastSynced)
+	}
+	if _, ok := stats.CronErrors["no-id"]; !ok || len(stats.CronErrors) != 1 {
+		t.Errorf("expected only 'no-id' to fail, got %v", stats.CronErrors)
+	}
+}
+
 // mockLaunchdManager records calls for verification.
 type mockLaunchdManager struct {
 	loadedLabels    map[string]bool
@@ -505,3 +534,59 @@ func TestBuildCronPlistXML_NamespacedIncludesAgencDirpath(t *testing.T) {
 		t.Errorf("expected AGENC_DIRPATH value %q in plist XML, got:\n%s", customDirpath, xmlStr)
 	}
 }
+
+func TestBuildCronPlistXML_OneShotFiresAtRunAt(t *testing.T) {
+	syncer := newCronSyncerWithManager(t.TempDir(), newMockManager())
+	cronCfg := config.CronConfig{
+		ID:     "test-uuid-oneshot",
+		RunAt:  "2026-11-02 09:45",
+		Prompt: "test",
+	}
+
+	xmlData, err := syncer.buildCronPlistXML("test-job", cronCfg, "agenc-cron.test-uuid-oneshot", "/usr/bin/agenc")
+	if err != nil || xmlData == nil {
+		t.Fatalf("buildCronPlistXML = %v, %v; want XML", xmlData, err)
+	}
+	xmlStr := strings.Join(strings.Fields(string(xmlData)), "")
+	for _, field := range []string{
+		"<key>Minute</key><integer>45</integer>",
+		"<key>Hour</key><integer>9</integer>",
+		"<key>Day</key><integer>2</integer>",
+		"<key>Month</key><integer>11</integer>",
+	} {
+		if !strings.Contains(xmlStr, field) {
+			t.Errorf("expected %s in the calendar interval, got:\n%s", field, xmlData)
+		}
+	}
+}
+
+func TestCronMissionArgs_PassesLimits(t *testing.T) {
+	cronCfg := config.CronConfig{
+		ID:         "test-uuid",
+		Schedule:   "0 9 * * *",
+		Prompt:     "test",
+		MaxPrompts: 3,
+		BudgetUSD:  2.5,
+	}
+
+	args, err := cronMissionArgs("test-job", cronCfg)
+	if err != nil {
+		t.Fatalf("cronMissionArgs failed: %v", err)
+	}
+	joined := strings.Join(args, " ")
+	for _, want := range []string{"--max-prompts 3", "--budget-usd 2.5", "--blank"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected %q in args, got %v", want, args)
+		}
+	}
+
+	cronCfg.MaxPrompts, cronCfg.BudgetUSD = 0, 0
+	args, err = cronMissionArgs("test-job", cronCfg)
+	if err != nil {
+		t.Fatalf("cronMissionArgs failed: %v", err)
+	}
+	joined = strings.Join(args, " ")
+	if strings.Contains(joined, "--max-prompts") || strings.Contains(joined, "--budget-usd") {
+		t.Errorf("expected no limit flags for an unlimited cron, got %v", args)
+	}
+}
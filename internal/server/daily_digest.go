@@ -0,0 +1,208 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/notify"
+	"github.com/odyssey/agenc/internal/report"
+)
+
+// dailyDigestCheckInterval is how often the server checks whether the daily
+// digest is due.
+const dailyDigestCheckInterval = time.Minute
+
+// dailyDigestNotifyTimeout bounds delivery of a digest to all of its notify
+// targets.
+const dailyDigestNotifyTimeout = 30 * time.Second
+
+// DailyDigestResponse is the response for GET /reports/digest: the digest
+// and its text, rendered with the configured template.
+type DailyDigestResponse struct {
+	Digest *report.Digest `json:"digest"`
+	Text   string         `json:"text"`
+}
+
+// runDailyDigestLoop writes the previous day's digest once the configured
+// dailyDigest.time has passed, and sends it to the digest's notify targets.
+// A digest that comes due during quiet hours waits until they end. The
+// written digest file marks the day as done, so restarts neither skip nor
+// repeat it.
+func (s *Server) runDailyDigestLoop(ctx context.Context) {
+	lastAttempted := ""
+
+	ticker := time.NewTicker(dailyDigestCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			cfg := s.getConfig()
+			if cfg == nil || cfg.DailyDigest == nil || cfg.QuietHours.IsActive(now) {
+				continue
+			}
+			day, ok := dailyDigestDue(cfg.DailyDigest.GetTime(), now)
+			date := day.Format(report.DateLayout)
+			if !ok || date == lastAttempted || report.Exists(config.GetDigestsDirpath(s.agencDirpath), date) {
+				continue
+			}
+			lastAttempted = date
+			s.writeDailyDigest(cfg, day)
+		}
+	}
+}
+
+// dailyDigestDue returns the day whose digest is due at now: the previous
+// day, once now has reached digestTime (HH:MM, local). ok is false earlier
+// in the day.
+func dailyDigestDue(digestTime string, now time.Time) (time.Time, bool) {
+	hourStr, minuteStr, _ := strings.Cut(digestTime, ":")
+	hour, _ := strconv.Atoi(hourStr)
+	minute, _ := strconv.Atoi(minuteStr)
+	dueAt := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if now.Before(dueAt) {
+		return time.Time{}, false
+	}
+	return now.AddDate(0, 0, -1), true
+}
+
+// writeDailyDigest builds, stores, and sends the digest for day. A custom
+// template that fails to render is logged and replaced by the built-in one,
+// so the digest still goes out.
+func (s *Server) writeDailyDigest(cfg *config.AgencConfig, day time.Time) {
+	digest, err := s.buildDailyDigest(day)
+	if err != nil {
+		s.logger.Printf("Daily digest: failed to build digest for %s: %v", day.Format(report.DateLayout), err)
+		return
+	}
+	text, err := s.renderDailyDigest(cfg, digest)
+	if err != nil {
+		s.logger.Printf("Daily digest: %v; using the built-in template", err)
+		text, err = s.renderDailyDigest(nil, digest)
+		if err != nil {
+			s.logger.Printf("Daily digest: %v", err)
+			return
+		}
+	}
+
+	if err := report.Write(config.GetDigestsDirpath(s.agencDirpath), digest, text); err != nil {
+		s.logger.Printf("Daily digest: %v", err)
+		return
+	}
+	s.logger.Printf("Daily digest: wrote digest for %s", digest.Date)
+	s.sendDailyDigest(cfg, digest, text)
+}
+
+// buildDailyDigest gathers the activity of the local day containing day and
+// summarizes it.
+func (s *Server) buildDailyDigest(day time.Time) (*report.Digest, error) {
+	from, to := report.DayBounds(day)
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	prompts, err := s.db.ListMissionPrompts(database.ListMissionPromptsParams{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.db.ListMissionEvents(database.ListMissionEventsParams{
+		Types: []string{EventMissionArchived, EventMissionCrashed},
+		From:  from,
+		To:    to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cronRuns, err := s.db.ListCronRuns(database.ListCronRunsParams{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+
+	activity := report.Activity{Missions: missions, Prompts: prompts, CronRuns: cronRuns}
+	for _, e := range events {
+		if e.Type == EventMissionArchived {
+			activity.Archives = append(activity.Archives, e)
+		} else {
+			activity.Crashes = append(activity.Crashes, e)
+		}
+	}
+	return report.Build(from, to, activity, time.Now()), nil
+}
+
+// renderDailyDigest renders digest with the template configured in
+// dailyDigest.template, or the built-in one when cfg sets none.
+func (s *Server) renderDailyDigest(cfg *config.AgencConfig, digest *report.Digest) (string, error) {
+	templateFilepath := ""
+	if cfg != nil && cfg.DailyDigest != nil {
+		templateFilepath = cfg.DailyDigest.GetTemplateFilepath(s.agencDirpath)
+	}
+	tmpl, err := report.LoadTemplate(templateFilepath)
+	if err != nil {
+		return "", err
+	}
+	return report.Render(tmpl, digest)
+}
+
+// sendDailyDigest delivers the rendered digest to dailyDigest.notify, with
+// the digest itself as the message data for webhooks. Best-effort: delivery
+// failures are logged.
+func (s *Server) sendDailyDigest(cfg *config.AgencConfig, digest *report.Digest, text string) {
+	var targets []notify.Target
+	for _, rawTarget := range cfg.DailyDigest.Notify {
+		target, err := notify.ParseTarget(rawTarget)
+		if err != nil {
+			s.logger.Printf("Daily digest: skipping notify target: %v", err)
+			continue
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	dispatcher, err := s.buildNotifyDispatcher(cfg)
+	if err != nil {
+		s.logger.Printf("Daily digest: failed to set up notifiers: %v", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dailyDigestNotifyTimeout)
+	defer cancel()
+	msg := notify.Message{Subject: "AgenC daily digest for " + digest.Date, Body: text, Data: digest}
+	if err := dispatcher.Send(ctx, targets, msg); err != nil {
+		s.logger.Printf("Daily digest: failed to deliver digest for %s: %v", digest.Date, err)
+	}
+}
+
+// handleGetDailyDigest handles GET /reports/digest?date=YYYY-MM-DD. It builds
+// the digest for the given local day (default: yesterday) on the fly, without
+// storing or sending it, so templates can be previewed.
+func (s *Server) handleGetDailyDigest(w http.ResponseWriter, r *http.Request) error {
+	day := time.Now().AddDate(0, 0, -1)
+	if date := r.URL.Query().Get("date"); date != "" {
+		parsed, err := time.ParseInLocation(report.DateLayout, date, time.Local)
+		if err != nil {
+			return newHTTPError(http.StatusBadRequest, "invalid 'date' parameter: expected YYYY-MM-DD")
+		}
+		day = parsed
+	}
+
+	digest, err := s.buildDailyDigest(day)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	text, err := s.renderDailyDigest(s.getConfig(), digest)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	writeJSON(w, http.StatusOK, DailyDigestResponse{Digest: digest, Text: text})
+	return nil
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/report"
+)
+
+func TestDailyDigestDue(t *testing.T) {
+	loc := time.FixedZone("PDT", -7*3600)
+	if _, ok := dailyDigestDue("07:00", time.Date(2026, 10, 17, 6, 59, 0, 0, loc)); ok {
+		t.Error("expected no digest due before the configured time")
+	}
+	day, ok := dailyDigestDue("07:00", time.Date(2026, 10, 17, 7, 0, 0, 0, loc))
+	if !ok || day.Format(report.DateLayout) != "2026-10-16" {
+		t.Errorf("expected yesterday's digest to be due, got %v, %v", day, ok)
+	}
+}
+
+func TestWriteDailyDigest(t *testing.T) {
+	tmpDir := t.TempDir()
+	db, err := database.Open(filepath.Join(tmpDir, "database.sqlite"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	srv := &Server{agencDirpath: tmpDir, logger: log.New(os.Stderr, "", 0), db: db, events: newEventBus()}
+
+	var received struct {
+		Subject string        `json:"subject"`
+		Body    string        `json:"body"`
+		Data    report.Digest `json:"data"`
+	}
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer hook.Close()
+
+	mission, err := db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if err := db.CreateMissionPrompt(&database.MissionPrompt{MissionID: mission.ID, Prompt: "fix the build"}); err != nil {
+		t.Fatalf("failed to record prompt: %v", err)
+	}
+	srv.publishEvent(EventMissionCrashed, mission.ID, map[string]string{"reason": "exit code 1"})
+
+	templateFilepath := filepath.Join(tmpDir, "digest.tmpl")
+	if err := os.WriteFile(templateFilepath, []byte("{{.Missions.Created}} created, {{.Prompts}} prompts, {{len .Failures}} failures"), 0600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	cfg := &config.AgencConfig{DailyDigest: &config.DailyDigestConfig{
+		Template: templateFilepath,
+		Notify:   []string{"webhook:" + hook.URL},
+	}}
+
+	srv.writeDailyDigest(cfg, time.Now())
+
+	date := time.Now().Format(report.DateLayout)
+	digestsDirpath := config.GetDigestsDirpath(tmpDir)
+	if !report.Exists(digestsDirpath, date) {
+		t.Fatal("expected the digest to be written")
+	}
+	_, textFilepath := report.Filepaths(digestsDirpath, date)
+	text, err := os.ReadFile(textFilepath)
+	if err != nil {
+		t.Fatalf("failed to read rendered digest: %v", err)
+	}
+	if string(text) != "1 created, 1 prompts, 1 failures\n" {
+		t.Errorf("expected the custom template to be used, got %q", text)
+	}
+
+	if !strings.Contains(received.Subject, date) || received.Body != string(text) {
+		t.Errorf("unexpected webhook message %q / %q", received.Subject, received.Body)
+	}
+	if received.Data.Missions.Created != 1 || len(received.Data.Failures) != 1 || received.Data.Failures[0].Kind != report.FailureCrash {
+		t.Errorf("expected the digest as the webhook data, got %+v", received.Data)
+	}
+}
@@ -0,0 +1,337 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// Event types published on the server's event bus.
+const (
+	EventMissionCreated      = "mission.created"
+	EventMissionIdle         = "mission.idle"
+	EventMissionCrashed      = "mission.crashed"
+	EventMissionUnresponsive = "mission.unresponsive"
+	EventMissionRestarted    = "mission.restarted"
+	EventMissionExited       = "mission.exited"
+	EventMissionArchived     = "mission.archived"
+	EventPromptSubmitted     = "mission.prompt"
+	EventToolRun             = "mission.tool_run"
+	EventRefUpdated          = "mission.ref_updated"
+	EventCronFired           = "cron.fired"
+	EventCronFailing         = "cron.failing"
+	EventCredentialRefreshed = "credential.refreshed"
+	EventConfigUpdated       = "config.updated"
+)
+
+const (
+	// eventHistorySize is how many recent events GET /events returns without
+	// follow, and the backlog a new follower starts from.
+	eventHistorySize = 200
+
+	// eventSubscriberBufferSize bounds each subscriber's queue. A subscriber
+	// that falls this far behind misses events rather than blocking publishers.
+	eventSubscriberBufferSize = 64
+
+	// eventKeepaliveInterval is how often an idle event stream sends an SSE
+	// comment, so dead clients are noticed and proxies keep the stream open.
+	eventKeepaliveInterval = 15 * time.Second
+
+	// defaultMissionEventsLimit caps GET /missions/{id}/events when no limit
+	// is given.
+	defaultMissionEventsLimit = 200
+)
+
+// Event is one change published on the event bus.
+type Event struct {
+	Type      string            `json:"type"`
+	Time      time.Time         `json:"time"`
+	MissionID string            `json:"mission_id,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+// matches reports whether e passes the type and mission filters of a
+// subscription. Empty filters match everything.
+func (e Event) matches(types []string, missionID string) bool {
+	if len(types) > 0 && !slices.Contains(types, e.Type) {
+		return false
+	}
+	return missionID == "" || e.MissionID == missionID
+}
+
+// eventBus fans published events out to subscribers and keeps a short history
+// of recent events. Publishing never blocks: a subscriber whose buffer is full
+// drops the event.
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+	history     []Event
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: make(map[chan Event]struct{})}
+}
+
+// publish records e and delivers it to every subscriber.
+func (b *eventBus) publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.history = append(b.history, e)
+	if len(b.history) > eventHistorySize {
+		b.history = slices.Clone(b.history[len(b.history)-eventHistorySize:])
+	}
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// subscribe returns a channel receiving every event published from now on,
+// along with the history at the moment of subscribing. Call unsubscribe when
+// done.
+func (b *eventBus) subscribe() (chan Event, []Event) {
+	ch := make(chan Event, eventSubscriberBufferSize)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[ch] = struct{}{}
+	return ch, slices.Clone(b.history)
+}
+
+func (b *eventBus) unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscribers, ch)
+}
+
+// recent returns a copy of the event history, oldest first.
+func (b *eventBus) recent() []Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.history)
+}
+
+// publishEvent publishes an event of the given type on the server's bus.
+// Events about a mission are also recorded in its timeline, which outlives
+// the bus's short history.
+func (s *Server) publishEvent(eventType string, missionID string, data map[string]string) {
+	e := Event{Type: eventType, Time: time.Now().UTC(), MissionID: missionID, Data: data}
+	s.events.publish(e)
+
+	if missionID == "" || s.db == nil {
+		return
+	}
+	record := &database.MissionEvent{MissionID: missionID, Type: eventType, Data: data, CreatedAt: e.Time}
+	if err := s.db.CreateMissionEvent(record); err != nil {
+		s.logger.Printf("Warning: failed to record %s event for mission %s: %v", eventType, database.ShortID(missionID), err)
+	}
+}
+
+// publishMissionCreated announces a new mission on the bus.
+func (s *Server) publishMissionCreated(m *database.Mission) {
+	data := map[string]string{"short_id": m.ShortID}
+	if m.GitRepo != "" {
+		data["repo"] = m.GitRepo
+	}
+	if m.Source != nil && *m.Source != "" {
+		data["source"] = *m.Source
+	}
+	s.publishEvent(EventMissionCreated, m.ID, data)
+}
+
+// PublishEventRequest is the JSON body for POST /events, used by processes
+// outside the server (wrappers) to publish onto the bus.
+type PublishEventRequest struct {
+	Type      string            `json:"type"`
+	MissionID string            `json:"mission_id,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+// handlePublishEvent handles POST /events.
+func (s *Server) handlePublishEvent(w http.ResponseWriter, r *http.Request) error {
+	var req PublishEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	req.Type = strings.TrimSpace(req.Type)
+	if req.Type == "" {
+		return newHTTPError(http.StatusBadRequest, "type is required")
+	}
+	if req.MissionID != "" {
+		resolvedID, err := s.db.ResolveMissionID(req.MissionID)
+		if err != nil {
+			return newHTTPError(http.StatusNotFound, "mission not found: "+req.MissionID)
+		}
+		req.MissionID = resolvedID
+	}
+
+	s.publishEvent(req.Type, req.MissionID, req.Data)
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// handleListEvents handles GET /events.
+//
+// Query params:
+//   - type: comma-separated event types to include (default: all)
+//   - mission: only events for this mission ID (full or short)
+//
+// In multi-user mode, events about missions the requesting user may not
+// access are left out.
+//   - follow: "true" to stream events as Server-Sent Events, starting with the
+//     recent history. Each event is one "data:" line of JSON. The stream ends
+//     when the client disconnects.
+//
+// Without follow, returns the recent history as a JSON array.
+func (s *Server) handleListEvents(w http.ResponseWriter, r *http.Request) error {
+	var types []string
+	if typesParam := r.URL.Query().Get("type"); typesParam != "" {
+		for _, t := range strings.Split(typesParam, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types = append(types, t)
+			}
+		}
+	}
+
+	var missionID string
+	if missionParam := r.URL.Query().Get("mission"); missionParam != "" {
+		resolvedID, err := s.db.ResolveMissionID(missionParam)
+		if err != nil {
+			return newHTTPError(http.StatusNotFound, "mission not found: "+missionParam)
+		}
+		missionID = resolvedID
+		if missionRecord, err := s.db.GetMission(missionID); err == nil && missionRecord != nil {
+			if err := s.checkMissionAccess(r, missionRecord); err != nil {
+				return err
+			}
+		}
+	}
+	visible := s.missionVisibility(r)
+
+	if r.URL.Query().Get("follow") != "true" {
+		events := []Event{}
+		for _, e := range s.events.recent() {
+			if e.matches(types, missionID) && visible(e.MissionID) {
+				events = append(events, e)
+			}
+		}
+		writeJSON(w, http.StatusOK, events)
+		return nil
+	}
+
+	return s.streamEvents(w, r, types, missionID, visible)
+}
+
+// streamEvents streams matching events the requesting user may see as
+// Server-Sent Events until the client disconnects.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, types []string, missionID string, visible func(string) bool) error {
+	rc := http.NewResponseController(w)
+
+	ch, history := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for _, e := range history {
+		if e.matches(types, missionID) && visible(e.MissionID) {
+			writeSSEEvent(w, e)
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return nil
+	}
+
+	keepalive := time.NewTicker(eventKeepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case e := <-ch:
+			if !e.matches(types, missionID) || !visible(e.MissionID) {
+				continue
+			}
+			writeSSEEvent(w, e)
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
+
+// writeSSEEvent writes e as an SSE event named after its type, with the JSON
+// encoding as its data. JSON never contains raw newlines, so one data line
+// always suffices.
+func writeSSEEvent(w io.Writer, e Event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+}
+
+// handleListMissionEvents handles GET /missions/{id}/events, returning the
+// mission's recorded timeline oldest first.
+//
+// Query params:
+//   - since: only events at or after this RFC3339 time
+//   - limit: keep only the most recent N events (default 200; 0 for all)
+func (s *Server) handleListMissionEvents(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	var since time.Time
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		if since, err = time.Parse(time.RFC3339, sinceStr); err != nil {
+			return newHTTPError(http.StatusBadRequest, "invalid 'since' parameter: expected RFC3339 format")
+		}
+	}
+
+	limit := defaultMissionEventsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 0 {
+			return newHTTPErrorf(http.StatusBadRequest, "invalid limit %q: must be a non-negative integer", limitStr)
+		}
+		limit = parsed
+	}
+
+	records, err := s.db.ListMissionEvents(database.ListMissionEventsParams{MissionID: resolvedID, From: since, Limit: limit})
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	events := make([]Event, 0, len(records))
+	for _, record := range records {
+		events = append(events, Event{
+			Type:      record.Type,
+			Time:      record.CreatedAt,
+			MissionID: record.MissionID,
+			Data:      record.Data,
+		})
+	}
+	writeJSON(w, http.StatusOK, events)
+	return nil
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventBus_HistoryIsBounded(t *testing.T) {
+	bus := newEventBus()
+	for i := 0; i < eventHistorySize+10; i++ {
+		bus.publish(Event{Type: EventMissionIdle})
+	}
+	if got := len(bus.recent()); got != eventHistorySize {
+		t.Errorf("expected history of %d events, got %d", eventHistorySize, got)
+	}
+}
+
+func TestEventBus_FullSubscriberDoesNotBlockPublish(t *testing.T) {
+	bus := newEventBus()
+	ch, _ := bus.subscribe()
+	defer bus.unsubscribe(ch)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < eventSubscriberBufferSize*2; i++ {
+			bus.publish(Event{Type: EventMissionIdle})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publish blocked on a full subscriber")
+	}
+	if len(ch) != eventSubscriberBufferSize {
+		t.Errorf("expected a full buffer of %d events, got %d", eventSubscriberBufferSize, len(ch))
+	}
+}
+
+func TestHandleListEvents_FiltersByTypeAndMission(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	mission, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	srv.publishMissionCreated(mission)
+	srv.publishEvent(EventMissionIdle, mission.ID, nil)
+	srv.publishEvent(EventMissionIdle, "other-mission", nil)
+
+	req := httptest.NewRequest("GET", "/events?type=mission.idle&mission="+mission.ShortID, nil)
+	w := httptest.NewRecorder()
+	if err := srv.handleListEvents(w, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var events []Event
+	if err := json.Unmarshal(w.Body.Bytes(), &events); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != EventMissionIdle || events[0].MissionID != mission.ID {
+		t.Errorf("expected one mission.idle event for %s, got %+v", mission.ShortID, events)
+	}
+}
+
+func TestHandleListEvents_FollowStreamsPublishedEvents(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.publishEvent(EventCronFired, "", map[string]string{"cron": "nightly"})
+
+	ts := httptest.NewServer(appHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), srv.handleListEvents))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"?follow=true", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", ct)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	var got []Event
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			t.Fatalf("failed to decode event %q: %v", data, err)
+		}
+		got = append(got, event)
+		if len(got) == 1 {
+			srv.publishEvent(EventCredentialRefreshed, "", nil)
+		}
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	if len(got) != 2 || got[0].Type != EventCronFired || got[0].Data["cron"] != "nightly" || got[1].Type != EventCredentialRefreshed {
+		t.Errorf("expected the cron.fired backlog then credential.refreshed, got %+v", got)
+	}
+}
+
+func TestHandleListMissionEvents_ReturnsRecordedTimeline(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	mission, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	srv.publishEvent(EventPromptSubmitted, mission.ID, nil)
+	srv.publishEvent(EventToolRun, mission.ID, map[string]string{"tool": "Bash"})
+	srv.publishEvent(EventCronFired, "", map[string]string{"cron": "nightly"})
+
+	// Timelines outlive the bus's in-memory history
+	srv.events = newEventBus()
+
+	req := httptest.NewRequest("GET", "/missions/"+mission.ShortID+"/events?limit=1", nil)
+	req.SetPathValue("id", mission.ShortID)
+	w := httptest.NewRecorder()
+	if err := srv.handleListMissionEvents(w, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var events []Event
+	if err := json.Unmarshal(w.Body.Bytes(), &events); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != EventToolRun || events[0].Data["tool"] != "Bash" || events[0].MissionID != mission.ID {
+		t.Errorf("expected only the most recent tool run, got %+v", events)
+	}
+}
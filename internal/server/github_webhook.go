@@ -0,0 +1,135 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/secrets"
+)
+
+// githubWebhookPath is where GitHub delivers webhook events. Requests to it
+// authenticate with the webhook signature rather than an API token.
+const githubWebhookPath = "/webhooks/github"
+
+// maxGitHubWebhookPayloadBytes matches the largest payload GitHub delivers.
+const maxGitHubWebhookPayloadBytes = 25 << 20 // 25 MiB
+
+// githubPushEvent holds the fields of a GitHub push event payload that the
+// receiver acts on.
+type githubPushEvent struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		FullName      string `json:"full_name"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+}
+
+// handleGitHubWebhook handles POST /webhooks/github. Verifies the delivery's
+// X-Hub-Signature-256 against the configured githubWebhook secret, then
+// enqueues a force-update of the repo library clone for pushes to a repo's
+// default branch. Pushes to other branches and repos not in the library are
+// acknowledged and ignored, so an org-wide webhook doesn't report failures.
+func (s *Server) handleGitHubWebhook(w http.ResponseWriter, r *http.Request) error {
+	webhookCfg := s.getConfig().GitHubWebhook
+	if webhookCfg == nil {
+		return newHTTPError(http.StatusNotFound, "GitHub webhook receiver is not configured")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxGitHubWebhookPayloadBytes+1))
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, "failed to read request body: "+err.Error())
+	}
+	if len(body) > maxGitHubWebhookPayloadBytes {
+		return newHTTPError(http.StatusRequestEntityTooLarge, "payload too large")
+	}
+
+	secret, err := s.resolveGitHubWebhookSecret(webhookCfg)
+	if err != nil {
+		s.logger.Printf("GitHub webhook: failed to resolve secret: %v", err)
+		return newHTTPError(http.StatusInternalServerError, "failed to resolve webhook secret")
+	}
+	if !validGitHubSignature(secret, body, r.Header.Get("X-Hub-Signature-256")) {
+		return newHTTPError(http.StatusUnauthorized, "invalid webhook signature")
+	}
+
+	switch event := r.Header.Get("X-GitHub-Event"); event {
+	case "ping":
+		writeJSON(w, http.StatusOK, map[string]string{"status": "pong"})
+		return nil
+	case "push":
+		return s.handleGitHubPushEvent(w, body)
+	default:
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "ignored"})
+		return nil
+	}
+}
+
+// handleGitHubPushEvent enqueues a force-update for the pushed repo when the
+// push landed on its default branch and the repo is in the library.
+func (s *Server) handleGitHubPushEvent(w http.ResponseWriter, body []byte) error {
+	var event githubPushEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid push event payload: "+err.Error())
+	}
+	if event.Repository.FullName == "" {
+		return newHTTPError(http.StatusBadRequest, "push event is missing repository.full_name")
+	}
+
+	repoName := "github.com/" + event.Repository.FullName
+	if event.Ref != "refs/heads/"+event.Repository.DefaultBranch {
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "ignored"})
+		return nil
+	}
+	if _, err := os.Stat(config.GetRepoDirpath(s.agencDirpath, repoName)); err != nil {
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "ignored"})
+		return nil
+	}
+
+	select {
+	case s.repoUpdateCh <- repoUpdateRequest{repoName: repoName}:
+		s.logger.Printf("GitHub webhook: enqueued update for '%s'", repoName)
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "accepted"})
+	default:
+		s.logger.Printf("GitHub webhook: channel full, could not enqueue '%s'", repoName)
+		return newHTTPError(http.StatusServiceUnavailable, "update queue full")
+	}
+	return nil
+}
+
+// resolveGitHubWebhookSecret expands any secret://NAME reference in the
+// configured webhook secret. Resolved per delivery so rotating the secret
+// takes effect without a server restart.
+func (s *Server) resolveGitHubWebhookSecret(webhookCfg *config.GitHubWebhookConfig) (string, error) {
+	if !secrets.HasRefs(webhookCfg.Secret) {
+		return webhookCfg.Secret, nil
+	}
+	store, err := secrets.Open(s.agencDirpath)
+	if err != nil {
+		return "", err
+	}
+	return store.Expand(webhookCfg.Secret)
+}
+
+// validGitHubSignature reports whether signatureHeader, GitHub's
+// "sha256=<hex>" X-Hub-Signature-256 value, is the HMAC-SHA256 of body under
+// secret.
+func validGitHubSignature(secret string, body []byte, signatureHeader string) bool {
+	hexSignature, ok := strings.CutPrefix(signatureHeader, "sha256=")
+	if !ok || secret == "" {
+		return false
+	}
+	signature, err := hex.DecodeString(hexSignature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(signature, mac.Sum(nil))
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+const testGitHubWebhookSecret = "webhook-secret"
+
+// signGitHubPayload returns the X-Hub-Signature-256 value GitHub would send
+// for body.
+func signGitHubPayload(secret string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newGitHubWebhookTestServer returns a test server with the webhook receiver
+// configured and github.com/owner/repo in the repo library.
+func newGitHubWebhookTestServer(t *testing.T) *Server {
+	t.Helper()
+	srv := newAutoSummaryTestServer(t)
+	srv.repoUpdateCh = make(chan repoUpdateRequest, 1)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		GitHubWebhook: &config.GitHubWebhookConfig{Secret: testGitHubWebhookSecret},
+	})
+	if err := os.MkdirAll(config.GetRepoDirpath(srv.agencDirpath, "github.com/owner/repo"), 0755); err != nil {
+		t.Fatalf("failed to create repo dir: %v", err)
+	}
+	return srv
+}
+
+func serveGitHubWebhook(srv *Server, event string, body string, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", githubWebhookPath, strings.NewReader(body))
+	req.Header.Set("X-GitHub-Event", event)
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+	rec := httptest.NewRecorder()
+	appHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), srv.handleGitHubWebhook).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleGitHubWebhook_PushEnqueuesUpdate(t *testing.T) {
+	srv := newGitHubWebhookTestServer(t)
+	body := `{"ref":"refs/heads/main","repository":{"full_name":"owner/repo","default_branch":"main"}}`
+
+	rec := serveGitHubWebhook(srv, "push", body, signGitHubPayload(testGitHubWebhookSecret, body))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rec.Code, rec.Body.String())
+	}
+	select {
+	case req := <-srv.repoUpdateCh:
+		if req.repoName != "github.com/owner/repo" {
+			t.Errorf("expected update for github.com/owner/repo, got '%s'", req.repoName)
+		}
+	default:
+		t.Fatal("expected an update request to be enqueued")
+	}
+}
+
+func TestHandleGitHubWebhook_RejectsBadSignature(t *testing.T) {
+	srv := newGitHubWebhookTestServer(t)
+	body := `{"ref":"refs/heads/main","repository":{"full_name":"owner/repo","default_branch":"main"}}`
+
+	cases := map[string]string{
+		"missing":    "",
+		"wrong key":  signGitHubPayload("other-secret", body),
+		"not hex":    "sha256=zzzz",
+		"sha1 style": "sha1=" + strings.TrimPrefix(signGitHubPayload(testGitHubWebhookSecret, body), "sha256="),
+	}
+	for name, signature := range cases {
+		rec := serveGitHubWebhook(srv, "push", body, signature)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", name, rec.Code)
+		}
+	}
+	if len(srv.repoUpdateCh) != 0 {
+		t.Error("expected no update to be enqueued for unsigned deliveries")
+	}
+}
+
+func TestHandleGitHubWebhook_IgnoresIrrelevantPushes(t *testing.T) {
+	srv := newGitHubWebhookTestServer(t)
+
+	bodies := map[string]string{
+		"other branch": `{"ref":"refs/heads/feature","repository":{"full_name":"owner/repo","default_branch":"main"}}`,
+		"tag":          `{"ref":"refs/tags/v1.0.0","repository":{"full_name":"owner/repo","default_branch":"main"}}`,
+		"unknown repo": `{"ref":"refs/heads/main","repository":{"full_name":"owner/elsewhere","default_branch":"main"}}`,
+	}
+	for name, body := range bodies {
+		rec := serveGitHubWebhook(srv, "push", body, signGitHubPayload(testGitHubWebhookSecret, body))
+		if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), "ignored") {
+			t.Errorf("%s: expected 202 ignored, got %d: %s", name, rec.Code, rec.Body.String())
+		}
+	}
+	if len(srv.repoUpdateCh) != 0 {
+		t.Error("expected no update to be enqueued")
+	}
+
+	rec := serveGitHubWebhook(srv, "ping", `{}`, signGitHubPayload(testGitHubWebhookSecret, `{}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("ping: expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHandleGitHubWebhook_NotConfigured(t *testing.T) {
+	srv := newGitHubWebhookTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{})
+
+	rec := serveGitHubWebhook(srv, "ping", `{}`, signGitHubPayload(testGitHubWebhookSecret, `{}`))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestRequireAPIToken_ExemptsGitHubWebhook(t *testing.T) {
+	srv := newGitHubWebhookTestServer(t)
+	handler := srv.requireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("POST", githubWebhookPath, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected webhook path to skip bearer auth, got %d", rec.Code)
+	}
+}
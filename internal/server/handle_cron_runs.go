@@ -0,0 +1,282 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// defaultCronRunsLimit caps GET /crons/{name}/runs when no limit is given.
+	defaultCronRunsLimit = 20
+
+	// cronRunStartupGrace is how long a freshly recorded run may go without a
+	// live wrapper before reconciliation declares it dead. Covers the gap
+	// between mission creation and the wrapper writing its PID file.
+	cronRunStartupGrace = time.Minute
+)
+
+// CronRunResponse is the JSON representation of a cron run.
+type CronRunResponse struct {
+	ID        string     `json:"id"`
+	CronID    string     `json:"cron_id"`
+	CronName  string     `json:"cron_name"`
+	Trigger   string     `json:"trigger"`
+	MissionID *string    `json:"mission_id"`
+	Status    string     `json:"status"`
+	ExitCode  *int       `json:"exit_code"`
+	Detail    string     `json:"detail,omitempty"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+func toCronRunResponse(r *database.CronRun) CronRunResponse {
+	return CronRunResponse{
+		ID:        r.ID,
+		CronID:    r.CronID,
+		CronName:  r.CronName,
+		Trigger:   r.Trigger,
+		MissionID: r.MissionID,
+		Status:    r.Status,
+		ExitCode:  r.ExitCode,
+		Detail:    r.Detail,
+		StartedAt: r.StartedAt,
+		EndedAt:   r.EndedAt,
+	}
+}
+
+// MissionExitRequest is the JSON body for POST /missions/{id}/exit.
+type MissionExitRequest struct {
+	ExitCode int `json:"exit_code"`
+	// LastError summarizes an unexpected exit and is recorded as the
+	// mission's last_error. Empty for clean exits and for exits the wrapper
+	// caused, such as a budget stop.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// handleListCronRuns handles GET /crons/{name}/runs. The path segment may be
+// a cron name or cron ID. Returns runs newest first, capped by ?limit=N
+// (default 20; 0 for all).
+func (s *Server) handleListCronRuns(w http.ResponseWriter, r *http.Request) error {
+	nameOrID := r.PathValue("name")
+
+	_, cronCfg, ok := s.findCronByNameOrID(nameOrID)
+	if !ok || cronCfg.ID == "" {
+		return newHTTPErrorf(http.StatusNotFound, "cron job '%s' not found", nameOrID)
+	}
+	cronID := cronCfg.ID
+
+	limit := defaultCronRunsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 0 {
+			return newHTTPErrorf(http.StatusBadRequest, "invalid limit %q: must be a non-negative integer", limitStr)
+		}
+		limit = parsed
+	}
+
+	runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: cronID, Limit: limit})
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	result := make([]CronRunResponse, 0, len(runs))
+	for _, run := range runs {
+		result = append(result, toCronRunResponse(run))
+	}
+	writeJSON(w, http.StatusOK, result)
+	return nil
+}
+
+// handleMissionExit handles POST /missions/{id}/exit. The wrapper calls this
+// when Claude exits on its own so a still-running cron run can record the
+// exit status. An unexpected exit is recorded as the mission's last_error,
+// and a clean exit clears it.
+func (s *Server) handleMissionExit(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	var req MissionExitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+
+	s.publishEvent(EventMissionExited, resolvedID, map[string]string{"exit_code": strconv.Itoa(req.ExitCode)})
+	s.recordMissionLastError(resolvedID, req)
+
+	status := database.CronRunStatusSucceeded
+	detail := ""
+	if req.ExitCode != 0 {
+		status = database.CronRunStatusFailed
+		detail = fmt.Sprintf("claude exited with code %d", req.ExitCode)
+	}
+	exitCode := req.ExitCode
+	finished, err := s.db.FinishCronRunForMission(resolvedID, status, &exitCode, detail)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if finished {
+		s.notifyCronRunForMission(resolvedID, status, detail)
+		if status == database.CronRunStatusSucceeded {
+			go s.startCronsWaitingOn(resolvedID)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// recordMissionLastError sets the mission's last_error from an unexpected
+// exit, or clears a previous one on a clean exit. Best-effort: failures are
+// logged.
+func (s *Server) recordMissionLastError(missionID string, req MissionExitRequest) {
+	lastError := req.LastError
+	if req.ExitCode == 0 {
+		missionRecord, err := s.db.GetMission(missionID)
+		if err != nil || missionRecord == nil || missionRecord.LastError == "" {
+			return
+		}
+		lastError = ""
+	} else if lastError == "" {
+		return
+	}
+	if err := s.db.SetMissionLastError(missionID, lastError); err != nil {
+		s.logger.Printf("Failed to record last error for mission %s: %v", missionID, err)
+	}
+}
+
+// lockCronFiring serializes the cron-sourced creates of one cron and returns
+// the function that releases it. The caller holds it from checkCronOverlap
+// until recordCronRunStart has inserted the new running row, so two firings
+// that arrive together (e.g. launchd catching up after sleep) can't both
+// pass the overlap check.
+func (s *Server) lockCronFiring(cronID string) (unlock func()) {
+	value, _ := s.cronFiringLocks.LoadOrStore(cronID, &sync.Mutex{})
+	cronMu := value.(*sync.Mutex)
+	cronMu.Lock()
+	return cronMu.Unlock
+}
+
+// checkCronOverlap rejects a scheduled cron firing while the cron's previous
+// run is still in progress, recording the firing as a skipped run. Manual
+// triggers (`agenc cron run`) are never skipped.
+func (s *Server) checkCronOverlap(req CreateMissionRequest) error {
+	cronName, trigger := parseCronSourceMetadata(req.SourceMetadata)
+	if trigger == database.CronRunTriggerManual {
+		return nil
+	}
+
+	s.reconcileCronRuns()
+
+	running, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: req.SourceID, Status: database.CronRunStatusRunning})
+	if err != nil {
+		s.logger.Printf("Cron overlap check: failed to list running runs: %v", err)
+		return nil
+	}
+	for _, run := range running {
+		if run.MissionID == nil {
+			continue
+		}
+
+		now := time.Now().UTC()
+		skipped := &database.CronRun{
+			ID:        uuid.New().String(),
+			CronID:    req.SourceID,
+			CronName:  cronName,
+			Trigger:   database.CronRunTriggerSchedule,
+			Status:    database.CronRunStatusSkipped,
+			Detail:    "previous run still running (mission " + database.ShortID(*run.MissionID) + ")",
+			StartedAt: now,
+			EndedAt:   &now,
+		}
+		if err := s.db.CreateCronRun(skipped); err != nil {
+			s.logger.Printf("Cron overlap check: failed to record skipped run for cron %s: %v", req.SourceID, err)
+		}
+		return newHTTPErrorf(http.StatusConflict, "cron '%s' skipped: %s", cronName, skipped.Detail)
+	}
+	return nil
+}
+
+// recordCronRunStart records a new running cron run for a freshly created
+// cron-triggered mission. Best-effort: failures are logged.
+func (s *Server) recordCronRunStart(missionRecord *database.Mission, req CreateMissionRequest) {
+	cronName, trigger := parseCronSourceMetadata(req.SourceMetadata)
+	if trigger != database.CronRunTriggerManual {
+		trigger = database.CronRunTriggerSchedule
+	}
+	missionID := missionRecord.ID
+	run := &database.CronRun{
+		ID:        uuid.New().String(),
+		CronID:    req.SourceID,
+		CronName:  cronName,
+		Trigger:   trigger,
+		MissionID: &missionID,
+		Status:    database.CronRunStatusRunning,
+	}
+	if err := s.db.CreateCronRun(run); err != nil {
+		s.logger.Printf("Failed to record cron run for mission %s: %v", missionRecord.ShortID, err)
+	}
+	s.notifyCronRun(req.SourceID, missionID, cronNotifyStarted, "")
+}
+
+// finishCronRunOnIdle marks a mission's running cron run as succeeded once
+// Claude finishes its first turn, then starts any downstream crons that were
+// waiting on it. No-op for missions without a running run.
+func (s *Server) finishCronRunOnIdle(missionID string) {
+	finished, err := s.db.FinishCronRunForMission(missionID, database.CronRunStatusSucceeded, nil, "")
+	if err != nil {
+		s.logger.Printf("Failed to finish cron run for mission %s: %v", database.ShortID(missionID), err)
+		return
+	}
+	if finished {
+		s.notifyCronRunForMission(missionID, database.CronRunStatusSucceeded, "")
+		go s.startCronsWaitingOn(missionID)
+	}
+}
+
+// reconcileCronRuns fails running cron runs whose mission was deleted or whose
+// wrapper is gone without having reported completion (e.g. the wrapper was
+// killed or the machine slept through the run). Run by the crash detection
+// loop, and before the overlap and dependency checks so they never act on a
+// dead run.
+func (s *Server) reconcileCronRuns() {
+	running, err := s.db.ListCronRuns(database.ListCronRunsParams{Status: database.CronRunStatusRunning})
+	if err != nil {
+		s.logger.Printf("Cron run reconcile: failed to list running runs: %v", err)
+		return
+	}
+	for _, run := range running {
+		detail := ""
+		switch {
+		case run.MissionID == nil:
+			detail = "mission deleted before the run finished"
+		case time.Since(run.StartedAt) < cronRunStartupGrace:
+			continue
+		case !s.isWrapperRunning(*run.MissionID):
+			detail = "wrapper exited before Claude finished"
+		default:
+			continue
+		}
+
+		if err := s.db.FinishCronRun(run.ID, database.CronRunStatusFailed, nil, detail); err != nil {
+			s.logger.Printf("Cron run reconcile: failed to update run %s: %v", run.ID, err)
+			continue
+		}
+		missionID := ""
+		if run.MissionID != nil {
+			missionID = *run.MissionID
+		}
+		s.notifyCronRun(run.CronID, missionID, database.CronRunStatusFailed, detail)
+	}
+}
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestLockCronFiring_SimultaneousFirings(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		Crons: map[string]config.CronConfig{
+			"daily-report": {ID: "cron-1", Schedule: "0 9 * * *"},
+		},
+	})
+	// A tmux that fails after a pause: spawning the wrapper, between the
+	// overlap check and recording the run, takes long enough for every
+	// firing to get there unless the firing lock holds the others back.
+	// handleCreateMission logs the failed spawn and moves on.
+	binDirpath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDirpath, "tmux"), []byte("#!/bin/sh\nsleep 0.1\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDirpath+":/bin:/usr/bin")
+
+	// Firings that arrive together, as after launchd catches up from sleep;
+	// only the first may start, the rest are skipped as overlapping
+	body := `{"source":"cron","source_id":"cron-1","source_metadata":"{\"cron_name\":\"daily-report\"}","headless":true}`
+	statuses := make(chan int, 5)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			w := httptest.NewRecorder()
+			err := srv.handleCreateMission(w, httptest.NewRequest("POST", "/missions", strings.NewReader(body)))
+			var httpErr *httpError
+			switch {
+			case err == nil:
+				statuses <- w.Code
+			case errors.As(err, &httpErr):
+				statuses <- httpErr.status
+			default:
+				t.Errorf("handleCreateMission failed: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(statuses)
+
+	var created, skipped int
+	for status := range statuses {
+		switch status {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+			skipped++
+		default:
+			t.Errorf("unexpected status %d", status)
+		}
+	}
+	if created != 1 || skipped != 4 {
+		t.Errorf("expected 1 firing to start and 4 to be skipped, got %d started and %d skipped", created, skipped)
+	}
+
+	runs, err := srv.db.ListCronRuns(database.ListCronRunsParams{CronID: "cron-1"})
+	if err != nil {
+		t.Fatalf("ListCronRuns failed: %v", err)
+	}
+	numByStatus := map[string]int{}
+	for _, run := range runs {
+		numByStatus[run.Status]++
+	}
+	if numByStatus[database.CronRunStatusRunning] != 1 || numByStatus[database.CronRunStatusSkipped] != 4 {
+		t.Errorf("expected 1 running and 4 skipped runs recorded, got %v", numByStatus)
+	}
+}
+
+func TestCronRuns_OverlapSkipAndExitReporting(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		Crons: map[string]config.CronConfig{
+			"daily-report": {ID: "cron-1", Schedule: "0 9 * * *"},
+		},
+	})
+
+	req := CreateMissionRequest{
+		Source:         "cron",
+		SourceID:       "cron-1",
+		SourceMetadata: `{"cron_name":"daily-report"}`,
+	}
+
+	// First firing: no run in progress, so it proceeds and is recorded
+	if err := srv.checkCronOverlap(req); err != nil {
+		t.Fatalf("first firing should not be skipped: %v", err)
+	}
+	missionRecord, err := srv.db.CreateMission("", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	srv.recordCronRunStart(missionRecord, req)
+
+	// Second scheduled firing overlaps the still-running first run
+	err = srv.checkCronOverlap(req)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusConflict {
+		t.Fatalf("expected 409 for overlapping firing, got %v", err)
+	}
+
+	// Manual triggers are never skipped
+	manualReq := req
+	manualReq.SourceMetadata = `{"cron_name":"daily-report","trigger":"manual"}`
+	if err := srv.checkCronOverlap(manualReq); err != nil {
+		t.Fatalf("manual trigger should not be skipped: %v", err)
+	}
+
+	// Claude exits non-zero: the running run fails with the exit code
+	exitReq := httptest.NewRequest("POST", "/missions/"+missionRecord.ID+"/exit", strings.NewReader(`{"exit_code":2}`))
+	exitReq.SetPathValue("id", missionRecord.ID)
+	if err := srv.handleMissionExit(httptest.NewRecorder(), exitReq); err != nil {
+		t.Fatalf("handleMissionExit failed: %v", err)
+	}
+
+	listReq := httptest.NewRequest("GET", "/crons/daily-report/runs", nil)
+	listReq.SetPathValue("name", "daily-report")
+	w := httptest.NewRecorder()
+	if err := srv.handleListCronRuns(w, listReq); err != nil {
+		t.Fatalf("handleListCronRuns failed: %v", err)
+	}
+
+	var runs []CronRunResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &runs); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(runs))
+	}
+
+	// Newest first: the skipped firing, then the failed run
+	if runs[0].Status != database.CronRunStatusSkipped || runs[0].MissionID != nil {
+		t.Errorf("expected newest run to be skipped with no mission, got %+v", runs[0])
+	}
+	failed := runs[1]
+	if failed.Status != database.CronRunStatusFailed {
+		t.Errorf("expected status failed, got %q", failed.Status)
+	}
+	if failed.ExitCode == nil || *failed.ExitCode != 2 {
+		t.Errorf("expected exit code 2, got %v", failed.ExitCode)
+	}
+	if failed.MissionID == nil || *failed.MissionID != missionRecord.ID {
+		t.Errorf("expected mission %s, got %v", missionRecord.ID, failed.MissionID)
+	}
+	if failed.Trigger != database.CronRunTriggerSchedule || failed.CronName != "daily-report" {
+		t.Errorf("unexpected trigger/name: %q/%q", failed.Trigger, failed.CronName)
+	}
+}
+
+func TestHandleListCronRuns_UnknownCron(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+
+	req := httptest.NewRequest("GET", "/crons/nope/runs", nil)
+	req.SetPathValue("name", "nope")
+	err := srv.handleListCronRuns(httptest.NewRecorder(), req)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusNotFound {
+		t.Fatalf("expected 404, got %v", err)
+	}
+}
+
+func TestHandleMissionExit_LastError(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	reportExit := func(body string) *database.Mission {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/missions/"+missionRecord.ID+"/exit", strings.NewReader(body))
+		req.SetPathValue("id", missionRecord.ID)
+		if err := srv.handleMissionExit(httptest.NewRecorder(), req); err != nil {
+			t.Fatalf("handleMissionExit failed: %v", err)
+		}
+		got, err := srv.db.GetMission(missionRecord.ID)
+		if err != nil {
+			t.Fatalf("GetMission failed: %v", err)
+		}
+		return got
+	}
+
+	// An unexpected exit records the wrapper's summary
+	got := reportExit(`{"exit_code":1,"last_error":"claude exited with code 1: Error: boom"}`)
+	if got.LastError != "claude exited with code 1: Error: boom" {
+		t.Errorf("expected last error to be recorded, got %q", got.LastError)
+	}
+
+	// A non-zero exit the wrapper caused (no summary) leaves it alone
+	got = reportExit(`{"exit_code":143}`)
+	if got.LastError != "claude exited with code 1: Error: boom" {
+		t.Errorf("expected last error to be kept, got %q", got.LastError)
+	}
+
+	// A clean exit clears it
+	got = reportExit(`{"exit_code":0}`)
+	if got.LastError != "" {
+		t.Errorf("expected last error to be cleared, got %q", got.LastError)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -12,24 +13,45 @@ import (
 
 // CronInfo represents a cron job in API responses.
 type CronInfo struct {
-	Name                 string `json:"name"`
-	ID                   string `json:"id"`
-	Schedule             string `json:"schedule"`
-	Prompt               string `json:"prompt"`
-	Description          string `json:"description,omitempty"`
-	Repo                 string `json:"repo,omitempty"`
-	Enabled              bool   `json:"enabled"`
-	NotificationsEnabled bool   `json:"notificationsEnabled"`
+	Name                       string            `json:"name"`
+	ID                         string            `json:"id"`
+	Schedule                   string            `json:"schedule,omitempty"`
+	RunAt                      string            `json:"runAt,omitempty"`
+	Prompt                     string            `json:"prompt"`
+	Description                string            `json:"description,omitempty"`
+	Repo                       string            `json:"repo,omitempty"`
+	Ref                        string            `json:"ref,omitempty"`
+	Enabled                    bool              `json:"enabled"`
+	NotificationsEnabled       bool              `json:"notificationsEnabled"`
+	After                      string            `json:"after,omitempty"`
+	MaxPrompts                 int               `json:"maxPrompts,omitempty"`
+	BudgetUSD                  float64           `json:"budgetUsd,omitempty"`
+	Env                        map[string]string `json:"env,omitempty"`
+	Notify                     []string          `json:"notify,omitempty"`
+	Project                    string            `json:"project,omitempty"`
+	IgnoreQuietHours           bool              `json:"ignoreQuietHours,omitempty"`
+	ResumePolicy               string            `json:"resumePolicy,omitempty"`
+	AlertOnConsecutiveFailures int               `json:"alertOnConsecutiveFailures,omitempty"`
 }
 
 // CreateCronRequest is the request body for POST /crons.
 type CreateCronRequest struct {
-	Name                 string `json:"name"`
-	Schedule             string `json:"schedule"`
-	Prompt               string `json:"prompt"`
-	Description          string `json:"description,omitempty"`
-	Repo                 string `json:"repo,omitempty"`
-	NotificationsEnabled *bool  `json:"notificationsEnabled,omitempty"`
+	Name                       string            `json:"name"`
+	Schedule                   string            `json:"schedule"`
+	Prompt                     string            `json:"prompt"`
+	Description                string            `json:"description,omitempty"`
+	Repo                       string            `json:"repo,omitempty"`
+	Ref                        string            `json:"ref,omitempty"`
+	NotificationsEnabled       *bool             `json:"notificationsEnabled,omitempty"`
+	After                      string            `json:"after,omitempty"`
+	MaxPrompts                 int               `json:"maxPrompts,omitempty"`
+	BudgetUSD                  float64           `json:"budgetUsd,omitempty"`
+	Env                        map[string]string `json:"env,omitempty"`
+	Notify                     []string          `json:"notify,omitempty"`
+	Project                    string            `json:"project,omitempty"`
+	IgnoreQuietHours           bool              `json:"ignoreQuietHours,omitempty"`
+	ResumePolicy               string            `json:"resumePolicy,omitempty"`
+	AlertOnConsecutiveFailures int               `json:"alertOnConsecutiveFailures,omitempty"`
 }
 
 // UpdateCronRequest is the request body for PATCH /crons/{name}.
@@ -41,21 +63,99 @@ type UpdateCronRequest struct {
 	Repo                 *string `json:"repo,omitempty"`
 	Enabled              *bool   `json:"enabled,omitempty"`
 	NotificationsEnabled *bool   `json:"notificationsEnabled,omitempty"`
+	// Ref pins each run to a branch, tag, or commit; an empty string
+	// clears it so runs start from the default branch.
+	Ref *string `json:"ref,omitempty"`
+	// After sets the upstream cron; an empty string clears it.
+	After *string `json:"after,omitempty"`
+	// MaxPrompts and BudgetUSD set each run's limits; zero clears them.
+	MaxPrompts *int     `json:"maxPrompts,omitempty"`
+	BudgetUSD  *float64 `json:"budgetUsd,omitempty"`
+	// Env replaces the run environment; an empty map clears it.
+	Env *map[string]string `json:"env,omitempty"`
+	// Notify replaces the notify targets; an empty list clears them.
+	Notify *[]string `json:"notify,omitempty"`
+	// Project sets the project each run's mission joins; an empty string
+	// clears it.
+	Project          *string `json:"project,omitempty"`
+	IgnoreQuietHours *bool   `json:"ignoreQuietHours,omitempty"`
+	// ResumePolicy sets how each run treats the previous one; an empty
+	// string resets it to fresh.
+	ResumePolicy *string `json:"resumePolicy,omitempty"`
+	// AlertOnConsecutiveFailures sets the failure streak that raises an
+	// alert; zero turns alerting off.
+	AlertOnConsecutiveFailures *int `json:"alertOnConsecutiveFailures,omitempty"`
 }
 
 func cronInfoFromConfig(name string, cronCfg config.CronConfig) CronInfo {
 	return CronInfo{
-		Name:                 name,
-		ID:                   cronCfg.ID,
-		Schedule:             cronCfg.Schedule,
-		Prompt:               cronCfg.Prompt,
-		Description:          cronCfg.Description,
-		Repo:                 cronCfg.Repo,
-		Enabled:              cronCfg.IsEnabled(),
-		NotificationsEnabled: cronCfg.AreNotificationsEnabled(),
+		Name:                       name,
+		ID:                         cronCfg.ID,
+		Schedule:                   cronCfg.Schedule,
+		RunAt:                      cronCfg.RunAt,
+		Prompt:                     cronCfg.Prompt,
+		Description:                cronCfg.Description,
+		Repo:                       cronCfg.Repo,
+		Ref:                        cronCfg.Ref,
+		Enabled:                    cronCfg.IsEnabled(),
+		NotificationsEnabled:       cronCfg.AreNotificationsEnabled(),
+		After:                      cronCfg.After,
+		MaxPrompts:                 cronCfg.MaxPrompts,
+		BudgetUSD:                  cronCfg.BudgetUSD,
+		Env:                        cronCfg.Env,
+		Notify:                     cronCfg.Notify,
+		Project:                    cronCfg.Project,
+		IgnoreQuietHours:           cronCfg.IgnoreQuietHours,
+		ResumePolicy:               cronCfg.ResumePolicy,
+		AlertOnConsecutiveFailures: cronCfg.AlertOnConsecutiveFailures,
 	}
 }
 
+// validateEnvKeys rejects cron or mission env keys that aren't valid variable
+// names.
+func validateEnvKeys(env map[string]string) error {
+	for key := range env {
+		if err := config.ValidateEnvVarName(key); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
+		}
+	}
+	return nil
+}
+
+// validateCronLimits rejects negative per-run mission limits.
+func validateCronLimits(maxPrompts int, budgetUSD float64) error {
+	if maxPrompts < 0 {
+		return newHTTPError(http.StatusBadRequest, "maxPrompts cannot be negative")
+	}
+	if budgetUSD < 0 {
+		return newHTTPError(http.StatusBadRequest, "budgetUsd cannot be negative")
+	}
+	return nil
+}
+
+// validateCronRef rejects a ref that git could never resolve, or one set on
+// a cron without a repo.
+func validateCronRef(ref string, repo string) error {
+	if ref == "" {
+		return nil
+	}
+	if repo == "" {
+		return newHTTPError(http.StatusBadRequest, "ref requires a repo")
+	}
+	if err := config.ValidateCronRef(ref); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%#s", err)
+	}
+	return nil
+}
+
+// validateCronAlertThreshold rejects a negative alertOnConsecutiveFailures.
+func validateCronAlertThreshold(alertOnConsecutiveFailures int) error {
+	if alertOnConsecutiveFailures < 0 {
+		return newHTTPError(http.StatusBadRequest, "alertOnConsecutiveFailures cannot be negative")
+	}
+	return nil
+}
+
 func (s *Server) handleListCrons(w http.ResponseWriter, r *http.Request) error {
 	cfg := s.getConfig()
 
@@ -63,6 +163,11 @@ func (s *Server) handleListCrons(w http.ResponseWriter, r *http.Request) error {
 	for name, cronCfg := range cfg.Crons {
 		crons = append(crons, cronInfoFromConfig(name, cronCfg))
 	}
+	for _, job := range s.listCronAtJobs() {
+		if _, exists := cfg.Crons[job.Name]; !exists {
+			crons = append(crons, cronInfoFromConfig(job.Name, cronConfigFromAtJob(job)))
+		}
+	}
 
 	sort.Slice(crons, func(i, j int) bool {
 		return crons[i].Name < crons[j].Name
@@ -87,6 +192,24 @@ func (s *Server) handleCreateCron(w http.ResponseWriter, r *http.Request) error
 	if req.Prompt == "" {
 		return newHTTPError(http.StatusBadRequest, "prompt cannot be empty")
 	}
+	if err := validateCronLimits(req.MaxPrompts, req.BudgetUSD); err != nil {
+		return err
+	}
+	if err := validateCronAlertThreshold(req.AlertOnConsecutiveFailures); err != nil {
+		return err
+	}
+	if err := validateCronRef(req.Ref, req.Repo); err != nil {
+		return err
+	}
+	if err := validateEnvKeys(req.Env); err != nil {
+		return err
+	}
+	if err := s.validateProjectRef(req.Project); err != nil {
+		return err
+	}
+	if _, err := config.ParseCronResumePolicy(req.ResumePolicy); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
+	}
 
 	release, err := config.AcquireConfigLock(s.agencDirpath)
 	if err != nil {
@@ -99,23 +222,39 @@ func (s *Server) handleCreateCron(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
-	if _, exists := cfg.Crons[req.Name]; exists {
+	if _, exists := cfg.Crons[req.Name]; exists || s.findCronAtJob(req.Name) != nil {
 		return newHTTPErrorf(http.StatusConflict, "cron job '%s' already exists", req.Name)
 	}
+	if err := cfg.ValidateCronNotifyTargets(req.Notify); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%#s", err)
+	}
 
 	cronCfg := config.CronConfig{
-		ID:                   uuid.New().String(),
-		Schedule:             req.Schedule,
-		Prompt:               req.Prompt,
-		Description:          req.Description,
-		Repo:                 req.Repo,
-		NotificationsEnabled: req.NotificationsEnabled,
+		ID:                         uuid.New().String(),
+		Schedule:                   req.Schedule,
+		Prompt:                     req.Prompt,
+		Description:                req.Description,
+		Repo:                       req.Repo,
+		Ref:                        req.Ref,
+		NotificationsEnabled:       req.NotificationsEnabled,
+		After:                      req.After,
+		MaxPrompts:                 req.MaxPrompts,
+		BudgetUSD:                  req.BudgetUSD,
+		Env:                        req.Env,
+		Notify:                     req.Notify,
+		Project:                    req.Project,
+		IgnoreQuietHours:           req.IgnoreQuietHours,
+		ResumePolicy:               req.ResumePolicy,
+		AlertOnConsecutiveFailures: req.AlertOnConsecutiveFailures,
 	}
 
 	if cfg.Crons == nil {
 		cfg.Crons = make(map[string]config.CronConfig)
 	}
 	cfg.Crons[req.Name] = cronCfg
+	if err := config.ValidateCronDependencies(cfg.Crons); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
+	}
 
 	if err := config.WriteAgencConfig(s.agencDirpath, cfg, cm); err != nil {
 		return err
@@ -157,6 +296,8 @@ func (s *Server) handleUpdateCron(w http.ResponseWriter, r *http.Request) error
 			return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
 		}
 		cronCfg.Schedule = *req.Schedule
+		// A schedule turns a one-shot cron into a recurring one
+		cronCfg.RunAt = ""
 	}
 	if req.Prompt != nil {
 		if *req.Prompt == "" {
@@ -170,14 +311,74 @@ func (s *Server) handleUpdateCron(w http.ResponseWriter, r *http.Request) error
 	if req.Repo != nil {
 		cronCfg.Repo = *req.Repo
 	}
+	if req.Ref != nil {
+		cronCfg.Ref = *req.Ref
+	}
+	if err := validateCronRef(cronCfg.Ref, cronCfg.Repo); err != nil {
+		return err
+	}
 	if req.Enabled != nil {
 		cronCfg.Enabled = req.Enabled
 	}
 	if req.NotificationsEnabled != nil {
 		cronCfg.NotificationsEnabled = req.NotificationsEnabled
 	}
+	if req.After != nil {
+		cronCfg.After = *req.After
+	}
+	if req.MaxPrompts != nil {
+		cronCfg.MaxPrompts = *req.MaxPrompts
+	}
+	if req.BudgetUSD != nil {
+		cronCfg.BudgetUSD = *req.BudgetUSD
+	}
+	if err := validateCronLimits(cronCfg.MaxPrompts, cronCfg.BudgetUSD); err != nil {
+		return err
+	}
+	if req.Env != nil {
+		if err := validateEnvKeys(*req.Env); err != nil {
+			return err
+		}
+		cronCfg.Env = *req.Env
+		if len(cronCfg.Env) == 0 {
+			cronCfg.Env = nil
+		}
+	}
+	if req.Notify != nil {
+		if err := cfg.ValidateCronNotifyTargets(*req.Notify); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "%#s", err)
+		}
+		cronCfg.Notify = *req.Notify
+		if len(cronCfg.Notify) == 0 {
+			cronCfg.Notify = nil
+		}
+	}
+	if req.Project != nil {
+		if err := s.validateProjectRef(*req.Project); err != nil {
+			return err
+		}
+		cronCfg.Project = *req.Project
+	}
+	if req.IgnoreQuietHours != nil {
+		cronCfg.IgnoreQuietHours = *req.IgnoreQuietHours
+	}
+	if req.ResumePolicy != nil {
+		if _, err := config.ParseCronResumePolicy(*req.ResumePolicy); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
+		}
+		cronCfg.ResumePolicy = *req.ResumePolicy
+	}
+	if req.AlertOnConsecutiveFailures != nil {
+		if err := validateCronAlertThreshold(*req.AlertOnConsecutiveFailures); err != nil {
+			return err
+		}
+		cronCfg.AlertOnConsecutiveFailures = *req.AlertOnConsecutiveFailures
+	}
 
 	cfg.Crons[name] = cronCfg
+	if err := config.ValidateCronDependencies(cfg.Crons); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
+	}
 
 	if err := config.WriteAgencConfig(s.agencDirpath, cfg, cm); err != nil {
 		return err
@@ -205,7 +406,20 @@ func (s *Server) handleDeleteCron(w http.ResponseWriter, r *http.Request) error
 	}
 
 	if _, exists := cfg.Crons[name]; !exists {
-		return newHTTPErrorf(http.StatusNotFound, "cron job '%s' not found", name)
+		job := s.findCronAtJob(name)
+		if job == nil || job.Name != name {
+			return newHTTPErrorf(http.StatusNotFound, "cron job '%s' not found", name)
+		}
+		if _, err := s.db.DeleteCronAtJob(job.ID); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to delete one-shot cron job: %s", err.Error())
+		}
+		s.syncCronsAfterMutation(cfg)
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	if dependents := cfg.GetCronDependents(name); len(dependents) > 0 {
+		return newHTTPErrorf(http.StatusConflict, "cron job '%s' is required by %s; remove their 'after' first",
+			name, strings.Join(dependents, ", "))
 	}
 
 	delete(cfg.Crons, name)
@@ -223,7 +437,7 @@ func (s *Server) handleDeleteCron(w http.ResponseWriter, r *http.Request) error
 
 // syncCronsAfterMutation triggers a launchd cron sync after a cron mutation.
 func (s *Server) syncCronsAfterMutation(cfg *config.AgencConfig) {
-	if err := s.cronSyncer.SyncCronsToLaunchd(cfg.Crons, s.logger); err != nil {
+	if err := s.cronSyncer.SyncCronsToLaunchd(s.scheduledCrons(cfg), s.logger); err != nil {
 		s.logger.Printf("Failed to sync crons after mutation: %v", err)
 	}
 }
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// ExportMissionRequest is the JSON body for POST /missions/{id}/export.
+type ExportMissionRequest struct {
+	// OutputPath is the absolute path of the bundle to write. Its extension
+	// selects the compression (.tar.zst, .tar.gz, or .tar).
+	OutputPath string `json:"output_path"`
+}
+
+// ExportMissionResponse is the JSON response for a successful export.
+type ExportMissionResponse struct {
+	OutputPath string `json:"output_path"`
+	SizeBytes  int64  `json:"size_bytes"`
+}
+
+// ImportMissionRequest is the JSON body for POST /missions/import.
+type ImportMissionRequest struct {
+	// BundlePath is the absolute path of a bundle written by export.
+	BundlePath string `json:"bundle_path"`
+}
+
+// handleExportMission handles POST /missions/{id}/export. The mission must be
+// stopped so the workspace and transcripts are not changing mid-archive.
+func (s *Server) handleExportMission(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	var req ExportMissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if !filepath.IsAbs(req.OutputPath) {
+		return newHTTPError(http.StatusBadRequest, "output_path must be an absolute path")
+	}
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	missionRecord, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if missionRecord == nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	if s.isWrapperRunning(resolvedID) {
+		return newHTTPErrorf(http.StatusConflict, "mission %s is running; stop it first with 'agenc mission stop %s'", missionRecord.ShortID, missionRecord.ShortID)
+	}
+
+	if err := mission.ExportBundle(s.agencDirpath, missionRecord, req.OutputPath); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to export mission: %s", err.Error())
+	}
+
+	resp := ExportMissionResponse{OutputPath: req.OutputPath}
+	if info, err := os.Stat(req.OutputPath); err == nil {
+		resp.SizeBytes = info.Size()
+	}
+	s.logger.Printf("Exported mission %s to %s", missionRecord.ShortID, req.OutputPath)
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
+
+// handleImportMission handles POST /missions/import. The bundled mission keeps
+// its ID, so importing the same bundle twice (or onto the machine it came
+// from) is rejected. The imported mission is left stopped.
+func (s *Server) handleImportMission(w http.ResponseWriter, r *http.Request) error {
+	var req ImportMissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if !filepath.IsAbs(req.BundlePath) {
+		return newHTTPError(http.StatusBadRequest, "bundle_path must be an absolute path")
+	}
+
+	manifest, err := mission.ReadBundleManifest(req.BundlePath)
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid bundle: %s", err.Error())
+	}
+	missionID := manifest.Mission.ID
+	if existing, err := s.db.GetMission(missionID); err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	} else if existing != nil {
+		return newHTTPErrorf(http.StatusConflict, "mission %s already exists on this machine", existing.ShortID)
+	}
+
+	if _, err := mission.ExtractBundle(s.agencDirpath, req.BundlePath); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to extract bundle: %s", err.Error())
+	}
+
+	missionRecord := manifest.Mission.ToMission()
+	if err := s.db.InsertImportedMission(missionRecord); err != nil {
+		_ = os.RemoveAll(config.GetMissionDirpath(s.agencDirpath, missionID))
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to record imported mission: %s", err.Error())
+	}
+
+	// Rebuild claude-config against this machine's ~/.claude so the shared
+	// directory symlinks (including projects/, where the transcripts now
+	// live) exist before the first resume. The wrapper rebuilds it again on
+	// every spawn, so a failure here is not fatal.
+	rc, _ := s.getConfig().GetRepoConfig(missionRecord.GitRepo)
+	if err := claudeconfig.BuildMissionConfigDir(s.agencDirpath, missionID, rc.TrustedMcpServers, rc.McpServers, rc.ClaudeMdAppend, false, nil); err != nil {
+		s.logger.Printf("Warning: failed to rebuild claude-config for imported mission %s: %v", missionRecord.ShortID, err)
+	}
+
+	// Dependency cache symlinks in the bundle point at the exporting
+	// machine's cache; repoint them at ours.
+	if missionRecord.GitRepo != "" {
+		agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionID)
+		s.linkDependencyCache(missionRecord.GitRepo, agentDirpath, s.getConfig().GetPostUpdateHookCache(missionRecord.GitRepo))
+	}
+
+	imported, err := s.db.GetMission(missionID)
+	if err != nil || imported == nil {
+		imported = missionRecord
+	}
+	s.logger.Printf("Imported mission %s from %s", imported.ShortID, req.BundlePath)
+	s.publishMissionCreated(imported)
+	writeJSON(w, http.StatusCreated, toMissionResponse(imported))
+	return nil
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestMissionExportImport_RoundTrip(t *testing.T) {
+	src := newAutoSummaryTestServer(t)
+	missionRecord, err := src.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	if err := src.db.UpdateMissionSessionName(missionRecord.ID, "Handoff work"); err != nil {
+		t.Fatalf("UpdateMissionSessionName failed: %v", err)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(src.agencDirpath, missionRecord.ID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "notes.md"), []byte("wip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	exportReq := httptest.NewRequest("POST", "/missions/"+missionRecord.ShortID+"/export", strings.NewReader(`{"output_path":"`+bundlePath+`"}`))
+	exportReq.SetPathValue("id", missionRecord.ShortID)
+	if err := src.handleExportMission(httptest.NewRecorder(), exportReq); err != nil {
+		t.Fatalf("handleExportMission failed: %v", err)
+	}
+
+	dst := newAutoSummaryTestServer(t)
+	importBody := `{"bundle_path":"` + bundlePath + `"}`
+	rec := httptest.NewRecorder()
+	if err := dst.handleImportMission(rec, httptest.NewRequest("POST", "/missions/import", strings.NewReader(importBody))); err != nil {
+		t.Fatalf("handleImportMission failed: %v", err)
+	}
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rec.Code)
+	}
+	var resp MissionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ID != missionRecord.ID || resp.SessionName != "Handoff work" || resp.GitRepo != "github.com/owner/repo" {
+		t.Errorf("imported mission does not match original: %+v", resp)
+	}
+
+	imported, err := dst.db.GetMission(missionRecord.ID)
+	if err != nil || imported == nil {
+		t.Fatalf("imported mission not in database: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(config.GetMissionAgentDirpath(dst.agencDirpath, missionRecord.ID), "notes.md")); err != nil || string(data) != "wip" {
+		t.Errorf("agent directory not restored: %q, %v", data, err)
+	}
+
+	// A second import of the same bundle conflicts with the existing mission
+	err = dst.handleImportMission(httptest.NewRecorder(), httptest.NewRequest("POST", "/missions/import", strings.NewReader(importBody)))
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusConflict {
+		t.Fatalf("expected 409 on re-import, got %v", err)
+	}
+}
+
+func TestMissionExport_RequiresAbsolutePath(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/missions/"+missionRecord.ID+"/export", strings.NewReader(`{"output_path":"bundle.tar"}`))
+	req.SetPathValue("id", missionRecord.ID)
+	err = srv.handleExportMission(httptest.NewRecorder(), req)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for relative path, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// missionOutputPollInterval controls how often a follow stream checks the log
+// file for new bytes.
+const missionOutputPollInterval = 500 * time.Millisecond
+
+// handleMissionOutput handles GET /missions/{id}/output.
+//
+// Query params:
+//   - source: "claude" (default, claude-output.log) or "wrapper" (wrapper.log)
+//   - mode: "tail" (default, last 200 lines) or "all"
+//   - follow: "true" to stream the log as Server-Sent Events, starting with the
+//     tail (or the whole file when mode=all) and emitting each new line as it
+//     is appended. The stream ends when the client disconnects.
+func (s *Server) handleMissionOutput(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	source := r.URL.Query().Get("source")
+	if source == "" {
+		source = "claude"
+	}
+
+	var logFilepath string
+	switch source {
+	case "claude":
+		logFilepath = config.GetMissionClaudeOutputLogFilepath(s.agencDirpath, resolvedID)
+	case "wrapper":
+		logFilepath = config.GetMissionWrapperLogFilepath(s.agencDirpath, resolvedID)
+	default:
+		return newHTTPErrorf(http.StatusBadRequest, "invalid source %q: must be \"claude\" or \"wrapper\"", source)
+	}
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = "tail"
+	}
+	if mode != "tail" && mode != "all" {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid mode %q: must be \"tail\" or \"all\"", mode)
+	}
+
+	follow := r.URL.Query().Get("follow") == "true"
+	if !follow {
+		if _, err := os.Stat(logFilepath); os.IsNotExist(err) {
+			return newHTTPError(http.StatusNotFound, "log file does not exist yet")
+		}
+		return writeLogResponse(w, logFilepath, mode)
+	}
+
+	return s.streamLogFile(w, r, logFilepath, mode)
+}
+
+// writeLogResponse writes the log at logFilepath as plain text, either in full
+// (mode "all") or its last defaultTailLines lines (mode "tail").
+func writeLogResponse(w http.ResponseWriter, logFilepath string, mode string) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	if mode == "all" {
+		file, err := os.Open(logFilepath)
+		if err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to open log file: %v", err)
+		}
+		defer file.Close()
+		_, _ = io.Copy(w, file) // client disconnects are not actionable
+		return nil
+	}
+
+	lines, err := readTailLines(logFilepath, defaultTailLines)
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to read log file: %v", err)
+	}
+	for _, line := range lines {
+		w.Write([]byte(line + "\n"))
+	}
+	return nil
+}
+
+// streamLogFile streams a log file as Server-Sent Events. Each log line
+// becomes one "data:" event. The file is polled rather than watched so that
+// logs which do not exist yet (a mission still starting up) and logs that are
+// truncated (a wrapper restart) are both handled by the same loop.
+func (s *Server) streamLogFile(w http.ResponseWriter, r *http.Request, logFilepath string, mode string) error {
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// Emit the initial backlog and remember where it ended. Both come from
+	// one handle and one size, so lines appended meanwhile are left to the
+	// follow loop rather than sent twice.
+	var offset int64
+	if mode == "tail" {
+		offset = writeTailBacklog(w, logFilepath)
+	}
+	if err := rc.Flush(); err != nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(missionOutputPollInterval)
+	defer ticker.Stop()
+
+	var partial []byte
+	for {
+		var chunk []byte
+		offset, chunk = readLogFrom(logFilepath, offset)
+
+		if len(chunk) > 0 {
+			partial = append(partial, chunk...)
+			for {
+				idx := bytes.IndexByte(partial, '\n')
+				if idx < 0 {
+					break
+				}
+				writeSSEData(w, string(partial[:idx]))
+				partial = partial[idx+1:]
+			}
+			if err := rc.Flush(); err != nil {
+				return nil
+			}
+		}
+
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeTailBacklog writes the last defaultTailLines lines of the log as SSE
+// events and returns the offset they end at. A missing log yields no events
+// and offset 0.
+func writeTailBacklog(w io.Writer, logFilepath string) int64 {
+	file, err := os.Open(logFilepath)
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0
+	}
+	lines, err := readTailLinesFrom(io.LimitReader(file, info.Size()), defaultTailLines)
+	if err == nil {
+		for _, line := range lines {
+			writeSSEData(w, line)
+		}
+	}
+	return info.Size()
+}
+
+// readLogFrom returns the bytes appended to the file since offset, along with
+// the new offset. If the file is shorter than offset (truncated), reading
+// restarts at zero. A missing file yields (0, nil).
+func readLogFrom(logFilepath string, offset int64) (int64, []byte) {
+	file, err := os.Open(logFilepath)
+	if err != nil {
+		return 0, nil
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return offset, nil
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if info.Size() == offset {
+		return offset, nil
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, nil
+	}
+	data, err := io.ReadAll(io.LimitReader(file, info.Size()-offset))
+	if err != nil {
+		return offset, nil
+	}
+	return offset + int64(len(data)), data
+}
+
+// writeSSEData writes a single SSE data event. Carriage returns are stripped
+// since they would otherwise be interpreted as line terminators by clients.
+func writeSSEData(w io.Writer, line string) {
+	line = strings.ReplaceAll(line, "\r", "")
+	fmt.Fprintf(w, "data: %s\n\n", line)
+}
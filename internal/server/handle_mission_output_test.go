@@ -0,0 +1,146 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestHandleMissionOutput_TailsClaudeOutput(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	mission, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if err := os.MkdirAll(config.GetMissionDirpath(srv.agencDirpath, mission.ID), 0755); err != nil {
+		t.Fatalf("failed to create mission dir: %v", err)
+	}
+	writeLogFile(t, config.GetMissionClaudeOutputLogFilepath(srv.agencDirpath, mission.ID), "hello\nworld\n")
+
+	req := httptest.NewRequest("GET", "/missions/"+mission.ShortID+"/output", nil)
+	req.SetPathValue("id", mission.ShortID)
+	w := httptest.NewRecorder()
+
+	if err := srv.handleMissionOutput(w, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Body.String() != "hello\nworld\n" {
+		t.Errorf("expected %q, got %q", "hello\nworld\n", w.Body.String())
+	}
+}
+
+func TestHandleMissionOutput_InvalidSource(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	mission, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/missions/"+mission.ID+"/output?source=bogus", nil)
+	req.SetPathValue("id", mission.ID)
+	w := httptest.NewRecorder()
+
+	err = srv.handleMissionOutput(w, req)
+	if err == nil {
+		t.Fatal("expected error for invalid source")
+	}
+	if httpStatusFromError(err) != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", httpStatusFromError(err))
+	}
+}
+
+func TestHandleMissionOutput_FollowStreamsAppendedLines(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	mission, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	if err := os.MkdirAll(config.GetMissionDirpath(srv.agencDirpath, mission.ID), 0755); err != nil {
+		t.Fatalf("failed to create mission dir: %v", err)
+	}
+	logFilepath := config.GetMissionWrapperLogFilepath(srv.agencDirpath, mission.ID)
+	writeLogFile(t, logFilepath, "existing\n")
+
+	ts := httptest.NewServer(appHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), func(w http.ResponseWriter, r *http.Request) error {
+		r.SetPathValue("id", mission.ID)
+		return srv.handleMissionOutput(w, r)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", ts.URL+"?source=wrapper&follow=true", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", ct)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	var got []string
+	appended := false
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		got = append(got, data)
+		if !appended {
+			f, err := os.OpenFile(logFilepath, os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				t.Fatalf("failed to open log for append: %v", err)
+			}
+			f.WriteString("appended\n")
+			f.Close()
+			appended = true
+		}
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	if len(got) != 2 || got[0] != "existing" || got[1] != "appended" {
+		t.Errorf("expected [existing appended], got %v", got)
+	}
+}
+
+func TestWriteTailBacklog_OffsetMatchesLinesSent(t *testing.T) {
+	logFilepath := filepath.Join(t.TempDir(), "claude-output.log")
+	writeLogFile(t, logFilepath, "first\nsecond\n")
+
+	var backlog bytes.Buffer
+	offset := writeTailBacklog(&backlog, logFilepath)
+	if backlog.String() != "data: first\n\ndata: second\n\n" {
+		t.Errorf("unexpected backlog %q", backlog.String())
+	}
+
+	// The follow loop picks up exactly what was appended after the backlog
+	f, err := os.OpenFile(logFilepath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open log for append: %v", err)
+	}
+	f.WriteString("third\n")
+	f.Close()
+	if _, chunk := readLogFrom(logFilepath, offset); string(chunk) != "third\n" {
+		t.Errorf("expected only the appended line after the backlog, got %q", chunk)
+	}
+
+	if offset := writeTailBacklog(&backlog, filepath.Join(t.TempDir(), "missing.log")); offset != 0 {
+		t.Errorf("expected offset 0 for a missing log, got %d", offset)
+	}
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// MissionStatsReport is the JSON body a wrapper sends to POST
+// /missions/{id}/stats. Token fields are absolute totals and are omitted when
+// unknown; WallClockSecondsDelta and ClaudeStarted are increments.
+type MissionStatsReport struct {
+	InputTokens           *int64 `json:"input_tokens,omitempty"`
+	OutputTokens          *int64 `json:"output_tokens,omitempty"`
+	CacheReadTokens       *int64 `json:"cache_read_tokens,omitempty"`
+	CacheCreationTokens   *int64 `json:"cache_creation_tokens,omitempty"`
+	WallClockSecondsDelta int64  `json:"wall_clock_seconds_delta,omitempty"`
+	ClaudeStarted         bool   `json:"claude_started,omitempty"`
+}
+
+// MissionStatsResponse is the JSON representation of a mission's resource
+// usage returned by the stats endpoints.
+type MissionStatsResponse struct {
+	MissionID           string    `json:"mission_id"`
+	ShortID             string    `json:"short_id"`
+	GitRepo             string    `json:"git_repo"`
+	Source              *string   `json:"source"`
+	CronName            string    `json:"cron_name,omitempty"`
+	InputTokens         int64     `json:"input_tokens"`
+	OutputTokens        int64     `json:"output_tokens"`
+	CacheReadTokens     int64     `json:"cache_read_tokens"`
+	CacheCreationTokens int64     `json:"cache_creation_tokens"`
+	TotalTokens         int64     `json:"total_tokens"`
+	WallClockSeconds    int64     `json:"wall_clock_seconds"`
+	ClaudeStarts        int64     `json:"claude_starts"`
+	Restarts            int64     `json:"restarts"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+func toMissionStatsResponse(m *database.Mission, stats *database.MissionStats) MissionStatsResponse {
+	resp := MissionStatsResponse{
+		MissionID: m.ID,
+		ShortID:   m.ShortID,
+		GitRepo:   m.GitRepo,
+		Source:    m.Source,
+	}
+	if m.Source != nil && *m.Source == "cron" && m.SourceMetadata != nil {
+		resp.CronName, _ = parseCronSourceMetadata(*m.SourceMetadata)
+	}
+	if stats != nil {
+		resp.InputTokens = stats.InputTokens
+		resp.OutputTokens = stats.OutputTokens
+		resp.CacheReadTokens = stats.CacheReadTokens
+		resp.CacheCreationTokens = stats.CacheCreationTokens
+		resp.TotalTokens = stats.TotalTokens()
+		resp.WallClockSeconds = stats.WallClockSeconds
+		resp.ClaudeStarts = stats.ClaudeStarts
+		resp.Restarts = stats.Restarts()
+		resp.UpdatedAt = stats.UpdatedAt
+	}
+	return resp
+}
+
+// handleRecordMissionStats handles POST /missions/{id}/stats.
+// Called periodically by the wrapper to report usage.
+func (s *Server) handleRecordMissionStats(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	var req MissionStatsReport
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if req.WallClockSecondsDelta < 0 {
+		return newHTTPError(http.StatusBadRequest, "wall_clock_seconds_delta must not be negative")
+	}
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	update := database.MissionStatsUpdate{
+		InputTokens:           req.InputTokens,
+		OutputTokens:          req.OutputTokens,
+		CacheReadTokens:       req.CacheReadTokens,
+		CacheCreationTokens:   req.CacheCreationTokens,
+		WallClockSecondsDelta: req.WallClockSecondsDelta,
+	}
+	if req.ClaudeStarted {
+		update.ClaudeStartsDelta = 1
+	}
+	if err := s.db.RecordMissionStats(resolvedID, update); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to record stats: %s", err.Error())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	return nil
+}
+
+// handleGetMissionStats handles GET /missions/{id}/stats.
+// Missions with no recorded stats return all-zero counters.
+func (s *Server) handleGetMissionStats(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	mission, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if mission == nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	stats, err := s.db.GetMissionStats(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	writeJSON(w, http.StatusOK, toMissionStatsResponse(mission, stats))
+	return nil
+}
+
+// handleListMissionStats handles GET /missions/stats.
+// Returns stats for every mission (including archived) that has reported
+// usage, ordered by total tokens descending.
+func (s *Server) handleListMissionStats(w http.ResponseWriter, r *http.Request) error {
+	allStats, err := s.db.ListMissionStats()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: true})
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	missionsByID := make(map[string]*database.Mission, len(missions))
+	for _, m := range missions {
+		missionsByID[m.ID] = m
+	}
+
+	responses := make([]MissionStatsResponse, 0, len(allStats))
+	for _, stats := range allStats {
+		m, ok := missionsByID[stats.MissionID]
+		if !ok {
+			continue
+		}
+		responses = append(responses, toMissionStatsResponse(m, stats))
+	}
+
+	writeJSON(w, http.StatusOK, responses)
+	return nil
+}
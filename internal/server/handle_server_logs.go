@@ -2,6 +2,7 @@ package server
 
 import (
 	"bufio"
+	"io"
 	"net/http"
 	"os"
 
@@ -82,9 +83,13 @@ func readTailLines(filepath string, n int) ([]string, error) {
 		return nil, err
 	}
 	defer file.Close()
+	return readTailLinesFrom(file, n)
+}
 
+// readTailLinesFrom returns the last n lines read from r.
+func readTailLinesFrom(r io.Reader, n int) ([]string, error) {
 	var lines []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		lines = append(lines, scanner.Text())
 		if len(lines) > n {
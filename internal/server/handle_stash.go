@@ -6,7 +6,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -17,6 +16,7 @@ import (
 
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 // ============================================================================
@@ -221,8 +221,8 @@ func (s *Server) findNonIdleMissions(responses []MissionResponse) []NonIdleMissi
 	var nonIdle []NonIdleMissionInfo
 	for _, resp := range responses {
 		if resp.ClaudeState != nil && *resp.ClaudeState != "idle" {
-			sessionName := ""
-			if activeSession, err := s.db.GetActiveSession(resp.ID); err == nil {
+			sessionName := resp.DisplayName
+			if activeSession, err := s.db.GetActiveSession(resp.ID); err == nil && sessionName == "" {
 				sessionName = resolveSessionTitle(activeSession)
 			}
 			nonIdle = append(nonIdle, NonIdleMissionInfo{
@@ -384,7 +384,7 @@ func (s *Server) ensureTmuxSessionsForRestore(missions []StashMission) {
 	}
 	for sessionName := range neededSessions {
 		if !tmuxSessionExists(sessionName) {
-			createCmd := exec.Command("tmux", "new-session", "-d", "-s", sessionName)
+			createCmd := tmux.Command("new-session", "-d", "-s", sessionName)
 			if output, err := createCmd.CombinedOutput(); err != nil {
 				s.logger.Printf("Warning: failed to create tmux session %s: %v (output: %s)", sessionName, err, string(output))
 			} else {
@@ -463,7 +463,7 @@ func (s *Server) restoreSingleMission(sm StashMission) bool {
 
 	for _, sessionName := range sm.LinkedSessions {
 		if paneID != "" {
-			if err := linkPoolWindowByPane(paneID, sessionName); err != nil {
+			if err := s.linkPoolWindowIntoSession(paneID, sessionName); err != nil {
 				s.logger.Printf("Warning: failed to link mission %s to session %s: %v", shortID, sessionName, err)
 			}
 		}
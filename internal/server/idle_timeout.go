@@ -25,9 +25,9 @@ const (
 	staleHeartbeatThreshold = 30 * time.Second
 )
 
-// runIdleTimeoutLoop periodically scans running missions and stops wrappers
-// that have been idle longer than the timeout threshold. The wrapper is
-// automatically re-spawned on the next attach (lazy start).
+// runIdleTimeoutLoop periodically scans running missions and suspends those
+// that have been idle past their threshold. The wrapper is automatically
+// re-spawned on the next attach (lazy start).
 func (s *Server) runIdleTimeoutLoop(ctx context.Context) {
 	// Initial delay to avoid racing with startup
 	select {
@@ -50,8 +50,25 @@ func (s *Server) runIdleTimeoutLoop(ctx context.Context) {
 	}
 }
 
-// runIdleTimeoutCycle checks all running missions and stops any that have been
-// idle beyond the timeout threshold.
+// idleSuspendThreshold returns how long a mission may be idle before it is
+// suspended, or zero if it never is. Missions not open in any tmux session
+// are suspended after defaultIdleTimeout, or sooner when suspendAfterIdle is
+// shorter; missions a user has open are only suspended under
+// suspendAfterIdle.
+func idleSuspendThreshold(isLinked bool, suspendAfterIdle time.Duration) time.Duration {
+	if isLinked {
+		return suspendAfterIdle
+	}
+	if suspendAfterIdle > 0 && suspendAfterIdle < defaultIdleTimeout {
+		return suspendAfterIdle
+	}
+	return defaultIdleTimeout
+}
+
+// runIdleTimeoutCycle checks all running missions and suspends any that have
+// been idle beyond their threshold: the wrapper is stopped gracefully, and
+// the mission is marked suspended until its next attach resumes the
+// conversation.
 func (s *Server) runIdleTimeoutCycle() {
 	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: false})
 	if err != nil {
@@ -63,32 +80,44 @@ func (s *Server) runIdleTimeoutCycle() {
 	s.reapStalePaneIDs(missions, now)
 
 	linkedPaneIDs := getLinkedPaneIDs(s.getPoolSessionName())
+	suspendAfterIdle := s.getConfig().GetSuspendAfterIdle()
 
 	for _, m := range missions {
 		if !s.isWrapperRunning(m.ID) {
 			continue
 		}
 
+		isLinked := m.TmuxPane != nil && linkedPaneIDs[*m.TmuxPane]
+		threshold := idleSuspendThreshold(isLinked, suspendAfterIdle)
+		if threshold == 0 {
+			continue
+		}
+
 		idleDuration := s.missionIdleDuration(m, now)
-		if idleDuration < defaultIdleTimeout {
+		if idleDuration < threshold {
 			continue
 		}
 
-		// Skip missions whose tmux pane is linked into a user session
-		if m.TmuxPane != nil && linkedPaneIDs[*m.TmuxPane] {
+		// A long-running tool call leaves the conversation log untouched;
+		// don't cut it off
+		if state := s.queryWrapperClaudeState(m.ID); state != nil && *state == "busy" {
 			continue
 		}
 
-		s.logger.Printf("Idle timeout: stopping mission %s (idle for %s)", database.ShortID(m.ID), idleDuration.Round(time.Second))
+		s.logger.Printf("Idle timeout: suspending mission %s (idle for %s)", database.ShortID(m.ID), idleDuration.Round(time.Second))
 		if err := s.stopWrapper(m.ID); err != nil {
 			s.logger.Printf("Idle timeout: failed to stop mission %s: %v", database.ShortID(m.ID), err)
 			continue
 		}
+		if _, err := s.db.MarkMissionSuspended(m.ID); err != nil {
+			s.logger.Printf("Idle timeout: failed to mark mission %s suspended: %v", database.ShortID(m.ID), err)
+		}
 
 		// Also destroy the pool window since the wrapper exited
 		if m.TmuxPane != nil {
 			s.destroyPoolWindow(*m.TmuxPane)
 		}
+		s.wakeMissionQueue()
 	}
 }
 
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleSuspendThreshold(t *testing.T) {
+	tests := []struct {
+		name             string
+		isLinked         bool
+		suspendAfterIdle time.Duration
+		want             time.Duration
+	}{
+		{"unlinked, unset", false, 0, defaultIdleTimeout},
+		{"unlinked, longer setting", false, 4 * time.Hour, defaultIdleTimeout},
+		{"unlinked, shorter setting", false, 10 * time.Minute, 10 * time.Minute},
+		{"linked, unset", true, 0, 0},
+		{"linked, set", true, 4 * time.Hour, 4 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idleSuspendThreshold(tt.isLinked, tt.suspendAfterIdle); got != tt.want {
+				t.Errorf("idleSuspendThreshold(%v, %s) = %s, want %s", tt.isLinked, tt.suspendAfterIdle, got, tt.want)
+			}
+		})
+	}
+}
@@ -32,6 +32,12 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer so http.ResponseController can reach
+// optional interfaces such as http.Flusher (needed for SSE streaming).
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 // appHandlerFunc is an HTTP handler that returns an error. Returning a non-nil
 // error causes the middleware to write the appropriate HTTP error response and
 // log the error message. Handlers should return newHTTPError for known error
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// ApproveMissionRequest is the optional JSON body for
+// POST /missions/{id}/approve.
+type ApproveMissionRequest struct {
+	// Branch is the real branch to fast-forward; empty uses the branch
+	// checked out in the mission's workspace.
+	Branch string `json:"branch"`
+}
+
+// ApproveMissionResponse is the JSON response for POST /missions/{id}/approve.
+type ApproveMissionResponse struct {
+	Branch       string `json:"branch"`
+	ReviewBranch string `json:"review_branch"`
+	Commit       string `json:"commit"`
+}
+
+// handleApproveMission handles POST /missions/{id}/approve. Fast-forwards a
+// reviewRequired mission's branch on origin to the agenc/review/ holding
+// branch its pushes landed on, then deletes the holding branch. Origin
+// rejects the push when the holding branch doesn't build on the real one.
+func (s *Server) handleApproveMission(w http.ResponseWriter, r *http.Request) error {
+	var req ApproveMissionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+		}
+	}
+
+	missionRecord, agentDirpath, err := s.lookupMissionWorkspace(r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	branch := strings.TrimSpace(req.Branch)
+	if branch == "" {
+		branch, err = mission.GetCurrentBranch(agentDirpath)
+		if err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to read mission branch: %s", err.Error())
+		}
+		if branch == "" {
+			return newHTTPErrorf(http.StatusConflict, "mission %s has a detached HEAD; pass the branch to approve", missionRecord.ShortID)
+		}
+	}
+	if err := mission.ValidateBranchName(branch); err != nil {
+		return newHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	commit, err := mission.ApproveReviewBranch(agentDirpath, branch)
+	if err != nil {
+		if commit == "" {
+			return newHTTPErrorf(http.StatusConflict, "failed to approve mission %s: %s", missionRecord.ShortID, err.Error())
+		}
+		// The branch moved; only the holding branch cleanup failed
+		s.logger.Printf("Mission %s: approved '%s' but failed to delete its holding branch: %v", missionRecord.ShortID, branch, err)
+	}
+
+	s.logger.Printf("Mission %s: approved '%s' at %s", missionRecord.ShortID, branch, commit)
+	writeJSON(w, http.StatusOK, ApproveMissionResponse{
+		Branch:       branch,
+		ReviewBranch: mission.ReviewBranchName(branch),
+		Commit:       commit,
+	})
+	return nil
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+func TestHandleApproveMission(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	remoteDirpath := filepath.Join(t.TempDir(), "remote.git")
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@test.com", "-c", "user.name=Test"}, args...)...)
+		cmd.Dir = agentDirpath
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	runGit("init", "-b", "main")
+	runGit("commit", "--allow-empty", "-m", "initial commit")
+	runGit("init", "--bare", remoteDirpath)
+	runGit("remote", "add", "origin", remoteDirpath)
+	runGit("push", "origin", "main")
+	if err := mission.ConfigureReviewPush(agentDirpath, true); err != nil {
+		t.Fatalf("ConfigureReviewPush failed: %v", err)
+	}
+
+	approve := func(body string) (*ApproveMissionResponse, error) {
+		req := httptest.NewRequest("POST", "/missions/"+missionRecord.ShortID+"/approve", strings.NewReader(body))
+		req.SetPathValue("id", missionRecord.ShortID)
+		rec := httptest.NewRecorder()
+		if err := srv.handleApproveMission(rec, req); err != nil {
+			return nil, err
+		}
+		var resp ApproveMissionResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return &resp, nil
+	}
+
+	// Nothing pushed for review yet
+	var httpErr *httpError
+	if _, err := approve(""); !errors.As(err, &httpErr) || httpErr.status != http.StatusConflict {
+		t.Fatalf("expected 409 without a holding branch, got %v", err)
+	}
+
+	runGit("commit", "--allow-empty", "-m", "agent work")
+	head := runGit("rev-parse", "HEAD")
+	runGit("push", "origin", "main")
+
+	resp, err := approve("")
+	if err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	if resp.Branch != "main" || resp.ReviewBranch != "agenc/review/main" || resp.Commit != head {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if got := runGit("--git-dir", remoteDirpath, "rev-parse", "main"); got != head {
+		t.Errorf("expected main fast-forwarded to %s, got %s", head, got)
+	}
+
+	if _, err := approve(`{"branch":"bad..name"}`); !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid branch name, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// Mission batch actions.
+const (
+	MissionBatchActionStop    = "stop"
+	MissionBatchActionArchive = "archive"
+	MissionBatchActionDelete  = "delete"
+)
+
+// MissionBatchFilter selects the missions a batch operation applies to. At
+// least one field must be set; set fields are ANDed together.
+type MissionBatchFilter struct {
+	// All matches every mission the action applies to. Required when no
+	// other filter is given, so an empty filter never means "everything".
+	All bool `json:"all,omitempty"`
+	// Repo matches missions for a repo, given as a canonical name,
+	// owner/repo, or GitHub URL.
+	Repo string `json:"repo,omitempty"`
+	// OlderThan matches missions whose last user prompt (or creation, if
+	// never prompted) is older than a number of days like "7d".
+	OlderThan string `json:"older_than,omitempty"`
+}
+
+// MissionBatchRequest is the JSON body for POST /missions/batch.
+type MissionBatchRequest struct {
+	Action string             `json:"action"`
+	Filter MissionBatchFilter `json:"filter"`
+	// DryRun reports which missions match without changing anything.
+	DryRun bool `json:"dry_run"`
+}
+
+// MissionBatchEntry describes one mission a batch operation acted on (or
+// would act on, in a dry run).
+type MissionBatchEntry struct {
+	MissionID    string    `json:"mission_id"`
+	ShortID      string    `json:"short_id"`
+	GitRepo      string    `json:"git_repo"`
+	SessionName  string    `json:"session_name"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	// Error is set when applying the action failed.
+	Error string `json:"error,omitempty"`
+	// Forbidden is set, along with Error, when the mission belongs to
+	// another user in multi-user mode; the action is not applied to it.
+	Forbidden bool `json:"forbidden,omitempty"`
+}
+
+// MissionBatchResponse is the JSON response for POST /missions/batch.
+type MissionBatchResponse struct {
+	Action  string              `json:"action"`
+	DryRun  bool                `json:"dry_run"`
+	Entries []MissionBatchEntry `json:"entries"`
+}
+
+// missionLastActiveAt returns when the user last prompted a mission, falling
+// back to its creation time. Heartbeats are not used: a running wrapper
+// heartbeats constantly, so they say nothing about whether anyone still
+// cares about the mission.
+func missionLastActiveAt(m *database.Mission) time.Time {
+	if m.LastUserPromptAt != nil && m.LastUserPromptAt.After(m.CreatedAt) {
+		return *m.LastUserPromptAt
+	}
+	return m.CreatedAt
+}
+
+// planMissionBatch returns the missions action applies to that match the
+// filter. Stop only applies to missions with a running wrapper, archive to
+// missions that are not archived yet, and delete to every mission. repoName
+// must already be canonical; olderThan of zero disables the age filter.
+func planMissionBatch(missions []*database.Mission, action string, repoName string, olderThan time.Duration, now time.Time, isRunning func(string) bool) []MissionBatchEntry {
+	entries := []MissionBatchEntry{}
+	for _, m := range missions {
+		switch action {
+		case MissionBatchActionStop:
+			if !isRunning(m.ID) {
+				continue
+			}
+		case MissionBatchActionArchive:
+			if m.Status == "archived" {
+				continue
+			}
+		}
+		if repoName != "" && m.GitRepo != repoName {
+			continue
+		}
+		lastActiveAt := missionLastActiveAt(m)
+		if olderThan > 0 && now.Sub(lastActiveAt) <= olderThan {
+			continue
+		}
+		entries = append(entries, MissionBatchEntry{
+			MissionID:    m.ID,
+			ShortID:      m.ShortID,
+			GitRepo:      m.GitRepo,
+			SessionName:  m.SessionName,
+			LastActiveAt: lastActiveAt,
+		})
+	}
+	return entries
+}
+
+// resolveBatchRepoFilter normalizes a repo filter to a canonical repo name.
+func resolveBatchRepoFilter(repo string) (string, error) {
+	if repo == "" || config.IsCanonicalRepoName(repo) {
+		return repo, nil
+	}
+	repoName, _, err := mission.ParseRepoReference(repo, false, "")
+	if err != nil {
+		return "", err
+	}
+	return repoName, nil
+}
+
+// handleMissionBatch handles POST /missions/batch. Applies a stop, archive,
+// or delete action to every mission matching the filter, or reports the
+// matches when dry_run is set. Failures are recorded on the affected entry
+// rather than aborting the batch. In multi-user mode, missions the requesting
+// user may not access are reported as forbidden and left alone.
+func (s *Server) handleMissionBatch(w http.ResponseWriter, r *http.Request) error {
+	var req MissionBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+
+	switch req.Action {
+	case MissionBatchActionStop, MissionBatchActionArchive, MissionBatchActionDelete:
+	default:
+		return newHTTPErrorf(http.StatusBadRequest, "unknown batch action '%s'; must be %s, %s, or %s",
+			req.Action, MissionBatchActionStop, MissionBatchActionArchive, MissionBatchActionDelete)
+	}
+	if !req.Filter.All && req.Filter.Repo == "" && req.Filter.OlderThan == "" {
+		return newHTTPError(http.StatusBadRequest, "a filter is required; set all, repo, or older_than")
+	}
+
+	repoName, err := resolveBatchRepoFilter(req.Filter.Repo)
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid repo filter: %s", err.Error())
+	}
+	olderThan, err := config.ParseRetentionDays(req.Filter.OlderThan)
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid older_than filter: %s", err.Error())
+	}
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: req.Action == MissionBatchActionDelete})
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to list missions: %s", err.Error())
+	}
+	byID := make(map[string]*database.Mission, len(missions))
+	for _, m := range missions {
+		byID[m.ID] = m
+	}
+
+	resp := MissionBatchResponse{
+		Action:  req.Action,
+		DryRun:  req.DryRun,
+		Entries: planMissionBatch(missions, req.Action, repoName, olderThan, time.Now(), s.isWrapperRunning),
+	}
+	for i := range resp.Entries {
+		entry := &resp.Entries[i]
+		if err := s.checkMissionAccess(r, byID[entry.MissionID]); err != nil {
+			entry.Error = err.Error()
+			entry.Forbidden = true
+		}
+	}
+	if req.DryRun {
+		writeJSON(w, http.StatusOK, resp)
+		return nil
+	}
+
+	for i := range resp.Entries {
+		entry := &resp.Entries[i]
+		if entry.Forbidden {
+			continue
+		}
+		m := byID[entry.MissionID]
+		var err error
+		switch req.Action {
+		case MissionBatchActionStop:
+			err = s.stopMission(m)
+		case MissionBatchActionArchive:
+			err = s.archiveMission(m)
+		case MissionBatchActionDelete:
+			_, err = s.deleteMission(m, s.cleanupRemoteByDefault())
+		}
+		if err != nil {
+			entry.Error = err.Error()
+			s.logger.Printf("Mission batch: failed to %s mission %s: %v", req.Action, entry.ShortID, err)
+		}
+	}
+	s.logger.Printf("Mission batch: %s applied to %d mission(s)", req.Action, len(resp.Entries))
+
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestPlanMissionBatch(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }
+	promptedAt := func(n int) *time.Time { t := daysAgo(n); return &t }
+
+	missions := []*database.Mission{
+		{ID: "old-running", Status: "active", GitRepo: "github.com/owner/a", CreatedAt: daysAgo(30), LastUserPromptAt: promptedAt(10)},
+		{ID: "new-running", Status: "active", GitRepo: "github.com/owner/a", CreatedAt: daysAgo(30), LastUserPromptAt: promptedAt(1)},
+		{ID: "old-stopped", Status: "active", GitRepo: "github.com/owner/b", CreatedAt: daysAgo(20)},
+		{ID: "archived", Status: "archived", GitRepo: "github.com/owner/a", CreatedAt: daysAgo(40)},
+	}
+	isRunning := func(id string) bool { return strings.HasSuffix(id, "-running") }
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		action    string
+		repoName  string
+		olderThan time.Duration
+		want      []string
+	}{
+		{"stop all only touches running", MissionBatchActionStop, "", 0, []string{"old-running", "new-running"}},
+		{"stop older than", MissionBatchActionStop, "", week, []string{"old-running"}},
+		{"archive skips archived", MissionBatchActionArchive, "", 0, []string{"old-running", "new-running", "old-stopped"}},
+		{"archive by repo and age", MissionBatchActionArchive, "github.com/owner/a", week, []string{"old-running"}},
+		{"delete includes archived", MissionBatchActionDelete, "github.com/owner/a", 0, []string{"old-running", "new-running", "archived"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range planMissionBatch(missions, tt.action, tt.repoName, tt.olderThan, now, isRunning) {
+				got = append(got, e.MissionID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHandleMissionBatch_RejectsMissingFilter(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+
+	for _, body := range []string{
+		`{"action":"archive","filter":{}}`,
+		`{"action":"explode","filter":{"all":true}}`,
+		`{"action":"archive","filter":{"older_than":"7 days"}}`,
+	} {
+		req := httptest.NewRequest("POST", "/missions/batch", strings.NewReader(body))
+		if err := srv.handleMissionBatch(httptest.NewRecorder(), req); err == nil {
+			t.Errorf("expected an error for %s", body)
+		}
+	}
+}
+
+func TestHandleMissionBatch_ArchivesByRepo(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	target, err := srv.db.CreateMission("github.com/owner/target", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	other, err := srv.db.CreateMission("github.com/owner/other", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/missions/batch", strings.NewReader(`{"action":"archive","filter":{"repo":"owner/target"}}`))
+	if err := srv.handleMissionBatch(rec, req); err != nil {
+		t.Fatalf("handleMissionBatch failed: %v", err)
+	}
+	var resp MissionBatchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].MissionID != target.ID || resp.Entries[0].Error != "" {
+		t.Fatalf("expected only the target mission to be archived, got %+v", resp.Entries)
+	}
+
+	for id, wantStatus := range map[string]string{target.ID: "archived", other.ID: "active"} {
+		fetched, err := srv.db.GetMission(id)
+		if err != nil || fetched == nil {
+			t.Fatalf("GetMission(%s) failed: %v", id, err)
+		}
+		if fetched.Status != wantStatus {
+			t.Errorf("mission %s: expected status %q, got %q", id, wantStatus, fetched.Status)
+		}
+	}
+}
+
+func TestHandleMissionBatch_SkipsOtherUsersMissions(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MultiUser: &config.MultiUserConfig{TmuxSocket: "/tmp/agenc-shared.sock"},
+	})
+	mine, err := srv.db.CreateMission("", &database.CreateMissionParams{Owner: "4000001"})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	theirs, err := srv.db.CreateMission("", &database.CreateMissionParams{Owner: "4000003"})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		body := fmt.Sprintf(`{"action":"archive","filter":{"all":true},"dry_run":%t}`, dryRun)
+		req := requestFromUID(4000001)
+		req.Body = io.NopCloser(strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		if err := srv.handleMissionBatch(rec, req); err != nil {
+			t.Fatalf("handleMissionBatch failed: %v", err)
+		}
+		var resp MissionBatchResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		forbidden := map[string]bool{}
+		for _, e := range resp.Entries {
+			forbidden[e.MissionID] = e.Forbidden
+		}
+		if len(resp.Entries) != 2 || forbidden[mine.ID] || !forbidden[theirs.ID] {
+			t.Fatalf("dry run %t: expected only the other user's mission reported as forbidden, got %+v", dryRun, resp.Entries)
+		}
+	}
+
+	for id, wantStatus := range map[string]string{mine.ID: "archived", theirs.ID: "active"} {
+		fetched, err := srv.db.GetMission(id)
+		if err != nil || fetched == nil {
+			t.Fatalf("GetMission(%s) failed: %v", id, err)
+		}
+		if fetched.Status != wantStatus {
+			t.Errorf("mission %s: expected status %q, got %q", id, wantStatus, fetched.Status)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// MissionBranchResponse is the JSON response for GET and POST
+// /missions/{id}/branch.
+type MissionBranchResponse struct {
+	MissionID string `json:"mission_id"`
+	ShortID   string `json:"short_id"`
+	// Branch is the branch checked out in the mission's workspace, or empty
+	// when HEAD is detached.
+	Branch string `json:"branch"`
+}
+
+// SetMissionBranchRequest is the JSON body for POST /missions/{id}/branch.
+type SetMissionBranchRequest struct {
+	Branch string `json:"branch"`
+	// Create makes a new branch from the workspace's current HEAD instead of
+	// switching to an existing one.
+	Create bool `json:"create"`
+}
+
+// resolveAutoBranchName returns the branch a new mission in repoName should
+// start on, or "" when the repo does not have autoBranch enabled. An invalid
+// rendered name is logged and ignored so a bad template never blocks mission
+// creation.
+func (s *Server) resolveAutoBranchName(repoName string, missionRecord *database.Mission, prompt string) string {
+	if repoName == "" {
+		return ""
+	}
+	template := s.getConfig().GetAutoBranchTemplate(repoName)
+	if template == "" {
+		return ""
+	}
+
+	branchName := mission.RenderBranchName(template, missionRecord.ID, prompt)
+	if err := mission.ValidateBranchName(branchName); err != nil {
+		s.logger.Printf("Mission create: skipping auto-branch for mission %s: %v", missionRecord.ShortID, err)
+		return ""
+	}
+	return branchName
+}
+
+// lookupMissionWorkspace resolves a mission ID and returns the record along
+// with its agent/ directory. Missions without a git repo have no branch to
+// manage and are rejected.
+func (s *Server) lookupMissionWorkspace(id string) (*database.Mission, string, error) {
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return nil, "", newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	missionRecord, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return nil, "", newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if missionRecord == nil {
+		return nil, "", newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	if missionRecord.GitRepo == "" {
+		return nil, "", newHTTPErrorf(http.StatusBadRequest, "mission %s has no git repo", missionRecord.ShortID)
+	}
+	return missionRecord, config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID), nil
+}
+
+// handleGetMissionBranch handles GET /missions/{id}/branch. Reports the branch
+// currently checked out in the mission's workspace.
+func (s *Server) handleGetMissionBranch(w http.ResponseWriter, r *http.Request) error {
+	missionRecord, agentDirpath, err := s.lookupMissionWorkspace(r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	branch, err := mission.GetCurrentBranch(agentDirpath)
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to read mission branch: %s", err.Error())
+	}
+	writeJSON(w, http.StatusOK, MissionBranchResponse{
+		MissionID: missionRecord.ID,
+		ShortID:   missionRecord.ShortID,
+		Branch:    branch,
+	})
+	return nil
+}
+
+// handleSetMissionBranch handles POST /missions/{id}/branch. Switches the
+// mission's workspace to another branch, creating it from the current HEAD
+// when requested. Uncommitted changes carry over; git refuses the switch if
+// they would be overwritten.
+func (s *Server) handleSetMissionBranch(w http.ResponseWriter, r *http.Request) error {
+	var req SetMissionBranchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	req.Branch = strings.TrimSpace(req.Branch)
+	if req.Branch == "" {
+		return newHTTPError(http.StatusBadRequest, "branch is required")
+	}
+	if err := mission.ValidateBranchName(req.Branch); err != nil {
+		return newHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	missionRecord, agentDirpath, err := s.lookupMissionWorkspace(r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	if err := mission.SwitchBranch(agentDirpath, req.Branch, req.Create); err != nil {
+		return newHTTPErrorf(http.StatusConflict, "failed to switch mission %s to branch '%s': %s", missionRecord.ShortID, req.Branch, err.Error())
+	}
+
+	s.logger.Printf("Switched mission %s to branch '%s'", missionRecord.ShortID, req.Branch)
+	writeJSON(w, http.StatusOK, MissionBranchResponse{
+		MissionID: missionRecord.ID,
+		ShortID:   missionRecord.ShortID,
+		Branch:    req.Branch,
+	})
+	return nil
+}
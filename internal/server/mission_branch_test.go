@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestMissionBranch_GetAndSet(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = agentDirpath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+	}
+
+	getBranch := func() string {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/missions/"+missionRecord.ShortID+"/branch", nil)
+		req.SetPathValue("id", missionRecord.ShortID)
+		rec := httptest.NewRecorder()
+		if err := srv.handleGetMissionBranch(rec, req); err != nil {
+			t.Fatalf("handleGetMissionBranch failed: %v", err)
+		}
+		var resp MissionBranchResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp.Branch
+	}
+	setBranch := func(body string) error {
+		req := httptest.NewRequest("POST", "/missions/"+missionRecord.ShortID+"/branch", strings.NewReader(body))
+		req.SetPathValue("id", missionRecord.ShortID)
+		return srv.handleSetMissionBranch(httptest.NewRecorder(), req)
+	}
+
+	if got := getBranch(); got != "main" {
+		t.Errorf("expected branch main, got %q", got)
+	}
+
+	if err := setBranch(`{"branch":"agenc/feature","create":true}`); err != nil {
+		t.Fatalf("creating branch failed: %v", err)
+	}
+	if got := getBranch(); got != "agenc/feature" {
+		t.Errorf("expected branch agenc/feature, got %q", got)
+	}
+
+	var httpErr *httpError
+	if err := setBranch(`{"branch":"missing"}`); !errors.As(err, &httpErr) || httpErr.status != http.StatusConflict {
+		t.Errorf("expected 409 switching to a missing branch, got %v", err)
+	}
+	if err := setBranch(`{"branch":"bad..name","create":true}`); !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid branch name, got %v", err)
+	}
+}
+
+func TestMissionBranch_RejectsMissionWithoutRepo(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/missions/"+missionRecord.ID+"/branch", nil)
+	req.SetPathValue("id", missionRecord.ID)
+	err = srv.handleGetMissionBranch(httptest.NewRecorder(), req)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+		t.Errorf("expected 400 for a mission without a repo, got %v", err)
+	}
+}
+
+func TestResolveAutoBranchName(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		RepoConfigs: map[string]config.RepoConfig{
+			"github.com/owner/branchy": {AutoBranch: true},
+			"github.com/owner/plain":   {},
+		},
+	})
+	missionRecord := &database.Mission{ID: "2b4c8f1a-1111-2222-3333-444455556666", ShortID: "2b4c8f1a"}
+
+	if got := srv.resolveAutoBranchName("github.com/owner/branchy", missionRecord, "Fix the login page"); got != "agenc/2b4c8f1a-fix-the-login-page" {
+		t.Errorf("unexpected auto branch name %q", got)
+	}
+	if got := srv.resolveAutoBranchName("github.com/owner/plain", missionRecord, "Fix the login page"); got != "" {
+		t.Errorf("expected no auto branch for repo without autoBranch, got %q", got)
+	}
+	if got := srv.resolveAutoBranchName("", missionRecord, "Fix the login page"); got != "" {
+		t.Errorf("expected no auto branch for a mission without a repo, got %q", got)
+	}
+}
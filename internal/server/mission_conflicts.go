@@ -0,0 +1,177 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// MissionConflictGroup is a set of active missions working on the same
+// branch of the same repo, in GET /missions/conflicts.
+type MissionConflictGroup struct {
+	Repo     string                   `json:"repo"`
+	Branch   string                   `json:"branch"`
+	Missions []MissionConflictMission `json:"missions"`
+	// Files are the paths modified by more than one of the missions
+	Files []MissionConflictFile `json:"files"`
+}
+
+// MissionConflictMission is one mission in a MissionConflictGroup.
+type MissionConflictMission struct {
+	MissionID string `json:"mission_id"`
+	ShortID   string `json:"short_id"`
+	Title     string `json:"title,omitempty"`
+	// ModifiedFiles is how many files the mission has changed, overlapping
+	// or not
+	ModifiedFiles int `json:"modified_files"`
+	// Error is why the mission's changes couldn't be read
+	Error string `json:"error,omitempty"`
+}
+
+// MissionConflictFile is a path modified by more than one mission of a
+// MissionConflictGroup.
+type MissionConflictFile struct {
+	Path string `json:"path"`
+	// Missions are the short IDs of the missions modifying the path
+	Missions []string `json:"missions"`
+}
+
+// missionBranchState is the branch an active mission has checked out.
+type missionBranchState struct {
+	mission *database.Mission
+	branch  string
+}
+
+// handleListMissionConflicts handles GET /missions/conflicts: active
+// missions sharing a repo and branch, with the files more than one of them
+// is modifying. The optional repo query parameter restricts it to one repo.
+func (s *Server) handleListMissionConflicts(w http.ResponseWriter, r *http.Request) error {
+	repoFilter, err := resolveBatchRepoFilter(r.URL.Query().Get("repo"))
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	states, err := s.listMissionBranchStates(repoFilter)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	groups := buildMissionConflicts(states, func(m *database.Mission) ([]string, error) {
+		s.enrichMissionWithSessionTitle(m)
+		return mission.ListModifiedFiles(config.GetMissionAgentDirpath(s.agencDirpath, m.ID))
+	})
+	writeJSON(w, http.StatusOK, groups)
+	return nil
+}
+
+// listMissionBranchStates returns the branch checked out by each active,
+// single-repo mission (optionally only those on repoFilter). Missions with a
+// detached HEAD or an unreadable checkout share no branch and are left out.
+func (s *Server) listMissionBranchStates(repoFilter string) ([]*missionBranchState, error) {
+	missions, err := s.db.ListMissions(database.ListMissionsParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	var states []*missionBranchState
+	for _, m := range missions {
+		if m.GitRepo == "" || len(m.GitRepos) > 0 || (repoFilter != "" && m.GitRepo != repoFilter) {
+			continue
+		}
+		branch, err := mission.GetCurrentBranch(config.GetMissionAgentDirpath(s.agencDirpath, m.ID))
+		if err != nil || branch == "" {
+			continue
+		}
+		states = append(states, &missionBranchState{mission: m, branch: branch})
+	}
+	return states, nil
+}
+
+// branchConflicts returns the short IDs of the other active missions on the
+// same repo and branch as missionRecord, for warning when it is created.
+// Best-effort: returns nothing when the branch can't be read.
+func (s *Server) branchConflicts(missionRecord *database.Mission) []string {
+	if missionRecord.GitRepo == "" || len(missionRecord.GitRepos) > 0 {
+		return nil
+	}
+	branch, err := mission.GetCurrentBranch(config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID))
+	if err != nil || branch == "" {
+		return nil
+	}
+	states, err := s.listMissionBranchStates(missionRecord.GitRepo)
+	if err != nil {
+		s.logger.Printf("Mission create: failed to check missions sharing a branch with %s: %v", missionRecord.ShortID, err)
+		return nil
+	}
+
+	var conflicts []string
+	for _, state := range states {
+		if state.mission.ID != missionRecord.ID && state.branch == branch {
+			conflicts = append(conflicts, state.mission.ShortID)
+		}
+	}
+	if len(conflicts) > 0 {
+		s.logger.Printf("Mission create: mission %s shares branch '%s' of %s with %v", missionRecord.ShortID, branch, missionRecord.GitRepo, conflicts)
+	}
+	return conflicts
+}
+
+// buildMissionConflicts groups missions by repo and branch and, for each
+// group of two or more, finds the files more than one of them modifies;
+// modifiedFiles lists a mission's changed files and is only called for
+// missions in such a group. Groups are sorted by repo then branch.
+func buildMissionConflicts(states []*missionBranchState, modifiedFiles func(m *database.Mission) ([]string, error)) []MissionConflictGroup {
+	type groupKey struct{ repo, branch string }
+	grouped := make(map[groupKey][]*missionBranchState)
+	for _, state := range states {
+		key := groupKey{state.mission.GitRepo, state.branch}
+		grouped[key] = append(grouped[key], state)
+	}
+
+	groups := []MissionConflictGroup{}
+	for key, members := range grouped {
+		if len(members) < 2 {
+			continue
+		}
+		group := MissionConflictGroup{Repo: key.repo, Branch: key.branch, Files: []MissionConflictFile{}}
+		modifiedBy := make(map[string][]string)
+		for _, state := range members {
+			files, err := modifiedFiles(state.mission)
+			member := MissionConflictMission{
+				MissionID:     state.mission.ID,
+				ShortID:       state.mission.ShortID,
+				Title:         state.mission.ResolvedSessionTitle,
+				ModifiedFiles: len(files),
+			}
+			if err != nil {
+				member.Error = err.Error()
+			}
+			group.Missions = append(group.Missions, member)
+			for _, path := range files {
+				modifiedBy[path] = append(modifiedBy[path], state.mission.ShortID)
+			}
+		}
+		for path, shortIDs := range modifiedBy {
+			if len(shortIDs) > 1 {
+				sort.Strings(shortIDs)
+				group.Files = append(group.Files, MissionConflictFile{Path: path, Missions: shortIDs})
+			}
+		}
+		sort.Slice(group.Missions, func(i, j int) bool {
+			return group.Missions[i].ShortID < group.Missions[j].ShortID
+		})
+		sort.Slice(group.Files, func(i, j int) bool {
+			return group.Files[i].Path < group.Files[j].Path
+		})
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Repo != groups[j].Repo {
+			return groups[i].Repo < groups[j].Repo
+		}
+		return groups[i].Branch < groups[j].Branch
+	})
+	return groups
+}
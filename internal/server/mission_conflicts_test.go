@@ -0,0 +1,65 @@
+package server
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestBuildMissionConflicts(t *testing.T) {
+	newState := func(shortID string, repo string, branch string) *missionBranchState {
+		return &missionBranchState{
+			mission: &database.Mission{ID: shortID + "-id", ShortID: shortID, GitRepo: repo},
+			branch:  branch,
+		}
+	}
+	states := []*missionBranchState{
+		newState("bbb", "github.com/owner/app", "main"),
+		newState("aaa", "github.com/owner/app", "main"),
+		newState("ccc", "github.com/owner/app", "main"),
+		newState("ddd", "github.com/owner/app", "feature"),
+		newState("eee", "github.com/owner/lib", "main"),
+	}
+	modified := map[string][]string{
+		"aaa": {"README.md", "cmd/main.go"},
+		"bbb": {"cmd/main.go", "go.mod"},
+		"ddd": {"README.md"},
+		"eee": {"README.md"},
+	}
+	var scanned []string
+	groups := buildMissionConflicts(states, func(m *database.Mission) ([]string, error) {
+		scanned = append(scanned, m.ShortID)
+		if m.ShortID == "ccc" {
+			return nil, errors.New("not a git repository")
+		}
+		return modified[m.ShortID], nil
+	})
+
+	if len(groups) != 1 {
+		t.Fatalf("expected only the shared branch grouped, got %+v", groups)
+	}
+	if len(scanned) != 3 {
+		t.Errorf("expected only missions sharing a branch scanned, got %v", scanned)
+	}
+
+	group := groups[0]
+	if group.Repo != "github.com/owner/app" || group.Branch != "main" {
+		t.Errorf("unexpected group %s (%s)", group.Repo, group.Branch)
+	}
+	var shortIDs []string
+	for _, m := range group.Missions {
+		shortIDs = append(shortIDs, m.ShortID)
+	}
+	if !reflect.DeepEqual(shortIDs, []string{"aaa", "bbb", "ccc"}) {
+		t.Errorf("expected missions sorted by short ID, got %v", shortIDs)
+	}
+	if group.Missions[1].ModifiedFiles != 2 || group.Missions[2].Error == "" {
+		t.Errorf("unexpected missions %+v", group.Missions)
+	}
+	want := []MissionConflictFile{{Path: "cmd/main.go", Missions: []string{"aaa", "bbb"}}}
+	if !reflect.DeepEqual(group.Files, want) {
+		t.Errorf("expected only the file both missions modify, got %+v", group.Files)
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// MissionDiffResponse is the JSON response for GET /missions/{id}/diff.
+type MissionDiffResponse struct {
+	MissionID string `json:"mission_id"`
+	ShortID   string `json:"short_id"`
+	// BaseRef is the branch the workspace is compared against (e.g.
+	// "origin/main"), or empty when the workspace has no origin.
+	BaseRef string `json:"base_ref"`
+	// BaseCommit is where the workspace diverged from BaseRef (HEAD when
+	// there is no BaseRef); empty when the workspace has no commits.
+	BaseCommit string `json:"base_commit"`
+	Status     string `json:"status"`
+	Stat       string `json:"stat"`
+	// Patch is only included when requested with ?patch=true.
+	Patch string `json:"patch,omitempty"`
+}
+
+// handleGetMissionDiff handles GET /missions/{id}/diff. Reports the mission
+// workspace's git status and its diff against the commit the mission started
+// from, so the work can be reviewed without attaching. The full patch is
+// included with ?patch=true.
+func (s *Server) handleGetMissionDiff(w http.ResponseWriter, r *http.Request) error {
+	missionRecord, agentDirpath, err := s.lookupMissionWorkspace(r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	diff, err := mission.GetWorkspaceDiff(agentDirpath, r.URL.Query().Get("patch") == "true")
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to diff mission %s: %s", missionRecord.ShortID, err.Error())
+	}
+	writeJSON(w, http.StatusOK, MissionDiffResponse{
+		MissionID:  missionRecord.ID,
+		ShortID:    missionRecord.ShortID,
+		BaseRef:    diff.BaseRef,
+		BaseCommit: diff.BaseCommit,
+		Status:     diff.Status,
+		Stat:       diff.Stat,
+		Patch:      diff.Patch,
+	})
+	return nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestHandleGetMissionDiff(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = agentDirpath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "notes.txt"), []byte("work\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	getDiff := func(query string) MissionDiffResponse {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/missions/"+missionRecord.ShortID+"/diff"+query, nil)
+		req.SetPathValue("id", missionRecord.ShortID)
+		rec := httptest.NewRecorder()
+		if err := srv.handleGetMissionDiff(rec, req); err != nil {
+			t.Fatalf("handleGetMissionDiff failed: %v", err)
+		}
+		var resp MissionDiffResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	resp := getDiff("")
+	if !strings.Contains(resp.Status, "?? notes.txt") || resp.BaseCommit == "" || resp.Patch != "" {
+		t.Errorf("expected the untracked file in status, a base commit, and no patch; got %+v", resp)
+	}
+
+	// Tracked changes show up in the patch once requested
+	cmd := exec.Command("git", "add", "notes.txt")
+	cmd.Dir = agentDirpath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git add failed: %s: %v", output, err)
+	}
+	if resp := getDiff("?patch=true"); !strings.Contains(resp.Patch, "+work") {
+		t.Errorf("expected the staged change in the patch, got %q", resp.Patch)
+	}
+}
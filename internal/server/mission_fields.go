@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// missionResponseFields maps the JSON field names of MissionResponse, the
+// names GET /missions accepts in its 'fields' parameter, to field indices.
+var missionResponseFields = jsonFieldIndices(reflect.TypeOf(MissionResponse{}))
+
+// missionFieldsFromWrapper are the MissionResponse fields filled in by
+// enrichMissionResponse, which queries each mission's wrapper.
+var missionFieldsFromWrapper = []string{"claude_state", "is_adjutant", "queue_position", "wrapper_health"}
+
+// jsonFieldIndices maps the JSON names of a struct type's exported fields to
+// their indices.
+func jsonFieldIndices(t reflect.Type) map[string]int {
+	indices := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		indices[name] = i
+	}
+	return indices
+}
+
+// parseMissionFields validates a comma-separated 'fields' parameter. Returns
+// nil for an empty parameter, meaning every field.
+func parseMissionFields(param string) ([]string, error) {
+	if param == "" {
+		return nil, nil
+	}
+	var fields []string
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := missionResponseFields[field]; !ok {
+			return nil, newHTTPErrorf(http.StatusBadRequest, "invalid 'fields' parameter: unknown field '%s'", field)
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// selectsAnyField reports whether a 'fields' selection includes any of
+// candidates. A nil selection means every field.
+func selectsAnyField(fields []string, candidates ...string) bool {
+	if fields == nil {
+		return true
+	}
+	for _, candidate := range candidates {
+		if slices.Contains(fields, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectMissionFields projects mission responses onto the given fields.
+// Selected fields are always present, including those MissionResponse omits
+// when empty.
+func selectMissionFields(responses []MissionResponse, fields []string) []map[string]any {
+	projected := make([]map[string]any, 0, len(responses))
+	for _, resp := range responses {
+		value := reflect.ValueOf(resp)
+		row := make(map[string]any, len(fields))
+		for _, field := range fields {
+			row[field] = value.Field(missionResponseFields[field]).Interface()
+		}
+		projected = append(projected, row)
+	}
+	return projected
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseMissionFields(t *testing.T) {
+	fields, err := parseMissionFields("")
+	if err != nil || fields != nil {
+		t.Errorf("expected no selection for an empty param, got %v, %v", fields, err)
+	}
+
+	fields, err = parseMissionFields("id, status,id")
+	if err != nil {
+		t.Fatalf("parseMissionFields failed: %v", err)
+	}
+	if len(fields) != 2 || fields[0] != "id" || fields[1] != "status" {
+		t.Errorf("expected [id status], got %v", fields)
+	}
+
+	if _, err := parseMissionFields("id,bogus"); err == nil || err.(*httpError).status != http.StatusBadRequest {
+		t.Errorf("expected a bad request for an unknown field, got %v", err)
+	}
+}
+
+func TestSelectMissionFields(t *testing.T) {
+	responses := []MissionResponse{{ID: "abc", Status: "active"}}
+	selected := selectMissionFields(responses, []string{"id", "project"})
+	if len(selected) != 1 || len(selected[0]) != 2 {
+		t.Fatalf("expected one mission with two fields, got %v", selected)
+	}
+	if selected[0]["id"] != "abc" {
+		t.Errorf("expected id 'abc', got %v", selected[0]["id"])
+	}
+	// Selected fields are present even when empty
+	if v, ok := selected[0]["project"]; !ok || v != "" {
+		t.Errorf("expected an empty project to be present, got %v (present=%v)", v, ok)
+	}
+}
+
+func TestHandleListMissions_Pagination(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	for i := 0; i < 3; i++ {
+		if _, err := srv.db.CreateMission("github.com/owner/repo", nil); err != nil {
+			t.Fatalf("failed to create mission: %v", err)
+		}
+	}
+
+	list := func(query string) ([]map[string]any, *httptest.ResponseRecorder, error) {
+		w := httptest.NewRecorder()
+		err := srv.handleListMissions(w, httptest.NewRequest(http.MethodGet, "/missions?"+query, nil))
+		if err != nil {
+			return nil, w, err
+		}
+		var page []map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("failed to decode missions: %v", err)
+		}
+		return page, w, nil
+	}
+
+	first, w, err := list("limit=2&fields=id")
+	if err != nil {
+		t.Fatalf("handleListMissions failed: %v", err)
+	}
+	if len(first) != 2 || len(first[0]) != 1 {
+		t.Fatalf("expected 2 missions with only 'id', got %v", first)
+	}
+	cursor := w.Header().Get(missionNextCursorHeader)
+	if cursor != first[1]["id"] {
+		t.Errorf("expected next cursor %v, got %q", first[1]["id"], cursor)
+	}
+
+	second, w, err := list("limit=2&fields=id&cursor=" + cursor)
+	if err != nil {
+		t.Fatalf("handleListMissions failed: %v", err)
+	}
+	if len(second) != 1 {
+		t.Fatalf("expected 1 mission on the last page, got %d", len(second))
+	}
+	if second[0]["id"] == first[0]["id"] || second[0]["id"] == first[1]["id"] {
+		t.Errorf("expected the last page not to repeat missions, got %v", second[0]["id"])
+	}
+	if next := w.Header().Get(missionNextCursorHeader); next != "" {
+		t.Errorf("expected no next cursor on the last page, got %q", next)
+	}
+
+	for _, query := range []string{"limit=-1", "offset=x", "sort=name", "cursor=" + cursor + "&offset=1", "cursor=nope"} {
+		if _, _, err := list(query); err == nil || err.(*httpError).status != http.StatusBadRequest {
+			t.Errorf("expected a bad request for %q, got %v", query, err)
+		}
+	}
+}
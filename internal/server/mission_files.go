@@ -0,0 +1,153 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// MissionFilesResponse is the JSON response for GET /missions/{id}/files.
+type MissionFilesResponse struct {
+	MissionID string `json:"mission_id"`
+	ShortID   string `json:"short_id"`
+	Status    string `json:"status"`
+	// Path is the listed path relative to the agent directory ("." for the
+	// directory itself)
+	Path string `json:"path"`
+	// Type is Path's entry type: "dir", or "file"/"symlink" when Path names
+	// a single entry, in which case Entries holds just that entry
+	Type    string                  `json:"type"`
+	Entries []mission.SnapshotEntry `json:"entries"`
+}
+
+// handleListMissionFiles handles GET /missions/{id}/files. Lists the
+// mission's agent directory (or the subdirectory named by ?path=) without
+// spawning or unarchiving the mission; ?recursive=true walks the whole tree
+// below it, leaving .git directories unexpanded.
+func (s *Server) handleListMissionFiles(w http.ResponseWriter, r *http.Request) error {
+	missionRecord, snapshot, relpath, err := s.openMissionSnapshotPath(r)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+
+	entry, err := mission.StatSnapshotEntry(snapshot, relpath)
+	if err != nil {
+		return snapshotPathError(missionRecord, relpath, err)
+	}
+	resp := MissionFilesResponse{
+		MissionID: missionRecord.ID,
+		ShortID:   missionRecord.ShortID,
+		Status:    missionRecord.Status,
+		Path:      relpath,
+		Type:      entry.Type,
+		Entries:   []mission.SnapshotEntry{entry},
+	}
+	if entry.Type == mission.SnapshotEntryDir {
+		resp.Entries, err = mission.ListSnapshotDir(snapshot, relpath, r.URL.Query().Get("recursive") == "true")
+		if err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to list '%s' in mission %s: %#s", relpath, missionRecord.ShortID, err)
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
+
+// handleGetMissionFileContent handles GET /missions/{id}/files/content.
+// Streams the raw contents of the file named by ?path= in the mission's
+// agent directory.
+func (s *Server) handleGetMissionFileContent(w http.ResponseWriter, r *http.Request) error {
+	missionRecord, snapshot, relpath, err := s.openMissionSnapshotPath(r)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+
+	file, err := snapshot.Open(relpath)
+	if err != nil {
+		return snapshotPathError(missionRecord, relpath, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to stat '%s' in mission %s: %v", relpath, missionRecord.ShortID, err)
+	}
+	if info.IsDir() {
+		return newHTTPErrorf(http.StatusBadRequest, "'%s' is a directory; list it with GET /missions/%s/files", relpath, missionRecord.ShortID)
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, file) // client disconnects are not actionable
+	return nil
+}
+
+// openMissionSnapshotPath resolves the request's mission, opens a read-only
+// snapshot of its agent directory, and cleans the ?path= query parameter.
+// The caller must close the snapshot.
+func (s *Server) openMissionSnapshotPath(r *http.Request) (*database.Mission, mission.Snapshot, string, error) {
+	id := r.PathValue("id")
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return nil, nil, "", newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	missionRecord, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return nil, nil, "", newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if missionRecord == nil {
+		return nil, nil, "", newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	relpath, err := mission.CleanSnapshotPath(r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, nil, "", newHTTPErrorf(http.StatusBadRequest, "%#s", err)
+	}
+	snapshot, err := s.openMissionSnapshot(missionRecord)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return missionRecord, snapshot, relpath, nil
+}
+
+// openMissionSnapshot opens a read-only snapshot of a mission's agent
+// directory, whatever the mission's status. This is where a mission whose
+// directory has been moved to cold storage would be served from its archive.
+func (s *Server) openMissionSnapshot(missionRecord *database.Mission) (mission.Snapshot, error) {
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+	if _, err := os.Stat(agentDirpath); os.IsNotExist(err) {
+		return nil, newHTTPErrorf(http.StatusNotFound, "mission %s has no agent directory on disk", missionRecord.ShortID)
+	}
+	snapshot, err := mission.OpenDirSnapshot(agentDirpath)
+	if err != nil {
+		return nil, newHTTPErrorf(http.StatusInternalServerError, "failed to open mission %s: %#s", missionRecord.ShortID, err)
+	}
+	return snapshot, nil
+}
+
+// snapshotPathError maps a failure to read relpath from a mission snapshot to
+// an HTTP error: 404 for a missing path, 400 for any other problem with the
+// path itself (e.g. a symlink out of the directory, which os.Root refuses),
+// 500 otherwise.
+func snapshotPathError(missionRecord *database.Mission, relpath string, err error) error {
+	err = stacktrace.RootCause(err)
+	var pathErr *fs.PathError
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return newHTTPErrorf(http.StatusNotFound, "'%s' does not exist in mission %s", relpath, missionRecord.ShortID)
+	case errors.As(err, &pathErr):
+		return newHTTPErrorf(http.StatusBadRequest, "cannot read '%s' in mission %s: %v", relpath, missionRecord.ShortID, err)
+	default:
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to read '%s' in mission %s: %v", relpath, missionRecord.ShortID, err)
+	}
+}
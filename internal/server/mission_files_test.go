@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+func TestHandleMissionFiles_ArchivedMission(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	if err := os.MkdirAll(filepath.Join(agentDirpath, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "docs", "plan.md"), []byte("the plan\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(srv.agencDirpath, filepath.Join(agentDirpath, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.db.ArchiveMission(missionRecord.ID); err != nil {
+		t.Fatalf("ArchiveMission failed: %v", err)
+	}
+
+	request := func(handler appHandlerFunc, query string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest("GET", "/missions/"+missionRecord.ShortID+"/files"+query, nil)
+		req.SetPathValue("id", missionRecord.ShortID)
+		rec := httptest.NewRecorder()
+		return rec, handler(rec, req)
+	}
+	list := func(query string) MissionFilesResponse {
+		t.Helper()
+		rec, err := request(srv.handleListMissionFiles, query)
+		if err != nil {
+			t.Fatalf("handleListMissionFiles(%q) failed: %v", query, err)
+		}
+		var resp MissionFilesResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	root := list("")
+	if root.Status != "archived" || root.Type != mission.SnapshotEntryDir || len(root.Entries) != 2 {
+		t.Fatalf("expected the archived mission's two top-level entries, got %+v", root)
+	}
+	if root.Entries[0].Path != "docs" || root.Entries[1].Type != mission.SnapshotEntrySymlink {
+		t.Errorf("expected docs and the escape symlink, got %+v", root.Entries)
+	}
+
+	recursive := list("?recursive=true")
+	if len(recursive.Entries) != 3 || recursive.Entries[1].Path != "docs/plan.md" {
+		t.Errorf("expected the recursive listing to include docs/plan.md, got %+v", recursive.Entries)
+	}
+
+	file := list("?path=/docs/plan.md")
+	if file.Type != mission.SnapshotEntryFile || len(file.Entries) != 1 || file.Entries[0].Size != int64(len("the plan\n")) {
+		t.Errorf("expected a single file entry for a file path, got %+v", file)
+	}
+
+	rec, err := request(srv.handleGetMissionFileContent, "/content?path=docs/plan.md")
+	if err != nil {
+		t.Fatalf("handleGetMissionFileContent failed: %v", err)
+	}
+	if rec.Body.String() != "the plan\n" {
+		t.Errorf("expected the file's contents, got %q", rec.Body.String())
+	}
+
+	for _, query := range []string{"?path=../../config.yml", "?path=escape/config/config.yml", "?path=missing.txt"} {
+		_, err := request(srv.handleGetMissionFileContent, "/content"+query)
+		var httpErr *httpError
+		if !errors.As(err, &httpErr) || httpErr.status == http.StatusOK || httpErr.status == http.StatusInternalServerError {
+			t.Errorf("%s: expected a client error, got %v", query, err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// missionGCInitialDelay is how long after startup the first retention
+	// pass runs, so it does not compete with startup reconciliation.
+	missionGCInitialDelay = 5 * time.Minute
+
+	// missionGCInterval is how often the retention policy is enforced.
+	missionGCInterval = time.Hour
+)
+
+// Mission GC actions.
+const (
+	missionGCActionArchive = "archive"
+	missionGCActionDelete  = "delete"
+)
+
+// MissionGCRequest is the optional JSON body for POST /missions/gc.
+type MissionGCRequest struct {
+	// DryRun reports what the retention policy would do without changing
+	// anything.
+	DryRun bool `json:"dry_run"`
+}
+
+// MissionGCEntry describes one mission the retention policy acted on (or
+// would act on, in a dry run).
+type MissionGCEntry struct {
+	MissionID   string `json:"mission_id"`
+	ShortID     string `json:"short_id"`
+	Action      string `json:"action"`
+	GitRepo     string `json:"git_repo"`
+	SessionName string `json:"session_name"`
+	// InactiveSince is the last heartbeat (or creation time) for archive
+	// actions, and the time of archiving for delete actions.
+	InactiveSince time.Time `json:"inactive_since"`
+	// Error is set when applying the action failed.
+	Error string `json:"error,omitempty"`
+}
+
+// MissionGCResponse is the JSON response for POST /missions/gc.
+type MissionGCResponse struct {
+	DryRun       bool             `json:"dry_run"`
+	ArchiveAfter string           `json:"archive_after"`
+	DeleteAfter  string           `json:"delete_after"`
+	Entries      []MissionGCEntry `json:"entries"`
+}
+
+// planMissionGC returns the missions the retention policy applies to at now.
+// Active missions whose last heartbeat (or creation time, if they never ran)
+// is older than archiveAfter are archived; archived missions whose updated_at
+// (set when archived) is older than deleteAfter are deleted. A zero threshold
+// disables that half of the policy. Missions with a running wrapper are never
+// touched.
+func planMissionGC(missions []*database.Mission, archiveAfter, deleteAfter time.Duration, now time.Time, isRunning func(string) bool) []MissionGCEntry {
+	entries := []MissionGCEntry{}
+	for _, m := range missions {
+		var action string
+		var inactiveSince time.Time
+		switch {
+		case m.Status == "archived" && deleteAfter > 0:
+			inactiveSince = m.UpdatedAt
+			if now.Sub(inactiveSince) > deleteAfter {
+				action = missionGCActionDelete
+			}
+		case m.Status != "archived" && archiveAfter > 0:
+			inactiveSince = m.CreatedAt
+			if m.LastHeartbeat != nil && m.LastHeartbeat.After(inactiveSince) {
+				inactiveSince = *m.LastHeartbeat
+			}
+			if now.Sub(inactiveSince) > archiveAfter {
+				action = missionGCActionArchive
+			}
+		}
+		if action == "" || isRunning(m.ID) {
+			continue
+		}
+		entries = append(entries, MissionGCEntry{
+			MissionID:     m.ID,
+			ShortID:       m.ShortID,
+			Action:        action,
+			GitRepo:       m.GitRepo,
+			SessionName:   m.SessionName,
+			InactiveSince: inactiveSince,
+		})
+	}
+	return entries
+}
+
+// runMissionGC plans the retention pass and, unless dryRun is set, applies
+// it. Failures are recorded on the affected entry rather than aborting the
+// pass.
+func (s *Server) runMissionGC(cfg *config.AgencConfig, dryRun bool) (*MissionGCResponse, error) {
+	resp := &MissionGCResponse{
+		DryRun:       dryRun,
+		ArchiveAfter: cfg.MissionAutoArchiveAfter,
+		DeleteAfter:  cfg.MissionAutoDeleteAfter,
+		Entries:      []MissionGCEntry{},
+	}
+
+	archiveAfter := cfg.GetMissionAutoArchiveAfter()
+	deleteAfter := cfg.GetMissionAutoDeleteAfter()
+	if archiveAfter == 0 && deleteAfter == 0 {
+		return resp, nil
+	}
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*database.Mission, len(missions))
+	for _, m := range missions {
+		byID[m.ID] = m
+	}
+
+	resp.Entries = planMissionGC(missions, archiveAfter, deleteAfter, time.Now(), s.isWrapperRunning)
+	if dryRun {
+		return resp, nil
+	}
+
+	for i := range resp.Entries {
+		entry := &resp.Entries[i]
+		m := byID[entry.MissionID]
+		var err error
+		switch entry.Action {
+		case missionGCActionArchive:
+			err = s.archiveMission(m)
+		case missionGCActionDelete:
+			_, err = s.deleteMission(m, s.cleanupRemoteByDefault())
+		}
+		if err != nil {
+			entry.Error = err.Error()
+			s.logger.Printf("Mission GC: failed to %s mission %s: %v", entry.Action, entry.ShortID, err)
+			continue
+		}
+		s.logger.Printf("Mission GC: %s mission %s (inactive since %s)", entry.Action, entry.ShortID, entry.InactiveSince.Format(time.RFC3339))
+	}
+	return resp, nil
+}
+
+// runMissionGCLoop periodically enforces the mission retention policy
+// configured by missionAutoArchiveAfter and missionAutoDeleteAfter.
+func (s *Server) runMissionGCLoop(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(missionGCInitialDelay):
+		s.runMissionGCCycle()
+	}
+
+	ticker := time.NewTicker(missionGCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runMissionGCCycle()
+		}
+	}
+}
+
+// runMissionGCCycle runs one retention pass, skipping it while a stash
+// operation is in progress so stashed missions are not archived out from
+// under the pop.
+func (s *Server) runMissionGCCycle() {
+	if s.stashInProgress.Load() {
+		return
+	}
+	if _, err := s.runMissionGC(s.getConfig(), false); err != nil {
+		s.logger.Printf("Mission GC: failed to list missions: %v", err)
+	}
+}
+
+// handleMissionGC handles POST /missions/gc. Runs the retention policy
+// immediately, or reports what it would do when dry_run is set.
+func (s *Server) handleMissionGC(w http.ResponseWriter, r *http.Request) error {
+	var req MissionGCRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+		}
+	}
+
+	resp, err := s.runMissionGC(s.getConfig(), req.DryRun)
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to run mission GC: %s", err.Error())
+	}
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
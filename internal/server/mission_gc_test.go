@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestPlanMissionGC(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }
+	heartbeat := func(n int) *time.Time { t := daysAgo(n); return &t }
+
+	missions := []*database.Mission{
+		{ID: "stale", ShortID: "stale", Status: "active", CreatedAt: daysAgo(60), LastHeartbeat: heartbeat(40)},
+		{ID: "fresh", ShortID: "fresh", Status: "active", CreatedAt: daysAgo(60), LastHeartbeat: heartbeat(2)},
+		{ID: "never-ran", ShortID: "never-ran", Status: "active", CreatedAt: daysAgo(45)},
+		{ID: "running", ShortID: "running", Status: "active", CreatedAt: daysAgo(60), LastHeartbeat: heartbeat(40)},
+		{ID: "old-archive", ShortID: "old-archive", Status: "archived", CreatedAt: daysAgo(200), UpdatedAt: daysAgo(100)},
+		{ID: "new-archive", ShortID: "new-archive", Status: "archived", CreatedAt: daysAgo(200), UpdatedAt: daysAgo(10)},
+	}
+	isRunning := func(id string) bool { return id == "running" }
+
+	got := map[string]string{}
+	for _, e := range planMissionGC(missions, 30*24*time.Hour, 90*24*time.Hour, now, isRunning) {
+		got[e.MissionID] = e.Action
+	}
+	want := map[string]string{
+		"stale":       missionGCActionArchive,
+		"never-ran":   missionGCActionArchive,
+		"old-archive": missionGCActionDelete,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for id, action := range want {
+		if got[id] != action {
+			t.Errorf("mission %s: expected %q, got %q", id, action, got[id])
+		}
+	}
+
+	// A zero threshold disables that half of the policy
+	for _, e := range planMissionGC(missions, 0, 90*24*time.Hour, now, isRunning) {
+		if e.Action == missionGCActionArchive {
+			t.Errorf("archive disabled but mission %s planned for archive", e.MissionID)
+		}
+	}
+	if entries := planMissionGC(missions, 0, 0, now, isRunning); len(entries) != 0 {
+		t.Errorf("expected no entries with policy disabled, got %d", len(entries))
+	}
+}
+
+func TestHandleMissionGC_DryRunLeavesMissionsAlone(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{MissionAutoArchiveAfter: "1d", MissionAutoDeleteAfter: "1d"})
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/missions/gc", strings.NewReader(`{"dry_run":true}`))
+	if err := srv.handleMissionGC(rec, req); err != nil {
+		t.Fatalf("handleMissionGC failed: %v", err)
+	}
+	var resp MissionGCResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.DryRun || resp.ArchiveAfter != "1d" || len(resp.Entries) != 0 {
+		t.Errorf("unexpected response for a brand-new mission: %+v", resp)
+	}
+
+	fetched, err := srv.db.GetMission(missionRecord.ID)
+	if err != nil || fetched == nil || fetched.Status != "active" {
+		t.Fatalf("mission should be untouched, got %+v, %v", fetched, err)
+	}
+}
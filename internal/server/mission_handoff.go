@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// MissionHandoffResponse is the response for GET /missions/{id}/handoff. Note
+// is empty when the mission has no handoff note.
+type MissionHandoffResponse struct {
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// recordMissionHandoff stores a handoff note for a mission and writes it into
+// the mission's agent directory as HANDOFF.md. Failing to write the file is
+// logged, not returned: the note is already safe in the database.
+func (s *Server) recordMissionHandoff(missionID string, note string) error {
+	handoff := &database.MissionHandoff{MissionID: missionID, Note: strings.TrimSpace(note)}
+	if err := s.db.CreateMissionHandoff(handoff); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to record handoff note: %s", err.Error())
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionID)
+	if err := mission.WriteHandoffFile(agentDirpath, handoff.Note, handoff.CreatedAt); err != nil {
+		s.logger.Printf("Warning: failed to write %s for mission %s: %v", mission.HandoffFilename, database.ShortID(missionID), err)
+	}
+	return nil
+}
+
+// handleGetMissionHandoff handles GET /missions/{id}/handoff, returning the
+// mission's latest handoff note.
+func (s *Server) handleGetMissionHandoff(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	handoff, err := s.db.GetLatestMissionHandoff(resolvedID)
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to get handoff note: %s", err.Error())
+	}
+
+	var resp MissionHandoffResponse
+	if handoff != nil {
+		resp = MissionHandoffResponse{Note: handoff.Note, CreatedAt: handoff.CreatedAt}
+	}
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
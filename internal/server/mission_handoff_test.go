@@ -0,0 +1,69 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+func TestHandleArchiveMission_RecordsHandoffNote(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	missionRecord, err := srv.db.CreateMission("", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	getHandoff := func() MissionHandoffResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/missions/"+missionRecord.ShortID+"/handoff", nil)
+		req.SetPathValue("id", missionRecord.ShortID)
+		w := httptest.NewRecorder()
+		if err := srv.handleGetMissionHandoff(w, req); err != nil {
+			t.Fatalf("handleGetMissionHandoff failed: %v", err)
+		}
+		var resp MissionHandoffResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+	if got := getHandoff(); got.Note != "" {
+		t.Fatalf("expected no handoff note before archiving, got %+v", got)
+	}
+
+	body := `{"handoff_note":"Parser done; tests still pending.\n"}`
+	req := httptest.NewRequest(http.MethodPost, "/missions/"+missionRecord.ShortID+"/archive", strings.NewReader(body))
+	req.SetPathValue("id", missionRecord.ShortID)
+	if err := srv.handleArchiveMission(httptest.NewRecorder(), req); err != nil {
+		t.Fatalf("handleArchiveMission failed: %v", err)
+	}
+
+	archived, err := srv.db.GetMission(missionRecord.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if archived.Status != "archived" {
+		t.Errorf("expected the mission to be archived, got status %q", archived.Status)
+	}
+	if got := getHandoff(); got.Note != "Parser done; tests still pending." || got.CreatedAt.IsZero() {
+		t.Errorf("expected the trimmed handoff note with its time, got %+v", got)
+	}
+	content, err := os.ReadFile(filepath.Join(agentDirpath, mission.HandoffFilename))
+	if err != nil {
+		t.Fatalf("expected %s in the agent directory: %v", mission.HandoffFilename, err)
+	}
+	if !strings.Contains(string(content), "Parser done; tests still pending.") {
+		t.Errorf("unexpected %s contents:\n%s", mission.HandoffFilename, content)
+	}
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// missionPRTitleMaxLen caps PR titles derived from a mission's session title
+// or prompt.
+const missionPRTitleMaxLen = 72
+
+// MissionPRRequest is the optional JSON body for POST /missions/{id}/pr.
+type MissionPRRequest struct {
+	// Title and Body describe the PR. Title defaults to the mission's session
+	// title or the first line of its prompt.
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// Base is the branch to merge into; empty uses the repo's default branch.
+	Base  string `json:"base"`
+	Draft bool   `json:"draft"`
+	// CommitMessage is used for outstanding changes; defaults to the title.
+	CommitMessage string `json:"commit_message"`
+}
+
+// MissionPRResponse is the JSON response for POST /missions/{id}/pr.
+type MissionPRResponse struct {
+	PRURL  string `json:"pr_url"`
+	Branch string `json:"branch"`
+	// Committed is true when outstanding changes were committed first.
+	Committed bool `json:"committed"`
+	// Created is false when an open PR for the branch already existed.
+	Created bool `json:"created"`
+}
+
+// defaultMissionPRTitle derives a PR title from the mission's session title,
+// falling back to the first line of its prompt. Returns "" when neither is
+// available, in which case gh fills the title from the commits.
+func defaultMissionPRTitle(m *database.Mission) string {
+	for _, candidate := range []string{m.ResolvedSessionTitle, m.SessionName} {
+		if strings.TrimSpace(candidate) != "" {
+			return truncateTitle(candidate, missionPRTitleMaxLen)
+		}
+	}
+	firstLine, _, _ := strings.Cut(strings.TrimSpace(m.Prompt), "\n")
+	if firstLine == "" {
+		return ""
+	}
+	return truncateTitle(firstLine, missionPRTitleMaxLen)
+}
+
+// ensureMissionPRBranch returns the branch to open the PR from. Missions still
+// on the repo's default branch (or the requested base, or a detached HEAD)
+// are first moved to a fresh branch named by the repo's autoBranchTemplate,
+// falling back to the default template.
+func (s *Server) ensureMissionPRBranch(missionRecord *database.Mission, agentDirpath string, base string) (string, error) {
+	branch, err := mission.GetCurrentBranch(agentDirpath)
+	if err != nil {
+		return "", err
+	}
+	defaultBranch, _ := mission.GetDefaultBranch(agentDirpath) // best-effort; origin/HEAD may be unset
+	if branch != "" && branch != defaultBranch && branch != base {
+		return branch, nil
+	}
+
+	template := s.getConfig().GetAutoBranchTemplate(missionRecord.GitRepo)
+	if template == "" {
+		template = config.DefaultAutoBranchTemplate
+	}
+	branch = mission.RenderBranchName(template, missionRecord.ID, missionRecord.Prompt)
+	if err := mission.SwitchBranch(agentDirpath, branch, true); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+// handleMissionPR handles POST /missions/{id}/pr. Commits outstanding changes
+// in the mission's workspace, pushes its branch, and opens a GitHub pull
+// request with gh (or reuses the open one for the branch). The PR URL is
+// recorded on the mission.
+func (s *Server) handleMissionPR(w http.ResponseWriter, r *http.Request) error {
+	var req MissionPRRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+		}
+	}
+
+	missionRecord, agentDirpath, err := s.lookupMissionWorkspace(r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+	s.enrichMissionWithSessionTitle(missionRecord)
+
+	branch, err := s.ensureMissionPRBranch(missionRecord, agentDirpath, req.Base)
+	if err != nil {
+		return newHTTPErrorf(http.StatusConflict, "failed to prepare a branch for mission %s: %s", missionRecord.ShortID, err.Error())
+	}
+
+	title := req.Title
+	if title == "" {
+		title = defaultMissionPRTitle(missionRecord)
+	}
+	body := req.Body
+	if body == "" && title != "" {
+		body = "Opened from AgenC mission `" + missionRecord.ShortID + "`."
+	}
+	commitMessage := req.CommitMessage
+	if commitMessage == "" {
+		commitMessage = title
+	}
+	if commitMessage == "" {
+		commitMessage = "Changes from AgenC mission " + missionRecord.ShortID
+	}
+
+	committed, err := mission.CommitAll(agentDirpath, commitMessage)
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to commit changes: %s", err.Error())
+	}
+	if err := mission.PushBranch(agentDirpath, branch); err != nil {
+		return newHTTPErrorf(http.StatusBadGateway, "failed to push branch: %s", err.Error())
+	}
+
+	resp := MissionPRResponse{Branch: branch, Committed: committed}
+	resp.PRURL, err = mission.FindPullRequest(agentDirpath, branch)
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadGateway, "failed to look up existing pull request: %s", err.Error())
+	}
+	if resp.PRURL == "" {
+		resp.PRURL, err = mission.CreatePullRequest(agentDirpath, mission.PullRequestOptions{
+			Branch: branch,
+			Base:   req.Base,
+			Title:  title,
+			Body:   body,
+			Draft:  req.Draft,
+		})
+		if err != nil {
+			return newHTTPErrorf(http.StatusBadGateway, "failed to open pull request: %s", err.Error())
+		}
+		resp.Created = true
+	}
+
+	if err := s.db.SetMissionPRURL(missionRecord.ID, resp.PRURL); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "pull request %s opened but failed to record it: %s", resp.PRURL, err.Error())
+	}
+
+	s.logger.Printf("Mission %s: pull request %s (branch '%s')", missionRecord.ShortID, resp.PRURL, branch)
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestDefaultMissionPRTitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		mission database.Mission
+		want    string
+	}{
+		{"session title wins", database.Mission{ResolvedSessionTitle: "Fix login redirect", SessionName: "other", Prompt: "prompt"}, "Fix login redirect"},
+		{"session name fallback", database.Mission{SessionName: "Refactor auth", Prompt: "prompt"}, "Refactor auth"},
+		{"first prompt line", database.Mission{Prompt: "  Add dark mode\nwith details below"}, "Add dark mode"},
+		{"nothing available", database.Mission{}, ""},
+	}
+	for _, tt := range tests {
+		if got := defaultMissionPRTitle(&tt.mission); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEnsureMissionPRBranch(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord := &database.Mission{
+		ID:      "2b4c8f1a-1111-2222-3333-444455556666",
+		ShortID: "2b4c8f1a",
+		GitRepo: "github.com/owner/repo",
+		Prompt:  "Fix the login page",
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = agentDirpath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+	}
+
+	// Still on the base branch: a fresh branch is created
+	branch, err := srv.ensureMissionPRBranch(missionRecord, agentDirpath, "main")
+	if err != nil {
+		t.Fatalf("ensureMissionPRBranch failed: %v", err)
+	}
+	if branch != "agenc/2b4c8f1a-fix-the-login-page" {
+		t.Errorf("unexpected branch %q", branch)
+	}
+
+	// Already on a feature branch: it is reused
+	branch, err = srv.ensureMissionPRBranch(missionRecord, agentDirpath, "main")
+	if err != nil {
+		t.Fatalf("ensureMissionPRBranch (second call) failed: %v", err)
+	}
+	if branch != "agenc/2b4c8f1a-fix-the-login-page" {
+		t.Errorf("expected existing branch to be reused, got %q", branch)
+	}
+}
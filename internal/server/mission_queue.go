@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// missionQueueCheckInterval is how often the server re-checks for free
+	// slots. Stops, archives, and deletes through the API wake the queue
+	// immediately; the ticker catches wrappers that exit on their own.
+	missionQueueCheckInterval = 5 * time.Second
+
+	// missionStartGracePeriod is how long a just-started mission counts as
+	// running before its wrapper has written its PID file, so back-to-back
+	// admissions can't overshoot the cap.
+	missionStartGracePeriod = 30 * time.Second
+)
+
+// queuedMission is a created mission waiting for a free slot under
+// missionsMaxConcurrent. The mission record and directory already exist; only
+// the wrapper spawn is deferred.
+type queuedMission struct {
+	missionID string
+	request   CreateMissionRequest
+	queuedAt  time.Time
+}
+
+// missionQueue is the server's FIFO of missions waiting to start. It lives in
+// memory: a server restart drops the queue, leaving its missions created but
+// stopped (start them with 'agenc mission resume'). The zero value is ready
+// to use.
+type missionQueue struct {
+	mu      sync.Mutex
+	entries []queuedMission
+	// startedAt records missions admitted by the queue logic, so they count
+	// against the cap until their wrapper is visibly running.
+	startedAt map[string]time.Time
+}
+
+// QueuedMissionResponse is one entry in GET /missions/queue.
+type QueuedMissionResponse struct {
+	Position  int       `json:"position"`
+	MissionID string    `json:"mission_id"`
+	ShortID   string    `json:"short_id"`
+	GitRepo   string    `json:"git_repo"`
+	Prompt    string    `json:"prompt"`
+	QueuedAt  time.Time `json:"queued_at"`
+}
+
+// position returns the 1-based queue position of missionID, or 0 when it is
+// not queued.
+func (q *missionQueue) position(missionID string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, entry := range q.entries {
+		if entry.missionID == missionID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// remove drops missionID from the queue, if present.
+func (q *missionQueue) remove(missionID string) {
+	q.take(missionID)
+}
+
+// take removes missionID from the queue and returns its entry, reporting
+// whether it was queued.
+func (q *missionQueue) take(missionID string) (queuedMission, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, entry := range q.entries {
+		if entry.missionID == missionID {
+			q.entries = append(q.entries[:i], q.entries[i+1:]...)
+			return entry, true
+		}
+	}
+	return queuedMission{}, false
+}
+
+// snapshot returns a copy of the queued entries in order.
+func (q *missionQueue) snapshot() []queuedMission {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]queuedMission(nil), q.entries...)
+}
+
+// markStartedLocked records that missionID was just admitted. Must be called
+// with mu held.
+func (q *missionQueue) markStartedLocked(missionID string, now time.Time) {
+	if q.startedAt == nil {
+		q.startedAt = make(map[string]time.Time)
+	}
+	q.startedAt[missionID] = now
+}
+
+// isQueueExempt reports whether a create request bypasses the queue: cron
+// missions are headless background work, and headless requests (agenc run)
+// wait on the mission synchronously.
+func isQueueExempt(req CreateMissionRequest) bool {
+	return req.Headless || req.Source == "cron"
+}
+
+// isInteractiveMission reports whether a mission counts against
+// missionsMaxConcurrent.
+func isInteractiveMission(m *database.Mission) bool {
+	return m.Source == nil || *m.Source != "cron"
+}
+
+// countRunningInteractiveMissionsLocked counts interactive missions whose
+// wrapper is running or that were admitted within missionStartGracePeriod.
+// Must be called with s.missionQueue.mu held.
+func (s *Server) countRunningInteractiveMissionsLocked(now time.Time) (int, error) {
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: false})
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range missions {
+		if !isInteractiveMission(m) {
+			continue
+		}
+		if s.isWrapperRunning(m.ID) {
+			delete(s.missionQueue.startedAt, m.ID)
+			count++
+			continue
+		}
+		if startedAt, ok := s.missionQueue.startedAt[m.ID]; ok {
+			if now.Sub(startedAt) < missionStartGracePeriod {
+				count++
+			} else {
+				delete(s.missionQueue.startedAt, m.ID)
+			}
+		}
+	}
+	return count, nil
+}
+
+// startOrQueueMission spawns the wrapper for a newly created mission, or
+// queues it when missionsMaxConcurrent interactive missions are already
+// running (or others are already waiting, to keep the queue FIFO). Returns
+// the mission's 1-based queue position, or 0 when it was started.
+func (s *Server) startOrQueueMission(missionRecord *database.Mission, req CreateMissionRequest) (int, error) {
+	limit := s.getConfig().MissionsMaxConcurrent
+	if limit <= 0 || isQueueExempt(req) {
+		return 0, s.spawnWrapper(missionRecord, req)
+	}
+
+	q := &s.missionQueue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	if len(q.entries) == 0 {
+		running, err := s.countRunningInteractiveMissionsLocked(now)
+		if err != nil {
+			s.logger.Printf("Mission queue: failed to count running missions, starting %s: %v", missionRecord.ShortID, err)
+			return 0, s.spawnWrapper(missionRecord, req)
+		}
+		if running < limit {
+			q.markStartedLocked(missionRecord.ID, now)
+			return 0, s.spawnWrapper(missionRecord, req)
+		}
+	}
+
+	q.entries = append(q.entries, queuedMission{
+		missionID: missionRecord.ID,
+		request:   req,
+		queuedAt:  now,
+	})
+	s.logger.Printf("Mission queue: queued mission %s at position %d (limit %d)", missionRecord.ShortID, len(q.entries), limit)
+	return len(q.entries), nil
+}
+
+// wakeMissionQueue asks the queue loop to check for free slots now. Never
+// blocks.
+func (s *Server) wakeMissionQueue() {
+	select {
+	case s.missionQueueWakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// runMissionQueueLoop starts queued missions as slots free up.
+func (s *Server) runMissionQueueLoop(ctx context.Context) {
+	ticker := time.NewTicker(missionQueueCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.missionQueueWakeCh:
+		}
+		s.drainMissionQueue()
+	}
+}
+
+// drainMissionQueue starts queued missions in order while interactive
+// missions are below missionsMaxConcurrent. Entries whose mission was
+// deleted, archived, or started some other way (e.g. attached) are dropped.
+// Removing the cap starts everything still waiting.
+func (s *Server) drainMissionQueue() {
+	q := &s.missionQueue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.entries) == 0 {
+		return
+	}
+
+	now := time.Now()
+	limit := s.getConfig().MissionsMaxConcurrent
+	running, err := s.countRunningInteractiveMissionsLocked(now)
+	if err != nil {
+		s.logger.Printf("Mission queue: failed to count running missions: %v", err)
+		return
+	}
+
+	for len(q.entries) > 0 && (limit <= 0 || running < limit) {
+		entry := q.entries[0]
+		q.entries = q.entries[1:]
+
+		missionRecord, err := s.db.GetMission(entry.missionID)
+		if err != nil {
+			s.logger.Printf("Mission queue: failed to load mission %s: %v", database.ShortID(entry.missionID), err)
+			continue
+		}
+		if missionRecord == nil || missionRecord.Status == "archived" || s.isWrapperRunning(missionRecord.ID) {
+			continue
+		}
+
+		// The window is still linked into the requesting session, but it
+		// shouldn't yank focus from whatever the user is doing by now.
+		req := entry.request
+		req.NoFocus = true
+
+		s.logger.Printf("Mission queue: starting mission %s after %s in queue", missionRecord.ShortID, now.Sub(entry.queuedAt).Round(time.Second))
+		if err := s.spawnWrapper(missionRecord, req); err != nil {
+			s.logger.Printf("Mission queue: failed to spawn wrapper for mission %s: %v", missionRecord.ShortID, err)
+			continue
+		}
+		q.markStartedLocked(missionRecord.ID, now)
+		running++
+	}
+}
+
+// handleListMissionQueue handles GET /missions/queue.
+// Returns the missions waiting for a slot under missionsMaxConcurrent, in the
+// order they will start.
+func (s *Server) handleListMissionQueue(w http.ResponseWriter, r *http.Request) error {
+	entries := s.missionQueue.snapshot()
+	result := make([]QueuedMissionResponse, 0, len(entries))
+	for i, entry := range entries {
+		resp := QueuedMissionResponse{
+			Position:  i + 1,
+			MissionID: entry.missionID,
+			ShortID:   database.ShortID(entry.missionID),
+			Prompt:    entry.request.Prompt,
+			QueuedAt:  entry.queuedAt,
+		}
+		if missionRecord, err := s.db.GetMission(entry.missionID); err == nil && missionRecord != nil {
+			resp.GitRepo = missionRecord.GitRepo
+		}
+		result = append(result, resp)
+	}
+	writeJSON(w, http.StatusOK, result)
+	return nil
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// newMissionQueueTestServer returns a server with a real DB and the given
+// missionsMaxConcurrent.
+func newMissionQueueTestServer(t *testing.T, limit int) *Server {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	db, err := database.Open(filepath.Join(tmpDir, "database.sqlite"))
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	srv := &Server{
+		agencDirpath:       tmpDir,
+		logger:             log.New(os.Stderr, "", 0),
+		db:                 db,
+		missionQueueWakeCh: make(chan struct{}, 1),
+		events:             newEventBus(),
+	}
+	srv.cachedConfig.Store(&config.AgencConfig{MissionsMaxConcurrent: limit})
+	return srv
+}
+
+// markWrapperRunning writes the test process's PID as the mission's wrapper
+// PID so isWrapperRunning reports it as running.
+func markWrapperRunning(t *testing.T, srv *Server, missionID string) {
+	t.Helper()
+	if err := os.MkdirAll(config.GetMissionDirpath(srv.agencDirpath, missionID), 0755); err != nil {
+		t.Fatalf("failed to create mission dir: %v", err)
+	}
+	pidFilepath := config.GetMissionPIDFilepath(srv.agencDirpath, missionID)
+	if err := os.WriteFile(pidFilepath, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		t.Fatalf("failed to write PID file: %v", err)
+	}
+}
+
+func createQueueTestMission(t *testing.T, srv *Server) *database.Mission {
+	t.Helper()
+	m, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	return m
+}
+
+func TestStartOrQueueMission_QueuesAtLimit(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 1)
+	running := createQueueTestMission(t, srv)
+	markWrapperRunning(t, srv, running.ID)
+
+	first := createQueueTestMission(t, srv)
+	position, err := srv.startOrQueueMission(first, CreateMissionRequest{Prompt: "first"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if position != 1 {
+		t.Fatalf("expected position 1, got %d", position)
+	}
+
+	second := createQueueTestMission(t, srv)
+	position, err = srv.startOrQueueMission(second, CreateMissionRequest{Prompt: "second"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if position != 2 {
+		t.Fatalf("expected position 2, got %d", position)
+	}
+
+	entries := srv.missionQueue.snapshot()
+	if len(entries) != 2 || entries[0].missionID != first.ID || entries[1].missionID != second.ID {
+		t.Fatalf("expected [first second] in FIFO order, got %+v", entries)
+	}
+	if got := srv.missionQueue.position(second.ID); got != 2 {
+		t.Errorf("position(second) = %d, want 2", got)
+	}
+}
+
+func TestStartOrQueueMission_CronAndHeadlessBypassQueue(t *testing.T) {
+	if !isQueueExempt(CreateMissionRequest{Source: "cron"}) {
+		t.Error("cron missions should bypass the queue")
+	}
+	if !isQueueExempt(CreateMissionRequest{Headless: true}) {
+		t.Error("headless missions should bypass the queue")
+	}
+	if isQueueExempt(CreateMissionRequest{Source: "mission"}) {
+		t.Error("child missions should be queued")
+	}
+
+	cron := "cron"
+	if isInteractiveMission(&database.Mission{Source: &cron}) {
+		t.Error("cron missions should not count against the limit")
+	}
+	if !isInteractiveMission(&database.Mission{}) {
+		t.Error("missions without a source should count against the limit")
+	}
+}
+
+func TestDrainMissionQueue_DropsMissionsThatCannotStart(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 5)
+
+	deleted := createQueueTestMission(t, srv)
+	if err := srv.db.DeleteMission(deleted.ID); err != nil {
+		t.Fatalf("failed to delete mission: %v", err)
+	}
+	archived := createQueueTestMission(t, srv)
+	if err := srv.db.ArchiveMission(archived.ID); err != nil {
+		t.Fatalf("failed to archive mission: %v", err)
+	}
+	attached := createQueueTestMission(t, srv)
+	markWrapperRunning(t, srv, attached.ID)
+
+	now := time.Now()
+	srv.missionQueue.entries = []queuedMission{
+		{missionID: deleted.ID, queuedAt: now},
+		{missionID: archived.ID, queuedAt: now},
+		{missionID: attached.ID, queuedAt: now},
+	}
+
+	srv.drainMissionQueue()
+
+	if entries := srv.missionQueue.snapshot(); len(entries) != 0 {
+		t.Errorf("expected the queue to be empty, got %+v", entries)
+	}
+}
+
+func TestDrainMissionQueue_WaitsWhileAtLimit(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 1)
+	running := createQueueTestMission(t, srv)
+	markWrapperRunning(t, srv, running.ID)
+
+	waiting := createQueueTestMission(t, srv)
+	srv.missionQueue.entries = []queuedMission{{missionID: waiting.ID, queuedAt: time.Now()}}
+
+	srv.drainMissionQueue()
+
+	if got := srv.missionQueue.position(waiting.ID); got != 1 {
+		t.Errorf("expected mission to stay queued at position 1, got %d", got)
+	}
+}
+
+func TestMissionQueue_Take(t *testing.T) {
+	var q missionQueue
+	q.entries = []queuedMission{
+		{missionID: "a", request: CreateMissionRequest{Prompt: "first"}},
+		{missionID: "b", request: CreateMissionRequest{Prompt: "second"}},
+	}
+
+	entry, ok := q.take("a")
+	if !ok || entry.request.Prompt != "first" {
+		t.Fatalf("expected to take a with its prompt, got %+v, %v", entry, ok)
+	}
+	if got := q.position("b"); got != 1 {
+		t.Errorf("position(b) = %d after taking a, want 1", got)
+	}
+	if _, ok := q.take("a"); ok {
+		t.Error("expected a to be gone after take")
+	}
+}
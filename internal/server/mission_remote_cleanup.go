@@ -0,0 +1,142 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// RemoteCleanupResponse describes what deleting a mission cleans up on
+// origin: the mission's pushed auto-branch and the branch's open draft PR.
+// It is the JSON response for GET /missions/{id}/remote-cleanup and the
+// remote_cleanup field of DELETE /missions/{id}.
+type RemoteCleanupResponse struct {
+	// Branch is the pushed auto-branch, or empty when there is nothing on
+	// origin to clean up.
+	Branch string `json:"branch,omitempty"`
+	// PRURL is the branch's open draft PR, closed along with the branch.
+	PRURL string `json:"pr_url,omitempty"`
+	// SkipReason explains why Branch is left alone, e.g. its PR is ready
+	// for review.
+	SkipReason string `json:"skip_reason,omitempty"`
+	// Cleaned is true once the PR was closed and the branch deleted.
+	Cleaned bool `json:"cleaned,omitempty"`
+	// Error is set when cleaning up failed; the mission is deleted anyway.
+	Error string `json:"error,omitempty"`
+}
+
+// actionable reports whether there is a branch to delete.
+func (plan *RemoteCleanupResponse) actionable() bool {
+	return plan.Branch != "" && plan.SkipReason == ""
+}
+
+// isMissionAutoBranch reports whether branch is one AgenC created for the
+// mission. autoBranchTemplate must include {shortID} or {missionID}, so those
+// branches all carry the mission's short ID; other branches may be shared, so
+// they are never deleted.
+func isMissionAutoBranch(missionRecord *database.Mission, branch string) bool {
+	return strings.Contains(branch, missionRecord.ShortID)
+}
+
+// planRemoteCleanup works out what deleting the mission would clean up on
+// origin. Branches with an open PR that is ready for review are skipped, since
+// deleting the branch would close a PR someone may be reviewing.
+func (s *Server) planRemoteCleanup(missionRecord *database.Mission) *RemoteCleanupResponse {
+	plan := &RemoteCleanupResponse{}
+	if missionRecord.GitRepo == "" {
+		return plan
+	}
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+	if _, err := os.Stat(agentDirpath); err != nil {
+		return plan
+	}
+
+	branch, err := mission.GetCurrentBranch(agentDirpath)
+	if err != nil || branch == "" || !isMissionAutoBranch(missionRecord, branch) {
+		return plan
+	}
+	pushed, err := mission.RemoteBranchExists(agentDirpath, branch)
+	if err != nil {
+		plan.Error = err.Error()
+		return plan
+	}
+	if !pushed {
+		return plan
+	}
+	plan.Branch = branch
+
+	pr, err := mission.FindOpenPullRequest(agentDirpath, branch)
+	if err != nil {
+		plan.SkipReason = "could not check for an open pull request: " + err.Error()
+		return plan
+	}
+	if pr != nil {
+		plan.PRURL = pr.URL
+		if !pr.IsDraft {
+			plan.SkipReason = "pull request " + pr.URL + " is ready for review"
+		}
+	}
+	return plan
+}
+
+// cleanupMissionRemote closes the mission's draft PR and deletes its pushed
+// auto-branch from origin, recording the outcome on the returned response.
+// Must run before the mission directory is removed.
+func (s *Server) cleanupMissionRemote(missionRecord *database.Mission) *RemoteCleanupResponse {
+	plan := s.planRemoteCleanup(missionRecord)
+	if !plan.actionable() {
+		if plan.SkipReason != "" {
+			s.logger.Printf("Mission %s: leaving remote branch '%s' in place: %s", missionRecord.ShortID, plan.Branch, plan.SkipReason)
+		}
+		return plan
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+	if plan.PRURL != "" {
+		comment := "Closed because AgenC mission `" + missionRecord.ShortID + "` was deleted."
+		if err := mission.ClosePullRequest(agentDirpath, plan.PRURL, comment); err != nil {
+			plan.Error = err.Error()
+			s.logger.Printf("Warning: failed to close pull request %s for mission %s: %v", plan.PRURL, missionRecord.ShortID, err)
+			return plan
+		}
+	}
+	if err := mission.DeleteRemoteBranch(agentDirpath, plan.Branch); err != nil {
+		plan.Error = err.Error()
+		s.logger.Printf("Warning: failed to delete remote branch '%s' for mission %s: %v", plan.Branch, missionRecord.ShortID, err)
+		return plan
+	}
+	plan.Cleaned = true
+	s.logger.Printf("Mission %s: deleted remote branch '%s'", missionRecord.ShortID, plan.Branch)
+	return plan
+}
+
+// cleanupRemoteByDefault reports whether deletions that don't say otherwise
+// (mission GC, batch removal, DELETE without cleanup_remote) clean up the
+// mission's remote branch. "ask" needs someone to ask, so it counts as never.
+func (s *Server) cleanupRemoteByDefault() bool {
+	return s.getConfig().GetCleanupRemoteOnDelete() == config.CleanupRemoteAlways
+}
+
+// handleGetMissionRemoteCleanup handles GET /missions/{id}/remote-cleanup.
+// Reports what deleting the mission with cleanup_remote=true would remove,
+// so clients can ask before deleting.
+func (s *Server) handleGetMissionRemoteCleanup(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	missionRecord, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if missionRecord == nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	writeJSON(w, http.StatusOK, s.planRemoteCleanup(missionRecord))
+	return nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+func TestPlanRemoteCleanup(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	remoteDirpath := filepath.Join(t.TempDir(), "remote.git")
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "initial commit"},
+		{"init", "--bare", remoteDirpath},
+		{"remote", "add", "origin", remoteDirpath},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = agentDirpath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+	}
+
+	if plan := srv.planRemoteCleanup(missionRecord); plan.Branch != "" {
+		t.Errorf("expected nothing to clean up on the default branch, got %+v", plan)
+	}
+
+	branch := mission.RenderBranchName(config.DefaultAutoBranchTemplate, missionRecord.ID, missionRecord.Prompt)
+	if err := mission.SwitchBranch(agentDirpath, branch, true); err != nil {
+		t.Fatalf("SwitchBranch failed: %v", err)
+	}
+	if plan := srv.planRemoteCleanup(missionRecord); plan.Branch != "" {
+		t.Errorf("expected nothing to clean up before the branch is pushed, got %+v", plan)
+	}
+
+	if err := mission.PushBranch(agentDirpath, branch); err != nil {
+		t.Fatalf("PushBranch failed: %v", err)
+	}
+	// gh cannot look up PRs for a non-GitHub remote, so the branch is found
+	// but left alone rather than risking closing a PR under review
+	plan := srv.cleanupMissionRemote(missionRecord)
+	if plan.Branch != branch || plan.SkipReason == "" || plan.Cleaned {
+		t.Errorf("expected branch %q found but skipped, got %+v", branch, plan)
+	}
+	if exists, err := mission.RemoteBranchExists(agentDirpath, branch); err != nil || !exists {
+		t.Errorf("expected the skipped branch to stay on origin, got %v, %v", exists, err)
+	}
+}
+
+func TestIsMissionAutoBranch(t *testing.T) {
+	missionRecord := &database.Mission{ID: "2b4c8f1a-0000-0000-0000-000000000000", ShortID: "2b4c8f1a"}
+	for branch, want := range map[string]bool{
+		"agenc/2b4c8f1a-fix-the-login-page":         true,
+		"work/2b4c8f1a-0000-0000-0000-000000000000": true,
+		"agenc/mission-2b4c8f1a":                    true,
+		"feature/login":                             false,
+		"main":                                      false,
+	} {
+		if got := isMissionAutoBranch(missionRecord, branch); got != want {
+			t.Errorf("isMissionAutoBranch(%q) = %v, want %v", branch, got, want)
+		}
+	}
+}
+
+func TestHandleDeleteMission_RejectsInvalidCleanupRemote(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	req := httptest.NewRequest(http.MethodDelete, "/missions/abc?cleanup_remote=maybe", nil)
+	req.SetPathValue("id", "abc")
+	err := srv.handleDeleteMission(httptest.NewRecorder(), req)
+	var httpErr *httpError
+	if !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid cleanup_remote, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// RepointMissionRequest is the JSON body for POST /missions/{id}/repoint.
+type RepointMissionRequest struct {
+	// Repo is the canonical name of a repo already in the library.
+	Repo string `json:"repo"`
+	// Mode is mission.RepointModeClone (default) or mission.RepointModeRebase.
+	Mode string `json:"mode,omitempty"`
+}
+
+// RepointMissionResponse is the JSON response for POST /missions/{id}/repoint.
+type RepointMissionResponse struct {
+	MissionID    string `json:"mission_id"`
+	ShortID      string `json:"short_id"`
+	PreviousRepo string `json:"previous_repo"`
+	Repo         string `json:"repo"`
+	Mode         string `json:"mode"`
+	// PreviousAgentDirpath is where the old workspace was kept, in clone mode.
+	PreviousAgentDirpath string `json:"previous_agent_dirpath,omitempty"`
+}
+
+// handleRepointMission handles POST /missions/{id}/repoint. Moves the
+// mission's workspace to another repo and updates its git_repo, keeping the
+// conversation: the agent/ path doesn't change, so Claude resumes the same
+// session. A mission running in tmux is reloaded around the swap; one running
+// without a pane is stopped, like a reload.
+func (s *Server) handleRepointMission(w http.ResponseWriter, r *http.Request) error {
+	var req RepointMissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	req.Repo = strings.TrimSpace(req.Repo)
+	if req.Repo == "" {
+		return newHTTPError(http.StatusBadRequest, "repo is required")
+	}
+	if req.Mode == "" {
+		req.Mode = mission.RepointModeClone
+	}
+	if req.Mode != mission.RepointModeClone && req.Mode != mission.RepointModeRebase {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid mode '%s'; must be '%s' or '%s'", req.Mode, mission.RepointModeClone, mission.RepointModeRebase)
+	}
+
+	id := r.PathValue("id")
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	missionRecord, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if missionRecord == nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	if missionRecord.Status == "archived" {
+		return newHTTPError(http.StatusBadRequest, "cannot repoint archived mission")
+	}
+	if config.IsMissionAdjutant(s.agencDirpath, resolvedID) {
+		return newHTTPError(http.StatusBadRequest, "cannot repoint an Adjutant mission")
+	}
+	if len(missionRecord.GitRepos) > 0 {
+		return newHTTPError(http.StatusBadRequest, "cannot repoint a multi-repo mission")
+	}
+	if missionRecord.GitRepo == req.Repo {
+		return newHTTPErrorf(http.StatusBadRequest, "mission %s already works on %s", missionRecord.ShortID, req.Repo)
+	}
+
+	repoDirpath := config.GetRepoDirpath(s.agencDirpath, req.Repo)
+	if _, err := os.Stat(repoDirpath); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "repo '%s' is not in the repo library; add it with 'agenc repo add'", req.Repo)
+	}
+	// Claude's conversation lives under its working directory, so a mission
+	// created with --path can only move to a repo that has the same path
+	if subpath := config.ReadMissionSubpath(s.agencDirpath, resolvedID); subpath != "" {
+		if err := mission.CheckSubpathExists(repoDirpath, subpath); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "mission %s runs in '%s', which '%s' lacks: %v", missionRecord.ShortID, subpath, req.Repo, err)
+		}
+	}
+
+	release, ok := s.tryAcquireReloadLock(resolvedID)
+	if !ok {
+		return newHTTPError(http.StatusConflict, "reload already in progress for mission "+missionRecord.ShortID)
+	}
+	defer release()
+
+	resp := RepointMissionResponse{
+		MissionID:    missionRecord.ID,
+		ShortID:      missionRecord.ShortID,
+		PreviousRepo: missionRecord.GitRepo,
+		Repo:         req.Repo,
+		Mode:         req.Mode,
+	}
+	repoint := func() error {
+		if err := s.repointWorkspace(missionRecord, req.Repo, req.Mode); err != nil {
+			return err
+		}
+		if req.Mode == mission.RepointModeClone {
+			resp.PreviousAgentDirpath = config.GetMissionPreviousAgentDirpath(s.agencDirpath, missionRecord.ID)
+		}
+		return nil
+	}
+
+	hasLivePane := missionRecord.TmuxPane != nil && *missionRecord.TmuxPane != ""
+	switch {
+	case hasLivePane && s.isWrapperRunning(resolvedID):
+		err = s.reloadMissionInTmuxWith(missionRecord, *missionRecord.TmuxPane, "", repoint)
+	case s.isWrapperRunning(resolvedID):
+		if err := s.stopWrapper(resolvedID); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to stop wrapper: %s", err.Error())
+		}
+		err = repoint()
+	default:
+		err = repoint()
+	}
+	if err != nil {
+		return newHTTPErrorf(http.StatusConflict, "failed to repoint mission %s to %s: %s", missionRecord.ShortID, req.Repo, err.Error())
+	}
+
+	s.logger.Printf("Repointed mission %s from '%s' to '%s' (%s)", missionRecord.ShortID, missionRecord.GitRepo, req.Repo, req.Mode)
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
+
+// repointWorkspace swaps the mission's agent/ directory over to repoName and
+// records the new repo. Must be called with the mission's wrapper stopped.
+func (s *Server) repointWorkspace(missionRecord *database.Mission, repoName string, mode string) error {
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+	repoDirpath := config.GetRepoDirpath(s.agencDirpath, repoName)
+
+	if mode == mission.RepointModeRebase {
+		if err := mission.RebaseOntoRepo(agentDirpath, repoDirpath); err != nil {
+			return err
+		}
+	} else {
+		previousDirpath := config.GetMissionPreviousAgentDirpath(s.agencDirpath, missionRecord.ID)
+		if err := mission.SetAsideAgentDir(agentDirpath, previousDirpath); err != nil {
+			return err
+		}
+		if mission.IsRepoStale(repoDirpath, 24*time.Hour) {
+			if err := mission.ForceUpdateRepo(repoDirpath); err != nil {
+				s.logger.Printf("Mission repoint: failed to pull stale repo '%s': %v (proceeding with stale copy)", repoName, err)
+			}
+		}
+
+		var err error
+		if s.getConfig().GetWorkspaceMode(repoName) == config.WorkspaceModeWorktree {
+			err = mission.AddWorktree(repoDirpath, agentDirpath, mission.WorktreeBranchName(missionRecord.ID), "HEAD")
+		} else {
+			err = mission.CopyRepo(repoDirpath, agentDirpath)
+		}
+		if err != nil {
+			// Put the old workspace back so the mission still has one
+			_ = os.RemoveAll(agentDirpath)
+			_ = mission.MoveAgentDir(previousDirpath, agentDirpath)
+			return err
+		}
+	}
+
+	s.linkDependencyCache(repoName, agentDirpath, s.getConfig().GetPostUpdateHookCache(repoName))
+	return s.db.UpdateMissionGitRepo(missionRecord.ID, repoName)
+}
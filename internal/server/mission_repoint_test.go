@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+func repointMission(srv *Server, missionID string, body string) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest("POST", "/missions/"+missionID+"/repoint", strings.NewReader(body))
+	req.SetPathValue("id", missionID)
+	rec := httptest.NewRecorder()
+	return rec, srv.handleRepointMission(rec, req)
+}
+
+func TestRepointMission_WorktreeRepo(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	const newRepo = "github.com/owner/project"
+	srv.cachedConfig.Store(&config.AgencConfig{
+		RepoConfigs: map[string]config.RepoConfig{newRepo: {WorkspaceMode: config.WorkspaceModeWorktree}},
+	})
+
+	repoDirpath := config.GetRepoDirpath(srv.agencDirpath, newRepo)
+	if err := os.MkdirAll(repoDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"-c", "user.email=test@test.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDirpath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s: %v", args, output, err)
+		}
+	}
+
+	// A blank exploration mission with some notes in its workspace
+	missionRecord, err := srv.db.CreateMission("", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "notes.md"), []byte("ideas"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := repointMission(srv, missionRecord.ShortID, `{"repo":"`+newRepo+`"}`)
+	if err != nil {
+		t.Fatalf("handleRepointMission failed: %v", err)
+	}
+	var resp RepointMissionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Mode != mission.RepointModeClone || resp.Repo != newRepo {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	if !mission.IsWorktree(agentDirpath) {
+		t.Error("expected the new workspace to be a worktree of the library clone")
+	}
+	previousNotesFilepath := filepath.Join(config.GetMissionPreviousAgentDirpath(srv.agencDirpath, missionRecord.ID), "notes.md")
+	if _, err := os.Stat(previousNotesFilepath); err != nil {
+		t.Errorf("expected the old workspace to be kept: %v", err)
+	}
+	updated, err := srv.db.GetMission(missionRecord.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if updated.GitRepo != newRepo {
+		t.Errorf("expected git_repo %s, got %q", newRepo, updated.GitRepo)
+	}
+}
+
+func TestRepointMission_RejectsBadRequests(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", &database.CreateMissionParams{})
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing repo", `{}`},
+		{"bad mode", `{"repo":"github.com/owner/other","mode":"merge"}`},
+		{"same repo", `{"repo":"github.com/owner/repo"}`},
+		{"not in library", `{"repo":"github.com/owner/other"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repointMission(srv, missionRecord.ShortID, tt.body)
+			var httpErr *httpError
+			if !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+				t.Errorf("expected a 400, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+// maxMissionMessageBytes caps a message sent with POST /missions/{id}/send.
+const maxMissionMessageBytes = 1 << 20 // 1 MiB
+
+// missionMessageSubmitDelay gives Claude's input box time to take in a pasted
+// message before Enter submits it; an Enter arriving mid-paste is swallowed.
+const missionMessageSubmitDelay = 200 * time.Millisecond
+
+// SendMissionMessageRequest is the JSON body for POST /missions/{id}/send.
+type SendMissionMessageRequest struct {
+	Message string `json:"message"`
+}
+
+// handleSendMissionMessage handles POST /missions/{id}/send. Delivers the
+// message to the mission's interactive Claude as a prompt: it is pasted into
+// the pane as one bracketed paste, so newlines stay in the message instead of
+// submitting it early, and then submitted with Enter. A message sent while
+// Claude is busy is queued by Claude like any typed prompt.
+func (s *Server) handleSendMissionMessage(w http.ResponseWriter, r *http.Request) error {
+	var req SendMissionMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return newHTTPError(http.StatusBadRequest, "message is required and must not be empty")
+	}
+	if len(message) > maxMissionMessageBytes {
+		return newHTTPErrorf(http.StatusBadRequest, "message exceeds maximum size of %d bytes", maxMissionMessageBytes)
+	}
+
+	missionRecord, paneID, err := s.lookupRunningMissionPane(r.PathValue("id"))
+	if err != nil {
+		return err
+	}
+
+	if err := pasteIntoPane(paneID, "agenc-send-"+missionRecord.ShortID, message); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "%s", err.Error())
+	}
+	time.Sleep(missionMessageSubmitDelay)
+	if err := sendKeysToPane(paneID, []string{"Enter"}); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "%s", err.Error())
+	}
+
+	s.logger.Printf("Sent a %d-byte message to mission %s (pane %s)", len(message), missionRecord.ShortID, paneID)
+	writeJSON(w, http.StatusOK, map[string]string{"status": "sent"})
+	return nil
+}
+
+// pasteIntoPane pastes text into the given tmux pane through a named buffer,
+// leaving the user's own paste buffers alone. The paste is bracketed (-p) so
+// programs that support it, like Claude, take it in as a single input.
+func pasteIntoPane(paneID string, bufferName string, text string) error {
+	paneTarget := "%" + paneID
+
+	loadCmd := tmux.Command("load-buffer", "-b", bufferName, "-")
+	loadCmd.Stdin = strings.NewReader(text)
+	if output, err := loadCmd.CombinedOutput(); err != nil {
+		return stacktrace.NewError("tmux load-buffer failed: %v (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+
+	// Drop the pane out of copy mode so the paste lands in the prompt. Gated
+	// on pane_in_mode, since send-keys -X against a pane in no mode can
+	// deliver a stray key to the running program.
+	modeOutput, err := tmux.Command("display-message", "-p", "-t", paneTarget, "#{pane_in_mode}").Output()
+	if err == nil && strings.TrimSpace(string(modeOutput)) == "1" {
+		_ = tmux.Command("send-keys", "-t", paneTarget, "-X", "cancel").Run()
+	}
+
+	pasteCmd := tmux.Command("paste-buffer", "-p", "-d", "-b", bufferName, "-t", paneTarget)
+	if output, err := pasteCmd.CombinedOutput(); err != nil {
+		return stacktrace.NewError("tmux paste-buffer failed: %v (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
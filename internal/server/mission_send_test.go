@@ -0,0 +1,42 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleSendMissionMessage_Validation(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	missionRecord, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	send := func(body string) error {
+		req := httptest.NewRequest(http.MethodPost, "/missions/"+missionRecord.ShortID+"/send", strings.NewReader(body))
+		req.SetPathValue("id", missionRecord.ShortID)
+		return srv.handleSendMissionMessage(httptest.NewRecorder(), req)
+	}
+
+	for name, body := range map[string]string{
+		"empty message":       `{"message":"  \n "}`,
+		"invalid body":        `{"message":`,
+		"mission not running": `{"message":"also update the changelog"}`,
+	} {
+		var httpErr *httpError
+		if err := send(body); !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %v", name, err)
+		}
+	}
+
+	if err := srv.db.ArchiveMission(missionRecord.ID); err != nil {
+		t.Fatalf("failed to archive mission: %v", err)
+	}
+	var httpErr *httpError
+	if err := send(`{"message":"hello"}`); !errors.As(err, &httpErr) || !strings.Contains(httpErr.message, "archived") {
+		t.Errorf("expected an archived-mission error, got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// missionSizeInitialDelay is how long after startup the first size check
+	// runs, so walking every agent directory does not compete with startup
+	// reconciliation.
+	missionSizeInitialDelay = 2 * time.Minute
+
+	// missionSizeCheckInterval is how often agent directories are measured.
+	missionSizeCheckInterval = 15 * time.Minute
+
+	// missionSizeCleanupHookTimeout is the hard timeout for the
+	// missionSizeLimit cleanupHook.
+	missionSizeCleanupHookTimeout = 10 * time.Minute
+
+	missionOversizedNotificationKind = "mission.oversized"
+)
+
+// measureDirBytes returns the total size of the regular files under dirpath.
+// Symlinks are not followed, so dependency caches linked in by
+// postUpdateHookCache are not counted. A missing directory measures zero.
+func measureDirBytes(dirpath string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dirpath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			// The file vanished mid-walk
+			return nil
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
+
+// markMissionsOversized sets IsOversized on each mission from its last
+// measured agent directory size.
+func (s *Server) markMissionsOversized(missions []*database.Mission) {
+	maxBytes := s.getConfig().GetMissionMaxSizeBytes()
+	for _, m := range missions {
+		m.IsOversized = maxBytes > 0 && m.AgentDirBytes > maxBytes
+	}
+}
+
+// runMissionSizeLoop periodically measures every active mission's agent
+// directory, flagging missions over the missionSizeLimit maxSize.
+func (s *Server) runMissionSizeLoop(ctx context.Context) {
+	// Agent directory size after the cleanup hook last ran, per mission, so
+	// the hook is not rerun until the directory changes
+	cleanedBytes := make(map[string]int64)
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(missionSizeInitialDelay):
+		s.runMissionSizeCycle(ctx, cleanedBytes)
+	}
+
+	ticker := time.NewTicker(missionSizeCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runMissionSizeCycle(ctx, cleanedBytes)
+		}
+	}
+}
+
+// runMissionSizeCycle measures each active mission's agent directory and
+// records the size. Missions over the limit get the cleanup hook run while
+// their wrapper is stopped, and a notification when they first cross it.
+func (s *Server) runMissionSizeCycle(ctx context.Context, cleanedBytes map[string]int64) {
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: false})
+	if err != nil {
+		s.logger.Printf("Mission size: failed to list missions: %v", err)
+		return
+	}
+
+	cfg := s.getConfig()
+	maxBytes := cfg.GetMissionMaxSizeBytes()
+	active := make(map[string]bool, len(missions))
+	for _, m := range missions {
+		if ctx.Err() != nil {
+			return
+		}
+		active[m.ID] = true
+		agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, m.ID)
+		sizeBytes, err := measureDirBytes(agentDirpath)
+		if err != nil {
+			s.logger.Printf("Mission size: failed to measure mission %s: %v", m.ShortID, err)
+			continue
+		}
+
+		if maxBytes > 0 && sizeBytes > maxBytes && cfg.MissionSizeLimit.CleanupHook != "" &&
+			cleanedBytes[m.ID] != sizeBytes && !s.isWrapperRunning(m.ID) {
+			s.runMissionSizeCleanupHook(ctx, m, agentDirpath, cfg.MissionSizeLimit.CleanupHook)
+			if slimmedBytes, err := measureDirBytes(agentDirpath); err == nil {
+				sizeBytes = slimmedBytes
+			}
+			cleanedBytes[m.ID] = sizeBytes
+		}
+
+		if sizeBytes == m.AgentDirBytes {
+			continue
+		}
+		if err := s.db.SetMissionAgentDirBytes(m.ID, sizeBytes); err != nil {
+			s.logger.Printf("Mission size: failed to record size for mission %s: %v", m.ShortID, err)
+			continue
+		}
+		if maxBytes > 0 && sizeBytes > maxBytes && m.AgentDirBytes <= maxBytes {
+			s.logger.Printf("Mission size: mission %s agent directory is %s, over the %s limit",
+				m.ShortID, config.FormatByteSize(sizeBytes), config.FormatByteSize(maxBytes))
+			if err := s.db.CreateNotification(buildMissionOversizedNotification(m, sizeBytes, maxBytes)); err != nil {
+				s.logger.Printf("Mission size: failed to create notification for mission %s: %v", m.ShortID, err)
+			}
+		}
+	}
+
+	for missionID := range cleanedBytes {
+		if !active[missionID] {
+			delete(cleanedBytes, missionID)
+		}
+	}
+}
+
+// runMissionSizeCleanupHook runs the missionSizeLimit cleanupHook in a
+// mission's agent directory. Failures are logged and otherwise ignored.
+func (s *Server) runMissionSizeCleanupHook(ctx context.Context, m *database.Mission, agentDirpath string, hookCmd string) {
+	expanded, err := s.expandHookSecrets(hookCmd)
+	if err != nil {
+		s.logger.Printf("Mission size: failed to resolve secrets for cleanupHook: %v", err)
+		return
+	}
+
+	hookCtx, cancel := context.WithTimeout(ctx, missionSizeCleanupHookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(hookCtx, "sh", "-c", expanded)
+	cmd.Dir = agentDirpath
+	cmd.Env = append(os.Environ(),
+		"AGENC_MISSION_UUID="+m.ID,
+		"AGENC_MISSION_SHORT_ID="+m.ShortID,
+		"AGENC_MISSION_REPO="+m.GitRepo,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		s.logger.Printf("Mission size: cleanupHook failed for mission %s: %v\nstderr: %s",
+			m.ShortID, err, strings.TrimSpace(stderr.String()))
+		return
+	}
+	s.logger.Printf("Mission size: cleanupHook succeeded for mission %s", m.ShortID)
+}
+
+// buildMissionOversizedNotification constructs the notification raised when
+// a mission's agent directory first grows past the size limit.
+func buildMissionOversizedNotification(m *database.Mission, sizeBytes int64, maxBytes int64) *database.Notification {
+	bodyParts := []string{
+		"**Mission:** " + m.ShortID,
+		fmt.Sprintf("**Agent directory:** %s (limit %s)", config.FormatByteSize(sizeBytes), config.FormatByteSize(maxBytes)),
+	}
+	if m.GitRepo != "" {
+		bodyParts = append(bodyParts, "**Repo:** "+m.GitRepo)
+	}
+	bodyParts = append(bodyParts, "Look for dependency directories or build output to remove, e.g. with `du -sh` in the mission's agent directory.")
+
+	missionID := m.ID
+	return &database.Notification{
+		ID:           uuid.New().String(),
+		Kind:         missionOversizedNotificationKind,
+		Title:        sanitizeNotificationTitle("Mission over size limit: " + m.ShortID),
+		BodyMarkdown: strings.Join(bodyParts, "\n\n"),
+		MissionID:    &missionID,
+	}
+}
+
+// checkMissionSizeBeforeArchive refuses to archive a mission whose agent
+// directory is over the size limit when missionSizeLimit.blockArchive is set.
+// The directory is measured afresh, so a mission slimmed since the last check
+// can be archived right away.
+func (s *Server) checkMissionSizeBeforeArchive(missionRecord *database.Mission) error {
+	cfg := s.getConfig()
+	maxBytes := cfg.GetMissionMaxSizeBytes()
+	if maxBytes == 0 || !cfg.MissionSizeLimit.BlockArchive {
+		return nil
+	}
+
+	sizeBytes, err := measureDirBytes(config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID))
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to measure mission %s: %s", missionRecord.ShortID, err.Error())
+	}
+	if sizeBytes != missionRecord.AgentDirBytes {
+		if err := s.db.SetMissionAgentDirBytes(missionRecord.ID, sizeBytes); err != nil {
+			s.logger.Printf("Mission size: failed to record size for mission %s: %v", missionRecord.ShortID, err)
+		}
+	}
+	if sizeBytes > maxBytes {
+		return newHTTPErrorf(http.StatusConflict,
+			"mission %s agent directory is %s, over the missionSizeLimit of %s; slim it down before archiving",
+			missionRecord.ShortID, config.FormatByteSize(sizeBytes), config.FormatByteSize(maxBytes))
+	}
+	return nil
+}
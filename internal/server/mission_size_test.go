@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// createSizedTestMission creates a mission whose agent directory holds a
+// single file of the given size.
+func createSizedTestMission(t *testing.T, srv *Server, sizeBytes int) *database.Mission {
+	t.Helper()
+	m, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, m.ID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatalf("failed to create agent dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDirpath, "big.bin"), make([]byte, sizeBytes), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	return m
+}
+
+func TestMeasureDirBytes(t *testing.T) {
+	dirpath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dirpath, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirpath, "a"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dirpath, "sub", "b"), make([]byte, 50), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Symlinked caches are shared, so they don't count against the mission
+	cacheDirpath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cacheDirpath, "c"), make([]byte, 1000), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(cacheDirpath, filepath.Join(dirpath, "node_modules")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := measureDirBytes(dirpath)
+	if err != nil || got != 150 {
+		t.Errorf("measureDirBytes = %d, %v; want 150", got, err)
+	}
+
+	got, err = measureDirBytes(filepath.Join(dirpath, "missing"))
+	if err != nil || got != 0 {
+		t.Errorf("measureDirBytes(missing) = %d, %v; want 0", got, err)
+	}
+}
+
+func TestRunMissionSizeCycle_FlagsOversizedMission(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MissionSizeLimit: &config.MissionSizeLimitConfig{MaxSize: "1KB"},
+	})
+	small := createSizedTestMission(t, srv, 100)
+	big := createSizedTestMission(t, srv, 4096)
+
+	srv.runMissionSizeCycle(context.Background(), map[string]int64{})
+
+	missions, err := srv.db.ListMissions(database.ListMissionsParams{})
+	if err != nil {
+		t.Fatalf("ListMissions failed: %v", err)
+	}
+	srv.markMissionsOversized(missions)
+	for _, m := range missions {
+		switch m.ID {
+		case small.ID:
+			if m.AgentDirBytes != 100 || m.IsOversized {
+				t.Errorf("small mission: got %d bytes, oversized=%v", m.AgentDirBytes, m.IsOversized)
+			}
+		case big.ID:
+			if m.AgentDirBytes != 4096 || !m.IsOversized {
+				t.Errorf("big mission: got %d bytes, oversized=%v", m.AgentDirBytes, m.IsOversized)
+			}
+		}
+	}
+
+	notifications, err := srv.db.ListNotifications(database.ListNotificationsParams{Kind: missionOversizedNotificationKind})
+	if err != nil {
+		t.Fatalf("ListNotifications failed: %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].MissionID == nil || *notifications[0].MissionID != big.ID {
+		t.Fatalf("expected one notification for the big mission, got %+v", notifications)
+	}
+
+	// A mission that stays over the limit is not announced again
+	srv.runMissionSizeCycle(context.Background(), map[string]int64{})
+	notifications, _ = srv.db.ListNotifications(database.ListNotificationsParams{Kind: missionOversizedNotificationKind})
+	if len(notifications) != 1 {
+		t.Errorf("expected no repeat notification, got %d", len(notifications))
+	}
+}
+
+func TestRunMissionSizeCycle_CleanupHookSlimsMission(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MissionSizeLimit: &config.MissionSizeLimitConfig{MaxSize: "1KB", CleanupHook: "rm -f big.bin"},
+	})
+	m := createSizedTestMission(t, srv, 4096)
+
+	cleanedBytes := map[string]int64{}
+	srv.runMissionSizeCycle(context.Background(), cleanedBytes)
+
+	got, err := srv.db.GetMission(m.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if got.AgentDirBytes != 0 {
+		t.Errorf("expected the cleanup hook to empty the agent dir, got %d bytes", got.AgentDirBytes)
+	}
+	if size, ok := cleanedBytes[m.ID]; !ok || size != 0 {
+		t.Errorf("expected the cleanup to be recorded, got %v", cleanedBytes)
+	}
+	notifications, _ := srv.db.ListNotifications(database.ListNotificationsParams{Kind: missionOversizedNotificationKind})
+	if len(notifications) != 0 {
+		t.Errorf("expected no notification once cleaned up, got %d", len(notifications))
+	}
+}
+
+func TestCheckMissionSizeBeforeArchive(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	m := createSizedTestMission(t, srv, 4096)
+
+	// Without blockArchive, oversized missions archive normally
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MissionSizeLimit: &config.MissionSizeLimitConfig{MaxSize: "1KB"},
+	})
+	if err := srv.checkMissionSizeBeforeArchive(m); err != nil {
+		t.Fatalf("expected no block without blockArchive, got %v", err)
+	}
+
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MissionSizeLimit: &config.MissionSizeLimitConfig{MaxSize: "1KB", BlockArchive: true},
+	})
+	err := srv.checkMissionSizeBeforeArchive(m)
+	if err == nil || httpStatusFromError(err) != http.StatusConflict {
+		t.Fatalf("expected 409 for an oversized mission, got %v", err)
+	}
+
+	// Slimming the mission unblocks it without waiting for the next check
+	if err := os.Remove(filepath.Join(config.GetMissionAgentDirpath(srv.agencDirpath, m.ID), "big.bin")); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.checkMissionSizeBeforeArchive(m); err != nil {
+		t.Errorf("expected a slimmed mission to archive, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/session"
+)
+
+const (
+	// missionSummaryMaxMessages is how many recent user messages are given to
+	// the summarizer.
+	missionSummaryMaxMessages = 10
+
+	// missionSummaryMaxInputLen caps each message (and the last assistant
+	// reply) passed to the summarizer. Longer text is truncated.
+	missionSummaryMaxInputLen = 500
+
+	// missionSummaryMaxOutputLen is the maximum length (in bytes) of a valid
+	// mission summary.
+	missionSummaryMaxOutputLen = 300
+
+	missionSummarySystemPrompt = "You summarize the state of a coding session between a user and an AI coding assistant. " +
+		"You will receive the user's recent messages and the assistant's latest reply. " +
+		"Output ONE plain sentence describing what is being worked on and where it stands. " +
+		"No quotes. No markdown. No preamble. Do NOT answer or continue the conversation."
+)
+
+// missionSummaryDue reports whether a mission whose summary was last generated
+// at lastSummaryPromptCount should be re-summarized now, given the configured
+// trigger. isIdle is true when the check comes from Claude going idle rather
+// than from a new prompt.
+func missionSummaryDue(cfg *config.AgencConfig, promptCount int, lastSummaryPromptCount int, isIdle bool) bool {
+	if promptCount <= lastSummaryPromptCount {
+		return false
+	}
+	switch cfg.GetMissionSummaryTrigger() {
+	case config.MissionSummaryTriggerPrompts:
+		return !isIdle && promptCount-lastSummaryPromptCount >= cfg.GetMissionSummaryEveryPrompts()
+	case config.MissionSummaryTriggerIdle:
+		return isIdle
+	default:
+		return false
+	}
+}
+
+// maybeSummarizeMissionAsync regenerates the mission's AI summary in the
+// background when the configured trigger says it is due. At most one
+// summarization runs per mission at a time.
+func (s *Server) maybeSummarizeMissionAsync(missionID string, isIdle bool) {
+	missionRecord, err := s.db.GetMission(missionID)
+	if err != nil || missionRecord == nil {
+		return
+	}
+	if !missionSummaryDue(s.getConfig(), missionRecord.PromptCount, missionRecord.LastSummaryPromptCount, isIdle) {
+		return
+	}
+	if _, inFlight := s.missionSummariesInFlight.LoadOrStore(missionID, struct{}{}); inFlight {
+		return
+	}
+
+	go func() {
+		defer s.missionSummariesInFlight.Delete(missionID)
+		if err := s.summarizeMission(context.Background(), missionRecord); err != nil {
+			s.logger.Printf("Mission summary: failed for %s: %v", database.ShortID(missionID), err)
+		}
+	}()
+}
+
+// summarizeMission generates a summary of the mission's conversation and
+// stores it along with the prompt count it covers.
+func (s *Server) summarizeMission(ctx context.Context, missionRecord *database.Mission) error {
+	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(s.agencDirpath, missionRecord.ID)
+	userMessages := session.ExtractRecentUserMessages(claudeConfigDirpath, missionRecord.ID, missionSummaryMaxMessages)
+	if len(userMessages) == 0 {
+		return fmt.Errorf("mission has no conversation to summarize")
+	}
+	var lastAssistantText string
+	if jsonlFilepath := session.FindActiveJSONLPath(claudeConfigDirpath, missionRecord.ID); jsonlFilepath != "" {
+		lastAssistantText = session.ExtractLastAssistantText(jsonlFilepath)
+	}
+
+	model := s.getConfig().GetMissionSummaryModel()
+	if model == "" {
+		model = summarizerModel
+	}
+
+	summary, err := runSummarizerCLI(ctx, s.agencDirpath, model, missionSummarySystemPrompt,
+		buildMissionSummaryPrompt(userMessages, lastAssistantText), missionSummaryMaxOutputLen)
+	if err != nil {
+		return err
+	}
+	return s.db.SetMissionAISummary(missionRecord.ID, summary, missionRecord.PromptCount)
+}
+
+// buildMissionSummaryPrompt renders the conversation excerpt handed to the
+// summarizer.
+func buildMissionSummaryPrompt(userMessages []string, lastAssistantText string) string {
+	var b strings.Builder
+	b.WriteString("Summarize this coding session.\n\nRecent user messages:\n")
+	for _, msg := range userMessages {
+		fmt.Fprintf(&b, "- %s\n", truncateSummaryInput(msg))
+	}
+	if lastAssistantText != "" {
+		fmt.Fprintf(&b, "\nAssistant's latest reply:\n%s\n", truncateSummaryInput(lastAssistantText))
+	}
+	return b.String()
+}
+
+func truncateSummaryInput(text string) string {
+	text = strings.TrimSpace(text)
+	if len(text) > missionSummaryMaxInputLen {
+		return text[:missionSummaryMaxInputLen-3] + "..."
+	}
+	return text
+}
+
+// handleSummarizeMission handles POST /missions/{id}/summarize. It generates
+// the mission's AI summary now, regardless of the configured trigger, and
+// returns the updated mission.
+func (s *Server) handleSummarizeMission(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	missionRecord, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if missionRecord == nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	if _, inFlight := s.missionSummariesInFlight.LoadOrStore(resolvedID, struct{}{}); inFlight {
+		return newHTTPErrorf(http.StatusConflict, "mission %s is already being summarized", missionRecord.ShortID)
+	}
+	defer s.missionSummariesInFlight.Delete(resolvedID)
+
+	if err := s.summarizeMission(r.Context(), missionRecord); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to summarize mission %s: %s", missionRecord.ShortID, err.Error())
+	}
+
+	updated, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	resp := toMissionResponse(updated)
+	s.enrichMissionResponse(&resp)
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestMissionSummaryDue(t *testing.T) {
+	promptsCfg := &config.AgencConfig{MissionSummary: &config.MissionSummaryConfig{EveryPrompts: 5}}
+	idleCfg := &config.AgencConfig{MissionSummary: &config.MissionSummaryConfig{Trigger: config.MissionSummaryTriggerIdle}}
+	neverCfg := &config.AgencConfig{MissionSummary: &config.MissionSummaryConfig{Trigger: config.MissionSummaryTriggerNever}}
+
+	tests := []struct {
+		name        string
+		cfg         *config.AgencConfig
+		promptCount int
+		lastCount   int
+		isIdle      bool
+		want        bool
+	}{
+		{"prompts below threshold", promptsCfg, 4, 0, false, false},
+		{"prompts at threshold", promptsCfg, 5, 0, false, true},
+		{"prompts since last summary", promptsCfg, 12, 8, false, false},
+		{"prompts ignores idle", promptsCfg, 10, 0, true, false},
+		{"idle with new prompts", idleCfg, 3, 2, true, true},
+		{"idle without new prompts", idleCfg, 3, 3, true, false},
+		{"idle ignores prompts", idleCfg, 30, 0, false, false},
+		{"never", neverCfg, 30, 0, true, false},
+		{"default every ten prompts", &config.AgencConfig{}, 10, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missionSummaryDue(tt.cfg, tt.promptCount, tt.lastCount, tt.isIdle); got != tt.want {
+				t.Errorf("missionSummaryDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMissionSummaryPrompt(t *testing.T) {
+	got := buildMissionSummaryPrompt([]string{"fix the login bug", strings.Repeat("x", 1000)}, "Patched the session check.")
+	if !strings.Contains(got, "- fix the login bug\n") {
+		t.Errorf("expected user messages as bullets, got %q", got)
+	}
+	if !strings.Contains(got, "Patched the session check.") {
+		t.Errorf("expected the assistant's latest reply, got %q", got)
+	}
+	if strings.Contains(got, strings.Repeat("x", missionSummaryMaxInputLen)) {
+		t.Error("expected long messages to be truncated")
+	}
+}
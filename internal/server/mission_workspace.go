@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// validateMissionRepos checks the Repos of a create request. A single entry
+// is folded into Repo; two or more make a multi-repo mission, which needs
+// distinct repos that are all in the library and can't be combined with
+// Repo, an Adjutant, a clone, or a path.
+func (s *Server) validateMissionRepos(req *CreateMissionRequest) error {
+	if len(req.Repos) == 0 {
+		return nil
+	}
+	if req.Repo != "" {
+		return newHTTPError(http.StatusBadRequest, "repo and repos are mutually exclusive")
+	}
+	if len(req.Repos) == 1 {
+		req.Repo = req.Repos[0]
+		req.Repos = nil
+		return nil
+	}
+	if req.Adjutant {
+		return newHTTPError(http.StatusBadRequest, "adjutant missions cannot have repos")
+	}
+	if req.CloneFrom != "" {
+		return newHTTPError(http.StatusBadRequest, "a cloned mission takes its source's repos")
+	}
+	if req.Path != "" {
+		return newHTTPError(http.StatusBadRequest, "path is not supported for multi-repo missions")
+	}
+
+	seen := make(map[string]bool, len(req.Repos))
+	for _, repoName := range req.Repos {
+		if seen[repoName] {
+			return newHTTPErrorf(http.StatusBadRequest, "repo '%s' is listed more than once", repoName)
+		}
+		seen[repoName] = true
+		if !config.IsCanonicalRepoName(repoName) {
+			return newHTTPErrorf(http.StatusBadRequest, "invalid repo name '%s'; must be in canonical format 'host/owner/repo'", repoName)
+		}
+		if _, err := os.Stat(config.GetRepoDirpath(s.agencDirpath, repoName)); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "repo '%s' is not in the repo library; add it with 'agenc repo add'", repoName)
+		}
+	}
+	return nil
+}
+
+// createWorkspaceMissionDir builds a multi-repo mission's directory: each
+// repo is copied from the library side by side under agent/, on its
+// autoBranch branch if it has one, with the dependency cache and upstream
+// remote a single-repo mission of that repo would get.
+func (s *Server) createWorkspaceMissionDir(missionRecord *database.Mission, repoNames []string, prompt string) error {
+	cfg := s.getConfig()
+	repos := make([]mission.WorkspaceRepo, len(repoNames))
+	for i, repoName := range repoNames {
+		repoDirpath := config.GetRepoDirpath(s.agencDirpath, repoName)
+		if mission.IsRepoStale(repoDirpath, 24*time.Hour) {
+			s.logger.Printf("Mission create: force-pulling stale repo '%s' before copy", repoName)
+			if err := mission.ForceUpdateRepo(repoDirpath); err != nil {
+				s.logger.Printf("Mission create: failed to pull stale repo '%s': %v (proceeding with stale copy)", repoName, err)
+			}
+		}
+		repos[i] = mission.WorkspaceRepo{
+			Name:          repoName,
+			SourceDirpath: repoDirpath,
+			Description:   cfg.GetRepoDescription(repoName),
+			BranchName:    s.resolveAutoBranchName(repoName, missionRecord, prompt),
+		}
+	}
+
+	if _, err := mission.CreateWorkspaceMissionDir(s.agencDirpath, missionRecord.ID, repos); err != nil {
+		return err
+	}
+
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+	for i, dirname := range mission.WorkspaceRepoDirnames(repoNames) {
+		repoDirpath := filepath.Join(agentDirpath, dirname)
+		s.linkDependencyCache(repoNames[i], repoDirpath, cfg.GetPostUpdateHookCache(repoNames[i]))
+		s.addUpstreamRemoteIfFork(repoNames[i], repoDirpath)
+	}
+	return nil
+}
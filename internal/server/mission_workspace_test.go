@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestValidateMissionRepos(t *testing.T) {
+	s := &Server{agencDirpath: t.TempDir()}
+	for _, repoName := range []string{"github.com/acme/api", "github.com/acme/client"} {
+		if err := os.MkdirAll(config.GetRepoDirpath(s.agencDirpath, repoName), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	both := []string{"github.com/acme/api", "github.com/acme/client"}
+
+	tests := []struct {
+		name    string
+		req     CreateMissionRequest
+		wantErr bool
+	}{
+		{"none", CreateMissionRequest{}, false},
+		{"two repos", CreateMissionRequest{Repos: both}, false},
+		{"with repo", CreateMissionRequest{Repo: "github.com/acme/api", Repos: both}, true},
+		{"adjutant", CreateMissionRequest{Repos: both, Adjutant: true}, true},
+		{"clone", CreateMissionRequest{Repos: both, CloneFrom: "abc"}, true},
+		{"path", CreateMissionRequest{Repos: both, Path: "services"}, true},
+		{"duplicate", CreateMissionRequest{Repos: []string{"github.com/acme/api", "github.com/acme/api"}}, true},
+		{"not in library", CreateMissionRequest{Repos: []string{"github.com/acme/api", "github.com/acme/web"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.validateMissionRepos(&tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err != nil && httpStatusFromError(err) != http.StatusBadRequest {
+				t.Errorf("expected a 400, got %d", httpStatusFromError(err))
+			}
+		})
+	}
+}
+
+func TestValidateMissionRepos_SingleRepoFoldsIntoRepo(t *testing.T) {
+	s := &Server{agencDirpath: t.TempDir()}
+	req := CreateMissionRequest{Repos: []string{"github.com/acme/api"}}
+	if err := s.validateMissionRepos(&req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Repo != "github.com/acme/api" || req.Repos != nil {
+		t.Errorf("expected the single repo to move to Repo, got Repo=%q Repos=%v", req.Repo, req.Repos)
+	}
+}
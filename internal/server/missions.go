@@ -1,6 +1,7 @@
 package server
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,10 +9,12 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"path/filepath"
 
@@ -22,27 +25,45 @@ import (
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/devcontainer"
 	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/session"
+	"github.com/odyssey/agenc/internal/tmux"
+	"github.com/odyssey/agenc/internal/version"
 )
 
 // MissionResponse is the JSON representation of a mission returned by the API.
 type MissionResponse struct {
-	ID                   string     `json:"id"`
-	ShortID              string     `json:"short_id"`
-	Prompt               string     `json:"prompt"`
-	Status               string     `json:"status"`
-	GitRepo              string     `json:"git_repo"`
-	LastHeartbeat        *time.Time `json:"last_heartbeat"`
-	LastUserPromptAt     *time.Time `json:"last_user_prompt_at"`
-	SessionName          string     `json:"session_name"`
-	SessionNameUpdatedAt *time.Time `json:"session_name_updated_at"`
-	Source               *string    `json:"source"`
-	SourceID             *string    `json:"source_id"`
-	SourceMetadata       *string    `json:"source_metadata"`
-	ConfigCommit         *string    `json:"config_commit"`
-	TmuxPane             *string    `json:"tmux_pane"`
-	PromptCount          int        `json:"prompt_count"`
-	CreatedAt            time.Time  `json:"created_at"`
-	UpdatedAt            time.Time  `json:"updated_at"`
+	ID                   string            `json:"id"`
+	ShortID              string            `json:"short_id"`
+	Prompt               string            `json:"prompt"`
+	Status               string            `json:"status"`
+	GitRepo              string            `json:"git_repo"`
+	LastHeartbeat        *time.Time        `json:"last_heartbeat"`
+	LastUserPromptAt     *time.Time        `json:"last_user_prompt_at"`
+	SessionName          string            `json:"session_name"`
+	SessionNameUpdatedAt *time.Time        `json:"session_name_updated_at"`
+	Source               *string           `json:"source"`
+	SourceID             *string           `json:"source_id"`
+	SourceMetadata       *string           `json:"source_metadata"`
+	ConfigCommit         *string           `json:"config_commit"`
+	TmuxPane             *string           `json:"tmux_pane"`
+	PromptCount          int               `json:"prompt_count"`
+	Tags                 []string          `json:"tags"`
+	PRURL                string            `json:"pr_url"`
+	MaxPrompts           int               `json:"max_prompts"`
+	BudgetUSD            float64           `json:"budget_usd"`
+	Owner                string            `json:"owner,omitempty"`
+	SharedWith           []string          `json:"shared_with,omitempty"`
+	DisplayName          string            `json:"display_name,omitempty"`
+	AISummary            string            `json:"ai_summary,omitempty"`
+	Project              string            `json:"project,omitempty"`
+	LastError            string            `json:"last_error,omitempty"`
+	AgentDirBytes        int64             `json:"agent_dir_bytes,omitempty"`
+	Env                  map[string]string `json:"env,omitempty"`
+	GitRepos             []string          `json:"git_repos,omitempty"`
+	GitRef               string            `json:"git_ref,omitempty"`
+	GitRefCommit         string            `json:"git_ref_commit,omitempty"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
 
 	// ResolvedSessionTitle is derived from the active session's title chain:
 	// custom_title > agenc_custom_title > auto_summary. Empty if no session exists.
@@ -58,6 +79,28 @@ type MissionResponse struct {
 	// IsAttached is true if the mission's tmux pane is currently linked into a
 	// session outside the pool. Computed live per request; never persisted.
 	IsAttached bool `json:"is_attached"`
+
+	// QueuePosition is the mission's 1-based place in the start queue when it
+	// is waiting for a slot under missionsMaxConcurrent; 0 otherwise.
+	QueuePosition int `json:"queue_position,omitempty"`
+
+	// BranchConflicts, set only when a mission is created, lists the short
+	// IDs of other active missions on the same repo and branch.
+	BranchConflicts []string `json:"branch_conflicts,omitempty"`
+
+	// UsedCachedClone, set only when a mission is created, is true when the
+	// repo's remote was unreachable and the mission started from the
+	// library's cached clone without refreshing it.
+	UsedCachedClone bool `json:"used_cached_clone,omitempty"`
+
+	// IsOversized is true if the mission's agent directory was last measured
+	// over the missionSizeLimit maxSize.
+	IsOversized bool `json:"is_oversized,omitempty"`
+
+	// WrapperHealth is the result of the server's recent pings of the
+	// mission's wrapper. Nil when the wrapper isn't running or hasn't been
+	// pinged yet.
+	WrapperHealth *WrapperHealth `json:"wrapper_health,omitempty"`
 }
 
 // ToMission converts a MissionResponse to a database.Mission.
@@ -78,15 +121,44 @@ func (mr *MissionResponse) ToMission() *database.Mission {
 		ConfigCommit:         mr.ConfigCommit,
 		TmuxPane:             mr.TmuxPane,
 		PromptCount:          mr.PromptCount,
+		Tags:                 mr.Tags,
+		PRURL:                mr.PRURL,
+		MaxPrompts:           mr.MaxPrompts,
+		BudgetUSD:            mr.BudgetUSD,
+		Owner:                mr.Owner,
+		SharedWith:           mr.SharedWith,
+		DisplayName:          mr.DisplayName,
+		AISummary:            mr.AISummary,
+		Project:              mr.Project,
+		LastError:            mr.LastError,
+		AgentDirBytes:        mr.AgentDirBytes,
+		Env:                  mr.Env,
+		GitRepos:             mr.GitRepos,
+		GitRef:               mr.GitRef,
+		GitRefCommit:         mr.GitRefCommit,
 		CreatedAt:            mr.CreatedAt,
 		UpdatedAt:            mr.UpdatedAt,
 		ResolvedSessionTitle: mr.ResolvedSessionTitle,
 		IsAdjutant:           mr.IsAdjutant,
 		ClaudeState:          mr.ClaudeState,
 		IsAttached:           mr.IsAttached,
+		QueuePosition:        mr.QueuePosition,
+		BranchConflicts:      mr.BranchConflicts,
+		UsedCachedClone:      mr.UsedCachedClone,
+		IsOversized:          mr.IsOversized,
+		WrapperHealth:        mr.wrapperHealthStatus(),
 	}
 }
 
+// wrapperHealthStatus returns the status of the mission's wrapper health, or
+// "" when it has none.
+func (mr *MissionResponse) wrapperHealthStatus() string {
+	if mr.WrapperHealth == nil {
+		return ""
+	}
+	return mr.WrapperHealth.Status
+}
+
 func toMissionResponse(m *database.Mission) MissionResponse {
 	return MissionResponse{
 		ID:                   m.ID,
@@ -104,11 +176,30 @@ func toMissionResponse(m *database.Mission) MissionResponse {
 		ConfigCommit:         m.ConfigCommit,
 		TmuxPane:             m.TmuxPane,
 		PromptCount:          m.PromptCount,
+		Tags:                 m.Tags,
+		PRURL:                m.PRURL,
+		MaxPrompts:           m.MaxPrompts,
+		BudgetUSD:            m.BudgetUSD,
+		Owner:                m.Owner,
+		SharedWith:           m.SharedWith,
+		DisplayName:          m.DisplayName,
+		AISummary:            m.AISummary,
+		Project:              m.Project,
+		LastError:            m.LastError,
+		AgentDirBytes:        m.AgentDirBytes,
+		Env:                  m.Env,
+		GitRepos:             m.GitRepos,
+		GitRef:               m.GitRef,
+		GitRefCommit:         m.GitRefCommit,
 		CreatedAt:            m.CreatedAt,
 		UpdatedAt:            m.UpdatedAt,
 		ResolvedSessionTitle: m.ResolvedSessionTitle,
 		IsAdjutant:           m.IsAdjutant,
 		IsAttached:           m.IsAttached,
+		QueuePosition:        m.QueuePosition,
+		BranchConflicts:      m.BranchConflicts,
+		UsedCachedClone:      m.UsedCachedClone,
+		IsOversized:          m.IsOversized,
 		// ClaudeState intentionally omitted — it is set post-conversion by
 		// enrichMissionResponse; database.Mission.ClaudeState is always nil here.
 	}
@@ -138,9 +229,23 @@ func resolveSessionTitle(s *database.Session) string {
 	return s.AutoSummary
 }
 
+// resolveMissionTitle returns the title shown for a mission: the display
+// name set with 'agenc mission rename' when there is one, otherwise the
+// active session's title.
+func resolveMissionTitle(m *database.Mission, activeSession *database.Session) string {
+	if m.DisplayName != "" {
+		return m.DisplayName
+	}
+	return resolveSessionTitle(activeSession)
+}
+
 // enrichMissionWithSessionTitle populates the ResolvedSessionTitle field
-// on a mission by looking up its active session.
+// on a mission from its display name or active session.
 func (s *Server) enrichMissionWithSessionTitle(m *database.Mission) {
+	if m.DisplayName != "" {
+		m.ResolvedSessionTitle = m.DisplayName
+		return
+	}
 	activeSession, err := s.db.GetActiveSession(m.ID)
 	if err != nil {
 		return
@@ -169,23 +274,21 @@ type wrapperStatusResponse struct {
 // queryWrapperClaudeState queries the wrapper for a running mission's Claude state.
 // Returns nil if the wrapper is not running or unreachable.
 func (s *Server) queryWrapperClaudeState(missionID string) *string {
-	pidFilepath := config.GetMissionPIDFilepath(s.agencDirpath, missionID)
+	return QueryWrapperClaudeState(s.agencDirpath, missionID)
+}
+
+// QueryWrapperClaudeState asks a mission's wrapper over its socket for
+// Claude's state ("idle", "busy", or "needs_attention"). Returns nil if the
+// wrapper is not running or unreachable. Usable without a server, e.g. by
+// CLI commands that must stay cheap.
+func QueryWrapperClaudeState(agencDirpath string, missionID string) *string {
+	pidFilepath := config.GetMissionPIDFilepath(agencDirpath, missionID)
 	pid, err := ReadPID(pidFilepath)
 	if err != nil || pid == 0 || !IsProcessRunning(pid) {
 		return nil
 	}
 
-	socketFilepath := config.GetMissionSocketFilepath(s.agencDirpath, missionID)
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
-				return net.DialTimeout("unix", socketFilepath, wrapperQueryTimeout)
-			},
-		},
-		Timeout: wrapperQueryTimeout,
-	}
-
-	resp, err := httpClient.Get("http://wrapper/status")
+	resp, err := newWrapperSocketClient(agencDirpath, missionID).Get("http://wrapper/status")
 	if err != nil {
 		return nil
 	}
@@ -198,17 +301,57 @@ func (s *Server) queryWrapperClaudeState(missionID string) *string {
 	return &status.ClaudeState
 }
 
-// enrichMissionResponse populates transient fields (ClaudeState, IsAdjutant)
-// by querying the running wrapper and checking the filesystem.
+// newWrapperSocketClient returns an HTTP client that talks to a mission's
+// wrapper over its unix socket, bounded by wrapperQueryTimeout.
+func newWrapperSocketClient(agencDirpath string, missionID string) *http.Client {
+	return newWrapperSocketClientWithTimeout(agencDirpath, missionID, wrapperQueryTimeout)
+}
+
+// newWrapperSocketClientWithTimeout is newWrapperSocketClient bounded by
+// timeout instead. A wrapper too old for this server's build fails requests
+// with a *version.IncompatibleAPIError.
+func newWrapperSocketClientWithTimeout(agencDirpath string, missionID string, timeout time.Duration) *http.Client {
+	socketFilepath := config.GetMissionSocketFilepath(agencDirpath, missionID)
+	return &http.Client{
+		Transport: &version.APIVersionTransport{
+			Base: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return net.DialTimeout("unix", socketFilepath, timeout)
+				},
+			},
+			Version:        version.WrapperAPIVersion,
+			Peer:           "mission " + database.ShortID(missionID) + "'s wrapper",
+			MinPeerVersion: version.MinWrapperAPIVersion,
+			Remedy:         "reload it with 'agenc mission reload " + database.ShortID(missionID) + "' so it runs the current agenc version",
+		},
+		Timeout: timeout,
+	}
+}
+
+// enrichMissionResponse populates transient fields (ClaudeState, IsAdjutant,
+// QueuePosition, WrapperHealth) by querying the running wrapper, checking the
+// filesystem, and looking the mission up in the start queue and the wrapper
+// health sweep's results.
 func (s *Server) enrichMissionResponse(resp *MissionResponse) {
 	resp.ClaudeState = s.queryWrapperClaudeState(resp.ID)
 	resp.IsAdjutant = config.IsMissionAdjutant(s.agencDirpath, resp.ID)
+	resp.QueuePosition = s.missionQueue.position(resp.ID)
+	resp.WrapperHealth = s.wrapperHealth.get(resp.ID)
 }
 
+// missionNextCursorHeader names the response header GET /missions sets when
+// a limited page has more missions after it; its value is the 'cursor' for
+// the next page.
+const missionNextCursorHeader = "X-Next-Cursor"
+
 // handleListMissions handles GET /missions.
 // Query params:
 //   - include_archived=true — include archived missions
 //   - tmux_pane=<id> — return the single mission running in the given tmux pane
+//   - sort=activity|created|updated — order, newest first (default activity)
+//   - limit=<n>, offset=<n> — page through the ordered missions
+//   - cursor=<mission id> — start after this mission instead of at an offset
+//   - fields=<name,...> — return only these MissionResponse fields
 func (s *Server) handleListMissions(w http.ResponseWriter, r *http.Request) error {
 	// If tmux_pane is specified, return the single mission for that pane
 	tmuxPane := r.URL.Query().Get("tmux_pane")
@@ -223,6 +366,7 @@ func (s *Server) handleListMissions(w http.ResponseWriter, r *http.Request) erro
 		}
 		s.enrichMissionWithSessionTitle(mission)
 		s.markMissionsAttached([]*database.Mission{mission})
+		s.markMissionsOversized([]*database.Mission{mission})
 		resp := toMissionResponse(mission)
 		s.enrichMissionResponse(&resp)
 		writeJSON(w, http.StatusOK, []MissionResponse{resp})
@@ -252,33 +396,111 @@ func (s *Server) handleListMissions(w http.ResponseWriter, r *http.Request) erro
 		}
 		params.Until = &t
 	}
+	if tagsStr := r.URL.Query().Get("tags"); tagsStr != "" {
+		for _, tag := range strings.Split(tagsStr, ",") {
+			if err := database.ValidateTag(tag); err != nil {
+				return newHTTPError(http.StatusBadRequest, "invalid 'tags' parameter: "+err.Error())
+			}
+			params.Tags = append(params.Tags, tag)
+		}
+	}
+
+	params.Project = r.URL.Query().Get("project")
+
+	if err := s.parseMissionPageParams(r, &params); err != nil {
+		return err
+	}
+	fields, err := parseMissionFields(r.URL.Query().Get("fields"))
+	if err != nil {
+		return err
+	}
 
+	// Fetch one extra mission to learn whether another page follows
+	limit := params.Limit
+	if limit > 0 {
+		params.Limit = limit + 1
+	}
 	missions, err := s.db.ListMissions(params)
 	if err != nil {
 		return newHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	if limit > 0 && len(missions) > limit {
+		missions = missions[:limit]
+		w.Header().Set(missionNextCursorHeader, missions[limit-1].ID)
+	}
 
-	for _, m := range missions {
-		s.enrichMissionWithSessionTitle(m)
+	// Skip the per-mission lookups for fields the caller didn't ask for
+	if selectsAnyField(fields, "resolved_session_title") {
+		for _, m := range missions {
+			s.enrichMissionWithSessionTitle(m)
+		}
+	}
+	if selectsAnyField(fields, "is_attached") {
+		s.markMissionsAttached(missions)
 	}
-	s.markMissionsAttached(missions)
+	s.markMissionsOversized(missions)
 
 	responses := toMissionResponses(missions)
 
-	var wg sync.WaitGroup
-	for i := range responses {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
-			s.enrichMissionResponse(&responses[idx])
-		}(i)
+	if selectsAnyField(fields, missionFieldsFromWrapper...) {
+		var wg sync.WaitGroup
+		for i := range responses {
+			wg.Add(1)
+			go func(idx int) {
+				defer wg.Done()
+				s.enrichMissionResponse(&responses[idx])
+			}(i)
+		}
+		wg.Wait()
 	}
-	wg.Wait()
 
+	if fields != nil {
+		writeJSON(w, http.StatusOK, selectMissionFields(responses, fields))
+		return nil
+	}
 	writeJSON(w, http.StatusOK, responses)
 	return nil
 }
 
+// parseMissionPageParams reads GET /missions' sort and paging parameters
+// into params. A cursor may be a short ID.
+func (s *Server) parseMissionPageParams(r *http.Request, params *database.ListMissionsParams) error {
+	query := r.URL.Query()
+
+	sort, err := database.ParseMissionSort(query.Get("sort"))
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid 'sort' parameter: "+err.Error())
+	}
+	params.Sort = sort
+
+	for _, p := range []struct {
+		name string
+		dest *int
+	}{{"limit", &params.Limit}, {"offset", &params.Offset}} {
+		raw := query.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return newHTTPErrorf(http.StatusBadRequest, "invalid '%s' parameter: expected a non-negative integer", p.name)
+		}
+		*p.dest = n
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		if params.Offset > 0 {
+			return newHTTPError(http.StatusBadRequest, "'cursor' and 'offset' cannot be combined")
+		}
+		resolvedID, err := s.db.ResolveMissionID(cursor)
+		if err != nil {
+			return newHTTPError(http.StatusBadRequest, "invalid 'cursor' parameter: mission not found: "+cursor)
+		}
+		params.After = resolvedID
+	}
+	return nil
+}
+
 // handleGetMission handles GET /missions/{id}.
 func (s *Server) handleGetMission(w http.ResponseWriter, r *http.Request) error {
 	id := r.PathValue("id")
@@ -298,6 +520,7 @@ func (s *Server) handleGetMission(w http.ResponseWriter, r *http.Request) error
 
 	s.enrichMissionWithSessionTitle(mission)
 	s.markMissionsAttached([]*database.Mission{mission})
+	s.markMissionsOversized([]*database.Mission{mission})
 	resp := toMissionResponse(mission)
 	s.enrichMissionResponse(&resp)
 	writeJSON(w, http.StatusOK, resp)
@@ -326,9 +549,52 @@ type CreateMissionRequest struct {
 	SourceID       string `json:"source_id"`
 	SourceMetadata string `json:"source_metadata"`
 	CloneFrom      string `json:"clone_from"`
-	NoFocus        bool   `json:"no_focus"`
+	// CloneMode selects what a CloneFrom mission takes from its source: one
+	// of the CloneMode* constants. Empty means CloneModeWorkspace.
+	CloneMode string `json:"clone_mode,omitempty"`
+	NoFocus   bool   `json:"no_focus"`
+	// MaxPrompts and BudgetUSD cap the mission's prompt count and estimated
+	// spend in USD. The wrapper gracefully stops Claude once either limit is
+	// reached. Zero means no limit.
+	MaxPrompts int     `json:"max_prompts,omitempty"`
+	BudgetUSD  float64 `json:"budget_usd,omitempty"`
+	// Sandbox runs the mission's Claude in a container even when its repo's
+	// isolation is not "container". Cloned missions inherit it.
+	Sandbox bool `json:"sandbox,omitempty"`
+	// Path is a directory within the repo that Claude runs in and treats as
+	// its project root, for monorepos. Empty means the repo root. Cloned
+	// missions inherit it.
+	Path string `json:"path,omitempty"`
+	// Backend is the agent backend the mission runs instead of its repo's
+	// (see config.AgentBackendClaude). Cloned missions inherit it.
+	Backend string `json:"backend,omitempty"`
+	// Project is the project the mission joins. When empty, cron runs join
+	// their cron's project and child and cloned missions their source's.
+	Project string `json:"project,omitempty"`
+	// Env overrides environment variables for every Claude spawn of the
+	// mission, on top of its repo's repoConfig env; values may reference
+	// secret://NAME. Recorded on the mission so reloads and resumes get the
+	// same environment. Cloned missions inherit it when Env is empty.
+	Env map[string]string `json:"env,omitempty"`
+	// Repos, when it names two or more repos, creates a multi-repo mission:
+	// each repo is checked out side by side under agent/, which gets a
+	// generated workspace README. Mutually exclusive with Repo; a single
+	// entry is treated as Repo.
+	Repos []string `json:"repos,omitempty"`
 }
 
+// Clone modes for CreateMissionRequest.CloneMode.
+const (
+	// CloneModeWorkspace copies the source's agent directory and starts a
+	// fresh conversation
+	CloneModeWorkspace = "workspace"
+	// CloneModeConversation forks the source's latest conversation into a
+	// clean checkout of its repo
+	CloneModeConversation = "conversation"
+	// CloneModeBoth copies the agent directory and forks the conversation
+	CloneModeBoth = "both"
+)
+
 // handleCreateMission handles POST /missions.
 // Creates a mission record, sets up the mission directory, and spawns the
 // wrapper process in the caller's tmux session (or headless).
@@ -348,8 +614,72 @@ func (s *Server) handleCreateMission(w http.ResponseWriter, r *http.Request) err
 		return err
 	}
 
+	// Scheduled cron firings are skipped while the cron's previous run is
+	// still going, so a slow job never piles up overlapping missions, during
+	// quiet hours, and while an 'after' upstream cron has not succeeded yet
+	// today. The cron's firing lock is held until the new run is recorded so
+	// simultaneous firings see each other.
+	if req.Source == "cron" && req.SourceID != "" {
+		unlockCronFiring := s.lockCronFiring(req.SourceID)
+		defer unlockCronFiring()
+		if err := s.checkCronOverlap(req); err != nil {
+			return err
+		}
+		if err := s.checkCronQuietHours(req, time.Now()); err != nil {
+			return err
+		}
+		if err := s.checkCronDependency(req); err != nil {
+			return err
+		}
+		req.Prompt = s.renderCronRunPrompt(req, time.Now())
+	}
+
+	if req.MaxPrompts < 0 {
+		return newHTTPError(http.StatusBadRequest, "max_prompts cannot be negative")
+	}
+	if req.BudgetUSD < 0 {
+		return newHTTPError(http.StatusBadRequest, "budget_usd cannot be negative")
+	}
+	if err := validateEnvKeys(req.Env); err != nil {
+		return err
+	}
+	if err := s.validateMissionRepos(&req); err != nil {
+		return err
+	}
+	if req.Sandbox && req.Adjutant {
+		return newHTTPError(http.StatusBadRequest, "adjutant missions cannot be sandboxed; they need the agenc CLI on the host")
+	}
+	if err := s.validateMissionBackend(req); err != nil {
+		return err
+	}
+	if req.Backend == "" || req.Backend == config.AgentBackendClaude {
+		if err := claudeconfig.ValidateMissionSettingsSources(s.agencDirpath); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "%#s", err)
+		}
+	}
+	subpath, err := mission.CleanSubpath(req.Path)
+	if err != nil {
+		return newHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if subpath != "" && (req.Adjutant || (req.Repo == "" && req.CloneFrom == "")) {
+		return newHTTPError(http.StatusBadRequest, "path requires a mission with a repo")
+	}
+	req.Path = subpath
+
+	project, err := s.resolveMissionProject(req)
+	if err != nil {
+		return err
+	}
+
 	// Build creation params
-	createParams := &database.CreateMissionParams{}
+	createParams := &database.CreateMissionParams{
+		MaxPrompts: req.MaxPrompts,
+		BudgetUSD:  req.BudgetUSD,
+		Owner:      s.missionOwnerForRequest(r),
+		Project:    project,
+		Env:        req.Env,
+		GitRepos:   req.Repos,
+	}
 	if req.Source != "" {
 		createParams.Source = &req.Source
 	}
@@ -363,6 +693,19 @@ func (s *Server) handleCreateMission(w http.ResponseWriter, r *http.Request) err
 		createParams.ConfigCommit = &commitHash
 	}
 
+	// A cron whose resumePolicy reuses its previous run either continues
+	// that mission's conversation in place or forks it into a fresh checkout
+	if req.Source == "cron" && req.SourceID != "" && req.CloneFrom == "" {
+		previous, action := s.resolveCronResume(req)
+		switch action {
+		case cronResumeActionContinue:
+			return s.continueCronMission(w, previous, req)
+		case cronResumeActionFork:
+			req.CloneFrom = previous.ID
+			req.CloneMode = CloneModeConversation
+		}
+	}
+
 	// Handle clone-from request
 	if req.CloneFrom != "" {
 		return s.handleCreateClonedMission(w, req, createParams)
@@ -374,12 +717,36 @@ func (s *Server) handleCreateMission(w http.ResponseWriter, r *http.Request) err
 	if gitRepoName != "" {
 		gitCloneDirpath = config.GetRepoDirpath(s.agencDirpath, gitRepoName)
 	}
+	if req.Path != "" {
+		if err := mission.CheckSubpathExists(gitCloneDirpath, req.Path); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "invalid path for '%s': %v", gitRepoName, err)
+		}
+	}
+
+	// Force-pull the library clone if it hasn't been fetched recently.
+	// This prevents missions from starting with a stale copy of the repo.
+	usedCachedClone := false
+	if gitCloneDirpath != "" && !req.Adjutant {
+		usedCachedClone = s.refreshStaleLibraryClone(gitRepoName, gitCloneDirpath)
+	}
+
+	// A cron pinned to a ref starts from it instead of the default branch,
+	// and the mission records the commit it resolved to
+	cronRef, err := s.resolveCronRef(req, gitRepoName, gitCloneDirpath)
+	if err != nil {
+		return err
+	}
+	if cronRef != nil {
+		createParams.GitRef = cronRef.Name
+		createParams.GitRefCommit = cronRef.Commit
+	}
 
 	// Create database record
 	missionRecord, err := s.db.CreateMission(gitRepoName, createParams)
 	if err != nil {
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission: %s", err.Error())
 	}
+	missionRecord.UsedCachedClone = usedCachedClone
 
 	// For adjutant missions, write marker file before creating mission dir
 	if req.Adjutant {
@@ -396,37 +763,80 @@ func (s *Server) handleCreateMission(w http.ResponseWriter, r *http.Request) err
 		gitCloneDirpath = ""
 	}
 
-	// Force-pull the library clone if it hasn't been fetched recently.
-	// This prevents missions from starting with a stale copy of the repo.
-	if gitCloneDirpath != "" && mission.IsRepoStale(gitCloneDirpath, 24*time.Hour) {
-		s.logger.Printf("Mission create: force-pulling stale repo '%s' before copy", gitRepoName)
-		if err := mission.ForceUpdateRepo(gitCloneDirpath); err != nil {
-			s.logger.Printf("Mission create: failed to pull stale repo '%s': %v (proceeding with stale copy)", gitRepoName, err)
-		}
-	}
-
 	// Create mission directory structure
-	if _, err := mission.CreateMissionDir(s.agencDirpath, missionRecord.ID, gitRepoName, gitCloneDirpath); err != nil {
+	workspaceMode := s.getConfig().GetWorkspaceMode(gitRepoName)
+	branchName := s.resolveAutoBranchName(gitRepoName, missionRecord, req.Prompt)
+	if len(req.Repos) > 0 {
+		if err := s.createWorkspaceMissionDir(missionRecord, req.Repos, req.Prompt); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission workspace: %s", err.Error())
+		}
+	} else if _, err := mission.CreateMissionDir(s.agencDirpath, missionRecord.ID, gitRepoName, gitCloneDirpath, workspaceMode, branchName); err != nil {
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission directory: %s", err.Error())
 	}
+	if cronRef != nil {
+		if err := s.checkoutCronRef(missionRecord, cronRef, workspaceMode, branchName); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to check out ref '%s': %s", cronRef.Name, err.Error())
+		}
+	}
+	if gitRepoName != "" {
+		agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+		s.linkDependencyCache(gitRepoName, agentDirpath, s.getConfig().GetPostUpdateHookCache(gitRepoName))
+		s.addUpstreamRemoteIfFork(gitRepoName, agentDirpath)
+	}
+	if req.Sandbox {
+		if err := writeSandboxMarker(s.agencDirpath, missionRecord.ID); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to write sandbox marker: %s", err.Error())
+		}
+	}
+	if req.Path != "" {
+		if err := writeMissionSubpath(s.agencDirpath, missionRecord.ID, req.Path); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to record mission path: %s", err.Error())
+		}
+	}
+	if req.Backend != "" {
+		if err := writeMissionBackend(s.agencDirpath, missionRecord.ID, req.Backend); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to record mission backend: %s", err.Error())
+		}
+	}
 
-	// Spawn wrapper process
-	if err := s.spawnWrapper(missionRecord, req); err != nil {
+	// Spawn wrapper process, or queue it behind missionsMaxConcurrent
+	queuePosition, err := s.startOrQueueMission(missionRecord, req)
+	if err != nil {
 		s.logger.Printf("Failed to spawn wrapper for mission %s: %v", missionRecord.ShortID, err)
 		// Mission was created successfully, just the wrapper failed
 		// Return the mission but log the error
 	}
+	missionRecord.QueuePosition = queuePosition
+	missionRecord.BranchConflicts = s.branchConflicts(missionRecord)
 
-	// Best-effort: surface cron-triggered missions as notifications so the
-	// user can find them via 'agenc notification manage' without polling.
+	s.publishMissionCreated(missionRecord)
 	if req.Source == "cron" {
-		s.createCronTriggeredNotification(missionRecord, req)
+		s.recordCronTrigger(missionRecord, req)
 	}
 
 	writeJSON(w, http.StatusCreated, toMissionResponse(missionRecord))
 	return nil
 }
 
+// recordCronTrigger does the bookkeeping for a cron firing that runs in
+// missionRecord: it records the run, retires a one-shot cron, surfaces the
+// firing as a notification so the user can find it via 'agenc notification
+// manage' without polling, and publishes cron.fired. Best-effort throughout.
+func (s *Server) recordCronTrigger(missionRecord *database.Mission, req CreateMissionRequest) {
+	if req.SourceID != "" {
+		s.recordCronRunStart(missionRecord, req)
+		s.completeOneShotCron(req)
+	}
+	s.createCronTriggeredNotification(missionRecord, req)
+
+	cronName, trigger := parseCronSourceMetadata(req.SourceMetadata)
+	s.publishEvent(EventCronFired, missionRecord.ID, map[string]string{
+		"cron_id": req.SourceID,
+		"cron":    cronName,
+		"trigger": trigger,
+	})
+}
+
 // createCronTriggeredNotification inserts a notification linked to the
 // just-created mission. Failures are logged and never propagated — the
 // mission has already been created and must succeed even if notification
@@ -537,31 +947,186 @@ func (s *Server) handleCreateClonedMission(w http.ResponseWriter, req CreateMiss
 		return newHTTPError(http.StatusNotFound, "source mission not found: "+req.CloneFrom)
 	}
 
+	cloneMode := req.CloneMode
+	if cloneMode == "" {
+		cloneMode = CloneModeWorkspace
+	}
+	if cloneMode != CloneModeWorkspace && cloneMode != CloneModeConversation && cloneMode != CloneModeBoth {
+		return newHTTPErrorf(http.StatusBadRequest, "clone_mode must be '%s', '%s', or '%s', got '%s'",
+			CloneModeWorkspace, CloneModeConversation, CloneModeBoth, cloneMode)
+	}
+
+	srcAgentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, sourceMission.ID)
+	srcProjectDirpath, err := claudeconfig.ComputeProjectDirpath(config.GetMissionWorkDirpath(s.agencDirpath, sourceMission.ID))
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if cloneMode != CloneModeWorkspace && len(session.ListProjectSessionIDs(srcProjectDirpath)) == 0 {
+		return newHTTPErrorf(http.StatusBadRequest, "mission %s has no conversation to clone", sourceMission.ShortID)
+	}
+
+	// The clone runs in the source's path unless told otherwise. A forked
+	// conversation is tied to the directory it ran in, so it can't move.
+	subpath := config.ReadMissionSubpath(s.agencDirpath, sourceMission.ID)
+	if req.Path != "" && req.Path != subpath {
+		if cloneMode != CloneModeWorkspace {
+			return newHTTPErrorf(http.StatusBadRequest, "a cloned conversation must run in the source mission's path ('%s'); use clone_mode '%s' to pick another", subpath, CloneModeWorkspace)
+		}
+		if err := mission.CheckSubpathExists(srcAgentDirpath, req.Path); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "invalid path for mission %s: %v", sourceMission.ShortID, err)
+		}
+		subpath = req.Path
+	}
+
+	if len(createParams.Env) == 0 {
+		createParams.Env = sourceMission.Env
+	}
+	createParams.GitRepos = sourceMission.GitRepos
+
+	// A forked cron run starts from the ref its cron is pinned to, like a
+	// fresh run would
+	var cronRef *mission.RepoRef
+	if cloneMode == CloneModeConversation && len(sourceMission.GitRepos) == 0 && sourceMission.GitRepo != "" {
+		if cronRef, err = s.resolveCronRef(req, sourceMission.GitRepo, config.GetRepoDirpath(s.agencDirpath, sourceMission.GitRepo)); err != nil {
+			return err
+		}
+	}
+	if cronRef != nil {
+		createParams.GitRef = cronRef.Name
+		createParams.GitRefCommit = cronRef.Commit
+	}
+
 	missionRecord, err := s.db.CreateMission(sourceMission.GitRepo, createParams)
 	if err != nil {
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission: %s", err.Error())
 	}
 
-	// Create empty mission dir structure, then copy agent dir from source
-	if _, err := mission.CreateMissionDir(s.agencDirpath, missionRecord.ID, "", ""); err != nil {
-		return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission directory: %s", err.Error())
+	dstAgentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
+
+	if cloneMode == CloneModeConversation && len(sourceMission.GitRepos) > 0 {
+		// A fresh multi-repo workspace, made the same way as for a new mission
+		if err := s.createWorkspaceMissionDir(missionRecord, sourceMission.GitRepos, req.Prompt); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission workspace: %s", err.Error())
+		}
+	} else if cloneMode == CloneModeConversation {
+		// A clean checkout, made the same way as for a new mission
+		var gitCloneDirpath string
+		if sourceMission.GitRepo != "" {
+			gitCloneDirpath = config.GetRepoDirpath(s.agencDirpath, sourceMission.GitRepo)
+		}
+		workspaceMode := s.getConfig().GetWorkspaceMode(sourceMission.GitRepo)
+		branchName := s.resolveAutoBranchName(sourceMission.GitRepo, missionRecord, req.Prompt)
+		if _, err := mission.CreateMissionDir(s.agencDirpath, missionRecord.ID, sourceMission.GitRepo, gitCloneDirpath, workspaceMode, branchName); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission directory: %s", err.Error())
+		}
+		if cronRef != nil {
+			if err := s.checkoutCronRef(missionRecord, cronRef, workspaceMode, branchName); err != nil {
+				return newHTTPErrorf(http.StatusInternalServerError, "failed to check out ref '%s': %s", cronRef.Name, err.Error())
+			}
+		}
+		if sourceMission.GitRepo != "" {
+			s.linkDependencyCache(sourceMission.GitRepo, dstAgentDirpath, s.getConfig().GetPostUpdateHookCache(sourceMission.GitRepo))
+			s.addUpstreamRemoteIfFork(sourceMission.GitRepo, dstAgentDirpath)
+		}
+	} else if mission.IsWorktree(srcAgentDirpath) {
+		// A worktree's .git file points at its own metadata in the library
+		// clone, so copying it verbatim would alias the source mission's
+		// worktree. Create a sibling worktree instead.
+		missionDirpath := config.GetMissionDirpath(s.agencDirpath, missionRecord.ID)
+		if err := os.MkdirAll(missionDirpath, 0755); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission directory: %s", err.Error())
+		}
+		if err := mission.CloneWorktree(srcAgentDirpath, dstAgentDirpath, mission.WorktreeBranchName(missionRecord.ID)); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to clone worktree: %s", err.Error())
+		}
+	} else {
+		// Create empty mission dir structure, then copy agent dir from source
+		if _, err := mission.CreateMissionDir(s.agencDirpath, missionRecord.ID, "", "", "", ""); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to create mission directory: %s", err.Error())
+		}
+		if err := mission.CopyAgentDir(srcAgentDirpath, dstAgentDirpath); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to copy agent directory: %s", err.Error())
+		}
 	}
 
-	srcAgentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, sourceMission.ID)
-	dstAgentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionRecord.ID)
-	if err := mission.CopyAgentDir(srcAgentDirpath, dstAgentDirpath); err != nil {
-		return newHTTPErrorf(http.StatusInternalServerError, "failed to copy agent directory: %s", err.Error())
+	if cloneMode != CloneModeWorkspace {
+		dstProjectDirpath, err := claudeconfig.ComputeProjectDirpath(filepath.Join(dstAgentDirpath, subpath))
+		if err != nil {
+			return newHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		if _, err := session.ForkLatestSession(srcProjectDirpath, dstProjectDirpath, srcAgentDirpath, dstAgentDirpath); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to clone conversation: %s", err.Error())
+		}
+	}
+
+	if req.Sandbox || config.IsMissionSandboxed(s.agencDirpath, sourceMission.ID) {
+		if err := writeSandboxMarker(s.agencDirpath, missionRecord.ID); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to write sandbox marker: %s", err.Error())
+		}
+	}
+	if subpath != "" {
+		if err := writeMissionSubpath(s.agencDirpath, missionRecord.ID, subpath); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to record mission path: %s", err.Error())
+		}
+	}
+	if backend := cmp.Or(req.Backend, config.ReadMissionBackend(s.agencDirpath, sourceMission.ID)); backend != "" {
+		if err := writeMissionBackend(s.agencDirpath, missionRecord.ID, backend); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to record mission backend: %s", err.Error())
+		}
 	}
 
 	// Spawn wrapper (may fail for interactive missions without tmux_session — that's OK)
-	if err := s.spawnWrapper(missionRecord, req); err != nil {
+	queuePosition, err := s.startOrQueueMission(missionRecord, req)
+	if err != nil {
 		s.logger.Printf("Failed to spawn wrapper for cloned mission %s: %v", missionRecord.ShortID, err)
 	}
+	missionRecord.QueuePosition = queuePosition
+	missionRecord.BranchConflicts = s.branchConflicts(missionRecord)
 
+	s.publishMissionCreated(missionRecord)
+	if req.Source == "cron" {
+		s.recordCronTrigger(missionRecord, req)
+	}
 	writeJSON(w, http.StatusCreated, toMissionResponse(missionRecord))
 	return nil
 }
 
+// writeSandboxMarker marks a mission as sandboxed so its wrapper runs Claude in
+// a container. The mission directory must already exist.
+func writeSandboxMarker(agencDirpath string, missionID string) error {
+	return os.WriteFile(config.GetMissionSandboxMarkerFilepath(agencDirpath, missionID), []byte{}, 0644)
+}
+
+// writeMissionSubpath records the directory within a mission's agent dir that
+// its wrapper runs Claude in. The mission directory must already exist.
+func writeMissionSubpath(agencDirpath string, missionID string, subpath string) error {
+	return os.WriteFile(config.GetMissionSubpathFilepath(agencDirpath, missionID), []byte(subpath+"\n"), 0644)
+}
+
+// writeMissionBackend records the agent backend a mission's wrapper runs in
+// place of its repo's. The mission directory must already exist.
+func writeMissionBackend(agencDirpath string, missionID string, backend string) error {
+	return os.WriteFile(config.GetMissionBackendFilepath(agencDirpath, missionID), []byte(backend+"\n"), 0644)
+}
+
+// validateMissionBackend rejects unknown backends and the combinations only
+// Claude supports: adjutant missions and sandbox containers.
+func (s *Server) validateMissionBackend(req CreateMissionRequest) error {
+	if req.Backend == "" || req.Backend == config.AgentBackendClaude {
+		return nil
+	}
+	if err := s.getConfig().ValidateAgentBackend(req.Backend); err != nil {
+		return newHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.Adjutant {
+		return newHTTPErrorf(http.StatusBadRequest, "adjutant missions can only use the '%s' backend", config.AgentBackendClaude)
+	}
+	if req.Sandbox {
+		return newHTTPErrorf(http.StatusBadRequest, "only the '%s' backend can run in a sandbox container", config.AgentBackendClaude)
+	}
+	return nil
+}
+
 // resolveLinkSessions returns the set of tmux session names to link a newly-
 // created mission's pool window into. The Source field acts as the dispatch
 // key:
@@ -602,9 +1167,10 @@ func (s *Server) resolveLinkSessions(req CreateMissionRequest) []string {
 // spawnWrapper launches the wrapper process for a mission.
 // All missions run in a pool window. After the pool window is created, the
 // window is linked into zero or more user sessions per resolveLinkSessions
-// (source-driven). req.Headless skips all linking. Link failures degrade
-// gracefully — the spawn always succeeds as long as the pool window itself
-// was created.
+// (source-driven), or into the mission's group session instead when
+// tmux.layout groups it. req.Headless skips all linking. Link failures
+// degrade gracefully — the spawn always succeeds as long as the pool window
+// itself was created.
 func (s *Server) spawnWrapper(missionRecord *database.Mission, req CreateMissionRequest) error {
 	// Build the wrapper command for the pool window.
 	// --run-wrapper tells the resume command to run the wrapper directly
@@ -626,8 +1192,16 @@ func (s *Server) spawnWrapper(missionRecord *database.Mission, req CreateMission
 	}
 
 	if !req.Headless {
-		for _, session := range s.resolveLinkSessions(req) {
-			if err := linkPoolWindowByPane(paneID, session); err != nil {
+		linkSessions := s.resolveLinkSessions(req)
+		groupSession := ""
+		if len(linkSessions) > 0 {
+			groupSession = s.missionGroupSession(missionRecord)
+		}
+		if groupSession != "" {
+			linkSessions = []string{groupSession}
+		}
+		for _, session := range linkSessions {
+			if err := s.linkPoolWindowIntoSession(paneID, session); err != nil {
 				s.logger.Printf("Warning: failed to link child %s into session %s: %v (continuing)", missionRecord.ShortID, session, err)
 				continue
 			}
@@ -635,6 +1209,11 @@ func (s *Server) spawnWrapper(missionRecord *database.Mission, req CreateMission
 				focusPaneInSession(paneID, session)
 			}
 		}
+		// Only a mission the user created from their terminal pulls them
+		// over to its group; child missions appear there quietly
+		if groupSession != "" && !req.NoFocus && req.Source != "mission" && req.TmuxSession != groupSession {
+			switchClientToSession(req.TmuxSession, groupSession)
+		}
 	}
 
 	s.reconcileTmuxWindowTitle(missionRecord.ID)
@@ -665,16 +1244,35 @@ func (s *Server) handleStopMission(w http.ResponseWriter, r *http.Request) error
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
 
-	if err := s.stopWrapper(resolvedID); err != nil {
+	if err := s.stopMission(missionRecord); err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "stopped"})
+	return nil
+}
+
+// stopMission stops a mission's wrapper and cleans up its pool window.
+// Shared by POST /missions/{id}/stop and batch operations.
+func (s *Server) stopMission(missionRecord *database.Mission) error {
+	// Stopping a queued mission takes it out of the queue; stopping a running
+	// one may free a slot for the next queued mission.
+	s.missionQueue.remove(missionRecord.ID)
+	defer s.wakeMissionQueue()
+
+	if err := s.stopWrapper(missionRecord.ID); err != nil {
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to stop wrapper: %s", err.Error())
 	}
 
+	// An explicit stop supersedes an idle suspension
+	if err := s.db.ClearMissionSuspended(missionRecord.ID); err != nil {
+		s.logger.Printf("Warning: failed to clear suspended status for mission %s: %v", database.ShortID(missionRecord.ID), err)
+	}
+
 	// Clean up pool window (may already be gone if wrapper exited cleanly)
 	if missionRecord.TmuxPane != nil {
 		s.destroyPoolWindow(*missionRecord.TmuxPane)
 	}
-
-	writeJSON(w, http.StatusOK, map[string]string{"status": "stopped"})
 	return nil
 }
 
@@ -716,11 +1314,30 @@ func (s *Server) stopWrapper(missionID string) error {
 	return nil
 }
 
+// DeleteMissionResponse is the JSON response for DELETE /missions/{id}.
+type DeleteMissionResponse struct {
+	Status string `json:"status"`
+	// RemoteCleanup reports the remote branch cleanup, when it was requested.
+	RemoteCleanup *RemoteCleanupResponse `json:"remote_cleanup,omitempty"`
+}
+
 // handleDeleteMission handles DELETE /missions/{id}.
 // Stops the wrapper, removes the mission directory, and deletes the DB record.
+// Query params:
+//   - cleanup_remote=true|false — also delete the mission's pushed auto-branch
+//     and close its draft PR (default: cleanupRemoteOnDelete is "always")
 func (s *Server) handleDeleteMission(w http.ResponseWriter, r *http.Request) error {
 	id := r.PathValue("id")
 
+	cleanupRemote := s.cleanupRemoteByDefault()
+	if raw := r.URL.Query().Get("cleanup_remote"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return newHTTPError(http.StatusBadRequest, "invalid 'cleanup_remote' parameter: expected true or false")
+		}
+		cleanupRemote = parsed
+	}
+
 	resolvedID, err := s.db.ResolveMissionID(id)
 	if err != nil {
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
@@ -734,49 +1351,81 @@ func (s *Server) handleDeleteMission(w http.ResponseWriter, r *http.Request) err
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
 
+	remoteCleanup, err := s.deleteMission(missionRecord, cleanupRemote)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, DeleteMissionResponse{Status: "deleted", RemoteCleanup: remoteCleanup})
+	return nil
+}
+
+// deleteMission stops a mission's wrapper, tears down its pool window,
+// devcontainer, and worktree registration, removes the mission directory, and
+// deletes the DB record. Shared by DELETE /missions/{id} and mission GC.
+// With cleanupRemote, it first deletes the mission's pushed auto-branch and
+// closes its draft PR (best-effort), returning what was cleaned up.
+func (s *Server) deleteMission(missionRecord *database.Mission, cleanupRemote bool) (*RemoteCleanupResponse, error) {
+	missionID := missionRecord.ID
+
+	s.missionQueue.remove(missionID)
+	defer s.wakeMissionQueue()
+
 	// Stop the wrapper if running and clean up pool window
-	if err := s.stopWrapper(resolvedID); err != nil {
-		s.logger.Printf("Warning: failed to stop wrapper for mission %s: %v", id, err)
+	if err := s.stopWrapper(missionID); err != nil {
+		s.logger.Printf("Warning: failed to stop wrapper for mission %s: %v", missionRecord.ShortID, err)
 	}
 	if missionRecord.TmuxPane != nil {
 		s.destroyPoolWindow(*missionRecord.TmuxPane)
 	}
 
-	// Clean up per-mission Keychain credentials from the old auth system
-	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(s.agencDirpath, resolvedID)
-	if err := claudeconfig.DeleteKeychainCredentials(claudeConfigDirpath); err != nil {
-		s.logger.Printf("Warning: failed to delete Keychain credentials for mission %s: %v", id, err)
+	// Clean up per-mission stored credentials from the old auth system
+	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(s.agencDirpath, missionID)
+	if err := claudeconfig.DeleteCredentials(claudeConfigDirpath); err != nil {
+		s.logger.Printf("Warning: failed to delete credentials for mission %s: %v", missionRecord.ShortID, err)
+	}
+
+	// Clean up origin while the workspace still exists to run git and gh in
+	var remoteCleanup *RemoteCleanupResponse
+	if cleanupRemote {
+		remoteCleanup = s.cleanupMissionRemote(missionRecord)
 	}
 
 	// Stop and remove devcontainer if the mission had one
-	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, resolvedID)
+	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, missionID)
 	if _, found := devcontainer.DetectDevcontainer(agentDirpath); found {
-		missionDirpath := config.GetMissionDirpath(s.agencDirpath, resolvedID)
+		missionDirpath := config.GetMissionDirpath(s.agencDirpath, missionID)
 		mergedConfigPath := filepath.Join(missionDirpath, "devcontainer.json")
 		stopCmd := exec.Command("devcontainer", "stop",
 			"--workspace-folder", agentDirpath,
 			"--config", mergedConfigPath,
 		)
 		if stopErr := stopCmd.Run(); stopErr != nil {
-			s.logger.Printf("Warning: failed to stop devcontainer for mission %s: %v", id, stopErr)
+			s.logger.Printf("Warning: failed to stop devcontainer for mission %s: %v", missionRecord.ShortID, stopErr)
+		}
+	}
+
+	// Unregister worktree-mode workspaces from the library clone so it does
+	// not accumulate stale worktree entries and mission branches
+	if mission.IsWorktree(agentDirpath) {
+		if err := mission.RemoveWorktree(agentDirpath); err != nil {
+			s.logger.Printf("Warning: failed to remove git worktree for mission %s: %v", missionRecord.ShortID, err)
 		}
 	}
 
 	// Remove the mission directory
-	missionDirpath := config.GetMissionDirpath(s.agencDirpath, resolvedID)
+	missionDirpath := config.GetMissionDirpath(s.agencDirpath, missionID)
 	if _, statErr := os.Stat(missionDirpath); statErr == nil {
 		if err := os.RemoveAll(missionDirpath); err != nil {
-			return newHTTPErrorf(http.StatusInternalServerError, "failed to remove mission directory: %s", err.Error())
+			return nil, newHTTPErrorf(http.StatusInternalServerError, "failed to remove mission directory: %s", err.Error())
 		}
 	}
 
 	// Delete from database
-	if err := s.db.DeleteMission(resolvedID); err != nil {
-		return newHTTPErrorf(http.StatusInternalServerError, "failed to delete mission: %s", err.Error())
+	if err := s.db.DeleteMission(missionID); err != nil {
+		return nil, newHTTPErrorf(http.StatusInternalServerError, "failed to delete mission: %s", err.Error())
 	}
-
-	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
-	return nil
+	return remoteCleanup, nil
 }
 
 // ReloadMissionRequest is the optional JSON body for POST /missions/{id}/reload.
@@ -893,10 +1542,15 @@ func (s *Server) handleClaudeIdle(w http.ResponseWriter, r *http.Request) error
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
 
+	s.finishCronRunOnIdle(resolvedID)
+	s.publishEvent(EventMissionIdle, resolvedID, nil)
+
 	if _, pending := s.pendingReloads.Load(resolvedID); pending {
 		go s.fireQueuedReload(resolvedID)
 	}
 
+	s.maybeSummarizeMissionAsync(resolvedID, true)
+
 	w.WriteHeader(http.StatusNoContent)
 	return nil
 }
@@ -945,11 +1599,23 @@ type AttachRequest struct {
 	// into multiple sessions, making pane-ID-based resolution ambiguous.
 	TmuxSession string `json:"tmux_session"`
 	NoFocus     bool   `json:"no_focus"`
+	// Split joins the mission's pane into the caller's window as a side-by-side
+	// split instead of linking the pool window into the session.
+	Split bool `json:"split,omitempty"`
+	// ExactSession links the pool window into TmuxSession even when
+	// tmux.layout would put it in the mission's group session, for sessions
+	// made to show just this mission.
+	ExactSession bool `json:"exact_session,omitempty"`
+	// CallerPane is the caller's tmux pane ID (without "%" prefix), which the
+	// mission pane is split next to. Required with Split.
+	CallerPane string `json:"caller_pane,omitempty"`
 }
 
 // handleAttachMission handles POST /missions/{id}/attach.
 // Ensures the mission's wrapper is running in the pool (lazy start), then links
-// the pool window into the caller's tmux session.
+// the pool window into the caller's tmux session (or the mission's group
+// session under tmux.layout perRepo/perProject, switching the caller there),
+// or with Split joins the mission pane into the caller's window.
 func (s *Server) handleAttachMission(w http.ResponseWriter, r *http.Request) error {
 	id := r.PathValue("id")
 
@@ -960,6 +1626,9 @@ func (s *Server) handleAttachMission(w http.ResponseWriter, r *http.Request) err
 	if req.TmuxSession == "" {
 		return newHTTPError(http.StatusBadRequest, "tmux_session is required")
 	}
+	if req.Split && req.CallerPane == "" {
+		return newHTTPError(http.StatusBadRequest, "caller_pane is required with split")
+	}
 	tmuxSession := req.TmuxSession
 
 	resolvedID, err := s.db.ResolveMissionID(id)
@@ -974,6 +1643,9 @@ func (s *Server) handleAttachMission(w http.ResponseWriter, r *http.Request) err
 	if missionRecord == nil {
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
+	if req.Split && missionRecord.TmuxPane != nil && *missionRecord.TmuxPane == req.CallerPane {
+		return newHTTPError(http.StatusBadRequest, "cannot split a mission into its own pane")
+	}
 
 	// Auto-unarchive if needed
 	if missionRecord.Status == "archived" {
@@ -1007,14 +1679,32 @@ func (s *Server) handleAttachMission(w http.ResponseWriter, r *http.Request) err
 	}
 	paneID := *missionRecord.TmuxPane
 
-	// Link the pool window into the caller's session if not already there.
-	if !isPaneInSession(paneID, tmuxSession) {
-		if err := linkPoolWindowByPane(paneID, tmuxSession); err != nil {
-			return newHTTPErrorf(http.StatusInternalServerError, "failed to link window: %s", err.Error())
+	if req.Split {
+		if err := joinPaneIntoWindow(paneID, req.CallerPane, s.getPoolSessionName(), req.NoFocus, s.logger); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to split mission into window: %s", err.Error())
 		}
+		s.reconcileTmuxWindowTitle(resolvedID)
+		s.logger.Printf("Split mission %s into the window of pane %s", database.ShortID(resolvedID), req.CallerPane)
+		writeJSON(w, http.StatusOK, map[string]string{"status": "attached"})
+		return nil
+	}
+
+	// Link the pool window into the caller's session, or its group session,
+	// if not already there.
+	callerSession := tmuxSession
+	if !req.ExactSession {
+		if groupSession := s.missionGroupSession(missionRecord); groupSession != "" {
+			tmuxSession = groupSession
+		}
+	}
+	if err := s.linkPoolWindowIntoSession(paneID, tmuxSession); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to link window: %s", err.Error())
 	}
 	if !req.NoFocus {
 		focusPaneInSession(paneID, tmuxSession)
+		if tmuxSession != callerSession {
+			switchClientToSession(callerSession, tmuxSession)
+		}
 	}
 	s.reconcileTmuxWindowTitle(resolvedID)
 
@@ -1035,8 +1725,9 @@ type DetachRequest struct {
 }
 
 // handleDetachMission handles POST /missions/{id}/detach.
-// Unlinks the mission's window from the caller's tmux session. The wrapper
-// keeps running in the pool.
+// Unlinks the mission's window from the caller's tmux session, or moves a pane
+// joined with attach --split back into its own pool window. The wrapper keeps
+// running in the pool.
 func (s *Server) handleDetachMission(w http.ResponseWriter, r *http.Request) error {
 	id := r.PathValue("id")
 
@@ -1065,13 +1756,26 @@ func (s *Server) handleDetachMission(w http.ResponseWriter, r *http.Request) err
 		return newHTTPError(http.StatusBadRequest, "mission has no tmux pane")
 	}
 
-	if err := unlinkPoolWindowByPane(*missionRecord.TmuxPane, tmuxSession); err != nil {
+	paneID := *missionRecord.TmuxPane
+	poolSessionName := s.getPoolSessionName()
+
+	if isPaneInSession(paneID, tmuxSession) && !isPaneInSession(paneID, poolSessionName) {
+		if err := breakPaneToPool(paneID, poolSessionName, database.ShortID(resolvedID)); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to detach split pane: %s", err.Error())
+		}
+		s.reconcileTmuxWindowTitle(resolvedID)
+		s.logger.Printf("Detached split mission %s from session %s", database.ShortID(resolvedID), tmuxSession)
+		writeJSON(w, http.StatusOK, map[string]string{"status": "detached"})
+		return nil
+	}
+
+	if err := unlinkPoolWindowByPane(paneID, tmuxSession); err != nil {
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to unlink window: %s", err.Error())
 	}
 
 	// Clean up any side shell panes the user created (via tmux split-window)
 	// so they don't linger in the pool after detach.
-	killExtraPanesInWindow(*missionRecord.TmuxPane, s.getPoolSessionName(), s.logger)
+	killExtraPanesInWindow(paneID, poolSessionName, s.logger)
 
 	s.logger.Printf("Detached mission %s from session %s", database.ShortID(resolvedID), tmuxSession)
 	writeJSON(w, http.StatusOK, map[string]string{"status": "detached"})
@@ -1096,46 +1800,58 @@ func (s *Server) handleSendKeys(w http.ResponseWriter, r *http.Request) error {
 		return newHTTPError(http.StatusBadRequest, "keys is required and must not be empty")
 	}
 
+	missionRecord, paneID, err := s.lookupRunningMissionPane(id)
+	if err != nil {
+		return err
+	}
+
+	if err := sendKeysToPane(paneID, req.Keys); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "%s", err.Error())
+	}
+
+	s.logger.Printf("Sent %d key(s) to mission %s (pane %s)", len(req.Keys), missionRecord.ShortID, paneID)
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	return nil
+}
+
+// lookupRunningMissionPane resolves a mission ID and returns the record along
+// with the tmux pane its interactive Claude runs in. Archived missions,
+// missions without a running wrapper, and stale pane references are rejected
+// with a hint on how to fix them.
+func (s *Server) lookupRunningMissionPane(id string) (*database.Mission, string, error) {
 	resolvedID, err := s.db.ResolveMissionID(id)
 	if err != nil {
-		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+		return nil, "", newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
 
 	missionRecord, err := s.db.GetMission(resolvedID)
 	if err != nil {
-		return newHTTPError(http.StatusInternalServerError, err.Error())
+		return nil, "", newHTTPError(http.StatusInternalServerError, err.Error())
 	}
 	if missionRecord == nil {
-		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+		return nil, "", newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
 
 	shortID := database.ShortID(resolvedID)
 
 	if missionRecord.Status == "archived" {
-		return newHTTPErrorf(http.StatusBadRequest,
-			"cannot send keys to archived mission %s — unarchive it with: agenc mission unarchive %s",
+		return nil, "", newHTTPErrorf(http.StatusBadRequest,
+			"cannot send to archived mission %s — unarchive it with: agenc mission unarchive %s",
 			shortID, shortID)
 	}
 	if missionRecord.TmuxPane == nil || *missionRecord.TmuxPane == "" {
-		return newHTTPErrorf(http.StatusBadRequest,
+		return nil, "", newHTTPErrorf(http.StatusBadRequest,
 			"mission %s is not running — start it with: agenc mission attach %s",
 			shortID, shortID)
 	}
 
 	paneID := *missionRecord.TmuxPane
-	if !poolWindowExistsByPane(paneID, s.getPoolSessionName()) {
-		return newHTTPErrorf(http.StatusInternalServerError,
+	if !tmuxPaneExists(paneID) {
+		return nil, "", newHTTPErrorf(http.StatusInternalServerError,
 			"mission %s has a stale pane reference — try: agenc mission reload %s",
 			shortID, shortID)
 	}
-
-	if err := sendKeysToPane(paneID, req.Keys); err != nil {
-		return newHTTPErrorf(http.StatusInternalServerError, "%s", err.Error())
-	}
-
-	s.logger.Printf("Sent %d key(s) to mission %s (pane %s)", len(req.Keys), shortID, paneID)
-	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
-	return nil
+	return missionRecord, paneID, nil
 }
 
 // ensureWrapperInPool checks if the mission's wrapper is running in the pool.
@@ -1160,8 +1876,13 @@ func (s *Server) ensureWrapperInPool(missionRecord *database.Mission) error {
 		return nil
 	}
 
-	// Wrapper not running — spawn it in the pool
-	resumeCmd, err := s.buildWrapperResumeCmd(missionRecord.ID, "")
+	// Wrapper not running — spawn it in the pool. A mission still waiting in
+	// the start queue jumps it, keeping the initial prompt it was queued with.
+	prompt := ""
+	if entry, ok := s.missionQueue.take(missionRecord.ID); ok {
+		prompt = entry.request.Prompt
+	}
+	resumeCmd, err := s.buildWrapperResumeCmd(missionRecord.ID, prompt)
 	if err != nil {
 		return err
 	}
@@ -1176,15 +1897,36 @@ func (s *Server) ensureWrapperInPool(missionRecord *database.Mission) error {
 		s.logger.Printf("Warning: failed to store pane ID for mission %s: %v", database.ShortID(missionRecord.ID), err)
 	}
 
+	// Resuming a suspended mission; the first heartbeat would also do this
+	if err := s.db.ClearMissionSuspended(missionRecord.ID); err != nil {
+		s.logger.Printf("Warning: failed to clear suspended status for mission %s: %v", database.ShortID(missionRecord.ID), err)
+	}
+
 	s.logger.Printf("Started wrapper in pool window %s for mission %s", poolWindowTarget, database.ShortID(missionRecord.ID))
 	return nil
 }
 
+// ArchiveMissionRequest is the optional JSON body for POST
+// /missions/{id}/archive.
+type ArchiveMissionRequest struct {
+	// HandoffNote, when non-empty, is recorded as the mission's handoff note
+	// and written into its agent directory as HANDOFF.md.
+	HandoffNote string `json:"handoff_note,omitempty"`
+}
+
 // handleArchiveMission handles POST /missions/{id}/archive.
-// Stops the wrapper, cleans up the pool window, and marks the mission archived.
+// Stops the wrapper, cleans up the pool window, and marks the mission archived,
+// recording the request's handoff note first when there is one. A note sent
+// for an already-archived mission is still recorded.
 func (s *Server) handleArchiveMission(w http.ResponseWriter, r *http.Request) error {
 	id := r.PathValue("id")
 
+	// Older clients send no body
+	var req ArchiveMissionRequest
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+
 	resolvedID, err := s.db.ResolveMissionID(id)
 	if err != nil {
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
@@ -1198,24 +1940,51 @@ func (s *Server) handleArchiveMission(w http.ResponseWriter, r *http.Request) er
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
 
+	if strings.TrimSpace(req.HandoffNote) != "" {
+		if err := s.recordMissionHandoff(missionRecord.ID, req.HandoffNote); err != nil {
+			return err
+		}
+	}
+
 	if missionRecord.Status == "archived" {
 		writeJSON(w, http.StatusOK, map[string]string{"status": "archived"})
 		return nil
 	}
 
-	// Stop wrapper and clean up pool window
-	if err := s.stopWrapper(resolvedID); err != nil {
-		s.logger.Printf("Warning: failed to stop wrapper for mission %s: %v", id, err)
+	if err := s.archiveMission(missionRecord); err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "archived"})
+	return nil
+}
+
+// archiveMission stops a mission's wrapper, cleans up its pool window, and
+// marks it archived. Shared by POST /missions/{id}/archive and mission GC.
+func (s *Server) archiveMission(missionRecord *database.Mission) error {
+	if err := s.checkMissionSizeBeforeArchive(missionRecord); err != nil {
+		return err
+	}
+
+	s.missionQueue.remove(missionRecord.ID)
+	defer s.wakeMissionQueue()
+
+	if err := s.stopWrapper(missionRecord.ID); err != nil {
+		s.logger.Printf("Warning: failed to stop wrapper for mission %s: %v", missionRecord.ShortID, err)
 	}
 	if missionRecord.TmuxPane != nil {
 		s.destroyPoolWindow(*missionRecord.TmuxPane)
 	}
 
-	if err := s.db.ArchiveMission(resolvedID); err != nil {
+	if err := s.db.ArchiveMission(missionRecord.ID); err != nil {
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to archive mission: %s", err.Error())
 	}
-
-	writeJSON(w, http.StatusOK, map[string]string{"status": "archived"})
+	// scratch/ only lives as long as the mission is active; the wrapper
+	// recreates it if the mission is unarchived
+	if err := os.RemoveAll(config.GetMissionScratchDirpath(s.agencDirpath, missionRecord.ID)); err != nil {
+		s.logger.Printf("Warning: failed to remove scratch directory for mission %s: %v", missionRecord.ShortID, err)
+	}
+	s.publishEvent(EventMissionArchived, missionRecord.ID, map[string]string{"short_id": missionRecord.ShortID})
 	return nil
 }
 
@@ -1243,6 +2012,18 @@ type UpdateMissionRequest struct {
 	ConfigCommit *string `json:"config_commit,omitempty"`
 	SessionName  *string `json:"session_name,omitempty"`
 	Prompt       *string `json:"prompt,omitempty"`
+
+	// Tags, when non-nil, replaces the mission's full tag set. An empty slice
+	// clears all tags.
+	Tags *[]string `json:"tags,omitempty"`
+
+	// DisplayName, when non-nil, replaces the mission's display name and
+	// retitles its tmux window. An empty string clears it.
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Project, when non-nil, moves the mission into the named project. An
+	// empty string takes it out of its project.
+	Project *string `json:"project,omitempty"`
 }
 
 // handleUpdateMission handles PATCH /missions/{id}.
@@ -1275,6 +2056,34 @@ func (s *Server) handleUpdateMission(w http.ResponseWriter, r *http.Request) err
 			return newHTTPErrorf(http.StatusInternalServerError, "failed to update prompt: %s", err.Error())
 		}
 	}
+	if req.Tags != nil {
+		for _, tag := range *req.Tags {
+			if err := database.ValidateTag(tag); err != nil {
+				return newHTTPError(http.StatusBadRequest, err.Error())
+			}
+		}
+		if err := s.db.SetMissionTags(resolvedID, *req.Tags); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to update tags: %s", err.Error())
+		}
+	}
+	if req.DisplayName != nil {
+		displayName := strings.Join(strings.Fields(*req.DisplayName), " ")
+		if utf8.RuneCountInString(displayName) > maxDisplayNameLen {
+			return newHTTPErrorf(http.StatusBadRequest, "display name too long (max %d characters)", maxDisplayNameLen)
+		}
+		if err := s.db.SetMissionDisplayName(resolvedID, displayName); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to update display_name: %s", err.Error())
+		}
+		s.reconcileTmuxWindowTitle(resolvedID)
+	}
+	if req.Project != nil {
+		if err := s.validateProjectRef(*req.Project); err != nil {
+			return err
+		}
+		if err := s.db.SetMissionProject(resolvedID, *req.Project); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to update project: %s", err.Error())
+		}
+	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "updated"})
 	return nil
 }
@@ -1322,8 +2131,21 @@ func (s *Server) handleHeartbeat(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// RecordPromptRequest is the optional JSON body for the record-prompt
+// endpoint.
+type RecordPromptRequest struct {
+	Prompt string `json:"prompt,omitempty"`
+}
+
+// MissionPromptResponse is one entry in GET /missions/{id}/prompts.
+type MissionPromptResponse struct {
+	Prompt    string    `json:"prompt"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // handleRecordPrompt handles POST /missions/{id}/prompt.
-// Increments the prompt count for the mission.
+// Increments the prompt count for the mission and, when the body carries the
+// prompt text, appends it to the mission's prompt history.
 func (s *Server) handleRecordPrompt(w http.ResponseWriter, r *http.Request) error {
 	id := r.PathValue("id")
 
@@ -1332,6 +2154,18 @@ func (s *Server) handleRecordPrompt(w http.ResponseWriter, r *http.Request) erro
 		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
 	}
 
+	// Old wrappers and containerized hooks send no body, so decode errors
+	// are ignored and only the count is updated.
+	var req RecordPromptRequest
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	if req.Prompt != "" {
+		if err := s.db.CreateMissionPrompt(&database.MissionPrompt{MissionID: resolvedID, Prompt: req.Prompt}); err != nil {
+			return newHTTPErrorf(http.StatusInternalServerError, "failed to record prompt: %s", err.Error())
+		}
+	}
+
 	if err := s.db.IncrementPromptCount(resolvedID); err != nil {
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to increment prompt_count: %s", err.Error())
 	}
@@ -1340,25 +2174,59 @@ func (s *Server) handleRecordPrompt(w http.ResponseWriter, r *http.Request) erro
 		return newHTTPErrorf(http.StatusInternalServerError, "failed to update last_user_prompt_at: %s", err.Error())
 	}
 
+	s.publishEvent(EventPromptSubmitted, resolvedID, nil)
+	s.maybeSummarizeMissionAsync(resolvedID, false)
+
 	w.WriteHeader(http.StatusNoContent)
 	return nil
 }
 
+// handleListMissionPrompts handles GET /missions/{id}/prompts.
+// Returns the mission's prompt history, oldest first.
+func (s *Server) handleListMissionPrompts(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+
+	records, err := s.db.ListMissionPrompts(database.ListMissionPromptsParams{MissionID: resolvedID})
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	prompts := make([]MissionPromptResponse, 0, len(records))
+	for _, record := range records {
+		prompts = append(prompts, MissionPromptResponse{Prompt: record.Prompt, CreatedAt: record.CreatedAt})
+	}
+	writeJSON(w, http.StatusOK, prompts)
+	return nil
+}
+
 // reloadMissionInTmux performs an in-place reload using tmux primitives.
 // When prompt is non-empty, it is threaded through the resume command and
 // fed to Claude's `-c` resume as an initial follow-up message.
 func (s *Server) reloadMissionInTmux(missionRecord *database.Mission, paneID string, prompt string) error {
+	return s.reloadMissionInTmuxWith(missionRecord, paneID, prompt, nil)
+}
+
+// reloadMissionInTmuxWith is reloadMissionInTmux with a hook that runs after
+// the wrapper has stopped and before the pane respawns, for changes that must
+// not happen under a live Claude. The pane is respawned even if whileStopped
+// fails, so the mission comes back either way; its error is returned.
+func (s *Server) reloadMissionInTmuxWith(missionRecord *database.Mission, paneID string, prompt string, whileStopped func() error) error {
 	targetPane := "%" + paneID
 
 	// Verify pane still exists
-	checkCmd := exec.Command("tmux", "display-message", "-p", "-t", targetPane, "#{pane_id}")
+	checkCmd := tmux.Command("display-message", "-p", "-t", targetPane, "#{pane_id}")
 	output, err := checkCmd.Output()
 	if err != nil || strings.TrimSpace(string(output)) != targetPane {
 		return fmt.Errorf("tmux pane %s no longer exists", paneID)
 	}
 
 	// Resolve window ID from pane ID
-	windowCmd := exec.Command("tmux", "display-message", "-p", "-t", targetPane, "#{window_id}")
+	windowCmd := tmux.Command("display-message", "-p", "-t", targetPane, "#{window_id}")
 	output, err = windowCmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to resolve window ID: %v", err)
@@ -1366,14 +2234,14 @@ func (s *Server) reloadMissionInTmux(missionRecord *database.Mission, paneID str
 	windowID := strings.TrimSpace(string(output))
 
 	// Set remain-on-exit on
-	setCmd := exec.Command("tmux", "set-option", "-w", "-t", windowID, "remain-on-exit", "on")
+	setCmd := tmux.Command("set-option", "-w", "-t", windowID, "remain-on-exit", "on")
 	if output, err := setCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to set remain-on-exit: %v (output: %s)", err, string(output))
 	}
 
 	// Ensure cleanup
 	defer func() {
-		restoreCmd := exec.Command("tmux", "set-option", "-w", "-t", windowID, "remain-on-exit", "off")
+		restoreCmd := tmux.Command("set-option", "-w", "-t", windowID, "remain-on-exit", "off")
 		_ = restoreCmd.Run() // best-effort cleanup; nothing to do if it fails
 	}()
 
@@ -1382,17 +2250,23 @@ func (s *Server) reloadMissionInTmux(missionRecord *database.Mission, paneID str
 		return fmt.Errorf("failed to stop wrapper: %w", err)
 	}
 
+	var hookErr error
+	if whileStopped != nil {
+		hookErr = whileStopped()
+	}
+
 	// Respawn the pane
 	resumeCommand, err := s.buildWrapperResumeCmd(missionRecord.ID, prompt)
 	if err != nil {
 		return err
 	}
-	respawnCmd := exec.Command("tmux", "respawn-pane", "-k", "-t", targetPane, resumeCommand)
+	respawnCmd := tmux.Command("respawn-pane", "-k", "-t", targetPane, resumeCommand)
 	if output, err := respawnCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("tmux respawn-pane failed: %v (output: %s)", err, string(output))
 	}
+	s.publishEvent(EventMissionRestarted, missionRecord.ID, map[string]string{"reason": "reload"})
 
-	return nil
+	return hookErr
 }
 
 // tmuxEnvPrefix returns a shell export prefix for commands executed in tmux
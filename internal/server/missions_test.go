@@ -1,6 +1,11 @@
 package server
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 
@@ -186,3 +191,116 @@ func TestBuildCronTriggeredNotification_TruncatesLongPrompt(t *testing.T) {
 		t.Errorf("expected truncation marker, got: %v", n.BodyMarkdown)
 	}
 }
+
+func TestValidateMissionBackend(t *testing.T) {
+	s := &Server{}
+	s.cachedConfig.Store(&config.AgencConfig{AgentBackends: map[string]config.AgentBackendConfig{
+		"aider": {Interactive: []string{"aider"}},
+	}})
+
+	tests := []struct {
+		name    string
+		req     CreateMissionRequest
+		wantErr bool
+	}{
+		{"default", CreateMissionRequest{}, false},
+		{"claude", CreateMissionRequest{Backend: "claude", Sandbox: true}, false},
+		{"built-in codex", CreateMissionRequest{Backend: "codex"}, false},
+		{"config backend", CreateMissionRequest{Backend: "aider"}, false},
+		{"unknown", CreateMissionRequest{Backend: "gemini"}, true},
+		{"adjutant", CreateMissionRequest{Backend: "codex", Adjutant: true}, true},
+		{"sandbox", CreateMissionRequest{Backend: "codex", Sandbox: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.validateMissionBackend(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err != nil && httpStatusFromError(err) != http.StatusBadRequest {
+				t.Errorf("expected a 400, got %d", httpStatusFromError(err))
+			}
+		})
+	}
+}
+
+func TestHandleAttachMission_SplitRequiresCallerPane(t *testing.T) {
+	s := &Server{}
+	body := bytes.NewBufferString(`{"tmux_session":"work","split":true}`)
+	req := httptest.NewRequest(http.MethodPost, "/missions/abc/attach", body)
+	req.SetPathValue("id", "abc")
+
+	err := s.handleAttachMission(httptest.NewRecorder(), req)
+	if err == nil || httpStatusFromError(err) != http.StatusBadRequest {
+		t.Fatalf("expected a 400 without caller_pane, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "caller_pane") {
+		t.Errorf("expected the error to name caller_pane, got %v", err)
+	}
+}
+
+func TestHandleRecordPrompt_StoresPromptHistory(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	srv.events = newEventBus()
+	mission, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	// A prompt body is recorded; an empty body (old wrappers, devcontainer
+	// hooks) only bumps the count.
+	for _, body := range []string{`{"prompt":"add a flag"}`, ``, `{"prompt":"now test it"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/missions/"+mission.ShortID+"/prompt", strings.NewReader(body))
+		req.SetPathValue("id", mission.ShortID)
+		if err := srv.handleRecordPrompt(httptest.NewRecorder(), req); err != nil {
+			t.Fatalf("handleRecordPrompt failed: %v", err)
+		}
+	}
+
+	updated, err := srv.db.GetMission(mission.ID)
+	if err != nil {
+		t.Fatalf("GetMission failed: %v", err)
+	}
+	if updated.PromptCount != 3 {
+		t.Errorf("expected prompt_count 3, got %d", updated.PromptCount)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/missions/"+mission.ShortID+"/prompts", nil)
+	req.SetPathValue("id", mission.ShortID)
+	w := httptest.NewRecorder()
+	if err := srv.handleListMissionPrompts(w, req); err != nil {
+		t.Fatalf("handleListMissionPrompts failed: %v", err)
+	}
+	var prompts []MissionPromptResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &prompts); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(prompts) != 2 || prompts[0].Prompt != "add a flag" || prompts[1].Prompt != "now test it" {
+		t.Errorf("expected the two prompts in order, got %+v", prompts)
+	}
+}
+
+func TestArchiveMission_RemovesScratchDir(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	missionRecord, err := srv.db.CreateMission("", nil)
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+	agentDirpath := config.GetMissionAgentDirpath(srv.agencDirpath, missionRecord.ID)
+	scratchDirpath := config.GetMissionScratchDirpath(srv.agencDirpath, missionRecord.ID)
+	for _, dirpath := range []string{agentDirpath, scratchDirpath} {
+		if err := os.MkdirAll(dirpath, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := srv.archiveMission(missionRecord); err != nil {
+		t.Fatalf("archiveMission failed: %v", err)
+	}
+	if _, err := os.Stat(scratchDirpath); !os.IsNotExist(err) {
+		t.Errorf("expected the scratch directory to be removed on archive, got err=%v", err)
+	}
+	if _, err := os.Stat(agentDirpath); err != nil {
+		t.Errorf("expected the agent directory to be kept on archive: %v", err)
+	}
+}
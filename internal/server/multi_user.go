@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+// peerUIDContextKey holds the uid of the client on a unix socket connection.
+type peerUIDContextKey struct{}
+
+// peerUserConnContext records the peer uid of each unix socket connection in
+// its context so handlers can tell which OS user sent a request. Connections
+// whose peer can't be identified are left without one.
+func peerUserConnContext(ctx context.Context, conn net.Conn) context.Context {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return ctx
+	}
+	uid, err := peerUID(unixConn)
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, peerUIDContextKey{}, uid)
+}
+
+// requestUser returns the OS user name of the process that sent r over the
+// unix socket, and whether it is the user running the server. ok is false
+// when the sender is unknown: TCP requests (already authenticated by bearer
+// token) and platforms without peer credentials.
+func requestUser(r *http.Request) (name string, isServerUser bool, ok bool) {
+	uid, found := r.Context().Value(peerUIDContextKey{}).(uint32)
+	if !found {
+		return "", false, false
+	}
+	isServerUser = int(uid) == os.Getuid()
+	uidStr := strconv.FormatUint(uint64(uid), 10)
+	u, err := user.LookupId(uidStr)
+	if err != nil {
+		// Fall back to the numeric uid so ownership still works for users
+		// without a passwd entry (e.g. some container setups)
+		return uidStr, isServerUser, true
+	}
+	return u.Username, isServerUser, true
+}
+
+// missionOwnerForRequest returns the owner to record on a mission created by
+// r: the requesting user in multi-user mode, otherwise empty (no owner).
+func (s *Server) missionOwnerForRequest(r *http.Request) string {
+	if !s.getConfig().IsMultiUserEnabled() {
+		return ""
+	}
+	name, _, ok := requestUser(r)
+	if !ok {
+		return ""
+	}
+	return name
+}
+
+// checkMissionAccess returns a 403 error if, in multi-user mode, the user
+// behind r may not touch m. The mission's owner, the users it is shared
+// with, and the user running the server always have access, as do missions
+// without an owner and requests whose sender can't be identified.
+func (s *Server) checkMissionAccess(r *http.Request, m *database.Mission) error {
+	if !s.getConfig().IsMultiUserEnabled() || m.Owner == "" {
+		return nil
+	}
+	name, isServerUser, ok := requestUser(r)
+	if !ok || isServerUser || name == m.Owner || slices.Contains(m.SharedWith, name) {
+		return nil
+	}
+	return newHTTPErrorf(http.StatusForbidden,
+		"mission %s belongs to %s; ask them to run 'agenc mission share %s %s'",
+		m.ShortID, m.Owner, m.ShortID, name)
+}
+
+// missionVisibility returns a function reporting whether the user behind r
+// may see data about the mission with the given ID, for routes that return
+// data from many missions at once. Data not tied to a mission is visible;
+// data about a mission that can't be loaded is not. Decisions are cached for
+// the life of the returned function, which is not safe for concurrent use.
+func (s *Server) missionVisibility(r *http.Request) func(missionID string) bool {
+	if !s.getConfig().IsMultiUserEnabled() {
+		return func(string) bool { return true }
+	}
+	visible := make(map[string]bool)
+	return func(missionID string) bool {
+		if missionID == "" {
+			return true
+		}
+		if v, ok := visible[missionID]; ok {
+			return v
+		}
+		m, err := s.db.GetMission(missionID)
+		v := err == nil && m != nil && s.checkMissionAccess(r, m) == nil
+		visible[missionID] = v
+		return v
+	}
+}
+
+// missionAccessGuard wraps a /missions/{id} handler so it is rejected with
+// 403 when the requesting user may not access the mission. Unknown mission
+// IDs pass through so the handler reports them as usual.
+func (s *Server) missionAccessGuard(fn appHandlerFunc) appHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if !s.getConfig().IsMultiUserEnabled() {
+			return fn(w, r)
+		}
+		resolvedID, err := s.db.ResolveMissionID(r.PathValue("id"))
+		if err != nil {
+			return fn(w, r)
+		}
+		missionRecord, err := s.db.GetMission(resolvedID)
+		if err != nil || missionRecord == nil {
+			return fn(w, r)
+		}
+		if err := s.checkMissionAccess(r, missionRecord); err != nil {
+			return err
+		}
+		return fn(w, r)
+	}
+}
+
+// ShareMissionRequest is the JSON body for POST /missions/{id}/share.
+type ShareMissionRequest struct {
+	User   string `json:"user"`
+	Remove bool   `json:"remove,omitempty"`
+}
+
+// ShareMissionResponse is returned by POST /missions/{id}/share.
+type ShareMissionResponse struct {
+	ShortID    string   `json:"short_id"`
+	Owner      string   `json:"owner"`
+	SharedWith []string `json:"shared_with"`
+}
+
+// handleShareMission grants or revokes another user's access to a mission.
+// Only the owner (or the user running the server) may change sharing.
+func (s *Server) handleShareMission(w http.ResponseWriter, r *http.Request) error {
+	if !s.getConfig().IsMultiUserEnabled() {
+		return newHTTPError(http.StatusBadRequest, "multi-user mode is off; set multiUser.tmuxSocket in config.yml and restart the server")
+	}
+
+	var req ShareMissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	req.User = strings.TrimSpace(req.User)
+	if req.User == "" {
+		return newHTTPError(http.StatusBadRequest, "user is required")
+	}
+
+	id := r.PathValue("id")
+	resolvedID, err := s.db.ResolveMissionID(id)
+	if err != nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	missionRecord, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if missionRecord == nil {
+		return newHTTPError(http.StatusNotFound, "mission not found: "+id)
+	}
+	if missionRecord.Owner == "" {
+		return newHTTPErrorf(http.StatusBadRequest, "mission %s has no owner; every user can already access it", missionRecord.ShortID)
+	}
+	if name, isServerUser, ok := requestUser(r); ok && !isServerUser && name != missionRecord.Owner {
+		return newHTTPErrorf(http.StatusForbidden, "only %s can change who mission %s is shared with", missionRecord.Owner, missionRecord.ShortID)
+	}
+
+	sharedWith := slices.DeleteFunc(slices.Clone(missionRecord.SharedWith), func(u string) bool {
+		return u == req.User
+	})
+	if !req.Remove {
+		if _, err := user.Lookup(req.User); err != nil {
+			return newHTTPErrorf(http.StatusBadRequest, "unknown user '%s'", req.User)
+		}
+		if req.User == missionRecord.Owner {
+			return newHTTPErrorf(http.StatusBadRequest, "%s already owns mission %s", req.User, missionRecord.ShortID)
+		}
+		sharedWith = append(sharedWith, req.User)
+	}
+
+	if err := s.db.SetMissionSharedWith(resolvedID, sharedWith); err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	updated, err := s.db.GetMission(resolvedID)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	writeJSON(w, http.StatusOK, ShareMissionResponse{
+		ShortID:    updated.ShortID,
+		Owner:      updated.Owner,
+		SharedWith: updated.SharedWith,
+	})
+	return nil
+}
+
+// applyMultiUserSocketPermissions opens the server socket to multiUser.group
+// so the other users' CLIs can reach the server. A no-op outside multi-user
+// mode; failures are logged, leaving the socket private to this user.
+func (s *Server) applyMultiUserSocketPermissions() {
+	cfg := s.getConfig()
+	if !cfg.IsMultiUserEnabled() || cfg.MultiUser.Group == "" {
+		return
+	}
+	if err := shareSocketWithGroup(s.socketPath, cfg.MultiUser.Group); err != nil {
+		s.logger.Printf("Warning: failed to share server socket with group '%s': %v", cfg.MultiUser.Group, err)
+	}
+}
+
+// shareTmuxServer opens the shared tmux server to the configured group and
+// users. tmux 3.3+ also checks its own access list, so each user is granted
+// read-write access there too. That access covers the whole server, every
+// mission window included: tmux has no per-window ACL, so checkMissionAccess
+// governs only what the agenc server does on a user's behalf. Called whenever
+// the pool session is created, since a fresh pool session may mean a fresh
+// tmux server.
+func (s *Server) shareTmuxServer() {
+	cfg := s.getConfig()
+	if !cfg.IsMultiUserEnabled() {
+		return
+	}
+	if cfg.MultiUser.Group != "" {
+		if err := shareSocketWithGroup(cfg.MultiUser.TmuxSocket, cfg.MultiUser.Group); err != nil {
+			s.logger.Printf("Warning: failed to share tmux socket with group '%s': %v", cfg.MultiUser.Group, err)
+		}
+	}
+	for _, name := range cfg.MultiUser.Users {
+		if output, err := tmux.Command("server-access", "-a", "-w", name).CombinedOutput(); err != nil {
+			s.logger.Printf("Warning: failed to grant tmux access to '%s': %v (output: %s)", name, err, strings.TrimSpace(string(output)))
+		}
+	}
+}
+
+// shareSocketWithGroup hands a socket file to groupName with read-write
+// access for the group.
+func shareSocketWithGroup(socketFilepath string, groupName string) error {
+	group, err := user.LookupGroup(groupName)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to look up group '%s'", groupName)
+	}
+	gid, err := strconv.Atoi(group.Gid)
+	if err != nil {
+		return stacktrace.Propagate(err, "invalid gid '%s' for group '%s'", group.Gid, groupName)
+	}
+	if err := os.Chown(socketFilepath, -1, gid); err != nil {
+		return stacktrace.Propagate(err, "failed to change group of '%s'", socketFilepath)
+	}
+	if err := os.Chmod(socketFilepath, 0660); err != nil {
+		return stacktrace.Propagate(err, "failed to change permissions of '%s'", socketFilepath)
+	}
+	return nil
+}
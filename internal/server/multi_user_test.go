@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// requestFromUID builds a request that looks like it arrived over the unix
+// socket from the given uid.
+func requestFromUID(uid uint32) *http.Request {
+	req := httptest.NewRequest("POST", "/missions/x/stop", nil)
+	return req.WithContext(context.WithValue(req.Context(), peerUIDContextKey{}, uid))
+}
+
+func TestCheckMissionAccess(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MultiUser: &config.MultiUserConfig{TmuxSocket: "/tmp/agenc-shared.sock"},
+	})
+
+	// Uids with no passwd entry resolve to their numeric form
+	const ownerUID, sharedUID, strangerUID = 4000001, 4000002, 4000003
+	owned := &database.Mission{ShortID: "abcd1234", Owner: "4000001", SharedWith: []string{"4000002"}}
+
+	tests := []struct {
+		name    string
+		req     *http.Request
+		mission *database.Mission
+		allowed bool
+	}{
+		{"owner", requestFromUID(ownerUID), owned, true},
+		{"shared user", requestFromUID(sharedUID), owned, true},
+		{"server user", requestFromUID(uint32(os.Getuid())), owned, true},
+		{"stranger", requestFromUID(strangerUID), owned, false},
+		{"unknown sender", httptest.NewRequest("POST", "/missions/x/stop", nil), owned, true},
+		{"unowned mission", requestFromUID(strangerUID), &database.Mission{ShortID: "abcd1234"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := srv.checkMissionAccess(tt.req, tt.mission)
+			if tt.allowed && err != nil {
+				t.Errorf("expected access, got %v", err)
+			}
+			var httpErr *httpError
+			if !tt.allowed && (!errors.As(err, &httpErr) || httpErr.status != http.StatusForbidden) {
+				t.Errorf("expected a 403, got %v", err)
+			}
+		})
+	}
+
+	// Outside multi-user mode nobody is turned away
+	srv.cachedConfig.Store(&config.AgencConfig{})
+	if err := srv.checkMissionAccess(requestFromUID(strangerUID), owned); err != nil {
+		t.Errorf("expected access outside multi-user mode, got %v", err)
+	}
+}
+
+func TestMissionOwnerForRequest(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	req := requestFromUID(4000001)
+
+	if owner := srv.missionOwnerForRequest(req); owner != "" {
+		t.Errorf("expected no owner outside multi-user mode, got %q", owner)
+	}
+
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MultiUser: &config.MultiUserConfig{TmuxSocket: "/tmp/agenc-shared.sock"},
+	})
+	if owner := srv.missionOwnerForRequest(req); owner != "4000001" {
+		t.Errorf("expected the requesting user as owner, got %q", owner)
+	}
+}
+
+func TestMissionAccessGuard_ReadRoutes(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	srv.requestLogger = slog.New(slog.DiscardHandler)
+	srv.cachedConfig.Store(&config.AgencConfig{
+		MultiUser: &config.MultiUserConfig{TmuxSocket: "/tmp/agenc-shared.sock"},
+	})
+	owned, err := srv.db.CreateMission("", &database.CreateMissionParams{Owner: "4000001"})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	srv.registerRoutes(mux)
+
+	serveAs := func(uid uint32, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), peerUIDContextKey{}, uid))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Reads expose the mission's prompts, transcript, and diff, so strangers
+	// are turned away from them just like from mutations
+	for _, suffix := range []string{"", "/branch", "/diff", "/prompts", "/handoff", "/output", "/stats", "/events", "/remote-cleanup"} {
+		if rec := serveAs(4000003, "/missions/"+owned.ShortID+suffix); rec.Code != http.StatusForbidden {
+			t.Errorf("GET /missions/{id}%s: expected 403 for a stranger, got %d", suffix, rec.Code)
+		}
+	}
+	if rec := serveAs(4000003, "/events?mission="+owned.ShortID); rec.Code != http.StatusForbidden {
+		t.Errorf("GET /events?mission={id}: expected 403 for a stranger, got %d", rec.Code)
+	}
+
+	// Routes spanning all missions leave the owned mission out for a stranger
+	srv.publishEvent(EventPromptSubmitted, owned.ID, nil)
+	projectDirpath := filepath.Join(claudeconfig.GetMissionClaudeConfigDirpath(srv.agencDirpath, owned.ID), "projects", "-agent-"+owned.ID)
+	if err := os.MkdirAll(projectDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	transcript := `{"type":"user","timestamp":"2026-03-01T10:00:00Z","message":{"role":"user","content":"the launch codes"}}` + "\n"
+	if err := os.WriteFile(filepath.Join(projectDirpath, "sess-1.jsonl"), []byte(transcript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/events", "/missions/search/transcripts?q=launch+codes"} {
+		var ownerResults, strangerResults []map[string]any
+		if err := json.NewDecoder(serveAs(4000001, path).Body).Decode(&ownerResults); err != nil {
+			t.Fatalf("GET %s: failed to decode the owner's response: %v", path, err)
+		}
+		if err := json.NewDecoder(serveAs(4000003, path).Body).Decode(&strangerResults); err != nil {
+			t.Fatalf("GET %s: failed to decode the stranger's response: %v", path, err)
+		}
+		if len(ownerResults) != 1 || len(strangerResults) != 0 {
+			t.Errorf("GET %s: expected 1 result for the owner and none for a stranger, got %d and %d", path, len(ownerResults), len(strangerResults))
+		}
+	}
+}
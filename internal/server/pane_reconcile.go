@@ -2,11 +2,11 @@ package server
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 // reconcilePaneIDs clears all stored tmux pane IDs and repopulates them by
@@ -81,7 +81,7 @@ type poolPaneInfo struct {
 // listPoolPanesWithPIDs queries tmux for all panes in the agenc-pool session
 // and returns their pane IDs (without "%" prefix) and process PIDs.
 func listPoolPanesWithPIDs(poolSessionName string) ([]poolPaneInfo, error) {
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", "="+poolSessionName, "-F", "#{pane_id} #{pane_pid}")
+	cmd := tmux.Command("list-panes", "-s", "-t", "="+poolSessionName, "-F", "#{pane_id} #{pane_pid}")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("tmux list-panes failed: %w", err)
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package server
+
+import (
+	"errors"
+	"net"
+)
+
+// peerUID is unsupported on this platform; multi-user access checks are
+// skipped for connections whose peer can't be identified.
+func peerUID(conn *net.UnixConn) (uint32, error) {
+	return 0, errors.New("peer credentials are not supported on this platform")
+}
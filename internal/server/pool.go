@@ -2,7 +2,6 @@ package server
 
 import (
 	"fmt"
-	"os/exec"
 	"sort"
 	"strings"
 
@@ -10,6 +9,7 @@ import (
 
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 // getPoolSessionName returns the pool tmux session name for this server's
@@ -27,13 +27,14 @@ func (s *Server) ensurePoolSession() error {
 		return nil
 	}
 
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", poolName, "-x", "200", "-y", "50")
+	cmd := tmux.Command("new-session", "-d", "-s", poolName, "-x", "200", "-y", "50")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return stacktrace.NewError("failed to create pool session: %v (output: %s)", err, string(output))
 	}
 
 	s.logger.Printf("Created tmux pool session: %s", poolName)
+	s.shareTmuxServer()
 	return nil
 }
 
@@ -41,7 +42,7 @@ func (s *Server) ensurePoolSession() error {
 // Uses tmux exact-match syntax (=name) to prevent prefix matching
 // (e.g., "agenc" would otherwise match "agenc-pool").
 func tmuxSessionExists(sessionName string) bool {
-	return exec.Command("tmux", "has-session", "-t", "="+sessionName).Run() == nil
+	return tmux.Command("has-session", "-t", "="+sessionName).Run() == nil
 }
 
 // createPoolWindow creates a new window in the pool session for the given
@@ -62,7 +63,7 @@ func (s *Server) createPoolWindow(missionID string, command string) (string, str
 	// mission title AgenC sets via rename-window. Without this, opening a side
 	// shell inside the mission's window clobbers the title for users with
 	// `allow-rename on` in their tmux config. See GitHub issue #5.
-	cmd := exec.Command("tmux",
+	cmd := tmux.Command(
 		"new-window", "-d", "-P", "-F", "#{pane_id}", "-t", target, "-n", windowName, command,
 		";",
 		"set-window-option", "-t", windowTarget, "allow-rename", "off",
@@ -87,7 +88,7 @@ func (s *Server) createPoolWindow(missionID string, command string) (string, str
 func unlinkPoolWindowByPane(paneID string, targetSession string) error {
 	paneTarget := "%" + paneID
 	// Find the window index in the target session that contains this pane
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", "="+targetSession, "-F", "#{pane_id} #{window_index}")
+	cmd := tmux.Command("list-panes", "-s", "-t", "="+targetSession, "-F", "#{pane_id} #{window_index}")
 	output, err := cmd.Output()
 	if err != nil {
 		return stacktrace.NewError("failed to list panes in session %s: %v", targetSession, err)
@@ -105,7 +106,7 @@ func unlinkPoolWindowByPane(paneID string, targetSession string) error {
 	}
 
 	windowTarget := fmt.Sprintf("%s:%s", targetSession, windowIndex)
-	unlinkCmd := exec.Command("tmux", "unlink-window", "-t", windowTarget)
+	unlinkCmd := tmux.Command("unlink-window", "-t", windowTarget)
 	unlinkOutput, err := unlinkCmd.CombinedOutput()
 	if err != nil {
 		return stacktrace.NewError("failed to unlink window: %v (output: %s)", err, string(unlinkOutput))
@@ -125,7 +126,7 @@ func killExtraPanesInWindow(keepPaneID string, poolSessionName string, logger in
 
 	// List all panes in the window containing keepPaneID, within the pool session.
 	// We query all panes in the pool and filter by window_id to find siblings.
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", "="+poolSessionName, "-F", "#{pane_id} #{window_id}")
+	cmd := tmux.Command("list-panes", "-s", "-t", "="+poolSessionName, "-F", "#{pane_id} #{window_id}")
 	output, err := cmd.Output()
 	if err != nil {
 		return
@@ -153,7 +154,7 @@ func killExtraPanesInWindow(keepPaneID string, poolSessionName string, logger in
 		paneID := parts[0]
 		windowID := parts[1]
 		if windowID == targetWindowID && paneID != keepTarget {
-			killCmd := exec.Command("tmux", "kill-pane", "-t", paneID)
+			killCmd := tmux.Command("kill-pane", "-t", paneID)
 			if killOutput, err := killCmd.CombinedOutput(); err != nil {
 				logger.Printf("Warning: failed to kill extra pane %s in pool window: %v (output: %s)", paneID, err, strings.TrimSpace(string(killOutput)))
 			} else {
@@ -165,13 +166,19 @@ func killExtraPanesInWindow(keepPaneID string, poolSessionName string, logger in
 
 // destroyPoolWindow kills the tmux window containing the given pane. Uses the
 // pane ID (immutable) rather than the window name (which may have been changed
-// by title reconciliation). No-op if paneID is empty.
+// by title reconciliation). A pane joined into a user's window (attach
+// --split) is killed on its own so the rest of that window survives. No-op if
+// paneID is empty.
 func (s *Server) destroyPoolWindow(paneID string) {
 	if paneID == "" {
 		return
 	}
 	paneTarget := "%" + paneID
-	cmd := exec.Command("tmux", "kill-window", "-t", paneTarget)
+	killCmd := "kill-window"
+	if tmuxPaneExists(paneID) && !isPaneInSession(paneID, s.getPoolSessionName()) {
+		killCmd = "kill-pane"
+	}
+	cmd := tmux.Command(killCmd, "-t", paneTarget)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Non-fatal: window may already be gone
@@ -189,6 +196,15 @@ func poolWindowExistsByPane(paneID string, poolSessionName string) bool {
 	return isPaneInSession(paneID, poolSessionName)
 }
 
+// tmuxPaneExists checks whether the given pane (without "%" prefix) exists
+// anywhere on the tmux server — in the pool or joined into a user's window.
+func tmuxPaneExists(paneID string) bool {
+	if paneID == "" {
+		return false
+	}
+	return tmux.Command("display-message", "-p", "-t", "%"+paneID, "#{pane_id}").Run() == nil
+}
+
 // getLinkedPaneIDs returns the set of tmux pane IDs (without the "%" prefix)
 // that are visible in at least one tmux session besides the pool. This uses
 // pane IDs rather than window names because window names can be renamed by tmux
@@ -198,7 +214,7 @@ func poolWindowExistsByPane(paneID string, poolSessionName string) bool {
 // If the tmux command fails (e.g., no server running), returns an empty map so
 // the caller falls through to the existing idle-kill behavior.
 func getLinkedPaneIDs(poolSessionName string) map[string]bool {
-	cmd := exec.Command("tmux", "list-panes", "-a", "-F", "#{session_name} #{pane_id}")
+	cmd := tmux.Command("list-panes", "-a", "-F", "#{session_name} #{pane_id}")
 	output, err := cmd.Output()
 	if err != nil {
 		return map[string]bool{}
@@ -279,7 +295,7 @@ func (s *Server) isMissionAttachedToNonPool(m *database.Mission) bool {
 //
 // If the tmux command fails (e.g., no server running), returns an empty map.
 func getLinkedPaneSessions(poolSessionName string) map[string][]string {
-	cmd := exec.Command("tmux", "list-panes", "-a", "-F", "#{session_name} #{pane_id}")
+	cmd := tmux.Command("list-panes", "-a", "-F", "#{session_name} #{pane_id}")
 	output, err := cmd.Output()
 	if err != nil {
 		return map[string][]string{}
@@ -321,7 +337,7 @@ func getLinkedPaneSessions(poolSessionName string) map[string][]string {
 // doesn't exist or the tmux command fails.
 func isPaneInSession(paneID string, sessionName string) bool {
 	target := "%" + paneID
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", "="+sessionName, "-F", "#{pane_id}")
+	cmd := tmux.Command("list-panes", "-s", "-t", "="+sessionName, "-F", "#{pane_id}")
 	output, err := cmd.Output()
 	if err != nil {
 		return false
@@ -339,7 +355,7 @@ func isPaneInSession(paneID string, sessionName string) bool {
 // name (which may have been changed by title reconciliation).
 func linkPoolWindowByPane(paneID string, targetSession string) error {
 	paneTarget := "%" + paneID
-	cmd := exec.Command("tmux", "link-window", "-d", "-a", "-s", paneTarget, "-t", "="+targetSession+":")
+	cmd := tmux.Command("link-window", "-d", "-a", "-s", paneTarget, "-t", "="+targetSession+":")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return stacktrace.NewError("failed to link window by pane: %v (output: %s)", err, string(output))
@@ -354,7 +370,7 @@ func linkPoolWindowByPane(paneID string, targetSession string) error {
 func focusPaneInSession(paneID string, sessionName string) {
 	paneTarget := "%" + paneID
 	// Query all panes in the session to find the window index for our pane
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", "="+sessionName, "-F", "#{pane_id} #{window_index}")
+	cmd := tmux.Command("list-panes", "-s", "-t", "="+sessionName, "-F", "#{pane_id} #{window_index}")
 	output, err := cmd.Output()
 	if err != nil {
 		return
@@ -364,30 +380,92 @@ func focusPaneInSession(paneID string, sessionName string) {
 		if len(parts) == 2 && parts[0] == paneTarget {
 			windowTarget := fmt.Sprintf("%s:%s", sessionName, parts[1])
 			//nolint:errcheck // best-effort
-			exec.Command("tmux", "select-window", "-t", windowTarget).Run()
+			tmux.Command("select-window", "-t", windowTarget).Run()
 			return
 		}
 	}
 }
 
-// listPoolPaneIDs returns the pane IDs (without "%" prefix) of all panes
-// currently running in the pool tmux session. Returns an empty slice
-// if the pool doesn't exist or tmux is not running.
-func listPoolPaneIDs(poolSessionName string) []string {
-	cmd := exec.Command("tmux", "list-panes", "-s", "-t", "="+poolSessionName, "-F", "#{pane_id}")
-	output, err := cmd.Output()
+// joinPaneIntoWindow moves the given pane out of its window and splits it
+// into the window of callerPaneID, side by side with the caller. Side shell
+// panes left in the pool window are killed first, the same cleanup detach
+// does, so they don't linger once the mission pane is gone. A no-op apart
+// from focusing when the pane is already in the caller's window.
+func joinPaneIntoWindow(paneID string, callerPaneID string, poolSessionName string, noFocus bool, logger interface{ Printf(string, ...any) }) error {
+	paneTarget := "%" + paneID
+	callerTarget := "%" + callerPaneID
+
+	callerWindowID := paneWindowID(callerPaneID)
+	if callerWindowID == "" {
+		return stacktrace.NewError("pane %s not found", callerPaneID)
+	}
+	if paneWindowID(paneID) == callerWindowID {
+		if !noFocus {
+			//nolint:errcheck // best-effort
+			tmux.Command("select-pane", "-t", paneTarget).Run()
+		}
+		return nil
+	}
+
+	killExtraPanesInWindow(paneID, poolSessionName, logger)
+
+	args := []string{"join-pane", "-h"}
+	if noFocus {
+		args = append(args, "-d")
+	}
+	args = append(args, "-s", paneTarget, "-t", callerTarget)
+	output, err := tmux.Command(args...).CombinedOutput()
+	if err != nil {
+		return stacktrace.NewError("failed to join pane: %v (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// breakPaneToPool moves a pane joined into a user's window (attach --split)
+// back into its own window in the pool session, named windowName and pinned
+// to allow-rename=off like the windows createPoolWindow makes.
+func breakPaneToPool(paneID string, poolSessionName string, windowName string) error {
+	cmd := tmux.Command("break-pane", "-d", "-P", "-F", "#{window_id}", "-s", "%"+paneID, "-t", "="+poolSessionName+":", "-n", windowName)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return stacktrace.NewError("failed to move pane back to the pool: %v (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	windowID := strings.TrimSpace(string(output))
+	//nolint:errcheck // best-effort, mirrors createPoolWindow
+	tmux.Command("set-window-option", "-t", windowID, "allow-rename", "off").Run()
+	return nil
+}
+
+// paneWindowID returns the tmux window ID (e.g. "@7") of the window holding
+// the given pane, or an empty string if the pane doesn't exist.
+func paneWindowID(paneID string) string {
+	out, err := tmux.Command("display-message", "-p", "-t", "%"+paneID, "#{window_id}").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// listAllPaneIDs returns the pane IDs (without "%" prefix) of every pane on
+// the tmux server, so mission panes joined into a user's window are included
+// alongside the pool. Panes in windows linked into several sessions are
+// listed once. Returns an empty slice if tmux is not running.
+func listAllPaneIDs() []string {
+	output, err := tmux.Command("list-panes", "-a", "-F", "#{pane_id}").Output()
 	if err != nil {
 		return nil
 	}
 
+	seen := make(map[string]bool)
 	var paneIDs []string
 	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
+		// Strip the "%" prefix — DB stores pane IDs without it
+		paneID := strings.TrimPrefix(strings.TrimSpace(line), "%")
+		if paneID == "" || seen[paneID] {
 			continue
 		}
-		// Strip the "%" prefix — DB stores pane IDs without it
-		paneIDs = append(paneIDs, strings.TrimPrefix(line, "%"))
+		seen[paneID] = true
+		paneIDs = append(paneIDs, paneID)
 	}
 	return paneIDs
 }
@@ -398,7 +476,7 @@ func listPoolPaneIDs(poolSessionName string) []string {
 func sendKeysToPane(paneID string, keys []string) error {
 	paneTarget := "%" + paneID
 	args := append([]string{"send-keys", "-t", paneTarget}, keys...)
-	cmd := exec.Command("tmux", args...)
+	cmd := tmux.Command(args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return stacktrace.NewError("tmux send-keys failed: %v (output: %s)", err, strings.TrimSpace(string(output)))
@@ -55,8 +55,9 @@ func tryAcquireServerLock(lockFilepath string) (*os.File, error) {
 
 // ForkServer re-executes the current binary as a background server process.
 // The child's stdout/stderr are redirected to logFilepath, and its PID is
-// written to pidFilepath.
-func ForkServer(logFilepath string, pidFilepath string) error {
+// written to pidFilepath. Any extraArgs are appended to the "server run"
+// invocation.
+func ForkServer(logFilepath string, pidFilepath string, extraArgs ...string) error {
 	executableFilepath, err := os.Executable()
 	if err != nil {
 		return stacktrace.Propagate(err, "failed to determine executable path")
@@ -67,7 +68,7 @@ func ForkServer(logFilepath string, pidFilepath string) error {
 		return stacktrace.Propagate(err, "failed to open server log file")
 	}
 
-	cmd := exec.Command(executableFilepath, "server", "run")
+	cmd := exec.Command(executableFilepath, append([]string{"server", "run"}, extraArgs...)...)
 	cmd.Stdout = logFile
 	cmd.Stderr = logFile
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// ProjectResponse is the JSON representation of a project returned by the
+// API. MissionCount counts the project's non-archived missions; Crons names
+// the cron jobs whose runs join the project.
+type ProjectResponse struct {
+	Name         string    `json:"name"`
+	GitRepo      string    `json:"git_repo"`
+	Notes        string    `json:"notes"`
+	MissionCount int       `json:"mission_count"`
+	Crons        []string  `json:"crons"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CreateProjectRequest is the JSON body for POST /projects.
+type CreateProjectRequest struct {
+	Name    string `json:"name"`
+	GitRepo string `json:"git_repo"`
+	Notes   string `json:"notes"`
+}
+
+// UpdateProjectRequest is the JSON body for PATCH /projects/{name}. Only
+// non-nil fields are applied; empty strings clear them.
+type UpdateProjectRequest struct {
+	GitRepo *string `json:"git_repo,omitempty"`
+	Notes   *string `json:"notes,omitempty"`
+}
+
+// handleListProjects handles GET /projects.
+func (s *Server) handleListProjects(w http.ResponseWriter, r *http.Request) error {
+	projects, err := s.db.ListProjects()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	counts, err := s.db.CountMissionsByProject()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resp := make([]ProjectResponse, 0, len(projects))
+	for _, p := range projects {
+		resp = append(resp, s.toProjectResponse(p, counts[p.Name]))
+	}
+	writeJSON(w, http.StatusOK, resp)
+	return nil
+}
+
+// handleCreateProject handles POST /projects.
+func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) error {
+	var req CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if err := config.ValidateProjectName(req.Name); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%s", err.Error())
+	}
+
+	existing, err := s.db.GetProject(req.Name)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if existing != nil {
+		return newHTTPErrorf(http.StatusConflict, "project '%s' already exists", req.Name)
+	}
+
+	project := &database.Project{Name: req.Name, GitRepo: req.GitRepo, Notes: strings.TrimSpace(req.Notes)}
+	if err := s.db.CreateProject(project); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to create project: %s", err.Error())
+	}
+
+	writeJSON(w, http.StatusCreated, s.toProjectResponse(project, 0))
+	return nil
+}
+
+// handleGetProject handles GET /projects/{name}.
+func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) error {
+	project, err := s.getProjectOr404(r.PathValue("name"))
+	if err != nil {
+		return err
+	}
+	counts, err := s.db.CountMissionsByProject()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	writeJSON(w, http.StatusOK, s.toProjectResponse(project, counts[project.Name]))
+	return nil
+}
+
+// handleUpdateProject handles PATCH /projects/{name}.
+func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) error {
+	project, err := s.getProjectOr404(r.PathValue("name"))
+	if err != nil {
+		return err
+	}
+
+	var req UpdateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if req.GitRepo != nil {
+		project.GitRepo = *req.GitRepo
+	}
+	if req.Notes != nil {
+		project.Notes = strings.TrimSpace(*req.Notes)
+	}
+	if err := s.db.UpdateProject(project.Name, project.GitRepo, project.Notes); err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "failed to update project: %s", err.Error())
+	}
+
+	updated, err := s.getProjectOr404(project.Name)
+	if err != nil {
+		return err
+	}
+	counts, err := s.db.CountMissionsByProject()
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	writeJSON(w, http.StatusOK, s.toProjectResponse(updated, counts[updated.Name]))
+	return nil
+}
+
+func (s *Server) getProjectOr404(name string) (*database.Project, error) {
+	project, err := s.db.GetProject(name)
+	if err != nil {
+		return nil, newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if project == nil {
+		return nil, newHTTPError(http.StatusNotFound, "project not found: "+name)
+	}
+	return project, nil
+}
+
+func (s *Server) toProjectResponse(p *database.Project, missionCount int) ProjectResponse {
+	return ProjectResponse{
+		Name:         p.Name,
+		GitRepo:      p.GitRepo,
+		Notes:        p.Notes,
+		MissionCount: missionCount,
+		Crons:        projectCronNames(s.getConfig(), p.Name),
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
+
+// projectCronNames returns the names of the crons whose runs join the named
+// project, sorted.
+func projectCronNames(cfg *config.AgencConfig, project string) []string {
+	names := []string{}
+	for name, cronCfg := range cfg.Crons {
+		if cronCfg.Project == project {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateProjectRef checks that a project named in a request exists. An
+// empty name (no project) is always valid.
+func (s *Server) validateProjectRef(name string) error {
+	if name == "" {
+		return nil
+	}
+	project, err := s.db.GetProject(name)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if project == nil {
+		return newHTTPErrorf(http.StatusBadRequest, "project '%s' does not exist; create it with 'agenc project new %s'", name, name)
+	}
+	return nil
+}
+
+// resolveMissionProject picks the project a new mission joins: the one
+// requested, else the one its cron names, else its parent or clone source's.
+// A cron naming a project that no longer exists is logged and ignored so the
+// run still starts.
+func (s *Server) resolveMissionProject(req CreateMissionRequest) (string, error) {
+	if req.Project != "" {
+		return req.Project, s.validateProjectRef(req.Project)
+	}
+
+	switch {
+	case req.Source == "cron" && req.SourceID != "":
+		cronName, cronCfg, ok := s.findCronByID(req.SourceID)
+		if !ok || cronCfg.Project == "" {
+			return "", nil
+		}
+		if err := s.validateProjectRef(cronCfg.Project); err != nil {
+			s.logger.Printf("Cron '%s' names missing project '%s'; starting its mission outside any project", cronName, cronCfg.Project)
+			return "", nil
+		}
+		return cronCfg.Project, nil
+	case req.CloneFrom != "":
+		return s.missionProject(req.CloneFrom), nil
+	case req.Source == "mission" && req.SourceID != "":
+		return s.missionProject(req.SourceID), nil
+	}
+	return "", nil
+}
+
+// missionProject returns the project of the given mission, or "" when it has
+// none or can't be found.
+func (s *Server) missionProject(missionID string) string {
+	resolvedID, err := s.db.ResolveMissionID(missionID)
+	if err != nil {
+		return ""
+	}
+	m, err := s.db.GetMission(resolvedID)
+	if err != nil || m == nil {
+		return ""
+	}
+	return m.Project
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestProjectHandlers(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	srv.cachedConfig.Store(&config.AgencConfig{Crons: map[string]config.CronConfig{
+		"nightly": {ID: "cron-1", Project: "billing"},
+		"weekly":  {ID: "cron-2"},
+	}})
+
+	create := func(body string) (*httptest.ResponseRecorder, error) {
+		w := httptest.NewRecorder()
+		err := srv.handleCreateProject(w, httptest.NewRequest(http.MethodPost, "/projects", strings.NewReader(body)))
+		return w, err
+	}
+	if _, err := create(`{"name":"billing","git_repo":"github.com/acme/billing","notes":"  ship invoices  "}`); err != nil {
+		t.Fatalf("handleCreateProject failed: %v", err)
+	}
+	if _, err := create(`{"name":"billing"}`); err == nil || err.(*httpError).status != http.StatusConflict {
+		t.Errorf("expected a conflict for a duplicate project, got %v", err)
+	}
+	if _, err := create(`{"name":"not valid"}`); err == nil || err.(*httpError).status != http.StatusBadRequest {
+		t.Errorf("expected a bad request for an invalid name, got %v", err)
+	}
+
+	if _, err := srv.db.CreateMission("github.com/acme/billing", &database.CreateMissionParams{Project: "billing"}); err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/billing", nil)
+	req.SetPathValue("name", "billing")
+	w := httptest.NewRecorder()
+	if err := srv.handleGetProject(w, req); err != nil {
+		t.Fatalf("handleGetProject failed: %v", err)
+	}
+	var project ProjectResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &project); err != nil {
+		t.Fatalf("failed to decode project: %v", err)
+	}
+	if project.Notes != "ship invoices" || project.MissionCount != 1 {
+		t.Errorf("expected trimmed notes and 1 mission, got %+v", project)
+	}
+	if len(project.Crons) != 1 || project.Crons[0] != "nightly" {
+		t.Errorf("expected the nightly cron, got %v", project.Crons)
+	}
+
+	req = httptest.NewRequest(http.MethodPatch, "/projects/billing", strings.NewReader(`{"notes":""}`))
+	req.SetPathValue("name", "billing")
+	w = httptest.NewRecorder()
+	if err := srv.handleUpdateProject(w, req); err != nil {
+		t.Fatalf("handleUpdateProject failed: %v", err)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &project); err != nil {
+		t.Fatalf("failed to decode project: %v", err)
+	}
+	if project.Notes != "" || project.GitRepo != "github.com/acme/billing" {
+		t.Errorf("expected notes cleared and repo kept, got %+v", project)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/projects/missing", nil)
+	req.SetPathValue("name", "missing")
+	if err := srv.handleGetProject(httptest.NewRecorder(), req); err == nil || err.(*httpError).status != http.StatusNotFound {
+		t.Errorf("expected not found for a missing project, got %v", err)
+	}
+}
+
+func TestResolveMissionProject(t *testing.T) {
+	srv := newMissionQueueTestServer(t, 0)
+	srv.cachedConfig.Store(&config.AgencConfig{Crons: map[string]config.CronConfig{
+		"nightly": {ID: "cron-1", Project: "billing"},
+		"stale":   {ID: "cron-2", Project: "deleted"},
+	}})
+	if err := srv.db.CreateProject(&database.Project{Name: "billing"}); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	parent, err := srv.db.CreateMission("github.com/acme/billing", &database.CreateMissionParams{Project: "billing"})
+	if err != nil {
+		t.Fatalf("failed to create mission: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		req     CreateMissionRequest
+		want    string
+		wantErr bool
+	}{
+		{"explicit", CreateMissionRequest{Project: "billing"}, "billing", false},
+		{"explicit missing", CreateMissionRequest{Project: "nope"}, "", true},
+		{"cron", CreateMissionRequest{Source: "cron", SourceID: "cron-1"}, "billing", false},
+		{"cron naming deleted project", CreateMissionRequest{Source: "cron", SourceID: "cron-2"}, "", false},
+		{"child mission", CreateMissionRequest{Source: "mission", SourceID: parent.ShortID}, "billing", false},
+		{"clone", CreateMissionRequest{CloneFrom: parent.ID}, "billing", false},
+		{"none", CreateMissionRequest{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := srv.resolveMissionProject(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveMissionProject error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("expected project %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
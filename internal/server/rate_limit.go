@@ -0,0 +1,144 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// rateLimiter holds the state behind the rateLimit config: one token bucket
+// per limited route and the count of requests in flight. The zero value is
+// ready to use.
+type rateLimiter struct {
+	mu       sync.Mutex
+	buckets  map[string]*tokenBucket
+	inFlight atomic.Int64
+}
+
+// tokenBucket refills at limit.PerMinute tokens per minute up to limit.Burst.
+type tokenBucket struct {
+	limit  config.EndpointRateLimit
+	tokens float64
+	last   time.Time
+}
+
+// take spends a token if one is available. Otherwise it returns false and how
+// long until the next token.
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	perSecond := float64(b.limit.PerMinute) / 60
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+}
+
+// allow reports whether a request to pattern fits its rate limit, returning
+// how long the caller should wait otherwise. A bucket is reset when its limit
+// changes in config.yml.
+func (l *rateLimiter) allow(pattern string, limit config.EndpointRateLimit, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bucket, ok := l.buckets[pattern]
+	if !ok || bucket.limit != limit {
+		bucket = &tokenBucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		if l.buckets == nil {
+			l.buckets = make(map[string]*tokenBucket)
+		}
+		l.buckets[pattern] = bucket
+	}
+	return bucket.take(now)
+}
+
+// acquire claims one of max request slots, returning false when all are
+// taken. Callers that get true must call release.
+func (l *rateLimiter) acquire(max int) bool {
+	if l.inFlight.Add(1) > int64(max) {
+		l.inFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+func (l *rateLimiter) release() {
+	l.inFlight.Add(-1)
+}
+
+// streamingPatterns are the routes that stream Server-Sent Events when called
+// with follow=true.
+var streamingPatterns = map[string]bool{
+	"GET /events":               true,
+	"GET /missions/{id}/output": true,
+}
+
+// isExemptFromConcurrencyCap reports whether a request skips the concurrent
+// request cap: streams (follow=true on a streaming route) stay open
+// indefinitely and would otherwise hold slots, and health checks must answer
+// even when the server is saturated so the CLI doesn't think it's down.
+func isExemptFromConcurrencyCap(r *http.Request, pattern string) bool {
+	if pattern == "GET /health" {
+		return true
+	}
+	return streamingPatterns[pattern] && r.URL.Query().Get("follow") == "true"
+}
+
+// rateLimitMiddleware applies the rateLimit config to every request routed
+// by mux: the per-route limits, then the concurrent request cap. Rejected
+// requests get a 429 with a Retry-After header.
+func (s *Server) rateLimitMiddleware(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		cfg := s.getConfig()
+
+		if limit, ok := cfg.GetEndpointRateLimit(pattern); ok {
+			if allowed, wait := s.rateLimiter.allow(pattern, limit, time.Now()); !allowed {
+				s.rejectRateLimited(w, r, wait, fmt.Sprintf(
+					"rate limit for %s exceeded (%d per minute, burst %d)", pattern, limit.PerMinute, limit.Burst))
+				return
+			}
+		}
+
+		if !isExemptFromConcurrencyCap(r, pattern) {
+			maxRequests := cfg.GetMaxConcurrentRequests()
+			if !s.rateLimiter.acquire(maxRequests) {
+				s.rejectRateLimited(w, r, time.Second, fmt.Sprintf(
+					"server is busy (%d requests in flight)", maxRequests))
+				return
+			}
+			defer s.rateLimiter.release()
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// rejectRateLimited writes a 429 JSON error telling the caller when to retry
+// and records it in the request log.
+func (s *Server) rejectRateLimited(w http.ResponseWriter, r *http.Request, wait time.Duration, reason string) {
+	retryAfterSeconds := max(1, int(math.Ceil(wait.Seconds())))
+	message := fmt.Sprintf("%s; retry in %ds", reason, retryAfterSeconds)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(errorResponse{Message: message}) // response already started; encode error cannot be propagated
+
+	if s.requestLogger != nil {
+		s.requestLogger.LogAttrs(r.Context(), slog.LevelWarn, "request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", http.StatusTooManyRequests),
+			slog.String("error", message),
+		)
+	}
+}
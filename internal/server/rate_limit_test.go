@@ -0,0 +1,103 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestTokenBucket_BurstThenRefill(t *testing.T) {
+	var limiter rateLimiter
+	limit := config.EndpointRateLimit{PerMinute: 60, Burst: 2}
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.allow("POST /missions", limit, now); !ok {
+			t.Fatalf("request %d within burst was refused", i+1)
+		}
+	}
+	ok, wait := limiter.allow("POST /missions", limit, now)
+	if ok {
+		t.Fatal("expected request beyond burst to be refused")
+	}
+	if wait <= 0 || wait > time.Second {
+		t.Errorf("expected a wait of up to 1s at 60/min, got %v", wait)
+	}
+
+	if ok, _ := limiter.allow("POST /missions", limit, now.Add(time.Second)); !ok {
+		t.Error("expected a token to be refilled after 1s")
+	}
+	if ok, _ := limiter.allow("POST /missions/{id}/stop", limit, now); !ok {
+		t.Error("expected other routes to have their own bucket")
+	}
+
+	// Changing the limit resets the bucket
+	raised := config.EndpointRateLimit{PerMinute: 60, Burst: 5}
+	if ok, _ := limiter.allow("POST /missions", raised, now.Add(time.Second)); !ok {
+		t.Error("expected a changed limit to start with a full bucket")
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	srv := &Server{}
+	srv.cachedConfig.Store(&config.AgencConfig{RateLimit: &config.RateLimitConfig{
+		MaxConcurrentRequests: 1,
+		Endpoints: map[string]config.EndpointRateLimit{
+			"POST /missions/{id}/stop": {PerMinute: 1},
+		},
+	}})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /missions/{id}/stop", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	handler := srv.rateLimitMiddleware(mux)
+
+	serve := func(method string, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec
+	}
+
+	if rec := serve(http.MethodPost, "/missions/abc/stop"); rec.Code != http.StatusOK {
+		t.Fatalf("first stop: expected 200, got %d", rec.Code)
+	}
+	rec := serve(http.MethodPost, "/missions/def/stop")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second stop: expected 429, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header on 429")
+	}
+
+	// Fill the single request slot, then check the cap and its exemptions
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serve(http.MethodGet, "/slow")
+	}()
+	<-started
+	if rec := serve(http.MethodGet, "/slow"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429 while the request slot is taken, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodGet, "/health"); rec.Code != http.StatusOK {
+		t.Errorf("expected health checks to bypass the cap, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodGet, "/slow?follow=true"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected follow=true on a non-streaming route to stay capped, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodGet, "/events?follow=true"); rec.Code != http.StatusOK {
+		t.Errorf("expected event streams to bypass the cap, got %d", rec.Code)
+	}
+	close(release)
+	<-done
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/repo"
+)
+
+// CreateRepoRequest is the JSON body for POST /repos/create.
+type CreateRepoRequest struct {
+	// Name is the new GitHub repo as owner/repo, or a bare repo name to
+	// create under the logged-in gh user.
+	Name    string `json:"name"`
+	Private bool   `json:"private,omitempty"`
+	// Template is an owner/repo template repository to generate from.
+	Template    string `json:"template,omitempty"`
+	License     string `json:"license,omitempty"`
+	Gitignore   string `json:"gitignore,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateRepoResponse is the JSON shape returned by POST /repos/create.
+type CreateRepoResponse struct {
+	Name string `json:"name"`
+}
+
+// resolveNewRepoName expands name to owner/repo using defaultOwner when it is
+// a bare repo name, and returns it with its canonical library name.
+func resolveNewRepoName(name string, defaultOwner string) (string, string, error) {
+	if !strings.Contains(name, "/") {
+		if defaultOwner == "" {
+			return "", "", newHTTPErrorf(http.StatusBadRequest,
+				"cannot determine the owner for '%s'; pass owner/repo or log in with 'gh auth login'", name)
+		}
+		name = defaultOwner + "/" + name
+	}
+	if strings.Count(name, "/") != 1 || strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") {
+		return "", "", newHTTPErrorf(http.StatusBadRequest, "invalid repo name '%s'; expected owner/repo", name)
+	}
+	repoName, _, err := mission.ParseRepoReference(name, false, "")
+	if err != nil {
+		return "", "", newHTTPErrorf(http.StatusBadRequest, "invalid repo name '%s': %v", name, err)
+	}
+	return name, repoName, nil
+}
+
+// handleCreateRepo handles POST /repos/create. Creates a new GitHub repo
+// (optionally from a template), clones it into the repo library, and records
+// its description in the repo config.
+func (s *Server) handleCreateRepo(w http.ResponseWriter, r *http.Request) error {
+	var req CreateRepoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if req.Name == "" {
+		return newHTTPError(http.StatusBadRequest, "name is required")
+	}
+
+	ownerRepo, repoName, err := resolveNewRepoName(req.Name, repo.GetDefaultGitHubUser())
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(config.GetRepoDirpath(s.agencDirpath, repoName)); err == nil {
+		return newHTTPErrorf(http.StatusConflict, "repo '%s' is already in the library", repoName)
+	}
+
+	if err := repo.CreateGitHubRepo(repo.CreateGitHubRepoOptions{
+		Name:        ownerRepo,
+		Private:     req.Private,
+		Template:    req.Template,
+		License:     req.License,
+		Gitignore:   req.Gitignore,
+		Description: req.Description,
+	}); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "failed to create GitHub repo '%s': %v", ownerRepo, err)
+	}
+	s.logger.Printf("Created GitHub repo '%s'", ownerRepo)
+
+	result, err := repo.ResolveAsRepoReference(s.agencDirpath, ownerRepo, "")
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "created '%s' on GitHub but failed to clone it: %v", ownerRepo, err)
+	}
+
+	if req.Description != "" {
+		release, err := config.AcquireConfigLock(s.agencDirpath)
+		if err != nil {
+			return newHTTPError(http.StatusInternalServerError, "failed to acquire config lock: "+err.Error())
+		}
+		defer release()
+
+		cfg, cm, err := config.ReadAgencConfig(s.agencDirpath)
+		if err != nil {
+			return newHTTPError(http.StatusInternalServerError, "failed to read config: "+err.Error())
+		}
+		rc, _ := cfg.GetRepoConfig(result.RepoName)
+		rc.Description = req.Description
+		cfg.SetRepoConfig(result.RepoName, rc)
+		if err := config.WriteAgencConfig(s.agencDirpath, cfg, cm); err != nil {
+			return newHTTPError(http.StatusInternalServerError, "failed to write config: "+err.Error())
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, CreateRepoResponse{Name: result.RepoName})
+	return nil
+}
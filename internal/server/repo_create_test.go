@@ -0,0 +1,43 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestResolveNewRepoName(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		defaultOwner  string
+		wantOwnerRepo string
+		wantRepoName  string
+		wantErr       bool
+	}{
+		{name: "owner/repo", input: "acme/app", wantOwnerRepo: "acme/app", wantRepoName: "github.com/acme/app"},
+		{name: "bare name uses default owner", input: "app", defaultOwner: "me", wantOwnerRepo: "me/app", wantRepoName: "github.com/me/app"},
+		{name: "bare name without default owner", input: "app", wantErr: true},
+		{name: "too many segments", input: "github.com/acme/app", wantErr: true},
+		{name: "empty owner", input: "/app", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ownerRepo, repoName, err := resolveNewRepoName(tt.input, tt.defaultOwner)
+			if tt.wantErr {
+				var httpErr *httpError
+				if !errors.As(err, &httpErr) || httpErr.status != http.StatusBadRequest {
+					t.Fatalf("expected 400 error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ownerRepo != tt.wantOwnerRepo || repoName != tt.wantRepoName {
+				t.Errorf("got (%q, %q), want (%q, %q)", ownerRepo, repoName, tt.wantOwnerRepo, tt.wantRepoName)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/repo"
+)
+
+// ForkRepoRequest is the JSON body for POST /repos/fork.
+type ForkRepoRequest struct {
+	// Upstream is the GitHub repo to fork, as owner/repo.
+	Upstream string `json:"upstream"`
+	// Organization forks into this organization instead of the logged-in gh
+	// user's account.
+	Organization string `json:"organization,omitempty"`
+	// Name gives the fork a different repo name than the upstream's.
+	Name string `json:"name,omitempty"`
+}
+
+// ForkRepoResponse is the JSON shape returned by POST /repos/fork.
+type ForkRepoResponse struct {
+	// Name is the fork's canonical repo name.
+	Name string `json:"name"`
+	// Upstream is the upstream's canonical repo name.
+	Upstream string `json:"upstream"`
+}
+
+// handleForkRepo handles POST /repos/fork. Forks a GitHub repo through the
+// GitHub API, clones both the upstream and the fork into the repo library,
+// and records the upstream in the fork's repo config so missions in the fork
+// get an "upstream" remote.
+func (s *Server) handleForkRepo(w http.ResponseWriter, r *http.Request) error {
+	var req ForkRepoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if req.Upstream == "" {
+		return newHTTPError(http.StatusBadRequest, "upstream is required")
+	}
+	if !strings.Contains(req.Upstream, "/") {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid upstream '%s'; expected owner/repo", req.Upstream)
+	}
+	if strings.Contains(req.Name, "/") {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid fork name '%s'; pass a bare repo name and use organization to pick the owner", req.Name)
+	}
+
+	upstreamOwnerRepo, _, err := resolveNewRepoName(req.Upstream, "")
+	if err != nil {
+		return err
+	}
+
+	upstreamResult, err := repo.ResolveAsRepoReference(s.agencDirpath, upstreamOwnerRepo, "")
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "failed to add upstream '%s' to the library: %v", upstreamOwnerRepo, err)
+	}
+
+	forkOwnerRepo, err := repo.ForkGitHubRepo(repo.ForkGitHubRepoOptions{
+		Upstream:     upstreamOwnerRepo,
+		Organization: req.Organization,
+		Name:         req.Name,
+	})
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "failed to fork '%s': %v", upstreamOwnerRepo, err)
+	}
+	s.logger.Printf("Forked GitHub repo '%s' to '%s'", upstreamOwnerRepo, forkOwnerRepo)
+
+	forkResult, err := repo.ResolveAsRepoReference(s.agencDirpath, forkOwnerRepo, "")
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "forked '%s' to '%s' but failed to clone the fork: %v", upstreamOwnerRepo, forkOwnerRepo, err)
+	}
+
+	// The library clone of the fork gets the remote too, so worktree-mode
+	// missions and manual work in the clone see it.
+	if err := mission.AddUpstreamRemote(forkResult.CloneDirpath, upstreamResult.CloneDirpath); err != nil {
+		s.logger.Printf("Warning: failed to add upstream remote to library clone of '%s': %v", forkResult.RepoName, err)
+	}
+
+	release, err := config.AcquireConfigLock(s.agencDirpath)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, "failed to acquire config lock: "+err.Error())
+	}
+	defer release()
+
+	cfg, cm, err := config.ReadAgencConfig(s.agencDirpath)
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, "failed to read config: "+err.Error())
+	}
+	rc, _ := cfg.GetRepoConfig(forkResult.RepoName)
+	rc.Upstream = upstreamResult.RepoName
+	cfg.SetRepoConfig(forkResult.RepoName, rc)
+	if err := config.WriteAgencConfig(s.agencDirpath, cfg, cm); err != nil {
+		return newHTTPError(http.StatusInternalServerError, "failed to write config: "+err.Error())
+	}
+
+	writeJSON(w, http.StatusCreated, ForkRepoResponse{Name: forkResult.RepoName, Upstream: upstreamResult.RepoName})
+	return nil
+}
+
+// addUpstreamRemoteIfFork gives a new mission's workspace an "upstream"
+// remote when its repo is a fork with a recorded upstream in the library.
+// Failures are logged and never propagated — the mission is usable without
+// the remote.
+func (s *Server) addUpstreamRemoteIfFork(gitRepoName string, agentDirpath string) {
+	upstreamRepoName := s.getConfig().GetRepoUpstream(gitRepoName)
+	if upstreamRepoName == "" {
+		return
+	}
+	upstreamDirpath := config.GetRepoDirpath(s.agencDirpath, upstreamRepoName)
+	if _, err := os.Stat(upstreamDirpath); err != nil {
+		s.logger.Printf("Warning: upstream '%s' of '%s' is not in the library; skipping upstream remote", upstreamRepoName, gitRepoName)
+		return
+	}
+	if err := mission.AddUpstreamRemote(agentDirpath, upstreamDirpath); err != nil {
+		s.logger.Printf("Warning: failed to add upstream remote for '%s': %v", gitRepoName, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/secrets"
 )
 
 const (
@@ -65,9 +66,14 @@ func (s *Server) processRepoUpdate(ctx context.Context, req repoUpdateRequest) {
 	headBefore, _ := mission.GetHEAD(repoDirpath)
 
 	if err := mission.ForceUpdateRepo(repoDirpath); err != nil {
-		s.logger.Printf("Repo update: failed to update '%s': %v", req.repoName, err)
+		if mission.IsNetworkError(err) {
+			s.deferRepoSync(req.repoName, repoSyncOperationFetch, err)
+		} else {
+			s.logger.Printf("Repo update: failed to update '%s': %v", req.repoName, err)
+		}
 		return
 	}
+	s.recordRepoReachable(req.repoName)
 
 	// Capture HEAD after update
 	headAfter, _ := mission.GetHEAD(repoDirpath)
@@ -105,13 +111,48 @@ func (s *Server) processRepoUpdate(ctx context.Context, req repoUpdateRequest) {
 			} else {
 				s.logger.Printf("Repo update: running postUpdateHook for '%s' (first clone)", req.repoName)
 			}
+			// Link cached paths first so the hook installs into the shared cache
+			s.linkDependencyCache(req.repoName, repoDirpath, rc.PostUpdateHookCache)
+			hookCmd, err := s.expandHookSecrets(rc.PostUpdateHook)
+			if err != nil {
+				s.logger.Printf("Repo update: skipping postUpdateHook for '%s': %v", req.repoName, err)
+				return
+			}
 			hookCtx, hookCancel := context.WithTimeout(ctx, postUpdateHookTimeout)
 			defer hookCancel()
-			runPostUpdateHook(hookCtx, s.logger, req.repoName, repoDirpath, rc.PostUpdateHook)
+			runPostUpdateHook(hookCtx, s.logger, req.repoName, repoDirpath, hookCmd)
 		}
 	}
 }
 
+// linkDependencyCache links a repo's postUpdateHookCache paths inside dirpath
+// (the library clone or a mission workspace) to the repo's shared dependency
+// cache. Failures are logged and never propagated: a workspace without the
+// cache still works, it just has to install dependencies itself.
+func (s *Server) linkDependencyCache(repoName string, dirpath string, cachePaths []string) {
+	if len(cachePaths) == 0 {
+		return
+	}
+	cacheDirpath := config.GetRepoDependencyCacheDirpath(s.agencDirpath, repoName)
+	if err := mission.LinkDependencyCache(dirpath, cacheDirpath, cachePaths); err != nil {
+		s.logger.Printf("Failed to link dependency cache for '%s' in %s: %v", repoName, dirpath, err)
+	}
+}
+
+// expandHookSecrets resolves secret://NAME references in a postUpdateHook
+// just before it runs, so secret values never live in config.yml. Each value
+// is single-quoted for the shell.
+func (s *Server) expandHookSecrets(hookCmd string) (string, error) {
+	if !secrets.HasRefs(hookCmd) {
+		return hookCmd, nil
+	}
+	store, err := secrets.Open(s.agencDirpath)
+	if err != nil {
+		return "", err
+	}
+	return store.ExpandShellCommand(hookCmd)
+}
+
 // runPostUpdateHook executes a shell command in the repo directory. It logs
 // success or failure but never returns an error — hook failures are non-fatal.
 func runPostUpdateHook(ctx context.Context, logger *log.Logger, repoName string, repoDirpath string, hookCmd string) {
@@ -158,7 +158,7 @@ func (s *Server) handleMoveRepo(w http.ResponseWriter, r *http.Request) error {
 	if err != nil {
 		s.logger.Printf("Warning: failed to read origin remote URL after rename: %v", err)
 	} else {
-		isSSH := strings.HasPrefix(currentRemoteURL, "git@")
+		isSSH := !strings.HasPrefix(currentRemoteURL, "https://") && !strings.HasPrefix(currentRemoteURL, "http://")
 		_, newCloneURL, parseErr := mission.ParseRepoReference(newName, isSSH, "")
 		if parseErr != nil {
 			s.logger.Printf("Warning: failed to derive new clone URL for '%s': %v", newName, parseErr)
@@ -2,10 +2,19 @@ package server
 
 import (
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/session"
 )
 
+// defaultTranscriptSearchLimit caps GET /missions/search/transcripts results
+// when no limit is given.
+const defaultTranscriptSearchLimit = 50
+
 // SearchMissionsResponse is a single result from mission search.
 type SearchMissionsResponse struct {
 	MissionID            string  `json:"mission_id"`
@@ -62,7 +71,7 @@ func (s *Server) handleSearchMissions(w http.ResponseWriter, r *http.Request) er
 			resp.Prompt = mission.Prompt
 			resp.CreatedAt = mission.CreatedAt.Format(time.RFC3339)
 			if activeSession, sessionErr := s.db.GetActiveSession(sr.MissionID); sessionErr == nil {
-				resp.ResolvedSessionTitle = resolveSessionTitle(activeSession)
+				resp.ResolvedSessionTitle = resolveMissionTitle(mission, activeSession)
 			}
 			if mission.LastHeartbeat != nil {
 				ts := mission.LastHeartbeat.Format(time.RFC3339)
@@ -81,3 +90,87 @@ func (s *Server) handleSearchMissions(w http.ResponseWriter, r *http.Request) er
 	writeJSON(w, http.StatusOK, responses)
 	return nil
 }
+
+// TranscriptMatchResponse is a single message matched by transcript search.
+type TranscriptMatchResponse struct {
+	MissionID string `json:"mission_id"`
+	ShortID   string `json:"short_id"`
+	SessionID string `json:"session_id"`
+	Role      string `json:"role"`
+	// Timestamp is RFC3339, or nil if the transcript entry had none.
+	Timestamp *string `json:"timestamp"`
+	Snippet   string  `json:"snippet"`
+	GitRepo   string  `json:"git_repo"`
+	Status    string  `json:"status"`
+}
+
+// handleSearchTranscripts handles GET /missions/search/transcripts?q=<query>&limit=<n>.
+// Unlike /missions/search it reads the session JSONL files directly instead of
+// the full-text index: matching is a literal case-insensitive substring,
+// every matching message is returned (not just the best per mission), newly
+// written content is visible immediately, and archived missions are included.
+// In multi-user mode, missions the requesting user may not access are
+// skipped. Results are sorted newest first.
+func (s *Server) handleSearchTranscripts(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query().Get("q")
+	limit := defaultTranscriptSearchLimit
+	if parsed, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && parsed > 0 {
+		limit = parsed
+	}
+
+	responses := []TranscriptMatchResponse{}
+	if query == "" {
+		writeJSON(w, http.StatusOK, responses)
+		return nil
+	}
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: true})
+	if err != nil {
+		return newHTTPError(http.StatusInternalServerError, "failed to list missions: "+err.Error())
+	}
+
+	type timedMatch struct {
+		resp      TranscriptMatchResponse
+		timestamp time.Time
+	}
+	var found []timedMatch
+	for _, m := range missions {
+		if s.checkMissionAccess(r, m) != nil {
+			continue
+		}
+		claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(s.agencDirpath, m.ID)
+		// Each mission contributes at most limit matches; the newest limit
+		// across all missions are kept below.
+		matches, err := session.GrepTranscripts(claudeConfigDirpath, m.ID, query, limit)
+		if err != nil {
+			s.logger.Printf("Transcript search: skipping mission %s: %v", m.ShortID, err)
+			continue
+		}
+		for _, match := range matches {
+			resp := TranscriptMatchResponse{
+				MissionID: m.ID,
+				ShortID:   m.ShortID,
+				SessionID: match.SessionID,
+				Role:      match.Role,
+				Snippet:   match.Snippet,
+				GitRepo:   m.GitRepo,
+				Status:    m.Status,
+			}
+			if !match.Timestamp.IsZero() {
+				ts := match.Timestamp.Format(time.RFC3339)
+				resp.Timestamp = &ts
+			}
+			found = append(found, timedMatch{resp: resp, timestamp: match.Timestamp})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].timestamp.After(found[j].timestamp)
+	})
+	for _, f := range found[:min(limit, len(found))] {
+		responses = append(responses, f.resp)
+	}
+
+	writeJSON(w, http.StatusOK, responses)
+	return nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+)
+
+func TestHandleSearchTranscripts(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+
+	writeTranscript := func(missionID string, content string) {
+		t.Helper()
+		projectDirpath := filepath.Join(claudeconfig.GetMissionClaudeConfigDirpath(srv.agencDirpath, missionID), "projects", "-agent-"+missionID)
+		if err := os.MkdirAll(projectDirpath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(projectDirpath, "sess-1.jsonl"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	older, err := srv.db.CreateMission("github.com/owner/repo", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	writeTranscript(older.ID, `{"type":"user","timestamp":"2026-03-01T10:00:00Z","message":{"role":"user","content":"Sketch the retry design"}}`+"\n")
+	if err := srv.db.ArchiveMission(older.ID); err != nil {
+		t.Fatalf("ArchiveMission failed: %v", err)
+	}
+
+	newer, err := srv.db.CreateMission("", nil)
+	if err != nil {
+		t.Fatalf("CreateMission failed: %v", err)
+	}
+	writeTranscript(newer.ID, `{"type":"assistant","timestamp":"2026-03-05T10:00:00Z","message":{"role":"assistant","content":[{"type":"text","text":"The Retry Design uses backoff."}]}}`+"\n")
+
+	search := func(query string) []TranscriptMatchResponse {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/missions/search/transcripts?q="+query, nil)
+		rec := httptest.NewRecorder()
+		if err := srv.handleSearchTranscripts(rec, req); err != nil {
+			t.Fatalf("handleSearchTranscripts failed: %v", err)
+		}
+		var results []TranscriptMatchResponse
+		if err := json.NewDecoder(rec.Body).Decode(&results); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return results
+	}
+
+	results := search("retry+design")
+	if len(results) != 2 {
+		t.Fatalf("expected 2 matches including the archived mission, got %+v", results)
+	}
+	if results[0].MissionID != newer.ID || results[0].Role != "assistant" {
+		t.Errorf("expected newest match first, got %+v", results[0])
+	}
+	if results[1].MissionID != older.ID || results[1].Timestamp == nil || *results[1].Timestamp != "2026-03-01T10:00:00Z" {
+		t.Errorf("unexpected archived mission match %+v", results[1])
+	}
+
+	if results := search("nothing+like+this"); len(results) != 0 {
+		t.Errorf("expected no matches, got %+v", results)
+	}
+}
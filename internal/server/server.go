@@ -9,12 +9,14 @@ import (
 	"os"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/mieubrisse/stacktrace"
 
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/launchd"
+	"github.com/odyssey/agenc/internal/tmux"
 	"github.com/odyssey/agenc/internal/version"
 )
 
@@ -28,6 +30,13 @@ type Server struct {
 	listener      net.Listener
 	db            *database.DB
 
+	// listenOverride is a TCP listen spec from `server run --listen` that
+	// takes precedence over the serverListen config key.
+	listenOverride string
+	// tcpHTTPServer serves the API on the optional loopback TCP listener,
+	// behind bearer-token auth. Nil when no TCP address is configured.
+	tcpHTTPServer *http.Server
+
 	// Background loop state
 	repoUpdateCycleCount int
 	cronSyncer           *CronSyncer
@@ -54,6 +63,9 @@ type Server struct {
 	// Mutating mission endpoints return 503 while this is true.
 	stashInProgress atomic.Bool
 
+	// startedAt is when Run began, for the uptime in GET /server/status.
+	startedAt time.Time
+
 	// loopHealth tracks the status of each background loop goroutine.
 	// Values are "running", "stopped", or "crashed".
 	loopHealth sync.Map
@@ -71,6 +83,46 @@ type Server struct {
 	// immediately at queue time if claude is already idle). Latest-wins:
 	// a second async reload for the same mission overwrites the first prompt.
 	pendingReloads sync.Map
+
+	// cronFiringLocks holds a *sync.Mutex per cron ID, held by a cron-sourced
+	// create from its overlap check until its run is recorded. See
+	// lockCronFiring.
+	cronFiringLocks sync.Map
+
+	// missionSummariesInFlight holds the IDs of missions whose AI summary is
+	// being generated, so triggers don't start a second summarization.
+	missionSummariesInFlight sync.Map
+
+	// missionQueue holds new missions waiting for a slot under
+	// missionsMaxConcurrent; missionQueueWakeCh nudges the queue loop when a
+	// slot may have freed up.
+	missionQueue       missionQueue
+	missionQueueWakeCh chan struct{}
+
+	// crashRestarts throttles autoRestartCrashed respawns per mission.
+	crashRestarts crashRestarts
+
+	// wrapperHealth holds the result of pinging each running wrapper.
+	wrapperHealth wrapperHealth
+
+	// connectivity tracks whether git remotes are reachable and the repo
+	// syncs deferred until they are.
+	connectivity connectivity
+
+	// rateLimiter enforces the rateLimit config on every API request.
+	rateLimiter rateLimiter
+
+	// events is the in-process event bus behind GET /events. The server
+	// publishes to it directly; wrappers publish through POST /events.
+	events *eventBus
+
+	// shadowMu serializes git work in the Claude config shadow repo between
+	// the config watcher's ingests and remote syncs.
+	shadowMu sync.Mutex
+
+	// shadowHead is the shadow repo HEAD last seen by checkShadowHeadChanged.
+	// Guarded by shadowMu.
+	shadowHead string
 }
 
 // NewServer creates a new Server instance.
@@ -82,6 +134,8 @@ func NewServer(agencDirpath string, socketPath string, logger *log.Logger) *Serv
 		cronSyncer:               NewCronSyncer(agencDirpath),
 		repoUpdateCh:             make(chan repoUpdateRequest, repoUpdateChannelSize),
 		writeableCopyReconcileCh: make(chan writeableCopyReconcileRequest, writeableCopyReconcileChannelSize),
+		missionQueueWakeCh:       make(chan struct{}, 1),
+		events:                   newEventBus(),
 	}
 }
 
@@ -126,6 +180,7 @@ func (s *Server) Run(ctx context.Context) error {
 		return stacktrace.Propagate(err, "failed to acquire server lock")
 	}
 	defer lockFile.Close()
+	s.startedAt = time.Now()
 
 	// Open the database
 	dbFilepath := config.GetDatabaseFilepath(s.agencDirpath)
@@ -164,13 +219,20 @@ func (s *Server) Run(ctx context.Context) error {
 
 	mux := http.NewServeMux()
 	s.registerRoutes(mux)
+	handler := s.apiVersionMiddleware(s.auditMiddleware(mux, s.rateLimitMiddleware(mux)))
 
 	s.httpServer = &http.Server{
-		Handler: mux,
+		Handler:     handler,
+		ConnContext: peerUserConnContext,
 	}
 
 	s.logger.Printf("Server listening on %s", s.socketPath)
 
+	// Load config and perform initial cron sync on startup. Runs before any
+	// tmux command so multi-user mode can point them at the shared socket.
+	s.loadConfigOnStartup()
+	s.applyMultiUserSocketPermissions()
+
 	// Ensure the tmux pool session exists for mission windows
 	if err := s.ensurePoolSession(); err != nil {
 		s.logger.Printf("Warning: failed to create tmux pool session: %v", err)
@@ -184,9 +246,6 @@ func (s *Server) Run(ctx context.Context) error {
 		s.logger.Printf("Warning: %v - cron scheduling will not work", err)
 	}
 
-	// Load config and perform initial cron sync on startup
-	s.loadConfigOnStartup()
-
 	var wg sync.WaitGroup
 
 	// Start HTTP server in a goroutine
@@ -198,19 +257,49 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}()
 
+	// Optionally expose the API on a loopback TCP address for local GUI
+	// tools. A bad address is logged rather than fatal: the CLI only needs
+	// the unix socket.
+	tcpHTTPServer, tcpListener, err := s.startTCPListener(handler)
+	if err != nil {
+		s.logger.Printf("Warning: TCP listener disabled: %v", err)
+	} else if tcpHTTPServer != nil {
+		s.tcpHTTPServer = tcpHTTPServer
+		s.logger.Printf("Server also listening on tcp %s (bearer token required)", tcpListener.Addr())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := tcpHTTPServer.Serve(tcpListener); err != http.ErrServerClosed {
+				s.logger.Printf("TCP HTTP server error: %v", err)
+			}
+		}()
+	}
+
 	// Start background loops with panic recovery
 	go s.runLoop("repo-update-worker", &wg, ctx, s.runRepoUpdateWorker)
 	go s.runLoop("repo-update-loop", &wg, ctx, s.runRepoUpdateLoop)
 	go s.runLoop("config-auto-commit", &wg, ctx, s.runConfigAutoCommitLoop)
 	go s.runLoop("config-watcher", &wg, ctx, s.runConfigWatcherLoop)
+	go s.runLoop("shadow-remote-sync", &wg, ctx, s.runShadowRemoteSyncLoop)
 	go s.runLoop("keybindings-writer", &wg, ctx, s.runKeybindingsWriterLoop)
 	go s.runLoop("idle-timeout", &wg, ctx, s.runIdleTimeoutLoop)
+	go s.runLoop("mission-gc", &wg, ctx, s.runMissionGCLoop)
+	go s.runLoop("mission-size", &wg, ctx, s.runMissionSizeLoop)
+	go s.runLoop("mission-queue", &wg, ctx, s.runMissionQueueLoop)
+	go s.runLoop("quiet-hours", &wg, ctx, s.runQuietHoursLoop)
+	go s.runLoop("daily-digest", &wg, ctx, s.runDailyDigestLoop)
+	go s.runLoop("crash-detection", &wg, ctx, s.runCrashDetectionLoop)
+	go s.runLoop("wrapper-health", &wg, ctx, s.runWrapperHealthLoop)
 	go s.runLoop("file-watcher", &wg, ctx, s.runFileWatcherLoop)
 	go s.runLoop("custom-title", &wg, ctx, s.runCustomTitleLoop)
 	go s.runLoop("auto-summary", &wg, ctx, s.runAutoSummaryLoop)
 	go s.runLoop("search-indexer", &wg, ctx, s.runSearchIndexerLoop)
 	go s.runLoop("writeable-copy-reconcile", &wg, ctx, s.runWriteableCopyReconcileWorker)
 
+	// Start one-shot crons that came due while the server was down. Runs
+	// after the listeners are up since each run calls back into the API.
+	go s.fireMissedOneShotCrons()
+
 	// Bootstrap writeable copies: clone if missing, install watchers, and
 	// enqueue an initial reconcile per copy. Subsequent config changes are
 	// handled by the config watcher (config_watcher.go).
@@ -223,6 +312,11 @@ func (s *Server) Run(ctx context.Context) error {
 	if err := s.httpServer.Shutdown(context.Background()); err != nil {
 		s.logger.Printf("HTTP server shutdown error: %v", err)
 	}
+	if s.tcpHTTPServer != nil {
+		if err := s.tcpHTTPServer.Shutdown(context.Background()); err != nil {
+			s.logger.Printf("TCP HTTP server shutdown error: %v", err)
+		}
+	}
 
 	wg.Wait()
 	if err := os.Remove(s.socketPath); err != nil && !os.IsNotExist(err) {
@@ -242,45 +336,87 @@ func (s *Server) loadConfigOnStartup() {
 	}
 
 	s.cachedConfig.Store(cfg)
+	tmux.SetSocketPath(cfg.GetTmuxSocket())
 
-	if len(cfg.Crons) == 0 {
+	crons := s.scheduledCrons(cfg)
+	if len(crons) == 0 && len(cfg.Crons) == 0 {
 		s.logger.Println("Cron syncer: no cron jobs configured")
 		return
 	}
 
-	if err := s.cronSyncer.SyncCronsToLaunchd(cfg.Crons, s.logger); err != nil {
+	if err := s.cronSyncer.SyncCronsToLaunchd(crons, s.logger); err != nil {
 		s.logger.Printf("Failed to sync crons on startup: %v", err)
 	}
 }
 
 func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.Handle("GET /health", appHandler(s.requestLogger, s.handleHealth))
+	mux.Handle("GET /version", appHandler(s.requestLogger, s.handleVersion))
+	mux.Handle("GET /metrics", appHandler(s.requestLogger, s.handleMetrics))
 	mux.Handle("GET /server/logs", appHandler(s.requestLogger, s.handleServerLogs))
+	mux.Handle("GET /server/status", appHandler(s.requestLogger, s.handleServerStatus))
+	mux.Handle("GET /server/connectivity", appHandler(s.requestLogger, s.handleGetConnectivity))
 	mux.Handle("GET /missions", appHandler(s.requestLogger, s.handleListMissions))
 	mux.Handle("GET /missions/search", appHandler(s.requestLogger, s.handleSearchMissions))
+	mux.Handle("GET /missions/queue", appHandler(s.requestLogger, s.handleListMissionQueue))
+	mux.Handle("GET /missions/search/transcripts", appHandler(s.requestLogger, s.handleSearchTranscripts))
+	mux.Handle("GET /missions/stats", appHandler(s.requestLogger, s.handleListMissionStats))
+	mux.Handle("GET /missions/stale-config", appHandler(s.requestLogger, s.handleListStaleConfigMissions))
+	mux.Handle("GET /missions/conflicts", appHandler(s.requestLogger, s.handleListMissionConflicts))
+	mux.Handle("GET /reports/agent-time", appHandler(s.requestLogger, s.handleGetAgentTimeReport))
+	mux.Handle("GET /reports/digest", appHandler(s.requestLogger, s.handleGetDailyDigest))
 	mux.Handle("POST /missions", appHandler(s.requestLogger, s.sleepGuard(s.stashGuard(s.handleCreateMission))))
-	mux.Handle("GET /missions/{id}", appHandler(s.requestLogger, s.handleGetMission))
-	mux.Handle("POST /missions/{id}/attach", appHandler(s.requestLogger, s.stashGuard(s.handleAttachMission)))
-	mux.Handle("POST /missions/{id}/detach", appHandler(s.requestLogger, s.stashGuard(s.handleDetachMission)))
-	mux.Handle("POST /missions/{id}/send-keys", appHandler(s.requestLogger, s.handleSendKeys))
-	mux.Handle("POST /missions/{id}/stop", appHandler(s.requestLogger, s.stashGuard(s.handleStopMission)))
-	mux.Handle("DELETE /missions/{id}", appHandler(s.requestLogger, s.stashGuard(s.handleDeleteMission)))
-	mux.Handle("POST /missions/{id}/reload", appHandler(s.requestLogger, s.stashGuard(s.handleReloadMission)))
+	mux.Handle("POST /missions/import", appHandler(s.requestLogger, s.stashGuard(s.handleImportMission)))
+	mux.Handle("POST /missions/gc", appHandler(s.requestLogger, s.stashGuard(s.handleMissionGC)))
+	mux.Handle("POST /missions/batch", appHandler(s.requestLogger, s.stashGuard(s.handleMissionBatch)))
+	mux.Handle("GET /missions/{id}", appHandler(s.requestLogger, s.missionAccessGuard(s.handleGetMission)))
+	mux.Handle("POST /missions/{id}/attach", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleAttachMission))))
+	mux.Handle("POST /missions/{id}/detach", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleDetachMission))))
+	mux.Handle("POST /missions/{id}/send-keys", appHandler(s.requestLogger, s.missionAccessGuard(s.handleSendKeys)))
+	mux.Handle("POST /missions/{id}/send", appHandler(s.requestLogger, s.missionAccessGuard(s.handleSendMissionMessage)))
+	mux.Handle("POST /missions/{id}/stop", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleStopMission))))
+	mux.Handle("POST /missions/{id}/export", appHandler(s.requestLogger, s.missionAccessGuard(s.handleExportMission)))
+	mux.Handle("GET /missions/{id}/branch", appHandler(s.requestLogger, s.missionAccessGuard(s.handleGetMissionBranch)))
+	mux.Handle("GET /missions/{id}/diff", appHandler(s.requestLogger, s.missionAccessGuard(s.handleGetMissionDiff)))
+	mux.Handle("GET /missions/{id}/files", appHandler(s.requestLogger, s.missionAccessGuard(s.handleListMissionFiles)))
+	mux.Handle("GET /missions/{id}/files/content", appHandler(s.requestLogger, s.missionAccessGuard(s.handleGetMissionFileContent)))
+	mux.Handle("POST /missions/{id}/branch", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleSetMissionBranch))))
+	mux.Handle("POST /missions/{id}/repoint", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleRepointMission))))
+	mux.Handle("POST /missions/{id}/share", appHandler(s.requestLogger, s.handleShareMission))
+	mux.Handle("GET /missions/{id}/remote-cleanup", appHandler(s.requestLogger, s.missionAccessGuard(s.handleGetMissionRemoteCleanup)))
+	mux.Handle("POST /missions/{id}/pr", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleMissionPR))))
+	mux.Handle("POST /missions/{id}/approve", appHandler(s.requestLogger, s.missionAccessGuard(s.handleApproveMission)))
+	mux.Handle("DELETE /missions/{id}", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleDeleteMission))))
+	mux.Handle("POST /missions/{id}/reload", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleReloadMission))))
 	mux.Handle("POST /missions/{id}/claude-idle", appHandler(s.requestLogger, s.handleClaudeIdle))
-	mux.Handle("POST /missions/{id}/archive", appHandler(s.requestLogger, s.stashGuard(s.handleArchiveMission)))
-	mux.Handle("POST /missions/{id}/unarchive", appHandler(s.requestLogger, s.stashGuard(s.handleUnarchiveMission)))
+	mux.Handle("POST /missions/{id}/exit", appHandler(s.requestLogger, s.handleMissionExit))
+	mux.Handle("POST /missions/{id}/archive", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleArchiveMission))))
+	mux.Handle("POST /missions/{id}/unarchive", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleUnarchiveMission))))
 	mux.Handle("POST /missions/{id}/heartbeat", appHandler(s.requestLogger, s.handleHeartbeat))
 	mux.Handle("POST /missions/{id}/prompt", appHandler(s.requestLogger, s.handleRecordPrompt))
-	mux.Handle("PATCH /missions/{id}", appHandler(s.requestLogger, s.stashGuard(s.handleUpdateMission)))
+	mux.Handle("GET /missions/{id}/prompts", appHandler(s.requestLogger, s.missionAccessGuard(s.handleListMissionPrompts)))
+	mux.Handle("GET /missions/{id}/handoff", appHandler(s.requestLogger, s.missionAccessGuard(s.handleGetMissionHandoff)))
+	mux.Handle("POST /missions/{id}/summarize", appHandler(s.requestLogger, s.missionAccessGuard(s.handleSummarizeMission)))
+	mux.Handle("GET /missions/{id}/output", appHandler(s.requestLogger, s.missionAccessGuard(s.handleMissionOutput)))
+	mux.Handle("GET /missions/{id}/stats", appHandler(s.requestLogger, s.missionAccessGuard(s.handleGetMissionStats)))
+	mux.Handle("GET /missions/{id}/events", appHandler(s.requestLogger, s.missionAccessGuard(s.handleListMissionEvents)))
+	mux.Handle("POST /missions/{id}/stats", appHandler(s.requestLogger, s.handleRecordMissionStats))
+	mux.Handle("POST /missions/{id}/busy-periods", appHandler(s.requestLogger, s.handleRecordBusyPeriod))
+	mux.Handle("PATCH /missions/{id}", appHandler(s.requestLogger, s.stashGuard(s.missionAccessGuard(s.handleUpdateMission))))
 	mux.Handle("GET /sessions", appHandler(s.requestLogger, s.handleListSessions))
 	mux.Handle("GET /sessions/{id}", appHandler(s.requestLogger, s.handleGetSession))
 	mux.Handle("PATCH /sessions/{id}", appHandler(s.requestLogger, s.handleUpdateSession))
 	mux.Handle("GET /repos", appHandler(s.requestLogger, s.handleListRepos))
 	mux.Handle("POST /repos", appHandler(s.requestLogger, s.handleAddRepo))
+	mux.Handle("POST /repos/create", appHandler(s.requestLogger, s.handleCreateRepo))
+	mux.Handle("POST /repos/fork", appHandler(s.requestLogger, s.handleForkRepo))
 	mux.Handle("DELETE /repos/", appHandler(s.requestLogger, s.handleRemoveRepo))
 	// Repo actions (push-event, mv) use a catch-all prefix since repo names
 	// contain slashes; handleRepoAction dispatches by URL suffix.
 	mux.Handle("POST /repos/", appHandler(s.requestLogger, s.handleRepoAction))
+	// GitHub webhook deliveries authenticate with their HMAC signature, so
+	// requireAPIToken lets this path through on the TCP listener.
+	mux.Handle("POST "+githubWebhookPath, appHandler(s.requestLogger, s.handleGitHubWebhook))
 
 	// Stash endpoints — push and pop are wrapped in stashGuard so they cannot
 	// race with each other (e.g., pop arriving while push's background goroutine
@@ -291,10 +427,14 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 
 	// Cron endpoints
 	mux.Handle("GET /crons", appHandler(s.requestLogger, s.handleListCrons))
+	mux.Handle("GET /crons/stats", appHandler(s.requestLogger, s.handleListCronStats))
 	mux.Handle("POST /crons", appHandler(s.requestLogger, s.sleepGuard(s.handleCreateCron)))
+	mux.Handle("POST /crons/at", appHandler(s.requestLogger, s.sleepGuard(s.handleCreateCronAt)))
 	mux.Handle("PATCH /crons/{name}", appHandler(s.requestLogger, s.handleUpdateCron))
 	mux.Handle("DELETE /crons/{name}", appHandler(s.requestLogger, s.handleDeleteCron))
 	mux.Handle("GET /crons/{id}/logs", appHandler(s.requestLogger, s.handleCronLogs))
+	mux.Handle("GET /crons/{name}/runs", appHandler(s.requestLogger, s.handleListCronRuns))
+	mux.Handle("GET /crons/{name}/stats", appHandler(s.requestLogger, s.handleGetCronStats))
 
 	// Sleep mode config endpoints
 	mux.Handle("GET /config/sleep/windows", appHandler(s.requestLogger, s.handleListSleepWindows))
@@ -306,6 +446,19 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	mux.Handle("PUT /config/claude-md", appHandler(s.requestLogger, s.handleUpdateClaudeMd))
 	mux.Handle("GET /config/settings-json", appHandler(s.requestLogger, s.handleGetSettingsJson))
 	mux.Handle("PUT /config/settings-json", appHandler(s.requestLogger, s.handleUpdateSettingsJson))
+	mux.Handle("POST /config/shadow/sync", appHandler(s.requestLogger, s.handleSyncShadowRemote))
+
+	// Skills installed from repos into ~/.claude/skills
+	mux.Handle("GET /skills", appHandler(s.requestLogger, s.handleListSkills))
+	mux.Handle("POST /skills", appHandler(s.requestLogger, s.handleAddSkill))
+	mux.Handle("POST /skills/{name}/update", appHandler(s.requestLogger, s.handleUpdateSkill))
+	mux.Handle("DELETE /skills/{name}", appHandler(s.requestLogger, s.handleRemoveSkill))
+
+	// Projects
+	mux.Handle("GET /projects", appHandler(s.requestLogger, s.handleListProjects))
+	mux.Handle("POST /projects", appHandler(s.requestLogger, s.handleCreateProject))
+	mux.Handle("GET /projects/{name}", appHandler(s.requestLogger, s.handleGetProject))
+	mux.Handle("PATCH /projects/{name}", appHandler(s.requestLogger, s.handleUpdateProject))
 
 	// Notifications
 	mux.Handle("GET /notifications", appHandler(s.requestLogger, s.handleListNotifications))
@@ -317,6 +470,10 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 
 	// Writeable copies
 	mux.Handle("GET /writeable-copies", appHandler(s.requestLogger, s.handleListWriteableCopies))
+
+	// Event bus
+	mux.Handle("GET /events", appHandler(s.requestLogger, s.handleListEvents))
+	mux.Handle("POST /events", appHandler(s.requestLogger, s.handlePublishEvent))
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) error {
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/version"
+)
+
+// serverStatusNextFires is how many upcoming fire times GET /server/status
+// lists per recurring cron.
+const serverStatusNextFires = 3
+
+// ServerStatusResponse is the JSON response for GET /server/status: what the
+// server and its cron scheduling are doing right now, for debugging why a
+// cron did or didn't run without reading the logs.
+type ServerStatusResponse struct {
+	Version       string            `json:"version"`
+	StartedAt     time.Time         `json:"started_at"`
+	UptimeSeconds int64             `json:"uptime_seconds"`
+	Loops         map[string]string `json:"loops"`
+	// QueueDepth is how many missions are waiting for a slot under
+	// missionsMaxConcurrent (0 = no cap). Cron missions never queue.
+	QueueDepth            int                  `json:"queue_depth"`
+	MissionsMaxConcurrent int                  `json:"missions_max_concurrent,omitempty"`
+	QuietHoursActive      bool                 `json:"quiet_hours_active"`
+	CronSync              CronSyncStats        `json:"cron_sync"`
+	Crons                 []CronScheduleStatus `json:"crons"`
+}
+
+// CronScheduleStatus is one cron's scheduling state in GET /server/status.
+type CronScheduleStatus struct {
+	Name     string `json:"name"`
+	ID       string `json:"id"`
+	Schedule string `json:"schedule,omitempty"`
+	RunAt    string `json:"run_at,omitempty"`
+	Enabled  bool   `json:"enabled"`
+	After    string `json:"after,omitempty"`
+	// Scheduled is whether the cron is among those loaded into launchd; a
+	// one-shot cron past its time is not
+	Scheduled bool `json:"scheduled"`
+	// NextFires are the cron's upcoming fire times; empty when it is
+	// disabled or not scheduled
+	NextFires []time.Time `json:"next_fires,omitempty"`
+	// SyncError is why the last launchd sync could not load the cron
+	SyncError string           `json:"sync_error,omitempty"`
+	LastRun   *CronRunResponse `json:"last_run,omitempty"`
+}
+
+// handleServerStatus handles GET /server/status.
+func (s *Server) handleServerStatus(w http.ResponseWriter, r *http.Request) error {
+	writeJSON(w, http.StatusOK, s.buildServerStatus(time.Now()))
+	return nil
+}
+
+// buildServerStatus assembles the GET /server/status response as of now.
+func (s *Server) buildServerStatus(now time.Time) ServerStatusResponse {
+	cfg := s.getConfig()
+
+	loops := make(map[string]string)
+	s.loopHealth.Range(func(key, value any) bool {
+		loops[key.(string)] = value.(string)
+		return true
+	})
+
+	status := ServerStatusResponse{
+		Version:               version.Version,
+		StartedAt:             s.startedAt,
+		Loops:                 loops,
+		QueueDepth:            len(s.missionQueue.snapshot()),
+		MissionsMaxConcurrent: cfg.MissionsMaxConcurrent,
+		QuietHoursActive:      cfg.QuietHours.IsActive(now),
+		Crons:                 []CronScheduleStatus{},
+	}
+	if !s.startedAt.IsZero() {
+		status.UptimeSeconds = int64(now.Sub(s.startedAt).Seconds())
+	}
+	if s.cronSyncer != nil {
+		status.CronSync = s.cronSyncer.Stats()
+	}
+
+	crons := make(map[string]config.CronConfig, len(cfg.Crons))
+	for name, cronCfg := range cfg.Crons {
+		crons[name] = cronCfg
+	}
+	for _, job := range s.listCronAtJobs() {
+		if _, exists := crons[job.Name]; !exists {
+			crons[job.Name] = cronConfigFromAtJob(job)
+		}
+	}
+	scheduled := s.scheduledCrons(cfg)
+
+	for name, cronCfg := range crons {
+		_, isScheduled := scheduled[name]
+		cronStatus := CronScheduleStatus{
+			Name:      name,
+			ID:        cronCfg.ID,
+			Schedule:  cronCfg.Schedule,
+			RunAt:     cronCfg.RunAt,
+			Enabled:   cronCfg.IsEnabled(),
+			After:     cronCfg.After,
+			Scheduled: isScheduled,
+			SyncError: status.CronSync.CronErrors[name],
+			NextFires: cronNextFires(cronCfg, isScheduled, now),
+		}
+		if s.db != nil && cronCfg.ID != "" {
+			if runs, err := s.db.ListCronRuns(database.ListCronRunsParams{CronID: cronCfg.ID, Limit: 1}); err == nil && len(runs) > 0 {
+				lastRun := toCronRunResponse(runs[0])
+				cronStatus.LastRun = &lastRun
+			}
+		}
+		status.Crons = append(status.Crons, cronStatus)
+	}
+	sort.Slice(status.Crons, func(i, j int) bool {
+		return status.Crons[i].Name < status.Crons[j].Name
+	})
+	return status
+}
+
+// cronNextFires returns the upcoming fire times of a cron, or none when it is
+// disabled or not loaded into launchd.
+func cronNextFires(cronCfg config.CronConfig, isScheduled bool, now time.Time) []time.Time {
+	if !isScheduled || !cronCfg.IsEnabled() {
+		return nil
+	}
+	if cronCfg.IsOneShot() {
+		runAt, err := config.ParseCronRunAt(cronCfg.RunAt)
+		if err != nil {
+			return nil
+		}
+		return []time.Time{runAt}
+	}
+	fires, err := config.NextCronRuns(cronCfg.Schedule, now, serverStatusNextFires)
+	if err != nil {
+		return nil
+	}
+	return fires
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestBuildServerStatus(t *testing.T) {
+	srv := newAutoSummaryTestServer(t)
+	disabled := false
+	srv.cachedConfig.Store(&config.AgencConfig{
+		Crons: map[string]config.CronConfig{
+			"nightly": {ID: "cron-nightly", Schedule: "0 6 * * *", Prompt: "Summarize"},
+			"paused":  {ID: "cron-paused", Schedule: "0 7 * * *", Prompt: "Summarize", Enabled: &disabled},
+			"past":    {ID: "cron-past", RunAt: "2020-01-01T09:00:00Z", Prompt: "Summarize"},
+		},
+	})
+	if err := srv.db.CreateCronRun(&database.CronRun{
+		ID:       "run-1",
+		CronID:   "cron-nightly",
+		CronName: "nightly",
+		Trigger:  database.CronRunTriggerSchedule,
+		Status:   database.CronRunStatusSkipped,
+		Detail:   "deferred until quiet hours end",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 6, 1, 5, 30, 0, 0, time.Local)
+	srv.startedAt = now.Add(-time.Hour)
+	status := srv.buildServerStatus(now)
+
+	if status.UptimeSeconds != 3600 {
+		t.Errorf("expected an hour of uptime, got %ds", status.UptimeSeconds)
+	}
+	if len(status.Crons) != 3 || status.Crons[0].Name != "nightly" {
+		t.Fatalf("expected three crons sorted by name, got %+v", status.Crons)
+	}
+
+	nightly := status.Crons[0]
+	if !nightly.Scheduled || len(nightly.NextFires) != serverStatusNextFires {
+		t.Errorf("expected nightly scheduled with %d next fires, got %+v", serverStatusNextFires, nightly)
+	} else if want := time.Date(2026, 6, 1, 6, 0, 0, 0, time.Local); !nightly.NextFires[0].Equal(want) {
+		t.Errorf("expected next fire %v, got %v", want, nightly.NextFires[0])
+	}
+	if nightly.LastRun == nil || nightly.LastRun.Detail != "deferred until quiet hours end" {
+		t.Errorf("expected the skipped last run, got %+v", nightly.LastRun)
+	}
+
+	past := status.Crons[1]
+	if past.Scheduled || len(past.NextFires) != 0 {
+		t.Errorf("expected a one-shot cron past its time unscheduled, got %+v", past)
+	}
+	paused := status.Crons[2]
+	if paused.Enabled || len(paused.NextFires) != 0 {
+		t.Errorf("expected a disabled cron to have no next fires, got %+v", paused)
+	}
+}
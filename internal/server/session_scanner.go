@@ -59,9 +59,10 @@ func (s *Server) runFileWatcherCycle() {
 }
 
 // watchRunningMissionFiles stats JSONL files for missions currently running
-// in the tmux pool and updates known_file_size.
+// in tmux (in the pool or split into a user's window) and updates
+// known_file_size.
 func (s *Server) watchRunningMissionFiles() {
-	paneIDs := listPoolPaneIDs(s.getPoolSessionName())
+	paneIDs := listAllPaneIDs()
 
 	for _, paneID := range paneIDs {
 		mission, err := s.db.GetMissionByTmuxPane(paneID)
@@ -73,8 +74,7 @@ func (s *Server) watchRunningMissionFiles() {
 			continue
 		}
 
-		agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, mission.ID)
-		projectDirpath, err := claudeconfig.ComputeProjectDirpath(agentDirpath)
+		projectDirpath, err := claudeconfig.ComputeProjectDirpath(config.GetMissionWorkDirpath(s.agencDirpath, mission.ID))
 		if err != nil {
 			s.logger.Printf("File watcher: failed to compute project dir for mission '%s': %v", database.ShortID(mission.ID), err)
 			continue
@@ -165,8 +165,7 @@ func (s *Server) watchNullFileSizeSessions() {
 // resolveSessionJSONLPath computes the JSONL file path for a session.
 // Returns empty string if the path cannot be determined or the file doesn't exist.
 func (s *Server) resolveSessionJSONLPath(sess *database.Session) string {
-	agentDirpath := config.GetMissionAgentDirpath(s.agencDirpath, sess.MissionID)
-	projectDirpath, err := claudeconfig.ComputeProjectDirpath(agentDirpath)
+	projectDirpath, err := claudeconfig.ComputeProjectDirpath(config.GetMissionWorkDirpath(s.agencDirpath, sess.MissionID))
 	if err != nil {
 		return ""
 	}
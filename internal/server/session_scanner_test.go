@@ -184,6 +184,16 @@ func TestDetermineBestTitle(t *testing.T) {
 		t.Errorf("no session: got %q, want %q", got, "my-project")
 	}
 
+	// Display name from mission rename outranks every session title
+	namedMission := &database.Mission{
+		ShortID:     "abc12345",
+		GitRepo:     "github.com/owner/my-project",
+		DisplayName: "Billing refactor",
+	}
+	if got := determineBestTitle(sessionWithCustom, namedMission, ""); got != "Billing refactor" {
+		t.Errorf("with display name: got %q, want %q", got, "Billing refactor")
+	}
+
 	// No session, no repo -- falls back to short ID
 	missionNoRepo := &database.Mission{ShortID: "abc12345"}
 	if got := determineBestTitle(nil, missionNoRepo, ""); got != "abc12345" {
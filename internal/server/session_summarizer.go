@@ -41,6 +41,17 @@ func generateSessionSummary(ctx context.Context, agencDirpath string, firstUserM
 
 	systemPrompt := buildSummarizerSystemPrompt(maxWords)
 
+	// Wrap the user message so the model sees it as input to summarize,
+	// not as a conversation to respond to.
+	wrappedPrompt := "Generate a window title for this user request:\n\n" + truncated
+
+	return runSummarizerCLI(ctx, agencDirpath, summarizerModel, systemPrompt, wrappedPrompt, summarizerMaxOutputLen)
+}
+
+// runSummarizerCLI runs a one-shot, tool-less `claude --print` with model and
+// systemPrompt and returns the trimmed response. Responses longer than
+// maxOutputLen bytes are rejected as the model ignoring its instructions.
+func runSummarizerCLI(ctx context.Context, agencDirpath string, model string, systemPrompt string, prompt string, maxOutputLen int) (string, error) {
 	claudeBinary, err := exec.LookPath("claude")
 	if err != nil {
 		return "", fmt.Errorf("'claude' binary not found in PATH: %w", err)
@@ -49,18 +60,14 @@ func generateSessionSummary(ctx context.Context, agencDirpath string, firstUserM
 	cmdCtx, cancel := context.WithTimeout(ctx, summarizerTimeout)
 	defer cancel()
 
-	// Wrap the user message so the model sees it as input to summarize,
-	// not as a conversation to respond to.
-	wrappedPrompt := "Generate a window title for this user request:\n\n" + truncated
-
 	cmd := exec.CommandContext(cmdCtx, claudeBinary,
 		"--print",
-		"--model", summarizerModel,
+		"--model", model,
 		"--system-prompt", systemPrompt,
 		"--no-session-persistence",
 		"--tools", "",
 		"--disable-slash-commands",
-		"-p", wrappedPrompt,
+		"-p", prompt,
 	)
 
 	// Run from a temp directory as defense-in-depth: if --no-session-persistence
@@ -89,8 +96,8 @@ func generateSessionSummary(ctx context.Context, agencDirpath string, firstUserM
 
 	// Reject responses that are too long — the model likely ignored the
 	// system prompt and produced a conversational response.
-	if len(summary) > summarizerMaxOutputLen {
-		return "", fmt.Errorf("claude returned response too long (%d bytes), likely not a title", len(summary))
+	if len(summary) > maxOutputLen {
+		return "", fmt.Errorf("claude returned response too long (%d bytes), likely ignored the system prompt", len(summary))
 	}
 
 	return summary, nil
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+)
+
+// shadowRemoteSyncInterval is how often the server pulls from and pushes to
+// the shadow repo's remote, when one is set.
+const shadowRemoteSyncInterval = 5 * time.Minute
+
+// ShadowSyncRequest is the optional JSON body for POST /config/shadow/sync.
+type ShadowSyncRequest struct {
+	// Prefer picks the winner of conflicting edits: "local" or "remote".
+	// Empty stops on conflicts.
+	Prefer string `json:"prefer,omitempty"`
+}
+
+// ShadowSyncResponse is the response for POST /config/shadow/sync.
+type ShadowSyncResponse struct {
+	RemoteURL string `json:"remote_url"`
+	Pulled    bool   `json:"pulled"`
+	Pushed    bool   `json:"pushed"`
+}
+
+// runShadowRemoteSyncLoop periodically syncs the Claude config shadow repo
+// with its remote ('agenc config shadow remote set'), backing up local edits
+// and applying edits pushed from other machines.
+func (s *Server) runShadowRemoteSyncLoop(ctx context.Context) {
+	ticker := time.NewTicker(shadowRemoteSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.syncShadowRemote(""); err != nil {
+				s.logger.Printf("Shadow remote sync: %v", err)
+			}
+		}
+	}
+}
+
+// syncShadowRemote ingests ~/.claude so local edits are committed, then syncs
+// the shadow repo with its remote, resolving conflicts per prefer (see
+// claudeconfig.SyncShadowRemote). Returns an empty RemoteURL when no remote
+// is set.
+func (s *Server) syncShadowRemote(prefer string) (ShadowSyncResponse, error) {
+	remoteURL, err := claudeconfig.GetShadowRemoteURL(s.agencDirpath)
+	if err != nil || remoteURL == "" {
+		return ShadowSyncResponse{}, err
+	}
+	userClaudeDirpath, err := s.getConfig().UserClaudeDirpath()
+	if err != nil {
+		return ShadowSyncResponse{}, stacktrace.Propagate(err, "failed to determine ~/.claude path")
+	}
+
+	s.shadowMu.Lock()
+	defer s.shadowMu.Unlock()
+
+	shadowDirpath := claudeconfig.GetShadowRepoDirpath(s.agencDirpath)
+	if err := claudeconfig.IngestFromClaudeDir(userClaudeDirpath, shadowDirpath); err != nil {
+		return ShadowSyncResponse{}, stacktrace.Propagate(err, "failed to ingest ~/.claude before syncing")
+	}
+	result, err := claudeconfig.SyncShadowRemote(s.agencDirpath, userClaudeDirpath, prefer)
+	if err != nil {
+		return ShadowSyncResponse{}, err
+	}
+	if result.Pulled {
+		s.logger.Printf("Shadow remote sync: applied Claude config changes from %s", remoteURL)
+		s.checkShadowHeadChanged()
+	}
+	if result.Pushed {
+		s.logger.Printf("Shadow remote sync: pushed Claude config changes to %s", remoteURL)
+	}
+	return ShadowSyncResponse{RemoteURL: remoteURL, Pulled: result.Pulled, Pushed: result.Pushed}, nil
+}
+
+// handleSyncShadowRemote handles POST /config/shadow/sync.
+// Syncs the shadow repo with its remote now instead of waiting for the loop.
+func (s *Server) handleSyncShadowRemote(w http.ResponseWriter, r *http.Request) error {
+	var req ShadowSyncRequest
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	if req.Prefer != "" && req.Prefer != claudeconfig.ShadowPreferLocal && req.Prefer != claudeconfig.ShadowPreferRemote {
+		return newHTTPErrorf(http.StatusBadRequest, "invalid prefer '%s'; must be '%s' or '%s'", req.Prefer, claudeconfig.ShadowPreferLocal, claudeconfig.ShadowPreferRemote)
+	}
+
+	result, err := s.syncShadowRemote(req.Prefer)
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadGateway, "%#s", err)
+	}
+	if result.RemoteURL == "" {
+		return newHTTPError(http.StatusBadRequest, "no shadow repo remote is set; set one with 'agenc config shadow remote set <url>'")
+	}
+	writeJSON(w, http.StatusOK, result)
+	return nil
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// SkillInfo describes one skill under ~/.claude/skills. Repo is empty for
+// skills written by hand rather than installed from a repo.
+type SkillInfo struct {
+	Name        string     `json:"name"`
+	Repo        string     `json:"repo,omitempty"`
+	Commit      string     `json:"commit,omitempty"`
+	InstalledAt *time.Time `json:"installed_at,omitempty"`
+}
+
+// AddSkillRequest is the JSON body for POST /skills.
+type AddSkillRequest struct {
+	// Repo is the skill repo: owner/repo, host/owner/repo, or a git URL.
+	Repo string `json:"repo"`
+	// Name is the skill's directory name; defaults to the repo's name.
+	Name string `json:"name,omitempty"`
+}
+
+// SkillChangeResponse is the response for POST /skills,
+// POST /skills/{name}/update, and DELETE /skills/{name}.
+type SkillChangeResponse struct {
+	Skill SkillInfo `json:"skill"`
+	// Changed is false when an update found the skill already current.
+	Changed bool `json:"changed"`
+	// RefreshedMissions is how many running missions had their skills
+	// rebuilt to include the change.
+	RefreshedMissions int `json:"refreshed_missions"`
+}
+
+// handleListSkills handles GET /skills.
+func (s *Server) handleListSkills(w http.ResponseWriter, r *http.Request) error {
+	userClaudeDirpath, err := s.getConfig().UserClaudeDirpath()
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "%#s", err)
+	}
+	skills, err := claudeconfig.ListSkills(userClaudeDirpath)
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "%#s", err)
+	}
+
+	result := make([]SkillInfo, 0, len(skills))
+	for _, skill := range skills {
+		result = append(result, toSkillInfo(skill.Name, skill.Source))
+	}
+	writeJSON(w, http.StatusOK, result)
+	return nil
+}
+
+// handleAddSkill handles POST /skills: clones a skill repo into
+// ~/.claude/skills and commits it to the shadow repo.
+func (s *Server) handleAddSkill(w http.ResponseWriter, r *http.Request) error {
+	var req AddSkillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return newHTTPError(http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+	if req.Repo == "" {
+		return newHTTPError(http.StatusBadRequest, "repo is required")
+	}
+	repoName, cloneURL, err := mission.ParseRepoReference(req.Repo, mission.DetectPreferredProtocol(s.agencDirpath), "")
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%#s", err)
+	}
+	name := req.Name
+	if name == "" {
+		name = claudeconfig.SkillNameFromRepo(repoName)
+	}
+	if err := claudeconfig.ValidateSkillName(name); err != nil {
+		return newHTTPErrorf(http.StatusBadRequest, "%#s", err)
+	}
+	if _, err := s.getInstalledSkill(name); err == nil {
+		return newHTTPErrorf(http.StatusConflict, "skill '%s' is already installed; use --name to install it under another name", name)
+	}
+
+	var source *claudeconfig.SkillSource
+	refreshed, err := s.applySkillChange("Add skill "+name+" from "+repoName, func(userClaudeDirpath string) error {
+		source, err = claudeconfig.InstallSkill(userClaudeDirpath, name, repoName, cloneURL)
+		return err
+	})
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "%#s", err)
+	}
+	s.logger.Printf("Skills: installed '%s' from %s at %s", name, repoName, shortCommit(source.Commit))
+
+	writeJSON(w, http.StatusCreated, SkillChangeResponse{
+		Skill:             toSkillInfo(name, source),
+		Changed:           true,
+		RefreshedMissions: refreshed,
+	})
+	return nil
+}
+
+// handleUpdateSkill handles POST /skills/{name}/update: pulls the skill's
+// source repo and commits the new version if it changed.
+func (s *Server) handleUpdateSkill(w http.ResponseWriter, r *http.Request) error {
+	name := r.PathValue("name")
+	skill, err := s.getInstalledSkill(name)
+	if err != nil {
+		return err
+	}
+	if skill.Source == nil {
+		return newHTTPErrorf(http.StatusBadRequest, "skill '%s' was not installed from a repo and can't be updated", name)
+	}
+
+	source := skill.Source
+	changed := false
+	previousCommit := source.Commit
+	refreshed, err := s.applySkillChange("Update skill "+name+" from "+source.Repo, func(userClaudeDirpath string) error {
+		source, changed, err = claudeconfig.UpdateSkill(userClaudeDirpath, name)
+		return err
+	})
+	if err != nil {
+		return newHTTPErrorf(http.StatusBadGateway, "%#s", err)
+	}
+	if changed {
+		s.logger.Printf("Skills: updated '%s' from %s to %s", name, shortCommit(previousCommit), shortCommit(source.Commit))
+	}
+
+	writeJSON(w, http.StatusOK, SkillChangeResponse{
+		Skill:             toSkillInfo(name, source),
+		Changed:           changed,
+		RefreshedMissions: refreshed,
+	})
+	return nil
+}
+
+// handleRemoveSkill handles DELETE /skills/{name}.
+func (s *Server) handleRemoveSkill(w http.ResponseWriter, r *http.Request) error {
+	name := r.PathValue("name")
+	skill, err := s.getInstalledSkill(name)
+	if err != nil {
+		return err
+	}
+	if skill.Source == nil {
+		return newHTTPErrorf(http.StatusBadRequest, "skill '%s' was not installed from a repo; remove it from ~/.claude/skills directly", name)
+	}
+
+	refreshed, err := s.applySkillChange("Remove skill "+name, func(userClaudeDirpath string) error {
+		return claudeconfig.RemoveSkill(userClaudeDirpath, name)
+	})
+	if err != nil {
+		return newHTTPErrorf(http.StatusInternalServerError, "%#s", err)
+	}
+	s.logger.Printf("Skills: removed '%s'", name)
+
+	writeJSON(w, http.StatusOK, SkillChangeResponse{
+		Skill:             toSkillInfo(name, skill.Source),
+		Changed:           true,
+		RefreshedMissions: refreshed,
+	})
+	return nil
+}
+
+// getInstalledSkill returns the named skill, or a 404 if it isn't installed.
+func (s *Server) getInstalledSkill(name string) (*claudeconfig.InstalledSkill, error) {
+	userClaudeDirpath, err := s.getConfig().UserClaudeDirpath()
+	if err != nil {
+		return nil, newHTTPErrorf(http.StatusInternalServerError, "%#s", err)
+	}
+	skill, err := claudeconfig.GetSkill(userClaudeDirpath, name)
+	if err != nil {
+		return nil, newHTTPErrorf(http.StatusInternalServerError, "%#s", err)
+	}
+	if skill == nil {
+		return nil, newHTTPErrorf(http.StatusNotFound, "skill '%s' not found", name)
+	}
+	return skill, nil
+}
+
+// applySkillChange makes a change to ~/.claude/skills and commits it to the
+// shadow repo with message, then rebuilds the skills of running missions so
+// they see it without a reload. Pending hand edits are ingested first so the
+// commit holds only this change. Returns how many missions were refreshed.
+func (s *Server) applySkillChange(message string, change func(userClaudeDirpath string) error) (int, error) {
+	userClaudeDirpath, err := s.getConfig().UserClaudeDirpath()
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "failed to determine ~/.claude path")
+	}
+
+	s.shadowMu.Lock()
+	defer s.shadowMu.Unlock()
+
+	shadowDirpath := claudeconfig.GetShadowRepoDirpath(s.agencDirpath)
+	if err := claudeconfig.IngestFromClaudeDir(userClaudeDirpath, shadowDirpath); err != nil {
+		return 0, stacktrace.Propagate(err, "failed to ingest ~/.claude before changing skills")
+	}
+	previousHead := claudeconfig.GetShadowRepoCommitHash(s.agencDirpath)
+
+	if err := change(userClaudeDirpath); err != nil {
+		return 0, err
+	}
+	if err := claudeconfig.IngestFromClaudeDirWithMessage(userClaudeDirpath, shadowDirpath, message); err != nil {
+		return 0, stacktrace.Propagate(err, "failed to commit the skill change to the shadow repo")
+	}
+
+	headCommit := claudeconfig.GetShadowRepoCommitHash(s.agencDirpath)
+	refreshed := 0
+	if headCommit != previousHead {
+		refreshed = s.refreshRunningMissionSkills(previousHead, headCommit)
+	}
+	s.checkShadowHeadChanged()
+	return refreshed, nil
+}
+
+// refreshRunningMissionSkills rebuilds the skills directory of every running
+// mission's config. Missions whose config was built from previousHead differ
+// from headCommit only by the skill change, so they are recorded as current;
+// older ones stay stale for the usual rollout. Best-effort: failures are
+// logged. Returns how many missions were refreshed.
+func (s *Server) refreshRunningMissionSkills(previousHead string, headCommit string) int {
+	missions, err := s.db.ListMissions(database.ListMissionsParams{})
+	if err != nil {
+		s.logger.Printf("Skills: failed to list missions to refresh: %v", err)
+		return 0
+	}
+
+	refreshed := 0
+	for _, m := range missions {
+		if !s.isWrapperRunning(m.ID) {
+			continue
+		}
+		if err := claudeconfig.RefreshMissionSkills(s.agencDirpath, m.ID); err != nil {
+			s.logger.Printf("Skills: failed to refresh skills of mission %s: %v", m.ShortID, err)
+			continue
+		}
+		refreshed++
+		if previousHead != "" && m.ConfigCommit != nil && *m.ConfigCommit == previousHead {
+			if err := s.db.UpdateMissionConfigCommit(m.ID, headCommit); err != nil {
+				s.logger.Printf("Skills: failed to record config commit of mission %s: %v", m.ShortID, err)
+			}
+		}
+	}
+	return refreshed
+}
+
+func toSkillInfo(name string, source *claudeconfig.SkillSource) SkillInfo {
+	info := SkillInfo{Name: name}
+	if source != nil {
+		info.Repo = source.Repo
+		info.Commit = source.Commit
+		installedAt := source.InstalledAt
+		info.InstalledAt = &installedAt
+	}
+	return info
+}
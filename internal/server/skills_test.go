@@ -0,0 +1,116 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// newSkillsTestServer returns a test server with an initialized shadow repo
+// and its ~/.claude path.
+func newSkillsTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	srv := newAutoSummaryTestServer(t)
+	if _, err := claudeconfig.InitShadowRepo(srv.agencDirpath); err != nil {
+		t.Fatalf("InitShadowRepo failed: %v", err)
+	}
+	userClaudeDirpath, err := srv.getConfig().UserClaudeDirpath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, userClaudeDirpath
+}
+
+func writeSkillFile(t *testing.T, userClaudeDirpath string, name string, content string) {
+	t.Helper()
+	skillFilepath := filepath.Join(userClaudeDirpath, "skills", name, "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(skillFilepath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(skillFilepath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplySkillChange_RefreshesRunningMissions(t *testing.T) {
+	srv, userClaudeDirpath := newSkillsTestServer(t)
+	writeSkillFile(t, userClaudeDirpath, "existing", "# Existing")
+	if _, err := srv.applySkillChange("Seed", func(string) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	previousHead := claudeconfig.GetShadowRepoCommitHash(srv.agencDirpath)
+
+	current := createQueueTestMission(t, srv)
+	older := createQueueTestMission(t, srv)
+	stopped := createQueueTestMission(t, srv)
+	for _, m := range []struct {
+		id     string
+		commit string
+	}{{current.ID, previousHead}, {older.ID, "0000000"}, {stopped.ID, previousHead}} {
+		if err := srv.db.UpdateMissionConfigCommit(m.id, m.commit); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Join(config.GetMissionDirpath(srv.agencDirpath, m.id), claudeconfig.MissionClaudeConfigDirname), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	markWrapperRunning(t, srv, current.ID)
+	markWrapperRunning(t, srv, older.ID)
+
+	refreshed, err := srv.applySkillChange("Add skill pdf", func(userClaudeDirpath string) error {
+		writeSkillFile(t, userClaudeDirpath, "pdf", "# PDF")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("applySkillChange failed: %v", err)
+	}
+	if refreshed != 2 {
+		t.Errorf("expected the 2 running missions refreshed, got %d", refreshed)
+	}
+
+	headCommit := claudeconfig.GetShadowRepoCommitHash(srv.agencDirpath)
+	if headCommit == previousHead {
+		t.Fatal("expected the skill committed to the shadow repo")
+	}
+	skillFilepath := filepath.Join(config.GetMissionDirpath(srv.agencDirpath, current.ID), claudeconfig.MissionClaudeConfigDirname, "skills", "pdf", "SKILL.md")
+	if _, err := os.Stat(skillFilepath); err != nil {
+		t.Errorf("expected the new skill in the running mission's config: %v", err)
+	}
+
+	for _, tt := range []struct {
+		id   string
+		want string
+	}{{current.ID, headCommit}, {older.ID, "0000000"}, {stopped.ID, previousHead}} {
+		m, err := srv.db.GetMission(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.ConfigCommit == nil || *m.ConfigCommit != tt.want {
+			t.Errorf("mission %s: expected config commit %s, got %v", m.ShortID, tt.want, m.ConfigCommit)
+		}
+	}
+}
+
+func TestHandleRemoveSkill_RefusesHandWrittenSkills(t *testing.T) {
+	srv, userClaudeDirpath := newSkillsTestServer(t)
+	writeSkillFile(t, userClaudeDirpath, "mine", "# Mine")
+
+	for name, wantStatus := range map[string]int{"mine": http.StatusBadRequest, "missing": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodDelete, "/skills/"+name, nil)
+		req.SetPathValue("name", name)
+		err := srv.handleRemoveSkill(httptest.NewRecorder(), req)
+		var httpErr *httpError
+		if !errors.As(err, &httpErr) || httpErr.status != wantStatus {
+			t.Errorf("%s: expected status %d, got %v", name, wantStatus, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(userClaudeDirpath, "skills", "mine")); err != nil {
+		t.Error("expected the hand-written skill kept")
+	}
+}
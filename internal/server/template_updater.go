@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mieubrisse/stacktrace"
+
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/mission"
@@ -78,6 +80,11 @@ func (s *Server) runRepoUpdateCycle(ctx context.Context) {
 		}
 	}
 
+	// Keep retrying syncs deferred while offline, even once no mission needs them
+	for _, repo := range s.connectivity.deferredRepos() {
+		reposToSync[repo] = true
+	}
+
 	preferSSH := mission.DetectPreferredProtocol(s.agencDirpath)
 
 	for repo := range reposToSync {
@@ -91,8 +98,17 @@ func (s *Server) runRepoUpdateCycle(ctx context.Context) {
 			continue
 		}
 
+		// Repos whose remote was unreachable wait out their retry backoff
+		if !s.connectivity.isDue(repoName, now) {
+			continue
+		}
+
 		if err := s.ensureRepoCloned(ctx, repoName, cloneURL); err != nil {
-			s.logger.Printf("Repo update: clone failed for '%s': %v", repoName, err)
+			if mission.IsNetworkError(err) {
+				s.deferRepoSync(repoName, repoSyncOperationClone, err)
+			} else {
+				s.logger.Printf("Repo update: clone failed for '%s': %v", repoName, err)
+			}
 			continue
 		}
 
@@ -126,11 +142,11 @@ func (s *Server) ensureRepoCloned(ctx context.Context, repoName string, cloneURL
 
 	gitCmd := exec.CommandContext(ctx, "git", "clone", cloneURL, cloneDirpath)
 	if output, err := gitCmd.CombinedOutput(); err != nil {
-		s.logger.Printf("Repo update: git clone output for '%s': %s", repoName, strings.TrimSpace(string(output)))
-		return err
+		return stacktrace.Propagate(err, "git clone failed: %s", strings.TrimSpace(string(output)))
 	}
 
 	s.logger.Printf("Repo update: cloned '%s' from %s", repoName, cloneURL)
+	s.recordRepoReachable(repoName)
 
 	// Enqueue a forceRunHook request so the postUpdateHook runs after first clone
 	select {
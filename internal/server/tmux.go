@@ -1,17 +1,23 @@
 package server
 
 import (
-	"os/exec"
+	"bytes"
+	"encoding/json"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/odyssey/agenc/internal/config"
 	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 const (
 	// maxTmuxWindowTitleLen is the maximum character length for tmux window titles.
 	maxTmuxWindowTitleLen = 30
+
+	// maxDisplayNameLen caps a mission's display name; longer names are
+	// truncated in the tmux window anyway.
+	maxDisplayNameLen = 100
 )
 
 // reconcileTmuxWindowTitle examines all available data for a mission and
@@ -19,11 +25,12 @@ const (
 // and can be called from any context (scanner, summarizer, mission switch).
 //
 // Title priority (highest to lowest):
-//  1. Active session's custom_title (from /rename)
-//  2. Active session's agenc_custom_title (user-set via CLI)
-//  3. Active session's auto_summary (from Claude or AgenC summarizer)
-//  4. Repo short name (from git_repo)
-//  5. Mission short ID (fallback)
+//  1. Mission's display_name (from `agenc mission rename`)
+//  2. Active session's custom_title (from /rename)
+//  3. Active session's agenc_custom_title (user-set via CLI)
+//  4. Active session's auto_summary (from Claude or AgenC summarizer)
+//  5. Repo short name (from git_repo)
+//  6. Mission short ID (fallback)
 func (s *Server) reconcileTmuxWindowTitle(missionID string) {
 	// Step 1: Get the active session's metadata
 	activeSession, err := s.db.GetActiveSession(missionID)
@@ -47,19 +54,48 @@ func (s *Server) reconcileTmuxWindowTitle(missionID string) {
 
 	bestTitle := determineBestTitle(activeSession, mission, repoTitle)
 
-	s.logger.Printf("Tmux reconcile [%s]: bestTitle=%q (custom=%q, agencCustom=%q, auto=%q)",
-		mission.ShortID, bestTitle,
+	s.logger.Printf("Tmux reconcile [%s]: bestTitle=%q (display=%q, custom=%q, agencCustom=%q, auto=%q)",
+		mission.ShortID, bestTitle, mission.DisplayName,
 		sessionField(activeSession, func(s *database.Session) string { return s.CustomTitle }),
 		sessionField(activeSession, func(s *database.Session) string { return s.AgencCustomTitle }),
 		sessionField(activeSession, func(s *database.Session) string { return s.AutoSummary }),
 	)
 
-	// Step 4: Apply the title to tmux
+	// Step 4: Apply the title to tmux. With a windowTitleTemplate the wrapper
+	// renders the title, since it knows Claude's state
+	if s.getConfig().GetWindowTitleTemplate(mission.GitRepo) != "" {
+		s.sendWrapperWindowTitle(mission, bestTitle)
+		return
+	}
 	s.applyTmuxTitle(mission, bestTitle)
 }
 
+// sendWrapperWindowTitle hands the mission's plain title to its wrapper,
+// which renders the windowTitleTemplate around it. Best-effort: a wrapper
+// that isn't running has no window to title.
+func (s *Server) sendWrapperWindowTitle(mission *database.Mission, title string) {
+	if mission.TmuxPane == nil || *mission.TmuxPane == "" {
+		return
+	}
+	body, err := json.Marshal(map[string]string{"title": title})
+	if err != nil {
+		return
+	}
+	resp, err := newWrapperSocketClient(s.agencDirpath, mission.ID).Post("http://wrapper/window-title", "application/json", bytes.NewReader(body))
+	if err != nil {
+		s.logger.Printf("Tmux reconcile [%s]: failed to send title to wrapper: %v", mission.ShortID, err)
+		return
+	}
+	resp.Body.Close()
+}
+
 // determineBestTitle picks the best available title using the priority chain.
 func determineBestTitle(activeSession *database.Session, mission *database.Mission, repoTitle string) string {
+	// Priority 0: display_name from mission rename, which outlives sessions
+	if mission.DisplayName != "" {
+		return mission.DisplayName
+	}
+
 	// Priority 1: custom_title from /rename
 	if activeSession != nil && activeSession.CustomTitle != "" {
 		return activeSession.CustomTitle
@@ -143,7 +179,7 @@ func (s *Server) applyTmuxTitle(mission *database.Mission, title string) {
 
 	truncatedTitle := truncateTitle(fullTitle, maxTmuxWindowTitleLen)
 
-	if err := exec.Command("tmux", "rename-window", "-t", paneID, truncatedTitle).Run(); err != nil {
+	if err := tmux.Command("rename-window", "-t", paneID, truncatedTitle).Run(); err != nil {
 		s.logger.Printf("Tmux reconcile [%s]: tmux rename-window failed for pane %s: %v", mission.ShortID, paneID, err)
 	}
 }
@@ -151,7 +187,7 @@ func (s *Server) applyTmuxTitle(mission *database.Mission, title string) {
 // isSolePaneInTmuxWindow returns true if the given pane is the only pane in its
 // tmux window. Returns false if the window has multiple panes or if detection fails.
 func isSolePaneInTmuxWindow(paneID string) bool {
-	out, err := exec.Command("tmux", "display-message", "-p", "-t", paneID, "#{window_panes}").Output()
+	out, err := tmux.Command("display-message", "-p", "-t", paneID, "#{window_panes}").Output()
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+// Kinds of tmux group session, the middle part of their names.
+const (
+	tmuxGroupKindRepo    = "repo"
+	tmuxGroupKindProject = "project"
+)
+
+// tmuxGroupNameUnsafeRegex matches runs of characters not kept in a group
+// session's name; tmux reserves ':' and '.' in targets.
+var tmuxGroupNameUnsafeRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// tmuxGroupFor returns the kind and name of the group session layout places
+// missionRecord's window in: its repo (the first of a multi-repo mission's)
+// under perRepo, its project under perProject. Returns empty strings under
+// the pool layout and for missions with nothing to group by, such as blank
+// missions or missions outside a project.
+func tmuxGroupFor(layout string, missionRecord *database.Mission) (string, string) {
+	var kind, name string
+	switch layout {
+	case config.TmuxLayoutPerRepo:
+		repo := missionRecord.GitRepo
+		if repo == "" && len(missionRecord.GitRepos) > 0 {
+			repo = missionRecord.GitRepos[0]
+		}
+		kind, name = tmuxGroupKindRepo, repo[strings.LastIndex(repo, "/")+1:]
+	case config.TmuxLayoutPerProject:
+		kind, name = tmuxGroupKindProject, missionRecord.Project
+	}
+	name = strings.Trim(tmuxGroupNameUnsafeRegex.ReplaceAllString(name, "-"), "-")
+	if name == "" {
+		return "", ""
+	}
+	return kind, name
+}
+
+// missionGroupSession returns the tmux session tmux.layout links
+// missionRecord's window into, or "" when its window goes into the caller's
+// session as usual.
+func (s *Server) missionGroupSession(missionRecord *database.Mission) string {
+	kind, name := tmuxGroupFor(s.getConfig().GetTmuxLayout(), missionRecord)
+	if name == "" {
+		return ""
+	}
+	return config.GetTmuxGroupSessionName(s.agencDirpath, kind, name)
+}
+
+// isTmuxGroupSession reports whether sessionName is a group session created
+// for tmux.layout, which AgenC recreates on demand.
+func (s *Server) isTmuxGroupSession(sessionName string) bool {
+	for _, kind := range []string{tmuxGroupKindRepo, tmuxGroupKindProject} {
+		if strings.HasPrefix(sessionName, config.GetTmuxGroupSessionName(s.agencDirpath, kind, "")) {
+			return true
+		}
+	}
+	return false
+}
+
+// linkPoolWindowIntoSession links the pool window containing paneID into
+// sessionName, creating it first if it is a missing group session. A new
+// group session's placeholder window is closed once the mission's window is
+// in, and clients are moved to another session rather than detached when
+// its last window goes, so detaching the last mission of a group doesn't
+// drop the user out of tmux. A no-op if the pane is already in the session.
+func (s *Server) linkPoolWindowIntoSession(paneID string, sessionName string) error {
+	if isPaneInSession(paneID, sessionName) {
+		return nil
+	}
+
+	placeholderWindowID := ""
+	if s.isTmuxGroupSession(sessionName) && !tmuxSessionExists(sessionName) {
+		output, err := tmux.Command("new-session", "-d", "-s", sessionName, "-x", "200", "-y", "50", "-P", "-F", "#{window_id}").CombinedOutput()
+		if err != nil {
+			return stacktrace.NewError("failed to create group session %s: %v (output: %s)", sessionName, err, strings.TrimSpace(string(output)))
+		}
+		placeholderWindowID = strings.TrimSpace(string(output))
+		//nolint:errcheck // best-effort
+		tmux.Command("set-option", "-t", "="+sessionName, "detach-on-destroy", "off").Run()
+		s.logger.Printf("Created tmux group session: %s", sessionName)
+		s.shareTmuxServer()
+	}
+
+	if err := linkPoolWindowByPane(paneID, sessionName); err != nil {
+		if placeholderWindowID != "" {
+			//nolint:errcheck // best-effort
+			tmux.Command("kill-session", "-t", "="+sessionName).Run()
+		}
+		return err
+	}
+	if placeholderWindowID != "" {
+		//nolint:errcheck // best-effort
+		tmux.Command("kill-window", "-t", placeholderWindowID).Run()
+	}
+	return nil
+}
+
+// switchClientToSession moves the most recently active tmux client showing
+// fromSession over to toSession, so a mission linked into its group session
+// comes into view. Best-effort: does nothing if fromSession has no clients.
+func switchClientToSession(fromSession string, toSession string) {
+	output, err := tmux.Command("list-clients", "-t", "="+fromSession, "-F", "#{client_activity} #{client_name}").Output()
+	if err != nil {
+		return
+	}
+	clientName := ""
+	var latestActivity int64 = -1
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		activity, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || activity <= latestActivity {
+			continue
+		}
+		latestActivity, clientName = activity, parts[1]
+	}
+	if clientName == "" {
+		return
+	}
+	//nolint:errcheck // best-effort
+	tmux.Command("switch-client", "-c", clientName, "-t", "="+toSession).Run()
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+)
+
+func TestTmuxGroupFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		layout   string
+		mission  database.Mission
+		wantKind string
+		wantName string
+	}{
+		{"pool layout", config.TmuxLayoutPool, database.Mission{GitRepo: "github.com/owner/api"}, "", ""},
+		{"repo", config.TmuxLayoutPerRepo, database.Mission{GitRepo: "github.com/owner/api"}, tmuxGroupKindRepo, "api"},
+		{"multi-repo uses the first repo", config.TmuxLayoutPerRepo, database.Mission{GitRepos: []string{"github.com/owner/web", "github.com/owner/api"}}, tmuxGroupKindRepo, "web"},
+		{"unsafe characters replaced", config.TmuxLayoutPerRepo, database.Mission{GitRepo: "github.com/owner/docs.site"}, tmuxGroupKindRepo, "docs-site"},
+		{"blank mission", config.TmuxLayoutPerRepo, database.Mission{}, "", ""},
+		{"project", config.TmuxLayoutPerProject, database.Mission{GitRepo: "github.com/owner/api", Project: "launch.v2"}, tmuxGroupKindProject, "launch-v2"},
+		{"no project", config.TmuxLayoutPerProject, database.Mission{GitRepo: "github.com/owner/api"}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, name := tmuxGroupFor(tt.layout, &tt.mission)
+			if kind != tt.wantKind || name != tt.wantName {
+				t.Errorf("expected (%q, %q), got (%q, %q)", tt.wantKind, tt.wantName, kind, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+const (
+	// wrapperHealthInterval is how often the server pings running wrappers.
+	wrapperHealthInterval = 30 * time.Second
+
+	// wrapperPingTimeout bounds each ping. Longer than the wrapper's own wait
+	// on its event loop, so a wedged loop is reported by the wrapper rather
+	// than timed out here.
+	wrapperPingTimeout = 5 * time.Second
+
+	// wrapperUnresponsiveThreshold is how many pings in a row a wrapper may
+	// fail before it is marked unresponsive, so one slow moment (such as
+	// spawning Claude) doesn't raise an alarm.
+	wrapperUnresponsiveThreshold = 3
+
+	missionUnresponsiveNotificationKind = "mission.unresponsive"
+)
+
+// Wrapper health statuses in WrapperHealth.
+const (
+	// WrapperHealthHealthy means the last ping was answered
+	WrapperHealthHealthy = "healthy"
+	// WrapperHealthDegraded means recent pings failed, but fewer than
+	// wrapperUnresponsiveThreshold in a row
+	WrapperHealthDegraded = "degraded"
+	// WrapperHealthUnresponsive means the wrapper failed
+	// wrapperUnresponsiveThreshold or more pings in a row
+	WrapperHealthUnresponsive = "unresponsive"
+)
+
+// WrapperHealth is the outcome of the server's pings of a running mission's
+// wrapper, in MissionResponse.
+type WrapperHealth struct {
+	Status      string    `json:"status"`
+	LastProbeAt time.Time `json:"last_probe_at"`
+	// LastHealthyAt is when a ping was last answered; nil if none has been
+	// since the server started
+	LastHealthyAt       *time.Time `json:"last_healthy_at,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+// wrapperHealth tracks the ping results of each running wrapper. The zero
+// value is ready to use.
+type wrapperHealth struct {
+	mu     sync.Mutex
+	health map[string]*WrapperHealth
+}
+
+// record records a ping of missionID's wrapper at now that failed with err,
+// or succeeded if err is nil. Returns the updated health and its status
+// before the ping ("" for a wrapper not pinged before).
+func (h *wrapperHealth) record(missionID string, err error, now time.Time) (WrapperHealth, string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.health == nil {
+		h.health = make(map[string]*WrapperHealth)
+	}
+	health, ok := h.health[missionID]
+	previousStatus := ""
+	if ok {
+		previousStatus = health.Status
+	} else {
+		health = &WrapperHealth{}
+		h.health[missionID] = health
+	}
+
+	health.LastProbeAt = now
+	if err == nil {
+		health.Status = WrapperHealthHealthy
+		health.LastHealthyAt = &now
+		health.ConsecutiveFailures = 0
+		health.LastError = ""
+		return *health, previousStatus
+	}
+	health.ConsecutiveFailures++
+	// The brief form keeps the stack trace out of API responses
+	health.LastError = fmt.Sprintf("%#s", err)
+	health.Status = WrapperHealthDegraded
+	if health.ConsecutiveFailures >= wrapperUnresponsiveThreshold {
+		health.Status = WrapperHealthUnresponsive
+	}
+	return *health, previousStatus
+}
+
+// get returns missionID's wrapper health, or nil if its wrapper hasn't been
+// pinged.
+func (h *wrapperHealth) get(missionID string) *WrapperHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	health, ok := h.health[missionID]
+	if !ok {
+		return nil
+	}
+	healthCopy := *health
+	return &healthCopy
+}
+
+// retain forgets the health of every mission not in missionIDs, so wrappers
+// that stopped don't keep reporting their last result.
+func (h *wrapperHealth) retain(missionIDs map[string]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for missionID := range h.health {
+		if !missionIDs[missionID] {
+			delete(h.health, missionID)
+		}
+	}
+}
+
+// runWrapperHealthLoop periodically pings the wrapper of every active mission
+// whose wrapper process is running.
+func (s *Server) runWrapperHealthLoop(ctx context.Context) {
+	ticker := time.NewTicker(wrapperHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runWrapperHealthSweep()
+		}
+	}
+}
+
+// runWrapperHealthSweep pings every running wrapper concurrently, skipping
+// missions mid-reload and the whole sweep while a stash operation is
+// stopping and restarting wrappers. A wrapper that crosses
+// wrapperUnresponsiveThreshold is logged, published as mission.unresponsive,
+// and recorded as a notification; the crash detection loop still decides
+// whether it is dead.
+func (s *Server) runWrapperHealthSweep() {
+	if s.stashInProgress.Load() {
+		return
+	}
+
+	missions, err := s.db.ListMissions(database.ListMissionsParams{IncludeArchived: false})
+	if err != nil {
+		s.logger.Printf("Wrapper health: failed to list missions: %v", err)
+		return
+	}
+
+	probed := make(map[string]bool)
+	var wg sync.WaitGroup
+	for _, m := range missions {
+		if m.Status != "active" || !s.isWrapperRunning(m.ID) {
+			continue
+		}
+		probed[m.ID] = true
+		if _, reloading := s.reloadsInProgress.Load(m.ID); reloading {
+			continue
+		}
+		wg.Add(1)
+		go func(m *database.Mission) {
+			defer wg.Done()
+			s.probeWrapper(m)
+		}(m)
+	}
+	wg.Wait()
+	s.wrapperHealth.retain(probed)
+}
+
+// probeWrapper pings m's wrapper and records the result, reporting when it
+// becomes unresponsive or recovers.
+func (s *Server) probeWrapper(m *database.Mission) {
+	health, previousStatus := s.wrapperHealth.record(m.ID, pingWrapper(s.agencDirpath, m.ID), time.Now())
+	switch {
+	case health.Status == WrapperHealthUnresponsive && previousStatus != WrapperHealthUnresponsive:
+		s.logger.Printf("Wrapper health: mission %s is unresponsive (%d failed pings): %s", m.ShortID, health.ConsecutiveFailures, health.LastError)
+		s.publishEvent(EventMissionUnresponsive, m.ID, map[string]string{"error": health.LastError})
+		if err := s.db.CreateNotification(buildMissionUnresponsiveNotification(m, health)); err != nil {
+			s.logger.Printf("failed to create unresponsive notification for mission %s: %v", m.ShortID, err)
+		}
+	case health.Status == WrapperHealthHealthy && previousStatus == WrapperHealthUnresponsive:
+		s.logger.Printf("Wrapper health: mission %s is responding again", m.ShortID)
+	}
+}
+
+// pingWrapper sends GET /ping to a mission's wrapper, which answers once its
+// main event loop has handled the ping.
+func pingWrapper(agencDirpath string, missionID string) error {
+	resp, err := newWrapperSocketClientWithTimeout(agencDirpath, missionID, wrapperPingTimeout).Get("http://wrapper/ping")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to reach the wrapper socket")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var body struct {
+		Error string `json:"error"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	if body.Error == "" {
+		body.Error = http.StatusText(resp.StatusCode)
+	}
+	return stacktrace.NewError("wrapper answered the ping with %d: %s", resp.StatusCode, body.Error)
+}
+
+// buildMissionUnresponsiveNotification constructs the notification recording
+// that a mission's wrapper stopped answering pings.
+func buildMissionUnresponsiveNotification(m *database.Mission, health WrapperHealth) *database.Notification {
+	bodyParts := []string{
+		"**Mission:** " + m.ShortID,
+		fmt.Sprintf("**Failed pings:** %d in a row", health.ConsecutiveFailures),
+		"**Last error:** " + health.LastError,
+	}
+	if m.GitRepo != "" {
+		bodyParts = append(bodyParts, "**Repo:** "+m.GitRepo)
+	}
+	if health.LastHealthyAt != nil {
+		bodyParts = append(bodyParts, "**Last answered:** "+health.LastHealthyAt.Format(time.RFC3339))
+	}
+	bodyParts = append(bodyParts, "The wrapper process is running but not answering. Reload it with `agenc mission reload "+m.ShortID+"`.")
+
+	missionID := m.ID
+	return &database.Notification{
+		ID:           uuid.New().String(),
+		Kind:         missionUnresponsiveNotificationKind,
+		Title:        sanitizeNotificationTitle("Mission unresponsive: " + m.ShortID),
+		BodyMarkdown: strings.Join(bodyParts, "\n\n"),
+		MissionID:    &missionID,
+	}
+}
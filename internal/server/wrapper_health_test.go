@@ -0,0 +1,47 @@
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWrapperHealth_Record(t *testing.T) {
+	var h wrapperHealth
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	pingErr := errors.New("connection refused")
+
+	if got := h.get("m1"); got != nil {
+		t.Fatalf("expected no health before the first ping, got %+v", got)
+	}
+
+	health, previous := h.record("m1", nil, now)
+	if health.Status != WrapperHealthHealthy || previous != "" {
+		t.Fatalf("expected healthy from nothing, got %s from %q", health.Status, previous)
+	}
+
+	for i := 1; i < wrapperUnresponsiveThreshold; i++ {
+		health, _ = h.record("m1", pingErr, now.Add(time.Duration(i)*time.Minute))
+		if health.Status != WrapperHealthDegraded {
+			t.Fatalf("failure %d: expected degraded, got %s", i, health.Status)
+		}
+	}
+	health, previous = h.record("m1", pingErr, now.Add(time.Hour))
+	if health.Status != WrapperHealthUnresponsive || previous != WrapperHealthDegraded {
+		t.Fatalf("expected unresponsive after %d failures, got %s from %s", wrapperUnresponsiveThreshold, health.Status, previous)
+	}
+	if health.LastError != "connection refused" || health.LastHealthyAt == nil || !health.LastHealthyAt.Equal(now) {
+		t.Errorf("unexpected health %+v", health)
+	}
+
+	health, previous = h.record("m1", nil, now.Add(2*time.Hour))
+	if health.Status != WrapperHealthHealthy || previous != WrapperHealthUnresponsive || health.ConsecutiveFailures != 0 || health.LastError != "" {
+		t.Errorf("expected a full recovery, got %+v from %s", health, previous)
+	}
+
+	h.record("m2", nil, now)
+	h.retain(map[string]bool{"m2": true})
+	if h.get("m1") != nil || h.get("m2") == nil {
+		t.Error("expected only m2 retained")
+	}
+}
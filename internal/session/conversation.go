@@ -3,6 +3,7 @@ package session
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 )
 
 // jsonlUserEntry represents a user message entry in a session JSONL file.
@@ -64,3 +65,39 @@ func extractUserMessagesFromJSONL(jsonlFilepath string, maxMessages int) []strin
 	}
 	return messages
 }
+
+// ExtractLastAssistantText returns the text of the last assistant message in
+// a JSONL session file that contains any text, joining multiple text blocks
+// with newlines. Tool calls and thinking blocks are ignored. Returns "" if no
+// assistant text is found.
+func ExtractLastAssistantText(jsonlFilepath string) string {
+	var last string
+	_ = ScanJSONLLines(jsonlFilepath, func(line []byte) error {
+		if !bytes.Contains(line, []byte(`"type":"assistant"`)) {
+			return nil
+		}
+		var entry jsonlEntry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.Type != "assistant" {
+			return nil
+		}
+		var msg apiMessage
+		if err := json.Unmarshal(entry.Message, &msg); err != nil {
+			return nil
+		}
+		var blocks []contentBlock
+		if err := json.Unmarshal(msg.Content, &blocks); err != nil {
+			return nil
+		}
+		var texts []string
+		for _, b := range blocks {
+			if b.Type == "text" && b.Text != "" {
+				texts = append(texts, b.Text)
+			}
+		}
+		if len(texts) > 0 {
+			last = strings.Join(texts, "\n")
+		}
+		return nil
+	})
+	return last
+}
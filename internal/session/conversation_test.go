@@ -403,3 +403,24 @@ func TestExtractRecentUserMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractLastAssistantText(t *testing.T) {
+	jsonlFilepath := filepath.Join(t.TempDir(), "session.jsonl")
+	content := `{"type":"user","message":{"role":"user","content":"Do the thing"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Working on it"}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"ls"}}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"thinking","thinking":"hmm"},{"type":"text","text":"Done."},{"type":"text","text":"All tests pass."}]}}
+{"type":"system","subtype":"stop"}
+`
+	if err := os.WriteFile(jsonlFilepath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write JSONL: %v", err)
+	}
+
+	if got, want := ExtractLastAssistantText(jsonlFilepath), "Done.\nAll tests pass."; got != want {
+		t.Errorf("ExtractLastAssistantText() = %q, want %q", got, want)
+	}
+
+	if got := ExtractLastAssistantText(filepath.Join(t.TempDir(), "missing.jsonl")); got != "" {
+		t.Errorf("expected empty result for missing file, got %q", got)
+	}
+}
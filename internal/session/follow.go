@@ -0,0 +1,60 @@
+package session
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// JSONLFollower incrementally reads lines appended to a JSONL file that another
+// process is still writing. Each call to ReadNewLines returns only the complete
+// lines written since the previous call; a trailing partial line is held back
+// until its newline arrives.
+type JSONLFollower struct {
+	jsonlFilepath string
+	offset        int64
+}
+
+// NewJSONLFollower returns a follower positioned at the start of the file.
+func NewJSONLFollower(jsonlFilepath string) *JSONLFollower {
+	return &JSONLFollower{jsonlFilepath: jsonlFilepath}
+}
+
+// ReadNewLines returns the complete, non-empty lines appended since the last
+// call. A file that does not exist yet yields no lines and no error.
+func (f *JSONLFollower) ReadNewLines() ([]string, error) {
+	file, err := os.Open(f.jsonlFilepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to open JSONL file '%s'", f.jsonlFilepath)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(f.offset, io.SeekStart); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to seek in JSONL file '%s'", f.jsonlFilepath)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read JSONL file '%s'", f.jsonlFilepath)
+	}
+
+	lastNewline := bytes.LastIndexByte(data, '\n')
+	if lastNewline < 0 {
+		return nil, nil
+	}
+	complete := data[:lastNewline+1]
+	f.offset += int64(len(complete))
+
+	var lines []string
+	for _, line := range bytes.Split(complete, []byte{'\n'}) {
+		line = trimLineEnding(line)
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,56 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestJSONLFollower_ReadNewLines(t *testing.T) {
+	jsonlFilepath := filepath.Join(t.TempDir(), "session.jsonl")
+	follower := NewJSONLFollower(jsonlFilepath)
+
+	// File not created yet
+	lines, err := follower.ReadNewLines()
+	if err != nil || lines != nil {
+		t.Fatalf("expected no lines for missing file, got %v, %v", lines, err)
+	}
+
+	appendToFile := func(content string) {
+		t.Helper()
+		f, err := os.OpenFile(jsonlFilepath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatalf("failed to open file: %v", err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(content); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	// A trailing partial line is held back until its newline arrives
+	appendToFile("{\"a\":1}\n\n{\"b\":")
+	lines, err = follower.ReadNewLines()
+	if err != nil {
+		t.Fatalf("ReadNewLines failed: %v", err)
+	}
+	if want := []string{`{"a":1}`}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("got %v, want %v", lines, want)
+	}
+
+	appendToFile("2}\r\n{\"c\":3}\n")
+	lines, err = follower.ReadNewLines()
+	if err != nil {
+		t.Fatalf("ReadNewLines failed: %v", err)
+	}
+	if want := []string{`{"b":2}`, `{"c":3}`}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("got %v, want %v", lines, want)
+	}
+
+	// Nothing new
+	lines, err = follower.ReadNewLines()
+	if err != nil || len(lines) != 0 {
+		t.Errorf("expected no new lines, got %v, %v", lines, err)
+	}
+}
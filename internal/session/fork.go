@@ -0,0 +1,44 @@
+package session
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/mieubrisse/stacktrace"
+)
+
+// ForkLatestSession copies the most recent conversation in srcProjectDirpath
+// into dstProjectDirpath under a new session ID, so another workspace can
+// resume it without sharing the transcript. References to the source session
+// ID and the source agent directory are rewritten to the new ID and
+// dstAgentDirpath. Returns the new session ID, or "" when the source has no
+// conversation to fork.
+func ForkLatestSession(srcProjectDirpath string, dstProjectDirpath string, srcAgentDirpath string, dstAgentDirpath string) (string, error) {
+	sessionIDs := ListProjectSessionIDs(srcProjectDirpath)
+	if len(sessionIDs) == 0 {
+		return "", nil
+	}
+	srcSessionID := sessionIDs[0]
+
+	data, err := os.ReadFile(filepath.Join(srcProjectDirpath, srcSessionID+".jsonl"))
+	if err != nil {
+		return "", stacktrace.Propagate(err, "failed to read session '%s'", srcSessionID)
+	}
+
+	newSessionID := uuid.NewString()
+	data = bytes.ReplaceAll(data, []byte(srcSessionID), []byte(newSessionID))
+	if srcAgentDirpath != dstAgentDirpath {
+		data = bytes.ReplaceAll(data, []byte(srcAgentDirpath), []byte(dstAgentDirpath))
+	}
+
+	if err := os.MkdirAll(dstProjectDirpath, 0700); err != nil {
+		return "", stacktrace.Propagate(err, "failed to create project directory '%s'", dstProjectDirpath)
+	}
+	dstFilepath := filepath.Join(dstProjectDirpath, newSessionID+".jsonl")
+	if err := os.WriteFile(dstFilepath, data, 0600); err != nil {
+		return "", stacktrace.Propagate(err, "failed to write forked session '%s'", dstFilepath)
+	}
+	return newSessionID, nil
+}
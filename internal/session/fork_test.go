@@ -0,0 +1,50 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestForkLatestSession(t *testing.T) {
+	srcProjectDirpath := t.TempDir()
+	dstProjectDirpath := filepath.Join(t.TempDir(), "dst-project")
+	srcAgentDirpath := "/agenc/missions/aaaa/agent"
+	dstAgentDirpath := "/agenc/missions/bbbb/agent"
+
+	srcSessionID := "11111111-1111-1111-1111-111111111111"
+	transcript := `{"type":"user","sessionId":"` + srcSessionID + `","cwd":"` + srcAgentDirpath + `","message":{"role":"user","content":"hi"}}` + "\n"
+	if err := os.WriteFile(filepath.Join(srcProjectDirpath, srcSessionID+".jsonl"), []byte(transcript), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	newSessionID, err := ForkLatestSession(srcProjectDirpath, dstProjectDirpath, srcAgentDirpath, dstAgentDirpath)
+	if err != nil {
+		t.Fatalf("ForkLatestSession failed: %v", err)
+	}
+	if newSessionID == "" || newSessionID == srcSessionID {
+		t.Fatalf("expected a new session ID, got %q", newSessionID)
+	}
+
+	forked, err := os.ReadFile(filepath.Join(dstProjectDirpath, newSessionID+".jsonl"))
+	if err != nil {
+		t.Fatalf("forked transcript missing: %v", err)
+	}
+	if strings.Contains(string(forked), srcSessionID) || strings.Contains(string(forked), srcAgentDirpath) {
+		t.Errorf("forked transcript still references the source: %s", forked)
+	}
+	if !strings.Contains(string(forked), newSessionID) || !strings.Contains(string(forked), dstAgentDirpath) {
+		t.Errorf("forked transcript not rewritten: %s", forked)
+	}
+}
+
+func TestForkLatestSession_NoConversation(t *testing.T) {
+	newSessionID, err := ForkLatestSession(t.TempDir(), filepath.Join(t.TempDir(), "dst"), "/a", "/b")
+	if err != nil {
+		t.Fatalf("ForkLatestSession failed: %v", err)
+	}
+	if newSessionID != "" {
+		t.Errorf("expected no session for an empty project, got %q", newSessionID)
+	}
+}
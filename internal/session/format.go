@@ -32,6 +32,7 @@ type apiMessage struct {
 // contentBlock represents a single block within a message's content array.
 type contentBlock struct {
 	Type      string                 `json:"type"`
+	ID        string                 `json:"id"`
 	Text      string                 `json:"text"`
 	Name      string                 `json:"name"`
 	Input     map[string]interface{} `json:"input"`
@@ -129,6 +130,13 @@ func collectJSONLLines(jsonlFilepath string, n int) ([]string, error) {
 	return result, nil
 }
 
+// FormatEntry formats a single JSONL session line the same way
+// FormatConversation does, returning "" for non-conversation entries. Used to
+// render a transcript incrementally as it is written.
+func FormatEntry(line string) string {
+	return formatJSONLLine(line)
+}
+
 // formatJSONLLine parses a single JSONL line and returns its formatted
 // representation, or "" if the line should be skipped.
 func formatJSONLLine(line string) string {
@@ -183,7 +191,7 @@ func formatUserEntry(rawMessage json.RawMessage, timestamp string) string {
 			parts = append(parts, b.Text)
 		}
 		if b.Type == "tool_result" && b.IsError {
-			errMsg := extractToolResultError(b)
+			errMsg := extractToolResultText(b)
 			if errMsg != "" {
 				parts = append(parts, "  > ERROR: "+truncate(errMsg, maxErrorLen))
 			}
@@ -244,10 +252,10 @@ func formatRoleHeader(role string, timestamp string) string {
 	return "[" + timestamp + " " + role + "]"
 }
 
-// extractToolResultError extracts the error message from a tool_result content
-// block. The content field can be either a plain string or an array of text
-// blocks.
-func extractToolResultError(b contentBlock) string {
+// extractToolResultText extracts the text (for errors, the error message) from
+// a tool_result content block. The content field can be either a plain string
+// or an array of text blocks.
+func extractToolResultText(b contentBlock) string {
 	// Try string content first.
 	var textContent string
 	if err := json.Unmarshal(b.Content, &textContent); err == nil {
@@ -0,0 +1,172 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// grepSnippetContext is how many characters of surrounding text a transcript
+// match snippet keeps on each side of the match.
+const grepSnippetContext = 60
+
+// errStopGrepping is a sentinel returned from GrepTranscripts' scan callback
+// once maxMatches is reached.
+var errStopGrepping = errors.New("reached max transcript matches")
+
+// TranscriptMatch is a single conversation message that matched a transcript
+// grep.
+type TranscriptMatch struct {
+	SessionID string
+	// Role is "user" or "assistant".
+	Role string
+	// Timestamp is when the message was written; zero if the entry had none.
+	Timestamp time.Time
+	// Snippet is the text around the first match in the message, with
+	// whitespace collapsed and the match wrapped in \x01 / \x02 markers (the
+	// same markers as full-text search snippets).
+	Snippet string
+}
+
+// GrepTranscripts scans every session transcript of a mission for messages
+// whose text contains query, case-insensitively. Only user text and assistant
+// prose are searched; tool calls, tool results, and thinking blocks are not.
+// Returns matches in file order, stopping after maxMatches when maxMatches is
+// positive. Returns nil if the mission has no transcripts.
+func GrepTranscripts(claudeConfigDirpath string, missionID string, query string, maxMatches int) ([]TranscriptMatch, error) {
+	needle := strings.ToLower(strings.TrimSpace(query))
+	if needle == "" {
+		return nil, nil
+	}
+	projectDirpath := findProjectDirpath(claudeConfigDirpath, missionID)
+	if projectDirpath == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(projectDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read project directory '%s'", projectDirpath)
+	}
+
+	var matches []TranscriptMatch
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		sessionID := strings.TrimSuffix(entry.Name(), ".jsonl")
+		err := ScanJSONLLines(filepath.Join(projectDirpath, entry.Name()), func(line []byte) error {
+			if match, ok := grepJSONLLine(line, needle); ok {
+				match.SessionID = sessionID
+				matches = append(matches, match)
+				if maxMatches > 0 && len(matches) >= maxMatches {
+					return errStopGrepping
+				}
+			}
+			return nil
+		})
+		if errors.Is(err, errStopGrepping) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// grepJSONLLine reports whether a user or assistant JSONL entry contains
+// needle (already lowercased), returning the match without its SessionID.
+func grepJSONLLine(line []byte, needle string) (TranscriptMatch, bool) {
+	var entry jsonlEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return TranscriptMatch{}, false
+	}
+	if entry.Type != "user" && entry.Type != "assistant" {
+		return TranscriptMatch{}, false
+	}
+
+	text := strings.Join(strings.Fields(extractMessageText(entry.Message)), " ")
+	snippet, ok := matchSnippet(text, needle)
+	if !ok {
+		return TranscriptMatch{}, false
+	}
+
+	match := TranscriptMatch{Role: entry.Type, Snippet: snippet}
+	if ts, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+		match.Timestamp = ts
+	}
+	return match, true
+}
+
+// extractMessageText returns the searchable text of a message: plain string
+// content, or the text blocks of array content.
+func extractMessageText(rawMessage json.RawMessage) string {
+	var msg apiMessage
+	if err := json.Unmarshal(rawMessage, &msg); err != nil {
+		return ""
+	}
+
+	var textContent string
+	if err := json.Unmarshal(msg.Content, &textContent); err == nil {
+		return textContent
+	}
+
+	var blocks []contentBlock
+	if err := json.Unmarshal(msg.Content, &blocks); err != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" && b.Text != "" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// matchSnippet finds needle (lowercased) in text and returns the surrounding
+// snippet with the match marked. Lowercasing can change the byte length of
+// some characters; in that case the snippet is cut from the lowercased text
+// so offsets stay valid.
+func matchSnippet(text string, needle string) (string, bool) {
+	lowered := strings.ToLower(text)
+	idx := strings.Index(lowered, needle)
+	if idx < 0 {
+		return "", false
+	}
+	if len(lowered) != len(text) {
+		text = lowered
+	}
+
+	start := max(idx-grepSnippetContext, 0)
+	end := min(idx+len(needle)+grepSnippetContext, len(text))
+	// Move the cut points off UTF-8 continuation bytes
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	b.WriteString(text[start:idx])
+	b.WriteString("\x01")
+	b.WriteString(text[idx : idx+len(needle)])
+	b.WriteString("\x02")
+	b.WriteString(text[idx+len(needle) : end])
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
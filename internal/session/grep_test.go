@@ -0,0 +1,77 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGrepTranscripts(t *testing.T) {
+	claudeConfigDirpath := t.TempDir()
+	missionID := "grep-mission-1"
+	projectDirpath := filepath.Join(claudeConfigDirpath, "projects", "-agent-"+missionID)
+	if err := os.MkdirAll(projectDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	jsonl := strings.Join([]string{
+		`{"type":"user","timestamp":"2026-03-16T16:06:30.476Z","message":{"role":"user","content":"Let's redesign the Token Bucket limiter"}}`,
+		`{"type":"assistant","timestamp":"2026-03-16T16:07:00Z","message":{"role":"assistant","content":[{"type":"thinking","thinking":"token bucket thoughts"},{"type":"tool_use","name":"Read","input":{"file_path":"token_bucket.go"}},{"type":"text","text":"The token bucket now refills lazily."}]}}`,
+		`{"type":"user","timestamp":"2026-03-16T16:08:00Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"x","content":"token bucket in tool output"}]}}`,
+		`{"type":"summary","summary":"Token bucket design"}`,
+	}, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(projectDirpath, "session-a.jsonl"), []byte(jsonl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := GrepTranscripts(claudeConfigDirpath, missionID, "token bucket", 0)
+	if err != nil {
+		t.Fatalf("GrepTranscripts failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches (user text and assistant prose), got %d: %+v", len(matches), matches)
+	}
+
+	first := matches[0]
+	if first.SessionID != "session-a" || first.Role != "user" {
+		t.Errorf("unexpected first match %+v", first)
+	}
+	wantTime := time.Date(2026, 3, 16, 16, 6, 30, 476000000, time.UTC)
+	if !first.Timestamp.Equal(wantTime) {
+		t.Errorf("expected timestamp %v, got %v", wantTime, first.Timestamp)
+	}
+	if first.Snippet != "Let's redesign the \x01Token Bucket\x02 limiter" {
+		t.Errorf("unexpected snippet %q", first.Snippet)
+	}
+	if matches[1].Role != "assistant" || matches[1].Snippet != "The \x01token bucket\x02 now refills lazily." {
+		t.Errorf("unexpected assistant match %+v", matches[1])
+	}
+
+	limited, err := GrepTranscripts(claudeConfigDirpath, missionID, "token bucket", 1)
+	if err != nil || len(limited) != 1 {
+		t.Errorf("expected 1 match with maxMatches=1, got %d (err %v)", len(limited), err)
+	}
+
+	none, err := GrepTranscripts(claudeConfigDirpath, "missing-mission", "token", 0)
+	if err != nil || none != nil {
+		t.Errorf("expected no matches for a mission without transcripts, got %+v (err %v)", none, err)
+	}
+}
+
+func TestMatchSnippet(t *testing.T) {
+	long := strings.Repeat("a", 100) + " needle " + strings.Repeat("b", 100)
+	got, ok := matchSnippet(long, "needle")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	want := "…" + strings.Repeat("a", 59) + " \x01needle\x02 " + strings.Repeat("b", 59) + "…"
+	if got != want {
+		t.Errorf("matchSnippet() = %q, want %q", got, want)
+	}
+
+	if _, ok := matchSnippet("nothing here", "needle"); ok {
+		t.Error("expected no match")
+	}
+}
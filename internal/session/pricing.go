@@ -0,0 +1,64 @@
+package session
+
+import "strings"
+
+// modelPrice holds per-million-token list prices in USD for a model family.
+// Cache reads are billed at a tenth of the input price and cache writes at
+// 1.25x the input price (5-minute TTL), so only input and output are listed.
+type modelPrice struct {
+	inputPerMTok  float64
+	outputPerMTok float64
+}
+
+const (
+	cacheReadPriceMultiplier  = 0.1
+	cacheWritePriceMultiplier = 1.25
+)
+
+// modelPrices maps model-ID prefixes to list prices. Matching is by longest
+// prefix, so dated model IDs (e.g. claude-sonnet-4-5-20250929) resolve to
+// their family.
+var modelPrices = map[string]modelPrice{
+	"claude-opus-4-0":    {inputPerMTok: 15, outputPerMTok: 75},
+	"claude-opus-4-2025": {inputPerMTok: 15, outputPerMTok: 75}, // dated Opus 4.0 IDs
+	"claude-opus-4-1":    {inputPerMTok: 15, outputPerMTok: 75},
+	"claude-opus-4":      {inputPerMTok: 5, outputPerMTok: 25},
+	"claude-3-opus":      {inputPerMTok: 15, outputPerMTok: 75},
+	"claude-sonnet":      {inputPerMTok: 3, outputPerMTok: 15},
+	"claude-3-7-sonnet":  {inputPerMTok: 3, outputPerMTok: 15},
+	"claude-3-5-sonnet":  {inputPerMTok: 3, outputPerMTok: 15},
+	"claude-haiku-4":     {inputPerMTok: 1, outputPerMTok: 5},
+	"claude-3-5-haiku":   {inputPerMTok: 0.8, outputPerMTok: 4},
+	"claude-3-haiku":     {inputPerMTok: 0.25, outputPerMTok: 1.25},
+}
+
+// defaultModelPrice is used for models missing from modelPrices (including
+// entries that don't record a model). Sonnet pricing keeps estimates in the
+// right order of magnitude without overstating spend.
+var defaultModelPrice = modelPrice{inputPerMTok: 3, outputPerMTok: 15}
+
+// lookupModelPrice returns the list price for a model ID.
+func lookupModelPrice(model string) modelPrice {
+	bestLen := 0
+	price := defaultModelPrice
+	for prefix, p := range modelPrices {
+		if len(prefix) > bestLen && strings.HasPrefix(model, prefix) {
+			bestLen = len(prefix)
+			price = p
+		}
+	}
+	return price
+}
+
+// EstimateCostUSD returns the approximate list-price cost of usage billed
+// against model. It is an estimate: it ignores discounts, batch pricing, and
+// the longer-TTL cache write rate.
+func EstimateCostUSD(model string, usage TokenUsage) float64 {
+	price := lookupModelPrice(model)
+	perToken := func(perMTok float64) float64 { return perMTok / 1_000_000 }
+
+	return float64(usage.InputTokens)*perToken(price.inputPerMTok) +
+		float64(usage.OutputTokens)*perToken(price.outputPerMTok) +
+		float64(usage.CacheReadTokens)*perToken(price.inputPerMTok*cacheReadPriceMultiplier) +
+		float64(usage.CacheCreationTokens)*perToken(price.inputPerMTok*cacheWritePriceMultiplier)
+}
@@ -235,7 +235,12 @@ func ListSessionIDs(claudeConfigDirpath string, missionID string) []string {
 	if projectDirpath == "" {
 		return nil
 	}
+	return ListProjectSessionIDs(projectDirpath)
+}
 
+// ListProjectSessionIDs returns the IDs of the sessions in a Claude Code
+// project directory that hold conversation data, most recent first.
+func ListProjectSessionIDs(projectDirpath string) []string {
 	entries, err := os.ReadDir(projectDirpath)
 	if err != nil {
 		return nil
@@ -353,3 +358,18 @@ func FindActiveJSONLPath(claudeConfigDirpath string, missionID string) string {
 	}
 	return findMostRecentJSONL(projectDirpath)
 }
+
+// FindMissionSessionJSONLPath returns the path of the given session's JSONL
+// log within the mission's project directory, or "" if the mission has no
+// such session.
+func FindMissionSessionJSONLPath(claudeConfigDirpath string, missionID string, sessionID string) string {
+	projectDirpath := findProjectDirpath(claudeConfigDirpath, missionID)
+	if projectDirpath == "" {
+		return ""
+	}
+	jsonlFilepath := filepath.Join(projectDirpath, sessionID+".jsonl")
+	if _, err := os.Stat(jsonlFilepath); err != nil {
+		return ""
+	}
+	return jsonlFilepath
+}
@@ -0,0 +1,286 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// maxTranscriptResultLen is the maximum length of a tool result kept in a
+// transcript; the rest is cut so a single large file read doesn't swamp the
+// document.
+const maxTranscriptResultLen = 4000
+
+// TranscriptFormat is an output format for RenderTranscript.
+type TranscriptFormat string
+
+const (
+	TranscriptFormatMarkdown TranscriptFormat = "md"
+	TranscriptFormatHTML     TranscriptFormat = "html"
+	TranscriptFormatJSON     TranscriptFormat = "json"
+)
+
+// Transcript is a session's conversation prepared for sharing: user and
+// assistant messages in order, with each tool call paired with its result.
+// Thinking blocks and non-conversation entries are dropped.
+type Transcript struct {
+	Title     string              `json:"title,omitempty"`
+	SessionID string              `json:"session_id"`
+	Messages  []TranscriptMessage `json:"messages"`
+}
+
+// TranscriptMessage is one user or assistant entry. An assistant entry holds
+// either text or tool calls; user entries holding only tool results are
+// folded into the calls they answer.
+type TranscriptMessage struct {
+	Role      string                `json:"role"`
+	Timestamp string                `json:"timestamp,omitempty"`
+	Text      string                `json:"text,omitempty"`
+	ToolCalls []*TranscriptToolCall `json:"tool_calls,omitempty"`
+}
+
+// TranscriptToolCall is a tool invocation and its (possibly truncated) result.
+type TranscriptToolCall struct {
+	Name    string                 `json:"name"`
+	Summary string                 `json:"summary"`
+	Input   map[string]interface{} `json:"input,omitempty"`
+	Result  string                 `json:"result,omitempty"`
+	IsError bool                   `json:"is_error,omitempty"`
+}
+
+// transcriptEntry extends jsonlEntry with the isMeta flag Claude Code sets on
+// entries it injects itself (e.g. local command caveats).
+type transcriptEntry struct {
+	jsonlEntry
+	IsMeta bool `json:"isMeta"`
+}
+
+// BuildTranscript reads a session JSONL file into a Transcript. The session
+// ID is taken from the file name.
+func BuildTranscript(jsonlFilepath string) (*Transcript, error) {
+	t := &Transcript{
+		SessionID: strings.TrimSuffix(filepath.Base(jsonlFilepath), ".jsonl"),
+		Messages:  []TranscriptMessage{},
+	}
+	pendingCalls := make(map[string]*TranscriptToolCall)
+
+	err := ScanJSONLLines(jsonlFilepath, func(line []byte) error {
+		var entry transcriptEntry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.IsMeta {
+			return nil
+		}
+		if entry.Type != "user" && entry.Type != "assistant" {
+			return nil
+		}
+		var msg apiMessage
+		if err := json.Unmarshal(entry.Message, &msg); err != nil {
+			return nil
+		}
+
+		var textContent string
+		if err := json.Unmarshal(msg.Content, &textContent); err == nil {
+			if textContent != "" {
+				t.Messages = append(t.Messages, TranscriptMessage{Role: entry.Type, Timestamp: entry.Timestamp, Text: textContent})
+			}
+			return nil
+		}
+		var blocks []contentBlock
+		if err := json.Unmarshal(msg.Content, &blocks); err != nil {
+			return nil
+		}
+
+		message := TranscriptMessage{Role: entry.Type, Timestamp: entry.Timestamp}
+		var texts []string
+		for _, b := range blocks {
+			switch b.Type {
+			case "text":
+				if b.Text != "" {
+					texts = append(texts, b.Text)
+				}
+			case "tool_use":
+				call := &TranscriptToolCall{
+					Name:    b.Name,
+					Summary: strings.TrimPrefix(formatToolCall(b.Name, b.Input), "  > "),
+					Input:   b.Input,
+				}
+				message.ToolCalls = append(message.ToolCalls, call)
+				if b.ID != "" {
+					pendingCalls[b.ID] = call
+				}
+			case "tool_result":
+				if call, ok := pendingCalls[b.ToolUseID]; ok {
+					call.Result = truncate(extractToolResultText(b), maxTranscriptResultLen)
+					call.IsError = b.IsError
+					delete(pendingCalls, b.ToolUseID)
+				}
+			}
+		}
+		message.Text = strings.Join(texts, "\n\n")
+		if message.Text != "" || len(message.ToolCalls) > 0 {
+			t.Messages = append(t.Messages, message)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read session transcript '%s'", jsonlFilepath)
+	}
+	return t, nil
+}
+
+// RenderTranscript writes the transcript in the given format. Markdown and
+// HTML collapse each tool call into a <details> block showing its input and
+// result.
+func RenderTranscript(t *Transcript, format TranscriptFormat, w io.Writer) error {
+	switch format {
+	case TranscriptFormatMarkdown:
+		return renderTranscriptMarkdown(t, w)
+	case TranscriptFormatHTML:
+		return transcriptHTMLTemplate.Execute(w, t)
+	case TranscriptFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(t)
+	default:
+		return stacktrace.NewError("invalid transcript format '%s'; must be '%s', '%s', or '%s'", format, TranscriptFormatMarkdown, TranscriptFormatHTML, TranscriptFormatJSON)
+	}
+}
+
+// transcriptTitle returns the document title, falling back to the session ID.
+func (t *Transcript) transcriptTitle() string {
+	if t.Title != "" {
+		return t.Title
+	}
+	return "Session " + t.SessionID
+}
+
+// roleLabel returns the display name for a message role.
+func roleLabel(role string) string {
+	if role == "assistant" {
+		return "Assistant"
+	}
+	return "User"
+}
+
+// formatToolInput renders a tool call's input as indented JSON.
+func formatToolInput(input map[string]interface{}) string {
+	if len(input) == 0 {
+		return ""
+	}
+	data, err := json.MarshalIndent(input, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return truncate(string(data), maxTranscriptResultLen)
+}
+
+func renderTranscriptMarkdown(t *Transcript, w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", t.transcriptTitle())
+
+	prevRole := ""
+	for _, m := range t.Messages {
+		// Consecutive entries from the same speaker read as one turn
+		if m.Role != prevRole {
+			b.WriteString("\n---\n\n")
+			fmt.Fprintf(&b, "**%s**", roleLabel(m.Role))
+			if m.Timestamp != "" {
+				fmt.Fprintf(&b, " · %s", m.Timestamp)
+			}
+			b.WriteString("\n")
+			prevRole = m.Role
+		}
+		if m.Text != "" {
+			fmt.Fprintf(&b, "\n%s\n", m.Text)
+		}
+		for _, call := range m.ToolCalls {
+			summary := html.EscapeString(call.Summary)
+			if call.IsError {
+				summary += " ⚠️"
+			}
+			fmt.Fprintf(&b, "\n<details><summary><code>%s</code></summary>\n\n", summary)
+			if input := formatToolInput(call.Input); input != "" {
+				writeMarkdownCodeBlock(&b, "json", input)
+			}
+			if call.Result != "" {
+				if call.IsError {
+					b.WriteString("Error:\n\n")
+				} else {
+					b.WriteString("Result:\n\n")
+				}
+				writeMarkdownCodeBlock(&b, "", call.Result)
+			}
+			b.WriteString("</details>\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownCodeBlock writes content as a fenced code block, using a fence
+// longer than any backtick run in the content so it can't close early.
+func writeMarkdownCodeBlock(b *strings.Builder, lang string, content string) {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(b, "%s%s\n%s\n%s\n\n", fence, lang, strings.TrimRight(content, "\n"), fence)
+}
+
+var transcriptHTMLTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"title":     (*Transcript).transcriptTitle,
+	"roleLabel": roleLabel,
+	"toolInput": formatToolInput,
+	"newTurn": func(messages []TranscriptMessage, i int) bool {
+		return i == 0 || messages[i-1].Role != messages[i].Role
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{title .}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 860px; margin: 2em auto; padding: 0 1em; line-height: 1.5; color: #1f2328; }
+.turn { border-top: 1px solid #d0d7de; padding-top: 0.5em; margin-top: 1.5em; }
+.role { font-weight: 600; }
+.role.user { color: #0969da; }
+.role.assistant { color: #8250df; }
+.timestamp { color: #656d76; font-size: 0.85em; margin-left: 0.5em; }
+.text { white-space: pre-wrap; margin: 0.5em 0; }
+details { margin: 0.4em 0; border: 1px solid #d0d7de; border-radius: 6px; padding: 0.3em 0.6em; }
+details.error { border-color: #cf222e; }
+summary { cursor: pointer; font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.9em; }
+pre { background: #f6f8fa; padding: 0.6em; overflow-x: auto; font-size: 0.85em; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>{{title .}}</h1>
+{{- $messages := .Messages}}
+{{- range $i, $m := $messages}}
+{{- if newTurn $messages $i}}
+<div class="turn"><span class="role {{$m.Role}}">{{roleLabel $m.Role}}</span>{{if $m.Timestamp}}<span class="timestamp">{{$m.Timestamp}}</span>{{end}}</div>
+{{- end}}
+{{- if $m.Text}}
+<div class="text">{{$m.Text}}</div>
+{{- end}}
+{{- range $m.ToolCalls}}
+<details{{if .IsError}} class="error"{{end}}><summary>{{.Summary}}</summary>
+{{- with toolInput .Input}}
+<pre>{{.}}</pre>
+{{- end}}
+{{- if .Result}}
+<div>{{if .IsError}}Error:{{else}}Result:{{end}}</div>
+<pre>{{.Result}}</pre>
+{{- end}}
+</details>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
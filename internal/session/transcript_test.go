@@ -0,0 +1,146 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const transcriptTestJSONL = `{"type":"user","message":{"role":"user","content":"List the files"},"timestamp":"2026-03-16T16:06:30Z"}
+{"type":"user","isMeta":true,"message":{"role":"user","content":"<local-command-caveat>ignore</local-command-caveat>"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"thinking","thinking":"hmm"},{"type":"text","text":"Sure, listing <them> now."}]},"timestamp":"2026-03-16T16:06:31Z"}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}]},"timestamp":"2026-03-16T16:06:32Z"}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"a.go\nb.go"}]},"timestamp":"2026-03-16T16:06:33Z"}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_2","name":"Read","input":{"file_path":"/missing"}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_2","is_error":true,"content":[{"type":"text","text":"file not found"}]}]}}
+{"type":"summary","summary":"Listing files"}
+`
+
+func writeTranscriptTestFile(t *testing.T) string {
+	t.Helper()
+	jsonlFilepath := filepath.Join(t.TempDir(), "18749fb5-02ba-4b19-b989-4e18fbf8ea92.jsonl")
+	if err := os.WriteFile(jsonlFilepath, []byte(transcriptTestJSONL), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return jsonlFilepath
+}
+
+func TestBuildTranscript(t *testing.T) {
+	transcript, err := BuildTranscript(writeTranscriptTestFile(t))
+	if err != nil {
+		t.Fatalf("BuildTranscript failed: %v", err)
+	}
+
+	if transcript.SessionID != "18749fb5-02ba-4b19-b989-4e18fbf8ea92" {
+		t.Errorf("SessionID = %q", transcript.SessionID)
+	}
+	// user text, assistant text, two assistant tool calls; meta entries,
+	// thinking, and tool-result-only user entries are dropped
+	if len(transcript.Messages) != 4 {
+		t.Fatalf("expected 4 messages, got %d: %+v", len(transcript.Messages), transcript.Messages)
+	}
+	if transcript.Messages[0].Role != "user" || transcript.Messages[0].Text != "List the files" {
+		t.Errorf("unexpected first message: %+v", transcript.Messages[0])
+	}
+	if transcript.Messages[1].Text != "Sure, listing <them> now." {
+		t.Errorf("unexpected assistant text: %q", transcript.Messages[1].Text)
+	}
+
+	bash := transcript.Messages[2].ToolCalls[0]
+	if bash.Summary != `Bash("ls")` || bash.Result != "a.go\nb.go" || bash.IsError {
+		t.Errorf("unexpected Bash call: %+v", bash)
+	}
+	read := transcript.Messages[3].ToolCalls[0]
+	if read.Result != "file not found" || !read.IsError {
+		t.Errorf("unexpected Read call: %+v", read)
+	}
+}
+
+func TestRenderTranscript_Markdown(t *testing.T) {
+	transcript, err := BuildTranscript(writeTranscriptTestFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transcript.Title = "Mission 2b4c8f1a"
+
+	var buf bytes.Buffer
+	if err := RenderTranscript(transcript, TranscriptFormatMarkdown, &buf); err != nil {
+		t.Fatalf("RenderTranscript failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# Mission 2b4c8f1a\n",
+		"**User** · 2026-03-16T16:06:30Z",
+		"<details><summary><code>Bash(&#34;ls&#34;)</code></summary>",
+		"Result:\n\n```\na.go\nb.go\n```",
+		"Error:\n\n```\nfile not found\n```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+	// The assistant's text and tool calls form one turn
+	if strings.Count(out, "**Assistant**") != 1 {
+		t.Errorf("expected one assistant header:\n%s", out)
+	}
+}
+
+func TestRenderTranscript_HTMLEscapes(t *testing.T) {
+	transcript, err := BuildTranscript(writeTranscriptTestFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderTranscript(transcript, TranscriptFormatHTML, &buf); err != nil {
+		t.Fatalf("RenderTranscript failed: %v", err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "<them>") {
+		t.Error("expected message text to be HTML-escaped")
+	}
+	if !strings.Contains(out, "<title>Session 18749fb5-02ba-4b19-b989-4e18fbf8ea92</title>") {
+		t.Errorf("expected session ID fallback title:\n%s", out)
+	}
+	if !strings.Contains(out, `<details class="error">`) {
+		t.Errorf("expected failed tool call to be marked as an error:\n%s", out)
+	}
+}
+
+func TestRenderTranscript_JSON(t *testing.T) {
+	transcript, err := BuildTranscript(writeTranscriptTestFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderTranscript(transcript, TranscriptFormatJSON, &buf); err != nil {
+		t.Fatalf("RenderTranscript failed: %v", err)
+	}
+	var decoded Transcript
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(decoded.Messages) != 4 || decoded.Messages[2].ToolCalls[0].Name != "Bash" {
+		t.Errorf("unexpected decoded transcript: %+v", decoded)
+	}
+}
+
+func TestRenderTranscript_InvalidFormat(t *testing.T) {
+	if err := RenderTranscript(&Transcript{}, "pdf", &bytes.Buffer{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestWriteMarkdownCodeBlock_LongerFence(t *testing.T) {
+	var b strings.Builder
+	writeMarkdownCodeBlock(&b, "", "```go\nx\n```")
+	if !strings.HasPrefix(b.String(), "````\n") {
+		t.Errorf("expected a four-backtick fence, got:\n%s", b.String())
+	}
+}
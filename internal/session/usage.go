@@ -0,0 +1,158 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TokenUsage holds cumulative token counts reported by the Anthropic API in
+// assistant message `usage` blocks.
+type TokenUsage struct {
+	InputTokens         int64
+	OutputTokens        int64
+	CacheReadTokens     int64
+	CacheCreationTokens int64
+}
+
+// Total returns the sum of all token categories.
+func (u TokenUsage) Total() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheReadTokens + u.CacheCreationTokens
+}
+
+// usageEntry is the subset of a JSONL assistant entry needed to tally usage.
+type usageEntry struct {
+	Type    string `json:"type"`
+	Message struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// UsageTracker incrementally tallies token usage across every session JSONL
+// file belonging to a mission. Each call to Update reads only the bytes
+// appended since the previous call. Claude Code writes one JSONL entry per
+// content block, each repeating the message's usage, so entries are
+// deduplicated by message ID. Alongside the token totals it keeps a running
+// cost estimate priced per message by the model that produced it.
+//
+// UsageTracker is not safe for concurrent use.
+type UsageTracker struct {
+	claudeConfigDirpath string
+	missionID           string
+	offsets             map[string]int64
+	seenMessageIDs      map[string]bool
+	total               TokenUsage
+	costUSD             float64
+}
+
+// NewUsageTracker creates a tracker for the given mission's sessions. The
+// first Update call scans all existing session files in full.
+func NewUsageTracker(claudeConfigDirpath string, missionID string) *UsageTracker {
+	return &UsageTracker{
+		claudeConfigDirpath: claudeConfigDirpath,
+		missionID:           missionID,
+		offsets:             make(map[string]int64),
+		seenMessageIDs:      make(map[string]bool),
+	}
+}
+
+// Update reads newly appended JSONL bytes and returns the cumulative usage
+// across all of the mission's sessions. Unreadable files are skipped and
+// retried on the next call.
+func (t *UsageTracker) Update() TokenUsage {
+	projectDirpath := findProjectDirpath(t.claudeConfigDirpath, t.missionID)
+	if projectDirpath == "" {
+		return t.total
+	}
+
+	entries, err := os.ReadDir(projectDirpath)
+	if err != nil {
+		return t.total
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		t.scanFile(filepath.Join(projectDirpath, entry.Name()))
+	}
+	return t.total
+}
+
+// scanFile consumes complete lines appended to jsonlFilepath since the last
+// recorded offset. A trailing partial line is left for the next call.
+func (t *UsageTracker) scanFile(jsonlFilepath string) {
+	file, err := os.Open(jsonlFilepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	offset := t.offsets[jsonlFilepath]
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return
+	}
+
+	lastNewline := bytes.LastIndexByte(data, '\n')
+	if lastNewline < 0 {
+		return
+	}
+	complete := data[:lastNewline+1]
+	t.offsets[jsonlFilepath] = offset + int64(len(complete))
+
+	for _, line := range bytes.Split(complete, []byte("\n")) {
+		line = trimLineEnding(line)
+		if len(line) == 0 || !bytes.Contains(line, []byte(`"usage"`)) {
+			continue
+		}
+		t.addLine(line)
+	}
+}
+
+func (t *UsageTracker) addLine(line []byte) {
+	var entry usageEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return
+	}
+	if entry.Type != "assistant" || entry.Message.Usage == nil {
+		return
+	}
+	if entry.Message.ID != "" {
+		if t.seenMessageIDs[entry.Message.ID] {
+			return
+		}
+		t.seenMessageIDs[entry.Message.ID] = true
+	}
+
+	usage := TokenUsage{
+		InputTokens:         entry.Message.Usage.InputTokens,
+		OutputTokens:        entry.Message.Usage.OutputTokens,
+		CacheReadTokens:     entry.Message.Usage.CacheReadInputTokens,
+		CacheCreationTokens: entry.Message.Usage.CacheCreationInputTokens,
+	}
+	t.total.InputTokens += usage.InputTokens
+	t.total.OutputTokens += usage.OutputTokens
+	t.total.CacheReadTokens += usage.CacheReadTokens
+	t.total.CacheCreationTokens += usage.CacheCreationTokens
+	t.costUSD += EstimateCostUSD(entry.Message.Model, usage)
+}
+
+// EstimatedCostUSD returns the estimated cost of all usage tallied by the
+// most recent Update call (see EstimateCostUSD).
+func (t *UsageTracker) EstimatedCostUSD() float64 {
+	return t.costUSD
+}
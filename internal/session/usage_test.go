@@ -0,0 +1,97 @@
+package session
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUsageTracker_DedupesAndReadsIncrementally(t *testing.T) {
+	tmpDir := t.TempDir()
+	missionID := "usage-mission-123"
+	projectDirpath := filepath.Join(tmpDir, "projects", "project-"+missionID)
+	if err := os.MkdirAll(projectDirpath, 0755); err != nil {
+		t.Fatalf("failed to create project dir: %v", err)
+	}
+
+	jsonlFilepath := filepath.Join(projectDirpath, "session.jsonl")
+	// Two entries for the same message ID (one per content block) must only be counted once
+	initial := `{"type":"user","message":{"role":"user","content":"hi"}}
+{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":10,"output_tokens":5,"cache_read_input_tokens":100,"cache_creation_input_tokens":7}}}
+{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":10,"output_tokens":5,"cache_read_input_tokens":100,"cache_creation_input_tokens":7}}}
+`
+	if err := os.WriteFile(jsonlFilepath, []byte(initial), 0644); err != nil {
+		t.Fatalf("failed to write JSONL: %v", err)
+	}
+
+	tracker := NewUsageTracker(tmpDir, missionID)
+	got := tracker.Update()
+	want := TokenUsage{InputTokens: 10, OutputTokens: 5, CacheReadTokens: 100, CacheCreationTokens: 7}
+	if got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	// Append a new message plus a partial trailing line that must not be consumed yet
+	f, err := os.OpenFile(jsonlFilepath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open JSONL: %v", err)
+	}
+	f.WriteString(`{"type":"assistant","message":{"id":"msg_2","usage":{"input_tokens":1,"output_tokens":2}}}` + "\n")
+	f.WriteString(`{"type":"assistant","message":{"id":"msg_3","usage":{"input_tok`)
+	f.Close()
+
+	got = tracker.Update()
+	if got.InputTokens != 11 || got.OutputTokens != 7 {
+		t.Errorf("expected input=11 output=7 after append, got %+v", got)
+	}
+	if got.Total() != 11+7+100+7 {
+		t.Errorf("unexpected total %d", got.Total())
+	}
+}
+
+func TestUsageTracker_EstimatesCostPerModel(t *testing.T) {
+	tmpDir := t.TempDir()
+	missionID := "cost-mission-123"
+	projectDirpath := filepath.Join(tmpDir, "projects", "project-"+missionID)
+	if err := os.MkdirAll(projectDirpath, 0755); err != nil {
+		t.Fatalf("failed to create project dir: %v", err)
+	}
+
+	// One million output tokens on Sonnet ($15) plus one million cache-read
+	// tokens on Opus 4.1 ($1.50)
+	content := `{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-5-20250929","usage":{"output_tokens":1000000}}}
+{"type":"assistant","message":{"id":"msg_2","model":"claude-opus-4-1-20250805","usage":{"cache_read_input_tokens":1000000}}}
+`
+	if err := os.WriteFile(filepath.Join(projectDirpath, "session.jsonl"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write JSONL: %v", err)
+	}
+
+	tracker := NewUsageTracker(tmpDir, missionID)
+	tracker.Update()
+	if got, want := tracker.EstimatedCostUSD(), 16.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected cost $%.4f, got $%.4f", want, got)
+	}
+}
+
+func TestLookupModelPrice(t *testing.T) {
+	tests := []struct {
+		model      string
+		wantInput  float64
+		wantOutput float64
+	}{
+		{"claude-opus-4-20250514", 15, 75},
+		{"claude-opus-4-1-20250805", 15, 75},
+		{"claude-opus-4-5-20251101", 5, 25},
+		{"claude-sonnet-4-5-20250929", 3, 15},
+		{"claude-haiku-4-5-20251001", 1, 5},
+		{"", 3, 15},
+		{"some-future-model", 3, 15},
+	}
+	for _, tt := range tests {
+		price := lookupModelPrice(tt.model)
+		if price.inputPerMTok != tt.wantInput || price.outputPerMTok != tt.wantOutput {
+			t.Errorf("lookupModelPrice(%q) = %+v, want input=%v output=%v", tt.model, price, tt.wantInput, tt.wantOutput)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package systemd
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Manager wraps `systemctl --user` operations.
+type Manager struct {
+	timeout time.Duration
+}
+
+// NewManager creates a new Manager with a default timeout of 30 seconds.
+func NewManager() *Manager {
+	return &Manager{
+		timeout: 30 * time.Second,
+	}
+}
+
+// run executes `systemctl --user <args>` and returns its combined output.
+func (m *Manager) run(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "systemctl", append([]string{"--user"}, args...)...)
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
+
+// EnableUnit reloads unit files and enables and starts the unit, so it also
+// starts on every login.
+func (m *Manager) EnableUnit(unitName string) error {
+	if output, err := m.run("daemon-reload"); err != nil {
+		return stacktrace.Propagate(err, "failed to reload systemd user units: %s", output)
+	}
+	if output, err := m.run("enable", "--now", unitName); err != nil {
+		return stacktrace.Propagate(err, "failed to enable unit %s: %s", unitName, output)
+	}
+	return nil
+}
+
+// DisableUnit stops and disables the unit. Disabling a unit that doesn't
+// exist is not an error.
+func (m *Manager) DisableUnit(unitName string) error {
+	output, err := m.run("disable", "--now", unitName)
+	if err != nil {
+		if strings.Contains(output, "does not exist") || strings.Contains(output, "not loaded") {
+			return nil
+		}
+		return stacktrace.Propagate(err, "failed to disable unit %s: %s", unitName, output)
+	}
+	return nil
+}
+
+// ReloadUnits makes systemd forget removed unit files.
+func (m *Manager) ReloadUnits() error {
+	if output, err := m.run("daemon-reload"); err != nil {
+		return stacktrace.Propagate(err, "failed to reload systemd user units: %s", output)
+	}
+	return nil
+}
+
+// StartUnit starts the unit now.
+func (m *Manager) StartUnit(unitName string) error {
+	if output, err := m.run("start", unitName); err != nil {
+		return stacktrace.Propagate(err, "failed to start unit %s: %s", unitName, output)
+	}
+	return nil
+}
+
+// IsActive reports whether the unit is currently running.
+func (m *Manager) IsActive(unitName string) bool {
+	_, err := m.run("is-active", "--quiet", unitName)
+	return err == nil
+}
+
+// VerifySystemctlAvailable checks that a systemd user instance is reachable.
+func VerifySystemctlAvailable() error {
+	cmd := exec.Command("systemctl", "--user", "show-environment")
+	if err := cmd.Run(); err != nil {
+		return stacktrace.NewError("systemctl --user not available (a systemd user session is required)")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package systemd
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Unit represents a systemd user service unit that supervises a
+// long-running process.
+type Unit struct {
+	Description          string
+	ExecStart            []string
+	EnvironmentVariables map[string]string
+	StandardOutPath      string
+	StandardErrorPath    string
+
+	// RestartOnFailure restarts the process whenever it exits unsuccessfully;
+	// a clean exit (status 0) leaves it stopped.
+	RestartOnFailure bool
+}
+
+// UnitFilename returns the unit filename for a service name, e.g.
+// "agenc-server" to "agenc-server.service".
+func UnitFilename(name string) string {
+	return name + ".service"
+}
+
+// GenerateUnitFile renders the unit file contents. The service is wanted by
+// default.target, so enabling it starts it on login.
+func (u *Unit) GenerateUnitFile() []byte {
+	var b strings.Builder
+
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=" + u.Description + "\n")
+	b.WriteString("\n")
+
+	b.WriteString("[Service]\n")
+	b.WriteString("Type=simple\n")
+	quotedArgs := make([]string, 0, len(u.ExecStart))
+	for _, arg := range u.ExecStart {
+		quotedArgs = append(quotedArgs, quoteValue(arg))
+	}
+	b.WriteString("ExecStart=" + strings.Join(quotedArgs, " ") + "\n")
+
+	// Sort keys for deterministic output
+	envKeys := make([]string, 0, len(u.EnvironmentVariables))
+	for k := range u.EnvironmentVariables {
+		envKeys = append(envKeys, k)
+	}
+	sort.Strings(envKeys)
+	for _, k := range envKeys {
+		b.WriteString("Environment=" + quoteValue(k+"="+u.EnvironmentVariables[k]) + "\n")
+	}
+
+	if u.RestartOnFailure {
+		b.WriteString("Restart=on-failure\n")
+		b.WriteString("RestartSec=5\n")
+	}
+	if u.StandardOutPath != "" {
+		b.WriteString("StandardOutput=append:" + escapeSpecifiers(u.StandardOutPath) + "\n")
+	}
+	if u.StandardErrorPath != "" {
+		b.WriteString("StandardError=append:" + escapeSpecifiers(u.StandardErrorPath) + "\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+
+	return []byte(b.String())
+}
+
+// quoteValue double-quotes a value for ExecStart or Environment, escaping
+// backslashes, quotes, and the '%' specifier and '$' variable prefixes
+// systemd would otherwise expand.
+func quoteValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "$", "$$")
+	return `"` + escapeSpecifiers(value) + `"`
+}
+
+// escapeSpecifiers escapes '%' so systemd doesn't read it as a specifier.
+func escapeSpecifiers(value string) string {
+	return strings.ReplaceAll(value, "%", "%%")
+}
+
+// UnitDirpath returns the path to the systemd user unit directory, honoring
+// $XDG_CONFIG_HOME. Returns error if home directory cannot be determined.
+func UnitDirpath() (string, error) {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "systemd", "user"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "cannot determine home directory")
+	}
+	return filepath.Join(homeDir, ".config", "systemd", "user"), nil
+}
@@ -0,0 +1,55 @@
+package systemd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateUnitFile(t *testing.T) {
+	unit := &Unit{
+		Description: "AgenC server",
+		ExecStart:   []string{"/home/me/bin/agenc", "server", "run"},
+		EnvironmentVariables: map[string]string{
+			"PATH":          "/usr/bin:/bin",
+			"AGENC_DIRPATH": `/home/me/my "agenc" 100%`,
+		},
+		StandardOutPath:   "/home/me/.agenc/server/server.log",
+		StandardErrorPath: "/home/me/.agenc/server/server.log",
+		RestartOnFailure:  true,
+	}
+	got := string(unit.GenerateUnitFile())
+
+	for _, want := range []string{
+		"[Unit]\nDescription=AgenC server\n",
+		`ExecStart="/home/me/bin/agenc" "server" "run"` + "\n",
+		// Environment lines are sorted by key, with quotes and specifiers escaped
+		`Environment="AGENC_DIRPATH=/home/me/my \"agenc\" 100%%"` + "\n" + `Environment="PATH=/usr/bin:/bin"` + "\n",
+		"Restart=on-failure\n",
+		"StandardOutput=append:/home/me/.agenc/server/server.log\n",
+		"StandardError=append:/home/me/.agenc/server/server.log\n",
+		"[Install]\nWantedBy=default.target\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected unit file to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestGenerateUnitFile_NoRestart(t *testing.T) {
+	unit := &Unit{Description: "test", ExecStart: []string{"/bin/true"}}
+	if got := string(unit.GenerateUnitFile()); strings.Contains(got, "Restart=") {
+		t.Errorf("expected no Restart= line, got:\n%s", got)
+	}
+}
+
+func TestUnitDirpath_XDGConfigHome(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	got, err := UnitDirpath()
+	if err != nil {
+		t.Fatalf("UnitDirpath returned error: %v", err)
+	}
+	if want := filepath.Join("/tmp/xdg", "systemd", "user"); got != want {
+		t.Errorf("UnitDirpath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+// Package terminal opens AgenC mission windows in terminals other than tmux.
+// Missions always run in the tmux pool session; a terminal backend only
+// opens a tab running a tmux client that shows one of them.
+//
+// The wezterm and zellij backends are compiled in with the build tags of the
+// same name (go build -tags wezterm,zellij).
+package terminal
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+)
+
+// Backend opens tabs in a terminal emulator or multiplexer.
+type Backend interface {
+	// Name is the terminalBackend value that selects the backend.
+	Name() string
+	// IsInside reports whether the current process runs inside the
+	// terminal, which is where OpenTab opens its tab.
+	IsInside() bool
+	// OpenTab opens a new tab titled title running argv.
+	OpenTab(title string, argv []string) error
+}
+
+var backends = map[string]Backend{}
+
+// register makes a backend available to Get. Called from the init of each
+// build-tagged backend file.
+func register(backend Backend) {
+	backends[backend.Name()] = backend
+}
+
+// Get returns the backend named name, or an error if it isn't compiled into
+// this binary.
+func Get(name string) (Backend, error) {
+	if backend, ok := backends[name]; ok {
+		return backend, nil
+	}
+	available := "none"
+	if names := Names(); len(names) > 0 {
+		available = strings.Join(names, ", ")
+	}
+	return nil, stacktrace.NewError(
+		"terminal backend '%s' is not compiled into this agenc binary (available: %s); rebuild with 'go build -tags %s'",
+		name, available, name,
+	)
+}
+
+// Names returns the compiled-in backends, sorted.
+func Names() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,33 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+)
+
+type fakeBackend struct{ name string }
+
+func (b fakeBackend) Name() string                   { return b.name }
+func (b fakeBackend) IsInside() bool                 { return false }
+func (b fakeBackend) OpenTab(string, []string) error { return nil }
+
+func TestGet(t *testing.T) {
+	saved := backends
+	t.Cleanup(func() { backends = saved })
+	backends = map[string]Backend{}
+
+	_, err := Get("wezterm")
+	if err == nil || !strings.Contains(err.Error(), "-tags wezterm") {
+		t.Fatalf("expected a rebuild hint for a backend that isn't compiled in, got %v", err)
+	}
+
+	register(fakeBackend{name: "zellij"})
+	register(fakeBackend{name: "wezterm"})
+	backend, err := Get("wezterm")
+	if err != nil || backend.Name() != "wezterm" {
+		t.Fatalf("expected the wezterm backend, got %v, %v", backend, err)
+	}
+	if names := Names(); strings.Join(names, ",") != "wezterm,zellij" {
+		t.Errorf("expected sorted names, got %v", names)
+	}
+}
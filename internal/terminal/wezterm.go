@@ -0,0 +1,37 @@
+//go:build wezterm
+
+package terminal
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func init() {
+	register(weztermBackend{})
+}
+
+// weztermBackend opens tabs through WezTerm's 'wezterm cli'.
+type weztermBackend struct{}
+
+func (weztermBackend) Name() string { return config.TerminalBackendWezterm }
+
+func (weztermBackend) IsInside() bool { return os.Getenv("WEZTERM_PANE") != "" }
+
+func (weztermBackend) OpenTab(title string, argv []string) error {
+	spawnArgs := append([]string{"cli", "spawn", "--"}, argv...)
+	output, err := exec.Command("wezterm", spawnArgs...).Output()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open a WezTerm tab")
+	}
+	paneID := strings.TrimSpace(string(output))
+	if output, err := exec.Command("wezterm", "cli", "set-tab-title", "--pane-id", paneID, title).CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "failed to set WezTerm tab title: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build zellij
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func init() {
+	register(zellijBackend{})
+}
+
+// zellijBackend opens tabs in the current Zellij session. 'zellij action
+// new-tab' can only run a command through a layout, so one is written to a
+// temporary file per tab.
+type zellijBackend struct{}
+
+func (zellijBackend) Name() string { return config.TerminalBackendZellij }
+
+func (zellijBackend) IsInside() bool { return os.Getenv("ZELLIJ") != "" }
+
+func (zellijBackend) OpenTab(title string, argv []string) error {
+	if len(argv) == 0 {
+		return stacktrace.NewError("no command to run in the Zellij tab")
+	}
+	layoutFile, err := os.CreateTemp("", "agenc-zellij-*.kdl")
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to create Zellij layout file")
+	}
+	defer os.Remove(layoutFile.Name())
+	if _, err := layoutFile.WriteString(zellijLayout(argv)); err != nil {
+		layoutFile.Close()
+		return stacktrace.Propagate(err, "failed to write Zellij layout file")
+	}
+	if err := layoutFile.Close(); err != nil {
+		return stacktrace.Propagate(err, "failed to write Zellij layout file")
+	}
+
+	output, err := exec.Command("zellij", "action", "new-tab", "--layout", layoutFile.Name(), "--name", title).CombinedOutput()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open a Zellij tab: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// zellijLayout returns a KDL layout with a single pane running argv, closed
+// when the command exits.
+func zellijLayout(argv []string) string {
+	var sb strings.Builder
+	sb.WriteString("layout {\n")
+	fmt.Fprintf(&sb, "    pane command=%s close_on_exit=true {\n", kdlString(argv[0]))
+	if len(argv) > 1 {
+		args := make([]string, 0, len(argv)-1)
+		for _, arg := range argv[1:] {
+			args = append(args, kdlString(arg))
+		}
+		fmt.Fprintf(&sb, "        args %s\n", strings.Join(args, " "))
+	}
+	sb.WriteString("    }\n}\n")
+	return sb.String()
+}
+
+// kdlString quotes s as a KDL string.
+func kdlString(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(s) + `"`
+}
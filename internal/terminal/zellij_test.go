@@ -0,0 +1,18 @@
+//go:build zellij
+
+package terminal
+
+import "testing"
+
+func TestZellijLayout(t *testing.T) {
+	got := zellijLayout([]string{"tmux", "-S", `/tmp/a "b"`, "attach-session", "-t", "=agenc-view-1234abcd"})
+	want := `layout {
+    pane command="tmux" close_on_exit=true {
+        args "-S" "/tmp/a \"b\"" "attach-session" "-t" "=agenc-view-1234abcd"
+    }
+}
+`
+	if got != want {
+		t.Errorf("unexpected layout:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+package tmux
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+var (
+	socketPathOnce sync.Once
+	socketPath     string
+)
+
+// SocketPath returns the tmux socket AgenC talks to: multiUser.tmuxSocket in
+// multi-user mode, or the empty string for the user's default tmux server.
+// Resolved from config.yml on first use and fixed for the life of the
+// process, matching the server, which only reads the key at startup. An
+// unreadable config falls back to the default server.
+func SocketPath() string {
+	socketPathOnce.Do(func() {
+		agencDirpath, err := config.GetAgencDirpath()
+		if err != nil {
+			return
+		}
+		cfg, _, err := config.ReadAgencConfig(agencDirpath)
+		if err != nil {
+			return
+		}
+		socketPath = cfg.GetTmuxSocket()
+	})
+	return socketPath
+}
+
+// SetSocketPath overrides the socket SocketPath resolves, for processes that
+// have already read their config. Must be called before any tmux command runs.
+func SetSocketPath(path string) {
+	socketPathOnce.Do(func() {})
+	socketPath = path
+}
+
+// Command returns an exec.Cmd running tmux with args against AgenC's tmux
+// server. Every AgenC tmux invocation goes through here so that the pool
+// session, mission windows, and attached sessions all live on one server.
+func Command(args ...string) *exec.Cmd {
+	return exec.Command("tmux", withSocket(args)...)
+}
+
+// CommandContext is Command with a context that kills tmux when done.
+func CommandContext(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "tmux", withSocket(args)...)
+}
+
+func withSocket(args []string) []string {
+	path := SocketPath()
+	if path == "" {
+		return args
+	}
+	return append([]string{"-S", path}, args...)
+}
+
+// Argv returns the full command line ("tmux" followed by its arguments) that
+// Command would run, for handing to programs that start tmux themselves.
+func Argv(args ...string) []string {
+	return append([]string{"tmux"}, withSocket(args)...)
+}
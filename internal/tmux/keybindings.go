@@ -3,12 +3,12 @@ package tmux
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/mieubrisse/stacktrace"
 
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/secrets"
 )
 
 // agencKeyTable is the tmux key table name used to namespace all AgenC
@@ -200,9 +200,21 @@ func BuildKeybindingsFromCommands(resolved []config.ResolvedPaletteCommand) []Cu
 			comment = fmt.Sprintf("%s — %s (%s)", cmd.Name, cmd.Title, bindingDesc)
 		}
 
+		// Commands with {{input:Label}} placeholders can't run straight from
+		// run-shell; open a small popup that asks for the values first.
+		// Commands referencing secrets go through the palette too, which
+		// resolves them at dispatch so values never land in this file.
+		command := cmd.Command
+		switch {
+		case cmd.HasInputs():
+			command = fmt.Sprintf(`tmux display-popup -E -w 60%% -h 30%% "AGENC_CALLING_MISSION_UUID=$AGENC_CALLING_MISSION_UUID %s tmux palette --run %s"`, agencBinary, cmd.Name)
+		case secrets.HasRefs(cmd.Command):
+			command = fmt.Sprintf("AGENC_CALLING_MISSION_UUID=$AGENC_CALLING_MISSION_UUID %s tmux palette --run %s", agencBinary, cmd.Name)
+		}
+
 		keybindings = append(keybindings, CustomKeybinding{
 			Key:             cmd.TmuxKeybinding,
-			Command:         cmd.Command,
+			Command:         command,
 			Comment:         comment,
 			IsMissionScoped: cmd.IsMissionScoped(),
 		})
@@ -242,11 +254,11 @@ func RefreshKeybindings(agencDirpath string) error {
 // Returns silently if no tmux server is running.
 func SourceKeybindings(keybindingsFilepath string) error {
 	// Check if tmux server is running
-	if err := exec.Command("tmux", "list-sessions").Run(); err != nil {
+	if err := Command("list-sessions").Run(); err != nil {
 		return nil
 	}
 
-	if output, err := exec.Command("tmux", "source-file", keybindingsFilepath).CombinedOutput(); err != nil {
+	if output, err := Command("source-file", keybindingsFilepath).CombinedOutput(); err != nil {
 		return stacktrace.Propagate(err, "failed to source keybindings into running tmux server\n%s", strings.TrimSpace(string(output)))
 	}
 
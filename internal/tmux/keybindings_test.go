@@ -313,6 +313,52 @@ func TestBuildKeybindingsFromCommands_GlobalKeyComment(t *testing.T) {
 	}
 }
 
+func TestBuildKeybindingsFromCommands_InputPlaceholdersOpenPrompt(t *testing.T) {
+	resolved := []config.ResolvedPaletteCommand{
+		{
+			Name:           "askRepo",
+			Command:        "agenc mission new {{input:Repo}} --prompt {{input:Prompt}}",
+			TmuxKeybinding: "r",
+		},
+	}
+
+	keybindings := BuildKeybindingsFromCommands(resolved)
+
+	if len(keybindings) != 1 {
+		t.Fatalf("expected 1 keybinding, got %d", len(keybindings))
+	}
+	kb := keybindings[0]
+	if !strings.Contains(kb.Command, "display-popup") || !strings.Contains(kb.Command, "tmux palette --run askRepo") {
+		t.Errorf("expected the keybinding to open the palette prompt for askRepo, got: %s", kb.Command)
+	}
+	if strings.Contains(kb.Command, "{{input:") {
+		t.Errorf("expected placeholders not to reach run-shell, got: %s", kb.Command)
+	}
+}
+
+func TestBuildKeybindingsFromCommands_SecretRefsResolvedAtDispatch(t *testing.T) {
+	resolved := []config.ResolvedPaletteCommand{
+		{
+			Name:           "deploy",
+			Command:        "DEPLOY_TOKEN=secret://DEPLOY_TOKEN ./deploy.sh",
+			TmuxKeybinding: "D",
+		},
+	}
+
+	keybindings := BuildKeybindingsFromCommands(resolved)
+
+	if len(keybindings) != 1 {
+		t.Fatalf("expected 1 keybinding, got %d", len(keybindings))
+	}
+	kb := keybindings[0]
+	if !strings.Contains(kb.Command, "tmux palette --run deploy") || strings.Contains(kb.Command, "display-popup") {
+		t.Errorf("expected the keybinding to dispatch deploy through the palette without a popup, got: %s", kb.Command)
+	}
+	if strings.Contains(kb.Command, "secret://") {
+		t.Errorf("expected secret references not to reach run-shell, got: %s", kb.Command)
+	}
+}
+
 func TestGenerateKeybindingsContent_OutputRedirect(t *testing.T) {
 	logFilepath := "/tmp/test/palette.log"
 
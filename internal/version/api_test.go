@@ -0,0 +1,86 @@
+package version
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckAPIVersion(t *testing.T) {
+	tests := []struct {
+		header  string
+		wantErr bool
+	}{
+		{"", false},
+		{"garbage", false},
+		{"1", true},
+		{"2", false},
+		{"3", false},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.header != "" {
+			h.Set(APIVersionHeader, tt.header)
+		}
+		err := CheckAPIVersion(h, "the peer", 2, "upgrade it")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckAPIVersion with header %q: got err=%v, wantErr %v", tt.header, err, tt.wantErr)
+		}
+	}
+
+	h := http.Header{}
+	h.Set(APIVersionHeader, "1")
+	err := CheckAPIVersion(h, "the peer", 2, "upgrade it")
+	var apiErr *IncompatibleAPIError
+	if !errors.As(err, &apiErr) || apiErr.PeerVersion != 1 || apiErr.MinVersion != 2 {
+		t.Fatalf("expected an IncompatibleAPIError for v1 < v2, got %v", err)
+	}
+	if want := "the peer speaks API v1, but v2 or newer is required; upgrade it"; err.Error() != want {
+		t.Errorf("expected message %q, got %q", want, err.Error())
+	}
+}
+
+func TestAPIVersionTransport(t *testing.T) {
+	var gotHeader string
+	peerVersion := ""
+	peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get(APIVersionHeader)
+		if peerVersion != "" {
+			w.Header().Set(APIVersionHeader, peerVersion)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer peer.Close()
+
+	client := &http.Client{Transport: &APIVersionTransport{
+		Base:           http.DefaultTransport,
+		Version:        3,
+		Peer:           "the server",
+		MinPeerVersion: 2,
+		Remedy:         "restart it",
+	}}
+
+	for _, v := range []string{"", "2", "3"} {
+		peerVersion = v
+		resp, err := client.Get(peer.URL)
+		if err != nil {
+			t.Fatalf("peer version %q: expected the request to succeed, got %v", v, err)
+		}
+		resp.Body.Close()
+		if gotHeader != "3" {
+			t.Errorf("expected the request to carry API version 3, got %q", gotHeader)
+		}
+	}
+
+	peerVersion = "1"
+	_, err := client.Get(peer.URL)
+	var apiErr *IncompatibleAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an IncompatibleAPIError from a v1 peer, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "restart it") {
+		t.Errorf("expected the remedy in the error, got %q", err.Error())
+	}
+}
@@ -0,0 +1,88 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compare orders two release versions such as "1.4.0" or "v1.5.0-beta.2" by
+// semantic versioning precedence, returning -1, 0, or +1. ok is false when
+// either is not a release version, like a development build's "unknown".
+func Compare(a string, b string) (result int, ok bool) {
+	aCore, aPre, aOK := parseRelease(a)
+	bCore, bPre, bOK := parseRelease(b)
+	if !aOK || !bOK {
+		return 0, false
+	}
+	for i := range aCore {
+		if aCore[i] != bCore[i] {
+			return compareInts(aCore[i], bCore[i]), true
+		}
+	}
+
+	// A prerelease sorts before its release; two prereleases compare by
+	// their dot-separated identifiers
+	switch {
+	case aPre == "" && bPre == "":
+		return 0, true
+	case aPre == "":
+		return 1, true
+	case bPre == "":
+		return -1, true
+	}
+	aIDs, bIDs := strings.Split(aPre, "."), strings.Split(bPre, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if c := comparePrereleaseIdentifiers(aIDs[i], bIDs[i]); c != 0 {
+			return c, true
+		}
+	}
+	return compareInts(len(aIDs), len(bIDs)), true
+}
+
+// parseRelease splits a version into its MAJOR.MINOR.PATCH numbers and
+// prerelease suffix, ignoring a leading "v" and any "+build" metadata.
+func parseRelease(v string) ([3]int, string, bool) {
+	var core [3]int
+	v = strings.TrimPrefix(v, "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+
+	parts := strings.Split(v, ".")
+	if len(parts) != len(core) {
+		return core, "", false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return core, "", false
+		}
+		core[i] = n
+	}
+	return core, pre, true
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers numerically and
+// others lexically, with numeric ones sorting first.
+func comparePrereleaseIdentifiers(a string, b string) int {
+	aNum, aErr := strconv.Atoi(a)
+	bNum, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInts(aNum, bNum)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
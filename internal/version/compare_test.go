@@ -0,0 +1,39 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.4.0", "1.4.0", 0},
+		{"v1.4.0", "1.4.0", 0},
+		{"1.4.0", "1.5.0", -1},
+		{"1.10.0", "1.9.3", 1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.5.0-beta.1", "1.5.0", -1},
+		{"1.5.0", "1.5.0-rc.1", 1},
+		{"1.5.0-beta.2", "1.5.0-beta.10", -1},
+		{"1.5.0-beta", "1.5.0-beta.1", -1},
+		{"1.5.0-alpha.1", "1.5.0-beta.1", -1},
+		{"1.5.0-beta.1", "1.4.9", 1},
+		{"1.4.0+build.7", "1.4.0", 0},
+	}
+	for _, tt := range tests {
+		got, ok := Compare(tt.a, tt.b)
+		if !ok || got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, %v; want %d", tt.a, tt.b, got, ok, tt.want)
+		}
+	}
+}
+
+func TestCompare_NotRelease(t *testing.T) {
+	for _, v := range []string{"unknown", "", "1.4", "1.4.x", "dev-abc123"} {
+		if _, ok := Compare(v, "1.4.0"); ok {
+			t.Errorf("expected %q not to compare as a release", v)
+		}
+	}
+}
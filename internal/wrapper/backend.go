@@ -0,0 +1,238 @@
+package wrapper
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// IdleSignal is how the wrapper learns that a backend's agent has finished
+// its turn and is waiting for input.
+type IdleSignal int
+
+const (
+	// IdleSignalHooks means the agent reports each turn through the hooks
+	// AgenC installs in its config (Claude Code's Stop and UserPromptSubmit).
+	IdleSignalHooks IdleSignal = iota
+	// IdleSignalNone means the agent reports nothing. The wrapper treats it
+	// as idle for its whole run, so queued reloads are never held back.
+	IdleSignalNone
+)
+
+// AgentBackend starts the agent CLI a mission runs. Interactive spawns are
+// attached to the wrapper's terminal; every returned command is started and
+// the caller must Wait on it. Devcontainer and sandbox spawns exist only for
+// Claude, so they stay in the wrapper rather than behind this interface.
+type AgentBackend interface {
+	// Name is the name missions and repos select the backend with.
+	Name() string
+	// SpawnInteractive starts a new conversation, with prompt pre-filled
+	// when non-empty.
+	SpawnInteractive(prompt string) (*exec.Cmd, error)
+	// Resume continues the mission's last conversation, submitting prompt
+	// when non-empty, or starts a new one when there is nothing to resume.
+	Resume(prompt string) (*exec.Cmd, error)
+	// SpawnHeadless runs prompt without a terminal, writing all output to
+	// output. isResume continues the last conversation where supported.
+	SpawnHeadless(prompt string, isResume bool, output io.Writer) (*exec.Cmd, error)
+	// IdleSignal reports how the agent signals idleness.
+	IdleSignal() IdleSignal
+}
+
+// resolveBackend returns the backend for this mission: the one it was created
+// with, else its repo's.
+func (w *Wrapper) resolveBackend() (AgentBackend, error) {
+	name := config.ReadMissionBackend(w.agencDirpath, w.missionID)
+	cfg, _, err := config.ReadAgencConfig(w.agencDirpath)
+	if err != nil {
+		if name == "" || name == config.AgentBackendClaude {
+			return &claudeBackend{w: w}, nil
+		}
+		return nil, stacktrace.Propagate(err, "failed to read config for agent backend '%s'", name)
+	}
+	if name == "" {
+		name = cfg.GetBackend(w.gitRepoName)
+	}
+
+	if name == config.AgentBackendClaude {
+		return &claudeBackend{w: w}, nil
+	}
+	templates, ok := cfg.GetAgentBackendTemplate(name)
+	if !ok {
+		return nil, stacktrace.NewError("unknown agent backend '%s'; available: %s", name, strings.Join(cfg.AgentBackendNames(), ", "))
+	}
+	return &commandBackend{
+		name:           name,
+		templates:      templates,
+		missionID:      w.missionID,
+		workDirpath:    w.workDirpath,
+		scratchDirpath: w.scratchDirpath,
+		start:          w.startAgentCmd,
+	}, nil
+}
+
+// isClaudeBackend reports whether the mission runs Claude Code, which gets
+// the Claude-specific setup (OAuth token, per-mission claude-config, MCP
+// credential sync, containers).
+func (w *Wrapper) isClaudeBackend() bool {
+	return w.backend == nil || w.backend.Name() == config.AgentBackendClaude
+}
+
+// claudeBackend runs Claude Code on the host. It reads the wrapper's model,
+// args, and (for headless runs) sandbox state at spawn time.
+type claudeBackend struct {
+	w *Wrapper
+}
+
+func (b *claudeBackend) Name() string { return config.AgentBackendClaude }
+
+func (b *claudeBackend) IdleSignal() IdleSignal { return IdleSignalHooks }
+
+func (b *claudeBackend) SpawnInteractive(prompt string) (*exec.Cmd, error) {
+	w := b.w
+	cmd, err := mission.BuildClaudeCmdWithPrompt(w.agencDirpath, w.missionID, w.workDirpath, w.defaultModel, w.interactiveClaudeArgs(), prompt)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.startAgentCmd(cmd); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to start claude")
+	}
+	return cmd, nil
+}
+
+func (b *claudeBackend) Resume(prompt string) (*exec.Cmd, error) {
+	w := b.w
+	sessionID := claudeconfig.GetLastSessionID(w.agencDirpath, w.missionID)
+	if sessionID == "" || !claudeconfig.ProjectDirectoryExists(w.workDirpath) {
+		return b.SpawnInteractive(prompt)
+	}
+	cmd, err := mission.BuildClaudeResumeCmdWithSession(w.agencDirpath, w.missionID, w.workDirpath, w.defaultModel, w.interactiveClaudeArgs(), sessionID, prompt)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.startAgentCmd(cmd); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to start claude resume")
+	}
+	return cmd, nil
+}
+
+func (b *claudeBackend) SpawnHeadless(prompt string, isResume bool, output io.Writer) (*exec.Cmd, error) {
+	cmd, err := b.w.buildHeadlessClaudeCmd(prompt, isResume)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := b.w.startAgentCmd(cmd); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to start headless claude")
+	}
+	return cmd, nil
+}
+
+// commandBackend runs an agent CLI from the argv templates of a built-in or
+// agentBackends backend, with AGENC_MISSION_UUID and
+// AGENC_MISSION_SCRATCH_DIRPATH set.
+type commandBackend struct {
+	name           string
+	templates      config.AgentBackendConfig
+	missionID      string
+	workDirpath    string
+	scratchDirpath string
+
+	// start starts a built command; the wrapper's startAgentCmd, which
+	// applies the network jail. Nil starts it directly.
+	start func(*exec.Cmd) error
+}
+
+func (b *commandBackend) Name() string { return b.name }
+
+func (b *commandBackend) IdleSignal() IdleSignal { return IdleSignalNone }
+
+func (b *commandBackend) SpawnInteractive(prompt string) (*exec.Cmd, error) {
+	return b.startAttached(b.templates.Interactive, prompt)
+}
+
+func (b *commandBackend) Resume(prompt string) (*exec.Cmd, error) {
+	if len(b.templates.Resume) == 0 {
+		return b.SpawnInteractive(prompt)
+	}
+	return b.startAttached(b.templates.Resume, prompt)
+}
+
+// SpawnHeadless always starts a new run; command templates have no separate
+// headless resume.
+func (b *commandBackend) SpawnHeadless(prompt string, isResume bool, output io.Writer) (*exec.Cmd, error) {
+	if len(b.templates.Headless) == 0 {
+		return nil, stacktrace.NewError("agent backend '%s' has no headless command", b.name)
+	}
+	cmd, err := b.command(b.templates.Headless, prompt)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := b.startCmd(cmd); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to start headless %s", b.name)
+	}
+	return cmd, nil
+}
+
+func (b *commandBackend) startAttached(template []string, prompt string) (*exec.Cmd, error) {
+	cmd, err := b.command(template, prompt)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := b.startCmd(cmd); err != nil {
+		return nil, stacktrace.Propagate(err, "failed to start %s", b.name)
+	}
+	return cmd, nil
+}
+
+func (b *commandBackend) startCmd(cmd *exec.Cmd) error {
+	if b.start == nil {
+		return cmd.Start()
+	}
+	return b.start(cmd)
+}
+
+func (b *commandBackend) command(template []string, prompt string) (*exec.Cmd, error) {
+	args := expandBackendTemplate(template, prompt)
+	if len(args) == 0 {
+		return nil, stacktrace.NewError("agent backend '%s' has an empty command", b.name)
+	}
+	binary, err := exec.LookPath(args[0])
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "'%s' binary for agent backend '%s' not found in PATH", args[0], b.name)
+	}
+	cmd := exec.Command(binary, args[1:]...)
+	cmd.Dir = b.workDirpath
+	cmd.Env = append(os.Environ(),
+		config.MissionUUIDEnvVar+"="+b.missionID,
+		config.MissionScratchDirpathEnvVar+"="+b.scratchDirpath,
+	)
+	return cmd, nil
+}
+
+// expandBackendTemplate substitutes prompt for the prompt placeholder in
+// template. An argument that is exactly the placeholder is dropped when
+// prompt is empty, so "agent {{prompt}}" starts without a first message.
+func expandBackendTemplate(template []string, prompt string) []string {
+	args := make([]string, 0, len(template))
+	for _, arg := range template {
+		if arg == config.AgentBackendPromptPlaceholder && prompt == "" {
+			continue
+		}
+		args = append(args, strings.ReplaceAll(arg, config.AgentBackendPromptPlaceholder, prompt))
+	}
+	return args
+}
@@ -0,0 +1,105 @@
+package wrapper
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestExpandBackendTemplate(t *testing.T) {
+	template := []string{"agent", "--model", "x", "{{prompt}}"}
+	if got, want := expandBackendTemplate(template, "fix the bug"), []string{"agent", "--model", "x", "fix the bug"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := expandBackendTemplate(template, ""), []string{"agent", "--model", "x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the bare placeholder dropped without a prompt, got %v", got)
+	}
+	if got := expandBackendTemplate([]string{"agent", "--message={{prompt}}"}, "hi"); got[1] != "--message=hi" {
+		t.Errorf("expected the placeholder replaced inside an argument, got %v", got)
+	}
+}
+
+func TestCommandBackend_SpawnHeadless(t *testing.T) {
+	workDirpath := t.TempDir()
+	backend := &commandBackend{
+		name: "echoer",
+		templates: config.AgentBackendConfig{
+			Interactive: []string{"sh"},
+			Headless:    []string{"sh", "-c", `echo "$AGENC_MISSION_UUID $AGENC_MISSION_SCRATCH_DIRPATH $(pwd) {{prompt}}"`},
+		},
+		missionID:      "mission-id",
+		workDirpath:    workDirpath,
+		scratchDirpath: "/scratch",
+	}
+
+	var output bytes.Buffer
+	cmd, err := backend.SpawnHeadless("hello", false, &output)
+	if err != nil {
+		t.Fatalf("SpawnHeadless failed: %v", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("headless command failed: %v", err)
+	}
+	resolvedWorkDirpath, _ := filepath.EvalSymlinks(workDirpath)
+	if got := strings.TrimSpace(output.String()); got != "mission-id /scratch "+resolvedWorkDirpath+" hello" {
+		t.Errorf("expected the mission ID, scratch directory, working directory, and prompt, got %q", got)
+	}
+
+	backend.templates.Headless = nil
+	if _, err := backend.SpawnHeadless("hello", false, &output); err == nil {
+		t.Error("expected an error for a backend without a headless command")
+	}
+}
+
+func TestResolveBackend(t *testing.T) {
+	agencDirpath := t.TempDir()
+	missionID := "11111111-2222-3333-4444-555555555555"
+	if err := os.MkdirAll(filepath.Join(agencDirpath, config.ConfigDirname), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configYAML := "repoConfig:\n  github.com/owner/repo:\n    backend: codex\n"
+	if err := os.WriteFile(config.GetConfigFilepath(agencDirpath), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(config.GetMissionDirpath(agencDirpath, missionID), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	resolve := func(gitRepoName string) AgentBackend {
+		t.Helper()
+		w := &Wrapper{agencDirpath: agencDirpath, missionID: missionID, gitRepoName: gitRepoName}
+		backend, err := w.resolveBackend()
+		if err != nil {
+			t.Fatalf("resolveBackend failed: %v", err)
+		}
+		return backend
+	}
+
+	if backend := resolve(""); backend.Name() != config.AgentBackendClaude || backend.IdleSignal() != IdleSignalHooks {
+		t.Errorf("expected claude by default, got %s", backend.Name())
+	}
+	if backend := resolve("github.com/owner/repo"); backend.Name() != config.AgentBackendCodex || backend.IdleSignal() != IdleSignalNone {
+		t.Errorf("expected the repo's codex backend, got %s", backend.Name())
+	}
+
+	// The mission's own backend wins over its repo's
+	if err := os.WriteFile(config.GetMissionBackendFilepath(agencDirpath, missionID), []byte("claude\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if backend := resolve("github.com/owner/repo"); backend.Name() != config.AgentBackendClaude {
+		t.Errorf("expected the mission's claude backend, got %s", backend.Name())
+	}
+
+	if err := os.WriteFile(config.GetMissionBackendFilepath(agencDirpath, missionID), []byte("gemini\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := &Wrapper{agencDirpath: agencDirpath, missionID: missionID}
+	if _, err := w.resolveBackend(); err == nil {
+		t.Error("expected an error for an unknown backend")
+	}
+}
@@ -0,0 +1,140 @@
+package wrapper
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// missionBudget holds a mission's limits, set at creation with --max-prompts
+// and --budget-usd. Zero means no limit.
+type missionBudget struct {
+	maxPrompts int
+	budgetUSD  float64
+}
+
+// isSet reports whether the mission has any limit.
+func (b missionBudget) isSet() bool {
+	return b.maxPrompts > 0 || b.budgetUSD > 0
+}
+
+// exhaustedReason returns why the mission must stop, or "" while it is within
+// its limits. The spend limit applies immediately; the prompt limit only once
+// Claude is idle, so the last allowed prompt gets a complete answer.
+func (b missionBudget) exhaustedReason(promptCount int, costUSD float64, claudeIdle bool) string {
+	if b.budgetUSD > 0 && costUSD >= b.budgetUSD {
+		return fmt.Sprintf("estimated spend $%.2f reached the $%.2f budget", costUSD, b.budgetUSD)
+	}
+	if b.maxPrompts > 0 && promptCount >= b.maxPrompts && claudeIdle {
+		return fmt.Sprintf("reached the %d-prompt limit", b.maxPrompts)
+	}
+	return ""
+}
+
+// statusline formats usage against the limits for Claude's statusline, e.g.
+// "$1.23 / $5.00 · 3/10 prompts".
+func (b missionBudget) statusline(promptCount int, costUSD float64) string {
+	costPart := fmt.Sprintf("$%.2f", costUSD)
+	if b.budgetUSD > 0 {
+		costPart += fmt.Sprintf(" / $%.2f", b.budgetUSD)
+	}
+	promptPart := fmt.Sprintf("%d prompts", promptCount)
+	if b.maxPrompts > 0 {
+		promptPart = fmt.Sprintf("%d/%d prompts", promptCount, b.maxPrompts)
+	}
+	return strings.Join([]string{costPart, promptPart}, " · ")
+}
+
+// loadBudget fetches the mission's limits and its prompt count so far from
+// the server. A failure leaves the mission unlimited: budgets are a guard
+// rail, and a server hiccup at startup shouldn't block the mission.
+func (w *Wrapper) loadBudget() {
+	missionRecord, err := w.client.GetMission(w.missionID)
+	if err != nil {
+		w.logger.Warn("Failed to load mission budget", "error", err)
+		return
+	}
+
+	w.budgetMu.Lock()
+	defer w.budgetMu.Unlock()
+	w.budget = missionBudget{maxPrompts: missionRecord.MaxPrompts, budgetUSD: missionRecord.BudgetUSD}
+	w.budgetPromptCount = missionRecord.PromptCount
+	if w.budget.isSet() {
+		w.logger.Info("Mission budget loaded",
+			"max_prompts", w.budget.maxPrompts,
+			"budget_usd", w.budget.budgetUSD,
+			"prompt_count", w.budgetPromptCount,
+		)
+	}
+}
+
+// budgetStatusline returns the mission's usage against its limits for the
+// statusline's budget segment, or "" when it has no limits.
+func (w *Wrapper) budgetStatusline() string {
+	w.budgetMu.Lock()
+	defer w.budgetMu.Unlock()
+	if !w.budget.isSet() {
+		return ""
+	}
+	return w.budget.statusline(w.budgetPromptCount, w.budgetCostUSD)
+}
+
+// hasBudget reports whether the mission has any limit to enforce.
+func (w *Wrapper) hasBudget() bool {
+	w.budgetMu.Lock()
+	defer w.budgetMu.Unlock()
+	return w.budget.isSet()
+}
+
+// countBudgetPrompt records a submitted prompt against the prompt limit.
+func (w *Wrapper) countBudgetPrompt() {
+	w.budgetMu.Lock()
+	defer w.budgetMu.Unlock()
+	w.budgetPromptCount++
+}
+
+// enforceBudget refreshes the statusline with the latest usage and, the
+// first time a limit is exhausted, asks the main loop to stop Claude. costUSD
+// is the mission's cumulative estimated spend; pass a negative value to keep
+// the last known figure.
+func (w *Wrapper) enforceBudget(costUSD float64, claudeIdle bool) {
+	w.budgetMu.Lock()
+	if !w.budget.isSet() {
+		w.budgetMu.Unlock()
+		return
+	}
+	if costUSD >= 0 {
+		w.budgetCostUSD = costUSD
+	}
+	reason := w.budget.exhaustedReason(w.budgetPromptCount, w.budgetCostUSD, claudeIdle)
+	w.budgetMu.Unlock()
+
+	w.refreshStatusline()
+
+	if reason == "" || !w.budgetTripped.CompareAndSwap(false, true) {
+		return
+	}
+	w.logger.Warn("Mission budget exhausted", "reason", reason)
+	select {
+	case w.budgetExhausted <- reason:
+	default:
+	}
+}
+
+// stopClaudeForBudget gracefully stops an interactive Claude whose budget is
+// exhausted: SIGTERM now, SIGKILL if it is still running after the shutdown
+// period. The main loop's handleClaudeExit then reports the exit and tells
+// the user why Claude stopped.
+func (w *Wrapper) stopClaudeForBudget(reason string) {
+	w.budgetStopReason = reason
+	if w.claudeCmd == nil || w.claudeCmd.Process == nil {
+		return
+	}
+	process := w.claudeCmd.Process
+	_ = process.Signal(syscall.SIGTERM)
+	w.budgetKillTimer = time.AfterFunc(headlessShutdownPeriod, func() {
+		w.logger.Warn("Claude did not exit after budget stop, sending SIGKILL")
+		_ = process.Kill()
+	})
+}
@@ -0,0 +1,96 @@
+package wrapper
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestMissionBudget_ExhaustedReason(t *testing.T) {
+	tests := []struct {
+		name        string
+		budget      missionBudget
+		promptCount int
+		costUSD     float64
+		claudeIdle  bool
+		wantSubstr  string
+	}{
+		{name: "no limits", budget: missionBudget{}, promptCount: 100, costUSD: 100, claudeIdle: true},
+		{name: "under both limits", budget: missionBudget{maxPrompts: 5, budgetUSD: 2}, promptCount: 4, costUSD: 1.99, claudeIdle: true},
+		{name: "spend reached while busy", budget: missionBudget{budgetUSD: 2}, costUSD: 2, wantSubstr: "$2.00 budget"},
+		{name: "prompt limit waits for idle", budget: missionBudget{maxPrompts: 5}, promptCount: 5},
+		{name: "prompt limit reached when idle", budget: missionBudget{maxPrompts: 5}, promptCount: 5, claudeIdle: true, wantSubstr: "5-prompt limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.budget.exhaustedReason(tt.promptCount, tt.costUSD, tt.claudeIdle)
+			if tt.wantSubstr == "" {
+				if got != "" {
+					t.Errorf("expected no reason, got %q", got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.wantSubstr) {
+				t.Errorf("expected reason containing %q, got %q", tt.wantSubstr, got)
+			}
+		})
+	}
+}
+
+func TestMissionBudget_Statusline(t *testing.T) {
+	if got, want := (missionBudget{maxPrompts: 10, budgetUSD: 5}).statusline(3, 1.234), "$1.23 / $5.00 · 3/10 prompts"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := (missionBudget{budgetUSD: 5}).statusline(3, 0.5), "$0.50 / $5.00 · 3 prompts"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestEnforceBudget_WritesStatuslineAndTripsOnce(t *testing.T) {
+	agencDirpath := t.TempDir()
+	missionID := "2b4c8f1a-0000-0000-0000-000000000000"
+	if err := os.MkdirAll(config.GetMissionDirpath(agencDirpath, missionID), 0755); err != nil {
+		t.Fatalf("failed to create mission dir: %v", err)
+	}
+
+	w := &Wrapper{
+		agencDirpath:    agencDirpath,
+		missionID:       missionID,
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		budget:          missionBudget{budgetUSD: 1},
+		budgetExhausted: make(chan string, 1),
+	}
+
+	w.enforceBudget(0.25, false)
+	got, err := os.ReadFile(filepath.Join(config.GetMissionDirpath(agencDirpath, missionID), config.StatuslineMessageFilename))
+	if err != nil {
+		t.Fatalf("statusline message not written: %v", err)
+	}
+	if strings.TrimSpace(string(got)) != "$0.25 / $1.00 · 0 prompts" {
+		t.Errorf("unexpected statusline message %q", got)
+	}
+	select {
+	case reason := <-w.budgetExhausted:
+		t.Fatalf("budget tripped early: %s", reason)
+	default:
+	}
+
+	w.enforceBudget(1.5, false)
+	w.enforceBudget(2, false)
+	select {
+	case <-w.budgetExhausted:
+	default:
+		t.Fatal("expected the budget to trip")
+	}
+	select {
+	case reason := <-w.budgetExhausted:
+		t.Fatalf("budget tripped twice: %s", reason)
+	default:
+	}
+}
@@ -0,0 +1,34 @@
+package wrapper
+
+import "time"
+
+// endBusyPeriod closes the busy period in progress, returning when it
+// started. Returns ok=false when Claude was not busy.
+func (w *Wrapper) endBusyPeriod() (startedAt time.Time, ok bool) {
+	if w.busySince.IsZero() {
+		return time.Time{}, false
+	}
+	startedAt = w.busySince
+	w.busySince = time.Time{}
+	return startedAt, true
+}
+
+// flushBusyPeriod records the busy period in progress when Claude exits or
+// the wrapper shuts down mid-turn, so the time spent isn't lost.
+func (w *Wrapper) flushBusyPeriod() {
+	if startedAt, ok := w.endBusyPeriod(); ok {
+		w.recordBusyPeriod(startedAt, time.Now())
+	}
+}
+
+// recordBusyPeriod reports a span Claude spent working to the server, which
+// backs `agenc report`. Best-effort: a failed report only leaves the time out
+// of reports.
+func (w *Wrapper) recordBusyPeriod(startedAt time.Time, endedAt time.Time) {
+	if endedAt.Sub(startedAt) < time.Second {
+		return
+	}
+	if err := w.client.RecordBusyPeriod(w.missionID, startedAt, endedAt); err != nil {
+		w.logger.Warn("Failed to record busy period", "error", err)
+	}
+}
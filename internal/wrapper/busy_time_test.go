@@ -0,0 +1,30 @@
+package wrapper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEndBusyPeriod(t *testing.T) {
+	w := &Wrapper{}
+	if _, ok := w.endBusyPeriod(); ok {
+		t.Fatal("expected no busy period while idle")
+	}
+
+	startedAt := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	w.busySince = startedAt
+	got, ok := w.endBusyPeriod()
+	if !ok || !got.Equal(startedAt) {
+		t.Fatalf("expected the open period starting at %v, got %v (ok=%v)", startedAt, got, ok)
+	}
+	if !w.busySince.IsZero() {
+		t.Error("expected the busy period to be cleared once ended")
+	}
+	if _, ok := w.endBusyPeriod(); ok {
+		t.Error("expected a period to be ended only once")
+	}
+
+	// Sub-second periods are dropped without reaching the server (the nil
+	// client would panic)
+	w.recordBusyPeriod(startedAt, startedAt.Add(500*time.Millisecond))
+}
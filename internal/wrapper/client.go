@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/version"
 )
 
 // ErrWrapperNotRunning is returned when the wrapper socket does not exist or
@@ -25,14 +27,21 @@ type WrapperClient struct {
 
 // NewWrapperClient creates a new client that connects to the wrapper at the
 // given socket path. The timeout controls both the dial timeout and the overall
-// HTTP request timeout.
+// HTTP request timeout. A wrapper too old for this client fails requests with
+// a *version.IncompatibleAPIError.
 func NewWrapperClient(socketFilepath string, timeout time.Duration) *WrapperClient {
 	return &WrapperClient{
 		httpClient: &http.Client{
-			Transport: &http.Transport{
-				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
-					return net.DialTimeout("unix", socketFilepath, timeout)
+			Transport: &version.APIVersionTransport{
+				Base: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						return net.DialTimeout("unix", socketFilepath, timeout)
+					},
 				},
+				Version:        version.WrapperAPIVersion,
+				Peer:           "the mission's wrapper",
+				MinPeerVersion: version.MinWrapperAPIVersion,
+				Remedy:         "reload the mission ('agenc mission reload <id>') so its wrapper runs the current agenc version",
 			},
 			Timeout: timeout,
 		},
@@ -42,8 +51,7 @@ func NewWrapperClient(socketFilepath string, timeout time.Duration) *WrapperClie
 }
 
 // SendClaudeUpdate sends a claude_update event to the wrapper.
-func (c *WrapperClient) SendClaudeUpdate(event, notificationType string) error {
-	req := ClaudeUpdateRequest{Event: event, NotificationType: notificationType}
+func (c *WrapperClient) SendClaudeUpdate(req ClaudeUpdateRequest) error {
 	cmdResp, err := c.postCommand("/claude-update", req)
 	if err != nil {
 		return err
@@ -79,6 +87,10 @@ func (c *WrapperClient) postCommand(path string, body any) (*CommandResponse, er
 		if isConnectionError(err) {
 			return nil, ErrWrapperNotRunning
 		}
+		var apiErr *version.IncompatibleAPIError
+		if errors.As(err, &apiErr) {
+			return nil, apiErr
+		}
 		return nil, stacktrace.Propagate(err, "failed to connect to wrapper")
 	}
 	defer resp.Body.Close()
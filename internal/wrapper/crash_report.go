@@ -0,0 +1,99 @@
+package wrapper
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+const (
+	// crashReportPaneLines is how many lines of pane history a crash report
+	// keeps.
+	crashReportPaneLines = 200
+
+	// lastErrorMaxLen caps the pane line quoted in a mission's last_error.
+	lastErrorMaxLen = 200
+)
+
+// writeCrashReport records Claude's last words after an unexpected exit: the
+// tail of the pane, where Claude's stdout and stderr both land, plus the exit
+// code and error. Returns the summary to store as the mission's last_error.
+// Best-effort: a report that cannot be written is logged and the summary is
+// still returned.
+func (w *Wrapper) writeCrashReport(exitCode int, exitErr error) string {
+	paneOutput := captureOwnPane(crashReportPaneLines)
+	report := buildCrashReport(w.missionID, w.backend.Name(), exitCode, exitErr, paneOutput, time.Now())
+
+	reportFilepath := config.GetMissionCrashReportFilepath(w.agencDirpath, w.missionID)
+	if err := os.WriteFile(reportFilepath, []byte(report), 0644); err != nil {
+		w.logger.Warn("Failed to write crash report", "path", reportFilepath, "error", err)
+	} else {
+		w.logger.Info("Wrote crash report", "path", reportFilepath)
+	}
+	return summarizeCrash(w.backend.Name(), exitCode, paneOutput)
+}
+
+// captureOwnPane returns the last lines of output in the wrapper's tmux pane,
+// joined across soft wraps, or "" outside tmux.
+func captureOwnPane(lines int) string {
+	paneID := os.Getenv("TMUX_PANE")
+	if os.Getenv("TMUX") == "" || paneID == "" {
+		return ""
+	}
+	out, err := tmux.Command("capture-pane", "-p", "-J", "-t", paneID, "-S", fmt.Sprintf("-%d", lines)).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(string(out), "\n ")
+}
+
+// buildCrashReport formats the crash report file.
+func buildCrashReport(missionID string, backendName string, exitCode int, exitErr error, paneOutput string, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Mission:    %s\n", missionID)
+	fmt.Fprintf(&b, "Time:       %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Agent:      %s\n", backendName)
+	fmt.Fprintf(&b, "Exit code:  %d\n", exitCode)
+	if exitErr != nil {
+		fmt.Fprintf(&b, "Exit error: %v\n", exitErr)
+	}
+	b.WriteString("\n--- Pane output (stdout and stderr) ---\n")
+	if paneOutput == "" {
+		b.WriteString("(not captured: not running in tmux)\n")
+	} else {
+		b.WriteString(paneOutput)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// summarizeCrash returns a one-line description of the crash for
+// last_error: the exit code and the last non-blank line of pane output,
+// which is usually the error the agent printed before exiting.
+func summarizeCrash(backendName string, exitCode int, paneOutput string) string {
+	summary := fmt.Sprintf("%s exited with code %d", backendName, exitCode)
+	lastLine := lastNonBlankLine(paneOutput)
+	if lastLine == "" {
+		return summary
+	}
+	if runes := []rune(lastLine); len(runes) > lastErrorMaxLen {
+		lastLine = string(runes[:lastErrorMaxLen-1]) + "…"
+	}
+	return summary + ": " + lastLine
+}
+
+// lastNonBlankLine returns the last line of s with non-space content,
+// trimmed.
+func lastNonBlankLine(s string) string {
+	lines := strings.Split(s, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
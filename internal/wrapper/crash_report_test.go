@@ -0,0 +1,49 @@
+package wrapper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizeCrash(t *testing.T) {
+	tests := []struct {
+		name       string
+		paneOutput string
+		want       string
+	}{
+		{"no pane output", "", "claude exited with code 1"},
+		{"last non-blank line is quoted", "Working...\nError: ENOSPC: no space left on device\n\n   \n", "claude exited with code 1: Error: ENOSPC: no space left on device"},
+		{"long line is truncated", strings.Repeat("x", 300), "claude exited with code 1: " + strings.Repeat("x", lastErrorMaxLen-1) + "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeCrash("claude", 1, tt.paneOutput); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildCrashReport(t *testing.T) {
+	now := time.Date(2026, 6, 1, 15, 4, 5, 0, time.UTC)
+	report := buildCrashReport("mission-1", "claude", 137, errors.New("signal: killed"), "last words", now)
+
+	for _, want := range []string{
+		"Mission:    mission-1",
+		"Time:       2026-06-01T15:04:05Z",
+		"Exit code:  137",
+		"Exit error: signal: killed",
+		"--- Pane output (stdout and stderr) ---\nlast words\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+
+	uncaptured := buildCrashReport("mission-1", "claude", 1, nil, "", now)
+	if strings.Contains(uncaptured, "Exit error:") || !strings.Contains(uncaptured, "(not captured") {
+		t.Errorf("unexpected report without pane output:\n%s", uncaptured)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/odyssey/agenc/internal/claudeconfig"
 	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/server"
 )
 
 const (
@@ -19,15 +20,14 @@ const (
 	credentialDownwardDebouncePeriod = 1 * time.Second
 )
 
-// initCredentialHash reads the current per-mission Keychain entry and caches
+// initCredentialHash reads the current per-mission credential entry and caches
 // its hash. Called after cloneCredentials (at initial spawn and after restarts).
 // Runs on the main goroutine before the sync goroutines start, so no mutex
 // needed at init time.
 func (w *Wrapper) initCredentialHash() {
 	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID)
-	serviceName := claudeconfig.ComputeCredentialServiceName(claudeConfigDirpath)
 
-	cred, err := claudeconfig.ReadKeychainCredentials(serviceName)
+	cred, err := claudeconfig.ReadMissionCredentials(claudeConfigDirpath)
 	if err != nil {
 		w.logger.Warn("Failed to read per-mission credentials for hash init", "error", err)
 		return
@@ -44,9 +44,9 @@ func (w *Wrapper) initCredentialHash() {
 	w.credentialHashMu.Unlock()
 }
 
-// watchCredentialUpwardSync polls the per-mission Keychain entry every 60
+// watchCredentialUpwardSync polls the per-mission credential entry every 60
 // seconds. When the credential hash changes (e.g. after an MCP OAuth flow),
-// it merges the fresh credentials into the global Keychain and broadcasts the
+// it merges the fresh credentials into the global credential entry and broadcasts the
 // change via the global-credentials-expiry file so other running missions can
 // pull the update.
 func (w *Wrapper) watchCredentialUpwardSync(ctx context.Context) {
@@ -54,7 +54,6 @@ func (w *Wrapper) watchCredentialUpwardSync(ctx context.Context) {
 	defer ticker.Stop()
 
 	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID)
-	perMissionServiceName := claudeconfig.ComputeCredentialServiceName(claudeConfigDirpath)
 
 	for {
 		select {
@@ -64,17 +63,17 @@ func (w *Wrapper) watchCredentialUpwardSync(ctx context.Context) {
 			if w.rebuilding.Load() {
 				continue
 			}
-			w.checkUpwardSync(perMissionServiceName)
+			w.checkUpwardSync(claudeConfigDirpath)
 		}
 	}
 }
 
-// checkUpwardSync reads the per-mission Keychain, compares its hash to the
+// checkUpwardSync reads the per-mission credential entry, compares its hash to the
 // cached value, and merges to global if changed. Only broadcasts when the
-// global Keychain was actually updated, to avoid spurious watcher events in
+// global credential entry was actually updated, to avoid spurious watcher events in
 // other missions' downward sync watchers.
-func (w *Wrapper) checkUpwardSync(perMissionServiceName string) {
-	perMissionCred, err := claudeconfig.ReadKeychainCredentials(perMissionServiceName)
+func (w *Wrapper) checkUpwardSync(claudeConfigDirpath string) {
+	perMissionCred, err := claudeconfig.ReadMissionCredentials(claudeConfigDirpath)
 	if err != nil {
 		w.logger.Warn("Upward sync: failed to read per-mission credentials", "error", err)
 		return
@@ -96,7 +95,7 @@ func (w *Wrapper) checkUpwardSync(perMissionServiceName string) {
 
 	w.logger.Info("Upward sync: per-mission credentials changed, merging to global")
 
-	globalCred, err := claudeconfig.ReadKeychainCredentials(claudeconfig.GlobalCredentialServiceName)
+	globalCred, err := claudeconfig.ReadGlobalCredentials()
 	if err != nil {
 		w.logger.Warn("Upward sync: failed to read global credentials", "error", err)
 		return
@@ -119,11 +118,11 @@ func (w *Wrapper) checkUpwardSync(perMissionServiceName string) {
 		return
 	}
 
-	if err := claudeconfig.WriteKeychainCredentials(claudeconfig.GlobalCredentialServiceName, string(merged)); err != nil {
-		w.logger.Warn("Upward sync: failed to write merged credentials to global Keychain", "error", err)
+	if err := claudeconfig.WriteGlobalCredentials(string(merged)); err != nil {
+		w.logger.Warn("Upward sync: failed to write merged credentials to global credential entry", "error", err)
 		return
 	}
-	w.logger.Info("Upward sync: propagated per-mission credential changes to global Keychain")
+	w.logger.Info("Upward sync: propagated per-mission credential changes to global credential entry")
 
 	// Broadcast using the current Unix timestamp so other missions can detect the change.
 	broadcastTimestamp := float64(time.Now().UnixNano()) / 1e9
@@ -132,12 +131,16 @@ func (w *Wrapper) checkUpwardSync(perMissionServiceName string) {
 	if err := os.WriteFile(expiryFilepath, []byte(broadcastStr), 0644); err != nil {
 		w.logger.Warn("Upward sync: failed to write credentials broadcast file", "error", err)
 	}
+
+	if err := w.client.PublishEvent(server.EventCredentialRefreshed, w.missionID, nil); err != nil {
+		w.logger.Warn("Upward sync: failed to publish credential event", "error", err)
+	}
 }
 
 // watchCredentialDownwardSync watches the global-credentials-expiry file.
 // When another mission's upward sync updates global credentials, this mission
-// pulls the fresh credentials into its per-mission Keychain entry.
-// No restart is triggered — Claude reads MCP OAuth tokens from Keychain per-request.
+// pulls the fresh credentials into its per-mission credential entry.
+// No restart is triggered — Claude reads MCP OAuth tokens from the credential store per-request.
 func (w *Wrapper) watchCredentialDownwardSync(ctx context.Context) {
 	expiryFilepath := config.GetGlobalCredentialsExpiryFilepath(w.agencDirpath)
 
@@ -194,7 +197,7 @@ func (w *Wrapper) watchCredentialDownwardSync(ctx context.Context) {
 
 // handleDownwardSync reads the broadcast timestamp, skips if not newer than
 // the last sync, then pulls fresh global credentials and merges them into
-// the per-mission Keychain entry.
+// the per-mission credential entry.
 func (w *Wrapper) handleDownwardSync(expiryFilepath string) {
 	data, err := os.ReadFile(expiryFilepath)
 	if err != nil {
@@ -215,7 +218,7 @@ func (w *Wrapper) handleDownwardSync(expiryFilepath string) {
 	w.logger.Info("Downward sync: global credentials updated, pulling",
 		"broadcastTimestamp", fileTimestamp, "lastSyncTimestamp", w.lastDownwardSyncTimestamp)
 
-	globalCred, err := claudeconfig.ReadKeychainCredentials(claudeconfig.GlobalCredentialServiceName)
+	globalCred, err := claudeconfig.ReadGlobalCredentials()
 	if err != nil {
 		w.logger.Warn("Downward sync: failed to read global credentials", "error", err)
 		// Update timestamp anyway to avoid thrashing on a bad global entry
@@ -224,9 +227,8 @@ func (w *Wrapper) handleDownwardSync(expiryFilepath string) {
 	}
 
 	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID)
-	perMissionServiceName := claudeconfig.ComputeCredentialServiceName(claudeConfigDirpath)
 
-	perMissionCred, err := claudeconfig.ReadKeychainCredentials(perMissionServiceName)
+	perMissionCred, err := claudeconfig.ReadMissionCredentials(claudeConfigDirpath)
 	if err != nil {
 		w.logger.Warn("Downward sync: failed to read per-mission credentials", "error", err)
 		w.lastDownwardSyncTimestamp = fileTimestamp
@@ -246,8 +248,8 @@ func (w *Wrapper) handleDownwardSync(expiryFilepath string) {
 		return
 	}
 
-	if err := claudeconfig.WriteKeychainCredentials(perMissionServiceName, string(merged)); err != nil {
-		w.logger.Warn("Downward sync: failed to write merged credentials to per-mission Keychain", "error", err)
+	if err := claudeconfig.WriteMissionCredentials(claudeConfigDirpath, string(merged)); err != nil {
+		w.logger.Warn("Downward sync: failed to write merged credentials to per-mission credential entry", "error", err)
 		return
 	}
 
@@ -259,5 +261,5 @@ func (w *Wrapper) handleDownwardSync(expiryFilepath string) {
 		w.credentialHashMu.Unlock()
 	}
 
-	w.logger.Info("Downward sync: merged global credentials into per-mission Keychain")
+	w.logger.Info("Downward sync: merged global credentials into per-mission credential entry")
 }
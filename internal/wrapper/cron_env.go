@@ -0,0 +1,60 @@
+package wrapper
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/secrets"
+)
+
+// applyCronEnv exports the env of the cron that launched this mission into
+// the wrapper's own environment, which every local Claude spawn inherits, and
+// records it in w.cronEnv for devcontainer spawns. secret://
+// references are resolved here, at spawn time, so secret values never touch
+// config.yml or the mission record, and a rotated secret is picked up on the
+// next reload. Missions not launched by a cron are left alone.
+func (w *Wrapper) applyCronEnv() error {
+	missionRecord, err := w.client.GetMission(w.missionID)
+	if err != nil {
+		w.logger.Warn("Failed to load mission for cron env", "error", err)
+		return nil
+	}
+	if missionRecord.Source == nil || *missionRecord.Source != "cron" || missionRecord.SourceID == nil {
+		return nil
+	}
+
+	cfg, _, err := config.ReadAgencConfig(w.agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read config for cron env")
+	}
+	cronName, cronCfg, ok := cfg.GetCronByID(*missionRecord.SourceID)
+	if !ok || len(cronCfg.Env) == 0 {
+		w.cronEnv = nil
+		return nil
+	}
+
+	store, err := secrets.Open(w.agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to open secret store for cron '%s'", cronName)
+	}
+	entries, err := store.ExpandEnv(cronCfg.Env)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve env for cron '%s'", cronName)
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		key, value, _ := strings.Cut(entry, "=")
+		if err := os.Setenv(key, value); err != nil {
+			return stacktrace.Propagate(err, "failed to set env var '%s' for cron '%s'", key, cronName)
+		}
+		keys = append(keys, key)
+	}
+	w.cronEnv = entries
+	// Log names only; values may be secrets
+	w.logger.Info("Applied cron env", "cron", cronName, "vars", strings.Join(keys, ","))
+	return nil
+}
@@ -0,0 +1,126 @@
+package wrapper
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/session"
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+const (
+	// desktopNotificationTimeout bounds the notifier command so a hung
+	// notification daemon can't pile up goroutines.
+	desktopNotificationTimeout = 10 * time.Second
+
+	// desktopNotificationTitleMaxLen caps the mission title shown in a
+	// notification.
+	desktopNotificationTitleMaxLen = 60
+)
+
+// notifyDesktopIfUnfocused sends a desktop notification with message unless
+// desktop notifications are disabled, quiet hours are active, or a tmux
+// client is currently viewing the mission's window. Runs in the background;
+// failures are logged.
+func (w *Wrapper) notifyDesktopIfUnfocused(message string) {
+	if !w.desktopNotifications || w.quietHours.IsActive(time.Now()) {
+		return
+	}
+	go func() {
+		if isWindowFocused() {
+			return
+		}
+		if err := sendDesktopNotification(w.notificationTitle(), message); err != nil {
+			w.logger.Warn("Failed to send desktop notification", "error", err)
+		}
+	}()
+}
+
+// notificationTitle returns the mission's session title, falling back to the
+// first line of its initial prompt and then its short ID.
+func (w *Wrapper) notificationTitle() string {
+	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID)
+	title := session.FindSessionName(claudeConfigDirpath, w.missionID)
+	if title == "" {
+		title, _, _ = strings.Cut(strings.TrimSpace(w.initialPrompt), "\n")
+	}
+	if title == "" {
+		return "AgenC mission " + database.ShortID(w.missionID)
+	}
+	if len(title) > desktopNotificationTitleMaxLen {
+		title = title[:desktopNotificationTitleMaxLen] + "…"
+	}
+	return title
+}
+
+// isWindowFocused reports whether any tmux client is currently showing the
+// window this process runs in. Returns false outside tmux or when tmux can't
+// tell (e.g. versions without #{window_active_clients}), so the user errs
+// towards being notified.
+func isWindowFocused() bool {
+	paneID := os.Getenv("TMUX_PANE")
+	if os.Getenv("TMUX") == "" || paneID == "" {
+		return false
+	}
+	out, err := tmux.Command("display-message", "-p", "-t", paneID, "#{window_active_clients}").Output()
+	if err != nil {
+		return false
+	}
+	count := strings.TrimSpace(string(out))
+	return count != "" && count != "0"
+}
+
+// sendDesktopNotification shows a native notification using the first
+// available notifier for this OS.
+func sendDesktopNotification(title string, message string) error {
+	name, args, err := desktopNotificationCommand(runtime.GOOS, exec.LookPath, title, message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), desktopNotificationTimeout)
+	defer cancel()
+	if output, err := exec.CommandContext(ctx, name, args...).CombinedOutput(); err != nil {
+		return stacktrace.Propagate(err, "%s failed: %s", name, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// desktopNotificationCommand picks the notifier command for goos:
+// terminal-notifier (falling back to osascript) on macOS and notify-send on
+// Linux. lookPath is exec.LookPath, injectable for tests.
+func desktopNotificationCommand(goos string, lookPath func(string) (string, error), title string, message string) (string, []string, error) {
+	switch goos {
+	case "darwin":
+		if path, err := lookPath("terminal-notifier"); err == nil {
+			return path, []string{"-title", "AgenC", "-subtitle", title, "-message", message, "-group", "agenc"}, nil
+		}
+		script := "display notification " + appleScriptQuote(message) +
+			" with title " + appleScriptQuote("AgenC") +
+			" subtitle " + appleScriptQuote(title)
+		return "osascript", []string{"-e", script}, nil
+	case "linux":
+		path, err := lookPath("notify-send")
+		if err != nil {
+			return "", nil, stacktrace.NewError("notify-send not found in PATH; install libnotify to get desktop notifications")
+		}
+		return path, []string{"--app-name=AgenC", title, message}, nil
+	default:
+		return "", nil, stacktrace.NewError("desktop notifications are not supported on %s", goos)
+	}
+}
+
+// appleScriptQuote returns s as an AppleScript string literal.
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,62 @@
+package wrapper
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDesktopNotificationCommand(t *testing.T) {
+	found := func(name string) (string, error) { return "/usr/local/bin/" + name, nil }
+	missing := func(name string) (string, error) { return "", errors.New("not found") }
+
+	tests := []struct {
+		name     string
+		goos     string
+		lookPath func(string) (string, error)
+		wantName string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name:     "macOS with terminal-notifier",
+			goos:     "darwin",
+			lookPath: found,
+			wantName: "/usr/local/bin/terminal-notifier",
+			wantArgs: []string{"-title", "AgenC", "-subtitle", `Fix "login"`, "-message", "Needs your attention", "-group", "agenc"},
+		},
+		{
+			name:     "macOS falls back to osascript",
+			goos:     "darwin",
+			lookPath: missing,
+			wantName: "osascript",
+			wantArgs: []string{"-e", `display notification "Needs your attention" with title "AgenC" subtitle "Fix \"login\""`},
+		},
+		{
+			name:     "linux",
+			goos:     "linux",
+			lookPath: found,
+			wantName: "/usr/local/bin/notify-send",
+			wantArgs: []string{"--app-name=AgenC", `Fix "login"`, "Needs your attention"},
+		},
+		{name: "linux without notify-send", goos: "linux", lookPath: missing, wantErr: true},
+		{name: "unsupported OS", goos: "windows", lookPath: found, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args, err := desktopNotificationCommand(tt.goos, tt.lookPath, `Fix "login"`, "Needs your attention")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name != tt.wantName || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("got %s %q, want %s %q", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -27,6 +27,13 @@ func (w *Wrapper) detectAndSetupDevcontainer() (*devcontainerState, error) {
 	if !found {
 		return nil, nil
 	}
+	if !w.isClaudeBackend() {
+		w.logger.Warn("Ignoring the repo's devcontainer.json; only the claude backend runs in devcontainers", "backend", w.backend.Name())
+		return nil, nil
+	}
+	if w.workDirpath != w.agentDirpath {
+		w.logger.Warn("Mission path is not supported with devcontainers; Claude runs in the container's workspace folder", "path", w.workDirpath)
+	}
 
 	missionDirpath := config.GetMissionDirpath(w.agencDirpath, w.missionID)
 	mergedConfigPath := filepath.Join(missionDirpath, "devcontainer.json")
@@ -45,6 +52,7 @@ func (w *Wrapper) detectAndSetupDevcontainer() (*devcontainerState, error) {
 		AgencDirpath:         w.agencDirpath,
 		HostAgentDirpath:     w.agentDirpath,
 		ClaudeConfigDirpath:  claudeConfigDirpath,
+		HostScratchDirpath:   w.scratchDirpath,
 		WrapperSocketPath:    wrapperSocketPath,
 		OAuthToken:           oauthToken,
 	}
@@ -72,16 +80,20 @@ func devcontainerUp(state *devcontainerState) error {
 }
 
 // devcontainerExecClaude builds an exec.Cmd that runs Claude inside the
-// devcontainer via `devcontainer exec`. The returned command has stdin/stdout/stderr
-// connected to the parent process.
-func devcontainerExecClaude(state *devcontainerState, claudeArgs []string) *exec.Cmd {
+// devcontainer via `devcontainer exec`. remoteEnv entries (KEY=VALUE) are set
+// in the container process, since it doesn't inherit the wrapper's
+// environment. The returned command has stdin/stdout/stderr connected to the
+// parent process.
+func devcontainerExecClaude(state *devcontainerState, remoteEnv []string, claudeArgs []string) *exec.Cmd {
 	args := []string{
 		"exec",
 		"--workspace-folder", state.agentDirpath,
 		"--config", state.mergedConfigPath,
-		"--",
-		"claude",
 	}
+	for _, entry := range remoteEnv {
+		args = append(args, "--remote-env", entry)
+	}
+	args = append(args, "--", "claude")
 	args = append(args, claudeArgs...)
 
 	cmd := exec.Command("devcontainer", args...)
@@ -0,0 +1,58 @@
+package wrapper
+
+import (
+	"time"
+
+	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/server"
+)
+
+// refreshHandoffContext fetches the mission's handoff note and keeps it as
+// context for the next Claude spawn while nobody has prompted the mission
+// since the note was left. Once the user is working in the resumed mission,
+// the conversation carries the context and the note is dropped. Best-effort:
+// when the server can't be reached, Claude starts without it.
+func (w *Wrapper) refreshHandoffContext() {
+	w.handoffContext = ""
+
+	handoff, err := w.client.GetMissionHandoff(w.missionID)
+	if err != nil {
+		w.logger.Warn("Failed to fetch handoff note", "error", err)
+		return
+	}
+	if handoff.Note == "" {
+		return
+	}
+	missionRecord, err := w.client.GetMission(w.missionID)
+	if err != nil {
+		w.logger.Warn("Failed to fetch mission for handoff note", "error", err)
+		return
+	}
+	if !isHandoffPending(handoff, missionRecord.LastUserPromptAt) {
+		return
+	}
+
+	w.handoffContext = mission.BuildHandoffContext(handoff.Note, handoff.CreatedAt)
+	w.logger.Info("Passing handoff note to Claude", "handoff_at", handoff.CreatedAt)
+}
+
+// isHandoffPending reports whether a handoff note was left after the user's
+// last prompt, i.e. it has not been acted on yet.
+func isHandoffPending(handoff *server.MissionHandoffResponse, lastUserPromptAt *time.Time) bool {
+	if handoff == nil || handoff.Note == "" {
+		return false
+	}
+	return lastUserPromptAt == nil || handoff.CreatedAt.After(*lastUserPromptAt)
+}
+
+// interactiveClaudeArgs returns the extra args for an interactive Claude
+// spawn: the configured claudeArgs plus any pending handoff note as an
+// appended system prompt.
+func (w *Wrapper) interactiveClaudeArgs() []string {
+	if w.handoffContext == "" {
+		return w.claudeArgs
+	}
+	args := make([]string, 0, len(w.claudeArgs)+2)
+	args = append(args, w.claudeArgs...)
+	return append(args, "--append-system-prompt", w.handoffContext)
+}
@@ -0,0 +1,45 @@
+package wrapper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/odyssey/agenc/internal/server"
+)
+
+func TestIsHandoffPending(t *testing.T) {
+	archivedAt := time.Date(2026, 6, 1, 22, 0, 0, 0, time.UTC)
+	handoff := &server.MissionHandoffResponse{Note: "tests pending", CreatedAt: archivedAt}
+	before := archivedAt.Add(-time.Hour)
+	after := archivedAt.Add(time.Hour)
+
+	if !isHandoffPending(handoff, nil) {
+		t.Error("expected a note on a never-prompted mission to be pending")
+	}
+	if !isHandoffPending(handoff, &before) {
+		t.Error("expected a note left after the last prompt to be pending")
+	}
+	if isHandoffPending(handoff, &after) {
+		t.Error("expected a note to be dropped once the user prompted the resumed mission")
+	}
+	if isHandoffPending(&server.MissionHandoffResponse{}, nil) {
+		t.Error("expected no pending note when the mission has none")
+	}
+}
+
+func TestInteractiveClaudeArgs(t *testing.T) {
+	w := &Wrapper{claudeArgs: []string{"--verbose"}}
+	if got := w.interactiveClaudeArgs(); !reflect.DeepEqual(got, []string{"--verbose"}) {
+		t.Errorf("expected only the configured args without a handoff, got %v", got)
+	}
+
+	w.handoffContext = "pick up the parser"
+	want := []string{"--verbose", "--append-system-prompt", "pick up the parser"}
+	if got := w.interactiveClaudeArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if !reflect.DeepEqual(w.claudeArgs, []string{"--verbose"}) {
+		t.Errorf("expected the configured args to be left untouched, got %v", w.claudeArgs)
+	}
+}
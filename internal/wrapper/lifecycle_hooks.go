@@ -0,0 +1,93 @@
+package wrapper
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/odyssey/agenc/internal/database"
+)
+
+// Lifecycle hook events, passed to hooks in AGENC_HOOK_EVENT. The names match
+// the lifecycleHooks keys in config.yml.
+const (
+	lifecycleEventMissionStart = "onMissionStart"
+	lifecycleEventClaudeIdle   = "onClaudeIdle"
+	lifecycleEventClaudeBusy   = "onClaudeBusy"
+	lifecycleEventMissionEnd   = "onMissionEnd"
+)
+
+// lifecycleHookTimeout bounds each hook so a hung script can't pile up
+// processes or hold the wrapper open at exit.
+const lifecycleHookTimeout = 30 * time.Second
+
+// lifecycleHookCommand returns the configured command for event, or "" when
+// none is set.
+func (w *Wrapper) lifecycleHookCommand(event string) string {
+	switch event {
+	case lifecycleEventMissionStart:
+		return w.lifecycleHooks.OnMissionStart
+	case lifecycleEventClaudeIdle:
+		return w.lifecycleHooks.OnClaudeIdle
+	case lifecycleEventClaudeBusy:
+		return w.lifecycleHooks.OnClaudeBusy
+	case lifecycleEventMissionEnd:
+		return w.lifecycleHooks.OnMissionEnd
+	default:
+		return ""
+	}
+}
+
+// lifecycleHookEnv returns the AGENC_* variables describing this mission
+// that are added to a hook's environment.
+func (w *Wrapper) lifecycleHookEnv(event string) []string {
+	return []string{
+		"AGENC_HOOK_EVENT=" + event,
+		"AGENC_DIRPATH=" + w.agencDirpath,
+		"AGENC_MISSION_UUID=" + w.missionID,
+		"AGENC_MISSION_SHORT_ID=" + database.ShortID(w.missionID),
+		"AGENC_MISSION_REPO=" + w.gitRepoName,
+		"AGENC_MISSION_DIRPATH=" + w.missionDirpath,
+		"AGENC_MISSION_AGENT_DIRPATH=" + w.agentDirpath,
+		"AGENC_MISSION_SCRATCH_DIRPATH=" + w.scratchDirpath,
+		"AGENC_MISSION_TMUX_PANE=" + w.tmuxPaneID,
+	}
+}
+
+// fireLifecycleHook runs the hook for event in the background. Failures are
+// logged, never propagated: hooks observe the mission but can't affect it.
+func (w *Wrapper) fireLifecycleHook(event string, extraEnv ...string) {
+	if w.lifecycleHookCommand(event) == "" {
+		return
+	}
+	go w.runLifecycleHook(event, extraEnv...)
+}
+
+// runLifecycleHook runs the hook for event via sh -c in the agent directory
+// and waits for it to finish. Used directly for onMissionEnd so the hook
+// completes before the wrapper exits.
+func (w *Wrapper) runLifecycleHook(event string, extraEnv ...string) {
+	command := w.lifecycleHookCommand(event)
+	if command == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lifecycleHookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = w.agentDirpath
+	cmd.Env = append(append(os.Environ(), w.lifecycleHookEnv(event)...), extraEnv...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		w.logger.Warn("Lifecycle hook failed",
+			"event", event,
+			"error", err,
+			"output", strings.TrimSpace(string(output)),
+		)
+		return
+	}
+	w.logger.Info("Lifecycle hook ran", "event", event)
+}
@@ -0,0 +1,61 @@
+package wrapper
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestRunLifecycleHook_PassesMissionEnv(t *testing.T) {
+	agentDirpath := t.TempDir()
+	outFilepath := filepath.Join(t.TempDir(), "hook.out")
+
+	w := &Wrapper{
+		agencDirpath:   "/tmp/agenc",
+		missionID:      "2b4c8f1a-0000-0000-0000-000000000000",
+		gitRepoName:    "github.com/owner/repo",
+		missionDirpath: "/tmp/agenc/missions/2b4c8f1a",
+		agentDirpath:   agentDirpath,
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		lifecycleHooks: config.LifecycleHooksConfig{
+			OnMissionEnd: `printf '%s|%s|%s|%s|%s' "$AGENC_HOOK_EVENT" "$AGENC_MISSION_SHORT_ID" "$AGENC_MISSION_REPO" "$AGENC_EXIT_CODE" "$PWD" > ` + outFilepath,
+		},
+	}
+
+	w.runLifecycleHook(lifecycleEventMissionEnd, "AGENC_EXIT_CODE=3")
+
+	got, err := os.ReadFile(outFilepath)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	fields := strings.Split(string(got), "|")
+	if len(fields) != 5 {
+		t.Fatalf("unexpected hook output %q", got)
+	}
+	want := []string{"onMissionEnd", "2b4c8f1a", "github.com/owner/repo", "3"}
+	for i, v := range want {
+		if fields[i] != v {
+			t.Errorf("field %d = %q, want %q", i, fields[i], v)
+		}
+	}
+	resolvedAgentDirpath, _ := filepath.EvalSymlinks(agentDirpath)
+	if resolved, _ := filepath.EvalSymlinks(fields[4]); resolved != resolvedAgentDirpath {
+		t.Errorf("hook ran in %q, want %q", fields[4], agentDirpath)
+	}
+}
+
+func TestLifecycleHookCommand_UnsetEventIsSkipped(t *testing.T) {
+	w := &Wrapper{lifecycleHooks: config.LifecycleHooksConfig{OnClaudeIdle: "true"}}
+
+	if got := w.lifecycleHookCommand(lifecycleEventClaudeIdle); got != "true" {
+		t.Errorf("onClaudeIdle command = %q, want %q", got, "true")
+	}
+	if got := w.lifecycleHookCommand(lifecycleEventClaudeBusy); got != "" {
+		t.Errorf("onClaudeBusy command = %q, want empty", got)
+	}
+}
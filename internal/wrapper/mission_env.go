@@ -0,0 +1,100 @@
+package wrapper
+
+import (
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/secrets"
+)
+
+// applyMissionEnv exports the repo's repoConfig env, overlaid with the
+// mission's own env overrides, into the wrapper's own environment, which
+// every local Claude spawn inherits, and records it in w.missionEnv for
+// container spawns. The overrides come from the mission record, so reloads
+// and resumes reproduce them; the repo env is re-read so an edit applies on
+// the next reload. Variables dropped since the last spawn get their
+// inherited values back. secret:// references are resolved here, as for
+// cron env.
+func (w *Wrapper) applyMissionEnv() error {
+	missionRecord, err := w.client.GetMission(w.missionID)
+	if err != nil {
+		w.logger.Warn("Failed to load mission for env", "error", err)
+		return nil
+	}
+
+	env := make(map[string]string)
+	if w.gitRepoName != "" {
+		cfg, _, err := config.ReadAgencConfig(w.agencDirpath)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read config for repo env")
+		}
+		if rc, ok := cfg.GetRepoConfig(w.gitRepoName); ok {
+			maps.Copy(env, rc.Env)
+		}
+	}
+	maps.Copy(env, missionRecord.Env)
+
+	var entries []string
+	if len(env) > 0 {
+		store, err := secrets.Open(w.agencDirpath)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to open secret store for mission env")
+		}
+		if entries, err = store.ExpandEnv(env); err != nil {
+			return stacktrace.Propagate(err, "failed to resolve mission env")
+		}
+	}
+	return w.setMissionEnv(entries)
+}
+
+// setMissionEnv replaces the previously applied mission env with entries.
+func (w *Wrapper) setMissionEnv(entries []string) error {
+	w.restoreMissionEnv()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	w.missionEnvPrev = make(map[string]*string, len(entries))
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		key, value, _ := strings.Cut(entry, "=")
+		if prev, ok := os.LookupEnv(key); ok {
+			w.missionEnvPrev[key] = &prev
+		} else {
+			w.missionEnvPrev[key] = nil
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return stacktrace.Propagate(err, "failed to set env var '%s'", key)
+		}
+		keys = append(keys, key)
+	}
+	w.missionEnv = entries
+	// Log names only; values may be secrets
+	w.logger.Info("Applied mission env", "vars", strings.Join(keys, ","))
+	return nil
+}
+
+// restoreMissionEnv undoes applyMissionEnv, restoring the values the
+// variables had before it ran (unsetting those that were unset).
+func (w *Wrapper) restoreMissionEnv() {
+	for key, value := range w.missionEnvPrev {
+		if value == nil {
+			_ = os.Unsetenv(key)
+		} else {
+			_ = os.Setenv(key, *value)
+		}
+	}
+	w.missionEnvPrev = nil
+	w.missionEnv = nil
+}
+
+// containerSpawnEnv returns the KEY=VALUE entries passed explicitly to
+// Claude in a container, where the wrapper's own environment doesn't reach:
+// the mission env, then the cron env.
+func (w *Wrapper) containerSpawnEnv() []string {
+	return append(append([]string{}, w.missionEnv...), w.cronEnv...)
+}
@@ -0,0 +1,54 @@
+package wrapper
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSetMissionEnv_RestoresDroppedVars(t *testing.T) {
+	t.Setenv("AGENC_TEST_INHERITED", "original")
+	t.Setenv("AGENC_TEST_ADDED", "")
+	os.Unsetenv("AGENC_TEST_ADDED")
+
+	w := &Wrapper{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	if err := w.setMissionEnv([]string{"AGENC_TEST_ADDED=1", "AGENC_TEST_INHERITED=override"}); err != nil {
+		t.Fatalf("setMissionEnv failed: %v", err)
+	}
+	if got := os.Getenv("AGENC_TEST_INHERITED"); got != "override" {
+		t.Errorf("expected override, got %q", got)
+	}
+	if got := os.Getenv("AGENC_TEST_ADDED"); got != "1" {
+		t.Errorf("expected added var to be set, got %q", got)
+	}
+
+	// A later spawn without the vars puts the inherited environment back
+	if err := w.setMissionEnv(nil); err != nil {
+		t.Fatalf("setMissionEnv failed: %v", err)
+	}
+	if got := os.Getenv("AGENC_TEST_INHERITED"); got != "original" {
+		t.Errorf("expected inherited value restored, got %q", got)
+	}
+	if _, ok := os.LookupEnv("AGENC_TEST_ADDED"); ok {
+		t.Error("expected added var to be unset again")
+	}
+	if w.missionEnv != nil {
+		t.Errorf("expected no recorded mission env, got %v", w.missionEnv)
+	}
+}
+
+func TestContainerSpawnEnv(t *testing.T) {
+	w := &Wrapper{
+		missionEnv: []string{"A=mission"},
+		cronEnv:    []string{"A=cron", "B=cron"},
+	}
+	want := []string{"A=mission", "A=cron", "B=cron"}
+	if got := w.containerSpawnEnv(); !reflect.DeepEqual(got, want) {
+		t.Errorf("containerSpawnEnv() = %v, want %v", got, want)
+	}
+	if len(w.missionEnv) != 1 {
+		t.Errorf("expected missionEnv to be left untouched, got %v", w.missionEnv)
+	}
+}
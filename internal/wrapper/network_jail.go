@@ -0,0 +1,159 @@
+package wrapper
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+// networkJailCommand is the hidden agenc subcommand that runs an agent inside
+// a network jail. jailNetwork rewrites an agent command to run it as
+//
+//	agenc mission net-jail <proxy-socket> <agent-binary> <agent-args>...
+const networkJailCommand = "mission net-jail"
+
+// RunNetworkJail runs argv inside the network jail the calling process was
+// started in: a network namespace with nothing but loopback, and a mount
+// namespace in which the host's agenc server and tmux sockets are hidden. It
+// brings loopback up, relays a loopback port to the network policy proxy's
+// unix socket, points argv's proxy env at that port, and runs argv attached
+// to the terminal. Returns argv's exit code; a signal death maps to
+// 128 + signal.
+func RunNetworkJail(proxySocketFilepath string, argv []string) (int, error) {
+	if len(argv) == 0 {
+		return 0, stacktrace.NewError("no command to run in the network jail")
+	}
+	if err := bringLoopbackUp(); err != nil {
+		return 0, stacktrace.Propagate(err, "failed to bring up loopback in the network jail")
+	}
+	if err := hideHostSockets(networkJailHiddenPaths()); err != nil {
+		return 0, stacktrace.Propagate(err, "failed to hide host sockets in the network jail")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "failed to listen for the network jail's proxy relay")
+	}
+	defer listener.Close()
+	go relayToUnixSocket(listener, proxySocketFilepath)
+
+	proxyURL := "http://" + listener.Addr().String()
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	for _, key := range networkProxyEnvVars {
+		cmd.Env = append(cmd.Env, key+"="+proxyURL)
+	}
+	for _, key := range networkNoProxyEnvVars {
+		cmd.Env = append(cmd.Env, key+"="+networkNoProxyValue)
+	}
+
+	// Ctrl-C reaches the agent straight from the terminal, which signals the
+	// whole foreground process group; the wrapper's SIGTERM and SIGHUP only
+	// reach this process and are passed on.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	if err := startJailedAgent(cmd); err != nil {
+		return 0, stacktrace.Propagate(err, "failed to start '%s' in the network jail", argv[0])
+	}
+	go func() {
+		for sig := range sigCh {
+			if sig != syscall.SIGINT {
+				_ = cmd.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "failed waiting for '%s' in the network jail", argv[0])
+	}
+	return 0, nil
+}
+
+// networkJailHiddenPaths returns the unix sockets, or directories of them,
+// through which a jailed agent could have an unjailed process send data out
+// for it: the agenc server directory (its socket, and the API tokens for its
+// TCP listener), AgenC's tmux socket, and the user's default tmux socket
+// directory, whose servers would run commands outside the jail. A pathname
+// unix socket ignores network namespaces, so these have to be hidden from the
+// filesystem instead. The mission's own wrapper socket stays reachable for
+// Claude's hooks.
+func networkJailHiddenPaths() []string {
+	var paths []string
+	if agencDirpath, err := config.GetAgencDirpath(); err == nil {
+		paths = append(paths, config.GetServerDirpath(agencDirpath))
+	}
+	if socketPath := tmux.SocketPath(); socketPath != "" {
+		paths = append(paths, socketPath)
+	}
+	tmuxTmpDirpath := os.Getenv("TMUX_TMPDIR")
+	if tmuxTmpDirpath == "" {
+		tmuxTmpDirpath = "/tmp"
+	}
+	paths = append(paths, filepath.Join(tmuxTmpDirpath, "tmux-"+strconv.Itoa(os.Getuid())))
+	if tmuxEnv := os.Getenv("TMUX"); tmuxEnv != "" {
+		// TMUX is "<socket>,<pid>,<session>"
+		paths = append(paths, filepath.Dir(strings.SplitN(tmuxEnv, ",", 2)[0]))
+	}
+	return paths
+}
+
+// relayToUnixSocket copies each connection accepted on listener to a fresh
+// connection to the unix socket at socketFilepath. A pathname unix socket is
+// reachable across network namespaces, which is what lets the jail reach the
+// policy proxy and nothing else.
+func relayToUnixSocket(listener net.Listener, socketFilepath string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			upstream, err := net.Dial("unix", socketFilepath)
+			if err != nil {
+				return
+			}
+			defer upstream.Close()
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, _ = io.Copy(upstream, conn)
+				closeWrite(upstream)
+			}()
+			go func() {
+				defer wg.Done()
+				_, _ = io.Copy(conn, upstream)
+				closeWrite(conn)
+			}()
+			wg.Wait()
+		}()
+	}
+}
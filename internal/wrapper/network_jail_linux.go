@@ -0,0 +1,107 @@
+package wrapper
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+
+	"github.com/mieubrisse/stacktrace"
+	"golang.org/x/sys/unix"
+)
+
+// networkJailSupported reports whether agents can be confined to the network
+// policy proxy on this platform.
+const networkJailSupported = true
+
+// jailNetwork rewrites cmd, which must not have been started, to run through
+// RunNetworkJail in a fresh user, network, and mount namespace. The user
+// namespace maps the caller's own uid and gid, so no root is needed and the
+// agent runs as the same user; it exists to grant CAP_NET_ADMIN and
+// CAP_SYS_ADMIN over the new namespaces, which RunNetworkJail needs to bring
+// loopback up and hide the host's sockets.
+func jailNetwork(cmd *exec.Cmd, proxySocketFilepath string) error {
+	agencBinpath, err := os.Executable()
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to resolve the agenc binary path")
+	}
+
+	args := []string{agencBinpath}
+	args = append(args, strings.Fields(networkJailCommand)...)
+	args = append(args, proxySocketFilepath, cmd.Path)
+	cmd.Args = append(args, cmd.Args[1:]...)
+	cmd.Path = agencBinpath
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET | syscall.CLONE_NEWNS
+	cmd.SysProcAttr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}
+	cmd.SysProcAttr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}
+	cmd.SysProcAttr.GidMappingsEnableSetgroups = false
+	cmd.SysProcAttr.AmbientCaps = append(cmd.SysProcAttr.AmbientCaps, unix.CAP_NET_ADMIN, unix.CAP_SYS_ADMIN)
+	return nil
+}
+
+// bringLoopbackUp sets the IFF_UP flag on lo; a new network namespace starts
+// with it down.
+func bringLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	ifreq, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifreq); err != nil {
+		return err
+	}
+	ifreq.SetUint16(ifreq.Uint16() | unix.IFF_UP)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifreq)
+}
+
+// hideHostSockets covers each of paths in the jail's mount namespace: a
+// directory with an empty tmpfs, anything else with /dev/null. Paths that
+// don't exist are skipped. The mounts stay inside the jail.
+func hideHostSockets(paths []string) error {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			err = unix.Mount("tmpfs", path, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "mode=0700")
+		} else {
+			err = unix.Mount("/dev/null", path, "", unix.MS_BIND, "")
+		}
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to hide '%s'", path)
+		}
+	}
+	return nil
+}
+
+// startJailedAgent starts cmd without the jail's capabilities and so it dies
+// with the jail. It runs in a nested user and mount namespace, which locks
+// the mounts hideHostSockets made: even an agent running as root can't
+// unmount them. Ambient capabilities and the parent-death signal are
+// per-thread, so the thread stays locked: the fork happens on it, and
+// PR_SET_PDEATHSIG fires when it exits.
+func startJailedAgent(cmd *exec.Cmd) error {
+	runtime.LockOSThread()
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil {
+		return stacktrace.Propagate(err, "failed to drop ambient capabilities")
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Pdeathsig:                  syscall.SIGKILL,
+		Cloneflags:                 syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS,
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}},
+		GidMappingsEnableSetgroups: false,
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,117 @@
+package wrapper
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// TestMain lets the test binary stand in for agenc when jailNetwork re-execs
+// os.Executable() as the net-jail subcommand.
+func TestMain(m *testing.M) {
+	subcommand := strings.Fields(networkJailCommand)
+	if len(os.Args) > len(subcommand)+2 && slices.Equal(os.Args[1:1+len(subcommand)], subcommand) {
+		args := os.Args[1+len(subcommand):]
+		exitCode, err := RunNetworkJail(args[0], args[1:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(exitCode)
+	}
+	os.Exit(m.Run())
+}
+
+func TestJailNetwork_OnlyReachesProxy(t *testing.T) {
+	curlBinpath, err := exec.LookPath("curl")
+	if err != nil {
+		t.Skip("curl not available")
+	}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "allowed")
+	}))
+	defer upstream.Close()
+	proxy := startTestNetworkProxy(t, "127.0.0.1")
+
+	// Sockets of unjailed processes: the agenc server, AgenC's multi-user
+	// tmux server, and the user's default tmux server
+	agencDirpath := t.TempDir()
+	tmuxTmpDirpath := t.TempDir()
+	sharedTmuxSocketFilepath := filepath.Join(t.TempDir(), "tmux.sock")
+	t.Setenv("AGENC_DIRPATH", agencDirpath)
+	t.Setenv("TMUX_TMPDIR", tmuxTmpDirpath)
+	t.Setenv("TMUX", "")
+	configDirpath := config.GetConfigDirpath(agencDirpath)
+	if err := os.MkdirAll(configDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	multiUserConfig := "multiUser:\n  tmuxSocket: " + sharedTmuxSocketFilepath + "\n"
+	if err := os.WriteFile(filepath.Join(configDirpath, config.ConfigFilename), []byte(multiUserConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hostSocketFilepaths := []string{
+		config.GetServerSocketFilepath(agencDirpath),
+		sharedTmuxSocketFilepath,
+		filepath.Join(tmuxTmpDirpath, "tmux-"+strconv.Itoa(os.Getuid()), "default"),
+	}
+	for _, socketFilepath := range hostSocketFilepaths {
+		if err := os.MkdirAll(filepath.Dir(socketFilepath), 0700); err != nil {
+			t.Fatal(err)
+		}
+		listener, err := net.Listen("unix", socketFilepath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer listener.Close()
+	}
+
+	// Through the proxy env the upstream is reachable; bypassing the proxy,
+	// as an agent could by unsetting the env, it isn't. Neither are the host
+	// sockets, even after trying to unmount what hides them.
+	script := `curl -sS --max-time 5 --noproxy '' "$1"; echo
+curl -sS --max-time 5 --noproxy '*' "$1" && echo bypassed
+shift
+for socket in "$@"; do
+	umount "$socket" 2>/dev/null; umount "$(dirname "$socket")" 2>/dev/null
+	[ -S "$socket" ] && echo "reachable: $socket"
+done
+true`
+	cmd := exec.Command("/bin/sh", append([]string{"-c", script, "sh", upstream.URL}, hostSocketFilepaths...)...)
+	if err := jailNetwork(cmd, proxy.socketFilepath); err != nil {
+		t.Fatalf("jailNetwork failed: %v", err)
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		t.Skipf("unprivileged user namespaces unavailable: %v", err)
+	}
+	_ = cmd.Wait()
+
+	got := output.String()
+	if !strings.HasPrefix(got, "allowed\n") {
+		t.Errorf("expected the upstream reachable through the proxy from %s, got %q", curlBinpath, got)
+	}
+	if strings.Contains(got, "bypassed") {
+		t.Errorf("expected a direct connection to fail inside the jail, got %q", got)
+	}
+	if strings.Contains(got, "reachable") {
+		t.Errorf("expected the host's server and tmux sockets hidden inside the jail, got %q", got)
+	}
+	for _, socketFilepath := range hostSocketFilepaths {
+		if info, err := os.Stat(socketFilepath); err != nil || info.Mode()&os.ModeSocket == 0 {
+			t.Errorf("expected %s still a socket outside the jail, got %v", socketFilepath, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build !linux
+
+package wrapper
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// networkJailSupported reports whether agents can be confined to the network
+// policy proxy on this platform. Without Linux namespaces, the proxy env is
+// all a network policy has.
+const networkJailSupported = false
+
+var errNetworkJailUnsupported = errors.New("network jails need Linux namespaces")
+
+func jailNetwork(cmd *exec.Cmd, proxySocketFilepath string) error {
+	return errNetworkJailUnsupported
+}
+
+func bringLoopbackUp() error {
+	return errNetworkJailUnsupported
+}
+
+func hideHostSockets(paths []string) error {
+	return errNetworkJailUnsupported
+}
+
+func startJailedAgent(cmd *exec.Cmd) error {
+	return errNetworkJailUnsupported
+}
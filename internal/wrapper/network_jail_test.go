@@ -0,0 +1,47 @@
+package wrapper
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestRelayToUnixSocket(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "relayed")
+	}))
+	defer upstream.Close()
+
+	proxy := startTestNetworkProxy(t, "127.0.0.1")
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go relayToUnixSocket(listener, proxy.socketFilepath)
+
+	relayURL, _ := url.Parse("http://" + listener.Addr().String())
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(relayURL)}}
+	resp, err := client.Get(upstream.URL)
+	if err != nil {
+		t.Fatalf("GET through the relay failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "relayed" {
+		t.Errorf("expected 200 relayed, got %d %q", resp.StatusCode, body)
+	}
+
+	resp, err = client.Get("http://example.com/")
+	if err != nil {
+		t.Fatalf("GET through the relay failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected the proxy's 403 for a host outside the policy, got %d", resp.StatusCode)
+	}
+}
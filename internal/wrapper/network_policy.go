@@ -0,0 +1,308 @@
+package wrapper
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+// networkProxyDialTimeout bounds connecting to an allowed upstream host.
+const networkProxyDialTimeout = 30 * time.Second
+
+// networkProxyEnvVars are pointed at the network policy proxy. Both cases are
+// set since tools disagree on which they read.
+var networkProxyEnvVars = []string{"HTTPS_PROXY", "HTTP_PROXY", "ALL_PROXY", "https_proxy", "http_proxy", "all_proxy"}
+
+// networkNoProxyEnvVars keep local traffic (dev servers, test databases) off
+// the proxy; it never leaves the machine.
+var networkNoProxyEnvVars = []string{"NO_PROXY", "no_proxy"}
+
+// networkNoProxyValue is the NO_PROXY value set while a network policy is
+// active. It replaces any inherited value, which could otherwise exempt
+// arbitrary hosts from the policy.
+const networkNoProxyValue = "localhost,127.0.0.1,::1"
+
+// networkProxy is a local HTTP proxy that only forwards to the hosts a repo's
+// networkPolicy allows. HTTPS goes through CONNECT tunnels, so traffic stays
+// end-to-end encrypted; only the destination host is inspected. It listens on
+// loopback and on a unix socket; the socket is how a network-jailed agent,
+// which has no route to the host's loopback, reaches it.
+type networkProxy struct {
+	listener       net.Listener
+	unixListener   net.Listener
+	socketFilepath string
+	server         *http.Server
+	policy         atomic.Pointer[config.NetworkPolicyConfig]
+	logger         *slog.Logger
+
+	// transport forwards plain-HTTP requests. Its Proxy is nil so the
+	// proxy's own env never loops requests back into it.
+	transport *http.Transport
+}
+
+// startNetworkProxy starts a network policy proxy on a random loopback port
+// and on a unix socket at socketFilepath.
+func startNetworkProxy(policy *config.NetworkPolicyConfig, socketFilepath string, logger *slog.Logger) (*networkProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to listen for the network policy proxy")
+	}
+	// A socket left by a crashed wrapper would make the listen fail
+	_ = os.Remove(socketFilepath)
+	unixListener, err := net.Listen("unix", socketFilepath)
+	if err != nil {
+		listener.Close()
+		return nil, stacktrace.Propagate(err, "failed to listen for the network policy proxy on '%s'", socketFilepath)
+	}
+
+	p := &networkProxy{
+		listener:       listener,
+		unixListener:   unixListener,
+		socketFilepath: socketFilepath,
+		logger:         logger,
+		transport: &http.Transport{
+			DialContext: (&net.Dialer{Timeout: networkProxyDialTimeout}).DialContext,
+		},
+	}
+	p.policy.Store(policy)
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: 30 * time.Second}
+	for _, l := range []net.Listener{listener, unixListener} {
+		go func() {
+			if err := p.server.Serve(l); err != nil && err != http.ErrServerClosed {
+				logger.Error("Network policy proxy stopped", "error", err)
+			}
+		}()
+	}
+	return p, nil
+}
+
+// URL returns the proxy URL to put in HTTPS_PROXY and friends.
+func (p *networkProxy) URL() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+// Close stops the proxy. Open tunnels are cut when their connections are.
+func (p *networkProxy) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = p.server.Shutdown(ctx)
+	p.transport.CloseIdleConnections()
+}
+
+// ServeHTTP tunnels CONNECT requests and forwards absolute-URL HTTP requests
+// to allowed hosts, refusing everything else with 403.
+func (p *networkProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	host := r.Host
+	if r.Method != http.MethodConnect && r.URL.Host != "" {
+		host = r.URL.Host
+	}
+	if !p.policy.Load().AllowsHost(host) {
+		p.logger.Warn("Network policy blocked connection", "host", host, "method", r.Method)
+		http.Error(w, "blocked by agenc networkPolicy: '"+host+"' is not in allowedHosts", http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	if r.URL.Host == "" {
+		http.Error(w, "not a proxy request", http.StatusBadRequest)
+		return
+	}
+	p.forward(w, r)
+}
+
+// tunnel connects the client to the CONNECT target and copies bytes both ways
+// until either side closes.
+func (p *networkProxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	upstream, err := net.DialTimeout("tcp", r.Host, networkProxyDialTimeout)
+	if err != nil {
+		http.Error(w, "failed to connect to '"+r.Host+"': "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "tunneling not supported", http.StatusInternalServerError)
+		return
+	}
+	client, buffered, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err := client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		client.Close()
+		upstream.Close()
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		// Bytes the client sent after the CONNECT request sit in the buffer
+		_, _ = io.Copy(upstream, buffered)
+		closeWrite(upstream)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(client, upstream)
+		closeWrite(client)
+	}()
+	wg.Wait()
+	client.Close()
+	upstream.Close()
+}
+
+// forward relays a plain-HTTP proxy request to its destination.
+func (p *networkProxy) forward(w http.ResponseWriter, r *http.Request) {
+	outReq := r.Clone(r.Context())
+	outReq.RequestURI = ""
+	outReq.Header.Del("Proxy-Connection")
+	outReq.Header.Del("Proxy-Authorization")
+
+	resp, err := p.transport.RoundTrip(outReq)
+	if err != nil {
+		http.Error(w, "failed to reach '"+r.URL.Host+"': "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// closeWrite half-closes conn so the peer sees EOF while replies can still
+// arrive.
+func closeWrite(conn net.Conn) {
+	if halfCloser, ok := conn.(interface{ CloseWrite() error }); ok {
+		_ = halfCloser.CloseWrite()
+		return
+	}
+	_ = conn.Close()
+}
+
+// applyNetworkPolicy starts, updates, or stops the network policy proxy to
+// match the repo's current networkPolicy, and points the wrapper's own
+// environment, which every local spawn inherits, at it. On Linux the agent
+// itself is then started in a network jail (see startAgentCmd), so the proxy
+// is its only way out; elsewhere the proxy env is advisory. Runs before each
+// spawn so a changed allowlist applies on the next reload. Fails closed: a
+// mission whose policy can't be applied doesn't start.
+func (w *Wrapper) applyNetworkPolicy() error {
+	var policy *config.NetworkPolicyConfig
+	windowsClaude := false
+	if w.gitRepoName != "" {
+		cfg, _, err := config.ReadAgencConfig(w.agencDirpath)
+		if err != nil {
+			return stacktrace.Propagate(err, "failed to read config for networkPolicy")
+		}
+		if rc, ok := cfg.GetRepoConfig(w.gitRepoName); ok {
+			policy = rc.NetworkPolicy
+		}
+		windowsClaude = cfg.UsesWindowsClaude()
+	}
+
+	if policy == nil {
+		if w.networkProxy != nil {
+			w.stopNetworkProxy()
+			w.logger.Info("Network policy removed; network access is unrestricted")
+		}
+		return nil
+	}
+
+	if w.devcontainer != nil || w.sandbox != nil {
+		return stacktrace.NewError("networkPolicy is not supported for missions that run in a container")
+	}
+	if windowsClaude && w.isClaudeBackend() {
+		// claude.exe runs on the Windows host, outside any Linux namespace
+		return stacktrace.NewError("networkPolicy is not supported with wsl.windowsClaude")
+	}
+
+	if w.networkProxy == nil {
+		proxy, err := startNetworkProxy(policy, config.GetMissionNetworkProxySocketFilepath(w.agencDirpath, w.missionID), w.logger)
+		if err != nil {
+			return err
+		}
+		w.networkProxy = proxy
+		w.networkProxyPrevEnv = make(map[string]*string)
+		for _, key := range append(networkProxyEnvVars, networkNoProxyEnvVars...) {
+			if value, ok := os.LookupEnv(key); ok {
+				w.networkProxyPrevEnv[key] = &value
+			} else {
+				w.networkProxyPrevEnv[key] = nil
+			}
+		}
+		for _, key := range networkProxyEnvVars {
+			if err := os.Setenv(key, proxy.URL()); err != nil {
+				return stacktrace.Propagate(err, "failed to set %s", key)
+			}
+		}
+		for _, key := range networkNoProxyEnvVars {
+			if err := os.Setenv(key, networkNoProxyValue); err != nil {
+				return stacktrace.Propagate(err, "failed to set %s", key)
+			}
+		}
+	} else {
+		w.networkProxy.policy.Store(policy)
+	}
+	w.logger.Info("Applied network policy", "proxy", w.networkProxy.URL(), "allowed_hosts", policy.AllowedHosts)
+	if !networkJailSupported {
+		w.logger.Warn("Network policy is advisory on this platform: the agent is pointed at the proxy but can bypass it")
+	}
+	return nil
+}
+
+// startAgentCmd starts an agent command built by a backend. While a network
+// policy is active on Linux, the command first moves into a network jail: a
+// fresh user and network namespace whose only way out is the policy proxy.
+// Fails closed when the jail can't be set up.
+func (w *Wrapper) startAgentCmd(cmd *exec.Cmd) error {
+	if w.networkProxy == nil || !networkJailSupported {
+		return cmd.Start()
+	}
+	if err := jailNetwork(cmd, w.networkProxy.socketFilepath); err != nil {
+		return stacktrace.Propagate(err, "failed to set up the network jail for networkPolicy")
+	}
+	if err := cmd.Start(); err != nil {
+		return stacktrace.Propagate(err, "failed to start the agent in a network jail for networkPolicy; this needs unprivileged user namespaces (check kernel.unprivileged_userns_clone and, on Ubuntu, kernel.apparmor_restrict_unprivileged_userns)")
+	}
+	return nil
+}
+
+// stopNetworkProxy shuts down the network policy proxy and restores the
+// proxy env it replaced.
+func (w *Wrapper) stopNetworkProxy() {
+	if w.networkProxy == nil {
+		return
+	}
+	w.networkProxy.Close()
+	w.networkProxy = nil
+	for key, value := range w.networkProxyPrevEnv {
+		if value == nil {
+			_ = os.Unsetenv(key)
+		} else {
+			_ = os.Setenv(key, *value)
+		}
+	}
+	w.networkProxyPrevEnv = nil
+}
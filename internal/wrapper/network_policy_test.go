@@ -0,0 +1,106 @@
+package wrapper
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func startTestNetworkProxy(t *testing.T, allowedHosts ...string) *networkProxy {
+	t.Helper()
+	socketFilepath := filepath.Join(t.TempDir(), "proxy.sock")
+	proxy, err := startNetworkProxy(&config.NetworkPolicyConfig{AllowedHosts: allowedHosts}, socketFilepath, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("startNetworkProxy failed: %v", err)
+	}
+	t.Cleanup(proxy.Close)
+	return proxy
+}
+
+// proxiedClient returns an HTTP client that sends every request through
+// proxy.
+func proxiedClient(t *testing.T, proxy *networkProxy) *http.Client {
+	t.Helper()
+	proxyURL, err := url.Parse(proxy.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+}
+
+func TestNetworkProxy_ForwardsAllowedHTTP(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello")
+	}))
+	defer upstream.Close()
+
+	proxy := startTestNetworkProxy(t, "127.0.0.1")
+	resp, err := proxiedClient(t, proxy).Get(upstream.URL)
+	if err != nil {
+		t.Fatalf("GET through proxy failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "hello" {
+		t.Errorf("expected 200 hello, got %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestNetworkProxy_TunnelsAllowedHTTPS(t *testing.T) {
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "secure")
+	}))
+	defer upstream.Close()
+
+	proxy := startTestNetworkProxy(t, "127.0.0.1")
+	client := upstream.Client()
+	proxyURL, _ := url.Parse(proxy.URL())
+	client.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+
+	resp, err := client.Get(upstream.URL)
+	if err != nil {
+		t.Fatalf("HTTPS GET through proxy failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "secure" {
+		t.Errorf("expected tunneled response, got %q", body)
+	}
+}
+
+func TestNetworkProxy_BlocksOtherHosts(t *testing.T) {
+	proxy := startTestNetworkProxy(t, "github.com")
+
+	resp, err := proxiedClient(t, proxy).Get("http://example.com/")
+	if err != nil {
+		t.Fatalf("GET through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for plain HTTP, got %d", resp.StatusCode)
+	}
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(proxy.URL(), "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprint(conn, "CONNECT example.com:443 HTTP/1.1\r\nHost: example.com:443\r\n\r\n")
+	tunnelResp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatalf("failed to read CONNECT response: %v", err)
+	}
+	if tunnelResp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for CONNECT, got %d", tunnelResp.StatusCode)
+	}
+}
@@ -0,0 +1,46 @@
+package wrapper
+
+import (
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// reviewFallbackProtectedBranches are protected when a reviewRequired repo's
+// default branch can't be determined (origin/HEAD unset).
+var reviewFallbackProtectedBranches = []string{"main", "master"}
+
+// reviewDenyEntries returns the settings.json deny entries for the repo's
+// review mode: none unless reviewRequired is set, otherwise rules keeping
+// Claude from pushing straight to the repo's default branch.
+func (w *Wrapper) reviewDenyEntries(rc config.RepoConfig) []string {
+	if !rc.ReviewRequired {
+		return nil
+	}
+	protectedBranches := reviewFallbackProtectedBranches
+	if defaultBranch, err := mission.GetDefaultBranch(w.agentDirpath); err == nil {
+		protectedBranches = []string{defaultBranch}
+	} else {
+		w.logger.Warn("Failed to determine default branch for review mode; protecting the usual defaults",
+			"branches", protectedBranches, "error", err)
+	}
+	return claudeconfig.BuildReviewDenyEntries(protectedBranches)
+}
+
+// applyReviewMode points the agent repo's pushes at agenc/review/ holding
+// branches when the repo's repoConfig sets reviewRequired, and removes that
+// mapping when it doesn't. Runs before each spawn so toggling the setting
+// applies on the next reload. Fails closed: a reviewRequired mission whose
+// push mapping can't be set doesn't start.
+func (w *Wrapper) applyReviewMode() error {
+	if w.gitRepoName == "" {
+		return nil
+	}
+	rc := w.loadRepoConfig()
+	if err := mission.ConfigureReviewPush(w.agentDirpath, rc.ReviewRequired); err != nil {
+		return stacktrace.Propagate(err, "failed to apply review mode to the agent repo")
+	}
+	return nil
+}
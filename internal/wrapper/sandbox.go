@@ -0,0 +1,196 @@
+package wrapper
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mieubrisse/stacktrace"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/devcontainer"
+)
+
+const (
+	// sandboxContainerHome is the home directory of the sandbox container's
+	// user. Sandboxes run as the image's default user, root for stock images;
+	// rootless Podman maps it back to the host user.
+	sandboxContainerHome = "/root"
+
+	// sandboxClaudeScript starts Claude inside the sandbox, installing Claude
+	// Code with npm first when the image doesn't ship a claude binary.
+	sandboxClaudeScript = `command -v claude >/dev/null 2>&1 || npm install -g --silent @anthropic-ai/claude-code >&2 || exit 1; exec claude "$@"`
+)
+
+// sandboxState holds what the wrapper needs to run Claude in a sandbox
+// container (isolation: container or --sandbox).
+type sandboxState struct {
+	// runtime is the container CLI, "docker" or "podman"
+	runtime string
+
+	image string
+
+	// containerName is unique per mission so a stale container from a
+	// crashed wrapper can be removed before the next start
+	containerName string
+
+	// mounts are --mount specs for the agent dir, claude-config, session
+	// transcripts, and (for interactive missions) the wrapper socket
+	mounts []string
+
+	// workspaceDirpath is the container working directory: the mission's
+	// working directory on the host, under the agent dir mount. Host paths are
+	// used as-is, so session transcript paths and trust entries in
+	// .claude.json line up without translation.
+	workspaceDirpath string
+}
+
+// checkBackendIsolation refuses to run a non-Claude backend for a mission
+// that asked for a sandbox container, which only exists for Claude, rather
+// than silently running it on the host.
+func (w *Wrapper) checkBackendIsolation() error {
+	if w.isClaudeBackend() {
+		return nil
+	}
+	cfg, _, err := config.ReadAgencConfig(w.agencDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to read config for sandbox setup")
+	}
+	if config.IsMissionSandboxed(w.agencDirpath, w.missionID) || cfg.IsContainerIsolated(w.gitRepoName) {
+		return stacktrace.NewError("the '%s' backend can't run in a sandbox container; only '%s' can", w.backend.Name(), config.AgentBackendClaude)
+	}
+	return nil
+}
+
+// setupSandbox returns the sandbox state when the mission should run Claude
+// in a container: it was created with --sandbox, or its repo's isolation is
+// "container". Returns nil otherwise, and when the repo has a devcontainer.json,
+// which already runs Claude in a container and takes precedence.
+func (w *Wrapper) setupSandbox() (*sandboxState, error) {
+	cfg, _, err := config.ReadAgencConfig(w.agencDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read config for sandbox setup")
+	}
+	if !config.IsMissionSandboxed(w.agencDirpath, w.missionID) && !cfg.IsContainerIsolated(w.gitRepoName) {
+		return nil, nil
+	}
+	if _, found := devcontainer.DetectDevcontainer(w.agentDirpath); found {
+		w.logger.Info("Repo has a devcontainer.json; using it instead of the sandbox container")
+		return nil, nil
+	}
+
+	runtime, err := resolveSandboxRuntime(cfg.GetSandboxRuntime())
+	if err != nil {
+		return nil, err
+	}
+
+	// Headless wrappers don't serve wrapper.sock; a bind mount of a missing
+	// source would fail to start the container
+	wrapperSocketPath := config.GetMissionSocketFilepath(w.agencDirpath, w.missionID)
+	if _, err := os.Stat(wrapperSocketPath); err != nil {
+		wrapperSocketPath = ""
+	}
+
+	agencMounts, err := devcontainer.BuildAgencMounts(devcontainer.OverlayParams{
+		MissionID:           w.missionID,
+		AgencDirpath:        w.agencDirpath,
+		HostAgentDirpath:    w.agentDirpath,
+		ClaudeConfigDirpath: claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID),
+		HostScratchDirpath:  w.scratchDirpath,
+		WrapperSocketPath:   wrapperSocketPath,
+	}, w.agentDirpath, sandboxContainerHome)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to build sandbox mounts")
+	}
+
+	mounts := []string{"source=" + w.agentDirpath + ",target=" + w.agentDirpath + ",type=bind"}
+	mounts = append(mounts, agencMounts...)
+
+	return &sandboxState{
+		runtime:          runtime,
+		image:            cfg.GetSandboxImage(),
+		containerName:    "agenc-" + database.ShortID(w.missionID),
+		mounts:           mounts,
+		workspaceDirpath: w.workDirpath,
+	}, nil
+}
+
+// resolveSandboxRuntime returns the container CLI to use: the configured one,
+// or docker or podman, whichever is found on PATH first.
+func resolveSandboxRuntime(configured string) (string, error) {
+	candidates := []string{config.SandboxRuntimeDocker, config.SandboxRuntimePodman}
+	if configured != "" {
+		candidates = []string{configured}
+	}
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", stacktrace.NewError(
+		"mission is sandboxed but no container runtime was found (looked for %s); install Docker or Podman, or set sandbox.runtime in config.yml",
+		strings.Join(candidates, ", "))
+}
+
+// buildSandboxRunArgs returns the `<runtime> run` arguments that start Claude
+// with claudeArgs in the sandbox. env entries are passed by name only (-e KEY)
+// so secret values come from the runtime CLI's environment instead of showing
+// up in the process list. interactive allocates a TTY.
+func buildSandboxRunArgs(state *sandboxState, envKeys []string, claudeArgs []string, interactive bool) []string {
+	args := []string{"run", "--rm", "-i"}
+	if interactive {
+		args = append(args, "-t")
+	}
+	args = append(args, "--name", state.containerName, "-w", state.workspaceDirpath)
+	for _, mount := range state.mounts {
+		args = append(args, "--mount", mount)
+	}
+	for _, key := range envKeys {
+		args = append(args, "-e", key)
+	}
+	args = append(args, state.image, "sh", "-c", sandboxClaudeScript, "claude")
+	args = append(args, claudeArgs...)
+	return args
+}
+
+// sandboxClaudeCmd builds the command that runs Claude in the sandbox. The
+// container gets the mission's identity, OAuth token, and cron env; nothing
+// else from the host environment is passed through.
+func (w *Wrapper) sandboxClaudeCmd(claudeArgs []string, interactive bool) (*exec.Cmd, error) {
+	oauthToken, err := config.ReadOAuthToken(w.agencDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "failed to read OAuth token")
+	}
+
+	env := []string{
+		config.MissionUUIDEnvVar + "=" + w.missionID,
+		config.MissionScratchDirpathEnvVar + "=" + devcontainer.ContainerScratchDirpath,
+		"AGENC_WRAPPER_SOCKET=" + devcontainer.ContainerWrapperSocketPath,
+		"CLAUDE_CONFIG_DIR=" + filepath.Join(sandboxContainerHome, ".claude"),
+		"CLAUDE_CODE_OAUTH_TOKEN=" + oauthToken,
+	}
+	env = append(env, w.containerSpawnEnv()...)
+
+	envKeys := make([]string, 0, len(env))
+	for _, entry := range env {
+		key, _, _ := strings.Cut(entry, "=")
+		envKeys = append(envKeys, key)
+	}
+
+	// Remove any container left behind by a wrapper that died before --rm
+	// could clean up, so the name is free
+	sandboxRemove(w.sandbox)
+
+	cmd := exec.Command(w.sandbox.runtime, buildSandboxRunArgs(w.sandbox, envKeys, claudeArgs, interactive)...)
+	cmd.Env = append(os.Environ(), env...)
+	return cmd, nil
+}
+
+// sandboxRemove force-removes the mission's sandbox container, if any.
+// Errors are ignored: usually the container is already gone.
+func sandboxRemove(state *sandboxState) {
+	_ = exec.Command(state.runtime, "rm", "-f", state.containerName).Run()
+}
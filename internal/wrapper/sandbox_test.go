@@ -0,0 +1,36 @@
+package wrapper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildSandboxRunArgs(t *testing.T) {
+	state := &sandboxState{
+		runtime:          "docker",
+		image:            "node:lts",
+		containerName:    "agenc-abc12345",
+		mounts:           []string{"source=/m/agent,target=/m/agent,type=bind"},
+		workspaceDirpath: "/m/agent",
+	}
+
+	got := buildSandboxRunArgs(state, []string{"AGENC_MISSION_UUID", "CLAUDE_CODE_OAUTH_TOKEN"}, []string{"--model", "opus"}, true)
+	want := []string{
+		"run", "--rm", "-i", "-t",
+		"--name", "agenc-abc12345", "-w", "/m/agent",
+		"--mount", "source=/m/agent,target=/m/agent,type=bind",
+		"-e", "AGENC_MISSION_UUID", "-e", "CLAUDE_CODE_OAUTH_TOKEN",
+		"node:lts", "sh", "-c", sandboxClaudeScript, "claude",
+		"--model", "opus",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildSandboxRunArgs() =\n  %v\nwant\n  %v", got, want)
+	}
+
+	headless := buildSandboxRunArgs(state, nil, []string{"--print"}, false)
+	for _, arg := range headless {
+		if arg == "-t" {
+			t.Error("expected no TTY for a headless sandbox")
+		}
+	}
+}
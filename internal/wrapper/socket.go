@@ -7,9 +7,11 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/version"
 )
 
 // StatusResponse is the JSON response for GET /status.
@@ -22,6 +24,22 @@ type StatusResponse struct {
 type ClaudeUpdateRequest struct {
 	Event            string `json:"event"`
 	NotificationType string `json:"notification_type"`
+	ToolName         string `json:"tool_name,omitempty"`
+	Prompt           string `json:"prompt,omitempty"`
+}
+
+// WindowTitleRequest is the JSON body for POST /window-title.
+type WindowTitleRequest struct {
+	// Title is the mission's plain window title, which a windowTitleTemplate's
+	// {title} placeholder expands to.
+	Title string `json:"title"`
+}
+
+// VersionResponse is the JSON response for GET /version.
+type VersionResponse struct {
+	Version                      string `json:"version"`
+	APIVersion                   int    `json:"api_version"`
+	MinSupportedClientAPIVersion int    `json:"min_supported_client_api_version"`
 }
 
 // CommandResponse is the JSON response for POST /claude-update and POST /rebuild.
@@ -37,6 +55,8 @@ type Command struct {
 	Command          string
 	Event            string
 	NotificationType string
+	ToolName         string
+	Prompt           string
 }
 
 // commandWithResponse pairs a Command with a channel for sending back the CommandResponse.
@@ -48,11 +68,16 @@ type commandWithResponse struct {
 const (
 	httpReadTimeout  = 5 * time.Second
 	httpWriteTimeout = 5 * time.Second
+
+	// pingTimeout bounds how long GET /ping waits for the main event loop to
+	// answer before reporting it unresponsive.
+	pingTimeout = 3 * time.Second
 )
 
 // startHTTPServer creates an HTTP server listening on a unix socket at
-// socketFilepath. It serves three endpoints: GET /status, POST /restart,
-// and POST /claude-update. The server shuts down when ctx is cancelled.
+// socketFilepath. It serves GET /status, GET /ping, GET /prime, GET /version,
+// POST /claude-update, POST /rebuild, and POST /window-title. The server
+// shuts down when ctx is cancelled.
 func startHTTPServer(ctx context.Context, socketFilepath string, w *Wrapper, logger *slog.Logger) {
 	// Remove stale socket file from a previous run
 	if err := os.Remove(socketFilepath); err != nil && !os.IsNotExist(err) {
@@ -79,13 +104,16 @@ func startHTTPServer(ctx context.Context, socketFilepath string, w *Wrapper, log
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /status", handleStatus(w))
+	mux.HandleFunc("GET /ping", handlePing(w))
 	mux.HandleFunc("GET /prime", handlePrime())
+	mux.HandleFunc("GET /version", handleVersion())
 	mux.HandleFunc("POST /claude-update", handleClaudeUpdateHTTP(w, logger))
 	mux.HandleFunc("POST /claude-update/{event}", handleClaudeUpdateWithPathEvent(w, logger))
 	mux.HandleFunc("POST /rebuild", handleRebuild(w, logger))
+	mux.HandleFunc("POST /window-title", handleWindowTitle(w))
 
 	server := &http.Server{
-		Handler:      mux,
+		Handler:      apiVersionMiddleware(mux),
 		ReadTimeout:  httpReadTimeout,
 		WriteTimeout: httpWriteTimeout,
 	}
@@ -102,6 +130,42 @@ func startHTTPServer(ctx context.Context, socketFilepath string, w *Wrapper, log
 	}
 }
 
+// handleVersion returns the wrapper's release version, its socket API
+// version, and the oldest client API version it accepts.
+func handleVersion() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		resp := VersionResponse{
+			Version:                      version.Version,
+			APIVersion:                   version.WrapperAPIVersion,
+			MinSupportedClientAPIVersion: minClientAPIVersion,
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(rw).Encode(resp) // response already started; encode error cannot be propagated
+	}
+}
+
+// minClientAPIVersion is the oldest client API version the wrapper's socket
+// serves. It is version.MinWrapperClientAPIVersion; tests raise it to exercise
+// rejection.
+var minClientAPIVersion = version.MinWrapperClientAPIVersion
+
+// apiVersionMiddleware stamps every response with the wrapper's socket API
+// version and rejects requests from clients older than
+// version.MinWrapperClientAPIVersion with 426 Upgrade Required. Requests
+// without a version header, such as the curl calls in containerized
+// missions' hook scripts, are served as before.
+func apiVersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set(version.APIVersionHeader, strconv.Itoa(version.WrapperAPIVersion))
+		if err := version.CheckAPIVersion(r.Header, "this agenc client", minClientAPIVersion,
+			"upgrade agenc ('agenc upgrade') so the CLI and server match the mission's wrapper"); err != nil {
+			writeCommandResponse(rw, http.StatusUpgradeRequired, CommandResponse{Status: "error", Error: err.Error()})
+			return
+		}
+		next.ServeHTTP(rw, r)
+	})
+}
+
 // handlePrime returns the embedded `agenc prime` content as plain text.
 // Used by containerized missions, whose hook scripts cannot invoke the
 // `agenc` CLI directly (the binary isn't mounted into the container).
@@ -129,6 +193,24 @@ func handleStatus(w *Wrapper) http.HandlerFunc {
 	}
 }
 
+// handleWindowTitle records the plain title the server settled on and
+// re-renders the mission's windowTitleTemplate in the background. Like
+// handleStatus, it bypasses the command channel: it touches no Claude state.
+func handleWindowTitle(w *Wrapper) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		var req WindowTitleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeCommandResponse(rw, http.StatusBadRequest, CommandResponse{
+				Status: "error",
+				Error:  "invalid JSON",
+			})
+			return
+		}
+		go w.setWindowTitleBase(req.Title)
+		writeCommandResponse(rw, http.StatusOK, CommandResponse{Status: "ok"})
+	}
+}
+
 // handleClaudeUpdateHTTP sends a claude_update command through the event loop
 // channel and waits for the response.
 func handleClaudeUpdateHTTP(w *Wrapper, logger *slog.Logger) http.HandlerFunc {
@@ -148,6 +230,8 @@ func handleClaudeUpdateHTTP(w *Wrapper, logger *slog.Logger) http.HandlerFunc {
 			Command:          "claude_update",
 			Event:            req.Event,
 			NotificationType: req.NotificationType,
+			ToolName:         req.ToolName,
+			Prompt:           req.Prompt,
 		}
 
 		resp := sendCommandAndWait(w.commandCh, cmd)
@@ -170,6 +254,31 @@ func handleRebuild(w *Wrapper, logger *slog.Logger) http.HandlerFunc {
 	}
 }
 
+// handlePing answers the server's health probe. The ping goes through the
+// event loop channel, so a wrapper whose main loop is wedged fails the probe
+// even though its socket still accepts connections. Answers 503 if the loop
+// doesn't respond within pingTimeout.
+func handlePing(w *Wrapper) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		responseCh := make(chan CommandResponse, 1)
+		timeout := time.NewTimer(pingTimeout)
+		defer timeout.Stop()
+
+		select {
+		case w.commandCh <- commandWithResponse{cmd: Command{Command: "ping"}, responseCh: responseCh}:
+		case <-timeout.C:
+			writeCommandResponse(rw, http.StatusServiceUnavailable, CommandResponse{Status: "error", Error: "event loop is not accepting commands"})
+			return
+		}
+		select {
+		case resp := <-responseCh:
+			writeCommandResponse(rw, http.StatusOK, resp)
+		case <-timeout.C:
+			writeCommandResponse(rw, http.StatusServiceUnavailable, CommandResponse{Status: "error", Error: "event loop did not answer the ping"})
+		}
+	}
+}
+
 // handleClaudeUpdateWithPathEvent handles POST /claude-update/{event} used by
 // containerized missions. The event type is in the URL path instead of the JSON
 // body, since container hooks use curl with the event in the URL.
@@ -199,6 +308,8 @@ func handleClaudeUpdateWithPathEvent(w *Wrapper, logger *slog.Logger) http.Handl
 			Command:          "claude_update",
 			Event:            event,
 			NotificationType: req.NotificationType,
+			ToolName:         req.ToolName,
+			Prompt:           req.Prompt,
 		}
 
 		resp := sendCommandAndWait(w.commandCh, cmd)
@@ -0,0 +1,47 @@
+package wrapper
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/version"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	handler := apiVersionMiddleware(handleVersion())
+
+	serve := func(clientVersion int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/version", nil)
+		req.Header.Set(version.APIVersionHeader, strconv.Itoa(clientVersion))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(version.WrapperAPIVersion)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a current client, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get(version.APIVersionHeader); got != strconv.Itoa(version.WrapperAPIVersion) {
+		t.Errorf("expected the response stamped with API version %d, got %q", version.WrapperAPIVersion, got)
+	}
+
+	minClientAPIVersion = version.WrapperAPIVersion + 1
+	t.Cleanup(func() { minClientAPIVersion = version.MinWrapperClientAPIVersion })
+
+	rec = serve(version.WrapperAPIVersion)
+	if rec.Code != http.StatusUpgradeRequired {
+		t.Fatalf("expected 426 for a client below the minimum, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp CommandResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "error" || !strings.Contains(resp.Error, "agenc upgrade") {
+		t.Errorf("expected an error telling the user to run 'agenc upgrade', got %+v", resp)
+	}
+}
@@ -0,0 +1,58 @@
+package wrapper
+
+import (
+	"time"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/session"
+)
+
+// reportStats sends a usage report for the mission: cumulative token usage
+// re-derived from the mission's session transcripts, the wall-clock time
+// elapsed since the previous report, and (when claudeStarted is true) one
+// additional Claude start. Failures are logged and otherwise ignored — stats
+// are best-effort accounting and must never disrupt the mission. Each report
+// also enforces the mission's budget against the re-derived spend.
+//
+// Safe to call from multiple goroutines.
+func (w *Wrapper) reportStats(claudeStarted bool) {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+
+	if w.usageTracker == nil {
+		claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID)
+		w.usageTracker = session.NewUsageTracker(claudeConfigDirpath, w.missionID)
+	}
+
+	now := time.Now()
+	if w.lastStatsReportAt.IsZero() {
+		w.lastStatsReportAt = now
+	}
+	elapsedSeconds := int64(now.Sub(w.lastStatsReportAt).Seconds())
+
+	usage := w.usageTracker.Update()
+
+	w.stateMu.RLock()
+	claudeIdle := w.claudeIdle
+	w.stateMu.RUnlock()
+	w.enforceBudget(w.usageTracker.EstimatedCostUSD(), claudeIdle)
+
+	report := server.MissionStatsReport{
+		InputTokens:           &usage.InputTokens,
+		OutputTokens:          &usage.OutputTokens,
+		CacheReadTokens:       &usage.CacheReadTokens,
+		CacheCreationTokens:   &usage.CacheCreationTokens,
+		WallClockSecondsDelta: elapsedSeconds,
+		ClaudeStarted:         claudeStarted,
+	}
+	if err := w.client.ReportMissionStats(w.missionID, report); err != nil {
+		w.logger.Warn("Failed to report mission stats", "error", err)
+		return
+	}
+
+	// Only advance the baseline once the delta has been accepted, so a failed
+	// report rolls its elapsed time into the next one. Whole seconds are
+	// reported; the fractional remainder carries over.
+	w.lastStatsReportAt = w.lastStatsReportAt.Add(time.Duration(elapsedSeconds) * time.Second)
+}
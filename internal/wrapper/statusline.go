@@ -0,0 +1,75 @@
+package wrapper
+
+import (
+	"os"
+	"slices"
+
+	"github.com/odyssey/agenc/internal/claudeconfig"
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/mission"
+)
+
+// refreshStatusline renders the mission's statusline.segments and writes
+// them to its statusline message file, which the statusLine AgenC merges
+// into the mission's Claude settings prints. Called after each spawn, when
+// Claude finishes a turn, and when budget usage changes. Best-effort: if the
+// mission can't be read from the server, the last statusline is kept. Must
+// not be called with budgetMu held.
+func (w *Wrapper) refreshStatusline() {
+	w.statuslineMu.Lock()
+	defer w.statuslineMu.Unlock()
+
+	segments := w.statuslineSegments
+	if segments == nil {
+		segments = config.DefaultStatuslineSegments
+	}
+	values := config.StatuslineValues{Budget: w.budgetStatusline()}
+
+	if slices.Contains(segments, config.StatuslineSegmentMission) ||
+		slices.Contains(segments, config.StatuslineSegmentConfigBehind) ||
+		slices.Contains(segments, config.StatuslineSegmentCron) {
+		missionRecord, err := w.client.GetMission(w.missionID)
+		if err != nil {
+			w.logger.Warn("Failed to read mission for the statusline", "error", err)
+			return
+		}
+		values.ShortID = missionRecord.ShortID
+		if missionRecord.CronName != nil {
+			values.CronName = *missionRecord.CronName
+		}
+		// The mission's config commit is the one the server records, since
+		// skill changes can bring a running mission's config up to date
+		if slices.Contains(segments, config.StatuslineSegmentConfigBehind) && missionRecord.ConfigCommit != nil {
+			if headCommit := claudeconfig.GetShadowRepoCommitHash(w.agencDirpath); headCommit != "" {
+				values.ConfigCommitsBehind = claudeconfig.CountCommitsBehind(w.agencDirpath, *missionRecord.ConfigCommit, headCommit)
+			}
+		}
+	}
+	if slices.Contains(segments, config.StatuslineSegmentBranch) {
+		// Best-effort: blank and multi-repo missions have no single branch
+		values.Branch, _ = mission.GetCurrentBranch(w.agentDirpath)
+	}
+
+	w.writeStatuslineMessage(config.RenderStatusline(segments, values))
+}
+
+// writeStatuslineMessage replaces the mission's statusline message, skipping
+// the write when it hasn't changed since the last one. The first write always
+// happens, clearing whatever a previous wrapper left behind. Must be called
+// with statuslineMu held.
+func (w *Wrapper) writeStatuslineMessage(message string) {
+	if w.statuslineWritten && message == w.lastStatuslineMessage {
+		return
+	}
+	content := ""
+	if message != "" {
+		content = message + "\n"
+	}
+	messageFilepath := config.GetMissionStatuslineMessageFilepath(w.agencDirpath, w.missionID)
+	if err := os.WriteFile(messageFilepath, []byte(content), 0644); err != nil {
+		w.logger.Warn("Failed to write statusline message", "error", err)
+		return
+	}
+	w.lastStatuslineMessage = message
+	w.statuslineWritten = true
+}
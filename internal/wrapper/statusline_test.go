@@ -0,0 +1,72 @@
+package wrapper
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestRefreshStatusline_ComposesSegments(t *testing.T) {
+	agencDirpath := t.TempDir()
+	missionID := "2b4c8f1a-0000-0000-0000-000000000000"
+	agentDirpath := config.GetMissionAgentDirpath(agencDirpath, missionID)
+	if err := os.MkdirAll(agentDirpath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := exec.Command("git", "-C", agentDirpath, "init", "-b", "feature/login").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %s: %v", output, err)
+	}
+	messageFilepath := config.GetMissionStatuslineMessageFilepath(agencDirpath, missionID)
+
+	w := &Wrapper{
+		agencDirpath:       agencDirpath,
+		missionID:          missionID,
+		agentDirpath:       agentDirpath,
+		logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		statuslineSegments: []string{config.StatuslineSegmentBranch, config.StatuslineSegmentBudget},
+	}
+
+	w.refreshStatusline()
+	if got := readStatusline(t, messageFilepath); got != "⎇ feature/login\n" {
+		t.Errorf("expected only the branch without a budget, got %q", got)
+	}
+
+	w.budget = missionBudget{maxPrompts: 10}
+	w.budgetPromptCount = 3
+	w.refreshStatusline()
+	if got := readStatusline(t, messageFilepath); got != "⎇ feature/login · $0.00 · 3/10 prompts\n" {
+		t.Errorf("expected the branch and budget, got %q", got)
+	}
+}
+
+func TestRefreshStatusline_ClearsStaleMessage(t *testing.T) {
+	agencDirpath := t.TempDir()
+	missionID := "2b4c8f1a-0000-0000-0000-000000000000"
+	messageFilepath := config.GetMissionStatuslineMessageFilepath(agencDirpath, missionID)
+	if err := os.MkdirAll(config.GetMissionDirpath(agencDirpath, missionID), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(messageFilepath, []byte("$9.99 · 99 prompts\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The default segments show only the budget, and this mission has none
+	w := &Wrapper{agencDirpath: agencDirpath, missionID: missionID, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	w.refreshStatusline()
+	if got := readStatusline(t, messageFilepath); got != "" {
+		t.Errorf("expected the previous wrapper's statusline cleared, got %q", got)
+	}
+}
+
+func readStatusline(t *testing.T, messageFilepath string) string {
+	t.Helper()
+	data, err := os.ReadFile(messageFilepath)
+	if err != nil {
+		t.Fatalf("failed to read statusline message: %v", err)
+	}
+	return string(data)
+}
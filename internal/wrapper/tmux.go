@@ -2,8 +2,9 @@ package wrapper
 
 import (
 	"os"
-	"os/exec"
 	"strings"
+
+	"github.com/odyssey/agenc/internal/tmux"
 )
 
 // setWindowBusy sets the tmux window tab to the busy colors, indicating
@@ -33,7 +34,7 @@ func (w *Wrapper) resetWindowTabStyle() {
 		return
 	}
 	//nolint:errcheck // best-effort; failure is not critical
-	exec.Command("tmux", "set-option", "-wu", "-t", windowID, "window-status-style").Run()
+	tmux.Command("set-option", "-wu", "-t", windowID, "window-status-style").Run()
 }
 
 // setWindowTabColors sets the foreground and background colors of this window's title in the
@@ -61,7 +62,7 @@ func (w *Wrapper) setWindowTabColors(bgColor, fgColor string) {
 
 	style := strings.Join(styleComponents, ",")
 	//nolint:errcheck // best-effort; failure is not critical
-	exec.Command("tmux", "set-option", "-w", "-t", windowID, "window-status-style", style).Run()
+	tmux.Command("set-option", "-w", "-t", windowID, "window-status-style", style).Run()
 }
 
 // resolveWindowID returns the tmux window ID (e.g. "@3") for the pane this
@@ -74,7 +75,7 @@ func resolveWindowID() string {
 	if paneID == "" {
 		return ""
 	}
-	out, err := exec.Command("tmux", "display-message", "-p", "-t", paneID, "#{window_id}").Output()
+	out, err := tmux.Command("display-message", "-p", "-t", paneID, "#{window_id}").Output()
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,119 @@
+package wrapper
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/odyssey/agenc/internal/config"
+	"github.com/odyssey/agenc/internal/database"
+	"github.com/odyssey/agenc/internal/mission"
+	"github.com/odyssey/agenc/internal/tmux"
+)
+
+const (
+	// windowTitleMaxLen caps a rendered windowTitleTemplate. Templates carry
+	// more context than the plain title, so the cap is looser than the
+	// server's.
+	windowTitleMaxLen = 60
+
+	// windowTitleTitleMaxLen caps the {title} placeholder so a long session
+	// title can't crowd out the rest of the template.
+	windowTitleTitleMaxLen = 30
+)
+
+// windowStatusEmojis maps Claude states (see getClaudeStateString) to the
+// {status-emoji} placeholder.
+var windowStatusEmojis = map[string]string{
+	"busy":            "🔄",
+	"idle":            "💤",
+	"needs_attention": "🔔",
+}
+
+// setWindowTitleBase records the plain title the server settled on for the
+// mission, which the {title} placeholder expands to, and re-renders the
+// window title.
+func (w *Wrapper) setWindowTitleBase(title string) {
+	w.windowTitleMu.Lock()
+	w.windowTitleBase = title
+	w.windowTitleMu.Unlock()
+	w.refreshWindowTitle()
+}
+
+// refreshWindowTitle renders the mission's windowTitleTemplate with Claude's
+// current state and the workspace's current branch, and applies it to the
+// tmux window. Called on every state change, so the tab bar tracks what the
+// mission is doing. No-op without a template, outside tmux, or when the pane
+// shares its window. Must not be called with stateMu held.
+func (w *Wrapper) refreshWindowTitle() {
+	if w.windowTitleTemplate == "" || w.tmuxPaneID == "" {
+		return
+	}
+
+	// Serialize renders so concurrent refreshes apply in order
+	w.windowTitleMu.Lock()
+	defer w.windowTitleMu.Unlock()
+
+	w.stateMu.RLock()
+	state := w.getClaudeStateString()
+	w.stateMu.RUnlock()
+
+	// Best-effort: blank and multi-repo missions have no single branch
+	branch, _ := mission.GetCurrentBranch(w.agentDirpath)
+
+	title := w.windowTitleBase
+	if title == "" {
+		title = w.windowTitleRepo
+	}
+	if title == "" {
+		title = database.ShortID(w.missionID)
+	}
+
+	rendered := config.RenderWindowTitle(w.windowTitleTemplate, config.WindowTitleValues{
+		Title:       truncateWindowTitle(title, windowTitleTitleMaxLen),
+		Repo:        w.windowTitleRepo,
+		ShortID:     database.ShortID(w.missionID),
+		Branch:      branch,
+		Emoji:       w.windowTitleEmoji,
+		StatusEmoji: windowStatusEmojis[state],
+	})
+
+	paneID := "%" + w.tmuxPaneID
+	if !isSolePaneInWindow(paneID) {
+		return
+	}
+	if err := tmux.Command("rename-window", "-t", paneID, truncateWindowTitle(rendered, windowTitleMaxLen)).Run(); err != nil {
+		w.logger.Warn("Failed to rename tmux window", "pane", paneID, "error", err)
+	}
+}
+
+// windowTitleRepoName returns the {repo} placeholder for a repo: its
+// configured title, or the last segment of its canonical name.
+func windowTitleRepoName(cfg *config.AgencConfig, gitRepoName string) string {
+	if gitRepoName == "" {
+		return ""
+	}
+	if title := cfg.GetRepoTitle(gitRepoName); title != "" {
+		return title
+	}
+	return gitRepoName[strings.LastIndex(gitRepoName, "/")+1:]
+}
+
+// isSolePaneInWindow reports whether paneID is the only pane in its tmux
+// window. Windows the user has split keep the title they have.
+func isSolePaneInWindow(paneID string) bool {
+	out, err := tmux.Command("display-message", "-p", "-t", paneID, "#{window_panes}").Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) == "1"
+}
+
+// truncateWindowTitle cuts title to maxLen runes, ending it with an ellipsis
+// when anything was cut.
+func truncateWindowTitle(title string, maxLen int) string {
+	if utf8.RuneCountInString(title) <= maxLen {
+		return title
+	}
+	runes := []rune(title)
+	return string(runes[:maxLen-1]) + "…"
+}
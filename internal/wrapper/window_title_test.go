@@ -0,0 +1,55 @@
+package wrapper
+
+import (
+	"testing"
+
+	"github.com/odyssey/agenc/internal/config"
+)
+
+func TestWindowTitleRepoName(t *testing.T) {
+	cfg := &config.AgencConfig{
+		RepoConfigs: map[string]config.RepoConfig{
+			"github.com/acme/widgets": {Title: "Widgets"},
+		},
+	}
+	tests := []struct {
+		repo string
+		want string
+	}{
+		{"github.com/acme/widgets", "Widgets"},
+		{"github.com/acme/api", "api"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := windowTitleRepoName(cfg, tt.repo); got != tt.want {
+			t.Errorf("windowTitleRepoName(%q) = %q, want %q", tt.repo, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateWindowTitle(t *testing.T) {
+	if got := truncateWindowTitle("short", 10); got != "short" {
+		t.Errorf("expected short title untouched, got %q", got)
+	}
+	if got := truncateWindowTitle("🔄 a long title", 6); got != "🔄 a l…" {
+		t.Errorf("expected rune-aware truncation, got %q", got)
+	}
+}
+
+func TestWindowStatusEmojis_CoverEveryClaudeState(t *testing.T) {
+	w := &Wrapper{}
+	for _, state := range []struct{ idle, attention bool }{{true, false}, {false, false}, {false, true}} {
+		w.claudeIdle, w.needsAttention = state.idle, state.attention
+		if windowStatusEmojis[w.getClaudeStateString()] == "" {
+			t.Errorf("no status emoji for state %q", w.getClaudeStateString())
+		}
+	}
+}
+
+func TestSetWindowTitleBase_WithoutTemplateOnlyRecords(t *testing.T) {
+	w := &Wrapper{tmuxPaneID: "1"}
+	w.setWindowTitleBase("Fix login")
+	if w.windowTitleBase != "Fix login" {
+		t.Errorf("expected the title recorded, got %q", w.windowTitleBase)
+	}
+}
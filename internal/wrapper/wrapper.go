@@ -24,6 +24,7 @@ import (
 	"github.com/odyssey/agenc/internal/database"
 	"github.com/odyssey/agenc/internal/mission"
 	"github.com/odyssey/agenc/internal/server"
+	"github.com/odyssey/agenc/internal/session"
 )
 
 const (
@@ -43,6 +44,8 @@ type Wrapper struct {
 	claudeArgs     []string
 	missionDirpath string
 	agentDirpath   string
+	scratchDirpath string
+	workDirpath    string // where Claude runs: agentDirpath, or the --path subdirectory of it
 	client         *server.Client
 	claudeCmd      *exec.Cmd
 	logger         *slog.Logger
@@ -54,6 +57,10 @@ type Wrapper struct {
 	// hook). Both reads and writes happen in the main event loop.
 	hasConversation bool
 
+	// handoffContext is the mission's handoff note rendered as context for
+	// Claude, refreshed before each spawn; empty when there is none to surface.
+	handoffContext string
+
 	// claudeIdle tracks whether Claude is currently idle (waiting for user
 	// input). Initialized true (Claude hasn't started processing yet). Updated
 	// by handleClaudeUpdate from the main event loop.
@@ -66,6 +73,10 @@ type Wrapper struct {
 	needsAttention   bool      // true when Claude needs user attention (permission prompt etc.)
 	lastUserPromptAt time.Time // zero value means no prompt yet this session
 
+	// busySince is when Claude started working on the current prompt; zero
+	// while Claude is idle. Only touched from the main event loop.
+	busySince time.Time
+
 	// Channels for internal communication between goroutines and the main loop.
 	// All are buffered with capacity 1 and use non-blocking sends to avoid
 	// goroutine leaks.
@@ -80,8 +91,8 @@ type Wrapper struct {
 	rebuilding atomic.Bool
 
 	// perMissionCredentialHash caches the SHA-256 hash of the per-mission
-	// Keychain credential JSON. The upward sync goroutine compares the current
-	// Keychain contents against this hash to detect when Claude updates MCP
+	// stored credential JSON. The upward sync goroutine compares the current
+	// stored contents against this hash to detect when Claude updates MCP
 	// OAuth tokens. Protected by credentialHashMu since both the upward and
 	// downward sync goroutines access it.
 	perMissionCredentialHash string
@@ -96,12 +107,93 @@ type Wrapper struct {
 	// mission's repo does not have a devcontainer.json.
 	devcontainer *devcontainerState
 
+	// backend starts the mission's agent CLI (see resolveBackend). Set at the
+	// start of Run and RunHeadless.
+	backend AgentBackend
+
+	// sandbox holds the state for missions that run Claude in a sandbox
+	// container (isolation: container or --sandbox). Nil otherwise, and
+	// always nil when devcontainer is set.
+	sandbox *sandboxState
+
+	// usageTracker and lastStatsReportAt back the periodic stats report (see
+	// reportStats). Protected by statsMu since reports are sent from both the
+	// heartbeat goroutine and the main event loop.
+	statsMu           sync.Mutex
+	usageTracker      *session.UsageTracker
+	lastStatsReportAt time.Time
+
 	// Window coloring configuration for tmux state feedback. Read from config.yml at startup.
 	// Empty strings mean that specific color setting is disabled.
 	windowBusyBackgroundColor      string
 	windowBusyForegroundColor      string
 	windowAttentionBackgroundColor string
 	windowAttentionForegroundColor string
+
+	// windowTitleTemplate is the mission's windowTitleTemplate, empty when
+	// the server's plain title is used as-is. windowTitleRepo and
+	// windowTitleEmoji back its {repo} and {emoji} placeholders. All three
+	// are read from config.yml at startup.
+	windowTitleTemplate string
+	windowTitleRepo     string
+	windowTitleEmoji    string
+
+	// windowTitleBase is the plain title the server last reported, which
+	// {title} expands to. windowTitleMu protects it and serializes renders.
+	windowTitleMu   sync.Mutex
+	windowTitleBase string
+
+	// desktopNotifications mirrors notifications.desktop. Read from
+	// config.yml at startup.
+	desktopNotifications bool
+
+	// quietHours mirrors quietHours; desktop notifications are held back
+	// while it is active. Read from config.yml at startup.
+	quietHours *config.QuietHoursConfig
+
+	// lifecycleHooks mirrors lifecycleHooks. Read from config.yml at startup.
+	lifecycleHooks config.LifecycleHooksConfig
+
+	// cronEnv holds the resolved KEY=VALUE env of the cron that launched this
+	// mission, refreshed before each spawn (see applyCronEnv).
+	cronEnv []string
+
+	// missionEnv holds the resolved KEY=VALUE repo and mission env, and
+	// missionEnvPrev the values it replaced (nil for unset vars). Both are
+	// refreshed before each spawn (see applyMissionEnv).
+	missionEnv     []string
+	missionEnvPrev map[string]*string
+
+	// networkProxy enforces the repo's networkPolicy, and networkProxyPrevEnv
+	// holds the proxy env it replaced (nil for unset vars). Both are nil when
+	// the repo has no policy (see applyNetworkPolicy).
+	networkProxy        *networkProxy
+	networkProxyPrevEnv map[string]*string
+
+	// budget holds the mission's prompt and spend limits (see loadBudget).
+	// budgetPromptCount and budgetCostUSD track usage against them. All are
+	// protected by budgetMu, which is never held while acquiring stateMu or
+	// statsMu.
+	budgetMu          sync.Mutex
+	budget            missionBudget
+	budgetPromptCount int
+	budgetCostUSD     float64
+
+	// statuslineSegments mirrors statusline.segments (nil for the default).
+	// Read from config.yml at startup. lastStatuslineMessage is the statusline last written, if
+	// statuslineWritten; statuslineMu protects both and serializes renders.
+	statuslineSegments    []string
+	statuslineMu          sync.Mutex
+	lastStatuslineMessage string
+	statuslineWritten     bool
+
+	// budgetExhausted receives the reason the first time a limit is reached;
+	// budgetTripped ensures that happens at most once. budgetStopReason and
+	// budgetKillTimer are only touched from the main event loop.
+	budgetExhausted  chan string
+	budgetTripped    atomic.Bool
+	budgetStopReason string
+	budgetKillTimer  *time.Timer
 }
 
 // NewWrapper creates a new Wrapper for the given mission. The initialPrompt
@@ -113,10 +205,22 @@ func NewWrapper(agencDirpath string, missionID string, gitRepoName string, initi
 	var titleCfg *config.TmuxWindowTitleConfig
 	var defaultModel string
 	var claudeArgs []string
+	var desktopNotifications bool
+	var quietHours *config.QuietHoursConfig
+	var lifecycleHooks config.LifecycleHooksConfig
+	var windowTitleTemplate, windowTitleRepo, windowTitleEmoji string
+	var statuslineSegments []string
 	if err == nil {
 		titleCfg = cfg.GetTmuxWindowTitleConfig()
+		desktopNotifications = cfg.IsDesktopNotificationsEnabled()
+		quietHours = cfg.QuietHours
+		lifecycleHooks = *cfg.GetLifecycleHooks()
 		defaultModel = cfg.GetDefaultModel(gitRepoName)
 		claudeArgs = cfg.GetClaudeArgs(gitRepoName)
+		windowTitleTemplate = cfg.GetWindowTitleTemplate(gitRepoName)
+		windowTitleRepo = windowTitleRepoName(cfg, gitRepoName)
+		windowTitleEmoji = cfg.GetRepoEmoji(gitRepoName)
+		statuslineSegments = cfg.GetStatuslineSegments()
 	} else {
 		titleCfg = &config.TmuxWindowTitleConfig{}
 	}
@@ -130,33 +234,43 @@ func NewWrapper(agencDirpath string, missionID string, gitRepoName string, initi
 		claudeArgs:                     claudeArgs,
 		missionDirpath:                 config.GetMissionDirpath(agencDirpath, missionID),
 		agentDirpath:                   config.GetMissionAgentDirpath(agencDirpath, missionID),
+		scratchDirpath:                 config.GetMissionScratchDirpath(agencDirpath, missionID),
+		workDirpath:                    config.GetMissionWorkDirpath(agencDirpath, missionID),
 		client:                         server.NewClient(config.GetServerSocketFilepath(agencDirpath)),
 		claudeExited:                   make(chan error, 1),
 		commandCh:                      make(chan commandWithResponse, 1),
+		budgetExhausted:                make(chan string, 1),
 		claudeIdle:                     true,
 		windowBusyBackgroundColor:      titleCfg.GetBusyBackgroundColor(),
 		windowBusyForegroundColor:      titleCfg.GetBusyForegroundColor(),
 		windowAttentionBackgroundColor: titleCfg.GetAttentionBackgroundColor(),
 		windowAttentionForegroundColor: titleCfg.GetAttentionForegroundColor(),
+		windowTitleTemplate:            windowTitleTemplate,
+		windowTitleRepo:                windowTitleRepo,
+		windowTitleEmoji:               windowTitleEmoji,
+		statuslineSegments:             statuslineSegments,
+		desktopNotifications:           desktopNotifications,
+		quietHours:                     quietHours,
+		lifecycleHooks:                 lifecycleHooks,
 	}
 }
 
-// cloneCredentials copies fresh credentials from the global Keychain into the
+// cloneCredentials copies fresh credentials from the global credential entry into the
 // per-mission entry so Claude has access to current MCP OAuth tokens at spawn.
 func (w *Wrapper) cloneCredentials() {
 	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID)
-	if err := claudeconfig.CloneKeychainCredentials(claudeConfigDirpath); err != nil {
-		w.logger.Warn("Failed to clone Keychain credentials", "error", err)
+	if err := claudeconfig.CloneCredentials(claudeConfigDirpath); err != nil {
+		w.logger.Warn("Failed to clone credentials", "error", err)
 	}
 }
 
-// writeBackCredentials merges per-mission Keychain credentials back into the
+// writeBackCredentials merges per-mission credentials back into the
 // global entry so MCP OAuth tokens acquired in this mission persist.
 func (w *Wrapper) writeBackCredentials() {
 	claudeConfigDirpath := claudeconfig.GetMissionClaudeConfigDirpath(w.agencDirpath, w.missionID)
-	if err := claudeconfig.WriteBackKeychainCredentials(claudeConfigDirpath); err != nil {
+	if err := claudeconfig.WriteBackCredentials(claudeConfigDirpath); err != nil {
 		if w.logger != nil {
-			w.logger.Warn("Failed to write back Keychain credentials", "error", err)
+			w.logger.Warn("Failed to write back credentials", "error", err)
 		}
 	}
 }
@@ -175,10 +289,18 @@ type runResources struct {
 // needed by the event loop and registers deferred cleanup on the caller's
 // behalf via the returned cleanup function.
 func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
+	backend, err := w.resolveBackend()
+	if err != nil {
+		return nil, nil, err
+	}
+	w.backend = backend
+
 	// Ensure OAuth token exists before installing signal handlers. This must
 	// happen first so Ctrl-C works naturally during the interactive setup flow.
-	if err := config.SetupOAuthToken(w.agencDirpath); err != nil {
-		return nil, nil, err
+	if w.isClaudeBackend() {
+		if err := config.SetupOAuthToken(w.agencDirpath); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Set up logger that writes to the log file
@@ -191,6 +313,7 @@ func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
 	w.logger.Info("Wrapper started",
 		"mission_id", database.ShortID(w.missionID),
 		"repo", w.gitRepoName,
+		"backend", w.backend.Name(),
 		"is_resume", isResume,
 	)
 
@@ -201,6 +324,13 @@ func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
 		return nil, nil, stacktrace.Propagate(err, "failed to write wrapper PID file")
 	}
 
+	// Recreate scratch/ for missions created before it existed or unarchived
+	// since archiving cleared it
+	if err := os.MkdirAll(w.scratchDirpath, 0755); err != nil {
+		logFile.Close()
+		return nil, nil, stacktrace.Propagate(err, "failed to create mission scratch directory")
+	}
+
 	// Set up context for background goroutines
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -231,11 +361,21 @@ func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
 	socketFilepath := config.GetMissionSocketFilepath(w.agencDirpath, w.missionID)
 	go startHTTPServer(ctx, socketFilepath, w, w.logger)
 
-	// Clone global MCP credentials into per-mission Keychain and start sync goroutines.
-	w.cloneCredentials()
-	w.initCredentialHash()
-	go w.watchCredentialUpwardSync(ctx)
-	go w.watchCredentialDownwardSync(ctx)
+	// Clone global MCP credentials into per-mission credential entry and start sync goroutines.
+	if w.isClaudeBackend() {
+		w.cloneCredentials()
+		w.initCredentialHash()
+		go w.watchCredentialUpwardSync(ctx)
+		go w.watchCredentialDownwardSync(ctx)
+	}
+
+	if err := w.checkBackendIsolation(); err != nil {
+		cancel()
+		signal.Stop(sigCh)
+		logFile.Close()
+		_ = os.Remove(pidFilepath)
+		return nil, nil, err
+	}
 
 	// Detect and setup devcontainer (after socket server is started so wrapper.sock exists)
 	dcState, dcErr := w.detectAndSetupDevcontainer()
@@ -258,25 +398,42 @@ func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
 			return nil, nil, stacktrace.Propagate(upErr, "devcontainer up failed")
 		}
 		w.logger.Info("Devcontainer started successfully")
+	} else if w.isClaudeBackend() {
+		sandbox, sandboxErr := w.setupSandbox()
+		if sandboxErr != nil {
+			cancel()
+			signal.Stop(sigCh)
+			logFile.Close()
+			_ = os.Remove(pidFilepath)
+			return nil, nil, stacktrace.Propagate(sandboxErr, "sandbox setup failed")
+		}
+		w.sandbox = sandbox
+		if sandbox != nil {
+			w.logger.Info("Running Claude in a sandbox container", "runtime", sandbox.runtime, "image", sandbox.image)
+		}
 	}
 
 	// Track whether a resumable conversation exists. For resumes, one already
 	// exists. For new missions, we start with false and flip to true when the
-	// first UserPromptSubmit hook fires.
-	if isResume {
+	// first UserPromptSubmit hook fires; backends without hooks never report
+	// one, so assume it exists.
+	if isResume || w.backend.IdleSignal() == IdleSignalNone {
 		w.hasConversation = true
 	}
 
-	// Change the wrapper's working directory to the agent directory so that
-	// tmux's #{pane_current_path} reflects the mission directory. This makes
-	// built-in tmux splits (prefix + %, prefix + ") open in the agent dir.
+	// Change the wrapper's working directory to the directory Claude runs in
+	// so that tmux's #{pane_current_path} reflects the mission directory. This
+	// makes built-in tmux splits (prefix + %, prefix + ") open there too.
 	// NOTE: This only works when the wrapper IS the process group leader —
 	// i.e., the shell that tmux spawned has exec'd into us (requires a simple
 	// command, not a compound one with ; or &&).
-	if err := os.Chdir(w.agentDirpath); err != nil {
-		w.logger.Warn("Failed to chdir to agent directory", "path", w.agentDirpath, "error", err)
+	if err := os.Chdir(w.workDirpath); err != nil {
+		w.logger.Warn("Failed to chdir to mission working directory", "path", w.workDirpath, "error", err)
 	}
 
+	// Load limits before the first spawn so its stats report can enforce them
+	w.loadBudget()
+
 	// Spawn initial Claude process
 	if err := w.spawnClaude(isResume); err != nil {
 		cancel()
@@ -290,6 +447,8 @@ func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
 	go func() {
 		w.claudeExited <- w.claudeCmd.Wait()
 	}()
+	w.fireLifecycleHook(lifecycleEventMissionStart)
+	go w.refreshWindowTitle()
 
 	res := &runResources{
 		logFile: logFile,
@@ -299,13 +458,19 @@ func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
 
 	cleanup := func() {
 		w.resetWindowTabStyle()
-		w.writeBackCredentials()
+		if w.isClaudeBackend() {
+			w.writeBackCredentials()
+		}
 		if w.devcontainer != nil {
 			w.logger.Info("Stopping devcontainer")
 			if stopErr := devcontainerStop(w.devcontainer); stopErr != nil {
 				w.logger.Error("Failed to stop devcontainer", "error", stopErr)
 			}
 		}
+		if w.sandbox != nil {
+			sandboxRemove(w.sandbox)
+		}
+		w.stopNetworkProxy()
 		signal.Stop(sigCh)
 		cancel()
 		_ = os.Remove(pidFilepath)
@@ -325,16 +490,45 @@ func (w *Wrapper) setupRun(isResume bool) (*runResources, func(), error) {
 // state. The server's config_watcher keeps the shadow current via notify;
 // the wrapper just reads from it.
 func (w *Wrapper) spawnClaude(isResume bool) error {
-	isContainerized := w.devcontainer != nil
+	isContainerized := w.devcontainer != nil || w.sandbox != nil
 
-	if err := w.rebuildClaudeConfig(isContainerized); err != nil {
-		return stacktrace.Propagate(err, "failed to rebuild claude-config before spawn")
+	if w.isClaudeBackend() {
+		if err := w.rebuildClaudeConfig(isContainerized); err != nil {
+			return stacktrace.Propagate(err, "failed to rebuild claude-config before spawn")
+		}
+	}
+	if err := w.applyMissionEnv(); err != nil {
+		return err
+	}
+	if err := w.applyCronEnv(); err != nil {
+		return err
+	}
+	if err := w.applyNetworkPolicy(); err != nil {
+		return err
+	}
+	if err := w.applyReviewMode(); err != nil {
+		return err
+	}
+	if w.isClaudeBackend() {
+		w.refreshHandoffContext()
 	}
 
-	if isContainerized {
-		return w.spawnClaudeInContainer(isResume)
+	var err error
+	switch {
+	case w.devcontainer != nil:
+		err = w.spawnClaudeInContainer(isResume)
+	case w.sandbox != nil:
+		err = w.spawnClaudeInSandbox(isResume)
+	default:
+		err = w.spawnClaudeDirectly(isResume)
 	}
-	return w.spawnClaudeDirectly(isResume)
+	if err != nil {
+		return err
+	}
+
+	w.reportStats(true)
+	go w.refreshStatusline()
+	return nil
 }
 
 // rebuildClaudeConfig regenerates the per-mission claude-config/ directory
@@ -349,9 +543,10 @@ func (w *Wrapper) rebuildClaudeConfig(isContainerized bool) error {
 			claudeconfig.GetShadowRepoDirpath(w.agencDirpath))
 	}
 
-	trustedMcpServers := w.loadTrustedMcpServers()
+	rc := w.loadRepoConfig()
 	if err := claudeconfig.BuildMissionConfigDir(
-		w.agencDirpath, w.missionID, trustedMcpServers, isContainerized,
+		w.agencDirpath, w.missionID, rc.TrustedMcpServers, rc.McpServers, rc.ClaudeMdAppend, isContainerized,
+		w.reviewDenyEntries(rc),
 	); err != nil {
 		return stacktrace.Propagate(err, "failed to build per-mission claude-config")
 	}
@@ -372,24 +567,18 @@ func (w *Wrapper) rebuildClaudeConfig(isContainerized bool) error {
 	return nil
 }
 
-// spawnClaudeDirectly spawns Claude as a local process (non-containerized path).
+// spawnClaudeDirectly spawns the mission's agent as a local process through
+// its backend (non-containerized path).
 func (w *Wrapper) spawnClaudeDirectly(isResume bool) error {
 	var cmd *exec.Cmd
 	var err error
-
 	if isResume {
-		sessionID := claudeconfig.GetLastSessionID(w.agencDirpath, w.missionID)
-		if sessionID != "" && claudeconfig.ProjectDirectoryExists(w.agentDirpath) {
-			cmd, err = mission.SpawnClaudeResumeWithSession(w.agencDirpath, w.missionID, w.agentDirpath, w.defaultModel, w.claudeArgs, sessionID, w.initialPrompt)
-		} else {
-			cmd, err = mission.SpawnClaudeWithPrompt(w.agencDirpath, w.missionID, w.agentDirpath, w.defaultModel, w.claudeArgs, w.initialPrompt)
-		}
+		cmd, err = w.backend.Resume(w.initialPrompt)
 	} else {
-		cmd, err = mission.SpawnClaudeWithPrompt(w.agencDirpath, w.missionID, w.agentDirpath, w.defaultModel, w.claudeArgs, w.initialPrompt)
+		cmd, err = w.backend.SpawnInteractive(w.initialPrompt)
 	}
-
 	if err != nil {
-		return stacktrace.Propagate(err, "failed to spawn claude process")
+		return stacktrace.Propagate(err, "failed to spawn %s process", w.backend.Name())
 	}
 	w.claudeCmd = cmd
 	return nil
@@ -399,16 +588,44 @@ func (w *Wrapper) spawnClaudeDirectly(isResume bool) error {
 // `devcontainer exec`. Env vars and config are set via bind mounts and
 // containerEnv in the devcontainer.json, not on the local exec.Cmd.
 func (w *Wrapper) spawnClaudeInContainer(isResume bool) error {
-	// Build the claude args the same way the direct spawn does
+	cmd := devcontainerExecClaude(w.devcontainer, w.containerSpawnEnv(), w.containerClaudeArgs(isResume))
+	if err := cmd.Start(); err != nil {
+		return stacktrace.Propagate(err, "failed to start claude in devcontainer")
+	}
+
+	w.claudeCmd = cmd
+	return nil
+}
+
+// spawnClaudeInSandbox spawns Claude in the mission's sandbox container.
+func (w *Wrapper) spawnClaudeInSandbox(isResume bool) error {
+	cmd, err := w.sandboxClaudeCmd(w.containerClaudeArgs(isResume), true)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return stacktrace.Propagate(err, "failed to start claude in sandbox container")
+	}
+
+	w.claudeCmd = cmd
+	return nil
+}
+
+// containerClaudeArgs builds the claude args for a containerized spawn the
+// same way the direct spawn does.
+func (w *Wrapper) containerClaudeArgs(isResume bool) []string {
 	var claudeArgs []string
 	if w.defaultModel != "" {
 		claudeArgs = append(claudeArgs, "--model", w.defaultModel)
 	}
-	claudeArgs = append(claudeArgs, w.claudeArgs...)
+	claudeArgs = append(claudeArgs, w.interactiveClaudeArgs()...)
 
 	if isResume {
 		sessionID := claudeconfig.GetLastSessionID(w.agencDirpath, w.missionID)
-		if sessionID != "" && claudeconfig.ProjectDirectoryExists(w.agentDirpath) {
+		if sessionID != "" && claudeconfig.ProjectDirectoryExists(w.workDirpath) {
 			claudeArgs = append(claudeArgs, "-r", sessionID)
 		}
 		// If no session to resume, start fresh (no extra args)
@@ -416,30 +633,23 @@ func (w *Wrapper) spawnClaudeInContainer(isResume bool) error {
 	if w.initialPrompt != "" {
 		claudeArgs = append(claudeArgs, w.initialPrompt)
 	}
-
-	cmd := devcontainerExecClaude(w.devcontainer, claudeArgs)
-	if err := cmd.Start(); err != nil {
-		return stacktrace.Propagate(err, "failed to start claude in devcontainer")
-	}
-
-	w.claudeCmd = cmd
-	return nil
+	return claudeArgs
 }
 
-// loadTrustedMcpServers reads the MCP trust configuration for this mission's repo.
-func (w *Wrapper) loadTrustedMcpServers() *config.TrustedMcpServers {
+// loadRepoConfig reads the repoConfig entry for this mission's repo, which
+// supplies its MCP trust and MCP server definitions. Returns the zero value
+// when the mission has no repo, the repo has no entry, or config.yml can't be
+// read.
+func (w *Wrapper) loadRepoConfig() config.RepoConfig {
 	if w.gitRepoName == "" {
-		return nil
+		return config.RepoConfig{}
 	}
 	cfg, _, err := config.ReadAgencConfig(w.agencDirpath)
 	if err != nil {
-		return nil
+		return config.RepoConfig{}
 	}
-	rc, ok := cfg.GetRepoConfig(w.gitRepoName)
-	if !ok {
-		return nil
-	}
-	return rc.TrustedMcpServers
+	rc, _ := cfg.GetRepoConfig(w.gitRepoName)
+	return rc
 }
 
 // Run executes the wrapper lifecycle. For a new mission, pass isResume=false.
@@ -464,6 +674,9 @@ func (w *Wrapper) Run(isResume bool) error {
 			resp := w.handleCommand(cmdResp.cmd)
 			cmdResp.responseCh <- resp
 
+		case reason := <-w.budgetExhausted:
+			w.stopClaudeForBudget(reason)
+
 		case exitErr := <-w.claudeExited:
 			done, err := w.handleClaudeExit(exitErr)
 			if done {
@@ -486,7 +699,9 @@ func (w *Wrapper) handleSignal(sig os.Signal) {
 	if w.claudeCmd != nil && w.claudeCmd.Process != nil {
 		_ = w.claudeCmd.Process.Signal(sig)
 	}
-	<-w.claudeExited
+	exitErr := <-w.claudeExited
+	w.flushBusyPeriod()
+	w.runLifecycleHook(lifecycleEventMissionEnd, "AGENC_EXIT_CODE="+strconv.Itoa(claudeExitCode(exitErr)))
 }
 
 // handleClaudeExit processes Claude's exit. The wrapper exits when claude
@@ -494,30 +709,59 @@ func (w *Wrapper) handleSignal(sig os.Signal) {
 // via tmux respawn-pane (see internal/server reloadMissionInTmux).
 func (w *Wrapper) handleClaudeExit(exitErr error) (done bool, err error) {
 	// Natural exit — wrapper exits
-	exitCode := 0
-	if exitErr != nil {
-		if ee, ok := exitErr.(*exec.ExitError); ok {
-			exitCode = ee.ExitCode()
-		} else {
-			exitCode = -1
-		}
-	}
+	exitCode := claudeExitCode(exitErr)
 	w.logger.Info("Wrapper exiting",
 		"reason", "claude_exited",
 		"exit_code", exitCode,
 		"exit_error", fmt.Sprintf("%v", exitErr),
 	)
 
-	// If Claude exited with an error, pause so the user can see
-	// any error messages Claude printed to the terminal before
-	// the tmux window closes.
-	if exitCode != 0 {
-		fmt.Fprintf(os.Stderr, "\nClaude exited with code %d. Press Enter to close this window.\n", exitCode)
+	// A non-zero exit the wrapper didn't cause is a crash: capture the pane
+	// before anything else prints to it.
+	lastError := ""
+	if exitCode != 0 && w.budgetStopReason == "" {
+		lastError = w.writeCrashReport(exitCode, exitErr)
+	}
+
+	// Let the server record the exit against any still-running cron run and
+	// the mission's last_error. Best-effort: the server reconciles runs whose
+	// wrapper never reported.
+	if err := w.client.ReportMissionExit(w.missionID, exitCode, lastError); err != nil {
+		w.logger.Warn("Failed to report Claude exit to server", "error", err)
+	}
+	w.flushBusyPeriod()
+	w.runLifecycleHook(lifecycleEventMissionEnd, "AGENC_EXIT_CODE="+strconv.Itoa(exitCode))
+
+	if w.budgetKillTimer != nil {
+		w.budgetKillTimer.Stop()
+	}
+
+	// If the wrapper stopped Claude for exceeding the mission's budget, say
+	// so; otherwise, if Claude exited with an error, pause so the user can
+	// see any error messages Claude printed to the terminal before the tmux
+	// window closes.
+	if w.budgetStopReason != "" {
+		fmt.Fprintf(os.Stderr, "\nMission budget exhausted: %s. Claude was stopped. Press Enter to close this window.\n", w.budgetStopReason)
+		_, _ = bufio.NewReader(os.Stdin).ReadBytes('\n') // intentionally ignored: press-enter-to-continue prompt
+	} else if exitCode != 0 {
+		fmt.Fprintf(os.Stderr, "\nClaude exited with code %d. Crash report: %s\nPress Enter to close this window.\n", exitCode, config.GetMissionCrashReportFilepath(w.agencDirpath, w.missionID))
 		_, _ = bufio.NewReader(os.Stdin).ReadBytes('\n') // intentionally ignored: press-enter-to-continue prompt
 	}
 	return true, nil
 }
 
+// claudeExitCode returns the exit code carried by the error from Claude's
+// cmd.Wait: 0 for a clean exit, -1 when Claude didn't exit normally.
+func claudeExitCode(exitErr error) int {
+	if exitErr == nil {
+		return 0
+	}
+	if ee, ok := exitErr.(*exec.ExitError); ok {
+		return ee.ExitCode()
+	}
+	return -1
+}
+
 // handleCommand processes a command from the HTTP server and returns a CommandResponse.
 func (w *Wrapper) handleCommand(cmd Command) CommandResponse {
 	switch cmd.Command {
@@ -525,6 +769,8 @@ func (w *Wrapper) handleCommand(cmd Command) CommandResponse {
 		return w.handleClaudeUpdate(cmd)
 	case "rebuild":
 		return w.handleRebuildCommand()
+	case "ping":
+		return CommandResponse{Status: "ok"}
 	default:
 		return CommandResponse{Status: "error", Error: "unknown command: " + cmd.Command}
 	}
@@ -586,10 +832,21 @@ func (w *Wrapper) handleClaudeUpdate(cmd Command) CommandResponse {
 
 	switch cmd.Event {
 	case "Stop":
+		if startedAt, ok := w.endBusyPeriod(); ok {
+			go w.recordBusyPeriod(startedAt, time.Now())
+		}
 		w.claudeIdle = true
 		w.hasConversation = true
 		w.needsAttention = false
 		w.resetWindowTabStyle()
+		go w.refreshWindowTitle()
+		go w.refreshStatusline()
+		w.notifyDesktopIfUnfocused("Finished and waiting for your input")
+		w.fireLifecycleHook(lifecycleEventClaudeIdle)
+		// Re-tally spend and check the prompt limit now that the turn is over.
+		if w.hasBudget() {
+			go w.reportStats(false)
+		}
 		// Notify the server so any async-queued reload can fire now.
 		// Best-effort: errors are logged, not propagated — a missed
 		// notification means the pending reload waits for the next Stop.
@@ -600,20 +857,31 @@ func (w *Wrapper) handleClaudeUpdate(cmd Command) CommandResponse {
 		}()
 
 	case "UserPromptSubmit":
+		if w.claudeIdle {
+			w.fireLifecycleHook(lifecycleEventClaudeBusy)
+			w.busySince = time.Now()
+		}
 		w.claudeIdle = false
 		w.hasConversation = true
 		w.needsAttention = false
 		w.lastUserPromptAt = time.Now().UTC()
 		w.setWindowBusy()
-		if err := w.client.RecordPrompt(w.missionID); err != nil {
+		go w.refreshWindowTitle()
+		if err := w.client.RecordPrompt(w.missionID, cmd.Prompt); err != nil {
 			w.logger.Warn("Failed to record prompt", "error", err)
 		}
+		w.countBudgetPrompt()
+		w.enforceBudget(-1, false)
 
 	case "PostToolUse", "PostToolUseFailure":
 		// A tool just completed (or failed) — Claude is still actively working,
 		// so reset the window to busy in case a permission prompt turned it orange.
+		if w.needsAttention {
+			go w.refreshWindowTitle()
+		}
 		w.needsAttention = false
 		w.setWindowBusy()
+		go w.recordToolRun(cmd.ToolName, cmd.Event == "PostToolUseFailure")
 
 	case "Notification":
 		// Color the pane for notification types that need user attention
@@ -621,12 +889,30 @@ func (w *Wrapper) handleClaudeUpdate(cmd Command) CommandResponse {
 		case "permission_prompt", "elicitation_dialog":
 			w.needsAttention = true
 			w.setWindowNeedsAttention()
+			go w.refreshWindowTitle()
+			w.notifyDesktopIfUnfocused("Needs your attention")
 		}
 	}
 
 	return CommandResponse{Status: "ok"}
 }
 
+// recordToolRun adds a tool run to the mission's timeline. Best-effort: a
+// missed entry only leaves a gap in the timeline. The tool name is empty for
+// containerized missions, whose hooks don't forward the hook payload.
+func (w *Wrapper) recordToolRun(toolName string, failed bool) {
+	data := map[string]string{}
+	if toolName != "" {
+		data["tool"] = toolName
+	}
+	if failed {
+		data["failed"] = "true"
+	}
+	if err := w.client.PublishEvent(server.EventToolRun, w.missionID, data); err != nil {
+		w.logger.Warn("Failed to record tool run", "error", err)
+	}
+}
+
 // getClaudeStateString returns the Claude state as a string for the status API.
 // Must be called with stateMu held (at least RLock).
 func (w *Wrapper) getClaudeStateString() string {
@@ -673,7 +959,16 @@ func (w *Wrapper) watchWorkspaceRemoteRefs(ctx context.Context) {
 		return
 	}
 
-	refsDirpath := filepath.Join(repoDirpath, ".git", "refs", "remotes", "origin")
+	gitDirpath := filepath.Join(repoDirpath, ".git")
+	if mission.IsWorktree(repoDirpath) {
+		// Linked worktrees share refs with the library clone's .git directory
+		gitDirpath, err = mission.GetGitCommonDirpath(repoDirpath)
+		if err != nil {
+			w.logger.Warn("Failed to resolve git directory for mission worktree", "error", err)
+			return
+		}
+	}
+	refsDirpath := filepath.Join(gitDirpath, "refs", "remotes", "origin")
 
 	eventCh := make(chan notify.EventInfo, 256)
 	if err := notify.Watch(refsDirpath, eventCh, notify.Create|notify.Write); err != nil {
@@ -711,6 +1006,9 @@ func (w *Wrapper) watchWorkspaceRemoteRefs(ctx context.Context) {
 		case <-debounceTimer.C:
 			timerActive = false
 			w.logger.Info("Remote ref changed, updating repo library", "repo", w.gitRepoName)
+			if err := w.client.PublishEvent(server.EventRefUpdated, w.missionID, map[string]string{"branch": defaultBranch}); err != nil {
+				w.logger.Warn("Failed to publish ref update event", "error", err)
+			}
 			w.triggerRepoPushEvent()
 		}
 	}
@@ -756,6 +1054,7 @@ func (w *Wrapper) writeHeartbeat(ctx context.Context) {
 			if err := w.client.Heartbeat(w.missionID, w.tmuxPaneID, lastPromptAtStr); err != nil {
 				w.logger.Warn("Failed to write heartbeat", "error", err)
 			}
+			w.reportStats(false)
 		}
 	}
 }
@@ -776,10 +1075,18 @@ const (
 // If a previous conversation exists (isResume=true), it uses claude -c -p <prompt>
 // to continue the conversation.
 func (w *Wrapper) RunHeadless(isResume bool, cfg HeadlessConfig) error {
-	// Ensure OAuth token exists before installing signal handlers.
-	if err := config.SetupOAuthToken(w.agencDirpath); err != nil {
+	backend, err := w.resolveBackend()
+	if err != nil {
 		return err
 	}
+	w.backend = backend
+
+	// Ensure OAuth token exists before installing signal handlers.
+	if w.isClaudeBackend() {
+		if err := config.SetupOAuthToken(w.agencDirpath); err != nil {
+			return err
+		}
+	}
 
 	// Set up logger
 	logFilepath := config.GetMissionWrapperLogFilepath(w.agencDirpath, w.missionID)
@@ -793,6 +1100,7 @@ func (w *Wrapper) RunHeadless(isResume bool, cfg HeadlessConfig) error {
 	w.logger.Info("Wrapper started",
 		"mission_id", database.ShortID(w.missionID),
 		"repo", w.gitRepoName,
+		"backend", w.backend.Name(),
 		"is_resume", isResume,
 		"headless", true,
 		"timeout", cfg.Timeout.String(),
@@ -832,12 +1140,14 @@ func (w *Wrapper) RunHeadless(isResume bool, cfg HeadlessConfig) error {
 	}
 	go w.writeHeartbeat(ctx)
 
-	// Clone global MCP credentials into per-mission Keychain and start sync goroutines.
-	w.cloneCredentials()
-	defer w.writeBackCredentials()
-	w.initCredentialHash()
-	go w.watchCredentialUpwardSync(ctx)
-	go w.watchCredentialDownwardSync(ctx)
+	// Clone global MCP credentials into per-mission credential entry and start sync goroutines.
+	if w.isClaudeBackend() {
+		w.cloneCredentials()
+		defer w.writeBackCredentials()
+		w.initCredentialHash()
+		go w.watchCredentialUpwardSync(ctx)
+		go w.watchCredentialDownwardSync(ctx)
+	}
 
 	// Rotate log file if needed
 	claudeOutputLogFilepath := config.GetMissionClaudeOutputLogFilepath(w.agencDirpath, w.missionID)
@@ -852,20 +1162,50 @@ func (w *Wrapper) RunHeadless(isResume bool, cfg HeadlessConfig) error {
 	}
 	defer outputFile.Close()
 
-	// Build and run the claude command
-	cmd, err := w.buildHeadlessClaudeCmd(isResume)
-	if err != nil {
-		return stacktrace.Propagate(err, "failed to build headless claude command")
+	w.loadBudget()
+	if err := w.applyMissionEnv(); err != nil {
+		return err
+	}
+	if err := w.applyCronEnv(); err != nil {
+		return err
 	}
 
-	cmd.Stdout = outputFile
-	cmd.Stderr = outputFile
+	if err := w.checkBackendIsolation(); err != nil {
+		return err
+	}
+	if w.isClaudeBackend() {
+		w.sandbox, err = w.setupSandbox()
+		if err != nil {
+			return stacktrace.Propagate(err, "sandbox setup failed")
+		}
+	}
+	if w.sandbox != nil {
+		w.logger.Info("Running Claude in a sandbox container", "runtime", w.sandbox.runtime, "image", w.sandbox.image)
+		if err := w.rebuildClaudeConfig(true); err != nil {
+			return stacktrace.Propagate(err, "failed to rebuild claude-config for sandbox")
+		}
+		defer sandboxRemove(w.sandbox)
+	}
+	if err := w.applyNetworkPolicy(); err != nil {
+		return err
+	}
+	defer w.stopNetworkProxy()
+	if err := w.applyReviewMode(); err != nil {
+		return err
+	}
 
-	if err := cmd.Start(); err != nil {
-		return stacktrace.Propagate(err, "failed to start headless claude")
+	cmd, err := w.backend.SpawnHeadless(w.initialPrompt, isResume, outputFile)
+	if err != nil {
+		return stacktrace.Propagate(err, "failed to start headless %s", w.backend.Name())
 	}
 
-	w.logger.Info("Claude process started", "pid", cmd.Process.Pid)
+	w.logger.Info("Agent process started", "backend", w.backend.Name(), "pid", cmd.Process.Pid)
+	w.reportStats(true)
+	w.fireLifecycleHook(lifecycleEventMissionStart)
+
+	// A headless agent works on its prompt from start to exit
+	startedAt := time.Now()
+	defer func() { w.recordBusyPeriod(startedAt, time.Now()) }()
 
 	// Wait for completion
 	claudeExited := make(chan error, 1)
@@ -879,6 +1219,7 @@ func (w *Wrapper) RunHeadless(isResume bool, cfg HeadlessConfig) error {
 		if err := w.gracefulShutdownClaude(cmd); err != nil {
 			w.logger.Warn("Graceful shutdown failed", "error", err)
 		}
+		w.runLifecycleHook(lifecycleEventMissionEnd, "AGENC_EXIT_CODE=-1")
 		return nil
 
 	case <-ctx.Done():
@@ -887,9 +1228,17 @@ func (w *Wrapper) RunHeadless(isResume bool, cfg HeadlessConfig) error {
 		if err := w.gracefulShutdownClaude(cmd); err != nil {
 			w.logger.Warn("Graceful shutdown failed", "error", err)
 		}
+		w.runLifecycleHook(lifecycleEventMissionEnd, "AGENC_EXIT_CODE=-1")
 		return stacktrace.NewError("headless mission timed out after %v", cfg.Timeout)
 
+	case reason := <-w.budgetExhausted:
+		w.logger.Info("Mission budget exhausted, shutting down", "reason", reason)
+		exitErr := w.terminateClaude(cmd, claudeExited)
+		w.runLifecycleHook(lifecycleEventMissionEnd, "AGENC_EXIT_CODE="+strconv.Itoa(claudeExitCode(exitErr)))
+		return stacktrace.NewError("headless mission stopped: %s", reason)
+
 	case err := <-claudeExited:
+		w.runLifecycleHook(lifecycleEventMissionEnd, "AGENC_EXIT_CODE="+strconv.Itoa(claudeExitCode(err)))
 		if err != nil {
 			w.logger.Info("Claude process exited with error", "error", err)
 			return stacktrace.Propagate(err, "claude exited with error")
@@ -902,17 +1251,26 @@ func (w *Wrapper) RunHeadless(isResume bool, cfg HeadlessConfig) error {
 // buildHeadlessClaudeCmd constructs the command for headless execution.
 // Uses claude --print -p <prompt> for new missions, or claude -c -p <prompt>
 // for resuming existing conversations.
-func (w *Wrapper) buildHeadlessClaudeCmd(isResume bool) (*exec.Cmd, error) {
+func (w *Wrapper) buildHeadlessClaudeCmd(prompt string, isResume bool) (*exec.Cmd, error) {
 	var args []string
 	if isResume {
 		// Resume with continuation flag and print mode
-		args = []string{"-c", "--print", "-p", w.initialPrompt}
+		args = []string{"-c", "--print", "-p", prompt}
 	} else {
 		// New conversation with print mode
-		args = []string{"--print", "-p", w.initialPrompt}
+		args = []string{"--print", "-p", prompt}
+	}
+
+	if w.sandbox != nil {
+		var claudeArgs []string
+		if w.defaultModel != "" {
+			claudeArgs = append(claudeArgs, "--model", w.defaultModel)
+		}
+		claudeArgs = append(claudeArgs, w.claudeArgs...)
+		return w.sandboxClaudeCmd(append(claudeArgs, args...), false)
 	}
 
-	return mission.BuildClaudeCmd(w.agencDirpath, w.missionID, w.agentDirpath, w.defaultModel, w.claudeArgs, args)
+	return mission.BuildClaudeCmd(w.agencDirpath, w.missionID, w.workDirpath, w.defaultModel, w.claudeArgs, args)
 }
 
 // gracefulShutdownClaude attempts to gracefully shut down a Claude process.
@@ -946,6 +1304,21 @@ func (w *Wrapper) gracefulShutdownClaude(cmd *exec.Cmd) error {
 	}
 }
 
+// terminateClaude stops a Claude process whose Wait result arrives on exited:
+// SIGTERM first, then SIGKILL if it hasn't exited after the shutdown period.
+// Returns the exit error.
+func (w *Wrapper) terminateClaude(cmd *exec.Cmd, exited <-chan error) error {
+	_ = cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(headlessShutdownPeriod):
+		w.logger.Warn("Graceful shutdown timed out, sending SIGKILL")
+		_ = cmd.Process.Kill()
+		return <-exited
+	}
+}
+
 // rotateLogFileIfNeeded rotates the log file if it exceeds the max size.
 func rotateLogFileIfNeeded(logFilepath string) error {
 	info, err := os.Stat(logFilepath)
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -249,7 +250,7 @@ func TestSocketProtocol(t *testing.T) {
 		{
 			name: "claude_update Stop event",
 			action: func() error {
-				return client.SendClaudeUpdate("Stop", "")
+				return client.SendClaudeUpdate(ClaudeUpdateRequest{Event: "Stop"})
 			},
 			checkState: func(t *testing.T, w *Wrapper) {
 				if !w.claudeIdle {
@@ -263,7 +264,7 @@ func TestSocketProtocol(t *testing.T) {
 		{
 			name: "claude_update UserPromptSubmit event",
 			action: func() error {
-				return client.SendClaudeUpdate("UserPromptSubmit", "")
+				return client.SendClaudeUpdate(ClaudeUpdateRequest{Event: "UserPromptSubmit"})
 			},
 			checkState: func(t *testing.T, w *Wrapper) {
 				if w.claudeIdle {
@@ -277,19 +278,19 @@ func TestSocketProtocol(t *testing.T) {
 		{
 			name: "claude_update Notification event",
 			action: func() error {
-				return client.SendClaudeUpdate("Notification", "permission_prompt")
+				return client.SendClaudeUpdate(ClaudeUpdateRequest{Event: "Notification", NotificationType: "permission_prompt"})
 			},
 		},
 		{
 			name: "claude_update PostToolUse event",
 			action: func() error {
-				return client.SendClaudeUpdate("PostToolUse", "")
+				return client.SendClaudeUpdate(ClaudeUpdateRequest{Event: "PostToolUse"})
 			},
 		},
 		{
 			name: "claude_update PostToolUseFailure event",
 			action: func() error {
-				return client.SendClaudeUpdate("PostToolUseFailure", "")
+				return client.SendClaudeUpdate(ClaudeUpdateRequest{Event: "PostToolUseFailure"})
 			},
 		},
 	}
@@ -408,7 +409,7 @@ func TestClaudeUpdateEventsStateTracking(t *testing.T) {
 	client := NewWrapperClient(setup.socketFilepath, 1*time.Second)
 
 	// Test Stop event: sets idle=true, hasConversation=true
-	if err := client.SendClaudeUpdate("Stop", ""); err != nil {
+	if err := client.SendClaudeUpdate(ClaudeUpdateRequest{Event: "Stop"}); err != nil {
 		t.Fatalf("Stop event failed: %v", err)
 	}
 	if !w.claudeIdle {
@@ -419,7 +420,7 @@ func TestClaudeUpdateEventsStateTracking(t *testing.T) {
 	}
 
 	// Test UserPromptSubmit event: sets idle=false, hasConversation=true
-	if err := client.SendClaudeUpdate("UserPromptSubmit", ""); err != nil {
+	if err := client.SendClaudeUpdate(ClaudeUpdateRequest{Event: "UserPromptSubmit"}); err != nil {
 		t.Fatalf("UserPromptSubmit event failed: %v", err)
 	}
 	if w.claudeIdle {
@@ -724,3 +725,29 @@ func TestSpawnClaude_RebuildsConfigAndLogsCommit(t *testing.T) {
 		t.Errorf("expected log output to contain shadow commit prefix %q, got:\n%s", shortHash, logOut)
 	}
 }
+
+// TestPing tests that GET /ping answers only while the event loop is running.
+func TestPing(t *testing.T) {
+	setup := setupTest(t)
+	defer setup.cleanup()
+
+	w := createTestWrapper(setup.agencDirpath, setup.missionID, "github.com/test/repo")
+	w.commandCh = make(chan commandWithResponse, 1)
+
+	rec := httptest.NewRecorder()
+	handlePing(w)(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without an event loop, got %d", rec.Code)
+	}
+	<-w.commandCh
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startTestEventLoop(ctx, w, 0)
+
+	rec = httptest.NewRecorder()
+	handlePing(w)(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 with the event loop running, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
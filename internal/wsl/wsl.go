@@ -0,0 +1,112 @@
+// Package wsl supports running AgenC inside the Windows Subsystem for Linux
+// alongside the Windows build of Claude Code, which sees paths in Windows form.
+package wsl
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DistroEnvVar names the WSL distribution the process runs in. WSL sets it in
+// every Linux process it starts.
+const DistroEnvVar = "WSL_DISTRO_NAME"
+
+// WSLENVEnvVar lists the variables WSL forwards between Linux and Windows
+// processes, each optionally suffixed with flags such as "/p" (translate the
+// value as a path).
+const WSLENVEnvVar = "WSLENV"
+
+var (
+	driveMountRegex = regexp.MustCompile(`^/mnt/([a-zA-Z])(/.*)?$`)
+	drivePathRegex  = regexp.MustCompile(`^([a-zA-Z]):(?:[\\/](.*))?$`)
+)
+
+// ToWindowsPath converts an absolute Linux path to the form Windows programs
+// use. Paths under a drive mount (/mnt/c/Users/me) become drive paths
+// (C:\Users\me); everything else goes through the distro's
+// \\wsl.localhost\<distro> share.
+func ToWindowsPath(linuxPath string, distro string) string {
+	linuxPath = path.Clean(linuxPath)
+	if match := driveMountRegex.FindStringSubmatch(linuxPath); match != nil {
+		rest := strings.TrimPrefix(match[2], "/")
+		return strings.ToUpper(match[1]) + `:\` + strings.ReplaceAll(rest, "/", `\`)
+	}
+	return `\\wsl.localhost\` + distro + strings.ReplaceAll(linuxPath, "/", `\`)
+}
+
+// ToLinuxPath converts a Windows drive path (C:\Users\me or C:/Users/me) to
+// its drive mount (/mnt/c/Users/me). Other paths are returned unchanged.
+func ToLinuxPath(windowsPath string) string {
+	match := drivePathRegex.FindStringSubmatch(windowsPath)
+	if match == nil {
+		return windowsPath
+	}
+	linuxPath := "/mnt/" + strings.ToLower(match[1])
+	if rest := strings.Trim(strings.ReplaceAll(match[2], `\`, "/"), "/"); rest != "" {
+		linuxPath += "/" + rest
+	}
+	return linuxPath
+}
+
+// AppendWSLENV returns env with names added to its WSLENV entry, creating the
+// entry if needed, so Windows programs started from WSL receive them.
+func AppendWSLENV(env []string, names ...string) []string {
+	prefix := WSLENVEnvVar + "="
+	result := make([]string, 0, len(env)+1)
+	var existing []string
+	for _, kv := range env {
+		if value, ok := strings.CutPrefix(kv, prefix); ok {
+			if value != "" {
+				existing = strings.Split(value, ":")
+			}
+			continue
+		}
+		result = append(result, kv)
+	}
+	return append(result, prefix+strings.Join(append(existing, names...), ":"))
+}
+
+var (
+	windowsHomeOnce    sync.Once
+	windowsHomeDirpath string
+)
+
+// WindowsHomeDirpath returns the Windows user's profile directory as a Linux
+// path (e.g. /mnt/c/Users/me), or the empty string outside WSL or when it
+// can't be determined. Resolved once per process.
+func WindowsHomeDirpath() string {
+	windowsHomeOnce.Do(func() {
+		if !IsWSL() {
+			return
+		}
+		profile := os.Getenv("USERPROFILE")
+		if profile == "" {
+			profile = queryWindowsUserProfile()
+		}
+		if linuxPath := ToLinuxPath(profile); strings.HasPrefix(linuxPath, "/mnt/") {
+			windowsHomeDirpath = linuxPath
+		}
+	})
+	return windowsHomeDirpath
+}
+
+// queryWindowsUserProfile asks cmd.exe for %USERPROFILE%, which WSL doesn't
+// forward by default. Runs from a drive mount so cmd.exe doesn't warn about
+// a UNC working directory.
+func queryWindowsUserProfile() string {
+	cmdExe, err := exec.LookPath("cmd.exe")
+	if err != nil {
+		return ""
+	}
+	cmd := exec.Command(cmdExe, "/c", "echo %USERPROFILE%")
+	cmd.Dir = "/mnt/c"
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,15 @@
+package wsl
+
+import "os"
+
+// wslInteropFilepath exists on WSL kernels with Windows interop enabled.
+const wslInteropFilepath = "/proc/sys/fs/binfmt_misc/WSLInterop"
+
+// IsWSL reports whether the process runs inside WSL.
+func IsWSL() bool {
+	if os.Getenv(DistroEnvVar) != "" {
+		return true
+	}
+	_, err := os.Stat(wslInteropFilepath)
+	return err == nil
+}
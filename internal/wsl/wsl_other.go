@@ -0,0 +1,8 @@
+//go:build !linux
+
+package wsl
+
+// IsWSL is always false off Linux.
+func IsWSL() bool {
+	return false
+}
@@ -0,0 +1,57 @@
+package wsl
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestToWindowsPath(t *testing.T) {
+	tests := []struct {
+		linuxPath string
+		want      string
+	}{
+		{"/mnt/c/Users/me/.claude", `C:\Users\me\.claude`},
+		{"/mnt/d", `D:\`},
+		{"/mnt/c/Users/me/", `C:\Users\me`},
+		{"/home/me/.agenc/missions/abc", `\\wsl.localhost\Ubuntu\home\me\.agenc\missions\abc`},
+		{"/mnt/wsl/shared", `\\wsl.localhost\Ubuntu\mnt\wsl\shared`},
+	}
+	for _, tt := range tests {
+		if got := ToWindowsPath(tt.linuxPath, "Ubuntu"); got != tt.want {
+			t.Errorf("ToWindowsPath(%q) = %q, want %q", tt.linuxPath, got, tt.want)
+		}
+	}
+}
+
+func TestToLinuxPath(t *testing.T) {
+	tests := []struct {
+		windowsPath string
+		want        string
+	}{
+		{`C:\Users\me`, "/mnt/c/Users/me"},
+		{`C:/Users/me/.claude/`, "/mnt/c/Users/me/.claude"},
+		{`D:\`, "/mnt/d"},
+		{`E:`, "/mnt/e"},
+		{`\\wsl.localhost\Ubuntu\home\me`, `\\wsl.localhost\Ubuntu\home\me`},
+		{"/home/me", "/home/me"},
+	}
+	for _, tt := range tests {
+		if got := ToLinuxPath(tt.windowsPath); got != tt.want {
+			t.Errorf("ToLinuxPath(%q) = %q, want %q", tt.windowsPath, got, tt.want)
+		}
+	}
+}
+
+func TestAppendWSLENV(t *testing.T) {
+	env := AppendWSLENV([]string{"HOME=/home/me", "WSLENV=USERPROFILE/p"}, "CLAUDE_CONFIG_DIR/p", "AGENC_MISSION_UUID")
+	want := []string{"HOME=/home/me", "WSLENV=USERPROFILE/p:CLAUDE_CONFIG_DIR/p:AGENC_MISSION_UUID"}
+	if !slices.Equal(env, want) {
+		t.Errorf("got %v, want %v", env, want)
+	}
+
+	env = AppendWSLENV([]string{"HOME=/home/me"}, "AGENC_MISSION_UUID")
+	want = []string{"HOME=/home/me", "WSLENV=AGENC_MISSION_UUID"}
+	if !slices.Equal(env, want) {
+		t.Errorf("got %v, want %v", env, want)
+	}
+}